@@ -34,7 +34,23 @@ var (
 		Long:  `Start a server that communicates via standard input/output streams using JSON-RPC messages.`,
 		RunE: func(_ *cobra.Command, _ []string) error {
 			token := viper.GetString("personal_access_token")
-			if token == "" {
+			appID := viper.GetInt64("app-id")
+			var appPrivateKey []byte
+			if appID != 0 {
+				// The key can be passed inline, which suits secret stores exposing environment variables
+				if key := viper.GetString("app-private-key"); key != "" {
+					appPrivateKey = []byte(key)
+				} else if path := viper.GetString("app-private-key-file"); path != "" {
+					key, err := os.ReadFile(path)
+					if err != nil {
+						return fmt.Errorf("failed to read GitHub App private key: %w", err)
+					}
+					appPrivateKey = key
+				} else {
+					return errors.New("GITHUB_APP_PRIVATE_KEY or GITHUB_APP_PRIVATE_KEY_FILE must be set with GITHUB_APP_ID")
+				}
+			} else if token == "" && viper.GetString("token-command") == "" && viper.GetString("token-file") == "" &&
+				!viper.GetBool("token-from-initialize") && viper.GetString("token-env-template") == "" {
 				return errors.New("GITHUB_PERSONAL_ACCESS_TOKEN not set")
 			}
 
@@ -69,6 +85,41 @@ var (
 				}
 			}
 
+			var contentWindowOverrides []string
+			if viper.IsSet("content_window_overrides") {
+				if err := viper.UnmarshalKey("content_window_overrides", &contentWindowOverrides); err != nil {
+					return fmt.Errorf("failed to unmarshal content-window-overrides: %w", err)
+				}
+			}
+
+			var eventWebhookEvents []string
+			if viper.IsSet("event_webhook_events") {
+				if err := viper.UnmarshalKey("event_webhook_events", &eventWebhookEvents); err != nil {
+					return fmt.Errorf("failed to unmarshal event-webhook-events: %w", err)
+				}
+			}
+
+			var confirmDestructiveTools []string
+			if viper.IsSet("confirm_destructive_tools") {
+				if err := viper.UnmarshalKey("confirm_destructive_tools", &confirmDestructiveTools); err != nil {
+					return fmt.Errorf("failed to unmarshal confirm-destructive-tools: %w", err)
+				}
+			}
+
+			var profiles []string
+			if viper.IsSet("profiles") {
+				if err := viper.UnmarshalKey("profiles", &profiles); err != nil {
+					return fmt.Errorf("failed to unmarshal profiles: %w", err)
+				}
+			}
+
+			var otlpHeaders []string
+			if viper.IsSet("otlp_headers") {
+				if err := viper.UnmarshalKey("otlp_headers", &otlpHeaders); err != nil {
+					return fmt.Errorf("failed to unmarshal otlp-headers: %w", err)
+				}
+			}
+
 			// Parse enabled features (similar to toolsets)
 			var enabledFeatures []string
 			if viper.IsSet("features") {
@@ -78,23 +129,68 @@ var (
 			}
 
 			ttl := viper.GetDuration("repo-access-cache-ttl")
+			trustOwnContent := viper.GetBool("lockdown-trust-own-content")
 			stdioServerConfig := ghmcp.StdioServerConfig{
-				Version:              version,
-				Host:                 viper.GetString("host"),
-				Token:                token,
-				EnabledToolsets:      enabledToolsets,
-				EnabledTools:         enabledTools,
-				EnabledFeatures:      enabledFeatures,
-				DynamicToolsets:      viper.GetBool("dynamic_toolsets"),
-				ReadOnly:             viper.GetBool("read-only"),
-				ExportTranslations:   viper.GetBool("export-translations"),
-				EnableCommandLogging: viper.GetBool("enable-command-logging"),
-				LogFilePath:          viper.GetString("log-file"),
-				ContentWindowSize:    viper.GetInt("content-window-size"),
-				LockdownMode:         viper.GetBool("lockdown-mode"),
-				InsidersMode:         viper.GetBool("insiders"),
-				ExcludeTools:         excludeTools,
-				RepoAccessCacheTTL:   &ttl,
+				Version:                version,
+				Commit:                 commit,
+				BuildDate:              date,
+				Host:                   viper.GetString("host"),
+				UploadHost:             viper.GetString("upload-host"),
+				Token:                  token,
+				TokenCommand:           viper.GetString("token-command"),
+				TokenCommandTTL:        viper.GetDuration("token-command-ttl"),
+				TokenFile:              viper.GetString("token-file"),
+				TokenFromInitialize:    viper.GetBool("token-from-initialize"),
+				TokenEnvTemplate:       viper.GetString("token-env-template"),
+				AppID:                  appID,
+				AppPrivateKey:          appPrivateKey,
+				AppInstallationID:      viper.GetInt64("app-installation-id"),
+				Profiles:               profiles,
+				EnabledToolsets:        enabledToolsets,
+				EnabledTools:           enabledTools,
+				EnabledFeatures:        enabledFeatures,
+				DynamicToolsets:        viper.GetBool("dynamic_toolsets"),
+				ReadOnly:               viper.GetBool("read-only"),
+				WithoutDestructive:     viper.GetBool("without-destructive"),
+				OnlyIdempotent:         viper.GetBool("only-idempotent"),
+				EstimateTokens:         viper.GetBool("estimate-tokens"),
+				DedupResults:           viper.GetBool("dedup-results"),
+				RootsEnforcement:       viper.GetString("roots-enforcement"),
+				EventWebhookURL:        viper.GetString("event-webhook-url"),
+				EventWebhookSecret:     viper.GetString("event-webhook-secret"),
+				EventWebhookEvents:     eventWebhookEvents,
+				CursorSigningKey:       viper.GetString("cursor-signing-key"),
+				IncludeArchivedRepos:   viper.GetBool("include-archived-repos"),
+				IncludeForks:           viper.GetBool("include-forks"),
+				CommentTemplatesFile:   viper.GetString("comment-templates-file"),
+				ToolNamePrefix:         viper.GetString("tool-name-prefix"),
+				ExportTranslations:     viper.GetBool("export-translations"),
+				EnableCommandLogging:   viper.GetBool("enable-command-logging"),
+				LogFilePath:            viper.GetString("log-file"),
+				ContentWindowSize:      viper.GetInt("content-window-size"),
+				ContentWindowOverrides: contentWindowOverrides,
+				LockdownMode:           viper.GetBool("lockdown-mode"),
+				InsidersMode:           viper.GetBool("insiders"),
+				ExcludeTools:           excludeTools,
+				RepoAccessCacheTTL:     &ttl,
+				SessionCallBudget:      viper.GetInt("session-call-budget"),
+				SessionCostBudget:      viper.GetInt("session-cost-budget"),
+				WriteQuotaPerHour:      viper.GetInt("write-quota-per-hour"),
+				WriteApprovalWebhook:   viper.GetString("write-approval-webhook"),
+				CacheDir:               viper.GetString("cache-dir"),
+				CacheTTL:               viper.GetDuration("cache-ttl"),
+				CacheMaxBytes:          int64(viper.GetInt("cache-max-size-mb")) << 20,
+				DisableCache:           viper.GetBool("no-cache"),
+				OTLPEndpoint:           viper.GetString("otlp-endpoint"),
+				OTLPHeaders:            otlpHeaders,
+				AuditLogFile:           viper.GetString("audit-log-file"),
+				AuditLogURL:            viper.GetString("audit-log-url"),
+				DryRun:                 viper.GetBool("dry-run"),
+				GHESVersion:            viper.GetString("ghes-version"),
+
+				LockdownTrustOwnContent: &trustOwnContent,
+				ConfirmDestructive:      viper.GetBool("confirm-destructive"),
+				ConfirmDestructiveTools: confirmDestructiveTools,
 			}
 			return ghmcp.RunStdioServer(stdioServerConfig)
 		},
@@ -127,26 +223,91 @@ var (
 				}
 			}
 
+			var contentWindowOverrides []string
+			if viper.IsSet("content_window_overrides") {
+				if err := viper.UnmarshalKey("content_window_overrides", &contentWindowOverrides); err != nil {
+					return fmt.Errorf("failed to unmarshal content-window-overrides: %w", err)
+				}
+			}
+
+			var eventWebhookEvents []string
+			if viper.IsSet("event_webhook_events") {
+				if err := viper.UnmarshalKey("event_webhook_events", &eventWebhookEvents); err != nil {
+					return fmt.Errorf("failed to unmarshal event-webhook-events: %w", err)
+				}
+			}
+
+			var otlpHeaders []string
+			if viper.IsSet("otlp_headers") {
+				if err := viper.UnmarshalKey("otlp_headers", &otlpHeaders); err != nil {
+					return fmt.Errorf("failed to unmarshal otlp-headers: %w", err)
+				}
+			}
+
+			var enabledFeatures []string
+			if viper.IsSet("features") {
+				if err := viper.UnmarshalKey("features", &enabledFeatures); err != nil {
+					return fmt.Errorf("failed to unmarshal features: %w", err)
+				}
+			}
+
+			var listenAddrs []string
+			if viper.IsSet("listen_addr") {
+				if err := viper.UnmarshalKey("listen_addr", &listenAddrs); err != nil {
+					return fmt.Errorf("failed to unmarshal listen-addr: %w", err)
+				}
+			}
+
 			ttl := viper.GetDuration("repo-access-cache-ttl")
+			trustOwnContent := viper.GetBool("lockdown-trust-own-content")
 			httpConfig := ghhttp.ServerConfig{
-				Version:              version,
-				Host:                 viper.GetString("host"),
-				Port:                 viper.GetInt("port"),
-				BaseURL:              viper.GetString("base-url"),
-				ResourcePath:         viper.GetString("base-path"),
-				ExportTranslations:   viper.GetBool("export-translations"),
-				EnableCommandLogging: viper.GetBool("enable-command-logging"),
-				LogFilePath:          viper.GetString("log-file"),
-				ContentWindowSize:    viper.GetInt("content-window-size"),
-				LockdownMode:         viper.GetBool("lockdown-mode"),
-				RepoAccessCacheTTL:   &ttl,
-				ScopeChallenge:       viper.GetBool("scope-challenge"),
-				ReadOnly:             viper.GetBool("read-only"),
-				EnabledToolsets:      enabledToolsets,
-				EnabledTools:         enabledTools,
-				DynamicToolsets:      viper.GetBool("dynamic_toolsets"),
-				ExcludeTools:         excludeTools,
-				InsidersMode:         viper.GetBool("insiders"),
+				Version:                version,
+				Commit:                 commit,
+				BuildDate:              date,
+				Host:                   viper.GetString("host"),
+				UploadHost:             viper.GetString("upload-host"),
+				Port:                   viper.GetInt("port"),
+				ListenAddrs:            listenAddrs,
+				BaseURL:                viper.GetString("base-url"),
+				ResourcePath:           viper.GetString("base-path"),
+				ExportTranslations:     viper.GetBool("export-translations"),
+				EnableCommandLogging:   viper.GetBool("enable-command-logging"),
+				LogFilePath:            viper.GetString("log-file"),
+				ContentWindowSize:      viper.GetInt("content-window-size"),
+				ContentWindowOverrides: contentWindowOverrides,
+				LockdownMode:           viper.GetBool("lockdown-mode"),
+				RepoAccessCacheTTL:     &ttl,
+				ScopeChallenge:         viper.GetBool("scope-challenge"),
+				ReadOnly:               viper.GetBool("read-only"),
+				WithoutDestructive:     viper.GetBool("without-destructive"),
+				OnlyIdempotent:         viper.GetBool("only-idempotent"),
+				EstimateTokens:         viper.GetBool("estimate-tokens"),
+				RootsEnforcement:       viper.GetString("roots-enforcement"),
+				EventWebhookURL:        viper.GetString("event-webhook-url"),
+				EventWebhookSecret:     viper.GetString("event-webhook-secret"),
+				EventWebhookEvents:     eventWebhookEvents,
+				CursorSigningKey:       viper.GetString("cursor-signing-key"),
+				IncludeArchivedRepos:   viper.GetBool("include-archived-repos"),
+				IncludeForks:           viper.GetBool("include-forks"),
+				CommentTemplatesFile:   viper.GetString("comment-templates-file"),
+				ToolNamePrefix:         viper.GetString("tool-name-prefix"),
+				EnabledToolsets:        enabledToolsets,
+				EnabledTools:           enabledTools,
+				DynamicToolsets:        viper.GetBool("dynamic_toolsets"),
+				ExcludeTools:           excludeTools,
+				InsidersMode:           viper.GetBool("insiders"),
+				Features:               enabledFeatures,
+				CacheTTL:               viper.GetDuration("cache-ttl"),
+				CacheMaxBytes:          int64(viper.GetInt("cache-max-size-mb")) << 20,
+				DisableCache:           viper.GetBool("no-cache"),
+				OTLPEndpoint:           viper.GetString("otlp-endpoint"),
+				OTLPHeaders:            otlpHeaders,
+				AuditLogFile:           viper.GetString("audit-log-file"),
+				AuditLogURL:            viper.GetString("audit-log-url"),
+				DryRun:                 viper.GetBool("dry-run"),
+
+				LockdownTrustOwnContent: &trustOwnContent,
+				FeaturesQuerySigningKey: viper.GetString("features-query-signing-key"),
 			}
 
 			return ghhttp.RunHTTPServer(httpConfig)
@@ -164,23 +325,68 @@ func init() {
 	rootCmd.PersistentFlags().StringSlice("toolsets", nil, github.GenerateToolsetsHelp())
 	rootCmd.PersistentFlags().StringSlice("tools", nil, "Comma-separated list of specific tools to enable")
 	rootCmd.PersistentFlags().StringSlice("exclude-tools", nil, "Comma-separated list of tool names to disable regardless of other settings")
-	rootCmd.PersistentFlags().StringSlice("features", nil, "Comma-separated list of feature flags to enable")
+	rootCmd.PersistentFlags().StringSlice("features", nil, "Comma-separated list of feature flags to enable (prefix a flag with - to disable it)")
 	rootCmd.PersistentFlags().Bool("dynamic-toolsets", false, "Enable dynamic toolsets")
 	rootCmd.PersistentFlags().Bool("read-only", false, "Restrict the server to read-only operations")
+	rootCmd.PersistentFlags().Bool("without-destructive", false, "Hide write tools that may delete or overwrite data")
+	rootCmd.PersistentFlags().Bool("only-idempotent", false, "Hide write tools that are not idempotent")
+	rootCmd.PersistentFlags().Bool("estimate-tokens", false, "Attach the estimated token count of each tool result to its _meta")
+	rootCmd.PersistentFlags().String("roots-enforcement", "off", "What to do with tool calls targeting a repository outside the client's roots: off, warn or block")
+	rootCmd.PersistentFlags().String("event-webhook-url", "", "URL to POST tool events to, such as write tool calls and lockdown blocks")
+	rootCmd.PersistentFlags().String("event-webhook-secret", "", "Secret to sign event webhook deliveries with, in the X-Hub-Signature-256 header")
+	rootCmd.PersistentFlags().StringSlice("event-webhook-events", nil, "Comma-separated list of tool events to send: write_tool, lockdown_block, enforcement_denial (defaults to all)")
+	rootCmd.PersistentFlags().String("cursor-signing-key", "", "Key to sign the pagination cursors of GraphQL tools with, so that they stay valid across restarts and server instances")
+	rootCmd.PersistentFlags().Bool("include-archived-repos", false, "Keep archived repositories in the results of list and search tools unless a call leaves them out")
+	rootCmd.PersistentFlags().Bool("include-forks", false, "Keep forks in the results of list and search tools unless a call leaves them out")
+	rootCmd.PersistentFlags().String("tool-name-prefix", "", "Prefix every tool name, e.g. 'github.' to expose 'github.list_issues' behind MCP aggregators")
 	rootCmd.PersistentFlags().String("log-file", "", "Path to log file")
 	rootCmd.PersistentFlags().Bool("enable-command-logging", false, "When enabled, the server will log all command requests and responses to the log file")
 	rootCmd.PersistentFlags().Bool("export-translations", false, "Save translations to a JSON file")
 	rootCmd.PersistentFlags().String("gh-host", "", "Specify the GitHub hostname (for GitHub Enterprise etc.)")
+	rootCmd.PersistentFlags().String("gh-upload-host", "", "URL to upload release assets to, when it differs from the one derived from the GitHub hostname")
 	rootCmd.PersistentFlags().Int("content-window-size", 5000, "Specify the content window size")
+	rootCmd.PersistentFlags().StringSlice("content-window-overrides", nil, "Comma-separated list of toolset=size or tool=size entries overriding the content window size, e.g. actions=20000")
 	rootCmd.PersistentFlags().Bool("lockdown-mode", false, "Enable lockdown mode")
+	rootCmd.PersistentFlags().Bool("lockdown-trust-own-content", true, "In lockdown mode, always trust content authored by the token owner")
 	rootCmd.PersistentFlags().Bool("insiders", false, "Enable insiders features")
 	rootCmd.PersistentFlags().Duration("repo-access-cache-ttl", 5*time.Minute, "Override the repo access cache TTL (e.g. 1m, 0s to disable)")
+	rootCmd.PersistentFlags().Duration("cache-ttl", 24*time.Hour, "How long cached responses are kept without being revalidated (0 to keep them until evicted)")
+	rootCmd.PersistentFlags().Int("cache-max-size-mb", 100, "Maximum size of the response cache in megabytes (0 for unlimited)")
+	rootCmd.PersistentFlags().Bool("no-cache", false, "Disable the response cache, which revalidates repeated GitHub API reads with conditional requests")
+	rootCmd.PersistentFlags().String("otlp-endpoint", "", "OTLP/HTTP endpoint to export OpenTelemetry traces of MCP requests and GitHub API calls to (e.g. http://localhost:4318)")
+	rootCmd.PersistentFlags().StringSlice("otlp-headers", nil, "Comma-separated key=value headers to send with trace exports, for example to authenticate with the collector")
+	rootCmd.PersistentFlags().String("audit-log-file", "", "File to append an audit record of every write tool call to, as JSON lines")
+	rootCmd.PersistentFlags().String("audit-log-url", "", "URL to POST an audit record of every write tool call to")
+	rootCmd.PersistentFlags().String("comment-templates-file", "", "YAML file of comment templates that create_issue_comment_from_template can post")
+	rootCmd.PersistentFlags().Bool("dry-run", false, "Describe the GitHub API requests of write tool calls (method, endpoint and payload) instead of making them")
+
+	// stdio-specific flags
+	stdioCmd.Flags().Int("session-call-budget", 0, "Maximum number of GitHub API requests per session (0 for unlimited)")
+	stdioCmd.Flags().Int("session-cost-budget", 0, "Maximum estimated GitHub rate limit cost per session, counting writes as 5 and GraphQL queries at their actual cost (0 for unlimited)")
+	stdioCmd.Flags().Int("write-quota-per-hour", 0, "Maximum number of write tool calls per hour before approval is required (0 for unlimited)")
+	stdioCmd.Flags().String("write-approval-webhook", "", "URL asked to approve write tool calls beyond the quota (defaults to asking the user)")
+	stdioCmd.Flags().Bool("confirm-destructive", false, "Ask the user to confirm calls to destructive tools, such as merge_pull_request and delete_file, through elicitation")
+	stdioCmd.Flags().StringSlice("confirm-destructive-tools", nil, "Comma-separated list of tool or tool=prompt entries to confirm with --confirm-destructive instead of the defaults; tool[argument=value&...] confirms only calls with these argument values, and {argument} in a prompt is replaced by the argument's value")
+	stdioCmd.Flags().String("ghes-version", "", "GitHub Enterprise Server version (e.g. 3.16) used to hide tools the instance does not support, without querying it")
+	stdioCmd.Flags().String("token-command", "", "Shell command printing the GitHub token, such as 'gh auth token', run again when the token expires")
+	stdioCmd.Flags().Duration("token-command-ttl", 5*time.Minute, "How long the output of --token-command is used before the command runs again")
+	stdioCmd.Flags().String("token-file", "", "File holding the GitHub token, read again whenever it changes")
+	stdioCmd.Flags().Bool("token-from-initialize", false, "Read the GitHub token from the github_token entry of the _meta of the client's initialize request")
+	stdioCmd.Flags().String("token-env-template", "", "Environment variable holding the GitHub token of clients that provide none, with {key} replaced by the _meta entry key of the initialize request, such as GITHUB_TOKEN_{user}")
+	stdioCmd.Flags().Int64("app-id", 0, "Authenticate as this GitHub App instead of with a personal access token (requires --app-private-key-file)")
+	stdioCmd.Flags().String("app-private-key-file", "", "Path to the PEM encoded private key of the GitHub App")
+	stdioCmd.Flags().Int64("app-installation-id", 0, "Installation of the GitHub App to authenticate as (defaults to its only installation)")
+	stdioCmd.Flags().StringSlice("profiles", nil, "Comma-separated list of name=host profiles tool calls can select with their profile argument, each authenticated with the token of GITHUB_PROFILE_<NAME>_TOKEN")
+	stdioCmd.Flags().Bool("dedup-results", false, "Replace tool results identical to an earlier result of the session with a short marker")
+	stdioCmd.Flags().String("cache-dir", "", "Directory to persist GitHub API responses in across restarts, revalidated with conditional requests (cached in memory when empty)")
 
 	// HTTP-specific flags
 	httpCmd.Flags().Int("port", 8082, "HTTP server port")
+	httpCmd.Flags().StringSlice("listen-addr", nil, "Comma-separated list of addresses to listen on instead of the port on every interface: host:port, [ipv6]:port, a host alone (listens on --port) or unix:/path/to/socket")
 	httpCmd.Flags().String("base-url", "", "Base URL where this server is publicly accessible (for OAuth resource metadata)")
 	httpCmd.Flags().String("base-path", "", "Externally visible base path for the HTTP server (for OAuth resource metadata)")
 	httpCmd.Flags().Bool("scope-challenge", false, "Enable OAuth scope challenge responses")
+	httpCmd.Flags().String("features-query-signing-key", "", "Key to verify the features_sig of the features query parameter with, letting clients that cannot set headers enable feature flags")
 
 	// Bind flag to viper
 	_ = viper.BindPFlag("toolsets", rootCmd.PersistentFlags().Lookup("toolsets"))
@@ -189,18 +395,61 @@ func init() {
 	_ = viper.BindPFlag("features", rootCmd.PersistentFlags().Lookup("features"))
 	_ = viper.BindPFlag("dynamic_toolsets", rootCmd.PersistentFlags().Lookup("dynamic-toolsets"))
 	_ = viper.BindPFlag("read-only", rootCmd.PersistentFlags().Lookup("read-only"))
+	_ = viper.BindPFlag("without-destructive", rootCmd.PersistentFlags().Lookup("without-destructive"))
+	_ = viper.BindPFlag("only-idempotent", rootCmd.PersistentFlags().Lookup("only-idempotent"))
+	_ = viper.BindPFlag("estimate-tokens", rootCmd.PersistentFlags().Lookup("estimate-tokens"))
+	_ = viper.BindPFlag("roots-enforcement", rootCmd.PersistentFlags().Lookup("roots-enforcement"))
+	_ = viper.BindPFlag("event-webhook-url", rootCmd.PersistentFlags().Lookup("event-webhook-url"))
+	_ = viper.BindPFlag("event-webhook-secret", rootCmd.PersistentFlags().Lookup("event-webhook-secret"))
+	_ = viper.BindPFlag("cursor-signing-key", rootCmd.PersistentFlags().Lookup("cursor-signing-key"))
+	_ = viper.BindPFlag("include-archived-repos", rootCmd.PersistentFlags().Lookup("include-archived-repos"))
+	_ = viper.BindPFlag("include-forks", rootCmd.PersistentFlags().Lookup("include-forks"))
+	_ = viper.BindPFlag("event_webhook_events", rootCmd.PersistentFlags().Lookup("event-webhook-events"))
+	_ = viper.BindPFlag("tool-name-prefix", rootCmd.PersistentFlags().Lookup("tool-name-prefix"))
 	_ = viper.BindPFlag("log-file", rootCmd.PersistentFlags().Lookup("log-file"))
 	_ = viper.BindPFlag("enable-command-logging", rootCmd.PersistentFlags().Lookup("enable-command-logging"))
 	_ = viper.BindPFlag("export-translations", rootCmd.PersistentFlags().Lookup("export-translations"))
 	_ = viper.BindPFlag("host", rootCmd.PersistentFlags().Lookup("gh-host"))
+	_ = viper.BindPFlag("upload-host", rootCmd.PersistentFlags().Lookup("gh-upload-host"))
 	_ = viper.BindPFlag("content-window-size", rootCmd.PersistentFlags().Lookup("content-window-size"))
+	_ = viper.BindPFlag("content_window_overrides", rootCmd.PersistentFlags().Lookup("content-window-overrides"))
 	_ = viper.BindPFlag("lockdown-mode", rootCmd.PersistentFlags().Lookup("lockdown-mode"))
+	_ = viper.BindPFlag("lockdown-trust-own-content", rootCmd.PersistentFlags().Lookup("lockdown-trust-own-content"))
 	_ = viper.BindPFlag("insiders", rootCmd.PersistentFlags().Lookup("insiders"))
 	_ = viper.BindPFlag("repo-access-cache-ttl", rootCmd.PersistentFlags().Lookup("repo-access-cache-ttl"))
+	_ = viper.BindPFlag("cache-ttl", rootCmd.PersistentFlags().Lookup("cache-ttl"))
+	_ = viper.BindPFlag("cache-max-size-mb", rootCmd.PersistentFlags().Lookup("cache-max-size-mb"))
+	_ = viper.BindPFlag("no-cache", rootCmd.PersistentFlags().Lookup("no-cache"))
+	_ = viper.BindPFlag("otlp-endpoint", rootCmd.PersistentFlags().Lookup("otlp-endpoint"))
+	_ = viper.BindPFlag("otlp_headers", rootCmd.PersistentFlags().Lookup("otlp-headers"))
+	_ = viper.BindPFlag("audit-log-file", rootCmd.PersistentFlags().Lookup("audit-log-file"))
+	_ = viper.BindPFlag("audit-log-url", rootCmd.PersistentFlags().Lookup("audit-log-url"))
+	_ = viper.BindPFlag("dry-run", rootCmd.PersistentFlags().Lookup("dry-run"))
+	_ = viper.BindPFlag("comment-templates-file", rootCmd.PersistentFlags().Lookup("comment-templates-file"))
+	_ = viper.BindPFlag("session-call-budget", stdioCmd.Flags().Lookup("session-call-budget"))
+	_ = viper.BindPFlag("session-cost-budget", stdioCmd.Flags().Lookup("session-cost-budget"))
+	_ = viper.BindPFlag("write-quota-per-hour", stdioCmd.Flags().Lookup("write-quota-per-hour"))
+	_ = viper.BindPFlag("write-approval-webhook", stdioCmd.Flags().Lookup("write-approval-webhook"))
+	_ = viper.BindPFlag("confirm-destructive", stdioCmd.Flags().Lookup("confirm-destructive"))
+	_ = viper.BindPFlag("confirm_destructive_tools", stdioCmd.Flags().Lookup("confirm-destructive-tools"))
+	_ = viper.BindPFlag("ghes-version", stdioCmd.Flags().Lookup("ghes-version"))
+	_ = viper.BindPFlag("token-command", stdioCmd.Flags().Lookup("token-command"))
+	_ = viper.BindPFlag("token-command-ttl", stdioCmd.Flags().Lookup("token-command-ttl"))
+	_ = viper.BindPFlag("token-file", stdioCmd.Flags().Lookup("token-file"))
+	_ = viper.BindPFlag("token-from-initialize", stdioCmd.Flags().Lookup("token-from-initialize"))
+	_ = viper.BindPFlag("token-env-template", stdioCmd.Flags().Lookup("token-env-template"))
+	_ = viper.BindPFlag("app-id", stdioCmd.Flags().Lookup("app-id"))
+	_ = viper.BindPFlag("app-private-key-file", stdioCmd.Flags().Lookup("app-private-key-file"))
+	_ = viper.BindPFlag("app-installation-id", stdioCmd.Flags().Lookup("app-installation-id"))
+	_ = viper.BindPFlag("profiles", stdioCmd.Flags().Lookup("profiles"))
+	_ = viper.BindPFlag("dedup-results", stdioCmd.Flags().Lookup("dedup-results"))
+	_ = viper.BindPFlag("cache-dir", stdioCmd.Flags().Lookup("cache-dir"))
 	_ = viper.BindPFlag("port", httpCmd.Flags().Lookup("port"))
+	_ = viper.BindPFlag("listen_addr", httpCmd.Flags().Lookup("listen-addr"))
 	_ = viper.BindPFlag("base-url", httpCmd.Flags().Lookup("base-url"))
 	_ = viper.BindPFlag("base-path", httpCmd.Flags().Lookup("base-path"))
 	_ = viper.BindPFlag("scope-challenge", httpCmd.Flags().Lookup("scope-challenge"))
+	_ = viper.BindPFlag("features-query-signing-key", httpCmd.Flags().Lookup("features-query-signing-key"))
 	// Add subcommands
 	rootCmd.AddCommand(stdioCmd)
 	rootCmd.AddCommand(httpCmd)
@@ -12,14 +12,18 @@ import (
 	"syscall"
 	"time"
 
+	"github.com/github/github-mcp-server/pkg/audit"
 	"github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/ghes"
 	"github.com/github/github-mcp-server/pkg/github"
+	"github.com/github/github-mcp-server/pkg/githubapp"
 	"github.com/github/github-mcp-server/pkg/http/transport"
 	"github.com/github/github-mcp-server/pkg/inventory"
 	"github.com/github/github-mcp-server/pkg/lockdown"
 	mcplog "github.com/github/github-mcp-server/pkg/log"
 	"github.com/github/github-mcp-server/pkg/observability"
 	"github.com/github/github-mcp-server/pkg/observability/metrics"
+	"github.com/github/github-mcp-server/pkg/observability/tracing"
 	"github.com/github/github-mcp-server/pkg/raw"
 	"github.com/github/github-mcp-server/pkg/scopes"
 	"github.com/github/github-mcp-server/pkg/translations"
@@ -38,8 +42,9 @@ type githubClients struct {
 	repoAccess *lockdown.RepoAccessCache
 }
 
-// createGitHubClients creates all the GitHub API clients needed by the server.
-func createGitHubClients(cfg github.MCPServerConfig, apiHost utils.APIHostResolver) (*githubClients, error) {
+// createGitHubClients creates all the GitHub API clients needed by the server, or by one of its
+// profiles when profile is set.
+func createGitHubClients(cfg github.MCPServerConfig, apiHost utils.APIHostResolver, profile string) (*githubClients, error) {
 	restURL, err := apiHost.BaseRESTURL(context.Background())
 	if err != nil {
 		return nil, fmt.Errorf("failed to get base REST URL: %w", err)
@@ -60,20 +65,58 @@ func createGitHubClients(cfg github.MCPServerConfig, apiHost utils.APIHostResolv
 		return nil, fmt.Errorf("failed to get Raw URL: %w", err)
 	}
 
-	// Construct REST client
-	restClient := gogithub.NewClient(nil).WithAuthToken(cfg.Token)
+	// Construct REST client. Requests are charged to the session's API budget, if any, and wait
+	// out rate limits rather than failing.
+	var restTransport http.RoundTripper = &transport.APIBudgetTransport{Transport: &transport.RateLimitTransport{Transport: http.DefaultTransport}}
+	if cfg.ResponseCache != nil {
+		restTransport = &transport.ConditionalTransport{Transport: restTransport, Cache: cfg.ResponseCache}
+	}
+	if cfg.Tracer != nil {
+		restTransport = &transport.TracingTransport{Transport: restTransport, Tracer: cfg.Tracer}
+	}
+	tokens := cfg.TokenSource
+	if cfg.AppID != 0 {
+		// Authenticate as a GitHub App installation, with tokens created as they expire
+		appTokens, err := githubapp.NewInstallationTokenSource(cfg.AppID, cfg.AppPrivateKey, cfg.AppInstallationID, restURL, nil)
+		if err != nil {
+			return nil, err
+		}
+		// Fail at startup rather than on the first tool call when the app cannot authenticate
+		if _, err := appTokens.Token(context.Background()); err != nil {
+			return nil, err
+		}
+		tokens = appTokens
+	}
+	if tokens == nil {
+		tokens = transport.StaticTokenSource(cfg.Token)
+	}
+
+	// Mutating requests of dry-run tool calls are intercepted before they are authenticated.
+	restClient := gogithub.NewClient(&http.Client{Transport: &transport.DryRunTransport{
+		Transport: &transport.TokenSourceTransport{Transport: restTransport, Source: tokens},
+	}})
 	restClient.UserAgent = fmt.Sprintf("github-mcp-server/%s", cfg.Version)
 	restClient.BaseURL = restURL
 	restClient.UploadURL = uploadURL
 
-	// Construct GraphQL client
+	// Construct GraphQL client, logging the rate limit cost of each query and recording it in
+	// the session's API budget.
 	// We use NewEnterpriseClient unconditionally since we already parsed the API host
+	var gqlTransport http.RoundTripper = &transport.GraphQLFeaturesTransport{
+		Transport: &transport.GraphQLCostTransport{
+			Transport: &transport.APIBudgetTransport{Transport: &transport.RateLimitTransport{Transport: http.DefaultTransport}},
+			Logger:    cfg.Logger,
+		},
+	}
+	if cfg.Tracer != nil {
+		gqlTransport = &transport.TracingTransport{Transport: gqlTransport, Tracer: cfg.Tracer}
+	}
 	gqlHTTPClient := &http.Client{
-		Transport: &transport.BearerAuthTransport{
-			Transport: &transport.GraphQLFeaturesTransport{
-				Transport: http.DefaultTransport,
+		Transport: &transport.DryRunTransport{
+			Transport: &transport.TokenSourceTransport{
+				Transport: gqlTransport,
+				Source:    tokens,
 			},
-			Token: cfg.Token,
 		},
 	}
 
@@ -91,7 +134,16 @@ func createGitHubClients(cfg github.MCPServerConfig, apiHost utils.APIHostResolv
 		if cfg.RepoAccessTTL != nil {
 			opts = append(opts, lockdown.WithTTL(*cfg.RepoAccessTTL))
 		}
-		repoAccessCache = lockdown.GetInstance(gqlClient, opts...)
+		if cfg.LockdownTrustOwnContent != nil {
+			opts = append(opts, lockdown.WithTrustOwnContent(*cfg.LockdownTrustOwnContent))
+		}
+		if profile != "" {
+			// Access to the repositories of a profile is checked with its own clients
+			opts = append(opts, lockdown.WithCacheName("repo-access-"+profile))
+			repoAccessCache = lockdown.NewRepoAccessCache(gqlClient, opts...)
+		} else {
+			repoAccessCache = lockdown.GetInstance(gqlClient, opts...)
+		}
 	}
 
 	return &githubClients{
@@ -108,8 +160,12 @@ func NewStdioMCPServer(ctx context.Context, cfg github.MCPServerConfig) (*mcp.Se
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse API host: %w", err)
 	}
+	apiHost, err = utils.WithUploadURL(apiHost, cfg.UploadHost)
+	if err != nil {
+		return nil, err
+	}
 
-	clients, err := createGitHubClients(cfg, apiHost)
+	clients, err := createGitHubClients(cfg, apiHost, "")
 	if err != nil {
 		return nil, fmt.Errorf("failed to create GitHub clients: %w", err)
 	}
@@ -136,10 +192,48 @@ func NewStdioMCPServer(ctx context.Context, cfg github.MCPServerConfig) (*mcp.Se
 		featureChecker,
 		obs,
 	)
+
+	// Each profile has its own clients, authenticated on its host with its token
+	profiles := make([]github.Profile, 0, len(cfg.Profiles))
+	profileClients := make([]*githubClients, 0, len(cfg.Profiles))
+	for _, profile := range cfg.Profiles {
+		profileAPIHost, err := utils.NewAPIHost(profile.Host)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse API host of profile %s: %w", profile.Name, err)
+		}
+		profileCfg := cfg
+		profileCfg.Host, profileCfg.Token, profileCfg.TokenSource, profileCfg.AppID = profile.Host, profile.Token, nil, 0
+		pc, err := createGitHubClients(profileCfg, profileAPIHost, profile.Name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create GitHub clients of profile %s: %w", profile.Name, err)
+		}
+		profileClients = append(profileClients, pc)
+		profiles = append(profiles, github.Profile{
+			Name: profile.Name,
+			Host: profile.Host,
+			Deps: github.NewBaseDeps(
+				pc.rest,
+				pc.gql,
+				pc.raw,
+				pc.repoAccess,
+				cfg.Translator,
+				github.FeatureFlags{
+					LockdownMode: cfg.LockdownMode,
+					InsidersMode: cfg.InsidersMode,
+				},
+				cfg.ContentWindowSize,
+				featureChecker,
+				obs,
+			),
+		})
+	}
+
 	// Build and register the tool/resource/prompt inventory
 	inventoryBuilder := github.NewInventory(cfg.Translator).
 		WithDeprecatedAliases(github.DeprecatedToolAliases).
 		WithReadOnly(cfg.ReadOnly).
+		WithoutDestructive(cfg.WithoutDestructive).
+		OnlyIdempotent(cfg.OnlyIdempotent).
 		WithToolsets(github.ResolvedEnabledToolsets(cfg.DynamicToolsets, cfg.EnabledToolsets, cfg.EnabledTools)).
 		WithTools(github.CleanTools(cfg.EnabledTools)).
 		WithExcludeTools(cfg.ExcludeTools).
@@ -151,12 +245,36 @@ func NewStdioMCPServer(ctx context.Context, cfg github.MCPServerConfig) (*mcp.Se
 		inventoryBuilder = inventoryBuilder.WithFilter(github.CreateToolScopeFilter(cfg.TokenScopes))
 	}
 
+	// Hide tools the type of token cannot use, such as those only GitHub Apps can call
+	tokenType := utils.TokenTypeOf(cfg.Token)
+	if cfg.AppID != 0 {
+		tokenType = utils.TokenTypeServerToServerGitHubAppToken
+	}
+	inventoryBuilder = inventoryBuilder.WithFilter(github.CreateTokenTypeFilter(tokenType))
+
+	// Hide tools the GitHub Enterprise Server version does not support
+	if cfg.GHESVersion != "" {
+		caps, err := ghes.Load(cfg.GHESVersion)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load GHES capabilities: %w", err)
+		}
+		if caps.Newer && cfg.Logger != nil {
+			cfg.Logger.Warn("GHES version is newer than the bundled capabilities, assuming those of the newest bundled version",
+				"ghesVersion", caps.Version, "supportedVersions", ghes.SupportedVersions())
+		}
+		inventoryBuilder = inventoryBuilder.WithFilter(github.CreateGHESCapabilityFilter(caps))
+	}
+
 	inventory, err := inventoryBuilder.Build()
 	if err != nil {
 		return nil, fmt.Errorf("failed to build inventory: %w", err)
 	}
 
-	ghServer, err := github.NewMCPServer(ctx, &cfg, deps, inventory)
+	var middleware []mcp.Middleware
+	if len(profiles) > 0 {
+		middleware = append(middleware, github.ProfileMiddleware(cfg.Host, profiles))
+	}
+	ghServer, err := github.NewMCPServer(ctx, &cfg, deps, inventory, middleware...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create GitHub MCP server: %w", err)
 	}
@@ -171,6 +289,12 @@ func NewStdioMCPServer(ctx context.Context, cfg github.MCPServerConfig) (*mcp.Se
 	}
 
 	ghServer.AddReceivingMiddleware(addUserAgentsMiddleware(cfg, clients.rest, clients.gqlHTTP))
+	for _, pc := range profileClients {
+		ghServer.AddReceivingMiddleware(addUserAgentsMiddleware(cfg, pc.rest, pc.gqlHTTP))
+	}
+	if sessionTokens, ok := cfg.TokenSource.(*transport.SessionTokenSource); ok {
+		ghServer.AddReceivingMiddleware(resolveSessionTokenMiddleware(sessionTokens))
+	}
 
 	return ghServer, nil
 }
@@ -179,12 +303,43 @@ type StdioServerConfig struct {
 	// Version of the server
 	Version string
 
+	// Commit and BuildDate identify the build of the server
+	Commit    string
+	BuildDate string
+
 	// GitHub Host to target for API requests (e.g. github.com or github.enterprise.com)
 	Host string
 
+	// UploadHost overrides the URL release assets are uploaded to, which is derived from Host
+	// by default
+	UploadHost string
+
 	// GitHub Token to authenticate with the GitHub API
 	Token string
 
+	// TokenCommand and TokenFile provide the token instead of Token, running the command again
+	// after TokenCommandTTL and reading the file again when it changes, so that long-lived
+	// sessions keep working after the initial token expires.
+	TokenCommand    string
+	TokenCommandTTL time.Duration
+	TokenFile       string
+
+	// TokenFromInitialize reads the token from the _meta of the client's initialize request
+	// instead, or from the environment variable TokenEnvTemplate names, so that one configured
+	// server can serve several users. Setting TokenEnvTemplate implies TokenFromInitialize.
+	TokenFromInitialize bool
+	TokenEnvTemplate    string
+
+	// AppID and AppPrivateKey authenticate as a GitHub App installation instead of with Token.
+	// AppInstallationID may be zero when the app has a single installation.
+	AppID             int64
+	AppPrivateKey     []byte
+	AppInstallationID int64
+
+	// Profiles are "name=host" entries of other hosts tool calls can use through their profile
+	// argument, each with the token of the GITHUB_PROFILE_<NAME>_TOKEN environment variable
+	Profiles []string
+
 	// EnabledToolsets is a list of toolsets to enable
 	// See: https://github.com/github/github-mcp-server?tab=readme-ov-file#tool-configuration
 	EnabledToolsets []string
@@ -204,6 +359,42 @@ type StdioServerConfig struct {
 	// ReadOnly indicates if we should only register read-only tools
 	ReadOnly bool
 
+	// WithoutDestructive hides write tools that may delete or overwrite data
+	WithoutDestructive bool
+
+	// OnlyIdempotent hides write tools whose repeated calls have additional effects
+	OnlyIdempotent bool
+
+	// EstimateTokens attaches the estimated token count of tool results to their _meta
+	EstimateTokens bool
+
+	// DedupResults replaces tool results identical to an earlier result of the session with a
+	// marker naming the earlier call
+	DedupResults bool
+
+	// EventWebhookURL receives tool events, signed with EventWebhookSecret when it is set.
+	// EventWebhookEvents selects the events to send, all of them when empty.
+	EventWebhookURL    string
+	EventWebhookSecret string
+	EventWebhookEvents []string
+
+	// CursorSigningKey signs the pagination cursors returned by GraphQL tools, so that they stay
+	// valid across restarts
+	CursorSigningKey string
+
+	// IncludeArchivedRepos and IncludeForks keep archived repositories and forks in the results
+	// of list and search tools by default
+	IncludeArchivedRepos bool
+	IncludeForks         bool
+
+	// CommentTemplatesFile is a YAML file of comment templates that
+	// create_issue_comment_from_template can post
+	CommentTemplatesFile string
+
+	// RootsEnforcement is off, warn or block: what to do with tool calls targeting a
+	// repository outside the client's roots
+	RootsEnforcement string
+
 	// ExportTranslations indicates if we should export translations
 	// See: https://github.com/github/github-mcp-server?tab=readme-ov-file#i18n--overriding-descriptions
 	ExportTranslations bool
@@ -214,9 +405,26 @@ type StdioServerConfig struct {
 	// Path to the log file if not stderr
 	LogFilePath string
 
+	// OTLPEndpoint, when set, is the OTLP/HTTP endpoint of an OpenTelemetry collector that spans
+	// of tool calls and GitHub API calls are exported to, with the "key=value" OTLPHeaders
+	OTLPEndpoint string
+	OTLPHeaders  []string
+
+	// AuditLogFile and AuditLogURL, when set, receive an audit record of every write tool call,
+	// appended to the file as JSON lines and POSTed to the URL
+	AuditLogFile string
+	AuditLogURL  string
+
+	// DryRun describes the GitHub API requests of write tool calls instead of making them
+	DryRun bool
+
 	// Content window size
 	ContentWindowSize int
 
+	// ContentWindowOverrides are "name=size" entries overriding the content window size for a
+	// toolset or tool
+	ContentWindowOverrides []string
+
 	// LockdownMode indicates if we should enable lockdown mode
 	LockdownMode bool
 
@@ -230,6 +438,54 @@ type StdioServerConfig struct {
 
 	// RepoAccessCacheTTL overrides the default TTL for repository access cache entries.
 	RepoAccessCacheTTL *time.Duration
+
+	// LockdownTrustOwnContent overrides whether lockdown mode always trusts content authored by
+	// the token owner. Defaults to true.
+	LockdownTrustOwnContent *bool
+
+	// GHESVersion is the GitHub Enterprise Server version (e.g. 3.16) whose bundled
+	// capabilities are used to hide tools the instance does not support.
+	GHESVersion string
+
+	// ToolNamePrefix, when set, is put in front of every tool name clients see
+	ToolNamePrefix string
+
+	// SessionCallBudget is the maximum number of GitHub API requests the session may make.
+	// Zero means unlimited.
+	SessionCallBudget int
+
+	// SessionCostBudget is the maximum estimated rate limit cost of the GitHub API requests
+	// the session may make. Zero means unlimited.
+	SessionCostBudget int
+
+	// WriteQuotaPerHour is the number of write tool calls the session may make in any hour
+	// without approval. Zero means unlimited.
+	WriteQuotaPerHour int
+
+	// WriteApprovalWebhook is the URL asked to approve write tool calls beyond the quota.
+	// When empty, the user is asked through elicitation.
+	WriteApprovalWebhook string
+
+	// ConfirmDestructive asks the user to confirm the calls of destructive tools through
+	// elicitation: the tools of the "name" or "name=prompt" ConfirmDestructiveTools entries, or
+	// else github.DefaultDestructiveToolPrompts.
+	ConfirmDestructive      bool
+	ConfirmDestructiveTools []string
+
+	// CacheDir, when set, persists REST and raw content responses in this directory, so that
+	// restarted servers revalidate them instead of fetching them again. Otherwise they are
+	// cached in memory.
+	CacheDir string
+
+	// CacheTTL is how long cached responses are kept without being revalidated. Zero keeps them
+	// until they are evicted.
+	CacheTTL time.Duration
+
+	// CacheMaxBytes caps the size of the response cache. Zero means unlimited.
+	CacheMaxBytes int64
+
+	// DisableCache turns off the response cache.
+	DisableCache bool
 }
 
 // RunStdioServer is not concurrent safe.
@@ -256,6 +512,111 @@ func RunStdioServer(cfg StdioServerConfig) error {
 	logger := slog.New(slogHandler)
 	logger.Info("starting server", "version", cfg.Version, "host", cfg.Host, "dynamicToolsets", cfg.DynamicToolsets, "readOnly", cfg.ReadOnly, "lockdownEnabled", cfg.LockdownMode)
 
+	contentWindowOverrides, err := github.ParseContentWindowOverrides(github.StripToolNamePrefix(cfg.ToolNamePrefix, cfg.ContentWindowOverrides))
+	if err != nil {
+		return err
+	}
+
+	var destructiveToolPrompts github.DestructiveToolPrompts
+	if cfg.ConfirmDestructive {
+		destructiveToolPrompts, err = github.ParseDestructiveToolPrompts(github.StripToolNamePrefix(cfg.ToolNamePrefix, cfg.ConfirmDestructiveTools))
+		if err != nil {
+			return err
+		}
+	}
+
+	profiles, err := github.ParseProfiles(cfg.Profiles)
+	if err != nil {
+		return err
+	}
+
+	rootsEnforcement, err := github.ParseRootsEnforcement(cfg.RootsEnforcement)
+	if err != nil {
+		return err
+	}
+
+	eventWebhookEvents, err := github.ParseToolEvents(cfg.EventWebhookEvents)
+	if err != nil {
+		return err
+	}
+
+	commentTemplates, err := github.LoadCommentTemplates(cfg.CommentTemplatesFile)
+	if err != nil {
+		return err
+	}
+
+	var responseCache transport.ResponseCache
+	switch {
+	case cfg.DisableCache:
+	case cfg.CacheDir != "":
+		diskCache, err := transport.NewDiskCache(cfg.CacheDir, cfg.CacheTTL, cfg.CacheMaxBytes)
+		if err != nil {
+			return err
+		}
+		logger.Info("caching responses on disk", "dir", cfg.CacheDir, "ttl", cfg.CacheTTL, "maxBytes", cfg.CacheMaxBytes, "size", diskCache.Size())
+		responseCache = diskCache
+	default:
+		responseCache = transport.NewMemoryCache(cfg.CacheTTL, cfg.CacheMaxBytes)
+	}
+
+	var tracer tracing.Tracer
+	if cfg.OTLPEndpoint != "" {
+		otlpHeaders, err := tracing.ParseOTLPHeaders(cfg.OTLPHeaders)
+		if err != nil {
+			return err
+		}
+		otlpTracer, err := tracing.NewOTLPTracer(tracing.OTLPConfig{
+			Endpoint:       cfg.OTLPEndpoint,
+			Headers:        otlpHeaders,
+			ServiceVersion: cfg.Version,
+			Logger:         logger.With("component", "tracing"),
+		})
+		if err != nil {
+			return err
+		}
+		defer func() {
+			shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			if err := otlpTracer.Shutdown(shutdownCtx); err != nil {
+				logger.Warn("failed to export remaining spans", "error", err)
+			}
+		}()
+		logger.Info("exporting traces", "endpoint", cfg.OTLPEndpoint)
+		tracer = otlpTracer
+	}
+
+	auditLog, err := audit.Open(audit.Config{FilePath: cfg.AuditLogFile, URL: cfg.AuditLogURL})
+	if err != nil {
+		return err
+	}
+	if auditLog != nil {
+		defer func() { _ = auditLog.Close() }()
+		logger.Info("recording write tool calls in the audit log", "file", cfg.AuditLogFile, "url", cfg.AuditLogURL)
+	}
+
+	sessionToken := cfg.TokenFromInitialize || cfg.TokenEnvTemplate != ""
+	var tokenSource transport.TokenSource
+	switch {
+	case sessionToken:
+		// The token is unknown until the client initializes the session, so tools are not
+		// filtered by its type or scopes
+		tokenSource = transport.NewSessionTokenSource(cfg.TokenEnvTemplate)
+		cfg.Token = ""
+		logger.Info("reading the token from the initialize request", "envTemplate", cfg.TokenEnvTemplate)
+	case cfg.TokenCommand != "":
+		tokenSource = transport.NewCommandTokenSource(cfg.TokenCommand, cfg.TokenCommandTTL)
+	case cfg.TokenFile != "":
+		tokenSource = transport.NewFileTokenSource(cfg.TokenFile)
+	}
+	if tokenSource != nil && !sessionToken {
+		// The initial token identifies the type of token and its scopes
+		token, err := tokenSource.Token(ctx)
+		if err != nil {
+			return err
+		}
+		cfg.Token = token
+	}
+
 	// Fetch token scopes for scope-based tool filtering (PAT tokens only)
 	// Only classic PATs (ghp_ prefix) return OAuth scopes via X-OAuth-Scopes header.
 	// Fine-grained PATs and other token types don't support this, so we skip filtering.
@@ -273,22 +634,63 @@ func RunStdioServer(cfg StdioServerConfig) error {
 	}
 
 	ghServer, err := NewStdioMCPServer(ctx, github.MCPServerConfig{
-		Version:           cfg.Version,
-		Host:              cfg.Host,
-		Token:             cfg.Token,
-		EnabledToolsets:   cfg.EnabledToolsets,
-		EnabledTools:      cfg.EnabledTools,
-		EnabledFeatures:   cfg.EnabledFeatures,
-		DynamicToolsets:   cfg.DynamicToolsets,
-		ReadOnly:          cfg.ReadOnly,
-		Translator:        t,
-		ContentWindowSize: cfg.ContentWindowSize,
-		LockdownMode:      cfg.LockdownMode,
-		InsidersMode:      cfg.InsidersMode,
-		ExcludeTools:      cfg.ExcludeTools,
-		Logger:            logger,
-		RepoAccessTTL:     cfg.RepoAccessCacheTTL,
-		TokenScopes:       tokenScopes,
+		Version:            cfg.Version,
+		Commit:             cfg.Commit,
+		BuildDate:          cfg.BuildDate,
+		Host:               cfg.Host,
+		UploadHost:         cfg.UploadHost,
+		Token:              cfg.Token,
+		TokenSource:        tokenSource,
+		AppID:              cfg.AppID,
+		AppPrivateKey:      cfg.AppPrivateKey,
+		AppInstallationID:  cfg.AppInstallationID,
+		Profiles:           profiles,
+		EnabledToolsets:    cfg.EnabledToolsets,
+		EnabledTools:       github.StripToolNamePrefix(cfg.ToolNamePrefix, cfg.EnabledTools),
+		EnabledFeatures:    cfg.EnabledFeatures,
+		DynamicToolsets:    cfg.DynamicToolsets,
+		ReadOnly:           cfg.ReadOnly,
+		WithoutDestructive: cfg.WithoutDestructive,
+		OnlyIdempotent:     cfg.OnlyIdempotent,
+		EstimateTokens:     cfg.EstimateTokens,
+		DedupResults:       cfg.DedupResults,
+		RootsEnforcement:   rootsEnforcement,
+		EventWebhook: github.EventWebhookConfig{
+			URL:    cfg.EventWebhookURL,
+			Secret: cfg.EventWebhookSecret,
+			Events: eventWebhookEvents,
+		},
+		CursorSigningKey: cfg.CursorSigningKey,
+		RepoFilter: &github.RepoFilter{
+			ExcludeArchived: !cfg.IncludeArchivedRepos,
+			ExcludeForks:    !cfg.IncludeForks,
+		},
+		CommentTemplates:       commentTemplates,
+		Translator:             t,
+		ContentWindowSize:      cfg.ContentWindowSize,
+		ContentWindowOverrides: contentWindowOverrides,
+		LockdownMode:           cfg.LockdownMode,
+		InsidersMode:           cfg.InsidersMode,
+		ExcludeTools:           github.StripToolNamePrefix(cfg.ToolNamePrefix, cfg.ExcludeTools),
+		Logger:                 logger,
+		RepoAccessTTL:          cfg.RepoAccessCacheTTL,
+		ResponseCache:          responseCache,
+		TokenScopes:            tokenScopes,
+		GHESVersion:            cfg.GHESVersion,
+		ToolNamePrefix:         cfg.ToolNamePrefix,
+		SessionCallBudget:      cfg.SessionCallBudget,
+		SessionCostBudget:      cfg.SessionCostBudget,
+		TrackAPIUsage:          true,
+		Tracer:                 tracer,
+		AuditLog:               auditLog,
+		DryRun:                 cfg.DryRun,
+		WriteQuota: github.WriteQuotaConfig{
+			PerHour:            cfg.WriteQuotaPerHour,
+			ApprovalWebhookURL: cfg.WriteApprovalWebhook,
+		},
+		ConfirmDestructive: destructiveToolPrompts,
+
+		LockdownTrustOwnContent: cfg.LockdownTrustOwnContent,
 	})
 	if err != nil {
 		return fmt.Errorf("failed to create MCP server: %w", err)
@@ -380,6 +782,27 @@ func addUserAgentsMiddleware(cfg github.MCPServerConfig, restClient *gogithub.Cl
 	}
 }
 
+// resolveSessionTokenMiddleware resolves the token of the session from the initialize request,
+// failing the request when the client provides none.
+func resolveSessionTokenMiddleware(tokens *transport.SessionTokenSource) mcp.Middleware {
+	return func(next mcp.MethodHandler) mcp.MethodHandler {
+		return func(ctx context.Context, method string, request mcp.Request) (mcp.Result, error) {
+			initializeRequest, ok := request.(*mcp.InitializeRequest)
+			if method != "initialize" || !ok {
+				return next(ctx, method, request)
+			}
+			var meta map[string]any
+			if initializeRequest.Params != nil {
+				meta = initializeRequest.Params.GetMeta()
+			}
+			if err := tokens.Resolve(meta); err != nil {
+				return nil, err
+			}
+			return next(ctx, method, request)
+		}
+	}
+}
+
 // fetchTokenScopesForHost fetches the OAuth scopes for a token from the GitHub API.
 // It constructs the appropriate API host URL based on the configured host.
 func fetchTokenScopesForHost(ctx context.Context, token, host string) ([]string, error) {
@@ -0,0 +1,102 @@
+// Package audit records the write tool calls of the server, so that what an agent changed can be
+// reconstructed later.
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+// Statuses of a recorded tool call.
+const (
+	// StatusSuccess is a call the tool completed.
+	StatusSuccess = "success"
+	// StatusError is a call the tool failed.
+	StatusError = "error"
+	// StatusDenied is a call refused before the tool ran, such as by roots enforcement.
+	StatusDenied = "denied"
+)
+
+// Record is the audit record of a tool call.
+type Record struct {
+	Timestamp time.Time `json:"timestamp"`
+	Tool      string    `json:"tool"`
+	// Arguments are the arguments of the call, with secrets redacted.
+	Arguments json.RawMessage `json:"arguments,omitempty"`
+	Caller    Caller          `json:"caller"`
+	Status    string          `json:"status"`
+	// Error is the error message of calls that did not succeed.
+	Error string `json:"error,omitempty"`
+}
+
+// Caller identifies who made a tool call.
+type Caller struct {
+	// Login is the GitHub user the token of the call belongs to, when it could be resolved.
+	Login         string `json:"login,omitempty"`
+	SessionID     string `json:"session_id,omitempty"`
+	Client        string `json:"client,omitempty"`
+	ClientVersion string `json:"client_version,omitempty"`
+}
+
+// Sink stores audit records.
+type Sink interface {
+	Write(ctx context.Context, record Record) error
+	Close() error
+}
+
+// Config selects the sinks audit records are written to.
+type Config struct {
+	// FilePath is a file records are appended to, one JSON object per line.
+	FilePath string
+	// URL receives each record as a JSON POST.
+	URL string
+}
+
+// Open opens the sinks of cfg. It returns nil when cfg selects none.
+func Open(cfg Config) (Sink, error) {
+	var sinks multiSink
+	if cfg.FilePath != "" {
+		sink, err := NewFileSink(cfg.FilePath)
+		if err != nil {
+			return nil, err
+		}
+		sinks = append(sinks, sink)
+	}
+	if cfg.URL != "" {
+		sink, err := NewHTTPSink(cfg.URL, nil)
+		if err != nil {
+			_ = sinks.Close()
+			return nil, err
+		}
+		sinks = append(sinks, sink)
+	}
+	switch len(sinks) {
+	case 0:
+		return nil, nil
+	case 1:
+		return sinks[0], nil
+	default:
+		return sinks, nil
+	}
+}
+
+// multiSink writes records to several sinks.
+type multiSink []Sink
+
+func (m multiSink) Write(ctx context.Context, record Record) error {
+	var errs []error
+	for _, sink := range m {
+		errs = append(errs, sink.Write(ctx, record))
+	}
+	return errors.Join(errs...)
+}
+
+func (m multiSink) Close() error {
+	var errs []error
+	for _, sink := range m {
+		errs = append(errs, sink.Close())
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,72 @@
+package audit
+
+import (
+	"bytes"
+	"encoding/json"
+	"regexp"
+	"strings"
+
+	"github.com/github/github-mcp-server/pkg/utils"
+)
+
+// secretKeyParts are the parts of argument names whose values are redacted.
+var secretKeyParts = []string{"token", "secret", "password", "passphrase", "private_key", "privatekey", "api_key", "apikey", "authorization", "credential", "cookie"}
+
+// gitHubTokenPattern matches GitHub tokens within any argument, such as one pasted into the body
+// of an issue.
+var gitHubTokenPattern = regexp.MustCompile(`\b(?:gh[pousr]_[A-Za-z0-9]{36,}|github_pat_[A-Za-z0-9_]{22,})\b`)
+
+// RedactArguments returns the arguments of a tool call with the values of arguments named like
+// secrets, and GitHub tokens anywhere, replaced by utils.RedactedValue. Arguments that are not a
+// JSON object are returned as is.
+func RedactArguments(args json.RawMessage) json.RawMessage {
+	if len(args) == 0 {
+		return args
+	}
+	decoder := json.NewDecoder(bytes.NewReader(args))
+	decoder.UseNumber()
+	var tree any
+	if decoder.Decode(&tree) != nil {
+		return args
+	}
+	redacted, err := json.Marshal(redact(tree))
+	if err != nil {
+		return args
+	}
+	return redacted
+}
+
+func redact(tree any) any {
+	switch node := tree.(type) {
+	case map[string]any:
+		for key, value := range node {
+			if isSecretKey(key) && value != nil {
+				node[key] = utils.RedactedValue
+				continue
+			}
+			node[key] = redact(value)
+		}
+	case []any:
+		for i, value := range node {
+			node[i] = redact(value)
+		}
+	case string:
+		return RedactText(node)
+	}
+	return tree
+}
+
+// RedactText replaces the GitHub tokens in s by utils.RedactedValue.
+func RedactText(s string) string {
+	return gitHubTokenPattern.ReplaceAllString(s, utils.RedactedValue)
+}
+
+func isSecretKey(key string) bool {
+	key = strings.ToLower(strings.ReplaceAll(key, "-", "_"))
+	for _, part := range secretKeyParts {
+		if strings.Contains(key, part) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,144 @@
+// Package ghes bundles the capabilities of the supported GitHub Enterprise Server versions, so
+// tools can be gated on the server version without querying the instance. This matters on
+// air-gapped instances, where the meta endpoints are often restricted.
+package ghes
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+//go:embed capabilities.json
+var capabilitiesJSON []byte
+
+// Capability is a REST or GraphQL feature tools rely on that GHES added in some version, or that
+// no GHES version provides. Features added before the oldest supported version are recorded too,
+// so the manifest lists every feature tools are gated on.
+type Capability struct {
+	Name        string `json:"name"`
+	API         string `json:"api"`
+	Description string `json:"description"`
+	// MinVersion is the first GHES version providing the capability. When empty, no GHES
+	// version provides it.
+	MinVersion string   `json:"min_version,omitempty"`
+	Toolsets   []string `json:"toolsets,omitempty"`
+	Tools      []string `json:"tools,omitempty"`
+}
+
+type manifest struct {
+	Versions     []string     `json:"versions"`
+	Capabilities []Capability `json:"capabilities"`
+}
+
+var bundled = func() manifest {
+	var m manifest
+	if err := json.Unmarshal(capabilitiesJSON, &m); err != nil {
+		panic(fmt.Sprintf("ghes: invalid bundled capabilities: %v", err))
+	}
+	return m
+}()
+
+// SupportedVersions returns the GHES versions the bundled capabilities were recorded for.
+func SupportedVersions() []string {
+	return append([]string(nil), bundled.Versions...)
+}
+
+// BundledCapabilities returns every bundled capability, including those all supported versions provide.
+func BundledCapabilities() []Capability {
+	return append([]Capability(nil), bundled.Capabilities...)
+}
+
+// Capabilities describes what a GHES version does not provide.
+type Capabilities struct {
+	// Version is the major.minor GHES version.
+	Version string
+	// Newer is true when Version is newer than every bundled version. The capabilities of the
+	// newest bundled version are assumed, so tools added since may be hidden.
+	Newer bool
+
+	unavailable []Capability
+	toolsets    map[string]bool
+	tools       map[string]bool
+}
+
+// Load returns the capabilities of a GHES version, given as major.minor or major.minor.patch.
+// Versions older than every bundled version are rejected, as they are no longer supported.
+func Load(version string) (*Capabilities, error) {
+	v, err := parseVersion(version)
+	if err != nil {
+		return nil, err
+	}
+
+	oldest, _ := parseVersion(bundled.Versions[0])
+	newest, _ := parseVersion(bundled.Versions[len(bundled.Versions)-1])
+	if v.less(oldest) {
+		return nil, fmt.Errorf("GHES version %s is not supported, the oldest supported version is %s", v, oldest)
+	}
+
+	caps := &Capabilities{
+		Version:  v.String(),
+		Newer:    newest.less(v),
+		toolsets: make(map[string]bool),
+		tools:    make(map[string]bool),
+	}
+	for _, c := range bundled.Capabilities {
+		if c.MinVersion != "" {
+			minVersion, err := parseVersion(c.MinVersion)
+			if err != nil {
+				return nil, fmt.Errorf("capability %s: %w", c.Name, err)
+			}
+			if !v.less(minVersion) {
+				continue
+			}
+		}
+		caps.unavailable = append(caps.unavailable, c)
+		for _, toolset := range c.Toolsets {
+			caps.toolsets[toolset] = true
+		}
+		for _, tool := range c.Tools {
+			caps.tools[tool] = true
+		}
+	}
+	return caps, nil
+}
+
+// Unavailable returns the capabilities the version does not provide.
+func (c *Capabilities) Unavailable() []Capability {
+	return append([]Capability(nil), c.unavailable...)
+}
+
+// ToolAvailable reports whether a tool in the given toolset can be used on the version.
+func (c *Capabilities) ToolAvailable(toolset, tool string) bool {
+	return !c.toolsets[toolset] && !c.tools[tool]
+}
+
+type version struct {
+	major, minor int
+}
+
+func parseVersion(s string) (version, error) {
+	parts := strings.Split(strings.TrimPrefix(strings.TrimSpace(s), "v"), ".")
+	if len(parts) < 2 || len(parts) > 3 {
+		return version{}, fmt.Errorf("invalid GHES version %q, expected major.minor such as 3.16", s)
+	}
+	var nums [3]int
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 0 {
+			return version{}, fmt.Errorf("invalid GHES version %q, expected major.minor such as 3.16", s)
+		}
+		nums[i] = n
+	}
+	return version{major: nums[0], minor: nums[1]}, nil
+}
+
+func (v version) less(o version) bool {
+	return v.major < o.major || (v.major == o.major && v.minor < o.minor)
+}
+
+func (v version) String() string {
+	return fmt.Sprintf("%d.%d", v.major, v.minor)
+}
@@ -0,0 +1,125 @@
+package ghes
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoad(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name            string
+		version         string
+		expectedErr     string
+		expectedVersion string
+		newer           bool
+		available       map[string]bool
+	}{
+		{
+			name:            "oldest supported version",
+			version:         "3.14",
+			expectedVersion: "3.14",
+			available: map[string]bool{
+				"get_file_contents":       true,
+				"assign_copilot_to_issue": false,
+				"sub_issue_write":         false,
+				"list_issue_types":        false,
+				"issue_dependency_write":  false,
+				"merge_queue_write":       true,
+			},
+		},
+		{
+			name:            "patch release with min version reached",
+			version:         "3.17.4",
+			expectedVersion: "3.17",
+			available: map[string]bool{
+				"assign_copilot_to_issue": false,
+				"sub_issue_write":         true,
+				"list_issue_types":        true,
+				"issue_dependency_write":  false,
+			},
+		},
+		{
+			name:            "newest bundled version",
+			version:         "3.19",
+			expectedVersion: "3.19",
+			available: map[string]bool{
+				"issue_dependency_write":         true,
+				"enable_pull_request_auto_merge": true,
+			},
+		},
+		{
+			name:            "newer than bundled",
+			version:         "4.0",
+			expectedVersion: "4.0",
+			newer:           true,
+			available: map[string]bool{
+				"request_copilot_review": false,
+				"sub_issue_write":        true,
+			},
+		},
+		{
+			name:        "too old",
+			version:     "3.9",
+			expectedErr: "GHES version 3.9 is not supported, the oldest supported version is 3.14",
+		},
+		{
+			name:        "malformed",
+			version:     "latest",
+			expectedErr: `invalid GHES version "latest"`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			caps, err := Load(tc.version)
+			if tc.expectedErr != "" {
+				require.ErrorContains(t, err, tc.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedVersion, caps.Version)
+			assert.Equal(t, tc.newer, caps.Newer)
+			for tool, available := range tc.available {
+				assert.Equal(t, available, caps.ToolAvailable("", tool), tool)
+			}
+		})
+	}
+}
+
+func TestToolAvailableByToolset(t *testing.T) {
+	t.Parallel()
+
+	caps, err := Load("3.16")
+	require.NoError(t, err)
+	assert.False(t, caps.ToolAvailable("copilot", "some_future_copilot_tool"))
+	assert.True(t, caps.ToolAvailable("repos", "some_future_repos_tool"))
+}
+
+func TestBundledCapabilities(t *testing.T) {
+	t.Parallel()
+
+	require.NotEmpty(t, SupportedVersions())
+	for i, v := range SupportedVersions() {
+		parsed, err := parseVersion(v)
+		require.NoError(t, err)
+		if i > 0 {
+			previous, _ := parseVersion(SupportedVersions()[i-1])
+			assert.True(t, previous.less(parsed), "versions must be in ascending order")
+		}
+	}
+	for _, c := range bundled.Capabilities {
+		assert.NotEmpty(t, c.Name)
+		assert.Contains(t, []string{"rest", "graphql"}, c.API, c.Name)
+		assert.True(t, len(c.Toolsets) > 0 || len(c.Tools) > 0, "%s gates no tools", c.Name)
+		if c.MinVersion != "" {
+			_, err := parseVersion(c.MinVersion)
+			assert.NoError(t, err, c.Name)
+		}
+	}
+}
@@ -28,25 +28,26 @@ const (
 
 // Method constants for consolidated actions tools
 const (
-	actionsMethodListWorkflows            = "list_workflows"
-	actionsMethodListWorkflowRuns         = "list_workflow_runs"
-	actionsMethodListWorkflowJobs         = "list_workflow_jobs"
-	actionsMethodListWorkflowArtifacts    = "list_workflow_run_artifacts"
-	actionsMethodGetWorkflow              = "get_workflow"
-	actionsMethodGetWorkflowRun           = "get_workflow_run"
-	actionsMethodGetWorkflowJob           = "get_workflow_job"
-	actionsMethodGetWorkflowRunUsage      = "get_workflow_run_usage"
-	actionsMethodGetWorkflowRunLogsURL    = "get_workflow_run_logs_url"
-	actionsMethodDownloadWorkflowArtifact = "download_workflow_run_artifact"
-	actionsMethodRunWorkflow              = "run_workflow"
-	actionsMethodRerunWorkflowRun         = "rerun_workflow_run"
-	actionsMethodRerunFailedJobs          = "rerun_failed_jobs"
-	actionsMethodCancelWorkflowRun        = "cancel_workflow_run"
-	actionsMethodDeleteWorkflowRunLogs    = "delete_workflow_run_logs"
+	actionsMethodListWorkflows             = "list_workflows"
+	actionsMethodListWorkflowRuns          = "list_workflow_runs"
+	actionsMethodListWorkflowJobs          = "list_workflow_jobs"
+	actionsMethodListWorkflowArtifacts     = "list_workflow_run_artifacts"
+	actionsMethodGetWorkflow               = "get_workflow"
+	actionsMethodGetWorkflowRun            = "get_workflow_run"
+	actionsMethodGetWorkflowJob            = "get_workflow_job"
+	actionsMethodGetWorkflowRunUsage       = "get_workflow_run_usage"
+	actionsMethodGetWorkflowRunLogsURL     = "get_workflow_run_logs_url"
+	actionsMethodGetWorkflowDispatchSchema = "get_workflow_dispatch_schema"
+	actionsMethodDownloadWorkflowArtifact  = "download_workflow_run_artifact"
+	actionsMethodRunWorkflow               = "run_workflow"
+	actionsMethodRerunWorkflowRun          = "rerun_workflow_run"
+	actionsMethodRerunFailedJobs           = "rerun_failed_jobs"
+	actionsMethodCancelWorkflowRun         = "cancel_workflow_run"
+	actionsMethodDeleteWorkflowRunLogs     = "delete_workflow_run_logs"
 )
 
 // handleFailedJobLogs gets logs for all failed jobs in a workflow run
-func handleFailedJobLogs(ctx context.Context, client *github.Client, owner, repo string, runID int64, returnContent bool, tailLines int, contentWindowSize int) (*mcp.CallToolResult, any, error) {
+func handleFailedJobLogs(ctx context.Context, client *github.Client, owner, repo string, runID int64, returnContent, debugOnly bool, tailLines int, contentWindowSize int) (*mcp.CallToolResult, any, error) {
 	// First, get all jobs for the workflow run
 	jobs, resp, err := client.Actions.ListWorkflowJobs(ctx, owner, repo, runID, &github.ListWorkflowJobsOptions{
 		Filter: "latest",
@@ -78,7 +79,7 @@ func handleFailedJobLogs(ctx context.Context, client *github.Client, owner, repo
 	// Collect logs for all failed jobs
 	var logResults []map[string]any
 	for _, job := range failedJobs {
-		jobResult, resp, err := getJobLogData(ctx, client, owner, repo, job.GetID(), job.GetName(), returnContent, tailLines, contentWindowSize)
+		jobResult, resp, err := getJobLogData(ctx, client, owner, repo, job.GetID(), job.GetName(), returnContent, debugOnly, tailLines, contentWindowSize)
 		if err != nil {
 			// Continue with other jobs even if one fails
 			jobResult = map[string]any{
@@ -111,8 +112,8 @@ func handleFailedJobLogs(ctx context.Context, client *github.Client, owner, repo
 }
 
 // handleSingleJobLogs gets logs for a single job
-func handleSingleJobLogs(ctx context.Context, client *github.Client, owner, repo string, jobID int64, returnContent bool, tailLines int, contentWindowSize int) (*mcp.CallToolResult, any, error) {
-	jobResult, resp, err := getJobLogData(ctx, client, owner, repo, jobID, "", returnContent, tailLines, contentWindowSize)
+func handleSingleJobLogs(ctx context.Context, client *github.Client, owner, repo string, jobID int64, returnContent, debugOnly bool, tailLines int, contentWindowSize int) (*mcp.CallToolResult, any, error) {
+	jobResult, resp, err := getJobLogData(ctx, client, owner, repo, jobID, "", returnContent, debugOnly, tailLines, contentWindowSize)
 	if err != nil {
 		return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to get job logs", resp, err), nil, nil
 	}
@@ -126,7 +127,7 @@ func handleSingleJobLogs(ctx context.Context, client *github.Client, owner, repo
 }
 
 // getJobLogData retrieves log data for a single job, either as URL or content
-func getJobLogData(ctx context.Context, client *github.Client, owner, repo string, jobID int64, jobName string, returnContent bool, tailLines int, contentWindowSize int) (map[string]any, *github.Response, error) {
+func getJobLogData(ctx context.Context, client *github.Client, owner, repo string, jobID int64, jobName string, returnContent, debugOnly bool, tailLines int, contentWindowSize int) (map[string]any, *github.Response, error) {
 	// Get the download URL for the job logs
 	url, resp, err := client.Actions.GetWorkflowJobLogs(ctx, owner, repo, jobID, 1)
 	if err != nil {
@@ -141,7 +142,29 @@ func getJobLogData(ctx context.Context, client *github.Client, owner, repo strin
 		result["job_name"] = jobName
 	}
 
-	if returnContent {
+	if debugOnly {
+		// Scan as much of the log as the content window allows, since debug lines are
+		// spread throughout the job rather than clustered at the end.
+		content, originalLength, httpResp, err := downloadLogContent(ctx, url.String(), contentWindowSize, contentWindowSize) //nolint:bodyclose // Response body is closed in downloadLogContent, but we need to return httpResp
+		if err != nil {
+			ghRes := &github.Response{
+				Response: httpResp,
+			}
+			return nil, ghRes, fmt.Errorf("failed to download log content for job %d: %w", jobID, err)
+		}
+		debugLines := filterDebugLogLines(content)
+		result["debug_line_count"] = len(debugLines)
+		if len(debugLines) > tailLines {
+			debugLines = debugLines[len(debugLines)-tailLines:]
+		}
+		result["logs_content"] = strings.Join(debugLines, "\n")
+		result["original_length"] = originalLength
+		if len(debugLines) == 0 {
+			result["message"] = "No debug log lines found. Re-run the workflow with enable_debug_logging=true to produce them."
+		} else {
+			result["message"] = "Job debug log excerpts retrieved successfully"
+		}
+	} else if returnContent {
 		// Download and return the actual log content
 		content, originalLength, httpResp, err := downloadLogContent(ctx, url.String(), tailLines, contentWindowSize) //nolint:bodyclose // Response body is closed in downloadLogContent, but we need to return httpResp
 		if err != nil {
@@ -164,6 +187,17 @@ func getJobLogData(ctx context.Context, client *github.Client, owner, repo strin
 	return result, resp, nil
 }
 
+// filterDebugLogLines returns the lines of a job log emitted by step debug logging.
+func filterDebugLogLines(content string) []string {
+	var debugLines []string
+	for _, line := range strings.Split(content, "\n") {
+		if strings.Contains(line, "##[debug]") {
+			debugLines = append(debugLines, line)
+		}
+	}
+	return debugLines
+}
+
 func downloadLogContent(ctx context.Context, logURL string, tailLines int, maxLines int) (string, int, *http.Response, error) {
 	prof := profiler.New(nil, profiler.IsProfilingEnabled())
 	finish := prof.Start(ctx, "log_buffer_processing")
@@ -399,6 +433,7 @@ func ActionsGet(t translations.TranslationHelperFunc) inventory.ServerTool {
 			Name: "actions_get",
 			Description: t("TOOL_ACTIONS_GET_DESCRIPTION", `Get details about specific GitHub Actions resources.
 Use this tool to get details about individual workflows, workflow runs, jobs, and artifacts by their unique IDs.
+Use 'get_workflow_dispatch_schema' before 'run_workflow' to get a JSON schema of the workflow's dispatch inputs and validate them.
 `),
 			Annotations: &mcp.ToolAnnotations{
 				Title:        t("TOOL_ACTIONS_GET_USER_TITLE", "Get details of GitHub Actions resources (workflows, workflow runs, jobs, and artifacts)"),
@@ -417,6 +452,7 @@ Use this tool to get details about individual workflows, workflow runs, jobs, an
 							actionsMethodDownloadWorkflowArtifact,
 							actionsMethodGetWorkflowRunUsage,
 							actionsMethodGetWorkflowRunLogsURL,
+							actionsMethodGetWorkflowDispatchSchema,
 						},
 					},
 					"owner": {
@@ -430,7 +466,7 @@ Use this tool to get details about individual workflows, workflow runs, jobs, an
 					"resource_id": {
 						Type: "string",
 						Description: `The unique identifier of the resource. This will vary based on the "method" provided, so ensure you provide the correct ID:
-- Provide a workflow ID or workflow file name (e.g. ci.yaml) for 'get_workflow' method.
+- Provide a workflow ID or workflow file name (e.g. ci.yaml) for 'get_workflow' and 'get_workflow_dispatch_schema' methods.
 - Provide a workflow run ID for 'get_workflow_run', 'get_workflow_run_usage', and 'get_workflow_run_logs_url' methods.
 - Provide an artifact ID for 'download_workflow_run_artifact' method.
 - Provide a job ID for 'get_workflow_job' method.
@@ -468,7 +504,7 @@ Use this tool to get details about individual workflows, workflow runs, jobs, an
 			var resourceIDInt int64
 			var parseErr error
 			switch method {
-			case actionsMethodGetWorkflow:
+			case actionsMethodGetWorkflow, actionsMethodGetWorkflowDispatchSchema:
 				// Do nothing, we accept both a string workflow ID or filename
 			default:
 				// For other methods, resource ID must be an integer
@@ -491,6 +527,8 @@ Use this tool to get details about individual workflows, workflow runs, jobs, an
 				return getWorkflowRunUsage(ctx, client, owner, repo, resourceIDInt)
 			case actionsMethodGetWorkflowRunLogsURL:
 				return getWorkflowRunLogsURL(ctx, client, owner, repo, resourceIDInt)
+			case actionsMethodGetWorkflowDispatchSchema:
+				return getWorkflowDispatchSchema(ctx, client, owner, repo, resourceID)
 			default:
 				return utils.NewToolResultError(fmt.Sprintf("unknown method: %s", method)), nil, nil
 			}
@@ -549,6 +587,10 @@ func ActionsRunTrigger(t translations.TranslationHelperFunc) inventory.ServerToo
 						Type:        "number",
 						Description: "The ID of the workflow run. Required for all methods except 'run_workflow'.",
 					},
+					"enable_debug_logging": {
+						Type:        "boolean",
+						Description: "Enable runner and step debug logging for the re-run. Only used for 'rerun_workflow_run' and 'rerun_failed_jobs' methods. Use get_job_logs with debug_only=true to read the resulting debug lines.",
+					},
 				},
 				Required: []string{"method", "owner", "repo"},
 			},
@@ -572,6 +614,10 @@ func ActionsRunTrigger(t translations.TranslationHelperFunc) inventory.ServerToo
 			workflowID, _ := OptionalParam[string](args, "workflow_id")
 			ref, _ := OptionalParam[string](args, "ref")
 			runID, _ := OptionalIntParam(args, "run_id")
+			enableDebugLogging, err := OptionalParam[bool](args, "enable_debug_logging")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
 
 			// Get optional inputs parameter
 			var inputs map[string]any
@@ -602,9 +648,9 @@ func ActionsRunTrigger(t translations.TranslationHelperFunc) inventory.ServerToo
 			case actionsMethodRunWorkflow:
 				return runWorkflow(ctx, client, owner, repo, workflowID, ref, inputs)
 			case actionsMethodRerunWorkflowRun:
-				return rerunWorkflowRun(ctx, client, owner, repo, int64(runID))
+				return rerunWorkflowRun(ctx, client, owner, repo, int64(runID), enableDebugLogging)
 			case actionsMethodRerunFailedJobs:
-				return rerunFailedJobs(ctx, client, owner, repo, int64(runID))
+				return rerunFailedJobs(ctx, client, owner, repo, int64(runID), enableDebugLogging)
 			case actionsMethodCancelWorkflowRun:
 				return cancelWorkflowRun(ctx, client, owner, repo, int64(runID))
 			case actionsMethodDeleteWorkflowRunLogs:
@@ -626,6 +672,7 @@ func ActionsGetJobLogs(t translations.TranslationHelperFunc) inventory.ServerToo
 			Description: t("TOOL_GET_JOB_LOGS_CONSOLIDATED_DESCRIPTION", `Get logs for GitHub Actions workflow jobs.
 Use this tool to retrieve logs for a specific job or all failed jobs in a workflow run.
 For single job logs, provide job_id. For all failed jobs in a run, provide run_id with failed_only=true.
+Use debug_only=true to extract step debug log lines from a run that was re-run with debug logging enabled.
 `),
 			Annotations: &mcp.ToolAnnotations{
 				Title:        t("TOOL_GET_JOB_LOGS_CONSOLIDATED_USER_TITLE", "Get GitHub Actions workflow job logs"),
@@ -658,6 +705,10 @@ For single job logs, provide job_id. For all failed jobs in a run, provide run_i
 						Type:        "boolean",
 						Description: "Returns actual log content instead of URLs",
 					},
+					"debug_only": {
+						Type:        "boolean",
+						Description: "When true, returns only the step debug log lines (##[debug]) instead of the full log. Implies return_content.",
+					},
 					"tail_lines": {
 						Type:        "number",
 						Description: "Number of lines to return from the end of the log",
@@ -698,6 +749,11 @@ For single job logs, provide job_id. For all failed jobs in a run, provide run_i
 				return utils.NewToolResultError(err.Error()), nil, nil
 			}
 
+			debugOnly, err := OptionalParam[bool](args, "debug_only")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+
 			tailLines, err := OptionalIntParam(args, "tail_lines")
 			if err != nil {
 				return utils.NewToolResultError(err.Error()), nil, nil
@@ -722,10 +778,10 @@ For single job logs, provide job_id. For all failed jobs in a run, provide run_i
 
 			if failedOnly && runID > 0 {
 				// Handle failed-only mode: get logs for all failed jobs in the workflow run
-				return handleFailedJobLogs(ctx, client, owner, repo, int64(runID), returnContent, tailLines, deps.GetContentWindowSize())
+				return handleFailedJobLogs(ctx, client, owner, repo, int64(runID), returnContent, debugOnly, tailLines, ContentWindowSize(ctx, deps))
 			} else if jobID > 0 {
 				// Handle single job mode
-				return handleSingleJobLogs(ctx, client, owner, repo, int64(jobID), returnContent, tailLines, deps.GetContentWindowSize())
+				return handleSingleJobLogs(ctx, client, owner, repo, int64(jobID), returnContent, debugOnly, tailLines, ContentWindowSize(ctx, deps))
 			}
 
 			return utils.NewToolResultError("Either job_id must be provided for single job logs, or run_id with failed_only=true for failed job logs"), nil, nil
@@ -1020,18 +1076,25 @@ func runWorkflow(ctx context.Context, client *github.Client, owner, repo, workfl
 	return utils.NewToolResultText(string(r)), nil, nil
 }
 
-func rerunWorkflowRun(ctx context.Context, client *github.Client, owner, repo string, runID int64) (*mcp.CallToolResult, any, error) {
-	resp, err := client.Actions.RerunWorkflowByID(ctx, owner, repo, runID)
+func rerunWorkflowRun(ctx context.Context, client *github.Client, owner, repo string, runID int64, enableDebugLogging bool) (*mcp.CallToolResult, any, error) {
+	var resp *github.Response
+	var err error
+	if enableDebugLogging {
+		resp, err = rerunWithDebugLogging(ctx, client, fmt.Sprintf("repos/%v/%v/actions/runs/%v/rerun", owner, repo, runID))
+	} else {
+		resp, err = client.Actions.RerunWorkflowByID(ctx, owner, repo, runID)
+	}
 	if err != nil {
 		return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to rerun workflow run", resp, err), nil, nil
 	}
 	defer func() { _ = resp.Body.Close() }()
 
 	result := map[string]any{
-		"message":     "Workflow run has been queued for re-run",
-		"run_id":      runID,
-		"status":      resp.Status,
-		"status_code": resp.StatusCode,
+		"message":              "Workflow run has been queued for re-run",
+		"run_id":               runID,
+		"enable_debug_logging": enableDebugLogging,
+		"status":               resp.Status,
+		"status_code":          resp.StatusCode,
 	}
 
 	r, err := json.Marshal(result)
@@ -1042,18 +1105,25 @@ func rerunWorkflowRun(ctx context.Context, client *github.Client, owner, repo st
 	return utils.NewToolResultText(string(r)), nil, nil
 }
 
-func rerunFailedJobs(ctx context.Context, client *github.Client, owner, repo string, runID int64) (*mcp.CallToolResult, any, error) {
-	resp, err := client.Actions.RerunFailedJobsByID(ctx, owner, repo, runID)
+func rerunFailedJobs(ctx context.Context, client *github.Client, owner, repo string, runID int64, enableDebugLogging bool) (*mcp.CallToolResult, any, error) {
+	var resp *github.Response
+	var err error
+	if enableDebugLogging {
+		resp, err = rerunWithDebugLogging(ctx, client, fmt.Sprintf("repos/%v/%v/actions/runs/%v/rerun-failed-jobs", owner, repo, runID))
+	} else {
+		resp, err = client.Actions.RerunFailedJobsByID(ctx, owner, repo, runID)
+	}
 	if err != nil {
 		return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to rerun failed jobs", resp, err), nil, nil
 	}
 	defer func() { _ = resp.Body.Close() }()
 
 	result := map[string]any{
-		"message":     "Failed jobs have been queued for re-run",
-		"run_id":      runID,
-		"status":      resp.Status,
-		"status_code": resp.StatusCode,
+		"message":              "Failed jobs have been queued for re-run",
+		"run_id":               runID,
+		"enable_debug_logging": enableDebugLogging,
+		"status":               resp.Status,
+		"status_code":          resp.StatusCode,
 	}
 
 	r, err := json.Marshal(result)
@@ -1064,6 +1134,16 @@ func rerunFailedJobs(ctx context.Context, client *github.Client, owner, repo str
 	return utils.NewToolResultText(string(r)), nil, nil
 }
 
+// rerunWithDebugLogging posts a re-run request with debug logging enabled. go-github's re-run
+// methods don't accept a request body, so the request is built directly.
+func rerunWithDebugLogging(ctx context.Context, client *github.Client, urlPath string) (*github.Response, error) {
+	req, err := client.NewRequest(http.MethodPost, urlPath, map[string]bool{"enable_debug_logging": true})
+	if err != nil {
+		return nil, err
+	}
+	return client.Do(ctx, req, nil)
+}
+
 func cancelWorkflowRun(ctx context.Context, client *github.Client, owner, repo string, runID int64) (*mcp.CallToolResult, any, error) {
 	resp, err := client.Actions.CancelWorkflowRunByID(ctx, owner, repo, runID)
 	if err != nil {
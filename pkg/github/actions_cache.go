@@ -0,0 +1,255 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/inventory"
+	"github.com/github/github-mcp-server/pkg/scopes"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/github/github-mcp-server/pkg/utils"
+	"github.com/google/go-github/v82/github"
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// ListActionsCaches creates a tool to list GitHub Actions caches for a repository.
+func ListActionsCaches(t translations.TranslationHelperFunc) inventory.ServerTool {
+	schema := &jsonschema.Schema{
+		Type: "object",
+		Properties: map[string]*jsonschema.Schema{
+			"owner": {
+				Type:        "string",
+				Description: DescriptionRepositoryOwner,
+			},
+			"repo": {
+				Type:        "string",
+				Description: DescriptionRepositoryName,
+			},
+			"key": {
+				Type:        "string",
+				Description: "Only list caches whose key starts with this prefix",
+			},
+			"ref": {
+				Type:        "string",
+				Description: "Only list caches for this git reference. A branch can be given as refs/heads/<branch> or just <branch>; a pull request as refs/pull/<number>/merge",
+			},
+			"sort": {
+				Type:        "string",
+				Description: "Property to sort caches by",
+				Enum:        []any{"created_at", "last_accessed_at", "size_in_bytes"},
+			},
+			"direction": {
+				Type:        "string",
+				Description: "Sort direction",
+				Enum:        []any{"asc", "desc"},
+			},
+		},
+		Required: []string{"owner", "repo"},
+	}
+	WithPagination(schema)
+
+	return NewTool(
+		ToolsetMetadataActions,
+		mcp.Tool{
+			Name:        "list_actions_caches",
+			Description: t("TOOL_LIST_ACTIONS_CACHES_DESCRIPTION", "List GitHub Actions caches in a repository, optionally filtered by key prefix or branch, with their sizes and last access times. Includes the repository's total cache usage."),
+			Annotations: &mcp.ToolAnnotations{
+				Title:        t("TOOL_LIST_ACTIONS_CACHES_USER_TITLE", "List GitHub Actions caches"),
+				ReadOnlyHint: true,
+			},
+			InputSchema: schema,
+		},
+		[]scopes.Scope{scopes.Repo},
+		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+			owner, err := RequiredParam[string](args, "owner")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			repo, err := RequiredParam[string](args, "repo")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			key, err := OptionalParam[string](args, "key")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			ref, err := OptionalParam[string](args, "ref")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			sort, err := OptionalParam[string](args, "sort")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			direction, err := OptionalParam[string](args, "direction")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			pagination, err := OptionalPaginationParams(args)
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+
+			client, err := deps.GetClient(ctx)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			opts := &github.ActionsCacheListOptions{
+				ListOptions: github.ListOptions{
+					Page:    pagination.Page,
+					PerPage: pagination.PerPage,
+				},
+			}
+			if key != "" {
+				opts.Key = github.Ptr(key)
+			}
+			if ref != "" {
+				opts.Ref = github.Ptr(ref)
+			}
+			if sort != "" {
+				opts.Sort = github.Ptr(sort)
+			}
+			if direction != "" {
+				opts.Direction = github.Ptr(direction)
+			}
+
+			caches, resp, err := client.Actions.ListCaches(ctx, owner, repo, opts)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to list actions caches", resp, err), nil, nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			usage, usageResp, err := client.Actions.GetCacheUsageForRepo(ctx, owner, repo)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to get actions cache usage", usageResp, err), nil, nil
+			}
+			defer func() { _ = usageResp.Body.Close() }()
+
+			var pageSize int64
+			for _, cache := range caches.ActionsCaches {
+				pageSize += cache.GetSizeInBytes()
+			}
+
+			result := map[string]any{
+				"caches":                      caches.ActionsCaches,
+				"total_count":                 caches.TotalCount,
+				"page_size_in_bytes":          pageSize,
+				"active_caches_count":         usage.ActiveCachesCount,
+				"active_caches_size_in_bytes": usage.ActiveCachesSizeInBytes,
+			}
+
+			r, err := json.Marshal(result)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return utils.NewToolResultText(string(r)), nil, nil
+		},
+	)
+}
+
+// DeleteActionsCache creates a tool to delete GitHub Actions caches by ID or key.
+func DeleteActionsCache(t translations.TranslationHelperFunc) inventory.ServerTool {
+	return NewTool(
+		ToolsetMetadataActions,
+		mcp.Tool{
+			Name:        "delete_actions_cache",
+			Description: t("TOOL_DELETE_ACTIONS_CACHE_DESCRIPTION", "Delete GitHub Actions caches in a repository, either a single cache by ID or every cache matching a key (optionally limited to one git reference). Use list_actions_caches to find caches to delete."),
+			Annotations: &mcp.ToolAnnotations{
+				Title:           t("TOOL_DELETE_ACTIONS_CACHE_USER_TITLE", "Delete GitHub Actions caches"),
+				ReadOnlyHint:    false,
+				DestructiveHint: jsonschema.Ptr(true),
+			},
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"owner": {
+						Type:        "string",
+						Description: DescriptionRepositoryOwner,
+					},
+					"repo": {
+						Type:        "string",
+						Description: DescriptionRepositoryName,
+					},
+					"cache_id": {
+						Type:        "number",
+						Description: "The ID of the cache to delete. Provide either cache_id or key.",
+					},
+					"key": {
+						Type:        "string",
+						Description: "Delete all caches with this exact key. Provide either cache_id or key.",
+					},
+					"ref": {
+						Type:        "string",
+						Description: "Only delete caches with the given key for this git reference. Only used with key.",
+					},
+				},
+				Required: []string{"owner", "repo"},
+			},
+		},
+		[]scopes.Scope{scopes.Repo},
+		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+			owner, err := RequiredParam[string](args, "owner")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			repo, err := RequiredParam[string](args, "repo")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			cacheID, err := OptionalIntParam(args, "cache_id")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			key, err := OptionalParam[string](args, "key")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			ref, err := OptionalParam[string](args, "ref")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+
+			if (cacheID == 0) == (key == "") {
+				return utils.NewToolResultError("provide exactly one of cache_id or key"), nil, nil
+			}
+			if ref != "" && key == "" {
+				return utils.NewToolResultError("ref can only be used with key"), nil, nil
+			}
+
+			client, err := deps.GetClient(ctx)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			if cacheID != 0 {
+				resp, err := client.Actions.DeleteCachesByID(ctx, owner, repo, int64(cacheID))
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to delete actions cache", resp, err), nil, nil
+				}
+				defer func() { _ = resp.Body.Close() }()
+
+				return utils.NewToolResultText(fmt.Sprintf("Deleted actions cache %d", cacheID)), nil, nil
+			}
+
+			var refPtr *string
+			if ref != "" {
+				refPtr = github.Ptr(ref)
+			}
+			resp, err := client.Actions.DeleteCachesByKey(ctx, owner, repo, key, refPtr)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to delete actions caches", resp, err), nil, nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if ref != "" {
+				return utils.NewToolResultText(fmt.Sprintf("Deleted actions caches with key %q on %s", key, ref)), nil, nil
+			}
+			return utils.NewToolResultText(fmt.Sprintf("Deleted actions caches with key %q", key)), nil, nil
+		},
+	)
+}
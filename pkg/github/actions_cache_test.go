@@ -0,0 +1,227 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v82/github"
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ListActionsCaches(t *testing.T) {
+	// Verify tool definition once
+	serverTool := ListActionsCaches(translations.NullTranslationHelper)
+	tool := serverTool.Tool
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_actions_caches", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.True(t, tool.Annotations.ReadOnlyHint)
+	schema := tool.InputSchema.(*jsonschema.Schema)
+	assert.Contains(t, schema.Properties, "key")
+	assert.Contains(t, schema.Properties, "ref")
+	assert.Contains(t, schema.Properties, "sort")
+	assert.ElementsMatch(t, schema.Required, []string{"owner", "repo"})
+
+	mockCaches := &github.ActionsCacheList{
+		TotalCount: 2,
+		ActionsCaches: []*github.ActionsCache{
+			{ID: github.Ptr(int64(1)), Key: github.Ptr("go-mod-abc"), Ref: github.Ptr("refs/heads/main"), SizeInBytes: github.Ptr(int64(1024))},
+			{ID: github.Ptr(int64(2)), Key: github.Ptr("go-mod-def"), Ref: github.Ptr("refs/heads/main"), SizeInBytes: github.Ptr(int64(2048))},
+		},
+	}
+	mockUsage := &github.ActionsCacheUsage{
+		FullName:                "owner/repo",
+		ActiveCachesSizeInBytes: 10240,
+		ActiveCachesCount:       5,
+	}
+
+	tests := []struct {
+		name           string
+		handlers       map[string]http.HandlerFunc
+		requestArgs    map[string]any
+		expectToolErr  bool
+		expectedErrMsg string
+	}{
+		{
+			name: "list caches filtered by key and ref",
+			handlers: map[string]http.HandlerFunc{
+				GetReposActionsCachesByOwnerByRepo: expectQueryParams(t, map[string]string{
+					"key":      "go-mod-",
+					"ref":      "main",
+					"sort":     "size_in_bytes",
+					"page":     "1",
+					"per_page": "30",
+				}).andThen(mockResponse(t, http.StatusOK, mockCaches)),
+				GetReposActionsCacheUsageByOwnerByRepo: mockResponse(t, http.StatusOK, mockUsage),
+			},
+			requestArgs: map[string]any{
+				"owner": "owner",
+				"repo":  "repo",
+				"key":   "go-mod-",
+				"ref":   "main",
+				"sort":  "size_in_bytes",
+			},
+		},
+		{
+			name: "list caches fails",
+			handlers: map[string]http.HandlerFunc{
+				GetReposActionsCachesByOwnerByRepo: mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+			},
+			requestArgs: map[string]any{
+				"owner": "owner",
+				"repo":  "repo",
+			},
+			expectToolErr:  true,
+			expectedErrMsg: "failed to list actions caches",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			deps := BaseDeps{
+				Client: github.NewClient(MockHTTPClientWithHandlers(tc.handlers)),
+			}
+			handler := serverTool.Handler(deps)
+			request := createMCPRequest(tc.requestArgs)
+
+			result, err := handler(ContextWithDeps(context.Background(), deps), &request)
+			require.NoError(t, err)
+
+			if tc.expectToolErr {
+				require.True(t, result.IsError)
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			textContent := getTextResult(t, result)
+			var response struct {
+				Caches                  []*github.ActionsCache `json:"caches"`
+				TotalCount              int                    `json:"total_count"`
+				PageSizeInBytes         int64                  `json:"page_size_in_bytes"`
+				ActiveCachesCount       int                    `json:"active_caches_count"`
+				ActiveCachesSizeInBytes int64                  `json:"active_caches_size_in_bytes"`
+			}
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &response))
+			assert.Len(t, response.Caches, 2)
+			assert.Equal(t, 2, response.TotalCount)
+			assert.Equal(t, int64(3072), response.PageSizeInBytes)
+			assert.Equal(t, 5, response.ActiveCachesCount)
+			assert.Equal(t, int64(10240), response.ActiveCachesSizeInBytes)
+		})
+	}
+}
+
+func Test_DeleteActionsCache(t *testing.T) {
+	// Verify tool definition once
+	serverTool := DeleteActionsCache(translations.NullTranslationHelper)
+	tool := serverTool.Tool
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "delete_actions_cache", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.False(t, tool.Annotations.ReadOnlyHint)
+	assert.True(t, *tool.Annotations.DestructiveHint)
+	schema := tool.InputSchema.(*jsonschema.Schema)
+	assert.Contains(t, schema.Properties, "cache_id")
+	assert.Contains(t, schema.Properties, "key")
+	assert.ElementsMatch(t, schema.Required, []string{"owner", "repo"})
+
+	noContent := func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}
+
+	tests := []struct {
+		name           string
+		handlers       map[string]http.HandlerFunc
+		requestArgs    map[string]any
+		expectToolErr  bool
+		expectedErrMsg string
+		expectedText   string
+	}{
+		{
+			name: "delete cache by ID",
+			handlers: map[string]http.HandlerFunc{
+				DeleteReposActionsCachesByOwnerByRepoByCacheID: noContent,
+			},
+			requestArgs: map[string]any{
+				"owner":    "owner",
+				"repo":     "repo",
+				"cache_id": float64(42),
+			},
+			expectedText: "Deleted actions cache 42",
+		},
+		{
+			name: "delete caches by key and ref",
+			handlers: map[string]http.HandlerFunc{
+				DeleteReposActionsCachesByOwnerByRepo: expectQueryParams(t, map[string]string{
+					"key": "go-mod-abc",
+					"ref": "refs/heads/main",
+				}).andThen(noContent),
+			},
+			requestArgs: map[string]any{
+				"owner": "owner",
+				"repo":  "repo",
+				"key":   "go-mod-abc",
+				"ref":   "refs/heads/main",
+			},
+			expectedText: `Deleted actions caches with key "go-mod-abc" on refs/heads/main`,
+		},
+		{
+			name:     "both cache_id and key",
+			handlers: map[string]http.HandlerFunc{},
+			requestArgs: map[string]any{
+				"owner":    "owner",
+				"repo":     "repo",
+				"cache_id": float64(42),
+				"key":      "go-mod-abc",
+			},
+			expectToolErr:  true,
+			expectedErrMsg: "provide exactly one of cache_id or key",
+		},
+		{
+			name:     "ref without key",
+			handlers: map[string]http.HandlerFunc{},
+			requestArgs: map[string]any{
+				"owner":    "owner",
+				"repo":     "repo",
+				"cache_id": float64(42),
+				"ref":      "main",
+			},
+			expectToolErr:  true,
+			expectedErrMsg: "ref can only be used with key",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			deps := BaseDeps{
+				Client: github.NewClient(MockHTTPClientWithHandlers(tc.handlers)),
+			}
+			handler := serverTool.Handler(deps)
+			request := createMCPRequest(tc.requestArgs)
+
+			result, err := handler(ContextWithDeps(context.Background(), deps), &request)
+			require.NoError(t, err)
+
+			if tc.expectToolErr {
+				require.True(t, result.IsError)
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			textContent := getTextResult(t, result)
+			assert.Equal(t, tc.expectedText, textContent.Text)
+		})
+	}
+}
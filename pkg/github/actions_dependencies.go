@@ -0,0 +1,476 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"path"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/github/github-mcp-server/pkg/inventory"
+	"github.com/github/github-mcp-server/pkg/scopes"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/github/github-mcp-server/pkg/utils"
+	"github.com/google/go-github/v82/github"
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"go.yaml.in/yaml/v3"
+)
+
+const (
+	// defaultWorkflowDependencyDepth and maxWorkflowDependencyDepth bound how deep references are
+	// followed into reusable workflows and composite actions.
+	defaultWorkflowDependencyDepth = 5
+	maxWorkflowDependencyDepth     = 10
+
+	// maxWorkflowDependencyNodes bounds the files fetched to resolve one workflow.
+	maxWorkflowDependencyNodes = 100
+)
+
+// Kinds of nodes in a workflow dependency graph.
+const (
+	workflowNodeWorkflow         = "workflow"
+	workflowNodeReusableWorkflow = "reusable_workflow"
+	workflowNodeAction           = "action"
+	workflowNodeCompositeAction  = "composite_action"
+	workflowNodeDocker           = "docker"
+)
+
+// How a workflow dependency is pinned. Only SHA and digest pins are immutable; tags and branches
+// can be moved to other code after a review.
+const (
+	workflowPinSHA    = "sha"
+	workflowPinTag    = "tag"
+	workflowPinBranch = "branch"
+	workflowPinLocal  = "local"
+	workflowPinDigest = "digest"
+	workflowPinNone   = "none"
+)
+
+// deprecatedActionRuntimes are the JavaScript action runtimes GitHub no longer supports.
+var deprecatedActionRuntimes = map[string]bool{"node12": true, "node16": true}
+
+var (
+	commitSHAPattern  = regexp.MustCompile(`^[0-9a-f]{40}$`)
+	versionTagPattern = regexp.MustCompile(`^v?\d+(\.\d+)*$`)
+)
+
+// WorkflowDependencyNode is a workflow, reusable workflow or action in a dependency graph.
+type WorkflowDependencyNode struct {
+	// ID is the reference as written in uses, with local references resolved to their
+	// repository.
+	ID         string `json:"id"`
+	Kind       string `json:"kind"`
+	Repository string `json:"repository,omitempty"`
+	Path       string `json:"path,omitempty"`
+	Ref        string `json:"ref,omitempty"`
+	Pin        string `json:"pin"`
+	// Runtime is the runs.using of an action, such as node20, composite or docker.
+	Runtime    string `json:"runtime,omitempty"`
+	Unpinned   bool   `json:"unpinned,omitempty"`
+	Deprecated string `json:"deprecated,omitempty"`
+	Error      string `json:"error,omitempty"`
+
+	owner, repo string
+}
+
+// WorkflowDependencyEdge records that a workflow or composite action uses another node.
+type WorkflowDependencyEdge struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+	// Job is the job of a workflow the reference appears in.
+	Job string `json:"job,omitempty"`
+}
+
+// WorkflowDependencyGraph is the graph of what a workflow runs, with the dependencies flagged in
+// a supply-chain review.
+type WorkflowDependencyGraph struct {
+	Root  string                    `json:"root"`
+	Nodes []*WorkflowDependencyNode `json:"nodes"`
+	Edges []WorkflowDependencyEdge  `json:"edges"`
+	// Unpinned lists the remote dependencies not pinned to a commit SHA or image digest.
+	Unpinned []string `json:"unpinned"`
+	// Deprecated lists the actions running on an unsupported runtime or archived.
+	Deprecated []string `json:"deprecated"`
+	Truncated  bool     `json:"truncated,omitempty"`
+}
+
+// workflowUsesReference is a job or step uses: reference found in a file.
+type workflowUsesReference struct {
+	uses string
+	job  string
+}
+
+// workflowPin classifies how a git ref pins a dependency.
+func workflowPin(ref string) string {
+	switch {
+	case commitSHAPattern.MatchString(ref):
+		return workflowPinSHA
+	case versionTagPattern.MatchString(ref):
+		return workflowPinTag
+	default:
+		return workflowPinBranch
+	}
+}
+
+// parseWorkflowUses resolves a uses: reference made by parent into a node. Local references
+// resolve to the repository and ref of the parent.
+func parseWorkflowUses(uses string, parent *WorkflowDependencyNode) (*WorkflowDependencyNode, error) {
+	if image, ok := strings.CutPrefix(uses, "docker://"); ok {
+		pin := workflowPinNone
+		if strings.Contains(image, "@sha256:") {
+			pin = workflowPinDigest
+		}
+		return &WorkflowDependencyNode{ID: uses, Kind: workflowNodeDocker, Pin: pin, Unpinned: pin != workflowPinDigest}, nil
+	}
+
+	node := &WorkflowDependencyNode{Kind: workflowNodeAction}
+	if local, ok := strings.CutPrefix(uses, "./"); ok {
+		node.owner, node.repo, node.Ref = parent.owner, parent.repo, parent.Ref
+		node.Path = path.Clean(local)
+		node.Pin = workflowPinLocal
+	} else {
+		target, ref, found := strings.Cut(uses, "@")
+		parts := strings.SplitN(target, "/", 3)
+		if !found || ref == "" || len(parts) < 2 || parts[0] == "" || parts[1] == "" {
+			return nil, fmt.Errorf("invalid uses reference %q", uses)
+		}
+		node.owner, node.repo, node.Ref = parts[0], parts[1], ref
+		if len(parts) == 3 {
+			node.Path = path.Clean(parts[2])
+		}
+		node.Pin = workflowPin(ref)
+		node.Unpinned = node.Pin != workflowPinSHA
+	}
+	node.Repository = node.owner + "/" + node.repo
+
+	if strings.HasPrefix(node.Path, ".github/workflows/") && (strings.HasSuffix(node.Path, ".yml") || strings.HasSuffix(node.Path, ".yaml")) {
+		node.Kind = workflowNodeReusableWorkflow
+	}
+	node.ID = node.Repository
+	if node.Path != "" && node.Path != "." {
+		node.ID += "/" + node.Path
+	}
+	if node.Ref != "" {
+		node.ID += "@" + node.Ref
+	}
+	return node, nil
+}
+
+// parseWorkflowFileUses returns the uses: references of the jobs and steps of a workflow, in job
+// order.
+func parseWorkflowFileUses(content []byte) ([]workflowUsesReference, error) {
+	var file struct {
+		Jobs map[string]struct {
+			Uses  string `yaml:"uses"`
+			Steps []struct {
+				Uses string `yaml:"uses"`
+			} `yaml:"steps"`
+		} `yaml:"jobs"`
+	}
+	if err := yaml.Unmarshal(content, &file); err != nil {
+		return nil, err
+	}
+
+	jobs := make([]string, 0, len(file.Jobs))
+	for job := range file.Jobs {
+		jobs = append(jobs, job)
+	}
+	slices.Sort(jobs)
+
+	var refs []workflowUsesReference
+	for _, job := range jobs {
+		if uses := file.Jobs[job].Uses; uses != "" {
+			refs = append(refs, workflowUsesReference{uses: uses, job: job})
+		}
+		for _, step := range file.Jobs[job].Steps {
+			if step.Uses != "" {
+				refs = append(refs, workflowUsesReference{uses: step.Uses, job: job})
+			}
+		}
+	}
+	return refs, nil
+}
+
+// parseActionFile returns the runtime of an action, and the uses: references of its steps when
+// it is a composite action, or of its image when it runs a Docker image.
+func parseActionFile(content []byte) (string, []workflowUsesReference, error) {
+	var file struct {
+		Runs struct {
+			Using string `yaml:"using"`
+			Image string `yaml:"image"`
+			Steps []struct {
+				Uses string `yaml:"uses"`
+			} `yaml:"steps"`
+		} `yaml:"runs"`
+	}
+	if err := yaml.Unmarshal(content, &file); err != nil {
+		return "", nil, err
+	}
+
+	var refs []workflowUsesReference
+	for _, step := range file.Runs.Steps {
+		if step.Uses != "" {
+			refs = append(refs, workflowUsesReference{uses: step.Uses})
+		}
+	}
+	if strings.HasPrefix(file.Runs.Image, "docker://") {
+		refs = append(refs, workflowUsesReference{uses: file.Runs.Image})
+	}
+	return file.Runs.Using, refs, nil
+}
+
+// workflowDependencyResolver fetches the files of a workflow dependency graph, remembering which
+// repositories are archived.
+type workflowDependencyResolver struct {
+	client   *github.Client
+	archived map[string]bool
+}
+
+// fetchFile returns the content of a file, or nil when it does not exist.
+func (r *workflowDependencyResolver) fetchFile(ctx context.Context, owner, repo, filePath, ref string) ([]byte, error) {
+	opts := &github.RepositoryContentGetOptions{Ref: ref}
+	file, _, resp, err := r.client.Repositories.GetContents(ctx, owner, repo, filePath, opts)
+	if resp != nil {
+		_ = resp.Body.Close()
+	}
+	if err != nil {
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get %s/%s/%s: %w", owner, repo, filePath, err)
+	}
+	if file == nil {
+		return nil, nil
+	}
+	content, err := file.GetContent()
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode %s/%s/%s: %w", owner, repo, filePath, err)
+	}
+	return []byte(content), nil
+}
+
+// isArchived reports whether a repository is archived, which leaves its actions unmaintained.
+func (r *workflowDependencyResolver) isArchived(ctx context.Context, owner, repo string) bool {
+	key := strings.ToLower(owner + "/" + repo)
+	if archived, ok := r.archived[key]; ok {
+		return archived
+	}
+	repository, resp, err := r.client.Repositories.Get(ctx, owner, repo)
+	if resp != nil {
+		_ = resp.Body.Close()
+	}
+	r.archived[key] = err == nil && repository.GetArchived()
+	return r.archived[key]
+}
+
+// resolve fetches the file of a node and returns the references it makes.
+func (r *workflowDependencyResolver) resolve(ctx context.Context, node *WorkflowDependencyNode) ([]workflowUsesReference, error) {
+	switch node.Kind {
+	case workflowNodeDocker:
+		return nil, nil
+	case workflowNodeWorkflow, workflowNodeReusableWorkflow:
+		content, err := r.fetchFile(ctx, node.owner, node.repo, node.Path, node.Ref)
+		if err != nil {
+			return nil, err
+		}
+		if content == nil {
+			return nil, fmt.Errorf("workflow file %s not found", node.Path)
+		}
+		return parseWorkflowFileUses(content)
+	}
+
+	var content []byte
+	for _, name := range []string{"action.yml", "action.yaml"} {
+		var err error
+		if content, err = r.fetchFile(ctx, node.owner, node.repo, path.Join(node.Path, name), node.Ref); err != nil {
+			return nil, err
+		}
+		if content != nil {
+			break
+		}
+	}
+	if content == nil {
+		return nil, errors.New("action metadata file action.yml not found")
+	}
+	runtime, refs, err := parseActionFile(content)
+	if err != nil {
+		return nil, err
+	}
+	node.Runtime = runtime
+	if runtime == "composite" {
+		node.Kind = workflowNodeCompositeAction
+	}
+	if deprecatedActionRuntimes[runtime] {
+		node.Deprecated = fmt.Sprintf("runs on the %s runtime, which GitHub Actions no longer supports", runtime)
+	} else if node.Pin != workflowPinLocal && r.isArchived(ctx, node.owner, node.repo) {
+		node.Deprecated = "the action's repository is archived"
+	}
+	return refs, nil
+}
+
+// buildWorkflowDependencyGraph resolves the references of a workflow breadth first, up to
+// maxDepth levels deep.
+func buildWorkflowDependencyGraph(ctx context.Context, client *github.Client, root *WorkflowDependencyNode, maxDepth int) (*WorkflowDependencyGraph, error) {
+	resolver := &workflowDependencyResolver{client: client, archived: map[string]bool{}}
+	graph := &WorkflowDependencyGraph{Root: root.ID, Nodes: []*WorkflowDependencyNode{root}, Edges: []WorkflowDependencyEdge{}, Unpinned: []string{}, Deprecated: []string{}}
+
+	refs, err := resolver.resolve(ctx, root)
+	if err != nil {
+		return nil, err
+	}
+
+	type pending struct {
+		node  *WorkflowDependencyNode
+		refs  []workflowUsesReference
+		depth int
+	}
+	seen := map[string]bool{root.ID: true}
+	queue := []pending{{node: root, refs: refs, depth: 0}}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		for _, ref := range current.refs {
+			child, err := parseWorkflowUses(ref.uses, current.node)
+			if err != nil {
+				child = &WorkflowDependencyNode{ID: ref.uses, Kind: workflowNodeAction, Pin: workflowPinNone, Error: err.Error()}
+			}
+			graph.Edges = append(graph.Edges, WorkflowDependencyEdge{From: current.node.ID, To: child.ID, Job: ref.job})
+			if seen[child.ID] {
+				continue
+			}
+			if len(graph.Nodes) >= maxWorkflowDependencyNodes {
+				graph.Truncated = true
+				continue
+			}
+			seen[child.ID] = true
+			graph.Nodes = append(graph.Nodes, child)
+			if child.Error != "" || current.depth+1 > maxDepth {
+				continue
+			}
+
+			childRefs, err := resolver.resolve(ctx, child)
+			if err != nil {
+				child.Error = err.Error()
+				continue
+			}
+			if current.depth+1 < maxDepth {
+				queue = append(queue, pending{node: child, refs: childRefs, depth: current.depth + 1})
+			} else if len(childRefs) > 0 {
+				graph.Truncated = true
+			}
+		}
+	}
+
+	for _, node := range graph.Nodes {
+		if node.Unpinned {
+			graph.Unpinned = append(graph.Unpinned, node.ID)
+		}
+		if node.Deprecated != "" {
+			graph.Deprecated = append(graph.Deprecated, node.ID)
+		}
+	}
+	return graph, nil
+}
+
+// GetWorkflowDependencies creates a tool returning the graph of reusable workflows and actions a
+// workflow uses.
+func GetWorkflowDependencies(t translations.TranslationHelperFunc) inventory.ServerTool {
+	return NewTool(
+		ToolsetMetadataActions,
+		mcp.Tool{
+			Name: "get_workflow_dependencies",
+			Description: t("TOOL_GET_WORKFLOW_DEPENDENCIES_DESCRIPTION", `Get the dependency graph of a GitHub Actions workflow for supply-chain reviews: the reusable workflows and actions it uses, followed recursively into reusable workflows and composite actions, with how each is pinned.
+Dependencies not pinned to a full commit SHA (or image digest) are listed as unpinned, since tags and branches can be moved. Actions running on an unsupported Node.js runtime, or whose repository is archived, are listed as deprecated.`),
+			Annotations: &mcp.ToolAnnotations{
+				Title:        t("TOOL_GET_WORKFLOW_DEPENDENCIES_USER_TITLE", "Get workflow dependency graph"),
+				ReadOnlyHint: true,
+			},
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"owner": {
+						Type:        "string",
+						Description: DescriptionRepositoryOwner,
+					},
+					"repo": {
+						Type:        "string",
+						Description: DescriptionRepositoryName,
+					},
+					"workflow": {
+						Type:        "string",
+						Description: "Workflow file name (e.g. ci.yml) or path (e.g. .github/workflows/ci.yml)",
+					},
+					"ref": {
+						Type:        "string",
+						Description: "Branch, tag or commit to read the workflow at. Defaults to the default branch.",
+					},
+					"max_depth": {
+						Type:        "number",
+						Description: fmt.Sprintf("Levels of reusable workflows and composite actions to follow (default %d, max %d)", defaultWorkflowDependencyDepth, maxWorkflowDependencyDepth),
+						Minimum:     jsonschema.Ptr(1.0),
+						Maximum:     jsonschema.Ptr(float64(maxWorkflowDependencyDepth)),
+					},
+				},
+				Required: []string{"owner", "repo", "workflow"},
+			},
+		},
+		[]scopes.Scope{scopes.Repo},
+		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+			owner, err := RequiredParam[string](args, "owner")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			repo, err := RequiredParam[string](args, "repo")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			workflow, err := RequiredParam[string](args, "workflow")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			ref, err := OptionalParam[string](args, "ref")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			maxDepth, err := OptionalIntParamWithDefault(args, "max_depth", defaultWorkflowDependencyDepth)
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			maxDepth = min(max(maxDepth, 1), maxWorkflowDependencyDepth)
+
+			workflowPath := strings.TrimPrefix(workflow, "/")
+			if !strings.Contains(workflowPath, "/") {
+				workflowPath = ".github/workflows/" + workflowPath
+			}
+
+			client, err := deps.GetClient(ctx)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			root := &WorkflowDependencyNode{
+				Kind:       workflowNodeWorkflow,
+				Repository: owner + "/" + repo,
+				Path:       workflowPath,
+				Ref:        ref,
+				Pin:        workflowPinLocal,
+				owner:      owner,
+				repo:       repo,
+			}
+			root.ID = root.Repository + "/" + root.Path
+			if ref != "" {
+				root.ID += "@" + ref
+			}
+
+			graph, err := buildWorkflowDependencyGraph(ctx, client, root, maxDepth)
+			if err != nil {
+				return utils.NewToolResultError(fmt.Sprintf("failed to read workflow %s: %v", workflowPath, err)), nil, nil
+			}
+			return MarshalledTextResult(graph), nil, nil
+		},
+	)
+}
@@ -0,0 +1,185 @@
+package github
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v82/github"
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ParseWorkflowUses(t *testing.T) {
+	parent := &WorkflowDependencyNode{Repository: "octo/app", Ref: "main", owner: "octo", repo: "app"}
+	sha := strings.Repeat("a", 40)
+
+	tests := []struct {
+		uses     string
+		expected WorkflowDependencyNode
+	}{
+		{
+			uses:     "actions/checkout@v4",
+			expected: WorkflowDependencyNode{ID: "actions/checkout@v4", Kind: "action", Repository: "actions/checkout", Ref: "v4", Pin: "tag", Unpinned: true},
+		},
+		{
+			uses:     "actions/checkout@" + sha,
+			expected: WorkflowDependencyNode{ID: "actions/checkout@" + sha, Kind: "action", Repository: "actions/checkout", Ref: sha, Pin: "sha"},
+		},
+		{
+			uses:     "github/codeql-action/init@main",
+			expected: WorkflowDependencyNode{ID: "github/codeql-action/init@main", Kind: "action", Repository: "github/codeql-action", Path: "init", Ref: "main", Pin: "branch", Unpinned: true},
+		},
+		{
+			uses:     "octo/shared/.github/workflows/build.yml@v1.2",
+			expected: WorkflowDependencyNode{ID: "octo/shared/.github/workflows/build.yml@v1.2", Kind: "reusable_workflow", Repository: "octo/shared", Path: ".github/workflows/build.yml", Ref: "v1.2", Pin: "tag", Unpinned: true},
+		},
+		{
+			uses:     "./.github/actions/setup",
+			expected: WorkflowDependencyNode{ID: "octo/app/.github/actions/setup@main", Kind: "action", Repository: "octo/app", Path: ".github/actions/setup", Ref: "main", Pin: "local"},
+		},
+		{
+			uses:     "docker://alpine:3.20",
+			expected: WorkflowDependencyNode{ID: "docker://alpine:3.20", Kind: "docker", Pin: "none", Unpinned: true},
+		},
+		{
+			uses:     "docker://alpine@sha256:abc",
+			expected: WorkflowDependencyNode{ID: "docker://alpine@sha256:abc", Kind: "docker", Pin: "digest"},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.uses, func(t *testing.T) {
+			node, err := parseWorkflowUses(tc.uses, parent)
+			require.NoError(t, err)
+			node.owner, node.repo = "", ""
+			assert.Equal(t, tc.expected, *node)
+		})
+	}
+
+	_, err := parseWorkflowUses("actions/checkout", parent)
+	assert.ErrorContains(t, err, "invalid uses reference")
+}
+
+func Test_GetWorkflowDependencies(t *testing.T) {
+	serverTool := GetWorkflowDependencies(translations.NullTranslationHelper)
+	tool := serverTool.Tool
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_workflow_dependencies", tool.Name)
+	assert.True(t, tool.Annotations.ReadOnlyHint)
+	schema := tool.InputSchema.(*jsonschema.Schema)
+	assert.ElementsMatch(t, schema.Required, []string{"owner", "repo", "workflow"})
+
+	sha := strings.Repeat("b", 40)
+	files := map[string]string{
+		"/repos/octo/app/contents/.github/workflows/ci.yml": `
+on: push
+jobs:
+  test:
+    runs-on: ubuntu-latest
+    steps:
+      - uses: actions/checkout@` + sha + `
+      - uses: ./.github/actions/setup
+      - run: make test
+  release:
+    uses: octo/shared/.github/workflows/release.yml@main
+`,
+		"/repos/octo/app/contents/.github/actions/setup/action.yml": `
+runs:
+  using: composite
+  steps:
+    - uses: actions/setup-node@v3
+    - uses: actions/checkout@` + sha + `
+`,
+		"/repos/actions/checkout/contents/action.yml":    "runs:\n  using: node20\n  main: dist/index.js\n",
+		"/repos/actions/setup-node/contents/action.yaml": "runs:\n  using: node16\n  main: dist/index.js\n",
+		"/repos/octo/shared/contents/.github/workflows/release.yml": `
+on: workflow_call
+jobs:
+  publish:
+    runs-on: ubuntu-latest
+    steps:
+      - uses: octo/retired-action@v1
+`,
+		"/repos/octo/retired-action/contents/action.yml": "runs:\n  using: docker\n  image: docker://ghcr.io/octo/retired:latest\n",
+	}
+	handlers := map[string]http.HandlerFunc{
+		"GET /repos/{owner}/{repo}/contents/{path:.*}": func(w http.ResponseWriter, r *http.Request) {
+			content, ok := files[r.URL.Path]
+			if !ok {
+				mockResponse(t, http.StatusNotFound, map[string]string{"message": "Not Found"})(w, r)
+				return
+			}
+			mockResponse(t, http.StatusOK, map[string]any{
+				"type":     "file",
+				"encoding": "base64",
+				"content":  base64.StdEncoding.EncodeToString([]byte(content)),
+			})(w, r)
+		},
+		GetReposByOwnerByRepo: func(w http.ResponseWriter, r *http.Request) {
+			mockResponse(t, http.StatusOK, map[string]any{"archived": strings.HasSuffix(r.URL.Path, "/retired-action")})(w, r)
+		},
+	}
+
+	callTool := func(t *testing.T, args map[string]any) (*WorkflowDependencyGraph, string) {
+		deps := BaseDeps{Client: github.NewClient(MockHTTPClientWithHandlers(handlers))}
+		request := createMCPRequest(args)
+		result, err := serverTool.Handler(deps)(ContextWithDeps(context.Background(), deps), &request)
+		require.NoError(t, err)
+		if result.IsError {
+			return nil, getErrorResult(t, result).Text
+		}
+		var graph WorkflowDependencyGraph
+		require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &graph))
+		return &graph, ""
+	}
+
+	t.Run("resolves the graph", func(t *testing.T) {
+		graph, errText := callTool(t, map[string]any{"owner": "octo", "repo": "app", "workflow": "ci.yml"})
+		require.Empty(t, errText)
+
+		assert.Equal(t, "octo/app/.github/workflows/ci.yml", graph.Root)
+		nodes := map[string]*WorkflowDependencyNode{}
+		for _, node := range graph.Nodes {
+			nodes[node.ID] = node
+		}
+		require.Len(t, nodes, 7)
+		assert.Equal(t, "composite_action", nodes["octo/app/.github/actions/setup"].Kind)
+		assert.Equal(t, "reusable_workflow", nodes["octo/shared/.github/workflows/release.yml@main"].Kind)
+		assert.Equal(t, "node20", nodes["actions/checkout@"+sha].Runtime)
+
+		assert.ElementsMatch(t, []string{
+			"actions/setup-node@v3",
+			"octo/shared/.github/workflows/release.yml@main",
+			"octo/retired-action@v1",
+			"docker://ghcr.io/octo/retired:latest",
+		}, graph.Unpinned)
+		assert.ElementsMatch(t, []string{"actions/setup-node@v3", "octo/retired-action@v1"}, graph.Deprecated)
+		assert.Contains(t, nodes["actions/setup-node@v3"].Deprecated, "node16")
+		assert.Contains(t, nodes["octo/retired-action@v1"].Deprecated, "archived")
+
+		assert.Contains(t, graph.Edges, WorkflowDependencyEdge{From: graph.Root, To: "octo/shared/.github/workflows/release.yml@main", Job: "release"})
+		assert.Contains(t, graph.Edges, WorkflowDependencyEdge{From: "octo/app/.github/actions/setup", To: "actions/checkout@" + sha},
+			"an action used twice keeps both edges")
+		assert.False(t, graph.Truncated)
+	})
+
+	t.Run("depth limit", func(t *testing.T) {
+		graph, errText := callTool(t, map[string]any{"owner": "octo", "repo": "app", "workflow": ".github/workflows/ci.yml", "max_depth": float64(1)})
+		require.Empty(t, errText)
+
+		assert.Len(t, graph.Nodes, 4)
+		assert.True(t, graph.Truncated)
+	})
+
+	t.Run("missing workflow", func(t *testing.T) {
+		_, errText := callTool(t, map[string]any{"owner": "octo", "repo": "app", "workflow": "nope.yml"})
+		assert.Contains(t, errText, "workflow file .github/workflows/nope.yml not found")
+	})
+}
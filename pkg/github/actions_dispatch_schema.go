@@ -0,0 +1,173 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/utils"
+	"github.com/google/go-github/v82/github"
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"go.yaml.in/yaml/v3"
+)
+
+// workflowDispatchInput is a single input declared under on.workflow_dispatch.inputs.
+type workflowDispatchInput struct {
+	Description string   `yaml:"description"`
+	Required    bool     `yaml:"required"`
+	Default     any      `yaml:"default"`
+	Type        string   `yaml:"type"`
+	Options     []string `yaml:"options"`
+}
+
+// WorkflowDispatchSchema describes the inputs a workflow accepts when dispatched.
+type WorkflowDispatchSchema struct {
+	Workflow     string             `json:"workflow"`
+	Dispatchable bool               `json:"dispatchable"`
+	Schema       *jsonschema.Schema `json:"schema,omitempty"`
+}
+
+func getWorkflowDispatchSchema(ctx context.Context, client *github.Client, owner, repo, resourceID string) (*mcp.CallToolResult, any, error) {
+	var workflow *github.Workflow
+	var resp *github.Response
+	var err error
+
+	if workflowIDInt, parseErr := strconv.ParseInt(resourceID, 10, 64); parseErr == nil {
+		workflow, resp, err = client.Actions.GetWorkflowByID(ctx, owner, repo, workflowIDInt)
+	} else {
+		workflow, resp, err = client.Actions.GetWorkflowByFileName(ctx, owner, repo, resourceID)
+	}
+	if err != nil {
+		return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to get workflow", resp, err), nil, nil
+	}
+	_ = resp.Body.Close()
+
+	fileContent, _, resp, err := client.Repositories.GetContents(ctx, owner, repo, workflow.GetPath(), nil)
+	if err != nil {
+		return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to get workflow file", resp, err), nil, nil
+	}
+	_ = resp.Body.Close()
+	if fileContent == nil {
+		return utils.NewToolResultError(fmt.Sprintf("workflow path %s is not a file", workflow.GetPath())), nil, nil
+	}
+
+	content, err := fileContent.GetContent()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to decode workflow file: %w", err)
+	}
+
+	schema, dispatchable, err := parseWorkflowDispatchSchema([]byte(content))
+	if err != nil {
+		return utils.NewToolResultError(fmt.Sprintf("failed to parse workflow file %s: %v", workflow.GetPath(), err)), nil, nil
+	}
+
+	return MarshalledTextResult(WorkflowDispatchSchema{
+		Workflow:     workflow.GetPath(),
+		Dispatchable: dispatchable,
+		Schema:       schema,
+	}), nil, nil
+}
+
+// parseWorkflowDispatchSchema builds a JSON schema for the workflow_dispatch inputs of a workflow
+// file. It reports false if the workflow has no workflow_dispatch trigger. Inputs that are required
+// but have a default are not listed as required, since the default is used when they are omitted.
+func parseWorkflowDispatchSchema(content []byte) (*jsonschema.Schema, bool, error) {
+	var file struct {
+		On yaml.Node `yaml:"on"`
+	}
+	if err := yaml.Unmarshal(content, &file); err != nil {
+		return nil, false, err
+	}
+
+	var dispatch *yaml.Node
+	switch file.On.Kind {
+	case yaml.ScalarNode:
+		if file.On.Value != "workflow_dispatch" {
+			return nil, false, nil
+		}
+	case yaml.SequenceNode:
+		found := false
+		for _, event := range file.On.Content {
+			if event.Value == "workflow_dispatch" {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, false, nil
+		}
+	case yaml.MappingNode:
+		found := false
+		for i := 0; i+1 < len(file.On.Content); i += 2 {
+			if file.On.Content[i].Value == "workflow_dispatch" {
+				dispatch = file.On.Content[i+1]
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, false, nil
+		}
+	default:
+		return nil, false, nil
+	}
+
+	schema := &jsonschema.Schema{
+		Type:       "object",
+		Properties: map[string]*jsonschema.Schema{},
+	}
+
+	var config struct {
+		Inputs yaml.Node `yaml:"inputs"`
+	}
+	if dispatch != nil && dispatch.Kind == yaml.MappingNode {
+		if err := dispatch.Decode(&config); err != nil {
+			return nil, false, err
+		}
+	}
+	if config.Inputs.Kind != yaml.MappingNode {
+		return schema, true, nil
+	}
+
+	for i := 0; i+1 < len(config.Inputs.Content); i += 2 {
+		name := config.Inputs.Content[i].Value
+		var input workflowDispatchInput
+		if err := config.Inputs.Content[i+1].Decode(&input); err != nil {
+			return nil, false, fmt.Errorf("invalid input %q: %w", name, err)
+		}
+
+		property := &jsonschema.Schema{Description: input.Description}
+		switch input.Type {
+		case "boolean":
+			property.Type = "boolean"
+		case "number":
+			property.Type = "number"
+		case "choice":
+			property.Type = "string"
+			for _, option := range input.Options {
+				property.Enum = append(property.Enum, option)
+			}
+		default:
+			// string, environment and untyped inputs are all passed as strings.
+			property.Type = "string"
+		}
+
+		if input.Default != nil {
+			def, err := json.Marshal(input.Default)
+			if err != nil {
+				return nil, false, fmt.Errorf("invalid default for input %q: %w", name, err)
+			}
+			property.Default = def
+		} else if input.Required {
+			schema.Required = append(schema.Required, name)
+		}
+
+		schema.Properties[name] = property
+		schema.PropertyOrder = append(schema.PropertyOrder, name)
+	}
+
+	return schema, true, nil
+}
@@ -0,0 +1,375 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"path"
+	"regexp"
+	"strings"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/inventory"
+	"github.com/github/github-mcp-server/pkg/scopes"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/github/github-mcp-server/pkg/utils"
+	"github.com/google/go-github/v82/github"
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+const (
+	defaultPinActionsBranch = "pin-actions"
+	workflowsDir            = ".github/workflows"
+	// maxTagDereferences bounds the chain of annotated tags followed to reach a commit.
+	maxTagDereferences = 5
+)
+
+// workflowUsesLinePattern matches a uses: line of a workflow, capturing the text before the
+// reference, the reference with its quotes, and the rest of the line.
+var workflowUsesLinePattern = regexp.MustCompile(`^(\s*(?:-\s+)?uses:\s*)(["']?)([^\s"'#]+)(["']?)(.*)$`)
+
+// PinnedAction is a uses: reference rewritten to a commit SHA.
+type PinnedAction struct {
+	File string `json:"file"`
+	Line int    `json:"line"`
+	Uses string `json:"uses"`
+	// Ref is the tag the reference was pinned from, kept as a comment for Dependabot.
+	Ref string `json:"ref"`
+	SHA string `json:"sha"`
+}
+
+// SkippedAction is a mutable uses: reference that was left unchanged.
+type SkippedAction struct {
+	File   string `json:"file"`
+	Line   int    `json:"line"`
+	Uses   string `json:"uses"`
+	Reason string `json:"reason"`
+}
+
+// PinActionsResult is the outcome of pinning the actions of a repository's workflows.
+type PinActionsResult struct {
+	Pinned  []PinnedAction  `json:"pinned"`
+	Skipped []SkippedAction `json:"skipped,omitempty"`
+	Branch  string          `json:"branch,omitempty"`
+	// PullRequest is the URL of the pull request opened with the changes.
+	PullRequest string `json:"pull_request,omitempty"`
+	Message     string `json:"message,omitempty"`
+}
+
+// tagResolver resolves tags of action repositories to the commits they point to, remembering
+// the tags already resolved.
+type tagResolver struct {
+	client   *github.Client
+	resolved map[string]tagResolution
+}
+
+type tagResolution struct {
+	sha    string
+	reason string
+}
+
+// resolve returns the commit SHA a tag points to, or the reason it cannot be pinned.
+func (r *tagResolver) resolve(ctx context.Context, owner, repo, tag string) (string, string, error) {
+	key := strings.ToLower(owner+"/"+repo) + "@" + tag
+	if res, ok := r.resolved[key]; ok {
+		return res.sha, res.reason, nil
+	}
+
+	ref, resp, err := r.client.Git.GetRef(ctx, owner, repo, "tags/"+tag)
+	if resp != nil {
+		_ = resp.Body.Close()
+	}
+	if err != nil {
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			// Branches are left alone: pinning them would stop updates the workflow relies on
+			res := tagResolution{reason: fmt.Sprintf("%s is not a tag of %s/%s", tag, owner, repo)}
+			r.resolved[key] = res
+			return "", res.reason, nil
+		}
+		return "", "", fmt.Errorf("failed to resolve tag %s of %s/%s: %w", tag, owner, repo, err)
+	}
+
+	object := ref.GetObject()
+	for i := 0; object.GetType() == "tag"; i++ {
+		if i == maxTagDereferences {
+			return "", "", fmt.Errorf("tag %s of %s/%s does not resolve to a commit", tag, owner, repo)
+		}
+		annotated, resp, err := r.client.Git.GetTag(ctx, owner, repo, object.GetSHA())
+		if resp != nil {
+			_ = resp.Body.Close()
+		}
+		if err != nil {
+			return "", "", fmt.Errorf("failed to get annotated tag %s of %s/%s: %w", tag, owner, repo, err)
+		}
+		object = annotated.GetObject()
+	}
+	if object.GetType() != "commit" {
+		res := tagResolution{reason: fmt.Sprintf("tag %s of %s/%s points to a %s", tag, owner, repo, object.GetType())}
+		r.resolved[key] = res
+		return "", res.reason, nil
+	}
+	r.resolved[key] = tagResolution{sha: object.GetSHA()}
+	return object.GetSHA(), "", nil
+}
+
+// pinWorkflowFile rewrites the uses: references of a workflow from tags to the commit SHAs they
+// point to, with the tag kept as a comment. Local references, Docker images and references
+// already pinned to a SHA are left unchanged.
+func pinWorkflowFile(ctx context.Context, resolver *tagResolver, parent *WorkflowDependencyNode, filePath, content string) (string, []PinnedAction, []SkippedAction, error) {
+	var pinned []PinnedAction
+	var skipped []SkippedAction
+	lines := strings.Split(content, "\n")
+	for i, line := range lines {
+		m := workflowUsesLinePattern.FindStringSubmatch(strings.TrimSuffix(line, "\r"))
+		if m == nil {
+			continue
+		}
+		prefix, openQuote, uses, closeQuote, rest := m[1], m[2], m[3], m[4], m[5]
+		if strings.HasPrefix(uses, "docker://") || strings.HasPrefix(uses, "./") || strings.Contains(uses, "${{") {
+			continue
+		}
+		node, err := parseWorkflowUses(uses, parent)
+		if err != nil {
+			skipped = append(skipped, SkippedAction{File: filePath, Line: i + 1, Uses: uses, Reason: err.Error()})
+			continue
+		}
+		if node.Pin == workflowPinSHA {
+			continue
+		}
+
+		sha, reason, err := resolver.resolve(ctx, node.owner, node.repo, node.Ref)
+		if err != nil {
+			return "", nil, nil, err
+		}
+		if reason != "" {
+			skipped = append(skipped, SkippedAction{File: filePath, Line: i + 1, Uses: uses, Reason: reason})
+			continue
+		}
+
+		target, _, _ := strings.Cut(uses, "@")
+		comment := " # " + node.Ref
+		if rest = strings.TrimSpace(rest); rest != "" {
+			comment += " " + rest
+		}
+		pinnedLine := prefix + openQuote + target + "@" + sha + closeQuote + comment
+		if strings.HasSuffix(line, "\r") {
+			pinnedLine += "\r"
+		}
+		lines[i] = pinnedLine
+		pinned = append(pinned, PinnedAction{File: filePath, Line: i + 1, Uses: uses, Ref: node.Ref, SHA: sha})
+	}
+	return strings.Join(lines, "\n"), pinned, skipped, nil
+}
+
+// pinActionsPullRequestBody describes the pinned references for reviewers.
+func pinActionsPullRequestBody(pinned []PinnedAction, skipped []SkippedAction) string {
+	var b strings.Builder
+	b.WriteString("Pins the actions and reusable workflows used by the workflows to full commit SHAs, so that a moved or compromised tag cannot change the code they run. ")
+	b.WriteString("Each tag is kept as a comment, which lets Dependabot keep the pins up to date.\n\n")
+	b.WriteString("| File | Reference | Commit |\n| --- | --- | --- |\n")
+	for _, p := range pinned {
+		fmt.Fprintf(&b, "| `%s`:%d | `%s` | `%s` |\n", p.File, p.Line, p.Uses, p.SHA)
+	}
+	if len(skipped) > 0 {
+		b.WriteString("\nLeft unchanged:\n\n")
+		for _, s := range skipped {
+			fmt.Fprintf(&b, "- `%s` in `%s`:%d: %s\n", s.Uses, s.File, s.Line, s.Reason)
+		}
+	}
+	return b.String()
+}
+
+// PinActions creates a tool that pins the actions used by a repository's workflows to commit
+// SHAs and opens a pull request with the changes.
+func PinActions(t translations.TranslationHelperFunc) inventory.ServerTool {
+	return NewTool(
+		ToolsetMetadataActions,
+		mcp.Tool{
+			Name: "pin_actions",
+			Description: t("TOOL_PIN_ACTIONS_DESCRIPTION", `Harden GitHub Actions workflows by pinning the actions and reusable workflows they use from tags to full commit SHAs, and open a pull request with the changes.
+Each tag is resolved to the commit it currently points to and kept as a comment (e.g. actions/checkout@<sha> # v4) so Dependabot can keep the pins up to date. References to branches, local actions and Docker images are left unchanged. Updating workflow files needs the workflow scope.`),
+			Annotations: &mcp.ToolAnnotations{
+				Title:        t("TOOL_PIN_ACTIONS_USER_TITLE", "Pin actions to commit SHAs"),
+				ReadOnlyHint: false,
+			},
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"owner": {
+						Type:        "string",
+						Description: DescriptionRepositoryOwner,
+					},
+					"repo": {
+						Type:        "string",
+						Description: DescriptionRepositoryName,
+					},
+					"workflows": {
+						Type:        "array",
+						Description: "Workflow file names (e.g. ci.yml) or paths to pin. Defaults to all workflows in .github/workflows.",
+						Items:       &jsonschema.Schema{Type: "string"},
+					},
+					"base": {
+						Type:        "string",
+						Description: "Branch to pin the workflows of and open the pull request against. Defaults to the default branch.",
+					},
+					"branch": {
+						Type:        "string",
+						Description: fmt.Sprintf("Name of the branch to create for the changes (default %s)", defaultPinActionsBranch),
+					},
+				},
+				Required: []string{"owner", "repo"},
+			},
+		},
+		[]scopes.Scope{scopes.Workflow},
+		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+			owner, err := RequiredParam[string](args, "owner")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			repo, err := RequiredParam[string](args, "repo")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			workflows, err := OptionalStringArrayParam(args, "workflows")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			base, err := OptionalParam[string](args, "base")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			branch, err := OptionalParam[string](args, "branch")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			if branch == "" {
+				branch = defaultPinActionsBranch
+			}
+
+			client, err := deps.GetClient(ctx)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			var baseRef *github.Reference
+			if base == "" {
+				baseRef, err = resolveDefaultBranch(ctx, client, owner, repo)
+				if err != nil {
+					return utils.NewToolResultErrorFromErr("failed to resolve default branch", err), nil, nil
+				}
+				base = strings.TrimPrefix(baseRef.GetRef(), "refs/heads/")
+			} else {
+				var resp *github.Response
+				baseRef, resp, err = client.Git.GetRef(ctx, owner, repo, "heads/"+base)
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx, fmt.Sprintf("failed to get base branch %s", base), resp, err), nil, nil
+				}
+				_ = resp.Body.Close()
+			}
+			baseSHA := baseRef.GetObject().GetSHA()
+
+			if len(workflows) == 0 {
+				_, dir, resp, err := client.Repositories.GetContents(ctx, owner, repo, workflowsDir, &github.RepositoryContentGetOptions{Ref: baseSHA})
+				if err != nil {
+					if resp != nil && resp.StatusCode == http.StatusNotFound {
+						return utils.NewToolResultError(fmt.Sprintf("%s/%s has no %s directory on %s", owner, repo, workflowsDir, base)), nil, nil
+					}
+					return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to list workflows", resp, err), nil, nil
+				}
+				_ = resp.Body.Close()
+				for _, entry := range dir {
+					if entry.GetType() == "file" && (strings.HasSuffix(entry.GetName(), ".yml") || strings.HasSuffix(entry.GetName(), ".yaml")) {
+						workflows = append(workflows, entry.GetPath())
+					}
+				}
+			}
+
+			parent := &WorkflowDependencyNode{owner: owner, repo: repo, Ref: baseSHA}
+			resolver := &tagResolver{client: client, resolved: map[string]tagResolution{}}
+			result := PinActionsResult{Pinned: []PinnedAction{}}
+			var entries []*github.TreeEntry
+			for _, workflow := range workflows {
+				workflowPath := strings.TrimPrefix(workflow, "/")
+				if !strings.Contains(workflowPath, "/") {
+					workflowPath = path.Join(workflowsDir, workflowPath)
+				}
+				file, _, resp, err := client.Repositories.GetContents(ctx, owner, repo, workflowPath, &github.RepositoryContentGetOptions{Ref: baseSHA})
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx, fmt.Sprintf("failed to get workflow %s", workflowPath), resp, err), nil, nil
+				}
+				_ = resp.Body.Close()
+				if file == nil {
+					return utils.NewToolResultError(fmt.Sprintf("%s is not a file", workflowPath)), nil, nil
+				}
+				content, err := file.GetContent()
+				if err != nil {
+					return utils.NewToolResultErrorFromErr(fmt.Sprintf("failed to decode workflow %s", workflowPath), err), nil, nil
+				}
+
+				updated, pinned, skipped, err := pinWorkflowFile(ctx, resolver, parent, workflowPath, content)
+				if err != nil {
+					return utils.NewToolResultErrorFromErr("failed to pin actions", err), nil, nil
+				}
+				result.Pinned = append(result.Pinned, pinned...)
+				result.Skipped = append(result.Skipped, skipped...)
+				if updated != content {
+					entries = append(entries, &github.TreeEntry{
+						Path:    github.Ptr(workflowPath),
+						Mode:    github.Ptr("100644"),
+						Type:    github.Ptr("blob"),
+						Content: github.Ptr(updated),
+					})
+				}
+			}
+
+			if len(entries) == 0 {
+				result.Message = "all actions are already pinned to commit SHAs; no pull request was opened"
+				return MarshalledTextResult(result), nil, nil
+			}
+
+			baseCommit, resp, err := client.Git.GetCommit(ctx, owner, repo, baseSHA)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to get base commit", resp, err), nil, nil
+			}
+			_ = resp.Body.Close()
+			tree, resp, err := client.Git.CreateTree(ctx, owner, repo, baseCommit.GetTree().GetSHA(), entries)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to create tree", resp, err), nil, nil
+			}
+			_ = resp.Body.Close()
+			commit, resp, err := client.Git.CreateCommit(ctx, owner, repo, github.Commit{
+				Message: github.Ptr("Pin GitHub Actions to commit SHAs"),
+				Tree:    tree,
+				Parents: []*github.Commit{{SHA: github.Ptr(baseSHA)}},
+			}, nil)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to create commit", resp, err), nil, nil
+			}
+			_ = resp.Body.Close()
+			_, resp, err = client.Git.CreateRef(ctx, owner, repo, github.CreateRef{Ref: "refs/heads/" + branch, SHA: commit.GetSHA()})
+			if err != nil {
+				if resp != nil && resp.StatusCode == http.StatusUnprocessableEntity {
+					return utils.NewToolResultError(fmt.Sprintf("branch %s already exists; pass another branch name", branch)), nil, nil
+				}
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, fmt.Sprintf("failed to create branch %s", branch), resp, err), nil, nil
+			}
+			_ = resp.Body.Close()
+			result.Branch = branch
+
+			pr, resp, err := client.PullRequests.Create(ctx, owner, repo, &github.NewPullRequest{
+				Title: github.Ptr("Pin GitHub Actions to commit SHAs"),
+				Head:  github.Ptr(branch),
+				Base:  github.Ptr(base),
+				Body:  github.Ptr(pinActionsPullRequestBody(result.Pinned, result.Skipped)),
+			})
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, fmt.Sprintf("failed to open a pull request for branch %s", branch), resp, err), nil, nil
+			}
+			_ = resp.Body.Close()
+			result.PullRequest = pr.GetHTMLURL()
+
+			return MarshalledTextResult(result), nil, nil
+		},
+	)
+}
@@ -0,0 +1,181 @@
+package github
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v82/github"
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_PinActions(t *testing.T) {
+	serverTool := PinActions(translations.NullTranslationHelper)
+	tool := serverTool.Tool
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "pin_actions", tool.Name)
+	assert.False(t, tool.Annotations.ReadOnlyHint)
+	schema := tool.InputSchema.(*jsonschema.Schema)
+	assert.ElementsMatch(t, schema.Required, []string{"owner", "repo"})
+
+	checkoutSHA := strings.Repeat("c", 40)
+	setupSHA := strings.Repeat("d", 40)
+	pinnedSHA := strings.Repeat("e", 40)
+	ci := "on: push\njobs:\n  test:\n    steps:\n" +
+		"      - uses: actions/checkout@v4\n" +
+		"      - uses: \"actions/setup-go@v5\" # setup\n" +
+		"      - uses: actions/cache@" + pinnedSHA + " # v4\n" +
+		"      - uses: octo/tool@main\n" +
+		"      - uses: ./.github/actions/local\n" +
+		"      - uses: docker://alpine:3\n" +
+		"  release:\n    uses: octo/shared/.github/workflows/release.yml@v1\n"
+	files := map[string]string{
+		"/repos/octo/app/contents/.github/workflows/ci.yml":   ci,
+		"/repos/octo/app/contents/.github/workflows/lint.yml": "jobs:\n  lint:\n    steps:\n      - uses: actions/checkout@" + checkoutSHA + "\n",
+	}
+	tags := map[string]map[string]any{
+		"/repos/actions/checkout/git/ref/tags/v4":  {"ref": "refs/tags/v4", "object": map[string]any{"type": "commit", "sha": checkoutSHA}},
+		"/repos/actions/setup-go/git/ref/tags/v5":  {"ref": "refs/tags/v5", "object": map[string]any{"type": "tag", "sha": "annotated"}},
+		"/repos/octo/shared/git/ref/tags/v1":       {"ref": "refs/tags/v1", "object": map[string]any{"type": "commit", "sha": pinnedSHA}},
+		"/repos/octo/app/git/ref/heads/main":       {"ref": "refs/heads/main", "object": map[string]any{"type": "commit", "sha": "base"}},
+		"/repos/octo/app/git/ref/heads/production": {"ref": "refs/heads/production", "object": map[string]any{"type": "commit", "sha": "prod"}},
+	}
+
+	newHandlers := func(tree *[]map[string]any, branches *[]string) map[string]http.HandlerFunc {
+		var mu sync.Mutex
+		return map[string]http.HandlerFunc{
+			GetReposByOwnerByRepo: mockResponse(t, http.StatusOK, map[string]any{"default_branch": "main"}),
+			GetReposGitRefByOwnerByRepoByRef: func(w http.ResponseWriter, r *http.Request) {
+				ref, ok := tags[r.URL.Path]
+				if !ok {
+					mockResponse(t, http.StatusNotFound, map[string]string{"message": "Not Found"})(w, r)
+					return
+				}
+				mockResponse(t, http.StatusOK, ref)(w, r)
+			},
+			GetReposGitTagsByOwnerByRepoByTagSHA: mockResponse(t, http.StatusOK, map[string]any{
+				"sha": "annotated", "object": map[string]any{"type": "commit", "sha": setupSHA},
+			}),
+			"GET /repos/{owner}/{repo}/contents/{path:.*}": func(w http.ResponseWriter, r *http.Request) {
+				if strings.HasSuffix(r.URL.Path, "/.github/workflows") {
+					mockResponse(t, http.StatusOK, []map[string]any{
+						{"type": "file", "name": "ci.yml", "path": ".github/workflows/ci.yml"},
+						{"type": "file", "name": "lint.yml", "path": ".github/workflows/lint.yml"},
+						{"type": "file", "name": "README.md", "path": ".github/workflows/README.md"},
+					})(w, r)
+					return
+				}
+				content, ok := files[r.URL.Path]
+				if !ok {
+					mockResponse(t, http.StatusNotFound, map[string]string{"message": "Not Found"})(w, r)
+					return
+				}
+				mockResponse(t, http.StatusOK, map[string]any{
+					"type":     "file",
+					"encoding": "base64",
+					"content":  base64.StdEncoding.EncodeToString([]byte(content)),
+				})(w, r)
+			},
+			GetReposGitCommitsByOwnerByRepoByCommitSHA: mockResponse(t, http.StatusOK, map[string]any{"sha": "base", "tree": map[string]any{"sha": "base-tree"}}),
+			PostReposGitTreesByOwnerByRepo: func(w http.ResponseWriter, r *http.Request) {
+				var body struct {
+					BaseTree string           `json:"base_tree"`
+					Tree     []map[string]any `json:"tree"`
+				}
+				require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+				assert.Equal(t, "base-tree", body.BaseTree)
+				mu.Lock()
+				*tree = body.Tree
+				mu.Unlock()
+				mockResponse(t, http.StatusCreated, map[string]any{"sha": "new-tree"})(w, r)
+			},
+			PostReposGitCommitsByOwnerByRepo: mockResponse(t, http.StatusCreated, map[string]any{"sha": "new-commit"}),
+			PostReposGitRefsByOwnerByRepo: func(w http.ResponseWriter, r *http.Request) {
+				var body map[string]any
+				require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+				assert.Equal(t, "new-commit", body["sha"])
+				mu.Lock()
+				*branches = append(*branches, body["ref"].(string))
+				mu.Unlock()
+				mockResponse(t, http.StatusCreated, map[string]any{"ref": body["ref"]})(w, r)
+			},
+			PostReposPullsByOwnerByRepo: func(w http.ResponseWriter, r *http.Request) {
+				var body map[string]any
+				require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+				assert.Equal(t, "main", body["base"])
+				assert.Contains(t, body["body"], "`actions/checkout@v4`")
+				assert.Contains(t, body["body"], "octo/tool@main")
+				mockResponse(t, http.StatusCreated, map[string]any{"number": 9, "html_url": "https://github.com/octo/app/pull/9"})(w, r)
+			},
+		}
+	}
+
+	run := func(t *testing.T, args map[string]any, tree *[]map[string]any, branches *[]string) (PinActionsResult, string) {
+		deps := BaseDeps{Client: github.NewClient(MockHTTPClientWithHandlers(newHandlers(tree, branches)))}
+		request := createMCPRequest(args)
+		result, err := serverTool.Handler(deps)(ContextWithDeps(context.Background(), deps), &request)
+		require.NoError(t, err)
+		if result.IsError {
+			return PinActionsResult{}, getErrorResult(t, result).Text
+		}
+		var pinned PinActionsResult
+		require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &pinned))
+		return pinned, ""
+	}
+
+	t.Run("pins tags and opens a pull request", func(t *testing.T) {
+		var tree []map[string]any
+		var branches []string
+		result, errText := run(t, map[string]any{"owner": "octo", "repo": "app"}, &tree, &branches)
+		require.Empty(t, errText)
+
+		assert.Equal(t, []PinnedAction{
+			{File: ".github/workflows/ci.yml", Line: 5, Uses: "actions/checkout@v4", Ref: "v4", SHA: checkoutSHA},
+			{File: ".github/workflows/ci.yml", Line: 6, Uses: "actions/setup-go@v5", Ref: "v5", SHA: setupSHA},
+			{File: ".github/workflows/ci.yml", Line: 12, Uses: "octo/shared/.github/workflows/release.yml@v1", Ref: "v1", SHA: pinnedSHA},
+		}, result.Pinned)
+		require.Len(t, result.Skipped, 1)
+		assert.Equal(t, "octo/tool@main", result.Skipped[0].Uses)
+		assert.Contains(t, result.Skipped[0].Reason, "main is not a tag")
+		assert.Equal(t, "pin-actions", result.Branch)
+		assert.Equal(t, "https://github.com/octo/app/pull/9", result.PullRequest)
+		assert.Equal(t, []string{"refs/heads/pin-actions"}, branches)
+
+		require.Len(t, tree, 1, "only changed workflows are committed")
+		assert.Equal(t, ".github/workflows/ci.yml", tree[0]["path"])
+		content := tree[0]["content"].(string)
+		assert.Contains(t, content, "      - uses: actions/checkout@"+checkoutSHA+" # v4\n")
+		assert.Contains(t, content, "      - uses: \"actions/setup-go@"+setupSHA+"\" # v5 # setup\n")
+		assert.Contains(t, content, "      - uses: actions/cache@"+pinnedSHA+" # v4\n")
+		assert.Contains(t, content, "    uses: octo/shared/.github/workflows/release.yml@"+pinnedSHA+" # v1\n")
+		assert.Contains(t, content, "      - uses: octo/tool@main\n")
+		assert.Contains(t, content, "      - uses: ./.github/actions/local\n")
+	})
+
+	t.Run("nothing to pin", func(t *testing.T) {
+		var tree []map[string]any
+		var branches []string
+		result, errText := run(t, map[string]any{"owner": "octo", "repo": "app", "workflows": []any{"lint.yml"}, "base": "production"}, &tree, &branches)
+		require.Empty(t, errText)
+
+		assert.Empty(t, result.Pinned)
+		assert.Empty(t, branches)
+		assert.Contains(t, result.Message, "already pinned")
+	})
+
+	t.Run("missing base branch", func(t *testing.T) {
+		var tree []map[string]any
+		var branches []string
+		_, errText := run(t, map[string]any{"owner": "octo", "repo": "app", "base": "nope"}, &tree, &branches)
+		assert.Contains(t, errText, "failed to get base branch nope")
+	})
+}
@@ -2,8 +2,10 @@ package github
 
 import (
 	"context"
+	"encoding/base64"
 	"encoding/json"
 	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/github/github-mcp-server/internal/toolsnaps"
@@ -267,6 +269,96 @@ func Test_ActionsGet_GetWorkflow(t *testing.T) {
 	})
 }
 
+func Test_ActionsGet_GetWorkflowDispatchSchema(t *testing.T) {
+	toolDef := ActionsGet(translations.NullTranslationHelper)
+
+	workflowYAML := `name: Deploy
+on:
+  push:
+  workflow_dispatch:
+    inputs:
+      environment:
+        description: Target environment
+        type: choice
+        options: [staging, production]
+        required: true
+      dry_run:
+        type: boolean
+        default: true
+        required: true
+      version:
+        description: Version to deploy
+        required: true
+`
+
+	mockedClient := MockHTTPClientWithHandlers(map[string]http.HandlerFunc{
+		GetReposActionsWorkflowsByOwnerByRepoByWorkflowID: mockResponse(t, http.StatusOK, &github.Workflow{
+			ID:   github.Ptr(int64(1)),
+			Path: github.Ptr(".github/workflows/deploy.yml"),
+		}),
+		"GET /repos/{owner}/{repo}/contents/{path:.*}": mockResponse(t, http.StatusOK, &github.RepositoryContent{
+			Type:     github.Ptr("file"),
+			Path:     github.Ptr(".github/workflows/deploy.yml"),
+			Content:  github.Ptr(base64.StdEncoding.EncodeToString([]byte(workflowYAML))),
+			Encoding: github.Ptr("base64"),
+		}),
+	})
+
+	deps := BaseDeps{
+		Client: github.NewClient(mockedClient),
+	}
+	handler := toolDef.Handler(deps)
+
+	request := createMCPRequest(map[string]any{
+		"method":      "get_workflow_dispatch_schema",
+		"owner":       "owner",
+		"repo":        "repo",
+		"resource_id": "deploy.yml",
+	})
+	result, err := handler(ContextWithDeps(context.Background(), deps), &request)
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	textContent := getTextResult(t, result)
+	var response WorkflowDispatchSchema
+	require.NoError(t, json.Unmarshal([]byte(textContent.Text), &response))
+	assert.Equal(t, ".github/workflows/deploy.yml", response.Workflow)
+	assert.True(t, response.Dispatchable)
+	require.NotNil(t, response.Schema)
+	assert.Equal(t, []string{"environment", "version"}, response.Schema.Required)
+	assert.Equal(t, []any{"staging", "production"}, response.Schema.Properties["environment"].Enum)
+	assert.Equal(t, "boolean", response.Schema.Properties["dry_run"].Type)
+	assert.JSONEq(t, "true", string(response.Schema.Properties["dry_run"].Default))
+	assert.Equal(t, "string", response.Schema.Properties["version"].Type)
+}
+
+func Test_ParseWorkflowDispatchSchema(t *testing.T) {
+	tests := []struct {
+		name         string
+		content      string
+		dispatchable bool
+	}{
+		{name: "single event", content: "on: workflow_dispatch\n", dispatchable: true},
+		{name: "event list", content: "on: [push, workflow_dispatch]\n", dispatchable: true},
+		{name: "event without inputs", content: "on:\n  workflow_dispatch:\n", dispatchable: true},
+		{name: "not dispatchable", content: "on:\n  push:\n    branches: [main]\n", dispatchable: false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			schema, dispatchable, err := parseWorkflowDispatchSchema([]byte(tc.content))
+			require.NoError(t, err)
+			assert.Equal(t, tc.dispatchable, dispatchable)
+			if tc.dispatchable {
+				require.NotNil(t, schema)
+				assert.Empty(t, schema.Properties)
+			} else {
+				assert.Nil(t, schema)
+			}
+		})
+	}
+}
+
 func Test_ActionsGet_GetWorkflowRun(t *testing.T) {
 	toolDef := ActionsGet(translations.NullTranslationHelper)
 
@@ -408,6 +500,62 @@ func Test_ActionsRunTrigger_RunWorkflow(t *testing.T) {
 	}
 }
 
+func Test_ActionsRunTrigger_RerunWithDebugLogging(t *testing.T) {
+	toolDef := ActionsRunTrigger(translations.NullTranslationHelper)
+
+	tests := []struct {
+		name     string
+		method   string
+		endpoint string
+		message  string
+	}{
+		{
+			name:     "rerun workflow run with debug logging",
+			method:   "rerun_workflow_run",
+			endpoint: PostReposActionsRunsRerunByOwnerByRepoByRunID,
+			message:  "Workflow run has been queued for re-run",
+		},
+		{
+			name:     "rerun failed jobs with debug logging",
+			method:   "rerun_failed_jobs",
+			endpoint: PostReposActionsRunsRerunFailedJobsByOwnerByRepoByRunID,
+			message:  "Failed jobs have been queued for re-run",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockedClient := MockHTTPClientWithHandlers(map[string]http.HandlerFunc{
+				tc.endpoint: expectRequestBody(t, map[string]any{
+					"enable_debug_logging": true,
+				}).andThen(mockResponse(t, http.StatusCreated, map[string]any{})),
+			})
+
+			deps := BaseDeps{
+				Client: github.NewClient(mockedClient),
+			}
+			handler := toolDef.Handler(deps)
+
+			request := createMCPRequest(map[string]any{
+				"method":               tc.method,
+				"owner":                "owner",
+				"repo":                 "repo",
+				"run_id":               float64(12345),
+				"enable_debug_logging": true,
+			})
+			result, err := handler(ContextWithDeps(context.Background(), deps), &request)
+			require.NoError(t, err)
+			require.False(t, result.IsError)
+
+			textContent := getTextResult(t, result)
+			var response map[string]any
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &response))
+			assert.Equal(t, tc.message, response["message"])
+			assert.Equal(t, true, response["enable_debug_logging"])
+		})
+	}
+}
+
 func Test_ActionsRunTrigger_CancelWorkflowRun(t *testing.T) {
 	toolDef := ActionsRunTrigger(translations.NullTranslationHelper)
 
@@ -552,6 +700,50 @@ func Test_ActionsGetJobLogs_SingleJob(t *testing.T) {
 	})
 }
 
+func Test_ActionsGetJobLogs_DebugOnly(t *testing.T) {
+	toolDef := ActionsGetJobLogs(translations.NullTranslationHelper)
+
+	logContent := "2024-01-01T00:00:00Z ##[group]Run tests\n" +
+		"2024-01-01T00:00:01Z ##[debug]Evaluating condition for step: 'Run tests'\n" +
+		"2024-01-01T00:00:02Z FAIL TestCache\n" +
+		"2024-01-01T00:00:03Z ##[debug]Finishing: Run tests"
+	logServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(logContent))
+	}))
+	defer logServer.Close()
+
+	mockedClient := MockHTTPClientWithHandlers(map[string]http.HandlerFunc{
+		GetReposActionsJobsLogsByOwnerByRepoByJobID: http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.Header().Set("Location", logServer.URL)
+			w.WriteHeader(http.StatusFound)
+		}),
+	})
+
+	deps := BaseDeps{
+		Client:            github.NewClient(mockedClient),
+		ContentWindowSize: 5000,
+	}
+	handler := toolDef.Handler(deps)
+
+	request := createMCPRequest(map[string]any{
+		"owner":      "owner",
+		"repo":       "repo",
+		"job_id":     float64(123),
+		"debug_only": true,
+	})
+	result, err := handler(ContextWithDeps(context.Background(), deps), &request)
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	textContent := getTextResult(t, result)
+	var response map[string]any
+	require.NoError(t, json.Unmarshal([]byte(textContent.Text), &response))
+	assert.Equal(t, float64(2), response["debug_line_count"])
+	assert.Equal(t, "2024-01-01T00:00:01Z ##[debug]Evaluating condition for step: 'Run tests'\n2024-01-01T00:00:03Z ##[debug]Finishing: Run tests", response["logs_content"])
+	assert.Equal(t, "Job debug log excerpts retrieved successfully", response["message"])
+}
+
 func Test_ActionsGetJobLogs_FailedJobs(t *testing.T) {
 	toolDef := ActionsGetJobLogs(translations.NullTranslationHelper)
 
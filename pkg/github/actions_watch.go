@@ -0,0 +1,222 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"slices"
+	"time"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/inventory"
+	"github.com/github/github-mcp-server/pkg/scopes"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/github/github-mcp-server/pkg/utils"
+	"github.com/google/go-github/v82/github"
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+const (
+	// defaultRunWatchTimeout and maxRunWatchTimeout bound how long watch_workflow_run waits for a
+	// run to complete, in seconds.
+	defaultRunWatchTimeout = 600
+	maxRunWatchTimeout     = 1800
+	// defaultRunWatchInterval is the initial delay between checks of the run, in seconds.
+	defaultRunWatchInterval = 10
+	// defaultRunWatchTailLines is the number of lines of the log of each failed job returned.
+	defaultRunWatchTailLines = 50
+)
+
+// failedJobConclusions are the conclusions of jobs whose logs watch_workflow_run returns.
+var failedJobConclusions = []string{"failure", "timed_out"}
+
+// WatchedWorkflowRun is the result of watch_workflow_run.
+type WatchedWorkflowRun struct {
+	RunID          int64  `json:"run_id"`
+	Name           string `json:"name"`
+	Status         string `json:"status"`
+	Conclusion     string `json:"conclusion,omitempty"`
+	RunAttempt     int    `json:"run_attempt"`
+	HeadBranch     string `json:"head_branch,omitempty"`
+	HeadSHA        string `json:"head_sha,omitempty"`
+	HTMLURL        string `json:"html_url"`
+	Completed      bool   `json:"completed"`
+	Polls          int    `json:"polls"`
+	ElapsedSeconds int    `json:"elapsed_seconds"`
+	Message        string `json:"message,omitempty"`
+	// FailedJobs holds the log excerpts of the failed jobs of a completed run.
+	FailedJobs []map[string]any `json:"failed_jobs,omitempty"`
+}
+
+// WatchWorkflowRun creates a tool that waits for a workflow run to complete, notifying the client
+// of its progress, and returns its conclusion with the log excerpts of its failed jobs.
+func WatchWorkflowRun(t translations.TranslationHelperFunc) inventory.ServerTool {
+	return NewTool(
+		ToolsetMetadataActions,
+		mcp.Tool{
+			Name: "watch_workflow_run",
+			Description: t("TOOL_WATCH_WORKFLOW_RUN_DESCRIPTION", `Wait for a GitHub Actions workflow run to complete, checking its status with a growing delay and reporting it as progress, then return its conclusion together with the last lines of the logs of its failed jobs.
+Use this after triggering or re-running a workflow instead of polling it with actions_get. If the run is still going when the timeout passes, call this tool again to keep waiting.`),
+			Annotations: &mcp.ToolAnnotations{
+				Title:        t("TOOL_WATCH_WORKFLOW_RUN_USER_TITLE", "Watch workflow run"),
+				ReadOnlyHint: true,
+			},
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"owner": {
+						Type:        "string",
+						Description: "Repository owner",
+					},
+					"repo": {
+						Type:        "string",
+						Description: "Repository name",
+					},
+					"run_id": {
+						Type:        "number",
+						Description: "The unique identifier of the workflow run",
+					},
+					"timeout_seconds": {
+						Type:        "number",
+						Description: fmt.Sprintf("How long to wait for the run to complete, in seconds (default %d, max %d)", defaultRunWatchTimeout, maxRunWatchTimeout),
+						Minimum:     jsonschema.Ptr(1.0),
+						Maximum:     jsonschema.Ptr(float64(maxRunWatchTimeout)),
+					},
+					"interval_seconds": {
+						Type:        "number",
+						Description: fmt.Sprintf("Delay before the second check, in seconds (default %d). Later delays grow by half, up to %d seconds", defaultRunWatchInterval, maxWatchInterval),
+						Minimum:     jsonschema.Ptr(1.0),
+						Maximum:     jsonschema.Ptr(float64(maxWatchInterval)),
+					},
+					"tail_lines": {
+						Type:        "number",
+						Description: fmt.Sprintf("Number of lines to return from the end of the log of each failed job (default %d)", defaultRunWatchTailLines),
+						Minimum:     jsonschema.Ptr(1.0),
+					},
+				},
+				Required: []string{"owner", "repo", "run_id"},
+			},
+		},
+		[]scopes.Scope{scopes.Repo},
+		func(ctx context.Context, deps ToolDependencies, request *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+			owner, err := RequiredParam[string](args, "owner")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			repo, err := RequiredParam[string](args, "repo")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			runID, err := RequiredBigInt(args, "run_id")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			timeoutSeconds, err := OptionalIntParamWithDefault(args, "timeout_seconds", defaultRunWatchTimeout)
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			intervalSeconds, err := OptionalIntParamWithDefault(args, "interval_seconds", defaultRunWatchInterval)
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			tailLines, err := OptionalIntParamWithDefault(args, "tail_lines", defaultRunWatchTailLines)
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			if tailLines <= 0 {
+				tailLines = defaultRunWatchTailLines
+			}
+
+			client, err := deps.GetClient(ctx)
+			if err != nil {
+				return utils.NewToolResultErrorFromErr("failed to get GitHub client", err), nil, nil
+			}
+
+			unit := watchTimeUnit(ctx)
+			start := time.Now()
+			var run *github.WorkflowRun
+			var polls int
+			var failure *mcp.CallToolResult
+			completed, err := pollUntil(ctx, request,
+				time.Duration(min(max(timeoutSeconds, 1), maxRunWatchTimeout))*unit,
+				time.Duration(min(max(intervalSeconds, 1), maxWatchInterval))*unit,
+				time.Duration(maxWatchInterval)*unit,
+				func(n int) (bool, string, error) {
+					polls = n
+					var resp *github.Response
+					var err error
+					run, resp, err = client.Actions.GetWorkflowRunByID(ctx, owner, repo, runID)
+					if err != nil {
+						if ctx.Err() != nil {
+							return false, "", ctx.Err()
+						}
+						failure = ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to get workflow run", resp, err)
+						return true, "", nil
+					}
+					_ = resp.Body.Close()
+					return run.GetStatus() == "completed", fmt.Sprintf("Workflow run %q is %s", run.GetName(), run.GetStatus()), nil
+				})
+			if err != nil {
+				return nil, nil, err
+			}
+			if failure != nil {
+				return failure, nil, nil
+			}
+
+			watched := WatchedWorkflowRun{
+				RunID:          run.GetID(),
+				Name:           run.GetName(),
+				Status:         run.GetStatus(),
+				Conclusion:     run.GetConclusion(),
+				RunAttempt:     run.GetRunAttempt(),
+				HeadBranch:     run.GetHeadBranch(),
+				HeadSHA:        run.GetHeadSHA(),
+				HTMLURL:        run.GetHTMLURL(),
+				Completed:      completed,
+				Polls:          polls,
+				ElapsedSeconds: int(math.Round(float64(time.Since(start)) / float64(unit))),
+			}
+			if !completed {
+				watched.Message = fmt.Sprintf("the run is still %s after %d seconds; call watch_workflow_run again to keep waiting", run.GetStatus(), timeoutSeconds)
+				return MarshalledTextResult(watched), nil, nil
+			}
+
+			jobs, resp, err := client.Actions.ListWorkflowJobs(ctx, owner, repo, runID, &github.ListWorkflowJobsOptions{
+				Filter:      "latest",
+				ListOptions: github.ListOptions{PerPage: 100},
+			})
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to list workflow jobs", resp, err), nil, nil
+			}
+			_ = resp.Body.Close()
+			for _, job := range jobs.Jobs {
+				if !slices.Contains(failedJobConclusions, job.GetConclusion()) {
+					continue
+				}
+				jobResult, resp, err := getJobLogData(ctx, client, owner, repo, job.GetID(), job.GetName(), true, false, tailLines, ContentWindowSize(ctx, deps))
+				if err != nil {
+					// Report the conclusion even when a log cannot be read
+					jobResult = map[string]any{
+						"job_id":   job.GetID(),
+						"job_name": job.GetName(),
+						"error":    err.Error(),
+					}
+					_, _ = ghErrors.NewGitHubAPIErrorToCtx(ctx, "failed to get job logs", resp, err)
+				}
+				jobResult["conclusion"] = job.GetConclusion()
+				var failedSteps []string
+				for _, step := range job.Steps {
+					if slices.Contains(failedJobConclusions, step.GetConclusion()) {
+						failedSteps = append(failedSteps, step.GetName())
+					}
+				}
+				if len(failedSteps) > 0 {
+					jobResult["failed_steps"] = failedSteps
+				}
+				watched.FailedJobs = append(watched.FailedJobs, jobResult)
+			}
+			return MarshalledTextResult(watched), nil, nil
+		},
+	)
+}
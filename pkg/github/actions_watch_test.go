@@ -0,0 +1,134 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v82/github"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_WatchWorkflowRun(t *testing.T) {
+	serverTool := WatchWorkflowRun(translations.NullTranslationHelper)
+	tool := serverTool.Tool
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+	assert.True(t, tool.Annotations.ReadOnlyHint)
+
+	logServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte("Run go test ./...\n--- FAIL: TestCache\nFAIL"))
+	}))
+	defer logServer.Close()
+
+	// newHandlers serves a run that is in progress for the given number of checks, then fails
+	newHandlers := func(inProgressPolls int) map[string]http.HandlerFunc {
+		polls := 0
+		return map[string]http.HandlerFunc{
+			GetReposActionsRunsByOwnerByRepoByRunID: func(w http.ResponseWriter, _ *http.Request) {
+				polls++
+				run := &github.WorkflowRun{
+					ID:         github.Ptr(int64(42)),
+					Name:       github.Ptr("CI"),
+					Status:     github.Ptr("in_progress"),
+					RunAttempt: github.Ptr(1),
+					HTMLURL:    github.Ptr("https://github.com/owner/repo/actions/runs/42"),
+				}
+				if polls > inProgressPolls {
+					run.Status = github.Ptr("completed")
+					run.Conclusion = github.Ptr("failure")
+				}
+				w.WriteHeader(http.StatusOK)
+				_ = json.NewEncoder(w).Encode(run)
+			},
+			GetReposActionsRunsJobsByOwnerByRepoByRunID: func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "latest", r.URL.Query().Get("filter"))
+				w.WriteHeader(http.StatusOK)
+				_ = json.NewEncoder(w).Encode(&github.Jobs{
+					TotalCount: github.Ptr(2),
+					Jobs: []*github.WorkflowJob{
+						{ID: github.Ptr(int64(1)), Name: github.Ptr("lint"), Conclusion: github.Ptr("success")},
+						{
+							ID:         github.Ptr(int64(2)),
+							Name:       github.Ptr("test"),
+							Conclusion: github.Ptr("failure"),
+							Steps: []*github.TaskStep{
+								{Name: github.Ptr("Checkout"), Conclusion: github.Ptr("success")},
+								{Name: github.Ptr("Run tests"), Conclusion: github.Ptr("failure")},
+							},
+						},
+					},
+				})
+			},
+			GetReposActionsJobsLogsByOwnerByRepoByJobID: func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/repos/owner/repo/actions/jobs/2/logs", r.URL.Path)
+				w.Header().Set("Location", logServer.URL)
+				w.WriteHeader(http.StatusFound)
+			},
+		}
+	}
+
+	watch := func(t *testing.T, ctx context.Context, inProgressPolls int, args map[string]any) WatchedWorkflowRun {
+		t.Helper()
+		deps := BaseDeps{
+			Client:            github.NewClient(MockHTTPClientWithHandlers(newHandlers(inProgressPolls))),
+			ContentWindowSize: 5000,
+		}
+		request := createMCPRequest(args)
+		ctx = context.WithValue(ContextWithDeps(ctx, deps), watchTimeUnitKey{}, time.Millisecond)
+		result, err := serverTool.Handler(deps)(ctx, &request)
+		require.NoError(t, err)
+		require.False(t, result.IsError)
+		var watched WatchedWorkflowRun
+		require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &watched))
+		return watched
+	}
+
+	t.Run("waits for the run and returns the logs of failed jobs", func(t *testing.T) {
+		watched := watch(t, context.Background(), 2, map[string]any{
+			"owner":            "owner",
+			"repo":             "repo",
+			"run_id":           float64(42),
+			"interval_seconds": float64(1),
+		})
+		assert.True(t, watched.Completed)
+		assert.Equal(t, 3, watched.Polls)
+		assert.Equal(t, "completed", watched.Status)
+		assert.Equal(t, "failure", watched.Conclusion)
+		require.Len(t, watched.FailedJobs, 1)
+		job := watched.FailedJobs[0]
+		assert.Equal(t, "test", job["job_name"])
+		assert.Equal(t, []any{"Run tests"}, job["failed_steps"])
+		assert.Contains(t, job["logs_content"], "--- FAIL: TestCache")
+	})
+
+	t.Run("reports a run still in progress when the timeout passes", func(t *testing.T) {
+		watched := watch(t, context.Background(), 1000, map[string]any{
+			"owner":            "owner",
+			"repo":             "repo",
+			"run_id":           float64(42),
+			"timeout_seconds":  float64(20),
+			"interval_seconds": float64(5),
+		})
+		assert.False(t, watched.Completed)
+		assert.Equal(t, "in_progress", watched.Status)
+		assert.Contains(t, watched.Message, "call watch_workflow_run again")
+		assert.Empty(t, watched.FailedJobs)
+	})
+
+	t.Run("stops when the request is cancelled", func(t *testing.T) {
+		deps := BaseDeps{Client: github.NewClient(MockHTTPClientWithHandlers(newHandlers(1000)))}
+		ctx, cancel := context.WithCancel(ContextWithDeps(context.Background(), deps))
+		ctx = context.WithValue(ctx, watchTimeUnitKey{}, time.Millisecond)
+		time.AfterFunc(10*time.Millisecond, cancel)
+		request := createMCPRequest(map[string]any{"owner": "owner", "repo": "repo", "run_id": float64(42)})
+		_, err := serverTool.Handler(deps)(ctx, &request)
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+}
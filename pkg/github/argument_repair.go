@@ -0,0 +1,232 @@
+package github
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/github/github-mcp-server/pkg/inventory"
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// ArgumentRepairsMetaKey is the _meta key under which tool results list the repairs made to the
+// caller's arguments.
+const ArgumentRepairsMetaKey = "github.com/argument-repairs"
+
+// repositoryAliases are argument names agents commonly use for an "owner/name" repository.
+var repositoryAliases = []string{"repository", "full_name", "repo_full_name", "nameWithOwner"}
+
+// repairArguments normalizes common mistakes in tool arguments before the handler validates them,
+// and returns a description of each repair. Repairs only ever produce arguments the tool's input
+// schema declares:
+//   - names differing from a declared name only in case or separators are renamed to it
+//   - an "owner/name" repository is split into owner and repo
+//   - strings holding numbers or booleans are converted to the declared type
+func repairArguments(tool *mcp.Tool, args map[string]any) []string {
+	schema, ok := tool.InputSchema.(*jsonschema.Schema)
+	if !ok || len(args) == 0 {
+		return nil
+	}
+
+	var repairs []string
+
+	declaredByKey := make(map[string]string, len(schema.Properties))
+	for name := range schema.Properties {
+		declaredByKey[argumentNameKey(name)] = name
+	}
+	for _, name := range slices.Sorted(maps.Keys(args)) {
+		if _, declared := schema.Properties[name]; declared {
+			continue
+		}
+		target, ok := declaredByKey[argumentNameKey(name)]
+		if !ok {
+			continue
+		}
+		if _, present := args[target]; present {
+			continue
+		}
+		args[target] = args[name]
+		delete(args, name)
+		repairs = append(repairs, fmt.Sprintf("renamed %q to %q", name, target))
+	}
+
+	if _, declared := schema.Properties["owner"]; declared {
+		if _, declared := schema.Properties["repo"]; declared {
+			repairs = append(repairs, splitRepositoryArgument(args)...)
+		}
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(args)) {
+		value := args[name]
+		prop, declared := schema.Properties[name]
+		if !declared {
+			continue
+		}
+		s, ok := value.(string)
+		if !ok {
+			continue
+		}
+		switch prop.Type {
+		case "number", "integer":
+			trimmed := strings.TrimPrefix(strings.TrimSpace(s), "#")
+			n, err := strconv.ParseFloat(trimmed, 64)
+			if err != nil || (prop.Type == "integer" && n != float64(int64(n))) {
+				continue
+			}
+			args[name] = n
+			repairs = append(repairs, fmt.Sprintf("converted %q from string to number", name))
+		case "boolean":
+			b, err := strconv.ParseBool(strings.TrimSpace(s))
+			if err != nil {
+				continue
+			}
+			args[name] = b
+			repairs = append(repairs, fmt.Sprintf("converted %q from string to boolean", name))
+		}
+	}
+
+	return repairs
+}
+
+// splitRepositoryArgument fills in owner and repo from an "owner/name" value passed as repo or
+// under one of the repositoryAliases.
+func splitRepositoryArgument(args map[string]any) []string {
+	var repairs []string
+
+	if repo, ok := args["repo"].(string); ok {
+		if owner, name, found := strings.Cut(repo, "/"); found && owner != "" && name != "" && !strings.Contains(name, "/") {
+			current, _ := args["owner"].(string)
+			if current == "" || strings.EqualFold(current, owner) {
+				args["owner"] = owner
+				args["repo"] = name
+				repairs = append(repairs, fmt.Sprintf("split repo %q into owner and repo", repo))
+			}
+		}
+		return repairs
+	}
+
+	for _, alias := range repositoryAliases {
+		value, ok := args[alias].(string)
+		if !ok {
+			continue
+		}
+		owner, name, found := strings.Cut(value, "/")
+		if !found || owner == "" || name == "" || strings.Contains(name, "/") {
+			continue
+		}
+		if current, _ := args["owner"].(string); current != "" && !strings.EqualFold(current, owner) {
+			continue
+		}
+		args["owner"] = owner
+		args["repo"] = name
+		delete(args, alias)
+		repairs = append(repairs, fmt.Sprintf("split %s %q into owner and repo", alias, value))
+		break
+	}
+	return repairs
+}
+
+// argumentNameKey folds case and separators so that issueNumber, issue_number and issue-number
+// all name the same argument.
+func argumentNameKey(name string) string {
+	return strings.ToLower(strings.NewReplacer("_", "", "-", "").Replace(name))
+}
+
+// withArgumentRepairs records the repairs made to the arguments in the result's _meta.
+func withArgumentRepairs(result *mcp.CallToolResult, repairs []string) *mcp.CallToolResult {
+	if result == nil || len(repairs) == 0 {
+		return result
+	}
+	if result.Meta == nil {
+		result.Meta = mcp.Meta{}
+	}
+	result.Meta[ArgumentRepairsMetaKey] = repairs
+	return result
+}
+
+// ArgumentRepairMiddleware repairs the arguments of tool calls and fills in the session's
+// defaults, as NewTool does, before the middleware checking the arguments runs, so that the
+// checks see the arguments the tool runs with. The arguments of the tool watch_resource polls are
+// repaired for that tool. It must run inside DeprecatedAliasMiddleware, to repair the arguments
+// for the tool actually called, and outside the middleware checking arguments.
+func ArgumentRepairMiddleware(inv *inventory.Inventory) mcp.Middleware {
+	return func(next mcp.MethodHandler) mcp.MethodHandler {
+		return func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+			callReq, ok := req.(*mcp.CallToolRequest)
+			if method != "tools/call" || !ok || callReq.Params == nil {
+				return next(ctx, method, req)
+			}
+			tool, _, err := inv.FindToolByName(callReq.Params.Name)
+			if err != nil {
+				return next(ctx, method, req)
+			}
+			args, ok := decodeArguments(callReq.Params.Arguments)
+			if !ok {
+				return next(ctx, method, req)
+			}
+
+			repairs := repairArguments(&tool.Tool, args)
+			applySessionContext(callReq, &tool.Tool, args)
+			if tool.Tool.Name == "watch_resource" {
+				repairWatchedArguments(callReq, inv, args)
+			}
+			rawArgs, err := json.Marshal(args)
+			if err != nil {
+				return next(ctx, method, req)
+			}
+
+			params := *callReq.Params
+			params.Arguments = rawArgs
+			repaired := *callReq
+			repaired.Params = &params
+			result, err := next(ctx, method, &repaired)
+			if toolResult, ok := result.(*mcp.CallToolResult); ok && len(repairs) > 0 {
+				result = withArgumentRepairs(toolResult, repairs)
+			}
+			return result, err
+		}
+	}
+}
+
+// decodeArguments decodes the arguments of a tool call, keeping numbers as they were written.
+func decodeArguments(rawArgs json.RawMessage) (map[string]any, bool) {
+	args := map[string]any{}
+	if len(rawArgs) == 0 {
+		return args, true
+	}
+	decoder := json.NewDecoder(bytes.NewReader(rawArgs))
+	decoder.UseNumber()
+	if decoder.Decode(&args) != nil || args == nil {
+		return nil, false
+	}
+	return args, true
+}
+
+// repairWatchedArguments repairs the arguments of the tool a watch_resource call polls, called
+// through a deprecated alias or not, and fills in the session's defaults.
+func repairWatchedArguments(req *mcp.CallToolRequest, inv *inventory.Inventory, args map[string]any) {
+	name, _ := args["tool"].(string)
+	watchedArgs, ok := args["arguments"].(map[string]any)
+	if name == "" || !ok {
+		return
+	}
+	alias := name
+	if replacement, ok := DeprecatedToolAliases[name]; ok {
+		name = replacement
+	}
+	tool, _, err := inv.FindToolByName(name)
+	if err != nil {
+		return
+	}
+	if name != alias {
+		applyDeprecatedAliasCall(DeprecatedToolAliasCalls[alias], &tool.Tool, watchedArgs)
+	}
+	repairArguments(&tool.Tool, watchedArgs)
+	applySessionContext(req, &tool.Tool, watchedArgs)
+}
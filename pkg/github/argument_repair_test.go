@@ -0,0 +1,120 @@
+package github
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v82/github"
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_RepairArguments(t *testing.T) {
+	tool := &mcp.Tool{
+		Name: "test_tool",
+		InputSchema: &jsonschema.Schema{
+			Type: "object",
+			Properties: map[string]*jsonschema.Schema{
+				"owner":        {Type: "string"},
+				"repo":         {Type: "string"},
+				"issue_number": {Type: "number"},
+				"per_page":     {Type: "integer"},
+				"draft":        {Type: "boolean"},
+				"title":        {Type: "string"},
+			},
+		},
+	}
+
+	tests := []struct {
+		name            string
+		args            map[string]any
+		expectedArgs    map[string]any
+		expectedRepairs []string
+	}{
+		{
+			name:         "valid arguments are untouched",
+			args:         map[string]any{"owner": "octo", "repo": "hello", "issue_number": float64(1)},
+			expectedArgs: map[string]any{"owner": "octo", "repo": "hello", "issue_number": float64(1)},
+		},
+		{
+			name:            "repository split into owner and repo",
+			args:            map[string]any{"repository": "octo/hello"},
+			expectedArgs:    map[string]any{"owner": "octo", "repo": "hello"},
+			expectedRepairs: []string{`split repository "octo/hello" into owner and repo`},
+		},
+		{
+			name:            "repo with owner prefix",
+			args:            map[string]any{"owner": "octo", "repo": "octo/hello"},
+			expectedArgs:    map[string]any{"owner": "octo", "repo": "hello"},
+			expectedRepairs: []string{`split repo "octo/hello" into owner and repo`},
+		},
+		{
+			name:         "repo with conflicting owner is left for the handler to reject",
+			args:         map[string]any{"owner": "other", "repo": "octo/hello"},
+			expectedArgs: map[string]any{"owner": "other", "repo": "octo/hello"},
+		},
+		{
+			name: "names differing in case or separators renamed",
+			args: map[string]any{"owner": "octo", "repo": "hello", "issueNumber": float64(1), "per-page": float64(5)},
+			expectedArgs: map[string]any{
+				"owner": "octo", "repo": "hello", "issue_number": float64(1), "per_page": float64(5),
+			},
+			expectedRepairs: []string{`renamed "issueNumber" to "issue_number"`, `renamed "per-page" to "per_page"`},
+		},
+		{
+			name:         "alias does not overwrite the declared name",
+			args:         map[string]any{"issue_number": float64(1), "issueNumber": float64(2)},
+			expectedArgs: map[string]any{"issue_number": float64(1), "issueNumber": float64(2)},
+		},
+		{
+			name: "strings coerced to numbers and booleans",
+			args: map[string]any{"issue_number": "#42", "per_page": " 10 ", "draft": "true", "title": "123"},
+			expectedArgs: map[string]any{
+				"issue_number": float64(42), "per_page": float64(10), "draft": true, "title": "123",
+			},
+			expectedRepairs: []string{
+				`converted "draft" from string to boolean`,
+				`converted "issue_number" from string to number`,
+				`converted "per_page" from string to number`,
+			},
+		},
+		{
+			name:         "unparseable values are left for the handler to reject",
+			args:         map[string]any{"issue_number": "forty-two", "per_page": "1.5", "draft": "maybe"},
+			expectedArgs: map[string]any{"issue_number": "forty-two", "per_page": "1.5", "draft": "maybe"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			repairs := repairArguments(tool, tc.args)
+			assert.Equal(t, tc.expectedArgs, tc.args)
+			assert.Equal(t, tc.expectedRepairs, repairs)
+		})
+	}
+}
+
+func Test_RepairArgumentsInToolCall(t *testing.T) {
+	serverTool := ListBranches(translations.NullTranslationHelper)
+	deps := BaseDeps{Client: github.NewClient(MockHTTPClientWithHandlers(map[string]http.HandlerFunc{
+		"GET /repos/octo/hello/branches": expectQueryParams(t, map[string]string{"page": "1", "per_page": "5"}).andThen(
+			mockResponse(t, http.StatusOK, []*github.Branch{{Name: github.Ptr("main")}}),
+		),
+	}))}
+	handler := serverTool.Handler(deps)
+
+	request := createMCPRequest(map[string]any{"repository": "octo/hello", "per_page": "5"})
+	result, err := handler(ContextWithDeps(context.Background(), deps), &request)
+	require.NoError(t, err)
+	require.False(t, result.IsError, getTextResult(t, result).Text)
+
+	assert.Equal(t, []string{
+		`renamed "per_page" to "perPage"`,
+		`split repository "octo/hello" into owner and repo`,
+		`converted "perPage" from string to number`,
+	}, result.Meta[ArgumentRepairsMetaKey])
+}
@@ -0,0 +1,84 @@
+package github
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/github/github-mcp-server/pkg/audit"
+	"github.com/github/github-mcp-server/pkg/http/transport"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// AuditLogMiddleware writes an audit record of every write tool call to sink: the tool, its
+// arguments with secrets redacted, the caller and whether the call succeeded. The caller's login
+// is resolved with the GitHub client of the call. Records are written in the background, so tool
+// calls never wait for the sink, and failed writes are logged. It must run inside
+// InjectDepsMiddleware and DeprecatedAliasMiddleware, and outside RootsEnforcementMiddleware, to
+// record its denials.
+func AuditLogMiddleware(sink audit.Sink, logger *slog.Logger, isWriteTool func(name string) bool) mcp.Middleware {
+	return func(next mcp.MethodHandler) mcp.MethodHandler {
+		return func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+			result, err := next(ctx, method, req)
+			callReq, ok := req.(*mcp.CallToolRequest)
+			// Dry runs change nothing, so they are not recorded
+			if method != "tools/call" || !ok || callReq.Params == nil || !isWriteTool(callReq.Params.Name) || transport.DryRunFromContext(ctx) != nil {
+				return result, err
+			}
+
+			record := audit.Record{
+				Timestamp: time.Now().UTC(),
+				Tool:      callReq.Params.Name,
+				Arguments: audit.RedactArguments(callReq.Params.Arguments),
+				Caller:    audit.Caller{SessionID: sessionID(callReq)},
+				Status:    audit.StatusSuccess,
+			}
+			if callReq.Session != nil {
+				if params := callReq.Session.InitializeParams(); params != nil && params.ClientInfo != nil {
+					record.Caller.Client = params.ClientInfo.Name
+					record.Caller.ClientVersion = params.ClientInfo.Version
+				}
+			}
+			toolResult, _ := result.(*mcp.CallToolResult)
+			switch {
+			case err != nil:
+				record.Status = audit.StatusError
+				record.Error = truncateRecordText(audit.RedactText(err.Error()))
+			case isToolError(result):
+				record.Status = audit.StatusError
+				if _, denied := toolResult.Meta[RootsViolationMetaKey]; denied {
+					record.Status = audit.StatusDenied
+				}
+				record.Error = truncateRecordText(audit.RedactText(toolResultText(toolResult)))
+			}
+
+			go func() {
+				ctx := context.WithoutCancel(ctx)
+				record.Caller.Login = callerLogin(ctx)
+				if err := sink.Write(ctx, record); err != nil {
+					logger.Warn("failed to write audit record", "tool", record.Tool, "error", err)
+				}
+			}()
+			return result, err
+		}
+	}
+}
+
+// callerLogin returns the login of the user the GitHub client of ctx authenticates as, or "" when
+// it cannot be resolved, as for GitHub App installation tokens.
+func callerLogin(ctx context.Context) string {
+	deps, ok := DepsFromContext(ctx)
+	if !ok {
+		return ""
+	}
+	client, err := deps.GetClient(ctx)
+	if err != nil {
+		return ""
+	}
+	user, resp, err := client.Users.Get(ctx, "")
+	if err != nil {
+		return ""
+	}
+	_ = resp.Body.Close()
+	return user.GetLogin()
+}
@@ -0,0 +1,645 @@
+package github
+
+import (
+	"context"
+	"fmt"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/inventory"
+	"github.com/github/github-mcp-server/pkg/scopes"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/github/github-mcp-server/pkg/utils"
+	"github.com/google/go-github/v82/github"
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// checkRunAnnotationBatchSize is the maximum number of annotations the API accepts per request.
+// Further annotations are added by updating the check run.
+const checkRunAnnotationBatchSize = 50
+
+// MinimalCheckRunAnnotation is the trimmed output type for check run annotations.
+type MinimalCheckRunAnnotation struct {
+	Path            string `json:"path"`
+	StartLine       int    `json:"start_line"`
+	EndLine         int    `json:"end_line"`
+	AnnotationLevel string `json:"annotation_level"`
+	Title           string `json:"title,omitempty"`
+	Message         string `json:"message"`
+	RawDetails      string `json:"raw_details,omitempty"`
+}
+
+// checkRunOutputSchema returns the properties describing the output of a check run, shared by
+// the tools creating and updating check runs.
+func checkRunOutputSchema() map[string]*jsonschema.Schema {
+	return map[string]*jsonschema.Schema{
+		"status": {
+			Type:        "string",
+			Description: "Status of the check run",
+			Enum:        []any{"queued", "in_progress", "completed"},
+		},
+		"conclusion": {
+			Type:        "string",
+			Description: "Conclusion of the check run. Required when status is completed, and setting it marks the check run completed.",
+			Enum:        []any{"success", "failure", "neutral", "cancelled", "skipped", "timed_out", "action_required"},
+		},
+		"details_url": {
+			Type:        "string",
+			Description: "URL of the integrator's site with the full details of the check",
+		},
+		"title": {
+			Type:        "string",
+			Description: "Title of the check run output. Required with summary, text or annotations.",
+		},
+		"summary": {
+			Type:        "string",
+			Description: "Summary of the check run output in Markdown. Required with title, text or annotations.",
+		},
+		"text": {
+			Type:        "string",
+			Description: "Details of the check run output in Markdown",
+		},
+		"annotations": {
+			Type:        "array",
+			Description: "Annotations on lines of files, shown on the pull request diff",
+			Items: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"path": {
+						Type:        "string",
+						Description: "Path of the annotated file, relative to the repository root",
+					},
+					"start_line": {
+						Type:        "number",
+						Description: "First annotated line",
+					},
+					"end_line": {
+						Type:        "number",
+						Description: "Last annotated line. Defaults to start_line.",
+					},
+					"annotation_level": {
+						Type:        "string",
+						Description: "Level of the annotation",
+						Enum:        []any{"notice", "warning", "failure"},
+					},
+					"title": {
+						Type:        "string",
+						Description: "Title of the annotation",
+					},
+					"message": {
+						Type:        "string",
+						Description: "Message of the annotation",
+					},
+				},
+				Required: []string{"path", "start_line", "annotation_level", "message"},
+			},
+		},
+	}
+}
+
+// checkRunOutputParams reads the output of a check run, returning nil if none was given.
+func checkRunOutputParams(args map[string]any) (*github.CheckRunOutput, error) {
+	title, err := OptionalParam[string](args, "title")
+	if err != nil {
+		return nil, err
+	}
+	summary, err := OptionalParam[string](args, "summary")
+	if err != nil {
+		return nil, err
+	}
+	text, err := OptionalParam[string](args, "text")
+	if err != nil {
+		return nil, err
+	}
+
+	var annotations []*github.CheckRunAnnotation
+	if raw, ok := args["annotations"]; ok && raw != nil {
+		items, ok := raw.([]any)
+		if !ok {
+			return nil, fmt.Errorf("annotations must be an array of objects")
+		}
+		for i, item := range items {
+			a, ok := item.(map[string]any)
+			if !ok {
+				return nil, fmt.Errorf("annotation %d must be an object", i)
+			}
+			path, err := RequiredParam[string](a, "path")
+			if err != nil {
+				return nil, fmt.Errorf("annotation %d: %w", i, err)
+			}
+			startLine, err := RequiredInt(a, "start_line")
+			if err != nil {
+				return nil, fmt.Errorf("annotation %d: %w", i, err)
+			}
+			endLine, err := OptionalIntParamWithDefault(a, "end_line", startLine)
+			if err != nil {
+				return nil, fmt.Errorf("annotation %d: %w", i, err)
+			}
+			level, err := RequiredParam[string](a, "annotation_level")
+			if err != nil {
+				return nil, fmt.Errorf("annotation %d: %w", i, err)
+			}
+			message, err := RequiredParam[string](a, "message")
+			if err != nil {
+				return nil, fmt.Errorf("annotation %d: %w", i, err)
+			}
+			annotation := &github.CheckRunAnnotation{
+				Path:            github.Ptr(path),
+				StartLine:       github.Ptr(startLine),
+				EndLine:         github.Ptr(endLine),
+				AnnotationLevel: github.Ptr(level),
+				Message:         github.Ptr(message),
+			}
+			if annotationTitle, err := OptionalParam[string](a, "title"); err != nil {
+				return nil, fmt.Errorf("annotation %d: %w", i, err)
+			} else if annotationTitle != "" {
+				annotation.Title = github.Ptr(annotationTitle)
+			}
+			annotations = append(annotations, annotation)
+		}
+	}
+
+	if title == "" && summary == "" && text == "" && len(annotations) == 0 {
+		return nil, nil
+	}
+	if title == "" || summary == "" {
+		return nil, fmt.Errorf("title and summary are required to set the output of a check run")
+	}
+	output := &github.CheckRunOutput{
+		Title:       github.Ptr(title),
+		Summary:     github.Ptr(summary),
+		Annotations: annotations,
+	}
+	if text != "" {
+		output.Text = github.Ptr(text)
+	}
+	return output, nil
+}
+
+// splitCheckRunAnnotations keeps the first batch of annotations on the output and returns the
+// remaining batches, which must be added by updating the check run.
+func splitCheckRunAnnotations(output *github.CheckRunOutput) [][]*github.CheckRunAnnotation {
+	if output == nil || len(output.Annotations) <= checkRunAnnotationBatchSize {
+		return nil
+	}
+	var batches [][]*github.CheckRunAnnotation
+	for rest := output.Annotations[checkRunAnnotationBatchSize:]; len(rest) > 0; {
+		n := min(checkRunAnnotationBatchSize, len(rest))
+		batches = append(batches, rest[:n])
+		rest = rest[n:]
+	}
+	output.Annotations = output.Annotations[:checkRunAnnotationBatchSize]
+	return batches
+}
+
+// addCheckRunAnnotations adds batches of annotations to a check run, returning the updated check
+// run.
+func addCheckRunAnnotations(ctx context.Context, client *github.Client, owner, repo string, checkRun *github.CheckRun, output *github.CheckRunOutput, batches [][]*github.CheckRunAnnotation) (*github.CheckRun, *github.Response, error) {
+	for _, batch := range batches {
+		updated, resp, err := client.Checks.UpdateCheckRun(ctx, owner, repo, checkRun.GetID(), github.UpdateCheckRunOptions{
+			Name: checkRun.GetName(),
+			Output: &github.CheckRunOutput{
+				Title:       output.Title,
+				Summary:     output.Summary,
+				Annotations: batch,
+			},
+		})
+		if err != nil {
+			return nil, resp, err
+		}
+		_ = resp.Body.Close()
+		checkRun = updated
+	}
+	return checkRun, nil, nil
+}
+
+// ListCheckRuns creates a tool to list the check runs of a commit.
+func ListCheckRuns(t translations.TranslationHelperFunc) inventory.ServerTool {
+	return NewTool(
+		ToolsetMetadataChecks,
+		mcp.Tool{
+			Name:        "list_check_runs",
+			Description: t("TOOL_LIST_CHECK_RUNS_DESCRIPTION", "List the check runs of a commit, branch or tag, including the check suite each belongs to. Use 'get_check_run_annotations' for the annotations a check run left on files, and 'rerequest_check_suite' to run a check suite again."),
+			Annotations: &mcp.ToolAnnotations{
+				Title:        t("TOOL_LIST_CHECK_RUNS_USER_TITLE", "List check runs"),
+				ReadOnlyHint: true,
+			},
+			InputSchema: WithPagination(&jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"owner": {
+						Type:        "string",
+						Description: "Repository owner",
+					},
+					"repo": {
+						Type:        "string",
+						Description: "Repository name",
+					},
+					"ref": {
+						Type:        "string",
+						Description: "Commit SHA, branch or tag name",
+					},
+					"check_name": {
+						Type:        "string",
+						Description: "Only list check runs with this name",
+					},
+					"status": {
+						Type:        "string",
+						Description: "Only list check runs with this status",
+						Enum:        []any{"queued", "in_progress", "completed"},
+					},
+					"filter": {
+						Type:        "string",
+						Description: "List only the latest check run of each name, or all of them including reruns",
+						Enum:        []any{"latest", "all"},
+					},
+				},
+				Required: []string{"owner", "repo", "ref"},
+			}),
+		},
+		[]scopes.Scope{scopes.Repo},
+		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+			owner, err := RequiredParam[string](args, "owner")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			repo, err := RequiredParam[string](args, "repo")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			ref, err := RequiredParam[string](args, "ref")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			opts := &github.ListCheckRunsOptions{}
+			for param, target := range map[string]**string{"check_name": &opts.CheckName, "status": &opts.Status, "filter": &opts.Filter} {
+				value, err := OptionalParam[string](args, param)
+				if err != nil {
+					return utils.NewToolResultError(err.Error()), nil, nil
+				}
+				if value != "" {
+					*target = github.Ptr(value)
+				}
+			}
+			pagination, err := OptionalPaginationParams(args)
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			opts.ListOptions = github.ListOptions{Page: pagination.Page, PerPage: pagination.PerPage}
+
+			client, err := deps.GetClient(ctx)
+			if err != nil {
+				return utils.NewToolResultErrorFromErr("failed to get GitHub client", err), nil, nil
+			}
+
+			checkRuns, resp, err := client.Checks.ListCheckRunsForRef(ctx, owner, repo, ref, opts)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to list check runs", resp, err), nil, nil
+			}
+			_ = resp.Body.Close()
+
+			result := MinimalCheckRunsResult{
+				TotalCount: checkRuns.GetTotal(),
+				CheckRuns:  make([]MinimalCheckRun, 0, len(checkRuns.CheckRuns)),
+			}
+			for _, checkRun := range checkRuns.CheckRuns {
+				result.CheckRuns = append(result.CheckRuns, convertToMinimalCheckRun(checkRun))
+			}
+			return MarshalledTextResult(result), nil, nil
+		},
+	)
+}
+
+// GetCheckRunAnnotations creates a tool to list the annotations of a check run.
+func GetCheckRunAnnotations(t translations.TranslationHelperFunc) inventory.ServerTool {
+	return NewTool(
+		ToolsetMetadataChecks,
+		mcp.Tool{
+			Name:        "get_check_run_annotations",
+			Description: t("TOOL_GET_CHECK_RUN_ANNOTATIONS_DESCRIPTION", "Get the annotations a check run left on lines of files, such as lint warnings and test failures"),
+			Annotations: &mcp.ToolAnnotations{
+				Title:        t("TOOL_GET_CHECK_RUN_ANNOTATIONS_USER_TITLE", "Get check run annotations"),
+				ReadOnlyHint: true,
+			},
+			InputSchema: WithPagination(&jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"owner": {
+						Type:        "string",
+						Description: "Repository owner",
+					},
+					"repo": {
+						Type:        "string",
+						Description: "Repository name",
+					},
+					"check_run_id": {
+						Type:        "number",
+						Description: "The ID of the check run",
+					},
+				},
+				Required: []string{"owner", "repo", "check_run_id"},
+			}),
+		},
+		[]scopes.Scope{scopes.Repo},
+		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+			owner, err := RequiredParam[string](args, "owner")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			repo, err := RequiredParam[string](args, "repo")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			checkRunID, err := RequiredBigInt(args, "check_run_id")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			pagination, err := OptionalPaginationParams(args)
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+
+			client, err := deps.GetClient(ctx)
+			if err != nil {
+				return utils.NewToolResultErrorFromErr("failed to get GitHub client", err), nil, nil
+			}
+
+			annotations, resp, err := client.Checks.ListCheckRunAnnotations(ctx, owner, repo, checkRunID, &github.ListOptions{Page: pagination.Page, PerPage: pagination.PerPage})
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to get check run annotations", resp, err), nil, nil
+			}
+			_ = resp.Body.Close()
+
+			result := make([]MinimalCheckRunAnnotation, 0, len(annotations))
+			for _, a := range annotations {
+				result = append(result, MinimalCheckRunAnnotation{
+					Path:            a.GetPath(),
+					StartLine:       a.GetStartLine(),
+					EndLine:         a.GetEndLine(),
+					AnnotationLevel: a.GetAnnotationLevel(),
+					Title:           a.GetTitle(),
+					Message:         a.GetMessage(),
+					RawDetails:      a.GetRawDetails(),
+				})
+			}
+			return MarshalledTextResult(result), nil, nil
+		},
+	)
+}
+
+// RerequestCheckSuite creates a tool to run the check runs of a check suite again.
+func RerequestCheckSuite(t translations.TranslationHelperFunc) inventory.ServerTool {
+	return NewTool(
+		ToolsetMetadataChecks,
+		mcp.Tool{
+			Name:        "rerequest_check_suite",
+			Description: t("TOOL_REREQUEST_CHECK_SUITE_DESCRIPTION", "Ask the app that created a check suite to run its checks again, for example after fixing a flaky failure. Get the check suite ID from 'list_check_runs'."),
+			Annotations: &mcp.ToolAnnotations{
+				Title:        t("TOOL_REREQUEST_CHECK_SUITE_USER_TITLE", "Re-request check suite"),
+				ReadOnlyHint: false,
+			},
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"owner": {
+						Type:        "string",
+						Description: "Repository owner",
+					},
+					"repo": {
+						Type:        "string",
+						Description: "Repository name",
+					},
+					"check_suite_id": {
+						Type:        "number",
+						Description: "The ID of the check suite",
+					},
+				},
+				Required: []string{"owner", "repo", "check_suite_id"},
+			},
+		},
+		[]scopes.Scope{scopes.Repo},
+		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+			owner, err := RequiredParam[string](args, "owner")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			repo, err := RequiredParam[string](args, "repo")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			checkSuiteID, err := RequiredBigInt(args, "check_suite_id")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+
+			client, err := deps.GetClient(ctx)
+			if err != nil {
+				return utils.NewToolResultErrorFromErr("failed to get GitHub client", err), nil, nil
+			}
+
+			resp, err := client.Checks.ReRequestCheckSuite(ctx, owner, repo, checkSuiteID)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to re-request check suite", resp, err), nil, nil
+			}
+			_ = resp.Body.Close()
+
+			return utils.NewToolResultText(fmt.Sprintf("Check suite %d was re-requested", checkSuiteID)), nil, nil
+		},
+	)
+}
+
+// CreateCheckRun creates a tool to create a check run. Only GitHub Apps can create check runs,
+// so the tool is only offered to GitHub App installation tokens.
+func CreateCheckRun(t translations.TranslationHelperFunc) inventory.ServerTool {
+	properties := checkRunOutputSchema()
+	properties["owner"] = &jsonschema.Schema{
+		Type:        "string",
+		Description: "Repository owner",
+	}
+	properties["repo"] = &jsonschema.Schema{
+		Type:        "string",
+		Description: "Repository name",
+	}
+	properties["name"] = &jsonschema.Schema{
+		Type:        "string",
+		Description: "Name of the check, such as \"lint\"",
+	}
+	properties["head_sha"] = &jsonschema.Schema{
+		Type:        "string",
+		Description: "SHA of the commit to check",
+	}
+	properties["external_id"] = &jsonschema.Schema{
+		Type:        "string",
+		Description: "Reference for the check run on the integrator's system",
+	}
+
+	return NewTool(
+		ToolsetMetadataChecks,
+		mcp.Tool{
+			Name:        "create_check_run",
+			Description: t("TOOL_CREATE_CHECK_RUN_DESCRIPTION", "Create a check run on a commit, optionally with an output and annotations on lines of files. Requires authenticating as a GitHub App installation."),
+			Annotations: &mcp.ToolAnnotations{
+				Title:        t("TOOL_CREATE_CHECK_RUN_USER_TITLE", "Create check run"),
+				ReadOnlyHint: false,
+			},
+			InputSchema: &jsonschema.Schema{
+				Type:       "object",
+				Properties: properties,
+				Required:   []string{"owner", "repo", "name", "head_sha"},
+			},
+		},
+		[]scopes.Scope{scopes.Repo},
+		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+			owner, err := RequiredParam[string](args, "owner")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			repo, err := RequiredParam[string](args, "repo")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			opts := github.CreateCheckRunOptions{}
+			if opts.Name, err = RequiredParam[string](args, "name"); err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			if opts.HeadSHA, err = RequiredParam[string](args, "head_sha"); err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			for param, target := range map[string]**string{"status": &opts.Status, "conclusion": &opts.Conclusion, "details_url": &opts.DetailsURL, "external_id": &opts.ExternalID} {
+				value, err := OptionalParam[string](args, param)
+				if err != nil {
+					return utils.NewToolResultError(err.Error()), nil, nil
+				}
+				if value != "" {
+					*target = github.Ptr(value)
+				}
+			}
+			if opts.GetStatus() == "completed" && opts.Conclusion == nil {
+				return utils.NewToolResultError("conclusion is required when status is completed"), nil, nil
+			}
+			if opts.Output, err = checkRunOutputParams(args); err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			batches := splitCheckRunAnnotations(opts.Output)
+
+			client, err := deps.GetClient(ctx)
+			if err != nil {
+				return utils.NewToolResultErrorFromErr("failed to get GitHub client", err), nil, nil
+			}
+
+			checkRun, resp, err := client.Checks.CreateCheckRun(ctx, owner, repo, opts)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to create check run", resp, err), nil, nil
+			}
+			_ = resp.Body.Close()
+
+			checkRun, resp, err = addCheckRunAnnotations(ctx, client, owner, repo, checkRun, opts.Output, batches)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to add check run annotations", resp, err), nil, nil
+			}
+
+			return MarshalledTextResult(convertToMinimalCheckRun(checkRun)), nil, nil
+		},
+	)
+}
+
+// UpdateCheckRun creates a tool to update a check run created by the same GitHub App.
+func UpdateCheckRun(t translations.TranslationHelperFunc) inventory.ServerTool {
+	properties := checkRunOutputSchema()
+	properties["owner"] = &jsonschema.Schema{
+		Type:        "string",
+		Description: "Repository owner",
+	}
+	properties["repo"] = &jsonschema.Schema{
+		Type:        "string",
+		Description: "Repository name",
+	}
+	properties["check_run_id"] = &jsonschema.Schema{
+		Type:        "number",
+		Description: "The ID of the check run",
+	}
+	properties["name"] = &jsonschema.Schema{
+		Type:        "string",
+		Description: "New name of the check",
+	}
+
+	return NewTool(
+		ToolsetMetadataChecks,
+		mcp.Tool{
+			Name:        "update_check_run",
+			Description: t("TOOL_UPDATE_CHECK_RUN_DESCRIPTION", "Update the status, conclusion or output of a check run, adding annotations to those it already has. Requires authenticating as the GitHub App installation that created the check run."),
+			Annotations: &mcp.ToolAnnotations{
+				Title:        t("TOOL_UPDATE_CHECK_RUN_USER_TITLE", "Update check run"),
+				ReadOnlyHint: false,
+			},
+			InputSchema: &jsonschema.Schema{
+				Type:       "object",
+				Properties: properties,
+				Required:   []string{"owner", "repo", "check_run_id"},
+			},
+		},
+		[]scopes.Scope{scopes.Repo},
+		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+			owner, err := RequiredParam[string](args, "owner")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			repo, err := RequiredParam[string](args, "repo")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			checkRunID, err := RequiredBigInt(args, "check_run_id")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			opts := github.UpdateCheckRunOptions{}
+			if opts.Name, err = OptionalParam[string](args, "name"); err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			for param, target := range map[string]**string{"status": &opts.Status, "conclusion": &opts.Conclusion, "details_url": &opts.DetailsURL} {
+				value, err := OptionalParam[string](args, param)
+				if err != nil {
+					return utils.NewToolResultError(err.Error()), nil, nil
+				}
+				if value != "" {
+					*target = github.Ptr(value)
+				}
+			}
+			if opts.GetStatus() == "completed" && opts.Conclusion == nil {
+				return utils.NewToolResultError("conclusion is required when status is completed"), nil, nil
+			}
+			if opts.Output, err = checkRunOutputParams(args); err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			batches := splitCheckRunAnnotations(opts.Output)
+
+			client, err := deps.GetClient(ctx)
+			if err != nil {
+				return utils.NewToolResultErrorFromErr("failed to get GitHub client", err), nil, nil
+			}
+
+			if opts.Name == "" {
+				// The API requires the name even when it does not change
+				existing, resp, err := client.Checks.GetCheckRun(ctx, owner, repo, checkRunID)
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to get check run", resp, err), nil, nil
+				}
+				_ = resp.Body.Close()
+				opts.Name = existing.GetName()
+			}
+
+			checkRun, resp, err := client.Checks.UpdateCheckRun(ctx, owner, repo, checkRunID, opts)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to update check run", resp, err), nil, nil
+			}
+			_ = resp.Body.Close()
+
+			checkRun, resp, err = addCheckRunAnnotations(ctx, client, owner, repo, checkRun, opts.Output, batches)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to add check run annotations", resp, err), nil, nil
+			}
+
+			return MarshalledTextResult(convertToMinimalCheckRun(checkRun)), nil, nil
+		},
+	)
+}
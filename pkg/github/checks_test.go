@@ -0,0 +1,318 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/github/github-mcp-server/pkg/utils"
+	"github.com/google/go-github/v82/github"
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ListCheckRuns(t *testing.T) {
+	serverTool := ListCheckRuns(translations.NullTranslationHelper)
+	tool := serverTool.Tool
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_check_runs", tool.Name)
+	assert.True(t, tool.Annotations.ReadOnlyHint)
+	schema := tool.InputSchema.(*jsonschema.Schema)
+	assert.ElementsMatch(t, schema.Required, []string{"owner", "repo", "ref"})
+
+	handlers := map[string]http.HandlerFunc{
+		GetReposCommitsCheckRunsByOwnerByRepoByRef: func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/repos/owner/repo/commits/main/check-runs", r.URL.Path)
+			assert.Equal(t, "lint", r.URL.Query().Get("check_name"))
+			assert.Equal(t, "all", r.URL.Query().Get("filter"))
+			mockResponse(t, http.StatusOK, &github.ListCheckRunsResults{
+				Total: github.Ptr(1),
+				CheckRuns: []*github.CheckRun{{
+					ID:         github.Ptr(int64(11)),
+					Name:       github.Ptr("lint"),
+					Status:     github.Ptr("completed"),
+					Conclusion: github.Ptr("failure"),
+					CheckSuite: &github.CheckSuite{ID: github.Ptr(int64(22))},
+					App:        &github.App{Slug: github.Ptr("linter")},
+				}},
+			})(w, r)
+		},
+	}
+	deps := BaseDeps{Client: github.NewClient(MockHTTPClientWithHandlers(handlers))}
+	request := createMCPRequest(map[string]any{
+		"owner":      "owner",
+		"repo":       "repo",
+		"ref":        "main",
+		"check_name": "lint",
+		"filter":     "all",
+	})
+
+	result, err := serverTool.Handler(deps)(ContextWithDeps(context.Background(), deps), &request)
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	var response MinimalCheckRunsResult
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &response))
+	assert.Equal(t, 1, response.TotalCount)
+	require.Len(t, response.CheckRuns, 1)
+	assert.Equal(t, int64(22), response.CheckRuns[0].CheckSuiteID)
+	assert.Equal(t, "linter", response.CheckRuns[0].App)
+	assert.Equal(t, "failure", response.CheckRuns[0].Conclusion)
+}
+
+func Test_GetCheckRunAnnotations(t *testing.T) {
+	serverTool := GetCheckRunAnnotations(translations.NullTranslationHelper)
+	tool := serverTool.Tool
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_check_run_annotations", tool.Name)
+	assert.True(t, tool.Annotations.ReadOnlyHint)
+
+	handlers := map[string]http.HandlerFunc{
+		GetReposCheckRunsAnnotationsByOwnerByRepoByCheckRunID: mockResponse(t, http.StatusOK, []*github.CheckRunAnnotation{{
+			Path:            github.Ptr("main.go"),
+			StartLine:       github.Ptr(3),
+			EndLine:         github.Ptr(3),
+			AnnotationLevel: github.Ptr("warning"),
+			Message:         github.Ptr("unused variable x"),
+		}}),
+	}
+	deps := BaseDeps{Client: github.NewClient(MockHTTPClientWithHandlers(handlers))}
+	request := createMCPRequest(map[string]any{"owner": "owner", "repo": "repo", "check_run_id": float64(11)})
+
+	result, err := serverTool.Handler(deps)(ContextWithDeps(context.Background(), deps), &request)
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	var response []MinimalCheckRunAnnotation
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &response))
+	assert.Equal(t, []MinimalCheckRunAnnotation{{
+		Path:            "main.go",
+		StartLine:       3,
+		EndLine:         3,
+		AnnotationLevel: "warning",
+		Message:         "unused variable x",
+	}}, response)
+}
+
+func Test_RerequestCheckSuite(t *testing.T) {
+	serverTool := RerequestCheckSuite(translations.NullTranslationHelper)
+	tool := serverTool.Tool
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "rerequest_check_suite", tool.Name)
+	assert.False(t, tool.Annotations.ReadOnlyHint)
+
+	handlers := map[string]http.HandlerFunc{
+		PostReposCheckSuitesRerequestByOwnerByRepoByCheckSuiteID: func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/repos/owner/repo/check-suites/22/rerequest", r.URL.Path)
+			w.WriteHeader(http.StatusCreated)
+		},
+	}
+	deps := BaseDeps{Client: github.NewClient(MockHTTPClientWithHandlers(handlers))}
+	request := createMCPRequest(map[string]any{"owner": "owner", "repo": "repo", "check_suite_id": float64(22)})
+
+	result, err := serverTool.Handler(deps)(ContextWithDeps(context.Background(), deps), &request)
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+	assert.Equal(t, "Check suite 22 was re-requested", getTextResult(t, result).Text)
+}
+
+func Test_CreateCheckRun(t *testing.T) {
+	serverTool := CreateCheckRun(translations.NullTranslationHelper)
+	tool := serverTool.Tool
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "create_check_run", tool.Name)
+	assert.False(t, tool.Annotations.ReadOnlyHint)
+	schema := tool.InputSchema.(*jsonschema.Schema)
+	assert.ElementsMatch(t, schema.Required, []string{"owner", "repo", "name", "head_sha"})
+
+	annotations := make([]any, 0, 60)
+	for i := 1; i <= 60; i++ {
+		annotations = append(annotations, map[string]any{
+			"path":             "main.go",
+			"start_line":       float64(i),
+			"annotation_level": "warning",
+			"message":          fmt.Sprintf("problem %d", i),
+		})
+	}
+	checkRun := &github.CheckRun{ID: github.Ptr(int64(11)), Name: github.Ptr("lint"), Status: github.Ptr("completed"), Conclusion: github.Ptr("failure")}
+
+	tests := []struct {
+		name           string
+		handlers       map[string]http.HandlerFunc
+		requestArgs    map[string]any
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "creates the check run and adds annotations beyond the first batch",
+			handlers: map[string]http.HandlerFunc{
+				PostReposCheckRunsByOwnerByRepo: func(w http.ResponseWriter, r *http.Request) {
+					var body github.CreateCheckRunOptions
+					require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+					assert.Equal(t, "lint", body.Name)
+					assert.Equal(t, "abc123", body.HeadSHA)
+					assert.Equal(t, "failure", body.GetConclusion())
+					require.NotNil(t, body.Output)
+					assert.Len(t, body.Output.Annotations, 50)
+					assert.Equal(t, 1, body.Output.Annotations[0].GetEndLine())
+					mockResponse(t, http.StatusCreated, checkRun)(w, r)
+				},
+				PatchReposCheckRunsByOwnerByRepoByCheckRunID: func(w http.ResponseWriter, r *http.Request) {
+					var body github.UpdateCheckRunOptions
+					require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+					assert.Equal(t, "lint", body.Name)
+					require.NotNil(t, body.Output)
+					assert.Equal(t, "Lint", body.Output.GetTitle())
+					require.Len(t, body.Output.Annotations, 10)
+					assert.Equal(t, "problem 51", body.Output.Annotations[0].GetMessage())
+					mockResponse(t, http.StatusOK, checkRun)(w, r)
+				},
+			},
+			requestArgs: map[string]any{
+				"name":        "lint",
+				"head_sha":    "abc123",
+				"status":      "completed",
+				"conclusion":  "failure",
+				"title":       "Lint",
+				"summary":     "60 problems",
+				"annotations": annotations,
+			},
+		},
+		{
+			name: "completed without a conclusion",
+			requestArgs: map[string]any{
+				"name":     "lint",
+				"head_sha": "abc123",
+				"status":   "completed",
+			},
+			expectError:    true,
+			expectedErrMsg: "conclusion is required",
+		},
+		{
+			name: "annotations without a title",
+			requestArgs: map[string]any{
+				"name":        "lint",
+				"head_sha":    "abc123",
+				"annotations": annotations[:1],
+			},
+			expectError:    true,
+			expectedErrMsg: "title and summary are required",
+		},
+		{
+			name: "token of a user",
+			handlers: map[string]http.HandlerFunc{
+				PostReposCheckRunsByOwnerByRepo: mockResponse(t, http.StatusForbidden, map[string]string{"message": "You must authenticate via a GitHub App."}),
+			},
+			requestArgs: map[string]any{
+				"name":     "lint",
+				"head_sha": "abc123",
+			},
+			expectError:    true,
+			expectedErrMsg: "must authenticate via a GitHub App",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			deps := BaseDeps{Client: github.NewClient(MockHTTPClientWithHandlers(tc.handlers))}
+			tc.requestArgs["owner"] = "owner"
+			tc.requestArgs["repo"] = "repo"
+			request := createMCPRequest(tc.requestArgs)
+
+			result, err := serverTool.Handler(deps)(ContextWithDeps(context.Background(), deps), &request)
+			require.NoError(t, err)
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+			require.False(t, result.IsError)
+
+			var response MinimalCheckRun
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &response))
+			assert.Equal(t, int64(11), response.ID)
+			assert.Equal(t, "failure", response.Conclusion)
+		})
+	}
+}
+
+func Test_UpdateCheckRun(t *testing.T) {
+	serverTool := UpdateCheckRun(translations.NullTranslationHelper)
+	tool := serverTool.Tool
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "update_check_run", tool.Name)
+	assert.False(t, tool.Annotations.ReadOnlyHint)
+	schema := tool.InputSchema.(*jsonschema.Schema)
+	assert.ElementsMatch(t, schema.Required, []string{"owner", "repo", "check_run_id"})
+
+	handlers := map[string]http.HandlerFunc{
+		GetReposCheckRunsByOwnerByRepoByCheckRunID: mockResponse(t, http.StatusOK, &github.CheckRun{ID: github.Ptr(int64(11)), Name: github.Ptr("lint")}),
+		PatchReposCheckRunsByOwnerByRepoByCheckRunID: expectRequestBody(t, map[string]any{
+			"name":       "lint",
+			"status":     "completed",
+			"conclusion": "success",
+		}).andThen(mockResponse(t, http.StatusOK, &github.CheckRun{
+			ID:         github.Ptr(int64(11)),
+			Name:       github.Ptr("lint"),
+			Status:     github.Ptr("completed"),
+			Conclusion: github.Ptr("success"),
+		})),
+	}
+	deps := BaseDeps{Client: github.NewClient(MockHTTPClientWithHandlers(handlers))}
+	request := createMCPRequest(map[string]any{
+		"owner":        "owner",
+		"repo":         "repo",
+		"check_run_id": float64(11),
+		"status":       "completed",
+		"conclusion":   "success",
+	})
+
+	result, err := serverTool.Handler(deps)(ContextWithDeps(context.Background(), deps), &request)
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	var response MinimalCheckRun
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &response))
+	assert.Equal(t, "success", response.Conclusion)
+}
+
+func TestCreateTokenTypeFilter(t *testing.T) {
+	tests := []struct {
+		name          string
+		tokenType     utils.TokenType
+		expectCreated bool
+	}{
+		{name: "installation token", tokenType: utils.TokenTypeServerToServerGitHubAppToken, expectCreated: true},
+		{name: "unknown token", tokenType: utils.TokenTypeUnknown, expectCreated: true},
+		{name: "personal access token", tokenType: utils.TokenTypePersonalAccessToken, expectCreated: false},
+		{name: "user access token for a GitHub App", tokenType: utils.TokenTypeUserToServerGitHubAppToken, expectCreated: false},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			inv, err := NewInventory(translations.NullTranslationHelper).
+				WithToolsets([]string{"all"}).
+				WithFilter(CreateTokenTypeFilter(tc.tokenType)).
+				Build()
+			require.NoError(t, err)
+
+			available := make(map[string]bool)
+			for _, tool := range inv.AvailableTools(context.Background()) {
+				available[tool.Tool.Name] = true
+			}
+			assert.True(t, available["list_check_runs"])
+			assert.Equal(t, tc.expectCreated, available["create_check_run"])
+			assert.Equal(t, tc.expectCreated, available["update_check_run"])
+			assert.Equal(t, tc.expectCreated, available["review_deployment_protection_rule"])
+		})
+	}
+}
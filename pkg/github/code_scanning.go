@@ -3,6 +3,7 @@ package github
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"io"
 	"net/http"
 
@@ -21,7 +22,7 @@ func GetCodeScanningAlert(t translations.TranslationHelperFunc) inventory.Server
 		ToolsetMetadataCodeSecurity,
 		mcp.Tool{
 			Name:        "get_code_scanning_alert",
-			Description: t("TOOL_GET_CODE_SCANNING_ALERT_DESCRIPTION", "Get details of a specific code scanning alert in a GitHub repository."),
+			Description: t("TOOL_GET_CODE_SCANNING_ALERT_DESCRIPTION", "Get details of a specific code scanning alert in a GitHub repository, optionally with the flow path of tainted data from its source to its sink."),
 			Annotations: &mcp.ToolAnnotations{
 				Title:        t("TOOL_GET_CODE_SCANNING_ALERT_USER_TITLE", "Get code scanning alert"),
 				ReadOnlyHint: true,
@@ -41,6 +42,11 @@ func GetCodeScanningAlert(t translations.TranslationHelperFunc) inventory.Server
 						Type:        "number",
 						Description: "The number of the alert.",
 					},
+					"include_flow_path": {
+						Type:        "boolean",
+						Description: "Include the steps of the data flow path of the alert, read from the most recent analysis that found it. Only alerts of path queries, such as CodeQL taint tracking queries, have a flow path.",
+						Default:     json.RawMessage(`false`),
+					},
 				},
 				Required: []string{"owner", "repo", "alertNumber"},
 			},
@@ -59,6 +65,10 @@ func GetCodeScanningAlert(t translations.TranslationHelperFunc) inventory.Server
 			if err != nil {
 				return utils.NewToolResultError(err.Error()), nil, nil
 			}
+			includeFlowPath, err := OptionalBoolParamWithDefault(args, "include_flow_path", false)
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
 
 			client, err := deps.GetClient(ctx)
 			if err != nil {
@@ -83,6 +93,18 @@ func GetCodeScanningAlert(t translations.TranslationHelperFunc) inventory.Server
 				return ghErrors.NewGitHubAPIStatusErrorResponse(ctx, "failed to get alert", resp, body), nil, nil
 			}
 
+			if includeFlowPath {
+				result, resp, err := getCodeScanningFlowPath(ctx, client, owner, repo, alert)
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx,
+						"failed to get alert flow path",
+						resp,
+						err,
+					), nil, nil
+				}
+				return MarshalledTextResult(result), nil, nil
+			}
+
 			r, err := json.Marshal(alert)
 			if err != nil {
 				return utils.NewToolResultErrorFromErr("failed to marshal alert", err), nil, nil
@@ -195,3 +217,246 @@ func ListCodeScanningAlerts(t translations.TranslationHelperFunc) inventory.Serv
 		},
 	)
 }
+
+// CodeScanningFlowStep is a location the data of a code scanning alert flows through, from its
+// source to its sink.
+type CodeScanningFlowStep struct {
+	Path        string `json:"path"`
+	StartLine   int    `json:"start_line,omitempty"`
+	EndLine     int    `json:"end_line,omitempty"`
+	StartColumn int    `json:"start_column,omitempty"`
+	EndColumn   int    `json:"end_column,omitempty"`
+	Message     string `json:"message,omitempty"`
+}
+
+// codeScanningAlertWithFlowPath is a code scanning alert with the flow path of its most recent
+// instance.
+type codeScanningAlertWithFlowPath struct {
+	*github.Alert
+	FlowPath []CodeScanningFlowStep `json:"flow_path,omitempty"`
+	// FlowPathNote explains why the flow path is missing.
+	FlowPathNote string `json:"flow_path_note,omitempty"`
+}
+
+// sarifLog holds the parts of a SARIF log needed to find the code flows of a result.
+type sarifLog struct {
+	Runs []struct {
+		Results []struct {
+			RuleID    string          `json:"ruleId"`
+			Locations []sarifLocation `json:"locations"`
+			CodeFlows []struct {
+				ThreadFlows []struct {
+					Locations []struct {
+						Location sarifLocation `json:"location"`
+					} `json:"locations"`
+				} `json:"threadFlows"`
+			} `json:"codeFlows"`
+		} `json:"results"`
+	} `json:"runs"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation struct {
+		ArtifactLocation struct {
+			URI string `json:"uri"`
+		} `json:"artifactLocation"`
+		Region struct {
+			StartLine   int `json:"startLine"`
+			EndLine     int `json:"endLine"`
+			StartColumn int `json:"startColumn"`
+			EndColumn   int `json:"endColumn"`
+		} `json:"region"`
+	} `json:"physicalLocation"`
+	Message struct {
+		Text string `json:"text"`
+	} `json:"message"`
+}
+
+// getCodeScanningFlowPath reads the flow path of the most recent instance of an alert from the
+// SARIF of the analysis that found it, which is the only place the REST API exposes code flows.
+func getCodeScanningFlowPath(ctx context.Context, client *github.Client, owner, repo string, alert *github.Alert) (*codeScanningAlertWithFlowPath, *github.Response, error) {
+	result := &codeScanningAlertWithFlowPath{Alert: alert}
+	instance := alert.GetMostRecentInstance()
+	if instance == nil || instance.Location == nil {
+		result.FlowPathNote = "the alert has no instance to read a flow path from"
+		return result, nil, nil
+	}
+
+	analyses, resp, err := client.CodeScanning.ListAnalysesForRepo(ctx, owner, repo, &github.AnalysesListOptions{
+		Ref:         instance.Ref,
+		ListOptions: github.ListOptions{PerPage: 100},
+	})
+	if err != nil {
+		return nil, resp, err
+	}
+	_ = resp.Body.Close()
+	var analysisID int64
+	for _, analysis := range analyses {
+		if analysis.GetCommitSHA() == instance.GetCommitSHA() && analysis.GetAnalysisKey() == instance.GetAnalysisKey() &&
+			analysis.GetCategory() == instance.GetCategory() {
+			analysisID = analysis.GetID()
+			break
+		}
+	}
+	if analysisID == 0 {
+		result.FlowPathNote = "the analysis that found the most recent instance of the alert is no longer available"
+		return result, nil, nil
+	}
+
+	req, err := client.NewRequest(http.MethodGet, fmt.Sprintf("repos/%s/%s/code-scanning/analyses/%d", owner, repo, analysisID), nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	req.Header.Set("Accept", "application/sarif+json")
+	var sarif sarifLog
+	resp, err = client.Do(ctx, req, &sarif)
+	if err != nil {
+		return nil, resp, err
+	}
+	_ = resp.Body.Close()
+
+	location := instance.Location
+	for _, run := range sarif.Runs {
+		for _, r := range run.Results {
+			if r.RuleID != alert.GetRule().GetID() || len(r.Locations) == 0 {
+				continue
+			}
+			primary := r.Locations[0].PhysicalLocation
+			if primary.ArtifactLocation.URI != location.GetPath() || primary.Region.StartLine != location.GetStartLine() {
+				continue
+			}
+			if len(r.CodeFlows) == 0 || len(r.CodeFlows[0].ThreadFlows) == 0 {
+				result.FlowPathNote = "the alert was not raised by a path query, so it has no flow path"
+				return result, resp, nil
+			}
+			for _, step := range r.CodeFlows[0].ThreadFlows[0].Locations {
+				physical := step.Location.PhysicalLocation
+				result.FlowPath = append(result.FlowPath, CodeScanningFlowStep{
+					Path:        physical.ArtifactLocation.URI,
+					StartLine:   physical.Region.StartLine,
+					EndLine:     physical.Region.EndLine,
+					StartColumn: physical.Region.StartColumn,
+					EndColumn:   physical.Region.EndColumn,
+					Message:     step.Location.Message.Text,
+				})
+			}
+			return result, resp, nil
+		}
+	}
+	result.FlowPathNote = "the alert was not found in the analysis that found its most recent instance"
+	return result, resp, nil
+}
+
+func UpdateCodeScanningAlert(t translations.TranslationHelperFunc) inventory.ServerTool {
+	return NewTool(
+		ToolsetMetadataCodeSecurity,
+		mcp.Tool{
+			Name:        "update_code_scanning_alert",
+			Description: t("TOOL_UPDATE_CODE_SCANNING_ALERT_DESCRIPTION", "Dismiss a code scanning alert with a reason, or reopen a dismissed alert, in a GitHub repository."),
+			Annotations: &mcp.ToolAnnotations{
+				Title:        t("TOOL_UPDATE_CODE_SCANNING_ALERT_USER_TITLE", "Update code scanning alert"),
+				ReadOnlyHint: false,
+			},
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"owner": {
+						Type:        "string",
+						Description: "The owner of the repository.",
+					},
+					"repo": {
+						Type:        "string",
+						Description: "The name of the repository.",
+					},
+					"alertNumber": {
+						Type:        "number",
+						Description: "The number of the alert.",
+					},
+					"state": {
+						Type:        "string",
+						Description: "The new state of the alert.",
+						Enum:        []any{"open", "dismissed"},
+					},
+					"dismissed_reason": {
+						Type:        "string",
+						Description: "The reason for dismissing the alert. Required when state is dismissed.",
+						Enum:        []any{"false positive", "won't fix", "used in tests"},
+					},
+					"dismissed_comment": {
+						Type:        "string",
+						Description: "A comment explaining the dismissal, up to 280 characters.",
+						MaxLength:   jsonschema.Ptr(280),
+					},
+				},
+				Required: []string{"owner", "repo", "alertNumber", "state"},
+			},
+		},
+		[]scopes.Scope{scopes.SecurityEvents},
+		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+			owner, err := RequiredParam[string](args, "owner")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			repo, err := RequiredParam[string](args, "repo")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			alertNumber, err := RequiredInt(args, "alertNumber")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			state, err := RequiredParam[string](args, "state")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			dismissedReason, err := OptionalParam[string](args, "dismissed_reason")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			dismissedComment, err := OptionalParam[string](args, "dismissed_comment")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+
+			stateInfo := &github.CodeScanningAlertState{State: state}
+			switch state {
+			case "dismissed":
+				if dismissedReason == "" {
+					return utils.NewToolResultError("dismissed_reason is required when state is dismissed"), nil, nil
+				}
+				stateInfo.DismissedReason = github.Ptr(dismissedReason)
+				if dismissedComment != "" {
+					stateInfo.DismissedComment = github.Ptr(dismissedComment)
+				}
+			case "open":
+				if dismissedReason != "" || dismissedComment != "" {
+					return utils.NewToolResultError("dismissed_reason and dismissed_comment can only be set when state is dismissed"), nil, nil
+				}
+			default:
+				return utils.NewToolResultError(fmt.Sprintf("invalid state %q: must be open or dismissed", state)), nil, nil
+			}
+
+			client, err := deps.GetClient(ctx)
+			if err != nil {
+				return utils.NewToolResultErrorFromErr("failed to get GitHub client", err), nil, nil
+			}
+
+			alert, resp, err := client.CodeScanning.UpdateAlert(ctx, owner, repo, int64(alertNumber), stateInfo)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					"failed to update alert",
+					resp,
+					err,
+				), nil, nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			r, err := json.Marshal(alert)
+			if err != nil {
+				return utils.NewToolResultErrorFromErr("failed to marshal alert", err), nil, nil
+			}
+
+			return utils.NewToolResultText(string(r)), nil, nil
+		},
+	)
+}
@@ -247,3 +247,167 @@ func Test_ListCodeScanningAlerts(t *testing.T) {
 		})
 	}
 }
+
+func Test_GetCodeScanningAlert_FlowPath(t *testing.T) {
+	toolDef := GetCodeScanningAlert(translations.NullTranslationHelper)
+
+	mockAlert := &github.Alert{
+		Number: github.Ptr(42),
+		State:  github.Ptr("open"),
+		Rule:   &github.Rule{ID: github.Ptr("js/xss")},
+		MostRecentInstance: &github.MostRecentInstance{
+			Ref:         github.Ptr("refs/heads/main"),
+			AnalysisKey: github.Ptr(".github/workflows/codeql.yml:analyze"),
+			Category:    github.Ptr("/language:javascript"),
+			CommitSHA:   github.Ptr("abc123"),
+			Location:    &github.Location{Path: github.Ptr("src/render.js"), StartLine: github.Ptr(12)},
+		},
+	}
+	analyses := []*github.ScanningAnalysis{
+		{ID: github.Ptr(int64(7)), CommitSHA: github.Ptr("def456"), AnalysisKey: github.Ptr(".github/workflows/codeql.yml:analyze"), Category: github.Ptr("/language:javascript")},
+		{ID: github.Ptr(int64(5)), CommitSHA: github.Ptr("abc123"), AnalysisKey: github.Ptr(".github/workflows/codeql.yml:analyze"), Category: github.Ptr("/language:javascript")},
+	}
+	sarif := `{"runs":[{"results":[
+		{"ruleId":"js/xss","locations":[{"physicalLocation":{"artifactLocation":{"uri":"src/other.js"},"region":{"startLine":12}}}]},
+		{"ruleId":"js/xss","locations":[{"physicalLocation":{"artifactLocation":{"uri":"src/render.js"},"region":{"startLine":12}}}],
+		 "codeFlows":[{"threadFlows":[{"locations":[
+			{"location":{"physicalLocation":{"artifactLocation":{"uri":"src/server.js"},"region":{"startLine":3,"startColumn":5,"endColumn":20}},"message":{"text":"req.query.name"}}},
+			{"location":{"physicalLocation":{"artifactLocation":{"uri":"src/render.js"},"region":{"startLine":12,"startColumn":10,"endColumn":14}},"message":{"text":"name"}}}
+		]}]}]}
+	]}]}`
+
+	mockedClient := MockHTTPClientWithHandlers(map[string]http.HandlerFunc{
+		GetReposCodeScanningAlertsByOwnerByRepoByAlertNumber: mockResponse(t, http.StatusOK, mockAlert),
+		GetReposCodeScanningAnalysesByOwnerByRepo: func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "refs/heads/main", r.URL.Query().Get("ref"))
+			w.WriteHeader(http.StatusOK)
+			_ = json.NewEncoder(w).Encode(analyses)
+		},
+		GetReposCodeScanningAnalysesByOwnerByRepoByAnalysisID: func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/repos/owner/repo/code-scanning/analyses/5", r.URL.Path)
+			assert.Equal(t, "application/sarif+json", r.Header.Get("Accept"))
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write([]byte(sarif))
+		},
+	})
+	deps := BaseDeps{Client: github.NewClient(mockedClient)}
+	handler := toolDef.Handler(deps)
+
+	request := createMCPRequest(map[string]any{
+		"owner":             "owner",
+		"repo":              "repo",
+		"alertNumber":       float64(42),
+		"include_flow_path": true,
+	})
+	result, err := handler(ContextWithDeps(context.Background(), deps), &request)
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	var returned struct {
+		Number       int                    `json:"number"`
+		FlowPath     []CodeScanningFlowStep `json:"flow_path"`
+		FlowPathNote string                 `json:"flow_path_note"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returned))
+	assert.Equal(t, 42, returned.Number)
+	assert.Empty(t, returned.FlowPathNote)
+	assert.Equal(t, []CodeScanningFlowStep{
+		{Path: "src/server.js", StartLine: 3, StartColumn: 5, EndColumn: 20, Message: "req.query.name"},
+		{Path: "src/render.js", StartLine: 12, StartColumn: 10, EndColumn: 14, Message: "name"},
+	}, returned.FlowPath)
+}
+
+func Test_UpdateCodeScanningAlert(t *testing.T) {
+	toolDef := UpdateCodeScanningAlert(translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(toolDef.Tool.Name, toolDef.Tool))
+	assert.False(t, toolDef.Tool.Annotations.ReadOnlyHint)
+
+	schema, ok := toolDef.Tool.InputSchema.(*jsonschema.Schema)
+	require.True(t, ok, "InputSchema should be *jsonschema.Schema")
+	assert.ElementsMatch(t, schema.Required, []string{"owner", "repo", "alertNumber", "state"})
+
+	tests := []struct {
+		name           string
+		requestArgs    map[string]any
+		expectedBody   map[string]any
+		expectedErrMsg string
+	}{
+		{
+			name: "dismisses an alert with a reason",
+			requestArgs: map[string]any{
+				"owner":             "owner",
+				"repo":              "repo",
+				"alertNumber":       float64(42),
+				"state":             "dismissed",
+				"dismissed_reason":  "false positive",
+				"dismissed_comment": "Input is sanitized upstream",
+			},
+			expectedBody: map[string]any{
+				"state":             "dismissed",
+				"dismissed_reason":  "false positive",
+				"dismissed_comment": "Input is sanitized upstream",
+			},
+		},
+		{
+			name: "reopens an alert",
+			requestArgs: map[string]any{
+				"owner":       "owner",
+				"repo":        "repo",
+				"alertNumber": float64(42),
+				"state":       "open",
+			},
+			expectedBody: map[string]any{"state": "open"},
+		},
+		{
+			name: "dismissing requires a reason",
+			requestArgs: map[string]any{
+				"owner":       "owner",
+				"repo":        "repo",
+				"alertNumber": float64(42),
+				"state":       "dismissed",
+			},
+			expectedErrMsg: "dismissed_reason is required when state is dismissed",
+		},
+		{
+			name: "reopening takes no reason",
+			requestArgs: map[string]any{
+				"owner":            "owner",
+				"repo":             "repo",
+				"alertNumber":      float64(42),
+				"state":            "open",
+				"dismissed_reason": "won't fix",
+			},
+			expectedErrMsg: "can only be set when state is dismissed",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockedClient := MockHTTPClientWithHandlers(map[string]http.HandlerFunc{
+				PatchReposCodeScanningAlertsByOwnerByRepoByAlertNumber: func(w http.ResponseWriter, r *http.Request) {
+					var body map[string]any
+					require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+					assert.Equal(t, tc.expectedBody, body)
+					w.WriteHeader(http.StatusOK)
+					_ = json.NewEncoder(w).Encode(&github.Alert{Number: github.Ptr(42), State: github.Ptr(body["state"].(string))})
+				},
+			})
+			deps := BaseDeps{Client: github.NewClient(mockedClient)}
+			handler := toolDef.Handler(deps)
+
+			request := createMCPRequest(tc.requestArgs)
+			result, err := handler(ContextWithDeps(context.Background(), deps), &request)
+			require.NoError(t, err)
+
+			if tc.expectedErrMsg != "" {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+			require.False(t, result.IsError)
+			var returnedAlert github.Alert
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returnedAlert))
+			assert.Equal(t, tc.expectedBody["state"], returnedAlert.GetState())
+		})
+	}
+}
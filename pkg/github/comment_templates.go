@@ -0,0 +1,295 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/github/github-mcp-server/pkg/inventory"
+	"github.com/github/github-mcp-server/pkg/scopes"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/github/github-mcp-server/pkg/utils"
+	"github.com/google/go-github/v82/github"
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"go.yaml.in/yaml/v3"
+)
+
+// CommentTemplate is a reusable comment whose {{name}} placeholders are filled in when it is
+// posted, so that agents answer common requests consistently without writing the comment out.
+type CommentTemplate struct {
+	Description string `yaml:"description"`
+	Body        string `yaml:"body"`
+}
+
+// CommentTemplates are comment templates by name.
+type CommentTemplates map[string]CommentTemplate
+
+// commentTemplatesFile is the format of the server's comment templates file, the same as the
+// comment_templates section of a repository's configuration.
+type commentTemplatesFile struct {
+	CommentTemplates CommentTemplates `yaml:"comment_templates"`
+}
+
+// LoadCommentTemplates reads the comment templates of the server from a YAML file. It returns no
+// templates when path is empty.
+func LoadCommentTemplates(path string) (CommentTemplates, error) {
+	if path == "" {
+		return nil, nil
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read comment templates: %w", err)
+	}
+	var file commentTemplatesFile
+	if err := yaml.Unmarshal(content, &file); err != nil {
+		return nil, fmt.Errorf("invalid comment templates file %s: %w", path, err)
+	}
+	for name, template := range file.CommentTemplates {
+		if strings.TrimSpace(template.Body) == "" {
+			return nil, fmt.Errorf("invalid comment templates file %s: template %q has no body", path, name)
+		}
+	}
+	return file.CommentTemplates, nil
+}
+
+type commentTemplatesContextKey struct{}
+
+// CommentTemplatesMiddleware makes the server's comment templates available to tool calls.
+func CommentTemplatesMiddleware(templates CommentTemplates) mcp.Middleware {
+	return func(next mcp.MethodHandler) mcp.MethodHandler {
+		return func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+			if method == "tools/call" {
+				ctx = context.WithValue(ctx, commentTemplatesContextKey{}, templates)
+			}
+			return next(ctx, method, req)
+		}
+	}
+}
+
+// commentTemplatesFromContext returns the comment templates of the server handling a tool call.
+func commentTemplatesFromContext(ctx context.Context) CommentTemplates {
+	templates, _ := ctx.Value(commentTemplatesContextKey{}).(CommentTemplates)
+	return templates
+}
+
+const (
+	commentTemplateSourceServer     = "server"
+	commentTemplateSourceRepository = "repository"
+)
+
+// CommentTemplateSummary describes a comment template available to a repository.
+type CommentTemplateSummary struct {
+	Name         string   `json:"name"`
+	Description  string   `json:"description,omitempty"`
+	Source       string   `json:"source"`
+	Placeholders []string `json:"placeholders,omitempty"`
+}
+
+type resolvedCommentTemplate struct {
+	CommentTemplate
+	source string
+}
+
+// resolveCommentTemplates returns the comment templates available to a repository: those of the
+// server, overridden by those of the repository's configuration. Without a repository, only the
+// server's templates are returned.
+func resolveCommentTemplates(ctx context.Context, client *github.Client, owner, repo string) (map[string]resolvedCommentTemplate, error) {
+	resolved := map[string]resolvedCommentTemplate{}
+	for name, template := range commentTemplatesFromContext(ctx) {
+		resolved[name] = resolvedCommentTemplate{CommentTemplate: template, source: commentTemplateSourceServer}
+	}
+	if owner == "" {
+		return resolved, nil
+	}
+	config, err := repoConfigs.get(ctx, client, owner, repo)
+	if err != nil {
+		return nil, err
+	}
+	for name, template := range config.CommentTemplates {
+		if strings.TrimSpace(template.Body) == "" {
+			continue
+		}
+		resolved[name] = resolvedCommentTemplate{CommentTemplate: template, source: commentTemplateSourceRepository}
+	}
+	return resolved, nil
+}
+
+// findCommentTemplate returns the template of a name, matched case-insensitively.
+func findCommentTemplate(templates map[string]resolvedCommentTemplate, name string) (resolvedCommentTemplate, bool) {
+	if template, ok := templates[name]; ok {
+		return template, true
+	}
+	for n, template := range templates {
+		if strings.EqualFold(n, name) {
+			return template, true
+		}
+	}
+	return resolvedCommentTemplate{}, false
+}
+
+func sortedCommentTemplateNames(templates map[string]resolvedCommentTemplate) []string {
+	names := make([]string, 0, len(templates))
+	for name := range templates {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ListCommentTemplates creates a tool to list the comment templates available to a repository.
+func ListCommentTemplates(t translations.TranslationHelperFunc) inventory.ServerTool {
+	return NewTool(
+		ToolsetMetadataIssues,
+		mcp.Tool{
+			Name:        "list_comment_templates",
+			Description: t("TOOL_LIST_COMMENT_TEMPLATES_DESCRIPTION", "List the comment templates configured on the server and, when a repository is given, in the comment_templates section of its .github/mcp-server.yml, which take precedence. Post a template with 'create_issue_comment_from_template', filling in the placeholders it reports."),
+			Annotations: &mcp.ToolAnnotations{
+				Title:        t("TOOL_LIST_COMMENT_TEMPLATES_USER_TITLE", "List comment templates"),
+				ReadOnlyHint: true,
+			},
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"owner": {
+						Type:        "string",
+						Description: "Repository owner. Leave out with repo to list only the server's templates",
+					},
+					"repo": {
+						Type:        "string",
+						Description: "Repository name",
+					},
+				},
+			},
+		},
+		[]scopes.Scope{scopes.Repo},
+		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+			owner, err := OptionalParam[string](args, "owner")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			repo, err := OptionalParam[string](args, "repo")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			if (owner == "") != (repo == "") {
+				return utils.NewToolResultError("owner and repo must be set together"), nil, nil
+			}
+
+			client, err := deps.GetClient(ctx)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			templates, err := resolveCommentTemplates(ctx, client, owner, repo)
+			if err != nil {
+				return utils.NewToolResultErrorFromErr("failed to get repository configuration", err), nil, nil
+			}
+
+			summaries := make([]CommentTemplateSummary, 0, len(templates))
+			for _, name := range sortedCommentTemplateNames(templates) {
+				template := templates[name]
+				summaries = append(summaries, CommentTemplateSummary{
+					Name:         name,
+					Description:  template.Description,
+					Source:       template.source,
+					Placeholders: savedReplyPlaceholders(template.Body),
+				})
+			}
+			return MarshalledTextResult(summaries), nil, nil
+		},
+	)
+}
+
+// CreateIssueCommentFromTemplate creates a tool to comment on an issue or pull request using a
+// comment template, with {{name}} placeholders substituted.
+func CreateIssueCommentFromTemplate(t translations.TranslationHelperFunc) inventory.ServerTool {
+	return NewTool(
+		ToolsetMetadataIssues,
+		mcp.Tool{
+			Name:        "create_issue_comment_from_template",
+			Description: t("TOOL_CREATE_ISSUE_COMMENT_FROM_TEMPLATE_DESCRIPTION", "Add a comment to an issue or pull request using a comment template of the server or of the repository's .github/mcp-server.yml. Placeholders of the form {{name}} in the template are replaced with values from 'substitutions'; the comment is not posted if any placeholder is left unfilled."),
+			Annotations: &mcp.ToolAnnotations{
+				Title:        t("TOOL_CREATE_ISSUE_COMMENT_FROM_TEMPLATE_USER_TITLE", "Add comment from template"),
+				ReadOnlyHint: false,
+			},
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"owner": {
+						Type:        "string",
+						Description: "Repository owner",
+					},
+					"repo": {
+						Type:        "string",
+						Description: "Repository name",
+					},
+					"issue_number": {
+						Type:        "number",
+						Description: "Issue or pull request number to comment on",
+					},
+					"template": {
+						Type:        "string",
+						Description: "Name of the comment template to use",
+					},
+					"substitutions": {
+						Type:        "object",
+						Description: "Values for {{name}} placeholders in the template, keyed by placeholder name. Example: {\"username\": \"octocat\"}",
+						AdditionalProperties: &jsonschema.Schema{
+							Type: "string",
+						},
+					},
+				},
+				Required: []string{"owner", "repo", "issue_number", "template"},
+			},
+		},
+		[]scopes.Scope{scopes.Repo},
+		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+			owner, err := RequiredParam[string](args, "owner")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			repo, err := RequiredParam[string](args, "repo")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			issueNumber, err := RequiredInt(args, "issue_number")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			name, err := RequiredParam[string](args, "template")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			substitutions, err := substitutionsParam(args)
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+
+			client, err := deps.GetClient(ctx)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			templates, err := resolveCommentTemplates(ctx, client, owner, repo)
+			if err != nil {
+				return utils.NewToolResultErrorFromErr("failed to get repository configuration", err), nil, nil
+			}
+			template, ok := findCommentTemplate(templates, name)
+			if !ok {
+				if len(templates) == 0 {
+					return utils.NewToolResultError(fmt.Sprintf("comment template '%s' not found: no comment templates are configured for %s/%s", name, owner, repo)), nil, nil
+				}
+				return utils.NewToolResultError(fmt.Sprintf("comment template '%s' not found; available templates: %s", name, strings.Join(sortedCommentTemplateNames(templates), ", "))), nil, nil
+			}
+
+			body, missing := applySavedReplySubstitutions(template.Body, substitutions)
+			if len(missing) > 0 {
+				return utils.NewToolResultError(fmt.Sprintf("missing substitutions for placeholders: %s", strings.Join(missing, ", "))), nil, nil
+			}
+
+			result, err := createIssueCommentResult(ctx, client, owner, repo, issueNumber, body)
+			return result, nil, err
+		},
+	)
+}
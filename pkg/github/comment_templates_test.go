@@ -0,0 +1,192 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v82/github"
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testCommentTemplatesConfig = `comment_templates:
+  needs-repro:
+    description: Ask the reporter for a minimal reproduction
+    body: |
+      Thanks @{{reporter}}! Could you share a minimal reproduction against {{version}}?
+`
+
+var testServerCommentTemplates = CommentTemplates{
+	"duplicate":   {Description: "Close as a duplicate", Body: "Closing as a duplicate of #{{original}}."},
+	"needs-repro": {Body: "Please add a reproduction."},
+}
+
+func Test_LoadCommentTemplates(t *testing.T) {
+	templates, err := LoadCommentTemplates("")
+	require.NoError(t, err)
+	assert.Nil(t, templates)
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "templates.yml")
+	require.NoError(t, os.WriteFile(path, []byte(testCommentTemplatesConfig), 0600))
+	templates, err = LoadCommentTemplates(path)
+	require.NoError(t, err)
+	assert.Equal(t, CommentTemplates{
+		"needs-repro": {
+			Description: "Ask the reporter for a minimal reproduction",
+			Body:        "Thanks @{{reporter}}! Could you share a minimal reproduction against {{version}}?\n",
+		},
+	}, templates)
+
+	require.NoError(t, os.WriteFile(path, []byte("comment_templates:\n  empty:\n    description: No body\n"), 0600))
+	_, err = LoadCommentTemplates(path)
+	assert.ErrorContains(t, err, `template "empty" has no body`)
+
+	_, err = LoadCommentTemplates(filepath.Join(dir, "missing.yml"))
+	assert.ErrorContains(t, err, "failed to read comment templates")
+}
+
+func Test_ListCommentTemplates(t *testing.T) {
+	serverTool := ListCommentTemplates(translations.NullTranslationHelper)
+	tool := serverTool.Tool
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_comment_templates", tool.Name)
+	assert.True(t, tool.Annotations.ReadOnlyHint)
+	schema := tool.InputSchema.(*jsonschema.Schema)
+	assert.Empty(t, schema.Required)
+
+	repoConfigs.clear()
+	t.Cleanup(repoConfigs.clear)
+
+	deps := BaseDeps{Client: github.NewClient(MockHTTPClientWithHandlers(map[string]http.HandlerFunc{
+		GetReposContentsRepoConfig: mockRepoConfig(t, testCommentTemplatesConfig),
+	}))}
+	ctx := context.WithValue(ContextWithDeps(context.Background(), deps), commentTemplatesContextKey{}, testServerCommentTemplates)
+
+	tests := []struct {
+		name           string
+		args           map[string]any
+		expected       []CommentTemplateSummary
+		expectedErrMsg string
+	}{
+		{
+			name: "server templates",
+			args: map[string]any{},
+			expected: []CommentTemplateSummary{
+				{Name: "duplicate", Description: "Close as a duplicate", Source: "server", Placeholders: []string{"original"}},
+				{Name: "needs-repro", Source: "server"},
+			},
+		},
+		{
+			name: "repository templates take precedence",
+			args: map[string]any{"owner": "octo-org", "repo": "configured"},
+			expected: []CommentTemplateSummary{
+				{Name: "duplicate", Description: "Close as a duplicate", Source: "server", Placeholders: []string{"original"}},
+				{Name: "needs-repro", Description: "Ask the reporter for a minimal reproduction", Source: "repository", Placeholders: []string{"reporter", "version"}},
+			},
+		},
+		{
+			name:           "owner without repo",
+			args:           map[string]any{"owner": "octo-org"},
+			expectedErrMsg: "owner and repo must be set together",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			request := createMCPRequest(tc.args)
+			result, err := serverTool.Handler(deps)(ctx, &request)
+			require.NoError(t, err)
+			if tc.expectedErrMsg != "" {
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+			var summaries []CommentTemplateSummary
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &summaries))
+			assert.Equal(t, tc.expected, summaries)
+		})
+	}
+}
+
+func Test_CreateIssueCommentFromTemplate(t *testing.T) {
+	serverTool := CreateIssueCommentFromTemplate(translations.NullTranslationHelper)
+	tool := serverTool.Tool
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "create_issue_comment_from_template", tool.Name)
+	assert.False(t, tool.Annotations.ReadOnlyHint)
+	schema := tool.InputSchema.(*jsonschema.Schema)
+	assert.ElementsMatch(t, schema.Required, []string{"owner", "repo", "issue_number", "template"})
+
+	tests := []struct {
+		name           string
+		args           map[string]any
+		expectedBody   string
+		expectedErrMsg string
+	}{
+		{
+			name:         "repository template",
+			args:         map[string]any{"template": "Needs-Repro", "substitutions": map[string]any{"reporter": "octocat", "version": "v1.2.0"}},
+			expectedBody: "Thanks @octocat! Could you share a minimal reproduction against v1.2.0?\n",
+		},
+		{
+			name:         "server template",
+			args:         map[string]any{"template": "duplicate", "substitutions": map[string]any{"original": "12"}},
+			expectedBody: "Closing as a duplicate of #12.",
+		},
+		{
+			name:           "missing substitution",
+			args:           map[string]any{"template": "needs-repro", "substitutions": map[string]any{"reporter": "octocat"}},
+			expectedErrMsg: "missing substitutions for placeholders: version",
+		},
+		{
+			name:           "unknown template",
+			args:           map[string]any{"template": "wontfix"},
+			expectedErrMsg: "comment template 'wontfix' not found; available templates: duplicate, needs-repro",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			repoConfigs.clear()
+			t.Cleanup(repoConfigs.clear)
+
+			createComment := mockResponse(t, http.StatusCreated, &github.IssueComment{
+				ID:      github.Ptr(int64(123)),
+				HTMLURL: github.Ptr("https://github.com/octo-org/configured/issues/42#issuecomment-123"),
+			})
+			if tc.expectedBody != "" {
+				createComment = expectRequestBody(t, map[string]any{"body": tc.expectedBody}).andThen(createComment)
+			}
+			deps := BaseDeps{Client: github.NewClient(MockHTTPClientWithHandlers(map[string]http.HandlerFunc{
+				GetReposContentsRepoConfig:                        mockRepoConfig(t, testCommentTemplatesConfig),
+				PostReposIssuesCommentsByOwnerByRepoByIssueNumber: createComment,
+			}))}
+			ctx := context.WithValue(ContextWithDeps(context.Background(), deps), commentTemplatesContextKey{}, testServerCommentTemplates)
+
+			args := map[string]any{"owner": "octo-org", "repo": "configured", "issue_number": float64(42)}
+			for k, v := range tc.args {
+				args[k] = v
+			}
+			request := createMCPRequest(args)
+			result, err := serverTool.Handler(deps)(ctx, &request)
+			require.NoError(t, err)
+			if tc.expectedErrMsg != "" {
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			var response MinimalResponse
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &response))
+			assert.Equal(t, "123", response.ID)
+		})
+	}
+}
@@ -0,0 +1,183 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/github/github-mcp-server/pkg/http/transport"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/github/github-mcp-server/pkg/utils"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// DestructiveToolPrompts maps the tools that need the user's confirmation to the prompt the user
+// confirms. A key is a tool name, optionally followed by conditions on the call's arguments, as in
+// "issue_write[method=update&state=closed]": only the calls whose arguments have these values are
+// then confirmed. "{name}" in a prompt is replaced by the value of the call's argument name.
+type DestructiveToolPrompts map[string]string
+
+// DefaultDestructiveToolPrompts are the tools confirmed by default, with their prompts.
+var DefaultDestructiveToolPrompts = DestructiveToolPrompts{
+	"delete_file":        "Delete {path} from the {branch} branch of {owner}/{repo}?",
+	"merge_pull_request": "Merge pull request #{pullNumber} in {owner}/{repo}?",
+	"issue_write[method=update&state=closed]": "Close issue #{issue_number} in {owner}/{repo}?",
+	"update_pull_request[state=closed]":       "Close pull request #{pullNumber} in {owner}/{repo}?",
+	"update_issue_state":                      "Set the state of issue #{issue_number} in {owner}/{repo} to {state}?",
+	"update_pull_request_state":               "Set the state of pull request #{pullNumber} in {owner}/{repo} to {state}?",
+	"delete_actions_cache":                    "Delete GitHub Actions caches of {owner}/{repo}?",
+}
+
+// promptPlaceholder matches the argument placeholders of a prompt.
+var promptPlaceholder = regexp.MustCompile(`\{([A-Za-z0-9_]+)\}`)
+
+// parseDestructiveToolKey splits a key of DestructiveToolPrompts into the tool name and the
+// argument values its calls must have.
+func parseDestructiveToolKey(key string) (string, map[string]string, error) {
+	name, rest, found := strings.Cut(key, "[")
+	name = strings.TrimSpace(name)
+	if !found {
+		return name, nil, nil
+	}
+	list, ok := strings.CutSuffix(strings.TrimSpace(rest), "]")
+	if !ok {
+		return "", nil, fmt.Errorf("missing ] after the conditions of %s", name)
+	}
+	conditions := make(map[string]string)
+	for _, condition := range strings.Split(list, "&") {
+		arg, value, ok := strings.Cut(condition, "=")
+		arg, value = strings.TrimSpace(arg), strings.TrimSpace(value)
+		if !ok || arg == "" || value == "" {
+			return "", nil, fmt.Errorf("invalid condition %q of %s: use argument=value", strings.TrimSpace(condition), name)
+		}
+		conditions[arg] = value
+	}
+	return name, conditions, nil
+}
+
+// match returns the prompt of a tool call, and whether the call needs confirmation. A key without
+// conditions takes precedence over those with conditions, which are tried in order.
+func (p DestructiveToolPrompts) match(name string, rawArgs json.RawMessage) (string, bool) {
+	if prompt, ok := p[name]; ok {
+		return prompt, true
+	}
+	var args map[string]any
+	_ = json.Unmarshal(rawArgs, &args)
+	for _, key := range slices.Sorted(maps.Keys(p)) {
+		tool, conditions, err := parseDestructiveToolKey(key)
+		if err != nil || tool != name || len(conditions) == 0 {
+			continue
+		}
+		matched := true
+		for arg, value := range conditions {
+			if v, ok := args[arg]; !ok || v == nil || !strings.EqualFold(fmt.Sprint(v), value) {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			return p[key], true
+		}
+	}
+	return "", false
+}
+
+// ParseDestructiveToolPrompts parses "tool" and "tool=prompt" entries, where tool is a tool name
+// optionally followed by conditions on its arguments, as in "update_pull_request[state=closed]".
+// Tools without a prompt use their default prompt, or a generic one. Without entries, the
+// defaults are returned.
+func ParseDestructiveToolPrompts(entries []string) (DestructiveToolPrompts, error) {
+	if len(entries) == 0 {
+		return DefaultDestructiveToolPrompts, nil
+	}
+
+	known := make(map[string]bool)
+	for _, tool := range AllTools(translations.NullTranslationHelper) {
+		known[tool.Tool.Name] = true
+	}
+
+	prompts := make(DestructiveToolPrompts, len(entries))
+	for _, entry := range entries {
+		key, prompt := strings.TrimSpace(entry), ""
+		// The conditions of a key contain "=", so the prompt starts after them
+		keyEnd := strings.Index(key, "]") + 1
+		if i := strings.Index(key[keyEnd:], "="); i >= 0 {
+			key, prompt = key[:keyEnd+i], key[keyEnd+i+1:]
+		}
+		name, conditions, err := parseDestructiveToolKey(key)
+		if err != nil {
+			return nil, fmt.Errorf("invalid destructive tool %q: %w", entry, err)
+		}
+		if !known[name] {
+			return nil, fmt.Errorf("invalid destructive tool %q: %q is not a tool", entry, name)
+		}
+		if len(conditions) > 0 {
+			conditionList := make([]string, 0, len(conditions))
+			for _, arg := range slices.Sorted(maps.Keys(conditions)) {
+				conditionList = append(conditionList, arg+"="+conditions[arg])
+			}
+			key = name + "[" + strings.Join(conditionList, "&") + "]"
+		} else {
+			key = name
+		}
+		prompt = strings.TrimSpace(prompt)
+		if prompt == "" {
+			prompt = DefaultDestructiveToolPrompts[key]
+		}
+		prompts[key] = prompt
+	}
+	return prompts, nil
+}
+
+// confirmationPrompt returns the prompt of a call, with the placeholders of its arguments filled
+// in.
+func confirmationPrompt(prompt string, req *mcp.CallToolRequest) string {
+	if prompt == "" {
+		return fmt.Sprintf("Allow the agent to call %s?", req.Params.Name)
+	}
+	var args map[string]any
+	_ = json.Unmarshal(req.Params.Arguments, &args)
+	return promptPlaceholder.ReplaceAllStringFunc(prompt, func(placeholder string) string {
+		value, ok := args[placeholder[1:len(placeholder)-1]]
+		if !ok || value == nil {
+			return placeholder
+		}
+		return fmt.Sprint(value)
+	})
+}
+
+// ConfirmDestructiveMiddleware asks the user to confirm the calls of the tools in prompts through
+// elicitation before they run. Calls the user declines, and calls from clients that cannot
+// elicit, fail without running. Dry runs are not confirmed, as they change nothing. It must run
+// inside DeprecatedAliasMiddleware and ArgumentRepairMiddleware, so that calls are matched by the
+// tool actually called and the arguments it runs with.
+func ConfirmDestructiveMiddleware(prompts DestructiveToolPrompts) mcp.Middleware {
+	return func(next mcp.MethodHandler) mcp.MethodHandler {
+		return func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+			callReq, ok := req.(*mcp.CallToolRequest)
+			if method != "tools/call" || !ok || callReq.Params == nil || transport.DryRunFromContext(ctx) != nil {
+				return next(ctx, method, req)
+			}
+			prompt, ok := prompts.match(callReq.Params.Name, callReq.Params.Arguments)
+			if !ok {
+				return next(ctx, method, req)
+			}
+
+			if !clientSupportsElicitation(callReq) {
+				return utils.NewToolResultError(fmt.Sprintf("%s requires the user's confirmation, but the client does not support elicitation", callReq.Params.Name)), nil
+			}
+			confirmed, err := confirmAction(ctx, callReq, confirmationPrompt(prompt, callReq))
+			if err != nil {
+				return utils.NewToolResultErrorFromErr(fmt.Sprintf("failed to ask for confirmation of %s", callReq.Params.Name), err), nil
+			}
+			if !confirmed {
+				return utils.NewToolResultError(fmt.Sprintf("the user did not confirm %s", callReq.Params.Name)), nil
+			}
+			return next(ctx, method, req)
+		}
+	}
+}
@@ -0,0 +1,177 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/github/github-mcp-server/pkg/http/transport"
+	"github.com/github/github-mcp-server/pkg/utils"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ParseDestructiveToolPrompts(t *testing.T) {
+	prompts, err := ParseDestructiveToolPrompts(nil)
+	require.NoError(t, err)
+	assert.Equal(t, DefaultDestructiveToolPrompts, prompts)
+
+	prompts, err = ParseDestructiveToolPrompts([]string{"merge_pull_request", " delete_file = Really delete {path}? ", "create_issue"})
+	require.NoError(t, err)
+	assert.Equal(t, DestructiveToolPrompts{
+		"merge_pull_request": DefaultDestructiveToolPrompts["merge_pull_request"],
+		"delete_file":        "Really delete {path}?",
+		"create_issue":       "",
+	}, prompts)
+
+	_, err = ParseDestructiveToolPrompts([]string{"drop_database"})
+	assert.ErrorContains(t, err, `"drop_database" is not a tool`)
+
+	prompts, err = ParseDestructiveToolPrompts([]string{
+		"issue_write[state=closed & method=update]",
+		"update_pull_request[state=closed]=Close #{pullNumber}?",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, DestructiveToolPrompts{
+		"issue_write[method=update&state=closed]": DefaultDestructiveToolPrompts["issue_write[method=update&state=closed]"],
+		"update_pull_request[state=closed]":       "Close #{pullNumber}?",
+	}, prompts)
+
+	_, err = ParseDestructiveToolPrompts([]string{"issue_write[state]"})
+	assert.ErrorContains(t, err, `invalid condition "state" of issue_write: use argument=value`)
+	_, err = ParseDestructiveToolPrompts([]string{"issue_write[state=closed"})
+	assert.ErrorContains(t, err, "missing ] after the conditions of issue_write")
+}
+
+func Test_DestructiveToolPromptsMatch(t *testing.T) {
+	prompts := DefaultDestructiveToolPrompts
+
+	prompt, ok := prompts.match("issue_write", json.RawMessage(`{"method":"update","owner":"octo","repo":"app","issue_number":7,"state":"closed"}`))
+	require.True(t, ok)
+	assert.Equal(t, "Close issue #{issue_number} in {owner}/{repo}?", prompt)
+	_, ok = prompts.match("update_pull_request", json.RawMessage(`{"owner":"octo","repo":"app","pullNumber":3,"state":"CLOSED"}`))
+	assert.True(t, ok)
+
+	// Calls that do not close anything are not confirmed
+	_, ok = prompts.match("issue_write", json.RawMessage(`{"method":"update","owner":"octo","repo":"app","issue_number":7,"title":"Renamed"}`))
+	assert.False(t, ok)
+	_, ok = prompts.match("issue_write", json.RawMessage(`{"method":"update","state":"open"}`))
+	assert.False(t, ok)
+	_, ok = prompts.match("update_pull_request", json.RawMessage(`{"pullNumber":3,"title":"Renamed"}`))
+	assert.False(t, ok)
+
+	prompt, ok = prompts.match("merge_pull_request", nil)
+	require.True(t, ok)
+	assert.Equal(t, DefaultDestructiveToolPrompts["merge_pull_request"], prompt)
+}
+
+func Test_ConfirmDestructiveMiddleware(t *testing.T) {
+	var called []string
+	next := func(_ context.Context, _ string, req mcp.Request) (mcp.Result, error) {
+		called = append(called, req.(*mcp.CallToolRequest).Params.Name)
+		return utils.NewToolResultText("done"), nil
+	}
+	handler := ConfirmDestructiveMiddleware(DestructiveToolPrompts{
+		"merge_pull_request": DefaultDestructiveToolPrompts["merge_pull_request"],
+		"create_issue":       "",
+	})(next)
+
+	var messages []string
+	connect := func(answer *mcp.ElicitResult) *mcp.ServerSession {
+		ctx := context.Background()
+		srv := mcp.NewServer(&mcp.Implementation{Name: "test"}, nil)
+		st, ct := mcp.NewInMemoryTransports()
+		ss, err := srv.Connect(ctx, st, nil)
+		require.NoError(t, err)
+		client := mcp.NewClient(&mcp.Implementation{Name: "test-client"}, &mcp.ClientOptions{
+			ElicitationHandler: func(_ context.Context, req *mcp.ElicitRequest) (*mcp.ElicitResult, error) {
+				messages = append(messages, req.Params.Message)
+				return answer, nil
+			},
+		})
+		cs, err := client.Connect(ctx, ct, nil)
+		require.NoError(t, err)
+		t.Cleanup(func() {
+			_ = cs.Close()
+			_ = ss.Close()
+		})
+		return ss
+	}
+	call := func(ctx context.Context, session *mcp.ServerSession, name, args string) *mcp.CallToolResult {
+		result, err := handler(ctx, "tools/call", &mcp.CallToolRequest{
+			Session: session,
+			Params:  &mcp.CallToolParamsRaw{Name: name, Arguments: json.RawMessage(args)},
+		})
+		require.NoError(t, err)
+		return result.(*mcp.CallToolResult)
+	}
+	confirming := connect(&mcp.ElicitResult{Action: "accept", Content: map[string]any{"confirm": true}})
+	declining := connect(&mcp.ElicitResult{Action: "decline"})
+
+	result := call(context.Background(), confirming, "merge_pull_request", `{"owner":"octo","repo":"app","pullNumber":42}`)
+	assert.False(t, result.IsError)
+	result = call(context.Background(), confirming, "create_issue", `{"owner":"octo","repo":"app","title":"Bug"}`)
+	assert.False(t, result.IsError)
+	assert.Equal(t, []string{"Merge pull request #42 in octo/app?", "Allow the agent to call create_issue?"}, messages)
+	assert.Equal(t, []string{"merge_pull_request", "create_issue"}, called)
+
+	result = call(context.Background(), declining, "merge_pull_request", `{"owner":"octo","repo":"app","pullNumber":42}`)
+	assert.Equal(t, "the user did not confirm merge_pull_request", getErrorResult(t, result).Text)
+
+	result = call(context.Background(), nil, "merge_pull_request", `{"owner":"octo","repo":"app","pullNumber":42}`)
+	assert.Contains(t, getErrorResult(t, result).Text, "the client does not support elicitation")
+
+	// Tools with conditions are confirmed only when their arguments match
+	closing := ConfirmDestructiveMiddleware(DefaultDestructiveToolPrompts)(next)
+	closeResult, err := closing(context.Background(), "tools/call", &mcp.CallToolRequest{
+		Session: declining,
+		Params:  &mcp.CallToolParamsRaw{Name: "update_pull_request", Arguments: json.RawMessage(`{"owner":"octo","repo":"app","pullNumber":3,"state":"closed"}`)},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "the user did not confirm update_pull_request", getErrorResult(t, closeResult.(*mcp.CallToolResult)).Text)
+	assert.Equal(t, "Close pull request #3 in octo/app?", messages[len(messages)-1])
+
+	// Tools that are not listed and dry runs are not confirmed
+	called = nil
+	result = call(context.Background(), nil, "get_me", `{}`)
+	assert.False(t, result.IsError)
+	dryRun := transport.ContextWithDryRun(context.Background(), &transport.DryRunRecorder{})
+	result = call(dryRun, nil, "merge_pull_request", `{"owner":"octo","repo":"app","pullNumber":42}`)
+	assert.False(t, result.IsError)
+	assert.Equal(t, []string{"get_me", "merge_pull_request"}, called)
+	assert.Len(t, messages, 4)
+}
+
+func Test_NewMCPServerConfirmsRepairedAndAliasCalls(t *testing.T) {
+	var messages []string
+	github := &countingTransport{}
+	cs := connectTestServer(t, MCPServerConfig{
+		ConfirmDestructive: DestructiveToolPrompts{
+			"issue_write[method=update&state=closed]":              DefaultDestructiveToolPrompts["issue_write[method=update&state=closed]"],
+			"actions_run_trigger[method=delete_workflow_run_logs]": "Delete the logs of run {run_id}?",
+		},
+	}, []string{"issues", "actions"}, github, &mcp.ClientOptions{
+		ElicitationHandler: func(_ context.Context, req *mcp.ElicitRequest) (*mcp.ElicitResult, error) {
+			messages = append(messages, req.Params.Message)
+			return &mcp.ElicitResult{Action: "decline"}, nil
+		},
+	})
+
+	result, err := cs.CallTool(context.Background(), &mcp.CallToolParams{
+		Name:      "issue_write",
+		Arguments: map[string]any{"method": "update", "owner": "octo", "repo": "ink", "issue_number": 7, "State": "closed"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "the user did not confirm issue_write", getErrorResult(t, result).Text)
+
+	result, err = cs.CallTool(context.Background(), &mcp.CallToolParams{
+		Name:      "delete_workflow_run_logs",
+		Arguments: map[string]any{"owner": "octo", "repo": "ink", "run_id": 7},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "the user did not confirm actions_run_trigger", getErrorResult(t, result).Text)
+
+	assert.Equal(t, []string{"Close issue #7 in octo/ink?", "Delete the logs of run 7?"}, messages)
+	assert.Zero(t, github.requests.Load())
+}
@@ -0,0 +1,85 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/github/github-mcp-server/pkg/inventory"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// ContentWindowOverrides overrides the content window size for some toolsets or tools, keyed by
+// toolset ID or tool name. A tool override takes precedence over the override of its toolset.
+type ContentWindowOverrides map[string]int
+
+// ParseContentWindowOverrides parses "name=size" entries, where name is a toolset ID or a tool
+// name, such as "actions=20000" or "get_job_logs=50000".
+func ParseContentWindowOverrides(entries []string) (ContentWindowOverrides, error) {
+	if len(entries) == 0 {
+		return nil, nil
+	}
+
+	known := make(map[string]bool)
+	for _, tool := range AllTools(translations.NullTranslationHelper) {
+		known[tool.Tool.Name] = true
+		known[string(tool.Toolset.ID)] = true
+	}
+
+	overrides := make(ContentWindowOverrides, len(entries))
+	for _, entry := range entries {
+		name, value, ok := strings.Cut(strings.TrimSpace(entry), "=")
+		name = strings.TrimSpace(name)
+		if !ok || name == "" {
+			return nil, fmt.Errorf("invalid content window override %q: expected name=size", entry)
+		}
+		if !known[name] {
+			return nil, fmt.Errorf("invalid content window override %q: %q is not a toolset or tool", entry, name)
+		}
+		size, err := strconv.Atoi(strings.TrimSpace(value))
+		if err != nil || size <= 0 {
+			return nil, fmt.Errorf("invalid content window override %q: size must be a positive integer", entry)
+		}
+		overrides[name] = size
+	}
+	return overrides, nil
+}
+
+// sizeFor returns the content window size overriding the default for a tool, if any.
+func (o ContentWindowOverrides) sizeFor(tool *inventory.ServerTool) (int, bool) {
+	if size, ok := o[tool.Tool.Name]; ok {
+		return size, true
+	}
+	size, ok := o[string(tool.Toolset.ID)]
+	return size, ok
+}
+
+type contentWindowContextKey struct{}
+
+// ContentWindowMiddleware applies the content window size overrides to tool calls. Tools read
+// the size in effect with ContentWindowSize.
+func ContentWindowMiddleware(overrides ContentWindowOverrides, inv *inventory.Inventory) mcp.Middleware {
+	return func(next mcp.MethodHandler) mcp.MethodHandler {
+		return func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+			if callReq, ok := req.(*mcp.CallToolRequest); ok && method == "tools/call" && callReq.Params != nil {
+				if tool, _, err := inv.FindToolByName(callReq.Params.Name); err == nil {
+					if size, ok := overrides.sizeFor(tool); ok {
+						ctx = context.WithValue(ctx, contentWindowContextKey{}, size)
+					}
+				}
+			}
+			return next(ctx, method, req)
+		}
+	}
+}
+
+// ContentWindowSize returns the content window size for the current tool call: its override, if
+// one is configured, or the default size of the server.
+func ContentWindowSize(ctx context.Context, deps ToolDependencies) int {
+	if size, ok := ctx.Value(contentWindowContextKey{}).(int); ok {
+		return size
+	}
+	return deps.GetContentWindowSize()
+}
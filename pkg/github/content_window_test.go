@@ -0,0 +1,59 @@
+package github
+
+import (
+	"context"
+	"testing"
+
+	"github.com/github/github-mcp-server/pkg/inventory"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ParseContentWindowOverrides(t *testing.T) {
+	overrides, err := ParseContentWindowOverrides(nil)
+	require.NoError(t, err)
+	assert.Nil(t, overrides)
+
+	overrides, err = ParseContentWindowOverrides([]string{"actions=20000", " get_job_logs = 50000 "})
+	require.NoError(t, err)
+	assert.Equal(t, ContentWindowOverrides{"actions": 20000, "get_job_logs": 50000}, overrides)
+
+	for _, entry := range []string{"actions", "=100", "actions=0", "actions=big", "no_such_tool=100"} {
+		_, err := ParseContentWindowOverrides([]string{entry})
+		assert.Error(t, err, entry)
+	}
+}
+
+func Test_ContentWindowMiddleware(t *testing.T) {
+	actions := inventory.ToolsetMetadata{ID: "actions"}
+	inv, err := inventory.NewBuilder().
+		SetTools([]inventory.ServerTool{
+			{Tool: mcp.Tool{Name: "get_job_logs"}, Toolset: actions},
+			{Tool: mcp.Tool{Name: "actions_list"}, Toolset: actions},
+			{Tool: mcp.Tool{Name: "list_issues"}, Toolset: inventory.ToolsetMetadata{ID: "issues"}},
+		}).
+		WithToolsets([]string{"all"}).
+		Build()
+	require.NoError(t, err)
+
+	deps := BaseDeps{ContentWindowSize: 5000}
+	var size int
+	handler := ContentWindowMiddleware(ContentWindowOverrides{"actions": 20000, "get_job_logs": 50000}, inv)(
+		func(ctx context.Context, _ string, _ mcp.Request) (mcp.Result, error) {
+			size = ContentWindowSize(ctx, deps)
+			return &mcp.CallToolResult{}, nil
+		},
+	)
+
+	tests := map[string]int{
+		"get_job_logs": 50000, // tool override wins over its toolset
+		"actions_list": 20000,
+		"list_issues":  5000,
+	}
+	for name, expected := range tests {
+		_, err := handler(context.Background(), "tools/call", &mcp.CallToolRequest{Params: &mcp.CallToolParamsRaw{Name: name}})
+		require.NoError(t, err)
+		assert.Equal(t, expected, size, name)
+	}
+}
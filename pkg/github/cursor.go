@@ -0,0 +1,114 @@
+package github
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"strings"
+	"sync"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// cursorPrefix versions the format of signed cursors.
+const cursorPrefix = "c1."
+
+// errInvalidCursor is returned for cursors that were not returned by the server, or were changed.
+var errInvalidCursor = errors.New("invalid cursor: pass the endCursor of a previous response of this tool unchanged in 'after'")
+
+// errForeignCursor is returned for cursors returned for another query, such as the same tool
+// listing another repository, or signed with another key.
+var errForeignCursor = errors.New("invalid cursor: it was returned for a different query, or before the server restarted; omit 'after' to start from the first page")
+
+// processCursorKey signs cursors when no key is configured. Cursors signed with it are only valid
+// until the server restarts, and only on the instance that returned them.
+var processCursorKey = sync.OnceValue(func() []byte {
+	key := make([]byte, 32)
+	_, _ = rand.Read(key)
+	return key
+})
+
+type cursorKeyContextKey struct{}
+
+// CursorSigningMiddleware signs the pagination cursors of tool calls with key, so that cursors
+// stay valid across restarts and across the instances of a server sharing the key.
+func CursorSigningMiddleware(key string) mcp.Middleware {
+	return func(next mcp.MethodHandler) mcp.MethodHandler {
+		return func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+			if method == "tools/call" {
+				ctx = context.WithValue(ctx, cursorKeyContextKey{}, []byte(key))
+			}
+			return next(ctx, method, req)
+		}
+	}
+}
+
+func cursorKey(ctx context.Context) []byte {
+	if key, ok := ctx.Value(cursorKeyContextKey{}).([]byte); ok {
+		return key
+	}
+	return processCursorKey()
+}
+
+func cursorMAC(ctx context.Context, scope, cursor string) []byte {
+	mac := hmac.New(sha256.New, cursorKey(ctx))
+	mac.Write([]byte(scope))
+	mac.Write([]byte{0})
+	mac.Write([]byte(cursor))
+	return mac.Sum(nil)[:16]
+}
+
+// signCursor wraps a GraphQL cursor into an opaque cursor bound to scope, which identifies the
+// query it pages through, such as "list_issues:owner/repo". Empty cursors stay empty.
+func signCursor(ctx context.Context, scope, cursor string) string {
+	if cursor == "" {
+		return ""
+	}
+	return cursorPrefix + base64.RawURLEncoding.EncodeToString([]byte(cursor)) + "." +
+		base64.RawURLEncoding.EncodeToString(cursorMAC(ctx, scope, cursor))
+}
+
+// verifyCursor returns the GraphQL cursor wrapped in a cursor returned by signCursor for the same
+// scope. Empty cursors stay empty.
+func verifyCursor(ctx context.Context, scope, signed string) (string, error) {
+	if signed == "" {
+		return "", nil
+	}
+	payload, sig, ok := strings.Cut(strings.TrimPrefix(signed, cursorPrefix), ".")
+	if !ok || !strings.HasPrefix(signed, cursorPrefix) {
+		return "", errInvalidCursor
+	}
+	cursor, err := base64.RawURLEncoding.DecodeString(payload)
+	if err != nil {
+		return "", errInvalidCursor
+	}
+	mac, err := base64.RawURLEncoding.DecodeString(sig)
+	if err != nil {
+		return "", errInvalidCursor
+	}
+	if !hmac.Equal(mac, cursorMAC(ctx, scope, string(cursor))) {
+		return "", errForeignCursor
+	}
+	return string(cursor), nil
+}
+
+// signPageInfo signs the cursors of a page for scope.
+func signPageInfo(ctx context.Context, scope string, pageInfo MinimalPageInfo) MinimalPageInfo {
+	pageInfo.StartCursor = signCursor(ctx, scope, pageInfo.StartCursor)
+	pageInfo.EndCursor = signCursor(ctx, scope, pageInfo.EndCursor)
+	return pageInfo
+}
+
+// ToSignedGraphQLParams converts cursor pagination parameters to GraphQL parameters, verifying
+// that the cursor was returned for scope.
+func (p CursorPaginationParams) ToSignedGraphQLParams(ctx context.Context, scope string) (*GraphQLPaginationParams, error) {
+	after, err := verifyCursor(ctx, scope, p.After)
+	if err != nil {
+		return nil, err
+	}
+	p.After = after
+	return p.ToGraphQLParams()
+}
@@ -0,0 +1,117 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/githubv4mock"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/shurcooL/githubv4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_SignedCursors(t *testing.T) {
+	ctx := context.Background()
+	scope := "list_issues:owner/repo"
+
+	signed := signCursor(ctx, scope, "Y3Vyc29yOnYyOpHOAAA=")
+	assert.NotContains(t, signed, "Y3Vyc29yOnYyOpHOAAA=")
+	cursor, err := verifyCursor(ctx, scope, signed)
+	require.NoError(t, err)
+	assert.Equal(t, "Y3Vyc29yOnYyOpHOAAA=", cursor)
+
+	// Empty cursors stay empty
+	assert.Empty(t, signCursor(ctx, scope, ""))
+	cursor, err = verifyCursor(ctx, scope, "")
+	require.NoError(t, err)
+	assert.Empty(t, cursor)
+
+	// Cursors are bound to the query they were returned for
+	_, err = verifyCursor(ctx, "list_issues:owner/other", signed)
+	assert.ErrorIs(t, err, errForeignCursor)
+
+	// Raw GraphQL cursors and tampered cursors are rejected
+	_, err = verifyCursor(ctx, scope, "Y3Vyc29yOnYyOpHOAAA=")
+	assert.ErrorIs(t, err, errInvalidCursor)
+	_, err = verifyCursor(ctx, scope, cursorPrefix+"Y3Vyc29yMg."+signed[len(signed)-22:])
+	assert.ErrorIs(t, err, errForeignCursor)
+
+	// Servers sharing a signing key accept each other's cursors
+	var keyed context.Context
+	handler := CursorSigningMiddleware("shared-secret")(func(ctx context.Context, _ string, _ mcp.Request) (mcp.Result, error) {
+		keyed = ctx
+		return nil, nil
+	})
+	_, _ = handler(ctx, "tools/call", &mcp.CallToolRequest{})
+	fromKeyed := signCursor(keyed, scope, "abc")
+	cursor, err = verifyCursor(context.WithValue(ctx, cursorKeyContextKey{}, []byte("shared-secret")), scope, fromKeyed)
+	require.NoError(t, err)
+	assert.Equal(t, "abc", cursor)
+	_, err = verifyCursor(ctx, scope, fromKeyed)
+	assert.ErrorIs(t, err, errForeignCursor)
+}
+
+func Test_ListSavedReplies_SignedCursor(t *testing.T) {
+	serverTool := ListSavedReplies(translations.NullTranslationHelper)
+
+	var query struct {
+		Viewer struct {
+			SavedReplies struct {
+				Nodes      []savedReplyNode
+				PageInfo   PageInfoFragment
+				TotalCount int
+			} `graphql:"savedReplies(first: $first, after: $after)"`
+		}
+	}
+	mockedClient := githubv4mock.NewMockedHTTPClient(
+		githubv4mock.NewQueryMatcher(
+			query,
+			map[string]any{
+				"first": githubv4.Int(30),
+				"after": githubv4.String("Y3Vyc29yOjI="),
+			},
+			githubv4mock.DataResponse(map[string]any{
+				"viewer": map[string]any{
+					"savedReplies": map[string]any{
+						"nodes": []map[string]any{
+							{"id": "SR_3", "title": "Stale", "body": "Closing as stale."},
+						},
+						"pageInfo": map[string]any{
+							"hasNextPage":     true,
+							"hasPreviousPage": true,
+							"startCursor":     "Y3Vyc29yOjM=",
+							"endCursor":       "Y3Vyc29yOjM=",
+						},
+						"totalCount": 5,
+					},
+				},
+			}),
+		),
+	)
+	deps := BaseDeps{GQLClient: githubv4.NewClient(mockedClient)}
+	handler := serverTool.Handler(deps)
+	ctx := ContextWithDeps(context.Background(), deps)
+
+	request := createMCPRequest(map[string]any{"after": signCursor(ctx, savedRepliesCursorScope, "Y3Vyc29yOjI=")})
+	result, err := handler(ctx, &request)
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	var response struct {
+		PageInfo MinimalPageInfo `json:"pageInfo"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &response))
+	assert.True(t, response.PageInfo.HasNextPage)
+	next, err := verifyCursor(ctx, savedRepliesCursorScope, response.PageInfo.EndCursor)
+	require.NoError(t, err)
+	assert.Equal(t, "Y3Vyc29yOjM=", next)
+
+	// A cursor returned by another tool is rejected before querying GitHub
+	request = createMCPRequest(map[string]any{"after": signCursor(ctx, "list_issues:owner/repo", "Y3Vyc29yOjI=")})
+	result, err = handler(ctx, &request)
+	require.NoError(t, err)
+	assert.Contains(t, getErrorResult(t, result).Text, "it was returned for a different query")
+}
@@ -126,6 +126,20 @@ func ListDependabotAlerts(t translations.TranslationHelperFunc) inventory.Server
 						Description: "Filter dependabot alerts by severity",
 						Enum:        []any{"low", "medium", "high", "critical"},
 					},
+					"ecosystem": {
+						Type:        "string",
+						Description: "Filter dependabot alerts by package ecosystem",
+						Enum:        []any{"composer", "go", "maven", "npm", "nuget", "pip", "pub", "rubygems", "rust"},
+					},
+					"package": {
+						Type:        "string",
+						Description: "Filter dependabot alerts by the name of the vulnerable package",
+					},
+					"scope": {
+						Type:        "string",
+						Description: "Filter dependabot alerts by the scope of the vulnerable dependency",
+						Enum:        []any{"development", "runtime"},
+					},
 				},
 				Required: []string{"owner", "repo"},
 			},
@@ -148,6 +162,18 @@ func ListDependabotAlerts(t translations.TranslationHelperFunc) inventory.Server
 			if err != nil {
 				return utils.NewToolResultError(err.Error()), nil, nil
 			}
+			ecosystem, err := OptionalParam[string](args, "ecosystem")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			pkg, err := OptionalParam[string](args, "package")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			scope, err := OptionalParam[string](args, "scope")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
 
 			client, err := deps.GetClient(ctx)
 			if err != nil {
@@ -155,8 +181,11 @@ func ListDependabotAlerts(t translations.TranslationHelperFunc) inventory.Server
 			}
 
 			alerts, resp, err := client.Dependabot.ListRepoAlerts(ctx, owner, repo, &github.ListAlertsOptions{
-				State:    ToStringPtr(state),
-				Severity: ToStringPtr(severity),
+				State:     ToStringPtr(state),
+				Severity:  ToStringPtr(severity),
+				Ecosystem: ToStringPtr(ecosystem),
+				Package:   ToStringPtr(pkg),
+				Scope:     ToStringPtr(scope),
 			})
 			if err != nil {
 				return ghErrors.NewGitHubAPIErrorResponse(ctx,
@@ -184,3 +213,117 @@ func ListDependabotAlerts(t translations.TranslationHelperFunc) inventory.Server
 		},
 	)
 }
+
+func UpdateDependabotAlert(t translations.TranslationHelperFunc) inventory.ServerTool {
+	return NewTool(
+		ToolsetMetadataDependabot,
+		mcp.Tool{
+			Name:        "update_dependabot_alert",
+			Description: t("TOOL_UPDATE_DEPENDABOT_ALERT_DESCRIPTION", "Dismiss a dependabot alert with a reason, or reopen a dismissed alert, in a GitHub repository."),
+			Annotations: &mcp.ToolAnnotations{
+				Title:        t("TOOL_UPDATE_DEPENDABOT_ALERT_USER_TITLE", "Update dependabot alert"),
+				ReadOnlyHint: false,
+			},
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"owner": {
+						Type:        "string",
+						Description: "The owner of the repository.",
+					},
+					"repo": {
+						Type:        "string",
+						Description: "The name of the repository.",
+					},
+					"alertNumber": {
+						Type:        "number",
+						Description: "The number of the alert.",
+					},
+					"state": {
+						Type:        "string",
+						Description: "The new state of the alert.",
+						Enum:        []any{"open", "dismissed"},
+					},
+					"dismissed_reason": {
+						Type:        "string",
+						Description: "The reason for dismissing the alert. Required when state is dismissed.",
+						Enum:        []any{"fix_started", "inaccurate", "no_bandwidth", "not_used", "tolerable_risk"},
+					},
+					"dismissed_comment": {
+						Type:        "string",
+						Description: "A comment explaining the dismissal, up to 280 characters.",
+						MaxLength:   jsonschema.Ptr(280),
+					},
+				},
+				Required: []string{"owner", "repo", "alertNumber", "state"},
+			},
+		},
+		[]scopes.Scope{scopes.SecurityEvents},
+		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+			owner, err := RequiredParam[string](args, "owner")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			repo, err := RequiredParam[string](args, "repo")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			alertNumber, err := RequiredInt(args, "alertNumber")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			state, err := RequiredParam[string](args, "state")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			dismissedReason, err := OptionalParam[string](args, "dismissed_reason")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			dismissedComment, err := OptionalParam[string](args, "dismissed_comment")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+
+			stateInfo := &github.DependabotAlertState{State: state}
+			switch state {
+			case "dismissed":
+				if dismissedReason == "" {
+					return utils.NewToolResultError("dismissed_reason is required when state is dismissed"), nil, nil
+				}
+				stateInfo.DismissedReason = github.Ptr(dismissedReason)
+				if dismissedComment != "" {
+					stateInfo.DismissedComment = github.Ptr(dismissedComment)
+				}
+			case "open":
+				if dismissedReason != "" || dismissedComment != "" {
+					return utils.NewToolResultError("dismissed_reason and dismissed_comment can only be set when state is dismissed"), nil, nil
+				}
+			default:
+				return utils.NewToolResultError(fmt.Sprintf("invalid state %q: must be open or dismissed", state)), nil, nil
+			}
+
+			client, err := deps.GetClient(ctx)
+			if err != nil {
+				return utils.NewToolResultErrorFromErr("failed to get GitHub client", err), nil, nil
+			}
+
+			alert, resp, err := client.Dependabot.UpdateAlert(ctx, owner, repo, alertNumber, stateInfo)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to update alert with number '%d'", alertNumber),
+					resp,
+					err,
+				), nil, nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			r, err := json.Marshal(alert)
+			if err != nil {
+				return utils.NewToolResultErrorFromErr("failed to marshal alert", err), nil, nil
+			}
+
+			return utils.NewToolResultText(string(r)), nil, nil
+		},
+	)
+}
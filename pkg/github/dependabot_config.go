@@ -0,0 +1,502 @@
+package github
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"path"
+	"slices"
+	"strings"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/inventory"
+	"github.com/github/github-mcp-server/pkg/scopes"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/github/github-mcp-server/pkg/utils"
+	"github.com/google/go-github/v82/github"
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"go.yaml.in/yaml/v3"
+)
+
+const (
+	defaultDependabotConfigPath   = ".github/dependabot.yml"
+	defaultDependabotInterval     = "weekly"
+	defaultDependabotConfigBranch = "dependabot-config"
+)
+
+// dependabotConfigPaths are the locations Dependabot reads its configuration from.
+var dependabotConfigPaths = []string{defaultDependabotConfigPath, ".github/dependabot.yaml"}
+
+// dependabotManifests maps the manifest files of each package ecosystem Dependabot supports to
+// the ecosystem.
+var dependabotManifests = map[string]string{
+	"package.json":     "npm",
+	"requirements.txt": "pip",
+	"pyproject.toml":   "pip",
+	"setup.py":         "pip",
+	"Pipfile":          "pip",
+	"go.mod":           "gomod",
+	"Cargo.toml":       "cargo",
+	"Gemfile":          "bundler",
+	"composer.json":    "composer",
+	"pom.xml":          "maven",
+	"build.gradle":     "gradle",
+	"build.gradle.kts": "gradle",
+	"packages.config":  "nuget",
+	"Dockerfile":       "docker",
+	"mix.exs":          "mix",
+	"pubspec.yaml":     "pub",
+	"Package.swift":    "swift",
+	"elm.json":         "elm",
+	".gitmodules":      "gitsubmodule",
+}
+
+// dependabotManifestExtensions maps manifest file extensions to their package ecosystem.
+var dependabotManifestExtensions = map[string]string{
+	".csproj": "nuget",
+	".fsproj": "nuget",
+	".vbproj": "nuget",
+	".tf":     "terraform",
+}
+
+// dependabotSkippedDirs hold vendored or test code whose manifests Dependabot should not update.
+var dependabotSkippedDirs = map[string]bool{"node_modules": true, "vendor": true, "testdata": true, "third_party": true}
+
+// DependabotEcosystem is a package ecosystem found in a repository, with the directories of its
+// manifests.
+type DependabotEcosystem struct {
+	Ecosystem   string   `json:"ecosystem"`
+	Directories []string `json:"directories"`
+}
+
+// DependabotConfigProposal is a Dependabot configuration covering the ecosystems of a repository.
+type DependabotConfigProposal struct {
+	Path string `json:"path"`
+	// Exists is whether the repository already has a Dependabot configuration, which the
+	// proposal extends while keeping its entries.
+	Exists     bool                  `json:"exists"`
+	Ecosystems []DependabotEcosystem `json:"ecosystems"`
+	// Added lists the ecosystems and directories not covered by the existing configuration.
+	Added   []DependabotEcosystem `json:"added"`
+	Changed bool                  `json:"changed"`
+	Config  string                `json:"config"`
+	// Truncated is set when the repository tree was too large to be listed in full, so some
+	// manifests may have been missed.
+	Truncated   bool   `json:"truncated,omitempty"`
+	Branch      string `json:"branch,omitempty"`
+	PullRequest string `json:"pull_request,omitempty"`
+	Message     string `json:"message,omitempty"`
+
+	// base is the default branch the proposal was made for, and sha the blob of the existing
+	// configuration.
+	base, sha string
+}
+
+// dependabotUpdate is an entry of the updates of a Dependabot configuration.
+type dependabotUpdate struct {
+	PackageEcosystem string   `yaml:"package-ecosystem"`
+	Directory        string   `yaml:"directory,omitempty"`
+	Directories      []string `yaml:"directories,omitempty"`
+	Schedule         struct {
+		Interval string `yaml:"interval"`
+	} `yaml:"schedule"`
+}
+
+// covers reports whether the entry updates the manifests of an ecosystem in a directory.
+func (u dependabotUpdate) covers(ecosystem, dir string) bool {
+	if u.PackageEcosystem != ecosystem {
+		return false
+	}
+	for _, pattern := range append([]string{u.Directory}, u.Directories...) {
+		if pattern == "" {
+			continue
+		}
+		pattern = "/" + strings.Trim(pattern, "/")
+		if pattern == dir || matchRulesetGlob(strings.TrimPrefix(pattern, "/"), strings.TrimPrefix(dir, "/")) {
+			return true
+		}
+	}
+	return false
+}
+
+// detectDependabotEcosystems returns the package ecosystems whose manifests are in a tree.
+func detectDependabotEcosystems(entries []*github.TreeEntry) []DependabotEcosystem {
+	dirs := map[string]map[string]bool{}
+	add := func(ecosystem, dir string) {
+		if dirs[ecosystem] == nil {
+			dirs[ecosystem] = map[string]bool{}
+		}
+		dirs[ecosystem]["/"+strings.TrimPrefix(dir, "/")] = true
+	}
+
+	for _, entry := range entries {
+		if entry.GetType() != "blob" {
+			continue
+		}
+		filePath := entry.GetPath()
+		dir, name := path.Split(filePath)
+		dir = strings.TrimSuffix(dir, "/")
+		if dir == ".github/workflows" && (strings.HasSuffix(name, ".yml") || strings.HasSuffix(name, ".yaml")) {
+			add("github-actions", "")
+			continue
+		}
+		skip := false
+		for _, segment := range strings.Split(dir, "/") {
+			if dependabotSkippedDirs[segment] || strings.HasPrefix(segment, ".") {
+				skip = true
+				break
+			}
+		}
+		if skip {
+			continue
+		}
+		if ecosystem, ok := dependabotManifests[name]; ok {
+			add(ecosystem, dir)
+		} else if ecosystem, ok := dependabotManifestExtensions[path.Ext(name)]; ok {
+			add(ecosystem, dir)
+		}
+	}
+
+	ecosystems := make([]DependabotEcosystem, 0, len(dirs))
+	for ecosystem, set := range dirs {
+		list := make([]string, 0, len(set))
+		for dir := range set {
+			list = append(list, dir)
+		}
+		slices.Sort(list)
+		ecosystems = append(ecosystems, DependabotEcosystem{Ecosystem: ecosystem, Directories: list})
+	}
+	slices.SortFunc(ecosystems, func(a, b DependabotEcosystem) int { return strings.Compare(a.Ecosystem, b.Ecosystem) })
+	return ecosystems
+}
+
+// buildDependabotConfig returns a configuration updating the ecosystems weekly, or at interval,
+// extending the existing configuration when there is one. Existing entries and comments are
+// kept; the ecosystems and directories they do not cover are added.
+func buildDependabotConfig(existing []byte, ecosystems []DependabotEcosystem, interval string) (string, []DependabotEcosystem, error) {
+	var current struct {
+		Updates []dependabotUpdate `yaml:"updates"`
+	}
+	doc := &yaml.Node{}
+	if len(existing) > 0 {
+		if err := yaml.Unmarshal(existing, &current); err != nil {
+			return "", nil, fmt.Errorf("failed to parse the existing configuration: %w", err)
+		}
+		if err := yaml.Unmarshal(existing, doc); err != nil {
+			return "", nil, fmt.Errorf("failed to parse the existing configuration: %w", err)
+		}
+	}
+	if len(doc.Content) == 0 || doc.Content[0].Kind != yaml.MappingNode {
+		doc = &yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{{
+			Kind: yaml.MappingNode,
+			Content: []*yaml.Node{
+				{Kind: yaml.ScalarNode, Value: "version"},
+				{Kind: yaml.ScalarNode, Tag: "!!int", Value: "2"},
+			},
+		}}}
+	}
+
+	root := doc.Content[0]
+	var updates *yaml.Node
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		if root.Content[i].Value == "updates" {
+			if root.Content[i+1].Kind != yaml.SequenceNode {
+				// An empty updates: is null
+				root.Content[i+1] = &yaml.Node{Kind: yaml.SequenceNode}
+			}
+			updates = root.Content[i+1]
+		}
+	}
+	if updates == nil {
+		updates = &yaml.Node{Kind: yaml.SequenceNode}
+		root.Content = append(root.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: "updates"}, updates)
+	}
+
+	var added []DependabotEcosystem
+	for _, ecosystem := range ecosystems {
+		var missing []string
+		for _, dir := range ecosystem.Directories {
+			if !slices.ContainsFunc(current.Updates, func(u dependabotUpdate) bool { return u.covers(ecosystem.Ecosystem, dir) }) {
+				missing = append(missing, dir)
+			}
+		}
+		if len(missing) == 0 {
+			continue
+		}
+		added = append(added, DependabotEcosystem{Ecosystem: ecosystem.Ecosystem, Directories: missing})
+
+		update := dependabotUpdate{PackageEcosystem: ecosystem.Ecosystem}
+		if len(missing) == 1 {
+			update.Directory = missing[0]
+		} else {
+			update.Directories = missing
+		}
+		update.Schedule.Interval = interval
+		node := &yaml.Node{}
+		if err := node.Encode(update); err != nil {
+			return "", nil, err
+		}
+		updates.Content = append(updates.Content, node)
+	}
+
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(doc); err != nil {
+		return "", nil, err
+	}
+	if err := encoder.Close(); err != nil {
+		return "", nil, err
+	}
+	return buf.String(), added, nil
+}
+
+// proposeDependabotConfig inspects the default branch of a repository and proposes a Dependabot
+// configuration covering its package ecosystems.
+func proposeDependabotConfig(ctx context.Context, client *github.Client, owner, repo, interval string) (*DependabotConfigProposal, *github.Response, error) {
+	repository, resp, err := client.Repositories.Get(ctx, owner, repo)
+	if err != nil {
+		return nil, resp, fmt.Errorf("failed to get repository: %w", err)
+	}
+	_ = resp.Body.Close()
+	ref := repository.GetDefaultBranch()
+
+	tree, resp, err := client.Git.GetTree(ctx, owner, repo, ref, true)
+	if err != nil {
+		return nil, resp, fmt.Errorf("failed to get repository tree: %w", err)
+	}
+	_ = resp.Body.Close()
+
+	proposal := &DependabotConfigProposal{
+		base:       ref,
+		Path:       defaultDependabotConfigPath,
+		Ecosystems: detectDependabotEcosystems(tree.Entries),
+		Truncated:  tree.GetTruncated(),
+	}
+
+	var existing []byte
+	for _, configPath := range dependabotConfigPaths {
+		file, _, resp, err := client.Repositories.GetContents(ctx, owner, repo, configPath, &github.RepositoryContentGetOptions{Ref: ref})
+		if resp != nil {
+			_ = resp.Body.Close()
+		}
+		if err != nil {
+			if resp != nil && resp.StatusCode == http.StatusNotFound {
+				continue
+			}
+			return nil, resp, fmt.Errorf("failed to get %s: %w", configPath, err)
+		}
+		if file == nil {
+			continue
+		}
+		content, err := file.GetContent()
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to decode %s: %w", configPath, err)
+		}
+		existing = []byte(content)
+		proposal.Path = configPath
+		proposal.Exists = true
+		proposal.sha = file.GetSHA()
+		break
+	}
+
+	config, added, err := buildDependabotConfig(existing, proposal.Ecosystems, interval)
+	if err != nil {
+		return nil, nil, err
+	}
+	proposal.Config = config
+	proposal.Added = added
+	proposal.Changed = len(added) > 0 || !proposal.Exists
+	if !proposal.Changed {
+		proposal.Config = string(existing)
+	}
+	return proposal, nil, nil
+}
+
+// dependabotConfigSchema returns the input schema shared by the Dependabot configuration tools.
+func dependabotConfigSchema(extra map[string]*jsonschema.Schema) *jsonschema.Schema {
+	properties := map[string]*jsonschema.Schema{
+		"owner": {
+			Type:        "string",
+			Description: DescriptionRepositoryOwner,
+		},
+		"repo": {
+			Type:        "string",
+			Description: DescriptionRepositoryName,
+		},
+		"interval": {
+			Type:        "string",
+			Description: fmt.Sprintf("How often Dependabot checks for updates of the added ecosystems (default %s)", defaultDependabotInterval),
+			Enum:        []any{"daily", "weekly", "monthly"},
+		},
+	}
+	for name, schema := range extra {
+		properties[name] = schema
+	}
+	return &jsonschema.Schema{Type: "object", Properties: properties, Required: []string{"owner", "repo"}}
+}
+
+// GenerateDependabotConfig creates a tool that proposes a Dependabot configuration covering the
+// package ecosystems of a repository.
+func GenerateDependabotConfig(t translations.TranslationHelperFunc) inventory.ServerTool {
+	return NewTool(
+		ToolsetMetadataDependabot,
+		mcp.Tool{
+			Name: "generate_dependabot_config",
+			Description: t("TOOL_GENERATE_DEPENDABOT_CONFIG_DESCRIPTION", `Detect the package ecosystems of a repository from the manifests on its default branch (package.json, go.mod, requirements.txt, Dockerfile, workflows, ...) and generate a .github/dependabot.yml covering them.
+An existing configuration is extended rather than replaced: its entries and comments are kept, and only ecosystems and directories it does not cover are added. Nothing is written; use 'update_dependabot_config' to open a pull request with the configuration.`),
+			Annotations: &mcp.ToolAnnotations{
+				Title:        t("TOOL_GENERATE_DEPENDABOT_CONFIG_USER_TITLE", "Generate Dependabot configuration"),
+				ReadOnlyHint: true,
+			},
+			InputSchema: dependabotConfigSchema(nil),
+		},
+		[]scopes.Scope{scopes.Repo},
+		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+			owner, err := RequiredParam[string](args, "owner")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			repo, err := RequiredParam[string](args, "repo")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			interval, err := OptionalParam[string](args, "interval")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			if interval == "" {
+				interval = defaultDependabotInterval
+			}
+
+			client, err := deps.GetClient(ctx)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			proposal, resp, err := proposeDependabotConfig(ctx, client, owner, repo, interval)
+			if err != nil {
+				if resp != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to generate Dependabot configuration", resp, err), nil, nil
+				}
+				return utils.NewToolResultErrorFromErr("failed to generate Dependabot configuration", err), nil, nil
+			}
+			return MarshalledTextResult(proposal), nil, nil
+		},
+	)
+}
+
+// UpdateDependabotConfig creates a tool that opens a pull request adding the package ecosystems
+// of a repository to its Dependabot configuration.
+func UpdateDependabotConfig(t translations.TranslationHelperFunc) inventory.ServerTool {
+	return NewTool(
+		ToolsetMetadataDependabot,
+		mcp.Tool{
+			Name: "update_dependabot_config",
+			Description: t("TOOL_UPDATE_DEPENDABOT_CONFIG_DESCRIPTION", `Open a pull request creating or extending a repository's .github/dependabot.yml so that it covers every package ecosystem found on the default branch.
+The configuration is the one 'generate_dependabot_config' proposes: existing entries and comments are kept. No pull request is opened when the configuration already covers every ecosystem.`),
+			Annotations: &mcp.ToolAnnotations{
+				Title:        t("TOOL_UPDATE_DEPENDABOT_CONFIG_USER_TITLE", "Update Dependabot configuration"),
+				ReadOnlyHint: false,
+			},
+			InputSchema: dependabotConfigSchema(map[string]*jsonschema.Schema{
+				"branch": {
+					Type:        "string",
+					Description: fmt.Sprintf("Name of the branch to create for the change (default %s)", defaultDependabotConfigBranch),
+				},
+			}),
+		},
+		[]scopes.Scope{scopes.Repo},
+		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+			owner, err := RequiredParam[string](args, "owner")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			repo, err := RequiredParam[string](args, "repo")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			interval, err := OptionalParam[string](args, "interval")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			if interval == "" {
+				interval = defaultDependabotInterval
+			}
+			branch, err := OptionalParam[string](args, "branch")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			if branch == "" {
+				branch = defaultDependabotConfigBranch
+			}
+
+			client, err := deps.GetClient(ctx)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			proposal, resp, err := proposeDependabotConfig(ctx, client, owner, repo, interval)
+			if err != nil {
+				if resp != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to generate Dependabot configuration", resp, err), nil, nil
+				}
+				return utils.NewToolResultErrorFromErr("failed to generate Dependabot configuration", err), nil, nil
+			}
+			if !proposal.Changed {
+				proposal.Message = "the Dependabot configuration already covers every ecosystem; no pull request was opened"
+				return MarshalledTextResult(proposal), nil, nil
+			}
+			if len(proposal.Ecosystems) == 0 {
+				return utils.NewToolResultError("no package ecosystems supported by Dependabot were found in the repository"), nil, nil
+			}
+
+			if _, err := createReferenceFromDefaultBranch(ctx, client, owner, repo, branch); err != nil {
+				return utils.NewToolResultErrorFromErr(fmt.Sprintf("failed to create branch %s", branch), err), nil, nil
+			}
+			proposal.Branch = branch
+
+			message := "Add Dependabot configuration"
+			if proposal.Exists {
+				message = "Update Dependabot configuration"
+			}
+			opts := &github.RepositoryContentFileOptions{
+				Message: github.Ptr(message),
+				Content: []byte(proposal.Config),
+				Branch:  github.Ptr(branch),
+			}
+			if proposal.sha != "" {
+				opts.SHA = github.Ptr(proposal.sha)
+			}
+			_, resp, err = client.Repositories.CreateFile(ctx, owner, repo, proposal.Path, opts)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, fmt.Sprintf("failed to write %s", proposal.Path), resp, err), nil, nil
+			}
+			_ = resp.Body.Close()
+
+			var body strings.Builder
+			body.WriteString("Configures Dependabot version updates for the package ecosystems found in the repository:\n\n")
+			for _, ecosystem := range proposal.Added {
+				fmt.Fprintf(&body, "- `%s` in `%s`\n", ecosystem.Ecosystem, strings.Join(ecosystem.Directories, "`, `"))
+			}
+			if proposal.Truncated {
+				body.WriteString("\nThe repository tree was too large to list in full, so some manifests may be missing.\n")
+			}
+			pr, resp, err := client.PullRequests.Create(ctx, owner, repo, &github.NewPullRequest{
+				Title: github.Ptr(message),
+				Head:  github.Ptr(branch),
+				Base:  github.Ptr(proposal.base),
+				Body:  github.Ptr(body.String()),
+			})
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, fmt.Sprintf("failed to open a pull request for branch %s", branch), resp, err), nil, nil
+			}
+			_ = resp.Body.Close()
+			proposal.PullRequest = pr.GetHTMLURL()
+
+			return MarshalledTextResult(proposal), nil, nil
+		},
+	)
+}
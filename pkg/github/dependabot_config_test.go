@@ -0,0 +1,209 @@
+package github
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/inventory"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v82/github"
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_DetectDependabotEcosystems(t *testing.T) {
+	var entries []*github.TreeEntry
+	for _, p := range []string{
+		"go.mod",
+		"web/package.json",
+		"web/node_modules/left-pad/package.json",
+		"docs/package.json",
+		"deploy/Dockerfile",
+		"infra/main.tf",
+		"infra/vars.tf",
+		"src/App/App.csproj",
+		".github/workflows/ci.yml",
+		".github/actions/setup/action.yml",
+		".devcontainer/Dockerfile",
+		"internal/testdata/go.mod",
+		"README.md",
+	} {
+		entries = append(entries, &github.TreeEntry{Path: github.Ptr(p), Type: github.Ptr("blob")})
+	}
+	entries = append(entries, &github.TreeEntry{Path: github.Ptr("web"), Type: github.Ptr("tree")})
+
+	assert.Equal(t, []DependabotEcosystem{
+		{Ecosystem: "docker", Directories: []string{"/deploy"}},
+		{Ecosystem: "github-actions", Directories: []string{"/"}},
+		{Ecosystem: "gomod", Directories: []string{"/"}},
+		{Ecosystem: "npm", Directories: []string{"/docs", "/web"}},
+		{Ecosystem: "nuget", Directories: []string{"/src/App"}},
+		{Ecosystem: "terraform", Directories: []string{"/infra"}},
+	}, detectDependabotEcosystems(entries))
+}
+
+func Test_BuildDependabotConfig(t *testing.T) {
+	ecosystems := []DependabotEcosystem{
+		{Ecosystem: "gomod", Directories: []string{"/"}},
+		{Ecosystem: "npm", Directories: []string{"/docs", "/web"}},
+	}
+
+	t.Run("new configuration", func(t *testing.T) {
+		config, added, err := buildDependabotConfig(nil, ecosystems, "weekly")
+		require.NoError(t, err)
+		assert.Equal(t, ecosystems, added)
+		assert.Equal(t, `version: 2
+updates:
+  - package-ecosystem: gomod
+    directory: /
+    schedule:
+      interval: weekly
+  - package-ecosystem: npm
+    directories:
+      - /docs
+      - /web
+    schedule:
+      interval: weekly
+`, config)
+	})
+
+	t.Run("extends an existing configuration", func(t *testing.T) {
+		existing := `# Managed by the platform team
+version: 2
+updates:
+  - package-ecosystem: npm
+    directories: ["/web"]
+    schedule:
+      interval: daily # keep npm fresh
+`
+		config, added, err := buildDependabotConfig([]byte(existing), ecosystems, "monthly")
+		require.NoError(t, err)
+		assert.Equal(t, []DependabotEcosystem{
+			{Ecosystem: "gomod", Directories: []string{"/"}},
+			{Ecosystem: "npm", Directories: []string{"/docs"}},
+		}, added)
+		assert.Contains(t, config, "# Managed by the platform team")
+		assert.Contains(t, config, "interval: daily # keep npm fresh")
+		assert.Contains(t, config, "  - package-ecosystem: npm\n    directory: /docs\n    schedule:\n      interval: monthly\n")
+	})
+
+	t.Run("glob directories", func(t *testing.T) {
+		existing := "version: 2\nupdates:\n  - package-ecosystem: npm\n    directories: [\"/**\"]\n    schedule: {interval: weekly}\n  - package-ecosystem: gomod\n    directory: \"/\"\n    schedule: {interval: weekly}\n"
+		_, added, err := buildDependabotConfig([]byte(existing), ecosystems, "weekly")
+		require.NoError(t, err)
+		assert.Empty(t, added)
+	})
+
+	t.Run("invalid configuration", func(t *testing.T) {
+		_, _, err := buildDependabotConfig([]byte("updates: ["), ecosystems, "weekly")
+		assert.ErrorContains(t, err, "failed to parse the existing configuration")
+	})
+}
+
+func Test_DependabotConfigTools(t *testing.T) {
+	generate := GenerateDependabotConfig(translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(generate.Tool.Name, generate.Tool))
+	assert.True(t, generate.Tool.Annotations.ReadOnlyHint)
+	update := UpdateDependabotConfig(translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(update.Tool.Name, update.Tool))
+	assert.False(t, update.Tool.Annotations.ReadOnlyHint)
+	assert.ElementsMatch(t, update.Tool.InputSchema.(*jsonschema.Schema).Required, []string{"owner", "repo"})
+
+	existing := "version: 2\nupdates:\n  - package-ecosystem: gomod\n    directory: /\n    schedule:\n      interval: weekly\n"
+	newHandlers := func(config string, written *map[string]any, pulls *[]map[string]any) map[string]http.HandlerFunc {
+		return map[string]http.HandlerFunc{
+			GetReposByOwnerByRepo: mockResponse(t, http.StatusOK, map[string]any{"default_branch": "trunk"}),
+			GetReposGitTreesByOwnerByRepoByTree: mockResponse(t, http.StatusOK, map[string]any{
+				"sha": "tree",
+				"tree": []map[string]any{
+					{"path": "go.mod", "type": "blob"},
+					{"path": ".github/workflows/ci.yml", "type": "blob"},
+				},
+			}),
+			"GET /repos/{owner}/{repo}/contents/{path:.*}": func(w http.ResponseWriter, r *http.Request) {
+				if config == "" || r.URL.Path != "/repos/octo/app/contents/.github/dependabot.yml" {
+					mockResponse(t, http.StatusNotFound, map[string]string{"message": "Not Found"})(w, r)
+					return
+				}
+				mockResponse(t, http.StatusOK, map[string]any{
+					"type":     "file",
+					"sha":      "config-sha",
+					"encoding": "base64",
+					"content":  base64.StdEncoding.EncodeToString([]byte(config)),
+				})(w, r)
+			},
+			GetReposGitRefByOwnerByRepoByRef: mockResponse(t, http.StatusOK, map[string]any{"ref": "refs/heads/trunk", "object": map[string]any{"sha": "base"}}),
+			PostReposGitRefsByOwnerByRepo:    mockResponse(t, http.StatusCreated, map[string]any{"ref": "refs/heads/dependabot-config"}),
+			"PUT /repos/{owner}/{repo}/contents/{path:.*}": func(w http.ResponseWriter, r *http.Request) {
+				require.NoError(t, json.NewDecoder(r.Body).Decode(written))
+				mockResponse(t, http.StatusCreated, map[string]any{"content": map[string]any{"path": ".github/dependabot.yml"}})(w, r)
+			},
+			PostReposPullsByOwnerByRepo: func(w http.ResponseWriter, r *http.Request) {
+				var body map[string]any
+				require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+				*pulls = append(*pulls, body)
+				mockResponse(t, http.StatusCreated, map[string]any{"number": 3, "html_url": "https://github.com/octo/app/pull/3"})(w, r)
+			},
+		}
+	}
+	run := func(t *testing.T, handlers map[string]http.HandlerFunc, tool func(translations.TranslationHelperFunc) inventory.ServerTool) DependabotConfigProposal {
+		t.Helper()
+		serverTool := tool(translations.NullTranslationHelper)
+		deps := BaseDeps{Client: github.NewClient(MockHTTPClientWithHandlers(handlers))}
+		request := createMCPRequest(map[string]any{"owner": "octo", "repo": "app"})
+		result, err := serverTool.Handler(deps)(ContextWithDeps(context.Background(), deps), &request)
+		require.NoError(t, err)
+		require.False(t, result.IsError, getTextResult(t, result).Text)
+		var proposal DependabotConfigProposal
+		require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &proposal))
+		return proposal
+	}
+
+	t.Run("generate", func(t *testing.T) {
+		var written map[string]any
+		var pulls []map[string]any
+		proposal := run(t, newHandlers(existing, &written, &pulls), GenerateDependabotConfig)
+
+		assert.True(t, proposal.Exists)
+		assert.True(t, proposal.Changed)
+		assert.Equal(t, []DependabotEcosystem{{Ecosystem: "github-actions", Directories: []string{"/"}}}, proposal.Added)
+		assert.Contains(t, proposal.Config, "package-ecosystem: github-actions")
+		assert.Nil(t, written)
+		assert.Empty(t, pulls)
+	})
+
+	t.Run("update opens a pull request", func(t *testing.T) {
+		var written map[string]any
+		var pulls []map[string]any
+		proposal := run(t, newHandlers(existing, &written, &pulls), UpdateDependabotConfig)
+
+		assert.Equal(t, "https://github.com/octo/app/pull/3", proposal.PullRequest)
+		assert.Equal(t, "dependabot-config", proposal.Branch)
+		assert.Equal(t, "config-sha", written["sha"])
+		assert.Equal(t, "dependabot-config", written["branch"])
+		content, err := base64.StdEncoding.DecodeString(written["content"].(string))
+		require.NoError(t, err)
+		assert.Equal(t, proposal.Config, string(content))
+		require.Len(t, pulls, 1)
+		assert.Equal(t, "trunk", pulls[0]["base"])
+		assert.Equal(t, "Update Dependabot configuration", pulls[0]["title"])
+	})
+
+	t.Run("update without changes", func(t *testing.T) {
+		var written map[string]any
+		var pulls []map[string]any
+		complete := existing + "  - package-ecosystem: github-actions\n    directory: /\n    schedule:\n      interval: weekly\n"
+		proposal := run(t, newHandlers(complete, &written, &pulls), UpdateDependabotConfig)
+
+		assert.False(t, proposal.Changed)
+		assert.Equal(t, complete, proposal.Config)
+		assert.Contains(t, proposal.Message, "already covers")
+		assert.Nil(t, written)
+		assert.Empty(t, pulls)
+	})
+}
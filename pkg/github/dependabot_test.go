@@ -179,6 +179,27 @@ func Test_ListDependabotAlerts(t *testing.T) {
 			expectError:    false,
 			expectedAlerts: []*github.DependabotAlert{&highSeverityAlert},
 		},
+		{
+			name: "successful ecosystem and package filtered listing",
+			mockedClient: MockHTTPClientWithHandlers(map[string]http.HandlerFunc{
+				GetReposDependabotAlertsByOwnerByRepo: expectQueryParams(t, map[string]string{
+					"ecosystem": "npm",
+					"package":   "lodash",
+					"scope":     "runtime",
+				}).andThen(
+					mockResponse(t, http.StatusOK, []*github.DependabotAlert{&criticalAlert}),
+				),
+			}),
+			requestArgs: map[string]any{
+				"owner":     "owner",
+				"repo":      "repo",
+				"ecosystem": "npm",
+				"package":   "lodash",
+				"scope":     "runtime",
+			},
+			expectError:    false,
+			expectedAlerts: []*github.DependabotAlert{&criticalAlert},
+		},
 		{
 			name: "successful all alerts listing",
 			mockedClient: MockHTTPClientWithHandlers(map[string]http.HandlerFunc{
@@ -250,3 +271,67 @@ func Test_ListDependabotAlerts(t *testing.T) {
 		})
 	}
 }
+
+func Test_UpdateDependabotAlert(t *testing.T) {
+	toolDef := UpdateDependabotAlert(translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(toolDef.Tool.Name, toolDef.Tool))
+	assert.False(t, toolDef.Tool.Annotations.ReadOnlyHint)
+
+	tests := []struct {
+		name           string
+		requestArgs    map[string]any
+		expectedBody   map[string]any
+		expectedErrMsg string
+	}{
+		{
+			name:         "dismiss alert",
+			requestArgs:  map[string]any{"owner": "owner", "repo": "repo", "alertNumber": float64(7), "state": "dismissed", "dismissed_reason": "not_used", "dismissed_comment": "Dev only"},
+			expectedBody: map[string]any{"state": "dismissed", "dismissed_reason": "not_used", "dismissed_comment": "Dev only"},
+		},
+		{
+			name:         "reopen alert",
+			requestArgs:  map[string]any{"owner": "owner", "repo": "repo", "alertNumber": float64(7), "state": "open"},
+			expectedBody: map[string]any{"state": "open"},
+		},
+		{
+			name:           "dismissed reason required",
+			requestArgs:    map[string]any{"owner": "owner", "repo": "repo", "alertNumber": float64(7), "state": "dismissed"},
+			expectedErrMsg: "dismissed_reason is required when state is dismissed",
+		},
+		{
+			name:           "invalid state",
+			requestArgs:    map[string]any{"owner": "owner", "repo": "repo", "alertNumber": float64(7), "state": "fixed"},
+			expectedErrMsg: `invalid state "fixed"`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockedClient := MockHTTPClientWithHandlers(map[string]http.HandlerFunc{
+				PatchReposDependabotAlertsByOwnerByRepoByAlertNumber: func(w http.ResponseWriter, r *http.Request) {
+					var body map[string]any
+					require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+					assert.Equal(t, tc.expectedBody, body)
+					w.WriteHeader(http.StatusOK)
+					_ = json.NewEncoder(w).Encode(&github.DependabotAlert{
+						Number: github.Ptr(7),
+						State:  github.Ptr(tc.expectedBody["state"].(string)),
+					})
+				},
+			})
+			deps := BaseDeps{Client: github.NewClient(mockedClient)}
+			request := createMCPRequest(tc.requestArgs)
+			result, err := toolDef.Handler(deps)(ContextWithDeps(context.Background(), deps), &request)
+			require.NoError(t, err)
+
+			if tc.expectedErrMsg != "" {
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+			require.False(t, result.IsError)
+			var alert github.DependabotAlert
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &alert))
+			assert.Equal(t, tc.expectedBody["state"], alert.GetState())
+		})
+	}
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/github/github-mcp-server/pkg/lockdown"
 	"github.com/github/github-mcp-server/pkg/observability"
 	"github.com/github/github-mcp-server/pkg/observability/metrics"
+	"github.com/github/github-mcp-server/pkg/observability/tracing"
 	"github.com/github/github-mcp-server/pkg/raw"
 	"github.com/github/github-mcp-server/pkg/scopes"
 	"github.com/github/github-mcp-server/pkg/translations"
@@ -232,7 +233,17 @@ func NewTool[In, Out any](
 ) inventory.ServerTool {
 	st := inventory.NewServerToolWithContextHandler(tool, toolset, func(ctx context.Context, req *mcp.CallToolRequest, args In) (*mcp.CallToolResult, Out, error) {
 		deps := MustDepsFromContext(ctx)
-		return handler(ctx, deps, req, args)
+		var repairs []string
+		if m, ok := any(args).(map[string]any); ok {
+			if m == nil {
+				m = map[string]any{}
+				args = any(m).(In)
+			}
+			repairs = repairArguments(&tool, m)
+			applySessionContext(req, &tool, m)
+		}
+		result, out, err := handler(ctx, deps, req, args)
+		return withArgumentRepairs(result, repairs), out, err
 	})
 	st.RequiredScopes = scopes.ToStringSlice(requiredScopes...)
 	st.AcceptedScopes = scopes.ExpandScopes(requiredScopes...)
@@ -271,6 +282,13 @@ type RequestDeps struct {
 	T                 translations.TranslationHelperFunc
 	ContentWindowSize int
 
+	// ResponseCache, when set, caches REST responses across requests, which are revalidated with
+	// conditional requests before they are reused.
+	ResponseCache transport.ResponseCache
+
+	// Tracer, when set, records a span for each GitHub API call.
+	Tracer tracing.Tracer
+
 	// Feature flag checker for runtime checks
 	featureChecker inventory.FeatureFlagChecker
 
@@ -319,8 +337,15 @@ func (d *RequestDeps) GetClient(ctx context.Context) (*gogithub.Client, error) {
 		return nil, fmt.Errorf("failed to get upload URL: %w", err)
 	}
 
-	// Construct REST client
-	restClient := gogithub.NewClient(nil).WithAuthToken(token)
+	// Construct REST client, waiting out rate limits rather than failing
+	var restTransport http.RoundTripper = &transport.RateLimitTransport{Transport: http.DefaultTransport}
+	if d.ResponseCache != nil {
+		restTransport = &transport.ConditionalTransport{Transport: restTransport, Cache: d.ResponseCache}
+	}
+	if d.Tracer != nil {
+		restTransport = &transport.TracingTransport{Transport: restTransport, Tracer: d.Tracer}
+	}
+	restClient := gogithub.NewClient(&http.Client{Transport: &transport.DryRunTransport{Transport: restTransport}}).WithAuthToken(token)
 	restClient.UserAgent = fmt.Sprintf("github-mcp-server/%s", d.version)
 	restClient.BaseURL = baseRestURL
 	restClient.UploadURL = uploadURL
@@ -340,12 +365,21 @@ func (d *RequestDeps) GetGQLClient(ctx context.Context) (*githubv4.Client, error
 	// We use NewEnterpriseClient unconditionally since we already parsed the API host
 	// Wrap transport with GraphQLFeaturesTransport to inject feature flags from context,
 	// matching the transport chain used by the remote server.
+	var gqlTransport http.RoundTripper = &transport.GraphQLFeaturesTransport{
+		Transport: &transport.GraphQLCostTransport{
+			Transport: &transport.RateLimitTransport{Transport: http.DefaultTransport},
+			Logger:    d.Logger(ctx),
+		},
+	}
+	if d.Tracer != nil {
+		gqlTransport = &transport.TracingTransport{Transport: gqlTransport, Tracer: d.Tracer}
+	}
 	gqlHTTPClient := &http.Client{
-		Transport: &transport.BearerAuthTransport{
-			Transport: &transport.GraphQLFeaturesTransport{
-				Transport: http.DefaultTransport,
+		Transport: &transport.DryRunTransport{
+			Transport: &transport.BearerAuthTransport{
+				Transport: gqlTransport,
+				Token:     token,
 			},
-			Token: token,
 		},
 	}
 
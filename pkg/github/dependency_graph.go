@@ -0,0 +1,167 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/inventory"
+	"github.com/github/github-mcp-server/pkg/scopes"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/github/github-mcp-server/pkg/utils"
+	"github.com/google/go-github/v82/github"
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// MinimalDependency is a package of the dependency graph of a repository.
+type MinimalDependency struct {
+	Name      string `json:"name"`
+	Version   string `json:"version,omitempty"`
+	Ecosystem string `json:"ecosystem,omitempty"`
+	PURL      string `json:"purl,omitempty"`
+	License   string `json:"license,omitempty"`
+}
+
+// MinimalSBOM summarizes the SPDX software bill of materials of a repository.
+type MinimalSBOM struct {
+	Name         string              `json:"name"`
+	SPDXVersion  string              `json:"spdx_version"`
+	Created      string              `json:"created,omitempty"`
+	PackageCount int                 `json:"package_count"`
+	Packages     []MinimalDependency `json:"packages"`
+}
+
+// convertToMinimalSBOM lists the dependencies of an SBOM, leaving out the repository itself,
+// filtered by the ecosystem of their package URL.
+func convertToMinimalSBOM(sbom *github.SBOMInfo, ecosystem string) MinimalSBOM {
+	minimal := MinimalSBOM{
+		Name:        sbom.GetName(),
+		SPDXVersion: sbom.GetSPDXVersion(),
+		Packages:    []MinimalDependency{},
+	}
+	if sbom.CreationInfo != nil {
+		minimal.Created = sbom.CreationInfo.GetCreated().UTC().Format(time.RFC3339)
+	}
+	for _, pkg := range sbom.Packages {
+		if pkg == nil || slices.Contains(sbom.DocumentDescribes, pkg.GetSPDXID()) {
+			continue
+		}
+		dep := MinimalDependency{
+			Name:    pkg.GetName(),
+			Version: pkg.GetVersionInfo(),
+			License: pkg.GetLicenseConcluded(),
+		}
+		if dep.License == "" || dep.License == "NOASSERTION" {
+			dep.License = pkg.GetLicenseDeclared()
+		}
+		if dep.License == "NOASSERTION" {
+			dep.License = ""
+		}
+		for _, ref := range pkg.ExternalRefs {
+			if ref != nil && ref.ReferenceType == "purl" {
+				dep.PURL = ref.ReferenceLocator
+				dep.Ecosystem, _, _ = strings.Cut(strings.TrimPrefix(dep.PURL, "pkg:"), "/")
+				break
+			}
+		}
+		if ecosystem != "" && !strings.EqualFold(dep.Ecosystem, ecosystem) {
+			continue
+		}
+		minimal.Packages = append(minimal.Packages, dep)
+	}
+	minimal.PackageCount = len(minimal.Packages)
+	return minimal
+}
+
+func GetRepositorySBOM(t translations.TranslationHelperFunc) inventory.ServerTool {
+	return NewTool(
+		ToolsetMetadataDependabot,
+		mcp.Tool{
+			Name: "get_repository_sbom",
+			Description: t("TOOL_GET_REPOSITORY_SBOM_DESCRIPTION", `Get the dependency graph of a GitHub repository, exported as an SPDX software bill of materials (SBOM).
+By default returns the list of dependencies with their version, ecosystem, package URL and license. Use format 'spdx' to get the complete SPDX document, including the relationships between packages.`),
+			Annotations: &mcp.ToolAnnotations{
+				Title:        t("TOOL_GET_REPOSITORY_SBOM_USER_TITLE", "Get repository SBOM"),
+				ReadOnlyHint: true,
+			},
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"owner": {
+						Type:        "string",
+						Description: "The owner of the repository.",
+					},
+					"repo": {
+						Type:        "string",
+						Description: "The name of the repository.",
+					},
+					"format": {
+						Type:        "string",
+						Description: "'packages' for the list of dependencies, or 'spdx' for the complete SPDX document. Defaults to packages",
+						Enum:        []any{"packages", "spdx"},
+						Default:     json.RawMessage(`"packages"`),
+					},
+					"ecosystem": {
+						Type:        "string",
+						Description: "Only list the dependencies of this package URL type, such as npm, golang, maven, pypi, gem, cargo or githubactions. Only used with the packages format",
+					},
+				},
+				Required: []string{"owner", "repo"},
+			},
+		},
+		[]scopes.Scope{scopes.Repo},
+		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+			owner, err := RequiredParam[string](args, "owner")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			repo, err := RequiredParam[string](args, "repo")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			format, err := OptionalParam[string](args, "format")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			ecosystem, err := OptionalParam[string](args, "ecosystem")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			switch format {
+			case "":
+				format = "packages"
+			case "packages", "spdx":
+			default:
+				return utils.NewToolResultError(fmt.Sprintf("invalid format %q: must be packages or spdx", format)), nil, nil
+			}
+
+			client, err := deps.GetClient(ctx)
+			if err != nil {
+				return utils.NewToolResultErrorFromErr("failed to get GitHub client", err), nil, nil
+			}
+
+			sbom, resp, err := client.DependencyGraph.GetSBOM(ctx, owner, repo)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to get the SBOM of repository '%s/%s'", owner, repo),
+					resp,
+					err,
+				), nil, nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if sbom.SBOM == nil {
+				return utils.NewToolResultError("the repository has no dependency graph"), nil, nil
+			}
+			if format == "spdx" {
+				return MarshalledTextResult(sbom.SBOM), nil, nil
+			}
+			return MarshalledTextResult(convertToMinimalSBOM(sbom.SBOM, ecosystem)), nil, nil
+		},
+	)
+}
@@ -0,0 +1,80 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v82/github"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_GetRepositorySBOM(t *testing.T) {
+	serverTool := GetRepositorySBOM(translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(serverTool.Tool.Name, serverTool.Tool))
+	assert.True(t, serverTool.Tool.Annotations.ReadOnlyHint)
+
+	sbom := `{"sbom": {
+		"SPDXID": "SPDXRef-DOCUMENT",
+		"spdxVersion": "SPDX-2.3",
+		"creationInfo": {"created": "2026-10-01T12:00:00Z", "creators": ["Tool: GitHub.com-Dependency-Graph"]},
+		"name": "com.github.octo-org/app",
+		"documentDescribes": ["SPDXRef-com.github.octo-org-app"],
+		"packages": [
+			{"SPDXID": "SPDXRef-com.github.octo-org-app", "name": "com.github.octo-org/app", "versionInfo": "main"},
+			{"SPDXID": "SPDXRef-npm-lodash-4.17.21", "name": "npm:lodash", "versionInfo": "4.17.21", "licenseConcluded": "MIT",
+				"externalRefs": [{"referenceCategory": "PACKAGE-MANAGER", "referenceType": "purl", "referenceLocator": "pkg:npm/lodash@4.17.21"}]},
+			{"SPDXID": "SPDXRef-go-cobra", "name": "go:github.com/spf13/cobra", "versionInfo": "1.8.1", "licenseConcluded": "NOASSERTION", "licenseDeclared": "Apache-2.0",
+				"externalRefs": [{"referenceCategory": "PACKAGE-MANAGER", "referenceType": "purl", "referenceLocator": "pkg:golang/github.com/spf13/cobra@1.8.1"}]}
+		],
+		"relationships": [{"spdxElementId": "SPDXRef-com.github.octo-org-app", "relatedSpdxElement": "SPDXRef-npm-lodash-4.17.21", "relationshipType": "DEPENDS_ON"}]
+	}}`
+	mockedClient := MockHTTPClientWithHandlers(map[string]http.HandlerFunc{
+		GetReposDependencyGraphSBOMByOwnerByRepo: func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write([]byte(sbom))
+		},
+	})
+	deps := BaseDeps{Client: github.NewClient(mockedClient)}
+	handler := serverTool.Handler(deps)
+	call := func(args map[string]any) string {
+		request := createMCPRequest(args)
+		result, err := handler(ContextWithDeps(context.Background(), deps), &request)
+		require.NoError(t, err)
+		require.False(t, result.IsError)
+		return getTextResult(t, result).Text
+	}
+
+	t.Run("packages", func(t *testing.T) {
+		var minimal MinimalSBOM
+		require.NoError(t, json.Unmarshal([]byte(call(map[string]any{"owner": "octo-org", "repo": "app"})), &minimal))
+		assert.Equal(t, MinimalSBOM{
+			Name:         "com.github.octo-org/app",
+			SPDXVersion:  "SPDX-2.3",
+			Created:      "2026-10-01T12:00:00Z",
+			PackageCount: 2,
+			Packages: []MinimalDependency{
+				{Name: "npm:lodash", Version: "4.17.21", Ecosystem: "npm", PURL: "pkg:npm/lodash@4.17.21", License: "MIT"},
+				{Name: "go:github.com/spf13/cobra", Version: "1.8.1", Ecosystem: "golang", PURL: "pkg:golang/github.com/spf13/cobra@1.8.1", License: "Apache-2.0"},
+			},
+		}, minimal)
+	})
+
+	t.Run("packages of one ecosystem", func(t *testing.T) {
+		var minimal MinimalSBOM
+		require.NoError(t, json.Unmarshal([]byte(call(map[string]any{"owner": "octo-org", "repo": "app", "ecosystem": "golang"})), &minimal))
+		require.Len(t, minimal.Packages, 1)
+		assert.Equal(t, "go:github.com/spf13/cobra", minimal.Packages[0].Name)
+	})
+
+	t.Run("spdx document", func(t *testing.T) {
+		var doc github.SBOMInfo
+		require.NoError(t, json.Unmarshal([]byte(call(map[string]any{"owner": "octo-org", "repo": "app", "format": "spdx"})), &doc))
+		assert.Len(t, doc.Packages, 3)
+		assert.Len(t, doc.Relationships, 1)
+	})
+}
@@ -1,6 +1,10 @@
 // deprecated_tool_aliases.go
 package github
 
+// DeprecatedToolAliasRemoval states when deprecated aliases stop working. It is reported in the
+// deprecation notice of calls made through an alias.
+const DeprecatedToolAliasRemoval = "next major release"
+
 // DeprecatedToolAliases maps old tool names to their new canonical names.
 // When tools are renamed, add an entry here to maintain backward compatibility.
 // Users referencing the old name will receive the new tool with a deprecation warning.
@@ -21,7 +25,7 @@ var DeprecatedToolAliases = map[string]string{
 	"get_workflow_job":               "actions_get",
 	"get_workflow_run_usage":         "actions_get",
 	"get_workflow_run_logs":          "actions_get",
-	"get_workflow_job_logs":          "actions_get",
+	"get_workflow_job_logs":          "get_job_logs",
 	"download_workflow_run_artifact": "actions_get",
 	"run_workflow":                   "actions_run_trigger",
 	"rerun_workflow_run":             "actions_run_trigger",
@@ -40,3 +44,53 @@ var DeprecatedToolAliases = map[string]string{
 	"update_project_item": "projects_write",
 	"delete_project_item": "projects_write",
 }
+
+// DeprecatedToolAliasCall is how a call made through a deprecated alias is run on its
+// replacement, when the replacement takes different arguments.
+type DeprecatedToolAliasCall struct {
+	// Method is the method of the consolidated tool the alias corresponds to.
+	Method string
+	// Arguments maps the argument names of the alias to those of the replacement. A name of the
+	// form "object.name" moves the argument into the object argument of the replacement.
+	Arguments map[string]string
+}
+
+// DeprecatedToolAliasCalls holds the method and the argument renames of the aliases in
+// DeprecatedToolAliases replaced by a consolidated tool. Aliases of renamed tools taking the same
+// arguments need no entry.
+var DeprecatedToolAliasCalls = map[string]DeprecatedToolAliasCall{
+	"list_workflows": {Method: "list_workflows"},
+	"list_workflow_runs": {Method: "list_workflow_runs", Arguments: map[string]string{
+		"workflow_id": "resource_id",
+		"actor":       "workflow_runs_filter.actor",
+		"branch":      "workflow_runs_filter.branch",
+		"event":       "workflow_runs_filter.event",
+		"status":      "workflow_runs_filter.status",
+	}},
+	"list_workflow_jobs": {Method: "list_workflow_jobs", Arguments: map[string]string{
+		"run_id": "resource_id",
+		"filter": "workflow_jobs_filter.filter",
+	}},
+	"list_workflow_run_artifacts":    {Method: "list_workflow_run_artifacts", Arguments: map[string]string{"run_id": "resource_id"}},
+	"get_workflow":                   {Method: "get_workflow", Arguments: map[string]string{"workflow_id": "resource_id"}},
+	"get_workflow_run":               {Method: "get_workflow_run", Arguments: map[string]string{"run_id": "resource_id"}},
+	"get_workflow_job":               {Method: "get_workflow_job", Arguments: map[string]string{"job_id": "resource_id"}},
+	"get_workflow_run_usage":         {Method: "get_workflow_run_usage", Arguments: map[string]string{"run_id": "resource_id"}},
+	"get_workflow_run_logs":          {Method: "get_workflow_run_logs_url", Arguments: map[string]string{"run_id": "resource_id"}},
+	"download_workflow_run_artifact": {Method: "download_workflow_run_artifact", Arguments: map[string]string{"artifact_id": "resource_id"}},
+	"run_workflow":                   {Method: "run_workflow"},
+	"rerun_workflow_run":             {Method: "rerun_workflow_run"},
+	"rerun_failed_jobs":              {Method: "rerun_failed_jobs"},
+	"cancel_workflow_run":            {Method: "cancel_workflow_run"},
+	"delete_workflow_run_logs":       {Method: "delete_workflow_run_logs"},
+
+	"list_projects":       {Method: "list_projects"},
+	"list_project_fields": {Method: "list_project_fields"},
+	"list_project_items":  {Method: "list_project_items"},
+	"get_project":         {Method: "get_project"},
+	"get_project_field":   {Method: "get_project_field"},
+	"get_project_item":    {Method: "get_project_item"},
+	"add_project_item":    {Method: "add_project_item"},
+	"update_project_item": {Method: "update_project_item"},
+	"delete_project_item": {Method: "delete_project_item"},
+}
@@ -0,0 +1,161 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/github/github-mcp-server/pkg/inventory"
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// DeprecationMetaKey is the _meta key under which the deprecation notice is attached to the
+// results of tools called through a deprecated alias.
+const DeprecationMetaKey = "github.com/deprecation"
+
+// DeprecatedAliasCallsMetric counts tool calls made through a deprecated alias, tagged with the
+// alias and its replacement, so unused aliases can be pruned from DeprecatedToolAliases.
+const DeprecatedAliasCallsMetric = "mcp.tools.deprecated_alias_calls"
+
+// DeprecationNotice tells the caller that a tool was called through a deprecated alias.
+type DeprecationNotice struct {
+	Alias       string `json:"alias"`
+	Replacement string `json:"replacement"`
+	Removal     string `json:"removal"`
+	Message     string `json:"message"`
+}
+
+// DeprecatedAliasMiddleware runs calls made to a deprecated tool alias on the tool replacing it,
+// with the method and arguments calls gives for the alias, and attaches a DeprecationNotice to the
+// result. Aliases that are still registered as tools, such as tools whose consolidated
+// replacement is behind a feature flag that is off, are called as usual. It must run outside the
+// middleware looking tools up by name, such as the write checks, and inside
+// InjectDepsMiddleware, to count the calls.
+func DeprecatedAliasMiddleware(aliases map[string]string, calls map[string]DeprecatedToolAliasCall, inv *inventory.Inventory) mcp.Middleware {
+	return func(next mcp.MethodHandler) mcp.MethodHandler {
+		return func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+			callReq, ok := req.(*mcp.CallToolRequest)
+			if method != "tools/call" || !ok || callReq.Params == nil {
+				return next(ctx, method, req)
+			}
+			alias := callReq.Params.Name
+			replacement, isAlias := aliases[alias]
+			if !isAlias || isToolAvailable(ctx, inv, alias) {
+				return next(ctx, method, req)
+			}
+
+			params := *callReq.Params
+			params.Name = replacement
+			if call, ok := calls[alias]; ok {
+				var tool *mcp.Tool
+				if serverTool, _, err := inv.FindToolByName(replacement); err == nil {
+					tool = &serverTool.Tool
+				}
+				params.Arguments = aliasCallArguments(call, tool, params.Arguments)
+			}
+			resolved := *callReq
+			resolved.Params = &params
+
+			if deps, ok := DepsFromContext(ctx); ok {
+				deps.Metrics(ctx).Increment(DeprecatedAliasCallsMetric, map[string]string{
+					"alias":       alias,
+					"replacement": replacement,
+				})
+			}
+
+			result, err := next(ctx, method, &resolved)
+			if toolResult, ok := result.(*mcp.CallToolResult); ok && toolResult != nil {
+				if toolResult.Meta == nil {
+					toolResult.Meta = mcp.Meta{}
+				}
+				toolResult.Meta[DeprecationMetaKey] = DeprecationNotice{
+					Alias:       alias,
+					Replacement: replacement,
+					Removal:     DeprecatedToolAliasRemoval,
+					Message:     fmt.Sprintf("tool %q is deprecated and will be removed in the %s, use %q instead", alias, DeprecatedToolAliasRemoval, replacement),
+				}
+			}
+			return result, err
+		}
+	}
+}
+
+func isToolAvailable(ctx context.Context, inv *inventory.Inventory, name string) bool {
+	for _, tool := range inv.AvailableTools(ctx) {
+		if tool.Tool.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// aliasCallArguments returns the arguments of a call made through an alias, as the replacement
+// tool takes them. Arguments that cannot be decoded are returned as is, for the tool to report.
+func aliasCallArguments(call DeprecatedToolAliasCall, tool *mcp.Tool, rawArgs json.RawMessage) json.RawMessage {
+	args, ok := decodeArguments(rawArgs)
+	if !ok {
+		return rawArgs
+	}
+	applyDeprecatedAliasCall(call, tool, args)
+	mapped, err := json.Marshal(args)
+	if err != nil {
+		return rawArgs
+	}
+	return mapped
+}
+
+// applyDeprecatedAliasCall sets the method of call in args, unless it is set already, and renames
+// the arguments of the alias to those of the replacement tool. Numbers passed for arguments the
+// replacement declares as strings, such as the run IDs now passed as resource_id, are converted.
+func applyDeprecatedAliasCall(call DeprecatedToolAliasCall, tool *mcp.Tool, args map[string]any) {
+	if call.Method != "" {
+		if _, ok := args["method"]; !ok {
+			args["method"] = call.Method
+		}
+	}
+	var schema *jsonschema.Schema
+	if tool != nil {
+		schema, _ = tool.InputSchema.(*jsonschema.Schema)
+	}
+	for _, from := range slices.Sorted(maps.Keys(call.Arguments)) {
+		value, ok := args[from]
+		if !ok {
+			continue
+		}
+		target, targetSchema := args, schema
+		name := call.Arguments[from]
+		if objectName, nested, found := strings.Cut(name, "."); found {
+			object, ok := args[objectName].(map[string]any)
+			if !ok {
+				if _, present := args[objectName]; present {
+					continue
+				}
+				object = map[string]any{}
+				args[objectName] = object
+			}
+			target, name = object, nested
+			targetSchema = nil
+			if schema != nil {
+				targetSchema = schema.Properties[objectName]
+			}
+		}
+		if _, present := target[name]; present {
+			continue
+		}
+		if targetSchema != nil && targetSchema.Properties[name] != nil && targetSchema.Properties[name].Type == "string" {
+			switch v := value.(type) {
+			case json.Number:
+				value = v.String()
+			case float64:
+				value = strconv.FormatFloat(v, 'f', -1, 64)
+			}
+		}
+		target[name] = value
+		delete(args, from)
+	}
+}
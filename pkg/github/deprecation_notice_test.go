@@ -0,0 +1,229 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/github/github-mcp-server/pkg/inventory"
+	"github.com/github/github-mcp-server/pkg/observability"
+	"github.com/github/github-mcp-server/pkg/observability/metrics"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v82/github"
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// countingMetrics counts the increments of each metric and tag set.
+type countingMetrics struct {
+	increments map[string][]map[string]string
+}
+
+func (m *countingMetrics) Increment(key string, tags map[string]string) {
+	m.increments[key] = append(m.increments[key], tags)
+}
+
+func (m *countingMetrics) Counter(string, map[string]string, int64)                {}
+func (m *countingMetrics) Distribution(string, map[string]string, float64)         {}
+func (m *countingMetrics) DistributionMs(string, map[string]string, time.Duration) {}
+func (m *countingMetrics) WithTags(map[string]string) metrics.Metrics              { return m }
+
+func Test_DeprecatedAliasMiddleware(t *testing.T) {
+	toolset := inventory.ToolsetMetadata{ID: "actions", Default: true}
+	inv, err := inventory.NewBuilder().
+		SetTools([]inventory.ServerTool{
+			{Tool: mcp.Tool{Name: "actions_get", Annotations: &mcp.ToolAnnotations{ReadOnlyHint: true}}, Toolset: toolset},
+			{Tool: mcp.Tool{Name: "list_workflows", Annotations: &mcp.ToolAnnotations{ReadOnlyHint: true}}, Toolset: toolset},
+		}).
+		WithToolsets([]string{"all"}).
+		Build()
+	require.NoError(t, err)
+
+	aliases := map[string]string{
+		"get_workflow":   "actions_get",
+		"list_workflows": "actions_list",
+	}
+	counter := &countingMetrics{increments: map[string][]map[string]string{}}
+	obs, err := observability.NewExporters(slog.New(slog.DiscardHandler), counter)
+	require.NoError(t, err)
+	ctx := ContextWithDeps(context.Background(), stubDeps{obsv: obs})
+
+	var calledName string
+	handler := DeprecatedAliasMiddleware(aliases, nil, inv)(func(_ context.Context, _ string, req mcp.Request) (mcp.Result, error) {
+		calledName = req.(*mcp.CallToolRequest).Params.Name
+		return &mcp.CallToolResult{}, nil
+	})
+	call := func(name string) *mcp.CallToolResult {
+		result, err := handler(ctx, "tools/call", &mcp.CallToolRequest{Params: &mcp.CallToolParamsRaw{Name: name}})
+		require.NoError(t, err)
+		return result.(*mcp.CallToolResult)
+	}
+
+	t.Run("alias calls run the replacement with a deprecation notice", func(t *testing.T) {
+		result := call("get_workflow")
+		assert.Equal(t, "actions_get", calledName)
+		require.Contains(t, result.Meta, DeprecationMetaKey)
+		notice := result.Meta[DeprecationMetaKey].(DeprecationNotice)
+		assert.Equal(t, "get_workflow", notice.Alias)
+		assert.Equal(t, "actions_get", notice.Replacement)
+		assert.Equal(t, DeprecatedToolAliasRemoval, notice.Removal)
+		assert.Contains(t, notice.Message, `use "actions_get" instead`)
+		assert.Equal(t, []map[string]string{{"alias": "get_workflow", "replacement": "actions_get"}}, counter.increments[DeprecatedAliasCallsMetric])
+	})
+
+	t.Run("canonical and still registered names are called as usual", func(t *testing.T) {
+		for _, name := range []string{"actions_get", "list_workflows"} {
+			result := call(name)
+			assert.Equal(t, name, calledName)
+			assert.NotContains(t, result.Meta, DeprecationMetaKey)
+		}
+		assert.Len(t, counter.increments[DeprecatedAliasCallsMetric], 1)
+	})
+}
+
+func Test_DeprecatedToolAliasCalls(t *testing.T) {
+	tools := map[string]*jsonschema.Schema{}
+	for _, tool := range AllTools(translations.NullTranslationHelper) {
+		if schema, ok := tool.Tool.InputSchema.(*jsonschema.Schema); ok {
+			tools[tool.Tool.Name] = schema
+		}
+	}
+
+	for alias, replacement := range DeprecatedToolAliases {
+		schema, ok := tools[replacement]
+		require.True(t, ok, "replacement %s of %s", replacement, alias)
+		call, hasCall := DeprecatedToolAliasCalls[alias]
+		if !slices.Contains(schema.Required, "method") {
+			assert.Empty(t, call.Method, alias)
+			continue
+		}
+		require.True(t, hasCall, "%s needs the method of %s", alias, replacement)
+		assert.Contains(t, schema.Properties["method"].Enum, call.Method, alias)
+		for from, to := range call.Arguments {
+			object, name, nested := strings.Cut(to, ".")
+			if !nested {
+				assert.Contains(t, schema.Properties, to, "%s argument %s", alias, from)
+				continue
+			}
+			require.Contains(t, schema.Properties, object, "%s argument %s", alias, from)
+			assert.Contains(t, schema.Properties[object].Properties, name, "%s argument %s", alias, from)
+		}
+	}
+	for alias := range DeprecatedToolAliasCalls {
+		assert.Contains(t, DeprecatedToolAliases, alias)
+	}
+}
+
+func Test_DeprecatedAliasMiddlewareRunsRealTools(t *testing.T) {
+	inv, err := NewInventory(translations.NullTranslationHelper).WithToolsets([]string{"actions"}).Build()
+	require.NoError(t, err)
+
+	tests := []struct {
+		name      string
+		alias     string
+		arguments string
+		handlers  map[string]http.HandlerFunc
+	}{
+		{
+			name:      "list runs of a workflow with filters",
+			alias:     "list_workflow_runs",
+			arguments: `{"owner":"octo","repo":"ink","workflow_id":42,"status":"completed","branch":"main"}`,
+			handlers: map[string]http.HandlerFunc{
+				GetReposActionsWorkflowsRunsByOwnerByRepoByWorkflowID: expectQueryParams(t, map[string]string{
+					"status":   "completed",
+					"branch":   "main",
+					"per_page": "30",
+					"page":     "1",
+				}).andThen(mockResponse(t, http.StatusOK, &github.WorkflowRuns{TotalCount: github.Ptr(0)})),
+			},
+		},
+		{
+			name:      "get a workflow run",
+			alias:     "get_workflow_run",
+			arguments: `{"owner":"octo","repo":"ink","run_id":7}`,
+			handlers: map[string]http.HandlerFunc{
+				GetReposActionsRunsByOwnerByRepoByRunID: mockResponse(t, http.StatusOK, &github.WorkflowRun{ID: github.Ptr(int64(7))}),
+			},
+		},
+		{
+			name:      "cancel a workflow run",
+			alias:     "cancel_workflow_run",
+			arguments: `{"owner":"octo","repo":"ink","run_id":7}`,
+			handlers: map[string]http.HandlerFunc{
+				PostReposActionsRunsCancelByOwnerByRepoByRunID: mockResponse(t, http.StatusAccepted, `{}`),
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			obs, err := observability.NewExporters(slog.New(slog.DiscardHandler), &countingMetrics{increments: map[string][]map[string]string{}})
+			require.NoError(t, err)
+			deps := BaseDeps{Client: github.NewClient(MockHTTPClientWithHandlers(tc.handlers)), Obsv: obs}
+			handler := DeprecatedAliasMiddleware(DeprecatedToolAliases, DeprecatedToolAliasCalls, inv)(
+				func(ctx context.Context, _ string, req mcp.Request) (mcp.Result, error) {
+					callReq := req.(*mcp.CallToolRequest)
+					tool, _, err := inv.FindToolByName(callReq.Params.Name)
+					require.NoError(t, err)
+					return tool.Handler(deps)(ctx, callReq)
+				})
+
+			result, err := handler(ContextWithDeps(context.Background(), deps), "tools/call", &mcp.CallToolRequest{
+				Params: &mcp.CallToolParamsRaw{Name: tc.alias, Arguments: json.RawMessage(tc.arguments)},
+			})
+			require.NoError(t, err)
+			toolResult := result.(*mcp.CallToolResult)
+			assert.False(t, toolResult.IsError, getTextResult(t, toolResult).Text)
+			assert.Contains(t, toolResult.Meta, DeprecationMetaKey)
+		})
+	}
+}
+
+func Test_ApplyDeprecatedAliasCall(t *testing.T) {
+	tool := ActionsList(translations.NullTranslationHelper).Tool
+	args := map[string]any{
+		"owner":                "octo",
+		"run_id":               float64(7),
+		"filter":               "all",
+		"workflow_jobs_filter": map[string]any{},
+	}
+	applyDeprecatedAliasCall(DeprecatedToolAliasCalls["list_workflow_jobs"], &tool, args)
+	assert.Equal(t, map[string]any{
+		"owner":                "octo",
+		"method":               "list_workflow_jobs",
+		"resource_id":          "7",
+		"workflow_jobs_filter": map[string]any{"filter": "all"},
+	}, args)
+
+	// A method given by the caller is kept
+	args = map[string]any{"method": "list_workflow_runs"}
+	applyDeprecatedAliasCall(DeprecatedToolAliasCalls["list_workflows"], &tool, args)
+	assert.Equal(t, "list_workflow_runs", args["method"])
+}
+
+func Test_NewMCPServerResolvesAliasesBeforeWriteChecks(t *testing.T) {
+	github := &countingTransport{}
+	counter := &countingMetrics{increments: map[string][]map[string]string{}}
+	deps := testServerDeps(github)
+	obs, err := observability.NewExporters(slog.New(slog.DiscardHandler), counter)
+	require.NoError(t, err)
+	deps.obsv = obs
+	cs := connectTestClient(t, MCPServerConfig{DryRun: true}, []string{"actions"}, deps, mcp.NewClient(&mcp.Implementation{Name: "test-client"}, nil))
+
+	result, err := cs.CallTool(context.Background(), &mcp.CallToolParams{
+		Name:      "cancel_workflow_run",
+		Arguments: map[string]any{"owner": "octo", "repo": "ink", "run_id": 7},
+	})
+	require.NoError(t, err)
+	assert.Contains(t, getTextResult(t, result).Text, `"dry_run":true`)
+	assert.Contains(t, result.Meta, DeprecationMetaKey)
+	assert.Zero(t, github.requests.Load())
+	assert.Len(t, counter.increments[DeprecatedAliasCallsMetric], 1)
+}
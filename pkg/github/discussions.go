@@ -4,7 +4,10 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"slices"
+	"strings"
 
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
 	"github.com/github/github-mcp-server/pkg/inventory"
 	"github.com/github/github-mcp-server/pkg/scopes"
 	"github.com/github/github-mcp-server/pkg/translations"
@@ -198,9 +201,10 @@ func ListDiscussions(t translations.TranslationHelperFunc) inventory.ServerTool
 			if err != nil {
 				return nil, nil, err
 			}
-			paginationParams, err := pagination.ToGraphQLParams()
+			cursorScope := "list_discussions:" + owner + "/" + repo
+			paginationParams, err := pagination.ToSignedGraphQLParams(ctx, cursorScope)
 			if err != nil {
-				return nil, nil, err
+				return utils.NewToolResultError(err.Error()), nil, nil
 			}
 
 			client, err := deps.GetGQLClient(ctx)
@@ -261,8 +265,8 @@ func ListDiscussions(t translations.TranslationHelperFunc) inventory.ServerTool
 				"pageInfo": map[string]any{
 					"hasNextPage":     pageInfo.HasNextPage,
 					"hasPreviousPage": pageInfo.HasPreviousPage,
-					"startCursor":     string(pageInfo.StartCursor),
-					"endCursor":       string(pageInfo.EndCursor),
+					"startCursor":     signCursor(ctx, cursorScope, string(pageInfo.StartCursor)),
+					"endCursor":       signCursor(ctx, cursorScope, string(pageInfo.EndCursor)),
 				},
 				"totalCount": totalCount,
 			}
@@ -355,7 +359,7 @@ func GetDiscussion(t translations.TranslationHelperFunc) inventory.ServerTool {
 			response := map[string]any{
 				"number":     int(d.Number),
 				"title":      string(d.Title),
-				"body":       string(d.Body),
+				"body":       normalizeBody(ctx, deps, string(d.Body), string(d.URL)),
 				"url":        string(d.URL),
 				"closed":     bool(d.Closed),
 				"isAnswered": bool(d.IsAnswered),
@@ -385,7 +389,7 @@ func GetDiscussionComments(t translations.TranslationHelperFunc) inventory.Serve
 		ToolsetMetadataDiscussions,
 		mcp.Tool{
 			Name:        "get_discussion_comments",
-			Description: t("TOOL_GET_DISCUSSION_COMMENTS_DESCRIPTION", "Get comments from a discussion"),
+			Description: t("TOOL_GET_DISCUSSION_COMMENTS_DESCRIPTION", "Get comments from a discussion, with the node IDs used to reply to a comment or mark it as the answer"),
 			Annotations: &mcp.ToolAnnotations{
 				Title:        t("TOOL_GET_DISCUSSION_COMMENTS_USER_TITLE", "Get discussion comments"),
 				ReadOnlyHint: true,
@@ -431,9 +435,10 @@ func GetDiscussionComments(t translations.TranslationHelperFunc) inventory.Serve
 			_, perPageProvided := args["perPage"]
 			paginationExplicit := perPageProvided
 
-			paginationParams, err := pagination.ToGraphQLParams()
+			cursorScope := fmt.Sprintf("get_discussion_comments:%s/%s#%d", params.Owner, params.Repo, params.DiscussionNumber)
+			paginationParams, err := pagination.ToSignedGraphQLParams(ctx, cursorScope)
 			if err != nil {
-				return nil, nil, err
+				return utils.NewToolResultError(err.Error()), nil, nil
 			}
 
 			// Use default of 30 if pagination was not explicitly provided
@@ -452,7 +457,13 @@ func GetDiscussionComments(t translations.TranslationHelperFunc) inventory.Serve
 					Discussion struct {
 						Comments struct {
 							Nodes []struct {
-								Body githubv4.String
+								ID     githubv4.ID
+								Body   githubv4.String
+								Author struct {
+									Login githubv4.String
+								}
+								CreatedAt githubv4.DateTime
+								URL       githubv4.String `graphql:"url"`
 							}
 							PageInfo struct {
 								HasNextPage     githubv4.Boolean
@@ -482,7 +493,20 @@ func GetDiscussionComments(t translations.TranslationHelperFunc) inventory.Serve
 
 			var comments []*github.IssueComment
 			for _, c := range q.Repository.Discussion.Comments.Nodes {
-				comments = append(comments, &github.IssueComment{Body: github.Ptr(string(c.Body))})
+				comment := &github.IssueComment{Body: github.Ptr(string(c.Body))}
+				if c.ID != nil {
+					comment.NodeID = github.Ptr(fmt.Sprint(c.ID))
+				}
+				if c.URL != "" {
+					comment.HTMLURL = github.Ptr(string(c.URL))
+				}
+				if c.Author.Login != "" {
+					comment.User = &github.User{Login: github.Ptr(string(c.Author.Login))}
+				}
+				if !c.CreatedAt.IsZero() {
+					comment.CreatedAt = &github.Timestamp{Time: c.CreatedAt.Time}
+				}
+				comments = append(comments, comment)
 			}
 
 			// Create response with pagination info
@@ -491,8 +515,8 @@ func GetDiscussionComments(t translations.TranslationHelperFunc) inventory.Serve
 				"pageInfo": map[string]any{
 					"hasNextPage":     q.Repository.Discussion.Comments.PageInfo.HasNextPage,
 					"hasPreviousPage": q.Repository.Discussion.Comments.PageInfo.HasPreviousPage,
-					"startCursor":     string(q.Repository.Discussion.Comments.PageInfo.StartCursor),
-					"endCursor":       string(q.Repository.Discussion.Comments.PageInfo.EndCursor),
+					"startCursor":     signCursor(ctx, cursorScope, string(q.Repository.Discussion.Comments.PageInfo.StartCursor)),
+					"endCursor":       signCursor(ctx, cursorScope, string(q.Repository.Discussion.Comments.PageInfo.EndCursor)),
 				},
 				"totalCount": q.Repository.Discussion.Comments.TotalCount,
 			}
@@ -607,3 +631,522 @@ func ListDiscussionCategories(t translations.TranslationHelperFunc) inventory.Se
 		},
 	)
 }
+
+func CreateDiscussion(t translations.TranslationHelperFunc) inventory.ServerTool {
+	return NewTool(
+		ToolsetMetadataDiscussions,
+		mcp.Tool{
+			Name:        "create_discussion",
+			Description: t("TOOL_CREATE_DISCUSSION_DESCRIPTION", "Create a discussion in a repository, in the given category. Use 'list_discussion_categories' to find available categories."),
+			Annotations: &mcp.ToolAnnotations{
+				Title:        t("TOOL_CREATE_DISCUSSION_USER_TITLE", "Create discussion"),
+				ReadOnlyHint: false,
+			},
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"owner": {
+						Type:        "string",
+						Description: "Repository owner",
+					},
+					"repo": {
+						Type:        "string",
+						Description: "Repository name",
+					},
+					"category": {
+						Type:        "string",
+						Description: "Discussion category name or ID to create the discussion in",
+					},
+					"title": {
+						Type:        "string",
+						Description: "Discussion title",
+					},
+					"body": {
+						Type:        "string",
+						Description: "Discussion body in markdown",
+					},
+				},
+				Required: []string{"owner", "repo", "category", "title", "body"},
+			},
+		},
+		[]scopes.Scope{scopes.Repo},
+		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+			owner, err := RequiredParam[string](args, "owner")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			repo, err := RequiredParam[string](args, "repo")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			category, err := RequiredParam[string](args, "category")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			title, err := RequiredParam[string](args, "title")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			body, err := RequiredParam[string](args, "body")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+
+			client, err := deps.GetGQLClient(ctx)
+			if err != nil {
+				return utils.NewToolResultError(fmt.Sprintf("failed to get GitHub GQL client: %v", err)), nil, nil
+			}
+
+			var q struct {
+				Repository struct {
+					ID                   githubv4.ID
+					DiscussionCategories struct {
+						Nodes []struct {
+							ID   githubv4.ID
+							Name githubv4.String
+						}
+					} `graphql:"discussionCategories(first: 100)"`
+				} `graphql:"repository(owner: $owner, name: $repo)"`
+			}
+			vars := map[string]any{
+				"owner": githubv4.String(owner),
+				"repo":  githubv4.String(repo),
+			}
+			if err := client.Query(ctx, &q, vars); err != nil {
+				return ghErrors.NewGitHubGraphQLErrorResponse(ctx, "failed to get discussion categories", err), nil, nil
+			}
+
+			var categoryID githubv4.ID
+			for _, c := range q.Repository.DiscussionCategories.Nodes {
+				if fmt.Sprint(c.ID) == category || strings.EqualFold(string(c.Name), category) {
+					categoryID = c.ID
+					break
+				}
+			}
+			if categoryID == nil {
+				return utils.NewToolResultError(fmt.Sprintf("discussion category '%s' not found in %s/%s", category, owner, repo)), nil, nil
+			}
+
+			var mutation struct {
+				CreateDiscussion struct {
+					Discussion struct {
+						ID     githubv4.ID
+						Number githubv4.Int
+						URL    githubv4.String `graphql:"url"`
+					}
+				} `graphql:"createDiscussion(input: $input)"`
+			}
+			input := githubv4.CreateDiscussionInput{
+				RepositoryID: q.Repository.ID,
+				CategoryID:   categoryID,
+				Title:        githubv4.String(title),
+				Body:         githubv4.String(body),
+			}
+			if err := client.Mutate(ctx, &mutation, input, nil); err != nil {
+				return ghErrors.NewGitHubGraphQLErrorResponse(ctx, "failed to create discussion", err), nil, nil
+			}
+
+			d := mutation.CreateDiscussion.Discussion
+			return MarshalledTextResult(map[string]any{
+				"id":     fmt.Sprint(d.ID),
+				"number": int(d.Number),
+				"url":    string(d.URL),
+			}), nil, nil
+		},
+	)
+}
+
+func AddDiscussionComment(t translations.TranslationHelperFunc) inventory.ServerTool {
+	return NewTool(
+		ToolsetMetadataDiscussions,
+		mcp.Tool{
+			Name:        "add_discussion_comment",
+			Description: t("TOOL_ADD_DISCUSSION_COMMENT_DESCRIPTION", "Add a comment to a discussion, or reply to one of its comments. Use 'get_discussion_comments' to find the node ID of the comment to reply to."),
+			Annotations: &mcp.ToolAnnotations{
+				Title:        t("TOOL_ADD_DISCUSSION_COMMENT_USER_TITLE", "Add discussion comment"),
+				ReadOnlyHint: false,
+			},
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"owner": {
+						Type:        "string",
+						Description: "Repository owner",
+					},
+					"repo": {
+						Type:        "string",
+						Description: "Repository name",
+					},
+					"discussionNumber": {
+						Type:        "number",
+						Description: "Discussion Number",
+					},
+					"body": {
+						Type:        "string",
+						Description: "Comment body in markdown",
+					},
+					"replyToId": {
+						Type:        "string",
+						Description: "Node ID of a top-level comment of the discussion to reply to",
+					},
+				},
+				Required: []string{"owner", "repo", "discussionNumber", "body"},
+			},
+		},
+		[]scopes.Scope{scopes.Repo},
+		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+			owner, err := RequiredParam[string](args, "owner")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			repo, err := RequiredParam[string](args, "repo")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			discussionNumber, err := RequiredInt(args, "discussionNumber")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			body, err := RequiredParam[string](args, "body")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			replyToID, err := OptionalParam[string](args, "replyToId")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+
+			client, err := deps.GetGQLClient(ctx)
+			if err != nil {
+				return utils.NewToolResultError(fmt.Sprintf("failed to get GitHub GQL client: %v", err)), nil, nil
+			}
+
+			var q struct {
+				Repository struct {
+					Discussion struct {
+						ID githubv4.ID
+					} `graphql:"discussion(number: $discussionNumber)"`
+				} `graphql:"repository(owner: $owner, name: $repo)"`
+			}
+			vars := map[string]any{
+				"owner":            githubv4.String(owner),
+				"repo":             githubv4.String(repo),
+				"discussionNumber": githubv4.Int(discussionNumber), // #nosec G115 - discussion numbers are always small positive integers
+			}
+			if err := client.Query(ctx, &q, vars); err != nil {
+				return ghErrors.NewGitHubGraphQLErrorResponse(ctx, "failed to get discussion", err), nil, nil
+			}
+
+			var mutation struct {
+				AddDiscussionComment struct {
+					Comment struct {
+						ID  githubv4.ID
+						URL githubv4.String `graphql:"url"`
+					}
+				} `graphql:"addDiscussionComment(input: $input)"`
+			}
+			input := githubv4.AddDiscussionCommentInput{
+				DiscussionID: q.Repository.Discussion.ID,
+				Body:         githubv4.String(body),
+			}
+			if replyToID != "" {
+				id := githubv4.ID(replyToID)
+				input.ReplyToID = &id
+			}
+			if err := client.Mutate(ctx, &mutation, input, nil); err != nil {
+				return ghErrors.NewGitHubGraphQLErrorResponse(ctx, "failed to add discussion comment", err), nil, nil
+			}
+
+			c := mutation.AddDiscussionComment.Comment
+			return MarshalledTextResult(map[string]any{
+				"id":  fmt.Sprint(c.ID),
+				"url": string(c.URL),
+			}), nil, nil
+		},
+	)
+}
+
+func MarkDiscussionAnswer(t translations.TranslationHelperFunc) inventory.ServerTool {
+	return NewTool(
+		ToolsetMetadataDiscussions,
+		mcp.Tool{
+			Name:        "mark_discussion_answer",
+			Description: t("TOOL_MARK_DISCUSSION_ANSWER_DESCRIPTION", "Mark a comment as the answer of its discussion, or unmark it. The discussion must be in a category that accepts answers, such as Q&A. Use 'get_discussion_comments' to find the node ID of the comment."),
+			Annotations: &mcp.ToolAnnotations{
+				Title:          t("TOOL_MARK_DISCUSSION_ANSWER_USER_TITLE", "Mark discussion answer"),
+				ReadOnlyHint:   false,
+				IdempotentHint: true,
+			},
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"commentId": {
+						Type:        "string",
+						Description: "Node ID of the discussion comment",
+					},
+					"unmark": {
+						Type:        "boolean",
+						Description: "Unmark the comment as the answer instead (default: false)",
+						Default:     json.RawMessage(`false`),
+					},
+				},
+				Required: []string{"commentId"},
+			},
+		},
+		[]scopes.Scope{scopes.Repo},
+		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+			commentID, err := RequiredParam[string](args, "commentId")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			unmark, err := OptionalBoolParamWithDefault(args, "unmark", false)
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+
+			client, err := deps.GetGQLClient(ctx)
+			if err != nil {
+				return utils.NewToolResultError(fmt.Sprintf("failed to get GitHub GQL client: %v", err)), nil, nil
+			}
+
+			type answeredDiscussion struct {
+				Number     githubv4.Int
+				IsAnswered githubv4.Boolean
+				URL        githubv4.String `graphql:"url"`
+			}
+			var d answeredDiscussion
+			if unmark {
+				var mutation struct {
+					UnmarkDiscussionCommentAsAnswer struct {
+						Discussion answeredDiscussion
+					} `graphql:"unmarkDiscussionCommentAsAnswer(input: $input)"`
+				}
+				input := githubv4.UnmarkDiscussionCommentAsAnswerInput{ID: githubv4.ID(commentID)}
+				if err := client.Mutate(ctx, &mutation, input, nil); err != nil {
+					return ghErrors.NewGitHubGraphQLErrorResponse(ctx, "failed to unmark discussion answer", err), nil, nil
+				}
+				d = mutation.UnmarkDiscussionCommentAsAnswer.Discussion
+			} else {
+				var mutation struct {
+					MarkDiscussionCommentAsAnswer struct {
+						Discussion answeredDiscussion
+					} `graphql:"markDiscussionCommentAsAnswer(input: $input)"`
+				}
+				input := githubv4.MarkDiscussionCommentAsAnswerInput{ID: githubv4.ID(commentID)}
+				if err := client.Mutate(ctx, &mutation, input, nil); err != nil {
+					return ghErrors.NewGitHubGraphQLErrorResponse(ctx, "failed to mark discussion answer", err), nil, nil
+				}
+				d = mutation.MarkDiscussionCommentAsAnswer.Discussion
+			}
+
+			return MarshalledTextResult(map[string]any{
+				"number":     int(d.Number),
+				"isAnswered": bool(d.IsAnswered),
+				"url":        string(d.URL),
+			}), nil, nil
+		},
+	)
+}
+
+// maxPromotedParticipants bounds the participants of a discussion mentioned in the issue it is
+// converted to.
+const maxPromotedParticipants = 20
+
+// ConvertDiscussionToIssue creates an issue from a discussion, mentioning its participants, and
+// comments on the discussion with a back-reference to the new issue.
+func ConvertDiscussionToIssue(t translations.TranslationHelperFunc) inventory.ServerTool {
+	return NewTool(
+		ToolsetMetadataDiscussions,
+		mcp.Tool{
+			Name:        "convert_discussion_to_issue",
+			Description: t("TOOL_CONVERT_DISCUSSION_TO_ISSUE_DESCRIPTION", fmt.Sprintf(`Convert a discussion into an issue, or create an issue linked to it. Creates an issue with the discussion's title and body, a link to the discussion, and mentions of its author and commenters (bots left out, up to %d), so they are notified. Then comments on the discussion with a link to the new issue, and closes the discussion as resolved unless 'closeDiscussion' is false.`, maxPromotedParticipants)),
+			Annotations: &mcp.ToolAnnotations{
+				Title:        t("TOOL_CONVERT_DISCUSSION_TO_ISSUE_USER_TITLE", "Convert discussion to issue"),
+				ReadOnlyHint: false,
+			},
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"owner": {
+						Type:        "string",
+						Description: "Repository owner",
+					},
+					"repo": {
+						Type:        "string",
+						Description: "Repository name",
+					},
+					"discussionNumber": {
+						Type:        "number",
+						Description: "Discussion Number",
+					},
+					"title": {
+						Type:        "string",
+						Description: "Title of the issue (default: the discussion's title)",
+					},
+					"mentionParticipants": {
+						Type:        "boolean",
+						Description: "Mention the participants of the discussion in the issue (default: true)",
+						Default:     json.RawMessage(`true`),
+					},
+					"closeDiscussion": {
+						Type:        "boolean",
+						Description: "Close the discussion as resolved after the issue is created (default: true)",
+						Default:     json.RawMessage(`true`),
+					},
+				},
+				Required: []string{"owner", "repo", "discussionNumber"},
+			},
+		},
+		[]scopes.Scope{scopes.Repo},
+		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+			owner, err := RequiredParam[string](args, "owner")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			repo, err := RequiredParam[string](args, "repo")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			discussionNumber, err := RequiredInt(args, "discussionNumber")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			title, err := OptionalParam[string](args, "title")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			mentionParticipants, err := OptionalBoolParamWithDefault(args, "mentionParticipants", true)
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			closeDiscussion, err := OptionalBoolParamWithDefault(args, "closeDiscussion", true)
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+
+			client, err := deps.GetGQLClient(ctx)
+			if err != nil {
+				return utils.NewToolResultError(fmt.Sprintf("failed to get GitHub GQL client: %v", err)), nil, nil
+			}
+
+			type discussionAuthor struct {
+				Typename githubv4.String `graphql:"__typename"`
+				Login    githubv4.String
+			}
+			var q struct {
+				Repository struct {
+					ID         githubv4.ID
+					Discussion struct {
+						ID       githubv4.ID
+						Title    githubv4.String
+						Body     githubv4.String
+						URL      githubv4.String `graphql:"url"`
+						Author   discussionAuthor
+						Comments struct {
+							Nodes []struct {
+								Author discussionAuthor
+							}
+						} `graphql:"comments(first: 100)"`
+					} `graphql:"discussion(number: $discussionNumber)"`
+				} `graphql:"repository(owner: $owner, name: $repo)"`
+			}
+			vars := map[string]any{
+				"owner":            githubv4.String(owner),
+				"repo":             githubv4.String(repo),
+				"discussionNumber": githubv4.Int(discussionNumber), // #nosec G115 - discussion numbers are always small positive integers
+			}
+			if err := client.Query(ctx, &q, vars); err != nil {
+				return ghErrors.NewGitHubGraphQLErrorResponse(ctx, "failed to get discussion", err), nil, nil
+			}
+			discussion := q.Repository.Discussion
+			if title == "" {
+				title = string(discussion.Title)
+			}
+
+			// The author first, then commenters in the order they joined
+			mentioned := []string{}
+			if mentionParticipants {
+				authors := []discussionAuthor{discussion.Author}
+				for _, c := range discussion.Comments.Nodes {
+					authors = append(authors, c.Author)
+				}
+				for _, author := range authors {
+					login := string(author.Login)
+					if login == "" || author.Typename == "Bot" || slices.Contains(mentioned, login) {
+						continue
+					}
+					if len(mentioned) == maxPromotedParticipants {
+						break
+					}
+					mentioned = append(mentioned, login)
+				}
+			}
+
+			body := fmt.Sprintf("%s\n\n---\n_Converted from discussion #%d: %s_", discussion.Body, discussionNumber, discussion.URL)
+			if len(mentioned) > 0 {
+				body += "\n\ncc @" + strings.Join(mentioned, " @")
+			}
+			var createMutation struct {
+				CreateIssue struct {
+					Issue struct {
+						ID     githubv4.ID
+						Number githubv4.Int
+						URL    githubv4.String `graphql:"url"`
+					}
+				} `graphql:"createIssue(input: $input)"`
+			}
+			issueBody := githubv4.String(body)
+			createInput := githubv4.CreateIssueInput{
+				RepositoryID: q.Repository.ID,
+				Title:        githubv4.String(title),
+				Body:         &issueBody,
+			}
+			if err := client.Mutate(ctx, &createMutation, createInput, nil); err != nil {
+				return ghErrors.NewGitHubGraphQLErrorResponse(ctx, "failed to create issue", err), nil, nil
+			}
+			issue := createMutation.CreateIssue.Issue
+
+			var commentMutation struct {
+				AddDiscussionComment struct {
+					Comment struct {
+						ID githubv4.ID
+					}
+				} `graphql:"addDiscussionComment(input: $input)"`
+			}
+			commentInput := githubv4.AddDiscussionCommentInput{
+				DiscussionID: discussion.ID,
+				Body:         githubv4.String(fmt.Sprintf("This discussion has been converted to an issue: %s", issue.URL)),
+			}
+			if err := client.Mutate(ctx, &commentMutation, commentInput, nil); err != nil {
+				return ghErrors.NewGitHubGraphQLErrorResponse(ctx, "issue created but failed to comment on discussion", err), nil, nil
+			}
+
+			if closeDiscussion {
+				var closeMutation struct {
+					CloseDiscussion struct {
+						Discussion struct {
+							ID githubv4.ID
+						}
+					} `graphql:"closeDiscussion(input: $input)"`
+				}
+				reason := githubv4.DiscussionCloseReasonResolved
+				closeInput := githubv4.CloseDiscussionInput{
+					DiscussionID: discussion.ID,
+					Reason:       &reason,
+				}
+				if err := client.Mutate(ctx, &closeMutation, closeInput, nil); err != nil {
+					return ghErrors.NewGitHubGraphQLErrorResponse(ctx, "issue created but failed to close discussion", err), nil, nil
+				}
+			}
+
+			return MarshalledTextResult(map[string]any{
+				"issue": map[string]any{
+					"number": int(issue.Number),
+					"url":    string(issue.URL),
+				},
+				"mentioned":        mentioned,
+				"discussionClosed": closeDiscussion,
+			}), nil, nil
+		},
+	)
+}
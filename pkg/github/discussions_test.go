@@ -650,7 +650,7 @@ func Test_GetDiscussionComments(t *testing.T) {
 	assert.ElementsMatch(t, schema.Required, []string{"owner", "repo", "discussionNumber"})
 
 	// Use exact string query that matches implementation output
-	qGetComments := "query($after:String$discussionNumber:Int!$first:Int!$owner:String!$repo:String!){repository(owner: $owner, name: $repo){discussion(number: $discussionNumber){comments(first: $first, after: $after){nodes{body},pageInfo{hasNextPage,hasPreviousPage,startCursor,endCursor},totalCount}}}}"
+	qGetComments := "query($after:String$discussionNumber:Int!$first:Int!$owner:String!$repo:String!){repository(owner: $owner, name: $repo){discussion(number: $discussionNumber){comments(first: $first, after: $after){nodes{id,body,author{login},createdAt,url},pageInfo{hasNextPage,hasPreviousPage,startCursor,endCursor},totalCount}}}}"
 
 	// Variables matching what GraphQL receives after JSON marshaling/unmarshaling
 	vars := map[string]any{
@@ -666,8 +666,8 @@ func Test_GetDiscussionComments(t *testing.T) {
 			"discussion": map[string]any{
 				"comments": map[string]any{
 					"nodes": []map[string]any{
-						{"body": "This is the first comment"},
-						{"body": "This is the second comment"},
+						{"id": "DC_1", "body": "This is the first comment", "author": map[string]any{"login": "user1"}, "createdAt": "2023-01-01T00:00:00Z", "url": "https://github.com/owner/repo/discussions/1#discussioncomment-1"},
+						{"id": "DC_2", "body": "This is the second comment"},
 					},
 					"pageInfo": map[string]any{
 						"hasNextPage":     false,
@@ -717,13 +717,17 @@ func Test_GetDiscussionComments(t *testing.T) {
 	for i, comment := range response.Comments {
 		assert.Equal(t, expectedBodies[i], *comment.Body)
 	}
+	assert.Equal(t, "DC_1", response.Comments[0].GetNodeID())
+	assert.Equal(t, "user1", response.Comments[0].GetUser().GetLogin())
+	assert.Equal(t, "https://github.com/owner/repo/discussions/1#discussioncomment-1", response.Comments[0].GetHTMLURL())
+	assert.Equal(t, "DC_2", response.Comments[1].GetNodeID())
 }
 
 func Test_GetDiscussionCommentsWithStringNumber(t *testing.T) {
 	// Test that WeakDecode handles string discussionNumber from MCP clients
 	toolDef := GetDiscussionComments(translations.NullTranslationHelper)
 
-	qGetComments := "query($after:String$discussionNumber:Int!$first:Int!$owner:String!$repo:String!){repository(owner: $owner, name: $repo){discussion(number: $discussionNumber){comments(first: $first, after: $after){nodes{body},pageInfo{hasNextPage,hasPreviousPage,startCursor,endCursor},totalCount}}}}"
+	qGetComments := "query($after:String$discussionNumber:Int!$first:Int!$owner:String!$repo:String!){repository(owner: $owner, name: $repo){discussion(number: $discussionNumber){comments(first: $first, after: $after){nodes{id,body,author{login},createdAt,url},pageInfo{hasNextPage,hasPreviousPage,startCursor,endCursor},totalCount}}}}"
 
 	vars := map[string]any{
 		"owner":            "owner",
@@ -924,3 +928,586 @@ func Test_ListDiscussionCategories(t *testing.T) {
 		})
 	}
 }
+
+func Test_CreateDiscussion(t *testing.T) {
+	// Verify tool definition once
+	serverTool := CreateDiscussion(translations.NullTranslationHelper)
+	tool := serverTool.Tool
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "create_discussion", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.False(t, tool.Annotations.ReadOnlyHint)
+	schema := tool.InputSchema.(*jsonschema.Schema)
+	assert.ElementsMatch(t, schema.Required, []string{"owner", "repo", "category", "title", "body"})
+
+	categoriesQuery := struct {
+		Repository struct {
+			ID                   githubv4.ID
+			DiscussionCategories struct {
+				Nodes []struct {
+					ID   githubv4.ID
+					Name githubv4.String
+				}
+			} `graphql:"discussionCategories(first: 100)"`
+		} `graphql:"repository(owner: $owner, name: $repo)"`
+	}{}
+	categoriesVars := map[string]any{
+		"owner": githubv4.String("owner"),
+		"repo":  githubv4.String("repo"),
+	}
+	categoriesResponse := githubv4mock.DataResponse(map[string]any{
+		"repository": map[string]any{
+			"id": "R_1",
+			"discussionCategories": map[string]any{
+				"nodes": []map[string]any{
+					{"id": "DIC_1", "name": "General"},
+					{"id": "DIC_2", "name": "Q&A"},
+				},
+			},
+		},
+	})
+
+	createMutation := struct {
+		CreateDiscussion struct {
+			Discussion struct {
+				ID     githubv4.ID
+				Number githubv4.Int
+				URL    githubv4.String `graphql:"url"`
+			}
+		} `graphql:"createDiscussion(input: $input)"`
+	}{}
+	createInput := githubv4.CreateDiscussionInput{
+		RepositoryID: "R_1",
+		CategoryID:   "DIC_2",
+		Title:        "How do I configure this?",
+		Body:         "I could not find it in the docs.",
+	}
+	createResponse := githubv4mock.DataResponse(map[string]any{
+		"createDiscussion": map[string]any{
+			"discussion": map[string]any{
+				"id":     "D_7",
+				"number": 7,
+				"url":    "https://github.com/owner/repo/discussions/7",
+			},
+		},
+	})
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]any
+		expectToolErr  bool
+		expectedErrMsg string
+	}{
+		{
+			name: "creates discussion by category name",
+			mockedClient: githubv4mock.NewMockedHTTPClient(
+				githubv4mock.NewQueryMatcher(categoriesQuery, categoriesVars, categoriesResponse),
+				githubv4mock.NewMutationMatcher(createMutation, createInput, nil, createResponse),
+			),
+			requestArgs: map[string]any{
+				"owner":    "owner",
+				"repo":     "repo",
+				"category": "q&a",
+				"title":    "How do I configure this?",
+				"body":     "I could not find it in the docs.",
+			},
+		},
+		{
+			name: "creates discussion by category ID",
+			mockedClient: githubv4mock.NewMockedHTTPClient(
+				githubv4mock.NewQueryMatcher(categoriesQuery, categoriesVars, categoriesResponse),
+				githubv4mock.NewMutationMatcher(createMutation, createInput, nil, createResponse),
+			),
+			requestArgs: map[string]any{
+				"owner":    "owner",
+				"repo":     "repo",
+				"category": "DIC_2",
+				"title":    "How do I configure this?",
+				"body":     "I could not find it in the docs.",
+			},
+		},
+		{
+			name: "unknown category",
+			mockedClient: githubv4mock.NewMockedHTTPClient(
+				githubv4mock.NewQueryMatcher(categoriesQuery, categoriesVars, categoriesResponse),
+			),
+			requestArgs: map[string]any{
+				"owner":    "owner",
+				"repo":     "repo",
+				"category": "Ideas",
+				"title":    "How do I configure this?",
+				"body":     "I could not find it in the docs.",
+			},
+			expectToolErr:  true,
+			expectedErrMsg: "discussion category 'Ideas' not found in owner/repo",
+		},
+		{
+			name:         "missing title",
+			mockedClient: githubv4mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"owner":    "owner",
+				"repo":     "repo",
+				"category": "General",
+				"body":     "I could not find it in the docs.",
+			},
+			expectToolErr:  true,
+			expectedErrMsg: "missing required parameter: title",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			deps := BaseDeps{
+				GQLClient: githubv4.NewClient(tc.mockedClient),
+			}
+			handler := serverTool.Handler(deps)
+			request := createMCPRequest(tc.requestArgs)
+
+			result, err := handler(ContextWithDeps(context.Background(), deps), &request)
+			require.NoError(t, err)
+
+			if tc.expectToolErr {
+				require.True(t, result.IsError)
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			var response struct {
+				Number int    `json:"number"`
+				URL    string `json:"url"`
+			}
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &response))
+			assert.Equal(t, 7, response.Number)
+			assert.Equal(t, "https://github.com/owner/repo/discussions/7", response.URL)
+		})
+	}
+}
+
+func Test_AddDiscussionComment(t *testing.T) {
+	// Verify tool definition once
+	serverTool := AddDiscussionComment(translations.NullTranslationHelper)
+	tool := serverTool.Tool
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "add_discussion_comment", tool.Name)
+	assert.False(t, tool.Annotations.ReadOnlyHint)
+	schema := tool.InputSchema.(*jsonschema.Schema)
+	assert.Contains(t, schema.Properties, "replyToId")
+	assert.ElementsMatch(t, schema.Required, []string{"owner", "repo", "discussionNumber", "body"})
+
+	discussionQuery := struct {
+		Repository struct {
+			Discussion struct {
+				ID githubv4.ID
+			} `graphql:"discussion(number: $discussionNumber)"`
+		} `graphql:"repository(owner: $owner, name: $repo)"`
+	}{}
+	discussionVars := map[string]any{
+		"owner":            githubv4.String("owner"),
+		"repo":             githubv4.String("repo"),
+		"discussionNumber": githubv4.Int(7),
+	}
+	discussionResponse := githubv4mock.DataResponse(map[string]any{
+		"repository": map[string]any{"discussion": map[string]any{"id": "D_7"}},
+	})
+
+	commentMutation := struct {
+		AddDiscussionComment struct {
+			Comment struct {
+				ID  githubv4.ID
+				URL githubv4.String `graphql:"url"`
+			}
+		} `graphql:"addDiscussionComment(input: $input)"`
+	}{}
+	commentResponse := githubv4mock.DataResponse(map[string]any{
+		"addDiscussionComment": map[string]any{
+			"comment": map[string]any{
+				"id":  "DC_3",
+				"url": "https://github.com/owner/repo/discussions/7#discussioncomment-3",
+			},
+		},
+	})
+	replyToID := githubv4.ID("DC_1")
+
+	tests := []struct {
+		name        string
+		input       githubv4.AddDiscussionCommentInput
+		requestArgs map[string]any
+	}{
+		{
+			name: "comments on discussion",
+			input: githubv4.AddDiscussionCommentInput{
+				DiscussionID: "D_7",
+				Body:         "Set it in the config file.",
+			},
+			requestArgs: map[string]any{
+				"owner":            "owner",
+				"repo":             "repo",
+				"discussionNumber": float64(7),
+				"body":             "Set it in the config file.",
+			},
+		},
+		{
+			name: "replies to comment",
+			input: githubv4.AddDiscussionCommentInput{
+				DiscussionID: "D_7",
+				Body:         "Set it in the config file.",
+				ReplyToID:    &replyToID,
+			},
+			requestArgs: map[string]any{
+				"owner":            "owner",
+				"repo":             "repo",
+				"discussionNumber": float64(7),
+				"body":             "Set it in the config file.",
+				"replyToId":        "DC_1",
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockedClient := githubv4mock.NewMockedHTTPClient(
+				githubv4mock.NewQueryMatcher(discussionQuery, discussionVars, discussionResponse),
+				githubv4mock.NewMutationMatcher(commentMutation, tc.input, nil, commentResponse),
+			)
+			deps := BaseDeps{
+				GQLClient: githubv4.NewClient(mockedClient),
+			}
+			handler := serverTool.Handler(deps)
+			request := createMCPRequest(tc.requestArgs)
+
+			result, err := handler(ContextWithDeps(context.Background(), deps), &request)
+			require.NoError(t, err)
+			require.False(t, result.IsError)
+
+			var response struct {
+				ID  string `json:"id"`
+				URL string `json:"url"`
+			}
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &response))
+			assert.Equal(t, "DC_3", response.ID)
+			assert.Equal(t, "https://github.com/owner/repo/discussions/7#discussioncomment-3", response.URL)
+		})
+	}
+}
+
+func Test_MarkDiscussionAnswer(t *testing.T) {
+	// Verify tool definition once
+	serverTool := MarkDiscussionAnswer(translations.NullTranslationHelper)
+	tool := serverTool.Tool
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "mark_discussion_answer", tool.Name)
+	assert.False(t, tool.Annotations.ReadOnlyHint)
+	schema := tool.InputSchema.(*jsonschema.Schema)
+	assert.Contains(t, schema.Properties, "unmark")
+	assert.ElementsMatch(t, schema.Required, []string{"commentId"})
+
+	type answeredDiscussion struct {
+		Number     githubv4.Int
+		IsAnswered githubv4.Boolean
+		URL        githubv4.String `graphql:"url"`
+	}
+	markMutation := struct {
+		MarkDiscussionCommentAsAnswer struct {
+			Discussion answeredDiscussion
+		} `graphql:"markDiscussionCommentAsAnswer(input: $input)"`
+	}{}
+	unmarkMutation := struct {
+		UnmarkDiscussionCommentAsAnswer struct {
+			Discussion answeredDiscussion
+		} `graphql:"unmarkDiscussionCommentAsAnswer(input: $input)"`
+	}{}
+	discussion := func(answered bool) map[string]any {
+		return map[string]any{
+			"number":     7,
+			"isAnswered": answered,
+			"url":        "https://github.com/owner/repo/discussions/7",
+		}
+	}
+
+	tests := []struct {
+		name             string
+		mockedClient     *http.Client
+		requestArgs      map[string]any
+		expectToolErr    bool
+		expectedErrMsg   string
+		expectedAnswered bool
+	}{
+		{
+			name: "marks comment as answer",
+			mockedClient: githubv4mock.NewMockedHTTPClient(
+				githubv4mock.NewMutationMatcher(markMutation, githubv4.MarkDiscussionCommentAsAnswerInput{ID: "DC_3"}, nil,
+					githubv4mock.DataResponse(map[string]any{
+						"markDiscussionCommentAsAnswer": map[string]any{"discussion": discussion(true)},
+					}),
+				),
+			),
+			requestArgs:      map[string]any{"commentId": "DC_3"},
+			expectedAnswered: true,
+		},
+		{
+			name: "unmarks comment as answer",
+			mockedClient: githubv4mock.NewMockedHTTPClient(
+				githubv4mock.NewMutationMatcher(unmarkMutation, githubv4.UnmarkDiscussionCommentAsAnswerInput{ID: "DC_3"}, nil,
+					githubv4mock.DataResponse(map[string]any{
+						"unmarkDiscussionCommentAsAnswer": map[string]any{"discussion": discussion(false)},
+					}),
+				),
+			),
+			requestArgs:      map[string]any{"commentId": "DC_3", "unmark": true},
+			expectedAnswered: false,
+		},
+		{
+			name: "category does not accept answers",
+			mockedClient: githubv4mock.NewMockedHTTPClient(
+				githubv4mock.NewMutationMatcher(markMutation, githubv4.MarkDiscussionCommentAsAnswerInput{ID: "DC_3"}, nil,
+					githubv4mock.ErrorResponse("Discussion category does not accept answers"),
+				),
+			),
+			requestArgs:    map[string]any{"commentId": "DC_3"},
+			expectToolErr:  true,
+			expectedErrMsg: "failed to mark discussion answer",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			deps := BaseDeps{
+				GQLClient: githubv4.NewClient(tc.mockedClient),
+			}
+			handler := serverTool.Handler(deps)
+			request := createMCPRequest(tc.requestArgs)
+
+			result, err := handler(ContextWithDeps(context.Background(), deps), &request)
+			require.NoError(t, err)
+
+			if tc.expectToolErr {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			var response struct {
+				IsAnswered bool   `json:"isAnswered"`
+				URL        string `json:"url"`
+			}
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &response))
+			assert.Equal(t, tc.expectedAnswered, response.IsAnswered)
+			assert.Equal(t, "https://github.com/owner/repo/discussions/7", response.URL)
+		})
+	}
+}
+
+func Test_ConvertDiscussionToIssue(t *testing.T) {
+	// Verify tool definition once
+	serverTool := ConvertDiscussionToIssue(translations.NullTranslationHelper)
+	tool := serverTool.Tool
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "convert_discussion_to_issue", tool.Name)
+	assert.False(t, tool.Annotations.ReadOnlyHint)
+	schema := tool.InputSchema.(*jsonschema.Schema)
+	assert.Contains(t, schema.Properties, "mentionParticipants")
+	assert.Contains(t, schema.Properties, "closeDiscussion")
+	assert.ElementsMatch(t, schema.Required, []string{"owner", "repo", "discussionNumber"})
+
+	type discussionAuthor struct {
+		Typename githubv4.String `graphql:"__typename"`
+		Login    githubv4.String
+	}
+	discussionQuery := struct {
+		Repository struct {
+			ID         githubv4.ID
+			Discussion struct {
+				ID       githubv4.ID
+				Title    githubv4.String
+				Body     githubv4.String
+				URL      githubv4.String `graphql:"url"`
+				Author   discussionAuthor
+				Comments struct {
+					Nodes []struct {
+						Author discussionAuthor
+					}
+				} `graphql:"comments(first: 100)"`
+			} `graphql:"discussion(number: $discussionNumber)"`
+		} `graphql:"repository(owner: $owner, name: $repo)"`
+	}{}
+	discussionQueryVars := map[string]any{
+		"owner":            githubv4.String("owner"),
+		"repo":             githubv4.String("repo"),
+		"discussionNumber": githubv4.Int(7),
+	}
+	author := func(typename, login string) map[string]any {
+		return map[string]any{"author": map[string]any{"__typename": typename, "login": login}}
+	}
+	discussionQueryResponse := githubv4mock.DataResponse(map[string]any{
+		"repository": map[string]any{
+			"id": "R_1",
+			"discussion": map[string]any{
+				"id":     "D_7",
+				"title":  "Crash when the config is empty",
+				"body":   "Steps to reproduce...",
+				"url":    "https://github.com/owner/repo/discussions/7",
+				"author": map[string]any{"__typename": "User", "login": "reporter"},
+				"comments": map[string]any{
+					"nodes": []map[string]any{
+						author("User", "maintainer"),
+						author("Bot", "github-actions"),
+						author("User", "reporter"),
+						author("User", "helper"),
+					},
+				},
+			},
+		},
+	})
+
+	createMutation := struct {
+		CreateIssue struct {
+			Issue struct {
+				ID     githubv4.ID
+				Number githubv4.Int
+				URL    githubv4.String `graphql:"url"`
+			}
+		} `graphql:"createIssue(input: $input)"`
+	}{}
+	createInput := func(title, body string) githubv4.CreateIssueInput {
+		issueBody := githubv4.String(body)
+		return githubv4.CreateIssueInput{RepositoryID: "R_1", Title: githubv4.String(title), Body: &issueBody}
+	}
+	createResponse := githubv4mock.DataResponse(map[string]any{
+		"createIssue": map[string]any{
+			"issue": map[string]any{
+				"id":     "I_42",
+				"number": 42,
+				"url":    "https://github.com/owner/repo/issues/42",
+			},
+		},
+	})
+
+	commentMutation := struct {
+		AddDiscussionComment struct {
+			Comment struct {
+				ID githubv4.ID
+			}
+		} `graphql:"addDiscussionComment(input: $input)"`
+	}{}
+	commentInput := githubv4.AddDiscussionCommentInput{
+		DiscussionID: "D_7",
+		Body:         "This discussion has been converted to an issue: https://github.com/owner/repo/issues/42",
+	}
+	commentResponse := githubv4mock.DataResponse(map[string]any{
+		"addDiscussionComment": map[string]any{"comment": map[string]any{"id": "DC_9"}},
+	})
+
+	resolved := githubv4.DiscussionCloseReasonResolved
+	closeMutation := struct {
+		CloseDiscussion struct {
+			Discussion struct {
+				ID githubv4.ID
+			}
+		} `graphql:"closeDiscussion(input: $input)"`
+	}{}
+	closeInput := githubv4.CloseDiscussionInput{DiscussionID: "D_7", Reason: &resolved}
+	closeResponse := githubv4mock.DataResponse(map[string]any{
+		"closeDiscussion": map[string]any{"discussion": map[string]any{"id": "D_7"}},
+	})
+
+	backReference := "Steps to reproduce...\n\n---\n_Converted from discussion #7: https://github.com/owner/repo/discussions/7_"
+	tests := []struct {
+		name              string
+		mockedClient      *http.Client
+		requestArgs       map[string]any
+		expectToolErr     bool
+		expectedErrMsg    string
+		expectedMentioned []string
+		expectedClosed    bool
+	}{
+		{
+			name: "converts discussion and mentions participants",
+			mockedClient: githubv4mock.NewMockedHTTPClient(
+				githubv4mock.NewQueryMatcher(discussionQuery, discussionQueryVars, discussionQueryResponse),
+				githubv4mock.NewMutationMatcher(createMutation, createInput("Crash when the config is empty", backReference+"\n\ncc @reporter @maintainer @helper"), nil, createResponse),
+				githubv4mock.NewMutationMatcher(commentMutation, commentInput, nil, commentResponse),
+				githubv4mock.NewMutationMatcher(closeMutation, closeInput, nil, closeResponse),
+			),
+			requestArgs: map[string]any{
+				"owner":            "owner",
+				"repo":             "repo",
+				"discussionNumber": float64(7),
+			},
+			expectedMentioned: []string{"reporter", "maintainer", "helper"},
+			expectedClosed:    true,
+		},
+		{
+			name: "creates linked issue without mentions",
+			mockedClient: githubv4mock.NewMockedHTTPClient(
+				githubv4mock.NewQueryMatcher(discussionQuery, discussionQueryVars, discussionQueryResponse),
+				githubv4mock.NewMutationMatcher(createMutation, createInput("Handle empty config", backReference), nil, createResponse),
+				githubv4mock.NewMutationMatcher(commentMutation, commentInput, nil, commentResponse),
+			),
+			requestArgs: map[string]any{
+				"owner":               "owner",
+				"repo":                "repo",
+				"discussionNumber":    float64(7),
+				"title":               "Handle empty config",
+				"mentionParticipants": false,
+				"closeDiscussion":     false,
+			},
+			expectedMentioned: []string{},
+			expectedClosed:    false,
+		},
+		{
+			name: "discussion not found",
+			mockedClient: githubv4mock.NewMockedHTTPClient(
+				githubv4mock.NewQueryMatcher(discussionQuery, discussionQueryVars, githubv4mock.ErrorResponse("Could not resolve to a Discussion with the number of 7.")),
+			),
+			requestArgs: map[string]any{
+				"owner":            "owner",
+				"repo":             "repo",
+				"discussionNumber": float64(7),
+			},
+			expectToolErr:  true,
+			expectedErrMsg: "failed to get discussion",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			deps := BaseDeps{
+				GQLClient: githubv4.NewClient(tc.mockedClient),
+			}
+			handler := serverTool.Handler(deps)
+			request := createMCPRequest(tc.requestArgs)
+
+			result, err := handler(ContextWithDeps(context.Background(), deps), &request)
+			require.NoError(t, err)
+
+			if tc.expectToolErr {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError, getTextResult(t, result).Text)
+			var response struct {
+				Issue struct {
+					Number int    `json:"number"`
+					URL    string `json:"url"`
+				} `json:"issue"`
+				Mentioned        []string `json:"mentioned"`
+				DiscussionClosed bool     `json:"discussionClosed"`
+			}
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &response))
+			assert.Equal(t, 42, response.Issue.Number)
+			assert.Equal(t, "https://github.com/owner/repo/issues/42", response.Issue.URL)
+			assert.Equal(t, tc.expectedMentioned, response.Mentioned)
+			assert.Equal(t, tc.expectedClosed, response.DiscussionClosed)
+		})
+	}
+}
@@ -0,0 +1,118 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"maps"
+
+	"github.com/github/github-mcp-server/pkg/http/transport"
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// DryRunArgument is the argument of write tools that asks for a dry run of the call.
+const DryRunArgument = "dry_run"
+
+// DryRunResult describes what a write tool call would have done.
+type DryRunResult struct {
+	DryRun   bool                      `json:"dry_run"`
+	Tool     string                    `json:"tool"`
+	Requests []transport.DryRunRequest `json:"requests"`
+}
+
+// DryRunMiddleware runs write tool calls as dry runs when serverDryRun is set or the call's
+// dry_run argument is true. The tool runs as usual, but the GitHub clients intercept its mutating
+// requests, whose method, endpoint and payload are returned in place of the tool's result. The
+// dry_run argument is added to the schema of every write tool listed. It must run inside
+// DeprecatedAliasMiddleware, and outside the middleware that should not see dry runs as writes:
+// AuditLogMiddleware, EventWebhookMiddleware, ConfirmDestructiveMiddleware and
+// WriteQuotaMiddleware.
+func DryRunMiddleware(serverDryRun bool, isWriteTool func(name string) bool) mcp.Middleware {
+	return func(next mcp.MethodHandler) mcp.MethodHandler {
+		return func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+			switch method {
+			case "tools/list":
+				result, err := next(ctx, method, req)
+				listResult, ok := result.(*mcp.ListToolsResult)
+				if err != nil || !ok || listResult == nil {
+					return result, err
+				}
+				withDryRun := *listResult
+				withDryRun.Tools = make([]*mcp.Tool, len(listResult.Tools))
+				for i, tool := range listResult.Tools {
+					withDryRun.Tools[i] = tool
+					schema, ok := tool.InputSchema.(*jsonschema.Schema)
+					if !ok || schema == nil || !isWriteTool(tool.Name) {
+						continue
+					}
+					// Copy the tool and its schema, as the result shares the server's registered tools
+					schemaCopy := *schema
+					schemaCopy.Properties = maps.Clone(schema.Properties)
+					if schemaCopy.Properties == nil {
+						schemaCopy.Properties = map[string]*jsonschema.Schema{}
+					}
+					schemaCopy.Properties[DryRunArgument] = &jsonschema.Schema{
+						Type:        "boolean",
+						Description: "Describe the GitHub API requests this call would make, without making them",
+					}
+					toolCopy := *tool
+					toolCopy.InputSchema = &schemaCopy
+					withDryRun.Tools[i] = &toolCopy
+				}
+				return &withDryRun, nil
+			case "tools/call":
+				callReq, ok := req.(*mcp.CallToolRequest)
+				if !ok || callReq.Params == nil || !isWriteTool(callReq.Params.Name) {
+					return next(ctx, method, req)
+				}
+				dryRun, stripped := stripDryRunArgument(callReq)
+				if !serverDryRun && !dryRun {
+					return next(ctx, method, stripped)
+				}
+
+				recorder := &transport.DryRunRecorder{}
+				result, err := next(transport.ContextWithDryRun(ctx, recorder), method, stripped)
+				requests := recorder.Requests()
+				// Calls failing before their first write, such as on invalid arguments, fail as usual
+				if err != nil || (len(requests) == 0 && isToolError(result)) {
+					return result, err
+				}
+				if requests == nil {
+					requests = []transport.DryRunRequest{}
+				}
+				return MarshalledTextResult(DryRunResult{
+					DryRun:   true,
+					Tool:     callReq.Params.Name,
+					Requests: requests,
+				}), nil
+			default:
+				return next(ctx, method, req)
+			}
+		}
+	}
+}
+
+// stripDryRunArgument returns whether the call asks for a dry run, and the call without the
+// dry_run argument, which tools do not know.
+func stripDryRunArgument(callReq *mcp.CallToolRequest) (bool, *mcp.CallToolRequest) {
+	var args map[string]json.RawMessage
+	if json.Unmarshal(callReq.Params.Arguments, &args) != nil {
+		return false, callReq
+	}
+	raw, ok := args[DryRunArgument]
+	if !ok {
+		return false, callReq
+	}
+	var dryRun bool
+	_ = json.Unmarshal(raw, &dryRun)
+	delete(args, DryRunArgument)
+	arguments, err := json.Marshal(args)
+	if err != nil {
+		return false, callReq
+	}
+	params := *callReq.Params
+	params.Arguments = arguments
+	stripped := *callReq
+	stripped.Params = &params
+	return dryRun, &stripped
+}
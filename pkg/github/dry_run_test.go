@@ -0,0 +1,149 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/github/github-mcp-server/pkg/http/transport"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v82/github"
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_DryRunMiddleware(t *testing.T) {
+	serverTool := AddIssueComment(translations.NullTranslationHelper)
+	registered := []*mcp.Tool{&serverTool.Tool, {Name: "get_me", InputSchema: &jsonschema.Schema{Type: "object"}}}
+	isWriteTool := func(name string) bool { return name == serverTool.Tool.Name }
+
+	var posted int
+	mockClient := MockHTTPClientWithHandlers(map[string]http.HandlerFunc{
+		PostReposIssuesCommentsByOwnerByRepoByIssueNumber: func(w http.ResponseWriter, _ *http.Request) {
+			posted++
+			mockResponse(t, http.StatusCreated, &github.IssueComment{ID: github.Ptr(int64(1))})(w, nil)
+		},
+	})
+	deps := BaseDeps{Client: github.NewClient(&http.Client{Transport: &transport.DryRunTransport{Transport: mockClient.Transport}})}
+	next := func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+		if method == "tools/list" {
+			return &mcp.ListToolsResult{Tools: registered}, nil
+		}
+		return serverTool.Handler(deps)(ctx, req.(*mcp.CallToolRequest))
+	}
+	call := func(handler mcp.MethodHandler, args map[string]any) *mcp.CallToolResult {
+		request := createMCPRequest(args)
+		request.Params.Name = serverTool.Tool.Name
+		result, err := handler(ContextWithDeps(context.Background(), deps), "tools/call", &request)
+		require.NoError(t, err)
+		return result.(*mcp.CallToolResult)
+	}
+	args := func(dryRun any) map[string]any {
+		args := map[string]any{"owner": "octo", "repo": "app", "issue_number": float64(42), "body": "Looks good"}
+		if dryRun != nil {
+			args[DryRunArgument] = dryRun
+		}
+		return args
+	}
+
+	t.Run("tools/list adds dry_run to write tools", func(t *testing.T) {
+		handler := DryRunMiddleware(false, isWriteTool)(next)
+		result, err := handler(context.Background(), "tools/list", &mcp.ListToolsRequest{})
+		require.NoError(t, err)
+		tools := result.(*mcp.ListToolsResult).Tools
+		require.Len(t, tools, 2)
+		assert.Contains(t, tools[0].InputSchema.(*jsonschema.Schema).Properties, DryRunArgument)
+		assert.NotContains(t, tools[1].InputSchema.(*jsonschema.Schema).Properties, DryRunArgument)
+		assert.NotContains(t, serverTool.Tool.InputSchema.(*jsonschema.Schema).Properties, DryRunArgument, "registered tools are unchanged")
+	})
+
+	t.Run("dry_run argument describes the requests", func(t *testing.T) {
+		handler := DryRunMiddleware(false, isWriteTool)(next)
+		result := call(handler, args(true))
+		require.False(t, result.IsError, getTextResult(t, result).Text)
+
+		var described DryRunResult
+		require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &described))
+		assert.True(t, described.DryRun)
+		assert.Equal(t, "add_issue_comment", described.Tool)
+		require.Len(t, described.Requests, 1)
+		assert.Equal(t, http.MethodPost, described.Requests[0].Method)
+		assert.Equal(t, "https://api.github.com/repos/octo/app/issues/42/comments", described.Requests[0].Endpoint)
+		assert.Equal(t, map[string]any{"body": "Looks good"}, described.Requests[0].Payload)
+		assert.Equal(t, 0, posted)
+	})
+
+	t.Run("server dry run applies to every write", func(t *testing.T) {
+		handler := DryRunMiddleware(true, isWriteTool)(next)
+		result := call(handler, args(nil))
+		assert.Contains(t, getTextResult(t, result).Text, `"dry_run":true`)
+		assert.Equal(t, 0, posted)
+	})
+
+	t.Run("invalid arguments fail as usual", func(t *testing.T) {
+		handler := DryRunMiddleware(true, isWriteTool)(next)
+		result := call(handler, map[string]any{"owner": "octo", "repo": "app"})
+		assert.Contains(t, getErrorResult(t, result).Text, "missing required parameter")
+	})
+
+	t.Run("dry_run false writes", func(t *testing.T) {
+		handler := DryRunMiddleware(false, isWriteTool)(next)
+		result := call(handler, args(false))
+		require.False(t, result.IsError, getTextResult(t, result).Text)
+		assert.NotContains(t, getTextResult(t, result).Text, "dry_run")
+		assert.Equal(t, 1, posted)
+	})
+}
+
+func Test_NewMCPServerExemptsDryRuns(t *testing.T) {
+	events := make(chan []byte, 10)
+	srv := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		var body json.RawMessage
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		events <- body
+	}))
+	t.Cleanup(srv.Close)
+
+	var prompts int
+	records := make(channelSink, 10)
+	github := &countingTransport{}
+	cs := connectTestServer(t, MCPServerConfig{
+		DryRun:             true,
+		AuditLog:           records,
+		EventWebhook:       EventWebhookConfig{URL: srv.URL},
+		ConfirmDestructive: DestructiveToolPrompts{"issue_write": ""},
+		WriteQuota:         WriteQuotaConfig{PerHour: 1},
+	}, []string{"issues"}, github, &mcp.ClientOptions{
+		ElicitationHandler: func(_ context.Context, _ *mcp.ElicitRequest) (*mcp.ElicitResult, error) {
+			prompts++
+			return &mcp.ElicitResult{Action: "decline"}, nil
+		},
+	})
+
+	// Dry runs are not confirmed and do not use up the write quota
+	for range 2 {
+		result, err := cs.CallTool(context.Background(), &mcp.CallToolParams{
+			Name:      "issue_write",
+			Arguments: map[string]any{"method": "create", "owner": "octo", "repo": "ink", "title": "hello"},
+		})
+		require.NoError(t, err)
+		require.False(t, result.IsError, getTextResult(t, result).Text)
+		assert.Contains(t, getTextResult(t, result).Text, `"dry_run":true`)
+	}
+	assert.Zero(t, prompts)
+	assert.Zero(t, github.requests.Load())
+
+	// Nor are they audited or sent to the webhook as writes
+	select {
+	case record := <-records:
+		assert.Failf(t, "unexpected audit record", "%+v", record)
+	case event := <-events:
+		assert.Failf(t, "unexpected event delivered", "%s", event)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
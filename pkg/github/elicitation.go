@@ -0,0 +1,48 @@
+package github
+
+import (
+	"context"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// clientSupportsElicitation reports whether the MCP client that sent this request can
+// answer form elicitation requests.
+func clientSupportsElicitation(req *mcp.CallToolRequest) bool {
+	if req == nil || req.Session == nil {
+		return false
+	}
+	params := req.Session.InitializeParams()
+	return params != nil && params.Capabilities != nil && params.Capabilities.Elicitation != nil
+}
+
+// confirmAction asks the user to confirm a high-impact change before it is made. Clients that
+// cannot elicit are treated as not having confirmed, so callers should check
+// clientSupportsElicitation first to tell the agent why the change was refused.
+func confirmAction(ctx context.Context, req *mcp.CallToolRequest, message string) (bool, error) {
+	if !clientSupportsElicitation(req) {
+		return false, nil
+	}
+
+	result, err := req.Session.Elicit(ctx, &mcp.ElicitParams{
+		Message: message,
+		RequestedSchema: map[string]any{
+			"type": "object",
+			"properties": map[string]any{
+				"confirm": map[string]any{
+					"type":        "boolean",
+					"description": "Confirm the change",
+				},
+			},
+			"required": []string{"confirm"},
+		},
+	})
+	if err != nil {
+		return false, err
+	}
+	if result.Action != "accept" {
+		return false, nil
+	}
+	confirmed, _ := result.Content["confirm"].(bool)
+	return confirmed, nil
+}
@@ -0,0 +1,80 @@
+package github
+
+import (
+	"context"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// connectElicitingSession returns a server session whose client answers every elicitation
+// request with the given result.
+func connectElicitingSession(t *testing.T, result *mcp.ElicitResult) *mcp.ServerSession {
+	t.Helper()
+	ctx := context.Background()
+	srv := mcp.NewServer(&mcp.Implementation{Name: "test"}, nil)
+	st, ct := mcp.NewInMemoryTransports()
+	ss, err := srv.Connect(ctx, st, nil)
+	require.NoError(t, err)
+
+	client := mcp.NewClient(&mcp.Implementation{Name: "test-client"}, &mcp.ClientOptions{
+		ElicitationHandler: func(context.Context, *mcp.ElicitRequest) (*mcp.ElicitResult, error) {
+			return result, nil
+		},
+	})
+	cs, err := client.Connect(ctx, ct, nil)
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		_ = cs.Close()
+		_ = ss.Close()
+	})
+	return ss
+}
+
+func Test_confirmAction(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("client without elicitation does not confirm", func(t *testing.T) {
+		req := createMCPRequestWithCapabilities(t, &mcp.ClientCapabilities{})
+		confirmed, err := confirmAction(ctx, &req, "Proceed?")
+		require.NoError(t, err)
+		assert.False(t, confirmed)
+	})
+
+	t.Run("nil session does not confirm", func(t *testing.T) {
+		req := createMCPRequest(nil)
+		confirmed, err := confirmAction(ctx, &req, "Proceed?")
+		require.NoError(t, err)
+		assert.False(t, confirmed)
+	})
+
+	t.Run("user accepts", func(t *testing.T) {
+		req := mcp.CallToolRequest{Session: connectElicitingSession(t, &mcp.ElicitResult{
+			Action:  "accept",
+			Content: map[string]any{"confirm": true},
+		})}
+		confirmed, err := confirmAction(ctx, &req, "Proceed?")
+		require.NoError(t, err)
+		assert.True(t, confirmed)
+	})
+
+	t.Run("user accepts without confirming", func(t *testing.T) {
+		req := mcp.CallToolRequest{Session: connectElicitingSession(t, &mcp.ElicitResult{
+			Action:  "accept",
+			Content: map[string]any{"confirm": false},
+		})}
+		confirmed, err := confirmAction(ctx, &req, "Proceed?")
+		require.NoError(t, err)
+		assert.False(t, confirmed)
+	})
+
+	t.Run("user declines", func(t *testing.T) {
+		req := mcp.CallToolRequest{Session: connectElicitingSession(t, &mcp.ElicitResult{Action: "decline"})}
+		confirmed, err := confirmAction(ctx, &req, "Proceed?")
+		require.NoError(t, err)
+		assert.False(t, confirmed)
+	})
+}
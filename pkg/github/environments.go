@@ -0,0 +1,594 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/inventory"
+	"github.com/github/github-mcp-server/pkg/scopes"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/github/github-mcp-server/pkg/utils"
+	"github.com/google/go-github/v82/github"
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// maxEnvironmentWaitTimer is the longest wait timer, in minutes, GitHub allows on an environment.
+const maxEnvironmentWaitTimer = 43200
+
+// EnvironmentProtection is a flattened view of a deployment environment's protection rules.
+type EnvironmentProtection struct {
+	Name                   string                `json:"name"`
+	HTMLURL                string                `json:"html_url,omitempty"`
+	WaitTimer              int                   `json:"wait_timer"`
+	Reviewers              []EnvironmentReviewer `json:"reviewers"`
+	PreventSelfReview      bool                  `json:"prevent_self_review"`
+	CanAdminsBypass        bool                  `json:"can_admins_bypass"`
+	DeploymentBranchPolicy *github.BranchPolicy  `json:"deployment_branch_policy,omitempty"`
+}
+
+// EnvironmentReviewer is a user or team required to approve deployments to an environment.
+type EnvironmentReviewer struct {
+	Type string `json:"type"`
+	ID   int64  `json:"id"`
+	Name string `json:"name,omitempty"`
+}
+
+// summarizeEnvironmentProtection collects the protection rules of an environment. The API only
+// reports wait timers and reviewers through protection_rules, not the top-level fields.
+func summarizeEnvironmentProtection(env *github.Environment) EnvironmentProtection {
+	protection := EnvironmentProtection{
+		Name:                   env.GetName(),
+		HTMLURL:                env.GetHTMLURL(),
+		Reviewers:              []EnvironmentReviewer{},
+		CanAdminsBypass:        env.GetCanAdminsBypass(),
+		DeploymentBranchPolicy: env.DeploymentBranchPolicy,
+	}
+	for _, rule := range env.ProtectionRules {
+		switch rule.GetType() {
+		case "wait_timer":
+			protection.WaitTimer = rule.GetWaitTimer()
+		case "required_reviewers":
+			protection.PreventSelfReview = rule.GetPreventSelfReview()
+			for _, r := range rule.Reviewers {
+				switch reviewer := r.Reviewer.(type) {
+				case *github.User:
+					protection.Reviewers = append(protection.Reviewers, EnvironmentReviewer{Type: "User", ID: reviewer.GetID(), Name: reviewer.GetLogin()})
+				case *github.Team:
+					protection.Reviewers = append(protection.Reviewers, EnvironmentReviewer{Type: "Team", ID: reviewer.GetID(), Name: reviewer.GetSlug()})
+				}
+			}
+		}
+	}
+	return protection
+}
+
+// GetEnvironmentProtection creates a tool to view the protection rules of deployment environments.
+func GetEnvironmentProtection(t translations.TranslationHelperFunc) inventory.ServerTool {
+	return NewTool(
+		ToolsetMetadataActions,
+		mcp.Tool{
+			Name:        "get_environment_protection",
+			Description: t("TOOL_GET_ENVIRONMENT_PROTECTION_DESCRIPTION", "Get the protection rules (wait timer, required reviewers, branch policy) of a deployment environment, or of every environment in the repository when no environment is given."),
+			Annotations: &mcp.ToolAnnotations{
+				Title:        t("TOOL_GET_ENVIRONMENT_PROTECTION_USER_TITLE", "Get environment protection rules"),
+				ReadOnlyHint: true,
+			},
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"owner": {
+						Type:        "string",
+						Description: DescriptionRepositoryOwner,
+					},
+					"repo": {
+						Type:        "string",
+						Description: DescriptionRepositoryName,
+					},
+					"environment": {
+						Type:        "string",
+						Description: "Name of the environment. Omit to list all environments.",
+					},
+				},
+				Required: []string{"owner", "repo"},
+			},
+		},
+		[]scopes.Scope{scopes.Repo},
+		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+			owner, err := RequiredParam[string](args, "owner")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			repo, err := RequiredParam[string](args, "repo")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			environment, err := OptionalParam[string](args, "environment")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+
+			client, err := deps.GetClient(ctx)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			if environment != "" {
+				env, resp, err := client.Repositories.GetEnvironment(ctx, owner, repo, environment)
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to get environment", resp, err), nil, nil
+				}
+				defer func() { _ = resp.Body.Close() }()
+
+				return MarshalledTextResult(summarizeEnvironmentProtection(env)), nil, nil
+			}
+
+			envs, resp, err := client.Repositories.ListEnvironments(ctx, owner, repo, &github.EnvironmentListOptions{
+				ListOptions: github.ListOptions{PerPage: 100},
+			})
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to list environments", resp, err), nil, nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			protections := make([]EnvironmentProtection, 0, len(envs.Environments))
+			for _, env := range envs.Environments {
+				protections = append(protections, summarizeEnvironmentProtection(env))
+			}
+
+			return MarshalledTextResult(map[string]any{
+				"environments": protections,
+				"totalCount":   envs.GetTotalCount(),
+			}), nil, nil
+		},
+	)
+}
+
+// UpdateEnvironmentProtection creates a tool to change the wait timer and required reviewers of
+// a deployment environment, for example to enact or lift a deploy freeze.
+func UpdateEnvironmentProtection(t translations.TranslationHelperFunc) inventory.ServerTool {
+	return NewTool(
+		ToolsetMetadataActions,
+		mcp.Tool{
+			Name: "update_environment_protection",
+			Description: t("TOOL_UPDATE_ENVIRONMENT_PROTECTION_DESCRIPTION", `Update the wait timer and required reviewers of a deployment environment, for example to enact a deploy freeze by adding reviewers or a long wait timer, and to lift it afterwards.
+Settings that are not provided keep their current values. The user is asked to confirm the change, so the client must support elicitation.`),
+			Annotations: &mcp.ToolAnnotations{
+				Title:           t("TOOL_UPDATE_ENVIRONMENT_PROTECTION_USER_TITLE", "Update environment protection rules"),
+				ReadOnlyHint:    false,
+				DestructiveHint: jsonschema.Ptr(true),
+			},
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"owner": {
+						Type:        "string",
+						Description: DescriptionRepositoryOwner,
+					},
+					"repo": {
+						Type:        "string",
+						Description: DescriptionRepositoryName,
+					},
+					"environment": {
+						Type:        "string",
+						Description: "Name of the environment",
+					},
+					"wait_timer": {
+						Type:        "number",
+						Description: "Minutes to delay jobs that reference the environment (0 to disable)",
+						Minimum:     jsonschema.Ptr(0.0),
+						Maximum:     jsonschema.Ptr(float64(maxEnvironmentWaitTimer)),
+					},
+					"reviewers": {
+						Type:        "array",
+						Description: "Users or teams required to approve deployments, replacing the current reviewers. Pass an empty array to remove all reviewers.",
+						Items: &jsonschema.Schema{
+							Type: "object",
+							Properties: map[string]*jsonschema.Schema{
+								"type": {
+									Type:        "string",
+									Description: "Reviewer type",
+									Enum:        []any{"User", "Team"},
+								},
+								"id": {
+									Type:        "number",
+									Description: "ID of the user or team",
+								},
+							},
+							Required: []string{"type", "id"},
+						},
+					},
+					"prevent_self_review": {
+						Type:        "boolean",
+						Description: "Prevent the user who triggered a deployment from approving it",
+					},
+					"can_admins_bypass": {
+						Type:        "boolean",
+						Description: "Allow repository administrators to bypass the protection rules",
+					},
+				},
+				Required: []string{"owner", "repo", "environment"},
+			},
+		},
+		[]scopes.Scope{scopes.Repo},
+		func(ctx context.Context, deps ToolDependencies, req *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+			owner, err := RequiredParam[string](args, "owner")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			repo, err := RequiredParam[string](args, "repo")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			environment, err := RequiredParam[string](args, "environment")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+
+			client, err := deps.GetClient(ctx)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			env, resp, err := client.Repositories.GetEnvironment(ctx, owner, repo, environment)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to get environment", resp, err), nil, nil
+			}
+			_ = resp.Body.Close()
+
+			current := summarizeEnvironmentProtection(env)
+			updated := current
+
+			if _, ok := args["wait_timer"]; ok {
+				waitTimer, err := OptionalIntParam(args, "wait_timer")
+				if err != nil {
+					return utils.NewToolResultError(err.Error()), nil, nil
+				}
+				if waitTimer < 0 || waitTimer > maxEnvironmentWaitTimer {
+					return utils.NewToolResultError(fmt.Sprintf("wait_timer must be between 0 and %d minutes", maxEnvironmentWaitTimer)), nil, nil
+				}
+				updated.WaitTimer = waitTimer
+			}
+			if _, ok := args["reviewers"]; ok {
+				reviewers, err := parseEnvironmentReviewers(args["reviewers"])
+				if err != nil {
+					return utils.NewToolResultError(err.Error()), nil, nil
+				}
+				updated.Reviewers = reviewers
+			}
+			if _, ok := args["prevent_self_review"]; ok {
+				updated.PreventSelfReview, err = OptionalParam[bool](args, "prevent_self_review")
+				if err != nil {
+					return utils.NewToolResultError(err.Error()), nil, nil
+				}
+			}
+			if _, ok := args["can_admins_bypass"]; ok {
+				updated.CanAdminsBypass, err = OptionalParam[bool](args, "can_admins_bypass")
+				if err != nil {
+					return utils.NewToolResultError(err.Error()), nil, nil
+				}
+			}
+
+			if !clientSupportsElicitation(req) {
+				return utils.NewToolResultError("updating environment protection rules requires the user's confirmation, but the client does not support elicitation"), nil, nil
+			}
+			confirmed, err := confirmAction(ctx, req, fmt.Sprintf("Update protection rules of environment %q in %s/%s?\n%s",
+				environment, owner, repo, describeEnvironmentProtectionChange(current, updated)))
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to confirm environment update: %w", err)
+			}
+			if !confirmed {
+				return utils.NewToolResultError("environment update was not confirmed by the user"), nil, nil
+			}
+
+			envReviewers := make([]*github.EnvReviewers, 0, len(updated.Reviewers))
+			for _, r := range updated.Reviewers {
+				envReviewers = append(envReviewers, &github.EnvReviewers{Type: github.Ptr(r.Type), ID: github.Ptr(r.ID)})
+			}
+			result, resp, err := client.Repositories.CreateUpdateEnvironment(ctx, owner, repo, environment, &github.CreateUpdateEnvironment{
+				WaitTimer:              github.Ptr(updated.WaitTimer),
+				Reviewers:              envReviewers,
+				CanAdminsBypass:        github.Ptr(updated.CanAdminsBypass),
+				DeploymentBranchPolicy: updated.DeploymentBranchPolicy,
+				PreventSelfReview:      github.Ptr(updated.PreventSelfReview),
+			})
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to update environment", resp, err), nil, nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return MarshalledTextResult(summarizeEnvironmentProtection(result)), nil, nil
+		},
+	)
+}
+
+// parseEnvironmentReviewers converts the reviewers argument into environment reviewers.
+func parseEnvironmentReviewers(v any) ([]EnvironmentReviewer, error) {
+	items, ok := v.([]any)
+	if !ok {
+		return nil, fmt.Errorf("parameter reviewers is not an array")
+	}
+	reviewers := make([]EnvironmentReviewer, 0, len(items))
+	for _, item := range items {
+		m, ok := item.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("each reviewer must be an object with type and id")
+		}
+		reviewerType, err := RequiredParam[string](m, "type")
+		if err != nil {
+			return nil, fmt.Errorf("reviewer: %w", err)
+		}
+		if reviewerType != "User" && reviewerType != "Team" {
+			return nil, fmt.Errorf("reviewer type must be User or Team, got %q", reviewerType)
+		}
+		id, err := RequiredInt(m, "id")
+		if err != nil {
+			return nil, fmt.Errorf("reviewer: %w", err)
+		}
+		reviewers = append(reviewers, EnvironmentReviewer{Type: reviewerType, ID: int64(id)})
+	}
+	return reviewers, nil
+}
+
+// describeEnvironmentProtectionChange lists the settings that differ between two protection states.
+func describeEnvironmentProtectionChange(from, to EnvironmentProtection) string {
+	var changes []string
+	if from.WaitTimer != to.WaitTimer {
+		changes = append(changes, fmt.Sprintf("wait timer: %d -> %d minutes", from.WaitTimer, to.WaitTimer))
+	}
+	if !sameEnvironmentReviewers(from.Reviewers, to.Reviewers) {
+		changes = append(changes, fmt.Sprintf("reviewers: %s -> %s", formatEnvironmentReviewers(from.Reviewers), formatEnvironmentReviewers(to.Reviewers)))
+	}
+	if from.PreventSelfReview != to.PreventSelfReview {
+		changes = append(changes, fmt.Sprintf("prevent self review: %t -> %t", from.PreventSelfReview, to.PreventSelfReview))
+	}
+	if from.CanAdminsBypass != to.CanAdminsBypass {
+		changes = append(changes, fmt.Sprintf("admins can bypass: %t -> %t", from.CanAdminsBypass, to.CanAdminsBypass))
+	}
+	if len(changes) == 0 {
+		return "no changes"
+	}
+	return strings.Join(changes, "\n")
+}
+
+func sameEnvironmentReviewers(a, b []EnvironmentReviewer) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i].Type != b[i].Type || a[i].ID != b[i].ID {
+			return false
+		}
+	}
+	return true
+}
+
+func formatEnvironmentReviewers(reviewers []EnvironmentReviewer) string {
+	if len(reviewers) == 0 {
+		return "none"
+	}
+	parts := make([]string, 0, len(reviewers))
+	for _, r := range reviewers {
+		if r.Name != "" {
+			parts = append(parts, fmt.Sprintf("%s %s", r.Type, r.Name))
+		} else {
+			parts = append(parts, fmt.Sprintf("%s %d", r.Type, r.ID))
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// DeploymentProtectionRuleApp is a GitHub App providing a custom deployment protection rule.
+type DeploymentProtectionRuleApp struct {
+	ID             int64  `json:"id"`
+	Slug           string `json:"slug"`
+	IntegrationURL string `json:"integration_url,omitempty"`
+}
+
+// DeploymentProtectionRule is a custom deployment protection rule enabled on an environment.
+type DeploymentProtectionRule struct {
+	ID      int64                       `json:"id"`
+	Enabled bool                        `json:"enabled"`
+	App     DeploymentProtectionRuleApp `json:"app"`
+}
+
+func convertToDeploymentProtectionRuleApp(app *github.CustomDeploymentProtectionRuleApp) DeploymentProtectionRuleApp {
+	return DeploymentProtectionRuleApp{
+		ID:             app.GetID(),
+		Slug:           app.GetSlug(),
+		IntegrationURL: app.GetIntegrationURL(),
+	}
+}
+
+// ListDeploymentProtectionRules creates a tool to list the custom deployment protection rules of
+// an environment, which GitHub Apps use to gate deployments on external checks.
+func ListDeploymentProtectionRules(t translations.TranslationHelperFunc) inventory.ServerTool {
+	return NewTool(
+		ToolsetMetadataActions,
+		mcp.Tool{
+			Name:        "list_deployment_protection_rules",
+			Description: t("TOOL_LIST_DEPLOYMENT_PROTECTION_RULES_DESCRIPTION", "List the custom deployment protection rules of an environment: the GitHub Apps that must approve deployments to it, and optionally the apps that could be enabled as rules."),
+			Annotations: &mcp.ToolAnnotations{
+				Title:        t("TOOL_LIST_DEPLOYMENT_PROTECTION_RULES_USER_TITLE", "List deployment protection rules"),
+				ReadOnlyHint: true,
+			},
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"owner": {
+						Type:        "string",
+						Description: DescriptionRepositoryOwner,
+					},
+					"repo": {
+						Type:        "string",
+						Description: DescriptionRepositoryName,
+					},
+					"environment": {
+						Type:        "string",
+						Description: "Name of the environment",
+					},
+					"include_available": {
+						Type:        "boolean",
+						Description: "Also list the installed GitHub Apps that could be enabled as deployment protection rules",
+						Default:     json.RawMessage(`false`),
+					},
+				},
+				Required: []string{"owner", "repo", "environment"},
+			},
+		},
+		[]scopes.Scope{scopes.Repo},
+		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+			owner, err := RequiredParam[string](args, "owner")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			repo, err := RequiredParam[string](args, "repo")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			environment, err := RequiredParam[string](args, "environment")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			includeAvailable, err := OptionalBoolParamWithDefault(args, "include_available", false)
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+
+			client, err := deps.GetClient(ctx)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			rules, resp, err := client.Repositories.GetAllDeploymentProtectionRules(ctx, owner, repo, environment)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to list deployment protection rules", resp, err), nil, nil
+			}
+			_ = resp.Body.Close()
+
+			result := map[string]any{}
+			enabled := make([]DeploymentProtectionRule, 0, len(rules.ProtectionRules))
+			for _, rule := range rules.ProtectionRules {
+				enabled = append(enabled, DeploymentProtectionRule{
+					ID:      rule.GetID(),
+					Enabled: rule.GetEnabled(),
+					App:     convertToDeploymentProtectionRuleApp(rule.GetApp()),
+				})
+			}
+			result["rules"] = enabled
+
+			if includeAvailable {
+				integrations, resp, err := client.Repositories.ListCustomDeploymentRuleIntegrations(ctx, owner, repo, environment)
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to list deployment protection rule integrations", resp, err), nil, nil
+				}
+				_ = resp.Body.Close()
+
+				available := make([]DeploymentProtectionRuleApp, 0, len(integrations.AvailableIntegrations))
+				for _, app := range integrations.AvailableIntegrations {
+					available = append(available, convertToDeploymentProtectionRuleApp(app))
+				}
+				result["available_integrations"] = available
+			}
+
+			return MarshalledTextResult(result), nil, nil
+		},
+	)
+}
+
+// ReviewDeploymentProtectionRule creates a tool to approve or reject a deployment waiting on a
+// custom deployment protection rule. Only the GitHub App providing the rule can review it.
+func ReviewDeploymentProtectionRule(t translations.TranslationHelperFunc) inventory.ServerTool {
+	return NewTool(
+		ToolsetMetadataActions,
+		mcp.Tool{
+			Name:        "review_deployment_protection_rule",
+			Description: t("TOOL_REVIEW_DEPLOYMENT_PROTECTION_RULE_DESCRIPTION", "Approve or reject a workflow run's deployment to an environment that waits on a custom deployment protection rule, acting as the rule's gatekeeper. Requires authenticating as the GitHub App installation providing the rule."),
+			Annotations: &mcp.ToolAnnotations{
+				Title:        t("TOOL_REVIEW_DEPLOYMENT_PROTECTION_RULE_USER_TITLE", "Review deployment protection rule"),
+				ReadOnlyHint: false,
+			},
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"owner": {
+						Type:        "string",
+						Description: DescriptionRepositoryOwner,
+					},
+					"repo": {
+						Type:        "string",
+						Description: DescriptionRepositoryName,
+					},
+					"run_id": {
+						Type:        "number",
+						Description: "The ID of the workflow run waiting to deploy",
+					},
+					"environment": {
+						Type:        "string",
+						Description: "Name of the environment the run deploys to",
+					},
+					"state": {
+						Type:        "string",
+						Description: "Whether to let the deployment proceed",
+						Enum:        []any{"approved", "rejected"},
+					},
+					"comment": {
+						Type:        "string",
+						Description: "Reason for the decision, shown on the workflow run",
+					},
+				},
+				Required: []string{"owner", "repo", "run_id", "environment", "state"},
+			},
+		},
+		[]scopes.Scope{scopes.Repo},
+		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+			owner, err := RequiredParam[string](args, "owner")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			repo, err := RequiredParam[string](args, "repo")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			runID, err := RequiredBigInt(args, "run_id")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			environment, err := RequiredParam[string](args, "environment")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			state, err := RequiredParam[string](args, "state")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			if state != "approved" && state != "rejected" {
+				return utils.NewToolResultError("state must be approved or rejected"), nil, nil
+			}
+			comment, err := OptionalParam[string](args, "comment")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+
+			client, err := deps.GetClient(ctx)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			resp, err := client.Actions.ReviewCustomDeploymentProtectionRule(ctx, owner, repo, runID, &github.ReviewCustomDeploymentProtectionRuleRequest{
+				EnvironmentName: environment,
+				State:           state,
+				Comment:         comment,
+			})
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to review deployment protection rule", resp, err), nil, nil
+			}
+			_ = resp.Body.Close()
+
+			return utils.NewToolResultText(fmt.Sprintf("Deployment of run %d to %s was %s", runID, environment, state)), nil, nil
+		},
+	)
+}
@@ -0,0 +1,409 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v82/github"
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mockProductionEnvironment is an environment with a wait timer and one required reviewer, in the
+// shape returned by the API.
+var mockProductionEnvironment = map[string]any{
+	"name":              "production",
+	"html_url":          "https://github.com/owner/repo/deployments/activity_log?environments_filter=production",
+	"can_admins_bypass": true,
+	"protection_rules": []map[string]any{
+		{"type": "wait_timer", "wait_timer": 30},
+		{
+			"type":                "required_reviewers",
+			"prevent_self_review": true,
+			"reviewers": []map[string]any{
+				{"type": "User", "reviewer": map[string]any{"id": 1, "login": "octocat"}},
+			},
+		},
+	},
+	"deployment_branch_policy": map[string]any{"protected_branches": true, "custom_branch_policies": false},
+}
+
+func Test_GetEnvironmentProtection(t *testing.T) {
+	// Verify tool definition once
+	serverTool := GetEnvironmentProtection(translations.NullTranslationHelper)
+	tool := serverTool.Tool
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_environment_protection", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.True(t, tool.Annotations.ReadOnlyHint)
+	schema := tool.InputSchema.(*jsonschema.Schema)
+	assert.Contains(t, schema.Properties, "environment")
+	assert.ElementsMatch(t, schema.Required, []string{"owner", "repo"})
+
+	t.Run("single environment", func(t *testing.T) {
+		deps := BaseDeps{
+			Client: github.NewClient(MockHTTPClientWithHandlers(map[string]http.HandlerFunc{
+				GetReposEnvironmentsByOwnerByRepoByEnvironmentName: mockResponse(t, http.StatusOK, mockProductionEnvironment),
+			})),
+		}
+		handler := serverTool.Handler(deps)
+		request := createMCPRequest(map[string]any{
+			"owner":       "owner",
+			"repo":        "repo",
+			"environment": "production",
+		})
+
+		result, err := handler(ContextWithDeps(context.Background(), deps), &request)
+		require.NoError(t, err)
+		require.False(t, result.IsError)
+
+		var protection EnvironmentProtection
+		require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &protection))
+		assert.Equal(t, "production", protection.Name)
+		assert.Equal(t, 30, protection.WaitTimer)
+		assert.True(t, protection.PreventSelfReview)
+		assert.True(t, protection.CanAdminsBypass)
+		assert.Equal(t, []EnvironmentReviewer{{Type: "User", ID: 1, Name: "octocat"}}, protection.Reviewers)
+	})
+
+	t.Run("all environments", func(t *testing.T) {
+		deps := BaseDeps{
+			Client: github.NewClient(MockHTTPClientWithHandlers(map[string]http.HandlerFunc{
+				GetReposEnvironmentsByOwnerByRepo: mockResponse(t, http.StatusOK, map[string]any{
+					"total_count":  2,
+					"environments": []any{mockProductionEnvironment, map[string]any{"name": "staging"}},
+				}),
+			})),
+		}
+		handler := serverTool.Handler(deps)
+		request := createMCPRequest(map[string]any{
+			"owner": "owner",
+			"repo":  "repo",
+		})
+
+		result, err := handler(ContextWithDeps(context.Background(), deps), &request)
+		require.NoError(t, err)
+		require.False(t, result.IsError)
+
+		var response struct {
+			Environments []EnvironmentProtection `json:"environments"`
+			TotalCount   int                     `json:"totalCount"`
+		}
+		require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &response))
+		assert.Equal(t, 2, response.TotalCount)
+		require.Len(t, response.Environments, 2)
+		assert.Equal(t, "staging", response.Environments[1].Name)
+		assert.Empty(t, response.Environments[1].Reviewers)
+	})
+}
+
+func Test_UpdateEnvironmentProtection(t *testing.T) {
+	// Verify tool definition once
+	serverTool := UpdateEnvironmentProtection(translations.NullTranslationHelper)
+	tool := serverTool.Tool
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "update_environment_protection", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.False(t, tool.Annotations.ReadOnlyHint)
+	schema := tool.InputSchema.(*jsonschema.Schema)
+	assert.Contains(t, schema.Properties, "wait_timer")
+	assert.Contains(t, schema.Properties, "reviewers")
+	assert.ElementsMatch(t, schema.Required, []string{"owner", "repo", "environment"})
+
+	frozenEnvironment := map[string]any{
+		"name":              "production",
+		"can_admins_bypass": true,
+		"protection_rules": []map[string]any{
+			{"type": "wait_timer", "wait_timer": 43200},
+		},
+	}
+
+	tests := []struct {
+		name           string
+		handlers       map[string]http.HandlerFunc
+		requestArgs    map[string]any
+		session        *mcp.ServerSession
+		expectToolErr  bool
+		expectedErrMsg string
+	}{
+		{
+			name: "enact freeze keeping other settings",
+			handlers: map[string]http.HandlerFunc{
+				GetReposEnvironmentsByOwnerByRepoByEnvironmentName: mockResponse(t, http.StatusOK, mockProductionEnvironment),
+				PutReposEnvironmentsByOwnerByRepoByEnvironmentName: expectRequestBody(t, map[string]any{
+					"wait_timer":               float64(43200),
+					"reviewers":                []any{map[string]any{"type": "User", "id": float64(1)}},
+					"can_admins_bypass":        true,
+					"prevent_self_review":      true,
+					"deployment_branch_policy": map[string]any{"protected_branches": true, "custom_branch_policies": false},
+				}).andThen(mockResponse(t, http.StatusOK, frozenEnvironment)),
+			},
+			requestArgs: map[string]any{
+				"owner":       "owner",
+				"repo":        "repo",
+				"environment": "production",
+				"wait_timer":  float64(43200),
+			},
+			session: connectElicitingSession(t, &mcp.ElicitResult{Action: "accept", Content: map[string]any{"confirm": true}}),
+		},
+		{
+			name: "client without elicitation",
+			handlers: map[string]http.HandlerFunc{
+				GetReposEnvironmentsByOwnerByRepoByEnvironmentName: mockResponse(t, http.StatusOK, mockProductionEnvironment),
+			},
+			requestArgs: map[string]any{
+				"owner":       "owner",
+				"repo":        "repo",
+				"environment": "production",
+				"wait_timer":  float64(43200),
+			},
+			expectToolErr:  true,
+			expectedErrMsg: "requires the user's confirmation, but the client does not support elicitation",
+		},
+		{
+			name: "replace reviewers with confirmation",
+			handlers: map[string]http.HandlerFunc{
+				GetReposEnvironmentsByOwnerByRepoByEnvironmentName: mockResponse(t, http.StatusOK, mockProductionEnvironment),
+				PutReposEnvironmentsByOwnerByRepoByEnvironmentName: expectRequestBody(t, map[string]any{
+					"wait_timer":               float64(30),
+					"reviewers":                []any{map[string]any{"type": "Team", "id": float64(7)}},
+					"can_admins_bypass":        false,
+					"prevent_self_review":      true,
+					"deployment_branch_policy": map[string]any{"protected_branches": true, "custom_branch_policies": false},
+				}).andThen(mockResponse(t, http.StatusOK, frozenEnvironment)),
+			},
+			requestArgs: map[string]any{
+				"owner":             "owner",
+				"repo":              "repo",
+				"environment":       "production",
+				"reviewers":         []any{map[string]any{"type": "Team", "id": float64(7)}},
+				"can_admins_bypass": false,
+			},
+			session: connectElicitingSession(t, &mcp.ElicitResult{Action: "accept", Content: map[string]any{"confirm": true}}),
+		},
+		{
+			name: "user declines confirmation",
+			handlers: map[string]http.HandlerFunc{
+				GetReposEnvironmentsByOwnerByRepoByEnvironmentName: mockResponse(t, http.StatusOK, mockProductionEnvironment),
+			},
+			requestArgs: map[string]any{
+				"owner":       "owner",
+				"repo":        "repo",
+				"environment": "production",
+				"wait_timer":  float64(0),
+			},
+			session:        connectElicitingSession(t, &mcp.ElicitResult{Action: "decline"}),
+			expectToolErr:  true,
+			expectedErrMsg: "environment update was not confirmed by the user",
+		},
+		{
+			name: "wait timer out of range",
+			handlers: map[string]http.HandlerFunc{
+				GetReposEnvironmentsByOwnerByRepoByEnvironmentName: mockResponse(t, http.StatusOK, mockProductionEnvironment),
+			},
+			requestArgs: map[string]any{
+				"owner":       "owner",
+				"repo":        "repo",
+				"environment": "production",
+				"wait_timer":  float64(50000),
+			},
+			expectToolErr:  true,
+			expectedErrMsg: "wait_timer must be between 0 and 43200 minutes",
+		},
+		{
+			name: "invalid reviewer type",
+			handlers: map[string]http.HandlerFunc{
+				GetReposEnvironmentsByOwnerByRepoByEnvironmentName: mockResponse(t, http.StatusOK, mockProductionEnvironment),
+			},
+			requestArgs: map[string]any{
+				"owner":       "owner",
+				"repo":        "repo",
+				"environment": "production",
+				"reviewers":   []any{map[string]any{"type": "Bot", "id": float64(7)}},
+			},
+			expectToolErr:  true,
+			expectedErrMsg: `reviewer type must be User or Team, got "Bot"`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			deps := BaseDeps{
+				Client: github.NewClient(MockHTTPClientWithHandlers(tc.handlers)),
+			}
+			handler := serverTool.Handler(deps)
+			request := createMCPRequest(tc.requestArgs)
+			request.Session = tc.session
+
+			result, err := handler(ContextWithDeps(context.Background(), deps), &request)
+			require.NoError(t, err)
+
+			if tc.expectToolErr {
+				require.True(t, result.IsError)
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			var protection EnvironmentProtection
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &protection))
+			assert.Equal(t, 43200, protection.WaitTimer)
+		})
+	}
+}
+
+func Test_DescribeEnvironmentProtectionChange(t *testing.T) {
+	from := EnvironmentProtection{WaitTimer: 30, Reviewers: []EnvironmentReviewer{{Type: "User", ID: 1, Name: "octocat"}}}
+	to := from
+	assert.Equal(t, "no changes", describeEnvironmentProtectionChange(from, to))
+
+	to.WaitTimer = 0
+	to.Reviewers = []EnvironmentReviewer{{Type: "Team", ID: 7}}
+	assert.Equal(t, "wait timer: 30 -> 0 minutes\nreviewers: User octocat -> Team 7", describeEnvironmentProtectionChange(from, to))
+}
+
+func Test_ListDeploymentProtectionRules(t *testing.T) {
+	serverTool := ListDeploymentProtectionRules(translations.NullTranslationHelper)
+	tool := serverTool.Tool
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_deployment_protection_rules", tool.Name)
+	assert.True(t, tool.Annotations.ReadOnlyHint)
+	schema := tool.InputSchema.(*jsonschema.Schema)
+	assert.ElementsMatch(t, schema.Required, []string{"owner", "repo", "environment"})
+
+	handlers := map[string]http.HandlerFunc{
+		GetReposEnvironmentsDeploymentProtectionRulesByOwnerByRepoByEnvironmentName: mockResponse(t, http.StatusOK, map[string]any{
+			"total_count": 1,
+			"custom_deployment_protection_rules": []map[string]any{
+				{"id": 3, "enabled": true, "app": map[string]any{"id": 9, "slug": "sre-gate", "integration_url": "https://api.github.com/apps/sre-gate"}},
+			},
+		}),
+		GetReposEnvironmentsDeploymentProtectionRulesAppsByOwnerByRepoByEnvironmentName: mockResponse(t, http.StatusOK, map[string]any{
+			"total_count": 1,
+			"available_custom_deployment_protection_rule_integrations": []map[string]any{
+				{"id": 10, "slug": "canary-check"},
+			},
+		}),
+	}
+
+	tests := []struct {
+		name              string
+		includeAvailable  bool
+		expectIntegration bool
+	}{
+		{name: "enabled rules only"},
+		{name: "with available integrations", includeAvailable: true, expectIntegration: true},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			deps := BaseDeps{Client: github.NewClient(MockHTTPClientWithHandlers(handlers))}
+			request := createMCPRequest(map[string]any{
+				"owner":             "owner",
+				"repo":              "repo",
+				"environment":       "production",
+				"include_available": tc.includeAvailable,
+			})
+
+			result, err := serverTool.Handler(deps)(ContextWithDeps(context.Background(), deps), &request)
+			require.NoError(t, err)
+			require.False(t, result.IsError)
+
+			var response struct {
+				Rules                 []DeploymentProtectionRule    `json:"rules"`
+				AvailableIntegrations []DeploymentProtectionRuleApp `json:"available_integrations"`
+			}
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &response))
+			assert.Equal(t, []DeploymentProtectionRule{{
+				ID:      3,
+				Enabled: true,
+				App:     DeploymentProtectionRuleApp{ID: 9, Slug: "sre-gate", IntegrationURL: "https://api.github.com/apps/sre-gate"},
+			}}, response.Rules)
+			if tc.expectIntegration {
+				assert.Equal(t, []DeploymentProtectionRuleApp{{ID: 10, Slug: "canary-check"}}, response.AvailableIntegrations)
+			} else {
+				assert.Nil(t, response.AvailableIntegrations)
+			}
+		})
+	}
+}
+
+func Test_ReviewDeploymentProtectionRule(t *testing.T) {
+	serverTool := ReviewDeploymentProtectionRule(translations.NullTranslationHelper)
+	tool := serverTool.Tool
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "review_deployment_protection_rule", tool.Name)
+	assert.False(t, tool.Annotations.ReadOnlyHint)
+	schema := tool.InputSchema.(*jsonschema.Schema)
+	assert.ElementsMatch(t, schema.Required, []string{"owner", "repo", "run_id", "environment", "state"})
+
+	tests := []struct {
+		name           string
+		handlers       map[string]http.HandlerFunc
+		state          string
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "rejects the deployment",
+			handlers: map[string]http.HandlerFunc{
+				PostReposActionsRunsDeploymentProtectionRuleByOwnerByRepoByRunID: expectRequestBody(t, map[string]any{
+					"environment_name": "production",
+					"state":            "rejected",
+					"comment":          "Error rate above threshold",
+				}).andThen(func(w http.ResponseWriter, _ *http.Request) {
+					w.WriteHeader(http.StatusNoContent)
+				}),
+			},
+			state: "rejected",
+		},
+		{
+			name:           "invalid state",
+			state:          "maybe",
+			expectError:    true,
+			expectedErrMsg: "state must be approved or rejected",
+		},
+		{
+			name: "not the app providing the rule",
+			handlers: map[string]http.HandlerFunc{
+				PostReposActionsRunsDeploymentProtectionRuleByOwnerByRepoByRunID: mockResponse(t, http.StatusForbidden, map[string]string{"message": "Resource not accessible by integration"}),
+			},
+			state:          "approved",
+			expectError:    true,
+			expectedErrMsg: "failed to review deployment protection rule",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			deps := BaseDeps{Client: github.NewClient(MockHTTPClientWithHandlers(tc.handlers))}
+			request := createMCPRequest(map[string]any{
+				"owner":       "owner",
+				"repo":        "repo",
+				"run_id":      float64(77),
+				"environment": "production",
+				"state":       tc.state,
+				"comment":     "Error rate above threshold",
+			})
+
+			result, err := serverTool.Handler(deps)(ContextWithDeps(context.Background(), deps), &request)
+			require.NoError(t, err)
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+			require.False(t, result.IsError)
+			assert.Equal(t, "Deployment of run 77 to production was rejected", getTextResult(t, result).Text)
+		})
+	}
+}
@@ -0,0 +1,189 @@
+package github
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/github/github-mcp-server/pkg/audit"
+	"github.com/github/github-mcp-server/pkg/http/transport"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// eventWebhookTimeout bounds how long the delivery of an event may take.
+const eventWebhookTimeout = 10 * time.Second
+
+// defaultEventWebhookClient delivers events when EventWebhookConfig has no HTTPClient. It is
+// shared, as the HTTP server builds an MCP server for each request.
+var defaultEventWebhookClient = &http.Client{Timeout: eventWebhookTimeout}
+
+// Tool events the event webhook can be sent.
+const (
+	// ToolEventWriteTool is sent after a write tool was called, whether or not it succeeded.
+	ToolEventWriteTool = "write_tool"
+	// ToolEventLockdownBlock is sent when lockdown mode withheld content from a tool result.
+	ToolEventLockdownBlock = "lockdown_block"
+	// ToolEventEnforcementDenial is sent when roots enforcement refused a tool call.
+	ToolEventEnforcementDenial = "enforcement_denial"
+)
+
+// ToolEvents lists the events the event webhook can be sent.
+var ToolEvents = []string{ToolEventWriteTool, ToolEventLockdownBlock, ToolEventEnforcementDenial}
+
+// EventWebhookConfig configures the webhook tool events are POSTed to, e.g. to stream agent
+// activity into a SIEM.
+type EventWebhookConfig struct {
+	// URL receives the events. Empty disables the webhook.
+	URL string
+
+	// Secret, when set, signs each delivery with an HMAC-SHA256 of its body in the
+	// X-Hub-Signature-256 header, as GitHub signs its webhooks.
+	Secret string
+
+	// Events are the events to send. Empty means all of ToolEvents.
+	Events []string
+
+	// HTTPClient delivers the events. Defaults to defaultEventWebhookClient.
+	HTTPClient *http.Client
+}
+
+// ToolEvent is the JSON body POSTed to the event webhook. The event name is also sent in the
+// X-GitHub-MCP-Event header.
+type ToolEvent struct {
+	Event     string          `json:"event"`
+	Timestamp time.Time       `json:"timestamp"`
+	SessionID string          `json:"session_id,omitempty"`
+	Client    string          `json:"client,omitempty"`
+	Tool      string          `json:"tool"`
+	Arguments json.RawMessage `json:"arguments,omitempty"`
+	IsError   bool            `json:"is_error"`
+	// Details are the ContentProvenance of lockdown_block events and the RootsViolation of
+	// enforcement_denial events.
+	Details any `json:"details,omitempty"`
+}
+
+// ParseToolEvents validates the names of tool events. An empty list selects all events.
+func ParseToolEvents(events []string) ([]string, error) {
+	var parsed []string
+	for _, event := range events {
+		event = strings.TrimSpace(event)
+		if event == "" {
+			continue
+		}
+		if !slices.Contains(ToolEvents, event) {
+			return nil, fmt.Errorf("invalid tool event %q: use %s", event, strings.Join(ToolEvents, ", "))
+		}
+		parsed = append(parsed, event)
+	}
+	return parsed, nil
+}
+
+// EventWebhookMiddleware sends the tool events selected by cfg to its webhook. Events are
+// delivered in the background, so tool calls never wait for the webhook, and failed deliveries
+// are logged but not retried. Arguments are sent redacted, as in the audit log. It must run
+// inside DeprecatedAliasMiddleware, to see the names of the tools actually called, and outside
+// RootsEnforcementMiddleware, to see its denials.
+func EventWebhookMiddleware(cfg EventWebhookConfig, logger *slog.Logger, isWriteTool func(name string) bool) mcp.Middleware {
+	if cfg.HTTPClient == nil {
+		cfg.HTTPClient = defaultEventWebhookClient
+	}
+	enabled := func(event string) bool {
+		return len(cfg.Events) == 0 || slices.Contains(cfg.Events, event)
+	}
+	return func(next mcp.MethodHandler) mcp.MethodHandler {
+		return func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+			result, err := next(ctx, method, req)
+			callReq, ok := req.(*mcp.CallToolRequest)
+			toolResult, isToolResult := result.(*mcp.CallToolResult)
+			if method != "tools/call" || !ok || callReq.Params == nil || !isToolResult || toolResult == nil {
+				return result, err
+			}
+
+			newEvent := func(event string, details any) ToolEvent {
+				e := ToolEvent{
+					Event:     event,
+					Timestamp: time.Now().UTC(),
+					SessionID: sessionID(callReq),
+					Tool:      callReq.Params.Name,
+					Arguments: audit.RedactArguments(callReq.Params.Arguments),
+					IsError:   toolResult.IsError,
+					Details:   details,
+				}
+				if callReq.Session != nil {
+					if params := callReq.Session.InitializeParams(); params != nil && params.ClientInfo != nil {
+						e.Client = params.ClientInfo.Name
+					}
+				}
+				return e
+			}
+
+			var events []ToolEvent
+			violation, denied := toolResult.Meta[RootsViolationMetaKey]
+			denied = denied && toolResult.IsError
+			if denied && enabled(ToolEventEnforcementDenial) {
+				events = append(events, newEvent(ToolEventEnforcementDenial, violation))
+			}
+			if provenance, ok := toolResult.Meta[ProvenanceMetaKey].(*ContentProvenance); ok && provenance.LockdownFiltered > 0 && enabled(ToolEventLockdownBlock) {
+				events = append(events, newEvent(ToolEventLockdownBlock, provenance))
+			}
+			// Denied calls never ran the tool, and dry runs changed nothing
+			if !denied && isWriteTool(callReq.Params.Name) && transport.DryRunFromContext(ctx) == nil && enabled(ToolEventWriteTool) {
+				events = append(events, newEvent(ToolEventWriteTool, nil))
+			}
+			for _, event := range events {
+				go func() {
+					if err := sendToolEvent(context.WithoutCancel(ctx), cfg, event); err != nil {
+						logger.Warn("failed to deliver tool event", "event", event.Event, "tool", event.Tool, "error", err)
+					}
+				}()
+			}
+			return result, err
+		}
+	}
+}
+
+// sendToolEvent POSTs an event to the event webhook.
+func sendToolEvent(ctx context.Context, cfg EventWebhookConfig, event ToolEvent) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal tool event: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, eventWebhookTimeout)
+	defer cancel()
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, cfg.URL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create tool event request: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("X-GitHub-MCP-Event", event.Event)
+	if cfg.Secret != "" {
+		httpReq.Header.Set("X-Hub-Signature-256", signEventBody(cfg.Secret, body))
+	}
+
+	resp, err := cfg.HTTPClient.Do(httpReq)
+	if err != nil {
+		return fmt.Errorf("failed to call event webhook: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("event webhook answered with status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// signEventBody returns the X-Hub-Signature-256 header value of a delivery body.
+func signEventBody(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,172 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/github/github-mcp-server/pkg/utils"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ParseToolEvents(t *testing.T) {
+	events, err := ParseToolEvents([]string{" write_tool", "", "lockdown_block"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"write_tool", "lockdown_block"}, events)
+
+	_, err = ParseToolEvents([]string{"tool_called"})
+	assert.ErrorContains(t, err, `invalid tool event "tool_called"`)
+}
+
+func Test_EventWebhookMiddleware(t *testing.T) {
+	type delivery struct {
+		event     ToolEvent
+		header    string
+		signature string
+		body      []byte
+	}
+	deliveries := make(chan delivery, 10)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		var event ToolEvent
+		_ = json.Unmarshal(body, &event)
+		deliveries <- delivery{event: event, header: r.Header.Get("X-GitHub-MCP-Event"), signature: r.Header.Get("X-Hub-Signature-256"), body: body}
+	}))
+	t.Cleanup(srv.Close)
+
+	results := map[string]*mcp.CallToolResult{
+		"create_issue": utils.NewToolResultText(`{"number": 1}`),
+		"get_issue":    utils.NewToolResultText(`{"number": 1}`),
+		"issue_read":   withProvenance(utils.NewToolResultError("access to issue details is restricted by lockdown mode"), &ContentProvenance{Lockdown: true, LockdownFiltered: 1}),
+		"merge_pr": func() *mcp.CallToolResult {
+			result := utils.NewToolResultError("octo/other is outside the roots of this session (octo/app)")
+			result.Meta = mcp.Meta{RootsViolationMetaKey: &RootsViolation{Owner: "octo", Repo: "other", Roots: []string{"octo/app"}}}
+			return result
+		}(),
+	}
+	next := func(_ context.Context, _ string, req mcp.Request) (mcp.Result, error) {
+		return results[req.(*mcp.CallToolRequest).Params.Name], nil
+	}
+	isWriteTool := func(name string) bool { return name == "create_issue" || name == "merge_pr" }
+	call := func(cfg EventWebhookConfig, name string) {
+		handler := EventWebhookMiddleware(cfg, slog.New(slog.DiscardHandler), isWriteTool)(next)
+		_, err := handler(context.Background(), "tools/call", &mcp.CallToolRequest{Params: &mcp.CallToolParamsRaw{Name: name, Arguments: json.RawMessage(`{"owner":"octo"}`)}})
+		require.NoError(t, err)
+	}
+	receive := func() delivery {
+		select {
+		case d := <-deliveries:
+			return d
+		case <-time.After(5 * time.Second):
+			require.FailNow(t, "no event delivered")
+			return delivery{}
+		}
+	}
+	assertNoDelivery := func() {
+		select {
+		case d := <-deliveries:
+			assert.Failf(t, "unexpected event delivered", "%s", d.body)
+		case <-time.After(50 * time.Millisecond):
+		}
+	}
+
+	cfg := EventWebhookConfig{URL: srv.URL, Secret: "s3cret"}
+
+	t.Run("write tool calls are sent signed", func(t *testing.T) {
+		call(cfg, "create_issue")
+		d := receive()
+		assert.Equal(t, ToolEventWriteTool, d.event.Event)
+		assert.Equal(t, ToolEventWriteTool, d.header)
+		assert.Equal(t, "create_issue", d.event.Tool)
+		assert.JSONEq(t, `{"owner":"octo"}`, string(d.event.Arguments))
+		assert.False(t, d.event.IsError)
+		assert.Equal(t, signEventBody("s3cret", d.body), d.signature)
+		assertNoDelivery()
+	})
+
+	t.Run("read tool calls are not sent", func(t *testing.T) {
+		call(cfg, "get_issue")
+		assertNoDelivery()
+	})
+
+	t.Run("lockdown blocks are sent with the provenance", func(t *testing.T) {
+		call(cfg, "issue_read")
+		d := receive()
+		assert.Equal(t, ToolEventLockdownBlock, d.event.Event)
+		assert.True(t, d.event.IsError)
+		details, err := json.Marshal(d.event.Details)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"author_associations":null,"lockdown":true,"lockdown_filtered":1,"truncated":false}`, string(details))
+	})
+
+	t.Run("enforcement denials are sent instead of the write tool call", func(t *testing.T) {
+		call(cfg, "merge_pr")
+		d := receive()
+		assert.Equal(t, ToolEventEnforcementDenial, d.event.Event)
+		assert.Equal(t, "merge_pr", d.event.Tool)
+		assertNoDelivery()
+	})
+
+	t.Run("arguments are sent redacted", func(t *testing.T) {
+		handler := EventWebhookMiddleware(cfg, slog.New(slog.DiscardHandler), isWriteTool)(next)
+		_, err := handler(context.Background(), "tools/call", &mcp.CallToolRequest{Params: &mcp.CallToolParamsRaw{
+			Name:      "create_issue",
+			Arguments: json.RawMessage(`{"owner":"octo","api_token":"s3cret-value"}`),
+		}})
+		require.NoError(t, err)
+		d := receive()
+		assert.JSONEq(t, `{"owner":"octo","api_token":"`+utils.RedactedValue+`"}`, string(d.event.Arguments))
+		assert.NotContains(t, string(d.body), "s3cret-value")
+	})
+
+	t.Run("only selected events are sent, unsigned without a secret", func(t *testing.T) {
+		selected := EventWebhookConfig{URL: srv.URL, Events: []string{ToolEventLockdownBlock}}
+		call(selected, "create_issue")
+		assertNoDelivery()
+		call(selected, "issue_read")
+		d := receive()
+		assert.Equal(t, ToolEventLockdownBlock, d.event.Event)
+		assert.Empty(t, d.signature)
+	})
+}
+
+func Test_NewMCPServerSendsEnforcementDenials(t *testing.T) {
+	events := make(chan ToolEvent, 10)
+	srv := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		var event ToolEvent
+		_ = json.NewDecoder(r.Body).Decode(&event)
+		events <- event
+	}))
+	t.Cleanup(srv.Close)
+
+	github := &countingTransport{}
+	client := mcp.NewClient(&mcp.Implementation{Name: "test-client"}, nil)
+	client.AddRoots(&mcp.Root{URI: "https://github.com/octo/ink"})
+	cs := connectTestClient(t, MCPServerConfig{
+		RootsEnforcement: RootsEnforcementBlock,
+		EventWebhook:     EventWebhookConfig{URL: srv.URL},
+	}, []string{"issues"}, testServerDeps(github), client)
+
+	result, err := cs.CallTool(context.Background(), &mcp.CallToolParams{
+		Name:      "issue_write",
+		Arguments: map[string]any{"method": "create", "owner": "evil", "repo": "x", "title": "hello"},
+	})
+	require.NoError(t, err)
+	require.True(t, result.IsError)
+	assert.Zero(t, github.requests.Load())
+
+	select {
+	case event := <-events:
+		assert.Equal(t, ToolEventEnforcementDenial, event.Event)
+		assert.Equal(t, "issue_write", event.Tool)
+	case <-time.After(5 * time.Second):
+		require.FailNow(t, "no event delivered")
+	}
+}
@@ -1,8 +1,19 @@
 package github
 
+import (
+	"context"
+	"strings"
+
+	"github.com/github/github-mcp-server/pkg/sanitize"
+)
+
 // MCPAppsFeatureFlag is the feature flag name for MCP Apps (interactive UI forms).
 const MCPAppsFeatureFlag = "remote_mcp_ui_apps"
 
+// NormalizeBodiesFeatureFlag is the feature flag name for normalizing issue, pull request and
+// discussion bodies into plain Markdown before they are returned.
+const NormalizeBodiesFeatureFlag = "normalize_bodies"
+
 // AllowedFeatureFlags is the allowlist of feature flags that can be enabled
 // by users via --features CLI flag or X-MCP-Features HTTP header.
 // Only flags in this list are accepted; unknown flags are silently ignored.
@@ -11,6 +22,16 @@ var AllowedFeatureFlags = []string{
 	MCPAppsFeatureFlag,
 	FeatureFlagIssuesGranular,
 	FeatureFlagPullRequestsGranular,
+	NormalizeBodiesFeatureFlag,
+}
+
+// FeatureFlagDescriptions describes what each flag in AllowedFeatureFlags changes, as reported
+// by the list_features tool.
+var FeatureFlagDescriptions = map[string]string{
+	MCPAppsFeatureFlag:              "Interactive MCP Apps UIs for get_me, issue_write and create_pull_request, in hosts that support them",
+	FeatureFlagIssuesGranular:       "Replaces the consolidated issue tools with single-purpose tools",
+	FeatureFlagPullRequestsGranular: "Replaces the consolidated pull request tools with single-purpose tools",
+	NormalizeBodiesFeatureFlag:      "Rewrites issue, pull request and discussion bodies into plain Markdown before they are returned",
 }
 
 // InsidersFeatureFlags is the list of feature flags that insiders mode enables.
@@ -28,8 +49,10 @@ type FeatureFlags struct {
 }
 
 // ResolveFeatureFlags computes the effective set of enabled feature flags by:
-//  1. Taking explicitly enabled features (from CLI flags or HTTP headers)
-//  2. Adding insiders-expanded features when insiders mode is active
+//  1. Taking the insiders-expanded features when insiders mode is active
+//  2. Applying the given features (from CLI flags or HTTP headers) in order: a name enables the
+//     feature, and a name with a "-" prefix (e.g. "-remote_mcp_ui_apps") disables it, so later
+//     entries override earlier ones and single insiders features can be turned off
 //  3. Validating all features against the AllowedFeatureFlags allowlist
 //
 // Returns a set (map) for O(1) lookup by the feature checker.
@@ -40,11 +63,6 @@ func ResolveFeatureFlags(enabledFeatures []string, insidersMode bool) map[string
 	}
 
 	effective := make(map[string]bool)
-	for _, f := range enabledFeatures {
-		if allowed[f] {
-			effective[f] = true
-		}
-	}
 	if insidersMode {
 		for _, f := range InsidersFeatureFlags {
 			if allowed[f] {
@@ -52,5 +70,23 @@ func ResolveFeatureFlags(enabledFeatures []string, insidersMode bool) map[string
 			}
 		}
 	}
+	for _, f := range enabledFeatures {
+		if name, ok := strings.CutPrefix(f, "-"); ok {
+			delete(effective, name)
+			continue
+		}
+		if allowed[f] {
+			effective[f] = true
+		}
+	}
 	return effective
 }
+
+// normalizeBody rewrites an issue, pull request or discussion body into plain Markdown when the
+// normalize_bodies feature flag is enabled. Relative links are resolved against htmlURL.
+func normalizeBody(ctx context.Context, deps ToolDependencies, body, htmlURL string) string {
+	if !deps.IsFeatureEnabled(ctx, NormalizeBodiesFeatureFlag) {
+		return body
+	}
+	return sanitize.NormalizeMarkdown(body, htmlURL)
+}
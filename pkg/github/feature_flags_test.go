@@ -184,6 +184,19 @@ func TestResolveFeatureFlags(t *testing.T) {
 			insidersMode:    true,
 			expectedFlags:   []string{MCPAppsFeatureFlag},
 		},
+		{
+			name:            "insiders flag disabled individually",
+			enabledFeatures: []string{"-" + MCPAppsFeatureFlag, NormalizeBodiesFeatureFlag},
+			insidersMode:    true,
+			expectedFlags:   []string{NormalizeBodiesFeatureFlag},
+			unexpectedFlags: []string{MCPAppsFeatureFlag},
+		},
+		{
+			name:            "disable wins over explicit enable",
+			enabledFeatures: []string{NormalizeBodiesFeatureFlag, "-" + NormalizeBodiesFeatureFlag},
+			insidersMode:    false,
+			unexpectedFlags: []string{NormalizeBodiesFeatureFlag},
+		},
 	}
 
 	for _, tt := range tests {
@@ -262,3 +275,11 @@ func TestHelloWorld_ConditionalBehavior_Config(t *testing.T) {
 		})
 	}
 }
+
+func TestFeatureFlagDescriptions(t *testing.T) {
+	t.Parallel()
+
+	for _, flag := range AllowedFeatureFlags {
+		assert.NotEmpty(t, FeatureFlagDescriptions[flag], "feature flag %q has no description", flag)
+	}
+}
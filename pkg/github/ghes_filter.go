@@ -0,0 +1,26 @@
+package github
+
+import (
+	"context"
+
+	"github.com/github/github-mcp-server/pkg/ghes"
+	"github.com/github/github-mcp-server/pkg/inventory"
+)
+
+// CreateGHESCapabilityFilter creates an inventory.ToolFilter that hides tools relying on REST or
+// GraphQL capabilities the GitHub Enterprise Server version does not provide. The capabilities
+// are bundled with the server, so this works on air-gapped instances whose meta endpoints are
+// restricted.
+//
+// Example usage:
+//
+//	caps, err := ghes.Load("3.16")
+//	if err != nil {
+//	    // Handle error - unsupported or malformed version
+//	}
+//	inventory := github.NewInventory(t).WithFilter(github.CreateGHESCapabilityFilter(caps)).Build()
+func CreateGHESCapabilityFilter(caps *ghes.Capabilities) inventory.ToolFilter {
+	return func(_ context.Context, tool *inventory.ServerTool) (bool, error) {
+		return caps.ToolAvailable(string(tool.Toolset.ID), tool.Tool.Name), nil
+	}
+}
@@ -0,0 +1,66 @@
+package github
+
+import (
+	"context"
+	"testing"
+
+	"github.com/github/github-mcp-server/pkg/ghes"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateGHESCapabilityFilter(t *testing.T) {
+	caps, err := ghes.Load("3.16")
+	require.NoError(t, err)
+
+	inv, err := NewInventory(translations.NullTranslationHelper).
+		WithToolsets([]string{"all"}).
+		WithFilter(CreateGHESCapabilityFilter(caps)).
+		Build()
+	require.NoError(t, err)
+
+	available := make(map[string]bool)
+	for _, tool := range inv.AvailableTools(context.Background()) {
+		available[tool.Tool.Name] = true
+	}
+	assert.True(t, available["get_file_contents"])
+	assert.True(t, available["issue_read"])
+	assert.False(t, available["assign_copilot_to_issue"])
+	assert.False(t, available["request_copilot_review"])
+	assert.False(t, available["sub_issue_write"])
+}
+
+// ghesVersionedTools maps the tools relying on REST or GraphQL features that GHES added in some
+// version to the bundled capability gating them.
+var ghesVersionedTools = map[string]string{
+	"assign_copilot_to_issue":         "copilot",
+	"request_copilot_review":          "copilot",
+	"sub_issue_write":                 "sub_issues",
+	"get_issue_hierarchy":             "sub_issues",
+	"list_issue_types":                "issue_types",
+	"issue_dependency_write":          "issue_dependencies",
+	"get_merge_queue":                 "merge_queue",
+	"merge_queue_write":               "merge_queue",
+	"check_merge_readiness":           "merge_queue",
+	"enable_pull_request_auto_merge":  "auto_merge",
+	"disable_pull_request_auto_merge": "auto_merge",
+}
+
+func TestGHESCapabilitiesCoverTools(t *testing.T) {
+	tools := make(map[string]bool)
+	for _, tool := range AllTools(translations.NullTranslationHelper) {
+		tools[tool.Tool.Name] = true
+	}
+
+	gatedBy := make(map[string][]string)
+	for _, c := range ghes.BundledCapabilities() {
+		for _, tool := range c.Tools {
+			gatedBy[tool] = append(gatedBy[tool], c.Name)
+		}
+	}
+	for tool, capability := range ghesVersionedTools {
+		assert.True(t, tools[tool], "%s is not a tool", tool)
+		assert.Contains(t, gatedBy[tool], capability, "%s is not gated on %s", tool, capability)
+	}
+}
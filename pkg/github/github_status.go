@@ -0,0 +1,239 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/utils"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+const (
+	// githubStatusPageURL is the status page agents are pointed to during an incident.
+	githubStatusPageURL = "https://www.githubstatus.com"
+	// githubStatusIncidentsURL lists the unresolved incidents of the GitHub status page.
+	githubStatusIncidentsURL = githubStatusPageURL + "/api/v2/incidents/unresolved.json"
+	// incidentServerErrorThreshold is the number of server errors within incidentServerErrorWindow
+	// after which the status page is checked for an incident.
+	incidentServerErrorThreshold = 3
+	// incidentServerErrorWindow is the sliding window over which server errors are counted.
+	incidentServerErrorWindow = time.Minute
+	// githubStatusTTL is how long the answer of the status page is cached.
+	githubStatusTTL = 5 * time.Minute
+	// githubStatusTimeout bounds how long the status page may take to answer.
+	githubStatusTimeout = 5 * time.Second
+)
+
+// githubIncident is an unresolved incident of the GitHub status page.
+type githubIncident struct {
+	Name      string `json:"name"`
+	Status    string `json:"status"`
+	Impact    string `json:"impact"`
+	Shortlink string `json:"shortlink"`
+}
+
+// githubStatusChecker counts the server errors GitHub answers with and, once they repeat, asks the
+// GitHub status page whether an incident is ongoing.
+type githubStatusChecker struct {
+	url    string
+	client *http.Client
+	now    func() time.Time
+
+	mu           sync.Mutex
+	serverErrors []time.Time
+	checkedAt    time.Time
+	incident     *githubIncident
+	// fetching is set while the status page is asked, so that it is asked once at a time
+	fetching bool
+}
+
+func newGitHubStatusChecker(statusURL string, client *http.Client) *githubStatusChecker {
+	return &githubStatusChecker{url: statusURL, client: client, now: time.Now}
+}
+
+// githubStatus is shared by all sessions, so that the server errors of every session count and
+// the status page is asked at most once per githubStatusTTL.
+var githubStatus = newGitHubStatusChecker(githubStatusIncidentsURL, &http.Client{Timeout: githubStatusTimeout})
+
+// isDotcomHost reports whether host, as given to MCPServerConfig, is github.com, the only host
+// whose outages are reported on the GitHub status page.
+func isDotcomHost(host string) bool {
+	if host == "" {
+		return true
+	}
+	u, err := url.Parse(host)
+	if err != nil {
+		return false
+	}
+	return u.Hostname() == "github.com" || strings.HasSuffix(u.Hostname(), ".github.com")
+}
+
+// githubIncidentMiddleware turns the failed tool calls caused by GitHub server errors into a
+// distinct error naming the ongoing GitHub incident, once the errors repeat and the status page
+// reports one, so that agents do not mistake an outage for a problem with their request. It must
+// run inside addGitHubAPIErrorToContext so that the errors reported by the handler can be read.
+func githubIncidentMiddleware(checker *githubStatusChecker) mcp.Middleware {
+	return func(next mcp.MethodHandler) mcp.MethodHandler {
+		return func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+			result, err := next(ctx, method, req)
+			if method != "tools/call" || err != nil {
+				return result, err
+			}
+			toolResult, ok := result.(*mcp.CallToolResult)
+			if !ok || toolResult == nil || !toolResult.IsError || !hasServerError(ctx) {
+				return result, err
+			}
+
+			incident := checker.recordServerError(ctx)
+			if incident == nil {
+				return result, err
+			}
+			return incidentResult(incident, toolResultText(toolResult)), nil
+		}
+	}
+}
+
+// hasServerError reports whether the handler reported a GitHub error with a 5xx status.
+func hasServerError(ctx context.Context) bool {
+	if apiErrors, err := ghErrors.GetGitHubAPIErrors(ctx); err == nil {
+		for _, e := range apiErrors {
+			if e.Response != nil && e.Response.Response != nil && e.Response.StatusCode >= http.StatusInternalServerError {
+				return true
+			}
+		}
+	}
+	if rawErrors, err := ghErrors.GetGitHubRawAPIErrors(ctx); err == nil {
+		for _, e := range rawErrors {
+			if e.Response != nil && e.Response.StatusCode >= http.StatusInternalServerError {
+				return true
+			}
+		}
+	}
+	if gqlErrors, err := ghErrors.GetGitHubGraphQLErrors(ctx); err == nil {
+		for _, e := range gqlErrors {
+			// The GraphQL client only reports the status of a failed request in its error message
+			if e.Err != nil && strings.Contains(e.Err.Error(), "non-200 OK status code: 5") {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// recordServerError counts a server error and returns the ongoing GitHub incident, if the server
+// errors repeated and the status page reports one. The status page is asked without holding the
+// lock, so that the errors of other calls are not held up by it: while it is being asked, they
+// get its previous answer.
+func (c *githubStatusChecker) recordServerError(ctx context.Context) *githubIncident {
+	c.mu.Lock()
+	now := c.now()
+	cutoff := now.Add(-incidentServerErrorWindow)
+	kept := c.serverErrors[:0]
+	for _, t := range c.serverErrors {
+		if t.After(cutoff) {
+			kept = append(kept, t)
+		}
+	}
+	c.serverErrors = append(kept, now)
+	repeated := len(c.serverErrors) >= incidentServerErrorThreshold
+	stale := c.checkedAt.IsZero() || now.Sub(c.checkedAt) >= githubStatusTTL
+	incident := c.incident
+	fetch := repeated && stale && !c.fetching
+	if fetch {
+		c.fetching = true
+	}
+	c.mu.Unlock()
+
+	if !repeated {
+		return nil
+	}
+	if !fetch {
+		return incident
+	}
+
+	// A status page that cannot be read is treated as reporting no incident, and is not asked
+	// again before githubStatusTTL either
+	incident, _ = c.fetchIncident(ctx)
+	c.mu.Lock()
+	c.incident = incident
+	c.checkedAt = now
+	c.fetching = false
+	c.mu.Unlock()
+	return incident
+}
+
+// fetchIncident returns the most severe unresolved incident of the status page, or nil if there is none.
+func (c *githubStatusChecker) fetchIncident(ctx context.Context) (*githubIncident, error) {
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), githubStatusTimeout)
+	defer cancel()
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodGet, c.url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create status request: %w", err)
+	}
+	resp, err := c.client.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get GitHub status: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("GitHub status page answered with status %d", resp.StatusCode)
+	}
+	var body struct {
+		Incidents []githubIncident `json:"incidents"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return nil, fmt.Errorf("failed to decode GitHub status: %w", err)
+	}
+
+	var worst *githubIncident
+	for i := range body.Incidents {
+		if worst == nil || incidentImpactRank(body.Incidents[i].Impact) > incidentImpactRank(worst.Impact) {
+			worst = &body.Incidents[i]
+		}
+	}
+	return worst, nil
+}
+
+// incidentImpactRank orders the impacts of the status page from least to most severe.
+func incidentImpactRank(impact string) int {
+	switch impact {
+	case "minor":
+		return 1
+	case "major":
+		return 2
+	case "critical":
+		return 3
+	default:
+		return 0
+	}
+}
+
+// incidentResult is the tool error returned in place of a failure caused by a GitHub incident.
+func incidentResult(incident *githubIncident, original string) *mcp.CallToolResult {
+	var b strings.Builder
+	fmt.Fprintf(&b, "GitHub is experiencing an incident: %s", incident.Name)
+	if incident.Impact != "" && incident.Impact != "none" {
+		fmt.Fprintf(&b, " (%s impact, %s)", incident.Impact, incident.Status)
+	}
+	fmt.Fprintf(&b, ". The request failed because of the incident, not because of its arguments; retry once the incident is resolved. See %s for updates.", incidentLink(incident))
+	if original != "" {
+		fmt.Fprintf(&b, "\n\nOriginal error: %s", original)
+	}
+	return utils.NewToolResultError(b.String())
+}
+
+// incidentLink returns the status page of an incident, or the GitHub status page if it has none.
+func incidentLink(incident *githubIncident) string {
+	if incident.Shortlink != "" {
+		return incident.Shortlink
+	}
+	return githubStatusPageURL
+}
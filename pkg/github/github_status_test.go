@@ -0,0 +1,204 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/google/go-github/v82/github"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_GitHubIncidentMiddleware(t *testing.T) {
+	const unresolved = `{"incidents":[
+		{"name":"Degraded performance for Issues","status":"investigating","impact":"minor","shortlink":"https://stspg.io/minor"},
+		{"name":"Incident with Git Operations and API Requests","status":"identified","impact":"major","shortlink":"https://stspg.io/major"}
+	]}`
+
+	restFailure := func(status int) mcp.MethodHandler {
+		resp := &github.Response{Response: &http.Response{StatusCode: status}}
+		return func(ctx context.Context, _ string, _ mcp.Request) (mcp.Result, error) {
+			return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to get issue", resp, errors.New(http.StatusText(status))), nil
+		}
+	}
+	graphQLFailure := func(ctx context.Context, _ string, _ mcp.Request) (mcp.Result, error) {
+		return ghErrors.NewGitHubGraphQLErrorResponse(ctx, "failed to get pull request",
+			errors.New(`non-200 OK status code: 503 Service Unavailable body: "unicorn"`)), nil
+	}
+
+	tests := []struct {
+		name            string
+		statusBody      string
+		statusCode      int
+		handler         mcp.MethodHandler
+		expectedText    string
+		expectedLookups int32
+	}{
+		{
+			name:            "repeated REST server errors during an incident",
+			statusBody:      unresolved,
+			statusCode:      http.StatusOK,
+			handler:         restFailure(http.StatusBadGateway),
+			expectedText:    "GitHub is experiencing an incident: Incident with Git Operations and API Requests (major impact, identified). The request failed because of the incident, not because of its arguments; retry once the incident is resolved. See https://stspg.io/major for updates.\n\nOriginal error: failed to get issue: Bad Gateway",
+			expectedLookups: 1,
+		},
+		{
+			name:            "repeated GraphQL server errors during an incident",
+			statusBody:      unresolved,
+			statusCode:      http.StatusOK,
+			handler:         graphQLFailure,
+			expectedText:    "GitHub is experiencing an incident: Incident with Git Operations and API Requests",
+			expectedLookups: 1,
+		},
+		{
+			name:            "repeated server errors without an incident",
+			statusBody:      `{"incidents":[]}`,
+			statusCode:      http.StatusOK,
+			handler:         restFailure(http.StatusInternalServerError),
+			expectedText:    "failed to get issue: Internal Server Error",
+			expectedLookups: 1,
+		},
+		{
+			name:            "status page unavailable",
+			statusCode:      http.StatusServiceUnavailable,
+			handler:         restFailure(http.StatusInternalServerError),
+			expectedText:    "failed to get issue: Internal Server Error",
+			expectedLookups: 1,
+		},
+		{
+			name:         "client errors do not check the status page",
+			statusBody:   unresolved,
+			statusCode:   http.StatusOK,
+			handler:      restFailure(http.StatusNotFound),
+			expectedText: "failed to get issue: Not Found",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var lookups atomic.Int32
+			status := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				lookups.Add(1)
+				w.WriteHeader(tc.statusCode)
+				_, _ = w.Write([]byte(tc.statusBody))
+			}))
+			defer status.Close()
+
+			checker := newGitHubStatusChecker(status.URL, status.Client())
+			chain := addGitHubAPIErrorToContext(githubIncidentMiddleware(checker)(tc.handler))
+			call := func() *mcp.CallToolResult {
+				result, err := chain(context.Background(), "tools/call", &mcp.CallToolRequest{Params: &mcp.CallToolParamsRaw{Name: "get_issue"}})
+				require.NoError(t, err)
+				return result.(*mcp.CallToolResult)
+			}
+
+			// The status page is only asked once the server errors repeat
+			for range incidentServerErrorThreshold - 1 {
+				assert.NotContains(t, getErrorResult(t, call()).Text, "GitHub is experiencing an incident")
+			}
+			assert.Zero(t, lookups.Load())
+
+			// and its answer is cached
+			for range 2 {
+				result := call()
+				assert.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedText)
+			}
+			assert.Equal(t, tc.expectedLookups, lookups.Load())
+		})
+	}
+}
+
+func Test_GitHubStatusCheckerWindow(t *testing.T) {
+	var lookups atomic.Int32
+	status := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		lookups.Add(1)
+		_, _ = w.Write([]byte(`{"incidents":[{"name":"Incident with Actions","status":"investigating","impact":"minor"}]}`))
+	}))
+	defer status.Close()
+
+	now := time.Date(2026, 10, 17, 9, 0, 0, 0, time.UTC)
+	checker := newGitHubStatusChecker(status.URL, status.Client())
+	checker.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	// Server errors further apart than the window do not add up
+	for range incidentServerErrorThreshold {
+		assert.Nil(t, checker.recordServerError(ctx))
+		now = now.Add(incidentServerErrorWindow)
+	}
+	assert.Zero(t, lookups.Load())
+
+	for range incidentServerErrorThreshold - 1 {
+		checker.recordServerError(ctx)
+	}
+	incident := checker.recordServerError(ctx)
+	require.NotNil(t, incident)
+	assert.Equal(t, "Incident with Actions", incident.Name)
+	assert.Equal(t, "https://www.githubstatus.com", incidentLink(incident))
+
+	// The status page is asked again once its answer expires
+	now = now.Add(githubStatusTTL)
+	for range incidentServerErrorThreshold {
+		checker.recordServerError(ctx)
+	}
+	assert.Equal(t, int32(2), lookups.Load())
+}
+
+func Test_GitHubStatusCheckerFetchesOnceAtATime(t *testing.T) {
+	var lookups atomic.Int32
+	received := make(chan struct{})
+	release := make(chan struct{})
+	status := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		lookups.Add(1)
+		close(received)
+		<-release
+		_, _ = w.Write([]byte(`{"incidents":[{"name":"Incident with Pull Requests","status":"investigating","impact":"major"}]}`))
+	}))
+	defer status.Close()
+	releaseStatus := sync.OnceFunc(func() { close(release) })
+	defer releaseStatus()
+
+	checker := newGitHubStatusChecker(status.URL, status.Client())
+	ctx := context.Background()
+	for range incidentServerErrorThreshold - 1 {
+		checker.recordServerError(ctx)
+	}
+
+	fetched := make(chan *githubIncident, 1)
+	go func() { fetched <- checker.recordServerError(ctx) }()
+	<-received
+
+	// Errors reported while the status page is asked neither wait for it nor ask it again
+	done := make(chan *githubIncident, 1)
+	go func() { done <- checker.recordServerError(ctx) }()
+	select {
+	case incident := <-done:
+		assert.Nil(t, incident)
+	case <-time.After(5 * time.Second):
+		t.Fatal("recordServerError waited for the status page")
+	}
+
+	releaseStatus()
+	incident := <-fetched
+	require.NotNil(t, incident)
+	assert.Equal(t, "Incident with Pull Requests", incident.Name)
+	assert.Equal(t, incident, checker.recordServerError(ctx))
+	assert.Equal(t, int32(1), lookups.Load())
+}
+
+func Test_IsDotcomHost(t *testing.T) {
+	assert.True(t, isDotcomHost(""))
+	assert.True(t, isDotcomHost("https://github.com"))
+	assert.True(t, isDotcomHost("https://api.github.com"))
+	assert.False(t, isDotcomHost("https://octo.ghe.com"))
+	assert.False(t, isDotcomHost("https://github.example.com"))
+}
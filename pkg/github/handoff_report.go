@@ -0,0 +1,193 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/inventory"
+	"github.com/github/github-mcp-server/pkg/lockdown"
+	"github.com/github/github-mcp-server/pkg/sanitize"
+	"github.com/github/github-mcp-server/pkg/scopes"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/github/github-mcp-server/pkg/utils"
+	"github.com/google/go-github/v82/github"
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// maxHandoffRepositories bounds the repositories a handoff report covers in one call, keeping
+// the search queries within the qualifiers GitHub accepts.
+const maxHandoffRepositories = 20
+
+// HandoffItem is an issue or pull request of a handoff report.
+type HandoffItem struct {
+	Repository string    `json:"repository"`
+	Number     int       `json:"number"`
+	Title      string    `json:"title"`
+	URL        string    `json:"url"`
+	Author     string    `json:"author,omitempty"`
+	Labels     []string  `json:"labels,omitempty"`
+	Draft      bool      `json:"draft,omitempty"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+// HandoffSection lists the open issues or pull requests of one kind, most recently updated first.
+type HandoffSection struct {
+	// Total counts every match, of which at most a hundred are listed
+	Total int           `json:"total"`
+	Items []HandoffItem `json:"items"`
+}
+
+// HandoffReport is the result of get_handoff_report.
+type HandoffReport struct {
+	User             string         `json:"user"`
+	Repositories     []string       `json:"repositories"`
+	AssignedIssues   HandoffSection `json:"assigned_issues"`
+	ReviewRequests   HandoffSection `json:"review_requests"`
+	OpenPullRequests HandoffSection `json:"open_pull_requests"`
+}
+
+// handoffSection searches the open issues or pull requests matching query in repositories,
+// leaving out those whose authors lockdown mode does not trust.
+func handoffSection(ctx context.Context, client *github.Client, cache *lockdown.RepoAccessCache, provenance *ContentProvenance, query string, repositories []string) (HandoffSection, *github.Response, error) {
+	for _, repository := range repositories {
+		query += " repo:" + repository
+	}
+	result, resp, err := client.Search.Issues(ctx, query, &github.SearchOptions{
+		Sort:        "updated",
+		Order:       "desc",
+		ListOptions: github.ListOptions{PerPage: 100},
+	})
+	if err != nil {
+		return HandoffSection{}, resp, err
+	}
+	_ = resp.Body.Close()
+
+	section := HandoffSection{Total: result.GetTotal(), Items: []HandoffItem{}}
+	for _, issue := range result.Issues {
+		_, repository, _ := strings.Cut(issue.GetRepositoryURL(), "/repos/")
+		login := issue.GetUser().GetLogin()
+		if provenance.Lockdown {
+			if cache == nil {
+				return HandoffSection{}, nil, fmt.Errorf("lockdown cache is not configured")
+			}
+			owner, repo, _ := strings.Cut(repository, "/")
+			safe, err := cache.IsSafeContent(ctx, login, owner, repo)
+			if err != nil {
+				return HandoffSection{}, nil, fmt.Errorf("failed to check lockdown mode: %w", err)
+			}
+			if !safe {
+				provenance.LockdownFiltered++
+				section.Total--
+				continue
+			}
+		}
+		provenance.addAuthorAssociation(issue.GetAuthorAssociation())
+		item := HandoffItem{
+			Repository: repository,
+			Number:     issue.GetNumber(),
+			Title:      sanitize.Sanitize(issue.GetTitle()),
+			URL:        issue.GetHTMLURL(),
+			Author:     login,
+			Draft:      issue.GetDraft(),
+			UpdatedAt:  issue.GetUpdatedAt().Time,
+		}
+		for _, label := range issue.Labels {
+			item.Labels = append(item.Labels, label.GetName())
+		}
+		section.Items = append(section.Items, item)
+	}
+	if section.Total > len(section.Items) {
+		provenance.Truncated = true
+	}
+	return section, nil, nil
+}
+
+// GetHandoffReport creates a tool that compiles the open work of a user across repositories.
+func GetHandoffReport(t translations.TranslationHelperFunc) inventory.ServerTool {
+	return NewTool(
+		ToolsetMetadataRepos,
+		mcp.Tool{
+			Name: "get_handoff_report",
+			Description: t("TOOL_GET_HANDOFF_REPORT_DESCRIPTION", fmt.Sprintf(`Compile everything a user has in flight across up to %d repositories into one report: the open issues assigned to them, the open pull requests awaiting their review, and their own open pull requests, each most recently updated first.
+Use it to hand work over before a vacation or a role change. When repositories is omitted, the repositories of the roots shared by the client are used; when username is omitted, the report covers the authenticated user.`, maxHandoffRepositories)),
+			Annotations: &mcp.ToolAnnotations{
+				Title:        t("TOOL_GET_HANDOFF_REPORT_USER_TITLE", "Get handoff report"),
+				ReadOnlyHint: true,
+			},
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"username": {
+						Type:        "string",
+						Description: "User whose work to report on (default: the authenticated user)",
+					},
+					"repositories": {
+						Type:        "array",
+						Description: fmt.Sprintf("Repositories to report on, as owner/repo (max %d). Defaults to the repositories of the client roots.", maxHandoffRepositories),
+						Items:       &jsonschema.Schema{Type: "string"},
+						MaxItems:    jsonschema.Ptr(maxHandoffRepositories),
+					},
+				},
+			},
+		},
+		[]scopes.Scope{scopes.Repo},
+		func(ctx context.Context, deps ToolDependencies, req *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+			username, err := OptionalParam[string](args, "username")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			requested, err := OptionalStringArrayParam(args, "repositories")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			repositories, err := requestedOrRootRepositories(req, requested, maxHandoffRepositories)
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+
+			client, err := deps.GetClient(ctx)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			cache, err := deps.GetRepoAccessCache(ctx)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to get repo access cache: %w", err)
+			}
+
+			if username == "" {
+				user, resp, err := client.Users.Get(ctx, "")
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to get authenticated user", resp, err), nil, nil
+				}
+				_ = resp.Body.Close()
+				username = user.GetLogin()
+			}
+
+			provenance := newContentProvenance(deps.GetFlags(ctx).LockdownMode)
+			report := HandoffReport{User: username, Repositories: repositories}
+			for _, s := range []struct {
+				section *HandoffSection
+				query   string
+				name    string
+			}{
+				{&report.AssignedIssues, "is:issue is:open assignee:" + username, "assigned issues"},
+				{&report.ReviewRequests, "is:pr is:open review-requested:" + username, "review requests"},
+				{&report.OpenPullRequests, "is:pr is:open author:" + username, "open pull requests"},
+			} {
+				section, resp, err := handoffSection(ctx, client, cache, provenance, s.query, repositories)
+				if err != nil {
+					if resp != nil {
+						return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to search "+s.name, resp, err), nil, nil
+					}
+					return utils.NewToolResultError(err.Error()), nil, nil
+				}
+				*s.section = section
+			}
+			return withProvenance(MarshalledTextResult(report), provenance), nil, nil
+		},
+	)
+}
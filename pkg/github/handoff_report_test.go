@@ -0,0 +1,111 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v82/github"
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_GetHandoffReport(t *testing.T) {
+	serverTool := GetHandoffReport(translations.NullTranslationHelper)
+	tool := serverTool.Tool
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_handoff_report", tool.Name)
+	assert.True(t, tool.Annotations.ReadOnlyHint)
+	schema := tool.InputSchema.(*jsonschema.Schema)
+	assert.Empty(t, schema.Required)
+
+	var queries []string
+	search := func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query().Get("q")
+		queries = append(queries, q)
+		assert.Equal(t, "updated", r.URL.Query().Get("sort"))
+		result := &github.IssuesSearchResult{Total: github.Ptr(0), Issues: []*github.Issue{}}
+		switch {
+		case strings.HasPrefix(q, "is:issue is:open assignee:octocat"):
+			result.Total = github.Ptr(1)
+			result.Issues = []*github.Issue{{
+				Number:        github.Ptr(42),
+				Title:         github.Ptr("Flaky ink tests"),
+				HTMLURL:       github.Ptr("https://github.com/octo/ink/issues/42"),
+				RepositoryURL: github.Ptr("https://api.github.com/repos/octo/ink"),
+				User:          &github.User{Login: github.Ptr("hubot")},
+				Labels:        []*github.Label{{Name: github.Ptr("bug")}},
+			}}
+		case strings.HasPrefix(q, "is:pr is:open author:octocat"):
+			result.Total = github.Ptr(1)
+			result.Issues = []*github.Issue{{
+				Number:        github.Ptr(7),
+				Title:         github.Ptr("Rewrite the palette"),
+				HTMLURL:       github.Ptr("https://github.com/octo/api/pull/7"),
+				RepositoryURL: github.Ptr("https://api.github.com/repos/octo/api"),
+				User:          &github.User{Login: github.Ptr("octocat")},
+				Draft:         github.Ptr(true),
+			}}
+		}
+		mockResponse(t, http.StatusOK, result)(w, r)
+	}
+
+	t.Run("reports the work of the authenticated user", func(t *testing.T) {
+		queries = nil
+		deps := BaseDeps{
+			Client: github.NewClient(MockHTTPClientWithHandlers(map[string]http.HandlerFunc{
+				GetUser:         mockResponse(t, http.StatusOK, &github.User{Login: github.Ptr("octocat")}),
+				GetSearchIssues: search,
+			})),
+			Flags: stubFeatureFlags(map[string]bool{"lockdown-mode": false}),
+		}
+		request := createMCPRequest(map[string]any{"repositories": []any{"octo/ink", "octo/api"}})
+		result, err := serverTool.Handler(deps)(ContextWithDeps(context.Background(), deps), &request)
+		require.NoError(t, err)
+		require.False(t, result.IsError, getTextResult(t, result).Text)
+
+		assert.Equal(t, []string{
+			"is:issue is:open assignee:octocat repo:octo/ink repo:octo/api",
+			"is:pr is:open review-requested:octocat repo:octo/ink repo:octo/api",
+			"is:pr is:open author:octocat repo:octo/ink repo:octo/api",
+		}, queries)
+
+		var report HandoffReport
+		require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &report))
+		assert.Equal(t, "octocat", report.User)
+		assert.Equal(t, []string{"octo/ink", "octo/api"}, report.Repositories)
+		require.Len(t, report.AssignedIssues.Items, 1)
+		assert.Equal(t, "octo/ink", report.AssignedIssues.Items[0].Repository)
+		assert.Equal(t, []string{"bug"}, report.AssignedIssues.Items[0].Labels)
+		assert.Equal(t, HandoffSection{Total: 0, Items: []HandoffItem{}}, report.ReviewRequests)
+		require.Len(t, report.OpenPullRequests.Items, 1)
+		assert.True(t, report.OpenPullRequests.Items[0].Draft)
+	})
+
+	t.Run("search fails", func(t *testing.T) {
+		deps := BaseDeps{
+			Client: github.NewClient(MockHTTPClientWithHandlers(map[string]http.HandlerFunc{
+				GetSearchIssues: mockResponse(t, http.StatusUnprocessableEntity, map[string]string{"message": "Validation Failed"}),
+			})),
+			Flags: stubFeatureFlags(map[string]bool{"lockdown-mode": false}),
+		}
+		request := createMCPRequest(map[string]any{"username": "octocat", "repositories": []any{"octo/ink"}})
+		result, err := serverTool.Handler(deps)(ContextWithDeps(context.Background(), deps), &request)
+		require.NoError(t, err)
+		assert.Contains(t, getErrorResult(t, result).Text, "failed to search assigned issues")
+	})
+
+	t.Run("invalid repository", func(t *testing.T) {
+		deps := BaseDeps{Client: github.NewClient(MockHTTPClientWithHandlers(nil))}
+		request := createMCPRequest(map[string]any{"username": "octocat", "repositories": []any{"ink"}})
+		result, err := serverTool.Handler(deps)(ContextWithDeps(context.Background(), deps), &request)
+		require.NoError(t, err)
+		assert.Contains(t, getErrorResult(t, result).Text, `invalid repository "ink"`)
+	})
+}
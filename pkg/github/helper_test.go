@@ -22,23 +22,33 @@ const (
 	// User endpoints
 	GetUser                        = "GET /user"
 	GetUserStarred                 = "GET /user/starred"
+	GetUserKeys                    = "GET /user/keys"
+	GetUserGPGKeys                 = "GET /user/gpg_keys"
 	GetUsersGistsByUsername        = "GET /users/{username}/gists"
 	GetUsersStarredByUsername      = "GET /users/{username}/starred"
 	PutUserStarredByOwnerByRepo    = "PUT /user/starred/{owner}/{repo}"
 	DeleteUserStarredByOwnerByRepo = "DELETE /user/starred/{owner}/{repo}"
+	GetRateLimitStatus             = "GET /rate_limit"
 
 	// Repository endpoints
-	GetReposByOwnerByRepo                = "GET /repos/{owner}/{repo}"
-	GetReposBranchesByOwnerByRepo        = "GET /repos/{owner}/{repo}/branches"
-	GetReposTagsByOwnerByRepo            = "GET /repos/{owner}/{repo}/tags"
-	GetReposCommitsByOwnerByRepo         = "GET /repos/{owner}/{repo}/commits"
-	GetReposCommitsByOwnerByRepoByRef    = "GET /repos/{owner}/{repo}/commits/{ref}"
-	GetReposContentsByOwnerByRepoByPath  = "GET /repos/{owner}/{repo}/contents/{path}"
-	PutReposContentsByOwnerByRepoByPath  = "PUT /repos/{owner}/{repo}/contents/{path}"
-	PostReposForksByOwnerByRepo          = "POST /repos/{owner}/{repo}/forks"
-	GetReposSubscriptionByOwnerByRepo    = "GET /repos/{owner}/{repo}/subscription"
-	PutReposSubscriptionByOwnerByRepo    = "PUT /repos/{owner}/{repo}/subscription"
-	DeleteReposSubscriptionByOwnerByRepo = "DELETE /repos/{owner}/{repo}/subscription"
+	GetReposByOwnerByRepo                      = "GET /repos/{owner}/{repo}"
+	GetReposBranchesByOwnerByRepo              = "GET /repos/{owner}/{repo}/branches"
+	GetReposBranchesByOwnerByRepoByBranch      = "GET /repos/{owner}/{repo}/branches/{branch}"
+	GetReposRulesBranchesByOwnerByRepoByBranch = "GET /repos/{owner}/{repo}/rules/branches/{branch}"
+	GetReposRulesetsByOwnerByRepo              = "GET /repos/{owner}/{repo}/rulesets"
+	PostReposRulesetsByOwnerByRepo             = "POST /repos/{owner}/{repo}/rulesets"
+	GetReposRulesetsByOwnerByRepoByRulesetID   = "GET /repos/{owner}/{repo}/rulesets/{ruleset_id}"
+	PutReposRulesetsByOwnerByRepoByRulesetID   = "PUT /repos/{owner}/{repo}/rulesets/{ruleset_id}"
+	GetReposTagsByOwnerByRepo                  = "GET /repos/{owner}/{repo}/tags"
+	GetReposCommitsByOwnerByRepo               = "GET /repos/{owner}/{repo}/commits"
+	GetReposCommitsByOwnerByRepoByRef          = "GET /repos/{owner}/{repo}/commits/{ref}"
+	GetReposContentsByOwnerByRepoByPath        = "GET /repos/{owner}/{repo}/contents/{path}"
+	PutReposContentsByOwnerByRepoByPath        = "PUT /repos/{owner}/{repo}/contents/{path}"
+	PostReposForksByOwnerByRepo                = "POST /repos/{owner}/{repo}/forks"
+	GetReposSubscriptionByOwnerByRepo          = "GET /repos/{owner}/{repo}/subscription"
+	PutReposSubscriptionByOwnerByRepo          = "PUT /repos/{owner}/{repo}/subscription"
+	DeleteReposSubscriptionByOwnerByRepo       = "DELETE /repos/{owner}/{repo}/subscription"
+	GetReposStargazersByOwnerByRepo            = "GET /repos/{owner}/{repo}/stargazers"
 
 	// Git endpoints
 	GetReposGitTreesByOwnerByRepoByTree        = "GET /repos/{owner}/{repo}/git/trees/{tree}"
@@ -52,12 +62,22 @@ const (
 	GetReposCommitsStatusByOwnerByRepoByRef    = "GET /repos/{owner}/{repo}/commits/{ref}/status"
 	GetReposCommitsStatusesByOwnerByRepoByRef  = "GET /repos/{owner}/{repo}/commits/{ref}/statuses"
 	GetReposCommitsCheckRunsByOwnerByRepoByRef = "GET /repos/{owner}/{repo}/commits/{ref}/check-runs"
+	GetReposCompareByOwnerByRepoByBasehead     = "GET /repos/{owner}/{repo}/compare/{basehead}"
+
+	// Checks endpoints
+	GetReposCheckRunsByOwnerByRepoByCheckRunID               = "GET /repos/{owner}/{repo}/check-runs/{check_run_id}"
+	GetReposCheckRunsAnnotationsByOwnerByRepoByCheckRunID    = "GET /repos/{owner}/{repo}/check-runs/{check_run_id}/annotations"
+	PostReposCheckRunsByOwnerByRepo                          = "POST /repos/{owner}/{repo}/check-runs"
+	PatchReposCheckRunsByOwnerByRepoByCheckRunID             = "PATCH /repos/{owner}/{repo}/check-runs/{check_run_id}"
+	PostReposCheckSuitesRerequestByOwnerByRepoByCheckSuiteID = "POST /repos/{owner}/{repo}/check-suites/{check_suite_id}/rerequest"
 
 	// Issues endpoints
 	GetReposIssuesByOwnerByRepoByIssueNumber                    = "GET /repos/{owner}/{repo}/issues/{issue_number}"
 	GetReposIssuesCommentsByOwnerByRepoByIssueNumber            = "GET /repos/{owner}/{repo}/issues/{issue_number}/comments"
+	GetReposIssuesByOwnerByRepo                                 = "GET /repos/{owner}/{repo}/issues"
 	PostReposIssuesByOwnerByRepo                                = "POST /repos/{owner}/{repo}/issues"
 	PostReposIssuesCommentsByOwnerByRepoByIssueNumber           = "POST /repos/{owner}/{repo}/issues/{issue_number}/comments"
+	PostReposIssuesLabelsByOwnerByRepoByIssueNumber             = "POST /repos/{owner}/{repo}/issues/{issue_number}/labels"
 	PatchReposIssuesByOwnerByRepoByIssueNumber                  = "PATCH /repos/{owner}/{repo}/issues/{issue_number}"
 	GetReposIssuesSubIssuesByOwnerByRepoByIssueNumber           = "GET /repos/{owner}/{repo}/issues/{issue_number}/sub_issues"
 	PostReposIssuesSubIssuesByOwnerByRepoByIssueNumber          = "POST /repos/{owner}/{repo}/issues/{issue_number}/sub_issues"
@@ -68,6 +88,7 @@ const (
 	GetReposPullsByOwnerByRepo                                = "GET /repos/{owner}/{repo}/pulls"
 	GetReposPullsByOwnerByRepoByPullNumber                    = "GET /repos/{owner}/{repo}/pulls/{pull_number}"
 	GetReposPullsFilesByOwnerByRepoByPullNumber               = "GET /repos/{owner}/{repo}/pulls/{pull_number}/files"
+	GetReposPullsCommitsByOwnerByRepoByPullNumber             = "GET /repos/{owner}/{repo}/pulls/{pull_number}/commits"
 	GetReposPullsReviewsByOwnerByRepoByPullNumber             = "GET /repos/{owner}/{repo}/pulls/{pull_number}/reviews"
 	PostReposPullsByOwnerByRepo                               = "POST /repos/{owner}/{repo}/pulls"
 	PatchReposPullsByOwnerByRepoByPullNumber                  = "PATCH /repos/{owner}/{repo}/pulls/{pull_number}"
@@ -75,6 +96,7 @@ const (
 	PutReposPullsUpdateBranchByOwnerByRepoByPullNumber        = "PUT /repos/{owner}/{repo}/pulls/{pull_number}/update-branch"
 	PostReposPullsRequestedReviewersByOwnerByRepoByPullNumber = "POST /repos/{owner}/{repo}/pulls/{pull_number}/requested_reviewers"
 	PostReposPullsCommentsByOwnerByRepoByPullNumber           = "POST /repos/{owner}/{repo}/pulls/{pull_number}/comments"
+	GetReposPullsCommentsByOwnerByRepoByCommentID             = "GET /repos/{owner}/{repo}/pulls/comments/{comment_id}"
 
 	// Notifications endpoints
 	GetNotifications                                 = "GET /notifications"
@@ -94,21 +116,33 @@ const (
 	PatchGistsByGistID = "PATCH /gists/{gist_id}"
 
 	// Releases endpoints
-	GetReposReleasesByOwnerByRepo          = "GET /repos/{owner}/{repo}/releases"
-	GetReposReleasesLatestByOwnerByRepo    = "GET /repos/{owner}/{repo}/releases/latest"
-	GetReposReleasesTagsByOwnerByRepoByTag = "GET /repos/{owner}/{repo}/releases/tags/{tag}"
+	GetReposReleasesByOwnerByRepo                   = "GET /repos/{owner}/{repo}/releases"
+	GetReposReleasesLatestByOwnerByRepo             = "GET /repos/{owner}/{repo}/releases/latest"
+	GetReposReleasesTagsByOwnerByRepoByTag          = "GET /repos/{owner}/{repo}/releases/tags/{tag}"
+	PostReposReleasesByOwnerByRepo                  = "POST /repos/{owner}/{repo}/releases"
+	PatchReposReleasesByOwnerByRepoByReleaseID      = "PATCH /repos/{owner}/{repo}/releases/{release_id}"
+	PostReposReleasesGenerateNotesByOwnerByRepo     = "POST /repos/{owner}/{repo}/releases/generate-notes"
+	GetReposReleasesAssetsByOwnerByRepoByReleaseID  = "GET /repos/{owner}/{repo}/releases/{release_id}/assets"
+	PostReposReleasesAssetsByOwnerByRepoByReleaseID = "POST /repos/{owner}/{repo}/releases/{release_id}/assets"
+	GetReposReleasesAssetsByOwnerByRepoByAssetID    = "GET /repos/{owner}/{repo}/releases/assets/{asset_id}"
 
 	// Code scanning endpoints
-	GetReposCodeScanningAlertsByOwnerByRepo              = "GET /repos/{owner}/{repo}/code-scanning/alerts"
-	GetReposCodeScanningAlertsByOwnerByRepoByAlertNumber = "GET /repos/{owner}/{repo}/code-scanning/alerts/{alert_number}"
+	GetReposCodeScanningAlertsByOwnerByRepo                = "GET /repos/{owner}/{repo}/code-scanning/alerts"
+	GetReposCodeScanningAlertsByOwnerByRepoByAlertNumber   = "GET /repos/{owner}/{repo}/code-scanning/alerts/{alert_number}"
+	PatchReposCodeScanningAlertsByOwnerByRepoByAlertNumber = "PATCH /repos/{owner}/{repo}/code-scanning/alerts/{alert_number}"
+	GetReposCodeScanningAnalysesByOwnerByRepo              = "GET /repos/{owner}/{repo}/code-scanning/analyses"
+	GetReposCodeScanningAnalysesByOwnerByRepoByAnalysisID  = "GET /repos/{owner}/{repo}/code-scanning/analyses/{analysis_id}"
 
 	// Secret scanning endpoints
-	GetReposSecretScanningAlertsByOwnerByRepo              = "GET /repos/{owner}/{repo}/secret-scanning/alerts"                //nolint:gosec // False positive - this is an API endpoint pattern, not a credential
-	GetReposSecretScanningAlertsByOwnerByRepoByAlertNumber = "GET /repos/{owner}/{repo}/secret-scanning/alerts/{alert_number}" //nolint:gosec // False positive - this is an API endpoint pattern, not a credential
+	GetReposSecretScanningAlertsByOwnerByRepo                = "GET /repos/{owner}/{repo}/secret-scanning/alerts"                  //nolint:gosec // False positive - this is an API endpoint pattern, not a credential
+	GetReposSecretScanningAlertsByOwnerByRepoByAlertNumber   = "GET /repos/{owner}/{repo}/secret-scanning/alerts/{alert_number}"   //nolint:gosec // False positive - this is an API endpoint pattern, not a credential
+	PatchReposSecretScanningAlertsByOwnerByRepoByAlertNumber = "PATCH /repos/{owner}/{repo}/secret-scanning/alerts/{alert_number}" //nolint:gosec // False positive - this is an API endpoint pattern, not a credential
 
 	// Dependabot endpoints
-	GetReposDependabotAlertsByOwnerByRepo              = "GET /repos/{owner}/{repo}/dependabot/alerts"
-	GetReposDependabotAlertsByOwnerByRepoByAlertNumber = "GET /repos/{owner}/{repo}/dependabot/alerts/{alert_number}"
+	GetReposDependabotAlertsByOwnerByRepo                = "GET /repos/{owner}/{repo}/dependabot/alerts"
+	GetReposDependabotAlertsByOwnerByRepoByAlertNumber   = "GET /repos/{owner}/{repo}/dependabot/alerts/{alert_number}"
+	PatchReposDependabotAlertsByOwnerByRepoByAlertNumber = "PATCH /repos/{owner}/{repo}/dependabot/alerts/{alert_number}"
+	GetReposDependencyGraphSBOMByOwnerByRepo             = "GET /repos/{owner}/{repo}/dependency-graph/sbom"
 
 	// Security advisories endpoints
 	GetAdvisories                           = "GET /advisories"
@@ -116,6 +150,20 @@ const (
 	GetReposSecurityAdvisoriesByOwnerByRepo = "GET /repos/{owner}/{repo}/security-advisories"
 	GetOrgsSecurityAdvisoriesByOrg          = "GET /orgs/{org}/security-advisories"
 
+	// Organization endpoints
+	GetOrgsByOrg   = "GET /orgs/{org}"
+	PatchOrgsByOrg = "PATCH /orgs/{org}"
+
+	// Organization credential endpoints
+	GetOrgsCredentialAuthorizationsByOrg = "GET /orgs/{org}/credential-authorizations"
+
+	// Organization ruleset endpoints
+	GetOrgsRulesetsByOrgByRulesetID = "GET /orgs/{org}/rulesets/{ruleset_id}"
+
+	// Organization repository endpoints
+	GetOrgsReposByOrg                        = "GET /orgs/{org}/repos"
+	GetReposVulnerabilityAlertsByOwnerByRepo = "GET /repos/{owner}/{repo}/vulnerability-alerts"
+
 	// Actions endpoints
 	GetReposActionsWorkflowsByOwnerByRepo                        = "GET /repos/{owner}/{repo}/actions/workflows"
 	GetReposActionsWorkflowsByOwnerByRepoByWorkflowID            = "GET /repos/{owner}/{repo}/actions/workflows/{workflow_id}"
@@ -132,6 +180,18 @@ const (
 	PostReposActionsRunsCancelByOwnerByRepoByRunID               = "POST /repos/{owner}/{repo}/actions/runs/{run_id}/cancel"
 	GetReposActionsJobsLogsByOwnerByRepoByJobID                  = "GET /repos/{owner}/{repo}/actions/jobs/{job_id}/logs"
 	DeleteReposActionsRunsLogsByOwnerByRepoByRunID               = "DELETE /repos/{owner}/{repo}/actions/runs/{run_id}/logs"
+	GetReposActionsCachesByOwnerByRepo                           = "GET /repos/{owner}/{repo}/actions/caches"
+	DeleteReposActionsCachesByOwnerByRepo                        = "DELETE /repos/{owner}/{repo}/actions/caches"
+	DeleteReposActionsCachesByOwnerByRepoByCacheID               = "DELETE /repos/{owner}/{repo}/actions/caches/{cache_id}"
+	GetReposActionsCacheUsageByOwnerByRepo                       = "GET /repos/{owner}/{repo}/actions/cache/usage"
+
+	// Environment endpoints
+	GetReposEnvironmentsByOwnerByRepo                                               = "GET /repos/{owner}/{repo}/environments"
+	GetReposEnvironmentsByOwnerByRepoByEnvironmentName                              = "GET /repos/{owner}/{repo}/environments/{environment_name}"
+	PutReposEnvironmentsByOwnerByRepoByEnvironmentName                              = "PUT /repos/{owner}/{repo}/environments/{environment_name}"
+	GetReposEnvironmentsDeploymentProtectionRulesByOwnerByRepoByEnvironmentName     = "GET /repos/{owner}/{repo}/environments/{environment_name}/deployment_protection_rules"
+	GetReposEnvironmentsDeploymentProtectionRulesAppsByOwnerByRepoByEnvironmentName = "GET /repos/{owner}/{repo}/environments/{environment_name}/deployment_protection_rules/apps"
+	PostReposActionsRunsDeploymentProtectionRuleByOwnerByRepoByRunID                = "POST /repos/{owner}/{repo}/actions/runs/{run_id}/deployment_protection_rule"
 
 	// Search endpoints
 	GetSearchCode         = "GET /search/code"
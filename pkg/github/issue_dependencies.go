@@ -0,0 +1,238 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/inventory"
+	"github.com/github/github-mcp-server/pkg/lockdown"
+	"github.com/github/github-mcp-server/pkg/sanitize"
+	"github.com/github/github-mcp-server/pkg/scopes"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/github/github-mcp-server/pkg/utils"
+	"github.com/google/go-github/v82/github"
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// IssueDependency is an issue blocking, or blocked by, another issue.
+type IssueDependency struct {
+	Repository string `json:"repository"`
+	Number     int    `json:"number"`
+	// ID identifies the issue in issue_dependency_write calls
+	ID    int64  `json:"id"`
+	Title string `json:"title"`
+	State string `json:"state"`
+	URL   string `json:"url"`
+}
+
+// IssueDependencies lists the issues an issue is blocked by and the issues it blocks.
+type IssueDependencies struct {
+	BlockedBy []IssueDependency `json:"blocked_by"`
+	Blocking  []IssueDependency `json:"blocking"`
+}
+
+// issueRepository returns the owner and name of the repository of an issue returned by the
+// REST API, which can differ from the repository of the issue it depends on.
+func issueRepository(issue *github.Issue, defaultOwner, defaultRepo string) (string, string) {
+	_, fullName, _ := strings.Cut(issue.GetRepositoryURL(), "/repos/")
+	if owner, repo, ok := strings.Cut(fullName, "/"); ok {
+		return owner, repo
+	}
+	return defaultOwner, defaultRepo
+}
+
+// listIssueDependencies lists the issues on one side of the dependencies of an issue, relation
+// being blocked_by or blocking. Issues lockdown mode does not trust are left out and counted in
+// the provenance.
+func listIssueDependencies(ctx context.Context, client *github.Client, cache *lockdown.RepoAccessCache, provenance *ContentProvenance, owner, repo string, issueNumber int, relation string, pagination PaginationParams) ([]IssueDependency, *github.Response, error) {
+	u := fmt.Sprintf("repos/%s/%s/issues/%d/dependencies/%s?page=%d&per_page=%d", owner, repo, issueNumber, relation, pagination.Page, pagination.PerPage)
+	req, err := client.NewRequest(http.MethodGet, u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	var issues []*github.Issue
+	resp, err := client.Do(ctx, req, &issues)
+	if err != nil {
+		return nil, resp, err
+	}
+	_ = resp.Body.Close()
+
+	dependencies := make([]IssueDependency, 0, len(issues))
+	for _, issue := range issues {
+		issueOwner, issueRepo := issueRepository(issue, owner, repo)
+		if provenance.Lockdown {
+			if cache == nil {
+				return nil, nil, fmt.Errorf("lockdown cache is not configured")
+			}
+			login := issue.GetUser().GetLogin()
+			if login == "" {
+				provenance.LockdownFiltered++
+				continue
+			}
+			isSafeContent, err := cache.IsSafeContent(ctx, login, issueOwner, issueRepo)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to check lockdown mode: %w", err)
+			}
+			if !isSafeContent {
+				provenance.LockdownFiltered++
+				continue
+			}
+		}
+		provenance.addAuthorAssociation(issue.GetAuthorAssociation())
+		dependencies = append(dependencies, IssueDependency{
+			Repository: issueOwner + "/" + issueRepo,
+			Number:     issue.GetNumber(),
+			ID:         issue.GetID(),
+			Title:      sanitize.Sanitize(issue.GetTitle()),
+			State:      issue.GetState(),
+			URL:        issue.GetHTMLURL(),
+		})
+	}
+	return dependencies, resp, nil
+}
+
+// GetIssueDependencies returns the issues an issue is blocked by and the issues it blocks.
+func GetIssueDependencies(ctx context.Context, client *github.Client, deps ToolDependencies, owner, repo string, issueNumber int, pagination PaginationParams) (*mcp.CallToolResult, error) {
+	cache, err := deps.GetRepoAccessCache(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get repo access cache: %w", err)
+	}
+	provenance := newContentProvenance(deps.GetFlags(ctx).LockdownMode)
+
+	var dependencies IssueDependencies
+	for _, relation := range []string{"blocked_by", "blocking"} {
+		issues, resp, err := listIssueDependencies(ctx, client, cache, provenance, owner, repo, issueNumber, relation, pagination)
+		if err != nil {
+			if resp == nil {
+				return utils.NewToolResultError(err.Error()), nil
+			}
+			return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to list issue dependencies", resp, err), nil
+		}
+		if relation == "blocked_by" {
+			dependencies.BlockedBy = issues
+		} else {
+			dependencies.Blocking = issues
+		}
+	}
+
+	return withProvenance(MarshalledTextResult(dependencies), provenance), nil
+}
+
+// IssueDependencyWrite creates a tool to add and remove the issues an issue is blocked by.
+func IssueDependencyWrite(t translations.TranslationHelperFunc) inventory.ServerTool {
+	return NewTool(
+		ToolsetMetadataIssues,
+		mcp.Tool{
+			Name: "issue_dependency_write",
+			Description: t("TOOL_ISSUE_DEPENDENCY_WRITE_DESCRIPTION", `Mark an issue as blocked by another issue, or remove that dependency. The blocking issue can be in another repository.
+To record that issue A blocks issue B, call it on B with blocked_by set to A. Use issue_read with the get_dependencies method to list the dependencies of an issue.`),
+			Annotations: &mcp.ToolAnnotations{
+				Title:        t("TOOL_ISSUE_DEPENDENCY_WRITE_USER_TITLE", "Change issue dependency"),
+				ReadOnlyHint: false,
+			},
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"method": {
+						Type: "string",
+						Description: `The write operation to perform.
+Options are:
+- 'add' - mark the issue as blocked by the blocked_by issue.
+- 'remove' - remove the dependency of the issue on the blocked_by issue.
+`,
+						Enum: []any{"add", "remove"},
+					},
+					"owner": {
+						Type:        "string",
+						Description: "Repository owner",
+					},
+					"repo": {
+						Type:        "string",
+						Description: "Repository name",
+					},
+					"issue_number": {
+						Type:        "number",
+						Description: "The number of the blocked issue",
+					},
+					"blocked_by": {
+						Type:        "string",
+						Description: "The blocking issue: an issue number, '#123', 'owner/repo#123', or an issue URL",
+					},
+				},
+				Required: []string{"method", "owner", "repo", "issue_number", "blocked_by"},
+			},
+		},
+		[]scopes.Scope{scopes.Repo},
+		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+			method, err := RequiredParam[string](args, "method")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			owner, err := RequiredParam[string](args, "owner")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			repo, err := RequiredParam[string](args, "repo")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			issueNumber, err := RequiredInt(args, "issue_number")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			blockedBy, err := RequiredParam[string](args, "blocked_by")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			ref, err := parseIssueReference(blockedBy, owner, repo)
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			if ref.Owner == owner && ref.Repo == repo && ref.Number == issueNumber {
+				return utils.NewToolResultError("an issue cannot be blocked by itself"), nil, nil
+			}
+
+			client, err := deps.GetClient(ctx)
+			if err != nil {
+				return utils.NewToolResultErrorFromErr("failed to get GitHub client", err), nil, nil
+			}
+
+			// The dependencies API identifies the blocking issue by its ID, not its number
+			blocking, resp, err := client.Issues.Get(ctx, ref.Owner, ref.Repo, ref.Number)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to get blocking issue", resp, err), nil, nil
+			}
+			_ = resp.Body.Close()
+
+			issueRef := fmt.Sprintf("%s/%s#%d", owner, repo, issueNumber)
+			blockingRef := fmt.Sprintf("%s/%s#%d", ref.Owner, ref.Repo, ref.Number)
+			var req *http.Request
+			var message string
+			switch method {
+			case "add":
+				req, err = client.NewRequest(http.MethodPost, fmt.Sprintf("repos/%s/%s/issues/%d/dependencies/blocked_by", owner, repo, issueNumber),
+					map[string]int64{"issue_id": blocking.GetID()})
+				message = fmt.Sprintf("%s is now blocked by %s", issueRef, blockingRef)
+			case "remove":
+				req, err = client.NewRequest(http.MethodDelete, fmt.Sprintf("repos/%s/%s/issues/%d/dependencies/blocked_by/%d", owner, repo, issueNumber, blocking.GetID()), nil)
+				message = fmt.Sprintf("%s is no longer blocked by %s", issueRef, blockingRef)
+			default:
+				return utils.NewToolResultError(fmt.Sprintf("unknown method: %s", method)), nil, nil
+			}
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to create request: %w", err)
+			}
+			resp, err = client.Do(ctx, req, nil)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, fmt.Sprintf("failed to %s issue dependency", method), resp, err), nil, nil
+			}
+			_ = resp.Body.Close()
+
+			return utils.NewToolResultText(message), nil, nil
+		},
+	)
+}
@@ -0,0 +1,174 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v82/github"
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/shurcooL/githubv4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	GetReposIssuesDependenciesBlockedBy    = "GET /repos/{owner}/{repo}/issues/{issue_number}/dependencies/blocked_by"
+	GetReposIssuesDependenciesBlocking     = "GET /repos/{owner}/{repo}/issues/{issue_number}/dependencies/blocking"
+	PostReposIssuesDependenciesBlockedBy   = "POST /repos/{owner}/{repo}/issues/{issue_number}/dependencies/blocked_by"
+	DeleteReposIssuesDependenciesBlockedBy = "DELETE /repos/{owner}/{repo}/issues/{issue_number}/dependencies/blocked_by/{issue_id}"
+)
+
+func Test_IssueRead_GetDependencies(t *testing.T) {
+	serverTool := IssueRead(translations.NullTranslationHelper)
+
+	mockedClient := MockHTTPClientWithHandlers(map[string]http.HandlerFunc{
+		GetReposIssuesDependenciesBlockedBy: func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/repos/owner/repo/issues/42/dependencies/blocked_by", r.URL.Path)
+			mockResponse(t, http.StatusOK, []*github.Issue{
+				{
+					ID:                github.Ptr(int64(1007)),
+					Number:            github.Ptr(7),
+					Title:             github.Ptr("Design the schema"),
+					State:             github.Ptr("open"),
+					HTMLURL:           github.Ptr("https://github.com/owner/api/issues/7"),
+					RepositoryURL:     github.Ptr("https://api.github.com/repos/owner/api"),
+					AuthorAssociation: github.Ptr("MEMBER"),
+					User:              &github.User{Login: github.Ptr("octocat")},
+				},
+			})(w, r)
+		},
+		GetReposIssuesDependenciesBlocking: mockResponse(t, http.StatusOK, []*github.Issue{
+			{
+				ID:            github.Ptr(int64(1050)),
+				Number:        github.Ptr(50),
+				Title:         github.Ptr("Ship the release"),
+				State:         github.Ptr("open"),
+				HTMLURL:       github.Ptr("https://github.com/owner/repo/issues/50"),
+				RepositoryURL: github.Ptr("https://api.github.com/repos/owner/repo"),
+				User:          &github.User{Login: github.Ptr("octocat")},
+			},
+		}),
+	})
+	gqlClient := githubv4.NewClient(nil)
+	deps := BaseDeps{
+		Client:          github.NewClient(mockedClient),
+		GQLClient:       gqlClient,
+		RepoAccessCache: stubRepoAccessCache(gqlClient, 15*time.Minute),
+		Flags:           stubFeatureFlags(map[string]bool{"lockdown-mode": false}),
+	}
+	request := createMCPRequest(map[string]any{
+		"method":       "get_dependencies",
+		"owner":        "owner",
+		"repo":         "repo",
+		"issue_number": float64(42),
+	})
+
+	result, err := serverTool.Handler(deps)(ContextWithDeps(context.Background(), deps), &request)
+	require.NoError(t, err)
+	require.False(t, result.IsError, getTextResult(t, result).Text)
+
+	var dependencies IssueDependencies
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &dependencies))
+	assert.Equal(t, []IssueDependency{{
+		Repository: "owner/api",
+		Number:     7,
+		ID:         1007,
+		Title:      "Design the schema",
+		State:      "open",
+		URL:        "https://github.com/owner/api/issues/7",
+	}}, dependencies.BlockedBy)
+	require.Len(t, dependencies.Blocking, 1)
+	assert.Equal(t, "owner/repo", dependencies.Blocking[0].Repository)
+	assert.Equal(t, 50, dependencies.Blocking[0].Number)
+}
+
+func Test_IssueDependencyWrite(t *testing.T) {
+	serverTool := IssueDependencyWrite(translations.NullTranslationHelper)
+	tool := serverTool.Tool
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "issue_dependency_write", tool.Name)
+	assert.False(t, tool.Annotations.ReadOnlyHint)
+	schema := tool.InputSchema.(*jsonschema.Schema)
+	assert.ElementsMatch(t, schema.Required, []string{"method", "owner", "repo", "issue_number", "blocked_by"})
+
+	getBlockingIssue := func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/repos/owner/api/issues/7", r.URL.Path)
+		mockResponse(t, http.StatusOK, &github.Issue{ID: github.Ptr(int64(1007)), Number: github.Ptr(7)})(w, r)
+	}
+
+	tests := []struct {
+		name           string
+		handlers       map[string]http.HandlerFunc
+		args           map[string]any
+		expectedText   string
+		expectedErrMsg string
+	}{
+		{
+			name: "add dependency",
+			handlers: map[string]http.HandlerFunc{
+				GetReposIssuesByOwnerByRepoByIssueNumber: getBlockingIssue,
+				PostReposIssuesDependenciesBlockedBy: expectRequestBody(t, map[string]any{
+					"issue_id": float64(1007),
+				}).andThen(mockResponse(t, http.StatusCreated, &github.Issue{Number: github.Ptr(42)})),
+			},
+			args:         map[string]any{"method": "add", "owner": "owner", "repo": "repo", "issue_number": float64(42), "blocked_by": "owner/api#7"},
+			expectedText: "owner/repo#42 is now blocked by owner/api#7",
+		},
+		{
+			name: "remove dependency",
+			handlers: map[string]http.HandlerFunc{
+				GetReposIssuesByOwnerByRepoByIssueNumber: getBlockingIssue,
+				DeleteReposIssuesDependenciesBlockedBy: func(w http.ResponseWriter, r *http.Request) {
+					assert.Equal(t, "/repos/owner/repo/issues/42/dependencies/blocked_by/1007", r.URL.Path)
+					mockResponse(t, http.StatusOK, &github.Issue{Number: github.Ptr(42)})(w, r)
+				},
+			},
+			args:         map[string]any{"method": "remove", "owner": "owner", "repo": "repo", "issue_number": float64(42), "blocked_by": "https://github.com/owner/api/issues/7"},
+			expectedText: "owner/repo#42 is no longer blocked by owner/api#7",
+		},
+		{
+			name: "blocking issue not found",
+			handlers: map[string]http.HandlerFunc{
+				GetReposIssuesByOwnerByRepoByIssueNumber: mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+			},
+			args:           map[string]any{"method": "add", "owner": "owner", "repo": "repo", "issue_number": float64(42), "blocked_by": "#7"},
+			expectedErrMsg: "failed to get blocking issue",
+		},
+		{
+			name: "dependency rejected",
+			handlers: map[string]http.HandlerFunc{
+				GetReposIssuesByOwnerByRepoByIssueNumber: getBlockingIssue,
+				PostReposIssuesDependenciesBlockedBy:     mockResponse(t, http.StatusUnprocessableEntity, `{"message": "Validation Failed"}`),
+			},
+			args:           map[string]any{"method": "add", "owner": "owner", "repo": "repo", "issue_number": float64(42), "blocked_by": "owner/api#7"},
+			expectedErrMsg: "failed to add issue dependency",
+		},
+		{
+			name:           "blocked by itself",
+			handlers:       map[string]http.HandlerFunc{},
+			args:           map[string]any{"method": "add", "owner": "owner", "repo": "repo", "issue_number": float64(42), "blocked_by": "42"},
+			expectedErrMsg: "an issue cannot be blocked by itself",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			deps := BaseDeps{Client: github.NewClient(MockHTTPClientWithHandlers(tc.handlers))}
+			request := createMCPRequest(tc.args)
+			result, err := serverTool.Handler(deps)(ContextWithDeps(context.Background(), deps), &request)
+			require.NoError(t, err)
+			if tc.expectedErrMsg != "" {
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+			require.False(t, result.IsError, getTextResult(t, result).Text)
+			assert.Equal(t, tc.expectedText, getTextResult(t, result).Text)
+		})
+	}
+}
@@ -104,6 +104,7 @@ func getCloseStateReason(stateReason string) IssueClosedStateReason {
 
 // IssueFragment represents a fragment of an issue node in the GraphQL API.
 type IssueFragment struct {
+	ID         githubv4.ID
 	Number     githubv4.Int
 	Title      githubv4.String
 	Body       githubv4.String
@@ -125,6 +126,9 @@ type IssueFragment struct {
 	Comments struct {
 		TotalCount githubv4.Int
 	} `graphql:"comments"`
+	IssueType *struct {
+		Name githubv4.String
+	}
 }
 
 // Common interface for all issue query types
@@ -157,17 +161,17 @@ type ListIssuesQueryTypeWithLabels struct {
 	} `graphql:"repository(owner: $owner, name: $repo)"`
 }
 
-// ListIssuesQueryWithSince is the query structure for fetching issues without label filtering but with since filtering.
-type ListIssuesQueryWithSince struct {
+// ListIssuesQueryWithFilters is the query structure for fetching issues without label filtering but with since or issue type filtering.
+type ListIssuesQueryWithFilters struct {
 	Repository struct {
-		Issues IssueQueryFragment `graphql:"issues(first: $first, after: $after, states: $states, orderBy: {field: $orderBy, direction: $direction}, filterBy: {since: $since})"`
+		Issues IssueQueryFragment `graphql:"issues(first: $first, after: $after, states: $states, orderBy: {field: $orderBy, direction: $direction}, filterBy: {since: $since, type: $type})"`
 	} `graphql:"repository(owner: $owner, name: $repo)"`
 }
 
-// ListIssuesQueryTypeWithLabelsWithSince is the query structure for fetching issues with both label and since filtering.
-type ListIssuesQueryTypeWithLabelsWithSince struct {
+// ListIssuesQueryTypeWithLabelsWithFilters is the query structure for fetching issues with label filtering and since or issue type filtering.
+type ListIssuesQueryTypeWithLabelsWithFilters struct {
 	Repository struct {
-		Issues IssueQueryFragment `graphql:"issues(first: $first, after: $after, labels: $labels, states: $states, orderBy: {field: $orderBy, direction: $direction}, filterBy: {since: $since})"`
+		Issues IssueQueryFragment `graphql:"issues(first: $first, after: $after, labels: $labels, states: $states, orderBy: {field: $orderBy, direction: $direction}, filterBy: {since: $since, type: $type})"`
 	} `graphql:"repository(owner: $owner, name: $repo)"`
 }
 
@@ -180,27 +184,73 @@ func (q *ListIssuesQuery) GetIssueFragment() IssueQueryFragment {
 	return q.Repository.Issues
 }
 
-func (q *ListIssuesQueryWithSince) GetIssueFragment() IssueQueryFragment {
+func (q *ListIssuesQueryWithFilters) GetIssueFragment() IssueQueryFragment {
 	return q.Repository.Issues
 }
 
-func (q *ListIssuesQueryTypeWithLabelsWithSince) GetIssueFragment() IssueQueryFragment {
+func (q *ListIssuesQueryTypeWithLabelsWithFilters) GetIssueFragment() IssueQueryFragment {
 	return q.Repository.Issues
 }
 
-func getIssueQueryType(hasLabels bool, hasSince bool) any {
+func getIssueQueryType(hasLabels bool, hasFilters bool) any {
 	switch {
-	case hasLabels && hasSince:
-		return &ListIssuesQueryTypeWithLabelsWithSince{}
+	case hasLabels && hasFilters:
+		return &ListIssuesQueryTypeWithLabelsWithFilters{}
 	case hasLabels:
 		return &ListIssuesQueryTypeWithLabels{}
-	case hasSince:
-		return &ListIssuesQueryWithSince{}
+	case hasFilters:
+		return &ListIssuesQueryWithFilters{}
 	default:
 		return &ListIssuesQuery{}
 	}
 }
 
+// issueProjectStatusesQuery reads the Projects v2 items of issues, with their Status field.
+type issueProjectStatusesQuery struct {
+	Nodes []struct {
+		Issue struct {
+			ID           githubv4.ID
+			ProjectItems struct {
+				Nodes []struct {
+					Project struct {
+						Title githubv4.String
+					}
+					Status *struct {
+						SingleSelect struct {
+							Name githubv4.String
+						} `graphql:"... on ProjectV2ItemFieldSingleSelectValue"`
+					} `graphql:"status: fieldValueByName(name: \"Status\")"`
+				}
+			} `graphql:"projectItems(first: 10)"`
+		} `graphql:"... on Issue"`
+	} `graphql:"nodes(ids: $ids)"`
+}
+
+// fetchIssueProjectStatuses returns the project statuses of issues, keyed by node ID. Reading
+// project items needs the read:project scope, so it is best effort: issues get no statuses when
+// the query fails.
+func fetchIssueProjectStatuses(ctx context.Context, client *githubv4.Client, ids []githubv4.ID) map[string][]MinimalProjectStatus {
+	if len(ids) == 0 {
+		return nil
+	}
+	var query issueProjectStatusesQuery
+	if err := client.Query(ctx, &query, map[string]any{"ids": ids}); err != nil {
+		return nil
+	}
+	statuses := make(map[string][]MinimalProjectStatus, len(query.Nodes))
+	for _, node := range query.Nodes {
+		id := fmt.Sprint(node.Issue.ID)
+		for _, item := range node.Issue.ProjectItems.Nodes {
+			status := MinimalProjectStatus{Project: string(item.Project.Title)}
+			if item.Status != nil {
+				status.Status = string(item.Status.SingleSelect.Name)
+			}
+			statuses[id] = append(statuses[id], status)
+		}
+	}
+	return statuses
+}
+
 // IssueRead creates a tool to get details of a specific issue in a GitHub repository.
 func IssueRead(t translations.TranslationHelperFunc) inventory.ServerTool {
 	schema := &jsonschema.Schema{
@@ -214,8 +264,10 @@ Options are:
 2. get_comments - Get issue comments.
 3. get_sub_issues - Get sub-issues of the issue.
 4. get_labels - Get labels assigned to the issue.
+5. get_linked_pull_requests - Get pull requests that reference the issue, and whether each will close it when merged.
+6. get_dependencies - Get the issues the issue is blocked by and the issues it blocks.
 `,
-				Enum: []any{"get", "get_comments", "get_sub_issues", "get_labels"},
+				Enum: []any{"get", "get_comments", "get_sub_issues", "get_labels", "get_linked_pull_requests", "get_dependencies"},
 			},
 			"owner": {
 				Type:        "string",
@@ -293,6 +345,12 @@ Options are:
 			case "get_labels":
 				result, err := GetIssueLabels(ctx, gqlClient, owner, repo, issueNumber)
 				return result, nil, err
+			case "get_linked_pull_requests":
+				result, err := GetIssueLinkedPullRequests(ctx, gqlClient, owner, repo, issueNumber)
+				return result, nil, err
+			case "get_dependencies":
+				result, err := GetIssueDependencies(ctx, client, deps, owner, repo, issueNumber, pagination)
+				return result, nil, err
 			default:
 				return utils.NewToolResultError(fmt.Sprintf("unknown method: %s", method)), nil, nil
 			}
@@ -320,6 +378,7 @@ func GetIssue(ctx context.Context, client *github.Client, deps ToolDependencies,
 		return ghErrors.NewGitHubAPIStatusErrorResponse(ctx, "failed to get issue", resp, body), nil
 	}
 
+	provenance := newContentProvenance(flags.LockdownMode)
 	if flags.LockdownMode {
 		if cache == nil {
 			return nil, fmt.Errorf("lockdown cache is not configured")
@@ -331,10 +390,12 @@ func GetIssue(ctx context.Context, client *github.Client, deps ToolDependencies,
 				return utils.NewToolResultError(fmt.Sprintf("failed to check lockdown mode: %v", err)), nil
 			}
 			if !isSafeContent {
-				return utils.NewToolResultError("access to issue details is restricted by lockdown mode"), nil
+				provenance.LockdownFiltered = 1
+				return withProvenance(utils.NewToolResultError("access to issue details is restricted by lockdown mode"), provenance), nil
 			}
 		}
 	}
+	provenance.addAuthorAssociation(issue.GetAuthorAssociation())
 
 	// Sanitize title/body on response
 	if issue != nil {
@@ -342,13 +403,16 @@ func GetIssue(ctx context.Context, client *github.Client, deps ToolDependencies,
 			issue.Title = github.Ptr(sanitize.Sanitize(*issue.Title))
 		}
 		if issue.Body != nil {
-			issue.Body = github.Ptr(sanitize.Sanitize(*issue.Body))
+			issue.Body = github.Ptr(sanitize.Sanitize(normalizeBody(ctx, deps, *issue.Body, issue.GetHTMLURL())))
 		}
 	}
 
 	minimalIssue := convertToMinimalIssue(issue)
+	if gqlClient, err := deps.GetGQLClient(ctx); err == nil && gqlClient != nil && issue.GetNodeID() != "" {
+		minimalIssue.ProjectStatuses = fetchIssueProjectStatuses(ctx, gqlClient, []githubv4.ID{issue.GetNodeID()})[issue.GetNodeID()]
+	}
 
-	return MarshalledTextResult(minimalIssue), nil
+	return withProvenance(MarshalledTextResult(minimalIssue), provenance), nil
 }
 
 func GetIssueComments(ctx context.Context, client *github.Client, deps ToolDependencies, owner string, repo string, issueNumber int, pagination PaginationParams) (*mcp.CallToolResult, error) {
@@ -378,6 +442,8 @@ func GetIssueComments(ctx context.Context, client *github.Client, deps ToolDepen
 		}
 		return ghErrors.NewGitHubAPIStatusErrorResponse(ctx, "failed to get issue comments", resp, body), nil
 	}
+
+	provenance := newContentProvenance(flags.LockdownMode)
 	if flags.LockdownMode {
 		if cache == nil {
 			return nil, fmt.Errorf("lockdown cache is not configured")
@@ -400,15 +466,17 @@ func GetIssueComments(ctx context.Context, client *github.Client, deps ToolDepen
 				filteredComments = append(filteredComments, comment)
 			}
 		}
+		provenance.LockdownFiltered = len(comments) - len(filteredComments)
 		comments = filteredComments
 	}
 
 	minimalComments := make([]MinimalIssueComment, 0, len(comments))
 	for _, comment := range comments {
+		provenance.addAuthorAssociation(comment.GetAuthorAssociation())
 		minimalComments = append(minimalComments, convertToMinimalIssueComment(comment))
 	}
 
-	return MarshalledTextResult(minimalComments), nil
+	return withProvenance(MarshalledTextResult(minimalComments), provenance), nil
 }
 
 func GetSubIssues(ctx context.Context, client *github.Client, deps ToolDependencies, owner string, repo string, issueNumber int, pagination PaginationParams) (*mcp.CallToolResult, error) {
@@ -444,6 +512,7 @@ func GetSubIssues(ctx context.Context, client *github.Client, deps ToolDependenc
 		return ghErrors.NewGitHubAPIStatusErrorResponse(ctx, "failed to list sub-issues", resp, body), nil
 	}
 
+	provenance := newContentProvenance(featureFlags.LockdownMode)
 	if featureFlags.LockdownMode {
 		if cache == nil {
 			return nil, fmt.Errorf("lockdown cache is not configured")
@@ -466,15 +535,19 @@ func GetSubIssues(ctx context.Context, client *github.Client, deps ToolDependenc
 				filteredSubIssues = append(filteredSubIssues, subIssue)
 			}
 		}
+		provenance.LockdownFiltered = len(subIssues) - len(filteredSubIssues)
 		subIssues = filteredSubIssues
 	}
+	for _, subIssue := range subIssues {
+		provenance.addAuthorAssociation((*github.Issue)(subIssue).GetAuthorAssociation())
+	}
 
 	r, err := json.Marshal(subIssues)
 	if err != nil {
 		return nil, fmt.Errorf("failed to marshal response: %w", err)
 	}
 
-	return utils.NewToolResultText(string(r)), nil
+	return withProvenance(utils.NewToolResultText(string(r)), provenance), nil
 }
 
 func GetIssueLabels(ctx context.Context, client *githubv4.Client, owner string, repo string, issueNumber int) (*mcp.CallToolResult, error) {
@@ -948,6 +1021,8 @@ func SearchIssues(t translations.TranslationHelperFunc) inventory.ServerTool {
 		Required: []string{"query"},
 	}
 	WithPagination(schema)
+	WithSearchRanking(schema)
+	WithArchivedFilter(schema)
 
 	return NewTool(
 		ToolsetMetadataIssues,
@@ -1402,6 +1477,10 @@ func ListIssues(t translations.TranslationHelperFunc) inventory.ServerTool {
 				Type:        "string",
 				Description: "Filter by date (ISO 8601 timestamp)",
 			},
+			"type": {
+				Type:        "string",
+				Description: "Filter by issue type, such as 'Bug'. Use list_issue_types to list the issue types of the organization",
+			},
 		},
 		Required: []string{"owner", "repo"},
 	}
@@ -1485,7 +1564,12 @@ func ListIssues(t translations.TranslationHelperFunc) inventory.ServerTool {
 				return utils.NewToolResultError(err.Error()), nil, nil
 			}
 
-			// There are two optional parameters: since and labels.
+			issueType, err := OptionalParam[string](args, "type")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+
+			// There are three optional parameters: since, type and labels.
 			var sinceTime time.Time
 			var hasSince bool
 			if since != "" {
@@ -1495,6 +1579,7 @@ func ListIssues(t translations.TranslationHelperFunc) inventory.ServerTool {
 				}
 				hasSince = true
 			}
+			hasType := issueType != ""
 			hasLabels := len(labels) > 0
 
 			// Get pagination parameters and convert to GraphQL format
@@ -1512,9 +1597,10 @@ func ListIssues(t translations.TranslationHelperFunc) inventory.ServerTool {
 			_, perPageProvided := args["perPage"]
 			paginationExplicit := perPageProvided
 
-			paginationParams, err := pagination.ToGraphQLParams()
+			cursorScope := "list_issues:" + owner + "/" + repo
+			paginationParams, err := pagination.ToSignedGraphQLParams(ctx, cursorScope)
 			if err != nil {
-				return nil, nil, err
+				return utils.NewToolResultError(err.Error()), nil, nil
 			}
 
 			// Use default of 30 if pagination was not explicitly provided
@@ -1554,11 +1640,20 @@ func ListIssues(t translations.TranslationHelperFunc) inventory.ServerTool {
 				vars["labels"] = labelStrings
 			}
 
-			if hasSince {
-				vars["since"] = githubv4.DateTime{Time: sinceTime}
+			// The since and type filters are set together, to nil when not given
+			hasFilters := hasSince || hasType
+			if hasFilters {
+				vars["since"] = (*githubv4.DateTime)(nil)
+				if hasSince {
+					vars["since"] = &githubv4.DateTime{Time: sinceTime}
+				}
+				vars["type"] = (*githubv4.String)(nil)
+				if hasType {
+					vars["type"] = githubv4.NewString(githubv4.String(issueType))
+				}
 			}
 
-			issueQuery := getIssueQueryType(hasLabels, hasSince)
+			issueQuery := getIssueQueryType(hasLabels, hasFilters)
 			if err := client.Query(ctx, issueQuery, vars); err != nil {
 				return ghErrors.NewGitHubGraphQLErrorResponse(
 					ctx,
@@ -1569,8 +1664,18 @@ func ListIssues(t translations.TranslationHelperFunc) inventory.ServerTool {
 
 			var resp MinimalIssuesResponse
 			if queryResult, ok := issueQuery.(IssueQueryResult); ok {
-				resp = convertToMinimalIssuesResponse(queryResult.GetIssueFragment())
+				fragment := queryResult.GetIssueFragment()
+				resp = convertToMinimalIssuesResponse(fragment)
+				ids := make([]githubv4.ID, len(fragment.Nodes))
+				for i, issue := range fragment.Nodes {
+					ids[i] = issue.ID
+				}
+				statuses := fetchIssueProjectStatuses(ctx, client, ids)
+				for i := range resp.Issues {
+					resp.Issues[i].ProjectStatuses = statuses[fmt.Sprint(ids[i])]
+				}
 			}
+			resp.PageInfo = signPageInfo(ctx, cursorScope, resp.PageInfo)
 
 			return MarshalledTextResult(resp), nil, nil
 		})
@@ -1599,3 +1704,179 @@ func parseISOTimestamp(timestamp string) (time.Time, error) {
 	// Return error with supported formats
 	return time.Time{}, fmt.Errorf("invalid ISO 8601 timestamp: %s (supported formats: YYYY-MM-DDThh:mm:ssZ or YYYY-MM-DD)", timestamp)
 }
+
+// ConvertIssueToDiscussion creates a discussion from an existing issue and closes the issue
+// with a back-reference to the new discussion.
+func ConvertIssueToDiscussion(t translations.TranslationHelperFunc) inventory.ServerTool {
+	return NewTool(
+		ToolsetMetadataIssues,
+		mcp.Tool{
+			Name:        "convert_issue_to_discussion",
+			Description: t("TOOL_CONVERT_ISSUE_TO_DISCUSSION_DESCRIPTION", "Convert an issue into a discussion. Creates a discussion in the given category with the issue's title and body, comments on the issue with a link to the new discussion, and closes the issue as not planned unless 'close_issue' is false. Use 'list_discussion_categories' to find available categories."),
+			Annotations: &mcp.ToolAnnotations{
+				Title:        t("TOOL_CONVERT_ISSUE_TO_DISCUSSION_USER_TITLE", "Convert issue to discussion"),
+				ReadOnlyHint: false,
+			},
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"owner": {
+						Type:        "string",
+						Description: "Repository owner",
+					},
+					"repo": {
+						Type:        "string",
+						Description: "Repository name",
+					},
+					"issue_number": {
+						Type:        "number",
+						Description: "The number of the issue to convert",
+					},
+					"category": {
+						Type:        "string",
+						Description: "Discussion category name or ID to create the discussion in",
+					},
+					"close_issue": {
+						Type:        "boolean",
+						Description: "Close the issue after the discussion is created (default: true)",
+						Default:     json.RawMessage(`true`),
+					},
+				},
+				Required: []string{"owner", "repo", "issue_number", "category"},
+			},
+		},
+		[]scopes.Scope{scopes.Repo},
+		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+			owner, err := RequiredParam[string](args, "owner")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			repo, err := RequiredParam[string](args, "repo")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			issueNumber, err := RequiredInt(args, "issue_number")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			category, err := RequiredParam[string](args, "category")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			closeIssue, err := OptionalBoolParamWithDefault(args, "close_issue", true)
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+
+			gqlClient, err := deps.GetGQLClient(ctx)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to get GitHub GQL client: %w", err)
+			}
+
+			var query struct {
+				Repository struct {
+					ID    githubv4.ID
+					Issue struct {
+						ID    githubv4.ID
+						Title githubv4.String
+						Body  githubv4.String
+					} `graphql:"issue(number: $issueNumber)"`
+					DiscussionCategories struct {
+						Nodes []struct {
+							ID   githubv4.ID
+							Name githubv4.String
+						}
+					} `graphql:"discussionCategories(first: 100)"`
+				} `graphql:"repository(owner: $owner, name: $repo)"`
+			}
+			vars := map[string]any{
+				"owner":       githubv4.String(owner),
+				"repo":        githubv4.String(repo),
+				"issueNumber": githubv4.Int(issueNumber), // #nosec G115 - issue numbers are always small positive integers
+			}
+			if err := gqlClient.Query(ctx, &query, vars); err != nil {
+				return ghErrors.NewGitHubGraphQLErrorResponse(ctx, "failed to get issue", err), nil, nil
+			}
+
+			var categoryID githubv4.ID
+			for _, c := range query.Repository.DiscussionCategories.Nodes {
+				if fmt.Sprintf("%v", c.ID) == category || strings.EqualFold(string(c.Name), category) {
+					categoryID = c.ID
+					break
+				}
+			}
+			if categoryID == nil {
+				return utils.NewToolResultError(fmt.Sprintf("discussion category '%s' not found in %s/%s", category, owner, repo)), nil, nil
+			}
+
+			issue := query.Repository.Issue
+			var createMutation struct {
+				CreateDiscussion struct {
+					Discussion struct {
+						ID     githubv4.ID
+						Number githubv4.Int
+						URL    githubv4.String `graphql:"url"`
+					}
+				} `graphql:"createDiscussion(input: $input)"`
+			}
+			body := fmt.Sprintf("%s\n\n---\n_Converted from #%d._", issue.Body, issueNumber)
+			createInput := githubv4.CreateDiscussionInput{
+				RepositoryID: query.Repository.ID,
+				CategoryID:   categoryID,
+				Title:        issue.Title,
+				Body:         githubv4.String(body),
+			}
+			if err := gqlClient.Mutate(ctx, &createMutation, createInput, nil); err != nil {
+				return ghErrors.NewGitHubGraphQLErrorResponse(ctx, "failed to create discussion", err), nil, nil
+			}
+			discussion := createMutation.CreateDiscussion.Discussion
+
+			var commentMutation struct {
+				AddComment struct {
+					Subject struct {
+						ID githubv4.ID
+					}
+				} `graphql:"addComment(input: $input)"`
+			}
+			commentInput := githubv4.AddCommentInput{
+				SubjectID: issue.ID,
+				Body:      githubv4.String(fmt.Sprintf("This issue has been converted to a discussion: %s", discussion.URL)),
+			}
+			if err := gqlClient.Mutate(ctx, &commentMutation, commentInput, nil); err != nil {
+				return ghErrors.NewGitHubGraphQLErrorResponse(ctx, "discussion created but failed to comment on issue", err), nil, nil
+			}
+
+			if closeIssue {
+				var closeMutation struct {
+					CloseIssue struct {
+						Issue struct {
+							ID githubv4.ID
+						}
+					} `graphql:"closeIssue(input: $input)"`
+				}
+				stateReason := IssueClosedStateReasonNotPlanned
+				closeInput := CloseIssueInput{
+					IssueID:     issue.ID,
+					StateReason: &stateReason,
+				}
+				if err := gqlClient.Mutate(ctx, &closeMutation, closeInput, nil); err != nil {
+					return ghErrors.NewGitHubGraphQLErrorResponse(ctx, "discussion created but failed to close issue", err), nil, nil
+				}
+			}
+
+			response := map[string]any{
+				"discussion": map[string]any{
+					"number": int(discussion.Number),
+					"url":    string(discussion.URL),
+				},
+				"issue_closed": closeIssue,
+			}
+			out, err := json.Marshal(response)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to marshal response: %w", err)
+			}
+
+			return utils.NewToolResultText(string(out)), nil, nil
+		},
+	)
+}
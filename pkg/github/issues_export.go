@@ -0,0 +1,420 @@
+package github
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/inventory"
+	"github.com/github/github-mcp-server/pkg/sanitize"
+	"github.com/github/github-mcp-server/pkg/scopes"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/github/github-mcp-server/pkg/utils"
+	"github.com/google/go-github/v82/github"
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+const (
+	// defaultExportChunkSize and maxExportChunkSize bound the items returned by one export_issues call.
+	defaultExportChunkSize = 500
+	maxExportChunkSize     = 1000
+	// exportPageSize is the number of issues read per API page.
+	exportPageSize = 100
+	// maxExportPages bounds the API pages read by one export_issues call, as exporting pull
+	// requests from a repository with many issues may read many pages for few items.
+	maxExportPages = 20
+)
+
+// exportColumns are the CSV columns of exported issues and pull requests, matching the JSON
+// fields of exportRecord.
+var exportColumns = []string{"number", "type", "title", "state", "state_reason", "author", "author_association", "labels", "assignees", "milestone", "comments", "created_at", "updated_at", "closed_at", "html_url"}
+
+// exportRecord is an exported issue or pull request.
+type exportRecord struct {
+	Number            int      `json:"number"`
+	Type              string   `json:"type"`
+	Title             string   `json:"title"`
+	State             string   `json:"state"`
+	StateReason       string   `json:"state_reason,omitempty"`
+	Author            string   `json:"author"`
+	AuthorAssociation string   `json:"author_association,omitempty"`
+	Labels            []string `json:"labels"`
+	Assignees         []string `json:"assignees"`
+	Milestone         string   `json:"milestone,omitempty"`
+	Comments          int      `json:"comments"`
+	CreatedAt         string   `json:"created_at"`
+	UpdatedAt         string   `json:"updated_at"`
+	ClosedAt          string   `json:"closed_at,omitempty"`
+	HTMLURL           string   `json:"html_url"`
+	Body              *string  `json:"body,omitempty"`
+}
+
+// exportToken is the decoded continuation token of export_issues. It records the filters it was
+// issued for, so that a token is not reused with other filters.
+type exportToken struct {
+	Page    int    `json:"page"`
+	Filters string `json:"filters"`
+}
+
+func encodeExportToken(token exportToken) string {
+	data, _ := json.Marshal(token)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodeExportToken(s string) (exportToken, error) {
+	var token exportToken
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil || json.Unmarshal(data, &token) != nil || token.Page < 1 {
+		return exportToken{}, fmt.Errorf("invalid continuation_token")
+	}
+	return token, nil
+}
+
+// exportFilters hashes the arguments that select the exported items.
+func exportFilters(parts ...string) string {
+	sum := sha256.Sum256([]byte(strings.Join(parts, "\x00")))
+	return hex.EncodeToString(sum[:8])
+}
+
+// ExportIssues creates a tool to export the issues or pull requests of a repository as NDJSON or
+// CSV, in chunks linked by continuation tokens.
+func ExportIssues(t translations.TranslationHelperFunc) inventory.ServerTool {
+	return NewTool(
+		ToolsetMetadataIssues,
+		mcp.Tool{
+			Name: "export_issues",
+			Description: t("TOOL_EXPORT_ISSUES_DESCRIPTION", `Export all issues or pull requests of a repository matching filters as an NDJSON or CSV dataset, for analysis outside the conversation.
+Each call returns a chunk of up to 'chunk_size' items, oldest first. While more items remain, the result includes a 'continuation_token': call again with the same filters and that token to get the next chunk, and concatenate the chunks. CSV chunks after the first have no header row.
+Use 'list_issues' or 'search_issues' instead to look at a few issues.`),
+			Annotations: &mcp.ToolAnnotations{
+				Title:        t("TOOL_EXPORT_ISSUES_USER_TITLE", "Export issues or pull requests"),
+				ReadOnlyHint: true,
+			},
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"owner": {
+						Type:        "string",
+						Description: "Repository owner",
+					},
+					"repo": {
+						Type:        "string",
+						Description: "Repository name",
+					},
+					"type": {
+						Type:        "string",
+						Description: "Export issues, pull requests or both",
+						Enum:        []any{"issue", "pull_request", "all"},
+						Default:     json.RawMessage(`"issue"`),
+					},
+					"state": {
+						Type:        "string",
+						Description: "Filter by state",
+						Enum:        []any{"open", "closed", "all"},
+						Default:     json.RawMessage(`"all"`),
+					},
+					"labels": {
+						Type:        "array",
+						Description: "Only export items with all of these labels",
+						Items: &jsonschema.Schema{
+							Type: "string",
+						},
+					},
+					"since": {
+						Type:        "string",
+						Description: "Only export items updated at or after this time (ISO 8601 timestamp)",
+					},
+					"format": {
+						Type:        "string",
+						Description: "Format of the dataset: one JSON object per line, or CSV with labels and assignees separated by ';'",
+						Enum:        []any{"ndjson", "csv"},
+						Default:     json.RawMessage(`"ndjson"`),
+					},
+					"include_body": {
+						Type:        "boolean",
+						Description: "Include the body of each item (default: false)",
+						Default:     json.RawMessage(`false`),
+					},
+					"chunk_size": {
+						Type:        "number",
+						Description: fmt.Sprintf("Maximum number of items in this chunk (default: %d, min: %d, max: %d)", defaultExportChunkSize, exportPageSize, maxExportChunkSize),
+						Minimum:     jsonschema.Ptr(float64(exportPageSize)),
+						Maximum:     jsonschema.Ptr(float64(maxExportChunkSize)),
+					},
+					"continuation_token": {
+						Type:        "string",
+						Description: "Token returned by the previous call, to get the next chunk",
+					},
+				},
+				Required: []string{"owner", "repo"},
+			},
+		},
+		[]scopes.Scope{scopes.Repo},
+		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+			owner, err := RequiredParam[string](args, "owner")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			repo, err := RequiredParam[string](args, "repo")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			itemType, err := OptionalParam[string](args, "type")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			if itemType == "" {
+				itemType = "issue"
+			}
+			if !slices.Contains([]string{"issue", "pull_request", "all"}, itemType) {
+				return utils.NewToolResultError("type must be issue, pull_request or all"), nil, nil
+			}
+			state, err := OptionalParam[string](args, "state")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			if state == "" {
+				state = "all"
+			}
+			if !slices.Contains([]string{"open", "closed", "all"}, state) {
+				return utils.NewToolResultError("state must be open, closed or all"), nil, nil
+			}
+			labels, err := OptionalStringArrayParam(args, "labels")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			since, err := OptionalParam[string](args, "since")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			var sinceTime time.Time
+			if since != "" {
+				sinceTime, err = parseISOTimestamp(since)
+				if err != nil {
+					return utils.NewToolResultError(fmt.Sprintf("failed to export issues: %s", err.Error())), nil, nil
+				}
+			}
+			format, err := OptionalParam[string](args, "format")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			if format == "" {
+				format = "ndjson"
+			}
+			if format != "ndjson" && format != "csv" {
+				return utils.NewToolResultError("format must be ndjson or csv"), nil, nil
+			}
+			includeBody, err := OptionalBoolParamWithDefault(args, "include_body", false)
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			chunkSize, err := OptionalIntParamWithDefault(args, "chunk_size", defaultExportChunkSize)
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			if chunkSize < exportPageSize || chunkSize > maxExportChunkSize {
+				return utils.NewToolResultError(fmt.Sprintf("chunk_size must be between %d and %d", exportPageSize, maxExportChunkSize)), nil, nil
+			}
+			continuation, err := OptionalParam[string](args, "continuation_token")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+
+			sortedLabels := slices.Sorted(slices.Values(labels))
+			filters := exportFilters(owner, repo, itemType, state, strings.Join(sortedLabels, ","), since, format, strconv.FormatBool(includeBody))
+			page := 1
+			if continuation != "" {
+				token, err := decodeExportToken(continuation)
+				if err != nil {
+					return utils.NewToolResultError(err.Error()), nil, nil
+				}
+				if token.Filters != filters {
+					return utils.NewToolResultError("continuation_token was returned for other arguments: call again with the same owner, repo, type, state, labels, since, format and include_body"), nil, nil
+				}
+				page = token.Page
+			}
+
+			client, err := deps.GetClient(ctx)
+			if err != nil {
+				return utils.NewToolResultErrorFromErr("failed to get GitHub client", err), nil, nil
+			}
+			cache, err := deps.GetRepoAccessCache(ctx)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to get repo access cache: %w", err)
+			}
+			flags := deps.GetFlags(ctx)
+			provenance := newContentProvenance(flags.LockdownMode)
+			if flags.LockdownMode && includeBody && cache == nil {
+				return nil, nil, fmt.Errorf("lockdown cache is not configured")
+			}
+
+			// Oldest first, so that items created during the export are appended to its last chunk
+			// rather than shifting the pages of the chunks still to come.
+			opts := &github.IssueListByRepoOptions{
+				State:     state,
+				Labels:    labels,
+				Sort:      "created",
+				Direction: "asc",
+				Since:     sinceTime,
+				ListOptions: github.ListOptions{
+					PerPage: exportPageSize,
+				},
+			}
+			var records []exportRecord
+			nextPage := page
+			for pages := 0; nextPage != 0 && pages < maxExportPages && len(records)+exportPageSize <= chunkSize; pages++ {
+				opts.ListOptions.Page = nextPage
+				issues, resp, err := client.Issues.ListByRepo(ctx, owner, repo, opts)
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to export issues", resp, err), nil, nil
+				}
+				if resp.StatusCode != http.StatusOK {
+					body, err := io.ReadAll(resp.Body)
+					_ = resp.Body.Close()
+					if err != nil {
+						return nil, nil, fmt.Errorf("failed to read response body: %w", err)
+					}
+					return ghErrors.NewGitHubAPIStatusErrorResponse(ctx, "failed to export issues", resp, body), nil, nil
+				}
+				_ = resp.Body.Close()
+				nextPage = resp.NextPage
+
+				for _, issue := range issues {
+					if (itemType == "issue" && issue.IsPullRequest()) || (itemType == "pull_request" && !issue.IsPullRequest()) {
+						continue
+					}
+					record := newExportRecord(issue)
+					if includeBody {
+						body := sanitize.Sanitize(issue.GetBody())
+						if flags.LockdownMode {
+							if login := issue.GetUser().GetLogin(); login != "" {
+								isSafeContent, err := cache.IsSafeContent(ctx, login, owner, repo)
+								if err != nil {
+									return utils.NewToolResultError(fmt.Sprintf("failed to check lockdown mode: %v", err)), nil, nil
+								}
+								if !isSafeContent {
+									provenance.LockdownFiltered++
+									body = ""
+								}
+							}
+						}
+						record.Body = &body
+					}
+					provenance.addAuthorAssociation(issue.GetAuthorAssociation())
+					records = append(records, record)
+				}
+			}
+
+			data, err := encodeExportRecords(records, format, includeBody, continuation == "")
+			if err != nil {
+				return nil, nil, err
+			}
+
+			noun := map[string]string{"issue": "issues", "pull_request": "pull requests", "all": "issues and pull requests"}[itemType]
+			message := fmt.Sprintf("Exported %d %s of %s/%s as %s.", len(records), noun, owner, repo, strings.ToUpper(format))
+			if nextPage != 0 {
+				message += fmt.Sprintf(" More items remain: call export_issues again with the same arguments and continuation_token %q.", encodeExportToken(exportToken{Page: nextPage, Filters: filters}))
+			} else {
+				message += " This is the last chunk."
+			}
+			mimeType := "application/x-ndjson"
+			if format == "csv" {
+				mimeType = "text/csv"
+			}
+			result := utils.NewToolResultResource(message, &mcp.ResourceContents{
+				URI:      fmt.Sprintf("repo://%s/%s/issues/export.%s?page=%d", owner, repo, format, page),
+				MIMEType: mimeType,
+				Text:     data,
+			})
+			return withProvenance(result, provenance), nil, nil
+		})
+}
+
+func newExportRecord(issue *github.Issue) exportRecord {
+	record := exportRecord{
+		Number:            issue.GetNumber(),
+		Type:              "issue",
+		Title:             sanitize.Sanitize(issue.GetTitle()),
+		State:             issue.GetState(),
+		StateReason:       issue.GetStateReason(),
+		Author:            issue.GetUser().GetLogin(),
+		AuthorAssociation: issue.GetAuthorAssociation(),
+		Labels:            []string{},
+		Assignees:         []string{},
+		Milestone:         issue.GetMilestone().GetTitle(),
+		Comments:          issue.GetComments(),
+		HTMLURL:           issue.GetHTMLURL(),
+	}
+	if issue.IsPullRequest() {
+		record.Type = "pull_request"
+	}
+	for _, label := range issue.Labels {
+		record.Labels = append(record.Labels, label.GetName())
+	}
+	for _, assignee := range issue.Assignees {
+		record.Assignees = append(record.Assignees, assignee.GetLogin())
+	}
+	if issue.CreatedAt != nil {
+		record.CreatedAt = issue.CreatedAt.Format(time.RFC3339)
+	}
+	if issue.UpdatedAt != nil {
+		record.UpdatedAt = issue.UpdatedAt.Format(time.RFC3339)
+	}
+	if issue.ClosedAt != nil {
+		record.ClosedAt = issue.ClosedAt.Format(time.RFC3339)
+	}
+	return record
+}
+
+// encodeExportRecords encodes records as NDJSON, or as CSV with a header row when header is set.
+func encodeExportRecords(records []exportRecord, format string, includeBody, header bool) (string, error) {
+	var buf bytes.Buffer
+	if format == "ndjson" {
+		encoder := json.NewEncoder(&buf)
+		encoder.SetEscapeHTML(false)
+		for _, record := range records {
+			if err := encoder.Encode(record); err != nil {
+				return "", fmt.Errorf("failed to encode export: %w", err)
+			}
+		}
+		return buf.String(), nil
+	}
+
+	w := csv.NewWriter(&buf)
+	columns := exportColumns
+	if includeBody {
+		columns = append(slices.Clone(columns), "body")
+	}
+	if header {
+		_ = w.Write(columns)
+	}
+	for _, r := range records {
+		row := []string{
+			strconv.Itoa(r.Number), r.Type, r.Title, r.State, r.StateReason, r.Author, r.AuthorAssociation,
+			strings.Join(r.Labels, ";"), strings.Join(r.Assignees, ";"), r.Milestone, strconv.Itoa(r.Comments),
+			r.CreatedAt, r.UpdatedAt, r.ClosedAt, r.HTMLURL,
+		}
+		if includeBody {
+			row = append(row, *r.Body)
+		}
+		_ = w.Write(row)
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return "", fmt.Errorf("failed to encode export: %w", err)
+	}
+	return buf.String(), nil
+}
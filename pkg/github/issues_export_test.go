@@ -0,0 +1,145 @@
+package github
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v82/github"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/shurcooL/githubv4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ExportIssues(t *testing.T) {
+	serverTool := ExportIssues(translations.NullTranslationHelper)
+	tool := serverTool.Tool
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+	assert.True(t, tool.Annotations.ReadOnlyHint)
+
+	createdAt := &github.Timestamp{Time: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)}
+	issue := func(number int, author string, pr bool) *github.Issue {
+		i := &github.Issue{
+			Number:            github.Ptr(number),
+			Title:             github.Ptr(fmt.Sprintf("Item %d, with a comma", number)),
+			Body:              github.Ptr(fmt.Sprintf("Body of %d", number)),
+			State:             github.Ptr("open"),
+			User:              &github.User{Login: github.Ptr(author)},
+			AuthorAssociation: github.Ptr("MEMBER"),
+			Labels:            []*github.Label{{Name: github.Ptr("bug")}, {Name: github.Ptr("p1")}},
+			Comments:          github.Ptr(2),
+			CreatedAt:         createdAt,
+			UpdatedAt:         createdAt,
+			HTMLURL:           github.Ptr(fmt.Sprintf("https://github.com/owner/repo/issues/%d", number)),
+		}
+		if pr {
+			i.PullRequestLinks = &github.PullRequestLinks{URL: github.Ptr("https://api.github.com/repos/owner/repo/pulls/1")}
+		}
+		return i
+	}
+	// Two pages of issues: the first mixes issues and a pull request
+	pages := map[string][]*github.Issue{
+		"1": {issue(1, "maintainer", false), issue(2, "maintainer", true)},
+		"2": {issue(3, "testuser", false)},
+	}
+	var requestedPages []string
+	listIssues := func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		assert.Equal(t, "created", query.Get("sort"))
+		assert.Equal(t, "asc", query.Get("direction"))
+		page := query.Get("page")
+		if page == "" {
+			page = "1"
+		}
+		requestedPages = append(requestedPages, page)
+		if page == "1" {
+			w.Header().Set("Link", `<https://api.github.com/repositories/1/issues?page=2>; rel="next"`)
+		}
+		w.WriteHeader(http.StatusOK)
+		_ = json.NewEncoder(w).Encode(pages[page])
+	}
+
+	export := func(args map[string]any, lockdown bool) (string, string) {
+		requestedPages = nil
+		cache := stubRepoAccessCache(githubv4.NewClient(nil), 5*time.Minute)
+		if lockdown {
+			cache = stubRepoAccessCache(githubv4.NewClient(newRepoAccessHTTPClient()), 5*time.Minute)
+		}
+		deps := BaseDeps{
+			Client:          github.NewClient(MockHTTPClientWithHandlers(map[string]http.HandlerFunc{GetReposIssuesByOwnerByRepo: listIssues})),
+			RepoAccessCache: cache,
+			Flags:           stubFeatureFlags(map[string]bool{"lockdown-mode": lockdown}),
+		}
+		handler := serverTool.Handler(deps)
+		request := createMCPRequest(args)
+		result, err := handler(ContextWithDeps(context.Background(), deps), &request)
+		require.NoError(t, err)
+		require.False(t, result.IsError)
+		resource := getResourceResult(t, result)
+		message, ok := result.Content[0].(*mcp.TextContent)
+		require.True(t, ok, "expected Content[0] to be TextContent")
+		return message.Text, resource.Text
+	}
+	tokenPattern := regexp.MustCompile(`continuation_token "([^"]+)"`)
+
+	t.Run("exports issues in chunks linked by continuation tokens", func(t *testing.T) {
+		args := map[string]any{"owner": "owner", "repo": "repo", "chunk_size": float64(100)}
+		message, data := export(args, false)
+		assert.Contains(t, message, "Exported 1 issues of owner/repo as NDJSON. More items remain")
+		var record exportRecord
+		require.NoError(t, json.Unmarshal([]byte(data), &record))
+		assert.Equal(t, 1, record.Number)
+		assert.Equal(t, "issue", record.Type)
+		assert.Equal(t, []string{"bug", "p1"}, record.Labels)
+		assert.Equal(t, "2024-01-02T03:04:05Z", record.CreatedAt)
+		assert.Nil(t, record.Body)
+
+		match := tokenPattern.FindStringSubmatch(message)
+		require.Len(t, match, 2)
+		args["continuation_token"] = match[1]
+		message, data = export(args, false)
+		assert.Contains(t, message, "Exported 1 issues of owner/repo as NDJSON. This is the last chunk.")
+		assert.Equal(t, []string{"2"}, requestedPages)
+		require.NoError(t, json.Unmarshal([]byte(data), &record))
+		assert.Equal(t, 3, record.Number)
+
+		args["state"] = "open"
+		request := createMCPRequest(args)
+		deps := BaseDeps{Client: github.NewClient(nil)}
+		result, err := serverTool.Handler(deps)(ContextWithDeps(context.Background(), deps), &request)
+		require.NoError(t, err)
+		assert.Contains(t, getErrorResult(t, result).Text, "continuation_token was returned for other arguments")
+	})
+
+	t.Run("exports pull requests as CSV with bodies", func(t *testing.T) {
+		message, data := export(map[string]any{"owner": "owner", "repo": "repo", "type": "pull_request", "format": "csv", "include_body": true}, false)
+		assert.Contains(t, message, "Exported 1 pull requests of owner/repo as CSV. This is the last chunk.")
+		assert.Equal(t, []string{"1", "2"}, requestedPages)
+		rows, err := csv.NewReader(strings.NewReader(data)).ReadAll()
+		require.NoError(t, err)
+		require.Len(t, rows, 2)
+		assert.Equal(t, append(exportColumns, "body"), rows[0])
+		assert.Equal(t, []string{"2", "pull_request", "Item 2, with a comma", "open", "", "maintainer", "MEMBER", "bug;p1", "", "", "2", "2024-01-02T03:04:05Z", "2024-01-02T03:04:05Z", "", "https://github.com/owner/repo/issues/2", "Body of 2"}, rows[1])
+	})
+
+	t.Run("lockdown withholds bodies of untrusted authors", func(t *testing.T) {
+		_, data := export(map[string]any{"owner": "owner", "repo": "repo", "include_body": true}, true)
+		lines := strings.Split(strings.TrimSpace(data), "\n")
+		require.Len(t, lines, 2)
+		var trusted, untrusted exportRecord
+		require.NoError(t, json.Unmarshal([]byte(lines[0]), &trusted))
+		require.NoError(t, json.Unmarshal([]byte(lines[1]), &untrusted))
+		assert.Equal(t, "Body of 1", *trusted.Body)
+		assert.Equal(t, "testuser", untrusted.Author)
+		assert.Empty(t, *untrusted.Body)
+	})
+}
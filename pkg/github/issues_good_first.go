@@ -0,0 +1,336 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/inventory"
+	"github.com/github/github-mcp-server/pkg/sanitize"
+	"github.com/github/github-mcp-server/pkg/scopes"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/github/github-mcp-server/pkg/utils"
+	"github.com/google/go-github/v82/github"
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+const (
+	// defaultGoodFirstIssueLabel is the label marking good first issues.
+	defaultGoodFirstIssueLabel = "good first issue"
+	// defaultGoodFirstIssueActiveDays is how recently an issue must have been updated to be a
+	// candidate.
+	defaultGoodFirstIssueActiveDays = 90
+	// defaultGoodFirstIssueLimit is the number of candidates curate_good_first_issues labels.
+	defaultGoodFirstIssueLimit = 5
+	// maxGoodFirstIssueComments is the number of comments beyond which an issue is too
+	// discussed to be a good first issue.
+	maxGoodFirstIssueComments = 10
+	// maxGoodFirstIssuePages bounds the pages of open issues scanned for candidates.
+	maxGoodFirstIssuePages = 5
+)
+
+// Patterns of label names, lowercased with punctuation replaced by spaces, that make an issue a
+// candidate or rule it out.
+var (
+	smallScopeLabelPattern = regexp.MustCompile(`\b(small|easy|trivial|beginner|starter|typo|low hanging|size (xs|s)|effort (low|xs|s)|difficulty (low|easy))\b`)
+	helpWantedLabelPattern = regexp.MustCompile(`\bhelp wanted\b`)
+	docsLabelPattern       = regexp.MustCompile(`\b(docs|documentation)\b`)
+	excludedLabelPattern   = regexp.MustCompile(`\b(blocked|wontfix|won t fix|duplicate|invalid|question|security|breaking|epic|needs (design|discussion|decision|triage))\b`)
+	labelPunctuation       = regexp.MustCompile(`[^a-z0-9]+`)
+)
+
+// GoodFirstIssueCandidate is an open issue that could be labeled as a good first issue, with the
+// reasons it was picked.
+type GoodFirstIssueCandidate struct {
+	Number    int      `json:"number"`
+	Title     string   `json:"title"`
+	URL       string   `json:"url"`
+	Labels    []string `json:"labels"`
+	Comments  int      `json:"comments"`
+	UpdatedAt string   `json:"updated_at"`
+	Score     int      `json:"score"`
+	Reasons   []string `json:"reasons"`
+}
+
+// GoodFirstIssueReport is the result of find_good_first_issues and curate_good_first_issues.
+type GoodFirstIssueReport struct {
+	Repository     string                    `json:"repository"`
+	Label          string                    `json:"label"`
+	ActiveSince    string                    `json:"active_since"`
+	Scanned        int                       `json:"scanned"`
+	AlreadyLabeled int                       `json:"already_labeled"`
+	Candidates     []GoodFirstIssueCandidate `json:"candidates"`
+	// Labeled holds the numbers of the issues curate_good_first_issues labeled.
+	Labeled []int `json:"labeled,omitempty"`
+	// Truncated is set when the repository has more recently active issues than were scanned.
+	Truncated bool `json:"truncated,omitempty"`
+}
+
+// normalizeLabel lowercases a label name and replaces its punctuation by spaces, so that
+// "size/XS" and "effort: low" match the label patterns.
+func normalizeLabel(name string) string {
+	return strings.TrimSpace(labelPunctuation.ReplaceAllString(strings.ToLower(name), " "))
+}
+
+// scoreGoodFirstIssue scores an open, unassigned issue as a good first issue. Issues without a
+// small scope or help wanted label, with an excluded label or with a long discussion are not
+// candidates.
+func scoreGoodFirstIssue(issue *github.Issue) (GoodFirstIssueCandidate, bool) {
+	candidate := GoodFirstIssueCandidate{
+		Number:    issue.GetNumber(),
+		Title:     sanitize.Sanitize(issue.GetTitle()),
+		URL:       issue.GetHTMLURL(),
+		Labels:    []string{},
+		Comments:  issue.GetComments(),
+		UpdatedAt: issue.GetUpdatedAt().UTC().Format(time.RFC3339),
+		Reasons:   []string{},
+	}
+	if candidate.Comments > maxGoodFirstIssueComments {
+		return candidate, false
+	}
+
+	smallScope, helpWanted, docs := false, false, false
+	for _, label := range issue.Labels {
+		name := label.GetName()
+		candidate.Labels = append(candidate.Labels, name)
+		normalized := normalizeLabel(name)
+		switch {
+		case excludedLabelPattern.MatchString(normalized):
+			return candidate, false
+		case smallScopeLabelPattern.MatchString(normalized) && !smallScope:
+			smallScope = true
+			candidate.Score += 3
+			candidate.Reasons = append(candidate.Reasons, fmt.Sprintf("labeled %q, suggesting a small scope", name))
+		case helpWantedLabelPattern.MatchString(normalized) && !helpWanted:
+			helpWanted = true
+			candidate.Score += 2
+			candidate.Reasons = append(candidate.Reasons, fmt.Sprintf("labeled %q", name))
+		case docsLabelPattern.MatchString(normalized) && !docs:
+			docs = true
+			candidate.Score++
+			candidate.Reasons = append(candidate.Reasons, fmt.Sprintf("labeled %q, usually approachable without knowing the code", name))
+		}
+	}
+	if !smallScope && !helpWanted {
+		return candidate, false
+	}
+	if candidate.Comments <= 3 {
+		candidate.Score++
+		candidate.Reasons = append(candidate.Reasons, fmt.Sprintf("little discussion to catch up on (%d comments)", candidate.Comments))
+	}
+	candidate.Reasons = append(candidate.Reasons, "unassigned and recently active")
+	return candidate, true
+}
+
+// findGoodFirstIssues scans the open, unassigned issues of a repository updated within
+// activeDays for good first issue candidates, best first.
+func findGoodFirstIssues(ctx context.Context, client *github.Client, owner, repo, label string, activeDays int) (GoodFirstIssueReport, *mcp.CallToolResult) {
+	since := time.Now().UTC().AddDate(0, 0, -activeDays)
+	report := GoodFirstIssueReport{
+		Repository:  owner + "/" + repo,
+		Label:       label,
+		ActiveSince: since.Format(time.RFC3339),
+		Candidates:  []GoodFirstIssueCandidate{},
+	}
+	opts := &github.IssueListByRepoOptions{
+		State:       "open",
+		Assignee:    "none",
+		Since:       since,
+		Sort:        "updated",
+		Direction:   "desc",
+		ListOptions: github.ListOptions{PerPage: 100},
+	}
+	for pages := 0; ; pages++ {
+		if pages == maxGoodFirstIssuePages {
+			report.Truncated = true
+			break
+		}
+		issues, resp, err := client.Issues.ListByRepo(ctx, owner, repo, opts)
+		if err != nil {
+			return report, ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to list issues", resp, err)
+		}
+		_ = resp.Body.Close()
+
+		for _, issue := range issues {
+			if issue.IsPullRequest() {
+				continue
+			}
+			report.Scanned++
+			if slices.ContainsFunc(issue.Labels, func(l *github.Label) bool { return strings.EqualFold(l.GetName(), label) }) {
+				report.AlreadyLabeled++
+				continue
+			}
+			if candidate, ok := scoreGoodFirstIssue(issue); ok {
+				report.Candidates = append(report.Candidates, candidate)
+			}
+		}
+
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.ListOptions.Page = resp.NextPage
+	}
+
+	// Issues are listed most recently updated first, so ties keep the most active first
+	slices.SortStableFunc(report.Candidates, func(a, b GoodFirstIssueCandidate) int {
+		return b.Score - a.Score
+	})
+	return report, nil
+}
+
+// goodFirstIssueProperties are the arguments shared by the good first issue tools.
+func goodFirstIssueProperties() map[string]*jsonschema.Schema {
+	return map[string]*jsonschema.Schema{
+		"owner": {
+			Type:        "string",
+			Description: "Repository owner",
+		},
+		"repo": {
+			Type:        "string",
+			Description: "Repository name",
+		},
+		"label": {
+			Type:        "string",
+			Description: fmt.Sprintf("Label marking good first issues (default: %q)", defaultGoodFirstIssueLabel),
+		},
+		"active_within_days": {
+			Type:        "number",
+			Description: fmt.Sprintf("Only consider issues updated within this many days (default: %d)", defaultGoodFirstIssueActiveDays),
+			Minimum:     jsonschema.Ptr(float64(1)),
+		},
+	}
+}
+
+// goodFirstIssueArgs reads the arguments shared by the good first issue tools.
+func goodFirstIssueArgs(args map[string]any) (owner, repo, label string, activeDays int, err error) {
+	owner, err = RequiredParam[string](args, "owner")
+	if err != nil {
+		return "", "", "", 0, err
+	}
+	repo, err = RequiredParam[string](args, "repo")
+	if err != nil {
+		return "", "", "", 0, err
+	}
+	label, err = OptionalParam[string](args, "label")
+	if err != nil {
+		return "", "", "", 0, err
+	}
+	if label = strings.TrimSpace(label); label == "" {
+		label = defaultGoodFirstIssueLabel
+	}
+	activeDays, err = OptionalIntParamWithDefault(args, "active_within_days", defaultGoodFirstIssueActiveDays)
+	if err != nil {
+		return "", "", "", 0, err
+	}
+	if activeDays < 1 {
+		return "", "", "", 0, fmt.Errorf("active_within_days must be at least 1")
+	}
+	return owner, repo, label, activeDays, nil
+}
+
+const goodFirstIssueHeuristics = `Candidates are open, unassigned issues updated recently that are not labeled as good first issues yet and have a label suggesting a small scope (such as "easy", "trivial", "typo" or "size/XS") or "help wanted". Issues with labels such as "blocked", "security", "question" or "needs design", or with more than %d comments, are left out. Candidates are scored by these labels, with documentation labels and little discussion scoring higher, and listed best first.`
+
+// FindGoodFirstIssues creates a tool that lists the open issues that could be labeled as good
+// first issues.
+func FindGoodFirstIssues(t translations.TranslationHelperFunc) inventory.ServerTool {
+	return NewTool(
+		ToolsetMetadataIssues,
+		mcp.Tool{
+			Name: "find_good_first_issues",
+			Description: t("TOOL_FIND_GOOD_FIRST_ISSUES_DESCRIPTION", "Find open issues of a repository that could be labeled as good first issues, to keep a pipeline of approachable issues for new contributors.\n"+
+				fmt.Sprintf(goodFirstIssueHeuristics, maxGoodFirstIssueComments)),
+			Annotations: &mcp.ToolAnnotations{
+				Title:        t("TOOL_FIND_GOOD_FIRST_ISSUES_USER_TITLE", "Find good first issue candidates"),
+				ReadOnlyHint: true,
+			},
+			InputSchema: &jsonschema.Schema{
+				Type:       "object",
+				Properties: goodFirstIssueProperties(),
+				Required:   []string{"owner", "repo"},
+			},
+		},
+		[]scopes.Scope{scopes.Repo},
+		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+			owner, repo, label, activeDays, err := goodFirstIssueArgs(args)
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+
+			client, err := deps.GetClient(ctx)
+			if err != nil {
+				return utils.NewToolResultErrorFromErr("failed to get GitHub client", err), nil, nil
+			}
+
+			report, errResult := findGoodFirstIssues(ctx, client, owner, repo, label, activeDays)
+			if errResult != nil {
+				return errResult, nil, nil
+			}
+			return MarshalledTextResult(report), nil, nil
+		})
+}
+
+// CurateGoodFirstIssues creates a tool that labels the best good first issue candidates.
+func CurateGoodFirstIssues(t translations.TranslationHelperFunc) inventory.ServerTool {
+	properties := goodFirstIssueProperties()
+	properties["limit"] = &jsonschema.Schema{
+		Type:        "number",
+		Description: fmt.Sprintf("Maximum number of candidates to label (default: %d)", defaultGoodFirstIssueLimit),
+		Minimum:     jsonschema.Ptr(float64(1)),
+		Maximum:     jsonschema.Ptr(float64(50)),
+	}
+	return NewTool(
+		ToolsetMetadataIssues,
+		mcp.Tool{
+			Name: "curate_good_first_issues",
+			Description: t("TOOL_CURATE_GOOD_FIRST_ISSUES_DESCRIPTION", "Label the best good first issue candidates of a repository, up to a limit, to keep a pipeline of approachable issues for new contributors. Use find_good_first_issues to review the candidates first.\n"+
+				fmt.Sprintf(goodFirstIssueHeuristics, maxGoodFirstIssueComments)),
+			Annotations: &mcp.ToolAnnotations{
+				Title:        t("TOOL_CURATE_GOOD_FIRST_ISSUES_USER_TITLE", "Label good first issues"),
+				ReadOnlyHint: false,
+			},
+			InputSchema: &jsonschema.Schema{
+				Type:       "object",
+				Properties: properties,
+				Required:   []string{"owner", "repo"},
+			},
+		},
+		[]scopes.Scope{scopes.Repo},
+		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+			owner, repo, label, activeDays, err := goodFirstIssueArgs(args)
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			limit, err := OptionalIntParamWithDefault(args, "limit", defaultGoodFirstIssueLimit)
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			if limit < 1 || limit > 50 {
+				return utils.NewToolResultError("limit must be between 1 and 50"), nil, nil
+			}
+
+			client, err := deps.GetClient(ctx)
+			if err != nil {
+				return utils.NewToolResultErrorFromErr("failed to get GitHub client", err), nil, nil
+			}
+
+			report, errResult := findGoodFirstIssues(ctx, client, owner, repo, label, activeDays)
+			if errResult != nil {
+				return errResult, nil, nil
+			}
+			report.Labeled = []int{}
+			for _, candidate := range report.Candidates[:min(limit, len(report.Candidates))] {
+				_, resp, err := client.Issues.AddLabelsToIssue(ctx, owner, repo, candidate.Number, []string{label})
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx, fmt.Sprintf("failed to label issue #%d (labeled so far: %v)", candidate.Number, report.Labeled), resp, err), nil, nil
+				}
+				_ = resp.Body.Close()
+				report.Labeled = append(report.Labeled, candidate.Number)
+			}
+			return MarshalledTextResult(report), nil, nil
+		})
+}
@@ -0,0 +1,133 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v82/github"
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// goodFirstIssueHandlers mocks the open issues of octo/app, recording the issues labeled.
+func goodFirstIssueHandlers(t *testing.T, labeled *[]string) map[string]http.HandlerFunc {
+	t.Helper()
+	issue := func(number, comments int, labels ...string) map[string]any {
+		issueLabels := []map[string]any{}
+		for _, label := range labels {
+			issueLabels = append(issueLabels, map[string]any{"name": label})
+		}
+		return map[string]any{
+			"number":     number,
+			"title":      "Issue",
+			"html_url":   fmt.Sprintf("https://github.com/octo/app/issues/%d", number),
+			"labels":     issueLabels,
+			"comments":   comments,
+			"updated_at": time.Now().Add(-time.Hour),
+		}
+	}
+	pullRequest := issue(7, 0, "easy")
+	pullRequest["pull_request"] = map[string]any{"url": "https://api.github.com/repos/octo/app/pulls/7"}
+	issues := []map[string]any{
+		issue(1, 5, "help wanted"),
+		issue(2, 0, "size/XS", "documentation"),
+		issue(3, 1, "bug"),
+		issue(4, 0, "Good First Issue", "easy"),
+		issue(5, 0, "easy", "blocked"),
+		issue(6, 20, "trivial"),
+		issue(8, 2, "effort: low", "help-wanted"),
+		pullRequest,
+	}
+	return map[string]http.HandlerFunc{
+		GetReposIssuesByOwnerByRepo: func(w http.ResponseWriter, r *http.Request) {
+			query := r.URL.Query()
+			assert.Equal(t, "open", query.Get("state"))
+			assert.Equal(t, "none", query.Get("assignee"))
+			assert.NotEmpty(t, query.Get("since"))
+			mockResponse(t, http.StatusOK, issues)(w, r)
+		},
+		PostReposIssuesLabelsByOwnerByRepoByIssueNumber: func(w http.ResponseWriter, r *http.Request) {
+			var labels []string
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&labels))
+			assert.Equal(t, []string{"good first issue"}, labels)
+			*labeled = append(*labeled, strings.Split(r.URL.Path, "/")[5])
+			mockResponse(t, http.StatusOK, []map[string]any{{"name": "good first issue"}})(w, r)
+		},
+	}
+}
+
+func Test_FindGoodFirstIssues(t *testing.T) {
+	serverTool := FindGoodFirstIssues(translations.NullTranslationHelper)
+	tool := serverTool.Tool
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "find_good_first_issues", tool.Name)
+	assert.True(t, tool.Annotations.ReadOnlyHint)
+	schema := tool.InputSchema.(*jsonschema.Schema)
+	assert.ElementsMatch(t, schema.Required, []string{"owner", "repo"})
+
+	var labeled []string
+	deps := BaseDeps{Client: github.NewClient(MockHTTPClientWithHandlers(goodFirstIssueHandlers(t, &labeled)))}
+	request := createMCPRequest(map[string]any{"owner": "octo", "repo": "app"})
+	result, err := serverTool.Handler(deps)(ContextWithDeps(context.Background(), deps), &request)
+	require.NoError(t, err)
+	require.False(t, result.IsError, getTextResult(t, result).Text)
+
+	var report GoodFirstIssueReport
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &report))
+	assert.Equal(t, "octo/app", report.Repository)
+	assert.Equal(t, "good first issue", report.Label)
+	assert.Equal(t, 7, report.Scanned)
+	assert.Equal(t, 1, report.AlreadyLabeled)
+	var numbers, scores []int
+	for _, candidate := range report.Candidates {
+		numbers = append(numbers, candidate.Number)
+		scores = append(scores, candidate.Score)
+	}
+	assert.Equal(t, []int{8, 2, 1}, numbers)
+	assert.Equal(t, []int{6, 5, 2}, scores)
+	assert.Equal(t, []string{
+		`labeled "size/XS", suggesting a small scope`,
+		`labeled "documentation", usually approachable without knowing the code`,
+		"little discussion to catch up on (0 comments)",
+		"unassigned and recently active",
+	}, report.Candidates[1].Reasons)
+	assert.Empty(t, labeled)
+
+	request = createMCPRequest(map[string]any{"owner": "octo", "repo": "app", "active_within_days": float64(-1)})
+	result, err = serverTool.Handler(deps)(ContextWithDeps(context.Background(), deps), &request)
+	require.NoError(t, err)
+	assert.Contains(t, getErrorResult(t, result).Text, "active_within_days must be at least 1")
+}
+
+func Test_CurateGoodFirstIssues(t *testing.T) {
+	serverTool := CurateGoodFirstIssues(translations.NullTranslationHelper)
+	tool := serverTool.Tool
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "curate_good_first_issues", tool.Name)
+	assert.False(t, tool.Annotations.ReadOnlyHint)
+	schema := tool.InputSchema.(*jsonschema.Schema)
+	assert.ElementsMatch(t, schema.Required, []string{"owner", "repo"})
+
+	var labeled []string
+	deps := BaseDeps{Client: github.NewClient(MockHTTPClientWithHandlers(goodFirstIssueHandlers(t, &labeled)))}
+	request := createMCPRequest(map[string]any{"owner": "octo", "repo": "app", "limit": float64(2)})
+	result, err := serverTool.Handler(deps)(ContextWithDeps(context.Background(), deps), &request)
+	require.NoError(t, err)
+	require.False(t, result.IsError, getTextResult(t, result).Text)
+
+	var report GoodFirstIssueReport
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &report))
+	assert.Equal(t, []int{8, 2}, report.Labeled)
+	assert.Len(t, report.Candidates, 3)
+	assert.Equal(t, []string{"8", "2"}, labeled)
+}
@@ -0,0 +1,307 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/inventory"
+	"github.com/github/github-mcp-server/pkg/lockdown"
+	"github.com/github/github-mcp-server/pkg/sanitize"
+	"github.com/github/github-mcp-server/pkg/scopes"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/github/github-mcp-server/pkg/utils"
+	"github.com/google/go-github/v82/github"
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+const (
+	// maxIssueHierarchyDepth is the deepest issue hierarchy GitHub allows.
+	maxIssueHierarchyDepth = 8
+	// maxIssueHierarchyNodes bounds the sub-issues get_issue_hierarchy reads.
+	maxIssueHierarchyNodes = 200
+)
+
+// hierarchyIssue is an issue as returned by the REST API, with the sub-issue summary go-github
+// does not decode.
+type hierarchyIssue struct {
+	ID                int64  `json:"id"`
+	Number            int    `json:"number"`
+	Title             string `json:"title"`
+	State             string `json:"state"`
+	HTMLURL           string `json:"html_url"`
+	RepositoryURL     string `json:"repository_url"`
+	ParentIssueURL    string `json:"parent_issue_url"`
+	AuthorAssociation string `json:"author_association"`
+	User              *struct {
+		Login string `json:"login"`
+	} `json:"user"`
+	SubIssuesSummary *struct {
+		Total            int `json:"total"`
+		Completed        int `json:"completed"`
+		PercentCompleted int `json:"percent_completed"`
+	} `json:"sub_issues_summary"`
+}
+
+// repository returns the owner/name of the repository of the issue.
+func (i *hierarchyIssue) repository() string {
+	_, fullName, _ := strings.Cut(i.RepositoryURL, "/repos/")
+	return fullName
+}
+
+// IssueHierarchyNode is an issue of a hierarchy, with the sub-issues read.
+type IssueHierarchyNode struct {
+	Repository string `json:"repository"`
+	Number     int    `json:"number"`
+	// ID identifies the issue in sub_issue_write calls
+	ID    int64  `json:"id"`
+	Title string `json:"title,omitempty"`
+	State string `json:"state"`
+	URL   string `json:"url"`
+	// SubIssuesTotal and SubIssuesCompleted count the direct sub-issues of the issue
+	SubIssuesTotal     int                   `json:"sub_issues_total"`
+	SubIssuesCompleted int                   `json:"sub_issues_completed"`
+	SubIssues          []*IssueHierarchyNode `json:"sub_issues,omitempty"`
+	// MoreSubIssues reports sub-issues left out below the requested depth or beyond the limit
+	MoreSubIssues bool `json:"more_sub_issues,omitempty"`
+}
+
+// IssueHierarchy is the result of get_issue_hierarchy.
+type IssueHierarchy struct {
+	// Ancestors lists the parents of the issue, nearest first
+	Ancestors []*IssueHierarchyNode `json:"ancestors"`
+	Issue     *IssueHierarchyNode   `json:"issue"`
+	// Descendants, Open and Closed count the sub-issues read at every level
+	Descendants int  `json:"descendants"`
+	Open        int  `json:"open"`
+	Closed      int  `json:"closed"`
+	Truncated   bool `json:"truncated"`
+}
+
+// issueHierarchyReader reads the hierarchy of an issue, withholding the content of authors
+// lockdown mode does not trust.
+type issueHierarchyReader struct {
+	client     *github.Client
+	cache      *lockdown.RepoAccessCache
+	provenance *ContentProvenance
+	hierarchy  *IssueHierarchy
+}
+
+// get reads the issue at urlStr, relative to the API base URL or absolute.
+func (r *issueHierarchyReader) get(ctx context.Context, urlStr string, v any) (*github.Response, error) {
+	req, err := r.client.NewRequest(http.MethodGet, urlStr, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := r.client.Do(ctx, req, v)
+	if err != nil {
+		return resp, err
+	}
+	_ = resp.Body.Close()
+	return resp, nil
+}
+
+// trusted reports whether lockdown mode lets the content of the issue through.
+func (r *issueHierarchyReader) trusted(ctx context.Context, issue *hierarchyIssue) (bool, error) {
+	if !r.provenance.Lockdown {
+		return true, nil
+	}
+	if r.cache == nil {
+		return false, fmt.Errorf("lockdown cache is not configured")
+	}
+	if issue.User == nil || issue.User.Login == "" {
+		return false, nil
+	}
+	owner, repo, _ := strings.Cut(issue.repository(), "/")
+	return r.cache.IsSafeContent(ctx, issue.User.Login, owner, repo)
+}
+
+// node converts an issue, leaving out its title when lockdown mode does not trust its author.
+func (r *issueHierarchyReader) node(ctx context.Context, issue *hierarchyIssue) (*IssueHierarchyNode, error) {
+	node := &IssueHierarchyNode{
+		Repository: issue.repository(),
+		Number:     issue.Number,
+		ID:         issue.ID,
+		State:      issue.State,
+		URL:        issue.HTMLURL,
+	}
+	if summary := issue.SubIssuesSummary; summary != nil {
+		node.SubIssuesTotal, node.SubIssuesCompleted = summary.Total, summary.Completed
+	}
+	trusted, err := r.trusted(ctx, issue)
+	if err != nil {
+		return nil, err
+	}
+	if trusted {
+		node.Title = sanitize.Sanitize(issue.Title)
+		r.provenance.addAuthorAssociation(issue.AuthorAssociation)
+	}
+	return node, nil
+}
+
+// addSubIssues reads the sub-issues of node down to depth levels below it. Sub-issues lockdown
+// mode does not trust are left out, with their own sub-issues.
+func (r *issueHierarchyReader) addSubIssues(ctx context.Context, node *IssueHierarchyNode, depth int) (*github.Response, error) {
+	if node.SubIssuesTotal == 0 {
+		return nil, nil
+	}
+	if depth == 0 || r.hierarchy.Descendants >= maxIssueHierarchyNodes {
+		node.MoreSubIssues = true
+		r.hierarchy.Truncated = true
+		return nil, nil
+	}
+
+	var subIssues []*hierarchyIssue
+	resp, err := r.get(ctx, fmt.Sprintf("repos/%s/issues/%d/sub_issues?per_page=100", node.Repository, node.Number), &subIssues)
+	if err != nil {
+		return resp, err
+	}
+	if len(subIssues) < node.SubIssuesTotal {
+		node.MoreSubIssues = true
+		r.hierarchy.Truncated = true
+	}
+	for _, subIssue := range subIssues {
+		if r.hierarchy.Descendants >= maxIssueHierarchyNodes {
+			node.MoreSubIssues = true
+			r.hierarchy.Truncated = true
+			break
+		}
+		trusted, err := r.trusted(ctx, subIssue)
+		if err != nil {
+			return nil, err
+		}
+		if !trusted {
+			r.provenance.LockdownFiltered++
+			continue
+		}
+		child, err := r.node(ctx, subIssue)
+		if err != nil {
+			return nil, err
+		}
+		r.hierarchy.Descendants++
+		if child.State == "closed" {
+			r.hierarchy.Closed++
+		} else {
+			r.hierarchy.Open++
+		}
+		node.SubIssues = append(node.SubIssues, child)
+		if resp, err := r.addSubIssues(ctx, child, depth-1); err != nil {
+			return resp, err
+		}
+	}
+	return nil, nil
+}
+
+// GetIssueHierarchy creates a tool to read the parents and sub-issues of an issue.
+func GetIssueHierarchy(t translations.TranslationHelperFunc) inventory.ServerTool {
+	return NewTool(
+		ToolsetMetadataIssues,
+		mcp.Tool{
+			Name: "get_issue_hierarchy",
+			Description: t("TOOL_GET_ISSUE_HIERARCHY_DESCRIPTION", `Get the place of an issue in its hierarchy: its parent issues up to the top-level one, and its sub-issues down to the requested depth, in their priority order, with how many of each issue's sub-issues are completed.
+Use it to review how an epic is broken down into tasks. The IDs returned identify issues in sub_issue_write calls.`),
+			Annotations: &mcp.ToolAnnotations{
+				Title:        t("TOOL_GET_ISSUE_HIERARCHY_USER_TITLE", "Get issue hierarchy"),
+				ReadOnlyHint: true,
+			},
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"owner": {
+						Type:        "string",
+						Description: "Repository owner",
+					},
+					"repo": {
+						Type:        "string",
+						Description: "Repository name",
+					},
+					"issue_number": {
+						Type:        "number",
+						Description: "Issue number",
+					},
+					"depth": {
+						Type:        "number",
+						Description: "How many levels of sub-issues to read (default: 3)",
+						Minimum:     jsonschema.Ptr(1.0),
+						Maximum:     jsonschema.Ptr(float64(maxIssueHierarchyDepth)),
+					},
+				},
+				Required: []string{"owner", "repo", "issue_number"},
+			},
+		},
+		[]scopes.Scope{scopes.Repo},
+		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+			owner, err := RequiredParam[string](args, "owner")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			repo, err := RequiredParam[string](args, "repo")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			issueNumber, err := RequiredInt(args, "issue_number")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			depth, err := OptionalIntParamWithDefault(args, "depth", 3)
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			if depth < 1 || depth > maxIssueHierarchyDepth {
+				return utils.NewToolResultError(fmt.Sprintf("depth must be between 1 and %d", maxIssueHierarchyDepth)), nil, nil
+			}
+
+			client, err := deps.GetClient(ctx)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			cache, err := deps.GetRepoAccessCache(ctx)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to get repo access cache: %w", err)
+			}
+
+			reader := &issueHierarchyReader{
+				client:     client,
+				cache:      cache,
+				provenance: newContentProvenance(deps.GetFlags(ctx).LockdownMode),
+				hierarchy:  &IssueHierarchy{Ancestors: []*IssueHierarchyNode{}},
+			}
+
+			var issue hierarchyIssue
+			resp, err := reader.get(ctx, fmt.Sprintf("repos/%s/%s/issues/%d", owner, repo, issueNumber), &issue)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to get issue", resp, err), nil, nil
+			}
+			if reader.hierarchy.Issue, err = reader.node(ctx, &issue); err != nil {
+				return utils.NewToolResultError(fmt.Sprintf("failed to check lockdown mode: %v", err)), nil, nil
+			}
+
+			parentURL := issue.ParentIssueURL
+			for parentURL != "" && len(reader.hierarchy.Ancestors) < maxIssueHierarchyDepth {
+				var parent hierarchyIssue
+				resp, err := reader.get(ctx, parentURL, &parent)
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to get parent issue", resp, err), nil, nil
+				}
+				node, err := reader.node(ctx, &parent)
+				if err != nil {
+					return utils.NewToolResultError(fmt.Sprintf("failed to check lockdown mode: %v", err)), nil, nil
+				}
+				reader.hierarchy.Ancestors = append(reader.hierarchy.Ancestors, node)
+				parentURL = parent.ParentIssueURL
+			}
+
+			if resp, err := reader.addSubIssues(ctx, reader.hierarchy.Issue, depth); err != nil {
+				if resp != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to list sub-issues", resp, err), nil, nil
+				}
+				return utils.NewToolResultError(fmt.Sprintf("failed to check lockdown mode: %v", err)), nil, nil
+			}
+
+			return withProvenance(MarshalledTextResult(reader.hierarchy), reader.provenance), nil, nil
+		},
+	)
+}
@@ -0,0 +1,117 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v82/github"
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// hierarchyIssueJSON returns an issue of octo/app as the REST API does.
+func hierarchyIssueJSON(number int, title, state string, parent, subIssues, completed int) map[string]any {
+	issue := map[string]any{
+		"id":                 int64(1000 + number),
+		"number":             number,
+		"title":              title,
+		"state":              state,
+		"html_url":           fmt.Sprintf("https://github.com/octo/app/issues/%d", number),
+		"repository_url":     "https://api.github.com/repos/octo/app",
+		"author_association": "MEMBER",
+		"user":               map[string]any{"login": "octocat"},
+		"sub_issues_summary": map[string]any{"total": subIssues, "completed": completed},
+	}
+	if parent != 0 {
+		issue["parent_issue_url"] = fmt.Sprintf("https://api.github.com/repos/octo/app/issues/%d", parent)
+	}
+	return issue
+}
+
+func Test_GetIssueHierarchy(t *testing.T) {
+	serverTool := GetIssueHierarchy(translations.NullTranslationHelper)
+	tool := serverTool.Tool
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_issue_hierarchy", tool.Name)
+	assert.True(t, tool.Annotations.ReadOnlyHint)
+	schema := tool.InputSchema.(*jsonschema.Schema)
+	assert.ElementsMatch(t, schema.Required, []string{"owner", "repo", "issue_number"})
+
+	issues := map[string]map[string]any{
+		"/repos/octo/app/issues/1":  hierarchyIssueJSON(1, "Roadmap", "open", 0, 1, 0),
+		"/repos/octo/app/issues/10": hierarchyIssueJSON(10, "Epic", "open", 1, 2, 1),
+	}
+	subIssues := map[string][]map[string]any{
+		"/repos/octo/app/issues/10/sub_issues": {
+			hierarchyIssueJSON(11, "Task with steps", "open", 10, 1, 0),
+			hierarchyIssueJSON(12, "Done task", "closed", 10, 0, 0),
+		},
+		"/repos/octo/app/issues/11/sub_issues": {
+			hierarchyIssueJSON(13, "Step", "open", 11, 0, 0),
+		},
+	}
+	client := github.NewClient(MockHTTPClientWithHandlers(map[string]http.HandlerFunc{
+		GetReposIssuesByOwnerByRepoByIssueNumber: func(w http.ResponseWriter, r *http.Request) {
+			issue, ok := issues[r.URL.Path]
+			if !ok {
+				mockResponse(t, http.StatusNotFound, map[string]string{"message": "Not Found"})(w, r)
+				return
+			}
+			mockResponse(t, http.StatusOK, issue)(w, r)
+		},
+		GetReposIssuesSubIssuesByOwnerByRepoByIssueNumber: func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "100", r.URL.Query().Get("per_page"))
+			mockResponse(t, http.StatusOK, subIssues[r.URL.Path])(w, r)
+		},
+	}))
+	deps := BaseDeps{Client: client, Flags: stubFeatureFlags(map[string]bool{"lockdown-mode": false})}
+
+	call := func(args map[string]any) *IssueHierarchy {
+		request := createMCPRequest(args)
+		result, err := serverTool.Handler(deps)(ContextWithDeps(context.Background(), deps), &request)
+		require.NoError(t, err)
+		require.False(t, result.IsError, getTextResult(t, result).Text)
+		var hierarchy IssueHierarchy
+		require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &hierarchy))
+		return &hierarchy
+	}
+
+	hierarchy := call(map[string]any{"owner": "octo", "repo": "app", "issue_number": float64(10)})
+	require.Len(t, hierarchy.Ancestors, 1)
+	assert.Equal(t, "Roadmap", hierarchy.Ancestors[0].Title)
+	assert.Equal(t, "octo/app", hierarchy.Issue.Repository)
+	assert.Equal(t, 2, hierarchy.Issue.SubIssuesTotal)
+	assert.Equal(t, 1, hierarchy.Issue.SubIssuesCompleted)
+	require.Len(t, hierarchy.Issue.SubIssues, 2)
+	assert.Equal(t, int64(1011), hierarchy.Issue.SubIssues[0].ID)
+	require.Len(t, hierarchy.Issue.SubIssues[0].SubIssues, 1)
+	assert.Equal(t, "Step", hierarchy.Issue.SubIssues[0].SubIssues[0].Title)
+	assert.Equal(t, 3, hierarchy.Descendants)
+	assert.Equal(t, 2, hierarchy.Open)
+	assert.Equal(t, 1, hierarchy.Closed)
+	assert.False(t, hierarchy.Truncated)
+
+	// Sub-issues below the requested depth are reported, not read
+	hierarchy = call(map[string]any{"owner": "octo", "repo": "app", "issue_number": float64(10), "depth": float64(1)})
+	require.Len(t, hierarchy.Issue.SubIssues, 2)
+	assert.Empty(t, hierarchy.Issue.SubIssues[0].SubIssues)
+	assert.True(t, hierarchy.Issue.SubIssues[0].MoreSubIssues)
+	assert.True(t, hierarchy.Truncated)
+
+	request := createMCPRequest(map[string]any{"owner": "octo", "repo": "app", "issue_number": float64(99)})
+	result, err := serverTool.Handler(deps)(ContextWithDeps(context.Background(), deps), &request)
+	require.NoError(t, err)
+	assert.Contains(t, getErrorResult(t, result).Text, "failed to get issue")
+
+	request = createMCPRequest(map[string]any{"owner": "octo", "repo": "app", "issue_number": float64(10), "depth": float64(9)})
+	result, err = serverTool.Handler(deps)(ContextWithDeps(context.Background(), deps), &request)
+	require.NoError(t, err)
+	assert.Contains(t, getErrorResult(t, result).Text, "depth must be between 1 and 8")
+}
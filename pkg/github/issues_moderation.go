@@ -0,0 +1,235 @@
+package github
+
+import (
+	"context"
+	"fmt"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/inventory"
+	"github.com/github/github-mcp-server/pkg/scopes"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/github/github-mcp-server/pkg/utils"
+	"github.com/google/go-github/v82/github"
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/shurcooL/githubv4"
+)
+
+// issueLockReasons are the reasons an issue conversation can be locked for.
+var issueLockReasons = []any{"off-topic", "too heated", "resolved", "spam"}
+
+// IssuePinWrite creates a tool to pin issues to the top of a repository's issue list, or unpin
+// them.
+func IssuePinWrite(t translations.TranslationHelperFunc) inventory.ServerTool {
+	return NewTool(
+		ToolsetMetadataIssues,
+		mcp.Tool{
+			Name:        "issue_pin_write",
+			Description: t("TOOL_ISSUE_PIN_WRITE_DESCRIPTION", "Pin an issue to the top of the issue list of its repository, or unpin it. A repository can have up to three pinned issues; unpin one before pinning another."),
+			Annotations: &mcp.ToolAnnotations{
+				Title:          t("TOOL_ISSUE_PIN_WRITE_USER_TITLE", "Pin or unpin issue"),
+				ReadOnlyHint:   false,
+				IdempotentHint: true,
+			},
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"method": {
+						Type: "string",
+						Description: `The write operation to perform.
+Options are:
+- 'pin' - pin the issue to the repository.
+- 'unpin' - unpin the issue from the repository.
+`,
+						Enum: []any{"pin", "unpin"},
+					},
+					"owner": {
+						Type:        "string",
+						Description: "Repository owner",
+					},
+					"repo": {
+						Type:        "string",
+						Description: "Repository name",
+					},
+					"issue_number": {
+						Type:        "number",
+						Description: "Issue number",
+					},
+				},
+				Required: []string{"method", "owner", "repo", "issue_number"},
+			},
+		},
+		[]scopes.Scope{scopes.Repo},
+		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+			method, err := RequiredParam[string](args, "method")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			owner, err := RequiredParam[string](args, "owner")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			repo, err := RequiredParam[string](args, "repo")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			issueNumber, err := RequiredInt(args, "issue_number")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			if method != "pin" && method != "unpin" {
+				return utils.NewToolResultError(fmt.Sprintf("unknown method: %s", method)), nil, nil
+			}
+
+			client, err := deps.GetGQLClient(ctx)
+			if err != nil {
+				return utils.NewToolResultError(fmt.Sprintf("failed to get GitHub GQL client: %v", err)), nil, nil
+			}
+			issueID, _, err := fetchIssueIDs(ctx, client, owner, repo, issueNumber, 0)
+			if err != nil {
+				return ghErrors.NewGitHubGraphQLErrorResponse(ctx, "failed to find issue", err), nil, nil
+			}
+
+			issueRef := fmt.Sprintf("%s/%s#%d", owner, repo, issueNumber)
+			if method == "pin" {
+				var mutation struct {
+					PinIssue struct {
+						Issue struct {
+							IsPinned githubv4.Boolean
+						}
+					} `graphql:"pinIssue(input: $input)"`
+				}
+				if err := client.Mutate(ctx, &mutation, githubv4.PinIssueInput{IssueID: issueID}, nil); err != nil {
+					return ghErrors.NewGitHubGraphQLErrorResponse(ctx, "failed to pin issue", err), nil, nil
+				}
+				return utils.NewToolResultText(fmt.Sprintf("%s is now pinned", issueRef)), nil, nil
+			}
+
+			var mutation struct {
+				UnpinIssue struct {
+					Issue struct {
+						IsPinned githubv4.Boolean
+					}
+				} `graphql:"unpinIssue(input: $input)"`
+			}
+			if err := client.Mutate(ctx, &mutation, githubv4.UnpinIssueInput{IssueID: issueID}, nil); err != nil {
+				return ghErrors.NewGitHubGraphQLErrorResponse(ctx, "failed to unpin issue", err), nil, nil
+			}
+			return utils.NewToolResultText(fmt.Sprintf("%s is no longer pinned", issueRef)), nil, nil
+		},
+	)
+}
+
+// IssueLockWrite creates a tool to lock the conversation of an issue or pull request, or unlock
+// it.
+func IssueLockWrite(t translations.TranslationHelperFunc) inventory.ServerTool {
+	return NewTool(
+		ToolsetMetadataIssues,
+		mcp.Tool{
+			Name: "issue_lock_write",
+			Description: t("TOOL_ISSUE_LOCK_WRITE_DESCRIPTION", `Lock the conversation of an issue or pull request so that only collaborators can comment on it, or unlock it.
+Lock with a reason to moderate a conversation that went off-topic, got too heated, is resolved or is spam; the reason is shown on the issue.`),
+			Annotations: &mcp.ToolAnnotations{
+				Title:          t("TOOL_ISSUE_LOCK_WRITE_USER_TITLE", "Lock or unlock issue conversation"),
+				ReadOnlyHint:   false,
+				IdempotentHint: true,
+			},
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"method": {
+						Type: "string",
+						Description: `The write operation to perform.
+Options are:
+- 'lock' - lock the conversation, optionally with a lock_reason.
+- 'unlock' - unlock the conversation.
+`,
+						Enum: []any{"lock", "unlock"},
+					},
+					"owner": {
+						Type:        "string",
+						Description: "Repository owner",
+					},
+					"repo": {
+						Type:        "string",
+						Description: "Repository name",
+					},
+					"issue_number": {
+						Type:        "number",
+						Description: "Issue or pull request number",
+					},
+					"lock_reason": {
+						Type:        "string",
+						Description: "Reason for locking the conversation. Only used with the lock method.",
+						Enum:        issueLockReasons,
+					},
+				},
+				Required: []string{"method", "owner", "repo", "issue_number"},
+			},
+		},
+		[]scopes.Scope{scopes.Repo},
+		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+			method, err := RequiredParam[string](args, "method")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			owner, err := RequiredParam[string](args, "owner")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			repo, err := RequiredParam[string](args, "repo")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			issueNumber, err := RequiredInt(args, "issue_number")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			lockReason, err := OptionalParam[string](args, "lock_reason")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+
+			client, err := deps.GetClient(ctx)
+			if err != nil {
+				return utils.NewToolResultErrorFromErr("failed to get GitHub client", err), nil, nil
+			}
+
+			issueRef := fmt.Sprintf("%s/%s#%d", owner, repo, issueNumber)
+			switch method {
+			case "lock":
+				var opts *github.LockIssueOptions
+				message := fmt.Sprintf("%s is now locked", issueRef)
+				if lockReason != "" {
+					valid := false
+					for _, reason := range issueLockReasons {
+						valid = valid || reason == lockReason
+					}
+					if !valid {
+						return utils.NewToolResultError(fmt.Sprintf("invalid lock_reason: %s", lockReason)), nil, nil
+					}
+					opts = &github.LockIssueOptions{LockReason: lockReason}
+					message += " as " + lockReason
+				}
+				resp, err := client.Issues.Lock(ctx, owner, repo, issueNumber, opts)
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to lock issue", resp, err), nil, nil
+				}
+				_ = resp.Body.Close()
+				return utils.NewToolResultText(message), nil, nil
+			case "unlock":
+				if lockReason != "" {
+					return utils.NewToolResultError("lock_reason can only be set with the lock method"), nil, nil
+				}
+				resp, err := client.Issues.Unlock(ctx, owner, repo, issueNumber)
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to unlock issue", resp, err), nil, nil
+				}
+				_ = resp.Body.Close()
+				return utils.NewToolResultText(fmt.Sprintf("%s is now unlocked", issueRef)), nil, nil
+			default:
+				return utils.NewToolResultError(fmt.Sprintf("unknown method: %s", method)), nil, nil
+			}
+		},
+	)
+}
@@ -0,0 +1,193 @@
+package github
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/githubv4mock"
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v82/github"
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/shurcooL/githubv4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	PutReposIssuesLockByOwnerByRepoByIssueNumber    = "PUT /repos/{owner}/{repo}/issues/{issue_number}/lock"
+	DeleteReposIssuesLockByOwnerByRepoByIssueNumber = "DELETE /repos/{owner}/{repo}/issues/{issue_number}/lock"
+)
+
+func Test_IssuePinWrite(t *testing.T) {
+	serverTool := IssuePinWrite(translations.NullTranslationHelper)
+	tool := serverTool.Tool
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "issue_pin_write", tool.Name)
+	assert.False(t, tool.Annotations.ReadOnlyHint)
+	schema := tool.InputSchema.(*jsonschema.Schema)
+	assert.ElementsMatch(t, schema.Required, []string{"method", "owner", "repo", "issue_number"})
+
+	issueIDQuery := githubv4mock.NewQueryMatcher(
+		struct {
+			Repository struct {
+				Issue struct {
+					ID githubv4.ID
+				} `graphql:"issue(number: $issueNumber)"`
+			} `graphql:"repository(owner: $owner, name: $repo)"`
+		}{},
+		map[string]any{
+			"owner":       githubv4.String("octo"),
+			"repo":        githubv4.String("ink"),
+			"issueNumber": githubv4.Int(42),
+		},
+		githubv4mock.DataResponse(map[string]any{
+			"repository": map[string]any{"issue": map[string]any{"id": "I_42"}},
+		}),
+	)
+
+	tests := []struct {
+		name           string
+		method         string
+		mutation       githubv4mock.Matcher
+		expectedText   string
+		expectedErrMsg string
+	}{
+		{
+			name:   "pin",
+			method: "pin",
+			mutation: githubv4mock.NewMutationMatcher(
+				struct {
+					PinIssue struct {
+						Issue struct {
+							IsPinned githubv4.Boolean
+						}
+					} `graphql:"pinIssue(input: $input)"`
+				}{},
+				githubv4.PinIssueInput{IssueID: "I_42"},
+				nil,
+				githubv4mock.DataResponse(map[string]any{"pinIssue": map[string]any{"issue": map[string]any{"isPinned": true}}}),
+			),
+			expectedText: "octo/ink#42 is now pinned",
+		},
+		{
+			name:   "unpin",
+			method: "unpin",
+			mutation: githubv4mock.NewMutationMatcher(
+				struct {
+					UnpinIssue struct {
+						Issue struct {
+							IsPinned githubv4.Boolean
+						}
+					} `graphql:"unpinIssue(input: $input)"`
+				}{},
+				githubv4.UnpinIssueInput{IssueID: "I_42"},
+				nil,
+				githubv4mock.DataResponse(map[string]any{"unpinIssue": map[string]any{"issue": map[string]any{"isPinned": false}}}),
+			),
+			expectedText: "octo/ink#42 is no longer pinned",
+		},
+		{
+			name:   "too many pinned issues",
+			method: "pin",
+			mutation: githubv4mock.NewMutationMatcher(
+				struct {
+					PinIssue struct {
+						Issue struct {
+							IsPinned githubv4.Boolean
+						}
+					} `graphql:"pinIssue(input: $input)"`
+				}{},
+				githubv4.PinIssueInput{IssueID: "I_42"},
+				nil,
+				githubv4mock.ErrorResponse("Repository can only have 3 pinned issues"),
+			),
+			expectedErrMsg: "failed to pin issue",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			deps := BaseDeps{GQLClient: githubv4.NewClient(githubv4mock.NewMockedHTTPClient(issueIDQuery, tc.mutation))}
+			request := createMCPRequest(map[string]any{"method": tc.method, "owner": "octo", "repo": "ink", "issue_number": float64(42)})
+			result, err := serverTool.Handler(deps)(ContextWithDeps(context.Background(), deps), &request)
+			require.NoError(t, err)
+			if tc.expectedErrMsg != "" {
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+			assert.Equal(t, tc.expectedText, getTextResult(t, result).Text)
+		})
+	}
+}
+
+func Test_IssueLockWrite(t *testing.T) {
+	serverTool := IssueLockWrite(translations.NullTranslationHelper)
+	tool := serverTool.Tool
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "issue_lock_write", tool.Name)
+	assert.True(t, tool.Annotations.IdempotentHint)
+	schema := tool.InputSchema.(*jsonschema.Schema)
+	assert.ElementsMatch(t, schema.Required, []string{"method", "owner", "repo", "issue_number"})
+
+	tests := []struct {
+		name           string
+		args           map[string]any
+		expectedText   string
+		expectedErrMsg string
+	}{
+		{
+			name:         "lock with a reason",
+			args:         map[string]any{"method": "lock", "lock_reason": "too heated"},
+			expectedText: "octo/ink#42 is now locked as too heated",
+		},
+		{
+			name:         "lock without a reason",
+			args:         map[string]any{"method": "lock"},
+			expectedText: "octo/ink#42 is now locked",
+		},
+		{
+			name:         "unlock",
+			args:         map[string]any{"method": "unlock"},
+			expectedText: "octo/ink#42 is now unlocked",
+		},
+		{
+			name:           "invalid reason",
+			args:           map[string]any{"method": "lock", "lock_reason": "boring"},
+			expectedErrMsg: "invalid lock_reason: boring",
+		},
+		{
+			name:           "reason with unlock",
+			args:           map[string]any{"method": "unlock", "lock_reason": "resolved"},
+			expectedErrMsg: "lock_reason can only be set with the lock method",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			lock := mockResponse(t, http.StatusNoContent, nil)
+			if reason, ok := tc.args["lock_reason"]; ok {
+				lock = expectRequestBody(t, map[string]any{"lock_reason": reason}).andThen(lock)
+			}
+			deps := BaseDeps{Client: github.NewClient(MockHTTPClientWithHandlers(map[string]http.HandlerFunc{
+				PutReposIssuesLockByOwnerByRepoByIssueNumber:    lock,
+				DeleteReposIssuesLockByOwnerByRepoByIssueNumber: mockResponse(t, http.StatusNoContent, nil),
+			}))}
+			args := map[string]any{"owner": "octo", "repo": "ink", "issue_number": float64(42)}
+			for k, v := range tc.args {
+				args[k] = v
+			}
+			request := createMCPRequest(args)
+			result, err := serverTool.Handler(deps)(ContextWithDeps(context.Background(), deps), &request)
+			require.NoError(t, err)
+			if tc.expectedErrMsg != "" {
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+			assert.Equal(t, tc.expectedText, getTextResult(t, result).Text)
+		})
+	}
+}
@@ -0,0 +1,314 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+	"time"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/inventory"
+	"github.com/github/github-mcp-server/pkg/sanitize"
+	"github.com/github/github-mcp-server/pkg/scopes"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/github/github-mcp-server/pkg/utils"
+	"github.com/google/go-github/v82/github"
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+const (
+	// defaultSLAWarningHours is how long before its deadline an unanswered issue is at risk.
+	defaultSLAWarningHours = 4
+	// maxSLAIssuePages bounds the pages of open issues check_issue_slas reads.
+	maxSLAIssuePages = 10
+)
+
+// slaResponderAssociations are the author associations of comments that count as a response.
+var slaResponderAssociations = []string{"OWNER", "MEMBER", "COLLABORATOR"}
+
+// IssueSLA is a first response target for the open issues with a label, or for all open issues
+// when Label is empty.
+type IssueSLA struct {
+	Label              string  `json:"label,omitempty"`
+	FirstResponseHours float64 `json:"first_response_hours"`
+}
+
+// IssueSLAStatus is an open issue without a first response that breached its SLA, or is about to.
+type IssueSLAStatus struct {
+	Number             int      `json:"number"`
+	Title              string   `json:"title"`
+	URL                string   `json:"url"`
+	Labels             []string `json:"labels"`
+	Assignees          []string `json:"assignees"`
+	SLALabel           string   `json:"sla_label,omitempty"`
+	FirstResponseHours float64  `json:"first_response_hours"`
+	CreatedAt          string   `json:"created_at"`
+	Deadline           string   `json:"deadline"`
+	HoursOverdue       float64  `json:"hours_overdue,omitempty"`
+	HoursRemaining     float64  `json:"hours_remaining,omitempty"`
+}
+
+// IssueSLAReport is the result of check_issue_slas.
+type IssueSLAReport struct {
+	Repository  string           `json:"repository"`
+	EvaluatedAt string           `json:"evaluated_at"`
+	Evaluated   int              `json:"evaluated"`
+	Violations  []IssueSLAStatus `json:"violations"`
+	AtRisk      []IssueSLAStatus `json:"at_risk"`
+	// Truncated is set when the repository has more open issues than were read.
+	Truncated bool `json:"truncated,omitempty"`
+}
+
+// parseIssueSLAs reads the slas argument.
+func parseIssueSLAs(v any) ([]IssueSLA, error) {
+	items, ok := v.([]any)
+	if !ok || len(items) == 0 {
+		return nil, fmt.Errorf("slas must be a non-empty array of objects")
+	}
+	slas := make([]IssueSLA, 0, len(items))
+	for i, item := range items {
+		m, ok := item.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("sla %d must be an object with label and first_response_hours", i)
+		}
+		label, err := OptionalParam[string](m, "label")
+		if err != nil {
+			return nil, fmt.Errorf("sla %d: %w", i, err)
+		}
+		hours, err := RequiredParam[float64](m, "first_response_hours")
+		if err != nil {
+			return nil, fmt.Errorf("sla %d: %w", i, err)
+		}
+		if hours <= 0 {
+			return nil, fmt.Errorf("sla %d: first_response_hours must be positive", i)
+		}
+		slas = append(slas, IssueSLA{Label: strings.TrimSpace(label), FirstResponseHours: hours})
+	}
+	return slas, nil
+}
+
+// applicableIssueSLA returns the strictest SLA matching the labels of issue. Labels are compared
+// case-insensitively, as GitHub does.
+func applicableIssueSLA(issue *github.Issue, slas []IssueSLA) (IssueSLA, bool) {
+	var applicable IssueSLA
+	found := false
+	for _, sla := range slas {
+		matches := sla.Label == "" || slices.ContainsFunc(issue.Labels, func(label *github.Label) bool {
+			return strings.EqualFold(label.GetName(), sla.Label)
+		})
+		if matches && (!found || sla.FirstResponseHours < applicable.FirstResponseHours) {
+			applicable, found = sla, true
+		}
+	}
+	return applicable, found
+}
+
+// isSLAResponse reports whether comment is a first response to an issue opened by author: a
+// comment by a maintainer other than the author, and not by a bot.
+func isSLAResponse(comment *github.IssueComment, author string) bool {
+	user := comment.GetUser()
+	return user.GetLogin() != author && user.GetType() != "Bot" &&
+		slices.Contains(slaResponderAssociations, comment.GetAuthorAssociation())
+}
+
+func roundHours(d time.Duration) float64 {
+	return math.Round(d.Hours()*10) / 10
+}
+
+// CheckIssueSLAs creates a tool that reports open issues without a first response from a
+// maintainer that breached their SLA, or will within a warning window.
+func CheckIssueSLAs(t translations.TranslationHelperFunc) inventory.ServerTool {
+	return NewTool(
+		ToolsetMetadataIssues,
+		mcp.Tool{
+			Name: "check_issue_slas",
+			Description: t("TOOL_CHECK_ISSUE_SLAS_DESCRIPTION", `Check the open issues of a repository against first response SLAs, such as "issues labeled 'bug' get a response within 24 hours", and list the issues that breached their SLA or are about to, so they can be escalated.
+An issue is answered once the repository owner, an organization member or a collaborator other than its author comments on it; bot comments do not count. When several SLAs match an issue, the strictest applies.
+Violations are listed most overdue first, and issues at risk closest to their deadline first.`),
+			Annotations: &mcp.ToolAnnotations{
+				Title:        t("TOOL_CHECK_ISSUE_SLAS_USER_TITLE", "Check issue response SLAs"),
+				ReadOnlyHint: true,
+			},
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"owner": {
+						Type:        "string",
+						Description: "Repository owner",
+					},
+					"repo": {
+						Type:        "string",
+						Description: "Repository name",
+					},
+					"slas": {
+						Type:        "array",
+						Description: "First response SLAs. Example: [{\"label\": \"bug\", \"first_response_hours\": 24}, {\"first_response_hours\": 72}]",
+						Items: &jsonschema.Schema{
+							Type: "object",
+							Properties: map[string]*jsonschema.Schema{
+								"label": {
+									Type:        "string",
+									Description: "Label of the issues the SLA applies to; omit to apply it to all open issues",
+								},
+								"first_response_hours": {
+									Type:        "number",
+									Description: "Hours within which an issue must get its first response",
+								},
+							},
+							Required: []string{"first_response_hours"},
+						},
+					},
+					"warning_hours": {
+						Type:        "number",
+						Description: fmt.Sprintf("Report unanswered issues whose deadline is within this many hours as at risk (default: %d)", defaultSLAWarningHours),
+						Minimum:     jsonschema.Ptr(float64(0)),
+					},
+				},
+				Required: []string{"owner", "repo", "slas"},
+			},
+		},
+		[]scopes.Scope{scopes.Repo},
+		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+			owner, err := RequiredParam[string](args, "owner")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			repo, err := RequiredParam[string](args, "repo")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			slas, err := parseIssueSLAs(args["slas"])
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			warningHours, ok, err := OptionalParamOK[float64](args, "warning_hours")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			if !ok {
+				warningHours = defaultSLAWarningHours
+			}
+			if warningHours < 0 {
+				return utils.NewToolResultError("warning_hours must not be negative"), nil, nil
+			}
+			warning := time.Duration(warningHours * float64(time.Hour))
+
+			client, err := deps.GetClient(ctx)
+			if err != nil {
+				return utils.NewToolResultErrorFromErr("failed to get GitHub client", err), nil, nil
+			}
+
+			now := time.Now().UTC()
+			report := IssueSLAReport{
+				Repository:  owner + "/" + repo,
+				EvaluatedAt: now.Format(time.RFC3339),
+				Violations:  []IssueSLAStatus{},
+				AtRisk:      []IssueSLAStatus{},
+			}
+			opts := &github.IssueListByRepoOptions{
+				State:       "open",
+				ListOptions: github.ListOptions{PerPage: 100},
+			}
+			for pages := 0; ; pages++ {
+				if pages == maxSLAIssuePages {
+					report.Truncated = true
+					break
+				}
+				issues, resp, err := client.Issues.ListByRepo(ctx, owner, repo, opts)
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to list issues", resp, err), nil, nil
+				}
+				_ = resp.Body.Close()
+
+				for _, issue := range issues {
+					if issue.IsPullRequest() {
+						continue
+					}
+					sla, ok := applicableIssueSLA(issue, slas)
+					if !ok {
+						continue
+					}
+					report.Evaluated++
+
+					created := issue.GetCreatedAt().Time
+					deadline := created.Add(time.Duration(sla.FirstResponseHours * float64(time.Hour)))
+					if now.Before(deadline.Add(-warning)) {
+						continue
+					}
+					answered, errResult := issueAnswered(ctx, client, owner, repo, issue)
+					if errResult != nil {
+						return errResult, nil, nil
+					}
+					if answered {
+						continue
+					}
+
+					status := IssueSLAStatus{
+						Number:             issue.GetNumber(),
+						Title:              sanitize.Sanitize(issue.GetTitle()),
+						URL:                issue.GetHTMLURL(),
+						Labels:             []string{},
+						Assignees:          []string{},
+						SLALabel:           sla.Label,
+						FirstResponseHours: sla.FirstResponseHours,
+						CreatedAt:          created.UTC().Format(time.RFC3339),
+						Deadline:           deadline.UTC().Format(time.RFC3339),
+					}
+					for _, label := range issue.Labels {
+						status.Labels = append(status.Labels, label.GetName())
+					}
+					for _, assignee := range issue.Assignees {
+						status.Assignees = append(status.Assignees, assignee.GetLogin())
+					}
+					if now.Before(deadline) {
+						status.HoursRemaining = roundHours(deadline.Sub(now))
+						report.AtRisk = append(report.AtRisk, status)
+					} else {
+						status.HoursOverdue = roundHours(now.Sub(deadline))
+						report.Violations = append(report.Violations, status)
+					}
+				}
+
+				if resp.NextPage == 0 {
+					break
+				}
+				opts.ListOptions.Page = resp.NextPage
+			}
+
+			slices.SortStableFunc(report.Violations, func(a, b IssueSLAStatus) int {
+				return strings.Compare(a.Deadline, b.Deadline)
+			})
+			slices.SortStableFunc(report.AtRisk, func(a, b IssueSLAStatus) int {
+				return strings.Compare(a.Deadline, b.Deadline)
+			})
+
+			return MarshalledTextResult(report), nil, nil
+		})
+}
+
+// issueAnswered reports whether issue has a comment counting as a first response.
+func issueAnswered(ctx context.Context, client *github.Client, owner, repo string, issue *github.Issue) (bool, *mcp.CallToolResult) {
+	if issue.GetComments() == 0 {
+		return false, nil
+	}
+	opts := &github.IssueListCommentsOptions{ListOptions: github.ListOptions{PerPage: 100}}
+	for {
+		comments, resp, err := client.Issues.ListComments(ctx, owner, repo, issue.GetNumber(), opts)
+		if err != nil {
+			return false, ghErrors.NewGitHubAPIErrorResponse(ctx, fmt.Sprintf("failed to list comments of issue #%d", issue.GetNumber()), resp, err)
+		}
+		_ = resp.Body.Close()
+		for _, comment := range comments {
+			if isSLAResponse(comment, issue.GetUser().GetLogin()) {
+				return true, nil
+			}
+		}
+		if resp.NextPage == 0 {
+			return false, nil
+		}
+		opts.ListOptions.Page = resp.NextPage
+	}
+}
@@ -0,0 +1,117 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v82/github"
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_CheckIssueSLAs(t *testing.T) {
+	serverTool := CheckIssueSLAs(translations.NullTranslationHelper)
+	tool := serverTool.Tool
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "check_issue_slas", tool.Name)
+	assert.True(t, tool.Annotations.ReadOnlyHint)
+	schema := tool.InputSchema.(*jsonschema.Schema)
+	assert.ElementsMatch(t, schema.Required, []string{"owner", "repo", "slas"})
+
+	now := time.Now()
+	issue := func(number int, age time.Duration, comments int, labels ...string) map[string]any {
+		issueLabels := []map[string]any{}
+		for _, label := range labels {
+			issueLabels = append(issueLabels, map[string]any{"name": label})
+		}
+		return map[string]any{
+			"number":     number,
+			"title":      "Issue",
+			"user":       map[string]any{"login": "reporter"},
+			"labels":     issueLabels,
+			"comments":   comments,
+			"created_at": now.Add(-age),
+		}
+	}
+	pullRequest := issue(6, 100*time.Hour, 0, "bug")
+	pullRequest["pull_request"] = map[string]any{"url": "https://api.github.com/repos/octo/app/pulls/6"}
+	issues := []map[string]any{
+		issue(1, 30*time.Hour, 0, "bug"),
+		issue(2, 30*time.Hour, 2, "bug"),
+		issue(3, 22*time.Hour, 1, "bug"),
+		issue(4, 10*time.Hour, 0),
+		issue(5, 50*time.Hour, 0, "Bug", "docs"),
+		pullRequest,
+	}
+	comments := map[string][]map[string]any{
+		"2": {
+			{"user": map[string]any{"login": "reporter"}, "author_association": "NONE"},
+			{"user": map[string]any{"login": "maintainer", "type": "User"}, "author_association": "MEMBER"},
+		},
+		"3": {
+			{"user": map[string]any{"login": "triage[bot]", "type": "Bot"}, "author_association": "MEMBER"},
+		},
+	}
+	var fetched []string
+	handlers := map[string]http.HandlerFunc{
+		GetReposIssuesByOwnerByRepo: func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "open", r.URL.Query().Get("state"))
+			mockResponse(t, http.StatusOK, issues)(w, r)
+		},
+		GetReposIssuesCommentsByOwnerByRepoByIssueNumber: func(w http.ResponseWriter, r *http.Request) {
+			number := strings.Split(r.URL.Path, "/")[5]
+			fetched = append(fetched, number)
+			mockResponse(t, http.StatusOK, comments[number])(w, r)
+		},
+	}
+
+	deps := BaseDeps{Client: github.NewClient(MockHTTPClientWithHandlers(handlers))}
+	request := createMCPRequest(map[string]any{
+		"owner": "octo",
+		"repo":  "app",
+		"slas": []any{
+			map[string]any{"label": "bug", "first_response_hours": float64(24)},
+			map[string]any{"first_response_hours": float64(72)},
+		},
+	})
+	result, err := serverTool.Handler(deps)(ContextWithDeps(context.Background(), deps), &request)
+	require.NoError(t, err)
+	require.False(t, result.IsError, getTextResult(t, result).Text)
+
+	var report IssueSLAReport
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &report))
+	assert.Equal(t, "octo/app", report.Repository)
+	assert.Equal(t, 5, report.Evaluated)
+	assert.ElementsMatch(t, []string{"2", "3"}, fetched, "comments are only read for commented issues near or past their deadline")
+
+	require.Len(t, report.Violations, 2)
+	assert.Equal(t, 5, report.Violations[0].Number, "the strictest SLA applies, and the most overdue issue comes first")
+	assert.Equal(t, "bug", report.Violations[0].SLALabel)
+	assert.InDelta(t, 26, report.Violations[0].HoursOverdue, 0.2)
+	assert.Equal(t, 1, report.Violations[1].Number)
+	assert.InDelta(t, 6, report.Violations[1].HoursOverdue, 0.2)
+
+	require.Len(t, report.AtRisk, 1)
+	assert.Equal(t, 3, report.AtRisk[0].Number, "bot comments are not a response")
+	assert.InDelta(t, 2, report.AtRisk[0].HoursRemaining, 0.2)
+	assert.False(t, report.Truncated)
+
+	t.Run("invalid SLA", func(t *testing.T) {
+		request := createMCPRequest(map[string]any{
+			"owner": "octo",
+			"repo":  "app",
+			"slas":  []any{map[string]any{"label": "bug", "first_response_hours": float64(-1)}},
+		})
+		result, err := serverTool.Handler(deps)(ContextWithDeps(context.Background(), deps), &request)
+		require.NoError(t, err)
+		assert.Contains(t, getErrorResult(t, result).Text, "sla 0: first_response_hours must be positive")
+	})
+}
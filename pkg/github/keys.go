@@ -0,0 +1,278 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/inventory"
+	"github.com/github/github-mcp-server/pkg/scopes"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/github/github-mcp-server/pkg/utils"
+	"github.com/google/go-github/v82/github"
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// sshKeyCredentialType is the credential type of SSH keys in SAML SSO credential authorizations.
+const sshKeyCredentialType = "SSH key"
+
+// SSHKeySummary describes an SSH key of the authenticated user. The key material itself is omitted.
+type SSHKeySummary struct {
+	ID        int64      `json:"id"`
+	Title     string     `json:"title"`
+	KeyType   string     `json:"key_type"`
+	ReadOnly  bool       `json:"read_only"`
+	Verified  bool       `json:"verified"`
+	CreatedAt *time.Time `json:"created_at,omitempty"`
+	AgeDays   int        `json:"age_days"`
+	LastUsed  *time.Time `json:"last_used,omitempty"`
+}
+
+// GPGKeySummary describes a GPG key of the authenticated user.
+type GPGKeySummary struct {
+	ID        int64      `json:"id"`
+	KeyID     string     `json:"key_id"`
+	Emails    []string   `json:"emails"`
+	CanSign   bool       `json:"can_sign"`
+	CreatedAt *time.Time `json:"created_at,omitempty"`
+	AgeDays   int        `json:"age_days"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+	Expired   bool       `json:"expired"`
+}
+
+// OrgMemberSSHKey describes an SSH key a member has authorized for SAML SSO access to an organization.
+type OrgMemberSSHKey struct {
+	Login        string     `json:"login"`
+	Title        string     `json:"title,omitempty"`
+	Fingerprint  string     `json:"fingerprint,omitempty"`
+	AuthorizedAt *time.Time `json:"authorized_at,omitempty"`
+	AgeDays      int        `json:"age_days"`
+	LastAccessed *time.Time `json:"last_accessed,omitempty"`
+}
+
+// keyAgeDays returns the number of whole days between created and now.
+func keyAgeDays(created *github.Timestamp, now time.Time) int {
+	if created == nil {
+		return 0
+	}
+	return int(now.Sub(created.Time).Hours() / 24)
+}
+
+func timestampPtr(ts *github.Timestamp) *time.Time {
+	if ts == nil {
+		return nil
+	}
+	return &ts.Time
+}
+
+// ListSSHKeys creates a tool to list the authenticated user's SSH keys.
+func ListSSHKeys(t translations.TranslationHelperFunc) inventory.ServerTool {
+	return NewTool(
+		ToolsetMetadataUsers,
+		mcp.Tool{
+			Name:        "list_ssh_keys",
+			Description: t("TOOL_LIST_SSH_KEYS_DESCRIPTION", "List the SSH keys of the authenticated user with their key type, age and last use, to review key hygiene. The key material is not returned."),
+			Annotations: &mcp.ToolAnnotations{
+				Title:        t("TOOL_LIST_SSH_KEYS_USER_TITLE", "List my SSH keys"),
+				ReadOnlyHint: true,
+			},
+			InputSchema: WithPagination(&jsonschema.Schema{
+				Type:       "object",
+				Properties: map[string]*jsonschema.Schema{},
+			}),
+		},
+		[]scopes.Scope{scopes.ReadPublicKey},
+		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+			pagination, err := OptionalPaginationParams(args)
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+
+			client, err := deps.GetClient(ctx)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			keys, resp, err := client.Users.ListKeys(ctx, "", &github.ListOptions{Page: pagination.Page, PerPage: pagination.PerPage})
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to list SSH keys", resp, err), nil, nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			now := time.Now()
+			result := make([]SSHKeySummary, 0, len(keys))
+			for _, key := range keys {
+				keyType, _, _ := strings.Cut(key.GetKey(), " ")
+				result = append(result, SSHKeySummary{
+					ID:        key.GetID(),
+					Title:     key.GetTitle(),
+					KeyType:   keyType,
+					ReadOnly:  key.GetReadOnly(),
+					Verified:  key.GetVerified(),
+					CreatedAt: timestampPtr(key.CreatedAt),
+					AgeDays:   keyAgeDays(key.CreatedAt, now),
+					LastUsed:  timestampPtr(key.LastUsed),
+				})
+			}
+
+			return MarshalledTextResult(result), nil, nil
+		},
+	)
+}
+
+// ListGPGKeys creates a tool to list the authenticated user's GPG keys.
+func ListGPGKeys(t translations.TranslationHelperFunc) inventory.ServerTool {
+	return NewTool(
+		ToolsetMetadataUsers,
+		mcp.Tool{
+			Name:        "list_gpg_keys",
+			Description: t("TOOL_LIST_GPG_KEYS_DESCRIPTION", "List the GPG keys of the authenticated user with their emails, age and expiry, to review key hygiene."),
+			Annotations: &mcp.ToolAnnotations{
+				Title:        t("TOOL_LIST_GPG_KEYS_USER_TITLE", "List my GPG keys"),
+				ReadOnlyHint: true,
+			},
+			InputSchema: WithPagination(&jsonschema.Schema{
+				Type:       "object",
+				Properties: map[string]*jsonschema.Schema{},
+			}),
+		},
+		[]scopes.Scope{scopes.ReadGPGKey},
+		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+			pagination, err := OptionalPaginationParams(args)
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+
+			client, err := deps.GetClient(ctx)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			keys, resp, err := client.Users.ListGPGKeys(ctx, "", &github.ListOptions{Page: pagination.Page, PerPage: pagination.PerPage})
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to list GPG keys", resp, err), nil, nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			now := time.Now()
+			result := make([]GPGKeySummary, 0, len(keys))
+			for _, key := range keys {
+				emails := make([]string, 0, len(key.Emails))
+				for _, e := range key.Emails {
+					emails = append(emails, e.GetEmail())
+				}
+				result = append(result, GPGKeySummary{
+					ID:        key.GetID(),
+					KeyID:     key.GetKeyID(),
+					Emails:    emails,
+					CanSign:   key.GetCanSign(),
+					CreatedAt: timestampPtr(key.CreatedAt),
+					AgeDays:   keyAgeDays(key.CreatedAt, now),
+					ExpiresAt: timestampPtr(key.ExpiresAt),
+					Expired:   key.ExpiresAt != nil && key.ExpiresAt.Before(now),
+				})
+			}
+
+			return MarshalledTextResult(result), nil, nil
+		},
+	)
+}
+
+// ListOrgMemberSSHKeys creates a tool for organization admins to review the age of the SSH keys
+// members have authorized for SAML single sign-on.
+func ListOrgMemberSSHKeys(t translations.TranslationHelperFunc) inventory.ServerTool {
+	return NewTool(
+		ToolsetMetadataOrgs,
+		mcp.Tool{
+			Name: "list_org_member_ssh_keys",
+			Description: t("TOOL_LIST_ORG_MEMBER_SSH_KEYS_DESCRIPTION", `List the SSH keys organization members have authorized for SAML single sign-on, oldest first, with their age and last access.
+Requires organization owner access and an organization using SAML single sign-on on GitHub Enterprise Cloud.`),
+			Annotations: &mcp.ToolAnnotations{
+				Title:        t("TOOL_LIST_ORG_MEMBER_SSH_KEYS_USER_TITLE", "List organization members' SSH keys"),
+				ReadOnlyHint: true,
+			},
+			InputSchema: WithPagination(&jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"org": {
+						Type:        "string",
+						Description: "Organization login",
+					},
+					"login": {
+						Type:        "string",
+						Description: "Only list the keys of this member",
+					},
+					"older_than_days": {
+						Type:        "number",
+						Description: "Only list keys authorized more than this many days ago",
+						Minimum:     jsonschema.Ptr(0.0),
+					},
+				},
+				Required: []string{"org"},
+			}),
+		},
+		[]scopes.Scope{scopes.AdminOrg},
+		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+			org, err := RequiredParam[string](args, "org")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			login, err := OptionalParam[string](args, "login")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			olderThanDays, err := OptionalIntParam(args, "older_than_days")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			pagination, err := OptionalPaginationParams(args)
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+
+			client, err := deps.GetClient(ctx)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			opts := &github.CredentialAuthorizationsListOptions{
+				ListOptions: github.ListOptions{Page: pagination.Page, PerPage: pagination.PerPage},
+				Login:       login,
+			}
+			credentials, resp, err := client.Organizations.ListCredentialAuthorizations(ctx, org, opts)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to list credential authorizations", resp, err), nil, nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			now := time.Now()
+			result := []OrgMemberSSHKey{}
+			for _, c := range credentials {
+				if c.GetCredentialType() != sshKeyCredentialType {
+					continue
+				}
+				age := keyAgeDays(c.CredentialAuthorizedAt, now)
+				if age < olderThanDays {
+					continue
+				}
+				result = append(result, OrgMemberSSHKey{
+					Login:        c.GetLogin(),
+					Title:        c.GetAuthorizedCredentialTitle(),
+					Fingerprint:  c.GetFingerprint(),
+					AuthorizedAt: timestampPtr(c.CredentialAuthorizedAt),
+					AgeDays:      age,
+					LastAccessed: timestampPtr(c.CredentialAccessedAt),
+				})
+			}
+			slices.SortStableFunc(result, func(a, b OrgMemberSSHKey) int {
+				return b.AgeDays - a.AgeDays
+			})
+
+			return MarshalledTextResult(result), nil, nil
+		},
+	)
+}
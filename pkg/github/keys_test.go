@@ -0,0 +1,191 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v82/github"
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ListSSHKeys(t *testing.T) {
+	// Verify tool definition once
+	serverTool := ListSSHKeys(translations.NullTranslationHelper)
+	tool := serverTool.Tool
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_ssh_keys", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.True(t, tool.Annotations.ReadOnlyHint)
+	assert.Equal(t, []string{"read:public_key"}, serverTool.RequiredScopes)
+
+	created := time.Now().Add(-400 * 24 * time.Hour)
+	mockKeys := []*github.Key{
+		{
+			ID:        github.Ptr(int64(1)),
+			Title:     github.Ptr("laptop"),
+			Key:       github.Ptr("ssh-rsa AAAAB3NzaC1yc2E"),
+			Verified:  github.Ptr(true),
+			CreatedAt: &github.Timestamp{Time: created},
+		},
+	}
+
+	deps := BaseDeps{Client: github.NewClient(MockHTTPClientWithHandlers(map[string]http.HandlerFunc{
+		GetUserKeys: expectQueryParams(t, map[string]string{"page": "1", "per_page": "30"}).andThen(
+			mockResponse(t, http.StatusOK, mockKeys),
+		),
+	}))}
+	handler := serverTool.Handler(deps)
+
+	request := createMCPRequest(map[string]any{})
+	result, err := handler(ContextWithDeps(context.Background(), deps), &request)
+	require.NoError(t, err)
+
+	var keys []SSHKeySummary
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &keys))
+	require.Len(t, keys, 1)
+	assert.Equal(t, "laptop", keys[0].Title)
+	assert.Equal(t, "ssh-rsa", keys[0].KeyType)
+	assert.Equal(t, 400, keys[0].AgeDays)
+	assert.True(t, keys[0].Verified)
+	assert.NotContains(t, getTextResult(t, result).Text, "AAAAB3NzaC1yc2E")
+}
+
+func Test_ListGPGKeys(t *testing.T) {
+	// Verify tool definition once
+	serverTool := ListGPGKeys(translations.NullTranslationHelper)
+	tool := serverTool.Tool
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_gpg_keys", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.True(t, tool.Annotations.ReadOnlyHint)
+	assert.Equal(t, []string{"read:gpg_key"}, serverTool.RequiredScopes)
+
+	mockKeys := []*github.GPGKey{
+		{
+			ID:        github.Ptr(int64(1)),
+			KeyID:     github.Ptr("3262EFF25BA0D270"),
+			Emails:    []*github.GPGEmail{{Email: github.Ptr("octocat@example.com")}},
+			CanSign:   github.Ptr(true),
+			CreatedAt: &github.Timestamp{Time: time.Now().Add(-10 * 24 * time.Hour)},
+			ExpiresAt: &github.Timestamp{Time: time.Now().Add(-24 * time.Hour)},
+		},
+		{
+			ID:    github.Ptr(int64(2)),
+			KeyID: github.Ptr("AAAA"),
+		},
+	}
+
+	deps := BaseDeps{Client: github.NewClient(MockHTTPClientWithHandlers(map[string]http.HandlerFunc{
+		GetUserGPGKeys: mockResponse(t, http.StatusOK, mockKeys),
+	}))}
+	handler := serverTool.Handler(deps)
+
+	request := createMCPRequest(map[string]any{})
+	result, err := handler(ContextWithDeps(context.Background(), deps), &request)
+	require.NoError(t, err)
+
+	var keys []GPGKeySummary
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &keys))
+	require.Len(t, keys, 2)
+	assert.Equal(t, []string{"octocat@example.com"}, keys[0].Emails)
+	assert.Equal(t, 10, keys[0].AgeDays)
+	assert.True(t, keys[0].Expired)
+	assert.False(t, keys[1].Expired)
+	assert.Empty(t, keys[1].Emails)
+}
+
+func Test_ListOrgMemberSSHKeys(t *testing.T) {
+	// Verify tool definition once
+	serverTool := ListOrgMemberSSHKeys(translations.NullTranslationHelper)
+	tool := serverTool.Tool
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_org_member_ssh_keys", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.True(t, tool.Annotations.ReadOnlyHint)
+	schema := tool.InputSchema.(*jsonschema.Schema)
+	assert.Contains(t, schema.Properties, "org")
+	assert.Contains(t, schema.Properties, "login")
+	assert.Contains(t, schema.Properties, "older_than_days")
+	assert.ElementsMatch(t, schema.Required, []string{"org"})
+
+	daysAgo := func(d int) *github.Timestamp {
+		return &github.Timestamp{Time: time.Now().Add(-time.Duration(d) * 24 * time.Hour)}
+	}
+	mockCredentials := []*github.CredentialAuthorization{
+		{Login: github.Ptr("alice"), CredentialType: github.Ptr("SSH key"), Fingerprint: github.Ptr("fp-new"), CredentialAuthorizedAt: daysAgo(30)},
+		{Login: github.Ptr("bob"), CredentialType: github.Ptr("personal access token"), CredentialAuthorizedAt: daysAgo(900)},
+		{Login: github.Ptr("carol"), CredentialType: github.Ptr("SSH key"), Fingerprint: github.Ptr("fp-old"), CredentialAuthorizedAt: daysAgo(800)},
+	}
+
+	tests := []struct {
+		name         string
+		mockedClient *http.Client
+		requestArgs  map[string]any
+		expectError  bool
+		expectedErr  string
+		expected     []string
+	}{
+		{
+			name: "lists ssh keys oldest first",
+			mockedClient: MockHTTPClientWithHandlers(map[string]http.HandlerFunc{
+				GetOrgsCredentialAuthorizationsByOrg: mockResponse(t, http.StatusOK, mockCredentials),
+			}),
+			requestArgs: map[string]any{"org": "octo-org"},
+			expected:    []string{"fp-old", "fp-new"},
+		},
+		{
+			name: "filters by age and login",
+			mockedClient: MockHTTPClientWithHandlers(map[string]http.HandlerFunc{
+				GetOrgsCredentialAuthorizationsByOrg: expectQueryParams(t, map[string]string{"login": "carol", "page": "1", "per_page": "30"}).andThen(
+					mockResponse(t, http.StatusOK, mockCredentials),
+				),
+			}),
+			requestArgs: map[string]any{"org": "octo-org", "login": "carol", "older_than_days": float64(365)},
+			expected:    []string{"fp-old"},
+		},
+		{
+			name: "organization without SAML single sign-on",
+			mockedClient: MockHTTPClientWithHandlers(map[string]http.HandlerFunc{
+				GetOrgsCredentialAuthorizationsByOrg: mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+			}),
+			requestArgs: map[string]any{"org": "octo-org"},
+			expectError: true,
+			expectedErr: "failed to list credential authorizations",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			deps := BaseDeps{Client: github.NewClient(tc.mockedClient)}
+			handler := serverTool.Handler(deps)
+
+			request := createMCPRequest(tc.requestArgs)
+			result, err := handler(ContextWithDeps(context.Background(), deps), &request)
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErr)
+				return
+			}
+
+			var keys []OrgMemberSSHKey
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &keys))
+			fingerprints := make([]string, 0, len(keys))
+			for _, k := range keys {
+				fingerprints = append(fingerprints, k.Fingerprint)
+			}
+			assert.Equal(t, tc.expected, fingerprints)
+		})
+	}
+}
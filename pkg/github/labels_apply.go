@@ -0,0 +1,195 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+	"sync"
+
+	"github.com/github/github-mcp-server/pkg/inventory"
+	"github.com/github/github-mcp-server/pkg/scopes"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/github/github-mcp-server/pkg/utils"
+	"github.com/google/go-github/v82/github"
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+const (
+	// maxApplyLabelsIssues is the number of issues apply_labels changes in one call.
+	maxApplyLabelsIssues = 100
+	// applyLabelsParallelism is the number of issues apply_labels changes at once.
+	applyLabelsParallelism = 5
+)
+
+// AppliedLabels is the outcome of apply_labels for one issue.
+type AppliedLabels struct {
+	Number int `json:"number"`
+	// Labels are the labels of the issue once changed
+	Labels []string `json:"labels,omitempty"`
+	Error  string   `json:"error,omitempty"`
+}
+
+// ApplyLabelsResult is the result of apply_labels.
+type ApplyLabelsResult struct {
+	Updated int             `json:"updated"`
+	Failed  int             `json:"failed"`
+	Issues  []AppliedLabels `json:"issues"`
+}
+
+// applyLabels adds and removes labels on one issue. Removing a label the issue does not have is
+// not an error.
+func applyLabels(ctx context.Context, client *github.Client, owner, repo string, number int, add, remove []string) AppliedLabels {
+	result := AppliedLabels{Number: number}
+	var labels []*github.Label
+	if len(add) > 0 {
+		added, resp, err := client.Issues.AddLabelsToIssue(ctx, owner, repo, number, add)
+		if err != nil {
+			result.Error = fmt.Sprintf("failed to add labels: %s", err)
+			return result
+		}
+		_ = resp.Body.Close()
+		labels = added
+	}
+	for _, label := range remove {
+		resp, err := client.Issues.RemoveLabelForIssue(ctx, owner, repo, number, label)
+		if err != nil {
+			if resp != nil && resp.StatusCode == http.StatusNotFound {
+				continue
+			}
+			result.Error = fmt.Sprintf("failed to remove label %q: %s", label, err)
+			return result
+		}
+		_ = resp.Body.Close()
+	}
+	if len(remove) > 0 {
+		// Removing labels does not return the labels left
+		current, resp, err := client.Issues.ListLabelsByIssue(ctx, owner, repo, number, &github.ListOptions{PerPage: 100})
+		if err != nil {
+			result.Error = fmt.Sprintf("failed to list labels: %s", err)
+			return result
+		}
+		_ = resp.Body.Close()
+		labels = current
+	}
+	result.Labels = make([]string, 0, len(labels))
+	for _, label := range labels {
+		result.Labels = append(result.Labels, label.GetName())
+	}
+	return result
+}
+
+// ApplyLabels creates a tool to add and remove labels on many issues in one call.
+func ApplyLabels(t translations.TranslationHelperFunc) inventory.ServerTool {
+	return NewTool(
+		ToolsetLabels,
+		mcp.Tool{
+			Name: "apply_labels",
+			Description: t("TOOL_APPLY_LABELS_DESCRIPTION", fmt.Sprintf(`Add and remove labels on up to %d issues or pull requests of a repository in one call. Labels added that do not exist yet are created.
+Each issue is changed independently: the result lists the labels of each issue once changed, or why it could not be changed.`, maxApplyLabelsIssues)),
+			Annotations: &mcp.ToolAnnotations{
+				Title:          t("TOOL_APPLY_LABELS_USER_TITLE", "Apply labels to issues"),
+				ReadOnlyHint:   false,
+				IdempotentHint: true,
+			},
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"owner": {
+						Type:        "string",
+						Description: "Repository owner",
+					},
+					"repo": {
+						Type:        "string",
+						Description: "Repository name",
+					},
+					"issue_numbers": {
+						Type:        "array",
+						Description: "Numbers of the issues or pull requests to change",
+						Items:       &jsonschema.Schema{Type: "number"},
+						MaxItems:    jsonschema.Ptr(maxApplyLabelsIssues),
+					},
+					"add": {
+						Type:        "array",
+						Description: "Labels to add",
+						Items:       &jsonschema.Schema{Type: "string"},
+					},
+					"remove": {
+						Type:        "array",
+						Description: "Labels to remove",
+						Items:       &jsonschema.Schema{Type: "string"},
+					},
+				},
+				Required: []string{"owner", "repo", "issue_numbers"},
+			},
+		},
+		[]scopes.Scope{scopes.Repo},
+		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+			owner, err := RequiredParam[string](args, "owner")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			repo, err := RequiredParam[string](args, "repo")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			numbers, err := OptionalIntArrayParam(args, "issue_numbers")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			if len(numbers) == 0 {
+				return utils.NewToolResultError("missing required parameter: issue_numbers"), nil, nil
+			}
+			if len(numbers) > maxApplyLabelsIssues {
+				return utils.NewToolResultError(fmt.Sprintf("too many issues: at most %d can be changed in one call", maxApplyLabelsIssues)), nil, nil
+			}
+			add, err := OptionalStringArrayParam(args, "add")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			remove, err := OptionalStringArrayParam(args, "remove")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			if len(add) == 0 && len(remove) == 0 {
+				return utils.NewToolResultError("at least one of add or remove must be provided"), nil, nil
+			}
+			for _, label := range add {
+				if slices.Contains(remove, label) {
+					return utils.NewToolResultError(fmt.Sprintf("label %q cannot be both added and removed", label)), nil, nil
+				}
+			}
+
+			client, err := deps.GetClient(ctx)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			result := ApplyLabelsResult{Issues: make([]AppliedLabels, len(numbers))}
+			var wg sync.WaitGroup
+			sem := make(chan struct{}, applyLabelsParallelism)
+			for i, number := range numbers {
+				wg.Add(1)
+				sem <- struct{}{}
+				go func() {
+					defer func() {
+						<-sem
+						wg.Done()
+					}()
+					result.Issues[i] = applyLabels(ctx, client, owner, repo, number, add, remove)
+				}()
+			}
+			wg.Wait()
+
+			for _, issue := range result.Issues {
+				if issue.Error != "" {
+					result.Failed++
+				} else {
+					result.Updated++
+				}
+			}
+			return MarshalledTextResult(result), nil, nil
+		},
+	)
+}
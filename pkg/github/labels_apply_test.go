@@ -0,0 +1,116 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v82/github"
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	GetReposIssuesLabelsByOwnerByRepoByIssueNumber          = "GET /repos/{owner}/{repo}/issues/{issue_number}/labels"
+	DeleteReposIssuesLabelsByOwnerByRepoByIssueNumberByName = "DELETE /repos/{owner}/{repo}/issues/{issue_number}/labels/{name}"
+)
+
+func Test_ApplyLabels(t *testing.T) {
+	serverTool := ApplyLabels(translations.NullTranslationHelper)
+	tool := serverTool.Tool
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "apply_labels", tool.Name)
+	assert.False(t, tool.Annotations.ReadOnlyHint)
+	schema := tool.InputSchema.(*jsonschema.Schema)
+	assert.ElementsMatch(t, schema.Required, []string{"owner", "repo", "issue_numbers"})
+
+	// Issue 3 is locked against changes; issue 2 does not have the removed label
+	var mu sync.Mutex
+	labels := map[string][]string{
+		"1": {"bug", "needs-triage"},
+		"2": {"bug"},
+	}
+	issueNumber := func(r *http.Request) string {
+		return strings.Split(r.URL.Path, "/")[5]
+	}
+	deps := BaseDeps{Client: github.NewClient(MockHTTPClientWithHandlers(map[string]http.HandlerFunc{
+		PostReposIssuesLabelsByOwnerByRepoByIssueNumber: func(w http.ResponseWriter, r *http.Request) {
+			number := issueNumber(r)
+			if number == "3" {
+				mockResponse(t, http.StatusForbidden, `{"message": "Must have admin rights to Repository."}`)(w, r)
+				return
+			}
+			var body []string
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			assert.Equal(t, []string{"triaged"}, body)
+			mu.Lock()
+			labels[number] = append(labels[number], body...)
+			mu.Unlock()
+			mockResponse(t, http.StatusOK, []*github.Label{})(w, r)
+		},
+		DeleteReposIssuesLabelsByOwnerByRepoByIssueNumberByName: func(w http.ResponseWriter, r *http.Request) {
+			number := issueNumber(r)
+			mu.Lock()
+			defer mu.Unlock()
+			for i, label := range labels[number] {
+				if label == "needs-triage" {
+					labels[number] = append(labels[number][:i], labels[number][i+1:]...)
+					mockResponse(t, http.StatusOK, []*github.Label{})(w, r)
+					return
+				}
+			}
+			mockResponse(t, http.StatusNotFound, `{"message": "Label does not exist"}`)(w, r)
+		},
+		GetReposIssuesLabelsByOwnerByRepoByIssueNumber: func(w http.ResponseWriter, r *http.Request) {
+			mu.Lock()
+			defer mu.Unlock()
+			var current []*github.Label
+			for _, label := range labels[issueNumber(r)] {
+				current = append(current, &github.Label{Name: github.Ptr(label)})
+			}
+			mockResponse(t, http.StatusOK, current)(w, r)
+		},
+	}))}
+
+	request := createMCPRequest(map[string]any{
+		"owner":         "owner",
+		"repo":          "repo",
+		"issue_numbers": []any{float64(1), float64(2), float64(3)},
+		"add":           []any{"triaged"},
+		"remove":        []any{"needs-triage"},
+	})
+	result, err := serverTool.Handler(deps)(ContextWithDeps(context.Background(), deps), &request)
+	require.NoError(t, err)
+	require.False(t, result.IsError, getTextResult(t, result).Text)
+
+	var applied ApplyLabelsResult
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &applied))
+	assert.Equal(t, 2, applied.Updated)
+	assert.Equal(t, 1, applied.Failed)
+	require.Len(t, applied.Issues, 3)
+	assert.Equal(t, AppliedLabels{Number: 1, Labels: []string{"bug", "triaged"}}, applied.Issues[0])
+	assert.Equal(t, AppliedLabels{Number: 2, Labels: []string{"bug", "triaged"}}, applied.Issues[1])
+	assert.Equal(t, 3, applied.Issues[2].Number)
+	assert.Contains(t, applied.Issues[2].Error, "failed to add labels")
+
+	for _, tc := range []struct {
+		args           map[string]any
+		expectedErrMsg string
+	}{
+		{map[string]any{"owner": "owner", "repo": "repo", "add": []any{"bug"}}, "missing required parameter: issue_numbers"},
+		{map[string]any{"owner": "owner", "repo": "repo", "issue_numbers": []any{float64(1)}}, "at least one of add or remove"},
+		{map[string]any{"owner": "owner", "repo": "repo", "issue_numbers": []any{float64(1)}, "add": []any{"bug"}, "remove": []any{"bug"}}, `label "bug" cannot be both added and removed`},
+	} {
+		request := createMCPRequest(tc.args)
+		result, err := serverTool.Handler(deps)(ContextWithDeps(context.Background(), deps), &request)
+		require.NoError(t, err)
+		assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+	}
+}
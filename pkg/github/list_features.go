@@ -0,0 +1,51 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+
+	"github.com/github/github-mcp-server/pkg/inventory"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// FeatureInfo describes a feature flag and whether it is enabled for the current session.
+type FeatureInfo struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Enabled     bool   `json:"enabled"`
+	Insiders    bool   `json:"insiders"`
+}
+
+// ListFeatures creates a tool that lists the experimental features that can be toggled
+// individually, and whether each is enabled for the current session.
+func ListFeatures(t translations.TranslationHelperFunc) inventory.ServerTool {
+	return NewTool(
+		ToolsetMetadataContext,
+		mcp.Tool{
+			Name:        "list_features",
+			Description: t("TOOL_LIST_FEATURES_DESCRIPTION", "List the experimental features of this GitHub MCP server and whether each is enabled for this session. Features marked insiders are enabled by insiders mode. The user can enable a feature with the --features flag or X-MCP-Features header, and turn off a single insiders feature by prefixing its name with '-'."),
+			Annotations: &mcp.ToolAnnotations{
+				Title:        t("TOOL_LIST_FEATURES_USER_TITLE", "List features"),
+				ReadOnlyHint: true,
+			},
+			// Use json.RawMessage to ensure "properties" is included even when empty.
+			// OpenAI strict mode requires the properties field to be present.
+			InputSchema: json.RawMessage(`{"type":"object","properties":{}}`),
+		},
+		nil,
+		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, _ map[string]any) (*mcp.CallToolResult, any, error) {
+			features := make([]FeatureInfo, 0, len(AllowedFeatureFlags))
+			for _, flag := range AllowedFeatureFlags {
+				features = append(features, FeatureInfo{
+					Name:        flag,
+					Description: FeatureFlagDescriptions[flag],
+					Enabled:     deps.IsFeatureEnabled(ctx, flag),
+					Insiders:    slices.Contains(InsidersFeatureFlags, flag),
+				})
+			}
+			return MarshalledTextResult(features), nil, nil
+		},
+	)
+}
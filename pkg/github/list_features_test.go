@@ -0,0 +1,48 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ListFeatures(t *testing.T) {
+	// Verify tool definition once
+	serverTool := ListFeatures(translations.NullTranslationHelper)
+	tool := serverTool.Tool
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_features", tool.Name)
+	assert.True(t, tool.Annotations.ReadOnlyHint)
+
+	enabled := ResolveFeatureFlags([]string{NormalizeBodiesFeatureFlag, "-" + MCPAppsFeatureFlag}, true)
+	deps := BaseDeps{
+		featureChecker: func(_ context.Context, flag string) (bool, error) {
+			return enabled[flag], nil
+		},
+	}
+	handler := serverTool.Handler(deps)
+
+	request := createMCPRequest(map[string]any{})
+	result, err := handler(ContextWithDeps(context.Background(), deps), &request)
+	require.NoError(t, err)
+
+	var features []FeatureInfo
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &features))
+	require.Len(t, features, len(AllowedFeatureFlags))
+
+	byName := make(map[string]FeatureInfo)
+	for _, f := range features {
+		byName[f.Name] = f
+	}
+	assert.True(t, byName[NormalizeBodiesFeatureFlag].Enabled)
+	assert.False(t, byName[MCPAppsFeatureFlag].Enabled)
+	assert.True(t, byName[MCPAppsFeatureFlag].Insiders)
+	assert.False(t, byName[FeatureFlagIssuesGranular].Enabled)
+	assert.NotEmpty(t, byName[FeatureFlagIssuesGranular].Description)
+}
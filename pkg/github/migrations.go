@@ -0,0 +1,436 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/inventory"
+	"github.com/github/github-mcp-server/pkg/scopes"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/github/github-mcp-server/pkg/utils"
+	"github.com/google/go-github/v82/github"
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// sourceImportsUnavailable explains the error returned by the source import API where it has been
+// retired, as on github.com.
+const sourceImportsUnavailable = "source imports are not available on this GitHub instance; use GitHub Enterprise Importer or push the repository with git instead"
+
+// MinimalMigration is the summary of an organization migration returned by the migration tools.
+type MinimalMigration struct {
+	ID                 int64    `json:"id"`
+	GUID               string   `json:"guid"`
+	State              string   `json:"state"`
+	LockRepositories   bool     `json:"lock_repositories"`
+	ExcludeAttachments bool     `json:"exclude_attachments"`
+	Repositories       []string `json:"repositories,omitempty"`
+	CreatedAt          string   `json:"created_at,omitempty"`
+	UpdatedAt          string   `json:"updated_at,omitempty"`
+	// ArchiveURL is where the archive of an exported migration can be downloaded, for a short time.
+	ArchiveURL string `json:"archive_url,omitempty"`
+}
+
+// MinimalSourceImport is the status of a source import returned by the migration tools.
+type MinimalSourceImport struct {
+	VCS           string `json:"vcs,omitempty"`
+	VCSURL        string `json:"vcs_url"`
+	Status        string `json:"status"`
+	StatusText    string `json:"status_text,omitempty"`
+	FailedStep    string `json:"failed_step,omitempty"`
+	Message       string `json:"message,omitempty"`
+	Percent       int    `json:"percent,omitempty"`
+	CommitCount   int    `json:"commit_count,omitempty"`
+	PushPercent   int    `json:"push_percent,omitempty"`
+	HasLargeFiles bool   `json:"has_large_files,omitempty"`
+	HTMLURL       string `json:"html_url,omitempty"`
+}
+
+func convertToMinimalMigration(migration *github.Migration) MinimalMigration {
+	m := MinimalMigration{
+		ID:                 migration.GetID(),
+		GUID:               migration.GetGUID(),
+		State:              migration.GetState(),
+		LockRepositories:   migration.GetLockRepositories(),
+		ExcludeAttachments: migration.GetExcludeAttachments(),
+		CreatedAt:          migration.GetCreatedAt(),
+		UpdatedAt:          migration.GetUpdatedAt(),
+	}
+	for _, repo := range migration.Repositories {
+		m.Repositories = append(m.Repositories, repo.GetFullName())
+	}
+	return m
+}
+
+func convertToMinimalSourceImport(imp *github.Import) MinimalSourceImport {
+	return MinimalSourceImport{
+		VCS:           imp.GetVCS(),
+		VCSURL:        imp.GetVCSURL(),
+		Status:        imp.GetStatus(),
+		StatusText:    imp.GetStatusText(),
+		FailedStep:    imp.GetFailedStep(),
+		Message:       imp.GetMessage(),
+		Percent:       imp.GetPercent(),
+		CommitCount:   imp.GetCommitCount(),
+		PushPercent:   imp.GetPushPercent(),
+		HasLargeFiles: imp.GetHasLargeFiles(),
+		HTMLURL:       imp.GetHTMLURL(),
+	}
+}
+
+// sourceImportErrorResponse reports a failed source import request, explaining a 404 or 410 from
+// instances where the API has been retired.
+func sourceImportErrorResponse(ctx context.Context, message string, resp *github.Response, err error) *mcp.CallToolResult {
+	if resp != nil && (resp.StatusCode == http.StatusGone || resp.StatusCode == http.StatusNotFound) {
+		_, _ = ghErrors.NewGitHubAPIErrorToCtx(ctx, message, resp, err)
+		return utils.NewToolResultError(fmt.Sprintf("%s: %s", message, sourceImportsUnavailable))
+	}
+	return ghErrors.NewGitHubAPIErrorResponse(ctx, message, resp, err)
+}
+
+// ListOrgMigrations creates a tool to list the most recent migrations of an organization.
+func ListOrgMigrations(t translations.TranslationHelperFunc) inventory.ServerTool {
+	return NewTool(
+		ToolsetMetadataMigrations,
+		mcp.Tool{
+			Name:        "list_org_migrations",
+			Description: t("TOOL_LIST_ORG_MIGRATIONS_DESCRIPTION", "List the most recent migrations of an organization, with their state and the repositories they export. Requires organization owner access."),
+			Annotations: &mcp.ToolAnnotations{
+				Title:        t("TOOL_LIST_ORG_MIGRATIONS_USER_TITLE", "List organization migrations"),
+				ReadOnlyHint: true,
+			},
+			InputSchema: WithPagination(&jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"org": {
+						Type:        "string",
+						Description: "Organization login",
+					},
+				},
+				Required: []string{"org"},
+			}),
+		},
+		[]scopes.Scope{scopes.AdminOrg},
+		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+			org, err := RequiredParam[string](args, "org")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			pagination, err := OptionalPaginationParams(args)
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+
+			client, err := deps.GetClient(ctx)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			migrations, resp, err := client.Migrations.ListMigrations(ctx, org, &github.ListOptions{Page: pagination.Page, PerPage: pagination.PerPage})
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to list organization migrations", resp, err), nil, nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			result := make([]MinimalMigration, 0, len(migrations))
+			for _, migration := range migrations {
+				result = append(result, convertToMinimalMigration(migration))
+			}
+			return MarshalledTextResult(result), nil, nil
+		},
+	)
+}
+
+// GetMigrationStatus creates a tool to check the progress of an organization migration or of a
+// source import into a repository.
+func GetMigrationStatus(t translations.TranslationHelperFunc) inventory.ServerTool {
+	return NewTool(
+		ToolsetMetadataMigrations,
+		mcp.Tool{
+			Name: "get_migration_status",
+			Description: t("TOOL_GET_MIGRATION_STATUS_DESCRIPTION", `Check the progress of a migration.
+Methods:
+1. org_migration - Get the state of an organization migration. Requires org and migration_id. Once the migration is exported, the result includes a short-lived URL to download its archive.
+2. source_import - Get the progress of a source import into a repository. Requires owner and repo. Source imports are not available on github.com.`),
+			Annotations: &mcp.ToolAnnotations{
+				Title:        t("TOOL_GET_MIGRATION_STATUS_USER_TITLE", "Get migration status"),
+				ReadOnlyHint: true,
+			},
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"method": {
+						Type:        "string",
+						Description: "The kind of migration to check",
+						Enum:        []any{"org_migration", "source_import"},
+					},
+					"org": {
+						Type:        "string",
+						Description: "Organization login, for org_migration",
+					},
+					"migration_id": {
+						Type:        "number",
+						Description: "The ID of the organization migration, for org_migration",
+					},
+					"owner": {
+						Type:        "string",
+						Description: "Repository owner, for source_import",
+					},
+					"repo": {
+						Type:        "string",
+						Description: "Repository name, for source_import",
+					},
+				},
+				Required: []string{"method"},
+			},
+		},
+		[]scopes.Scope{scopes.AdminOrg, scopes.Repo},
+		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+			method, err := RequiredParam[string](args, "method")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+
+			client, err := deps.GetClient(ctx)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			switch method {
+			case "org_migration":
+				org, err := RequiredParam[string](args, "org")
+				if err != nil {
+					return utils.NewToolResultError(err.Error()), nil, nil
+				}
+				migrationID, err := RequiredBigInt(args, "migration_id")
+				if err != nil {
+					return utils.NewToolResultError(err.Error()), nil, nil
+				}
+
+				migration, resp, err := client.Migrations.MigrationStatus(ctx, org, migrationID)
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to get migration status", resp, err), nil, nil
+				}
+				_ = resp.Body.Close()
+
+				result := convertToMinimalMigration(migration)
+				if migration.GetState() == "exported" {
+					archiveURL, err := client.Migrations.MigrationArchiveURL(ctx, org, migrationID)
+					if err != nil {
+						return utils.NewToolResultErrorFromErr("failed to get migration archive URL", err), nil, nil
+					}
+					result.ArchiveURL = archiveURL
+				}
+				return MarshalledTextResult(result), nil, nil
+			case "source_import":
+				owner, err := RequiredParam[string](args, "owner")
+				if err != nil {
+					return utils.NewToolResultError(err.Error()), nil, nil
+				}
+				repo, err := RequiredParam[string](args, "repo")
+				if err != nil {
+					return utils.NewToolResultError(err.Error()), nil, nil
+				}
+
+				imp, resp, err := client.Migrations.ImportProgress(ctx, owner, repo)
+				if err != nil {
+					return sourceImportErrorResponse(ctx, "failed to get source import status", resp, err), nil, nil
+				}
+				defer func() { _ = resp.Body.Close() }()
+
+				return MarshalledTextResult(convertToMinimalSourceImport(imp)), nil, nil
+			default:
+				return utils.NewToolResultError(fmt.Sprintf("unknown method: %s", method)), nil, nil
+			}
+		},
+	)
+}
+
+// StartOrgMigration creates a tool to start exporting repositories of an organization into a
+// migration archive.
+func StartOrgMigration(t translations.TranslationHelperFunc) inventory.ServerTool {
+	return NewTool(
+		ToolsetMetadataMigrations,
+		mcp.Tool{
+			Name: "start_org_migration",
+			Description: t("TOOL_START_ORG_MIGRATION_DESCRIPTION", `Start exporting repositories of an organization, with their issues, pull requests and other metadata, into a migration archive that can be imported into another organization or GitHub instance. Requires organization owner access.
+Check the progress with get_migration_status. Locked repositories stay read-only until they are unlocked after the import.`),
+			Annotations: &mcp.ToolAnnotations{
+				Title:        t("TOOL_START_ORG_MIGRATION_USER_TITLE", "Start organization migration"),
+				ReadOnlyHint: false,
+			},
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"org": {
+						Type:        "string",
+						Description: "Organization login",
+					},
+					"repositories": {
+						Type:        "array",
+						Description: "Names of the repositories of the organization to migrate",
+						Items:       &jsonschema.Schema{Type: "string"},
+						MinItems:    jsonschema.Ptr(1),
+					},
+					"lock_repositories": {
+						Type:        "boolean",
+						Description: "Lock the repositories while they are migrated, so that no changes are lost",
+						Default:     json.RawMessage(`false`),
+					},
+					"exclude_attachments": {
+						Type:        "boolean",
+						Description: "Leave attachments out of the archive to make it smaller",
+						Default:     json.RawMessage(`false`),
+					},
+					"exclude_releases": {
+						Type:        "boolean",
+						Description: "Leave releases out of the archive to make it smaller",
+						Default:     json.RawMessage(`false`),
+					},
+				},
+				Required: []string{"org", "repositories"},
+			},
+		},
+		[]scopes.Scope{scopes.AdminOrg},
+		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+			org, err := RequiredParam[string](args, "org")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			repositories, err := OptionalStringArrayParam(args, "repositories")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			if len(repositories) == 0 {
+				return utils.NewToolResultError("repositories must name at least one repository"), nil, nil
+			}
+			lockRepositories, err := OptionalBoolParamWithDefault(args, "lock_repositories", false)
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			excludeAttachments, err := OptionalBoolParamWithDefault(args, "exclude_attachments", false)
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			excludeReleases, err := OptionalBoolParamWithDefault(args, "exclude_releases", false)
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+
+			client, err := deps.GetClient(ctx)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			migration, resp, err := client.Migrations.StartMigration(ctx, org, repositories, &github.MigrationOptions{
+				LockRepositories:   lockRepositories,
+				ExcludeAttachments: excludeAttachments,
+				ExcludeReleases:    excludeReleases,
+			})
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to start organization migration", resp, err), nil, nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return MarshalledTextResult(convertToMinimalMigration(migration)), nil, nil
+		},
+	)
+}
+
+// StartSourceImport creates a tool to import the history of a repository hosted elsewhere into an
+// empty GitHub repository, on instances that still provide the source import API.
+func StartSourceImport(t translations.TranslationHelperFunc) inventory.ServerTool {
+	return NewTool(
+		ToolsetMetadataMigrations,
+		mcp.Tool{
+			Name: "start_source_import",
+			Description: t("TOOL_START_SOURCE_IMPORT_DESCRIPTION", `Start importing a Git, Subversion, Mercurial or Team Foundation Version Control repository hosted elsewhere into an existing empty GitHub repository.
+Source imports are only available on GitHub Enterprise Server instances that still provide them; they were retired on github.com. Check the progress with get_migration_status.`),
+			Annotations: &mcp.ToolAnnotations{
+				Title:        t("TOOL_START_SOURCE_IMPORT_USER_TITLE", "Start source import"),
+				ReadOnlyHint: false,
+			},
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"owner": {
+						Type:        "string",
+						Description: "Owner of the repository to import into",
+					},
+					"repo": {
+						Type:        "string",
+						Description: "Name of the empty repository to import into",
+					},
+					"vcs_url": {
+						Type:        "string",
+						Description: "URL of the repository to import",
+					},
+					"vcs": {
+						Type:        "string",
+						Description: "Version control system of the repository to import. Detected from the URL when omitted",
+						Enum:        []any{"git", "subversion", "mercurial", "tfvc"},
+					},
+					"vcs_username": {
+						Type:        "string",
+						Description: "Username to authenticate to the repository to import, if it is private",
+					},
+					"vcs_password": {
+						Type:        "string",
+						Description: "Password to authenticate to the repository to import, if it is private",
+					},
+					"tfvc_project": {
+						Type:        "string",
+						Description: "For a tfvc import, the name of the project to import",
+					},
+				},
+				Required: []string{"owner", "repo", "vcs_url"},
+			},
+		},
+		[]scopes.Scope{scopes.Repo},
+		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+			owner, err := RequiredParam[string](args, "owner")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			repo, err := RequiredParam[string](args, "repo")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			vcsURL, err := RequiredParam[string](args, "vcs_url")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			imp := &github.Import{VCSURL: github.Ptr(vcsURL)}
+			for name, field := range map[string]**string{
+				"vcs":          &imp.VCS,
+				"vcs_username": &imp.VCSUsername,
+				"vcs_password": &imp.VCSPassword,
+				"tfvc_project": &imp.TFVCProject,
+			} {
+				value, err := OptionalParam[string](args, name)
+				if err != nil {
+					return utils.NewToolResultError(err.Error()), nil, nil
+				}
+				if value != "" {
+					*field = github.Ptr(value)
+				}
+			}
+
+			client, err := deps.GetClient(ctx)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			started, resp, err := client.Migrations.StartImport(ctx, owner, repo, imp)
+			if err != nil {
+				return sourceImportErrorResponse(ctx, "failed to start source import", resp, err), nil, nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return MarshalledTextResult(convertToMinimalSourceImport(started)), nil, nil
+		},
+	)
+}
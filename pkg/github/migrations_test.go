@@ -0,0 +1,188 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v82/github"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ListOrgMigrations(t *testing.T) {
+	serverTool := ListOrgMigrations(translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(serverTool.Tool.Name, serverTool.Tool))
+	assert.True(t, serverTool.Tool.Annotations.ReadOnlyHint)
+
+	mockedClient := MockHTTPClientWithHandlers(map[string]http.HandlerFunc{
+		"GET /orgs/{org}/migrations": func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "2", r.URL.Query().Get("page"))
+			w.WriteHeader(http.StatusOK)
+			_ = json.NewEncoder(w).Encode([]*github.Migration{{
+				ID:               github.Ptr(int64(79)),
+				GUID:             github.Ptr("0b989ba4"),
+				State:            github.Ptr("exporting"),
+				LockRepositories: github.Ptr(true),
+				Repositories:     []*github.Repository{{FullName: github.Ptr("octo-org/app")}},
+			}})
+		},
+	})
+	deps := BaseDeps{Client: github.NewClient(mockedClient)}
+	request := createMCPRequest(map[string]any{"org": "octo-org", "page": float64(2)})
+	result, err := serverTool.Handler(deps)(ContextWithDeps(context.Background(), deps), &request)
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	var migrations []MinimalMigration
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &migrations))
+	assert.Equal(t, []MinimalMigration{{
+		ID:               79,
+		GUID:             "0b989ba4",
+		State:            "exporting",
+		LockRepositories: true,
+		Repositories:     []string{"octo-org/app"},
+	}}, migrations)
+}
+
+func Test_GetMigrationStatus(t *testing.T) {
+	serverTool := GetMigrationStatus(translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(serverTool.Tool.Name, serverTool.Tool))
+	assert.True(t, serverTool.Tool.Annotations.ReadOnlyHint)
+
+	handlers := map[string]http.HandlerFunc{
+		"GET /orgs/{org}/migrations/{migration_id}": mockResponse(t, http.StatusOK, &github.Migration{
+			ID:    github.Ptr(int64(79)),
+			State: github.Ptr("exported"),
+		}),
+		"GET /orgs/{org}/migrations/{migration_id}/archive": func(w http.ResponseWriter, _ *http.Request) {
+			w.Header().Set("Location", "https://storage.example.com/archive.tar.gz")
+			w.WriteHeader(http.StatusFound)
+		},
+		"GET /repos/{owner}/{repo}/import": func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == "/repos/octo-org/retired/import" {
+				w.WriteHeader(http.StatusGone)
+				_, _ = w.Write([]byte(`{"message": "Gone"}`))
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+			_ = json.NewEncoder(w).Encode(&github.Import{
+				VCS:         github.Ptr("subversion"),
+				VCSURL:      github.Ptr("https://svn.example.com/app"),
+				Status:      github.Ptr("importing"),
+				StatusText:  github.Ptr("Importing..."),
+				Percent:     github.Ptr(42),
+				CommitCount: github.Ptr(1042),
+			})
+		},
+	}
+	call := func(args map[string]any) *mcp.CallToolResult {
+		deps := BaseDeps{Client: github.NewClient(MockHTTPClientWithHandlers(handlers))}
+		request := createMCPRequest(args)
+		result, err := serverTool.Handler(deps)(ContextWithDeps(context.Background(), deps), &request)
+		require.NoError(t, err)
+		return result
+	}
+
+	t.Run("org migration with archive URL", func(t *testing.T) {
+		result := call(map[string]any{"method": "org_migration", "org": "octo-org", "migration_id": float64(79)})
+		require.False(t, result.IsError)
+		var migration MinimalMigration
+		require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &migration))
+		assert.Equal(t, "exported", migration.State)
+		assert.Equal(t, "https://storage.example.com/archive.tar.gz", migration.ArchiveURL)
+	})
+
+	t.Run("source import progress", func(t *testing.T) {
+		result := call(map[string]any{"method": "source_import", "owner": "octo-org", "repo": "app"})
+		require.False(t, result.IsError)
+		var imp MinimalSourceImport
+		require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &imp))
+		assert.Equal(t, MinimalSourceImport{
+			VCS:         "subversion",
+			VCSURL:      "https://svn.example.com/app",
+			Status:      "importing",
+			StatusText:  "Importing...",
+			Percent:     42,
+			CommitCount: 1042,
+		}, imp)
+	})
+
+	t.Run("source imports retired on the instance", func(t *testing.T) {
+		result := call(map[string]any{"method": "source_import", "owner": "octo-org", "repo": "retired"})
+		assert.Contains(t, getErrorResult(t, result).Text, sourceImportsUnavailable)
+	})
+
+	t.Run("missing migration ID", func(t *testing.T) {
+		result := call(map[string]any{"method": "org_migration", "org": "octo-org"})
+		assert.Contains(t, getErrorResult(t, result).Text, "missing required parameter: migration_id")
+	})
+}
+
+func Test_StartOrgMigration(t *testing.T) {
+	serverTool := StartOrgMigration(translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(serverTool.Tool.Name, serverTool.Tool))
+	assert.False(t, serverTool.Tool.Annotations.ReadOnlyHint)
+
+	mockedClient := MockHTTPClientWithHandlers(map[string]http.HandlerFunc{
+		"POST /orgs/{org}/migrations": func(w http.ResponseWriter, r *http.Request) {
+			var body map[string]any
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			assert.Equal(t, []any{"app", "docs"}, body["repositories"])
+			assert.Equal(t, true, body["lock_repositories"])
+			assert.Equal(t, false, body["exclude_attachments"])
+			w.WriteHeader(http.StatusCreated)
+			_ = json.NewEncoder(w).Encode(&github.Migration{ID: github.Ptr(int64(80)), State: github.Ptr("pending"), LockRepositories: github.Ptr(true)})
+		},
+	})
+	deps := BaseDeps{Client: github.NewClient(mockedClient)}
+	handler := serverTool.Handler(deps)
+
+	request := createMCPRequest(map[string]any{"org": "octo-org", "repositories": []any{"app", "docs"}, "lock_repositories": true})
+	result, err := handler(ContextWithDeps(context.Background(), deps), &request)
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+	var migration MinimalMigration
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &migration))
+	assert.Equal(t, int64(80), migration.ID)
+	assert.Equal(t, "pending", migration.State)
+
+	request = createMCPRequest(map[string]any{"org": "octo-org", "repositories": []any{}})
+	result, err = handler(ContextWithDeps(context.Background(), deps), &request)
+	require.NoError(t, err)
+	assert.Contains(t, getErrorResult(t, result).Text, "repositories must name at least one repository")
+}
+
+func Test_StartSourceImport(t *testing.T) {
+	serverTool := StartSourceImport(translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(serverTool.Tool.Name, serverTool.Tool))
+	assert.False(t, serverTool.Tool.Annotations.ReadOnlyHint)
+
+	mockedClient := MockHTTPClientWithHandlers(map[string]http.HandlerFunc{
+		"PUT /repos/{owner}/{repo}/import": func(w http.ResponseWriter, r *http.Request) {
+			var body map[string]any
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			assert.Equal(t, map[string]any{"vcs_url": "https://svn.example.com/app", "vcs": "subversion", "vcs_username": "octocat"}, body)
+			w.WriteHeader(http.StatusCreated)
+			_ = json.NewEncoder(w).Encode(&github.Import{VCS: github.Ptr("subversion"), VCSURL: github.Ptr("https://svn.example.com/app"), Status: github.Ptr("detecting")})
+		},
+	})
+	deps := BaseDeps{Client: github.NewClient(mockedClient)}
+	request := createMCPRequest(map[string]any{
+		"owner":        "octo-org",
+		"repo":         "app",
+		"vcs_url":      "https://svn.example.com/app",
+		"vcs":          "subversion",
+		"vcs_username": "octocat",
+	})
+	result, err := serverTool.Handler(deps)(ContextWithDeps(context.Background(), deps), &request)
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+	var imp MinimalSourceImport
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &imp))
+	assert.Equal(t, "detecting", imp.Status)
+}
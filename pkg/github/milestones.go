@@ -0,0 +1,283 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/inventory"
+	"github.com/github/github-mcp-server/pkg/scopes"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/github/github-mcp-server/pkg/utils"
+	"github.com/google/go-github/v82/github"
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// MinimalMilestone is the summary of a milestone returned by the milestone tools.
+type MinimalMilestone struct {
+	Number       int    `json:"number"`
+	Title        string `json:"title"`
+	Description  string `json:"description,omitempty"`
+	State        string `json:"state"`
+	DueOn        string `json:"due_on,omitempty"`
+	OpenIssues   int    `json:"open_issues"`
+	ClosedIssues int    `json:"closed_issues"`
+	HTMLURL      string `json:"html_url"`
+}
+
+func convertToMinimalMilestone(milestone *github.Milestone) MinimalMilestone {
+	m := MinimalMilestone{
+		Number:       milestone.GetNumber(),
+		Title:        milestone.GetTitle(),
+		Description:  milestone.GetDescription(),
+		State:        milestone.GetState(),
+		OpenIssues:   milestone.GetOpenIssues(),
+		ClosedIssues: milestone.GetClosedIssues(),
+		HTMLURL:      milestone.GetHTMLURL(),
+	}
+	if milestone.DueOn != nil {
+		m.DueOn = milestone.DueOn.Format(time.RFC3339)
+	}
+	return m
+}
+
+// ListMilestones creates a tool to list the milestones of a repository.
+func ListMilestones(t translations.TranslationHelperFunc) inventory.ServerTool {
+	schema := &jsonschema.Schema{
+		Type: "object",
+		Properties: map[string]*jsonschema.Schema{
+			"owner": {
+				Type:        "string",
+				Description: "Repository owner",
+			},
+			"repo": {
+				Type:        "string",
+				Description: "Repository name",
+			},
+			"state": {
+				Type:        "string",
+				Description: "Filter by state",
+				Enum:        []any{"open", "closed", "all"},
+				Default:     json.RawMessage(`"open"`),
+			},
+			"sort": {
+				Type:        "string",
+				Description: "Sort by due date or by the share of closed issues",
+				Enum:        []any{"due_on", "completeness"},
+				Default:     json.RawMessage(`"due_on"`),
+			},
+			"direction": {
+				Type:        "string",
+				Description: "Sort direction",
+				Enum:        []any{"asc", "desc"},
+				Default:     json.RawMessage(`"asc"`),
+			},
+		},
+		Required: []string{"owner", "repo"},
+	}
+	WithPagination(schema)
+
+	return NewTool(
+		ToolsetMetadataIssues,
+		mcp.Tool{
+			Name:        "list_milestones",
+			Description: t("TOOL_LIST_MILESTONES_DESCRIPTION", "List the milestones of a GitHub repository, with their due dates and how many of their issues are open and closed."),
+			Annotations: &mcp.ToolAnnotations{
+				Title:        t("TOOL_LIST_MILESTONES_USER_TITLE", "List milestones"),
+				ReadOnlyHint: true,
+			},
+			InputSchema: schema,
+		},
+		[]scopes.Scope{scopes.Repo},
+		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+			owner, err := RequiredParam[string](args, "owner")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			repo, err := RequiredParam[string](args, "repo")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			opts := &github.MilestoneListOptions{}
+			for name, field := range map[string]*string{
+				"state":     &opts.State,
+				"sort":      &opts.Sort,
+				"direction": &opts.Direction,
+			} {
+				if *field, err = OptionalParam[string](args, name); err != nil {
+					return utils.NewToolResultError(err.Error()), nil, nil
+				}
+			}
+			pagination, err := OptionalPaginationParams(args)
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			opts.ListOptions = github.ListOptions{Page: pagination.Page, PerPage: pagination.PerPage}
+
+			client, err := deps.GetClient(ctx)
+			if err != nil {
+				return utils.NewToolResultErrorFromErr("failed to get GitHub client", err), nil, nil
+			}
+
+			milestones, resp, err := client.Issues.ListMilestones(ctx, owner, repo, opts)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to list milestones", resp, err), nil, nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			minimalMilestones := make([]MinimalMilestone, 0, len(milestones))
+			for _, milestone := range milestones {
+				minimalMilestones = append(minimalMilestones, convertToMinimalMilestone(milestone))
+			}
+			return MarshalledTextResult(minimalMilestones), nil, nil
+		},
+	)
+}
+
+// MilestoneWrite creates a tool to create, update and delete the milestones of a repository.
+func MilestoneWrite(t translations.TranslationHelperFunc) inventory.ServerTool {
+	return NewTool(
+		ToolsetMetadataIssues,
+		mcp.Tool{
+			Name:        "milestone_write",
+			Description: t("TOOL_MILESTONE_WRITE_DESCRIPTION", "Create, update or delete a milestone of a GitHub repository. To set the milestone of an issue, use the 'issue_write' tool."),
+			Annotations: &mcp.ToolAnnotations{
+				Title:        t("TOOL_MILESTONE_WRITE_USER_TITLE", "Write operations on repository milestones"),
+				ReadOnlyHint: false,
+			},
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"method": {
+						Type: "string",
+						Description: `The write operation to perform.
+Options are:
+- 'create' - create a milestone. Requires title.
+- 'update' - update the given fields of a milestone. Requires milestone_number.
+- 'delete' - delete a milestone. Its issues are kept, without a milestone. Requires milestone_number.
+`,
+						Enum: []any{"create", "update", "delete"},
+					},
+					"owner": {
+						Type:        "string",
+						Description: "Repository owner",
+					},
+					"repo": {
+						Type:        "string",
+						Description: "Repository name",
+					},
+					"milestone_number": {
+						Type:        "number",
+						Description: "Number of the milestone to update or delete",
+					},
+					"title": {
+						Type:        "string",
+						Description: "Milestone title",
+					},
+					"description": {
+						Type:        "string",
+						Description: "Milestone description",
+					},
+					"state": {
+						Type:        "string",
+						Description: "Milestone state",
+						Enum:        []any{"open", "closed"},
+					},
+					"due_on": {
+						Type:        "string",
+						Description: "Due date (ISO 8601 date or timestamp, e.g. '2026-12-31')",
+					},
+				},
+				Required: []string{"method", "owner", "repo"},
+			},
+		},
+		[]scopes.Scope{scopes.Repo},
+		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+			method, err := RequiredParam[string](args, "method")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			owner, err := RequiredParam[string](args, "owner")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			repo, err := RequiredParam[string](args, "repo")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+
+			milestone := &github.Milestone{}
+			for name, field := range map[string]**string{
+				"title":       &milestone.Title,
+				"description": &milestone.Description,
+				"state":       &milestone.State,
+			} {
+				value, ok, err := OptionalParamOK[string](args, name)
+				if err != nil {
+					return utils.NewToolResultError(err.Error()), nil, nil
+				}
+				if ok {
+					*field = github.Ptr(value)
+				}
+			}
+			dueOn, err := OptionalParam[string](args, "due_on")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			if dueOn != "" {
+				due, err := parseISOTimestamp(dueOn)
+				if err != nil {
+					return utils.NewToolResultError(fmt.Sprintf("invalid due_on: %s", err.Error())), nil, nil
+				}
+				milestone.DueOn = &github.Timestamp{Time: due}
+			}
+
+			var milestoneNumber int
+			if method != "create" {
+				if milestoneNumber, err = RequiredInt(args, "milestone_number"); err != nil {
+					return utils.NewToolResultError(err.Error()), nil, nil
+				}
+			}
+
+			client, err := deps.GetClient(ctx)
+			if err != nil {
+				return utils.NewToolResultErrorFromErr("failed to get GitHub client", err), nil, nil
+			}
+
+			switch method {
+			case "create":
+				if milestone.GetTitle() == "" {
+					return utils.NewToolResultError("missing required parameter: title"), nil, nil
+				}
+				created, resp, err := client.Issues.CreateMilestone(ctx, owner, repo, milestone)
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to create milestone", resp, err), nil, nil
+				}
+				defer func() { _ = resp.Body.Close() }()
+				return MarshalledTextResult(convertToMinimalMilestone(created)), nil, nil
+			case "update":
+				if milestone.Title == nil && milestone.Description == nil && milestone.State == nil && milestone.DueOn == nil {
+					return utils.NewToolResultError("at least one of title, description, state or due_on must be provided for update"), nil, nil
+				}
+				updated, resp, err := client.Issues.EditMilestone(ctx, owner, repo, milestoneNumber, milestone)
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx, fmt.Sprintf("failed to update milestone %d", milestoneNumber), resp, err), nil, nil
+				}
+				defer func() { _ = resp.Body.Close() }()
+				return MarshalledTextResult(convertToMinimalMilestone(updated)), nil, nil
+			case "delete":
+				resp, err := client.Issues.DeleteMilestone(ctx, owner, repo, milestoneNumber)
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx, fmt.Sprintf("failed to delete milestone %d", milestoneNumber), resp, err), nil, nil
+				}
+				defer func() { _ = resp.Body.Close() }()
+				return utils.NewToolResultText(fmt.Sprintf("milestone %d deleted successfully", milestoneNumber)), nil, nil
+			default:
+				return utils.NewToolResultError(fmt.Sprintf("unknown method: %s", method)), nil, nil
+			}
+		},
+	)
+}
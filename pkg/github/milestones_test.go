@@ -0,0 +1,163 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v82/github"
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	GetReposMilestonesByOwnerByRepo                     = "GET /repos/{owner}/{repo}/milestones"
+	PostReposMilestonesByOwnerByRepo                    = "POST /repos/{owner}/{repo}/milestones"
+	PatchReposMilestonesByOwnerByRepoByMilestoneNumber  = "PATCH /repos/{owner}/{repo}/milestones/{milestone_number}"
+	DeleteReposMilestonesByOwnerByRepoByMilestoneNumber = "DELETE /repos/{owner}/{repo}/milestones/{milestone_number}"
+)
+
+func Test_ListMilestones(t *testing.T) {
+	serverTool := ListMilestones(translations.NullTranslationHelper)
+	tool := serverTool.Tool
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_milestones", tool.Name)
+	assert.True(t, tool.Annotations.ReadOnlyHint)
+	schema := tool.InputSchema.(*jsonschema.Schema)
+	assert.ElementsMatch(t, schema.Required, []string{"owner", "repo"})
+
+	deps := BaseDeps{Client: github.NewClient(MockHTTPClientWithHandlers(map[string]http.HandlerFunc{
+		GetReposMilestonesByOwnerByRepo: expectQueryParams(t, map[string]string{
+			"state":     "all",
+			"sort":      "completeness",
+			"direction": "desc",
+			"page":      "1",
+			"per_page":  "30",
+		}).andThen(mockResponse(t, http.StatusOK, []*github.Milestone{
+			{
+				Number:       github.Ptr(3),
+				Title:        github.Ptr("v2.0"),
+				State:        github.Ptr("open"),
+				OpenIssues:   github.Ptr(4),
+				ClosedIssues: github.Ptr(6),
+				DueOn:        &github.Timestamp{Time: time.Date(2026, 12, 31, 8, 0, 0, 0, time.UTC)},
+				HTMLURL:      github.Ptr("https://github.com/owner/repo/milestone/3"),
+			},
+		})),
+	}))}
+
+	request := createMCPRequest(map[string]any{"owner": "owner", "repo": "repo", "state": "all", "sort": "completeness", "direction": "desc"})
+	result, err := serverTool.Handler(deps)(ContextWithDeps(context.Background(), deps), &request)
+	require.NoError(t, err)
+	require.False(t, result.IsError, getTextResult(t, result).Text)
+
+	var milestones []MinimalMilestone
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &milestones))
+	assert.Equal(t, []MinimalMilestone{{
+		Number:       3,
+		Title:        "v2.0",
+		State:        "open",
+		DueOn:        "2026-12-31T08:00:00Z",
+		OpenIssues:   4,
+		ClosedIssues: 6,
+		HTMLURL:      "https://github.com/owner/repo/milestone/3",
+	}}, milestones)
+}
+
+func Test_MilestoneWrite(t *testing.T) {
+	serverTool := MilestoneWrite(translations.NullTranslationHelper)
+	tool := serverTool.Tool
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "milestone_write", tool.Name)
+	assert.False(t, tool.Annotations.ReadOnlyHint)
+	schema := tool.InputSchema.(*jsonschema.Schema)
+	assert.ElementsMatch(t, schema.Required, []string{"method", "owner", "repo"})
+
+	tests := []struct {
+		name           string
+		handlers       map[string]http.HandlerFunc
+		args           map[string]any
+		expectedText   string
+		expectedErrMsg string
+	}{
+		{
+			name: "create milestone",
+			handlers: map[string]http.HandlerFunc{
+				PostReposMilestonesByOwnerByRepo: expectRequestBody(t, map[string]any{
+					"title":  "v2.0",
+					"due_on": "2026-12-31T00:00:00Z",
+				}).andThen(mockResponse(t, http.StatusCreated, &github.Milestone{Number: github.Ptr(3), Title: github.Ptr("v2.0"), State: github.Ptr("open")})),
+			},
+			args:         map[string]any{"method": "create", "owner": "owner", "repo": "repo", "title": "v2.0", "due_on": "2026-12-31"},
+			expectedText: `"number":3`,
+		},
+		{
+			name: "close milestone",
+			handlers: map[string]http.HandlerFunc{
+				PatchReposMilestonesByOwnerByRepoByMilestoneNumber: expectRequestBody(t, map[string]any{
+					"state": "closed",
+				}).andThen(mockResponse(t, http.StatusOK, &github.Milestone{Number: github.Ptr(3), Title: github.Ptr("v2.0"), State: github.Ptr("closed")})),
+			},
+			args:         map[string]any{"method": "update", "owner": "owner", "repo": "repo", "milestone_number": float64(3), "state": "closed"},
+			expectedText: `"state":"closed"`,
+		},
+		{
+			name: "delete milestone",
+			handlers: map[string]http.HandlerFunc{
+				DeleteReposMilestonesByOwnerByRepoByMilestoneNumber: func(w http.ResponseWriter, _ *http.Request) {
+					w.WriteHeader(http.StatusNoContent)
+				},
+			},
+			args:         map[string]any{"method": "delete", "owner": "owner", "repo": "repo", "milestone_number": float64(3)},
+			expectedText: "milestone 3 deleted successfully",
+		},
+		{
+			name:           "create without title",
+			handlers:       map[string]http.HandlerFunc{},
+			args:           map[string]any{"method": "create", "owner": "owner", "repo": "repo"},
+			expectedErrMsg: "missing required parameter: title",
+		},
+		{
+			name:           "update without milestone number",
+			handlers:       map[string]http.HandlerFunc{},
+			args:           map[string]any{"method": "update", "owner": "owner", "repo": "repo", "state": "closed"},
+			expectedErrMsg: "missing required parameter: milestone_number",
+		},
+		{
+			name:           "update without fields",
+			handlers:       map[string]http.HandlerFunc{},
+			args:           map[string]any{"method": "update", "owner": "owner", "repo": "repo", "milestone_number": float64(3)},
+			expectedErrMsg: "at least one of title, description, state or due_on",
+		},
+		{
+			name: "milestone not found",
+			handlers: map[string]http.HandlerFunc{
+				DeleteReposMilestonesByOwnerByRepoByMilestoneNumber: mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+			},
+			args:           map[string]any{"method": "delete", "owner": "owner", "repo": "repo", "milestone_number": float64(9)},
+			expectedErrMsg: "failed to delete milestone 9",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			deps := BaseDeps{Client: github.NewClient(MockHTTPClientWithHandlers(tc.handlers))}
+			request := createMCPRequest(tc.args)
+			result, err := serverTool.Handler(deps)(ContextWithDeps(context.Background(), deps), &request)
+			require.NoError(t, err)
+			if tc.expectedErrMsg != "" {
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+			require.False(t, result.IsError, getTextResult(t, result).Text)
+			assert.Contains(t, getTextResult(t, result).Text, tc.expectedText)
+		})
+	}
+}
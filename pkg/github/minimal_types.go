@@ -191,6 +191,14 @@ type MinimalIssue struct {
 	ClosedAt          string            `json:"closed_at,omitempty"`
 	ClosedBy          string            `json:"closed_by,omitempty"`
 	IssueType         string            `json:"issue_type,omitempty"`
+	// ProjectStatuses lists the Projects v2 the issue is in, with its Status field in each
+	ProjectStatuses []MinimalProjectStatus `json:"project_statuses,omitempty"`
+}
+
+// MinimalProjectStatus is the value of the Status field of an issue in a Projects v2 project.
+type MinimalProjectStatus struct {
+	Project string `json:"project"`
+	Status  string `json:"status,omitempty"`
 }
 
 // MinimalIssuesResponse is the trimmed output for a paginated list of issues.
@@ -301,6 +309,36 @@ type MinimalPullRequestReview struct {
 
 // Helper functions
 
+func convertToMinimalRepository(repo *github.Repository) MinimalRepository {
+	minimalRepo := MinimalRepository{
+		ID:            repo.GetID(),
+		Name:          repo.GetName(),
+		FullName:      repo.GetFullName(),
+		Description:   repo.GetDescription(),
+		HTMLURL:       repo.GetHTMLURL(),
+		Language:      repo.GetLanguage(),
+		Stars:         repo.GetStargazersCount(),
+		Forks:         repo.GetForksCount(),
+		OpenIssues:    repo.GetOpenIssuesCount(),
+		Private:       repo.GetPrivate(),
+		Fork:          repo.GetFork(),
+		Archived:      repo.GetArchived(),
+		DefaultBranch: repo.GetDefaultBranch(),
+	}
+
+	if repo.UpdatedAt != nil {
+		minimalRepo.UpdatedAt = repo.UpdatedAt.Format("2006-01-02T15:04:05Z")
+	}
+	if repo.CreatedAt != nil {
+		minimalRepo.CreatedAt = repo.CreatedAt.Format("2006-01-02T15:04:05Z")
+	}
+	if repo.Topics != nil {
+		minimalRepo.Topics = repo.Topics
+	}
+
+	return minimalRepo
+}
+
 func convertToMinimalPullRequestReview(review *github.PullRequestReview) MinimalPullRequestReview {
 	m := MinimalPullRequestReview{
 		ID:                review.GetID(),
@@ -403,6 +441,10 @@ func fragmentToMinimalIssue(fragment IssueFragment) MinimalIssue {
 		m.Labels = append(m.Labels, string(label.Name))
 	}
 
+	if fragment.IssueType != nil {
+		m.IssueType = string(fragment.IssueType.Name)
+	}
+
 	return m
 }
 
@@ -717,6 +759,7 @@ type MinimalReviewComment struct {
 
 // MinimalReviewThread is the trimmed output type for PR review thread objects.
 type MinimalReviewThread struct {
+	ID          string                 `json:"id"`
 	IsResolved  bool                   `json:"is_resolved"`
 	IsOutdated  bool                   `json:"is_outdated"`
 	IsCollapsed bool                   `json:"is_collapsed"`
@@ -797,6 +840,9 @@ type MinimalCheckRun struct {
 	DetailsURL  string `json:"details_url,omitempty"`
 	StartedAt   string `json:"started_at,omitempty"`
 	CompletedAt string `json:"completed_at,omitempty"`
+	// CheckSuiteID is the check suite the run belongs to, which can be re-requested.
+	CheckSuiteID int64  `json:"check_suite_id,omitempty"`
+	App          string `json:"app,omitempty"`
 }
 
 // MinimalCheckRunsResult is the trimmed output type for check runs list results.
@@ -808,12 +854,14 @@ type MinimalCheckRunsResult struct {
 // convertToMinimalCheckRun converts a GitHub API CheckRun to MinimalCheckRun
 func convertToMinimalCheckRun(checkRun *github.CheckRun) MinimalCheckRun {
 	minimalCheckRun := MinimalCheckRun{
-		ID:         checkRun.GetID(),
-		Name:       checkRun.GetName(),
-		Status:     checkRun.GetStatus(),
-		Conclusion: checkRun.GetConclusion(),
-		HTMLURL:    checkRun.GetHTMLURL(),
-		DetailsURL: checkRun.GetDetailsURL(),
+		ID:           checkRun.GetID(),
+		Name:         checkRun.GetName(),
+		Status:       checkRun.GetStatus(),
+		Conclusion:   checkRun.GetConclusion(),
+		HTMLURL:      checkRun.GetHTMLURL(),
+		DetailsURL:   checkRun.GetDetailsURL(),
+		CheckSuiteID: checkRun.GetCheckSuite().GetID(),
+		App:          checkRun.GetApp().GetSlug(),
 	}
 
 	if checkRun.StartedAt != nil {
@@ -852,7 +900,9 @@ func convertToMinimalReviewThread(thread reviewThreadNode) MinimalReviewThread {
 		comments = append(comments, convertToMinimalReviewComment(c))
 	}
 
+	id, _ := thread.ID.(string)
 	return MinimalReviewThread{
+		ID:          id,
 		IsResolved:  bool(thread.IsResolved),
 		IsOutdated:  bool(thread.IsOutdated),
 		IsCollapsed: bool(thread.IsCollapsed),
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"slices"
 	"strconv"
 	"time"
 
@@ -25,6 +26,13 @@ const (
 	FilterOnlyParticipating = "only_participating"
 )
 
+// notificationReasons are the reasons GitHub gives for sending a notification.
+var notificationReasons = []any{
+	"approval_requested", "assign", "author", "ci_activity", "comment", "invitation", "manual",
+	"member_feature_requested", "mention", "review_requested", "security_advisory_credit",
+	"security_alert", "state_change", "subscribed", "team_mention",
+}
+
 // ListNotifications creates a tool to list notifications for the current user.
 func ListNotifications(t translations.TranslationHelperFunc) inventory.ServerTool {
 	return NewTool(
@@ -60,6 +68,14 @@ func ListNotifications(t translations.TranslationHelperFunc) inventory.ServerToo
 						Type:        "string",
 						Description: "Optional repository name. If provided with owner, only notifications for this repository are listed.",
 					},
+					"reason": {
+						Type:        "array",
+						Description: "Only list notifications sent for these reasons, such as review_requested or mention. Filtering applies to each page of results.",
+						Items: &jsonschema.Schema{
+							Type: "string",
+							Enum: notificationReasons,
+						},
+					},
 				},
 			}),
 		},
@@ -93,6 +109,10 @@ func ListNotifications(t translations.TranslationHelperFunc) inventory.ServerToo
 			if err != nil {
 				return utils.NewToolResultError(err.Error()), nil, nil
 			}
+			reasons, err := OptionalStringArrayParam(args, "reason")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
 
 			paginationParams, err := OptionalPaginationParams(args)
 			if err != nil {
@@ -151,6 +171,12 @@ func ListNotifications(t translations.TranslationHelperFunc) inventory.ServerToo
 				return ghErrors.NewGitHubAPIStatusErrorResponse(ctx, "failed to get notifications", resp, body), nil, nil
 			}
 
+			if len(reasons) > 0 {
+				notifications = slices.DeleteFunc(notifications, func(n *github.Notification) bool {
+					return !slices.Contains(reasons, n.GetReason())
+				})
+			}
+
 			// Marshal response to JSON
 			r, err := json.Marshal(notifications)
 			if err != nil {
@@ -30,6 +30,7 @@ func Test_ListNotifications(t *testing.T) {
 	assert.Contains(t, schema.Properties, "before")
 	assert.Contains(t, schema.Properties, "owner")
 	assert.Contains(t, schema.Properties, "repo")
+	assert.Contains(t, schema.Properties, "reason")
 	assert.Contains(t, schema.Properties, "page")
 	assert.Contains(t, schema.Properties, "perPage")
 	// All fields are optional, so Required should be empty
@@ -95,6 +96,21 @@ func Test_ListNotifications(t *testing.T) {
 			expectError:    false,
 			expectedResult: []*github.Notification{mockNotification},
 		},
+		{
+			name: "success filtered by reason",
+			mockedClient: MockHTTPClientWithHandlers(map[string]http.HandlerFunc{
+				GetNotifications: mockResponse(t, http.StatusOK, []*github.Notification{
+					{ID: github.Ptr("122"), Reason: github.Ptr("subscribed")},
+					mockNotification,
+					{ID: github.Ptr("124"), Reason: github.Ptr("ci_activity")},
+				}),
+			}),
+			requestArgs: map[string]any{
+				"reason": []any{"mention", "review_requested"},
+			},
+			expectError:    false,
+			expectedResult: []*github.Notification{mockNotification},
+		},
 		{
 			name: "error",
 			mockedClient: MockHTTPClientWithHandlers(map[string]http.HandlerFunc{
@@ -132,7 +148,7 @@ func Test_ListNotifications(t *testing.T) {
 			var returned []*github.Notification
 			err = json.Unmarshal([]byte(textContent.Text), &returned)
 			require.NoError(t, err)
-			require.NotEmpty(t, returned)
+			require.Len(t, returned, len(tc.expectedResult))
 			assert.Equal(t, *tc.expectedResult[0].ID, *returned[0].ID)
 		})
 	}
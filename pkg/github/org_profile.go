@@ -0,0 +1,419 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/inventory"
+	"github.com/github/github-mcp-server/pkg/scopes"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/github/github-mcp-server/pkg/utils"
+	"github.com/google/go-github/v82/github"
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/shurcooL/githubv4"
+)
+
+// orgProfileREADMEPath is the path of the profile README in the repositories holding it.
+const orgProfileREADMEPath = "profile/README.md"
+
+// orgProfileREADMERepos maps the visibility of an organization's profile README to the
+// repository holding it: the public one is shown to everyone, the members one only to members.
+var orgProfileREADMERepos = map[string]string{
+	"public":  ".github",
+	"members": ".github-private",
+}
+
+// OrgProfileREADME is a profile README of an organization.
+type OrgProfileREADME struct {
+	Repository string `json:"repository"`
+	Path       string `json:"path"`
+	SHA        string `json:"sha"`
+	URL        string `json:"url"`
+	Content    string `json:"content"`
+}
+
+// PinnedRepository is a repository pinned to the profile of an organization.
+type PinnedRepository struct {
+	Repository  string     `json:"repository"`
+	Description string     `json:"description,omitempty"`
+	URL         string     `json:"url"`
+	Stars       int        `json:"stars"`
+	Archived    bool       `json:"archived"`
+	PushedAt    *time.Time `json:"pushed_at,omitempty"`
+}
+
+// OrgProfile is the landing page of an organization: its profile fields, READMEs and pinned
+// repositories.
+type OrgProfile struct {
+	Org                string             `json:"org"`
+	URL                string             `json:"url"`
+	Name               string             `json:"name,omitempty"`
+	Description        string             `json:"description,omitempty"`
+	Blog               string             `json:"blog,omitempty"`
+	Location           string             `json:"location,omitempty"`
+	Email              string             `json:"email,omitempty"`
+	TwitterUsername    string             `json:"twitter_username,omitempty"`
+	PublicREADME       *OrgProfileREADME  `json:"public_readme,omitempty"`
+	MembersREADME      *OrgProfileREADME  `json:"members_readme,omitempty"`
+	PinnedRepositories []PinnedRepository `json:"pinned_repositories"`
+}
+
+// orgPinnedItemsQuery lists the repositories pinned to the profile of an organization.
+type orgPinnedItemsQuery struct {
+	Organization struct {
+		PinnedItems struct {
+			Nodes []struct {
+				Repository struct {
+					NameWithOwner  githubv4.String
+					Description    githubv4.String
+					URL            githubv4.URI
+					StargazerCount githubv4.Int
+					IsArchived     githubv4.Boolean
+					PushedAt       *githubv4.DateTime
+				} `graphql:"... on Repository"`
+			}
+		} `graphql:"pinnedItems(first: 6, types: [REPOSITORY])"`
+	} `graphql:"organization(login: $org)"`
+}
+
+// getOrgProfileREADME returns the profile README of an organization with the given visibility,
+// or nil when it has none.
+func getOrgProfileREADME(ctx context.Context, client *github.Client, org, visibility string) (*OrgProfileREADME, *github.Response, error) {
+	repo := orgProfileREADMERepos[visibility]
+	file, _, resp, err := client.Repositories.GetContents(ctx, org, repo, orgProfileREADMEPath, nil)
+	if resp != nil {
+		_ = resp.Body.Close()
+	}
+	if err != nil {
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			return nil, nil, nil
+		}
+		return nil, resp, err
+	}
+	if file == nil {
+		return nil, nil, nil
+	}
+	content, err := file.GetContent()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to decode the %s profile README: %w", visibility, err)
+	}
+	return &OrgProfileREADME{
+		Repository: org + "/" + repo,
+		Path:       orgProfileREADMEPath,
+		SHA:        file.GetSHA(),
+		URL:        file.GetHTMLURL(),
+		Content:    content,
+	}, nil, nil
+}
+
+// GetOrgProfile creates a tool to read the landing page of an organization.
+func GetOrgProfile(t translations.TranslationHelperFunc) inventory.ServerTool {
+	return NewTool(
+		ToolsetMetadataOrgs,
+		mcp.Tool{
+			Name: "get_org_profile",
+			Description: t("TOOL_GET_ORG_PROFILE_DESCRIPTION", `Get the landing page of an organization: its profile fields, its public profile README (.github/profile/README.md), its members-only profile README (.github-private/profile/README.md) and its pinned repositories.
+Use update_org_profile and update_org_profile_readme to change them. Pinned repositories cannot be changed through the API.`),
+			Annotations: &mcp.ToolAnnotations{
+				Title:        t("TOOL_GET_ORG_PROFILE_USER_TITLE", "Get organization profile"),
+				ReadOnlyHint: true,
+			},
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"org": {
+						Type:        "string",
+						Description: "Organization login",
+					},
+				},
+				Required: []string{"org"},
+			},
+		},
+		[]scopes.Scope{scopes.Repo, scopes.ReadOrg},
+		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+			org, err := RequiredParam[string](args, "org")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+
+			client, err := deps.GetClient(ctx)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			gqlClient, err := deps.GetGQLClient(ctx)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to get GitHub GraphQL client: %w", err)
+			}
+
+			organization, resp, err := client.Organizations.Get(ctx, org)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to get organization", resp, err), nil, nil
+			}
+			_ = resp.Body.Close()
+
+			profile := OrgProfile{
+				Org:                organization.GetLogin(),
+				URL:                organization.GetHTMLURL(),
+				Name:               organization.GetName(),
+				Description:        organization.GetDescription(),
+				Blog:               organization.GetBlog(),
+				Location:           organization.GetLocation(),
+				Email:              organization.GetEmail(),
+				TwitterUsername:    organization.GetTwitterUsername(),
+				PinnedRepositories: []PinnedRepository{},
+			}
+			for visibility, readme := range map[string]**OrgProfileREADME{"public": &profile.PublicREADME, "members": &profile.MembersREADME} {
+				*readme, resp, err = getOrgProfileREADME(ctx, client, org, visibility)
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx, fmt.Sprintf("failed to get the %s profile README", visibility), resp, err), nil, nil
+				}
+			}
+
+			var query orgPinnedItemsQuery
+			if err := gqlClient.Query(ctx, &query, map[string]any{"org": githubv4.String(org)}); err != nil {
+				return ghErrors.NewGitHubGraphQLErrorResponse(ctx, "failed to get pinned repositories", err), nil, nil
+			}
+			for _, node := range query.Organization.PinnedItems.Nodes {
+				repo := node.Repository
+				if repo.NameWithOwner == "" {
+					continue
+				}
+				pinned := PinnedRepository{
+					Repository:  string(repo.NameWithOwner),
+					Description: string(repo.Description),
+					URL:         repo.URL.String(),
+					Stars:       int(repo.StargazerCount),
+					Archived:    bool(repo.IsArchived),
+				}
+				if repo.PushedAt != nil {
+					pinned.PushedAt = &repo.PushedAt.Time
+				}
+				profile.PinnedRepositories = append(profile.PinnedRepositories, pinned)
+			}
+
+			return MarshalledTextResult(profile), nil, nil
+		},
+	)
+}
+
+// UpdateOrgProfile creates a tool to update the profile fields of an organization.
+func UpdateOrgProfile(t translations.TranslationHelperFunc) inventory.ServerTool {
+	fields := []string{"name", "description", "blog", "location", "email", "twitter_username"}
+	return NewTool(
+		ToolsetMetadataOrgs,
+		mcp.Tool{
+			Name:        "update_org_profile",
+			Description: t("TOOL_UPDATE_ORG_PROFILE_DESCRIPTION", "Update the profile fields shown on the landing page of an organization. Only the fields given are changed, and an empty string clears a field. Requires organization owner access."),
+			Annotations: &mcp.ToolAnnotations{
+				Title:          t("TOOL_UPDATE_ORG_PROFILE_USER_TITLE", "Update organization profile"),
+				ReadOnlyHint:   false,
+				IdempotentHint: true,
+			},
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"org": {
+						Type:        "string",
+						Description: "Organization login",
+					},
+					"name": {
+						Type:        "string",
+						Description: "Display name of the organization",
+					},
+					"description": {
+						Type:        "string",
+						Description: "Short description of the organization",
+					},
+					"blog": {
+						Type:        "string",
+						Description: "URL of the organization's website",
+					},
+					"location": {
+						Type:        "string",
+						Description: "Location of the organization",
+					},
+					"email": {
+						Type:        "string",
+						Description: "Publicly visible email address",
+					},
+					"twitter_username": {
+						Type:        "string",
+						Description: "Twitter username of the organization, without @",
+					},
+				},
+				Required: []string{"org"},
+			},
+		},
+		[]scopes.Scope{scopes.AdminOrg},
+		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+			org, err := RequiredParam[string](args, "org")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			values := make(map[string]*string, len(fields))
+			for _, field := range fields {
+				value, ok, err := OptionalParamOK[string](args, field)
+				if err != nil {
+					return utils.NewToolResultError(err.Error()), nil, nil
+				}
+				if ok {
+					values[field] = github.Ptr(value)
+				}
+			}
+			if len(values) == 0 {
+				return utils.NewToolResultError("at least one of name, description, blog, location, email or twitter_username must be given"), nil, nil
+			}
+
+			client, err := deps.GetClient(ctx)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			organization, resp, err := client.Organizations.Edit(ctx, org, &github.Organization{
+				Name:            values["name"],
+				Description:     values["description"],
+				Blog:            values["blog"],
+				Location:        values["location"],
+				Email:           values["email"],
+				TwitterUsername: values["twitter_username"],
+			})
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to update organization profile", resp, err), nil, nil
+			}
+			_ = resp.Body.Close()
+
+			return MarshalledTextResult(OrgProfile{
+				Org:                organization.GetLogin(),
+				URL:                organization.GetHTMLURL(),
+				Name:               organization.GetName(),
+				Description:        organization.GetDescription(),
+				Blog:               organization.GetBlog(),
+				Location:           organization.GetLocation(),
+				Email:              organization.GetEmail(),
+				TwitterUsername:    organization.GetTwitterUsername(),
+				PinnedRepositories: []PinnedRepository{},
+			}), nil, nil
+		},
+	)
+}
+
+// UpdateOrgProfileREADME creates a tool to write the profile README of an organization.
+func UpdateOrgProfileREADME(t translations.TranslationHelperFunc) inventory.ServerTool {
+	return NewTool(
+		ToolsetMetadataOrgs,
+		mcp.Tool{
+			Name: "update_org_profile_readme",
+			Description: t("TOOL_UPDATE_ORG_PROFILE_README_DESCRIPTION", `Replace the profile README of an organization, committing it to the default branch of the repository holding it: .github for the public README, or .github-private for the one only members see. The README is created when the repository has none, but the repository must exist.
+Get the current README with get_org_profile first, and pass its sha to avoid overwriting changes made since.`),
+			Annotations: &mcp.ToolAnnotations{
+				Title:        t("TOOL_UPDATE_ORG_PROFILE_README_USER_TITLE", "Update organization profile README"),
+				ReadOnlyHint: false,
+			},
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"org": {
+						Type:        "string",
+						Description: "Organization login",
+					},
+					"content": {
+						Type:        "string",
+						Description: "New Markdown content of the README",
+					},
+					"visibility": {
+						Type:        "string",
+						Description: "Which README to update: public, shown to everyone, or members, shown only to organization members",
+						Enum:        []any{"public", "members"},
+						Default:     json.RawMessage(`"public"`),
+					},
+					"sha": {
+						Type:        "string",
+						Description: "SHA of the README being replaced, as returned by get_org_profile. The update fails when the README changed since. Defaults to the current README",
+					},
+					"message": {
+						Type:        "string",
+						Description: "Commit message",
+					},
+				},
+				Required: []string{"org", "content"},
+			},
+		},
+		[]scopes.Scope{scopes.Repo},
+		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+			org, err := RequiredParam[string](args, "org")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			content, err := RequiredParam[string](args, "content")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			visibility, err := OptionalParam[string](args, "visibility")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			if visibility == "" {
+				visibility = "public"
+			}
+			repo, ok := orgProfileREADMERepos[visibility]
+			if !ok {
+				return utils.NewToolResultError(fmt.Sprintf("invalid visibility %q: use public or members", visibility)), nil, nil
+			}
+			sha, err := OptionalParam[string](args, "sha")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			message, err := OptionalParam[string](args, "message")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			if message == "" {
+				message = "Update organization profile README"
+			}
+
+			client, err := deps.GetClient(ctx)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			if sha == "" {
+				current, resp, err := getOrgProfileREADME(ctx, client, org, visibility)
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx, fmt.Sprintf("failed to get the %s profile README", visibility), resp, err), nil, nil
+				}
+				if current != nil {
+					sha = current.SHA
+				}
+			}
+
+			opts := &github.RepositoryContentFileOptions{
+				Message: github.Ptr(message),
+				Content: []byte(content),
+			}
+			if sha != "" {
+				opts.SHA = github.Ptr(sha)
+			}
+			result, resp, err := client.Repositories.CreateFile(ctx, org, repo, orgProfileREADMEPath, opts)
+			if err != nil {
+				if resp != nil && resp.StatusCode == http.StatusNotFound {
+					return utils.NewToolResultError(fmt.Sprintf("%s/%s does not exist or is not accessible: create the repository to hold the %s profile README", org, repo, visibility)), nil, nil
+				}
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, fmt.Sprintf("failed to write the %s profile README", visibility), resp, err), nil, nil
+			}
+			_ = resp.Body.Close()
+
+			return MarshalledTextResult(OrgProfileREADME{
+				Repository: org + "/" + repo,
+				Path:       orgProfileREADMEPath,
+				SHA:        result.GetContent().GetSHA(),
+				URL:        result.GetContent().GetHTMLURL(),
+				Content:    content,
+			}), nil, nil
+		},
+	)
+}
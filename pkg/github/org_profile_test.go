@@ -0,0 +1,223 @@
+package github
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/githubv4mock"
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v82/github"
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/shurcooL/githubv4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	GetReposContentsProfileREADME = "GET /repos/{owner}/{repo}/contents/profile/README.md"
+	PutReposContentsProfileREADME = "PUT /repos/{owner}/{repo}/contents/profile/README.md"
+)
+
+// orgProfileREADMEHandler mocks the public profile README of octo-org, which has no members one.
+func orgProfileREADMEHandler(t *testing.T) http.HandlerFunc {
+	t.Helper()
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/repos/octo-org/.github/contents/profile/README.md" {
+			mockResponse(t, http.StatusNotFound, map[string]string{"message": "Not Found"})(w, r)
+			return
+		}
+		mockResponse(t, http.StatusOK, &github.RepositoryContent{
+			Type:     github.Ptr("file"),
+			Encoding: github.Ptr("base64"),
+			Content:  github.Ptr(base64.StdEncoding.EncodeToString([]byte("# Welcome to Octo\n"))),
+			SHA:      github.Ptr("readme123"),
+			HTMLURL:  github.Ptr("https://github.com/octo-org/.github/blob/main/profile/README.md"),
+		})(w, r)
+	}
+}
+
+func Test_GetOrgProfile(t *testing.T) {
+	serverTool := GetOrgProfile(translations.NullTranslationHelper)
+	tool := serverTool.Tool
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_org_profile", tool.Name)
+	assert.True(t, tool.Annotations.ReadOnlyHint)
+	schema := tool.InputSchema.(*jsonschema.Schema)
+	assert.ElementsMatch(t, schema.Required, []string{"org"})
+
+	restClient := github.NewClient(MockHTTPClientWithHandlers(map[string]http.HandlerFunc{
+		GetOrgsByOrg: mockResponse(t, http.StatusOK, &github.Organization{
+			Login:       github.Ptr("octo-org"),
+			Name:        github.Ptr("Octo"),
+			Description: github.Ptr("Tools for octopuses"),
+			Blog:        github.Ptr("https://octo.example.com"),
+			HTMLURL:     github.Ptr("https://github.com/octo-org"),
+		}),
+		GetReposContentsProfileREADME: orgProfileREADMEHandler(t),
+	}))
+	gqlClient := githubv4.NewClient(githubv4mock.NewMockedHTTPClient(
+		githubv4mock.NewQueryMatcher(
+			orgPinnedItemsQuery{},
+			map[string]any{"org": githubv4.String("octo-org")},
+			githubv4mock.DataResponse(map[string]any{
+				"organization": map[string]any{
+					"pinnedItems": map[string]any{
+						"nodes": []map[string]any{
+							{
+								"nameWithOwner":  "octo-org/ink",
+								"description":    "Octopus ink",
+								"url":            "https://github.com/octo-org/ink",
+								"stargazerCount": 42,
+								"isArchived":     false,
+								"pushedAt":       "2026-09-01T00:00:00Z",
+							},
+							{
+								"nameWithOwner":  "octo-org/legacy",
+								"description":    "",
+								"url":            "https://github.com/octo-org/legacy",
+								"stargazerCount": 3,
+								"isArchived":     true,
+								"pushedAt":       nil,
+							},
+						},
+					},
+				},
+			}),
+		),
+	))
+	deps := BaseDeps{Client: restClient, GQLClient: gqlClient}
+
+	request := createMCPRequest(map[string]any{"org": "octo-org"})
+	result, err := serverTool.Handler(deps)(ContextWithDeps(context.Background(), deps), &request)
+	require.NoError(t, err)
+	require.False(t, result.IsError, getTextResult(t, result).Text)
+
+	var profile OrgProfile
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &profile))
+	assert.Equal(t, "octo-org", profile.Org)
+	assert.Equal(t, "Octo", profile.Name)
+	assert.Equal(t, "https://octo.example.com", profile.Blog)
+	require.NotNil(t, profile.PublicREADME)
+	assert.Equal(t, OrgProfileREADME{
+		Repository: "octo-org/.github",
+		Path:       "profile/README.md",
+		SHA:        "readme123",
+		URL:        "https://github.com/octo-org/.github/blob/main/profile/README.md",
+		Content:    "# Welcome to Octo\n",
+	}, *profile.PublicREADME)
+	assert.Nil(t, profile.MembersREADME)
+	require.Len(t, profile.PinnedRepositories, 2)
+	assert.Equal(t, "octo-org/ink", profile.PinnedRepositories[0].Repository)
+	assert.Equal(t, 42, profile.PinnedRepositories[0].Stars)
+	assert.NotNil(t, profile.PinnedRepositories[0].PushedAt)
+	assert.True(t, profile.PinnedRepositories[1].Archived)
+}
+
+func Test_UpdateOrgProfile(t *testing.T) {
+	serverTool := UpdateOrgProfile(translations.NullTranslationHelper)
+	tool := serverTool.Tool
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "update_org_profile", tool.Name)
+	assert.False(t, tool.Annotations.ReadOnlyHint)
+	schema := tool.InputSchema.(*jsonschema.Schema)
+	assert.ElementsMatch(t, schema.Required, []string{"org"})
+
+	deps := BaseDeps{Client: github.NewClient(MockHTTPClientWithHandlers(map[string]http.HandlerFunc{
+		PatchOrgsByOrg: expectRequestBody(t, map[string]any{
+			"description": "Tools for octopuses and squids",
+			"location":    "",
+		}).andThen(mockResponse(t, http.StatusOK, &github.Organization{
+			Login:       github.Ptr("octo-org"),
+			Description: github.Ptr("Tools for octopuses and squids"),
+		})),
+	}))}
+
+	request := createMCPRequest(map[string]any{"org": "octo-org", "description": "Tools for octopuses and squids", "location": ""})
+	result, err := serverTool.Handler(deps)(ContextWithDeps(context.Background(), deps), &request)
+	require.NoError(t, err)
+	require.False(t, result.IsError, getTextResult(t, result).Text)
+	var profile OrgProfile
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &profile))
+	assert.Equal(t, "Tools for octopuses and squids", profile.Description)
+
+	request = createMCPRequest(map[string]any{"org": "octo-org"})
+	result, err = serverTool.Handler(deps)(ContextWithDeps(context.Background(), deps), &request)
+	require.NoError(t, err)
+	assert.Contains(t, getErrorResult(t, result).Text, "at least one of name, description")
+}
+
+func Test_UpdateOrgProfileREADME(t *testing.T) {
+	serverTool := UpdateOrgProfileREADME(translations.NullTranslationHelper)
+	tool := serverTool.Tool
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "update_org_profile_readme", tool.Name)
+	assert.False(t, tool.Annotations.ReadOnlyHint)
+	schema := tool.InputSchema.(*jsonschema.Schema)
+	assert.ElementsMatch(t, schema.Required, []string{"org", "content"})
+
+	tests := []struct {
+		name           string
+		args           map[string]any
+		put            http.HandlerFunc
+		expectedErrMsg string
+	}{
+		{
+			name: "replaces the public README",
+			args: map[string]any{"org": "octo-org", "content": "# Hello, Octo\n"},
+			put: expectRequestBody(t, map[string]any{
+				"message": "Update organization profile README",
+				"content": base64.StdEncoding.EncodeToString([]byte("# Hello, Octo\n")),
+				"sha":     "readme123",
+			}).andThen(mockResponse(t, http.StatusOK, &github.RepositoryContentResponse{
+				Content: &github.RepositoryContent{SHA: github.Ptr("readme456")},
+			})),
+		},
+		{
+			name: "creates the members README",
+			args: map[string]any{"org": "octo-org", "content": "# Members\n", "visibility": "members", "message": "Add members README"},
+			put: func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/repos/octo-org/.github-private/contents/profile/README.md", r.URL.Path)
+				expectRequestBody(t, map[string]any{
+					"message": "Add members README",
+					"content": base64.StdEncoding.EncodeToString([]byte("# Members\n")),
+				}).andThen(mockResponse(t, http.StatusCreated, &github.RepositoryContentResponse{
+					Content: &github.RepositoryContent{SHA: github.Ptr("readme456")},
+				}))(w, r)
+			},
+		},
+		{
+			name:           "repository missing",
+			args:           map[string]any{"org": "octo-org", "content": "# Members\n", "visibility": "members"},
+			put:            mockResponse(t, http.StatusNotFound, map[string]string{"message": "Not Found"}),
+			expectedErrMsg: "octo-org/.github-private does not exist or is not accessible",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			deps := BaseDeps{Client: github.NewClient(MockHTTPClientWithHandlers(map[string]http.HandlerFunc{
+				GetReposContentsProfileREADME: orgProfileREADMEHandler(t),
+				PutReposContentsProfileREADME: tc.put,
+			}))}
+			request := createMCPRequest(tc.args)
+			result, err := serverTool.Handler(deps)(ContextWithDeps(context.Background(), deps), &request)
+			require.NoError(t, err)
+			if tc.expectedErrMsg != "" {
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+			require.False(t, result.IsError, getTextResult(t, result).Text)
+			var readme OrgProfileREADME
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &readme))
+			assert.Equal(t, "readme456", readme.SHA)
+			assert.Equal(t, tc.args["content"], readme.Content)
+		})
+	}
+}
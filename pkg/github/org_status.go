@@ -0,0 +1,209 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/inventory"
+	"github.com/github/github-mcp-server/pkg/scopes"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/github/github-mcp-server/pkg/utils"
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/shurcooL/githubv4"
+)
+
+const (
+	// orgStatusPageSize is the number of repositories fetched per GraphQL request. Status
+	// rollups are expensive, so this is kept below the 100 node maximum.
+	orgStatusPageSize = 50
+	// orgStatusMaxRepos caps how many repositories a single call will scan.
+	orgStatusMaxRepos = 1000
+)
+
+// orgStatusStateOrder sorts the most actionable states first.
+var orgStatusStateOrder = map[string]int{
+	"failure":  0,
+	"error":    1,
+	"pending":  2,
+	"expected": 3,
+	"success":  4,
+	"none":     5,
+}
+
+type orgDefaultBranchStatusQuery struct {
+	Organization struct {
+		Repositories struct {
+			Nodes []struct {
+				Name             githubv4.String
+				URL              githubv4.String
+				IsArchived       githubv4.Boolean
+				IsFork           githubv4.Boolean
+				DefaultBranchRef *struct {
+					Name   githubv4.String
+					Target struct {
+						Commit struct {
+							Oid               githubv4.String
+							StatusCheckRollup *struct {
+								State githubv4.String
+							}
+						} `graphql:"... on Commit"`
+					}
+				}
+			}
+			PageInfo struct {
+				HasNextPage githubv4.Boolean
+				EndCursor   githubv4.String
+			}
+		} `graphql:"repositories(first: $first, after: $after, orderBy: {field: NAME, direction: ASC})"`
+	} `graphql:"organization(login: $org)"`
+}
+
+// RepositoryBranchStatus is the CI status of a repository's default branch.
+type RepositoryBranchStatus struct {
+	Repository    string `json:"repository"`
+	DefaultBranch string `json:"default_branch,omitempty"`
+	SHA           string `json:"sha,omitempty"`
+	State         string `json:"state"`
+	URL           string `json:"url"`
+}
+
+// OrgDefaultBranchStatus summarizes default-branch CI status across an organization.
+type OrgDefaultBranchStatus struct {
+	Org          string                   `json:"org"`
+	Summary      map[string]int           `json:"summary"`
+	Repositories []RepositoryBranchStatus `json:"repositories"`
+	NotFound     []string                 `json:"not_found,omitempty"`
+	Truncated    bool                     `json:"truncated,omitempty"`
+}
+
+// GetOrgDefaultBranchStatus creates a tool that reports the latest default-branch CI status for
+// every repository in an organization.
+func GetOrgDefaultBranchStatus(t translations.TranslationHelperFunc) inventory.ServerTool {
+	return NewTool(
+		ToolsetMetadataActions,
+		mcp.Tool{
+			Name: "get_org_default_branch_status",
+			Description: t("TOOL_GET_ORG_DEFAULT_BRANCH_STATUS_DESCRIPTION", `Report the combined CI status (checks and commit statuses) of the latest commit on the default branch of every repository in an organization, or of a given subset of repositories, in one call.
+Use this to answer questions like "is main green everywhere?". Failing repositories are listed first.`),
+			Annotations: &mcp.ToolAnnotations{
+				Title:        t("TOOL_GET_ORG_DEFAULT_BRANCH_STATUS_USER_TITLE", "Get default branch status across an organization"),
+				ReadOnlyHint: true,
+			},
+			InputSchema: WithRepoFilter(&jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"org": {
+						Type:        "string",
+						Description: "Organization login",
+					},
+					"repos": {
+						Type:        "array",
+						Description: "Only report these repositories (names without the owner). Defaults to every repository in the organization.",
+						Items: &jsonschema.Schema{
+							Type: "string",
+						},
+					},
+				},
+				Required: []string{"org"},
+			}),
+		},
+		[]scopes.Scope{scopes.Repo},
+		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+			org, err := RequiredParam[string](args, "org")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			repos, err := OptionalStringArrayParam(args, "repos")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			filter, err := repoFilterParams(ctx, args)
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+
+			client, err := deps.GetGQLClient(ctx)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to get GitHub GQL client: %w", err)
+			}
+
+			wanted := map[string]bool{}
+			for _, r := range repos {
+				wanted[strings.ToLower(r)] = true
+			}
+
+			result := OrgDefaultBranchStatus{
+				Org:          org,
+				Summary:      map[string]int{},
+				Repositories: []RepositoryBranchStatus{},
+			}
+			found := map[string]bool{}
+			scanned := 0
+			vars := map[string]any{
+				"org":   githubv4.String(org),
+				"first": githubv4.Int(orgStatusPageSize),
+				"after": (*githubv4.String)(nil),
+			}
+
+			for {
+				var query orgDefaultBranchStatusQuery
+				if err := client.Query(ctx, &query, vars); err != nil {
+					return ghErrors.NewGitHubGraphQLErrorResponse(ctx, "failed to get organization repositories", err), nil, nil
+				}
+
+				for _, node := range query.Organization.Repositories.Nodes {
+					scanned++
+					name := string(node.Name)
+					if len(wanted) > 0 && !wanted[strings.ToLower(name)] {
+						continue
+					}
+					found[strings.ToLower(name)] = true
+					if !filter.Keeps(bool(node.IsArchived), bool(node.IsFork)) {
+						continue
+					}
+
+					status := RepositoryBranchStatus{
+						Repository: name,
+						State:      "none",
+						URL:        string(node.URL),
+					}
+					if ref := node.DefaultBranchRef; ref != nil {
+						status.DefaultBranch = string(ref.Name)
+						status.SHA = string(ref.Target.Commit.Oid)
+						if rollup := ref.Target.Commit.StatusCheckRollup; rollup != nil {
+							status.State = strings.ToLower(string(rollup.State))
+						}
+					}
+					result.Summary[status.State]++
+					result.Repositories = append(result.Repositories, status)
+				}
+
+				pageInfo := query.Organization.Repositories.PageInfo
+				if !pageInfo.HasNextPage || (len(wanted) > 0 && len(found) == len(wanted)) {
+					break
+				}
+				if scanned >= orgStatusMaxRepos {
+					result.Truncated = true
+					break
+				}
+				vars["after"] = githubv4.NewString(pageInfo.EndCursor)
+			}
+
+			for _, r := range repos {
+				if !found[strings.ToLower(r)] && !result.Truncated {
+					result.NotFound = append(result.NotFound, r)
+				}
+			}
+
+			sort.SliceStable(result.Repositories, func(i, j int) bool {
+				return orgStatusStateOrder[result.Repositories[i].State] < orgStatusStateOrder[result.Repositories[j].State]
+			})
+
+			return MarshalledTextResult(result), nil, nil
+		},
+	)
+}
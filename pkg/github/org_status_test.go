@@ -0,0 +1,153 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/shurcooL/githubv4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_GetOrgDefaultBranchStatus(t *testing.T) {
+	// Verify tool definition once
+	serverTool := GetOrgDefaultBranchStatus(translations.NullTranslationHelper)
+	tool := serverTool.Tool
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_org_default_branch_status", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.True(t, tool.Annotations.ReadOnlyHint)
+	schema := tool.InputSchema.(*jsonschema.Schema)
+	assert.Contains(t, schema.Properties, "org")
+	assert.Contains(t, schema.Properties, "repos")
+	assert.Contains(t, schema.Properties, "include_archived")
+	assert.ElementsMatch(t, schema.Required, []string{"org"})
+
+	repoNode := func(name, state string, archived bool) map[string]any {
+		node := map[string]any{
+			"name":       name,
+			"url":        "https://github.com/acme/" + name,
+			"isArchived": archived,
+			"defaultBranchRef": map[string]any{
+				"name":   "main",
+				"target": map[string]any{"oid": name + "-sha", "statusCheckRollup": nil},
+			},
+		}
+		if state != "" {
+			node["defaultBranchRef"].(map[string]any)["target"].(map[string]any)["statusCheckRollup"] = map[string]any{"state": state}
+		}
+		return node
+	}
+	page := func(hasNext bool, cursor string, nodes ...map[string]any) map[string]any {
+		return map[string]any{
+			"data": map[string]any{
+				"organization": map[string]any{
+					"repositories": map[string]any{
+						"nodes":    nodes,
+						"pageInfo": map[string]any{"hasNextPage": hasNext, "endCursor": cursor},
+					},
+				},
+			},
+		}
+	}
+	// githubv4mock holds a single matcher per query, so pages are served by cursor instead.
+	pages := map[string]map[string]any{
+		"": page(true, "cursor1",
+			repoNode("api", "SUCCESS", false),
+			repoNode("legacy", "FAILURE", true),
+			repoNode("web", "FAILURE", false),
+		),
+		"cursor1": page(false, "cursor2",
+			repoNode("worker", "PENDING", false),
+			repoNode("docs", "", false),
+		),
+	}
+
+	tests := []struct {
+		name     string
+		pages    []string
+		args     map[string]any
+		expected OrgDefaultBranchStatus
+	}{
+		{
+			name:  "all repositories across pages",
+			pages: []string{"", "cursor1"},
+			args:  map[string]any{"org": "acme"},
+			expected: OrgDefaultBranchStatus{
+				Org:     "acme",
+				Summary: map[string]int{"success": 1, "failure": 1, "pending": 1, "none": 1},
+				Repositories: []RepositoryBranchStatus{
+					{Repository: "web", DefaultBranch: "main", SHA: "web-sha", State: "failure", URL: "https://github.com/acme/web"},
+					{Repository: "worker", DefaultBranch: "main", SHA: "worker-sha", State: "pending", URL: "https://github.com/acme/worker"},
+					{Repository: "api", DefaultBranch: "main", SHA: "api-sha", State: "success", URL: "https://github.com/acme/api"},
+					{Repository: "docs", DefaultBranch: "main", SHA: "docs-sha", State: "none", URL: "https://github.com/acme/docs"},
+				},
+			},
+		},
+		{
+			name:  "subset reports repositories that were not found",
+			pages: []string{"", "cursor1"},
+			args:  map[string]any{"org": "acme", "repos": []any{"docs", "missing"}},
+			expected: OrgDefaultBranchStatus{
+				Org:     "acme",
+				Summary: map[string]int{"none": 1},
+				Repositories: []RepositoryBranchStatus{
+					{Repository: "docs", DefaultBranch: "main", SHA: "docs-sha", State: "none", URL: "https://github.com/acme/docs"},
+				},
+				NotFound: []string{"missing"},
+			},
+		},
+		{
+			name:  "subset stops once all repositories are found",
+			pages: []string{""},
+			args:  map[string]any{"org": "acme", "repos": []any{"API", "legacy"}, "include_archived": true},
+			expected: OrgDefaultBranchStatus{
+				Org:     "acme",
+				Summary: map[string]int{"success": 1, "failure": 1},
+				Repositories: []RepositoryBranchStatus{
+					{Repository: "legacy", DefaultBranch: "main", SHA: "legacy-sha", State: "failure", URL: "https://github.com/acme/legacy"},
+					{Repository: "api", DefaultBranch: "main", SHA: "api-sha", State: "success", URL: "https://github.com/acme/api"},
+				},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var requested []string
+			gqlClient := MockHTTPClientWithHandlers(map[string]http.HandlerFunc{
+				"POST /graphql": func(w http.ResponseWriter, r *http.Request) {
+					var body struct {
+						Variables map[string]any `json:"variables"`
+					}
+					require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+					assert.Equal(t, "acme", body.Variables["org"])
+					after, _ := body.Variables["after"].(string)
+					requested = append(requested, after)
+					w.Header().Set("Content-Type", "application/json")
+					_ = json.NewEncoder(w).Encode(pages[after])
+				},
+			})
+			deps := BaseDeps{
+				GQLClient: githubv4.NewClient(gqlClient),
+			}
+			handler := serverTool.Handler(deps)
+			request := createMCPRequest(tc.args)
+
+			result, err := handler(ContextWithDeps(context.Background(), deps), &request)
+			require.NoError(t, err)
+			require.False(t, result.IsError)
+
+			var response OrgDefaultBranchStatus
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &response))
+			assert.Equal(t, tc.expected, response)
+			assert.Equal(t, tc.pages, requested)
+		})
+	}
+}
@@ -322,6 +322,39 @@ func OptionalBigIntArrayParam(args map[string]any, p string) ([]int64, error) {
 	}
 }
 
+// OptionalIntArrayParam is a helper function that can be used to fetch a requested parameter from the request.
+// It does the following checks:
+// 1. Checks if the parameter is present in the request, if not, it returns an empty slice
+// 2. If it is present, iterates the elements and checks each is a whole number
+func OptionalIntArrayParam(args map[string]any, p string) ([]int, error) {
+	// Check if the parameter is present in the request
+	if _, ok := args[p]; !ok {
+		return []int{}, nil
+	}
+
+	switch v := args[p].(type) {
+	case nil:
+		return []int{}, nil
+	case []int:
+		return v, nil
+	case []any:
+		intSlice := make([]int, len(v))
+		for i, v := range v {
+			f, ok := v.(float64)
+			if !ok {
+				return []int{}, fmt.Errorf("parameter %s is not of type number, is %T", p, v)
+			}
+			if f != float64(int(f)) {
+				return []int{}, fmt.Errorf("parameter %s is not a whole number: %v", p, f)
+			}
+			intSlice[i] = int(f)
+		}
+		return intSlice, nil
+	default:
+		return []int{}, fmt.Errorf("parameter %s could not be coerced to []int, is %T", p, args[p])
+	}
+}
+
 // WithPagination adds REST API pagination parameters to a tool.
 // https://docs.github.com/en/rest/using-the-rest-api/using-pagination-in-the-rest-api
 func WithPagination(schema *jsonschema.Schema) *jsonschema.Schema {
@@ -376,7 +409,7 @@ func WithCursorPagination(schema *jsonschema.Schema) *jsonschema.Schema {
 
 	schema.Properties["after"] = &jsonschema.Schema{
 		Type:        "string",
-		Description: "Cursor for pagination. Use the endCursor from the previous page's PageInfo for GraphQL APIs.",
+		Description: "Opaque cursor for pagination. Pass the endCursor from the pageInfo of the previous response of this tool, unchanged.",
 	}
 
 	return schema
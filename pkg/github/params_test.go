@@ -549,6 +549,64 @@ func TestOptionalStringArrayParam(t *testing.T) {
 	}
 }
 
+func TestOptionalIntArrayParam(t *testing.T) {
+	tests := []struct {
+		name        string
+		params      map[string]any
+		paramName   string
+		expected    []int
+		expectError bool
+	}{
+		{
+			name:        "parameter not in request",
+			params:      map[string]any{},
+			paramName:   "numbers",
+			expected:    []int{},
+			expectError: false,
+		},
+		{
+			name: "valid any array parameter",
+			params: map[string]any{
+				"numbers": []any{float64(1), float64(42)},
+			},
+			paramName:   "numbers",
+			expected:    []int{1, 42},
+			expectError: false,
+		},
+		{
+			name: "fractional number",
+			params: map[string]any{
+				"numbers": []any{float64(1.5)},
+			},
+			paramName:   "numbers",
+			expected:    []int{},
+			expectError: true,
+		},
+		{
+			name: "wrong slice type parameter",
+			params: map[string]any{
+				"numbers": []any{"1"},
+			},
+			paramName:   "numbers",
+			expected:    []int{},
+			expectError: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := OptionalIntArrayParam(tc.params, tc.paramName)
+
+			if tc.expectError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expected, result)
+			}
+		})
+	}
+}
+
 func TestOptionalPaginationParams(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -0,0 +1,179 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/inventory"
+	"github.com/github/github-mcp-server/pkg/scopes"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/github/github-mcp-server/pkg/utils"
+	"github.com/google/go-github/v82/github"
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// Permalink is a link to a file or line range pinned to a commit SHA.
+type Permalink struct {
+	Permalink string `json:"permalink"`
+	SHA       string `json:"sha"`
+	Ref       string `json:"ref"`
+	Path      string `json:"path"`
+	StartLine int    `json:"start_line,omitempty"`
+	EndLine   int    `json:"end_line,omitempty"`
+}
+
+// GetPermalink creates a tool that builds a commit-pinned permalink to a file or line range.
+func GetPermalink(t translations.TranslationHelperFunc) inventory.ServerTool {
+	return NewTool(
+		ToolsetMetadataRepos,
+		mcp.Tool{
+			Name:        "get_permalink",
+			Description: t("TOOL_GET_PERMALINK_DESCRIPTION", "Get a permanent link to a file or a range of lines in a repository. The branch or tag is resolved to a commit SHA so that the link keeps pointing at the same code after the branch moves. Use this to cite code in comments and reports."),
+			Annotations: &mcp.ToolAnnotations{
+				Title:        t("TOOL_GET_PERMALINK_USER_TITLE", "Get permalink to code"),
+				ReadOnlyHint: true,
+			},
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"owner": {
+						Type:        "string",
+						Description: DescriptionRepositoryOwner,
+					},
+					"repo": {
+						Type:        "string",
+						Description: DescriptionRepositoryName,
+					},
+					"path": {
+						Type:        "string",
+						Description: "Path to the file",
+					},
+					"ref": {
+						Type:        "string",
+						Description: "Branch, tag or commit SHA. Defaults to the repository's default branch.",
+					},
+					"start_line": {
+						Type:        "number",
+						Description: "First line of the range to link to",
+						Minimum:     jsonschema.Ptr(1.0),
+					},
+					"end_line": {
+						Type:        "number",
+						Description: "Last line of the range to link to. Defaults to start_line.",
+						Minimum:     jsonschema.Ptr(1.0),
+					},
+				},
+				Required: []string{"owner", "repo", "path"},
+			},
+		},
+		[]scopes.Scope{scopes.Repo},
+		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+			owner, err := RequiredParam[string](args, "owner")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			repo, err := RequiredParam[string](args, "repo")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			path, err := RequiredParam[string](args, "path")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			path = strings.Trim(path, "/")
+			ref, err := OptionalParam[string](args, "ref")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			startLine, err := OptionalIntParam(args, "start_line")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			endLine, err := OptionalIntParam(args, "end_line")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			if endLine > 0 && startLine == 0 {
+				return utils.NewToolResultError("end_line requires start_line"), nil, nil
+			}
+			if endLine == 0 {
+				endLine = startLine
+			}
+			if endLine < startLine {
+				return utils.NewToolResultError("end_line must not be before start_line"), nil, nil
+			}
+
+			client, err := deps.GetClient(ctx)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			if ref == "" {
+				repository, resp, err := client.Repositories.Get(ctx, owner, repo)
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to get repository", resp, err), nil, nil
+				}
+				_ = resp.Body.Close()
+				ref = repository.GetDefaultBranch()
+			}
+
+			sha, resp, err := client.Repositories.GetCommitSHA1(ctx, owner, repo, ref, "")
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, fmt.Sprintf("failed to resolve ref: %s", ref), resp, err), nil, nil
+			}
+			_ = resp.Body.Close()
+
+			// Check the file exists at the resolved commit, and that the lines are in range.
+			fileContent, _, resp, err := client.Repositories.GetContents(ctx, owner, repo, path, &github.RepositoryContentGetOptions{Ref: sha})
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, fmt.Sprintf("failed to get file %s at %s", path, ref), resp, err), nil, nil
+			}
+			_ = resp.Body.Close()
+			if fileContent == nil {
+				return utils.NewToolResultError(fmt.Sprintf("%s is not a file", path)), nil, nil
+			}
+			if startLine > 0 {
+				content, err := fileContent.GetContent()
+				if err != nil {
+					return nil, nil, fmt.Errorf("failed to decode file content: %w", err)
+				}
+				lineCount := strings.Count(strings.TrimSuffix(content, "\n"), "\n") + 1
+				if endLine > lineCount {
+					return utils.NewToolResultError(fmt.Sprintf("line %d is past the end of %s (%d lines)", endLine, path, lineCount)), nil, nil
+				}
+			}
+
+			return MarshalledTextResult(Permalink{
+				Permalink: buildPermalink(client, owner, repo, sha, path, startLine, endLine),
+				SHA:       sha,
+				Ref:       ref,
+				Path:      path,
+				StartLine: startLine,
+				EndLine:   endLine,
+			}), nil, nil
+		},
+	)
+}
+
+// buildPermalink returns the web URL of a file pinned to sha, anchored to a line range if startLine is set.
+func buildPermalink(client *github.Client, owner, repo, sha, path string, startLine, endLine int) string {
+	segments := strings.Split(path, "/")
+	for i, s := range segments {
+		segments[i] = url.PathEscape(s)
+	}
+
+	link := fmt.Sprintf("%s://%s/%s/%s/blob/%s/%s",
+		client.BaseURL.Scheme, strings.TrimPrefix(client.BaseURL.Host, "api."),
+		url.PathEscape(owner), url.PathEscape(repo), sha, strings.Join(segments, "/"))
+	switch {
+	case startLine > 0 && endLine > startLine:
+		link += fmt.Sprintf("#L%d-L%d", startLine, endLine)
+	case startLine > 0:
+		link += fmt.Sprintf("#L%d", startLine)
+	}
+	return link
+}
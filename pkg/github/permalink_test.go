@@ -0,0 +1,185 @@
+package github
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v82/github"
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_GetPermalink(t *testing.T) {
+	// Verify tool definition once
+	serverTool := GetPermalink(translations.NullTranslationHelper)
+	tool := serverTool.Tool
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_permalink", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.True(t, tool.Annotations.ReadOnlyHint)
+	schema := tool.InputSchema.(*jsonschema.Schema)
+	assert.Contains(t, schema.Properties, "owner")
+	assert.Contains(t, schema.Properties, "repo")
+	assert.Contains(t, schema.Properties, "path")
+	assert.Contains(t, schema.Properties, "ref")
+	assert.Contains(t, schema.Properties, "start_line")
+	assert.Contains(t, schema.Properties, "end_line")
+	assert.ElementsMatch(t, schema.Required, []string{"owner", "repo", "path"})
+
+	const sha = "6dcb09b5b57875f334f61aebed695e2e4193db5e"
+	mockFile := &github.RepositoryContent{
+		Type:     github.Ptr("file"),
+		Path:     github.Ptr("pkg/a b.go"),
+		Encoding: github.Ptr("base64"),
+		Content:  github.Ptr(base64.StdEncoding.EncodeToString([]byte("one\ntwo\nthree\n"))),
+	}
+	contents := "GET /repos/{owner}/{repo}/contents/{path:.*}"
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]any
+		expectError    bool
+		expectedErrMsg string
+		expected       Permalink
+	}{
+		{
+			name: "resolves default branch and links to line range",
+			mockedClient: MockHTTPClientWithHandlers(map[string]http.HandlerFunc{
+				GetReposByOwnerByRepo:             mockResponse(t, http.StatusOK, &github.Repository{DefaultBranch: github.Ptr("main")}),
+				GetReposCommitsByOwnerByRepoByRef: mockResponse(t, http.StatusOK, sha),
+				contents: expectQueryParams(t, map[string]string{"ref": sha}).andThen(
+					mockResponse(t, http.StatusOK, mockFile),
+				),
+			}),
+			requestArgs: map[string]any{
+				"owner":      "owner",
+				"repo":       "repo",
+				"path":       "/pkg/a b.go",
+				"start_line": float64(2),
+				"end_line":   float64(3),
+			},
+			expected: Permalink{
+				Permalink: "https://github.com/owner/repo/blob/" + sha + "/pkg/a%20b.go#L2-L3",
+				SHA:       sha,
+				Ref:       "main",
+				Path:      "pkg/a b.go",
+				StartLine: 2,
+				EndLine:   3,
+			},
+		},
+		{
+			name: "explicit ref without line range",
+			mockedClient: MockHTTPClientWithHandlers(map[string]http.HandlerFunc{
+				GetReposCommitsByOwnerByRepoByRef: mockResponse(t, http.StatusOK, sha),
+				contents:                          mockResponse(t, http.StatusOK, mockFile),
+			}),
+			requestArgs: map[string]any{
+				"owner": "owner",
+				"repo":  "repo",
+				"path":  "pkg/a b.go",
+				"ref":   "v1.0.0",
+			},
+			expected: Permalink{
+				Permalink: "https://github.com/owner/repo/blob/" + sha + "/pkg/a%20b.go",
+				SHA:       sha,
+				Ref:       "v1.0.0",
+				Path:      "pkg/a b.go",
+			},
+		},
+		{
+			name: "single line",
+			mockedClient: MockHTTPClientWithHandlers(map[string]http.HandlerFunc{
+				GetReposCommitsByOwnerByRepoByRef: mockResponse(t, http.StatusOK, sha),
+				contents:                          mockResponse(t, http.StatusOK, mockFile),
+			}),
+			requestArgs: map[string]any{
+				"owner":      "owner",
+				"repo":       "repo",
+				"path":       "pkg/a b.go",
+				"ref":        "main",
+				"start_line": float64(3),
+			},
+			expected: Permalink{
+				Permalink: "https://github.com/owner/repo/blob/" + sha + "/pkg/a%20b.go#L3",
+				SHA:       sha,
+				Ref:       "main",
+				Path:      "pkg/a b.go",
+				StartLine: 3,
+				EndLine:   3,
+			},
+		},
+		{
+			name: "line past end of file",
+			mockedClient: MockHTTPClientWithHandlers(map[string]http.HandlerFunc{
+				GetReposCommitsByOwnerByRepoByRef: mockResponse(t, http.StatusOK, sha),
+				contents:                          mockResponse(t, http.StatusOK, mockFile),
+			}),
+			requestArgs: map[string]any{
+				"owner":      "owner",
+				"repo":       "repo",
+				"path":       "pkg/a b.go",
+				"ref":        "main",
+				"start_line": float64(2),
+				"end_line":   float64(9),
+			},
+			expectError:    true,
+			expectedErrMsg: "line 9 is past the end of pkg/a b.go (3 lines)",
+		},
+		{
+			name: "unknown ref",
+			mockedClient: MockHTTPClientWithHandlers(map[string]http.HandlerFunc{
+				GetReposCommitsByOwnerByRepoByRef: mockResponse(t, http.StatusUnprocessableEntity, `{"message": "No commit found for SHA: nope"}`),
+			}),
+			requestArgs: map[string]any{
+				"owner": "owner",
+				"repo":  "repo",
+				"path":  "README.md",
+				"ref":   "nope",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to resolve ref: nope",
+		},
+		{
+			name:         "end line before start line",
+			mockedClient: MockHTTPClientWithHandlers(map[string]http.HandlerFunc{}),
+			requestArgs: map[string]any{
+				"owner":      "owner",
+				"repo":       "repo",
+				"path":       "README.md",
+				"start_line": float64(5),
+				"end_line":   float64(2),
+			},
+			expectError:    true,
+			expectedErrMsg: "end_line must not be before start_line",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			deps := BaseDeps{Client: github.NewClient(tc.mockedClient)}
+			handler := serverTool.Handler(deps)
+
+			request := createMCPRequest(tc.requestArgs)
+			result, err := handler(ContextWithDeps(context.Background(), deps), &request)
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			var permalink Permalink
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &permalink))
+			assert.Equal(t, tc.expected, permalink)
+		})
+	}
+}
@@ -0,0 +1,62 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// watchTimeUnitKey is a context key overriding the unit of the timeouts and intervals of the
+// watch tools, so tests do not wait for seconds.
+type watchTimeUnitKey struct{}
+
+func watchTimeUnit(ctx context.Context) time.Duration {
+	if unit, ok := ctx.Value(watchTimeUnitKey{}).(time.Duration); ok {
+		return unit
+	}
+	return time.Second
+}
+
+// pollUntil calls poll until it reports done or the timeout passes, waiting interval before the
+// second call and half as long again before each later one, up to maxInterval. While waiting, it
+// notifies the client with the progress message poll returned, if the request asked for progress.
+// Cancelling ctx, as the SDK does when the client cancels the request, stops polling with
+// ctx.Err(). It returns whether the last call reported done.
+func pollUntil(ctx context.Context, request *mcp.CallToolRequest, timeout, interval, maxInterval time.Duration, poll func(polls int) (done bool, progress string, err error)) (bool, error) {
+	deadline := time.Now().Add(timeout)
+	delay := interval
+	var progressToken any
+	if request != nil && request.Params != nil {
+		progressToken = request.Params.GetProgressToken()
+	}
+
+	for polls := 1; ; polls++ {
+		done, progress, err := poll(polls)
+		if err != nil || done {
+			return done, err
+		}
+		remaining := time.Until(deadline)
+		if remaining <= 0 {
+			return false, nil
+		}
+
+		wait := min(delay, remaining)
+		if progressToken != nil && request.Session != nil {
+			_ = request.Session.NotifyProgress(ctx, &mcp.ProgressNotificationParams{
+				ProgressToken: progressToken,
+				Progress:      float64(polls),
+				Message:       fmt.Sprintf("%s, checking again in %s", progress, wait.Round(time.Second)),
+			})
+		}
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return false, ctx.Err()
+		case <-timer.C:
+		}
+		delay = min(delay*3/2, maxInterval)
+	}
+}
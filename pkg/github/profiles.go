@@ -0,0 +1,214 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/github/github-mcp-server/pkg/utils"
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// ProfileArgument is the argument of tool calls selecting the profile they use.
+const ProfileArgument = "profile"
+
+// DefaultProfile is the name of the profile of the server's own host and token.
+const DefaultProfile = "default"
+
+// profileName matches the names of profiles.
+var profileName = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]*$`)
+
+// ProfileConfig is a named GitHub host, and the token to authenticate with on it, that tool calls
+// can use instead of the server's own.
+type ProfileConfig struct {
+	Name  string
+	Host  string
+	Token string
+}
+
+// ProfileTokenEnv returns the environment variable holding the token of a profile, such as
+// GITHUB_PROFILE_GHES_TOKEN for the profile ghes.
+func ProfileTokenEnv(name string) string {
+	return "GITHUB_PROFILE_" + strings.ToUpper(strings.ReplaceAll(name, "-", "_")) + "_TOKEN"
+}
+
+// ParseProfiles parses "name=host" entries, such as "ghes=https://github.example.com". The token
+// of each profile is read from the environment variable ProfileTokenEnv names.
+func ParseProfiles(entries []string) ([]ProfileConfig, error) {
+	profiles := make([]ProfileConfig, 0, len(entries))
+	seen := make(map[string]bool, len(entries))
+	for _, entry := range entries {
+		name, host, ok := strings.Cut(strings.TrimSpace(entry), "=")
+		name, host = strings.ToLower(strings.TrimSpace(name)), strings.TrimSpace(host)
+		if !ok || host == "" {
+			return nil, fmt.Errorf("invalid profile %q: expected name=host", entry)
+		}
+		if !profileName.MatchString(name) || name == DefaultProfile {
+			return nil, fmt.Errorf("invalid profile %q: names are lowercase letters, digits, - and _, and cannot be %q", entry, DefaultProfile)
+		}
+		if seen[name] {
+			return nil, fmt.Errorf("invalid profile %q: profile %s is defined twice", entry, name)
+		}
+		seen[name] = true
+		if _, err := utils.NewAPIHost(host); err != nil {
+			return nil, fmt.Errorf("invalid profile %q: %w", entry, err)
+		}
+		token := strings.TrimSpace(os.Getenv(ProfileTokenEnv(name)))
+		if token == "" {
+			return nil, fmt.Errorf("profile %s has no token: set %s", name, ProfileTokenEnv(name))
+		}
+		profiles = append(profiles, ProfileConfig{Name: name, Host: host, Token: token})
+	}
+	return profiles, nil
+}
+
+// Profile is a profile, with the dependencies of the tool calls using it.
+type Profile struct {
+	Name string
+	Host string
+	Deps ToolDependencies
+}
+
+// rootProfile is the profile the roots of a session point to.
+type rootProfile struct {
+	roots   *RootContext
+	profile string
+}
+
+var sessionRootProfiles = newSessionStore[rootProfile](sessionStoreMaxSessions)
+
+// profileForRoots returns the profile whose host the roots of a session point to, when none of
+// them points to the server's own host and all of them agree on one profile.
+func profileForRoots(req *mcp.CallToolRequest, profiles []Profile) string {
+	id := sessionID(req)
+	rc, ok := sessionRoots.load(id)
+	if !ok || rc == nil || len(rc.Repositories) > 0 {
+		return ""
+	}
+	if cached, ok := sessionRootProfiles.load(id); ok && cached.roots == rc {
+		return cached.profile
+	}
+
+	var matches []string
+	for _, profile := range profiles {
+		webHost := rootsWebHost(profile.Host)
+		for _, root := range rc.Roots {
+			if _, ok := resolveRoot(root, webHost); ok {
+				matches = append(matches, profile.Name)
+				break
+			}
+		}
+	}
+	matched := ""
+	if len(matches) == 1 {
+		matched = matches[0]
+	}
+	sessionRootProfiles.update(id, func(v *rootProfile) { *v = rootProfile{roots: rc, profile: matched} })
+	return matched
+}
+
+// ProfileMiddleware runs tool calls with the dependencies of the profile named by their profile
+// argument or, without one, of the profile the session's roots point to. Other calls use the
+// server's own dependencies. The profile argument is added to the schema of every tool listed. It
+// must run inside InjectDepsMiddleware and RootsMiddleware, and outside the middleware using the
+// dependencies of the call, such as AuditLogMiddleware.
+func ProfileMiddleware(defaultHost string, profiles []Profile) mcp.Middleware {
+	byName := make(map[string]Profile, len(profiles))
+	names := []string{DefaultProfile}
+	enum := []any{DefaultProfile}
+	descriptions := []string{fmt.Sprintf("%s (%s)", DefaultProfile, rootsWebHost(defaultHost))}
+	for _, profile := range profiles {
+		byName[profile.Name] = profile
+		names = append(names, profile.Name)
+		enum = append(enum, profile.Name)
+		descriptions = append(descriptions, fmt.Sprintf("%s (%s)", profile.Name, rootsWebHost(profile.Host)))
+	}
+	description := fmt.Sprintf("GitHub account and host to call: %s. Defaults to the profile the session's roots point to, or %s",
+		strings.Join(descriptions, ", "), DefaultProfile)
+
+	return func(next mcp.MethodHandler) mcp.MethodHandler {
+		return func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+			switch method {
+			case "tools/list":
+				result, err := next(ctx, method, req)
+				listResult, ok := result.(*mcp.ListToolsResult)
+				if err != nil || !ok || listResult == nil {
+					return result, err
+				}
+				withProfile := *listResult
+				withProfile.Tools = make([]*mcp.Tool, len(listResult.Tools))
+				for i, tool := range listResult.Tools {
+					withProfile.Tools[i] = tool
+					schema, ok := tool.InputSchema.(*jsonschema.Schema)
+					if !ok || schema == nil {
+						continue
+					}
+					// Copy the tool and its schema, as the result shares the server's registered tools
+					schemaCopy := *schema
+					schemaCopy.Properties = maps.Clone(schema.Properties)
+					if schemaCopy.Properties == nil {
+						schemaCopy.Properties = map[string]*jsonschema.Schema{}
+					}
+					schemaCopy.Properties[ProfileArgument] = &jsonschema.Schema{
+						Type:        "string",
+						Description: description,
+						Enum:        enum,
+					}
+					toolCopy := *tool
+					toolCopy.InputSchema = &schemaCopy
+					withProfile.Tools[i] = &toolCopy
+				}
+				return &withProfile, nil
+			case "tools/call":
+				callReq, ok := req.(*mcp.CallToolRequest)
+				if !ok || callReq.Params == nil {
+					return next(ctx, method, req)
+				}
+				name, stripped := stripProfileArgument(callReq)
+				if name == "" {
+					name = profileForRoots(callReq, profiles)
+				}
+				if name == "" || name == DefaultProfile {
+					return next(ctx, method, stripped)
+				}
+				profile, ok := byName[name]
+				if !ok {
+					return utils.NewToolResultError(fmt.Sprintf("unknown profile %q: use one of %s", name, strings.Join(names, ", "))), nil
+				}
+				return next(ContextWithDeps(ctx, profile.Deps), method, stripped)
+			default:
+				return next(ctx, method, req)
+			}
+		}
+	}
+}
+
+// stripProfileArgument returns the profile a call asks for, and the call without the profile
+// argument, which tools do not know.
+func stripProfileArgument(callReq *mcp.CallToolRequest) (string, *mcp.CallToolRequest) {
+	var args map[string]json.RawMessage
+	if json.Unmarshal(callReq.Params.Arguments, &args) != nil {
+		return "", callReq
+	}
+	raw, ok := args[ProfileArgument]
+	if !ok {
+		return "", callReq
+	}
+	var name string
+	_ = json.Unmarshal(raw, &name)
+	delete(args, ProfileArgument)
+	arguments, err := json.Marshal(args)
+	if err != nil {
+		return "", callReq
+	}
+	params := *callReq.Params
+	params.Arguments = arguments
+	stripped := *callReq
+	stripped.Params = &params
+	return strings.ToLower(strings.TrimSpace(name)), &stripped
+}
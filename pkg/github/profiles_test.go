@@ -0,0 +1,98 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/github/github-mcp-server/pkg/utils"
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ParseProfiles(t *testing.T) {
+	t.Setenv("GITHUB_PROFILE_GHES_TOKEN", "ghp_ghes")
+	t.Setenv("GITHUB_PROFILE_WORK_ACCOUNT_TOKEN", " ghp_work\n")
+
+	profiles, err := ParseProfiles([]string{"GHES=https://github.example.com", " work-account = https://github.com "})
+	require.NoError(t, err)
+	assert.Equal(t, []ProfileConfig{
+		{Name: "ghes", Host: "https://github.example.com", Token: "ghp_ghes"},
+		{Name: "work-account", Host: "https://github.com", Token: "ghp_work"},
+	}, profiles)
+
+	_, err = ParseProfiles([]string{"ghes"})
+	assert.ErrorContains(t, err, "expected name=host")
+	_, err = ParseProfiles([]string{"default=https://github.com"})
+	assert.ErrorContains(t, err, `cannot be "default"`)
+	_, err = ParseProfiles([]string{"ghes=https://github.com", "ghes=https://github.example.com"})
+	assert.ErrorContains(t, err, "profile ghes is defined twice")
+	_, err = ParseProfiles([]string{"other=https://github.com"})
+	assert.EqualError(t, err, "profile other has no token: set GITHUB_PROFILE_OTHER_TOKEN")
+}
+
+func Test_ProfileMiddleware(t *testing.T) {
+	defaultDeps := BaseDeps{ContentWindowSize: 1}
+	ghesDeps := BaseDeps{ContentWindowSize: 2}
+	var calls []struct {
+		deps ToolDependencies
+		args string
+	}
+	next := func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+		if method == "tools/list" {
+			return &mcp.ListToolsResult{Tools: []*mcp.Tool{
+				{Name: "get_me", InputSchema: &jsonschema.Schema{Type: "object"}},
+			}}, nil
+		}
+		calls = append(calls, struct {
+			deps ToolDependencies
+			args string
+		}{MustDepsFromContext(ctx), string(req.(*mcp.CallToolRequest).Params.Arguments)})
+		return utils.NewToolResultText("done"), nil
+	}
+	handler := InjectDepsMiddleware(defaultDeps)(ProfileMiddleware("", []Profile{
+		{Name: "ghes", Host: "https://github.example.com", Deps: ghesDeps},
+	})(next))
+	call := func(args string) *mcp.CallToolResult {
+		result, err := handler(context.Background(), "tools/call", &mcp.CallToolRequest{
+			Params: &mcp.CallToolParamsRaw{Name: "get_me", Arguments: json.RawMessage(args)},
+		})
+		require.NoError(t, err)
+		return result.(*mcp.CallToolResult)
+	}
+
+	result, err := handler(context.Background(), "tools/list", &mcp.ListToolsRequest{})
+	require.NoError(t, err)
+	property := result.(*mcp.ListToolsResult).Tools[0].InputSchema.(*jsonschema.Schema).Properties[ProfileArgument]
+	require.NotNil(t, property)
+	assert.Equal(t, []any{"default", "ghes"}, property.Enum)
+	assert.Contains(t, property.Description, "default (github.com), ghes (github.example.com)")
+
+	call(`{"profile":"ghes","owner":"octo"}`)
+	call(`{"profile":"default"}`)
+	call(`{}`)
+	require.Len(t, calls, 3)
+	assert.Equal(t, ghesDeps, calls[0].deps)
+	assert.JSONEq(t, `{"owner":"octo"}`, calls[0].args)
+	assert.Equal(t, defaultDeps, calls[1].deps)
+	assert.Equal(t, defaultDeps, calls[2].deps)
+
+	assert.Equal(t, `unknown profile "other": use one of default, ghes`, getErrorResult(t, call(`{"profile":"other"}`)).Text)
+
+	// Without a profile argument, calls use the profile the session's roots point to
+	sessionRoots.update("", func(rc **RootContext) {
+		*rc = &RootContext{Supported: true, Roots: []*mcp.Root{{URI: "https://github.example.com/octo/app"}}}
+	})
+	t.Cleanup(func() {
+		sessionRoots.delete("")
+		sessionRootProfiles.delete("")
+	})
+	calls = nil
+	call(`{}`)
+	call(`{"profile":"default"}`)
+	require.Len(t, calls, 2)
+	assert.Equal(t, ghesDeps, calls[0].deps)
+	assert.Equal(t, defaultDeps, calls[1].deps)
+}
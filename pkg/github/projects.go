@@ -42,6 +42,7 @@ const (
 	projectsMethodAddProjectItem            = "add_project_item"
 	projectsMethodUpdateProjectItem         = "update_project_item"
 	projectsMethodDeleteProjectItem         = "delete_project_item"
+	projectsMethodMoveProjectItem           = "move_project_item"
 	projectsMethodListProjectStatusUpdates  = "list_project_status_updates"
 	projectsMethodGetProjectStatusUpdate    = "get_project_status_update"
 	projectsMethodCreateProjectStatusUpdate = "create_project_status_update"
@@ -403,7 +404,7 @@ func ProjectsWrite(t translations.TranslationHelperFunc) inventory.ServerTool {
 		ToolsetMetadataProjects,
 		mcp.Tool{
 			Name:        "projects_write",
-			Description: t("TOOL_PROJECTS_WRITE_DESCRIPTION", "Add, update, or delete project items, or create status updates in a GitHub Project."),
+			Description: t("TOOL_PROJECTS_WRITE_DESCRIPTION", "Add, update, delete, or move project items, or create status updates in a GitHub Project. To move an item between columns of the same project, use 'update_project_item' on the Status field; use 'move_project_item' to move an item to a different project owned by the same owner."),
 			Annotations: &mcp.ToolAnnotations{
 				Title:           t("TOOL_PROJECTS_WRITE_USER_TITLE", "Modify GitHub Project items"),
 				ReadOnlyHint:    false,
@@ -419,6 +420,7 @@ func ProjectsWrite(t translations.TranslationHelperFunc) inventory.ServerTool {
 							projectsMethodAddProjectItem,
 							projectsMethodUpdateProjectItem,
 							projectsMethodDeleteProjectItem,
+							projectsMethodMoveProjectItem,
 							projectsMethodCreateProjectStatusUpdate,
 						},
 					},
@@ -437,7 +439,7 @@ func ProjectsWrite(t translations.TranslationHelperFunc) inventory.ServerTool {
 					},
 					"item_id": {
 						Type:        "number",
-						Description: "The project item ID. Required for 'update_project_item' and 'delete_project_item' methods.",
+						Description: "The project item ID. Required for 'update_project_item', 'delete_project_item' and 'move_project_item' methods.",
 					},
 					"item_type": {
 						Type:        "string",
@@ -462,7 +464,11 @@ func ProjectsWrite(t translations.TranslationHelperFunc) inventory.ServerTool {
 					},
 					"updated_field": {
 						Type:        "object",
-						Description: "Object consisting of the ID of the project field to update and the new value for the field. To clear the field, set value to null. Example: {\"id\": 123456, \"value\": \"New Value\"}. Required for 'update_project_item' method.",
+						Description: "Object consisting of the ID of the project field to update and the new value for the field. To clear the field, set value to null. Example: {\"id\": 123456, \"value\": \"New Value\"}. Required for 'update_project_item' method. Optional for 'move_project_item', where it is applied to the item in the target project (for example to set its Status column).",
+					},
+					"target_project_number": {
+						Type:        "number",
+						Description: "The number of the project to move the item to. Must belong to the same owner. Required for 'move_project_item' method.",
 					},
 					"body": {
 						Type:        "string",
@@ -577,6 +583,24 @@ func ProjectsWrite(t translations.TranslationHelperFunc) inventory.ServerTool {
 					return utils.NewToolResultError(err.Error()), nil, nil
 				}
 				return deleteProjectItem(ctx, client, owner, ownerType, projectNumber, itemID)
+			case projectsMethodMoveProjectItem:
+				itemID, err := RequiredBigInt(args, "item_id")
+				if err != nil {
+					return utils.NewToolResultError(err.Error()), nil, nil
+				}
+				targetProjectNumber, err := RequiredInt(args, "target_project_number")
+				if err != nil {
+					return utils.NewToolResultError(err.Error()), nil, nil
+				}
+				var fieldValue map[string]any
+				if rawUpdatedField, exists := args["updated_field"]; exists && rawUpdatedField != nil {
+					var ok bool
+					fieldValue, ok = rawUpdatedField.(map[string]any)
+					if !ok {
+						return utils.NewToolResultError("updated_field must be an object"), nil, nil
+					}
+				}
+				return moveProjectItem(ctx, client, owner, ownerType, projectNumber, targetProjectNumber, itemID, fieldValue)
 			case projectsMethodCreateProjectStatusUpdate:
 				body, err := OptionalParam[string](args, "body")
 				if err != nil {
@@ -1025,6 +1049,91 @@ func deleteProjectItem(ctx context.Context, client *github.Client, owner, ownerT
 	return utils.NewToolResultText("project item successfully deleted"), nil, nil
 }
 
+// moveProjectItem moves an issue or pull request from one project to another owned by the same
+// owner. The item is added to the target project, optionally has a field set there, and is then
+// removed from the source project. Draft issues cannot be moved as they only exist within a project.
+func moveProjectItem(ctx context.Context, client *github.Client, owner, ownerType string, sourceProjectNumber, targetProjectNumber int, itemID int64, fieldValue map[string]any) (*mcp.CallToolResult, any, error) {
+	if sourceProjectNumber == targetProjectNumber {
+		return utils.NewToolResultError("target_project_number must differ from project_number; use 'update_project_item' to change the item's column within a project"), nil, nil
+	}
+
+	var updatePayload *github.UpdateProjectItemOptions
+	if fieldValue != nil {
+		var err error
+		updatePayload, err = buildUpdateProjectItem(fieldValue)
+		if err != nil {
+			return utils.NewToolResultError(err.Error()), nil, nil
+		}
+	}
+
+	var sourceItem *github.ProjectV2Item
+	var resp *github.Response
+	var err error
+	if ownerType == "org" {
+		sourceItem, resp, err = client.Projects.GetOrganizationProjectItem(ctx, owner, sourceProjectNumber, itemID, nil)
+	} else {
+		sourceItem, resp, err = client.Projects.GetUserProjectItem(ctx, owner, sourceProjectNumber, itemID, nil)
+	}
+	if err != nil {
+		return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to get project item", resp, err), nil, nil
+	}
+	_ = resp.Body.Close()
+
+	addOpts := &github.AddProjectItemOptions{}
+	switch {
+	case sourceItem.Content != nil && sourceItem.Content.Issue != nil:
+		addOpts.Type = github.Ptr(github.ProjectV2ItemContentTypeIssue)
+		addOpts.ID = sourceItem.Content.Issue.ID
+	case sourceItem.Content != nil && sourceItem.Content.PullRequest != nil:
+		addOpts.Type = github.Ptr(github.ProjectV2ItemContentTypePullRequest)
+		addOpts.ID = sourceItem.Content.PullRequest.ID
+	default:
+		return utils.NewToolResultError("only issue and pull request items can be moved between projects"), nil, nil
+	}
+
+	var targetItem *github.ProjectV2Item
+	if ownerType == "org" {
+		targetItem, resp, err = client.Projects.AddOrganizationProjectItem(ctx, owner, targetProjectNumber, addOpts)
+	} else {
+		targetItem, resp, err = client.Projects.AddUserProjectItem(ctx, owner, targetProjectNumber, addOpts)
+	}
+	if err != nil {
+		return ghErrors.NewGitHubAPIErrorResponse(ctx, ProjectAddFailedError, resp, err), nil, nil
+	}
+	_ = resp.Body.Close()
+
+	if updatePayload != nil {
+		var updatedItem *github.ProjectV2Item
+		if ownerType == "org" {
+			updatedItem, resp, err = client.Projects.UpdateOrganizationProjectItem(ctx, owner, targetProjectNumber, targetItem.GetID(), updatePayload)
+		} else {
+			updatedItem, resp, err = client.Projects.UpdateUserProjectItem(ctx, owner, targetProjectNumber, targetItem.GetID(), updatePayload)
+		}
+		if err != nil {
+			return ghErrors.NewGitHubAPIErrorResponse(ctx, ProjectUpdateFailedError, resp, err), nil, nil
+		}
+		_ = resp.Body.Close()
+		targetItem = updatedItem
+	}
+
+	if ownerType == "org" {
+		resp, err = client.Projects.DeleteOrganizationProjectItem(ctx, owner, sourceProjectNumber, itemID)
+	} else {
+		resp, err = client.Projects.DeleteUserProjectItem(ctx, owner, sourceProjectNumber, itemID)
+	}
+	if err != nil {
+		return ghErrors.NewGitHubAPIErrorResponse(ctx, "item added to target project but failed to remove it from the source project", resp, err), nil, nil
+	}
+	_ = resp.Body.Close()
+
+	r, err := json.Marshal(targetItem)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to marshal response: %w", err)
+	}
+
+	return utils.NewToolResultText(string(r)), nil, nil
+}
+
 // resolveProjectNodeID resolves (owner, ownerType, projectNumber) to a project node ID via GraphQL.
 func resolveProjectNodeID(ctx context.Context, gqlClient *githubv4.Client, owner, ownerType string, projectNumber int) (githubv4.ID, error) {
 	var projectIDQueryUser struct {
@@ -1224,6 +1333,11 @@ func listProjectStatusUpdates(ctx context.Context, gqlClient *githubv4.Client, a
 	if err != nil {
 		return utils.NewToolResultError(err.Error()), nil, nil
 	}
+	cursorScope := fmt.Sprintf("list_project_status_updates:%s/%s#%d", ownerType, owner, projectNumber)
+	afterCursor, err = verifyCursor(ctx, cursorScope, afterCursor)
+	if err != nil {
+		return utils.NewToolResultError(err.Error()), nil, nil
+	}
 
 	vars := map[string]any{
 		"owner":         githubv4.String(owner),
@@ -1265,8 +1379,8 @@ func listProjectStatusUpdates(ctx context.Context, gqlClient *githubv4.Client, a
 		"pageInfo": map[string]any{
 			"hasNextPage":     pi.HasNextPage,
 			"hasPreviousPage": pi.HasPreviousPage,
-			"nextCursor":      string(pi.EndCursor),
-			"prevCursor":      string(pi.StartCursor),
+			"nextCursor":      signCursor(ctx, cursorScope, string(pi.EndCursor)),
+			"prevCursor":      signCursor(ctx, cursorScope, string(pi.StartCursor)),
 		},
 	}
 
@@ -425,6 +425,7 @@ func Test_ProjectsWrite(t *testing.T) {
 	assert.Contains(t, inputSchema.Properties, "issue_number")
 	assert.Contains(t, inputSchema.Properties, "pull_request_number")
 	assert.Contains(t, inputSchema.Properties, "updated_field")
+	assert.Contains(t, inputSchema.Properties, "target_project_number")
 	assert.ElementsMatch(t, inputSchema.Required, []string{"method", "owner", "project_number"})
 
 	// Verify DestructiveHint is set
@@ -815,6 +816,118 @@ func Test_ProjectsWrite_DeleteProjectItem(t *testing.T) {
 	})
 }
 
+func Test_ProjectsWrite_MoveProjectItem(t *testing.T) {
+	toolDef := ProjectsWrite(translations.NullTranslationHelper)
+
+	sourceItem := map[string]any{
+		"id":           1001,
+		"content_type": "Issue",
+		"content":      map[string]any{"id": 555, "number": 42},
+	}
+
+	t.Run("success organization with field update", func(t *testing.T) {
+		var addedBody map[string]any
+		var deletedPath string
+		mockedClient := MockHTTPClientWithHandlers(map[string]http.HandlerFunc{
+			GetOrgsProjectsV2ItemsByProjectByItemID: mockResponse(t, http.StatusOK, sourceItem),
+			PostOrgsProjectsV2ItemsByProject: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/orgs/octo-org/projectsV2/2/items", r.URL.Path)
+				require.NoError(t, json.NewDecoder(r.Body).Decode(&addedBody))
+				w.WriteHeader(http.StatusCreated)
+				_, _ = w.Write([]byte(`{"id": 2002}`))
+			}),
+			PatchOrgsProjectsV2ItemsByProjectByItemID: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/orgs/octo-org/projectsV2/2/items/2002", r.URL.Path)
+				w.WriteHeader(http.StatusOK)
+				_, _ = w.Write([]byte(`{"id": 2002}`))
+			}),
+			DeleteOrgsProjectsV2ItemsByProjectByItemID: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				deletedPath = r.URL.Path
+				w.WriteHeader(http.StatusNoContent)
+			}),
+		})
+
+		deps := BaseDeps{
+			Client: gh.NewClient(mockedClient),
+		}
+		handler := toolDef.Handler(deps)
+		request := createMCPRequest(map[string]any{
+			"method":                "move_project_item",
+			"owner":                 "octo-org",
+			"owner_type":            "org",
+			"project_number":        float64(1),
+			"target_project_number": float64(2),
+			"item_id":               float64(1001),
+			"updated_field": map[string]any{
+				"id":    float64(101),
+				"value": "Todo",
+			},
+		})
+		result, err := handler(ContextWithDeps(context.Background(), deps), &request)
+
+		require.NoError(t, err)
+		require.False(t, result.IsError)
+
+		assert.Equal(t, "Issue", addedBody["type"])
+		assert.Equal(t, float64(555), addedBody["id"])
+		assert.Equal(t, "/orgs/octo-org/projectsV2/1/items/1001", deletedPath)
+
+		textContent := getTextResult(t, result)
+		var response map[string]any
+		require.NoError(t, json.Unmarshal([]byte(textContent.Text), &response))
+		assert.Equal(t, float64(2002), response["id"])
+	})
+
+	t.Run("draft issue cannot be moved", func(t *testing.T) {
+		mockedClient := MockHTTPClientWithHandlers(map[string]http.HandlerFunc{
+			GetUsersProjectsV2ItemsByUsernameByProjectByItemID: mockResponse(t, http.StatusOK, map[string]any{
+				"id":           1001,
+				"content_type": "DraftIssue",
+				"content":      map[string]any{"id": 7, "title": "Draft"},
+			}),
+		})
+
+		deps := BaseDeps{
+			Client: gh.NewClient(mockedClient),
+		}
+		handler := toolDef.Handler(deps)
+		request := createMCPRequest(map[string]any{
+			"method":                "move_project_item",
+			"owner":                 "octocat",
+			"owner_type":            "user",
+			"project_number":        float64(1),
+			"target_project_number": float64(2),
+			"item_id":               float64(1001),
+		})
+		result, err := handler(ContextWithDeps(context.Background(), deps), &request)
+
+		require.NoError(t, err)
+		require.True(t, result.IsError)
+		textContent := getTextResult(t, result)
+		assert.Contains(t, textContent.Text, "only issue and pull request items can be moved")
+	})
+
+	t.Run("missing target_project_number", func(t *testing.T) {
+		deps := BaseDeps{
+			Client: gh.NewClient(MockHTTPClientWithHandlers(map[string]http.HandlerFunc{})),
+		}
+		handler := toolDef.Handler(deps)
+		request := createMCPRequest(map[string]any{
+			"method":         "move_project_item",
+			"owner":          "octo-org",
+			"owner_type":     "org",
+			"project_number": float64(1),
+			"item_id":        float64(1001),
+		})
+		result, err := handler(ContextWithDeps(context.Background(), deps), &request)
+
+		require.NoError(t, err)
+		require.True(t, result.IsError)
+		textContent := getTextResult(t, result)
+		assert.Contains(t, textContent.Text, "missing required parameter: target_project_number")
+	})
+}
+
 func Test_ProjectsList_ListProjectStatusUpdates(t *testing.T) {
 	toolDef := ProjectsList(translations.NullTranslationHelper)
 
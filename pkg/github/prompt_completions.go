@@ -0,0 +1,238 @@
+package github
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"sync"
+	"time"
+
+	ghcontext "github.com/github/github-mcp-server/pkg/context"
+	"github.com/google/go-github/v82/github"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// completionCacheTTL bounds how stale cached completion values may be. Clients ask for completions
+// as the user types, so the same lookup is repeated many times in quick succession.
+const completionCacheTTL = time.Minute
+
+// PromptArgumentResolvers is a map of prompt argument names to their completion handlers
+var PromptArgumentResolvers = map[string]CompleteHandler{
+	"owner":     completeOwner,
+	"repo":      completeRepo,
+	"branch":    completeBranch,
+	"base":      completeBranch,
+	"head":      completeBranch,
+	"label":     completeLabel,
+	"labels":    completeListArgument(completeLabel),
+	"milestone": completeMilestone,
+	"assignee":  completeAssignee,
+	"assignees": completeListArgument(completeAssignee),
+}
+
+// PromptCompletionHandler returns a CompletionHandlerFunc for prompt argument completions.
+func PromptCompletionHandler(getClient GetClientFn) func(ctx context.Context, req *mcp.CompleteRequest) (*mcp.CompleteResult, error) {
+	return func(ctx context.Context, req *mcp.CompleteRequest) (*mcp.CompleteResult, error) {
+		if req.Params.Ref.Type != "ref/prompt" {
+			return nil, nil // Not a prompt completion
+		}
+
+		resolver, ok := PromptArgumentResolvers[req.Params.Argument.Name]
+		if !ok {
+			// Free-text arguments such as titles have nothing to complete
+			return &mcp.CompleteResult{Completion: mcp.CompletionResultDetails{Values: []string{}}}, nil
+		}
+
+		resolved := map[string]string{}
+		if req.Params.Context != nil && req.Params.Context.Arguments != nil {
+			resolved = req.Params.Context.Arguments
+		}
+
+		client, err := getClient(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		values, err := resolver(ctx, client, resolved, req.Params.Argument.Value)
+		if err != nil {
+			return nil, err
+		}
+		hasMore := len(values) > 100
+		if hasMore {
+			values = values[:100]
+		}
+
+		return &mcp.CompleteResult{
+			Completion: mcp.CompletionResultDetails{
+				Values:  values,
+				Total:   len(values),
+				HasMore: hasMore,
+			},
+		}, nil
+	}
+}
+
+// completeListArgument completes the last entry of a comma-separated argument, keeping the
+// entries before it, so "bug, enh" completes to "bug, enhancement".
+func completeListArgument(complete CompleteHandler) CompleteHandler {
+	return func(ctx context.Context, client *github.Client, resolved map[string]string, argValue string) ([]string, error) {
+		head, last := "", argValue
+		if i := strings.LastIndex(argValue, ","); i >= 0 {
+			head, last = argValue[:i+1]+" ", strings.TrimSpace(argValue[i+1:])
+		}
+		values, err := complete(ctx, client, resolved, last)
+		if err != nil {
+			return nil, err
+		}
+		for i, value := range values {
+			values[i] = head + value
+		}
+		return values, nil
+	}
+}
+
+func completeLabel(ctx context.Context, client *github.Client, resolved map[string]string, argValue string) ([]string, error) {
+	owner, repo := resolved["owner"], resolved["repo"]
+	if owner == "" || repo == "" {
+		return nil, errors.New("owner or repo not specified")
+	}
+	labels, err := completionLookups.get(ctx, "labels", owner, repo, func() ([]string, error) {
+		var names []string
+		opts := &github.ListOptions{PerPage: 100}
+		for {
+			labels, resp, err := client.Issues.ListLabels(ctx, owner, repo, opts)
+			if err != nil {
+				return nil, err
+			}
+			for _, label := range labels {
+				names = append(names, label.GetName())
+			}
+			if resp.NextPage == 0 {
+				return names, nil
+			}
+			opts.Page = resp.NextPage
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	return filterCompletions(labels, argValue), nil
+}
+
+func completeMilestone(ctx context.Context, client *github.Client, resolved map[string]string, argValue string) ([]string, error) {
+	owner, repo := resolved["owner"], resolved["repo"]
+	if owner == "" || repo == "" {
+		return nil, errors.New("owner or repo not specified")
+	}
+	milestones, err := completionLookups.get(ctx, "milestones", owner, repo, func() ([]string, error) {
+		milestones, _, err := client.Issues.ListMilestones(ctx, owner, repo, &github.MilestoneListOptions{
+			State:       "open",
+			ListOptions: github.ListOptions{PerPage: 100},
+		})
+		if err != nil {
+			return nil, err
+		}
+		titles := make([]string, 0, len(milestones))
+		for _, milestone := range milestones {
+			titles = append(titles, milestone.GetTitle())
+		}
+		return titles, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return filterCompletions(milestones, argValue), nil
+}
+
+func completeAssignee(ctx context.Context, client *github.Client, resolved map[string]string, argValue string) ([]string, error) {
+	owner, repo := resolved["owner"], resolved["repo"]
+	if owner == "" || repo == "" {
+		return nil, errors.New("owner or repo not specified")
+	}
+	assignees, err := completionLookups.get(ctx, "assignees", owner, repo, func() ([]string, error) {
+		users, _, err := client.Issues.ListAssignees(ctx, owner, repo, &github.ListOptions{PerPage: 100})
+		if err != nil {
+			return nil, err
+		}
+		logins := make([]string, 0, len(users))
+		for _, user := range users {
+			logins = append(logins, user.GetLogin())
+		}
+		return logins, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return filterCompletions(assignees, argValue), nil
+}
+
+// filterCompletions returns the values starting with argValue, ignoring case.
+func filterCompletions(values []string, argValue string) []string {
+	filtered := []string{}
+	prefix := strings.ToLower(argValue)
+	for _, value := range values {
+		if strings.HasPrefix(strings.ToLower(value), prefix) {
+			filtered = append(filtered, value)
+		}
+	}
+	return filtered
+}
+
+type completionCacheEntry struct {
+	values  []string
+	expires time.Time
+}
+
+// completionCache caches the results of completion lookups for completionCacheTTL.
+type completionCache struct {
+	mu      sync.Mutex
+	entries map[string]completionCacheEntry
+}
+
+var completionLookups = &completionCache{entries: make(map[string]completionCacheEntry)}
+
+// get returns the cached values of a lookup, or runs it. Entries are scoped to the token making
+// the request, so a remote server never completes values a user cannot see.
+func (c *completionCache) get(ctx context.Context, kind, owner, repo string, lookup func() ([]string, error)) ([]string, error) {
+	key := strings.Join([]string{completionCacheScope(ctx), kind, strings.ToLower(owner), strings.ToLower(repo)}, "/")
+
+	c.mu.Lock()
+	now := time.Now()
+	for k, entry := range c.entries {
+		if now.After(entry.expires) {
+			delete(c.entries, k)
+		}
+	}
+	entry, ok := c.entries[key]
+	c.mu.Unlock()
+	if ok {
+		return entry.values, nil
+	}
+
+	values, err := lookup()
+	if err != nil {
+		return nil, err
+	}
+	c.mu.Lock()
+	c.entries[key] = completionCacheEntry{values: values, expires: now.Add(completionCacheTTL)}
+	c.mu.Unlock()
+	return values, nil
+}
+
+func (c *completionCache) clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries = make(map[string]completionCacheEntry)
+}
+
+// completionCacheScope identifies the token a request is made with, when it is known.
+func completionCacheScope(ctx context.Context) string {
+	tokenInfo, ok := ghcontext.GetTokenInfo(ctx)
+	if !ok || tokenInfo == nil || tokenInfo.Token == "" {
+		return ""
+	}
+	sum := sha256.Sum256([]byte(tokenInfo.Token))
+	return hex.EncodeToString(sum[:8])
+}
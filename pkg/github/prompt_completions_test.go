@@ -0,0 +1,131 @@
+package github
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	ghcontext "github.com/github/github-mcp-server/pkg/context"
+	"github.com/google/go-github/v82/github"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func promptCompleteRequest(argName, argValue string, resolved map[string]string) *mcp.CompleteRequest {
+	return &mcp.CompleteRequest{
+		Params: &mcp.CompleteParams{
+			Ref:      &mcp.CompleteReference{Type: "ref/prompt", Name: "issue_to_fix_workflow"},
+			Argument: mcp.CompleteParamsArgument{Name: argName, Value: argValue},
+			Context:  &mcp.CompleteContext{Arguments: resolved},
+		},
+	}
+}
+
+func TestPromptCompletionHandler(t *testing.T) {
+	labels := []*github.Label{{Name: github.Ptr("bug")}, {Name: github.Ptr("Enhancement")}, {Name: github.Ptr("enhancement-candidate")}}
+	milestones := []*github.Milestone{{Title: github.Ptr("v1.0")}, {Title: github.Ptr("v2.0")}}
+	assignees := []*github.User{{Login: github.Ptr("octocat")}, {Login: github.Ptr("hubot")}}
+	repo := map[string]string{"owner": "octo", "repo": "hello"}
+
+	tests := []struct {
+		name     string
+		argName  string
+		argValue string
+		resolved map[string]string
+		expected []string
+		wantErr  string
+	}{
+		{
+			name:     "labels match prefix ignoring case",
+			argName:  "label",
+			argValue: "enh",
+			resolved: repo,
+			expected: []string{"Enhancement", "enhancement-candidate"},
+		},
+		{
+			name:     "comma-separated labels complete the last entry",
+			argName:  "labels",
+			argValue: "bug, enhancement-",
+			resolved: repo,
+			expected: []string{"bug, enhancement-candidate"},
+		},
+		{
+			name:     "milestone titles",
+			argName:  "milestone",
+			argValue: "v2",
+			resolved: repo,
+			expected: []string{"v2.0"},
+		},
+		{
+			name:     "assignable users",
+			argName:  "assignees",
+			argValue: "",
+			resolved: repo,
+			expected: []string{"octocat", "hubot"},
+		},
+		{
+			name:     "free-text argument has no completions",
+			argName:  "title",
+			argValue: "Fix",
+			resolved: repo,
+			expected: []string{},
+		},
+		{
+			name:     "missing repo",
+			argName:  "label",
+			resolved: map[string]string{"owner": "octo"},
+			wantErr:  "owner or repo not specified",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			completionLookups.clear()
+			t.Cleanup(completionLookups.clear)
+
+			client := github.NewClient(MockHTTPClientWithHandlers(map[string]http.HandlerFunc{
+				"GET /repos/octo/hello/labels":     mockResponse(t, http.StatusOK, labels),
+				"GET /repos/octo/hello/milestones": mockResponse(t, http.StatusOK, milestones),
+				"GET /repos/octo/hello/assignees":  mockResponse(t, http.StatusOK, assignees),
+			}))
+			handler := CompletionsHandler(func(_ context.Context) (*github.Client, error) { return client, nil })
+
+			result, err := handler(context.Background(), promptCompleteRequest(tc.argName, tc.argValue, tc.resolved))
+			if tc.wantErr != "" {
+				require.ErrorContains(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, result.Completion.Values)
+		})
+	}
+}
+
+func TestPromptCompletionHandler_CachesLookups(t *testing.T) {
+	completionLookups.clear()
+	t.Cleanup(completionLookups.clear)
+
+	calls := 0
+	client := github.NewClient(MockHTTPClientWithHandlers(map[string]http.HandlerFunc{
+		"GET /repos/octo/hello/branches": func(w http.ResponseWriter, r *http.Request) {
+			calls++
+			mockResponse(t, http.StatusOK, []*github.Branch{{Name: github.Ptr("main")}, {Name: github.Ptr("feature")}})(w, r)
+		},
+	}))
+	handler := CompletionsHandler(func(_ context.Context) (*github.Client, error) { return client, nil })
+	resolved := map[string]string{"owner": "octo", "repo": "hello"}
+
+	for _, value := range []string{"", "m", "ma"} {
+		result, err := handler(context.Background(), promptCompleteRequest("branch", value, resolved))
+		require.NoError(t, err)
+		assert.Contains(t, result.Completion.Values, "main")
+	}
+	assert.Equal(t, 1, calls)
+
+	// Another token does not share the cached values
+	ctx := ghcontext.WithTokenInfo(context.Background(), &ghcontext.TokenInfo{Token: "other"})
+	_, err := handler(ctx, promptCompleteRequest("branch", "", resolved))
+	require.NoError(t, err)
+	assert.Equal(t, 2, calls)
+}
@@ -0,0 +1,52 @@
+package github
+
+import (
+	"slices"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// ProvenanceMetaKey is the _meta key under which content provenance is attached to tool results.
+const ProvenanceMetaKey = "github.com/provenance"
+
+// ContentProvenance describes the origin of user-authored content in a tool result, so that
+// guardrail layers in hosts can make policy decisions without re-fetching the content.
+type ContentProvenance struct {
+	// AuthorAssociations lists the distinct author associations (OWNER, MEMBER, NONE, ...) of
+	// the content included in the result.
+	AuthorAssociations []string `json:"author_associations"`
+	// Lockdown reports whether lockdown mode was applied to the result.
+	Lockdown bool `json:"lockdown"`
+	// LockdownFiltered is the number of items withheld from the result by lockdown mode.
+	LockdownFiltered int `json:"lockdown_filtered"`
+	// Truncated reports whether the result omits content due to size limits.
+	Truncated bool `json:"truncated"`
+}
+
+func newContentProvenance(lockdown bool) *ContentProvenance {
+	return &ContentProvenance{
+		AuthorAssociations: []string{},
+		Lockdown:           lockdown,
+	}
+}
+
+// addAuthorAssociation records the author association of an item included in the result.
+func (p *ContentProvenance) addAuthorAssociation(association string) {
+	if association == "" || slices.Contains(p.AuthorAssociations, association) {
+		return
+	}
+	p.AuthorAssociations = append(p.AuthorAssociations, association)
+	slices.Sort(p.AuthorAssociations)
+}
+
+// withProvenance attaches the provenance to the result's _meta and returns the result.
+func withProvenance(result *mcp.CallToolResult, p *ContentProvenance) *mcp.CallToolResult {
+	if result == nil || p == nil {
+		return result
+	}
+	if result.Meta == nil {
+		result.Meta = mcp.Meta{}
+	}
+	result.Meta[ProvenanceMetaKey] = p
+	return result
+}
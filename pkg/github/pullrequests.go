@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"strings"
 
 	"github.com/go-viper/mapstructure/v2"
 	"github.com/google/go-github/v82/github"
@@ -15,6 +16,7 @@ import (
 
 	ghErrors "github.com/github/github-mcp-server/pkg/errors"
 	"github.com/github/github-mcp-server/pkg/inventory"
+	"github.com/github/github-mcp-server/pkg/lockdown"
 	"github.com/github/github-mcp-server/pkg/octicons"
 	"github.com/github/github-mcp-server/pkg/sanitize"
 	"github.com/github/github-mcp-server/pkg/scopes"
@@ -39,8 +41,10 @@ Possible options:
  6. get_reviews - Get the reviews on a pull request. When asked for review comments, use get_review_comments method.
  7. get_comments - Get comments on a pull request. Use this if user doesn't specifically want review comments. Use with pagination parameters to control the number of results returned.
  8. get_check_runs - Get check runs for the head commit of a pull request. Check runs are the individual CI/CD jobs and checks that run on the PR.
+ 9. get_linked_issues - Get the issues this pull request will close when merged, whether linked by closing keywords or manually.
+ 10. get_unresolved_review_threads - Get all unresolved review threads on a pull request, with the thread IDs to resolve them with pull_request_review_write. Use this to find the conversations that still block merging on repositories requiring resolved threads.
 `,
-				Enum: []any{"get", "get_diff", "get_status", "get_files", "get_review_comments", "get_reviews", "get_comments", "get_check_runs"},
+				Enum: []any{"get", "get_diff", "get_status", "get_files", "get_review_comments", "get_reviews", "get_comments", "get_check_runs", "get_linked_issues", "get_unresolved_review_threads"},
 			},
 			"owner": {
 				Type:        "string",
@@ -132,6 +136,20 @@ Possible options:
 			case "get_check_runs":
 				result, err := GetPullRequestCheckRuns(ctx, client, owner, repo, pullNumber, pagination)
 				return result, nil, err
+			case "get_linked_issues":
+				gqlClient, err := deps.GetGQLClient(ctx)
+				if err != nil {
+					return utils.NewToolResultErrorFromErr("failed to get GitHub GQL client", err), nil, nil
+				}
+				result, err := GetPullRequestLinkedIssues(ctx, gqlClient, owner, repo, pullNumber)
+				return result, nil, err
+			case "get_unresolved_review_threads":
+				gqlClient, err := deps.GetGQLClient(ctx)
+				if err != nil {
+					return utils.NewToolResultErrorFromErr("failed to get GitHub GQL client", err), nil, nil
+				}
+				result, err := GetPullRequestUnresolvedReviewThreads(ctx, gqlClient, deps, owner, repo, pullNumber)
+				return result, nil, err
 			default:
 				return utils.NewToolResultError(fmt.Sprintf("unknown method: %s", method)), nil, nil
 			}
@@ -169,10 +187,11 @@ func GetPullRequest(ctx context.Context, client *github.Client, deps ToolDepende
 			pr.Title = github.Ptr(sanitize.Sanitize(*pr.Title))
 		}
 		if pr.Body != nil {
-			pr.Body = github.Ptr(sanitize.Sanitize(*pr.Body))
+			pr.Body = github.Ptr(sanitize.Sanitize(normalizeBody(ctx, deps, *pr.Body, pr.GetHTMLURL())))
 		}
 	}
 
+	provenance := newContentProvenance(ff.LockdownMode)
 	if ff.LockdownMode {
 		if cache == nil {
 			return nil, fmt.Errorf("lockdown cache is not configured")
@@ -185,14 +204,16 @@ func GetPullRequest(ctx context.Context, client *github.Client, deps ToolDepende
 			}
 
 			if !isSafeContent {
-				return utils.NewToolResultError("access to pull request is restricted by lockdown mode"), nil
+				provenance.LockdownFiltered = 1
+				return withProvenance(utils.NewToolResultError("access to pull request is restricted by lockdown mode"), provenance), nil
 			}
 		}
 	}
+	provenance.addAuthorAssociation(pr.GetAuthorAssociation())
 
 	minimalPR := convertToMinimalPullRequest(pr)
 
-	return MarshalledTextResult(minimalPR), nil
+	return withProvenance(MarshalledTextResult(minimalPR), provenance), nil
 }
 
 func GetPullRequestDiff(ctx context.Context, client *github.Client, owner, repo string, pullNumber int) (*mcp.CallToolResult, error) {
@@ -416,7 +437,8 @@ func GetPullRequestReviewComments(ctx context.Context, gqlClient *githubv4.Clien
 	ff := deps.GetFlags(ctx)
 
 	// Convert pagination parameters to GraphQL format
-	gqlParams, err := pagination.ToGraphQLParams()
+	cursorScope := fmt.Sprintf("get_review_comments:%s/%s#%d", owner, repo, pullNumber)
+	gqlParams, err := pagination.ToSignedGraphQLParams(ctx, cursorScope)
 	if err != nil {
 		return utils.NewToolResultError(fmt.Sprintf("invalid pagination parameters: %v", err)), nil
 	}
@@ -447,35 +469,124 @@ func GetPullRequestReviewComments(ctx context.Context, gqlClient *githubv4.Clien
 	}
 
 	// Lockdown mode filtering
+	provenance := newContentProvenance(ff.LockdownMode)
 	if ff.LockdownMode {
 		if cache == nil {
 			return nil, fmt.Errorf("lockdown cache is not configured")
 		}
+		if err := filterReviewThreadComments(ctx, cache, owner, repo, query.Repository.PullRequest.ReviewThreads.Nodes, provenance); err != nil {
+			return nil, err
+		}
+	}
+
+	response := convertToMinimalReviewThreadsResponse(query)
+	response.PageInfo = signPageInfo(ctx, cursorScope, response.PageInfo)
+	return withProvenance(MarshalledTextResult(response), provenance), nil
+}
 
-		// Iterate through threads and filter comments
-		for i := range query.Repository.PullRequest.ReviewThreads.Nodes {
-			thread := &query.Repository.PullRequest.ReviewThreads.Nodes[i]
-			filteredComments := make([]reviewCommentNode, 0, len(thread.Comments.Nodes))
+// filterReviewThreadComments drops the comments of review threads whose authors lack push
+// access, for lockdown mode.
+func filterReviewThreadComments(ctx context.Context, cache *lockdown.RepoAccessCache, owner, repo string, threads []reviewThreadNode, provenance *ContentProvenance) error {
+	for i := range threads {
+		thread := &threads[i]
+		filteredComments := make([]reviewCommentNode, 0, len(thread.Comments.Nodes))
 
-			for _, comment := range thread.Comments.Nodes {
-				login := string(comment.Author.Login)
-				if login != "" {
-					isSafeContent, err := cache.IsSafeContent(ctx, login, owner, repo)
-					if err != nil {
-						return nil, fmt.Errorf("failed to check lockdown mode: %w", err)
-					}
-					if isSafeContent {
-						filteredComments = append(filteredComments, comment)
-					}
+		for _, comment := range thread.Comments.Nodes {
+			login := string(comment.Author.Login)
+			if login != "" {
+				isSafeContent, err := cache.IsSafeContent(ctx, login, owner, repo)
+				if err != nil {
+					return fmt.Errorf("failed to check lockdown mode: %w", err)
+				}
+				if isSafeContent {
+					filteredComments = append(filteredComments, comment)
 				}
 			}
+		}
+
+		provenance.LockdownFiltered += len(thread.Comments.Nodes) - len(filteredComments)
+		thread.Comments.Nodes = filteredComments
+		thread.Comments.TotalCount = githubv4.Int(int32(len(filteredComments))) //nolint:gosec // comment count is bounded by API limits
+	}
+	return nil
+}
+
+// maxReviewThreadPages bounds the pages of 100 review threads read to find the unresolved ones.
+const maxReviewThreadPages = 10
+
+// UnresolvedReviewThreadsResponse is the output of get_unresolved_review_threads.
+type UnresolvedReviewThreadsResponse struct {
+	ReviewThreads   []MinimalReviewThread `json:"review_threads"`
+	UnresolvedCount int                   `json:"unresolved_count"`
+	// TotalCount counts all review threads of the pull request, resolved or not.
+	TotalCount int `json:"total_count"`
+	// Incomplete reports that the pull request has more review threads than were read.
+	Incomplete bool `json:"incomplete,omitempty"`
+}
+
+// GetPullRequestUnresolvedReviewThreads reads the review threads of a pull request and returns the
+// unresolved ones. The GraphQL API cannot filter threads by state, so they are filtered here.
+func GetPullRequestUnresolvedReviewThreads(ctx context.Context, gqlClient *githubv4.Client, deps ToolDependencies, owner, repo string, pullNumber int) (*mcp.CallToolResult, error) {
+	cache, err := deps.GetRepoAccessCache(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get repo access cache: %w", err)
+	}
+	ff := deps.GetFlags(ctx)
+
+	vars := map[string]any{
+		"owner":             githubv4.String(owner),
+		"repo":              githubv4.String(repo),
+		"prNum":             githubv4.Int(int32(pullNumber)), //nolint:gosec // pullNumber is controlled by user input validation
+		"first":             githubv4.Int(100),
+		"commentsPerThread": githubv4.Int(100),
+		"after":             (*githubv4.String)(nil),
+	}
+
+	var unresolved []reviewThreadNode
+	var response UnresolvedReviewThreadsResponse
+	for page := 0; page < maxReviewThreadPages; page++ {
+		var query reviewThreadsQuery
+		if err := gqlClient.Query(ctx, &query, vars); err != nil {
+			return ghErrors.NewGitHubGraphQLErrorResponse(ctx,
+				"failed to get pull request review threads",
+				err,
+			), nil
+		}
+
+		threads := query.Repository.PullRequest.ReviewThreads
+		response.TotalCount = int(threads.TotalCount)
+		for _, thread := range threads.Nodes {
+			if !thread.IsResolved {
+				unresolved = append(unresolved, thread)
+			}
+		}
+		if !threads.PageInfo.HasNextPage {
+			break
+		}
+		if page == maxReviewThreadPages-1 {
+			response.Incomplete = true
+			break
+		}
+		vars["after"] = githubv4.String(threads.PageInfo.EndCursor)
+	}
 
-			thread.Comments.Nodes = filteredComments
-			thread.Comments.TotalCount = githubv4.Int(int32(len(filteredComments))) //nolint:gosec // comment count is bounded by API limits
+	provenance := newContentProvenance(ff.LockdownMode)
+	if ff.LockdownMode {
+		if cache == nil {
+			return nil, fmt.Errorf("lockdown cache is not configured")
 		}
+		if err := filterReviewThreadComments(ctx, cache, owner, repo, unresolved, provenance); err != nil {
+			return nil, err
+		}
+	}
+
+	response.ReviewThreads = make([]MinimalReviewThread, 0, len(unresolved))
+	for _, thread := range unresolved {
+		response.ReviewThreads = append(response.ReviewThreads, convertToMinimalReviewThread(thread))
 	}
+	response.UnresolvedCount = len(response.ReviewThreads)
 
-	return MarshalledTextResult(convertToMinimalReviewThreadsResponse(query)), nil
+	return withProvenance(MarshalledTextResult(response), provenance), nil
 }
 
 func GetPullRequestReviews(ctx context.Context, client *github.Client, deps ToolDependencies, owner, repo string, pullNumber int) (*mcp.CallToolResult, error) {
@@ -503,10 +614,12 @@ func GetPullRequestReviews(ctx context.Context, client *github.Client, deps Tool
 		return ghErrors.NewGitHubAPIStatusErrorResponse(ctx, "failed to get pull request reviews", resp, body), nil
 	}
 
+	provenance := newContentProvenance(ff.LockdownMode)
 	if ff.LockdownMode {
 		if cache == nil {
 			return nil, fmt.Errorf("lockdown cache is not configured")
 		}
+		total := len(reviews)
 		filteredReviews := make([]*github.PullRequestReview, 0, len(reviews))
 		for _, review := range reviews {
 			login := review.GetUser().GetLogin()
@@ -521,14 +634,16 @@ func GetPullRequestReviews(ctx context.Context, client *github.Client, deps Tool
 				reviews = filteredReviews
 			}
 		}
+		provenance.LockdownFiltered = total - len(reviews)
 	}
 
 	minimalReviews := make([]MinimalPullRequestReview, 0, len(reviews))
 	for _, review := range reviews {
+		provenance.addAuthorAssociation(review.GetAuthorAssociation())
 		minimalReviews = append(minimalReviews, convertToMinimalPullRequestReview(review))
 	}
 
-	return MarshalledTextResult(minimalReviews), nil
+	return withProvenance(MarshalledTextResult(minimalReviews), provenance), nil
 }
 
 // PullRequestWriteUIResourceURI is the URI for the create_pull_request tool's MCP App UI resource.
@@ -540,7 +655,7 @@ func CreatePullRequest(t translations.TranslationHelperFunc) inventory.ServerToo
 		ToolsetMetadataPullRequests,
 		mcp.Tool{
 			Name:        "create_pull_request",
-			Description: t("TOOL_CREATE_PULL_REQUEST_DESCRIPTION", "Create a new pull request in a GitHub repository."),
+			Description: t("TOOL_CREATE_PULL_REQUEST_DESCRIPTION", "Create a new pull request in a GitHub repository. Set 'use_template' to pre-fill the description from the repository's pull request template, and 'strict_template' to verify that every template section has been filled in. Repositories may set a default base branch and require their template in .github/mcp-server.yml."),
 			Annotations: &mcp.ToolAnnotations{
 				Title:        t("TOOL_CREATE_PULL_REQUEST_USER_TITLE", "Open new pull request"),
 				ReadOnlyHint: false,
@@ -576,7 +691,7 @@ func CreatePullRequest(t translations.TranslationHelperFunc) inventory.ServerToo
 					},
 					"base": {
 						Type:        "string",
-						Description: "Branch to merge into",
+						Description: "Branch to merge into. Required unless the repository sets a base branch in its .github/mcp-server.yml",
 					},
 					"draft": {
 						Type:        "boolean",
@@ -586,8 +701,16 @@ func CreatePullRequest(t translations.TranslationHelperFunc) inventory.ServerToo
 						Type:        "boolean",
 						Description: "Allow maintainer edits",
 					},
+					"use_template": {
+						Type:        "boolean",
+						Description: "When no body is provided, pre-fill the PR description with the repository's pull request template",
+					},
+					"strict_template": {
+						Type:        "boolean",
+						Description: "Reject the PR if the body does not contain every section of the repository's pull request template with its content filled in",
+					},
 				},
-				Required: []string{"owner", "repo", "title", "head", "base"},
+				Required: []string{"owner", "repo", "title", "head"},
 			},
 		},
 		[]scopes.Scope{scopes.Repo},
@@ -629,9 +752,6 @@ func CreatePullRequest(t translations.TranslationHelperFunc) inventory.ServerToo
 			if head == "" {
 				return utils.NewToolResultError("missing required parameter: head"), nil, nil
 			}
-			if base == "" {
-				return utils.NewToolResultError("missing required parameter: base"), nil, nil
-			}
 
 			body, err := OptionalParam[string](args, "body")
 			if err != nil {
@@ -648,6 +768,54 @@ func CreatePullRequest(t translations.TranslationHelperFunc) inventory.ServerToo
 				return utils.NewToolResultError(err.Error()), nil, nil
 			}
 
+			useTemplate, err := OptionalParam[bool](args, "use_template")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+
+			strictTemplate, err := OptionalParam[bool](args, "strict_template")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+
+			client, err := deps.GetClient(ctx)
+			if err != nil {
+				return utils.NewToolResultErrorFromErr("failed to get GitHub client", err), nil, nil
+			}
+			config, err := repoConfigs.get(ctx, client, owner, repo)
+			if err != nil {
+				return utils.NewToolResultErrorFromErr("failed to get repository configuration", err), nil, nil
+			}
+			if base == "" {
+				base = config.BaseBranch
+			}
+			if base == "" {
+				return utils.NewToolResultError("missing required parameter: base"), nil, nil
+			}
+			strictTemplate = strictTemplate || config.RequirePullRequestTemplate
+
+			if (useTemplate && body == "") || strictTemplate {
+				gqlClient, err := deps.GetGQLClient(ctx)
+				if err != nil {
+					return utils.NewToolResultErrorFromErr("failed to get GitHub GQL client", err), nil, nil
+				}
+				template, err := fetchPullRequestTemplate(ctx, gqlClient, owner, repo)
+				if err != nil {
+					return ghErrors.NewGitHubGraphQLErrorResponse(ctx, "failed to get pull request template", err), nil, nil
+				}
+				if template != nil {
+					if useTemplate && body == "" {
+						body = template.Body
+					}
+					if strictTemplate {
+						if problems := validatePullRequestBody(template.Body, body); len(problems) > 0 {
+							return utils.NewToolResultError(fmt.Sprintf("pull request body does not follow the repository's template (%s): %s\n\nTemplate:\n%s",
+								template.Filename, strings.Join(problems, "; "), template.Body)), nil, nil
+						}
+					}
+				}
+			}
+
 			newPR := &github.NewPullRequest{
 				Title: github.Ptr(title),
 				Head:  github.Ptr(head),
@@ -661,10 +829,6 @@ func CreatePullRequest(t translations.TranslationHelperFunc) inventory.ServerToo
 			newPR.Draft = github.Ptr(draft)
 			newPR.MaintainerCanModify = github.Ptr(maintainerCanModify)
 
-			client, err := deps.GetClient(ctx)
-			if err != nil {
-				return utils.NewToolResultErrorFromErr("failed to get GitHub client", err), nil, nil
-			}
 			pr, resp, err := client.PullRequests.Create(ctx, owner, repo, newPR)
 			if err != nil {
 				return ghErrors.NewGitHubAPIErrorResponse(ctx,
@@ -1319,8 +1483,12 @@ func MergePullRequest(t translations.TranslationHelperFunc) inventory.ServerTool
 			}
 			result, resp, err := client.PullRequests.Merge(ctx, owner, repo, pullNumber, commitMessage, options)
 			if err != nil {
+				message := "failed to merge pull request"
+				if strings.Contains(strings.ToLower(err.Error()), "merge queue") {
+					message = "failed to merge pull request: its base branch requires a merge queue, add it to the queue with merge_queue_write instead"
+				}
 				return ghErrors.NewGitHubAPIErrorResponse(ctx,
-					"failed to merge pull request",
+					message,
 					resp,
 					err,
 				), nil, nil
@@ -1387,6 +1555,8 @@ func SearchPullRequests(t translations.TranslationHelperFunc) inventory.ServerTo
 		Required: []string{"query"},
 	}
 	WithPagination(schema)
+	WithSearchRanking(schema)
+	WithArchivedFilter(schema)
 
 	return NewTool(
 		ToolsetMetadataPullRequests,
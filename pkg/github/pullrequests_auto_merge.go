@@ -0,0 +1,283 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/inventory"
+	"github.com/github/github-mcp-server/pkg/scopes"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/github/github-mcp-server/pkg/utils"
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/shurcooL/githubv4"
+)
+
+// autoMergeMethods maps the merge_method values of merge_pull_request to their GraphQL enum.
+var autoMergeMethods = map[string]githubv4.PullRequestMergeMethod{
+	"merge":  githubv4.PullRequestMergeMethodMerge,
+	"squash": githubv4.PullRequestMergeMethodSquash,
+	"rebase": githubv4.PullRequestMergeMethodRebase,
+}
+
+// autoMergeRequestNode is the auto-merge request of a pull request.
+type autoMergeRequestNode struct {
+	MergeMethod githubv4.String
+	EnabledAt   githubv4.DateTime
+	EnabledBy   struct {
+		Login githubv4.String
+	}
+}
+
+// AutoMerge describes the auto-merge request of a pull request.
+type AutoMerge struct {
+	PullNumber  int    `json:"pull_number"`
+	URL         string `json:"url"`
+	MergeMethod string `json:"merge_method"`
+	EnabledAt   string `json:"enabled_at,omitempty"`
+	EnabledBy   string `json:"enabled_by,omitempty"`
+	MergeQueue  bool   `json:"merge_queue,omitempty"`
+}
+
+// autoMergePullRequestQuery reads what enabling or disabling auto-merge on a pull request needs.
+type autoMergePullRequestQuery struct {
+	Repository struct {
+		PullRequest struct {
+			ID                  githubv4.ID
+			URL                 githubv4.String
+			IsMergeQueueEnabled githubv4.Boolean
+			AutoMergeRequest    *autoMergeRequestNode
+		} `graphql:"pullRequest(number: $pullNumber)"`
+	} `graphql:"repository(owner: $owner, name: $repo)"`
+}
+
+func queryAutoMergePullRequest(ctx context.Context, client *githubv4.Client, owner, repo string, pullNumber int) (*autoMergePullRequestQuery, error) {
+	var q autoMergePullRequestQuery
+	vars := map[string]any{
+		"owner":      githubv4.String(owner),
+		"repo":       githubv4.String(repo),
+		"pullNumber": githubv4.Int(int32(pullNumber)), // #nosec G115 - pull request numbers are always small positive integers
+	}
+	if err := client.Query(ctx, &q, vars); err != nil {
+		return nil, err
+	}
+	return &q, nil
+}
+
+// EnablePullRequestAutoMerge creates a tool to merge a pull request automatically once its
+// requirements are met.
+func EnablePullRequestAutoMerge(t translations.TranslationHelperFunc) inventory.ServerTool {
+	return NewTool(
+		ToolsetMetadataPullRequests,
+		mcp.Tool{
+			Name:        "enable_pull_request_auto_merge",
+			Description: t("TOOL_ENABLE_PULL_REQUEST_AUTO_MERGE_DESCRIPTION", "Enable auto-merge on a pull request, so that GitHub merges it once its required reviews and checks pass, instead of polling until it can be merged. On branches with a merge queue, the pull request is added to the queue once it is ready, and the merge method and commit message are set by the queue. The repository must allow auto-merge."),
+			Annotations: &mcp.ToolAnnotations{
+				Title:          t("TOOL_ENABLE_PULL_REQUEST_AUTO_MERGE_USER_TITLE", "Enable pull request auto-merge"),
+				ReadOnlyHint:   false,
+				IdempotentHint: true,
+			},
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"owner": {
+						Type:        "string",
+						Description: "Repository owner",
+					},
+					"repo": {
+						Type:        "string",
+						Description: "Repository name",
+					},
+					"pullNumber": {
+						Type:        "number",
+						Description: "Pull request number",
+					},
+					"merge_method": {
+						Type:        "string",
+						Description: "Merge method. Defaults to merge",
+						Enum:        []any{"merge", "squash", "rebase"},
+					},
+					"commit_title": {
+						Type:        "string",
+						Description: "Title for merge commit",
+					},
+					"commit_message": {
+						Type:        "string",
+						Description: "Extra detail for merge commit",
+					},
+					"expected_head_sha": {
+						Type:        "string",
+						Description: "Only enable auto-merge if the head of the pull request is still this commit",
+					},
+				},
+				Required: []string{"owner", "repo", "pullNumber"},
+			},
+		},
+		[]scopes.Scope{scopes.Repo},
+		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+			owner, err := RequiredParam[string](args, "owner")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			repo, err := RequiredParam[string](args, "repo")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			pullNumber, err := RequiredInt(args, "pullNumber")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			mergeMethod, err := OptionalParam[string](args, "merge_method")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			commitTitle, err := OptionalParam[string](args, "commit_title")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			commitMessage, err := OptionalParam[string](args, "commit_message")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			expectedHeadSHA, err := OptionalParam[string](args, "expected_head_sha")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+
+			input := githubv4.EnablePullRequestAutoMergeInput{}
+			if mergeMethod != "" {
+				method, ok := autoMergeMethods[mergeMethod]
+				if !ok {
+					return utils.NewToolResultError(fmt.Sprintf("invalid merge_method: %s", mergeMethod)), nil, nil
+				}
+				input.MergeMethod = &method
+			}
+			if commitTitle != "" {
+				input.CommitHeadline = githubv4.NewString(githubv4.String(commitTitle))
+			}
+			if commitMessage != "" {
+				input.CommitBody = githubv4.NewString(githubv4.String(commitMessage))
+			}
+			if expectedHeadSHA != "" {
+				input.ExpectedHeadOid = githubv4.NewGitObjectID(githubv4.GitObjectID(expectedHeadSHA))
+			}
+
+			client, err := deps.GetGQLClient(ctx)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to get GitHub GQL client: %w", err)
+			}
+			q, err := queryAutoMergePullRequest(ctx, client, owner, repo, pullNumber)
+			if err != nil {
+				return ghErrors.NewGitHubGraphQLErrorResponse(ctx, "failed to get pull request", err), nil, nil
+			}
+			input.PullRequestID = q.Repository.PullRequest.ID
+
+			var m struct {
+				EnablePullRequestAutoMerge struct {
+					PullRequest struct {
+						AutoMergeRequest *autoMergeRequestNode
+					}
+				} `graphql:"enablePullRequestAutoMerge(input: $input)"`
+			}
+			if err := client.Mutate(ctx, &m, input, nil); err != nil {
+				message := "failed to enable auto-merge"
+				if strings.Contains(strings.ToLower(err.Error()), "clean status") {
+					message = "failed to enable auto-merge: the pull request can already be merged, merge it with merge_pull_request instead"
+				}
+				return ghErrors.NewGitHubGraphQLErrorResponse(ctx, message, err), nil, nil
+			}
+
+			result := AutoMerge{
+				PullNumber: pullNumber,
+				URL:        string(q.Repository.PullRequest.URL),
+				MergeQueue: bool(q.Repository.PullRequest.IsMergeQueueEnabled),
+			}
+			if request := m.EnablePullRequestAutoMerge.PullRequest.AutoMergeRequest; request != nil {
+				result.MergeMethod = strings.ToLower(string(request.MergeMethod))
+				result.EnabledBy = string(request.EnabledBy.Login)
+				if !request.EnabledAt.IsZero() {
+					result.EnabledAt = request.EnabledAt.Format(time.RFC3339)
+				}
+			}
+			return MarshalledTextResult(result), nil, nil
+		},
+	)
+}
+
+// DisablePullRequestAutoMerge creates a tool to cancel the auto-merge of a pull request.
+func DisablePullRequestAutoMerge(t translations.TranslationHelperFunc) inventory.ServerTool {
+	return NewTool(
+		ToolsetMetadataPullRequests,
+		mcp.Tool{
+			Name:        "disable_pull_request_auto_merge",
+			Description: t("TOOL_DISABLE_PULL_REQUEST_AUTO_MERGE_DESCRIPTION", "Disable auto-merge on a pull request, so that it is no longer merged automatically once its requirements are met."),
+			Annotations: &mcp.ToolAnnotations{
+				Title:          t("TOOL_DISABLE_PULL_REQUEST_AUTO_MERGE_USER_TITLE", "Disable pull request auto-merge"),
+				ReadOnlyHint:   false,
+				IdempotentHint: true,
+			},
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"owner": {
+						Type:        "string",
+						Description: "Repository owner",
+					},
+					"repo": {
+						Type:        "string",
+						Description: "Repository name",
+					},
+					"pullNumber": {
+						Type:        "number",
+						Description: "Pull request number",
+					},
+				},
+				Required: []string{"owner", "repo", "pullNumber"},
+			},
+		},
+		[]scopes.Scope{scopes.Repo},
+		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+			owner, err := RequiredParam[string](args, "owner")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			repo, err := RequiredParam[string](args, "repo")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			pullNumber, err := RequiredInt(args, "pullNumber")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+
+			client, err := deps.GetGQLClient(ctx)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to get GitHub GQL client: %w", err)
+			}
+			q, err := queryAutoMergePullRequest(ctx, client, owner, repo, pullNumber)
+			if err != nil {
+				return ghErrors.NewGitHubGraphQLErrorResponse(ctx, "failed to get pull request", err), nil, nil
+			}
+			ref := fmt.Sprintf("%s/%s#%d", owner, repo, pullNumber)
+			if q.Repository.PullRequest.AutoMergeRequest == nil {
+				return utils.NewToolResultText(fmt.Sprintf("auto-merge is not enabled for %s", ref)), nil, nil
+			}
+
+			var m struct {
+				DisablePullRequestAutoMerge struct {
+					PullRequest struct {
+						Number githubv4.Int
+					}
+				} `graphql:"disablePullRequestAutoMerge(input: $input)"`
+			}
+			input := githubv4.DisablePullRequestAutoMergeInput{PullRequestID: q.Repository.PullRequest.ID}
+			if err := client.Mutate(ctx, &m, input, nil); err != nil {
+				return ghErrors.NewGitHubGraphQLErrorResponse(ctx, "failed to disable auto-merge", err), nil, nil
+			}
+			return utils.NewToolResultText(fmt.Sprintf("auto-merge is disabled for %s", ref)), nil, nil
+		},
+	)
+}
@@ -0,0 +1,168 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/githubv4mock"
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/shurcooL/githubv4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func mockAutoMergePullRequest(autoMergeRequest map[string]any) githubv4mock.Matcher {
+	return githubv4mock.NewQueryMatcher(
+		autoMergePullRequestQuery{},
+		map[string]any{"owner": githubv4.String("octo"), "repo": githubv4.String("ink"), "pullNumber": githubv4.Int(7)},
+		githubv4mock.DataResponse(map[string]any{"repository": map[string]any{"pullRequest": map[string]any{
+			"id":                  "PR_7",
+			"url":                 "https://github.com/octo/ink/pull/7",
+			"isMergeQueueEnabled": false,
+			"autoMergeRequest":    autoMergeRequest,
+		}}}),
+	)
+}
+
+func Test_EnablePullRequestAutoMerge(t *testing.T) {
+	serverTool := EnablePullRequestAutoMerge(translations.NullTranslationHelper)
+	tool := serverTool.Tool
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "enable_pull_request_auto_merge", tool.Name)
+	assert.False(t, tool.Annotations.ReadOnlyHint)
+	assert.True(t, tool.Annotations.IdempotentHint)
+	schema := tool.InputSchema.(*jsonschema.Schema)
+	assert.ElementsMatch(t, schema.Required, []string{"owner", "repo", "pullNumber"})
+
+	enableMutation := struct {
+		EnablePullRequestAutoMerge struct {
+			PullRequest struct {
+				AutoMergeRequest *autoMergeRequestNode
+			}
+		} `graphql:"enablePullRequestAutoMerge(input: $input)"`
+	}{}
+	squash := githubv4.PullRequestMergeMethodSquash
+
+	tests := []struct {
+		name           string
+		args           map[string]any
+		matchers       []githubv4mock.Matcher
+		expected       AutoMerge
+		expectedErrMsg string
+	}{
+		{
+			name: "squash with commit message",
+			args: map[string]any{"merge_method": "squash", "commit_title": "Fix flaky test (#7)", "commit_message": "Retries the upload"},
+			matchers: []githubv4mock.Matcher{
+				mockAutoMergePullRequest(nil),
+				githubv4mock.NewMutationMatcher(enableMutation,
+					githubv4.EnablePullRequestAutoMergeInput{
+						PullRequestID:  "PR_7",
+						MergeMethod:    &squash,
+						CommitHeadline: githubv4.NewString("Fix flaky test (#7)"),
+						CommitBody:     githubv4.NewString("Retries the upload"),
+					}, nil,
+					githubv4mock.DataResponse(map[string]any{"enablePullRequestAutoMerge": map[string]any{"pullRequest": map[string]any{
+						"autoMergeRequest": map[string]any{"mergeMethod": "SQUASH", "enabledAt": "2026-10-17T09:00:00Z", "enabledBy": map[string]any{"login": "octocat"}},
+					}}})),
+			},
+			expected: AutoMerge{
+				PullNumber:  7,
+				URL:         "https://github.com/octo/ink/pull/7",
+				MergeMethod: "squash",
+				EnabledAt:   "2026-10-17T09:00:00Z",
+				EnabledBy:   "octocat",
+			},
+		},
+		{
+			name: "pull request that can already be merged",
+			args: map[string]any{},
+			matchers: []githubv4mock.Matcher{
+				mockAutoMergePullRequest(nil),
+				githubv4mock.NewMutationMatcher(enableMutation,
+					githubv4.EnablePullRequestAutoMergeInput{PullRequestID: "PR_7"}, nil,
+					githubv4mock.ErrorResponse("Pull request Pull request is in clean status")),
+			},
+			expectedErrMsg: "merge it with merge_pull_request instead",
+		},
+		{
+			name:           "invalid merge method",
+			args:           map[string]any{"merge_method": "fast-forward"},
+			expectedErrMsg: "invalid merge_method: fast-forward",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			deps := BaseDeps{GQLClient: githubv4.NewClient(githubv4mock.NewMockedHTTPClient(tc.matchers...))}
+			args := map[string]any{"owner": "octo", "repo": "ink", "pullNumber": float64(7)}
+			for k, v := range tc.args {
+				args[k] = v
+			}
+			request := createMCPRequest(args)
+			result, err := serverTool.Handler(deps)(ContextWithDeps(context.Background(), deps), &request)
+			require.NoError(t, err)
+			if tc.expectedErrMsg != "" {
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+			var autoMerge AutoMerge
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &autoMerge))
+			assert.Equal(t, tc.expected, autoMerge)
+		})
+	}
+}
+
+func Test_DisablePullRequestAutoMerge(t *testing.T) {
+	serverTool := DisablePullRequestAutoMerge(translations.NullTranslationHelper)
+	tool := serverTool.Tool
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "disable_pull_request_auto_merge", tool.Name)
+	assert.True(t, tool.Annotations.IdempotentHint)
+	schema := tool.InputSchema.(*jsonschema.Schema)
+	assert.ElementsMatch(t, schema.Required, []string{"owner", "repo", "pullNumber"})
+
+	tests := []struct {
+		name         string
+		matchers     []githubv4mock.Matcher
+		expectedText string
+	}{
+		{
+			name: "disable",
+			matchers: []githubv4mock.Matcher{
+				mockAutoMergePullRequest(map[string]any{"mergeMethod": "MERGE", "enabledAt": "2026-10-17T09:00:00Z", "enabledBy": map[string]any{"login": "octocat"}}),
+				githubv4mock.NewMutationMatcher(
+					struct {
+						DisablePullRequestAutoMerge struct {
+							PullRequest struct {
+								Number githubv4.Int
+							}
+						} `graphql:"disablePullRequestAutoMerge(input: $input)"`
+					}{},
+					githubv4.DisablePullRequestAutoMergeInput{PullRequestID: "PR_7"}, nil,
+					githubv4mock.DataResponse(map[string]any{"disablePullRequestAutoMerge": map[string]any{"pullRequest": map[string]any{"number": 7}}})),
+			},
+			expectedText: "auto-merge is disabled for octo/ink#7",
+		},
+		{
+			name:         "not enabled",
+			matchers:     []githubv4mock.Matcher{mockAutoMergePullRequest(nil)},
+			expectedText: "auto-merge is not enabled for octo/ink#7",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			deps := BaseDeps{GQLClient: githubv4.NewClient(githubv4mock.NewMockedHTTPClient(tc.matchers...))}
+			request := createMCPRequest(map[string]any{"owner": "octo", "repo": "ink", "pullNumber": float64(7)})
+			result, err := serverTool.Handler(deps)(ContextWithDeps(context.Background(), deps), &request)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedText, getTextResult(t, result).Text)
+		})
+	}
+}
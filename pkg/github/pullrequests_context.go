@@ -0,0 +1,249 @@
+package github
+
+import (
+	"context"
+	"fmt"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/inventory"
+	"github.com/github/github-mcp-server/pkg/sanitize"
+	"github.com/github/github-mcp-server/pkg/scopes"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/github/github-mcp-server/pkg/utils"
+	"github.com/google/go-github/v82/github"
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// pullRequestContextPageSize is the number of files, commits and check runs fetched for
+// the pull request context. Larger pull requests are reported as truncated.
+const pullRequestContextPageSize = 100
+
+// PullRequestContext is a compact bundle of the data typically needed to describe or
+// review a pull request.
+type PullRequestContext struct {
+	PullRequest      MinimalPullRequest         `json:"pull_request"`
+	Files            []MinimalPRFile            `json:"files"`
+	FilesTruncated   bool                       `json:"files_truncated,omitempty"`
+	Commits          []PullRequestContextCommit `json:"commits"`
+	CommitsTruncated bool                       `json:"commits_truncated,omitempty"`
+	LinkedIssues     []LinkedIssue              `json:"linked_issues"`
+	CI               PullRequestContextCIStatus `json:"ci"`
+}
+
+// PullRequestContextCommit is a commit on the pull request, reduced to its message.
+type PullRequestContextCommit struct {
+	SHA     string `json:"sha"`
+	Message string `json:"message"`
+	Author  string `json:"author,omitempty"`
+}
+
+// PullRequestContextCIStatus summarizes commit statuses and check runs on the head commit.
+type PullRequestContextCIStatus struct {
+	State         string         `json:"state"`
+	TotalChecks   int            `json:"total_checks"`
+	Conclusions   map[string]int `json:"conclusions,omitempty"`
+	FailingChecks []string       `json:"failing_checks,omitempty"`
+	PendingChecks []string       `json:"pending_checks,omitempty"`
+}
+
+// GetPullRequestContext creates a tool that bundles the data needed to write a description for,
+// or review, a pull request into a single response.
+func GetPullRequestContext(t translations.TranslationHelperFunc) inventory.ServerTool {
+	return NewTool(
+		ToolsetMetadataPullRequests,
+		mcp.Tool{
+			Name:        "get_pull_request_context",
+			Description: t("TOOL_GET_PULL_REQUEST_CONTEXT_DESCRIPTION", "Get a compact bundle of pull request context in one call: pull request details, a per-file diff summary (without patches), commit messages, linked issues the pull request closes, and a CI status summary for the head commit. Intended as input for writing pull request descriptions or reviews; use 'pull_request_read' for full diffs or paginated details."),
+			Annotations: &mcp.ToolAnnotations{
+				Title:        t("TOOL_GET_PULL_REQUEST_CONTEXT_USER_TITLE", "Get pull request context"),
+				ReadOnlyHint: true,
+			},
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"owner": {
+						Type:        "string",
+						Description: "Repository owner",
+					},
+					"repo": {
+						Type:        "string",
+						Description: "Repository name",
+					},
+					"pullNumber": {
+						Type:        "number",
+						Description: "Pull request number",
+					},
+				},
+				Required: []string{"owner", "repo", "pullNumber"},
+			},
+		},
+		[]scopes.Scope{scopes.Repo},
+		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+			owner, err := RequiredParam[string](args, "owner")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			repo, err := RequiredParam[string](args, "repo")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			pullNumber, err := RequiredInt(args, "pullNumber")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+
+			client, err := deps.GetClient(ctx)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			gqlClient, err := deps.GetGQLClient(ctx)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to get GitHub GQL client: %w", err)
+			}
+
+			pr, resp, err := client.PullRequests.Get(ctx, owner, repo, pullNumber)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to get pull request", resp, err), nil, nil
+			}
+			_ = resp.Body.Close()
+
+			lockdownMode := deps.GetFlags(ctx).LockdownMode
+			provenance := newContentProvenance(lockdownMode)
+			if lockdownMode {
+				cache, err := deps.GetRepoAccessCache(ctx)
+				if err != nil {
+					return nil, nil, fmt.Errorf("failed to get repo access cache: %w", err)
+				}
+				if cache == nil {
+					return nil, nil, fmt.Errorf("lockdown cache is not configured")
+				}
+				if login := pr.GetUser().GetLogin(); login != "" {
+					isSafeContent, err := cache.IsSafeContent(ctx, login, owner, repo)
+					if err != nil {
+						return nil, nil, fmt.Errorf("failed to check content removal: %w", err)
+					}
+					if !isSafeContent {
+						provenance.LockdownFiltered = 1
+						return withProvenance(utils.NewToolResultError("access to pull request is restricted by lockdown mode"), provenance), nil, nil
+					}
+				}
+			}
+			provenance.addAuthorAssociation(pr.GetAuthorAssociation())
+
+			if pr.Title != nil {
+				pr.Title = github.Ptr(sanitize.Sanitize(*pr.Title))
+			}
+			if pr.Body != nil {
+				pr.Body = github.Ptr(sanitize.Sanitize(normalizeBody(ctx, deps, *pr.Body, pr.GetHTMLURL())))
+			}
+
+			result := PullRequestContext{
+				PullRequest: convertToMinimalPullRequest(pr),
+			}
+
+			listOpts := &github.ListOptions{PerPage: pullRequestContextPageSize}
+
+			files, resp, err := client.PullRequests.ListFiles(ctx, owner, repo, pullNumber, listOpts)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to get pull request files", resp, err), nil, nil
+			}
+			_ = resp.Body.Close()
+			result.Files = convertToMinimalPRFiles(files)
+			for i := range result.Files {
+				result.Files[i].Patch = ""
+			}
+			result.FilesTruncated = pr.GetChangedFiles() > len(files)
+
+			commits, resp, err := client.PullRequests.ListCommits(ctx, owner, repo, pullNumber, listOpts)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to get pull request commits", resp, err), nil, nil
+			}
+			_ = resp.Body.Close()
+			result.Commits = make([]PullRequestContextCommit, 0, len(commits))
+			for _, c := range commits {
+				author := c.GetAuthor().GetLogin()
+				if author == "" {
+					author = c.GetCommit().GetAuthor().GetName()
+				}
+				result.Commits = append(result.Commits, PullRequestContextCommit{
+					SHA:     c.GetSHA(),
+					Message: c.GetCommit().GetMessage(),
+					Author:  author,
+				})
+			}
+			result.CommitsTruncated = pr.GetCommits() > len(commits)
+
+			result.LinkedIssues, err = fetchClosingIssues(ctx, gqlClient, owner, repo, pullNumber)
+			if err != nil {
+				return ghErrors.NewGitHubGraphQLErrorResponse(ctx, "failed to get linked issues", err), nil, nil
+			}
+
+			headSHA := pr.GetHead().GetSHA()
+			status, resp, err := client.Repositories.GetCombinedStatus(ctx, owner, repo, headSHA, nil)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to get combined status", resp, err), nil, nil
+			}
+			_ = resp.Body.Close()
+
+			checkRuns, resp, err := client.Checks.ListCheckRunsForRef(ctx, owner, repo, headSHA, &github.ListCheckRunsOptions{ListOptions: *listOpts})
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to get check runs", resp, err), nil, nil
+			}
+			_ = resp.Body.Close()
+
+			result.CI = summarizePullRequestCI(status, checkRuns)
+
+			provenance.Truncated = result.FilesTruncated || result.CommitsTruncated
+			return withProvenance(MarshalledTextResult(result), provenance), nil, nil
+		},
+	)
+}
+
+// summarizePullRequestCI folds commit statuses and check runs into a single summary. The overall
+// state is "failure" if anything failed, "pending" if anything is still running, and "success" otherwise.
+func summarizePullRequestCI(status *github.CombinedStatus, checkRuns *github.ListCheckRunsResults) PullRequestContextCIStatus {
+	summary := PullRequestContextCIStatus{
+		Conclusions: map[string]int{},
+	}
+
+	for _, s := range status.Statuses {
+		summary.TotalChecks++
+		state := s.GetState()
+		summary.Conclusions[state]++
+		switch state {
+		case "failure", "error":
+			summary.FailingChecks = append(summary.FailingChecks, s.GetContext())
+		case "pending":
+			summary.PendingChecks = append(summary.PendingChecks, s.GetContext())
+		}
+	}
+
+	for _, run := range checkRuns.CheckRuns {
+		summary.TotalChecks++
+		if run.GetStatus() != "completed" {
+			summary.Conclusions[run.GetStatus()]++
+			summary.PendingChecks = append(summary.PendingChecks, run.GetName())
+			continue
+		}
+		conclusion := run.GetConclusion()
+		summary.Conclusions[conclusion]++
+		switch conclusion {
+		case "failure", "timed_out", "cancelled", "action_required", "startup_failure":
+			summary.FailingChecks = append(summary.FailingChecks, run.GetName())
+		}
+	}
+
+	switch {
+	case summary.TotalChecks == 0:
+		summary.State = "none"
+	case len(summary.FailingChecks) > 0:
+		summary.State = "failure"
+	case len(summary.PendingChecks) > 0:
+		summary.State = "pending"
+	default:
+		summary.State = "success"
+	}
+
+	return summary
+}
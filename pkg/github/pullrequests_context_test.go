@@ -0,0 +1,195 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/githubv4mock"
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v82/github"
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/shurcooL/githubv4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_GetPullRequestContext(t *testing.T) {
+	// Verify tool definition once
+	serverTool := GetPullRequestContext(translations.NullTranslationHelper)
+	tool := serverTool.Tool
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_pull_request_context", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.True(t, tool.Annotations.ReadOnlyHint)
+	schema := tool.InputSchema.(*jsonschema.Schema)
+	assert.Contains(t, schema.Properties, "owner")
+	assert.Contains(t, schema.Properties, "repo")
+	assert.Contains(t, schema.Properties, "pullNumber")
+	assert.ElementsMatch(t, schema.Required, []string{"owner", "repo", "pullNumber"})
+
+	mockPR := &github.PullRequest{
+		Number:       github.Ptr(42),
+		Title:        github.Ptr("Add caching layer"),
+		Body:         github.Ptr("Fixes #7"),
+		State:        github.Ptr("open"),
+		HTMLURL:      github.Ptr("https://github.com/owner/repo/pull/42"),
+		User:         &github.User{Login: github.Ptr("octocat")},
+		Head:         &github.PullRequestBranch{SHA: github.Ptr("abc123"), Ref: github.Ptr("feature")},
+		Base:         &github.PullRequestBranch{SHA: github.Ptr("def456"), Ref: github.Ptr("main")},
+		ChangedFiles: github.Ptr(3),
+		Commits:      github.Ptr(1),
+	}
+	mockFiles := []*github.CommitFile{
+		{Filename: github.Ptr("cache.go"), Status: github.Ptr("added"), Additions: github.Ptr(40), Patch: github.Ptr("@@ -0,0 +1,40 @@")},
+		{Filename: github.Ptr("server.go"), Status: github.Ptr("modified"), Additions: github.Ptr(2), Deletions: github.Ptr(1)},
+	}
+	mockCommits := []*github.RepositoryCommit{
+		{
+			SHA:    github.Ptr("abc123"),
+			Commit: &github.Commit{Message: github.Ptr("Add caching layer\n\nUses an LRU.")},
+			Author: &github.User{Login: github.Ptr("octocat")},
+		},
+	}
+	mockStatus := &github.CombinedStatus{
+		State: github.Ptr("success"),
+		Statuses: []*github.RepoStatus{
+			{Context: github.Ptr("ci/lint"), State: github.Ptr("success")},
+		},
+	}
+	mockCheckRuns := &github.ListCheckRunsResults{
+		Total: github.Ptr(2),
+		CheckRuns: []*github.CheckRun{
+			{Name: github.Ptr("build"), Status: github.Ptr("completed"), Conclusion: github.Ptr("success")},
+			{Name: github.Ptr("test"), Status: github.Ptr("completed"), Conclusion: github.Ptr("failure")},
+		},
+	}
+
+	linkedIssuesMatcher := githubv4mock.NewQueryMatcher(
+		closingIssuesQuery{},
+		map[string]any{
+			"owner": githubv4.String("owner"),
+			"repo":  githubv4.String("repo"),
+			"prNum": githubv4.Int(42),
+		},
+		githubv4mock.DataResponse(map[string]any{
+			"repository": map[string]any{
+				"pullRequest": map[string]any{
+					"closingIssuesReferences": map[string]any{
+						"nodes": []map[string]any{
+							{
+								"number":     7,
+								"title":      "Slow responses",
+								"state":      "OPEN",
+								"url":        "https://github.com/owner/repo/issues/7",
+								"repository": map[string]any{"nameWithOwner": "owner/repo"},
+							},
+						},
+					},
+				},
+			},
+		}),
+	)
+
+	tests := []struct {
+		name           string
+		restHandlers   map[string]http.HandlerFunc
+		requestArgs    map[string]any
+		expectToolErr  bool
+		expectedErrMsg string
+	}{
+		{
+			name: "bundles pull request context",
+			restHandlers: map[string]http.HandlerFunc{
+				GetReposPullsByOwnerByRepoByPullNumber:        mockResponse(t, http.StatusOK, mockPR),
+				GetReposPullsFilesByOwnerByRepoByPullNumber:   mockResponse(t, http.StatusOK, mockFiles),
+				GetReposPullsCommitsByOwnerByRepoByPullNumber: mockResponse(t, http.StatusOK, mockCommits),
+				GetReposCommitsStatusByOwnerByRepoByRef:       mockResponse(t, http.StatusOK, mockStatus),
+				GetReposCommitsCheckRunsByOwnerByRepoByRef:    mockResponse(t, http.StatusOK, mockCheckRuns),
+			},
+			requestArgs: map[string]any{
+				"owner":      "owner",
+				"repo":       "repo",
+				"pullNumber": float64(42),
+			},
+		},
+		{
+			name: "pull request not found",
+			restHandlers: map[string]http.HandlerFunc{
+				GetReposPullsByOwnerByRepoByPullNumber: mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+			},
+			requestArgs: map[string]any{
+				"owner":      "owner",
+				"repo":       "repo",
+				"pullNumber": float64(999),
+			},
+			expectToolErr:  true,
+			expectedErrMsg: "failed to get pull request",
+		},
+		{
+			name:         "missing pullNumber",
+			restHandlers: map[string]http.HandlerFunc{},
+			requestArgs: map[string]any{
+				"owner": "owner",
+				"repo":  "repo",
+			},
+			expectToolErr:  true,
+			expectedErrMsg: "missing required parameter: pullNumber",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			deps := BaseDeps{
+				Client:    github.NewClient(MockHTTPClientWithHandlers(tc.restHandlers)),
+				GQLClient: githubv4.NewClient(githubv4mock.NewMockedHTTPClient(linkedIssuesMatcher)),
+			}
+			handler := serverTool.Handler(deps)
+			request := createMCPRequest(tc.requestArgs)
+
+			result, err := handler(ContextWithDeps(context.Background(), deps), &request)
+			require.NoError(t, err)
+
+			if tc.expectToolErr {
+				require.True(t, result.IsError)
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			textContent := getTextResult(t, result)
+			var prContext PullRequestContext
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &prContext))
+
+			assert.Equal(t, 42, prContext.PullRequest.Number)
+			require.Len(t, prContext.Files, 2)
+			assert.Empty(t, prContext.Files[0].Patch)
+			assert.True(t, prContext.FilesTruncated)
+			require.Len(t, prContext.Commits, 1)
+			assert.Equal(t, "Add caching layer\n\nUses an LRU.", prContext.Commits[0].Message)
+			assert.False(t, prContext.CommitsTruncated)
+			require.Len(t, prContext.LinkedIssues, 1)
+			assert.Equal(t, 7, prContext.LinkedIssues[0].Number)
+			assert.Equal(t, "open", prContext.LinkedIssues[0].State)
+			assert.Equal(t, "failure", prContext.CI.State)
+			assert.Equal(t, 3, prContext.CI.TotalChecks)
+			assert.Equal(t, []string{"test"}, prContext.CI.FailingChecks)
+		})
+	}
+}
+
+func Test_SummarizePullRequestCI(t *testing.T) {
+	none := summarizePullRequestCI(&github.CombinedStatus{}, &github.ListCheckRunsResults{})
+	assert.Equal(t, "none", none.State)
+
+	pending := summarizePullRequestCI(
+		&github.CombinedStatus{Statuses: []*github.RepoStatus{{Context: github.Ptr("deploy"), State: github.Ptr("pending")}}},
+		&github.ListCheckRunsResults{CheckRuns: []*github.CheckRun{{Name: github.Ptr("build"), Status: github.Ptr("completed"), Conclusion: github.Ptr("success")}}},
+	)
+	assert.Equal(t, "pending", pending.State)
+	assert.Equal(t, []string{"deploy"}, pending.PendingChecks)
+}
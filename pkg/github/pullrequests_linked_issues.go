@@ -0,0 +1,447 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/inventory"
+	"github.com/github/github-mcp-server/pkg/sanitize"
+	"github.com/github/github-mcp-server/pkg/scopes"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/github/github-mcp-server/pkg/utils"
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/shurcooL/githubv4"
+)
+
+// closingKeywordPattern matches the keywords GitHub recognises for linking a pull request to
+// the issues it closes.
+const closingKeywordPattern = `(?:close[sd]?|fix(?:e[sd])?|resolve[sd]?)`
+
+// closingKeywords are the accepted values of the linked_issue_write keyword parameter.
+var closingKeywords = []any{"Closes", "Fixes", "Resolves"}
+
+// LinkedIssue is an issue that a pull request will close when merged.
+type LinkedIssue struct {
+	Repository string `json:"repository"`
+	Number     int    `json:"number"`
+	Title      string `json:"title"`
+	State      string `json:"state"`
+	URL        string `json:"url"`
+}
+
+// LinkedPullRequest is a pull request that references an issue.
+type LinkedPullRequest struct {
+	Repository string `json:"repository"`
+	Number     int    `json:"number"`
+	Title      string `json:"title"`
+	State      string `json:"state"`
+	URL        string `json:"url"`
+	// Closes is true when the pull request will close the issue when merged.
+	Closes bool `json:"closes"`
+}
+
+type linkedItemNode struct {
+	Number     githubv4.Int
+	Title      githubv4.String
+	State      githubv4.String
+	URL        githubv4.String `graphql:"url"`
+	Repository struct {
+		NameWithOwner githubv4.String
+	}
+}
+
+type closingIssuesQuery struct {
+	Repository struct {
+		PullRequest struct {
+			ClosingIssuesReferences struct {
+				Nodes []linkedItemNode
+			} `graphql:"closingIssuesReferences(first: 25)"`
+		} `graphql:"pullRequest(number: $prNum)"`
+	} `graphql:"repository(owner: $owner, name: $repo)"`
+}
+
+type linkedPullRequestsQuery struct {
+	Repository struct {
+		Issue struct {
+			ClosedByPullRequestsReferences struct {
+				Nodes []linkedItemNode
+			} `graphql:"closedByPullRequestsReferences(first: 25, includeClosedPrs: true)"`
+			TimelineItems struct {
+				Nodes []struct {
+					CrossReferencedEvent struct {
+						WillCloseTarget githubv4.Boolean
+						Source          struct {
+							PullRequest linkedItemNode `graphql:"... on PullRequest"`
+						}
+					} `graphql:"... on CrossReferencedEvent"`
+				}
+			} `graphql:"timelineItems(first: 100, itemTypes: [CROSS_REFERENCED_EVENT])"`
+		} `graphql:"issue(number: $issueNumber)"`
+	} `graphql:"repository(owner: $owner, name: $repo)"`
+}
+
+// fetchClosingIssues returns the issues that a pull request will close when merged.
+func fetchClosingIssues(ctx context.Context, client *githubv4.Client, owner, repo string, pullNumber int) ([]LinkedIssue, error) {
+	var query closingIssuesQuery
+	vars := map[string]any{
+		"owner": githubv4.String(owner),
+		"repo":  githubv4.String(repo),
+		"prNum": githubv4.Int(int32(pullNumber)), // #nosec G115 - pull request numbers are always small positive integers
+	}
+	if err := client.Query(ctx, &query, vars); err != nil {
+		return nil, err
+	}
+
+	issues := make([]LinkedIssue, 0, len(query.Repository.PullRequest.ClosingIssuesReferences.Nodes))
+	for _, node := range query.Repository.PullRequest.ClosingIssuesReferences.Nodes {
+		issues = append(issues, LinkedIssue{
+			Repository: string(node.Repository.NameWithOwner),
+			Number:     int(node.Number),
+			Title:      sanitize.Sanitize(string(node.Title)),
+			State:      strings.ToLower(string(node.State)),
+			URL:        string(node.URL),
+		})
+	}
+	return issues, nil
+}
+
+// GetPullRequestLinkedIssues returns the issues a pull request closes.
+func GetPullRequestLinkedIssues(ctx context.Context, client *githubv4.Client, owner, repo string, pullNumber int) (*mcp.CallToolResult, error) {
+	issues, err := fetchClosingIssues(ctx, client, owner, repo, pullNumber)
+	if err != nil {
+		return ghErrors.NewGitHubGraphQLErrorResponse(ctx, "failed to get linked issues", err), nil
+	}
+
+	return MarshalledTextResult(map[string]any{
+		"linked_issues": issues,
+		"totalCount":    len(issues),
+	}), nil
+}
+
+// GetIssueLinkedPullRequests returns the pull requests that close or mention an issue.
+func GetIssueLinkedPullRequests(ctx context.Context, client *githubv4.Client, owner, repo string, issueNumber int) (*mcp.CallToolResult, error) {
+	var query linkedPullRequestsQuery
+	vars := map[string]any{
+		"owner":       githubv4.String(owner),
+		"repo":        githubv4.String(repo),
+		"issueNumber": githubv4.Int(issueNumber), // #nosec G115 - issue numbers are always small positive integers
+	}
+	if err := client.Query(ctx, &query, vars); err != nil {
+		return ghErrors.NewGitHubGraphQLErrorResponse(ctx, "failed to get linked pull requests", err), nil
+	}
+
+	pullRequests := []LinkedPullRequest{}
+	index := map[string]int{}
+	add := func(node linkedItemNode, closes bool) {
+		if node.Number == 0 {
+			return
+		}
+		key := string(node.URL)
+		if i, ok := index[key]; ok {
+			pullRequests[i].Closes = pullRequests[i].Closes || closes
+			return
+		}
+		index[key] = len(pullRequests)
+		pullRequests = append(pullRequests, LinkedPullRequest{
+			Repository: string(node.Repository.NameWithOwner),
+			Number:     int(node.Number),
+			Title:      sanitize.Sanitize(string(node.Title)),
+			State:      strings.ToLower(string(node.State)),
+			URL:        string(node.URL),
+			Closes:     closes,
+		})
+	}
+
+	for _, node := range query.Repository.Issue.ClosedByPullRequestsReferences.Nodes {
+		add(node, true)
+	}
+	for _, item := range query.Repository.Issue.TimelineItems.Nodes {
+		add(item.CrossReferencedEvent.Source.PullRequest, bool(item.CrossReferencedEvent.WillCloseTarget))
+	}
+
+	return MarshalledTextResult(map[string]any{
+		"pull_requests": pullRequests,
+		"totalCount":    len(pullRequests),
+	}), nil
+}
+
+// issueReference identifies an issue, possibly in another repository.
+type issueReference struct {
+	Owner  string
+	Repo   string
+	Number int
+}
+
+// parseIssueReference parses "123", "#123", "owner/repo#123" or an issue URL. References without
+// a repository default to defaultOwner/defaultRepo.
+func parseIssueReference(ref, defaultOwner, defaultRepo string) (issueReference, error) {
+	ref = strings.TrimSpace(ref)
+	if u, err := url.Parse(ref); err == nil && u.Scheme != "" {
+		parts := strings.Split(strings.Trim(u.Path, "/"), "/")
+		if len(parts) == 4 && parts[2] == "issues" {
+			if n, err := strconv.Atoi(parts[3]); err == nil && n > 0 {
+				return issueReference{Owner: parts[0], Repo: parts[1], Number: n}, nil
+			}
+		}
+		return issueReference{}, fmt.Errorf("invalid issue URL: %s", ref)
+	}
+
+	owner, repo := defaultOwner, defaultRepo
+	numberPart := strings.TrimPrefix(ref, "#")
+	if i := strings.Index(ref, "#"); i > 0 {
+		repoParts := strings.Split(ref[:i], "/")
+		if len(repoParts) != 2 || repoParts[0] == "" || repoParts[1] == "" {
+			return issueReference{}, fmt.Errorf("invalid issue reference: %s", ref)
+		}
+		owner, repo = repoParts[0], repoParts[1]
+		numberPart = ref[i+1:]
+	}
+
+	n, err := strconv.Atoi(numberPart)
+	if err != nil || n <= 0 {
+		return issueReference{}, fmt.Errorf("invalid issue reference: %s", ref)
+	}
+	return issueReference{Owner: owner, Repo: repo, Number: n}, nil
+}
+
+// shortForm returns the reference as it should be written in a pull request body in owner/repo.
+func (r issueReference) shortForm(owner, repo string) string {
+	if strings.EqualFold(r.Owner, owner) && strings.EqualFold(r.Repo, repo) {
+		return fmt.Sprintf("#%d", r.Number)
+	}
+	return fmt.Sprintf("%s/%s#%d", r.Owner, r.Repo, r.Number)
+}
+
+// closingRegexp matches a closing keyword followed by any of the ways the issue can be
+// referenced from a pull request in owner/repo. The reference is captured in group 1.
+func (r issueReference) closingRegexp(owner, repo string) *regexp.Regexp {
+	alternatives := []string{
+		regexp.QuoteMeta(fmt.Sprintf("%s/%s#%d", r.Owner, r.Repo, r.Number)),
+		`https?://[^/\s]+/` + regexp.QuoteMeta(fmt.Sprintf("%s/%s/issues/%d", r.Owner, r.Repo, r.Number)),
+	}
+	if strings.EqualFold(r.Owner, owner) && strings.EqualFold(r.Repo, repo) {
+		alternatives = append(alternatives, `#`+strconv.Itoa(r.Number))
+	}
+	return regexp.MustCompile(`(?im)\b` + closingKeywordPattern + `:?\s+(` + strings.Join(alternatives, "|") + `)\b`)
+}
+
+// linkIssuesInBody appends a closing keyword line to body for each reference that is not already
+// linked. It returns the new body and the references that were added.
+func linkIssuesInBody(body string, refs []issueReference, keyword, owner, repo string) (string, []string) {
+	var lines, added []string
+	for _, ref := range refs {
+		if ref.closingRegexp(owner, repo).MatchString(body) {
+			continue
+		}
+		short := ref.shortForm(owner, repo)
+		lines = append(lines, fmt.Sprintf("%s %s", keyword, short))
+		added = append(added, short)
+	}
+	if len(lines) == 0 {
+		return body, nil
+	}
+
+	body = strings.TrimRight(body, " \t\r\n")
+	if body != "" {
+		body += "\n\n"
+	}
+	return body + strings.Join(lines, "\n"), added
+}
+
+// unlinkIssuesInBody removes closing keywords for the given references from body, leaving plain
+// mentions in place. Lines that only contained the closing reference are dropped. It returns the
+// new body and the references that were removed.
+func unlinkIssuesInBody(body string, refs []issueReference, owner, repo string) (string, []string) {
+	var removed []string
+	for _, ref := range refs {
+		re := ref.closingRegexp(owner, repo)
+		if !re.MatchString(body) {
+			continue
+		}
+		removed = append(removed, ref.shortForm(owner, repo))
+
+		lines := strings.Split(body, "\n")
+		kept := lines[:0]
+		for _, line := range lines {
+			match := re.FindStringSubmatch(line)
+			if match == nil {
+				kept = append(kept, line)
+				continue
+			}
+			replaced := re.ReplaceAllString(line, "$1")
+			if strings.TrimSpace(replaced) == strings.TrimSpace(match[1]) {
+				continue
+			}
+			kept = append(kept, replaced)
+		}
+		body = strings.Join(kept, "\n")
+	}
+	return strings.TrimRight(body, " \t\r\n"), removed
+}
+
+// LinkedIssueWrite creates a tool to link issues to, or unlink them from, a pull request.
+func LinkedIssueWrite(t translations.TranslationHelperFunc) inventory.ServerTool {
+	return NewTool(
+		ToolsetMetadataPullRequests,
+		mcp.Tool{
+			Name:        "linked_issue_write",
+			Description: t("TOOL_LINKED_ISSUE_WRITE_DESCRIPTION", "Link issues to or unlink issues from a pull request by managing closing keywords (e.g. 'Closes #123') in the pull request body. Linked issues are closed automatically when the pull request is merged into the default branch. Unlinking keeps plain mentions of the issue in place."),
+			Annotations: &mcp.ToolAnnotations{
+				Title:        t("TOOL_LINKED_ISSUE_WRITE_USER_TITLE", "Link or unlink issues on a pull request"),
+				ReadOnlyHint: false,
+			},
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"method": {
+						Type: "string",
+						Description: `The write operation to perform.
+Options are:
+- 'link' - add a closing keyword for each issue not already linked.
+- 'unlink' - remove closing keywords for each issue.
+`,
+						Enum: []any{"link", "unlink"},
+					},
+					"owner": {
+						Type:        "string",
+						Description: "Repository owner",
+					},
+					"repo": {
+						Type:        "string",
+						Description: "Repository name",
+					},
+					"pullNumber": {
+						Type:        "number",
+						Description: "Pull request number",
+					},
+					"issues": {
+						Type:        "array",
+						Description: "Issues to link or unlink. Each entry is an issue number, '#123', 'owner/repo#123', or an issue URL.",
+						Items: &jsonschema.Schema{
+							Type: "string",
+						},
+					},
+					"keyword": {
+						Type:        "string",
+						Description: "Closing keyword to use when linking",
+						Enum:        closingKeywords,
+						Default:     json.RawMessage(`"Closes"`),
+					},
+				},
+				Required: []string{"method", "owner", "repo", "pullNumber", "issues"},
+			},
+		},
+		[]scopes.Scope{scopes.Repo},
+		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+			method, err := RequiredParam[string](args, "method")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			owner, err := RequiredParam[string](args, "owner")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			repo, err := RequiredParam[string](args, "repo")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			pullNumber, err := RequiredInt(args, "pullNumber")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			rawIssues, err := OptionalStringArrayParam(args, "issues")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			if len(rawIssues) == 0 {
+				return utils.NewToolResultError("missing required parameter: issues"), nil, nil
+			}
+			keyword, err := OptionalParam[string](args, "keyword")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			if keyword == "" {
+				keyword = "Closes"
+			}
+
+			refs := make([]issueReference, 0, len(rawIssues))
+			for _, raw := range rawIssues {
+				ref, err := parseIssueReference(raw, owner, repo)
+				if err != nil {
+					return utils.NewToolResultError(err.Error()), nil, nil
+				}
+				refs = append(refs, ref)
+			}
+
+			client, err := deps.GetGQLClient(ctx)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to get GitHub GQL client: %w", err)
+			}
+
+			var query struct {
+				Repository struct {
+					PullRequest struct {
+						ID   githubv4.ID
+						Body githubv4.String
+					} `graphql:"pullRequest(number: $prNum)"`
+				} `graphql:"repository(owner: $owner, name: $repo)"`
+			}
+			vars := map[string]any{
+				"owner": githubv4.String(owner),
+				"repo":  githubv4.String(repo),
+				"prNum": githubv4.Int(int32(pullNumber)), // #nosec G115 - pull request numbers are always small positive integers
+			}
+			if err := client.Query(ctx, &query, vars); err != nil {
+				return ghErrors.NewGitHubGraphQLErrorResponse(ctx, "failed to get pull request", err), nil, nil
+			}
+
+			body := string(query.Repository.PullRequest.Body)
+			var newBody string
+			var changed []string
+			switch method {
+			case "link":
+				newBody, changed = linkIssuesInBody(body, refs, keyword, owner, repo)
+			case "unlink":
+				newBody, changed = unlinkIssuesInBody(body, refs, owner, repo)
+			default:
+				return utils.NewToolResultError(fmt.Sprintf("unknown method: %s", method)), nil, nil
+			}
+
+			if len(changed) > 0 {
+				var mutation struct {
+					UpdatePullRequest struct {
+						PullRequest struct {
+							ID githubv4.ID
+						}
+					} `graphql:"updatePullRequest(input: $input)"`
+				}
+				input := githubv4.UpdatePullRequestInput{
+					PullRequestID: query.Repository.PullRequest.ID,
+					Body:          githubv4.NewString(githubv4.String(newBody)),
+				}
+				if err := client.Mutate(ctx, &mutation, input, nil); err != nil {
+					return ghErrors.NewGitHubGraphQLErrorResponse(ctx, "failed to update pull request", err), nil, nil
+				}
+			}
+
+			if changed == nil {
+				changed = []string{}
+			}
+			key := "linked"
+			if method == "unlink" {
+				key = "unlinked"
+			}
+			return MarshalledTextResult(map[string]any{
+				key:       changed,
+				"updated": len(changed) > 0,
+			}), nil, nil
+		},
+	)
+}
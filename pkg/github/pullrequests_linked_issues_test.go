@@ -0,0 +1,342 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/githubv4mock"
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v82/github"
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/shurcooL/githubv4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_PullRequestRead_GetLinkedIssues(t *testing.T) {
+	serverTool := PullRequestRead(translations.NullTranslationHelper)
+
+	mockedClient := githubv4mock.NewMockedHTTPClient(
+		githubv4mock.NewQueryMatcher(
+			closingIssuesQuery{},
+			map[string]any{
+				"owner": githubv4.String("owner"),
+				"repo":  githubv4.String("repo"),
+				"prNum": githubv4.Int(42),
+			},
+			githubv4mock.DataResponse(map[string]any{
+				"repository": map[string]any{
+					"pullRequest": map[string]any{
+						"closingIssuesReferences": map[string]any{
+							"nodes": []map[string]any{
+								{
+									"number":     7,
+									"title":      "Slow responses",
+									"state":      "OPEN",
+									"url":        "https://github.com/owner/repo/issues/7",
+									"repository": map[string]any{"nameWithOwner": "owner/repo"},
+								},
+							},
+						},
+					},
+				},
+			}),
+		),
+	)
+
+	deps := BaseDeps{
+		Client:    github.NewClient(MockHTTPClientWithHandlers(map[string]http.HandlerFunc{})),
+		GQLClient: githubv4.NewClient(mockedClient),
+	}
+	handler := serverTool.Handler(deps)
+	request := createMCPRequest(map[string]any{
+		"method":     "get_linked_issues",
+		"owner":      "owner",
+		"repo":       "repo",
+		"pullNumber": float64(42),
+	})
+
+	result, err := handler(ContextWithDeps(context.Background(), deps), &request)
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	textContent := getTextResult(t, result)
+	var response struct {
+		LinkedIssues []LinkedIssue `json:"linked_issues"`
+		TotalCount   int           `json:"totalCount"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(textContent.Text), &response))
+	assert.Equal(t, 1, response.TotalCount)
+	assert.Equal(t, LinkedIssue{
+		Repository: "owner/repo",
+		Number:     7,
+		Title:      "Slow responses",
+		State:      "open",
+		URL:        "https://github.com/owner/repo/issues/7",
+	}, response.LinkedIssues[0])
+}
+
+func Test_IssueRead_GetLinkedPullRequests(t *testing.T) {
+	serverTool := IssueRead(translations.NullTranslationHelper)
+
+	pr := func(number int, state string) map[string]any {
+		return map[string]any{
+			"number":     number,
+			"title":      "Fix it",
+			"state":      state,
+			"url":        fmt.Sprintf("https://github.com/owner/repo/pull/%d", number),
+			"repository": map[string]any{"nameWithOwner": "owner/repo"},
+		}
+	}
+
+	mockedClient := githubv4mock.NewMockedHTTPClient(
+		githubv4mock.NewQueryMatcher(
+			linkedPullRequestsQuery{},
+			map[string]any{
+				"owner":       githubv4.String("owner"),
+				"repo":        githubv4.String("repo"),
+				"issueNumber": githubv4.Int(7),
+			},
+			githubv4mock.DataResponse(map[string]any{
+				"repository": map[string]any{
+					"issue": map[string]any{
+						"closedByPullRequestsReferences": map[string]any{
+							"nodes": []map[string]any{pr(10, "OPEN")},
+						},
+						"timelineItems": map[string]any{
+							"nodes": []map[string]any{
+								{"willCloseTarget": true, "source": pr(10, "OPEN")},
+								{"willCloseTarget": false, "source": pr(11, "MERGED")},
+								{"willCloseTarget": false, "source": map[string]any{}},
+							},
+						},
+					},
+				},
+			}),
+		),
+	)
+
+	deps := BaseDeps{
+		Client:    github.NewClient(MockHTTPClientWithHandlers(map[string]http.HandlerFunc{})),
+		GQLClient: githubv4.NewClient(mockedClient),
+	}
+	handler := serverTool.Handler(deps)
+	request := createMCPRequest(map[string]any{
+		"method":       "get_linked_pull_requests",
+		"owner":        "owner",
+		"repo":         "repo",
+		"issue_number": float64(7),
+	})
+
+	result, err := handler(ContextWithDeps(context.Background(), deps), &request)
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	textContent := getTextResult(t, result)
+	var response struct {
+		PullRequests []LinkedPullRequest `json:"pull_requests"`
+		TotalCount   int                 `json:"totalCount"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(textContent.Text), &response))
+	require.Equal(t, 2, response.TotalCount)
+	assert.Equal(t, 10, response.PullRequests[0].Number)
+	assert.True(t, response.PullRequests[0].Closes)
+	assert.Equal(t, 11, response.PullRequests[1].Number)
+	assert.Equal(t, "merged", response.PullRequests[1].State)
+	assert.False(t, response.PullRequests[1].Closes)
+}
+
+func Test_LinkedIssueWrite(t *testing.T) {
+	// Verify tool definition once
+	serverTool := LinkedIssueWrite(translations.NullTranslationHelper)
+	tool := serverTool.Tool
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "linked_issue_write", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.False(t, tool.Annotations.ReadOnlyHint)
+	schema := tool.InputSchema.(*jsonschema.Schema)
+	assert.Contains(t, schema.Properties, "method")
+	assert.Contains(t, schema.Properties, "issues")
+	assert.Contains(t, schema.Properties, "keyword")
+	assert.ElementsMatch(t, schema.Required, []string{"method", "owner", "repo", "pullNumber", "issues"})
+
+	prQuery := struct {
+		Repository struct {
+			PullRequest struct {
+				ID   githubv4.ID
+				Body githubv4.String
+			} `graphql:"pullRequest(number: $prNum)"`
+		} `graphql:"repository(owner: $owner, name: $repo)"`
+	}{}
+	prQueryVars := map[string]any{
+		"owner": githubv4.String("owner"),
+		"repo":  githubv4.String("repo"),
+		"prNum": githubv4.Int(42),
+	}
+	prQueryResponse := func(body string) githubv4mock.GQLResponse {
+		return githubv4mock.DataResponse(map[string]any{
+			"repository": map[string]any{
+				"pullRequest": map[string]any{"id": "PR_42", "body": body},
+			},
+		})
+	}
+	updateMutation := struct {
+		UpdatePullRequest struct {
+			PullRequest struct {
+				ID githubv4.ID
+			}
+		} `graphql:"updatePullRequest(input: $input)"`
+	}{}
+	updateResponse := githubv4mock.DataResponse(map[string]any{
+		"updatePullRequest": map[string]any{"pullRequest": map[string]any{"id": "PR_42"}},
+	})
+
+	tests := []struct {
+		name             string
+		mockedClient     *http.Client
+		requestArgs      map[string]any
+		expectToolErr    bool
+		expectedErrMsg   string
+		expectedResponse map[string]any
+	}{
+		{
+			name: "link issues",
+			mockedClient: githubv4mock.NewMockedHTTPClient(
+				githubv4mock.NewQueryMatcher(prQuery, prQueryVars, prQueryResponse("Adds caching.\n\nFixes #7")),
+				githubv4mock.NewMutationMatcher(updateMutation, githubv4.UpdatePullRequestInput{
+					PullRequestID: "PR_42",
+					Body:          githubv4.NewString("Adds caching.\n\nFixes #7\n\nResolves #8\nResolves other/lib#3"),
+				}, nil, updateResponse),
+			),
+			requestArgs: map[string]any{
+				"method":     "link",
+				"owner":      "owner",
+				"repo":       "repo",
+				"pullNumber": float64(42),
+				"issues":     []any{"7", "#8", "other/lib#3"},
+				"keyword":    "Resolves",
+			},
+			expectedResponse: map[string]any{
+				"linked":  []any{"#8", "other/lib#3"},
+				"updated": true,
+			},
+		},
+		{
+			name: "unlink issue",
+			mockedClient: githubv4mock.NewMockedHTTPClient(
+				githubv4mock.NewQueryMatcher(prQuery, prQueryVars, prQueryResponse("Adds caching, closes #7.\n\nFixes https://github.com/owner/repo/issues/7")),
+				githubv4mock.NewMutationMatcher(updateMutation, githubv4.UpdatePullRequestInput{
+					PullRequestID: "PR_42",
+					Body:          githubv4.NewString("Adds caching, #7."),
+				}, nil, updateResponse),
+			),
+			requestArgs: map[string]any{
+				"method":     "unlink",
+				"owner":      "owner",
+				"repo":       "repo",
+				"pullNumber": float64(42),
+				"issues":     []any{"https://github.com/owner/repo/issues/7"},
+			},
+			expectedResponse: map[string]any{
+				"unlinked": []any{"#7"},
+				"updated":  true,
+			},
+		},
+		{
+			name: "link already linked issue is a no-op",
+			mockedClient: githubv4mock.NewMockedHTTPClient(
+				githubv4mock.NewQueryMatcher(prQuery, prQueryVars, prQueryResponse("Closes #7")),
+			),
+			requestArgs: map[string]any{
+				"method":     "link",
+				"owner":      "owner",
+				"repo":       "repo",
+				"pullNumber": float64(42),
+				"issues":     []any{"#7"},
+			},
+			expectedResponse: map[string]any{
+				"linked":  []any{},
+				"updated": false,
+			},
+		},
+		{
+			name:         "invalid issue reference",
+			mockedClient: githubv4mock.NewMockedHTTPClient(),
+			requestArgs: map[string]any{
+				"method":     "link",
+				"owner":      "owner",
+				"repo":       "repo",
+				"pullNumber": float64(42),
+				"issues":     []any{"not-an-issue"},
+			},
+			expectToolErr:  true,
+			expectedErrMsg: "invalid issue reference: not-an-issue",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			deps := BaseDeps{
+				GQLClient: githubv4.NewClient(tc.mockedClient),
+			}
+			handler := serverTool.Handler(deps)
+			request := createMCPRequest(tc.requestArgs)
+
+			result, err := handler(ContextWithDeps(context.Background(), deps), &request)
+			require.NoError(t, err)
+
+			if tc.expectToolErr {
+				require.True(t, result.IsError)
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			textContent := getTextResult(t, result)
+			var response map[string]any
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &response))
+			assert.Equal(t, tc.expectedResponse, response)
+		})
+	}
+}
+
+func Test_ParseIssueReference(t *testing.T) {
+	tests := []struct {
+		ref      string
+		expected issueReference
+		wantErr  bool
+	}{
+		{ref: "12", expected: issueReference{Owner: "owner", Repo: "repo", Number: 12}},
+		{ref: "#12", expected: issueReference{Owner: "owner", Repo: "repo", Number: 12}},
+		{ref: "other/lib#3", expected: issueReference{Owner: "other", Repo: "lib", Number: 3}},
+		{ref: "https://github.com/other/lib/issues/3", expected: issueReference{Owner: "other", Repo: "lib", Number: 3}},
+		{ref: "https://github.com/other/lib/pull/3", wantErr: true},
+		{ref: "lib#3", wantErr: true},
+		{ref: "#0", wantErr: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.ref, func(t *testing.T) {
+			ref, err := parseIssueReference(tc.ref, "owner", "repo")
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, ref)
+		})
+	}
+}
+
+func Test_UnlinkIssuesInBody_KeepsOtherIssues(t *testing.T) {
+	refs := []issueReference{{Owner: "owner", Repo: "repo", Number: 1}}
+	body, removed := unlinkIssuesInBody("Closes #1\nCloses #12\nFixes #1, see #1", refs, "owner", "repo")
+	assert.Equal(t, "Closes #12\n#1, see #1", body)
+	assert.Equal(t, []string{"#1"}, removed)
+}
@@ -0,0 +1,386 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/inventory"
+	"github.com/github/github-mcp-server/pkg/scopes"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/github/github-mcp-server/pkg/utils"
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/shurcooL/githubv4"
+)
+
+// mergeQueueEntryNode is an entry of a merge queue.
+type mergeQueueEntryNode struct {
+	Position             githubv4.Int
+	State                githubv4.String
+	EnqueuedAt           githubv4.DateTime
+	EstimatedTimeToMerge *githubv4.Int
+	Jump                 githubv4.Boolean
+	Enqueuer             struct {
+		Login githubv4.String
+	}
+	PullRequest *struct {
+		Number githubv4.Int
+		Title  githubv4.String
+		URL    githubv4.String
+	}
+}
+
+// MergeQueueEntry is a pull request waiting in a merge queue.
+type MergeQueueEntry struct {
+	PullNumber           int    `json:"pull_number,omitempty"`
+	Title                string `json:"title,omitempty"`
+	URL                  string `json:"url,omitempty"`
+	Position             int    `json:"position"`
+	State                string `json:"state"`
+	EnqueuedAt           string `json:"enqueued_at,omitempty"`
+	EnqueuedBy           string `json:"enqueued_by,omitempty"`
+	EstimatedTimeToMerge string `json:"estimated_time_to_merge,omitempty"`
+	Jumped               bool   `json:"jumped,omitempty"`
+}
+
+// MergeQueueStatus is the merge queue of a branch, or the place of a pull request in it.
+type MergeQueueStatus struct {
+	Branch     string            `json:"branch"`
+	URL        string            `json:"url,omitempty"`
+	MergeQueue bool              `json:"merge_queue"`
+	Queued     *bool             `json:"queued,omitempty"`
+	Length     int               `json:"length"`
+	Entries    []MergeQueueEntry `json:"entries,omitempty"`
+}
+
+func convertToMergeQueueEntry(node mergeQueueEntryNode) MergeQueueEntry {
+	entry := MergeQueueEntry{
+		Position:   int(node.Position),
+		State:      strings.ToLower(string(node.State)),
+		EnqueuedBy: string(node.Enqueuer.Login),
+		Jumped:     bool(node.Jump),
+	}
+	if !node.EnqueuedAt.IsZero() {
+		entry.EnqueuedAt = node.EnqueuedAt.Format(time.RFC3339)
+	}
+	if node.EstimatedTimeToMerge != nil {
+		entry.EstimatedTimeToMerge = (time.Duration(*node.EstimatedTimeToMerge) * time.Second).String()
+	}
+	if pr := node.PullRequest; pr != nil {
+		entry.PullNumber = int(pr.Number)
+		entry.Title = string(pr.Title)
+		entry.URL = string(pr.URL)
+	}
+	return entry
+}
+
+// GetMergeQueue creates a tool to read the merge queue of a branch, or the place of a pull
+// request in the merge queue of its base branch.
+func GetMergeQueue(t translations.TranslationHelperFunc) inventory.ServerTool {
+	return NewTool(
+		ToolsetMetadataPullRequests,
+		mcp.Tool{
+			Name:        "get_merge_queue",
+			Description: t("TOOL_GET_MERGE_QUEUE_DESCRIPTION", "Get the merge queue of a branch: the pull requests waiting in it, with their position, state and estimated time to merge. With pullNumber, get the place of that pull request in the merge queue of its base branch instead."),
+			Annotations: &mcp.ToolAnnotations{
+				Title:        t("TOOL_GET_MERGE_QUEUE_USER_TITLE", "Get merge queue"),
+				ReadOnlyHint: true,
+			},
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"owner": {
+						Type:        "string",
+						Description: "Repository owner",
+					},
+					"repo": {
+						Type:        "string",
+						Description: "Repository name",
+					},
+					"branch": {
+						Type:        "string",
+						Description: "Branch whose merge queue to get. Defaults to the default branch",
+					},
+					"pullNumber": {
+						Type:        "number",
+						Description: "Pull request whose place in the merge queue of its base branch to get",
+					},
+					"perPage": {
+						Type:        "number",
+						Description: "Maximum number of entries to return (1-100, default 30)",
+						Minimum:     jsonschema.Ptr(1.0),
+						Maximum:     jsonschema.Ptr(100.0),
+					},
+				},
+				Required: []string{"owner", "repo"},
+			},
+		},
+		[]scopes.Scope{scopes.Repo},
+		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+			owner, err := RequiredParam[string](args, "owner")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			repo, err := RequiredParam[string](args, "repo")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			branch, err := OptionalParam[string](args, "branch")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			pullNumber, err := OptionalIntParam(args, "pullNumber")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			perPage, err := OptionalIntParamWithDefault(args, "perPage", 30)
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			if perPage < 1 || perPage > 100 {
+				return utils.NewToolResultError("perPage must be between 1 and 100"), nil, nil
+			}
+			if pullNumber != 0 && branch != "" {
+				return utils.NewToolResultError("branch cannot be set with pullNumber, which uses the base branch of the pull request"), nil, nil
+			}
+
+			client, err := deps.GetGQLClient(ctx)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to get GitHub GQL client: %w", err)
+			}
+
+			if pullNumber != 0 {
+				var q struct {
+					Repository struct {
+						PullRequest struct {
+							BaseRefName         githubv4.String
+							IsMergeQueueEnabled githubv4.Boolean
+							IsInMergeQueue      githubv4.Boolean
+							MergeQueueEntry     *mergeQueueEntryNode
+						} `graphql:"pullRequest(number: $pullNumber)"`
+					} `graphql:"repository(owner: $owner, name: $repo)"`
+				}
+				vars := map[string]any{
+					"owner":      githubv4.String(owner),
+					"repo":       githubv4.String(repo),
+					"pullNumber": githubv4.Int(int32(pullNumber)), // #nosec G115 - pull request numbers are always small positive integers
+				}
+				if err := client.Query(ctx, &q, vars); err != nil {
+					return ghErrors.NewGitHubGraphQLErrorResponse(ctx, "failed to get merge queue status", err), nil, nil
+				}
+
+				pr := q.Repository.PullRequest
+				queued := bool(pr.IsInMergeQueue)
+				status := MergeQueueStatus{
+					Branch:     string(pr.BaseRefName),
+					MergeQueue: bool(pr.IsMergeQueueEnabled),
+					Queued:     &queued,
+				}
+				if pr.MergeQueueEntry != nil {
+					status.Entries = []MergeQueueEntry{convertToMergeQueueEntry(*pr.MergeQueueEntry)}
+				}
+				return MarshalledTextResult(status), nil, nil
+			}
+
+			var q struct {
+				Repository struct {
+					DefaultBranchRef struct {
+						Name githubv4.String
+					}
+					MergeQueue *struct {
+						URL     githubv4.String
+						Entries struct {
+							TotalCount githubv4.Int
+							Nodes      []mergeQueueEntryNode
+						} `graphql:"entries(first: $first)"`
+					} `graphql:"mergeQueue(branch: $branch)"`
+				} `graphql:"repository(owner: $owner, name: $repo)"`
+			}
+			vars := map[string]any{
+				"owner": githubv4.String(owner),
+				"repo":  githubv4.String(repo),
+				"first": githubv4.Int(int32(perPage)), // #nosec G115 - perPage is bounded to 100 above
+			}
+			if branch != "" {
+				vars["branch"] = githubv4.String(branch)
+			} else {
+				vars["branch"] = (*githubv4.String)(nil)
+			}
+			if err := client.Query(ctx, &q, vars); err != nil {
+				return ghErrors.NewGitHubGraphQLErrorResponse(ctx, "failed to get merge queue", err), nil, nil
+			}
+
+			if branch == "" {
+				branch = string(q.Repository.DefaultBranchRef.Name)
+			}
+			status := MergeQueueStatus{Branch: branch}
+			if mq := q.Repository.MergeQueue; mq != nil {
+				status.MergeQueue = true
+				status.URL = string(mq.URL)
+				status.Length = int(mq.Entries.TotalCount)
+				status.Entries = make([]MergeQueueEntry, 0, len(mq.Entries.Nodes))
+				for _, node := range mq.Entries.Nodes {
+					status.Entries = append(status.Entries, convertToMergeQueueEntry(node))
+				}
+			}
+			return MarshalledTextResult(status), nil, nil
+		},
+	)
+}
+
+// MergeQueueWrite creates a tool to add pull requests to and remove them from the merge queue of
+// their base branch.
+func MergeQueueWrite(t translations.TranslationHelperFunc) inventory.ServerTool {
+	return NewTool(
+		ToolsetMetadataPullRequests,
+		mcp.Tool{
+			Name: "merge_queue_write",
+			Description: t("TOOL_MERGE_QUEUE_WRITE_DESCRIPTION", `Add a pull request to the merge queue of its base branch, or remove it. Use this instead of merge_pull_request for branches that require a merge queue: the queue merges the pull request once its checks pass against the changes queued ahead of it.
+Available methods:
+- add: add the pull request to the merge queue.
+- remove: remove the pull request from the merge queue.`),
+			Annotations: &mcp.ToolAnnotations{
+				Title:        t("TOOL_MERGE_QUEUE_WRITE_USER_TITLE", "Add or remove a pull request from the merge queue"),
+				ReadOnlyHint: false,
+			},
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"method": {
+						Type:        "string",
+						Description: "The write operation to perform",
+						Enum:        []any{"add", "remove"},
+					},
+					"owner": {
+						Type:        "string",
+						Description: "Repository owner",
+					},
+					"repo": {
+						Type:        "string",
+						Description: "Repository name",
+					},
+					"pullNumber": {
+						Type:        "number",
+						Description: "Pull request number",
+					},
+					"expected_head_sha": {
+						Type:        "string",
+						Description: "For add: only add the pull request if its head is still this commit",
+					},
+					"jump": {
+						Type:        "boolean",
+						Description: "For add: put the pull request at the front of the queue. Requires permission to jump the queue",
+					},
+				},
+				Required: []string{"method", "owner", "repo", "pullNumber"},
+			},
+		},
+		[]scopes.Scope{scopes.Repo},
+		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+			method, err := RequiredParam[string](args, "method")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			owner, err := RequiredParam[string](args, "owner")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			repo, err := RequiredParam[string](args, "repo")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			pullNumber, err := RequiredInt(args, "pullNumber")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			expectedHeadSHA, err := OptionalParam[string](args, "expected_head_sha")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			jump, err := OptionalParam[bool](args, "jump")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			switch method {
+			case "add":
+			case "remove":
+				if expectedHeadSHA != "" || jump {
+					return utils.NewToolResultError("expected_head_sha and jump can only be set with the add method"), nil, nil
+				}
+			default:
+				return utils.NewToolResultError(fmt.Sprintf("unknown method: %s", method)), nil, nil
+			}
+
+			client, err := deps.GetGQLClient(ctx)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to get GitHub GQL client: %w", err)
+			}
+
+			var q struct {
+				Repository struct {
+					PullRequest struct {
+						ID                  githubv4.ID
+						BaseRefName         githubv4.String
+						IsMergeQueueEnabled githubv4.Boolean
+						IsInMergeQueue      githubv4.Boolean
+					} `graphql:"pullRequest(number: $pullNumber)"`
+				} `graphql:"repository(owner: $owner, name: $repo)"`
+			}
+			vars := map[string]any{
+				"owner":      githubv4.String(owner),
+				"repo":       githubv4.String(repo),
+				"pullNumber": githubv4.Int(int32(pullNumber)), // #nosec G115 - pull request numbers are always small positive integers
+			}
+			if err := client.Query(ctx, &q, vars); err != nil {
+				return ghErrors.NewGitHubGraphQLErrorResponse(ctx, "failed to get pull request", err), nil, nil
+			}
+			pr := q.Repository.PullRequest
+			ref := fmt.Sprintf("%s/%s#%d", owner, repo, pullNumber)
+
+			if method == "remove" {
+				if !pr.IsInMergeQueue {
+					return utils.NewToolResultError(fmt.Sprintf("%s is not in the merge queue", ref)), nil, nil
+				}
+				var m struct {
+					DequeuePullRequest struct {
+						MergeQueueEntry struct {
+							ID githubv4.ID
+						}
+					} `graphql:"dequeuePullRequest(input: $input)"`
+				}
+				if err := client.Mutate(ctx, &m, githubv4.DequeuePullRequestInput{ID: pr.ID}, nil); err != nil {
+					return ghErrors.NewGitHubGraphQLErrorResponse(ctx, "failed to remove pull request from the merge queue", err), nil, nil
+				}
+				return utils.NewToolResultText(fmt.Sprintf("%s was removed from the merge queue of %s", ref, pr.BaseRefName)), nil, nil
+			}
+
+			if !pr.IsMergeQueueEnabled {
+				return utils.NewToolResultError(fmt.Sprintf("%s does not use a merge queue; merge %s with merge_pull_request instead", pr.BaseRefName, ref)), nil, nil
+			}
+			if pr.IsInMergeQueue {
+				return utils.NewToolResultError(fmt.Sprintf("%s is already in the merge queue", ref)), nil, nil
+			}
+			input := githubv4.EnqueuePullRequestInput{PullRequestID: pr.ID}
+			if expectedHeadSHA != "" {
+				input.ExpectedHeadOid = githubv4.NewGitObjectID(githubv4.GitObjectID(expectedHeadSHA))
+			}
+			if jump {
+				input.Jump = githubv4.NewBoolean(true)
+			}
+			var m struct {
+				EnqueuePullRequest struct {
+					MergeQueueEntry mergeQueueEntryNode
+				} `graphql:"enqueuePullRequest(input: $input)"`
+			}
+			if err := client.Mutate(ctx, &m, input, nil); err != nil {
+				return ghErrors.NewGitHubGraphQLErrorResponse(ctx, "failed to add pull request to the merge queue", err), nil, nil
+			}
+
+			entry := convertToMergeQueueEntry(m.EnqueuePullRequest.MergeQueueEntry)
+			return MarshalledTextResult(entry), nil, nil
+		},
+	)
+}
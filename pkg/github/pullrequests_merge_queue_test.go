@@ -0,0 +1,274 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/githubv4mock"
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v82/github"
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/shurcooL/githubv4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_GetMergeQueue(t *testing.T) {
+	serverTool := GetMergeQueue(translations.NullTranslationHelper)
+	tool := serverTool.Tool
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_merge_queue", tool.Name)
+	assert.True(t, tool.Annotations.ReadOnlyHint)
+	schema := tool.InputSchema.(*jsonschema.Schema)
+	assert.ElementsMatch(t, schema.Required, []string{"owner", "repo"})
+
+	queueQuery := struct {
+		Repository struct {
+			DefaultBranchRef struct {
+				Name githubv4.String
+			}
+			MergeQueue *struct {
+				URL     githubv4.String
+				Entries struct {
+					TotalCount githubv4.Int
+					Nodes      []mergeQueueEntryNode
+				} `graphql:"entries(first: $first)"`
+			} `graphql:"mergeQueue(branch: $branch)"`
+		} `graphql:"repository(owner: $owner, name: $repo)"`
+	}{}
+	pullQuery := struct {
+		Repository struct {
+			PullRequest struct {
+				BaseRefName         githubv4.String
+				IsMergeQueueEnabled githubv4.Boolean
+				IsInMergeQueue      githubv4.Boolean
+				MergeQueueEntry     *mergeQueueEntryNode
+			} `graphql:"pullRequest(number: $pullNumber)"`
+		} `graphql:"repository(owner: $owner, name: $repo)"`
+	}{}
+	entry := func(number, position int, state string) map[string]any {
+		return map[string]any{
+			"position":             position,
+			"state":                state,
+			"enqueuedAt":           "2026-10-17T09:00:00Z",
+			"estimatedTimeToMerge": 600,
+			"jump":                 false,
+			"enqueuer":             map[string]any{"login": "octocat"},
+			"pullRequest":          map[string]any{"number": number, "title": "Fix flaky test", "url": "https://github.com/octo/ink/pull/7"},
+		}
+	}
+
+	tests := []struct {
+		name           string
+		args           map[string]any
+		matcher        githubv4mock.Matcher
+		expected       MergeQueueStatus
+		expectedErrMsg string
+	}{
+		{
+			name: "default branch queue",
+			args: map[string]any{},
+			matcher: githubv4mock.NewQueryMatcher(queueQuery,
+				map[string]any{"owner": githubv4.String("octo"), "repo": githubv4.String("ink"), "first": githubv4.Int(30), "branch": (*githubv4.String)(nil)},
+				githubv4mock.DataResponse(map[string]any{"repository": map[string]any{
+					"defaultBranchRef": map[string]any{"name": "main"},
+					"mergeQueue": map[string]any{
+						"url":     "https://github.com/octo/ink/queue/main",
+						"entries": map[string]any{"totalCount": 1, "nodes": []any{entry(7, 1, "AWAITING_CHECKS")}},
+					},
+				}}),
+			),
+			expected: MergeQueueStatus{
+				Branch:     "main",
+				URL:        "https://github.com/octo/ink/queue/main",
+				MergeQueue: true,
+				Length:     1,
+				Entries: []MergeQueueEntry{{
+					PullNumber: 7, Title: "Fix flaky test", URL: "https://github.com/octo/ink/pull/7",
+					Position: 1, State: "awaiting_checks", EnqueuedAt: "2026-10-17T09:00:00Z", EnqueuedBy: "octocat", EstimatedTimeToMerge: "10m0s",
+				}},
+			},
+		},
+		{
+			name: "branch without a merge queue",
+			args: map[string]any{"branch": "release"},
+			matcher: githubv4mock.NewQueryMatcher(queueQuery,
+				map[string]any{"owner": githubv4.String("octo"), "repo": githubv4.String("ink"), "first": githubv4.Int(30), "branch": githubv4.String("release")},
+				githubv4mock.DataResponse(map[string]any{"repository": map[string]any{
+					"defaultBranchRef": map[string]any{"name": "main"},
+					"mergeQueue":       nil,
+				}}),
+			),
+			expected: MergeQueueStatus{Branch: "release"},
+		},
+		{
+			name: "pull request position",
+			args: map[string]any{"pullNumber": float64(7)},
+			matcher: githubv4mock.NewQueryMatcher(pullQuery,
+				map[string]any{"owner": githubv4.String("octo"), "repo": githubv4.String("ink"), "pullNumber": githubv4.Int(7)},
+				githubv4mock.DataResponse(map[string]any{"repository": map[string]any{"pullRequest": map[string]any{
+					"baseRefName":         "main",
+					"isMergeQueueEnabled": true,
+					"isInMergeQueue":      true,
+					"mergeQueueEntry":     entry(7, 2, "QUEUED"),
+				}}}),
+			),
+			expected: MergeQueueStatus{
+				Branch:     "main",
+				MergeQueue: true,
+				Queued:     github.Ptr(true),
+				Entries: []MergeQueueEntry{{
+					PullNumber: 7, Title: "Fix flaky test", URL: "https://github.com/octo/ink/pull/7",
+					Position: 2, State: "queued", EnqueuedAt: "2026-10-17T09:00:00Z", EnqueuedBy: "octocat", EstimatedTimeToMerge: "10m0s",
+				}},
+			},
+		},
+		{
+			name:           "branch with pull request",
+			args:           map[string]any{"pullNumber": float64(7), "branch": "main"},
+			expectedErrMsg: "branch cannot be set with pullNumber",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var matchers []githubv4mock.Matcher
+			if tc.matcher.Request != "" {
+				matchers = append(matchers, tc.matcher)
+			}
+			deps := BaseDeps{GQLClient: githubv4.NewClient(githubv4mock.NewMockedHTTPClient(matchers...))}
+			args := map[string]any{"owner": "octo", "repo": "ink"}
+			for k, v := range tc.args {
+				args[k] = v
+			}
+			request := createMCPRequest(args)
+			result, err := serverTool.Handler(deps)(ContextWithDeps(context.Background(), deps), &request)
+			require.NoError(t, err)
+			if tc.expectedErrMsg != "" {
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+			var status MergeQueueStatus
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &status))
+			assert.Equal(t, tc.expected, status)
+		})
+	}
+}
+
+func Test_MergeQueueWrite(t *testing.T) {
+	serverTool := MergeQueueWrite(translations.NullTranslationHelper)
+	tool := serverTool.Tool
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "merge_queue_write", tool.Name)
+	assert.False(t, tool.Annotations.ReadOnlyHint)
+	schema := tool.InputSchema.(*jsonschema.Schema)
+	assert.ElementsMatch(t, schema.Required, []string{"method", "owner", "repo", "pullNumber"})
+
+	pullQuery := func(enabled, queued bool) githubv4mock.Matcher {
+		return githubv4mock.NewQueryMatcher(
+			struct {
+				Repository struct {
+					PullRequest struct {
+						ID                  githubv4.ID
+						BaseRefName         githubv4.String
+						IsMergeQueueEnabled githubv4.Boolean
+						IsInMergeQueue      githubv4.Boolean
+					} `graphql:"pullRequest(number: $pullNumber)"`
+				} `graphql:"repository(owner: $owner, name: $repo)"`
+			}{},
+			map[string]any{"owner": githubv4.String("octo"), "repo": githubv4.String("ink"), "pullNumber": githubv4.Int(7)},
+			githubv4mock.DataResponse(map[string]any{"repository": map[string]any{"pullRequest": map[string]any{
+				"id":                  "PR_7",
+				"baseRefName":         "main",
+				"isMergeQueueEnabled": enabled,
+				"isInMergeQueue":      queued,
+			}}}),
+		)
+	}
+	enqueueMutation := struct {
+		EnqueuePullRequest struct {
+			MergeQueueEntry mergeQueueEntryNode
+		} `graphql:"enqueuePullRequest(input: $input)"`
+	}{}
+
+	tests := []struct {
+		name           string
+		args           map[string]any
+		matchers       []githubv4mock.Matcher
+		expectedText   string
+		expectedErrMsg string
+	}{
+		{
+			name: "add",
+			args: map[string]any{"method": "add", "expected_head_sha": "abc123", "jump": true},
+			matchers: []githubv4mock.Matcher{
+				pullQuery(true, false),
+				githubv4mock.NewMutationMatcher(enqueueMutation,
+					githubv4.EnqueuePullRequestInput{PullRequestID: "PR_7", ExpectedHeadOid: githubv4.NewGitObjectID("abc123"), Jump: githubv4.NewBoolean(true)}, nil,
+					githubv4mock.DataResponse(map[string]any{"enqueuePullRequest": map[string]any{"mergeQueueEntry": map[string]any{
+						"position": 1, "state": "QUEUED", "jump": true,
+						"enqueuer":    map[string]any{"login": "octocat"},
+						"pullRequest": map[string]any{"number": 7, "title": "Fix flaky test", "url": "https://github.com/octo/ink/pull/7"},
+					}}})),
+			},
+			expectedText: `{"pull_number":7,"title":"Fix flaky test","url":"https://github.com/octo/ink/pull/7","position":1,"state":"queued","enqueued_by":"octocat","jumped":true}`,
+		},
+		{
+			name:           "add without a merge queue",
+			args:           map[string]any{"method": "add"},
+			matchers:       []githubv4mock.Matcher{pullQuery(false, false)},
+			expectedErrMsg: "main does not use a merge queue; merge octo/ink#7 with merge_pull_request instead",
+		},
+		{
+			name: "remove",
+			args: map[string]any{"method": "remove"},
+			matchers: []githubv4mock.Matcher{
+				pullQuery(true, true),
+				githubv4mock.NewMutationMatcher(
+					struct {
+						DequeuePullRequest struct {
+							MergeQueueEntry struct {
+								ID githubv4.ID
+							}
+						} `graphql:"dequeuePullRequest(input: $input)"`
+					}{},
+					githubv4.DequeuePullRequestInput{ID: "PR_7"}, nil,
+					githubv4mock.DataResponse(map[string]any{"dequeuePullRequest": map[string]any{"mergeQueueEntry": map[string]any{"id": "MQE_1"}}})),
+			},
+			expectedText: "octo/ink#7 was removed from the merge queue of main",
+		},
+		{
+			name:           "remove when not queued",
+			args:           map[string]any{"method": "remove"},
+			matchers:       []githubv4mock.Matcher{pullQuery(true, false)},
+			expectedErrMsg: "octo/ink#7 is not in the merge queue",
+		},
+		{
+			name:           "jump with remove",
+			args:           map[string]any{"method": "remove", "jump": true},
+			expectedErrMsg: "expected_head_sha and jump can only be set with the add method",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			deps := BaseDeps{GQLClient: githubv4.NewClient(githubv4mock.NewMockedHTTPClient(tc.matchers...))}
+			args := map[string]any{"owner": "octo", "repo": "ink", "pullNumber": float64(7)}
+			for k, v := range tc.args {
+				args[k] = v
+			}
+			request := createMCPRequest(args)
+			result, err := serverTool.Handler(deps)(ContextWithDeps(context.Background(), deps), &request)
+			require.NoError(t, err)
+			if tc.expectedErrMsg != "" {
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+			assert.Equal(t, tc.expectedText, getTextResult(t, result).Text)
+		})
+	}
+}
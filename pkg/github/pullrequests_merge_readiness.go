@@ -0,0 +1,318 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/inventory"
+	"github.com/github/github-mcp-server/pkg/scopes"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/github/github-mcp-server/pkg/utils"
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/shurcooL/githubv4"
+)
+
+// Merge readiness verdicts, from the most to the least actionable for an agent shepherding a
+// pull request.
+const (
+	mergeReadinessReady   = "ready"
+	mergeReadinessPending = "pending"
+	mergeReadinessQueued  = "queued"
+	mergeReadinessBlocked = "blocked"
+)
+
+// mergeReadinessCheckNode is a check run or commit status of the head commit.
+type mergeReadinessCheckNode struct {
+	Typename githubv4.String `graphql:"__typename"`
+	CheckRun struct {
+		Name       githubv4.String
+		Status     githubv4.String
+		Conclusion githubv4.String
+		IsRequired githubv4.Boolean `graphql:"isRequired(pullRequestNumber: $pullNumber)"`
+	} `graphql:"... on CheckRun"`
+	StatusContext struct {
+		Context    githubv4.String
+		State      githubv4.String
+		IsRequired githubv4.Boolean `graphql:"isRequired(pullRequestNumber: $pullNumber)"`
+	} `graphql:"... on StatusContext"`
+}
+
+type mergeReadinessQuery struct {
+	Repository struct {
+		PullRequest struct {
+			Number              githubv4.Int
+			URL                 githubv4.String
+			State               githubv4.String
+			IsDraft             githubv4.Boolean
+			Mergeable           githubv4.String
+			MergeStateStatus    githubv4.String
+			ReviewDecision      githubv4.String
+			BaseRefName         githubv4.String
+			IsMergeQueueEnabled githubv4.Boolean
+			IsInMergeQueue      githubv4.Boolean
+			MergeQueueEntry     *struct {
+				Position githubv4.Int
+				State    githubv4.String
+			}
+			ReviewRequests struct {
+				TotalCount githubv4.Int
+			}
+			Commits struct {
+				Nodes []struct {
+					Commit struct {
+						StatusCheckRollup *struct {
+							State    githubv4.String
+							Contexts struct {
+								TotalCount githubv4.Int
+								Nodes      []mergeReadinessCheckNode
+							} `graphql:"contexts(first: 100)"`
+						}
+					}
+				}
+			} `graphql:"commits(last: 1)"`
+		} `graphql:"pullRequest(number: $pullNumber)"`
+	} `graphql:"repository(owner: $owner, name: $repo)"`
+}
+
+// MergeReadinessCheck is a required check of the pull request and its outcome.
+type MergeReadinessCheck struct {
+	Name  string `json:"name"`
+	State string `json:"state"`
+}
+
+// MergeReadinessQueue describes the merge queue of the base branch.
+type MergeReadinessQueue struct {
+	Queued   bool   `json:"queued"`
+	Position int    `json:"position,omitempty"`
+	State    string `json:"state,omitempty"`
+}
+
+// MergeReadiness is the verdict on whether a pull request can be merged, with the reasons it
+// cannot.
+type MergeReadiness struct {
+	PullNumber       int                   `json:"pull_number"`
+	URL              string                `json:"url"`
+	Verdict          string                `json:"verdict"`
+	BlockingReasons  []string              `json:"blocking_reasons"`
+	Warnings         []string              `json:"warnings,omitempty"`
+	BaseBranch       string                `json:"base_branch"`
+	Mergeable        string                `json:"mergeable"`
+	MergeStateStatus string                `json:"merge_state_status"`
+	ReviewDecision   string                `json:"review_decision,omitempty"`
+	RequiredChecks   []MergeReadinessCheck `json:"required_checks"`
+	MergeQueue       *MergeReadinessQueue  `json:"merge_queue,omitempty"`
+}
+
+// checkNodeState reduces a check run or commit status to success, failure, pending, skipped or
+// neutral.
+func checkNodeState(node mergeReadinessCheckNode) (name, state string) {
+	if node.Typename == "StatusContext" {
+		name = string(node.StatusContext.Context)
+		switch node.StatusContext.State {
+		case "SUCCESS":
+			return name, "success"
+		case "PENDING", "EXPECTED":
+			return name, "pending"
+		default:
+			return name, "failure"
+		}
+	}
+	name = string(node.CheckRun.Name)
+	if node.CheckRun.Status != "COMPLETED" {
+		return name, "pending"
+	}
+	switch node.CheckRun.Conclusion {
+	case "SUCCESS":
+		return name, "success"
+	case "SKIPPED":
+		return name, "skipped"
+	case "NEUTRAL":
+		return name, "neutral"
+	default:
+		return name, "failure"
+	}
+}
+
+// evaluateMergeReadiness turns the state of a pull request into a verdict. Pending checks and
+// mergeability that is still being computed make a pull request pending rather than blocked,
+// as they resolve without anyone acting on it.
+func evaluateMergeReadiness(query *mergeReadinessQuery) MergeReadiness {
+	pr := query.Repository.PullRequest
+	result := MergeReadiness{
+		PullNumber:       int(pr.Number),
+		URL:              string(pr.URL),
+		BlockingReasons:  []string{},
+		BaseBranch:       string(pr.BaseRefName),
+		Mergeable:        strings.ToLower(string(pr.Mergeable)),
+		MergeStateStatus: strings.ToLower(string(pr.MergeStateStatus)),
+		ReviewDecision:   strings.ToLower(string(pr.ReviewDecision)),
+		RequiredChecks:   []MergeReadinessCheck{},
+	}
+	pending := false
+
+	if pr.State != "OPEN" {
+		result.BlockingReasons = append(result.BlockingReasons, fmt.Sprintf("the pull request is %s", strings.ToLower(string(pr.State))))
+	}
+	if pr.IsDraft {
+		result.BlockingReasons = append(result.BlockingReasons, "the pull request is a draft and must be marked ready for review")
+	}
+
+	switch pr.Mergeable {
+	case "CONFLICTING":
+		result.BlockingReasons = append(result.BlockingReasons, fmt.Sprintf("the pull request has merge conflicts with %s that must be resolved", pr.BaseRefName))
+	case "UNKNOWN":
+		pending = true
+		result.Warnings = append(result.Warnings, "GitHub is still computing whether the pull request can be merged; check again shortly")
+	}
+
+	switch pr.ReviewDecision {
+	case "CHANGES_REQUESTED":
+		result.BlockingReasons = append(result.BlockingReasons, "a reviewer requested changes that must be addressed and re-reviewed")
+	case "REVIEW_REQUIRED":
+		reason := "an approving review is required"
+		if pr.ReviewRequests.TotalCount > 0 {
+			reason += fmt.Sprintf("; %d review request(s) are outstanding", pr.ReviewRequests.TotalCount)
+		}
+		result.BlockingReasons = append(result.BlockingReasons, reason)
+	}
+
+	checksBlocking := false
+	if nodes := pr.Commits.Nodes; len(nodes) > 0 && nodes[0].Commit.StatusCheckRollup != nil {
+		rollup := nodes[0].Commit.StatusCheckRollup
+		var failingOptional []string
+		for _, node := range rollup.Contexts.Nodes {
+			name, state := checkNodeState(node)
+			required := node.CheckRun.IsRequired || node.StatusContext.IsRequired
+			if !required {
+				if state == "failure" {
+					failingOptional = append(failingOptional, name)
+				}
+				continue
+			}
+			result.RequiredChecks = append(result.RequiredChecks, MergeReadinessCheck{Name: name, State: state})
+			switch state {
+			case "failure":
+				checksBlocking = true
+				result.BlockingReasons = append(result.BlockingReasons, fmt.Sprintf("required check %q failed", name))
+			case "pending":
+				pending = true
+			}
+		}
+		if len(failingOptional) > 0 {
+			result.Warnings = append(result.Warnings, fmt.Sprintf("checks that are not required failed: %s", strings.Join(failingOptional, ", ")))
+		}
+		if int(rollup.Contexts.TotalCount) > len(rollup.Contexts.Nodes) {
+			result.Warnings = append(result.Warnings, fmt.Sprintf("only the first %d of %d checks were evaluated", len(rollup.Contexts.Nodes), rollup.Contexts.TotalCount))
+		}
+	}
+
+	switch pr.MergeStateStatus {
+	case "BEHIND":
+		result.BlockingReasons = append(result.BlockingReasons, fmt.Sprintf("the head branch is behind %s and must be updated", pr.BaseRefName))
+	case "BLOCKED":
+		// Blocked without a reason found above means a requirement the API does not detail,
+		// such as a required check that has not reported yet or unresolved conversations.
+		if len(result.BlockingReasons) == 0 && !pending && !checksBlocking {
+			result.BlockingReasons = append(result.BlockingReasons, "merging is blocked by branch protection or rulesets, for example by required checks that have not reported or unresolved conversations")
+		}
+	case "UNKNOWN":
+		pending = true
+	}
+
+	if pr.IsMergeQueueEnabled {
+		queue := &MergeReadinessQueue{Queued: bool(pr.IsInMergeQueue)}
+		if entry := pr.MergeQueueEntry; entry != nil {
+			queue.Position = int(entry.Position)
+			queue.State = strings.ToLower(string(entry.State))
+		}
+		result.MergeQueue = queue
+		if !queue.Queued {
+			result.Warnings = append(result.Warnings, fmt.Sprintf("%s requires merging through its merge queue; enable auto-merge with enable_pull_request_auto_merge or add the pull request to the queue with merge_queue_write instead of merging it directly", pr.BaseRefName))
+		}
+	}
+
+	switch {
+	case len(result.BlockingReasons) > 0:
+		result.Verdict = mergeReadinessBlocked
+	case result.MergeQueue != nil && result.MergeQueue.Queued:
+		result.Verdict = mergeReadinessQueued
+	case pending:
+		result.Verdict = mergeReadinessPending
+	default:
+		result.Verdict = mergeReadinessReady
+	}
+	return result
+}
+
+// CheckMergeReadiness creates a tool that aggregates everything deciding whether a pull request
+// can be merged into a single verdict.
+func CheckMergeReadiness(t translations.TranslationHelperFunc) inventory.ServerTool {
+	return NewTool(
+		ToolsetMetadataPullRequests,
+		mcp.Tool{
+			Name: "check_merge_readiness",
+			Description: t("TOOL_CHECK_MERGE_READINESS_DESCRIPTION", `Check whether a pull request can be merged, combining its mergeable state, required checks, review requirements and merge queue requirements into a single verdict:
+- ready: the pull request can be merged now.
+- pending: nothing blocks the pull request, but required checks are still running or mergeability is still being computed; check again later.
+- queued: the pull request is in the merge queue of its base branch.
+- blocked: see blocking_reasons for what must change before it can be merged.`),
+			Annotations: &mcp.ToolAnnotations{
+				Title:        t("TOOL_CHECK_MERGE_READINESS_USER_TITLE", "Check pull request merge readiness"),
+				ReadOnlyHint: true,
+			},
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"owner": {
+						Type:        "string",
+						Description: "Repository owner",
+					},
+					"repo": {
+						Type:        "string",
+						Description: "Repository name",
+					},
+					"pullNumber": {
+						Type:        "number",
+						Description: "Pull request number",
+					},
+				},
+				Required: []string{"owner", "repo", "pullNumber"},
+			},
+		},
+		[]scopes.Scope{scopes.Repo},
+		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+			owner, err := RequiredParam[string](args, "owner")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			repo, err := RequiredParam[string](args, "repo")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			pullNumber, err := RequiredInt(args, "pullNumber")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+
+			client, err := deps.GetGQLClient(ctx)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to get GitHub GQL client: %w", err)
+			}
+
+			var query mergeReadinessQuery
+			vars := map[string]any{
+				"owner":      githubv4.String(owner),
+				"repo":       githubv4.String(repo),
+				"pullNumber": githubv4.Int(int32(pullNumber)), // #nosec G115 - pull request numbers are always small positive integers
+			}
+			if err := client.Query(ctx, &query, vars); err != nil {
+				return ghErrors.NewGitHubGraphQLErrorResponse(ctx, "failed to get pull request merge readiness", err), nil, nil
+			}
+
+			return MarshalledTextResult(evaluateMergeReadiness(&query)), nil, nil
+		},
+	)
+}
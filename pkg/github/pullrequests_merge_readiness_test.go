@@ -0,0 +1,217 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/githubv4mock"
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/shurcooL/githubv4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_CheckMergeReadiness(t *testing.T) {
+	serverTool := CheckMergeReadiness(translations.NullTranslationHelper)
+	tool := serverTool.Tool
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "check_merge_readiness", tool.Name)
+	assert.True(t, tool.Annotations.ReadOnlyHint)
+	schema := tool.InputSchema.(*jsonschema.Schema)
+	assert.ElementsMatch(t, schema.Required, []string{"owner", "repo", "pullNumber"})
+
+	checkRun := func(name, status string, conclusion any, required bool) map[string]any {
+		return map[string]any{"__typename": "CheckRun", "name": name, "status": status, "conclusion": conclusion, "isRequired": required}
+	}
+	statusContext := func(context, state string, required bool) map[string]any {
+		return map[string]any{"__typename": "StatusContext", "context": context, "state": state, "isRequired": required}
+	}
+	pullRequest := func(overrides map[string]any, checks ...map[string]any) map[string]any {
+		pr := map[string]any{
+			"number":              42,
+			"url":                 "https://github.com/owner/repo/pull/42",
+			"state":               "OPEN",
+			"isDraft":             false,
+			"mergeable":           "MERGEABLE",
+			"mergeStateStatus":    "CLEAN",
+			"reviewDecision":      "APPROVED",
+			"baseRefName":         "main",
+			"isMergeQueueEnabled": false,
+			"isInMergeQueue":      false,
+			"mergeQueueEntry":     nil,
+			"reviewRequests":      map[string]any{"totalCount": 0},
+			"commits": map[string]any{
+				"nodes": []map[string]any{
+					{"commit": map[string]any{"statusCheckRollup": map[string]any{
+						"state":    "SUCCESS",
+						"contexts": map[string]any{"totalCount": len(checks), "nodes": checks},
+					}}},
+				},
+			},
+		}
+		for k, v := range overrides {
+			pr[k] = v
+		}
+		return pr
+	}
+
+	tests := []struct {
+		name     string
+		pr       map[string]any
+		expected MergeReadiness
+	}{
+		{
+			name: "ready",
+			pr: pullRequest(nil,
+				checkRun("build", "COMPLETED", "SUCCESS", true),
+				checkRun("lint", "COMPLETED", "FAILURE", false),
+				statusContext("deploy/preview", "SUCCESS", false),
+			),
+			expected: MergeReadiness{
+				Verdict:          "ready",
+				BlockingReasons:  []string{},
+				Warnings:         []string{"checks that are not required failed: lint"},
+				Mergeable:        "mergeable",
+				MergeStateStatus: "clean",
+				ReviewDecision:   "approved",
+				RequiredChecks:   []MergeReadinessCheck{{Name: "build", State: "success"}},
+			},
+		},
+		{
+			name: "required checks still running",
+			pr: pullRequest(map[string]any{"reviewDecision": nil, "mergeStateStatus": "BLOCKED"},
+				checkRun("build", "IN_PROGRESS", nil, true),
+				statusContext("ci/legacy", "PENDING", true),
+			),
+			expected: MergeReadiness{
+				Verdict:          "pending",
+				BlockingReasons:  []string{},
+				Mergeable:        "mergeable",
+				MergeStateStatus: "blocked",
+				RequiredChecks: []MergeReadinessCheck{
+					{Name: "build", State: "pending"},
+					{Name: "ci/legacy", State: "pending"},
+				},
+			},
+		},
+		{
+			name: "blocked by conflicts, reviews and checks",
+			pr: pullRequest(map[string]any{
+				"isDraft":          true,
+				"mergeable":        "CONFLICTING",
+				"mergeStateStatus": "DIRTY",
+				"reviewDecision":   "REVIEW_REQUIRED",
+				"reviewRequests":   map[string]any{"totalCount": 2},
+			},
+				checkRun("build", "COMPLETED", "TIMED_OUT", true),
+			),
+			expected: MergeReadiness{
+				Verdict: "blocked",
+				BlockingReasons: []string{
+					"the pull request is a draft and must be marked ready for review",
+					"the pull request has merge conflicts with main that must be resolved",
+					"an approving review is required; 2 review request(s) are outstanding",
+					`required check "build" failed`,
+				},
+				Mergeable:        "conflicting",
+				MergeStateStatus: "dirty",
+				ReviewDecision:   "review_required",
+				RequiredChecks:   []MergeReadinessCheck{{Name: "build", State: "failure"}},
+			},
+		},
+		{
+			name: "blocked without a detailed reason",
+			pr:   pullRequest(map[string]any{"mergeStateStatus": "BLOCKED"}),
+			expected: MergeReadiness{
+				Verdict:          "blocked",
+				BlockingReasons:  []string{"merging is blocked by branch protection or rulesets, for example by required checks that have not reported or unresolved conversations"},
+				Mergeable:        "mergeable",
+				MergeStateStatus: "blocked",
+				ReviewDecision:   "approved",
+				RequiredChecks:   []MergeReadinessCheck{},
+			},
+		},
+		{
+			name: "behind the base branch",
+			pr:   pullRequest(map[string]any{"mergeStateStatus": "BEHIND"}),
+			expected: MergeReadiness{
+				Verdict:          "blocked",
+				BlockingReasons:  []string{"the head branch is behind main and must be updated"},
+				Mergeable:        "mergeable",
+				MergeStateStatus: "behind",
+				ReviewDecision:   "approved",
+				RequiredChecks:   []MergeReadinessCheck{},
+			},
+		},
+		{
+			name: "merge queue required",
+			pr:   pullRequest(map[string]any{"isMergeQueueEnabled": true}),
+			expected: MergeReadiness{
+				Verdict:          "ready",
+				BlockingReasons:  []string{},
+				Warnings:         []string{"main requires merging through its merge queue; enable auto-merge with enable_pull_request_auto_merge or add the pull request to the queue with merge_queue_write instead of merging it directly"},
+				Mergeable:        "mergeable",
+				MergeStateStatus: "clean",
+				ReviewDecision:   "approved",
+				RequiredChecks:   []MergeReadinessCheck{},
+				MergeQueue:       &MergeReadinessQueue{},
+			},
+		},
+		{
+			name: "in the merge queue",
+			pr: pullRequest(map[string]any{
+				"isMergeQueueEnabled": true,
+				"isInMergeQueue":      true,
+				"mergeQueueEntry":     map[string]any{"position": 3, "state": "AWAITING_CHECKS"},
+			}),
+			expected: MergeReadiness{
+				Verdict:          "queued",
+				BlockingReasons:  []string{},
+				Mergeable:        "mergeable",
+				MergeStateStatus: "clean",
+				ReviewDecision:   "approved",
+				RequiredChecks:   []MergeReadinessCheck{},
+				MergeQueue:       &MergeReadinessQueue{Queued: true, Position: 3, State: "awaiting_checks"},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockedClient := githubv4mock.NewMockedHTTPClient(
+				githubv4mock.NewQueryMatcher(
+					mergeReadinessQuery{},
+					map[string]any{
+						"owner":      githubv4.String("owner"),
+						"repo":       githubv4.String("repo"),
+						"pullNumber": githubv4.Int(42),
+					},
+					githubv4mock.DataResponse(map[string]any{
+						"repository": map[string]any{"pullRequest": tc.pr},
+					}),
+				),
+			)
+			deps := BaseDeps{GQLClient: githubv4.NewClient(mockedClient)}
+			request := createMCPRequest(map[string]any{
+				"owner":      "owner",
+				"repo":       "repo",
+				"pullNumber": float64(42),
+			})
+
+			result, err := serverTool.Handler(deps)(ContextWithDeps(context.Background(), deps), &request)
+			require.NoError(t, err)
+			require.False(t, result.IsError)
+
+			var readiness MergeReadiness
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &readiness))
+			tc.expected.PullNumber = 42
+			tc.expected.URL = "https://github.com/owner/repo/pull/42"
+			tc.expected.BaseBranch = "main"
+			assert.Equal(t, tc.expected, readiness)
+		})
+	}
+}
@@ -0,0 +1,344 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/inventory"
+	"github.com/github/github-mcp-server/pkg/scopes"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/github/github-mcp-server/pkg/utils"
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/shurcooL/githubv4"
+)
+
+// CLA statuses of a pull request.
+const (
+	claSigned    = "signed"
+	claNotSigned = "not_signed"
+	claPending   = "pending"
+	claNotFound  = "not_found"
+)
+
+// Actions suggested to welcome a contributor.
+const (
+	onboardingActionComment    = "comment"
+	onboardingActionAddLabel   = "add_label"
+	onboardingActionWaitForCLA = "wait_for_cla"
+)
+
+// claPattern matches the names of CLA checks and labels, such as "license/cla", "cla/google",
+// "EasyCLA" and "cla: yes".
+var claPattern = regexp.MustCompile(`(?i)(^|[^a-z])cla([^a-z]|$)|easycla|contributor license`)
+
+// claSignedWords and claNotSignedWords are the words of CLA labels telling whether the CLA is
+// signed, such as "cla: yes" and "cla: no".
+var (
+	claSignedWords    = []string{"yes", "signed", "ok"}
+	claNotSignedWords = []string{"no", "unsigned", "missing", "required", "needed"}
+)
+
+// newcomerLabelParts are the parts of the names of labels marking the contributions of newcomers,
+// after lowercasing and replacing dashes and underscores by spaces.
+var newcomerLabelParts = []string{"first time", "first timer", "first contribution", "new contributor", "newcomer"}
+
+// onboardingCheckNode is a check run or commit status of the head commit, with its link.
+type onboardingCheckNode struct {
+	Typename githubv4.String `graphql:"__typename"`
+	CheckRun struct {
+		Name       githubv4.String
+		Status     githubv4.String
+		Conclusion githubv4.String
+		DetailsURL githubv4.String `graphql:"detailsUrl"`
+	} `graphql:"... on CheckRun"`
+	StatusContext struct {
+		Context   githubv4.String
+		State     githubv4.String
+		TargetURL githubv4.String `graphql:"targetUrl"`
+	} `graphql:"... on StatusContext"`
+}
+
+type contributorOnboardingQuery struct {
+	Repository struct {
+		Labels struct {
+			Nodes []struct {
+				Name githubv4.String
+			}
+		} `graphql:"labels(first: 100)"`
+		PullRequest struct {
+			Number            githubv4.Int
+			URL               githubv4.String
+			State             githubv4.String
+			AuthorAssociation githubv4.String
+			Author            *struct {
+				Typename githubv4.String `graphql:"__typename"`
+				Login    githubv4.String
+			}
+			Labels struct {
+				Nodes []struct {
+					Name githubv4.String
+				}
+			} `graphql:"labels(first: 100)"`
+			Commits struct {
+				Nodes []struct {
+					Commit struct {
+						StatusCheckRollup *struct {
+							Contexts struct {
+								Nodes []onboardingCheckNode
+							} `graphql:"contexts(first: 100)"`
+						}
+					}
+				}
+			} `graphql:"commits(last: 1)"`
+		} `graphql:"pullRequest(number: $pullNumber)"`
+	} `graphql:"repository(owner: $owner, name: $repo)"`
+}
+
+// ContributorCLAStatus is the state of the CLA check of a pull request.
+type ContributorCLAStatus struct {
+	Status string `json:"status"`
+	Source string `json:"source,omitempty"`
+	URL    string `json:"url,omitempty"`
+}
+
+// OnboardingAction is an action suggested to a maintainer welcoming a contributor.
+type OnboardingAction struct {
+	Action  string `json:"action"`
+	Reason  string `json:"reason"`
+	Label   string `json:"label,omitempty"`
+	Comment string `json:"comment,omitempty"`
+}
+
+// ContributorOnboardingStatus describes the author of a pull request as a contributor to the
+// repository.
+type ContributorOnboardingStatus struct {
+	PullNumber                int                  `json:"pull_number"`
+	URL                       string               `json:"url"`
+	Author                    string               `json:"author"`
+	IsBot                     bool                 `json:"is_bot,omitempty"`
+	AuthorAssociation         string               `json:"author_association"`
+	FirstContribution         bool                 `json:"first_contribution"`
+	FirstContributionOnGitHub bool                 `json:"first_contribution_on_github,omitempty"`
+	CLA                       ContributorCLAStatus `json:"cla"`
+	SuggestedActions          []OnboardingAction   `json:"suggested_actions"`
+}
+
+// claStatusRank orders CLA statuses, so that the least favorable of several CLA checks wins.
+var claStatusRank = map[string]int{claNotFound: 0, claSigned: 1, claPending: 2, claNotSigned: 3}
+
+// evaluateCLA finds the CLA status of a pull request in the checks of its head commit, or else in
+// its labels, as applied by CLA bots such as "cla: yes".
+func evaluateCLA(query *contributorOnboardingQuery) ContributorCLAStatus {
+	status := ContributorCLAStatus{Status: claNotFound}
+	pr := query.Repository.PullRequest
+	if nodes := pr.Commits.Nodes; len(nodes) > 0 && nodes[0].Commit.StatusCheckRollup != nil {
+		for _, node := range nodes[0].Commit.StatusCheckRollup.Contexts.Nodes {
+			var check mergeReadinessCheckNode
+			check.Typename = node.Typename
+			check.CheckRun.Name = node.CheckRun.Name
+			check.CheckRun.Status = node.CheckRun.Status
+			check.CheckRun.Conclusion = node.CheckRun.Conclusion
+			check.StatusContext.Context = node.StatusContext.Context
+			check.StatusContext.State = node.StatusContext.State
+			name, state := checkNodeState(check)
+			if !claPattern.MatchString(name) {
+				continue
+			}
+			found := ContributorCLAStatus{Source: name, URL: string(node.CheckRun.DetailsURL)}
+			if node.Typename == "StatusContext" {
+				found.URL = string(node.StatusContext.TargetURL)
+			}
+			switch state {
+			case "success", "neutral", "skipped":
+				found.Status = claSigned
+			case "pending":
+				found.Status = claPending
+			default:
+				found.Status = claNotSigned
+			}
+			if claStatusRank[found.Status] > claStatusRank[status.Status] {
+				status = found
+			}
+		}
+	}
+	if status.Status != claNotFound {
+		return status
+	}
+
+	for _, label := range pr.Labels.Nodes {
+		name := strings.ToLower(string(label.Name))
+		if !claPattern.MatchString(name) {
+			continue
+		}
+		words := strings.FieldsFunc(name, func(r rune) bool { return r < 'a' || r > 'z' })
+		switch {
+		case slices.ContainsFunc(words, func(w string) bool { return slices.Contains(claNotSignedWords, w) }):
+			return ContributorCLAStatus{Status: claNotSigned, Source: "label: " + string(label.Name)}
+		case slices.ContainsFunc(words, func(w string) bool { return slices.Contains(claSignedWords, w) }):
+			return ContributorCLAStatus{Status: claSigned, Source: "label: " + string(label.Name)}
+		}
+	}
+	return status
+}
+
+// isNewcomerLabel reports whether a label marks the contributions of newcomers.
+func isNewcomerLabel(name string) bool {
+	name = strings.ToLower(strings.NewReplacer("-", " ", "_", " ").Replace(name))
+	for _, part := range newcomerLabelParts {
+		if strings.Contains(name, part) {
+			return true
+		}
+	}
+	return false
+}
+
+// evaluateContributorOnboarding describes the author of a pull request and suggests how to
+// welcome them. Only labels that exist in the repository are suggested.
+func evaluateContributorOnboarding(owner, repo string, query *contributorOnboardingQuery) ContributorOnboardingStatus {
+	pr := query.Repository.PullRequest
+	result := ContributorOnboardingStatus{
+		PullNumber:                int(pr.Number),
+		URL:                       string(pr.URL),
+		AuthorAssociation:         strings.ToLower(string(pr.AuthorAssociation)),
+		FirstContribution:         pr.AuthorAssociation == "FIRST_TIME_CONTRIBUTOR" || pr.AuthorAssociation == "FIRST_TIMER",
+		FirstContributionOnGitHub: pr.AuthorAssociation == "FIRST_TIMER",
+		CLA:                       evaluateCLA(query),
+		SuggestedActions:          []OnboardingAction{},
+	}
+	if pr.Author != nil {
+		result.Author = string(pr.Author.Login)
+		result.IsBot = pr.Author.Typename == "Bot"
+	}
+	if pr.State != "OPEN" || result.IsBot || result.Author == "" {
+		return result
+	}
+
+	var claRequest string
+	switch result.CLA.Status {
+	case claNotSigned:
+		claRequest = "Before we can review your changes, please sign our Contributor License Agreement"
+		if result.CLA.URL != "" {
+			claRequest += ": " + result.CLA.URL
+		} else {
+			claRequest += "."
+		}
+	case claPending:
+		result.SuggestedActions = append(result.SuggestedActions, OnboardingAction{
+			Action: onboardingActionWaitForCLA,
+			Reason: fmt.Sprintf("the %s check has not finished; review once it confirms the CLA is signed", result.CLA.Source),
+		})
+	}
+
+	switch {
+	case result.FirstContribution:
+		where := fmt.Sprintf("to %s/%s", owner, repo)
+		if result.FirstContributionOnGitHub {
+			where = "on GitHub"
+		}
+		comment := fmt.Sprintf("Welcome @%s, and thank you for your first contribution %s! A maintainer will review your pull request soon.", result.Author, where)
+		if claRequest != "" {
+			comment += " " + claRequest
+		}
+		result.SuggestedActions = append(result.SuggestedActions, OnboardingAction{
+			Action:  onboardingActionComment,
+			Reason:  "this is the author's first contribution " + where,
+			Comment: comment,
+		})
+
+		applied := false
+		for _, label := range pr.Labels.Nodes {
+			applied = applied || isNewcomerLabel(string(label.Name))
+		}
+		if !applied {
+			for _, label := range query.Repository.Labels.Nodes {
+				if isNewcomerLabel(string(label.Name)) {
+					result.SuggestedActions = append(result.SuggestedActions, OnboardingAction{
+						Action: onboardingActionAddLabel,
+						Reason: "the repository labels the contributions of newcomers",
+						Label:  string(label.Name),
+					})
+					break
+				}
+			}
+		}
+	case claRequest != "":
+		result.SuggestedActions = append(result.SuggestedActions, OnboardingAction{
+			Action:  onboardingActionComment,
+			Reason:  "the author has not signed the CLA",
+			Comment: fmt.Sprintf("Thank you for your pull request, @%s! %s", result.Author, claRequest),
+		})
+	}
+	return result
+}
+
+// GetContributorOnboardingStatus creates a tool that reports whether the author of a pull request
+// signed the CLA and is a first-time contributor, and suggests how to welcome them.
+func GetContributorOnboardingStatus(t translations.TranslationHelperFunc) inventory.ServerTool {
+	return NewTool(
+		ToolsetMetadataPullRequests,
+		mcp.Tool{
+			Name: "get_contributor_onboarding_status",
+			Description: t("TOOL_GET_CONTRIBUTOR_ONBOARDING_STATUS_DESCRIPTION", `Get the onboarding status of the author of a pull request: whether they signed the Contributor License Agreement (CLA), whether this is their first contribution to the repository, and suggested actions to welcome them.
+The CLA status is found in checks or commit statuses named like a CLA check, such as "license/cla", or else in labels such as "cla: yes"; it is not_found when the repository has neither. Suggested actions are comments to post and existing repository labels to add; nothing is suggested for bots or pull requests that are not open.`),
+			Annotations: &mcp.ToolAnnotations{
+				Title:        t("TOOL_GET_CONTRIBUTOR_ONBOARDING_STATUS_USER_TITLE", "Get contributor onboarding status"),
+				ReadOnlyHint: true,
+			},
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"owner": {
+						Type:        "string",
+						Description: "Repository owner",
+					},
+					"repo": {
+						Type:        "string",
+						Description: "Repository name",
+					},
+					"pullNumber": {
+						Type:        "number",
+						Description: "Pull request number",
+					},
+				},
+				Required: []string{"owner", "repo", "pullNumber"},
+			},
+		},
+		[]scopes.Scope{scopes.Repo},
+		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+			owner, err := RequiredParam[string](args, "owner")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			repo, err := RequiredParam[string](args, "repo")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			pullNumber, err := RequiredInt(args, "pullNumber")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+
+			client, err := deps.GetGQLClient(ctx)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to get GitHub GQL client: %w", err)
+			}
+
+			var query contributorOnboardingQuery
+			vars := map[string]any{
+				"owner":      githubv4.String(owner),
+				"repo":       githubv4.String(repo),
+				"pullNumber": githubv4.Int(int32(pullNumber)), // #nosec G115 - pull request numbers are always small positive integers
+			}
+			if err := client.Query(ctx, &query, vars); err != nil {
+				return ghErrors.NewGitHubGraphQLErrorResponse(ctx, "failed to get contributor onboarding status", err), nil, nil
+			}
+
+			return MarshalledTextResult(evaluateContributorOnboarding(owner, repo, &query)), nil, nil
+		},
+	)
+}
@@ -0,0 +1,172 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/githubv4mock"
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/shurcooL/githubv4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_GetContributorOnboardingStatus(t *testing.T) {
+	serverTool := GetContributorOnboardingStatus(translations.NullTranslationHelper)
+	tool := serverTool.Tool
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_contributor_onboarding_status", tool.Name)
+	assert.True(t, tool.Annotations.ReadOnlyHint)
+	schema := tool.InputSchema.(*jsonschema.Schema)
+	assert.ElementsMatch(t, schema.Required, []string{"owner", "repo", "pullNumber"})
+
+	labels := func(names ...string) map[string]any {
+		nodes := []map[string]any{}
+		for _, name := range names {
+			nodes = append(nodes, map[string]any{"name": name})
+		}
+		return map[string]any{"nodes": nodes}
+	}
+	checkRun := func(name, status string, conclusion any) map[string]any {
+		return map[string]any{"__typename": "CheckRun", "name": name, "status": status, "conclusion": conclusion, "detailsUrl": "https://cla.example.com/check"}
+	}
+	statusContext := func(context, state string) map[string]any {
+		return map[string]any{"__typename": "StatusContext", "context": context, "state": state, "targetUrl": "https://cla-assistant.io/owner/repo?pullRequest=42"}
+	}
+	pullRequest := func(association string, prLabels map[string]any, checks ...map[string]any) map[string]any {
+		return map[string]any{
+			"number":            42,
+			"url":               "https://github.com/owner/repo/pull/42",
+			"state":             "OPEN",
+			"authorAssociation": association,
+			"author":            map[string]any{"__typename": "User", "login": "newbie"},
+			"labels":            prLabels,
+			"commits": map[string]any{
+				"nodes": []map[string]any{
+					{"commit": map[string]any{"statusCheckRollup": map[string]any{
+						"contexts": map[string]any{"nodes": checks},
+					}}},
+				},
+			},
+		}
+	}
+
+	tests := []struct {
+		name     string
+		pr       map[string]any
+		expected ContributorOnboardingStatus
+	}{
+		{
+			name: "first-time contributor without CLA",
+			pr: pullRequest("FIRST_TIME_CONTRIBUTOR", labels(),
+				checkRun("build", "COMPLETED", "SUCCESS"),
+				statusContext("license/cla", "FAILURE"),
+			),
+			expected: ContributorOnboardingStatus{
+				AuthorAssociation: "first_time_contributor",
+				FirstContribution: true,
+				CLA:               ContributorCLAStatus{Status: "not_signed", Source: "license/cla", URL: "https://cla-assistant.io/owner/repo?pullRequest=42"},
+				SuggestedActions: []OnboardingAction{
+					{
+						Action:  "comment",
+						Reason:  "this is the author's first contribution to owner/repo",
+						Comment: "Welcome @newbie, and thank you for your first contribution to owner/repo! A maintainer will review your pull request soon. Before we can review your changes, please sign our Contributor License Agreement: https://cla-assistant.io/owner/repo?pullRequest=42",
+					},
+					{Action: "add_label", Reason: "the repository labels the contributions of newcomers", Label: "first-time-contributor"},
+				},
+			},
+		},
+		{
+			name: "first contribution on GitHub with pending CLA and newcomer label applied",
+			pr: pullRequest("FIRST_TIMER", labels("First Time Contributor"),
+				checkRun("EasyCLA", "IN_PROGRESS", nil),
+			),
+			expected: ContributorOnboardingStatus{
+				AuthorAssociation:         "first_timer",
+				FirstContribution:         true,
+				FirstContributionOnGitHub: true,
+				CLA:                       ContributorCLAStatus{Status: "pending", Source: "EasyCLA", URL: "https://cla.example.com/check"},
+				SuggestedActions: []OnboardingAction{
+					{Action: "wait_for_cla", Reason: "the EasyCLA check has not finished; review once it confirms the CLA is signed"},
+					{
+						Action:  "comment",
+						Reason:  "this is the author's first contribution on GitHub",
+						Comment: "Welcome @newbie, and thank you for your first contribution on GitHub! A maintainer will review your pull request soon.",
+					},
+				},
+			},
+		},
+		{
+			name: "returning contributor with CLA label",
+			pr:   pullRequest("CONTRIBUTOR", labels("cla: yes")),
+			expected: ContributorOnboardingStatus{
+				AuthorAssociation: "contributor",
+				CLA:               ContributorCLAStatus{Status: "signed", Source: "label: cla: yes"},
+				SuggestedActions:  []OnboardingAction{},
+			},
+		},
+		{
+			name: "returning contributor without CLA",
+			pr:   pullRequest("CONTRIBUTOR", labels("cla: no")),
+			expected: ContributorOnboardingStatus{
+				AuthorAssociation: "contributor",
+				CLA:               ContributorCLAStatus{Status: "not_signed", Source: "label: cla: no"},
+				SuggestedActions: []OnboardingAction{
+					{
+						Action:  "comment",
+						Reason:  "the author has not signed the CLA",
+						Comment: "Thank you for your pull request, @newbie! Before we can review your changes, please sign our Contributor License Agreement.",
+					},
+				},
+			},
+		},
+		{
+			name: "repository without CLA",
+			pr:   pullRequest("MEMBER", labels("enhancement"), checkRun("build", "COMPLETED", "SUCCESS")),
+			expected: ContributorOnboardingStatus{
+				AuthorAssociation: "member",
+				CLA:               ContributorCLAStatus{Status: "not_found"},
+				SuggestedActions:  []OnboardingAction{},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockedClient := githubv4mock.NewMockedHTTPClient(
+				githubv4mock.NewQueryMatcher(
+					contributorOnboardingQuery{},
+					map[string]any{
+						"owner":      githubv4.String("owner"),
+						"repo":       githubv4.String("repo"),
+						"pullNumber": githubv4.Int(42),
+					},
+					githubv4mock.DataResponse(map[string]any{
+						"repository": map[string]any{
+							"labels":      labels("bug", "first-time-contributor"),
+							"pullRequest": tc.pr,
+						},
+					}),
+				),
+			)
+			deps := BaseDeps{GQLClient: githubv4.NewClient(mockedClient)}
+			handler := serverTool.Handler(deps)
+
+			request := createMCPRequest(map[string]any{"owner": "owner", "repo": "repo", "pullNumber": float64(42)})
+			result, err := handler(ContextWithDeps(context.Background(), deps), &request)
+			require.NoError(t, err)
+			require.False(t, result.IsError, getTextResult(t, result).Text)
+
+			var status ContributorOnboardingStatus
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &status))
+			tc.expected.PullNumber = 42
+			tc.expected.URL = "https://github.com/owner/repo/pull/42"
+			tc.expected.Author = "newbie"
+			assert.Equal(t, tc.expected, status)
+		})
+	}
+}
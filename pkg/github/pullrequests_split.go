@@ -0,0 +1,437 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"path"
+	"slices"
+	"sort"
+	"strings"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/inventory"
+	"github.com/github/github-mcp-server/pkg/scopes"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/github/github-mcp-server/pkg/utils"
+	"github.com/google/go-github/v82/github"
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+const (
+	// splitPageSize is the number of files and commits fetched per request. The API lists at
+	// most 3000 files and 250 commits of a pull request.
+	splitPageSize = 100
+	// splitRootGroup names the group of files at the root of the repository.
+	splitRootGroup = "(root)"
+)
+
+// codeownersPaths are the locations GitHub reads CODEOWNERS from, in order of precedence.
+var codeownersPaths = []string{".github/CODEOWNERS", "CODEOWNERS", "docs/CODEOWNERS"}
+
+// codeownersRule is a line of a CODEOWNERS file.
+type codeownersRule struct {
+	pattern string
+	owners  []string
+}
+
+// parseCodeowners parses the rules of a CODEOWNERS file, skipping comments and blank lines.
+func parseCodeowners(content string) []codeownersRule {
+	var rules []codeownersRule
+	for _, line := range strings.Split(content, "\n") {
+		if i := strings.Index(line, "#"); i >= 0 {
+			line = line[:i]
+		}
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		rules = append(rules, codeownersRule{pattern: fields[0], owners: fields[1:]})
+	}
+	return rules
+}
+
+// matches reports whether a CODEOWNERS pattern, which follows gitignore rules, matches a file.
+func (r codeownersRule) matches(filePath string) bool {
+	pattern := r.pattern
+	anchored := strings.HasPrefix(pattern, "/") || strings.Contains(strings.TrimSuffix(pattern, "/"), "/")
+	pattern = strings.TrimPrefix(pattern, "/")
+	if strings.HasSuffix(pattern, "/") {
+		pattern += "**"
+	}
+	if !anchored {
+		pattern = "**/" + pattern
+	}
+	return matchRulesetGlob(pattern, filePath) || matchRulesetGlob(pattern+"/**", filePath)
+}
+
+// codeownersFor returns the owners of a file. The last matching rule wins, and a matching rule
+// without owners leaves the file unowned.
+func codeownersFor(rules []codeownersRule, filePath string) []string {
+	for i := len(rules) - 1; i >= 0; i-- {
+		if rules[i].matches(filePath) {
+			return rules[i].owners
+		}
+	}
+	return nil
+}
+
+// splitDirectoryGroup returns the directory of a file, cut to the given depth.
+func splitDirectoryGroup(filePath string, depth int) string {
+	dir := path.Dir(filePath)
+	if dir == "." {
+		return splitRootGroup
+	}
+	parts := strings.Split(dir, "/")
+	if len(parts) > depth {
+		parts = parts[:depth]
+	}
+	return strings.Join(parts, "/")
+}
+
+// PullRequestSplitGroup is a proposed smaller pull request.
+type PullRequestSplitGroup struct {
+	Name       string   `json:"name"`
+	Owners     []string `json:"owners,omitempty"`
+	Files      []string `json:"files"`
+	Additions  int      `json:"additions"`
+	Deletions  int      `json:"deletions"`
+	Branch     string   `json:"branch,omitempty"`
+	Commits    int      `json:"commits,omitempty"`
+	CompareURL string   `json:"compare_url,omitempty"`
+}
+
+// PullRequestSplit is a proposal to split a pull request into smaller ones.
+type PullRequestSplit struct {
+	PullNumber int                     `json:"pull_number"`
+	GroupBy    string                  `json:"group_by"`
+	TotalFiles int                     `json:"total_files"`
+	Groups     []PullRequestSplitGroup `json:"groups"`
+	Notes      []string                `json:"notes,omitempty"`
+}
+
+// proposePullRequestSplit groups the files of a pull request by directory, or by owners when
+// CODEOWNERS rules are given. Files without owners are grouped by directory.
+func proposePullRequestSplit(files []*github.CommitFile, depth int, codeowners []codeownersRule) []PullRequestSplitGroup {
+	index := map[string]int{}
+	var groups []PullRequestSplitGroup
+	for _, f := range files {
+		name := splitDirectoryGroup(f.GetFilename(), depth)
+		var owners []string
+		if codeowners != nil {
+			if owners = codeownersFor(codeowners, f.GetFilename()); len(owners) > 0 {
+				owners = slices.Clone(owners)
+				sort.Strings(owners)
+				name = strings.Join(owners, " ")
+			}
+		}
+		i, ok := index[name]
+		if !ok {
+			i = len(groups)
+			index[name] = i
+			groups = append(groups, PullRequestSplitGroup{Name: name, Owners: owners})
+		}
+		groups[i].Files = append(groups[i].Files, f.GetFilename())
+		groups[i].Additions += f.GetAdditions()
+		groups[i].Deletions += f.GetDeletions()
+	}
+	sort.Slice(groups, func(i, j int) bool { return groups[i].Name < groups[j].Name })
+	return groups
+}
+
+// fetchCodeowners returns the CODEOWNERS rules of a repository at a ref, or nil if it has none.
+func fetchCodeowners(ctx context.Context, client *github.Client, owner, repo, ref string) ([]codeownersRule, error) {
+	for _, p := range codeownersPaths {
+		file, _, resp, err := client.Repositories.GetContents(ctx, owner, repo, p, &github.RepositoryContentGetOptions{Ref: ref})
+		if resp != nil {
+			_ = resp.Body.Close()
+		}
+		if err != nil {
+			if resp != nil && resp.StatusCode == http.StatusNotFound {
+				continue
+			}
+			return nil, fmt.Errorf("failed to read %s: %w", p, err)
+		}
+		if file == nil {
+			continue
+		}
+		content, err := file.GetContent()
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode %s: %w", p, err)
+		}
+		return parseCodeowners(content), nil
+	}
+	return nil, nil
+}
+
+// replaySplitGroup recreates the commits of a pull request on top of base, keeping only their
+// changes to the files of a group, and returns the last commit and the number of commits made.
+// Merge commits are skipped, as their changes come from the base branch. On failure, the response
+// of the failed request is returned with the error.
+func replaySplitGroup(ctx context.Context, client *github.Client, owner, repo string, base *github.Commit, commits []*github.RepositoryCommit, inGroup map[string]bool, modes map[string]string) (*github.Commit, int, *github.Response, error) {
+	parent := base
+	count := 0
+	for _, c := range commits {
+		if len(c.Parents) > 1 {
+			continue
+		}
+		var entries []*github.TreeEntry
+		for _, f := range c.Files {
+			name, previous := f.GetFilename(), f.GetPreviousFilename()
+			if previous != "" && inGroup[previous] {
+				entries = append(entries, &github.TreeEntry{Path: github.Ptr(previous), Mode: github.Ptr("100644"), Type: github.Ptr("blob")})
+			}
+			if !inGroup[name] {
+				continue
+			}
+			entry := &github.TreeEntry{Path: github.Ptr(name), Mode: github.Ptr("100644"), Type: github.Ptr("blob")}
+			if mode, ok := modes[name]; ok {
+				entry.Mode = github.Ptr(mode)
+			}
+			if f.GetStatus() != "removed" {
+				entry.SHA = f.SHA
+			}
+			entries = append(entries, entry)
+		}
+		if len(entries) == 0 {
+			continue
+		}
+
+		tree, resp, err := client.Git.CreateTree(ctx, owner, repo, parent.GetTree().GetSHA(), entries)
+		if err != nil {
+			return nil, 0, resp, fmt.Errorf("failed to create tree: %w", err)
+		}
+		_ = resp.Body.Close()
+		commit, resp, err := client.Git.CreateCommit(ctx, owner, repo, github.Commit{
+			Message: c.GetCommit().Message,
+			Author:  c.GetCommit().Author,
+			Tree:    tree,
+			Parents: []*github.Commit{{SHA: parent.SHA}},
+		}, nil)
+		if err != nil {
+			return nil, 0, resp, fmt.Errorf("failed to create commit: %w", err)
+		}
+		_ = resp.Body.Close()
+		parent = commit
+		count++
+	}
+	return parent, count, nil, nil
+}
+
+// SplitPullRequest creates a tool that proposes how to split a large pull request into smaller
+// ones and can create a branch for each of them.
+func SplitPullRequest(t translations.TranslationHelperFunc) inventory.ServerTool {
+	return NewTool(
+		ToolsetMetadataPullRequests,
+		mcp.Tool{
+			Name: "split_pull_request",
+			Description: t("TOOL_SPLIT_PULL_REQUEST_DESCRIPTION", `Propose how to split a large pull request into smaller ones that are easier to review, grouping its changed files by directory or by code owners.
+With create_branches, a branch is created from the merge base for each group, replaying the commits of the pull request restricted to the files of the group. Open a pull request for each branch with 'create_pull_request'; the original pull request is left unchanged.`),
+			Annotations: &mcp.ToolAnnotations{
+				Title:        t("TOOL_SPLIT_PULL_REQUEST_USER_TITLE", "Split pull request"),
+				ReadOnlyHint: false,
+			},
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"owner": {
+						Type:        "string",
+						Description: "Repository owner",
+					},
+					"repo": {
+						Type:        "string",
+						Description: "Repository name",
+					},
+					"pullNumber": {
+						Type:        "number",
+						Description: "Pull request number",
+					},
+					"group_by": {
+						Type:        "string",
+						Description: "Group files by directory, or by their owners in CODEOWNERS. Files without owners are grouped by directory.",
+						Enum:        []any{"directory", "owners"},
+						Default:     json.RawMessage(`"directory"`),
+					},
+					"depth": {
+						Type:        "number",
+						Description: "Number of directory levels files are grouped by",
+						Minimum:     jsonschema.Ptr(1.0),
+						Default:     json.RawMessage(`1`),
+					},
+					"create_branches": {
+						Type:        "boolean",
+						Description: "Create a branch for each group instead of only proposing the split",
+						Default:     json.RawMessage(`false`),
+					},
+				},
+				Required: []string{"owner", "repo", "pullNumber"},
+			},
+		},
+		[]scopes.Scope{scopes.Repo},
+		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+			owner, err := RequiredParam[string](args, "owner")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			repo, err := RequiredParam[string](args, "repo")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			pullNumber, err := RequiredInt(args, "pullNumber")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			groupBy, err := OptionalParam[string](args, "group_by")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			if groupBy == "" {
+				groupBy = "directory"
+			}
+			if groupBy != "directory" && groupBy != "owners" {
+				return utils.NewToolResultError("group_by must be one of: directory, owners"), nil, nil
+			}
+			depth, err := OptionalIntParamWithDefault(args, "depth", 1)
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			if depth < 1 {
+				return utils.NewToolResultError("depth must be at least 1"), nil, nil
+			}
+			createBranches, err := OptionalBoolParamWithDefault(args, "create_branches", false)
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+
+			client, err := deps.GetClient(ctx)
+			if err != nil {
+				return utils.NewToolResultErrorFromErr("failed to get GitHub client", err), nil, nil
+			}
+
+			pr, resp, err := client.PullRequests.Get(ctx, owner, repo, pullNumber)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to get pull request", resp, err), nil, nil
+			}
+			_ = resp.Body.Close()
+
+			var files []*github.CommitFile
+			opts := &github.ListOptions{PerPage: splitPageSize}
+			for {
+				page, resp, err := client.PullRequests.ListFiles(ctx, owner, repo, pullNumber, opts)
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to get pull request files", resp, err), nil, nil
+				}
+				_ = resp.Body.Close()
+				files = append(files, page...)
+				if resp.NextPage == 0 {
+					break
+				}
+				opts.Page = resp.NextPage
+			}
+
+			result := PullRequestSplit{
+				PullNumber: pullNumber,
+				GroupBy:    groupBy,
+				TotalFiles: len(files),
+			}
+			if len(files) < pr.GetChangedFiles() {
+				result.Notes = append(result.Notes, fmt.Sprintf("only the first %d of %d changed files can be listed through the API", len(files), pr.GetChangedFiles()))
+			}
+
+			var codeowners []codeownersRule
+			if groupBy == "owners" {
+				codeowners, err = fetchCodeowners(ctx, client, owner, repo, pr.GetBase().GetRef())
+				if err != nil {
+					return utils.NewToolResultErrorFromErr("failed to get CODEOWNERS", err), nil, nil
+				}
+				if codeowners == nil {
+					result.Notes = append(result.Notes, "the repository has no CODEOWNERS file, so files are grouped by directory")
+					codeowners = []codeownersRule{}
+				}
+			}
+			result.Groups = proposePullRequestSplit(files, depth, codeowners)
+
+			if len(result.Groups) < 2 {
+				result.Notes = append(result.Notes, "all changed files fall in a single group; try a greater depth or another group_by")
+				return MarshalledTextResult(result), nil, nil
+			}
+			if !createBranches {
+				return MarshalledTextResult(result), nil, nil
+			}
+
+			comparison, resp, err := client.Repositories.CompareCommits(ctx, owner, repo, pr.GetBase().GetSHA(), pr.GetHead().GetSHA(), &github.ListOptions{PerPage: 1})
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to get the merge base", resp, err), nil, nil
+			}
+			_ = resp.Body.Close()
+			mergeBase := comparison.GetMergeBaseCommit()
+			base := &github.Commit{SHA: mergeBase.SHA, Tree: mergeBase.GetCommit().Tree}
+
+			headTree, resp, err := client.Git.GetTree(ctx, owner, repo, pr.GetHead().GetSHA(), true)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to get the head tree", resp, err), nil, nil
+			}
+			_ = resp.Body.Close()
+			modes := map[string]string{}
+			for _, entry := range headTree.Entries {
+				modes[entry.GetPath()] = entry.GetMode()
+			}
+
+			var commits []*github.RepositoryCommit
+			opts = &github.ListOptions{PerPage: splitPageSize}
+			for {
+				page, resp, err := client.PullRequests.ListCommits(ctx, owner, repo, pullNumber, opts)
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to get pull request commits", resp, err), nil, nil
+				}
+				_ = resp.Body.Close()
+				commits = append(commits, page...)
+				if resp.NextPage == 0 {
+					break
+				}
+				opts.Page = resp.NextPage
+			}
+			// The commits listed for a pull request do not include their files
+			for i, c := range commits {
+				commit, resp, err := client.Repositories.GetCommit(ctx, owner, repo, c.GetSHA(), &github.ListOptions{PerPage: 300})
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx, fmt.Sprintf("failed to get commit %s", c.GetSHA()), resp, err), nil, nil
+				}
+				_ = resp.Body.Close()
+				commits[i] = commit
+			}
+
+			compareBase := pr.GetBase().GetRepo().GetHTMLURL()
+			for i := range result.Groups {
+				group := &result.Groups[i]
+				inGroup := map[string]bool{}
+				for _, f := range group.Files {
+					inGroup[f] = true
+				}
+				last, count, resp, err := replaySplitGroup(ctx, client, owner, repo, base, commits, inGroup, modes)
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx, fmt.Sprintf("failed to create the commits of group %s", group.Name), resp, err), nil, nil
+				}
+				if count == 0 {
+					result.Notes = append(result.Notes, fmt.Sprintf("group %s only changed in merge commits, so no branch was created for it", group.Name))
+					continue
+				}
+				branch := fmt.Sprintf("%s-split-%d", pr.GetHead().GetRef(), i+1)
+				_, resp, err = client.Git.CreateRef(ctx, owner, repo, github.CreateRef{Ref: "refs/heads/" + branch, SHA: last.GetSHA()})
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx, fmt.Sprintf("failed to create branch %s", branch), resp, err), nil, nil
+				}
+				_ = resp.Body.Close()
+				group.Branch = branch
+				group.Commits = count
+				if compareBase != "" {
+					group.CompareURL = fmt.Sprintf("%s/compare/%s...%s", compareBase, pr.GetBase().GetRef(), branch)
+				}
+			}
+
+			return MarshalledTextResult(result), nil, nil
+		},
+	)
+}
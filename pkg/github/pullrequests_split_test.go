@@ -0,0 +1,253 @@
+package github
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v82/github"
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_CodeownersFor(t *testing.T) {
+	rules := parseCodeowners(`# Default owners
+*       @octo-org/maintainers
+
+/docs/  @octo-org/docs
+*.go    @gophers # Go code
+pkg/github/ @octo-org/api @alice
+/generated/
+`)
+	tests := []struct {
+		path     string
+		expected []string
+	}{
+		{path: "README.md", expected: []string{"@octo-org/maintainers"}},
+		{path: "docs/guide/setup.md", expected: []string{"@octo-org/docs"}},
+		{path: "docs/main.go", expected: []string{"@gophers"}},
+		{path: "pkg/github/tools.go", expected: []string{"@octo-org/api", "@alice"}},
+		{path: "internal/pkg/github/x.txt", expected: []string{"@octo-org/maintainers"}},
+		{path: "generated/api.pb.go", expected: []string{}},
+	}
+	for _, tc := range tests {
+		t.Run(tc.path, func(t *testing.T) {
+			assert.Equal(t, tc.expected, codeownersFor(rules, tc.path))
+		})
+	}
+}
+
+func Test_ProposePullRequestSplit(t *testing.T) {
+	files := []*github.CommitFile{
+		{Filename: github.Ptr("pkg/github/tools.go"), Additions: github.Ptr(10), Deletions: github.Ptr(2)},
+		{Filename: github.Ptr("pkg/errors/error.go"), Additions: github.Ptr(5)},
+		{Filename: github.Ptr("docs/setup.md"), Additions: github.Ptr(3), Deletions: github.Ptr(1)},
+		{Filename: github.Ptr("go.mod"), Additions: github.Ptr(1), Deletions: github.Ptr(1)},
+	}
+
+	assert.Equal(t, []PullRequestSplitGroup{
+		{Name: splitRootGroup, Files: []string{"go.mod"}, Additions: 1, Deletions: 1},
+		{Name: "docs", Files: []string{"docs/setup.md"}, Additions: 3, Deletions: 1},
+		{Name: "pkg", Files: []string{"pkg/github/tools.go", "pkg/errors/error.go"}, Additions: 15, Deletions: 2},
+	}, proposePullRequestSplit(files, 1, nil))
+
+	groups := proposePullRequestSplit(files, 2, nil)
+	require.Len(t, groups, 4)
+	assert.Equal(t, "pkg/errors", groups[2].Name)
+	assert.Equal(t, "pkg/github", groups[3].Name)
+
+	codeowners := parseCodeowners("/pkg/ @bob @alice\n")
+	assert.Equal(t, []PullRequestSplitGroup{
+		{Name: splitRootGroup, Files: []string{"go.mod"}, Additions: 1, Deletions: 1},
+		{Name: "@alice @bob", Owners: []string{"@alice", "@bob"}, Files: []string{"pkg/github/tools.go", "pkg/errors/error.go"}, Additions: 15, Deletions: 2},
+		{Name: "docs", Files: []string{"docs/setup.md"}, Additions: 3, Deletions: 1},
+	}, proposePullRequestSplit(files, 1, codeowners))
+}
+
+func Test_SplitPullRequest(t *testing.T) {
+	serverTool := SplitPullRequest(translations.NullTranslationHelper)
+	tool := serverTool.Tool
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "split_pull_request", tool.Name)
+	assert.False(t, tool.Annotations.ReadOnlyHint)
+	schema := tool.InputSchema.(*jsonschema.Schema)
+	assert.Contains(t, schema.Properties, "group_by")
+	assert.Contains(t, schema.Properties, "create_branches")
+	assert.ElementsMatch(t, schema.Required, []string{"owner", "repo", "pullNumber"})
+
+	pullRequest := &github.PullRequest{
+		Number:       github.Ptr(42),
+		ChangedFiles: github.Ptr(3),
+		Base: &github.PullRequestBranch{
+			Ref:  github.Ptr("main"),
+			SHA:  github.Ptr("base"),
+			Repo: &github.Repository{HTMLURL: github.Ptr("https://github.com/owner/repo")},
+		},
+		Head: &github.PullRequestBranch{Ref: github.Ptr("big-change"), SHA: github.Ptr("head")},
+	}
+	files := []*github.CommitFile{
+		{Filename: github.Ptr("cmd/main.go"), Additions: github.Ptr(4)},
+		{Filename: github.Ptr("pkg/server.go"), Additions: github.Ptr(20), Deletions: github.Ptr(5)},
+		{Filename: github.Ptr("pkg/old.go"), Deletions: github.Ptr(30)},
+	}
+
+	t.Run("proposes a split by owners", func(t *testing.T) {
+		deps := BaseDeps{Client: github.NewClient(MockHTTPClientWithHandlers(map[string]http.HandlerFunc{
+			GetReposPullsByOwnerByRepoByPullNumber:      mockResponse(t, http.StatusOK, pullRequest),
+			GetReposPullsFilesByOwnerByRepoByPullNumber: mockResponse(t, http.StatusOK, files),
+			"GET /repos/owner/repo/contents/.github/CODEOWNERS": mockResponse(t, http.StatusOK, &github.RepositoryContent{
+				Type:     github.Ptr("file"),
+				Encoding: github.Ptr("base64"),
+				Content:  github.Ptr(base64.StdEncoding.EncodeToString([]byte("/cmd/ @cli-team\n"))),
+			}),
+		}))}
+		request := createMCPRequest(map[string]any{
+			"owner":      "owner",
+			"repo":       "repo",
+			"pullNumber": float64(42),
+			"group_by":   "owners",
+		})
+
+		result, err := serverTool.Handler(deps)(ContextWithDeps(context.Background(), deps), &request)
+		require.NoError(t, err)
+		require.False(t, result.IsError)
+
+		var split PullRequestSplit
+		require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &split))
+		assert.Equal(t, PullRequestSplit{
+			PullNumber: 42,
+			GroupBy:    "owners",
+			TotalFiles: 3,
+			Groups: []PullRequestSplitGroup{
+				{Name: "@cli-team", Owners: []string{"@cli-team"}, Files: []string{"cmd/main.go"}, Additions: 4},
+				{Name: "pkg", Files: []string{"pkg/server.go", "pkg/old.go"}, Additions: 20, Deletions: 35},
+			},
+		}, split)
+	})
+
+	t.Run("creates a branch per group", func(t *testing.T) {
+		commit := func(sha, message string, parents int, files ...*github.CommitFile) *github.RepositoryCommit {
+			c := &github.RepositoryCommit{
+				SHA:    github.Ptr(sha),
+				Commit: &github.Commit{Message: github.Ptr(message), Author: &github.CommitAuthor{Name: github.Ptr("Dev"), Email: github.Ptr("dev@example.com")}},
+				Files:  files,
+			}
+			for i := 0; i < parents; i++ {
+				c.Parents = append(c.Parents, &github.Commit{SHA: github.Ptr(fmt.Sprintf("parent%d", i))})
+			}
+			return c
+		}
+		commits := map[string]*github.RepositoryCommit{
+			"c1": commit("c1", "Add server", 1,
+				&github.CommitFile{Filename: github.Ptr("cmd/main.go"), Status: github.Ptr("added"), SHA: github.Ptr("blob-main")},
+				&github.CommitFile{Filename: github.Ptr("pkg/server.go"), Status: github.Ptr("added"), SHA: github.Ptr("blob-server-1")},
+			),
+			"c2": commit("c2", "Merge main", 2,
+				&github.CommitFile{Filename: github.Ptr("README.md"), Status: github.Ptr("modified"), SHA: github.Ptr("blob-readme")},
+			),
+			"c3": commit("c3", "Remove old server", 1,
+				&github.CommitFile{Filename: github.Ptr("pkg/server.go"), Status: github.Ptr("modified"), SHA: github.Ptr("blob-server-2")},
+				&github.CommitFile{Filename: github.Ptr("pkg/old.go"), Status: github.Ptr("removed"), SHA: github.Ptr("blob-old")},
+			),
+		}
+
+		var trees [][]map[string]any
+		var createdCommits []map[string]any
+		refs := map[string]string{}
+		deps := BaseDeps{Client: github.NewClient(MockHTTPClientWithHandlers(map[string]http.HandlerFunc{
+			GetReposPullsByOwnerByRepoByPullNumber:      mockResponse(t, http.StatusOK, pullRequest),
+			GetReposPullsFilesByOwnerByRepoByPullNumber: mockResponse(t, http.StatusOK, files),
+			GetReposCompareByOwnerByRepoByBasehead: func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/repos/owner/repo/compare/base...head", r.URL.Path)
+				mockResponse(t, http.StatusOK, &github.CommitsComparison{
+					MergeBaseCommit: &github.RepositoryCommit{
+						SHA:    github.Ptr("merge-base"),
+						Commit: &github.Commit{Tree: &github.Tree{SHA: github.Ptr("merge-base-tree")}},
+					},
+				})(w, r)
+			},
+			GetReposGitTreesByOwnerByRepoByTree: mockResponse(t, http.StatusOK, &github.Tree{
+				SHA: github.Ptr("head-tree"),
+				Entries: []*github.TreeEntry{
+					{Path: github.Ptr("cmd/main.go"), Mode: github.Ptr("100755")},
+					{Path: github.Ptr("pkg/server.go"), Mode: github.Ptr("100644")},
+				},
+			}),
+			GetReposPullsCommitsByOwnerByRepoByPullNumber: mockResponse(t, http.StatusOK, []*github.RepositoryCommit{
+				{SHA: github.Ptr("c1")}, {SHA: github.Ptr("c2")}, {SHA: github.Ptr("c3")},
+			}),
+			GetReposCommitsByOwnerByRepoByRef: func(w http.ResponseWriter, r *http.Request) {
+				sha := r.URL.Path[len("/repos/owner/repo/commits/"):]
+				mockResponse(t, http.StatusOK, commits[sha])(w, r)
+			},
+			PostReposGitTreesByOwnerByRepo: func(w http.ResponseWriter, r *http.Request) {
+				var body struct {
+					BaseTree string           `json:"base_tree"`
+					Tree     []map[string]any `json:"tree"`
+				}
+				require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+				trees = append(trees, body.Tree)
+				mockResponse(t, http.StatusCreated, &github.Tree{SHA: github.Ptr(fmt.Sprintf("tree%d", len(trees)))})(w, r)
+			},
+			PostReposGitCommitsByOwnerByRepo: func(w http.ResponseWriter, r *http.Request) {
+				var body map[string]any
+				require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+				createdCommits = append(createdCommits, body)
+				sha := fmt.Sprintf("new%d", len(createdCommits))
+				mockResponse(t, http.StatusCreated, &github.Commit{SHA: github.Ptr(sha), Tree: &github.Tree{SHA: github.Ptr(body["tree"].(string))}})(w, r)
+			},
+			PostReposGitRefsByOwnerByRepo: func(w http.ResponseWriter, r *http.Request) {
+				var body github.CreateRef
+				require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+				refs[body.Ref] = body.SHA
+				mockResponse(t, http.StatusCreated, &github.Reference{Ref: github.Ptr(body.Ref)})(w, r)
+			},
+		}))}
+		request := createMCPRequest(map[string]any{
+			"owner":           "owner",
+			"repo":            "repo",
+			"pullNumber":      float64(42),
+			"create_branches": true,
+		})
+
+		result, err := serverTool.Handler(deps)(ContextWithDeps(context.Background(), deps), &request)
+		require.NoError(t, err)
+		require.False(t, result.IsError)
+
+		var split PullRequestSplit
+		require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &split))
+		require.Len(t, split.Groups, 2)
+		assert.Equal(t, "big-change-split-1", split.Groups[0].Branch)
+		assert.Equal(t, 1, split.Groups[0].Commits)
+		assert.Equal(t, "https://github.com/owner/repo/compare/main...big-change-split-1", split.Groups[0].CompareURL)
+		assert.Equal(t, "big-change-split-2", split.Groups[1].Branch)
+		assert.Equal(t, 2, split.Groups[1].Commits)
+
+		// The merge commit is skipped and each commit only keeps the files of the group
+		assert.Equal(t, [][]map[string]any{
+			{{"path": "cmd/main.go", "mode": "100755", "type": "blob", "sha": "blob-main"}},
+			{{"path": "pkg/server.go", "mode": "100644", "type": "blob", "sha": "blob-server-1"}},
+			{
+				{"path": "pkg/server.go", "mode": "100644", "type": "blob", "sha": "blob-server-2"},
+				{"path": "pkg/old.go", "mode": "100644", "type": "blob", "sha": nil},
+			},
+		}, trees)
+		require.Len(t, createdCommits, 3)
+		assert.Equal(t, "Add server", createdCommits[0]["message"])
+		assert.Equal(t, []any{"merge-base"}, createdCommits[0]["parents"])
+		assert.Equal(t, []any{"merge-base"}, createdCommits[1]["parents"])
+		assert.Equal(t, "Remove old server", createdCommits[2]["message"])
+		assert.Equal(t, []any{"new2"}, createdCommits[2]["parents"])
+		assert.Equal(t, map[string]string{
+			"refs/heads/big-change-split-1": "new1",
+			"refs/heads/big-change-split-2": "new3",
+		}, refs)
+	})
+}
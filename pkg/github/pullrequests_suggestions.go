@@ -0,0 +1,245 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/inventory"
+	"github.com/github/github-mcp-server/pkg/scopes"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/github/github-mcp-server/pkg/utils"
+	"github.com/google/go-github/v82/github"
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// parseSuggestion returns the replacement lines of the suggestion block in a review comment.
+// Comments with several suggestion blocks are rejected, as GitHub cannot tell which to apply
+// either.
+func parseSuggestion(body string) ([]string, error) {
+	var (
+		suggestions [][]string
+		current     []string
+		fence       string
+	)
+	for _, line := range strings.Split(strings.ReplaceAll(body, "\r\n", "\n"), "\n") {
+		trimmed := strings.TrimSpace(line)
+		if fence == "" {
+			info := strings.TrimLeft(trimmed, "`~")
+			if n := len(trimmed) - len(info); n >= 3 && strings.TrimSpace(info) == "suggestion" && strings.Trim(trimmed[:n], trimmed[:1]) == "" {
+				fence = trimmed[:n]
+				current = []string{}
+			}
+			continue
+		}
+		if strings.HasPrefix(trimmed, fence) && strings.Trim(trimmed, fence[:1]) == "" {
+			suggestions = append(suggestions, current)
+			fence = ""
+			continue
+		}
+		current = append(current, line)
+	}
+
+	switch len(suggestions) {
+	case 0:
+		return nil, errors.New("the comment does not contain a suggestion block")
+	case 1:
+		return suggestions[0], nil
+	default:
+		return nil, fmt.Errorf("the comment contains %d suggestion blocks; only comments with a single suggestion can be applied", len(suggestions))
+	}
+}
+
+// applySuggestion replaces lines start to end (1-based, inclusive) of a file with the suggested
+// lines, keeping the line endings of the file. The last line of the diff hunk the comment was
+// made on must still be the last commented line, so suggestions made on lines that have since
+// moved are not applied to the wrong place.
+func applySuggestion(content string, start, end int, suggestion []string, diffHunk string) (string, error) {
+	newline := "\n"
+	if strings.Contains(content, "\r\n") {
+		newline = "\r\n"
+	}
+	lines := strings.Split(content, newline)
+	lineCount := len(lines)
+	if lines[lineCount-1] == "" {
+		// The file ends with a newline
+		lineCount--
+	}
+	if start < 1 || end < start || end > lineCount {
+		return "", fmt.Errorf("the commented lines %d-%d are outside of the file, which has %d lines", start, end, lineCount)
+	}
+
+	hunk := strings.Split(strings.TrimRight(diffHunk, "\r\n"), "\n")
+	for i := len(hunk) - 1; i >= 0; i-- {
+		line := strings.TrimSuffix(hunk[i], "\r")
+		if strings.HasPrefix(line, `\`) {
+			// "\ No newline at end of file"
+			continue
+		}
+		if line == "" || (line[0] != '+' && line[0] != ' ') || line[1:] != lines[end-1] {
+			return "", errors.New("the commented lines changed since the suggestion was made; ask the reviewer to suggest the change again")
+		}
+		break
+	}
+
+	replaced := make([]string, 0, len(lines)-(end-start+1)+len(suggestion))
+	replaced = append(replaced, lines[:start-1]...)
+	replaced = append(replaced, suggestion...)
+	replaced = append(replaced, lines[end:]...)
+	return strings.Join(replaced, newline), nil
+}
+
+// ApplyReviewSuggestion creates a tool that commits the change suggested in a review comment to
+// the head branch of its pull request.
+func ApplyReviewSuggestion(t translations.TranslationHelperFunc) inventory.ServerTool {
+	return NewTool(
+		ToolsetMetadataPullRequests,
+		mcp.Tool{
+			Name:        "apply_review_suggestion",
+			Description: t("TOOL_APPLY_REVIEW_SUGGESTION_DESCRIPTION", "Apply the change suggested in a pull request review comment (its ```suggestion block) by committing it to the head branch of the pull request, like the \"Commit suggestion\" button. The reviewer is credited as a co-author of the commit. Get review comment IDs with 'pull_request_read' and the 'get_review_comments' method."),
+			Annotations: &mcp.ToolAnnotations{
+				Title:        t("TOOL_APPLY_REVIEW_SUGGESTION_USER_TITLE", "Apply review suggestion"),
+				ReadOnlyHint: false,
+			},
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"owner": {
+						Type:        "string",
+						Description: "Repository owner",
+					},
+					"repo": {
+						Type:        "string",
+						Description: "Repository name",
+					},
+					"pullNumber": {
+						Type:        "number",
+						Description: "Pull request number",
+					},
+					"commentId": {
+						Type:        "number",
+						Description: "The ID of the review comment with the suggestion",
+					},
+					"commitMessage": {
+						Type:        "string",
+						Description: "Commit message. Defaults to \"Apply suggestion from @<reviewer>\"",
+					},
+				},
+				Required: []string{"owner", "repo", "pullNumber", "commentId"},
+			},
+		},
+		[]scopes.Scope{scopes.Repo},
+		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+			owner, err := RequiredParam[string](args, "owner")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			repo, err := RequiredParam[string](args, "repo")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			pullNumber, err := RequiredInt(args, "pullNumber")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			commentID, err := RequiredBigInt(args, "commentId")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			message, err := OptionalParam[string](args, "commitMessage")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+
+			client, err := deps.GetClient(ctx)
+			if err != nil {
+				return utils.NewToolResultErrorFromErr("failed to get GitHub client", err), nil, nil
+			}
+
+			comment, resp, err := client.PullRequests.GetComment(ctx, owner, repo, commentID)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to get review comment", resp, err), nil, nil
+			}
+			_ = resp.Body.Close()
+			if !strings.HasSuffix(comment.GetPullRequestURL(), fmt.Sprintf("/pulls/%d", pullNumber)) {
+				return utils.NewToolResultError(fmt.Sprintf("review comment %d does not belong to pull request #%d", commentID, pullNumber)), nil, nil
+			}
+			if comment.Line == nil {
+				return utils.NewToolResultError("the review comment is outdated or not on a line of the file, so its suggestion cannot be applied"), nil, nil
+			}
+			if comment.GetSide() == "LEFT" {
+				return utils.NewToolResultError("the review comment is on removed lines, so its suggestion cannot be applied"), nil, nil
+			}
+			suggestion, err := parseSuggestion(comment.GetBody())
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+
+			pr, resp, err := client.PullRequests.Get(ctx, owner, repo, pullNumber)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to get pull request", resp, err), nil, nil
+			}
+			_ = resp.Body.Close()
+			if pr.GetState() != "open" {
+				return utils.NewToolResultError(fmt.Sprintf("pull request #%d is %s; suggestions can only be applied to open pull requests", pullNumber, pr.GetState())), nil, nil
+			}
+			headRepo := pr.GetHead().GetRepo()
+			if headRepo == nil {
+				return utils.NewToolResultError("the head repository of the pull request no longer exists"), nil, nil
+			}
+			headOwner, headName, branch := headRepo.GetOwner().GetLogin(), headRepo.GetName(), pr.GetHead().GetRef()
+
+			path := comment.GetPath()
+			if result := protectedPathsResult(ctx, client, owner, repo, path); result != nil {
+				return result, nil, nil
+			}
+
+			file, _, resp, err := client.Repositories.GetContents(ctx, headOwner, headName, path, &github.RepositoryContentGetOptions{Ref: branch})
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, fmt.Sprintf("failed to get %s", path), resp, err), nil, nil
+			}
+			_ = resp.Body.Close()
+			if file == nil || file.GetEncoding() == "none" {
+				return utils.NewToolResultError(fmt.Sprintf("%s is not a file whose content can be read through the API", path)), nil, nil
+			}
+			content, err := file.GetContent()
+			if err != nil {
+				return utils.NewToolResultErrorFromErr(fmt.Sprintf("failed to decode %s", path), err), nil, nil
+			}
+
+			end := comment.GetLine()
+			start := end
+			if comment.StartLine != nil {
+				start = comment.GetStartLine()
+			}
+			updated, err := applySuggestion(content, start, end, suggestion, comment.GetDiffHunk())
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+
+			reviewer := comment.GetUser()
+			if message == "" {
+				message = fmt.Sprintf("Apply suggestion from @%s", reviewer.GetLogin())
+			}
+			if reviewer.GetLogin() != "" && reviewer.GetID() != 0 {
+				message += fmt.Sprintf("\n\nCo-authored-by: %s <%d+%s@users.noreply.github.com>", reviewer.GetLogin(), reviewer.GetID(), reviewer.GetLogin())
+			}
+
+			result, resp, err := client.Repositories.UpdateFile(ctx, headOwner, headName, path, &github.RepositoryContentFileOptions{
+				Message: github.Ptr(message),
+				Content: []byte(updated),
+				SHA:     file.SHA,
+				Branch:  github.Ptr(branch),
+			})
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to commit suggestion", resp, err), nil, nil
+			}
+			_ = resp.Body.Close()
+
+			return MarshalledTextResult(convertToMinimalFileContentResponse(result)), nil, nil
+		},
+	)
+}
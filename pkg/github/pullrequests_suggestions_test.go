@@ -0,0 +1,191 @@
+package github
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v82/github"
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ParseSuggestion(t *testing.T) {
+	tests := []struct {
+		name     string
+		body     string
+		expected []string
+		errMsg   string
+	}{
+		{
+			name:     "single line",
+			body:     "Use the constant here.\n\n```suggestion\n\treturn defaultTimeout\n```\n",
+			expected: []string{"\treturn defaultTimeout"},
+		},
+		{
+			name:     "longer fence around a code block",
+			body:     "````suggestion\r\n// Example:\r\n```go\r\nx := 1\r\n```\r\n````",
+			expected: []string{"// Example:", "```go", "x := 1", "```"},
+		},
+		{
+			name:     "deletion",
+			body:     "```suggestion\n```",
+			expected: []string{},
+		},
+		{
+			name:   "no suggestion",
+			body:   "```go\nx := 1\n```",
+			errMsg: "does not contain a suggestion block",
+		},
+		{
+			name:   "several suggestions",
+			body:   "```suggestion\na\n```\nor\n```suggestion\nb\n```",
+			errMsg: "contains 2 suggestion blocks",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			suggestion, err := parseSuggestion(tc.body)
+			if tc.errMsg != "" {
+				assert.ErrorContains(t, err, tc.errMsg)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, suggestion)
+		})
+	}
+}
+
+func Test_ApplySuggestion(t *testing.T) {
+	content := "package main\r\n\r\nfunc main() {\r\n\tprintln(\"helo\")\r\n\tprintln(\"wrld\")\r\n}\r\n"
+	hunk := "@@ -1,5 +1,6 @@\n package main\n \n func main() {\n+\tprintln(\"helo\")\n+\tprintln(\"wrld\")"
+
+	updated, err := applySuggestion(content, 4, 5, []string{"\tprintln(\"hello world\")"}, hunk)
+	require.NoError(t, err)
+	assert.Equal(t, "package main\r\n\r\nfunc main() {\r\n\tprintln(\"hello world\")\r\n}\r\n", updated)
+
+	_, err = applySuggestion(content, 5, 7, []string{""}, hunk)
+	assert.ErrorContains(t, err, "outside of the file")
+
+	_, err = applySuggestion(content, 3, 4, []string{""}, hunk)
+	assert.ErrorContains(t, err, "changed since the suggestion was made")
+}
+
+func Test_ApplyReviewSuggestion(t *testing.T) {
+	repoConfigs.clear()
+	t.Cleanup(repoConfigs.clear)
+
+	serverTool := ApplyReviewSuggestion(translations.NullTranslationHelper)
+	tool := serverTool.Tool
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "apply_review_suggestion", tool.Name)
+	assert.False(t, tool.Annotations.ReadOnlyHint)
+	schema := tool.InputSchema.(*jsonschema.Schema)
+	assert.ElementsMatch(t, schema.Required, []string{"owner", "repo", "pullNumber", "commentId"})
+
+	comment := &github.PullRequestComment{
+		ID:             github.Ptr(int64(101)),
+		Body:           github.Ptr("Typo.\n```suggestion\n\tprintln(\"hello\")\n```"),
+		Path:           github.Ptr("main.go"),
+		Line:           github.Ptr(4),
+		Side:           github.Ptr("RIGHT"),
+		DiffHunk:       github.Ptr("@@ -1,3 +1,5 @@\n package main\n \n func main() {\n+\tprintln(\"helo\")"),
+		PullRequestURL: github.Ptr("https://api.github.com/repos/owner/repo/pulls/42"),
+		User:           &github.User{Login: github.Ptr("reviewer"), ID: github.Ptr(int64(7))},
+	}
+	pullRequest := &github.PullRequest{
+		Number: github.Ptr(42),
+		State:  github.Ptr("open"),
+		Head: &github.PullRequestBranch{
+			Ref:  github.Ptr("fix-greeting"),
+			Repo: &github.Repository{Name: github.Ptr("repo"), Owner: &github.User{Login: github.Ptr("contributor")}},
+		},
+	}
+	file := &github.RepositoryContent{
+		Type:     github.Ptr("file"),
+		Path:     github.Ptr("main.go"),
+		SHA:      github.Ptr("blob123"),
+		Encoding: github.Ptr("base64"),
+		Content:  github.Ptr(base64.StdEncoding.EncodeToString([]byte("package main\n\nfunc main() {\n\tprintln(\"helo\")\n}\n"))),
+	}
+
+	tests := []struct {
+		name           string
+		handlers       map[string]http.HandlerFunc
+		requestArgs    map[string]any
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "commits the suggestion to the head branch of a fork",
+			handlers: map[string]http.HandlerFunc{
+				GetReposPullsCommentsByOwnerByRepoByCommentID: mockResponse(t, http.StatusOK, comment),
+				GetReposPullsByOwnerByRepoByPullNumber:        mockResponse(t, http.StatusOK, pullRequest),
+				GetReposContentsByOwnerByRepoByPath: func(w http.ResponseWriter, r *http.Request) {
+					assert.Equal(t, "/repos/contributor/repo/contents/main.go", r.URL.Path)
+					assert.Equal(t, "fix-greeting", r.URL.Query().Get("ref"))
+					mockResponse(t, http.StatusOK, file)(w, r)
+				},
+				PutReposContentsByOwnerByRepoByPath: expectRequestBody(t, map[string]any{
+					"message": "Apply suggestion from @reviewer\n\nCo-authored-by: reviewer <7+reviewer@users.noreply.github.com>",
+					"content": base64.StdEncoding.EncodeToString([]byte("package main\n\nfunc main() {\n\tprintln(\"hello\")\n}\n")),
+					"sha":     "blob123",
+					"branch":  "fix-greeting",
+				}).andThen(mockResponse(t, http.StatusOK, &github.RepositoryContentResponse{
+					Commit: github.Commit{SHA: github.Ptr("commit456"), Message: github.Ptr("Apply suggestion from @reviewer")},
+				})),
+			},
+			requestArgs: map[string]any{"pullNumber": float64(42), "commentId": float64(101)},
+		},
+		{
+			name: "comment from another pull request",
+			handlers: map[string]http.HandlerFunc{
+				GetReposPullsCommentsByOwnerByRepoByCommentID: mockResponse(t, http.StatusOK, comment),
+			},
+			requestArgs:    map[string]any{"pullNumber": float64(4), "commentId": float64(101)},
+			expectError:    true,
+			expectedErrMsg: "does not belong to pull request #4",
+		},
+		{
+			name: "comment without a suggestion",
+			handlers: map[string]http.HandlerFunc{
+				GetReposPullsCommentsByOwnerByRepoByCommentID: mockResponse(t, http.StatusOK, &github.PullRequestComment{
+					Body:           github.Ptr("Looks good"),
+					Line:           github.Ptr(4),
+					PullRequestURL: github.Ptr("https://api.github.com/repos/owner/repo/pulls/42"),
+				}),
+			},
+			requestArgs:    map[string]any{"pullNumber": float64(42), "commentId": float64(101)},
+			expectError:    true,
+			expectedErrMsg: "does not contain a suggestion block",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			deps := BaseDeps{Client: github.NewClient(MockHTTPClientWithHandlers(tc.handlers))}
+			tc.requestArgs["owner"] = "owner"
+			tc.requestArgs["repo"] = "repo"
+			request := createMCPRequest(tc.requestArgs)
+
+			result, err := serverTool.Handler(deps)(ContextWithDeps(context.Background(), deps), &request)
+			require.NoError(t, err)
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+			require.False(t, result.IsError)
+
+			var response MinimalFileContentResponse
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &response))
+			assert.Equal(t, "commit456", response.Commit.SHA)
+		})
+	}
+}
@@ -0,0 +1,129 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/shurcooL/githubv4"
+)
+
+var (
+	// templateHeadingRe matches a markdown ATX heading and captures its text.
+	templateHeadingRe = regexp.MustCompile(`^\s{0,3}(#{1,6})\s+(.*?)\s*#*\s*$`)
+	// templateCommentRe matches HTML comments, which templates use for authoring hints.
+	templateCommentRe = regexp.MustCompile(`(?s)<!--.*?-->`)
+)
+
+// pullRequestTemplate is a pull request template configured for a repository.
+type pullRequestTemplate struct {
+	Filename string
+	Body     string
+}
+
+// templateSection is a headed section of a markdown document.
+type templateSection struct {
+	Level   int
+	Heading string
+	Content string
+}
+
+// fetchPullRequestTemplate returns the repository's default pull request template, or nil if
+// the repository has none.
+func fetchPullRequestTemplate(ctx context.Context, client *githubv4.Client, owner, repo string) (*pullRequestTemplate, error) {
+	var query struct {
+		Repository struct {
+			PullRequestTemplates []struct {
+				Filename githubv4.String
+				Body     githubv4.String
+			}
+		} `graphql:"repository(owner: $owner, name: $repo)"`
+	}
+	vars := map[string]any{
+		"owner": githubv4.String(owner),
+		"repo":  githubv4.String(repo),
+	}
+	if err := client.Query(ctx, &query, vars); err != nil {
+		return nil, err
+	}
+	if len(query.Repository.PullRequestTemplates) == 0 {
+		return nil, nil
+	}
+
+	tmpl := query.Repository.PullRequestTemplates[0]
+	return &pullRequestTemplate{
+		Filename: string(tmpl.Filename),
+		Body:     string(tmpl.Body),
+	}, nil
+}
+
+// splitTemplateSections splits a markdown document into its headed sections. Text before the
+// first heading is ignored.
+func splitTemplateSections(markdown string) []templateSection {
+	var sections []templateSection
+	var current *templateSection
+	var content []string
+	inFence := false
+
+	flush := func() {
+		if current != nil {
+			current.Content = normalizeTemplateContent(strings.Join(content, "\n"))
+			sections = append(sections, *current)
+		}
+		content = nil
+	}
+
+	for _, line := range strings.Split(markdown, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			inFence = !inFence
+		}
+		if !inFence {
+			if m := templateHeadingRe.FindStringSubmatch(line); m != nil {
+				flush()
+				current = &templateSection{Level: len(m[1]), Heading: m[2]}
+				continue
+			}
+		}
+		content = append(content, line)
+	}
+	flush()
+
+	return sections
+}
+
+// normalizeTemplateContent strips HTML comments and surrounding whitespace so placeholder-only
+// sections compare as empty.
+func normalizeTemplateContent(s string) string {
+	return strings.TrimSpace(templateCommentRe.ReplaceAllString(s, ""))
+}
+
+// validatePullRequestBody checks that body contains every section of the template and that each
+// section has been filled in. Sections that only group deeper subsections need not have content
+// of their own. It returns a description of each problem found.
+func validatePullRequestBody(template, body string) []string {
+	bodySections := map[string]templateSection{}
+	for _, s := range splitTemplateSections(body) {
+		key := strings.ToLower(s.Heading)
+		if _, ok := bodySections[key]; !ok {
+			bodySections[key] = s
+		}
+	}
+
+	var problems []string
+	templateSections := splitTemplateSections(template)
+	for i, want := range templateSections {
+		isGroup := want.Content == "" && i+1 < len(templateSections) && templateSections[i+1].Level > want.Level
+		got, ok := bodySections[strings.ToLower(want.Heading)]
+		switch {
+		case !ok:
+			problems = append(problems, fmt.Sprintf("missing section %q", want.Heading))
+		case isGroup:
+			// Grouping headings only need to be present.
+		case got.Content == "" || got.Content == want.Content:
+			problems = append(problems, fmt.Sprintf("section %q is not filled in", want.Heading))
+		}
+	}
+	return problems
+}
@@ -0,0 +1,157 @@
+package github
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/githubv4mock"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v82/github"
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/shurcooL/githubv4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testPullRequestTemplate = `<!-- Thanks for contributing! -->
+## Summary
+<!-- What does this PR do? -->
+
+## Testing
+- [ ] Unit tests
+
+## Details
+### Notes
+<!-- Anything else? -->
+`
+
+func Test_CreatePullRequest_Template(t *testing.T) {
+	serverTool := CreatePullRequest(translations.NullTranslationHelper)
+	schema := serverTool.Tool.InputSchema.(*jsonschema.Schema)
+	assert.Contains(t, schema.Properties, "use_template")
+	assert.Contains(t, schema.Properties, "strict_template")
+
+	mockPR := &github.PullRequest{
+		Number:  github.Ptr(42),
+		HTMLURL: github.Ptr("https://github.com/owner/repo/pull/42"),
+	}
+
+	var templateQuery struct {
+		Repository struct {
+			PullRequestTemplates []struct {
+				Filename githubv4.String
+				Body     githubv4.String
+			}
+		} `graphql:"repository(owner: $owner, name: $repo)"`
+	}
+	templateMatcher := githubv4mock.NewQueryMatcher(
+		templateQuery,
+		map[string]any{
+			"owner": githubv4.String("owner"),
+			"repo":  githubv4.String("repo"),
+		},
+		githubv4mock.DataResponse(map[string]any{
+			"repository": map[string]any{
+				"pullRequestTemplates": []map[string]any{
+					{"filename": ".github/pull_request_template.md", "body": testPullRequestTemplate},
+				},
+			},
+		}),
+	)
+
+	filledBody := "## Summary\nAdds caching.\n\n## Testing\n- [x] Unit tests\n\n## Details\n### Notes\nNone."
+
+	tests := []struct {
+		name           string
+		restHandlers   map[string]http.HandlerFunc
+		requestArgs    map[string]any
+		expectToolErr  bool
+		expectedErrMsg string
+	}{
+		{
+			name: "pre-fills body from template",
+			restHandlers: map[string]http.HandlerFunc{
+				PostReposPullsByOwnerByRepo: expectRequestBody(t, map[string]any{
+					"title":                 "Add caching",
+					"head":                  "feature",
+					"base":                  "main",
+					"body":                  testPullRequestTemplate,
+					"draft":                 false,
+					"maintainer_can_modify": false,
+				}).andThen(mockResponse(t, http.StatusCreated, mockPR)),
+			},
+			requestArgs: map[string]any{
+				"owner":        "owner",
+				"repo":         "repo",
+				"title":        "Add caching",
+				"head":         "feature",
+				"base":         "main",
+				"use_template": true,
+			},
+		},
+		{
+			name: "strict template accepts filled body",
+			restHandlers: map[string]http.HandlerFunc{
+				PostReposPullsByOwnerByRepo: mockResponse(t, http.StatusCreated, mockPR),
+			},
+			requestArgs: map[string]any{
+				"owner":           "owner",
+				"repo":            "repo",
+				"title":           "Add caching",
+				"head":            "feature",
+				"base":            "main",
+				"body":            filledBody,
+				"strict_template": true,
+			},
+		},
+		{
+			name:         "strict template rejects unfilled sections",
+			restHandlers: map[string]http.HandlerFunc{},
+			requestArgs: map[string]any{
+				"owner":           "owner",
+				"repo":            "repo",
+				"title":           "Add caching",
+				"head":            "feature",
+				"base":            "main",
+				"body":            "## Summary\n<!-- What does this PR do? -->\n\n## Testing\n- [ ] Unit tests",
+				"strict_template": true,
+			},
+			expectToolErr:  true,
+			expectedErrMsg: `section "Summary" is not filled in; section "Testing" is not filled in; missing section "Details"; missing section "Notes"`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			deps := BaseDeps{
+				Client:    github.NewClient(MockHTTPClientWithHandlers(tc.restHandlers)),
+				GQLClient: githubv4.NewClient(githubv4mock.NewMockedHTTPClient(templateMatcher)),
+			}
+			handler := serverTool.Handler(deps)
+			request := createMCPRequest(tc.requestArgs)
+
+			result, err := handler(ContextWithDeps(context.Background(), deps), &request)
+			require.NoError(t, err)
+
+			if tc.expectToolErr {
+				require.True(t, result.IsError)
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			textContent := getTextResult(t, result)
+			assert.Contains(t, textContent.Text, "https://github.com/owner/repo/pull/42")
+		})
+	}
+}
+
+func Test_SplitTemplateSections(t *testing.T) {
+	sections := splitTemplateSections("intro\n# Title #\nbody\n```\n# not a heading\n```\n## Sub\n<!-- hint -->\n")
+	assert.Equal(t, []templateSection{
+		{Level: 1, Heading: "Title", Content: "body\n```\n# not a heading\n```"},
+		{Level: 2, Heading: "Sub", Content: ""},
+	}, sections)
+}
@@ -755,6 +755,22 @@ func Test_MergePullRequest(t *testing.T) {
 			expectError:    true,
 			expectedErrMsg: "failed to merge pull request",
 		},
+		{
+			name: "base branch requires a merge queue",
+			mockedClient: MockHTTPClientWithHandlers(map[string]http.HandlerFunc{
+				PutReposPullsMergeByOwnerByRepoByPullNumber: func(w http.ResponseWriter, _ *http.Request) {
+					w.WriteHeader(http.StatusMethodNotAllowed)
+					_, _ = w.Write([]byte(`{"message": "Changes must be made through the merge queue"}`))
+				},
+			}),
+			requestArgs: map[string]any{
+				"owner":      "owner",
+				"repo":       "repo",
+				"pullNumber": float64(42),
+			},
+			expectError:    true,
+			expectedErrMsg: "add it to the queue with merge_queue_write instead",
+		},
 	}
 
 	for _, tc := range tests {
@@ -911,7 +927,7 @@ func Test_SearchPullRequests(t *testing.T) {
 				GetSearchIssues: expectQueryParams(
 					t,
 					map[string]string{
-						"q":        "is:pr feature",
+						"q":        "is:pr feature archived:false",
 						"page":     "1",
 						"per_page": "30",
 					},
@@ -932,7 +948,7 @@ func Test_SearchPullRequests(t *testing.T) {
 				GetSearchIssues: expectQueryParams(
 					t,
 					map[string]string{
-						"q":        "is:pr review-required",
+						"q":        "is:pr review-required archived:false",
 						"page":     "1",
 						"per_page": "30",
 					},
@@ -1782,6 +1798,7 @@ func Test_GetPullRequestComments(t *testing.T) {
 				assert.Len(t, result.ReviewThreads, 1)
 
 				thread := result.ReviewThreads[0]
+				assert.Equal(t, "RT_kwDOA0xdyM4AX1Yz", thread.ID)
 				assert.Equal(t, false, thread.IsResolved)
 				assert.Equal(t, false, thread.IsOutdated)
 				assert.Equal(t, false, thread.IsCollapsed)
@@ -1798,8 +1815,13 @@ func Test_GetPullRequestComments(t *testing.T) {
 				// Validate pagination info
 				assert.Equal(t, false, result.PageInfo.HasNextPage)
 				assert.Equal(t, false, result.PageInfo.HasPreviousPage)
-				assert.Equal(t, "cursor1", result.PageInfo.StartCursor)
-				assert.Equal(t, "cursor2", result.PageInfo.EndCursor)
+				scope := "get_review_comments:owner/repo#42"
+				startCursor, err := verifyCursor(context.Background(), scope, result.PageInfo.StartCursor)
+				require.NoError(t, err)
+				assert.Equal(t, "cursor1", startCursor)
+				endCursor, err := verifyCursor(context.Background(), scope, result.PageInfo.EndCursor)
+				require.NoError(t, err)
+				assert.Equal(t, "cursor2", endCursor)
 
 				// Validate total count
 				assert.Equal(t, 1, result.TotalCount)
@@ -1983,6 +2005,127 @@ func Test_GetPullRequestComments(t *testing.T) {
 	}
 }
 
+func Test_GetPullRequestUnresolvedReviewThreads(t *testing.T) {
+	thread := func(id string, resolved bool, author string) map[string]any {
+		return map[string]any{
+			"id":          id,
+			"isResolved":  resolved,
+			"isOutdated":  false,
+			"isCollapsed": resolved,
+			"comments": map[string]any{
+				"totalCount": 1,
+				"nodes": []map[string]any{
+					{
+						"id":        "PRRC_" + id,
+						"body":      "Comment on " + id,
+						"path":      "main.go",
+						"line":      3,
+						"author":    map[string]any{"login": author},
+						"createdAt": "2024-01-01T12:00:00Z",
+						"updatedAt": "2024-01-01T12:00:00Z",
+						"url":       "https://github.com/owner/repo/pull/42#discussion_" + id,
+					},
+				},
+			},
+		}
+	}
+	page := func(hasNextPage bool, nodes ...map[string]any) map[string]any {
+		return map[string]any{
+			"data": map[string]any{
+				"repository": map[string]any{
+					"pullRequest": map[string]any{
+						"reviewThreads": map[string]any{
+							"nodes": nodes,
+							"pageInfo": map[string]any{
+								"hasNextPage":     hasNextPage,
+								"hasPreviousPage": false,
+								"startCursor":     "start",
+								"endCursor":       "page1",
+							},
+							"totalCount": 3,
+						},
+					},
+				},
+			},
+		}
+	}
+	// Serve the first page of threads, then the second one after the cursor of the first
+	pages := func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Variables map[string]any `json:"variables"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		assert.EqualValues(t, 100, body.Variables["first"])
+		response := page(true, thread("PRRT_1", false, "maintainer"), thread("PRRT_2", true, "maintainer"))
+		if body.Variables["after"] == "page1" {
+			response = page(false, thread("PRRT_3", false, "testuser"))
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(response)
+	}
+
+	tests := []struct {
+		name            string
+		lockdownEnabled bool
+		expectedIDs     []string
+		expectedAuthors []int
+	}{
+		{
+			name:            "returns the unresolved threads of all pages",
+			expectedIDs:     []string{"PRRT_1", "PRRT_3"},
+			expectedAuthors: []int{1, 1},
+		},
+		{
+			name:            "lockdown filters comments of unresolved threads",
+			lockdownEnabled: true,
+			expectedIDs:     []string{"PRRT_1", "PRRT_3"},
+			expectedAuthors: []int{1, 0},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			httpClient, transport := NewMockHTTPClient()
+			transport.OnRequest(http.MethodPost, "/graphql", pages)
+			gqlClient := githubv4.NewClient(httpClient)
+			cache := stubRepoAccessCache(gqlClient, 5*time.Minute)
+			if tc.lockdownEnabled {
+				cache = stubRepoAccessCache(githubv4.NewClient(newRepoAccessHTTPClient()), 5*time.Minute)
+			}
+			deps := BaseDeps{
+				Client:          github.NewClient(nil),
+				GQLClient:       gqlClient,
+				RepoAccessCache: cache,
+				Flags:           stubFeatureFlags(map[string]bool{"lockdown-mode": tc.lockdownEnabled}),
+			}
+			serverTool := PullRequestRead(translations.NullTranslationHelper)
+			handler := serverTool.Handler(deps)
+
+			request := createMCPRequest(map[string]any{
+				"method":     "get_unresolved_review_threads",
+				"owner":      "owner",
+				"repo":       "repo",
+				"pullNumber": float64(42),
+			})
+			result, err := handler(ContextWithDeps(context.Background(), deps), &request)
+			require.NoError(t, err)
+			require.False(t, result.IsError, getTextResult(t, result).Text)
+
+			var response UnresolvedReviewThreadsResponse
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &response))
+			assert.Equal(t, 2, response.UnresolvedCount)
+			assert.Equal(t, 3, response.TotalCount)
+			assert.False(t, response.Incomplete)
+			require.Len(t, response.ReviewThreads, len(tc.expectedIDs))
+			for i, thread := range response.ReviewThreads {
+				assert.Equal(t, tc.expectedIDs[i], thread.ID)
+				assert.False(t, thread.IsResolved)
+				assert.Len(t, thread.Comments, tc.expectedAuthors[i])
+			}
+		})
+	}
+}
+
 func Test_GetPullRequestReviews(t *testing.T) {
 	// Verify tool definition once
 	serverTool := PullRequestRead(translations.NullTranslationHelper)
@@ -2179,7 +2322,7 @@ func Test_CreatePullRequest(t *testing.T) {
 	assert.Contains(t, schema.Properties, "base")
 	assert.Contains(t, schema.Properties, "draft")
 	assert.Contains(t, schema.Properties, "maintainer_can_modify")
-	assert.ElementsMatch(t, schema.Required, []string{"owner", "repo", "title", "head", "base"})
+	assert.ElementsMatch(t, schema.Required, []string{"owner", "repo", "title", "head"})
 
 	// Setup mock PR for success case
 	mockPR := &github.PullRequest{
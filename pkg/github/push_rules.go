@@ -0,0 +1,454 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/inventory"
+	"github.com/github/github-mcp-server/pkg/scopes"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/github/github-mcp-server/pkg/utils"
+	"github.com/google/go-github/v82/github"
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// PushRuleViolation is a rule that would block a planned push.
+type PushRuleViolation struct {
+	Rule      string   `json:"rule"`
+	Source    string   `json:"source,omitempty"`
+	RulesetID int64    `json:"ruleset_id,omitempty"`
+	Message   string   `json:"message"`
+	Paths     []string `json:"paths,omitempty"`
+}
+
+// PushRulesPreview is the evaluation of the rules applying to a planned push.
+type PushRulesPreview struct {
+	Branch       string              `json:"branch"`
+	BranchExists bool                `json:"branch_exists"`
+	Allowed      bool                `json:"allowed"`
+	Violations   []PushRuleViolation `json:"violations"`
+	// Notes are rules that apply to the push but could not be evaluated in advance.
+	Notes []string `json:"notes,omitempty"`
+}
+
+// plannedFile is a file of a planned push. Size is -1 when the content of the file is unknown.
+type plannedFile struct {
+	Path string
+	Size int64
+}
+
+// pushPlan is a push evaluated against the rules of a branch.
+type pushPlan struct {
+	Branch       string
+	BranchExists bool
+	Message      string
+	AuthorEmail  string
+	Files        []plannedFile
+}
+
+func (p *pushPlan) violation(meta github.BranchRuleMetadata, rule, message string, paths ...string) PushRuleViolation {
+	return PushRuleViolation{
+		Rule:      rule,
+		Source:    meta.RulesetSource,
+		RulesetID: meta.RulesetID,
+		Message:   message,
+		Paths:     paths,
+	}
+}
+
+// evaluateBranchRules returns the violations of the rules of a branch by a push, and notes on the
+// rules it cannot evaluate. Pushes made through the API are fast-forward commits signed by GitHub,
+// so the linear history, non-fast-forward and signature rules never block them. Bypass permissions
+// are not known to the rules endpoint and so are not accounted for.
+func evaluateBranchRules(rules *github.BranchRules, plan *pushPlan) ([]PushRuleViolation, []string) {
+	var violations []PushRuleViolation
+	var notes []string
+
+	if plan.BranchExists {
+		for _, r := range rules.Update {
+			violations = append(violations, plan.violation(r.BranchRuleMetadata, "update", "the branch cannot be updated"))
+		}
+		for _, r := range rules.PullRequest {
+			message := "changes must be made through a pull request"
+			if n := r.Parameters.RequiredApprovingReviewCount; n > 0 {
+				message += fmt.Sprintf(" with %d approving review(s)", n)
+			}
+			violations = append(violations, plan.violation(r.BranchRuleMetadata, "pull_request", message))
+		}
+		for _, r := range rules.MergeQueue {
+			violations = append(violations, plan.violation(r.BranchRuleMetadata, "merge_queue", "changes must be merged through the merge queue"))
+		}
+	} else {
+		for _, r := range rules.Creation {
+			violations = append(violations, plan.violation(*r, "creation", "the branch cannot be created"))
+		}
+	}
+
+	for _, r := range rules.RequiredStatusChecks {
+		if !plan.BranchExists && r.Parameters.DoNotEnforceOnCreate != nil && *r.Parameters.DoNotEnforceOnCreate {
+			continue
+		}
+		contexts := make([]string, 0, len(r.Parameters.RequiredStatusChecks))
+		for _, check := range r.Parameters.RequiredStatusChecks {
+			contexts = append(contexts, check.Context)
+		}
+		violations = append(violations, plan.violation(r.BranchRuleMetadata, "required_status_checks",
+			fmt.Sprintf("the commit must pass the required status checks before it is pushed: %s", strings.Join(contexts, ", "))))
+	}
+	for _, r := range rules.Workflows {
+		if !plan.BranchExists && r.Parameters.DoNotEnforceOnCreate != nil && *r.Parameters.DoNotEnforceOnCreate {
+			continue
+		}
+		violations = append(violations, plan.violation(r.BranchRuleMetadata, "workflows", "the commit must pass the required workflows before it is pushed"))
+	}
+	for _, r := range rules.RequiredDeployments {
+		violations = append(violations, plan.violation(r.BranchRuleMetadata, "required_deployments",
+			fmt.Sprintf("the commit must be deployed to %s before it is pushed", strings.Join(r.Parameters.RequiredDeploymentEnvironments, ", "))))
+	}
+
+	for _, r := range rules.BranchNamePattern {
+		if ok, err := patternRulePasses(r.Parameters, plan.Branch); err != nil {
+			notes = append(notes, fmt.Sprintf("branch_name_pattern from %s could not be evaluated: %v", r.RulesetSource, err))
+		} else if !ok {
+			violations = append(violations, plan.violation(r.BranchRuleMetadata, "branch_name_pattern", describePatternRule("branch name", r.Parameters)))
+		}
+	}
+	for _, r := range rules.CommitMessagePattern {
+		if ok, err := patternRulePasses(r.Parameters, plan.Message); err != nil {
+			notes = append(notes, fmt.Sprintf("commit_message_pattern from %s could not be evaluated: %v", r.RulesetSource, err))
+		} else if !ok {
+			violations = append(violations, plan.violation(r.BranchRuleMetadata, "commit_message_pattern", describePatternRule("commit message", r.Parameters)))
+		}
+	}
+	for _, emailRules := range []struct {
+		rule  string
+		rules []*github.PatternBranchRule
+	}{
+		{"commit_author_email_pattern", rules.CommitAuthorEmailPattern},
+		{"committer_email_pattern", rules.CommitterEmailPattern},
+	} {
+		rule := emailRules.rule
+		for _, r := range emailRules.rules {
+			if plan.AuthorEmail == "" {
+				notes = append(notes, fmt.Sprintf("%s from %s requires the %s; pass author_email to evaluate it", rule, r.RulesetSource, describePatternRule("email", r.Parameters)))
+				continue
+			}
+			if ok, err := patternRulePasses(r.Parameters, plan.AuthorEmail); err != nil {
+				notes = append(notes, fmt.Sprintf("%s from %s could not be evaluated: %v", rule, r.RulesetSource, err))
+			} else if !ok {
+				violations = append(violations, plan.violation(r.BranchRuleMetadata, rule, describePatternRule("email", r.Parameters)))
+			}
+		}
+	}
+
+	for _, r := range rules.FilePathRestriction {
+		var paths []string
+		for _, f := range plan.Files {
+			for _, pattern := range r.Parameters.RestrictedFilePaths {
+				if matchRulesetGlob(pattern, f.Path) {
+					paths = append(paths, f.Path)
+					break
+				}
+			}
+		}
+		if len(paths) > 0 {
+			violations = append(violations, plan.violation(r.BranchRuleMetadata, "file_path_restriction", "the files are restricted from being pushed", paths...))
+		}
+	}
+	for _, r := range rules.FileExtensionRestriction {
+		var paths []string
+		for _, f := range plan.Files {
+			for _, ext := range r.Parameters.RestrictedFileExtensions {
+				if strings.HasSuffix(strings.ToLower(f.Path), strings.ToLower(strings.TrimPrefix(ext, "*"))) {
+					paths = append(paths, f.Path)
+					break
+				}
+			}
+		}
+		if len(paths) > 0 {
+			violations = append(violations, plan.violation(r.BranchRuleMetadata, "file_extension_restriction",
+				fmt.Sprintf("files with the extensions %s cannot be pushed", strings.Join(r.Parameters.RestrictedFileExtensions, ", ")), paths...))
+		}
+	}
+	for _, r := range rules.MaxFilePathLength {
+		var paths []string
+		for _, f := range plan.Files {
+			if len(f.Path) > r.Parameters.MaxFilePathLength {
+				paths = append(paths, f.Path)
+			}
+		}
+		if len(paths) > 0 {
+			violations = append(violations, plan.violation(r.BranchRuleMetadata, "max_file_path_length",
+				fmt.Sprintf("file paths cannot be longer than %d characters", r.Parameters.MaxFilePathLength), paths...))
+		}
+	}
+	for _, r := range rules.MaxFileSize {
+		limit := r.Parameters.MaxFileSize * 1024 * 1024
+		var paths []string
+		unknown := false
+		for _, f := range plan.Files {
+			switch {
+			case f.Size < 0:
+				unknown = true
+			case f.Size > limit:
+				paths = append(paths, f.Path)
+			}
+		}
+		if len(paths) > 0 {
+			violations = append(violations, plan.violation(r.BranchRuleMetadata, "max_file_size",
+				fmt.Sprintf("files cannot be larger than %d MB", r.Parameters.MaxFileSize), paths...))
+		}
+		if unknown {
+			notes = append(notes, fmt.Sprintf("max_file_size from %s limits files to %d MB; pass the content of the files to evaluate it", r.RulesetSource, r.Parameters.MaxFileSize))
+		}
+	}
+
+	for _, r := range rules.CodeScanning {
+		notes = append(notes, fmt.Sprintf("code_scanning from %s is enforced when pull requests are merged", r.RulesetSource))
+	}
+	for _, r := range rules.CopilotCodeReview {
+		notes = append(notes, fmt.Sprintf("copilot_code_review from %s is enforced on pull requests", r.RulesetSource))
+	}
+
+	return violations, notes
+}
+
+// patternRulePasses reports whether a value satisfies a pattern rule.
+func patternRulePasses(params github.PatternRuleParameters, value string) (bool, error) {
+	var matched bool
+	switch params.Operator {
+	case github.PatternRuleOperatorStartsWith:
+		matched = strings.HasPrefix(value, params.Pattern)
+	case github.PatternRuleOperatorEndsWith:
+		matched = strings.HasSuffix(value, params.Pattern)
+	case github.PatternRuleOperatorContains:
+		matched = strings.Contains(value, params.Pattern)
+	case github.PatternRuleOperatorRegex:
+		re, err := regexp.Compile(params.Pattern)
+		if err != nil {
+			return false, fmt.Errorf("unsupported regular expression %q", params.Pattern)
+		}
+		matched = re.MatchString(value)
+	default:
+		return false, fmt.Errorf("unknown operator %q", params.Operator)
+	}
+	negate := params.Negate != nil && *params.Negate
+	return matched != negate, nil
+}
+
+// describePatternRule describes what a pattern rule requires of a value.
+func describePatternRule(subject string, params github.PatternRuleParameters) string {
+	verb := "must"
+	if params.Negate != nil && *params.Negate {
+		verb = "must not"
+	}
+	operator := strings.ReplaceAll(string(params.Operator), "_", " ")
+	if params.Operator == github.PatternRuleOperatorRegex {
+		operator = "match"
+	}
+	description := fmt.Sprintf("the %s %s %s %q", subject, verb, operator, params.Pattern)
+	if params.Name != nil && *params.Name != "" {
+		description += fmt.Sprintf(" (%s)", *params.Name)
+	}
+	return description
+}
+
+// matchRulesetGlob reports whether a file path matches a file path restriction pattern, in which
+// "**" matches any number of directories and "*" and "?" do not match slashes.
+func matchRulesetGlob(pattern, filePath string) bool {
+	var expr strings.Builder
+	expr.WriteString("^")
+	for i := 0; i < len(pattern); i++ {
+		switch c := pattern[i]; {
+		case strings.HasPrefix(pattern[i:], "**/"):
+			expr.WriteString("(?:.*/)?")
+			i += 2
+		case strings.HasPrefix(pattern[i:], "**"):
+			expr.WriteString(".*")
+			i++
+		case c == '*':
+			expr.WriteString("[^/]*")
+		case c == '?':
+			expr.WriteString("[^/]")
+		default:
+			expr.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	expr.WriteString("$")
+	re, err := regexp.Compile(expr.String())
+	return err == nil && re.MatchString(strings.TrimPrefix(filePath, "/"))
+}
+
+// PreviewPushRules creates a tool to evaluate the rules that would block a planned push.
+func PreviewPushRules(t translations.TranslationHelperFunc) inventory.ServerTool {
+	return NewTool(
+		ToolsetMetadataRepos,
+		mcp.Tool{
+			Name: "preview_push_rules",
+			Description: t("TOOL_PREVIEW_PUSH_RULES_DESCRIPTION", `Check whether a planned push would be blocked before making it, by evaluating the repository rulesets, branch protection and .github/mcp-server.yml protected paths that apply to the branch.
+Returns the rules the push would violate, such as required pull requests or status checks, restricted file paths, and commit message patterns. Bypass permissions are not accounted for.`),
+			Annotations: &mcp.ToolAnnotations{
+				Title:        t("TOOL_PREVIEW_PUSH_RULES_USER_TITLE", "Preview rules for a push"),
+				ReadOnlyHint: true,
+			},
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"owner": {
+						Type:        "string",
+						Description: "Repository owner",
+					},
+					"repo": {
+						Type:        "string",
+						Description: "Repository name",
+					},
+					"branch": {
+						Type:        "string",
+						Description: "Branch to push to, which may not exist yet",
+					},
+					"message": {
+						Type:        "string",
+						Description: "Commit message",
+					},
+					"files": {
+						Type:        "array",
+						Description: "Files to push, each object with path and, to evaluate file size limits, content",
+						Items: &jsonschema.Schema{
+							Type: "object",
+							Properties: map[string]*jsonschema.Schema{
+								"path": {
+									Type:        "string",
+									Description: "path to the file",
+								},
+								"content": {
+									Type:        "string",
+									Description: "file content",
+								},
+							},
+							Required: []string{"path"},
+						},
+					},
+					"author_email": {
+						Type:        "string",
+						Description: "Email of the commit author, to evaluate email pattern rules",
+					},
+				},
+				Required: []string{"owner", "repo", "branch"},
+			},
+		},
+		[]scopes.Scope{scopes.Repo},
+		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+			owner, err := RequiredParam[string](args, "owner")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			repo, err := RequiredParam[string](args, "repo")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			plan := &pushPlan{}
+			if plan.Branch, err = RequiredParam[string](args, "branch"); err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			if plan.Message, err = OptionalParam[string](args, "message"); err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			if plan.AuthorEmail, err = OptionalParam[string](args, "author_email"); err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			if filesObj, ok := args["files"]; ok && filesObj != nil {
+				files, ok := filesObj.([]any)
+				if !ok {
+					return utils.NewToolResultError("files parameter must be an array of objects with path and content"), nil, nil
+				}
+				for _, file := range files {
+					fileMap, ok := file.(map[string]any)
+					if !ok {
+						return utils.NewToolResultError("each file must be an object with a path"), nil, nil
+					}
+					path, ok := fileMap["path"].(string)
+					if !ok || path == "" {
+						return utils.NewToolResultError("each file must have a path"), nil, nil
+					}
+					f := plannedFile{Path: strings.TrimPrefix(path, "/"), Size: -1}
+					if content, ok := fileMap["content"].(string); ok {
+						f.Size = int64(len(content))
+					}
+					plan.Files = append(plan.Files, f)
+				}
+			}
+
+			client, err := deps.GetClient(ctx)
+			if err != nil {
+				return utils.NewToolResultErrorFromErr("failed to get GitHub client", err), nil, nil
+			}
+
+			preview := PushRulesPreview{Branch: plan.Branch, Violations: []PushRuleViolation{}}
+
+			branch, resp, err := client.Repositories.GetBranch(ctx, owner, repo, plan.Branch, 1)
+			switch {
+			case err == nil:
+				plan.BranchExists = true
+			case resp != nil && resp.StatusCode == http.StatusNotFound:
+			default:
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, fmt.Sprintf("failed to get branch %s", plan.Branch), resp, err), nil, nil
+			}
+			preview.BranchExists = plan.BranchExists
+
+			rules, resp, err := client.Repositories.GetRulesForBranch(ctx, owner, repo, plan.Branch, &github.ListOptions{PerPage: 100})
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, fmt.Sprintf("failed to get the rules of branch %s", plan.Branch), resp, err), nil, nil
+			}
+			_ = resp.Body.Close()
+			violations, notes := evaluateBranchRules(rules, plan)
+			preview.Violations = append(preview.Violations, violations...)
+			preview.Notes = notes
+
+			// Branch protection predates rulesets and is reported by the branch itself
+			if branch.GetProtected() {
+				checks := branch.GetProtection().GetRequiredStatusChecks()
+				switch {
+				case checks != nil && len(checks.GetContexts()) > 0:
+					preview.Violations = append(preview.Violations, PushRuleViolation{
+						Rule:    "branch_protection",
+						Message: fmt.Sprintf("the commit must pass the required status checks before it is pushed: %s", strings.Join(checks.GetContexts(), ", ")),
+					})
+				default:
+					preview.Notes = append(preview.Notes, "the branch is protected; its protection may require pull requests or reviews, which can only be read with admin access")
+				}
+			}
+
+			config, err := repoConfigs.get(ctx, client, owner, repo)
+			if err != nil {
+				preview.Violations = append(preview.Violations, PushRuleViolation{
+					Rule:    "repository_configuration",
+					Source:  repoConfigPath,
+					Message: err.Error(),
+				})
+			} else {
+				var paths []string
+				for _, f := range plan.Files {
+					if _, ok := config.protects(f.Path); ok {
+						paths = append(paths, f.Path)
+					}
+				}
+				if len(paths) > 0 {
+					preview.Violations = append(preview.Violations, PushRuleViolation{
+						Rule:    "protected_paths",
+						Source:  repoConfigPath,
+						Message: "the files are protected by the repository's configuration and must be changed by a maintainer",
+						Paths:   paths,
+					})
+				}
+			}
+
+			preview.Allowed = len(preview.Violations) == 0
+			return MarshalledTextResult(preview), nil, nil
+		},
+	)
+}
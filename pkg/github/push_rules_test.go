@@ -0,0 +1,208 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v82/github"
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_PreviewPushRules(t *testing.T) {
+	serverTool := PreviewPushRules(translations.NullTranslationHelper)
+	tool := serverTool.Tool
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "preview_push_rules", tool.Name)
+	assert.True(t, tool.Annotations.ReadOnlyHint)
+	schema := tool.InputSchema.(*jsonschema.Schema)
+	assert.Contains(t, schema.Properties, "files")
+	assert.ElementsMatch(t, schema.Required, []string{"owner", "repo", "branch"})
+
+	rule := func(ruleType string, parameters map[string]any) map[string]any {
+		r := map[string]any{
+			"type":                ruleType,
+			"ruleset_source_type": "Repository",
+			"ruleset_source":      "owner/repo",
+			"ruleset_id":          42,
+		}
+		if parameters != nil {
+			r["parameters"] = parameters
+		}
+		return r
+	}
+	mainRules := []map[string]any{
+		rule("pull_request", map[string]any{
+			"required_approving_review_count":   1,
+			"dismiss_stale_reviews_on_push":     false,
+			"require_code_owner_review":         false,
+			"require_last_push_approval":        false,
+			"required_review_thread_resolution": false,
+		}),
+		rule("required_status_checks", map[string]any{
+			"required_status_checks":               []map[string]any{{"context": "build"}, {"context": "test"}},
+			"strict_required_status_checks_policy": false,
+			"do_not_enforce_on_create":             true,
+		}),
+		rule("commit_message_pattern", map[string]any{
+			"name":     "Conventional commits",
+			"operator": "regex",
+			"pattern":  `^(feat|fix|docs|chore)(\(.+\))?: `,
+		}),
+		rule("file_path_restriction", map[string]any{
+			"restricted_file_paths": []string{".github/workflows/**", "secrets/*"},
+		}),
+		rule("max_file_size", map[string]any{"max_file_size": 1}),
+		rule("creation", nil),
+	}
+
+	tests := []struct {
+		name               string
+		branchExists       bool
+		requestArgs        map[string]any
+		expectedAllowed    bool
+		expectedViolations []PushRuleViolation
+		expectedNotes      []string
+	}{
+		{
+			name:         "push to existing protected branch",
+			branchExists: true,
+			requestArgs: map[string]any{
+				"branch":  "main",
+				"message": "update workflow",
+				"files": []any{
+					map[string]any{"path": ".github/workflows/ci.yml", "content": "on: push"},
+					map[string]any{"path": "README.md"},
+				},
+			},
+			expectedViolations: []PushRuleViolation{
+				{Rule: "pull_request", Source: "owner/repo", RulesetID: 42, Message: "changes must be made through a pull request with 1 approving review(s)"},
+				{Rule: "required_status_checks", Source: "owner/repo", RulesetID: 42, Message: "the commit must pass the required status checks before it is pushed: build, test"},
+				{Rule: "commit_message_pattern", Source: "owner/repo", RulesetID: 42, Message: `the commit message must match "^(feat|fix|docs|chore)(\\(.+\\))?: " (Conventional commits)`},
+				{Rule: "file_path_restriction", Source: "owner/repo", RulesetID: 42, Message: "the files are restricted from being pushed", Paths: []string{".github/workflows/ci.yml"}},
+			},
+			expectedNotes: []string{"max_file_size from owner/repo limits files to 1 MB; pass the content of the files to evaluate it"},
+		},
+		{
+			name:         "new branch skips checks not enforced on creation",
+			branchExists: false,
+			requestArgs: map[string]any{
+				"branch":  "feature",
+				"message": "feat: add caching",
+				"files": []any{
+					map[string]any{"path": "cache.go", "content": "package cache"},
+				},
+			},
+			expectedViolations: []PushRuleViolation{
+				{Rule: "creation", Source: "owner/repo", RulesetID: 42, Message: "the branch cannot be created"},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			branchHandler := mockResponse(t, http.StatusNotFound, map[string]string{"message": "Branch not found"})
+			if tc.branchExists {
+				branchHandler = mockResponse(t, http.StatusOK, &github.Branch{Name: github.Ptr("main"), Protected: github.Ptr(false)})
+			}
+			mockedClient := MockHTTPClientWithHandlers(map[string]http.HandlerFunc{
+				GetReposBranchesByOwnerByRepoByBranch:      branchHandler,
+				GetReposRulesBranchesByOwnerByRepoByBranch: mockResponse(t, http.StatusOK, mainRules),
+			})
+			deps := BaseDeps{Client: github.NewClient(mockedClient)}
+			tc.requestArgs["owner"] = "owner"
+			tc.requestArgs["repo"] = "repo"
+			request := createMCPRequest(tc.requestArgs)
+
+			result, err := serverTool.Handler(deps)(ContextWithDeps(context.Background(), deps), &request)
+			require.NoError(t, err)
+			require.False(t, result.IsError)
+
+			var preview PushRulesPreview
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &preview))
+			assert.Equal(t, tc.branchExists, preview.BranchExists)
+			assert.Equal(t, tc.expectedAllowed, preview.Allowed)
+			assert.Equal(t, tc.expectedViolations, preview.Violations)
+			assert.Equal(t, tc.expectedNotes, preview.Notes)
+		})
+	}
+}
+
+func Test_PreviewPushRules_ProtectionAndRepoConfig(t *testing.T) {
+	repoConfigs.clear()
+	t.Cleanup(repoConfigs.clear)
+
+	serverTool := PreviewPushRules(translations.NullTranslationHelper)
+	mockedClient := MockHTTPClientWithHandlers(map[string]http.HandlerFunc{
+		GetReposBranchesByOwnerByRepoByBranch: mockResponse(t, http.StatusOK, &github.Branch{
+			Name:      github.Ptr("develop"),
+			Protected: github.Ptr(true),
+			Protection: &github.Protection{
+				RequiredStatusChecks: &github.RequiredStatusChecks{Contexts: &[]string{"ci"}},
+			},
+		}),
+		GetReposRulesBranchesByOwnerByRepoByBranch: mockResponse(t, http.StatusOK, []any{}),
+		GetReposContentsRepoConfig:                 mockRepoConfig(t, testRepoConfig),
+	})
+	deps := BaseDeps{Client: github.NewClient(mockedClient)}
+	request := createMCPRequest(map[string]any{
+		"owner":  "octo-org",
+		"repo":   "configured",
+		"branch": "develop",
+		"files": []any{
+			map[string]any{"path": "LICENSE", "content": "MIT"},
+		},
+	})
+
+	result, err := serverTool.Handler(deps)(ContextWithDeps(context.Background(), deps), &request)
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	var preview PushRulesPreview
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &preview))
+	assert.False(t, preview.Allowed)
+	assert.Equal(t, []PushRuleViolation{
+		{Rule: "branch_protection", Message: "the commit must pass the required status checks before it is pushed: ci"},
+		{Rule: "protected_paths", Source: ".github/mcp-server.yml", Message: "the files are protected by the repository's configuration and must be changed by a maintainer", Paths: []string{"LICENSE"}},
+	}, preview.Violations)
+}
+
+func Test_PatternRulePasses(t *testing.T) {
+	negate := true
+	tests := []struct {
+		name     string
+		params   github.PatternRuleParameters
+		value    string
+		expected bool
+	}{
+		{"starts with", github.PatternRuleParameters{Operator: github.PatternRuleOperatorStartsWith, Pattern: "release/"}, "release/1.0", true},
+		{"ends with", github.PatternRuleParameters{Operator: github.PatternRuleOperatorEndsWith, Pattern: "@example.com"}, "dev@other.com", false},
+		{"negated contains", github.PatternRuleParameters{Operator: github.PatternRuleOperatorContains, Pattern: "WIP", Negate: &negate}, "WIP: draft", false},
+		{"regex", github.PatternRuleParameters{Operator: github.PatternRuleOperatorRegex, Pattern: `^[A-Z]+-\d+`}, "ABC-123 fix", true},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			passes, err := patternRulePasses(tc.params, tc.value)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, passes)
+		})
+	}
+
+	// Lookarounds are valid for GitHub but not for Go regular expressions
+	_, err := patternRulePasses(github.PatternRuleParameters{Operator: github.PatternRuleOperatorRegex, Pattern: `^(?!WIP)`}, "fix")
+	assert.Error(t, err)
+}
+
+func Test_MatchRulesetGlob(t *testing.T) {
+	assert.True(t, matchRulesetGlob(".github/workflows/**", ".github/workflows/ci.yml"))
+	assert.True(t, matchRulesetGlob("**/*.pem", "certs/prod/server.pem"))
+	assert.True(t, matchRulesetGlob("**/*.pem", "server.pem"))
+	assert.False(t, matchRulesetGlob("secrets/*", "secrets/nested/key"))
+	assert.False(t, matchRulesetGlob("*.md", "docs/README.md"))
+}
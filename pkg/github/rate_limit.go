@@ -0,0 +1,88 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/inventory"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/github/github-mcp-server/pkg/utils"
+	"github.com/google/go-github/v82/github"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// RateLimitQuota is the quota left of a GitHub API rate limit.
+type RateLimitQuota struct {
+	Limit     int       `json:"limit"`
+	Remaining int       `json:"remaining"`
+	Used      int       `json:"used"`
+	Reset     time.Time `json:"reset"`
+	// ResetsIn is the number of seconds until the quota is replenished.
+	ResetsIn int `json:"resets_in_seconds"`
+}
+
+// rateLimitQuotas returns the quota of each rate limit resource GitHub reported.
+func rateLimitQuotas(limits *github.RateLimits, now time.Time) map[string]RateLimitQuota {
+	resources := map[string]*github.Rate{
+		"core":                        limits.Core,
+		"search":                      limits.Search,
+		"code_search":                 limits.CodeSearch,
+		"graphql":                     limits.GraphQL,
+		"integration_manifest":        limits.IntegrationManifest,
+		"source_import":               limits.SourceImport,
+		"code_scanning_upload":        limits.CodeScanningUpload,
+		"actions_runner_registration": limits.ActionsRunnerRegistration,
+		"scim":                        limits.SCIM,
+		"dependency_snapshots":        limits.DependencySnapshots,
+		"audit_log":                   limits.AuditLog,
+	}
+	quotas := map[string]RateLimitQuota{}
+	for name, rate := range resources {
+		if rate == nil {
+			continue
+		}
+		quotas[name] = RateLimitQuota{
+			Limit:     rate.Limit,
+			Remaining: rate.Remaining,
+			Used:      rate.Used,
+			Reset:     rate.Reset.Time,
+			ResetsIn:  max(int(rate.Reset.Sub(now).Seconds()), 0),
+		}
+	}
+	return quotas
+}
+
+// GetRateLimit creates a tool reporting the GitHub API quota left to the authenticated user.
+func GetRateLimit(t translations.TranslationHelperFunc) inventory.ServerTool {
+	return NewTool(
+		ToolsetMetadataContext,
+		mcp.Tool{
+			Name:        "get_rate_limit",
+			Description: t("TOOL_GET_RATE_LIMIT_DESCRIPTION", "Get the GitHub API rate limits of the authenticated user: the limit, remaining and used requests of each resource (core REST API, search, GraphQL, ...) and when they reset. Checking does not count against the limits. Use this before tasks making many calls, or when calls fail with rate limit errors, to decide whether to wait."),
+			Annotations: &mcp.ToolAnnotations{
+				Title:        t("TOOL_GET_RATE_LIMIT_USER_TITLE", "Get API rate limits"),
+				ReadOnlyHint: true,
+			},
+			// Use json.RawMessage to ensure "properties" is included even when empty.
+			// OpenAI strict mode requires the properties field to be present.
+			InputSchema: json.RawMessage(`{"type":"object","properties":{}}`),
+		},
+		nil,
+		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, _ map[string]any) (*mcp.CallToolResult, any, error) {
+			client, err := deps.GetClient(ctx)
+			if err != nil {
+				return utils.NewToolResultErrorFromErr("failed to get GitHub client", err), nil, nil
+			}
+
+			limits, resp, err := client.RateLimit.Get(ctx)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to get rate limits", resp, err), nil, nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return MarshalledTextResult(rateLimitQuotas(limits, time.Now())), nil, nil
+		},
+	)
+}
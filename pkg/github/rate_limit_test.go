@@ -0,0 +1,78 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v82/github"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_GetRateLimit(t *testing.T) {
+	serverTool := GetRateLimit(translations.NullTranslationHelper)
+	tool := serverTool.Tool
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_rate_limit", tool.Name)
+	assert.True(t, tool.Annotations.ReadOnlyHint)
+
+	reset := time.Now().Add(30 * time.Minute).Truncate(time.Second)
+	tests := []struct {
+		name           string
+		handlers       map[string]http.HandlerFunc
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "reports each resource",
+			handlers: map[string]http.HandlerFunc{
+				GetRateLimitStatus: mockResponse(t, http.StatusOK, map[string]any{
+					"resources": map[string]any{
+						"core":    map[string]any{"limit": 5000, "remaining": 4990, "used": 10, "reset": reset.Unix()},
+						"search":  map[string]any{"limit": 30, "remaining": 0, "used": 30, "reset": reset.Unix()},
+						"graphql": map[string]any{"limit": 5000, "remaining": 5000, "used": 0, "reset": reset.Unix()},
+					},
+				}),
+			},
+		},
+		{
+			name: "API error",
+			handlers: map[string]http.HandlerFunc{
+				GetRateLimitStatus: mockResponse(t, http.StatusUnauthorized, map[string]string{"message": "Bad credentials"}),
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to get rate limits",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			deps := BaseDeps{Client: github.NewClient(MockHTTPClientWithHandlers(tc.handlers))}
+			request := createMCPRequest(map[string]any{})
+
+			result, err := serverTool.Handler(deps)(ContextWithDeps(context.Background(), deps), &request)
+			require.NoError(t, err)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			var quotas map[string]RateLimitQuota
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &quotas))
+			require.Len(t, quotas, 3)
+			assert.Equal(t, 4990, quotas["core"].Remaining)
+			assert.Equal(t, 0, quotas["search"].Remaining)
+			assert.True(t, reset.Equal(quotas["search"].Reset))
+			assert.InDelta(t, 30*60, quotas["search"].ResetsIn, 5)
+		})
+	}
+}
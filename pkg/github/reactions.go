@@ -0,0 +1,362 @@
+package github
+
+import (
+	"context"
+	"fmt"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/inventory"
+	"github.com/github/github-mcp-server/pkg/scopes"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/github/github-mcp-server/pkg/utils"
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/shurcooL/githubv4"
+)
+
+// reactionContents maps the reaction names of the REST API, which tools accept, to the reaction
+// contents of the GraphQL API.
+var reactionContents = map[string]githubv4.ReactionContent{
+	"+1":       githubv4.ReactionContentThumbsUp,
+	"-1":       githubv4.ReactionContentThumbsDown,
+	"laugh":    githubv4.ReactionContentLaugh,
+	"confused": githubv4.ReactionContentConfused,
+	"heart":    githubv4.ReactionContentHeart,
+	"hooray":   githubv4.ReactionContentHooray,
+	"rocket":   githubv4.ReactionContentRocket,
+	"eyes":     githubv4.ReactionContentEyes,
+}
+
+// reactionSubjectProperties returns the schema properties identifying what a reaction is on.
+func reactionSubjectProperties() map[string]*jsonschema.Schema {
+	return map[string]*jsonschema.Schema{
+		"subject_type": {
+			Type: "string",
+			Description: `What the reaction is on, and the parameters identifying it:
+- 'issue', 'pull_request', 'discussion' - the number.
+- 'issue_comment', 'pull_request_review_comment' - the comment_id.
+- 'pull_request_review' - the pull request number and the review_id.
+- 'discussion_comment' - the node_id of the comment, as returned by get_discussion_comments.
+`,
+			Enum: []any{"issue", "pull_request", "issue_comment", "pull_request_review", "pull_request_review_comment", "discussion", "discussion_comment"},
+		},
+		"owner": {
+			Type:        "string",
+			Description: "Repository owner",
+		},
+		"repo": {
+			Type:        "string",
+			Description: "Repository name",
+		},
+		"number": {
+			Type:        "number",
+			Description: "Number of the issue, pull request or discussion",
+		},
+		"comment_id": {
+			Type:        "number",
+			Description: "ID of the issue comment or pull request review comment",
+		},
+		"review_id": {
+			Type:        "number",
+			Description: "ID of the pull request review",
+		},
+		"node_id": {
+			Type:        "string",
+			Description: "Node ID of the subject. Required for discussion comments; for other subjects it replaces the parameters identifying them.",
+		},
+	}
+}
+
+// resolveReactionSubject returns the node ID of what a reaction tool call is on, or a tool result
+// reporting why it cannot be found.
+func resolveReactionSubject(ctx context.Context, deps ToolDependencies, args map[string]any) (githubv4.ID, *mcp.CallToolResult, error) {
+	subjectType, err := RequiredParam[string](args, "subject_type")
+	if err != nil {
+		return nil, utils.NewToolResultError(err.Error()), nil
+	}
+	nodeID, err := OptionalParam[string](args, "node_id")
+	if err != nil {
+		return nil, utils.NewToolResultError(err.Error()), nil
+	}
+	if nodeID != "" {
+		return githubv4.ID(nodeID), nil, nil
+	}
+	if subjectType == "discussion_comment" {
+		return nil, utils.NewToolResultError("node_id is required for discussion comments"), nil
+	}
+
+	owner, err := RequiredParam[string](args, "owner")
+	if err != nil {
+		return nil, utils.NewToolResultError(err.Error()), nil
+	}
+	repo, err := RequiredParam[string](args, "repo")
+	if err != nil {
+		return nil, utils.NewToolResultError(err.Error()), nil
+	}
+
+	switch subjectType {
+	case "discussion":
+		number, err := RequiredInt(args, "number")
+		if err != nil {
+			return nil, utils.NewToolResultError(err.Error()), nil
+		}
+		client, err := deps.GetGQLClient(ctx)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to get GitHub GQL client: %w", err)
+		}
+		var q struct {
+			Repository struct {
+				Discussion struct {
+					ID githubv4.ID
+				} `graphql:"discussion(number: $discussionNumber)"`
+			} `graphql:"repository(owner: $owner, name: $repo)"`
+		}
+		vars := map[string]any{
+			"owner":            githubv4.String(owner),
+			"repo":             githubv4.String(repo),
+			"discussionNumber": githubv4.Int(number), // #nosec G115 - discussion numbers are always small positive integers
+		}
+		if err := client.Query(ctx, &q, vars); err != nil {
+			return nil, ghErrors.NewGitHubGraphQLErrorResponse(ctx, "failed to get discussion", err), nil
+		}
+		return q.Repository.Discussion.ID, nil, nil
+
+	case "issue", "pull_request":
+		number, err := RequiredInt(args, "number")
+		if err != nil {
+			return nil, utils.NewToolResultError(err.Error()), nil
+		}
+		client, err := deps.GetClient(ctx)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to get GitHub client: %w", err)
+		}
+		// Pull requests are issues too, so the issues API returns their node ID
+		issue, resp, err := client.Issues.Get(ctx, owner, repo, number)
+		if err != nil {
+			return nil, ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to get "+subjectType, resp, err), nil
+		}
+		_ = resp.Body.Close()
+		return githubv4.ID(issue.GetNodeID()), nil, nil
+
+	case "issue_comment", "pull_request_review_comment":
+		commentID, err := RequiredBigInt(args, "comment_id")
+		if err != nil {
+			return nil, utils.NewToolResultError(err.Error()), nil
+		}
+		client, err := deps.GetClient(ctx)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to get GitHub client: %w", err)
+		}
+		if subjectType == "issue_comment" {
+			comment, resp, err := client.Issues.GetComment(ctx, owner, repo, commentID)
+			if err != nil {
+				return nil, ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to get issue comment", resp, err), nil
+			}
+			_ = resp.Body.Close()
+			return githubv4.ID(comment.GetNodeID()), nil, nil
+		}
+		comment, resp, err := client.PullRequests.GetComment(ctx, owner, repo, commentID)
+		if err != nil {
+			return nil, ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to get pull request review comment", resp, err), nil
+		}
+		_ = resp.Body.Close()
+		return githubv4.ID(comment.GetNodeID()), nil, nil
+
+	case "pull_request_review":
+		number, err := RequiredInt(args, "number")
+		if err != nil {
+			return nil, utils.NewToolResultError(err.Error()), nil
+		}
+		reviewID, err := RequiredBigInt(args, "review_id")
+		if err != nil {
+			return nil, utils.NewToolResultError(err.Error()), nil
+		}
+		client, err := deps.GetClient(ctx)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to get GitHub client: %w", err)
+		}
+		review, resp, err := client.PullRequests.GetReview(ctx, owner, repo, number, reviewID)
+		if err != nil {
+			return nil, ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to get pull request review", resp, err), nil
+		}
+		_ = resp.Body.Close()
+		return githubv4.ID(review.GetNodeID()), nil, nil
+
+	default:
+		return nil, utils.NewToolResultError(fmt.Sprintf("unknown subject_type: %s", subjectType)), nil
+	}
+}
+
+// ReactionSummary is the result of list_reactions.
+type ReactionSummary struct {
+	SubjectID string           `json:"subject_id"`
+	Reactions MinimalReactions `json:"reactions"`
+	// ViewerReactions lists the reactions of the authenticated user
+	ViewerReactions []string `json:"viewer_reactions"`
+}
+
+// ListReactions creates a tool to count the reactions on an issue, pull request, review,
+// discussion or comment.
+func ListReactions(t translations.TranslationHelperFunc) inventory.ServerTool {
+	return NewTool(
+		ToolsetMetadataIssues,
+		mcp.Tool{
+			Name:        "list_reactions",
+			Description: t("TOOL_LIST_REACTIONS_DESCRIPTION", "Count the emoji reactions on an issue, pull request, pull request review, discussion or one of their comments, and list those of the authenticated user. Use it to gauge community feedback, or to check whether you already acknowledged a comment."),
+			Annotations: &mcp.ToolAnnotations{
+				Title:        t("TOOL_LIST_REACTIONS_USER_TITLE", "List reactions"),
+				ReadOnlyHint: true,
+			},
+			InputSchema: &jsonschema.Schema{
+				Type:       "object",
+				Properties: reactionSubjectProperties(),
+				Required:   []string{"subject_type"},
+			},
+		},
+		[]scopes.Scope{scopes.Repo},
+		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+			subjectID, result, err := resolveReactionSubject(ctx, deps, args)
+			if result != nil || err != nil {
+				return result, nil, err
+			}
+
+			client, err := deps.GetGQLClient(ctx)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to get GitHub GQL client: %w", err)
+			}
+			var q struct {
+				Node struct {
+					Reactable struct {
+						ReactionGroups []struct {
+							Content  githubv4.ReactionContent
+							Reactors struct {
+								TotalCount githubv4.Int
+							}
+							ViewerHasReacted githubv4.Boolean
+						}
+					} `graphql:"... on Reactable"`
+				} `graphql:"node(id: $id)"`
+			}
+			if err := client.Query(ctx, &q, map[string]any{"id": subjectID}); err != nil {
+				return ghErrors.NewGitHubGraphQLErrorResponse(ctx, "failed to list reactions", err), nil, nil
+			}
+
+			summary := ReactionSummary{SubjectID: fmt.Sprint(subjectID), ViewerReactions: []string{}}
+			counts := map[githubv4.ReactionContent]*int{
+				githubv4.ReactionContentThumbsUp:   &summary.Reactions.PlusOne,
+				githubv4.ReactionContentThumbsDown: &summary.Reactions.MinusOne,
+				githubv4.ReactionContentLaugh:      &summary.Reactions.Laugh,
+				githubv4.ReactionContentConfused:   &summary.Reactions.Confused,
+				githubv4.ReactionContentHeart:      &summary.Reactions.Heart,
+				githubv4.ReactionContentHooray:     &summary.Reactions.Hooray,
+				githubv4.ReactionContentRocket:     &summary.Reactions.Rocket,
+				githubv4.ReactionContentEyes:       &summary.Reactions.Eyes,
+			}
+			for _, group := range q.Node.Reactable.ReactionGroups {
+				count := int(group.Reactors.TotalCount)
+				summary.Reactions.TotalCount += count
+				if c, ok := counts[group.Content]; ok {
+					*c = count
+				}
+				if group.ViewerHasReacted {
+					for name, content := range reactionContents {
+						if content == group.Content {
+							summary.ViewerReactions = append(summary.ViewerReactions, name)
+						}
+					}
+				}
+			}
+			return MarshalledTextResult(summary), nil, nil
+		},
+	)
+}
+
+// ReactionWrite creates a tool to add or remove a reaction of the authenticated user on an issue,
+// pull request, review, discussion or comment.
+func ReactionWrite(t translations.TranslationHelperFunc) inventory.ServerTool {
+	properties := reactionSubjectProperties()
+	properties["method"] = &jsonschema.Schema{
+		Type: "string",
+		Description: `The write operation to perform.
+Options are:
+- 'add' - react with the content.
+- 'remove' - remove your reaction with the content.
+`,
+		Enum: []any{"add", "remove"},
+	}
+	properties["content"] = &jsonschema.Schema{
+		Type:        "string",
+		Description: "The reaction",
+		Enum:        []any{"+1", "-1", "laugh", "confused", "heart", "hooray", "rocket", "eyes"},
+	}
+
+	return NewTool(
+		ToolsetMetadataIssues,
+		mcp.Tool{
+			Name:        "reaction_write",
+			Description: t("TOOL_REACTION_WRITE_DESCRIPTION", "Add or remove an emoji reaction of the authenticated user on an issue, pull request, pull request review, discussion or one of their comments. Prefer a reaction to a comment to acknowledge feedback without notifying everyone subscribed."),
+			Annotations: &mcp.ToolAnnotations{
+				Title:          t("TOOL_REACTION_WRITE_USER_TITLE", "Add or remove reaction"),
+				ReadOnlyHint:   false,
+				IdempotentHint: true,
+			},
+			InputSchema: &jsonschema.Schema{
+				Type:       "object",
+				Properties: properties,
+				Required:   []string{"method", "subject_type", "content"},
+			},
+		},
+		[]scopes.Scope{scopes.Repo},
+		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+			method, err := RequiredParam[string](args, "method")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			if method != "add" && method != "remove" {
+				return utils.NewToolResultError(fmt.Sprintf("unknown method: %s", method)), nil, nil
+			}
+			contentName, err := RequiredParam[string](args, "content")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			content, ok := reactionContents[contentName]
+			if !ok {
+				return utils.NewToolResultError(fmt.Sprintf("invalid content: %s", contentName)), nil, nil
+			}
+			subjectID, result, err := resolveReactionSubject(ctx, deps, args)
+			if result != nil || err != nil {
+				return result, nil, err
+			}
+
+			client, err := deps.GetGQLClient(ctx)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to get GitHub GQL client: %w", err)
+			}
+			if method == "add" {
+				var mutation struct {
+					AddReaction struct {
+						Reaction struct {
+							Content githubv4.ReactionContent
+						}
+					} `graphql:"addReaction(input: $input)"`
+				}
+				if err := client.Mutate(ctx, &mutation, githubv4.AddReactionInput{SubjectID: subjectID, Content: content}, nil); err != nil {
+					return ghErrors.NewGitHubGraphQLErrorResponse(ctx, "failed to add reaction", err), nil, nil
+				}
+				return utils.NewToolResultText(fmt.Sprintf("reacted with %s", contentName)), nil, nil
+			}
+
+			var mutation struct {
+				RemoveReaction struct {
+					Reaction struct {
+						Content githubv4.ReactionContent
+					}
+				} `graphql:"removeReaction(input: $input)"`
+			}
+			if err := client.Mutate(ctx, &mutation, githubv4.RemoveReactionInput{SubjectID: subjectID, Content: content}, nil); err != nil {
+				return ghErrors.NewGitHubGraphQLErrorResponse(ctx, "failed to remove reaction", err), nil, nil
+			}
+			return utils.NewToolResultText(fmt.Sprintf("removed the %s reaction", contentName)), nil, nil
+		},
+	)
+}
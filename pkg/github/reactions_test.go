@@ -0,0 +1,176 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/githubv4mock"
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v82/github"
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/shurcooL/githubv4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	GetReposPullsReviewsByOwnerByRepoByPullNumberByReviewID = "GET /repos/{owner}/{repo}/pulls/{pull_number}/reviews/{review_id}"
+	GetReposIssuesCommentsByOwnerByRepoByCommentID          = "GET /repos/{owner}/{repo}/issues/comments/{comment_id}"
+)
+
+func Test_ListReactions(t *testing.T) {
+	serverTool := ListReactions(translations.NullTranslationHelper)
+	tool := serverTool.Tool
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_reactions", tool.Name)
+	assert.True(t, tool.Annotations.ReadOnlyHint)
+	schema := tool.InputSchema.(*jsonschema.Schema)
+	assert.ElementsMatch(t, schema.Required, []string{"subject_type"})
+
+	restClient := github.NewClient(MockHTTPClientWithHandlers(map[string]http.HandlerFunc{
+		GetReposIssuesByOwnerByRepoByIssueNumber: mockResponse(t, http.StatusOK, &github.Issue{Number: github.Ptr(42), NodeID: github.Ptr("I_42")}),
+	}))
+	gqlClient := githubv4.NewClient(githubv4mock.NewMockedHTTPClient(
+		githubv4mock.NewQueryMatcher(
+			struct {
+				Node struct {
+					Reactable struct {
+						ReactionGroups []struct {
+							Content  githubv4.ReactionContent
+							Reactors struct {
+								TotalCount githubv4.Int
+							}
+							ViewerHasReacted githubv4.Boolean
+						}
+					} `graphql:"... on Reactable"`
+				} `graphql:"node(id: $id)"`
+			}{},
+			map[string]any{"id": githubv4.ID("I_42")},
+			githubv4mock.DataResponse(map[string]any{
+				"node": map[string]any{
+					"reactionGroups": []map[string]any{
+						{"content": "THUMBS_UP", "reactors": map[string]any{"totalCount": 5}, "viewerHasReacted": true},
+						{"content": "HEART", "reactors": map[string]any{"totalCount": 2}, "viewerHasReacted": false},
+						{"content": "EYES", "reactors": map[string]any{"totalCount": 0}, "viewerHasReacted": false},
+					},
+				},
+			}),
+		),
+	))
+	deps := BaseDeps{Client: restClient, GQLClient: gqlClient}
+
+	request := createMCPRequest(map[string]any{"subject_type": "issue", "owner": "octo", "repo": "ink", "number": float64(42)})
+	result, err := serverTool.Handler(deps)(ContextWithDeps(context.Background(), deps), &request)
+	require.NoError(t, err)
+	require.False(t, result.IsError, getTextResult(t, result).Text)
+
+	var summary ReactionSummary
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &summary))
+	assert.Equal(t, ReactionSummary{
+		SubjectID:       "I_42",
+		Reactions:       MinimalReactions{TotalCount: 7, PlusOne: 5, Heart: 2},
+		ViewerReactions: []string{"+1"},
+	}, summary)
+}
+
+func Test_ReactionWrite(t *testing.T) {
+	serverTool := ReactionWrite(translations.NullTranslationHelper)
+	tool := serverTool.Tool
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "reaction_write", tool.Name)
+	assert.False(t, tool.Annotations.ReadOnlyHint)
+	schema := tool.InputSchema.(*jsonschema.Schema)
+	assert.ElementsMatch(t, schema.Required, []string{"method", "subject_type", "content"})
+
+	addMutation := struct {
+		AddReaction struct {
+			Reaction struct {
+				Content githubv4.ReactionContent
+			}
+		} `graphql:"addReaction(input: $input)"`
+	}{}
+	removeMutation := struct {
+		RemoveReaction struct {
+			Reaction struct {
+				Content githubv4.ReactionContent
+			}
+		} `graphql:"removeReaction(input: $input)"`
+	}{}
+
+	tests := []struct {
+		name           string
+		args           map[string]any
+		mutation       githubv4mock.Matcher
+		expectedText   string
+		expectedErrMsg string
+	}{
+		{
+			name: "add to a pull request review",
+			args: map[string]any{"method": "add", "subject_type": "pull_request_review", "owner": "octo", "repo": "ink", "number": float64(7), "review_id": float64(99), "content": "rocket"},
+			mutation: githubv4mock.NewMutationMatcher(addMutation,
+				githubv4.AddReactionInput{SubjectID: "PRR_99", Content: githubv4.ReactionContentRocket}, nil,
+				githubv4mock.DataResponse(map[string]any{"addReaction": map[string]any{"reaction": map[string]any{"content": "ROCKET"}}})),
+			expectedText: "reacted with rocket",
+		},
+		{
+			name: "add to an issue comment",
+			args: map[string]any{"method": "add", "subject_type": "issue_comment", "owner": "octo", "repo": "ink", "comment_id": float64(1234), "content": "+1"},
+			mutation: githubv4mock.NewMutationMatcher(addMutation,
+				githubv4.AddReactionInput{SubjectID: "IC_1234", Content: githubv4.ReactionContentThumbsUp}, nil,
+				githubv4mock.DataResponse(map[string]any{"addReaction": map[string]any{"reaction": map[string]any{"content": "THUMBS_UP"}}})),
+			expectedText: "reacted with +1",
+		},
+		{
+			name: "remove from a discussion comment",
+			args: map[string]any{"method": "remove", "subject_type": "discussion_comment", "node_id": "DC_1", "content": "heart"},
+			mutation: githubv4mock.NewMutationMatcher(removeMutation,
+				githubv4.RemoveReactionInput{SubjectID: "DC_1", Content: githubv4.ReactionContentHeart}, nil,
+				githubv4mock.DataResponse(map[string]any{"removeReaction": map[string]any{"reaction": map[string]any{"content": "HEART"}}})),
+			expectedText: "removed the heart reaction",
+		},
+		{
+			name:           "discussion comment without node ID",
+			args:           map[string]any{"method": "add", "subject_type": "discussion_comment", "owner": "octo", "repo": "ink", "content": "heart"},
+			expectedErrMsg: "node_id is required for discussion comments",
+		},
+		{
+			name:           "invalid content",
+			args:           map[string]any{"method": "add", "subject_type": "issue", "owner": "octo", "repo": "ink", "number": float64(42), "content": "thumbsup"},
+			expectedErrMsg: "invalid content: thumbsup",
+		},
+		{
+			name:           "pull request review without review ID",
+			args:           map[string]any{"method": "add", "subject_type": "pull_request_review", "owner": "octo", "repo": "ink", "number": float64(7), "content": "eyes"},
+			expectedErrMsg: "missing required parameter: review_id",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var matchers []githubv4mock.Matcher
+			if tc.mutation.Request != "" {
+				matchers = append(matchers, tc.mutation)
+			}
+			deps := BaseDeps{
+				Client: github.NewClient(MockHTTPClientWithHandlers(map[string]http.HandlerFunc{
+					GetReposPullsReviewsByOwnerByRepoByPullNumberByReviewID: mockResponse(t, http.StatusOK, &github.PullRequestReview{ID: github.Ptr(int64(99)), NodeID: github.Ptr("PRR_99")}),
+					GetReposIssuesCommentsByOwnerByRepoByCommentID:          mockResponse(t, http.StatusOK, &github.IssueComment{ID: github.Ptr(int64(1234)), NodeID: github.Ptr("IC_1234")}),
+				})),
+				GQLClient: githubv4.NewClient(githubv4mock.NewMockedHTTPClient(matchers...)),
+			}
+			request := createMCPRequest(tc.args)
+			result, err := serverTool.Handler(deps)(ContextWithDeps(context.Background(), deps), &request)
+			require.NoError(t, err)
+			if tc.expectedErrMsg != "" {
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+			assert.Equal(t, tc.expectedText, getTextResult(t, result).Text)
+		})
+	}
+}
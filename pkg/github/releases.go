@@ -0,0 +1,687 @@
+package github
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"path/filepath"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/inventory"
+	"github.com/github/github-mcp-server/pkg/scopes"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/github/github-mcp-server/pkg/utils"
+	"github.com/google/go-github/v82/github"
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// maxReleaseAssetDownloadSize is the size above which download_release_asset returns the
+// download URL of an asset instead of its content.
+const maxReleaseAssetDownloadSize = 1024 * 1024
+
+// MinimalReleaseAsset is the summary of a release asset returned by the release tools.
+type MinimalReleaseAsset struct {
+	ID                 int64  `json:"id"`
+	Name               string `json:"name"`
+	Label              string `json:"label,omitempty"`
+	ContentType        string `json:"content_type,omitempty"`
+	State              string `json:"state,omitempty"`
+	Size               int    `json:"size"`
+	DownloadCount      int    `json:"download_count"`
+	BrowserDownloadURL string `json:"browser_download_url,omitempty"`
+	UpdatedAt          string `json:"updated_at,omitempty"`
+}
+
+func convertToMinimalReleaseAsset(asset *github.ReleaseAsset) MinimalReleaseAsset {
+	m := MinimalReleaseAsset{
+		ID:                 asset.GetID(),
+		Name:               asset.GetName(),
+		Label:              asset.GetLabel(),
+		ContentType:        asset.GetContentType(),
+		State:              asset.GetState(),
+		Size:               asset.GetSize(),
+		DownloadCount:      asset.GetDownloadCount(),
+		BrowserDownloadURL: asset.GetBrowserDownloadURL(),
+	}
+	if asset.UpdatedAt != nil {
+		m.UpdatedAt = asset.UpdatedAt.Format(time.RFC3339)
+	}
+	return m
+}
+
+// releaseSchemaProperties are the properties of a release set by create_release and update_release.
+func releaseSchemaProperties() map[string]*jsonschema.Schema {
+	return map[string]*jsonschema.Schema{
+		"owner": {
+			Type:        "string",
+			Description: "Repository owner",
+		},
+		"repo": {
+			Type:        "string",
+			Description: "Repository name",
+		},
+		"tag_name": {
+			Type:        "string",
+			Description: "Tag of the release (e.g., 'v1.0.0')",
+		},
+		"target_commitish": {
+			Type:        "string",
+			Description: "Branch or commit SHA the tag is created from when it does not exist yet. Defaults to the default branch",
+		},
+		"name": {
+			Type:        "string",
+			Description: "Release title",
+		},
+		"body": {
+			Type:        "string",
+			Description: "Release notes in markdown",
+		},
+		"draft": {
+			Type:        "boolean",
+			Description: "Whether the release is a draft, visible only to users with push access",
+		},
+		"prerelease": {
+			Type:        "boolean",
+			Description: "Whether the release is a prerelease",
+		},
+		"make_latest": {
+			Type:        "string",
+			Description: "Whether the release is marked as the latest release. 'legacy' marks the latest release by creation date and semantic version",
+			Enum:        []any{"true", "false", "legacy"},
+		},
+	}
+}
+
+// releaseParams reads the release properties set in a call to create_release or update_release.
+// Properties left out of the call are left nil.
+func releaseParams(args map[string]any) (*github.RepositoryRelease, error) {
+	release := &github.RepositoryRelease{}
+	for name, field := range map[string]**string{
+		"tag_name":         &release.TagName,
+		"target_commitish": &release.TargetCommitish,
+		"name":             &release.Name,
+		"body":             &release.Body,
+		"make_latest":      &release.MakeLatest,
+	} {
+		value, ok, err := OptionalParamOK[string](args, name)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			*field = github.Ptr(value)
+		}
+	}
+	for name, field := range map[string]**bool{
+		"draft":      &release.Draft,
+		"prerelease": &release.Prerelease,
+	} {
+		value, ok, err := OptionalParamOK[bool](args, name)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			*field = github.Ptr(value)
+		}
+	}
+	return release, nil
+}
+
+// CreateRelease creates a tool to create a release in a GitHub repository.
+func CreateRelease(t translations.TranslationHelperFunc) inventory.ServerTool {
+	properties := releaseSchemaProperties()
+	properties["generate_release_notes"] = &jsonschema.Schema{
+		Type:        "boolean",
+		Description: "Generate the name and notes of the release from the changes since the previous release. A provided name and body are used instead of, or prepended to, the generated ones",
+	}
+	return NewTool(
+		ToolsetMetadataReleases,
+		mcp.Tool{
+			Name:        "create_release",
+			Description: t("TOOL_CREATE_RELEASE_DESCRIPTION", "Create a release in a GitHub repository, creating its tag if it does not exist. Create it as a draft to upload assets before publishing it with 'update_release'."),
+			Annotations: &mcp.ToolAnnotations{
+				Title:        t("TOOL_CREATE_RELEASE_USER_TITLE", "Create release"),
+				ReadOnlyHint: false,
+			},
+			InputSchema: &jsonschema.Schema{
+				Type:       "object",
+				Properties: properties,
+				Required:   []string{"owner", "repo", "tag_name"},
+			},
+		},
+		[]scopes.Scope{scopes.Repo},
+		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+			owner, err := RequiredParam[string](args, "owner")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			repo, err := RequiredParam[string](args, "repo")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			if _, err := RequiredParam[string](args, "tag_name"); err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			release, err := releaseParams(args)
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			generateNotes, err := OptionalParam[bool](args, "generate_release_notes")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			if generateNotes {
+				release.GenerateReleaseNotes = github.Ptr(true)
+			}
+
+			client, err := deps.GetClient(ctx)
+			if err != nil {
+				return utils.NewToolResultErrorFromErr("failed to get GitHub client", err), nil, nil
+			}
+
+			created, resp, err := client.Repositories.CreateRelease(ctx, owner, repo, release)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to create release %s", release.GetTagName()),
+					resp,
+					err,
+				), nil, nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return MarshalledTextResult(convertToMinimalRelease(created)), nil, nil
+		},
+	)
+}
+
+// UpdateRelease creates a tool to update a release in a GitHub repository.
+func UpdateRelease(t translations.TranslationHelperFunc) inventory.ServerTool {
+	properties := releaseSchemaProperties()
+	properties["release_id"] = &jsonschema.Schema{
+		Type:        "number",
+		Description: "ID of the release",
+	}
+	return NewTool(
+		ToolsetMetadataReleases,
+		mcp.Tool{
+			Name:        "update_release",
+			Description: t("TOOL_UPDATE_RELEASE_DESCRIPTION", "Update a release in a GitHub repository. Only the provided fields are changed. Set 'draft' to false to publish a draft release."),
+			Annotations: &mcp.ToolAnnotations{
+				Title:        t("TOOL_UPDATE_RELEASE_USER_TITLE", "Update release"),
+				ReadOnlyHint: false,
+			},
+			InputSchema: &jsonschema.Schema{
+				Type:       "object",
+				Properties: properties,
+				Required:   []string{"owner", "repo", "release_id"},
+			},
+		},
+		[]scopes.Scope{scopes.Repo},
+		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+			owner, err := RequiredParam[string](args, "owner")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			repo, err := RequiredParam[string](args, "repo")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			releaseID, err := RequiredBigInt(args, "release_id")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			release, err := releaseParams(args)
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+
+			client, err := deps.GetClient(ctx)
+			if err != nil {
+				return utils.NewToolResultErrorFromErr("failed to get GitHub client", err), nil, nil
+			}
+
+			updated, resp, err := client.Repositories.EditRelease(ctx, owner, repo, releaseID, release)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to update release %d", releaseID),
+					resp,
+					err,
+				), nil, nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return MarshalledTextResult(convertToMinimalRelease(updated)), nil, nil
+		},
+	)
+}
+
+// GenerateReleaseNotes creates a tool to generate the notes of a release without creating it.
+func GenerateReleaseNotes(t translations.TranslationHelperFunc) inventory.ServerTool {
+	return NewTool(
+		ToolsetMetadataReleases,
+		mcp.Tool{
+			Name:        "generate_release_notes",
+			Description: t("TOOL_GENERATE_RELEASE_NOTES_DESCRIPTION", "Generate the name and markdown notes of a release from the pull requests merged since the previous release, without creating it. Follows the repository's .github/release.yml when it has one."),
+			Annotations: &mcp.ToolAnnotations{
+				Title:        t("TOOL_GENERATE_RELEASE_NOTES_USER_TITLE", "Generate release notes"),
+				ReadOnlyHint: true,
+			},
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"owner": {
+						Type:        "string",
+						Description: "Repository owner",
+					},
+					"repo": {
+						Type:        "string",
+						Description: "Repository name",
+					},
+					"tag_name": {
+						Type:        "string",
+						Description: "Tag of the release, which need not exist yet",
+					},
+					"previous_tag_name": {
+						Type:        "string",
+						Description: "Tag of the previous release to list the changes since. Defaults to the latest release",
+					},
+					"target_commitish": {
+						Type:        "string",
+						Description: "Branch or commit SHA the tag will be created from when it does not exist yet",
+					},
+					"configuration_file_path": {
+						Type:        "string",
+						Description: "Path of a release notes configuration file to use instead of .github/release.yml",
+					},
+				},
+				Required: []string{"owner", "repo", "tag_name"},
+			},
+		},
+		[]scopes.Scope{scopes.Repo},
+		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+			owner, err := RequiredParam[string](args, "owner")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			repo, err := RequiredParam[string](args, "repo")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			tagName, err := RequiredParam[string](args, "tag_name")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			opts := &github.GenerateNotesOptions{TagName: tagName}
+			for name, field := range map[string]**string{
+				"previous_tag_name":       &opts.PreviousTagName,
+				"target_commitish":        &opts.TargetCommitish,
+				"configuration_file_path": &opts.ConfigurationFilePath,
+			} {
+				value, err := OptionalParam[string](args, name)
+				if err != nil {
+					return utils.NewToolResultError(err.Error()), nil, nil
+				}
+				if value != "" {
+					*field = github.Ptr(value)
+				}
+			}
+
+			client, err := deps.GetClient(ctx)
+			if err != nil {
+				return utils.NewToolResultErrorFromErr("failed to get GitHub client", err), nil, nil
+			}
+
+			notes, resp, err := client.Repositories.GenerateReleaseNotes(ctx, owner, repo, opts)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to generate release notes for %s", tagName),
+					resp,
+					err,
+				), nil, nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return MarshalledTextResult(notes), nil, nil
+		},
+	)
+}
+
+// ListReleaseAssets creates a tool to list the assets of a release.
+func ListReleaseAssets(t translations.TranslationHelperFunc) inventory.ServerTool {
+	return NewTool(
+		ToolsetMetadataReleases,
+		mcp.Tool{
+			Name:        "list_release_assets",
+			Description: t("TOOL_LIST_RELEASE_ASSETS_DESCRIPTION", "List the assets uploaded to a release"),
+			Annotations: &mcp.ToolAnnotations{
+				Title:        t("TOOL_LIST_RELEASE_ASSETS_USER_TITLE", "List release assets"),
+				ReadOnlyHint: true,
+			},
+			InputSchema: WithPagination(&jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"owner": {
+						Type:        "string",
+						Description: "Repository owner",
+					},
+					"repo": {
+						Type:        "string",
+						Description: "Repository name",
+					},
+					"release_id": {
+						Type:        "number",
+						Description: "ID of the release",
+					},
+				},
+				Required: []string{"owner", "repo", "release_id"},
+			}),
+		},
+		[]scopes.Scope{scopes.Repo},
+		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+			owner, err := RequiredParam[string](args, "owner")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			repo, err := RequiredParam[string](args, "repo")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			releaseID, err := RequiredBigInt(args, "release_id")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			pagination, err := OptionalPaginationParams(args)
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+
+			client, err := deps.GetClient(ctx)
+			if err != nil {
+				return utils.NewToolResultErrorFromErr("failed to get GitHub client", err), nil, nil
+			}
+
+			assets, resp, err := client.Repositories.ListReleaseAssets(ctx, owner, repo, releaseID, &github.ListOptions{
+				Page:    pagination.Page,
+				PerPage: pagination.PerPage,
+			})
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to list the assets of release %d", releaseID),
+					resp,
+					err,
+				), nil, nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			minimalAssets := make([]MinimalReleaseAsset, 0, len(assets))
+			for _, asset := range assets {
+				if asset != nil {
+					minimalAssets = append(minimalAssets, convertToMinimalReleaseAsset(asset))
+				}
+			}
+			return MarshalledTextResult(minimalAssets), nil, nil
+		},
+	)
+}
+
+// UploadReleaseAsset creates a tool to upload an asset to a release.
+func UploadReleaseAsset(t translations.TranslationHelperFunc) inventory.ServerTool {
+	return NewTool(
+		ToolsetMetadataReleases,
+		mcp.Tool{
+			Name:        "upload_release_asset",
+			Description: t("TOOL_UPLOAD_RELEASE_ASSET_DESCRIPTION", "Upload a file as an asset of a release. Binary files must be base64 encoded."),
+			Annotations: &mcp.ToolAnnotations{
+				Title:        t("TOOL_UPLOAD_RELEASE_ASSET_USER_TITLE", "Upload release asset"),
+				ReadOnlyHint: false,
+			},
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"owner": {
+						Type:        "string",
+						Description: "Repository owner",
+					},
+					"repo": {
+						Type:        "string",
+						Description: "Repository name",
+					},
+					"release_id": {
+						Type:        "number",
+						Description: "ID of the release",
+					},
+					"name": {
+						Type:        "string",
+						Description: "File name of the asset",
+					},
+					"content": {
+						Type:        "string",
+						Description: "Content of the asset",
+					},
+					"encoding": {
+						Type:        "string",
+						Description: "Encoding of the content. Defaults to utf-8",
+						Enum:        []any{"utf-8", "base64"},
+						Default:     json.RawMessage(`"utf-8"`),
+					},
+					"content_type": {
+						Type:        "string",
+						Description: "Media type of the asset. Defaults to the type of the file name's extension",
+					},
+					"label": {
+						Type:        "string",
+						Description: "Label shown instead of the file name in the list of assets",
+					},
+				},
+				Required: []string{"owner", "repo", "release_id", "name", "content"},
+			},
+		},
+		[]scopes.Scope{scopes.Repo},
+		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+			owner, err := RequiredParam[string](args, "owner")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			repo, err := RequiredParam[string](args, "repo")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			releaseID, err := RequiredBigInt(args, "release_id")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			name, err := RequiredParam[string](args, "name")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			content, err := RequiredParam[string](args, "content")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			encoding, err := OptionalParam[string](args, "encoding")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			contentType, err := OptionalParam[string](args, "content_type")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			label, err := OptionalParam[string](args, "label")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+
+			data := []byte(content)
+			switch encoding {
+			case "", "utf-8":
+			case "base64":
+				data, err = base64.StdEncoding.DecodeString(content)
+				if err != nil {
+					return utils.NewToolResultError(fmt.Sprintf("content is not valid base64: %v", err)), nil, nil
+				}
+			default:
+				return utils.NewToolResultError(fmt.Sprintf("invalid encoding %q: must be utf-8 or base64", encoding)), nil, nil
+			}
+			if contentType == "" {
+				contentType = mime.TypeByExtension(filepath.Ext(name))
+			}
+			if contentType == "" {
+				contentType = "application/octet-stream"
+			}
+
+			client, err := deps.GetClient(ctx)
+			if err != nil {
+				return utils.NewToolResultErrorFromErr("failed to get GitHub client", err), nil, nil
+			}
+
+			// Uploads are resolved against the client's UploadURL rather than the upload_url of
+			// the release, so they go to the upload host configured for the GitHub instance.
+			query := url.Values{"name": {name}}
+			if label != "" {
+				query.Set("label", label)
+			}
+			u := fmt.Sprintf("repos/%s/%s/releases/%d/assets?%s", url.PathEscape(owner), url.PathEscape(repo), releaseID, query.Encode())
+			req, err := client.NewUploadRequest(u, bytes.NewReader(data), int64(len(data)), contentType)
+			if err != nil {
+				return utils.NewToolResultErrorFromErr("failed to create upload request", err), nil, nil
+			}
+			asset := new(github.ReleaseAsset)
+			resp, err := client.Do(ctx, req, asset)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to upload %s to release %d", name, releaseID),
+					resp,
+					err,
+				), nil, nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			return MarshalledTextResult(convertToMinimalReleaseAsset(asset)), nil, nil
+		},
+	)
+}
+
+// DownloadReleaseAsset creates a tool to download the content of a release asset.
+func DownloadReleaseAsset(t translations.TranslationHelperFunc) inventory.ServerTool {
+	return NewTool(
+		ToolsetMetadataReleases,
+		mcp.Tool{
+			Name:        "download_release_asset",
+			Description: t("TOOL_DOWNLOAD_RELEASE_ASSET_DESCRIPTION", "Download the content of a release asset. Assets larger than 1MB are returned as a link to download them instead."),
+			Annotations: &mcp.ToolAnnotations{
+				Title:        t("TOOL_DOWNLOAD_RELEASE_ASSET_USER_TITLE", "Download release asset"),
+				ReadOnlyHint: true,
+			},
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"owner": {
+						Type:        "string",
+						Description: "Repository owner",
+					},
+					"repo": {
+						Type:        "string",
+						Description: "Repository name",
+					},
+					"asset_id": {
+						Type:        "number",
+						Description: "ID of the asset, as returned by list_release_assets",
+					},
+				},
+				Required: []string{"owner", "repo", "asset_id"},
+			},
+		},
+		[]scopes.Scope{scopes.Repo},
+		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+			owner, err := RequiredParam[string](args, "owner")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			repo, err := RequiredParam[string](args, "repo")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			assetID, err := RequiredBigInt(args, "asset_id")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+
+			client, err := deps.GetClient(ctx)
+			if err != nil {
+				return utils.NewToolResultErrorFromErr("failed to get GitHub client", err), nil, nil
+			}
+
+			asset, resp, err := client.Repositories.GetReleaseAsset(ctx, owner, repo, assetID)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to get release asset %d", assetID),
+					resp,
+					err,
+				), nil, nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if asset.GetSize() > maxReleaseAssetDownloadSize {
+				size := int64(asset.GetSize())
+				return utils.NewToolResultResourceLink(
+					fmt.Sprintf("Asset %s is too large to display (%d bytes). Use the download URL to fetch the content: %s", asset.GetName(), size, asset.GetBrowserDownloadURL()),
+					&mcp.ResourceLink{
+						URI:      asset.GetBrowserDownloadURL(),
+						Name:     asset.GetName(),
+						MIMEType: asset.GetContentType(),
+						Size:     &size,
+					}), nil, nil
+			}
+
+			// Asset downloads redirect to signed URLs, which must be fetched without the token.
+			rc, _, err := client.Repositories.DownloadReleaseAsset(ctx, owner, repo, assetID, http.DefaultClient)
+			if err != nil {
+				return utils.NewToolResultErrorFromErr(fmt.Sprintf("failed to download release asset %d", assetID), err), nil, nil
+			}
+			defer func() { _ = rc.Close() }()
+			data, err := io.ReadAll(io.LimitReader(rc, maxReleaseAssetDownloadSize+1))
+			if err != nil {
+				return utils.NewToolResultErrorFromErr("failed to read release asset", err), nil, nil
+			}
+			if len(data) > maxReleaseAssetDownloadSize {
+				return utils.NewToolResultError(fmt.Sprintf("release asset %d is larger than 1MB; download it from %s", assetID, asset.GetBrowserDownloadURL())), nil, nil
+			}
+
+			contentType := asset.GetContentType()
+			if contentType == "" || contentType == "application/octet-stream" {
+				contentType = http.DetectContentType(data)
+			}
+			contents := &mcp.ResourceContents{
+				URI:      asset.GetBrowserDownloadURL(),
+				MIMEType: contentType,
+			}
+			if isTextMediaType(contentType) && utf8.Valid(data) {
+				contents.Text = string(data)
+				return utils.NewToolResultResource(fmt.Sprintf("successfully downloaded text asset %s", asset.GetName()), contents), nil, nil
+			}
+			contents.Blob = data
+			return utils.NewToolResultResource(fmt.Sprintf("successfully downloaded binary asset %s", asset.GetName()), contents), nil, nil
+		},
+	)
+}
+
+// isTextMediaType reports whether a media type is text, ignoring its parameters.
+func isTextMediaType(contentType string) bool {
+	mediaType, _, _ := strings.Cut(contentType, ";")
+	mediaType = strings.TrimSpace(mediaType)
+	return strings.HasPrefix(mediaType, "text/") ||
+		mediaType == "application/json" ||
+		mediaType == "application/xml" ||
+		mediaType == "application/yaml" ||
+		strings.HasSuffix(mediaType, "+json") ||
+		strings.HasSuffix(mediaType, "+xml")
+}
@@ -0,0 +1,281 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/inventory"
+	"github.com/github/github-mcp-server/pkg/sanitize"
+	"github.com/github/github-mcp-server/pkg/scopes"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/github/github-mcp-server/pkg/utils"
+	"github.com/google/go-github/v82/github"
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// maxChangelogReleasePages bounds the pages of releases get_version_changelog reads.
+const maxChangelogReleasePages = 5
+
+var (
+	// markdownHeading matches a markdown heading, capturing its level and text.
+	markdownHeading = regexp.MustCompile(`^(#{1,6})\s+(.*?)\s*#*\s*$`)
+	// breakingCommit matches the subject of a conventional commit marked as breaking, such as
+	// "feat(api)!: drop v1 endpoints".
+	breakingCommit = regexp.MustCompile(`^\w+(\([^)]*\))?!:`)
+)
+
+// ChangelogRelease is a release published between the two versions of a changelog.
+type ChangelogRelease struct {
+	Tag         string `json:"tag"`
+	Name        string `json:"name,omitempty"`
+	URL         string `json:"url"`
+	PublishedAt string `json:"published_at"`
+	Prerelease  bool   `json:"prerelease,omitempty"`
+	Notes       string `json:"notes,omitempty"`
+}
+
+// BreakingChange is a breaking change announced by a release or a commit.
+type BreakingChange struct {
+	Release string `json:"release,omitempty"`
+	Commit  string `json:"commit,omitempty"`
+	Text    string `json:"text"`
+}
+
+// VersionChangelog is the result of get_version_changelog.
+type VersionChangelog struct {
+	Repository string `json:"repository"`
+	From       string `json:"from"`
+	To         string `json:"to"`
+	CompareURL string `json:"compare_url"`
+	Commits    int    `json:"commits"`
+	// CommitsScanned is the number of commits whose messages were searched for breaking changes,
+	// which the compare API limits
+	CommitsScanned  int                `json:"commits_scanned"`
+	Releases        []ChangelogRelease `json:"releases"`
+	BreakingChanges []BreakingChange   `json:"breaking_changes"`
+}
+
+// breakingSections returns the sections of release notes whose heading mentions breaking
+// changes, and the lines outside them announcing one.
+func breakingSections(notes string) []string {
+	var sections []string
+	var section []string
+	sectionLevel := 0
+	flush := func() {
+		if text := strings.TrimSpace(strings.Join(section, "\n")); text != "" {
+			sections = append(sections, text)
+		}
+		section, sectionLevel = nil, 0
+	}
+	for _, line := range strings.Split(strings.ReplaceAll(notes, "\r\n", "\n"), "\n") {
+		if m := markdownHeading.FindStringSubmatch(strings.TrimSpace(line)); m != nil {
+			level := len(m[1])
+			if sectionLevel > 0 && level <= sectionLevel {
+				flush()
+			}
+			if sectionLevel > 0 {
+				// Subheadings are part of the section
+				section = append(section, line)
+				continue
+			}
+			if strings.Contains(strings.ToLower(m[2]), "breaking") {
+				sectionLevel = level
+			}
+			continue
+		}
+		switch {
+		case sectionLevel > 0:
+			section = append(section, line)
+		case strings.Contains(line, "BREAKING"):
+			sections = append(sections, strings.TrimSpace(line))
+		}
+	}
+	flush()
+	return sections
+}
+
+// isBreakingCommit reports whether a commit message announces a breaking change, following the
+// conventional commits specification.
+func isBreakingCommit(message string) bool {
+	subject, _, _ := strings.Cut(message, "\n")
+	return breakingCommit.MatchString(subject) || strings.Contains(message, "BREAKING CHANGE") || strings.Contains(message, "BREAKING-CHANGE")
+}
+
+// GetVersionChangelog creates a tool to summarize the changes of a repository between two
+// versions.
+func GetVersionChangelog(t translations.TranslationHelperFunc) inventory.ServerTool {
+	return NewTool(
+		ToolsetMetadataReleases,
+		mcp.Tool{
+			Name: "get_version_changelog",
+			Description: t("TOOL_GET_VERSION_CHANGELOG_DESCRIPTION", `Get what changed in a repository between two versions, such as a dependency being upgraded: the releases published after the from version up to the to version, the number of commits between them, and the breaking changes announced by the release notes' breaking changes sections and by conventional commit messages.
+Use it to decide whether an upgrade needs code changes. Breaking changes not announced in either place are not found.`),
+			Annotations: &mcp.ToolAnnotations{
+				Title:        t("TOOL_GET_VERSION_CHANGELOG_USER_TITLE", "Get changelog between versions"),
+				ReadOnlyHint: true,
+			},
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"owner": {
+						Type:        "string",
+						Description: "Repository owner",
+					},
+					"repo": {
+						Type:        "string",
+						Description: "Repository name",
+					},
+					"from": {
+						Type:        "string",
+						Description: "Tag of the version upgraded from (e.g., 'v1.2.0')",
+					},
+					"to": {
+						Type:        "string",
+						Description: "Tag of the version upgraded to (e.g., 'v2.0.0')",
+					},
+					"include_notes": {
+						Type:        "boolean",
+						Description: "Include the full notes of each release, not only their breaking changes",
+						Default:     json.RawMessage(`false`),
+					},
+				},
+				Required: []string{"owner", "repo", "from", "to"},
+			},
+		},
+		[]scopes.Scope{scopes.Repo},
+		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+			owner, err := RequiredParam[string](args, "owner")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			repo, err := RequiredParam[string](args, "repo")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			from, err := RequiredParam[string](args, "from")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			to, err := RequiredParam[string](args, "to")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			includeNotes, err := OptionalParam[bool](args, "include_notes")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+
+			client, err := deps.GetClient(ctx)
+			if err != nil {
+				return utils.NewToolResultErrorFromErr("failed to get GitHub client", err), nil, nil
+			}
+
+			comparison, resp, err := client.Repositories.CompareCommits(ctx, owner, repo, from, to, nil)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, fmt.Sprintf("failed to compare %s...%s", from, to), resp, err), nil, nil
+			}
+			_ = resp.Body.Close()
+			if comparison.GetStatus() == "behind" {
+				return utils.NewToolResultError(fmt.Sprintf("%s is newer than %s: swap from and to", from, to)), nil, nil
+			}
+
+			changelog := VersionChangelog{
+				Repository:      owner + "/" + repo,
+				From:            from,
+				To:              to,
+				CompareURL:      comparison.GetHTMLURL(),
+				Commits:         comparison.GetAheadBy(),
+				CommitsScanned:  len(comparison.Commits),
+				Releases:        []ChangelogRelease{},
+				BreakingChanges: []BreakingChange{},
+			}
+
+			// Releases are listed newest first. The release of a tag is published after its
+			// commit, so once a page ends before the from commit, the from release was seen.
+			fromCommitDate := comparison.GetBaseCommit().GetCommit().GetCommitter().GetDate().Time
+			var releases []*github.RepositoryRelease
+			opts := &github.ListOptions{PerPage: 100}
+			for page := 0; page < maxChangelogReleasePages; page++ {
+				pageReleases, resp, err := client.Repositories.ListReleases(ctx, owner, repo, opts)
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to list releases", resp, err), nil, nil
+				}
+				_ = resp.Body.Close()
+				releases = append(releases, pageReleases...)
+				if resp.NextPage == 0 || len(pageReleases) == 0 || pageReleases[len(pageReleases)-1].GetPublishedAt().Before(fromCommitDate) {
+					break
+				}
+				opts.Page = resp.NextPage
+			}
+
+			// The versions are bounded by the publication of their releases, or by the date of
+			// their commits when they have none
+			fromDate, toDate := fromCommitDate, time.Time{}
+			for _, release := range releases {
+				switch release.GetTagName() {
+				case from:
+					fromDate = release.GetPublishedAt().Time
+				case to:
+					toDate = release.GetPublishedAt().Time
+				}
+			}
+			if toDate.IsZero() {
+				commit, resp, err := client.Repositories.GetCommit(ctx, owner, repo, to, &github.ListOptions{PerPage: 1})
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx, fmt.Sprintf("failed to get commit of %s", to), resp, err), nil, nil
+				}
+				_ = resp.Body.Close()
+				toDate = commit.GetCommit().GetCommitter().GetDate().Time
+			}
+
+			// Releases are listed newest first: report them oldest first, as an upgrade goes
+			for i := len(releases) - 1; i >= 0; i-- {
+				release := releases[i]
+				published := release.GetPublishedAt().Time
+				if release.GetDraft() || release.GetTagName() == from || !published.After(fromDate) || published.After(toDate) {
+					continue
+				}
+				entry := ChangelogRelease{
+					Tag:         release.GetTagName(),
+					Name:        sanitize.Sanitize(release.GetName()),
+					URL:         release.GetHTMLURL(),
+					PublishedAt: published.Format(time.RFC3339),
+					Prerelease:  release.GetPrerelease(),
+				}
+				if includeNotes {
+					entry.Notes = sanitize.Sanitize(release.GetBody())
+				}
+				changelog.Releases = append(changelog.Releases, entry)
+				for _, section := range breakingSections(release.GetBody()) {
+					changelog.BreakingChanges = append(changelog.BreakingChanges, BreakingChange{
+						Release: release.GetTagName(),
+						Text:    sanitize.Sanitize(section),
+					})
+				}
+			}
+
+			for _, commit := range comparison.Commits {
+				message := commit.GetCommit().GetMessage()
+				if !isBreakingCommit(message) {
+					continue
+				}
+				sha := commit.GetSHA()
+				if len(sha) > 7 {
+					sha = sha[:7]
+				}
+				changelog.BreakingChanges = append(changelog.BreakingChanges, BreakingChange{
+					Commit: sha,
+					Text:   sanitize.Sanitize(strings.TrimSpace(message)),
+				})
+			}
+
+			return MarshalledTextResult(changelog), nil, nil
+		},
+	)
+}
@@ -0,0 +1,138 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v82/github"
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_breakingSections(t *testing.T) {
+	notes := `## What's Changed
+* Add retries by @octocat
+
+## ⚠️ Breaking Changes
+* Drop support for Go 1.22
+* Rename ` + "`Client.Do`" + `
+
+### Migration
+Use ` + "`Client.Send`" + `.
+
+## Fixes
+* BREAKING: the timeout is now in seconds
+* Fix a typo`
+
+	assert.Equal(t, []string{
+		"* Drop support for Go 1.22\n* Rename `Client.Do`\n\n### Migration\nUse `Client.Send`.",
+		"* BREAKING: the timeout is now in seconds",
+	}, breakingSections(notes))
+	assert.Empty(t, breakingSections("## Fixes\n* Fix a typo"))
+
+	assert.True(t, isBreakingCommit("feat(api)!: drop v1 endpoints"))
+	assert.True(t, isBreakingCommit("refactor: new config\n\nBREAKING CHANGE: the config file moved"))
+	assert.False(t, isBreakingCommit("fix: handle breaking news"))
+}
+
+func Test_GetVersionChangelog(t *testing.T) {
+	serverTool := GetVersionChangelog(translations.NullTranslationHelper)
+	tool := serverTool.Tool
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_version_changelog", tool.Name)
+	assert.True(t, tool.Annotations.ReadOnlyHint)
+	schema := tool.InputSchema.(*jsonschema.Schema)
+	assert.ElementsMatch(t, schema.Required, []string{"owner", "repo", "from", "to"})
+
+	day := func(d int) *github.Timestamp {
+		return &github.Timestamp{Time: time.Date(2026, 9, d, 12, 0, 0, 0, time.UTC)}
+	}
+	release := func(tag string, published int, body string) *github.RepositoryRelease {
+		return &github.RepositoryRelease{
+			TagName:     github.Ptr(tag),
+			Name:        github.Ptr(tag),
+			Body:        github.Ptr(body),
+			HTMLURL:     github.Ptr("https://github.com/octo/lib/releases/tag/" + tag),
+			PublishedAt: day(published),
+		}
+	}
+	releases := []*github.RepositoryRelease{
+		release("v2.1.0", 20, "## Features\n* Streaming"),
+		release("v2.0.0", 10, "## Breaking Changes\n* Drop Go 1.22"),
+		{TagName: github.Ptr("v2.0.0-draft"), Draft: github.Ptr(true), PublishedAt: day(9)},
+		release("v1.5.0", 5, "## Fixes\n* Fix a leak"),
+		release("v1.4.0", 1, "Initial"),
+	}
+
+	tests := []struct {
+		name             string
+		args             map[string]any
+		status           string
+		expectedReleases []string
+		expectedErrMsg   string
+	}{
+		{
+			name:             "changes between releases",
+			args:             map[string]any{"owner": "octo", "repo": "lib", "from": "v1.5.0", "to": "v2.1.0"},
+			status:           "ahead",
+			expectedReleases: []string{"v2.0.0", "v2.1.0"},
+		},
+		{
+			name:           "versions swapped",
+			args:           map[string]any{"owner": "octo", "repo": "lib", "from": "v2.1.0", "to": "v1.5.0"},
+			status:         "behind",
+			expectedErrMsg: "v2.1.0 is newer than v1.5.0: swap from and to",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			deps := BaseDeps{Client: github.NewClient(MockHTTPClientWithHandlers(map[string]http.HandlerFunc{
+				GetReposCompareByOwnerByRepoByBasehead: func(w http.ResponseWriter, r *http.Request) {
+					assert.Equal(t, "/repos/octo/lib/compare/"+tc.args["from"].(string)+"..."+tc.args["to"].(string), r.URL.Path)
+					mockResponse(t, http.StatusOK, &github.CommitsComparison{
+						Status:     github.Ptr(tc.status),
+						AheadBy:    github.Ptr(3),
+						HTMLURL:    github.Ptr("https://github.com/octo/lib/compare/v1.5.0...v2.1.0"),
+						BaseCommit: &github.RepositoryCommit{Commit: &github.Commit{Committer: &github.CommitAuthor{Date: day(4)}}},
+						Commits: []*github.RepositoryCommit{
+							{SHA: github.Ptr("aaaaaaaaaa"), Commit: &github.Commit{Message: github.Ptr("feat!: drop Go 1.22")}},
+							{SHA: github.Ptr("bbbbbbbbbb"), Commit: &github.Commit{Message: github.Ptr("fix: typo")}},
+							{SHA: github.Ptr("cccccccccc"), Commit: &github.Commit{Message: github.Ptr("feat: streaming")}},
+						},
+					})(w, r)
+				},
+				GetReposReleasesByOwnerByRepo: mockResponse(t, http.StatusOK, releases),
+			}))}
+			request := createMCPRequest(tc.args)
+			result, err := serverTool.Handler(deps)(ContextWithDeps(context.Background(), deps), &request)
+			require.NoError(t, err)
+			if tc.expectedErrMsg != "" {
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+			require.False(t, result.IsError, getTextResult(t, result).Text)
+
+			var changelog VersionChangelog
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &changelog))
+			var tags []string
+			for _, r := range changelog.Releases {
+				tags = append(tags, r.Tag)
+				assert.Empty(t, r.Notes)
+			}
+			assert.Equal(t, tc.expectedReleases, tags)
+			assert.Equal(t, 3, changelog.Commits)
+			assert.Equal(t, []BreakingChange{
+				{Release: "v2.0.0", Text: "* Drop Go 1.22"},
+				{Commit: "aaaaaaa", Text: "feat!: drop Go 1.22"},
+			}, changelog.BreakingChanges)
+		})
+	}
+}
@@ -0,0 +1,397 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v82/github"
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_CreateRelease(t *testing.T) {
+	serverTool := CreateRelease(translations.NullTranslationHelper)
+	tool := serverTool.Tool
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "create_release", tool.Name)
+	assert.False(t, tool.Annotations.ReadOnlyHint)
+	schema := tool.InputSchema.(*jsonschema.Schema)
+	assert.Contains(t, schema.Properties, "generate_release_notes")
+	assert.ElementsMatch(t, schema.Required, []string{"owner", "repo", "tag_name"})
+
+	mockRelease := &github.RepositoryRelease{
+		ID:         github.Ptr(int64(1)),
+		TagName:    github.Ptr("v1.2.0"),
+		Name:       github.Ptr("v1.2.0"),
+		Draft:      github.Ptr(true),
+		Prerelease: github.Ptr(true),
+		HTMLURL:    github.Ptr("https://github.com/owner/repo/releases/tag/v1.2.0"),
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]any
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "creates draft prerelease with generated notes",
+			mockedClient: MockHTTPClientWithHandlers(map[string]http.HandlerFunc{
+				PostReposReleasesByOwnerByRepo: expectRequestBody(t, map[string]any{
+					"tag_name":               "v1.2.0",
+					"target_commitish":       "main",
+					"draft":                  true,
+					"prerelease":             true,
+					"generate_release_notes": true,
+				}).andThen(mockResponse(t, http.StatusCreated, mockRelease)),
+			}),
+			requestArgs: map[string]any{
+				"owner":                  "owner",
+				"repo":                   "repo",
+				"tag_name":               "v1.2.0",
+				"target_commitish":       "main",
+				"draft":                  true,
+				"prerelease":             true,
+				"generate_release_notes": true,
+			},
+		},
+		{
+			name: "release already exists",
+			mockedClient: MockHTTPClientWithHandlers(map[string]http.HandlerFunc{
+				PostReposReleasesByOwnerByRepo: mockResponse(t, http.StatusUnprocessableEntity, map[string]any{
+					"message": "Validation Failed",
+					"errors":  []map[string]any{{"resource": "Release", "code": "already_exists", "field": "tag_name"}},
+				}),
+			}),
+			requestArgs: map[string]any{
+				"owner":    "owner",
+				"repo":     "repo",
+				"tag_name": "v1.2.0",
+			},
+			expectError:    true,
+			expectedErrMsg: "failed to create release v1.2.0",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			deps := BaseDeps{Client: github.NewClient(tc.mockedClient)}
+			handler := serverTool.Handler(deps)
+			request := createMCPRequest(tc.requestArgs)
+
+			result, err := handler(ContextWithDeps(context.Background(), deps), &request)
+			require.NoError(t, err)
+
+			if tc.expectError {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			var returned MinimalRelease
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returned))
+			assert.Equal(t, int64(1), returned.ID)
+			assert.True(t, returned.Draft)
+			assert.True(t, returned.Prerelease)
+		})
+	}
+}
+
+func Test_UpdateRelease(t *testing.T) {
+	serverTool := UpdateRelease(translations.NullTranslationHelper)
+	tool := serverTool.Tool
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "update_release", tool.Name)
+	schema := tool.InputSchema.(*jsonschema.Schema)
+	assert.ElementsMatch(t, schema.Required, []string{"owner", "repo", "release_id"})
+
+	// Only the provided fields are sent, so publishing a draft leaves its notes unchanged
+	mockedClient := MockHTTPClientWithHandlers(map[string]http.HandlerFunc{
+		PatchReposReleasesByOwnerByRepoByReleaseID: expectRequestBody(t, map[string]any{
+			"draft":       false,
+			"make_latest": "true",
+		}).andThen(mockResponse(t, http.StatusOK, &github.RepositoryRelease{
+			ID:      github.Ptr(int64(1)),
+			TagName: github.Ptr("v1.2.0"),
+			Draft:   github.Ptr(false),
+		})),
+	})
+	deps := BaseDeps{Client: github.NewClient(mockedClient)}
+	request := createMCPRequest(map[string]any{
+		"owner":       "owner",
+		"repo":        "repo",
+		"release_id":  float64(1),
+		"draft":       false,
+		"make_latest": "true",
+	})
+	result, err := serverTool.Handler(deps)(ContextWithDeps(context.Background(), deps), &request)
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	var returned MinimalRelease
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returned))
+	assert.False(t, returned.Draft)
+}
+
+func Test_GenerateReleaseNotes(t *testing.T) {
+	serverTool := GenerateReleaseNotes(translations.NullTranslationHelper)
+	tool := serverTool.Tool
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "generate_release_notes", tool.Name)
+	assert.True(t, tool.Annotations.ReadOnlyHint)
+
+	mockedClient := MockHTTPClientWithHandlers(map[string]http.HandlerFunc{
+		PostReposReleasesGenerateNotesByOwnerByRepo: expectRequestBody(t, map[string]any{
+			"tag_name":          "v1.2.0",
+			"previous_tag_name": "v1.1.0",
+		}).andThen(mockResponse(t, http.StatusOK, &github.RepositoryReleaseNotes{
+			Name: "v1.2.0",
+			Body: "## What's Changed\n* Add caching by @octocat in #42",
+		})),
+	})
+	deps := BaseDeps{Client: github.NewClient(mockedClient)}
+	request := createMCPRequest(map[string]any{
+		"owner":             "owner",
+		"repo":              "repo",
+		"tag_name":          "v1.2.0",
+		"previous_tag_name": "v1.1.0",
+	})
+	result, err := serverTool.Handler(deps)(ContextWithDeps(context.Background(), deps), &request)
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	var returned github.RepositoryReleaseNotes
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returned))
+	assert.Equal(t, "v1.2.0", returned.Name)
+	assert.Contains(t, returned.Body, "Add caching")
+}
+
+func Test_ListReleaseAssets(t *testing.T) {
+	serverTool := ListReleaseAssets(translations.NullTranslationHelper)
+	tool := serverTool.Tool
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_release_assets", tool.Name)
+	assert.True(t, tool.Annotations.ReadOnlyHint)
+
+	mockedClient := MockHTTPClientWithHandlers(map[string]http.HandlerFunc{
+		GetReposReleasesAssetsByOwnerByRepoByReleaseID: mockResponse(t, http.StatusOK, []*github.ReleaseAsset{
+			{
+				ID:                 github.Ptr(int64(7)),
+				Name:               github.Ptr("app-linux-amd64.tar.gz"),
+				ContentType:        github.Ptr("application/gzip"),
+				State:              github.Ptr("uploaded"),
+				Size:               github.Ptr(2048),
+				DownloadCount:      github.Ptr(12),
+				BrowserDownloadURL: github.Ptr("https://github.com/owner/repo/releases/download/v1.2.0/app-linux-amd64.tar.gz"),
+			},
+		}),
+	})
+	deps := BaseDeps{Client: github.NewClient(mockedClient)}
+	request := createMCPRequest(map[string]any{
+		"owner":      "owner",
+		"repo":       "repo",
+		"release_id": float64(1),
+	})
+	result, err := serverTool.Handler(deps)(ContextWithDeps(context.Background(), deps), &request)
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	var returned []MinimalReleaseAsset
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returned))
+	require.Len(t, returned, 1)
+	assert.Equal(t, int64(7), returned[0].ID)
+	assert.Equal(t, 12, returned[0].DownloadCount)
+}
+
+func Test_UploadReleaseAsset(t *testing.T) {
+	serverTool := UploadReleaseAsset(translations.NullTranslationHelper)
+	tool := serverTool.Tool
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "upload_release_asset", tool.Name)
+	assert.False(t, tool.Annotations.ReadOnlyHint)
+	schema := tool.InputSchema.(*jsonschema.Schema)
+	assert.ElementsMatch(t, schema.Required, []string{"owner", "repo", "release_id", "name", "content"})
+
+	tests := []struct {
+		name                string
+		requestArgs         map[string]any
+		expectedBody        string
+		expectedContentType string
+		expectedErrMsg      string
+	}{
+		{
+			name: "uploads text content",
+			requestArgs: map[string]any{
+				"owner":      "owner",
+				"repo":       "repo",
+				"release_id": float64(1),
+				"name":       "checksums.txt",
+				"content":    "abc123  app.tar.gz\n",
+				"label":      "Checksums",
+			},
+			expectedBody:        "abc123  app.tar.gz\n",
+			expectedContentType: "text/plain; charset=utf-8",
+		},
+		{
+			name: "uploads base64 content",
+			requestArgs: map[string]any{
+				"owner":        "owner",
+				"repo":         "repo",
+				"release_id":   float64(1),
+				"name":         "app.bin",
+				"content":      "AAEC",
+				"encoding":     "base64",
+				"content_type": "application/x-binary",
+			},
+			expectedBody:        "\x00\x01\x02",
+			expectedContentType: "application/x-binary",
+		},
+		{
+			name: "invalid base64 content",
+			requestArgs: map[string]any{
+				"owner":      "owner",
+				"repo":       "repo",
+				"release_id": float64(1),
+				"name":       "app.bin",
+				"content":    "not base64!",
+				"encoding":   "base64",
+			},
+			expectedErrMsg: "content is not valid base64",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockedClient := MockHTTPClientWithHandlers(map[string]http.HandlerFunc{
+				PostReposReleasesAssetsByOwnerByRepoByReleaseID: func(w http.ResponseWriter, r *http.Request) {
+					// Uploads go to the upload host rather than the API host
+					assert.Equal(t, "uploads.github.com", r.URL.Host)
+					assert.Equal(t, tc.requestArgs["name"], r.URL.Query().Get("name"))
+					assert.Equal(t, tc.expectedContentType, r.Header.Get("Content-Type"))
+					body, err := io.ReadAll(r.Body)
+					require.NoError(t, err)
+					assert.Equal(t, tc.expectedBody, string(body))
+					mockResponse(t, http.StatusCreated, &github.ReleaseAsset{
+						ID:   github.Ptr(int64(9)),
+						Name: github.Ptr(r.URL.Query().Get("name")),
+						Size: github.Ptr(len(body)),
+					})(w, r)
+				},
+			})
+			deps := BaseDeps{Client: github.NewClient(mockedClient)}
+			request := createMCPRequest(tc.requestArgs)
+
+			result, err := serverTool.Handler(deps)(ContextWithDeps(context.Background(), deps), &request)
+			require.NoError(t, err)
+
+			if tc.expectedErrMsg != "" {
+				require.True(t, result.IsError)
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			var returned MinimalReleaseAsset
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returned))
+			assert.Equal(t, int64(9), returned.ID)
+			assert.Equal(t, len(tc.expectedBody), returned.Size)
+		})
+	}
+}
+
+func Test_DownloadReleaseAsset(t *testing.T) {
+	serverTool := DownloadReleaseAsset(translations.NullTranslationHelper)
+	tool := serverTool.Tool
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "download_release_asset", tool.Name)
+	assert.True(t, tool.Annotations.ReadOnlyHint)
+
+	asset := func(name, contentType string, size int) *github.ReleaseAsset {
+		return &github.ReleaseAsset{
+			ID:                 github.Ptr(int64(7)),
+			Name:               github.Ptr(name),
+			ContentType:        github.Ptr(contentType),
+			Size:               github.Ptr(size),
+			BrowserDownloadURL: github.Ptr("https://github.com/owner/repo/releases/download/v1.2.0/" + name),
+		}
+	}
+	// The asset endpoint returns its metadata as JSON, and its content when asked for octet-stream
+	assetHandler := func(metadata *github.ReleaseAsset, content string) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get("Accept") == "application/octet-stream" {
+				_, _ = w.Write([]byte(content))
+				return
+			}
+			mockResponse(t, http.StatusOK, metadata)(w, r)
+		}
+	}
+
+	tests := []struct {
+		name         string
+		handler      http.HandlerFunc
+		expectedText string
+		expectedBlob []byte
+		expectedLink bool
+	}{
+		{
+			name:         "text asset",
+			handler:      assetHandler(asset("checksums.txt", "text/plain", 19), "abc123  app.tar.gz\n"),
+			expectedText: "abc123  app.tar.gz\n",
+		},
+		{
+			name:         "binary asset",
+			handler:      assetHandler(asset("app.bin", "application/octet-stream", 3), "\x00\x01\x02"),
+			expectedBlob: []byte{0, 1, 2},
+		},
+		{
+			name:         "large asset",
+			handler:      assetHandler(asset("app.tar.gz", "application/gzip", 50*1024*1024), ""),
+			expectedLink: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockedClient := MockHTTPClientWithHandlers(map[string]http.HandlerFunc{
+				GetReposReleasesAssetsByOwnerByRepoByAssetID: tc.handler,
+			})
+			deps := BaseDeps{Client: github.NewClient(mockedClient)}
+			request := createMCPRequest(map[string]any{
+				"owner":    "owner",
+				"repo":     "repo",
+				"asset_id": float64(7),
+			})
+
+			result, err := serverTool.Handler(deps)(ContextWithDeps(context.Background(), deps), &request)
+			require.NoError(t, err)
+			require.False(t, result.IsError)
+			require.Len(t, result.Content, 2)
+
+			if tc.expectedLink {
+				link, ok := result.Content[1].(*mcp.ResourceLink)
+				require.True(t, ok)
+				assert.Equal(t, "https://github.com/owner/repo/releases/download/v1.2.0/app.tar.gz", link.URI)
+				return
+			}
+			resource, ok := result.Content[1].(*mcp.EmbeddedResource)
+			require.True(t, ok)
+			assert.Equal(t, tc.expectedText, resource.Resource.Text)
+			assert.Equal(t, tc.expectedBlob, resource.Resource.Blob)
+		})
+	}
+}
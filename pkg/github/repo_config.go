@@ -0,0 +1,171 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/github/github-mcp-server/pkg/utils"
+	"github.com/google/go-github/v82/github"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"go.yaml.in/yaml/v3"
+)
+
+const (
+	// repoConfigPath is the file in which a repository sets the defaults of the tools acting on it.
+	repoConfigPath = ".github/mcp-server.yml"
+	// repoConfigCacheTTL bounds how long changes to a repository's configuration take to apply.
+	repoConfigCacheTTL = 5 * time.Minute
+)
+
+// repoConfig is the configuration a repository sets in repoConfigPath, letting its maintainers
+// shape how agents act on it without changing the configuration of the server.
+type repoConfig struct {
+	// BaseBranch is the branch pull requests target and new branches start from by default,
+	// instead of the default branch of the repository.
+	BaseBranch string `yaml:"base_branch"`
+	// ProtectedPaths are the files tools must not create, update or delete. A pattern ending in
+	// a slash or "/**" protects a directory, a pattern without a slash matches file names in any
+	// directory, and other patterns match the whole path.
+	ProtectedPaths []string `yaml:"protected_paths"`
+	// RequirePullRequestTemplate rejects pull requests whose body does not fill in every section
+	// of the pull request template, as if strict_template were set.
+	RequirePullRequestTemplate bool `yaml:"require_pull_request_template"`
+	// CommentTemplates are the comment templates of the repository, by name. They take precedence
+	// over the server's templates of the same name.
+	CommentTemplates CommentTemplates `yaml:"comment_templates"`
+}
+
+// protects returns the pattern protecting a file path, if any.
+func (c *repoConfig) protects(filePath string) (string, bool) {
+	filePath = path.Clean(strings.TrimPrefix(filePath, "/"))
+	for _, pattern := range c.ProtectedPaths {
+		pattern = strings.TrimPrefix(strings.TrimSpace(pattern), "/")
+		if pattern == "" {
+			continue
+		}
+		if dir, ok := strings.CutSuffix(pattern, "/**"); ok || strings.HasSuffix(pattern, "/") {
+			dir = strings.TrimSuffix(dir, "/")
+			if filePath == dir || strings.HasPrefix(filePath, dir+"/") {
+				return pattern, true
+			}
+			continue
+		}
+		target := filePath
+		if !strings.Contains(pattern, "/") {
+			target = path.Base(filePath)
+		}
+		if matched, _ := path.Match(pattern, target); matched {
+			return pattern, true
+		}
+	}
+	return "", false
+}
+
+// checkProtectedPaths returns an error naming the first file path the configuration protects.
+func (c *repoConfig) checkProtectedPaths(filePaths ...string) error {
+	for _, p := range filePaths {
+		if pattern, ok := c.protects(p); ok {
+			return fmt.Errorf("path %q is protected by %q in the repository's %s; it must be changed by a maintainer", p, pattern, repoConfigPath)
+		}
+	}
+	return nil
+}
+
+// parseRepoConfig parses the contents of repoConfigPath.
+func parseRepoConfig(content []byte) (*repoConfig, error) {
+	var config repoConfig
+	if err := yaml.Unmarshal(content, &config); err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", repoConfigPath, err)
+	}
+	return &config, nil
+}
+
+// fetchRepoConfig reads the configuration of a repository from its default branch. Repositories
+// without one get an empty configuration.
+func fetchRepoConfig(ctx context.Context, client *github.Client, owner, repo string) (*repoConfig, error) {
+	file, _, resp, err := client.Repositories.GetContents(ctx, owner, repo, repoConfigPath, nil)
+	if resp != nil {
+		_ = resp.Body.Close()
+	}
+	if err != nil {
+		var ghErr *github.ErrorResponse
+		if errors.As(err, &ghErr) && ghErr.Response != nil && ghErr.Response.StatusCode == http.StatusNotFound {
+			return &repoConfig{}, nil
+		}
+		return nil, fmt.Errorf("failed to read %s: %w", repoConfigPath, err)
+	}
+	if file == nil {
+		return nil, fmt.Errorf("failed to read %s: it is a directory", repoConfigPath)
+	}
+	content, err := file.GetContent()
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode %s: %w", repoConfigPath, err)
+	}
+	return parseRepoConfig([]byte(content))
+}
+
+type repoConfigCacheEntry struct {
+	config  *repoConfig
+	expires time.Time
+}
+
+// repoConfigCache caches the configuration of repositories for repoConfigCacheTTL.
+type repoConfigCache struct {
+	mu      sync.Mutex
+	entries map[string]repoConfigCacheEntry
+}
+
+var repoConfigs = &repoConfigCache{entries: make(map[string]repoConfigCacheEntry)}
+
+// get returns the cached configuration of a repository, or fetches it. Entries are scoped to the
+// token making the request, like completion lookups.
+func (c *repoConfigCache) get(ctx context.Context, client *github.Client, owner, repo string) (*repoConfig, error) {
+	key := strings.Join([]string{completionCacheScope(ctx), strings.ToLower(owner), strings.ToLower(repo)}, "/")
+
+	c.mu.Lock()
+	now := time.Now()
+	for k, entry := range c.entries {
+		if now.After(entry.expires) {
+			delete(c.entries, k)
+		}
+	}
+	entry, ok := c.entries[key]
+	c.mu.Unlock()
+	if ok {
+		return entry.config, nil
+	}
+
+	config, err := fetchRepoConfig(ctx, client, owner, repo)
+	if err != nil {
+		return nil, err
+	}
+	c.mu.Lock()
+	c.entries[key] = repoConfigCacheEntry{config: config, expires: now.Add(repoConfigCacheTTL)}
+	c.mu.Unlock()
+	return config, nil
+}
+
+func (c *repoConfigCache) clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries = make(map[string]repoConfigCacheEntry)
+}
+
+// protectedPathsResult returns the error result refusing a change to files the configuration of
+// the repository protects, or nil if the change is allowed.
+func protectedPathsResult(ctx context.Context, client *github.Client, owner, repo string, filePaths ...string) *mcp.CallToolResult {
+	config, err := repoConfigs.get(ctx, client, owner, repo)
+	if err != nil {
+		return utils.NewToolResultErrorFromErr("failed to get repository configuration", err)
+	}
+	if err := config.checkProtectedPaths(filePaths...); err != nil {
+		return utils.NewToolResultError(err.Error())
+	}
+	return nil
+}
@@ -0,0 +1,265 @@
+package github
+
+import (
+	"context"
+	"encoding/base64"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/githubv4mock"
+	"github.com/github/github-mcp-server/pkg/inventory"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v82/github"
+	"github.com/shurcooL/githubv4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testRepoConfig = `base_branch: develop
+protected_paths:
+  - .github/workflows/**
+  - LICENSE
+  - "*.lock"
+  - docs/adr/
+require_pull_request_template: true
+`
+
+// GetReposContentsRepoConfig is the endpoint of the repository configuration of octo-org/configured.
+const GetReposContentsRepoConfig = "GET /repos/octo-org/configured/contents/.github/mcp-server.yml"
+
+func mockRepoConfig(t *testing.T, config string) http.HandlerFunc {
+	return mockResponse(t, http.StatusOK, &github.RepositoryContent{
+		Type:     github.Ptr("file"),
+		Path:     github.Ptr(repoConfigPath),
+		Encoding: github.Ptr("base64"),
+		Content:  github.Ptr(base64.StdEncoding.EncodeToString([]byte(config))),
+	})
+}
+
+func Test_RepoConfigProtects(t *testing.T) {
+	config, err := parseRepoConfig([]byte(testRepoConfig))
+	require.NoError(t, err)
+	assert.Equal(t, "develop", config.BaseBranch)
+	assert.True(t, config.RequirePullRequestTemplate)
+
+	tests := []struct {
+		path    string
+		pattern string
+	}{
+		{path: ".github/workflows/ci.yml", pattern: ".github/workflows/**"},
+		{path: "/.github/workflows/release/deploy.yml", pattern: ".github/workflows/**"},
+		{path: "LICENSE", pattern: "LICENSE"},
+		{path: "frontend/yarn.lock", pattern: "*.lock"},
+		{path: "docs/adr/0001-record.md", pattern: "docs/adr/"},
+		{path: ".github/dependabot.yml"},
+		{path: "docs/LICENSE.md"},
+		{path: "docs/adr-index.md"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.path, func(t *testing.T) {
+			pattern, ok := config.protects(tc.path)
+			assert.Equal(t, tc.pattern != "", ok)
+			assert.Equal(t, tc.pattern, pattern)
+		})
+	}
+
+	_, err = parseRepoConfig([]byte("protected_paths: LICENSE"))
+	assert.ErrorContains(t, err, "invalid .github/mcp-server.yml")
+}
+
+func Test_RepoConfigCache(t *testing.T) {
+	repoConfigs.clear()
+	t.Cleanup(repoConfigs.clear)
+
+	fetches := 0
+	client := github.NewClient(MockHTTPClientWithHandlers(map[string]http.HandlerFunc{
+		GetReposContentsRepoConfig: func(w http.ResponseWriter, r *http.Request) {
+			fetches++
+			mockRepoConfig(t, testRepoConfig)(w, r)
+		},
+	}))
+
+	for _, repo := range []string{"configured", "Configured"} {
+		config, err := repoConfigs.get(context.Background(), client, "octo-org", repo)
+		require.NoError(t, err)
+		assert.Equal(t, "develop", config.BaseBranch)
+	}
+	assert.Equal(t, 1, fetches)
+
+	// Repositories without a configuration get an empty one
+	config, err := repoConfigs.get(context.Background(), client, "octo-org", "unconfigured")
+	require.NoError(t, err)
+	assert.Equal(t, &repoConfig{}, config)
+}
+
+func Test_RepoConfig_ProtectedPaths(t *testing.T) {
+	repoConfigs.clear()
+	t.Cleanup(repoConfigs.clear)
+
+	deps := BaseDeps{
+		Client: github.NewClient(MockHTTPClientWithHandlers(map[string]http.HandlerFunc{
+			GetReposContentsRepoConfig: mockRepoConfig(t, testRepoConfig),
+		})),
+	}
+	tests := []struct {
+		name        string
+		requestArgs map[string]any
+	}{
+		{
+			name: "create_or_update_file",
+			requestArgs: map[string]any{
+				"path":    ".github/workflows/ci.yml",
+				"content": "on: push",
+				"message": "Update CI",
+				"branch":  "main",
+			},
+		},
+		{
+			name: "delete_file",
+			requestArgs: map[string]any{
+				"path":    "LICENSE",
+				"message": "Remove license",
+				"branch":  "main",
+			},
+		},
+		{
+			name: "push_files",
+			requestArgs: map[string]any{
+				"branch":  "main",
+				"message": "Update dependencies",
+				"files": []any{
+					map[string]any{"path": "go.mod", "content": "module example"},
+					map[string]any{"path": "web/package.lock", "content": "{}"},
+				},
+			},
+		},
+	}
+
+	tools := map[string]func(translations.TranslationHelperFunc) inventory.ServerTool{
+		"create_or_update_file": CreateOrUpdateFile,
+		"delete_file":           DeleteFile,
+		"push_files":            PushFiles,
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.requestArgs["owner"] = "octo-org"
+			tc.requestArgs["repo"] = "configured"
+			serverTool := tools[tc.name](translations.NullTranslationHelper)
+			handler := serverTool.Handler(deps)
+			request := createMCPRequest(tc.requestArgs)
+
+			result, err := handler(ContextWithDeps(context.Background(), deps), &request)
+			require.NoError(t, err)
+			require.True(t, result.IsError)
+			assert.Contains(t, getErrorResult(t, result).Text, "is protected by")
+		})
+	}
+}
+
+func Test_RepoConfig_CreatePullRequest(t *testing.T) {
+	repoConfigs.clear()
+	t.Cleanup(repoConfigs.clear)
+
+	var templateQuery struct {
+		Repository struct {
+			PullRequestTemplates []struct {
+				Filename githubv4.String
+				Body     githubv4.String
+			}
+		} `graphql:"repository(owner: $owner, name: $repo)"`
+	}
+	templateMatcher := githubv4mock.NewQueryMatcher(
+		templateQuery,
+		map[string]any{
+			"owner": githubv4.String("octo-org"),
+			"repo":  githubv4.String("configured"),
+		},
+		githubv4mock.DataResponse(map[string]any{
+			"repository": map[string]any{
+				"pullRequestTemplates": []map[string]any{
+					{"filename": ".github/pull_request_template.md", "body": testPullRequestTemplate},
+				},
+			},
+		}),
+	)
+	deps := BaseDeps{
+		Client: github.NewClient(MockHTTPClientWithHandlers(map[string]http.HandlerFunc{
+			GetReposContentsRepoConfig: mockRepoConfig(t, testRepoConfig),
+			PostReposPullsByOwnerByRepo: expectRequestBody(t, map[string]any{
+				"title":                 "Add caching",
+				"head":                  "feature",
+				"base":                  "develop",
+				"body":                  "## Summary\nAdds caching.\n\n## Testing\n- [x] Unit tests\n\n## Details\n### Notes\nNone.",
+				"draft":                 false,
+				"maintainer_can_modify": false,
+			}).andThen(mockResponse(t, http.StatusCreated, &github.PullRequest{
+				Number:  github.Ptr(42),
+				HTMLURL: github.Ptr("https://github.com/octo-org/configured/pull/42"),
+			})),
+		})),
+		GQLClient: githubv4.NewClient(githubv4mock.NewMockedHTTPClient(templateMatcher)),
+	}
+	serverTool := CreatePullRequest(translations.NullTranslationHelper)
+	handler := serverTool.Handler(deps)
+
+	// The template is required without strict_template
+	request := createMCPRequest(map[string]any{
+		"owner": "octo-org",
+		"repo":  "configured",
+		"title": "Add caching",
+		"head":  "feature",
+		"body":  "Adds caching.",
+	})
+	result, err := handler(ContextWithDeps(context.Background(), deps), &request)
+	require.NoError(t, err)
+	assert.Contains(t, getErrorResult(t, result).Text, "pull request body does not follow the repository's template")
+
+	// The base branch defaults to the configured one
+	request = createMCPRequest(map[string]any{
+		"owner": "octo-org",
+		"repo":  "configured",
+		"title": "Add caching",
+		"head":  "feature",
+		"body":  "## Summary\nAdds caching.\n\n## Testing\n- [x] Unit tests\n\n## Details\n### Notes\nNone.",
+	})
+	result, err = handler(ContextWithDeps(context.Background(), deps), &request)
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+	assert.Contains(t, getTextResult(t, result).Text, "https://github.com/octo-org/configured/pull/42")
+}
+
+func Test_RepoConfig_CreateBranch(t *testing.T) {
+	repoConfigs.clear()
+	t.Cleanup(repoConfigs.clear)
+
+	deps := BaseDeps{
+		Client: github.NewClient(MockHTTPClientWithHandlers(map[string]http.HandlerFunc{
+			GetReposContentsRepoConfig: mockRepoConfig(t, testRepoConfig),
+			GetReposGitRefByOwnerByRepoByRef: func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/repos/octo-org/configured/git/ref/heads/develop", r.URL.Path)
+				mockResponse(t, http.StatusOK, &github.Reference{
+					Ref:    github.Ptr("refs/heads/develop"),
+					Object: &github.GitObject{SHA: github.Ptr("abc123")},
+				})(w, r)
+			},
+			PostReposGitRefsByOwnerByRepo: expectRequestBody(t, map[string]any{
+				"ref": "refs/heads/feature",
+				"sha": "abc123",
+			}).andThen(mockResponse(t, http.StatusCreated, &github.Reference{
+				Ref:    github.Ptr("refs/heads/feature"),
+				Object: &github.GitObject{SHA: github.Ptr("abc123")},
+			})),
+		})),
+	}
+	serverTool := CreateBranch(translations.NullTranslationHelper)
+	handler := serverTool.Handler(deps)
+	request := createMCPRequest(map[string]any{
+		"owner":  "octo-org",
+		"repo":   "configured",
+		"branch": "feature",
+	})
+	result, err := handler(ContextWithDeps(context.Background(), deps), &request)
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+	assert.Contains(t, getTextResult(t, result).Text, "refs/heads/feature")
+}
@@ -0,0 +1,96 @@
+package github
+
+import (
+	"context"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// RepoFilter sets which repositories list and search tools leave out of their results unless a
+// call asks for them.
+type RepoFilter struct {
+	ExcludeArchived bool
+	ExcludeForks    bool
+}
+
+// DefaultRepoFilter is used when the server configures no filter.
+var DefaultRepoFilter = RepoFilter{ExcludeArchived: true, ExcludeForks: true}
+
+type repoFilterContextKey struct{}
+
+// RepoFilterMiddleware sets the repositories left out of the results of tool calls by default.
+func RepoFilterMiddleware(filter RepoFilter) mcp.Middleware {
+	return func(next mcp.MethodHandler) mcp.MethodHandler {
+		return func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+			if method == "tools/call" {
+				ctx = context.WithValue(ctx, repoFilterContextKey{}, filter)
+			}
+			return next(ctx, method, req)
+		}
+	}
+}
+
+// RepoFilterFromContext returns the repository filter of the server handling a tool call.
+func RepoFilterFromContext(ctx context.Context) RepoFilter {
+	if filter, ok := ctx.Value(repoFilterContextKey{}).(RepoFilter); ok {
+		return filter
+	}
+	return DefaultRepoFilter
+}
+
+// WithArchivedFilter adds the parameter including archived repositories to a tool.
+func WithArchivedFilter(schema *jsonschema.Schema) *jsonschema.Schema {
+	schema.Properties["include_archived"] = &jsonschema.Schema{
+		Type:        "boolean",
+		Description: "Include archived repositories. Defaults to the server configuration, which leaves them out unless configured otherwise",
+	}
+	return schema
+}
+
+// WithRepoFilter adds the parameters including archived repositories and forks to a tool.
+func WithRepoFilter(schema *jsonschema.Schema) *jsonschema.Schema {
+	WithArchivedFilter(schema)
+	schema.Properties["include_forks"] = &jsonschema.Schema{
+		Type:        "boolean",
+		Description: "Include forks. Defaults to the server configuration, which leaves them out unless configured otherwise",
+	}
+	return schema
+}
+
+// repoFilterParams reads the parameters added by WithRepoFilter, defaulting to the repository
+// filter of the server. It returns the filter of the call.
+func repoFilterParams(ctx context.Context, args map[string]any) (RepoFilter, error) {
+	filter := RepoFilterFromContext(ctx)
+	includeArchived, err := OptionalBoolParamWithDefault(args, "include_archived", !filter.ExcludeArchived)
+	if err != nil {
+		return RepoFilter{}, err
+	}
+	includeForks, err := OptionalBoolParamWithDefault(args, "include_forks", !filter.ExcludeForks)
+	if err != nil {
+		return RepoFilter{}, err
+	}
+	return RepoFilter{ExcludeArchived: !includeArchived, ExcludeForks: !includeForks}, nil
+}
+
+// repoFilterQuery adds the qualifiers of filter to a search query, unless the query sets them
+// itself or targets a single repository, which may be archived or a fork. forks is false for
+// searches that cannot filter forks, such as issue searches.
+func repoFilterQuery(query string, filter RepoFilter, forks bool) string {
+	if hasRepoFilter(query) {
+		return query
+	}
+	if filter.ExcludeArchived && !hasFilter(query, "archived") && !hasSpecificFilter(query, "is", "archived") {
+		query += " archived:false"
+	}
+	// Repository search leaves forks out unless asked for them
+	if forks && !filter.ExcludeForks && !hasFilter(query, "fork") {
+		query += " fork:true"
+	}
+	return query
+}
+
+// Keeps reports whether a repository passes the filter.
+func (f RepoFilter) Keeps(archived, fork bool) bool {
+	return !(f.ExcludeArchived && archived) && !(f.ExcludeForks && fork)
+}
@@ -0,0 +1,58 @@
+package github
+
+import (
+	"context"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_RepoFilterQuery(t *testing.T) {
+	all := RepoFilter{}
+	tests := []struct {
+		name     string
+		query    string
+		filter   RepoFilter
+		forks    bool
+		expected string
+	}{
+		{name: "default filter", query: "language:go cli", filter: DefaultRepoFilter, forks: true, expected: "language:go cli archived:false"},
+		{name: "include everything", query: "language:go cli", filter: all, forks: true, expected: "language:go cli fork:true"},
+		{name: "search without forks", query: "is:issue bug", filter: all, forks: false, expected: "is:issue bug"},
+		{name: "explicit archived filter", query: "archived:true cli", filter: DefaultRepoFilter, forks: true, expected: "archived:true cli"},
+		{name: "is:archived filter", query: "is:issue is:archived", filter: DefaultRepoFilter, expected: "is:issue is:archived"},
+		{name: "explicit fork filter", query: "cli fork:only", filter: all, forks: true, expected: "cli fork:only"},
+		{name: "single repository", query: "repo:octo-org/legacy is:issue", filter: DefaultRepoFilter, expected: "repo:octo-org/legacy is:issue"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, repoFilterQuery(tc.query, tc.filter, tc.forks))
+		})
+	}
+}
+
+func Test_RepoFilterParams(t *testing.T) {
+	filter, err := repoFilterParams(context.Background(), map[string]any{})
+	require.NoError(t, err)
+	assert.Equal(t, DefaultRepoFilter, filter)
+
+	// The server filter sets the defaults, which calls override
+	var ctx context.Context
+	handler := RepoFilterMiddleware(RepoFilter{ExcludeArchived: true})(func(c context.Context, _ string, _ mcp.Request) (mcp.Result, error) {
+		ctx = c
+		return nil, nil
+	})
+	_, _ = handler(context.Background(), "tools/call", &mcp.CallToolRequest{})
+
+	filter, err = repoFilterParams(ctx, map[string]any{})
+	require.NoError(t, err)
+	assert.Equal(t, RepoFilter{ExcludeArchived: true}, filter)
+	filter, err = repoFilterParams(ctx, map[string]any{"include_archived": true, "include_forks": false})
+	require.NoError(t, err)
+	assert.Equal(t, RepoFilter{ExcludeForks: true}, filter)
+
+	assert.True(t, filter.Keeps(true, false))
+	assert.False(t, filter.Keeps(false, true))
+}
@@ -8,10 +8,12 @@ import (
 	"io"
 	"net/http"
 	"strings"
+	"sync"
 
 	ghErrors "github.com/github/github-mcp-server/pkg/errors"
 	"github.com/github/github-mcp-server/pkg/inventory"
 	"github.com/github/github-mcp-server/pkg/octicons"
+	"github.com/github/github-mcp-server/pkg/raw"
 	"github.com/github/github-mcp-server/pkg/scopes"
 	"github.com/github/github-mcp-server/pkg/translations"
 	"github.com/github/github-mcp-server/pkg/utils"
@@ -458,6 +460,10 @@ SHA MUST be provided for existing file updates.
 
 			path = strings.TrimPrefix(path, "/")
 
+			if result := protectedPathsResult(ctx, client, owner, repo, path); result != nil {
+				return result, nil, nil
+			}
+
 			// SHA validation using Contents API to fetch current file metadata (blob SHA)
 			getOpts := &github.RepositoryContentGetOptions{Ref: branch}
 
@@ -845,6 +851,223 @@ func GetFileContents(t translations.TranslationHelperFunc) inventory.ServerTool
 	)
 }
 
+const (
+	// maxBatchFiles bounds the number of files get_files_contents reads in one call.
+	maxBatchFiles = 50
+	// defaultBatchParallelism and maxBatchParallelism bound the concurrent raw content requests
+	// of get_files_contents.
+	defaultBatchParallelism = 5
+	maxBatchParallelism     = 10
+	// maxBatchFileSize is the size of the largest file get_files_contents returns, matching the
+	// limit of get_file_contents.
+	maxBatchFileSize = 1024 * 1024
+)
+
+// FileContents is a file read by get_files_contents. Files that could not be read have an Error
+// instead of content, and binary files are reported without their content.
+type FileContents struct {
+	Path    string `json:"path"`
+	Size    int    `json:"size,omitempty"`
+	Binary  bool   `json:"binary,omitempty"`
+	Content string `json:"content,omitempty"`
+	Error   string `json:"error,omitempty"`
+}
+
+// FilesContents is the result of get_files_contents. All files are read at the same commit.
+type FilesContents struct {
+	Owner string         `json:"owner"`
+	Repo  string         `json:"repo"`
+	Ref   string         `json:"ref,omitempty"`
+	SHA   string         `json:"sha"`
+	Note  string         `json:"note,omitempty"`
+	Files []FileContents `json:"files"`
+}
+
+// GetFilesContents creates a tool to read several files of a repository in one call.
+func GetFilesContents(t translations.TranslationHelperFunc) inventory.ServerTool {
+	return NewTool(
+		ToolsetMetadataRepos,
+		mcp.Tool{
+			Name:        "get_files_contents",
+			Description: t("TOOL_GET_FILES_CONTENTS_DESCRIPTION", fmt.Sprintf("Get the contents of up to %d files from a GitHub repository in one call, all at the same commit. Prefer this over calling get_file_contents for each file. Files that cannot be read are reported with an error, without failing the others. Binary files and files over 1MB are listed without their content; use get_file_contents for those, and for directories.", maxBatchFiles)),
+			Annotations: &mcp.ToolAnnotations{
+				Title:        t("TOOL_GET_FILES_CONTENTS_USER_TITLE", "Get contents of multiple files"),
+				ReadOnlyHint: true,
+			},
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"owner": {
+						Type:        "string",
+						Description: "Repository owner (username or organization)",
+					},
+					"repo": {
+						Type:        "string",
+						Description: "Repository name",
+					},
+					"paths": {
+						Type:        "array",
+						Description: fmt.Sprintf("Paths of the files to read (max %d)", maxBatchFiles),
+						Items: &jsonschema.Schema{
+							Type: "string",
+						},
+						MinItems: jsonschema.Ptr(1),
+						MaxItems: jsonschema.Ptr(maxBatchFiles),
+					},
+					"ref": {
+						Type:        "string",
+						Description: "Accepts optional git refs such as `refs/tags/{tag}`, `refs/heads/{branch}` or `refs/pull/{pr_number}/head`. Defaults to the default branch",
+					},
+					"sha": {
+						Type:        "string",
+						Description: "Accepts optional commit SHA. If specified, it will be used instead of ref",
+					},
+					"parallelism": {
+						Type:        "number",
+						Description: fmt.Sprintf("Maximum number of files read concurrently (default %d, max %d)", defaultBatchParallelism, maxBatchParallelism),
+						Minimum:     jsonschema.Ptr(1.0),
+						Maximum:     jsonschema.Ptr(float64(maxBatchParallelism)),
+					},
+				},
+				Required: []string{"owner", "repo", "paths"},
+			},
+		},
+		[]scopes.Scope{scopes.Repo},
+		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+			owner, err := RequiredParam[string](args, "owner")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			repo, err := RequiredParam[string](args, "repo")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			paths, err := OptionalStringArrayParam(args, "paths")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			ref, err := OptionalParam[string](args, "ref")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			sha, err := OptionalParam[string](args, "sha")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			parallelism, err := OptionalIntParamWithDefault(args, "parallelism", defaultBatchParallelism)
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			parallelism = min(max(parallelism, 1), maxBatchParallelism)
+
+			// Read each path once, in the order given
+			var files []FileContents
+			seen := make(map[string]bool, len(paths))
+			for _, p := range paths {
+				p = strings.TrimPrefix(strings.TrimSpace(p), "/")
+				if p == "" || seen[p] {
+					continue
+				}
+				seen[p] = true
+				files = append(files, FileContents{Path: p})
+			}
+			if len(files) == 0 {
+				return utils.NewToolResultError("missing required parameter: paths"), nil, nil
+			}
+			if len(files) > maxBatchFiles {
+				return utils.NewToolResultError(fmt.Sprintf("too many paths: %d files can be read per call, got %d", maxBatchFiles, len(files))), nil, nil
+			}
+
+			client, err := deps.GetClient(ctx)
+			if err != nil {
+				return utils.NewToolResultError("failed to get GitHub client"), nil, nil
+			}
+			rawClient, err := deps.GetRawClient(ctx)
+			if err != nil {
+				return utils.NewToolResultError("failed to get GitHub raw content client"), nil, nil
+			}
+
+			// Resolve the ref once, so all files are read at the same commit
+			rawOpts, fallbackUsed, err := resolveGitReference(ctx, client, owner, repo, ref, sha)
+			if err != nil {
+				return utils.NewToolResultError(fmt.Sprintf("failed to resolve git reference: %s", err)), nil, nil
+			}
+			commitOpts := &raw.ContentOpts{SHA: rawOpts.SHA}
+
+			var wg sync.WaitGroup
+			sem := make(chan struct{}, parallelism)
+			for i := range files {
+				wg.Add(1)
+				sem <- struct{}{}
+				go func(file *FileContents) {
+					defer func() {
+						<-sem
+						wg.Done()
+					}()
+					readRawFile(ctx, rawClient, owner, repo, commitOpts, file)
+				}(&files[i])
+			}
+			wg.Wait()
+
+			result := FilesContents{
+				Owner: owner,
+				Repo:  repo,
+				Ref:   rawOpts.Ref,
+				SHA:   rawOpts.SHA,
+				Files: files,
+			}
+			if fallbackUsed {
+				result.Note = fmt.Sprintf("the provided ref '%s' does not exist, default branch '%s' was used instead", ref, rawOpts.Ref)
+			}
+			return MarshalledTextResult(result), nil, nil
+		},
+	)
+}
+
+// readRawFile reads a file through the raw content API into file, or records why it could not.
+func readRawFile(ctx context.Context, rawClient *raw.Client, owner, repo string, opts *raw.ContentOpts, file *FileContents) {
+	resp, err := rawClient.GetRawContent(ctx, owner, repo, file.Path, opts)
+	if err != nil {
+		file.Error = fmt.Sprintf("failed to get raw content: %s", err)
+		return
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		file.Error = "not found: the path does not exist, or is a directory"
+		return
+	default:
+		file.Error = fmt.Sprintf("failed to get raw content: unexpected status %d", resp.StatusCode)
+		return
+	}
+
+	content, err := io.ReadAll(io.LimitReader(resp.Body, maxBatchFileSize+1))
+	if err != nil {
+		file.Error = fmt.Sprintf("failed to read file content: %s", err)
+		return
+	}
+	if len(content) > maxBatchFileSize {
+		file.Error = "file is too large to return (over 1MB); use get_file_contents to get its download URL"
+		return
+	}
+	file.Size = len(content)
+
+	contentType := http.DetectContentType(content)
+	isText := len(content) == 0 ||
+		strings.HasPrefix(contentType, "text/") ||
+		contentType == "application/json" ||
+		contentType == "application/xml" ||
+		strings.HasSuffix(contentType, "+json") ||
+		strings.HasSuffix(contentType, "+xml")
+	if !isText {
+		file.Binary = true
+		return
+	}
+	file.Content = string(content)
+}
+
 // ForkRepository creates a tool to fork a repository.
 func ForkRepository(t translations.TranslationHelperFunc) inventory.ServerTool {
 	return NewTool(
@@ -1011,6 +1234,10 @@ func DeleteFile(t translations.TranslationHelperFunc) inventory.ServerTool {
 				return nil, nil, fmt.Errorf("failed to get GitHub client: %w", err)
 			}
 
+			if result := protectedPathsResult(ctx, client, owner, repo, path); result != nil {
+				return result, nil, nil
+			}
+
 			// Get the reference for the branch
 			ref, resp, err := client.Git.GetRef(ctx, owner, repo, "refs/heads/"+branch)
 			if err != nil {
@@ -1157,7 +1384,7 @@ func CreateBranch(t translations.TranslationHelperFunc) inventory.ServerTool {
 					},
 					"from_branch": {
 						Type:        "string",
-						Description: "Source branch (defaults to repo default)",
+						Description: "Source branch (defaults to the base branch set in the repository's .github/mcp-server.yml, or the repo default)",
 					},
 				},
 				Required: []string{"owner", "repo", "branch"},
@@ -1187,6 +1414,14 @@ func CreateBranch(t translations.TranslationHelperFunc) inventory.ServerTool {
 				return nil, nil, fmt.Errorf("failed to get GitHub client: %w", err)
 			}
 
+			if fromBranch == "" {
+				config, err := repoConfigs.get(ctx, client, owner, repo)
+				if err != nil {
+					return utils.NewToolResultErrorFromErr("failed to get repository configuration", err), nil, nil
+				}
+				fromBranch = config.BaseBranch
+			}
+
 			// Get the source branch SHA
 			var ref *github.Reference
 
@@ -1325,6 +1560,18 @@ func PushFiles(t translations.TranslationHelperFunc) inventory.ServerTool {
 				return nil, nil, fmt.Errorf("failed to get GitHub client: %w", err)
 			}
 
+			var paths []string
+			for _, file := range filesObj {
+				if fileMap, ok := file.(map[string]any); ok {
+					if path, ok := fileMap["path"].(string); ok {
+						paths = append(paths, path)
+					}
+				}
+			}
+			if result := protectedPathsResult(ctx, client, owner, repo, paths...); result != nil {
+				return result, nil, nil
+			}
+
 			// Get the reference for the branch
 			var repositoryIsEmpty bool
 			var branchNotFound bool
@@ -1897,7 +2144,7 @@ func ListStarredRepositories(t translations.TranslationHelperFunc) inventory.Ser
 				Title:        t("TOOL_LIST_STARRED_REPOSITORIES_USER_TITLE", "List starred repositories"),
 				ReadOnlyHint: true,
 			},
-			InputSchema: WithPagination(&jsonschema.Schema{
+			InputSchema: WithRepoFilter(WithPagination(&jsonschema.Schema{
 				Type: "object",
 				Properties: map[string]*jsonschema.Schema{
 					"username": {
@@ -1915,7 +2162,7 @@ func ListStarredRepositories(t translations.TranslationHelperFunc) inventory.Ser
 						Enum:        []any{"asc", "desc"},
 					},
 				},
-			}),
+			})),
 		},
 		[]scopes.Scope{scopes.Repo},
 		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
@@ -1931,6 +2178,10 @@ func ListStarredRepositories(t translations.TranslationHelperFunc) inventory.Ser
 			if err != nil {
 				return utils.NewToolResultError(err.Error()), nil, nil
 			}
+			filter, err := repoFilterParams(ctx, args)
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
 			pagination, err := OptionalPaginationParams(args)
 			if err != nil {
 				return utils.NewToolResultError(err.Error()), nil, nil
@@ -1985,6 +2236,9 @@ func ListStarredRepositories(t translations.TranslationHelperFunc) inventory.Ser
 			minimalRepos := make([]MinimalRepository, 0, len(repos))
 			for _, starredRepo := range repos {
 				repo := starredRepo.Repository
+				if !filter.Keeps(repo.GetArchived(), repo.GetFork()) {
+					continue
+				}
 				minimalRepo := MinimalRepository{
 					ID:            repo.GetID(),
 					Name:          repo.GetName(),
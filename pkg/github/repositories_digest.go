@@ -0,0 +1,452 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/github/github-mcp-server/pkg/inventory"
+	"github.com/github/github-mcp-server/pkg/lockdown"
+	"github.com/github/github-mcp-server/pkg/sanitize"
+	"github.com/github/github-mcp-server/pkg/scopes"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/github/github-mcp-server/pkg/utils"
+	"github.com/google/go-github/v82/github"
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+const (
+	// maxDigestRepositories bounds the repositories a digest covers in one call.
+	maxDigestRepositories = 20
+	// digestParallelism is the number of repositories read at once.
+	digestParallelism = 5
+	// maxDigestPages bounds the pages of pull requests and issues read per repository.
+	maxDigestPages = 3
+	// digestUnlabeled groups the new issues that have no label.
+	digestUnlabeled = "(unlabeled)"
+)
+
+// DigestItem is an issue or pull request of a digest.
+type DigestItem struct {
+	Number int    `json:"number"`
+	Title  string `json:"title"`
+	Author string `json:"author,omitempty"`
+}
+
+// DigestRelease is a release published in the window of a digest.
+type DigestRelease struct {
+	Tag        string `json:"tag"`
+	Name       string `json:"name,omitempty"`
+	Prerelease bool   `json:"prerelease,omitempty"`
+}
+
+// DigestRun is a failed workflow run on the default branch.
+type DigestRun struct {
+	// ID identifies the run in get_job_logs and rerun_failed_jobs calls
+	ID       int64  `json:"id"`
+	Workflow string `json:"workflow"`
+	Event    string `json:"event,omitempty"`
+	HeadSHA  string `json:"head_sha,omitempty"`
+}
+
+// RepositoryDigest is what happened in one repository over the window of a digest. Empty
+// sections are left out, so a repository without any had no activity.
+type RepositoryDigest struct {
+	Repository    string `json:"repository"`
+	DefaultBranch string `json:"default_branch,omitempty"`
+	// Releases lists the releases published in the window, newest first
+	Releases           []DigestRelease `json:"releases,omitempty"`
+	MergedPullRequests []DigestItem    `json:"merged_pull_requests,omitempty"`
+	// NewIssues groups the issues opened in the window by label. An issue with several labels
+	// is listed under each of them.
+	NewIssues      map[string][]DigestItem `json:"new_issues,omitempty"`
+	NewIssueCount  int                     `json:"new_issue_count,omitempty"`
+	FailedRuns     []DigestRun             `json:"failed_runs,omitempty"`
+	FailedRunCount int                     `json:"failed_run_count,omitempty"`
+	// Truncated reports sections cut to max_items
+	Truncated bool `json:"truncated,omitempty"`
+	// Errors lists the sections that could not be read; the others are still reported
+	Errors []string `json:"errors,omitempty"`
+}
+
+// RepositoriesDigest is the result of get_repositories_digest.
+type RepositoriesDigest struct {
+	Since        time.Time          `json:"since"`
+	Until        time.Time          `json:"until"`
+	Summary      map[string]int     `json:"summary"`
+	Repositories []RepositoryDigest `json:"repositories"`
+}
+
+// digestReader reads the activity of one repository over a window, withholding the content of
+// authors lockdown mode does not trust.
+type digestReader struct {
+	client       *github.Client
+	cache        *lockdown.RepoAccessCache
+	provenance   *ContentProvenance
+	owner, repo  string
+	since, until time.Time
+	maxItems     int
+}
+
+// inWindow reports whether t falls in the window of the digest.
+func (r *digestReader) inWindow(t time.Time) bool {
+	return !t.Before(r.since) && t.Before(r.until)
+}
+
+// item converts an issue or pull request, or returns nil when lockdown mode does not trust its
+// author.
+func (r *digestReader) item(ctx context.Context, number int, title string, user *github.User, association string) (*DigestItem, error) {
+	login := user.GetLogin()
+	if r.provenance.Lockdown {
+		if r.cache == nil {
+			return nil, fmt.Errorf("lockdown cache is not configured")
+		}
+		if login == "" {
+			r.provenance.LockdownFiltered++
+			return nil, nil
+		}
+		safe, err := r.cache.IsSafeContent(ctx, login, r.owner, r.repo)
+		if err != nil {
+			return nil, err
+		}
+		if !safe {
+			r.provenance.LockdownFiltered++
+			return nil, nil
+		}
+	}
+	r.provenance.addAuthorAssociation(association)
+	return &DigestItem{Number: number, Title: sanitize.Sanitize(title), Author: login}, nil
+}
+
+// releases lists the releases published in the window, which the first page covers unless the
+// repository publishes more than a hundred releases a window.
+func (r *digestReader) releases(ctx context.Context, digest *RepositoryDigest) error {
+	releases, resp, err := r.client.Repositories.ListReleases(ctx, r.owner, r.repo, &github.ListOptions{PerPage: 100})
+	if err != nil {
+		return fmt.Errorf("failed to list releases: %w", err)
+	}
+	_ = resp.Body.Close()
+	for _, release := range releases {
+		if release.GetDraft() || release.PublishedAt == nil || !r.inWindow(release.PublishedAt.Time) {
+			continue
+		}
+		if len(digest.Releases) == r.maxItems {
+			digest.Truncated = true
+			break
+		}
+		digest.Releases = append(digest.Releases, DigestRelease{
+			Tag:        release.GetTagName(),
+			Name:       sanitize.Sanitize(release.GetName()),
+			Prerelease: release.GetPrerelease(),
+		})
+	}
+	return nil
+}
+
+// mergedPullRequests lists the pull requests merged in the window, reading closed pull requests
+// by most recent update until they were last updated before the window.
+func (r *digestReader) mergedPullRequests(ctx context.Context, digest *RepositoryDigest) error {
+	opts := &github.PullRequestListOptions{
+		State:       "closed",
+		Sort:        "updated",
+		Direction:   "desc",
+		ListOptions: github.ListOptions{PerPage: 100},
+	}
+	for page := 0; page < maxDigestPages; page++ {
+		pulls, resp, err := r.client.PullRequests.List(ctx, r.owner, r.repo, opts)
+		if err != nil {
+			return fmt.Errorf("failed to list pull requests: %w", err)
+		}
+		_ = resp.Body.Close()
+		for _, pull := range pulls {
+			if pull.UpdatedAt != nil && pull.UpdatedAt.Before(r.since) {
+				return nil
+			}
+			if pull.MergedAt == nil || !r.inWindow(pull.MergedAt.Time) {
+				continue
+			}
+			item, err := r.item(ctx, pull.GetNumber(), pull.GetTitle(), pull.User, pull.GetAuthorAssociation())
+			if err != nil {
+				return fmt.Errorf("failed to check lockdown mode: %w", err)
+			}
+			if item == nil {
+				continue
+			}
+			if len(digest.MergedPullRequests) == r.maxItems {
+				digest.Truncated = true
+				return nil
+			}
+			digest.MergedPullRequests = append(digest.MergedPullRequests, *item)
+		}
+		if resp.NextPage == 0 {
+			return nil
+		}
+		opts.Page = resp.NextPage
+	}
+	digest.Truncated = true
+	return nil
+}
+
+// newIssues groups the issues opened in the window by label, reading issues by most recent
+// creation until they were created before the window.
+func (r *digestReader) newIssues(ctx context.Context, digest *RepositoryDigest) error {
+	opts := &github.IssueListByRepoOptions{
+		State:       "all",
+		Sort:        "created",
+		Direction:   "desc",
+		Since:       r.since,
+		ListOptions: github.ListOptions{PerPage: 100},
+	}
+	listed := 0
+	for page := 0; page < maxDigestPages; page++ {
+		issues, resp, err := r.client.Issues.ListByRepo(ctx, r.owner, r.repo, opts)
+		if err != nil {
+			return fmt.Errorf("failed to list issues: %w", err)
+		}
+		_ = resp.Body.Close()
+		for _, issue := range issues {
+			if issue.CreatedAt != nil && issue.CreatedAt.Before(r.since) {
+				return nil
+			}
+			if issue.IsPullRequest() || issue.CreatedAt == nil || !r.inWindow(issue.CreatedAt.Time) {
+				continue
+			}
+			item, err := r.item(ctx, issue.GetNumber(), issue.GetTitle(), issue.User, issue.GetAuthorAssociation())
+			if err != nil {
+				return fmt.Errorf("failed to check lockdown mode: %w", err)
+			}
+			if item == nil {
+				continue
+			}
+			digest.NewIssueCount++
+			if listed == r.maxItems {
+				digest.Truncated = true
+				continue
+			}
+			listed++
+			if digest.NewIssues == nil {
+				digest.NewIssues = map[string][]DigestItem{}
+			}
+			if len(issue.Labels) == 0 {
+				digest.NewIssues[digestUnlabeled] = append(digest.NewIssues[digestUnlabeled], *item)
+			}
+			for _, label := range issue.Labels {
+				digest.NewIssues[label.GetName()] = append(digest.NewIssues[label.GetName()], *item)
+			}
+		}
+		if resp.NextPage == 0 {
+			return nil
+		}
+		opts.ListOptions.Page = resp.NextPage
+	}
+	digest.Truncated = true
+	return nil
+}
+
+// failedRuns lists the workflow runs on the default branch that failed in the window.
+func (r *digestReader) failedRuns(ctx context.Context, digest *RepositoryDigest) error {
+	repository, resp, err := r.client.Repositories.Get(ctx, r.owner, r.repo)
+	if err != nil {
+		return fmt.Errorf("failed to get repository: %w", err)
+	}
+	_ = resp.Body.Close()
+	digest.DefaultBranch = repository.GetDefaultBranch()
+
+	runs, resp, err := r.client.Actions.ListRepositoryWorkflowRuns(ctx, r.owner, r.repo, &github.ListWorkflowRunsOptions{
+		Branch:      digest.DefaultBranch,
+		Status:      "failure",
+		Created:     r.since.UTC().Format(time.RFC3339) + ".." + r.until.UTC().Format(time.RFC3339),
+		ListOptions: github.ListOptions{PerPage: r.maxItems},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to list workflow runs: %w", err)
+	}
+	_ = resp.Body.Close()
+	digest.FailedRunCount = runs.GetTotalCount()
+	for _, run := range runs.WorkflowRuns {
+		sha := run.GetHeadSHA()
+		if len(sha) > 7 {
+			sha = sha[:7]
+		}
+		digest.FailedRuns = append(digest.FailedRuns, DigestRun{
+			ID:       run.GetID(),
+			Workflow: run.GetName(),
+			Event:    run.GetEvent(),
+			HeadSHA:  sha,
+		})
+	}
+	if digest.FailedRunCount > len(digest.FailedRuns) {
+		digest.Truncated = true
+	}
+	return nil
+}
+
+// read reads every section of the digest, recording the sections that fail.
+func (r *digestReader) read(ctx context.Context) RepositoryDigest {
+	digest := RepositoryDigest{Repository: r.owner + "/" + r.repo}
+	for _, section := range []func(context.Context, *RepositoryDigest) error{r.releases, r.mergedPullRequests, r.newIssues, r.failedRuns} {
+		if err := section(ctx, &digest); err != nil {
+			digest.Errors = append(digest.Errors, err.Error())
+		}
+	}
+	return digest
+}
+
+// GetRepositoriesDigest creates a tool that summarizes recent activity across repositories.
+func GetRepositoriesDigest(t translations.TranslationHelperFunc) inventory.ServerTool {
+	return NewTool(
+		ToolsetMetadataRepos,
+		mcp.Tool{
+			Name: "get_repositories_digest",
+			Description: t("TOOL_GET_REPOSITORIES_DIGEST_DESCRIPTION", fmt.Sprintf(`Get a compact digest of what happened in up to %d repositories over a time window, by default the last 24 hours: releases published, pull requests merged, issues opened grouped by label, and workflow runs that failed on the default branch.
+Use it for daily stand-ups or to catch up on repositories being monitored. When repositories is omitted, the repositories of the roots shared by the client are used. Sections without activity are left out.`, maxDigestRepositories)),
+			Annotations: &mcp.ToolAnnotations{
+				Title:        t("TOOL_GET_REPOSITORIES_DIGEST_USER_TITLE", "Get repositories digest"),
+				ReadOnlyHint: true,
+			},
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"repositories": {
+						Type:        "array",
+						Description: fmt.Sprintf("Repositories to report on, as owner/repo (max %d). Defaults to the repositories of the client roots.", maxDigestRepositories),
+						Items:       &jsonschema.Schema{Type: "string"},
+						MaxItems:    jsonschema.Ptr(maxDigestRepositories),
+					},
+					"since": {
+						Type:        "string",
+						Description: "Start of the window, as an ISO 8601 timestamp (default: 24 hours ago)",
+					},
+					"until": {
+						Type:        "string",
+						Description: "End of the window, as an ISO 8601 timestamp (default: now)",
+					},
+					"max_items": {
+						Type:        "number",
+						Description: "Most items listed per section and repository (default: 10)",
+						Minimum:     jsonschema.Ptr(1.0),
+						Maximum:     jsonschema.Ptr(50.0),
+					},
+				},
+			},
+		},
+		[]scopes.Scope{scopes.Repo},
+		func(ctx context.Context, deps ToolDependencies, req *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+			requested, err := OptionalStringArrayParam(args, "repositories")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			repositories, err := requestedOrRootRepositories(req, requested, maxDigestRepositories)
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			until := time.Now()
+			untilStr, err := OptionalParam[string](args, "until")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			if untilStr != "" {
+				if until, err = parseISOTimestamp(untilStr); err != nil {
+					return utils.NewToolResultError(fmt.Sprintf("invalid until: %s", err)), nil, nil
+				}
+			}
+			since := until.Add(-24 * time.Hour)
+			sinceStr, err := OptionalParam[string](args, "since")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			if sinceStr != "" {
+				if since, err = parseISOTimestamp(sinceStr); err != nil {
+					return utils.NewToolResultError(fmt.Sprintf("invalid since: %s", err)), nil, nil
+				}
+			}
+			if !since.Before(until) {
+				return utils.NewToolResultError("since must be before until"), nil, nil
+			}
+			maxItems, err := OptionalIntParamWithDefault(args, "max_items", 10)
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			if maxItems < 1 || maxItems > 50 {
+				return utils.NewToolResultError("max_items must be between 1 and 50"), nil, nil
+			}
+
+			client, err := deps.GetClient(ctx)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			cache, err := deps.GetRepoAccessCache(ctx)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to get repo access cache: %w", err)
+			}
+			lockdownMode := deps.GetFlags(ctx).LockdownMode
+
+			result := RepositoriesDigest{
+				Since:        since.UTC(),
+				Until:        until.UTC(),
+				Repositories: make([]RepositoryDigest, len(repositories)),
+			}
+			provenances := make([]*ContentProvenance, len(repositories))
+
+			var wg sync.WaitGroup
+			sem := make(chan struct{}, digestParallelism)
+			for i, repository := range repositories {
+				owner, repo, _ := strings.Cut(repository, "/")
+				wg.Add(1)
+				sem <- struct{}{}
+				go func() {
+					defer func() {
+						<-sem
+						wg.Done()
+					}()
+					reader := &digestReader{
+						client:     client,
+						cache:      cache,
+						provenance: newContentProvenance(lockdownMode),
+						owner:      owner,
+						repo:       repo,
+						since:      since,
+						until:      until,
+						maxItems:   maxItems,
+					}
+					result.Repositories[i] = reader.read(ctx)
+					provenances[i] = reader.provenance
+				}()
+			}
+			wg.Wait()
+
+			provenance := newContentProvenance(lockdownMode)
+			for _, p := range provenances {
+				for _, association := range p.AuthorAssociations {
+					provenance.addAuthorAssociation(association)
+				}
+				provenance.LockdownFiltered += p.LockdownFiltered
+			}
+
+			result.Summary = map[string]int{"releases": 0, "merged_pull_requests": 0, "new_issues": 0, "failed_runs": 0, "failed": 0}
+			for _, digest := range result.Repositories {
+				result.Summary["releases"] += len(digest.Releases)
+				result.Summary["merged_pull_requests"] += len(digest.MergedPullRequests)
+				result.Summary["new_issues"] += digest.NewIssueCount
+				result.Summary["failed_runs"] += digest.FailedRunCount
+				if len(digest.Errors) > 0 {
+					result.Summary["failed"]++
+				}
+				provenance.Truncated = provenance.Truncated || digest.Truncated
+			}
+			sort.SliceStable(result.Repositories, func(i, j int) bool {
+				return digestActivity(result.Repositories[i]) > digestActivity(result.Repositories[j])
+			})
+			return withProvenance(MarshalledTextResult(result), provenance), nil, nil
+		},
+	)
+}
+
+// digestActivity counts the items of a digest, so that the busiest repositories come first.
+func digestActivity(digest RepositoryDigest) int {
+	return len(digest.Releases) + len(digest.MergedPullRequests) + digest.NewIssueCount + digest.FailedRunCount + len(digest.Errors)
+}
@@ -0,0 +1,155 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v82/github"
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// digestHandlers mocks the activity of octo/ink on 2026-10-16, and fails for any other repository.
+func digestHandlers(t *testing.T) map[string]http.HandlerFunc {
+	t.Helper()
+	at := func(s string) *github.Timestamp {
+		ts, err := time.Parse(time.RFC3339, s)
+		require.NoError(t, err)
+		return &github.Timestamp{Time: ts}
+	}
+	onlyInk := func(next http.HandlerFunc) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			if !strings.HasPrefix(r.URL.Path, "/repos/octo/ink") {
+				mockResponse(t, http.StatusNotFound, map[string]string{"message": "Not Found"})(w, r)
+				return
+			}
+			next(w, r)
+		}
+	}
+	return map[string]http.HandlerFunc{
+		GetReposReleasesByOwnerByRepo: onlyInk(mockResponse(t, http.StatusOK, []*github.RepositoryRelease{
+			{TagName: github.Ptr("v2.0.0-rc.1"), Draft: github.Ptr(true), PublishedAt: nil},
+			{TagName: github.Ptr("v1.4.0"), Name: github.Ptr("Ink 1.4"), PublishedAt: at("2026-10-16T09:00:00Z")},
+			{TagName: github.Ptr("v1.3.0"), PublishedAt: at("2026-10-01T09:00:00Z")},
+		})),
+		GetReposPullsByOwnerByRepo: onlyInk(expectQueryParams(t, map[string]string{
+			"state":     "closed",
+			"sort":      "updated",
+			"direction": "desc",
+			"per_page":  "100",
+		}).andThen(mockResponse(t, http.StatusOK, []*github.PullRequest{
+			{Number: github.Ptr(12), Title: github.Ptr("Faster squirting"), User: &github.User{Login: github.Ptr("octocat")}, UpdatedAt: at("2026-10-16T12:00:00Z"), MergedAt: at("2026-10-16T11:00:00Z")},
+			{Number: github.Ptr(11), Title: github.Ptr("Closed unmerged"), UpdatedAt: at("2026-10-16T10:00:00Z")},
+			{Number: github.Ptr(9), Title: github.Ptr("Old news"), UpdatedAt: at("2026-10-10T10:00:00Z"), MergedAt: at("2026-10-10T10:00:00Z")},
+		}))),
+		GetReposIssuesByOwnerByRepo: onlyInk(mockResponse(t, http.StatusOK, []*github.Issue{
+			{Number: github.Ptr(15), Title: github.Ptr("Ink smudges"), CreatedAt: at("2026-10-16T14:00:00Z"), Labels: []*github.Label{{Name: github.Ptr("bug")}, {Name: github.Ptr("ui")}}},
+			{Number: github.Ptr(14), Title: github.Ptr("Bump deps"), CreatedAt: at("2026-10-16T13:00:00Z"), PullRequestLinks: &github.PullRequestLinks{URL: github.Ptr("https://api.github.com/repos/octo/ink/pulls/14")}},
+			{Number: github.Ptr(13), Title: github.Ptr("Question about colors"), CreatedAt: at("2026-10-16T08:00:00Z")},
+			{Number: github.Ptr(8), Title: github.Ptr("Old issue with a new comment"), CreatedAt: at("2026-09-01T08:00:00Z")},
+		})),
+		GetReposByOwnerByRepo: onlyInk(mockResponse(t, http.StatusOK, &github.Repository{DefaultBranch: github.Ptr("main")})),
+		GetReposActionsRunsByOwnerByRepo: onlyInk(expectQueryParams(t, map[string]string{
+			"branch":   "main",
+			"status":   "failure",
+			"created":  "2026-10-16T00:00:00Z..2026-10-17T00:00:00Z",
+			"per_page": "10",
+		}).andThen(mockResponse(t, http.StatusOK, &github.WorkflowRuns{
+			TotalCount: github.Ptr(1),
+			WorkflowRuns: []*github.WorkflowRun{
+				{ID: github.Ptr(int64(777)), Name: github.Ptr("CI"), Event: github.Ptr("push"), HeadSHA: github.Ptr("abcdef0123456789")},
+			},
+		}))),
+	}
+}
+
+func Test_GetRepositoriesDigest(t *testing.T) {
+	serverTool := GetRepositoriesDigest(translations.NullTranslationHelper)
+	tool := serverTool.Tool
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_repositories_digest", tool.Name)
+	assert.True(t, tool.Annotations.ReadOnlyHint)
+	schema := tool.InputSchema.(*jsonschema.Schema)
+	assert.Empty(t, schema.Required)
+
+	deps := BaseDeps{
+		Client: github.NewClient(MockHTTPClientWithHandlers(digestHandlers(t))),
+		Flags:  stubFeatureFlags(map[string]bool{"lockdown-mode": false}),
+	}
+	window := map[string]any{"since": "2026-10-16T00:00:00Z", "until": "2026-10-17T00:00:00Z"}
+
+	t.Run("reports activity and per-repository failures", func(t *testing.T) {
+		args := map[string]any{"repositories": []any{"octo/quiet", "octo/ink", "OCTO/ink"}}
+		for k, v := range window {
+			args[k] = v
+		}
+		request := createMCPRequest(args)
+		result, err := serverTool.Handler(deps)(ContextWithDeps(context.Background(), deps), &request)
+		require.NoError(t, err)
+		require.False(t, result.IsError, getTextResult(t, result).Text)
+
+		var digest RepositoriesDigest
+		require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &digest))
+		assert.Equal(t, map[string]int{"releases": 1, "merged_pull_requests": 1, "new_issues": 2, "failed_runs": 1, "failed": 1}, digest.Summary)
+		require.Len(t, digest.Repositories, 2)
+
+		ink := digest.Repositories[0]
+		assert.Equal(t, "octo/ink", ink.Repository)
+		assert.Equal(t, "main", ink.DefaultBranch)
+		assert.Equal(t, []DigestRelease{{Tag: "v1.4.0", Name: "Ink 1.4"}}, ink.Releases)
+		assert.Equal(t, []DigestItem{{Number: 12, Title: "Faster squirting", Author: "octocat"}}, ink.MergedPullRequests)
+		assert.Equal(t, 2, ink.NewIssueCount)
+		assert.Equal(t, map[string][]DigestItem{
+			"bug":           {{Number: 15, Title: "Ink smudges"}},
+			"ui":            {{Number: 15, Title: "Ink smudges"}},
+			digestUnlabeled: {{Number: 13, Title: "Question about colors"}},
+		}, ink.NewIssues)
+		assert.Equal(t, []DigestRun{{ID: 777, Workflow: "CI", Event: "push", HeadSHA: "abcdef0"}}, ink.FailedRuns)
+		assert.False(t, ink.Truncated)
+		assert.Empty(t, ink.Errors)
+
+		quiet := digest.Repositories[1]
+		assert.Equal(t, "octo/quiet", quiet.Repository)
+		assert.Len(t, quiet.Errors, 4)
+		assert.Contains(t, quiet.Errors[0], "failed to list releases")
+	})
+
+	t.Run("defaults to the repositories of the roots", func(t *testing.T) {
+		sessionRoots.update("", func(rc **RootContext) {
+			*rc = &RootContext{Supported: true, Repositories: []GitHubRoot{{Owner: "octo", Repo: "ink"}, {Owner: "octo", Repo: "ink", Ref: "main"}}}
+		})
+		t.Cleanup(func() { sessionRoots.delete("") })
+
+		request := createMCPRequest(window)
+		result, err := serverTool.Handler(deps)(ContextWithDeps(context.Background(), deps), &request)
+		require.NoError(t, err)
+		require.False(t, result.IsError, getTextResult(t, result).Text)
+
+		var digest RepositoriesDigest
+		require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &digest))
+		require.Len(t, digest.Repositories, 1)
+		assert.Equal(t, "octo/ink", digest.Repositories[0].Repository)
+	})
+
+	t.Run("without repositories or roots", func(t *testing.T) {
+		request := createMCPRequest(window)
+		result, err := serverTool.Handler(deps)(ContextWithDeps(context.Background(), deps), &request)
+		require.NoError(t, err)
+		assert.Contains(t, getErrorResult(t, result).Text, "missing required parameter: repositories")
+	})
+
+	t.Run("invalid window", func(t *testing.T) {
+		request := createMCPRequest(map[string]any{"repositories": []any{"octo/ink"}, "since": "2026-10-17T00:00:00Z", "until": "2026-10-16T00:00:00Z"})
+		result, err := serverTool.Handler(deps)(ContextWithDeps(context.Background(), deps), &request)
+		require.NoError(t, err)
+		assert.Contains(t, getErrorResult(t, result).Text, "since must be before until")
+	})
+}
@@ -3920,7 +3920,7 @@ func Test_ListStarredRepositories(t *testing.T) {
 			),
 			requestArgs:   map[string]any{},
 			expectError:   false,
-			expectedCount: 2,
+			expectedCount: 1, // forks are left out by default
 		},
 		{
 			name: "successful list for specific user",
@@ -3934,7 +3934,8 @@ func Test_ListStarredRepositories(t *testing.T) {
 				),
 			),
 			requestArgs: map[string]any{
-				"username": "testuser",
+				"username":      "testuser",
+				"include_forks": true,
 			},
 			expectError:   false,
 			expectedCount: 2,
@@ -4180,3 +4181,133 @@ func Test_UnstarRepository(t *testing.T) {
 		})
 	}
 }
+
+func Test_GetFilesContents(t *testing.T) {
+	// Verify tool definition once
+	serverTool := GetFilesContents(translations.NullTranslationHelper)
+	tool := serverTool.Tool
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	schema, ok := tool.InputSchema.(*jsonschema.Schema)
+	require.True(t, ok, "InputSchema should be *jsonschema.Schema")
+	assert.Equal(t, "get_files_contents", tool.Name)
+	assert.True(t, tool.Annotations.ReadOnlyHint)
+	assert.ElementsMatch(t, schema.Required, []string{"owner", "repo", "paths"})
+
+	const sha = "0123456789abcdef0123456789abcdef01234567"
+	rawHandler := func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/owner/repo/" + sha + "/README.md":
+			_, _ = w.Write([]byte("# Hello"))
+		case "/owner/repo/" + sha + "/src/main.go":
+			_, _ = w.Write([]byte("package main\n"))
+		case "/owner/repo/" + sha + "/logo.png":
+			_, _ = w.Write([]byte("\x89PNG\r\n\x1a\n\x00\x00\x00\x0dIHDR"))
+		case "/owner/repo/" + sha + "/big.txt":
+			_, _ = w.Write([]byte(strings.Repeat("a", maxBatchFileSize+1)))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}
+
+	tests := []struct {
+		name           string
+		apiHandlers    map[string]http.HandlerFunc
+		requestArgs    map[string]any
+		expectError    string
+		expectedResult FilesContents
+	}{
+		{
+			name:        "reads files at the resolved commit",
+			apiHandlers: map[string]http.HandlerFunc{GetReposGitRefByOwnerByRepoByRef: mockResponse(t, http.StatusOK, &github.Reference{Ref: github.Ptr("refs/heads/main"), Object: &github.GitObject{SHA: github.Ptr(sha)}})},
+			requestArgs: map[string]any{
+				"owner":       "owner",
+				"repo":        "repo",
+				"ref":         "refs/heads/main",
+				"paths":       []any{"README.md", "/src/main.go", "README.md", "logo.png", "missing.txt", "big.txt"},
+				"parallelism": 2,
+			},
+			expectedResult: FilesContents{
+				Owner: "owner",
+				Repo:  "repo",
+				Ref:   "refs/heads/main",
+				SHA:   sha,
+				Files: []FileContents{
+					{Path: "README.md", Size: 7, Content: "# Hello"},
+					{Path: "src/main.go", Size: 13, Content: "package main\n"},
+					{Path: "logo.png", Size: 16, Binary: true},
+					{Path: "missing.txt", Error: "not found: the path does not exist, or is a directory"},
+					{Path: "big.txt", Error: "file is too large to return (over 1MB); use get_file_contents to get its download URL"},
+				},
+			},
+		},
+		{
+			name:        "reads files at a commit SHA",
+			apiHandlers: map[string]http.HandlerFunc{},
+			requestArgs: map[string]any{
+				"owner": "owner",
+				"repo":  "repo",
+				"sha":   sha,
+				"paths": []any{"README.md"},
+			},
+			expectedResult: FilesContents{
+				Owner: "owner",
+				Repo:  "repo",
+				SHA:   sha,
+				Files: []FileContents{{Path: "README.md", Size: 7, Content: "# Hello"}},
+			},
+		},
+		{
+			name:        "too many paths",
+			apiHandlers: map[string]http.HandlerFunc{},
+			requestArgs: map[string]any{
+				"owner": "owner",
+				"repo":  "repo",
+				"sha":   sha,
+				"paths": func() []any {
+					paths := make([]any, maxBatchFiles+1)
+					for i := range paths {
+						paths[i] = "file" + strings.Repeat("x", i)
+					}
+					return paths
+				}(),
+			},
+			expectError: "too many paths",
+		},
+		{
+			name:        "no paths",
+			apiHandlers: map[string]http.HandlerFunc{},
+			requestArgs: map[string]any{
+				"owner": "owner",
+				"repo":  "repo",
+				"paths": []any{" "},
+			},
+			expectError: "missing required parameter: paths",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := github.NewClient(MockHTTPClientWithHandlers(tc.apiHandlers))
+			rawClient := raw.NewClient(github.NewClient(MockHTTPClientWithHandlers(map[string]http.HandlerFunc{"": rawHandler})), &url.URL{Scheme: "https", Host: "raw.example.com", Path: "/"})
+			deps := BaseDeps{
+				Client:    client,
+				RawClient: rawClient,
+			}
+			handler := serverTool.Handler(deps)
+
+			request := createMCPRequest(tc.requestArgs)
+			result, err := handler(ContextWithDeps(context.Background(), deps), &request)
+			require.NoError(t, err)
+
+			if tc.expectError != "" {
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectError)
+				return
+			}
+
+			var returned FilesContents
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returned))
+			assert.Equal(t, tc.expectedResult, returned)
+		})
+	}
+}
@@ -154,11 +154,24 @@ func completeBranch(ctx context.Context, client *github.Client, resolved map[str
 	if owner == "" || repo == "" {
 		return values, errors.New("owner or repo not specified")
 	}
-	branches, _, _ := client.Repositories.ListBranches(ctx, owner, repo, nil)
+	branches, err := completionLookups.get(ctx, "branches", owner, repo, func() ([]string, error) {
+		branches, _, err := client.Repositories.ListBranches(ctx, owner, repo, &github.BranchListOptions{ListOptions: github.ListOptions{PerPage: 100}})
+		if err != nil {
+			return nil, err
+		}
+		names := make([]string, 0, len(branches))
+		for _, branch := range branches {
+			names = append(names, branch.GetName())
+		}
+		return names, nil
+	})
+	if err != nil {
+		return values, nil
+	}
 
 	for _, branch := range branches {
-		if argValue == "" || strings.HasPrefix(branch.GetName(), argValue) {
-			values = append(values, branch.GetName())
+		if argValue == "" || strings.HasPrefix(branch, argValue) {
+			values = append(values, branch)
 		}
 	}
 	if len(values) > 100 {
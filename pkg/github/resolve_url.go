@@ -0,0 +1,291 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/inventory"
+	"github.com/github/github-mcp-server/pkg/scopes"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/github/github-mcp-server/pkg/utils"
+	"github.com/google/go-github/v82/github"
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// githubURLKind identifies the kind of object a github.com URL points to.
+type githubURLKind string
+
+const (
+	githubURLIssue       githubURLKind = "issue"
+	githubURLPullRequest githubURLKind = "pull_request"
+	githubURLFile        githubURLKind = "file"
+	githubURLCommit      githubURLKind = "commit"
+	githubURLDiscussion  githubURLKind = "discussion"
+	githubURLRelease     githubURLKind = "release"
+)
+
+// lineFragmentPattern matches line anchors such as #L10, #L10-L20 and #L10C3-L20C5.
+var lineFragmentPattern = regexp.MustCompile(`^L(\d+)(?:C\d+)?(?:-L(\d+)(?:C\d+)?)?$`)
+
+// githubURL is a github.com URL broken down into the parts needed to fetch the object it points to.
+type githubURL struct {
+	Kind      githubURLKind
+	Owner     string
+	Repo      string
+	Number    int
+	Ref       string
+	Path      string
+	StartLine int
+	EndLine   int
+	Tag       string
+}
+
+// parseGitHubURL parses a github.com URL of an issue, pull request, file, commit, discussion or release.
+// Hosts are not validated here.
+func parseGitHubURL(rawURL string) (githubURL, error) {
+	u, err := url.Parse(strings.TrimSpace(rawURL))
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return githubURL{}, fmt.Errorf("not a valid GitHub URL: %s", rawURL)
+	}
+
+	parts := strings.Split(strings.Trim(u.EscapedPath(), "/"), "/")
+	for i, p := range parts {
+		if unescaped, err := url.PathUnescape(p); err == nil {
+			parts[i] = unescaped
+		}
+	}
+	if len(parts) < 4 {
+		return githubURL{}, fmt.Errorf("unsupported GitHub URL: %s", rawURL)
+	}
+
+	result := githubURL{Owner: parts[0], Repo: parts[1]}
+	section, rest := parts[2], parts[3:]
+
+	parseNumber := func() error {
+		n, err := strconv.Atoi(rest[0])
+		if err != nil || n <= 0 {
+			return fmt.Errorf("invalid number in GitHub URL: %s", rawURL)
+		}
+		result.Number = n
+		return nil
+	}
+
+	switch section {
+	case "issues":
+		result.Kind = githubURLIssue
+		return result, parseNumber()
+	case "pull":
+		// Commits viewed inside a pull request resolve to the commit itself.
+		if len(rest) >= 3 && rest[1] == "commits" {
+			result.Kind = githubURLCommit
+			result.Ref = rest[2]
+			return result, nil
+		}
+		result.Kind = githubURLPullRequest
+		return result, parseNumber()
+	case "discussions":
+		result.Kind = githubURLDiscussion
+		return result, parseNumber()
+	case "commit":
+		result.Kind = githubURLCommit
+		result.Ref = rest[0]
+		return result, nil
+	case "releases":
+		result.Kind = githubURLRelease
+		switch {
+		case rest[0] == "latest":
+			return result, nil
+		case rest[0] == "tag" && len(rest) >= 2:
+			result.Tag = strings.Join(rest[1:], "/")
+			return result, nil
+		}
+	case "blob":
+		if len(rest) < 2 {
+			break
+		}
+		result.Kind = githubURLFile
+		result.Ref = rest[0]
+		result.Path = strings.Join(rest[1:], "/")
+		if u.Fragment != "" {
+			m := lineFragmentPattern.FindStringSubmatch(u.Fragment)
+			if m == nil {
+				return githubURL{}, fmt.Errorf("unsupported line anchor in GitHub URL: #%s", u.Fragment)
+			}
+			result.StartLine, _ = strconv.Atoi(m[1])
+			result.EndLine = result.StartLine
+			if m[2] != "" {
+				result.EndLine, _ = strconv.Atoi(m[2])
+			}
+			if result.EndLine < result.StartLine {
+				result.StartLine, result.EndLine = result.EndLine, result.StartLine
+			}
+		}
+		return result, nil
+	}
+
+	return githubURL{}, fmt.Errorf("unsupported GitHub URL: %s", rawURL)
+}
+
+// FileLines is a range of lines from a file in a repository.
+type FileLines struct {
+	Owner     string `json:"owner"`
+	Repo      string `json:"repo"`
+	Path      string `json:"path"`
+	Ref       string `json:"ref"`
+	StartLine int    `json:"start_line,omitempty"`
+	EndLine   int    `json:"end_line,omitempty"`
+	Content   string `json:"content"`
+	HTMLURL   string `json:"html_url,omitempty"`
+}
+
+// ResolveGitHubURL creates a tool that fetches the object a github.com URL points to.
+func ResolveGitHubURL(t translations.TranslationHelperFunc) inventory.ServerTool {
+	return NewTool(
+		ToolsetMetadataRepos,
+		mcp.Tool{
+			Name: "resolve_github_url",
+			Description: t("TOOL_RESOLVE_GITHUB_URL_DESCRIPTION", `Fetch the object a GitHub URL points to, without having to parse the URL first.
+Supports issue, pull request, discussion, commit and release URLs, and file URLs with an optional line range (e.g. https://github.com/owner/repo/blob/main/README.md#L10-L20).`),
+			Annotations: &mcp.ToolAnnotations{
+				Title:        t("TOOL_RESOLVE_GITHUB_URL_USER_TITLE", "Resolve GitHub URL"),
+				ReadOnlyHint: true,
+			},
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"url": {
+						Type:        "string",
+						Description: "GitHub URL to resolve",
+					},
+				},
+				Required: []string{"url"},
+			},
+		},
+		[]scopes.Scope{scopes.Repo},
+		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+			rawURL, err := RequiredParam[string](args, "url")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			parsed, err := parseGitHubURL(rawURL)
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+
+			client, err := deps.GetClient(ctx)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			if u, _ := url.Parse(rawURL); !isGitHubWebHost(u.Hostname(), client) {
+				return utils.NewToolResultError(fmt.Sprintf("URL host %s does not belong to the configured GitHub instance", u.Hostname())), nil, nil
+			}
+
+			repoArgs := map[string]any{"owner": parsed.Owner, "repo": parsed.Repo}
+			switch parsed.Kind {
+			case githubURLIssue:
+				repoArgs["method"] = "get"
+				repoArgs["issue_number"] = parsed.Number
+				return dispatchTool(ctx, deps, IssueRead(t), repoArgs)
+			case githubURLPullRequest:
+				repoArgs["method"] = "get"
+				repoArgs["pullNumber"] = parsed.Number
+				return dispatchTool(ctx, deps, PullRequestRead(t), repoArgs)
+			case githubURLDiscussion:
+				repoArgs["discussionNumber"] = parsed.Number
+				return dispatchTool(ctx, deps, GetDiscussion(t), repoArgs)
+			case githubURLCommit:
+				repoArgs["sha"] = parsed.Ref
+				repoArgs["include_diff"] = false
+				return dispatchTool(ctx, deps, GetCommit(t), repoArgs)
+			case githubURLRelease:
+				if parsed.Tag == "" {
+					return dispatchTool(ctx, deps, GetLatestRelease(t), repoArgs)
+				}
+				repoArgs["tag"] = parsed.Tag
+				return dispatchTool(ctx, deps, GetReleaseByTag(t), repoArgs)
+			default:
+				return getFileLines(ctx, client, parsed, rawURL)
+			}
+		},
+	)
+}
+
+// isGitHubWebHost reports whether host serves the web UI of the GitHub instance the client talks to.
+func isGitHubWebHost(host string, client *github.Client) bool {
+	host = strings.ToLower(host)
+	apiHost := strings.ToLower(client.BaseURL.Hostname())
+	webHost := strings.TrimPrefix(apiHost, "api.")
+	return host == apiHost || host == webHost || host == "www."+webHost
+}
+
+// dispatchTool invokes another tool's handler with the given arguments, so results have the same
+// shape as calling that tool directly.
+func dispatchTool(ctx context.Context, deps ToolDependencies, tool inventory.ServerTool, args map[string]any) (*mcp.CallToolResult, any, error) {
+	raw, err := json.Marshal(args)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to marshal arguments: %w", err)
+	}
+	result, err := tool.Handler(deps)(ctx, &mcp.CallToolRequest{Params: &mcp.CallToolParamsRaw{Name: tool.Tool.Name, Arguments: raw}})
+	return result, nil, err
+}
+
+// maxBlobRefSegments bounds the number of path segments tried as the ref of a file URL.
+const maxBlobRefSegments = 5
+
+// getFileLines fetches the lines a file URL points to. A file URL does not tell where a ref
+// containing slashes, such as feature/x, ends and the path begins, so while the file is not found
+// the first segment of the path is moved to the ref and the file asked for again.
+func getFileLines(ctx context.Context, client *github.Client, parsed githubURL, rawURL string) (*mcp.CallToolResult, any, error) {
+	var fileContent *github.RepositoryContent
+	for segments := 1; ; segments++ {
+		var resp *github.Response
+		var err error
+		fileContent, _, resp, err = client.Repositories.GetContents(ctx, parsed.Owner, parsed.Repo, parsed.Path, &github.RepositoryContentGetOptions{Ref: parsed.Ref})
+		if err == nil {
+			_ = resp.Body.Close()
+			break
+		}
+		first, rest, found := strings.Cut(parsed.Path, "/")
+		if resp == nil || resp.StatusCode != http.StatusNotFound || !found || segments == maxBlobRefSegments {
+			return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to get file contents", resp, err), nil, nil
+		}
+		parsed.Ref += "/" + first
+		parsed.Path = rest
+	}
+	if fileContent == nil {
+		return utils.NewToolResultError(fmt.Sprintf("%s is not a file", parsed.Path)), nil, nil
+	}
+	content, err := fileContent.GetContent()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to decode file content: %w", err)
+	}
+
+	result := FileLines{
+		Owner:   parsed.Owner,
+		Repo:    parsed.Repo,
+		Path:    parsed.Path,
+		Ref:     parsed.Ref,
+		Content: content,
+		HTMLURL: rawURL,
+	}
+	if parsed.StartLine > 0 {
+		lines := strings.Split(content, "\n")
+		if parsed.StartLine > len(lines) {
+			return utils.NewToolResultError(fmt.Sprintf("line %d is past the end of %s (%d lines)", parsed.StartLine, parsed.Path, len(lines))), nil, nil
+		}
+		end := min(parsed.EndLine, len(lines))
+		result.StartLine = parsed.StartLine
+		result.EndLine = end
+		result.Content = strings.Join(lines[parsed.StartLine-1:end], "\n")
+	}
+
+	return MarshalledTextResult(result), nil, nil
+}
@@ -0,0 +1,260 @@
+package github
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v82/github"
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/shurcooL/githubv4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ParseGitHubURL(t *testing.T) {
+	tests := []struct {
+		name        string
+		url         string
+		expected    githubURL
+		expectedErr string
+	}{
+		{
+			name:     "issue",
+			url:      "https://github.com/owner/repo/issues/42",
+			expected: githubURL{Kind: githubURLIssue, Owner: "owner", Repo: "repo", Number: 42},
+		},
+		{
+			name:     "issue comment anchor",
+			url:      "https://github.com/owner/repo/issues/42#issuecomment-1",
+			expected: githubURL{Kind: githubURLIssue, Owner: "owner", Repo: "repo", Number: 42},
+		},
+		{
+			name:     "pull request files tab",
+			url:      "https://github.com/owner/repo/pull/7/files",
+			expected: githubURL{Kind: githubURLPullRequest, Owner: "owner", Repo: "repo", Number: 7},
+		},
+		{
+			name:     "commit in pull request",
+			url:      "https://github.com/owner/repo/pull/7/commits/abc123",
+			expected: githubURL{Kind: githubURLCommit, Owner: "owner", Repo: "repo", Ref: "abc123"},
+		},
+		{
+			name:     "commit",
+			url:      "https://github.com/owner/repo/commit/abc123",
+			expected: githubURL{Kind: githubURLCommit, Owner: "owner", Repo: "repo", Ref: "abc123"},
+		},
+		{
+			name:     "discussion",
+			url:      "https://github.com/owner/repo/discussions/3",
+			expected: githubURL{Kind: githubURLDiscussion, Owner: "owner", Repo: "repo", Number: 3},
+		},
+		{
+			name:     "release by tag",
+			url:      "https://github.com/owner/repo/releases/tag/v1.2.0",
+			expected: githubURL{Kind: githubURLRelease, Owner: "owner", Repo: "repo", Tag: "v1.2.0"},
+		},
+		{
+			name:     "latest release",
+			url:      "https://github.com/owner/repo/releases/latest",
+			expected: githubURL{Kind: githubURLRelease, Owner: "owner", Repo: "repo"},
+		},
+		{
+			name:     "file with line range",
+			url:      "https://github.com/owner/repo/blob/main/pkg/a.go#L10-L20",
+			expected: githubURL{Kind: githubURLFile, Owner: "owner", Repo: "repo", Ref: "main", Path: "pkg/a.go", StartLine: 10, EndLine: 20},
+		},
+		{
+			name:     "file with single line and columns",
+			url:      "https://github.com/owner/repo/blob/abc123/a.go#L5C2",
+			expected: githubURL{Kind: githubURLFile, Owner: "owner", Repo: "repo", Ref: "abc123", Path: "a.go", StartLine: 5, EndLine: 5},
+		},
+		{
+			name:     "file without line range",
+			url:      "https://github.com/owner/repo/blob/main/README.md",
+			expected: githubURL{Kind: githubURLFile, Owner: "owner", Repo: "repo", Ref: "main", Path: "README.md"},
+		},
+		{
+			name:        "repository url",
+			url:         "https://github.com/owner/repo",
+			expectedErr: "unsupported GitHub URL",
+		},
+		{
+			name:        "unsupported section",
+			url:         "https://github.com/owner/repo/actions/runs/1",
+			expectedErr: "unsupported GitHub URL",
+		},
+		{
+			name:        "invalid number",
+			url:         "https://github.com/owner/repo/issues/abc",
+			expectedErr: "invalid number",
+		},
+		{
+			name:        "not a url",
+			url:         "owner/repo#1",
+			expectedErr: "not a valid GitHub URL",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			parsed, err := parseGitHubURL(tc.url)
+			if tc.expectedErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, parsed)
+		})
+	}
+}
+
+func Test_ResolveGitHubURL(t *testing.T) {
+	// Verify tool definition once
+	serverTool := ResolveGitHubURL(translations.NullTranslationHelper)
+	tool := serverTool.Tool
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "resolve_github_url", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.True(t, tool.Annotations.ReadOnlyHint)
+	schema := tool.InputSchema.(*jsonschema.Schema)
+	assert.Contains(t, schema.Properties, "url")
+	assert.ElementsMatch(t, schema.Required, []string{"url"})
+
+	mockIssue := &github.Issue{
+		Number:  github.Ptr(42),
+		Title:   github.Ptr("Test issue"),
+		State:   github.Ptr("open"),
+		HTMLURL: github.Ptr("https://github.com/owner/repo/issues/42"),
+		User:    &github.User{Login: github.Ptr("octocat")},
+	}
+	mockFile := &github.RepositoryContent{
+		Type:     github.Ptr("file"),
+		Path:     github.Ptr("pkg/a.go"),
+		Encoding: github.Ptr("base64"),
+		Content:  github.Ptr(base64.StdEncoding.EncodeToString([]byte("line 1\nline 2\nline 3\nline 4\n"))),
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		url            string
+		expectError    bool
+		expectedErrMsg string
+		verify         func(t *testing.T, text string)
+	}{
+		{
+			name: "issue url",
+			mockedClient: MockHTTPClientWithHandlers(map[string]http.HandlerFunc{
+				GetReposIssuesByOwnerByRepoByIssueNumber: mockResponse(t, http.StatusOK, mockIssue),
+			}),
+			url: "https://github.com/owner/repo/issues/42",
+			verify: func(t *testing.T, text string) {
+				var issue MinimalIssue
+				require.NoError(t, json.Unmarshal([]byte(text), &issue))
+				assert.Equal(t, 42, issue.Number)
+				assert.Equal(t, "Test issue", issue.Title)
+			},
+		},
+		{
+			name: "file url with line range",
+			mockedClient: MockHTTPClientWithHandlers(map[string]http.HandlerFunc{
+				"GET /repos/{owner}/{repo}/contents/{path:.*}": expectQueryParams(t, map[string]string{"ref": "main"}).andThen(
+					mockResponse(t, http.StatusOK, mockFile),
+				),
+			}),
+			url: "https://github.com/owner/repo/blob/main/pkg/a.go#L2-L3",
+			verify: func(t *testing.T, text string) {
+				var lines FileLines
+				require.NoError(t, json.Unmarshal([]byte(text), &lines))
+				assert.Equal(t, "pkg/a.go", lines.Path)
+				assert.Equal(t, "main", lines.Ref)
+				assert.Equal(t, 2, lines.StartLine)
+				assert.Equal(t, 3, lines.EndLine)
+				assert.Equal(t, "line 2\nline 3", lines.Content)
+			},
+		},
+		{
+			name: "file url on a ref containing a slash",
+			mockedClient: MockHTTPClientWithHandlers(map[string]http.HandlerFunc{
+				"GET /repos/{owner}/{repo}/contents/{path:.*}": func(w http.ResponseWriter, r *http.Request) {
+					if r.URL.Query().Get("ref") != "feature/x" || r.URL.Path != "/repos/owner/repo/contents/pkg/a.go" {
+						w.WriteHeader(http.StatusNotFound)
+						_, _ = w.Write([]byte(`{"message": "No commit found for the ref"}`))
+						return
+					}
+					mockResponse(t, http.StatusOK, mockFile)(w, r)
+				},
+			}),
+			url: "https://github.com/owner/repo/blob/feature/x/pkg/a.go#L1",
+			verify: func(t *testing.T, text string) {
+				var lines FileLines
+				require.NoError(t, json.Unmarshal([]byte(text), &lines))
+				assert.Equal(t, "feature/x", lines.Ref)
+				assert.Equal(t, "pkg/a.go", lines.Path)
+				assert.Equal(t, "line 1", lines.Content)
+			},
+		},
+		{
+			name: "file not found on any ref",
+			mockedClient: MockHTTPClientWithHandlers(map[string]http.HandlerFunc{
+				"GET /repos/{owner}/{repo}/contents/{path:.*}": mockResponse(t, http.StatusNotFound, `{"message": "Not Found"}`),
+			}),
+			url:            "https://github.com/owner/repo/blob/main/pkg/missing.go",
+			expectError:    true,
+			expectedErrMsg: "failed to get file contents",
+		},
+		{
+			name:           "line range past end of file",
+			mockedClient:   MockHTTPClientWithHandlers(map[string]http.HandlerFunc{"GET /repos/{owner}/{repo}/contents/{path:.*}": mockResponse(t, http.StatusOK, mockFile)}),
+			url:            "https://github.com/owner/repo/blob/main/pkg/a.go#L50",
+			expectError:    true,
+			expectedErrMsg: "line 50 is past the end of pkg/a.go",
+		},
+		{
+			name:           "url from another host",
+			mockedClient:   MockHTTPClientWithHandlers(map[string]http.HandlerFunc{}),
+			url:            "https://gitlab.com/owner/repo/issues/42",
+			expectError:    true,
+			expectedErrMsg: "does not belong to the configured GitHub instance",
+		},
+		{
+			name:           "unsupported url",
+			mockedClient:   MockHTTPClientWithHandlers(map[string]http.HandlerFunc{}),
+			url:            "https://github.com/owner/repo/actions",
+			expectError:    true,
+			expectedErrMsg: "unsupported GitHub URL",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			deps := BaseDeps{
+				Client:          github.NewClient(tc.mockedClient),
+				GQLClient:       githubv4.NewClient(nil),
+				RepoAccessCache: stubRepoAccessCache(githubv4.NewClient(nil), 15*time.Minute),
+				Flags:           stubFeatureFlags(map[string]bool{"lockdown-mode": false}),
+			}
+			handler := serverTool.Handler(deps)
+
+			request := createMCPRequest(map[string]any{"url": tc.url})
+			result, err := handler(ContextWithDeps(context.Background(), deps), &request)
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			tc.verify(t, getTextResult(t, result).Text)
+		})
+	}
+}
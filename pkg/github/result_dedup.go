@@ -0,0 +1,102 @@
+package github
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// ResultDedupMetaKey is the _meta key under which a DuplicateResult is attached to tool results
+// replaced by ResultDedupMiddleware.
+const ResultDedupMetaKey = "github.com/duplicate-result"
+
+const (
+	// minDedupResultSize is the size of the smallest result worth replacing: the marker of
+	// smaller results would save little or nothing.
+	minDedupResultSize = 512
+	// maxDedupResults bounds the number of result hashes remembered for each session.
+	maxDedupResults = 1000
+)
+
+// DuplicateResult tells the caller that a tool result was identical to an earlier one.
+type DuplicateResult struct {
+	// Call is the number of the tool call of the session, counting from 1, that first returned
+	// the result.
+	Call   int    `json:"call"`
+	SHA256 string `json:"sha256"`
+}
+
+// resultHistory holds the hashes of the tool results of a session, and the number of the call
+// that first returned each.
+type resultHistory struct {
+	calls  int
+	hashes map[string]int
+}
+
+// sessionResults holds the result history of each session.
+var sessionResults = newSessionStore[resultHistory](sessionStoreMaxSessions)
+
+// ResultDedupMiddleware replaces tool results byte-identical to a result returned earlier in the
+// session with a short marker naming that call and the hash of the result, saving context when
+// agents poll the same endpoints again. Error results and small results are returned as is.
+func ResultDedupMiddleware(next mcp.MethodHandler) mcp.MethodHandler {
+	return func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+		result, err := next(ctx, method, req)
+		callReq, ok := req.(*mcp.CallToolRequest)
+		toolResult, isToolResult := result.(*mcp.CallToolResult)
+		if method != "tools/call" || !ok || !isToolResult || toolResult == nil {
+			return result, err
+		}
+
+		hash, size := hashResult(toolResult)
+		var call, firstCall int
+		sessionResults.update(sessionID(callReq), func(h *resultHistory) {
+			h.calls++
+			call = h.calls
+			if toolResult.IsError || size < minDedupResultSize {
+				return
+			}
+			if h.hashes == nil || len(h.hashes) >= maxDedupResults {
+				h.hashes = make(map[string]int)
+			}
+			if first, ok := h.hashes[hash]; ok {
+				firstCall = first
+				return
+			}
+			h.hashes[hash] = call
+		})
+		if firstCall == 0 {
+			return toolResult, err
+		}
+
+		marker := &mcp.CallToolResult{
+			Meta: toolResult.Meta,
+			Content: []mcp.Content{&mcp.TextContent{
+				Text: fmt.Sprintf("Result unchanged: identical to the result of call #%d of this session (sha256:%s). Refer to that result instead.", firstCall, hash[:12]),
+			}},
+		}
+		if marker.Meta == nil {
+			marker.Meta = mcp.Meta{}
+		}
+		marker.Meta[ResultDedupMetaKey] = DuplicateResult{Call: firstCall, SHA256: hash}
+		return marker, err
+	}
+}
+
+// hashResult returns the SHA-256 hash of the content and structured content of a tool result, and
+// the size of their JSON encoding.
+func hashResult(result *mcp.CallToolResult) (string, int) {
+	data, err := json.Marshal(struct {
+		Content           []mcp.Content `json:"content"`
+		StructuredContent any           `json:"structuredContent,omitempty"`
+	}{result.Content, result.StructuredContent})
+	if err != nil {
+		return "", 0
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), len(data)
+}
@@ -0,0 +1,59 @@
+package github
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/github/github-mcp-server/pkg/utils"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ResultDedupMiddleware(t *testing.T) {
+	sessionResults.delete("")
+	t.Cleanup(func() { sessionResults.delete("") })
+
+	large := strings.Repeat("x", minDedupResultSize)
+	results := map[string]*mcp.CallToolResult{
+		"list_issues":      utils.NewToolResultText(large),
+		"list_branches":    utils.NewToolResultText("[]"),
+		"get_job_logs":     utils.NewToolResultError(large),
+		"list_commits":     utils.NewToolResultText(large + "y"),
+		"get_file_content": utils.NewToolResultText(large),
+	}
+	handler := ResultDedupMiddleware(func(_ context.Context, _ string, req mcp.Request) (mcp.Result, error) {
+		// Copy the result, as the middleware may attach _meta to it
+		result := *results[req.(*mcp.CallToolRequest).Params.Name]
+		return &result, nil
+	})
+	call := func(name string) *mcp.CallToolResult {
+		result, err := handler(context.Background(), "tools/call", &mcp.CallToolRequest{Params: &mcp.CallToolParamsRaw{Name: name}})
+		require.NoError(t, err)
+		return result.(*mcp.CallToolResult)
+	}
+
+	first := call("list_issues")
+	assert.Equal(t, large, getTextResult(t, first).Text)
+	assert.NotContains(t, first.Meta, ResultDedupMetaKey)
+
+	// Small and error results are always returned as is
+	for range 2 {
+		assert.Equal(t, "[]", getTextResult(t, call("list_branches")).Text)
+		assert.Equal(t, large, getErrorResult(t, call("get_job_logs")).Text)
+	}
+	assert.Equal(t, large+"y", getTextResult(t, call("list_commits")).Text)
+
+	// Any result identical to an earlier one is replaced, whichever tool returned it
+	for _, name := range []string{"list_issues", "get_file_content"} {
+		duplicate := call(name)
+		require.Contains(t, duplicate.Meta, ResultDedupMetaKey)
+		marker := duplicate.Meta[ResultDedupMetaKey].(DuplicateResult)
+		assert.Equal(t, 1, marker.Call)
+		assert.Len(t, marker.SHA256, 64)
+		text := getTextResult(t, duplicate).Text
+		assert.Contains(t, text, "identical to the result of call #1 of this session")
+		assert.Contains(t, text, "sha256:"+marker.SHA256[:12])
+	}
+}
@@ -0,0 +1,394 @@
+package github
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/github/github-mcp-server/pkg/inventory"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// rootsListTimeout bounds how long a tool call waits for the client to list its roots.
+const rootsListTimeout = 5 * time.Second
+
+// GitHubRoot is a client root that resolves to a GitHub repository, and optionally to a branch,
+// tag or commit of it and a directory within it.
+type GitHubRoot struct {
+	URI   string `json:"uri"`
+	Name  string `json:"name,omitempty"`
+	Owner string `json:"owner"`
+	Repo  string `json:"repo"`
+	Ref   string `json:"ref,omitempty"`
+	Path  string `json:"path,omitempty"`
+}
+
+// RootContext is what the server knows about the roots of a session.
+type RootContext struct {
+	// Supported reports whether the client answered the roots/list request.
+	Supported bool `json:"supported"`
+	// Roots lists all roots of the client, and Repositories those resolving to a repository on
+	// the GitHub host the server talks to.
+	Roots        []*mcp.Root  `json:"roots"`
+	Repositories []GitHubRoot `json:"repositories"`
+	// Owner, Repo, Ref and Path are inferred when all repositories agree on them. Tools use them
+	// as defaults for omitted owner, repo, ref, branch and path arguments, unless set_context was
+	// called.
+	Owner       string    `json:"owner,omitempty"`
+	Repo        string    `json:"repo,omitempty"`
+	Ref         string    `json:"ref,omitempty"`
+	Path        string    `json:"path,omitempty"`
+	RefreshedAt time.Time `json:"refreshed_at"`
+	Error       string    `json:"error,omitempty"`
+}
+
+// sessionContext returns the defaults the roots imply for owner, repo, ref and path arguments.
+func (rc *RootContext) sessionContext() SessionContext {
+	if rc == nil {
+		return SessionContext{}
+	}
+	return SessionContext{Owner: rc.Owner, Repo: rc.Repo, Ref: rc.Ref, Path: rc.Path}
+}
+
+var sessionRoots = newSessionStore[*RootContext](sessionStoreMaxSessions)
+
+// ParseGitHubRootURI returns the repository a root URI points to, for URIs on webHost such as
+// "https://github.com/octo/hello". An empty webHost means github.com. URIs of a branch, tag or
+// commit, such as "https://github.com/octo/hello/tree/main", also set the Ref of the root, and
+// URIs of a directory, such as ".../tree/main/services/payments", its Path. Refs containing
+// slashes must escape them as %2F, as in ".../tree/feature%2Flogin/services/payments".
+func ParseGitHubRootURI(uri, webHost string) (GitHubRoot, bool) {
+	u, err := url.Parse(uri)
+	if err != nil || (u.Scheme != "https" && u.Scheme != "http") || !sameHost(u.Hostname(), webHost) {
+		return GitHubRoot{}, false
+	}
+	owner, repo, ok := ownerRepoFromPath(u.Path)
+	if !ok {
+		return GitHubRoot{}, false
+	}
+	root := GitHubRoot{URI: uri, Owner: owner, Repo: repo}
+
+	segments := strings.Split(strings.Trim(u.EscapedPath(), "/"), "/")
+	if len(segments) >= 4 && segments[2] == "tree" {
+		ref, err := url.PathUnescape(segments[3])
+		if err != nil || ref == "" {
+			return GitHubRoot{}, false
+		}
+		root.Ref = ref
+
+		for _, segment := range segments[4:] {
+			part, err := url.PathUnescape(segment)
+			if err != nil {
+				return GitHubRoot{}, false
+			}
+			if part != "" {
+				root.Path = path.Join(root.Path, part)
+			}
+		}
+	}
+	return root, true
+}
+
+// resolveRoot returns the repository a root points to. Besides GitHub URIs, file:// roots of local
+// clones are resolved through the URL of their "origin" remote, which only works when the client
+// shares the server's file system, as with stdio.
+func resolveRoot(root *mcp.Root, webHost string) (GitHubRoot, bool) {
+	if gr, ok := ParseGitHubRootURI(root.URI, webHost); ok {
+		gr.Name = root.Name
+		return gr, true
+	}
+	u, err := url.Parse(root.URI)
+	if err != nil || u.Scheme != "file" {
+		return GitHubRoot{}, false
+	}
+	remote, err := gitOriginURL(filepath.FromSlash(u.Path))
+	if err != nil {
+		return GitHubRoot{}, false
+	}
+	owner, repo, ok := parseGitRemote(remote, webHost)
+	if !ok {
+		return GitHubRoot{}, false
+	}
+	return GitHubRoot{URI: root.URI, Name: root.Name, Owner: owner, Repo: repo}, true
+}
+
+// parseGitRemote parses remote URLs such as "git@github.com:octo/hello.git",
+// "ssh://git@github.com/octo/hello.git" and "https://github.com/octo/hello".
+func parseGitRemote(remote, webHost string) (owner, repo string, ok bool) {
+	if !strings.Contains(remote, "://") {
+		// scp-like syntax: [user@]host:path
+		userHost, path, found := strings.Cut(remote, ":")
+		if !found {
+			return "", "", false
+		}
+		if i := strings.LastIndex(userHost, "@"); i >= 0 {
+			userHost = userHost[i+1:]
+		}
+		if !sameHost(userHost, webHost) {
+			return "", "", false
+		}
+		return ownerRepoFromPath(path)
+	}
+	u, err := url.Parse(remote)
+	if err != nil || !sameHost(u.Hostname(), webHost) {
+		return "", "", false
+	}
+	return ownerRepoFromPath(u.Path)
+}
+
+// ownerRepoFromPath returns the owner and repository of a path such as "/octo/hello.git".
+func ownerRepoFromPath(path string) (owner, repo string, ok bool) {
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+	if len(parts) < 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", false
+	}
+	return parts[0], strings.TrimSuffix(parts[1], ".git"), true
+}
+
+func sameHost(host, webHost string) bool {
+	if webHost == "" {
+		webHost = "github.com"
+	}
+	host = strings.TrimPrefix(strings.ToLower(host), "www.")
+	return host == strings.ToLower(webHost)
+}
+
+// rootsWebHost returns the web host of the configured GitHub host, such as "github.com" or
+// "github.example.com".
+func rootsWebHost(host string) string {
+	if host == "" {
+		return "github.com"
+	}
+	if u, err := url.Parse(host); err == nil && u.Hostname() != "" {
+		return strings.ToLower(u.Hostname())
+	}
+	return strings.ToLower(host)
+}
+
+// gitOriginURL reads the URL of the "origin" remote of the clone at dir.
+func gitOriginURL(dir string) (string, error) {
+	gitDir := filepath.Join(dir, ".git")
+	info, err := os.Stat(gitDir)
+	if err != nil {
+		return "", err
+	}
+	if !info.IsDir() {
+		// Worktrees and submodules have a .git file pointing to the git directory
+		content, err := os.ReadFile(gitDir)
+		if err != nil {
+			return "", err
+		}
+		target, ok := strings.CutPrefix(strings.TrimSpace(string(content)), "gitdir:")
+		if !ok {
+			return "", errors.New("unrecognized .git file")
+		}
+		gitDir = strings.TrimSpace(target)
+		if !filepath.IsAbs(gitDir) {
+			gitDir = filepath.Join(dir, gitDir)
+		}
+		if common, err := os.ReadFile(filepath.Join(gitDir, "commondir")); err == nil {
+			gitDir = filepath.Join(gitDir, strings.TrimSpace(string(common)))
+		}
+	}
+
+	file, err := os.Open(filepath.Join(gitDir, "config"))
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	inOrigin := false
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if strings.HasPrefix(line, "[") {
+			inOrigin = line == `[remote "origin"]`
+			continue
+		}
+		if !inOrigin {
+			continue
+		}
+		if key, value, ok := strings.Cut(line, "="); ok && strings.TrimSpace(key) == "url" {
+			return strings.TrimSpace(value), nil
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return "", err
+	}
+	return "", errors.New("no origin remote")
+}
+
+// listRootContext asks the client of a session for its roots and resolves them.
+func listRootContext(ctx context.Context, session *mcp.ServerSession, webHost string) *RootContext {
+	rc := &RootContext{Roots: []*mcp.Root{}, Repositories: []GitHubRoot{}, RefreshedAt: time.Now()}
+
+	ctx, cancel := context.WithTimeout(ctx, rootsListTimeout)
+	defer cancel()
+	result, err := session.ListRoots(ctx, nil)
+	if err != nil {
+		rc.Error = err.Error()
+		return rc
+	}
+	rc.Supported = true
+	for _, root := range result.Roots {
+		if root == nil {
+			continue
+		}
+		rc.Roots = append(rc.Roots, root)
+		if gr, ok := resolveRoot(root, webHost); ok {
+			rc.Repositories = append(rc.Repositories, gr)
+		}
+	}
+
+	for i, gr := range rc.Repositories {
+		if i == 0 {
+			rc.Owner, rc.Repo, rc.Ref, rc.Path = gr.Owner, gr.Repo, gr.Ref, gr.Path
+			continue
+		}
+		if !strings.EqualFold(gr.Owner, rc.Owner) {
+			rc.Owner, rc.Repo, rc.Ref, rc.Path = "", "", "", ""
+			break
+		}
+		if !strings.EqualFold(gr.Repo, rc.Repo) {
+			rc.Repo, rc.Ref, rc.Path = "", "", ""
+		}
+		if gr.Ref != rc.Ref {
+			rc.Ref = ""
+		}
+		if gr.Path != rc.Path {
+			rc.Path = ""
+		}
+	}
+	return rc
+}
+
+// clientSupportsRoots reports whether the client of a session may answer roots/list requests.
+// Sessions of the stateless HTTP server are never initialized, and cannot be called back.
+func clientSupportsRoots(session *mcp.ServerSession) bool {
+	if session == nil {
+		return false
+	}
+	params := session.InitializeParams()
+	return params != nil && params.Capabilities != nil
+}
+
+// RootsMiddleware keeps a per-session cache of the client's roots, and of the repository they
+// resolve to. The roots are listed before the first tool call of a session, and again after the
+// client notifies the server that they changed.
+func RootsMiddleware(host string) mcp.Middleware {
+	webHost := rootsWebHost(host)
+	return func(next mcp.MethodHandler) mcp.MethodHandler {
+		return func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+			switch r := req.(type) {
+			case *mcp.RootsListChangedRequest:
+				if clientSupportsRoots(r.Session) {
+					id := r.Session.ID()
+					sessionRoots.delete(id)
+					// Notifications are handled synchronously, so the client cannot answer
+					// roots/list until this returns
+					go func(session *mcp.ServerSession) {
+						rc := listRootContext(context.WithoutCancel(ctx), session, webHost)
+						sessionRoots.update(id, func(v **RootContext) { *v = rc })
+					}(r.Session)
+				}
+			case *mcp.CallToolRequest:
+				if clientSupportsRoots(r.Session) {
+					id := r.Session.ID()
+					if _, ok := sessionRoots.load(id); !ok {
+						rc := listRootContext(ctx, r.Session, webHost)
+						sessionRoots.update(id, func(v **RootContext) { *v = rc })
+					}
+				}
+			}
+			return next(ctx, method, req)
+		}
+	}
+}
+
+// ActiveRootContext is the result of the get_root_context tool.
+type ActiveRootContext struct {
+	// Defaults are the owner, repo and ref tools use when they are omitted, and Source where
+	// they come from: "set_context", "roots", or empty when there are none.
+	Defaults SessionContext `json:"defaults"`
+	Source   string         `json:"source,omitempty"`
+	Roots    *RootContext   `json:"roots"`
+}
+
+// requestedOrRootRepositories returns the repositories a multi-repository tool covers, as
+// owner/repo without duplicates: those requested, or else the repositories the roots of the
+// session resolve to. At most limit repositories are allowed.
+func requestedOrRootRepositories(req *mcp.CallToolRequest, requested []string, limit int) ([]string, error) {
+	if len(requested) == 0 {
+		if rc, ok := sessionRoots.load(sessionID(req)); ok {
+			for _, root := range rc.Repositories {
+				requested = append(requested, root.Owner+"/"+root.Repo)
+			}
+		}
+		if len(requested) == 0 {
+			return nil, fmt.Errorf("missing required parameter: repositories, and the client has not shared repository roots with this session")
+		}
+	}
+
+	var repositories []string
+	seen := map[string]bool{}
+	for _, raw := range requested {
+		owner, repo, err := parseRolloutTarget(raw, "")
+		if err != nil {
+			return nil, fmt.Errorf("invalid repository %q: expected owner/repo", raw)
+		}
+		key := strings.ToLower(owner + "/" + repo)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		repositories = append(repositories, owner+"/"+repo)
+	}
+	if len(repositories) > limit {
+		return nil, fmt.Errorf("too many repositories: at most %d repositories are allowed per call, got %d", limit, len(repositories))
+	}
+	return repositories, nil
+}
+
+// GetRootContext creates a tool that reports the client roots of the session, the repositories
+// they resolve to, and the defaults in effect for owner and repo arguments.
+func GetRootContext(t translations.TranslationHelperFunc) inventory.ServerTool {
+	return NewTool(
+		ToolsetMetadataContext,
+		mcp.Tool{
+			Name:        "get_root_context",
+			Description: t("TOOL_GET_ROOT_CONTEXT_DESCRIPTION", "Get the roots the client shared with this session, the GitHub repositories they resolve to, and the default owner, repo, ref and directory path tools use when those arguments are omitted. Use this to check which repository is in scope before calling tools without an owner or repo."),
+			Annotations: &mcp.ToolAnnotations{
+				Title:        t("TOOL_GET_ROOT_CONTEXT_USER_TITLE", "Get root context"),
+				ReadOnlyHint: true,
+			},
+			// Use json.RawMessage to ensure "properties" is included even when empty.
+			// OpenAI strict mode requires the properties field to be present.
+			InputSchema: json.RawMessage(`{"type":"object","properties":{}}`),
+		},
+		nil,
+		func(_ context.Context, _ ToolDependencies, req *mcp.CallToolRequest, _ map[string]any) (*mcp.CallToolResult, any, error) {
+			id := sessionID(req)
+			result := ActiveRootContext{}
+			result.Defaults, result.Source = activeSessionContext(id)
+			if rc, ok := sessionRoots.load(id); ok {
+				result.Roots = rc
+			} else {
+				result.Roots = &RootContext{
+					Roots:        []*mcp.Root{},
+					Repositories: []GitHubRoot{},
+					Error:        "the client has not shared roots with this session",
+				}
+			}
+			return MarshalledTextResult(result), nil, nil
+		},
+	)
+}
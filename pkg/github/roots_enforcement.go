@@ -0,0 +1,213 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/github/github-mcp-server/pkg/utils"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// RootsEnforcement is what the server does with tool calls targeting a repository outside the
+// client's roots.
+type RootsEnforcement string
+
+const (
+	// RootsEnforcementOff lets tool calls target any repository.
+	RootsEnforcementOff RootsEnforcement = "off"
+	// RootsEnforcementWarn logs tool calls targeting a repository outside the roots, and
+	// attaches a RootsViolation to their results.
+	RootsEnforcementWarn RootsEnforcement = "warn"
+	// RootsEnforcementBlock refuses tool calls targeting a repository outside the roots.
+	RootsEnforcementBlock RootsEnforcement = "block"
+)
+
+// RootsViolationMetaKey is the _meta key under which the roots violation of a tool call is
+// attached to its result in warn mode, and to the error result refusing it in block mode.
+const RootsViolationMetaKey = "github.com/roots-violation"
+
+// RootsViolation describes a tool call targeting a repository outside the client's roots.
+type RootsViolation struct {
+	Owner   string   `json:"owner"`
+	Repo    string   `json:"repo,omitempty"`
+	Roots   []string `json:"roots"`
+	Message string   `json:"message"`
+}
+
+// ParseRootsEnforcement parses a roots enforcement mode. The empty string means off.
+func ParseRootsEnforcement(mode string) (RootsEnforcement, error) {
+	switch RootsEnforcement(strings.ToLower(strings.TrimSpace(mode))) {
+	case "", RootsEnforcementOff:
+		return RootsEnforcementOff, nil
+	case RootsEnforcementWarn:
+		return RootsEnforcementWarn, nil
+	case RootsEnforcementBlock:
+		return RootsEnforcementBlock, nil
+	default:
+		return "", fmt.Errorf("invalid roots enforcement %q: use off, warn or block", mode)
+	}
+}
+
+// RootsEnforcementMiddleware checks the owner and repo arguments of tool calls against the
+// repositories of the client's roots, as loaded by RootsMiddleware, so it must run inside it. The
+// arguments are checked as repaired by ArgumentRepairMiddleware, which must run outside it, and
+// calls whose owner or repo cannot be interpreted are treated as outside the roots. Calls naming
+// only an owner are allowed when a root belongs to that owner. Sessions without roots resolving
+// to a repository are not restricted. Defaults set with set_context are checked when they are
+// set, as set_context takes the same arguments. watch_resource calls are checked against the
+// arguments of the tool they poll, and resolve_github_url calls against the repository of their
+// URL. In warn mode, calls outside the roots are logged to logger.
+func RootsEnforcementMiddleware(mode RootsEnforcement, logger *slog.Logger) mcp.Middleware {
+	return func(next mcp.MethodHandler) mcp.MethodHandler {
+		return func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+			callReq, ok := req.(*mcp.CallToolRequest)
+			if mode == RootsEnforcementOff || method != "tools/call" || !ok || callReq.Params == nil {
+				return next(ctx, method, req)
+			}
+			rc, ok := sessionRoots.load(sessionID(callReq))
+			if !ok || rc == nil || len(rc.Repositories) == 0 {
+				return next(ctx, method, req)
+			}
+			var violation *RootsViolation
+			if checked, ok := rootsCheckedArguments(callReq.Params.Name, callReq.Params.Arguments); ok {
+				violation = checkRoots(rc.Repositories, checked)
+			} else {
+				violation = uncheckedRootsViolation(rc.Repositories)
+			}
+			if violation == nil {
+				return next(ctx, method, req)
+			}
+
+			if mode == RootsEnforcementBlock {
+				result := utils.NewToolResultError(violation.Message)
+				result.Meta = mcp.Meta{RootsViolationMetaKey: violation}
+				return result, nil
+			}
+			if logger != nil {
+				logger.Warn("tool call outside client roots",
+					"tool", callReq.Params.Name, "owner", violation.Owner, "repo", violation.Repo, "roots", violation.Roots)
+			}
+			result, err := next(ctx, method, req)
+			if toolResult, ok := result.(*mcp.CallToolResult); ok && toolResult != nil {
+				if toolResult.Meta == nil {
+					toolResult.Meta = mcp.Meta{}
+				}
+				toolResult.Meta[RootsViolationMetaKey] = violation
+			}
+			return result, err
+		}
+	}
+}
+
+// rootsCheckedArguments returns the arguments of a tool call whose owner and repo are checked
+// against the roots, or false if they cannot be told. watch_resource and resolve_github_url call
+// the handlers of other tools directly, without going through the middleware, so the arguments of
+// the polled tool and the repository of the URL are checked instead of their own arguments.
+func rootsCheckedArguments(name string, rawArgs json.RawMessage) (json.RawMessage, bool) {
+	if len(rawArgs) == 0 {
+		return rawArgs, true
+	}
+	switch name {
+	case "watch_resource":
+		var args struct {
+			Tool      string          `json:"tool"`
+			Arguments json.RawMessage `json:"arguments"`
+		}
+		if json.Unmarshal(rawArgs, &args) != nil {
+			return nil, false
+		}
+		if replacement, ok := DeprecatedToolAliases[args.Tool]; ok {
+			args.Tool = replacement
+		}
+		return rootsCheckedArguments(args.Tool, args.Arguments)
+	case "resolve_github_url":
+		var args struct {
+			URL string `json:"url"`
+		}
+		if json.Unmarshal(rawArgs, &args) != nil {
+			return nil, false
+		}
+		parsed, err := parseGitHubURL(args.URL)
+		if err != nil {
+			return nil, false
+		}
+		repoArgs, err := json.Marshal(map[string]string{"owner": parsed.Owner, "repo": parsed.Repo})
+		if err != nil {
+			return nil, false
+		}
+		return repoArgs, true
+	default:
+		return rawArgs, true
+	}
+}
+
+// checkRoots returns the violation of a tool call whose owner and repo arguments name a
+// repository none of the roots resolve to, or nil. Owners and repos that are not plain names, and
+// repos given without an owner, cannot be checked and are violations too.
+func checkRoots(roots []GitHubRoot, rawArgs json.RawMessage) *RootsViolation {
+	if len(rawArgs) == 0 {
+		return nil
+	}
+	var args struct {
+		Owner any `json:"owner"`
+		Repo  any `json:"repo"`
+	}
+	if json.Unmarshal(rawArgs, &args) != nil {
+		return uncheckedRootsViolation(roots)
+	}
+	owner, ownerOK := rootsCheckedName(args.Owner)
+	repo, repoOK := rootsCheckedName(args.Repo)
+	if !ownerOK || !repoOK || (owner == "" && repo != "") {
+		return uncheckedRootsViolation(roots)
+	}
+	if owner == "" {
+		return nil
+	}
+
+	names := rootNames(roots)
+	for _, root := range roots {
+		if strings.EqualFold(root.Owner, owner) && (repo == "" || strings.EqualFold(root.Repo, repo)) {
+			return nil
+		}
+	}
+
+	target := owner
+	if repo != "" {
+		target += "/" + repo
+	}
+	return &RootsViolation{
+		Owner:   owner,
+		Repo:    repo,
+		Roots:   names,
+		Message: fmt.Sprintf("%s is outside the roots of this session (%s). Work on the repositories of the roots, or ask the user to add %s to them.", target, strings.Join(names, ", "), target),
+	}
+}
+
+// rootsCheckedName returns an owner or repo argument, or false if it is not a plain name.
+func rootsCheckedName(value any) (string, bool) {
+	if value == nil {
+		return "", true
+	}
+	name, ok := value.(string)
+	return name, ok && !strings.Contains(name, "/")
+}
+
+// uncheckedRootsViolation is the violation of a tool call whose repository cannot be told.
+func uncheckedRootsViolation(roots []GitHubRoot) *RootsViolation {
+	names := rootNames(roots)
+	return &RootsViolation{
+		Roots:   names,
+		Message: fmt.Sprintf("The repository of this call could not be checked against the roots of this session (%s). Pass the owner and repo arguments as plain names.", strings.Join(names, ", ")),
+	}
+}
+
+func rootNames(roots []GitHubRoot) []string {
+	names := make([]string, 0, len(roots))
+	for _, root := range roots {
+		names = append(names, root.Owner+"/"+root.Repo)
+	}
+	return names
+}
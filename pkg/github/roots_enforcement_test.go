@@ -0,0 +1,214 @@
+package github
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"testing"
+
+	"github.com/github/github-mcp-server/pkg/utils"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ParseRootsEnforcement(t *testing.T) {
+	for input, expected := range map[string]RootsEnforcement{
+		"":       RootsEnforcementOff,
+		"off":    RootsEnforcementOff,
+		"Warn":   RootsEnforcementWarn,
+		" block": RootsEnforcementBlock,
+	} {
+		mode, err := ParseRootsEnforcement(input)
+		require.NoError(t, err, input)
+		assert.Equal(t, expected, mode, input)
+	}
+
+	_, err := ParseRootsEnforcement("strict")
+	assert.ErrorContains(t, err, "use off, warn or block")
+}
+
+func Test_RootsEnforcementMiddleware(t *testing.T) {
+	// Requests without a session share the stdio session's roots
+	sessionRoots.update("", func(rc **RootContext) {
+		*rc = &RootContext{Supported: true, Repositories: []GitHubRoot{{Owner: "octo-org", Repo: "app"}}}
+	})
+	t.Cleanup(func() { sessionRoots.delete("") })
+
+	var called bool
+	next := func(_ context.Context, _ string, _ mcp.Request) (mcp.Result, error) {
+		called = true
+		return utils.NewToolResultText("ok"), nil
+	}
+	callTool := func(mode RootsEnforcement, name string, args map[string]any) *mcp.CallToolResult {
+		called = false
+		raw, err := json.Marshal(args)
+		require.NoError(t, err)
+		req := &mcp.CallToolRequest{Params: &mcp.CallToolParamsRaw{Name: name, Arguments: raw}}
+		result, err := RootsEnforcementMiddleware(mode, nil)(next)(context.Background(), "tools/call", req)
+		require.NoError(t, err)
+		return result.(*mcp.CallToolResult)
+	}
+	call := func(mode RootsEnforcement, args map[string]any) *mcp.CallToolResult {
+		return callTool(mode, "get_file_contents", args)
+	}
+
+	t.Run("calls within the roots go ahead", func(t *testing.T) {
+		for _, args := range []map[string]any{
+			{"owner": "Octo-Org", "repo": "APP"},
+			{"owner": "octo-org"},
+			{"query": "is:open"},
+		} {
+			result := call(RootsEnforcementBlock, args)
+			assert.True(t, called)
+			assert.False(t, result.IsError)
+			assert.NotContains(t, result.Meta, RootsViolationMetaKey)
+		}
+	})
+
+	t.Run("block refuses calls outside the roots", func(t *testing.T) {
+		result := call(RootsEnforcementBlock, map[string]any{"owner": "octo-org", "repo": "other"})
+		assert.False(t, called)
+		require.True(t, result.IsError)
+		assert.Contains(t, getTextResult(t, result).Text, "octo-org/other is outside the roots of this session (octo-org/app)")
+		assert.Contains(t, result.Meta, RootsViolationMetaKey)
+	})
+
+	t.Run("warn annotates calls outside the roots", func(t *testing.T) {
+		result := call(RootsEnforcementWarn, map[string]any{"owner": "someone-else"})
+		assert.True(t, called)
+		assert.False(t, result.IsError)
+		require.Contains(t, result.Meta, RootsViolationMetaKey)
+		violation := result.Meta[RootsViolationMetaKey].(*RootsViolation)
+		assert.Equal(t, "someone-else", violation.Owner)
+		assert.Equal(t, []string{"octo-org/app"}, violation.Roots)
+	})
+
+	t.Run("warn logs calls outside the roots", func(t *testing.T) {
+		var logs bytes.Buffer
+		logger := slog.New(slog.NewTextHandler(&logs, nil))
+		req := &mcp.CallToolRequest{Params: &mcp.CallToolParamsRaw{Name: "get_file_contents", Arguments: json.RawMessage(`{"owner":"someone-else","repo":"x"}`)}}
+		_, err := RootsEnforcementMiddleware(RootsEnforcementWarn, logger)(next)(context.Background(), "tools/call", req)
+		require.NoError(t, err)
+		assert.Contains(t, logs.String(), "tool call outside client roots")
+		assert.Contains(t, logs.String(), "owner=someone-else")
+	})
+
+	t.Run("block refuses calls whose repository cannot be checked", func(t *testing.T) {
+		for _, args := range []map[string]any{
+			{"owner": "someone-else/x"},
+			{"owner": "octo-org", "repo": "app/../other"},
+			{"owner": []string{"someone-else"}},
+			{"owner": "octo-org", "repo": 1},
+			{"repo": "app"},
+		} {
+			result := call(RootsEnforcementBlock, args)
+			assert.False(t, called, args)
+			require.True(t, result.IsError, args)
+			assert.Contains(t, getTextResult(t, result).Text, "could not be checked against the roots of this session (octo-org/app)")
+		}
+
+		result := callTool(RootsEnforcementBlock, "watch_resource", map[string]any{"tool": 1})
+		assert.False(t, called)
+		assert.True(t, result.IsError)
+
+		result = callTool(RootsEnforcementBlock, "resolve_github_url", map[string]any{"url": "https://example.com/someone-else/x"})
+		assert.False(t, called)
+		assert.True(t, result.IsError)
+	})
+
+	t.Run("watch_resource is checked against the arguments of the polled tool", func(t *testing.T) {
+		result := callTool(RootsEnforcementBlock, "watch_resource", map[string]any{
+			"tool":      "pull_request_read",
+			"arguments": map[string]any{"owner": "someone-else", "repo": "x", "pullNumber": 1},
+			"condition": "merged == true",
+		})
+		assert.False(t, called)
+		require.True(t, result.IsError)
+		assert.Contains(t, getTextResult(t, result).Text, "someone-else/x is outside the roots of this session")
+
+		result = callTool(RootsEnforcementBlock, "watch_resource", map[string]any{
+			"tool":      "pull_request_read",
+			"arguments": map[string]any{"owner": "octo-org", "repo": "app", "pullNumber": 1},
+			"condition": "merged == true",
+		})
+		assert.True(t, called)
+		assert.False(t, result.IsError)
+	})
+
+	t.Run("resolve_github_url is checked against the repository of the URL", func(t *testing.T) {
+		result := callTool(RootsEnforcementBlock, "resolve_github_url", map[string]any{"url": "https://github.com/someone-else/x/issues/1"})
+		assert.False(t, called)
+		require.True(t, result.IsError)
+		assert.Contains(t, getTextResult(t, result).Text, "someone-else/x is outside the roots of this session")
+
+		result = callTool(RootsEnforcementBlock, "resolve_github_url", map[string]any{"url": "https://github.com/octo-org/app/blob/main/README.md#L1-L5"})
+		assert.True(t, called)
+		assert.False(t, result.IsError)
+
+		// A URL watched with watch_resource is checked too
+		result = callTool(RootsEnforcementBlock, "watch_resource", map[string]any{
+			"tool":      "resolve_github_url",
+			"arguments": map[string]any{"url": "https://github.com/someone-else/x/pull/2"},
+			"condition": "state == closed",
+		})
+		assert.False(t, called)
+		assert.True(t, result.IsError)
+	})
+
+	t.Run("sessions without repository roots are not restricted", func(t *testing.T) {
+		sessionRoots.update("", func(rc **RootContext) { *rc = &RootContext{Supported: true} })
+		result := call(RootsEnforcementBlock, map[string]any{"owner": "someone-else", "repo": "x"})
+		assert.True(t, called)
+		assert.False(t, result.IsError)
+	})
+}
+
+func Test_NewMCPServerChecksRootsOfRepairedArguments(t *testing.T) {
+	github := &countingTransport{}
+	client := mcp.NewClient(&mcp.Implementation{Name: "test-client"}, nil)
+	client.AddRoots(&mcp.Root{URI: "https://github.com/octo/ink"})
+	cs := connectTestClient(t, MCPServerConfig{RootsEnforcement: RootsEnforcementBlock}, []string{"issues"}, testServerDeps(github), client)
+
+	for _, args := range []map[string]any{
+		{"owner": "evil", "repo": "x"},
+		{"repository": "evil/x"},
+		{"repo": "evil/x"},
+		{"Owner": "evil", "repo": "x"},
+		{"own-er": "evil", "repo": "x"},
+	} {
+		args["method"] = "create"
+		args["title"] = "hello"
+		result, err := cs.CallTool(context.Background(), &mcp.CallToolParams{Name: "issue_write", Arguments: args})
+		require.NoError(t, err)
+		require.True(t, result.IsError, args)
+		assert.Contains(t, getTextResult(t, result).Text, "evil/x is outside the roots of this session (octo/ink)", args)
+	}
+	assert.Zero(t, github.requests.Load())
+
+	result, err := cs.CallTool(context.Background(), &mcp.CallToolParams{
+		Name:      "issue_write",
+		Arguments: map[string]any{"method": "create", "repository": "octo/ink", "title": "hello"},
+	})
+	require.NoError(t, err)
+	assert.False(t, result.IsError)
+	assert.Equal(t, int32(1), github.requests.Load())
+}
+
+func Test_NewMCPServerLogsRootsWarnings(t *testing.T) {
+	var logs bytes.Buffer
+	client := mcp.NewClient(&mcp.Implementation{Name: "test-client"}, nil)
+	client.AddRoots(&mcp.Root{URI: "https://github.com/octo/ink"})
+	cs := connectTestClient(t, MCPServerConfig{
+		RootsEnforcement: RootsEnforcementWarn,
+		Logger:           slog.New(slog.NewTextHandler(&logs, nil)),
+	}, []string{"issues"}, testServerDeps(&countingTransport{}), client)
+
+	_, err := cs.CallTool(context.Background(), &mcp.CallToolParams{
+		Name:      "issue_write",
+		Arguments: map[string]any{"method": "create", "owner": "evil", "repo": "x", "title": "hello"},
+	})
+	require.NoError(t, err)
+	assert.Contains(t, logs.String(), "tool call outside client roots")
+}
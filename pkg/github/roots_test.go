@@ -0,0 +1,145 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/inventory"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v82/github"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ParseGitHubRootURI(t *testing.T) {
+	tests := []struct {
+		uri     string
+		webHost string
+		owner   string
+		repo    string
+		ref     string
+		path    string
+		ok      bool
+	}{
+		{uri: "https://github.com/octo/hello", owner: "octo", repo: "hello", ok: true},
+		{uri: "https://www.github.com/octo/hello.git", owner: "octo", repo: "hello", ok: true},
+		{uri: "https://github.example.com/octo/hello", webHost: "github.example.com", owner: "octo", repo: "hello", ok: true},
+		{uri: "https://github.example.com/octo/hello"},
+		{uri: "https://github.com/octo/hello/tree/main", owner: "octo", repo: "hello", ref: "main", ok: true},
+		{uri: "https://github.com/octo/hello/tree/feature%2Flogin", owner: "octo", repo: "hello", ref: "feature/login", ok: true},
+		{uri: "https://github.com/octo/hello/tree/main/services/payments", owner: "octo", repo: "hello", ref: "main", path: "services/payments", ok: true},
+		{uri: "https://github.com/octo/hello/tree/feature%2Flogin/services/payments/", owner: "octo", repo: "hello", ref: "feature/login", path: "services/payments", ok: true},
+		{uri: "https://github.com/octo"},
+		{uri: "file:///home/octo/hello"},
+	}
+	for _, tc := range tests {
+		root, ok := ParseGitHubRootURI(tc.uri, tc.webHost)
+		assert.Equal(t, tc.ok, ok, tc.uri)
+		assert.Equal(t, tc.owner, root.Owner, tc.uri)
+		assert.Equal(t, tc.repo, root.Repo, tc.uri)
+		assert.Equal(t, tc.ref, root.Ref, tc.uri)
+		assert.Equal(t, tc.path, root.Path, tc.uri)
+	}
+}
+
+func Test_parseGitRemote(t *testing.T) {
+	for _, remote := range []string{
+		"git@github.com:octo/hello.git",
+		"ssh://git@github.com/octo/hello.git",
+		"https://github.com/octo/hello",
+	} {
+		owner, repo, ok := parseGitRemote(remote, "github.com")
+		require.True(t, ok, remote)
+		assert.Equal(t, "octo", owner, remote)
+		assert.Equal(t, "hello", repo, remote)
+	}
+	_, _, ok := parseGitRemote("git@gitlab.com:octo/hello.git", "github.com")
+	assert.False(t, ok)
+}
+
+func Test_resolveRootFromLocalClone(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, ".git"), 0o755))
+	config := "[core]\n\tbare = false\n[remote \"upstream\"]\n\turl = git@github.com:other/hello.git\n[remote \"origin\"]\n\turl = git@github.com:octo/hello.git\n"
+	require.NoError(t, os.WriteFile(filepath.Join(dir, ".git", "config"), []byte(config), 0o600))
+
+	root, ok := resolveRoot(&mcp.Root{URI: "file://" + filepath.ToSlash(dir), Name: "hello"}, "github.com")
+	require.True(t, ok)
+	assert.Equal(t, GitHubRoot{URI: "file://" + filepath.ToSlash(dir), Name: "hello", Owner: "octo", Repo: "hello"}, root)
+
+	_, ok = resolveRoot(&mcp.Root{URI: "file://" + filepath.ToSlash(t.TempDir())}, "github.com")
+	assert.False(t, ok)
+}
+
+func Test_RootsMiddleware(t *testing.T) {
+	// Verify tool definition once
+	tool := GetRootContext(translations.NullTranslationHelper).Tool
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+	assert.True(t, tool.Annotations.ReadOnlyHint)
+
+	t.Cleanup(func() { sessionRoots.delete("") })
+	ctx := context.Background()
+
+	deps := BaseDeps{Client: github.NewClient(MockHTTPClientWithHandlers(map[string]http.HandlerFunc{
+		"GET /repos/octo/hello/branches": mockResponse(t, http.StatusOK, []*github.Branch{{Name: github.Ptr("main")}}),
+	}))}
+	srv := mcp.NewServer(&mcp.Implementation{Name: "test-server"}, nil)
+	srv.AddReceivingMiddleware(InjectDepsMiddleware(deps), RootsMiddleware(""))
+	for _, tool := range []func(translations.TranslationHelperFunc) inventory.ServerTool{GetRootContext, ListBranches} {
+		serverTool := tool(translations.NullTranslationHelper)
+		serverTool.RegisterFunc(srv, deps)
+	}
+
+	client := mcp.NewClient(&mcp.Implementation{Name: "test-client"}, nil)
+	client.AddRoots(&mcp.Root{URI: "https://github.com/octo/hello/tree/feature", Name: "hello"})
+	st, ct := mcp.NewInMemoryTransports()
+	ss, err := srv.Connect(ctx, st, nil)
+	require.NoError(t, err)
+	cs, err := client.Connect(ctx, ct, nil)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = cs.Close()
+		_ = ss.Close()
+	})
+
+	getRootContext := func() ActiveRootContext {
+		result, err := cs.CallTool(ctx, &mcp.CallToolParams{Name: "get_root_context"})
+		require.NoError(t, err)
+		var active ActiveRootContext
+		require.NoError(t, json.Unmarshal([]byte(result.Content[0].(*mcp.TextContent).Text), &active))
+		return active
+	}
+
+	active := getRootContext()
+	assert.Equal(t, SessionContext{Owner: "octo", Repo: "hello", Ref: "feature"}, active.Defaults)
+	assert.Equal(t, "roots", active.Source)
+	require.True(t, active.Roots.Supported)
+	assert.Equal(t, []GitHubRoot{{URI: "https://github.com/octo/hello/tree/feature", Name: "hello", Owner: "octo", Repo: "hello", Ref: "feature"}}, active.Roots.Repositories)
+
+	// Tools default to the repository of the roots
+	result, err := cs.CallTool(ctx, &mcp.CallToolParams{Name: "list_branches", Arguments: map[string]any{}})
+	require.NoError(t, err)
+	require.False(t, result.IsError, result.Content[0].(*mcp.TextContent).Text)
+
+	// A second repository of the same owner only keeps the owner default
+	client.AddRoots(&mcp.Root{URI: "https://github.com/octo/world"})
+	require.Eventually(t, func() bool {
+		return len(getRootContext().Roots.Repositories) == 2
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.Equal(t, SessionContext{Owner: "octo"}, getRootContext().Defaults)
+
+	// A directory root also sets the path default
+	client.RemoveRoots("https://github.com/octo/world", "https://github.com/octo/hello/tree/feature")
+	client.AddRoots(&mcp.Root{URI: "https://github.com/octo/hello/tree/main/services/payments"})
+	expected := SessionContext{Owner: "octo", Repo: "hello", Ref: "main", Path: "services/payments"}
+	require.Eventually(t, func() bool {
+		return getRootContext().Defaults == expected
+	}, 5*time.Second, 10*time.Millisecond)
+}
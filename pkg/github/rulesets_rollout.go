@@ -0,0 +1,329 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/inventory"
+	"github.com/github/github-mcp-server/pkg/scopes"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/github/github-mcp-server/pkg/utils"
+	"github.com/google/go-github/v82/github"
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+const (
+	// maxRulesetRolloutTargets bounds the repositories a ruleset is rolled out to in one call.
+	maxRulesetRolloutTargets = 100
+
+	// rulesetRolloutParallelism is the number of target repositories handled at once.
+	rulesetRolloutParallelism = 5
+)
+
+// Actions of a ruleset rollout on a target repository.
+const (
+	rulesetRolloutCreate    = "create"
+	rulesetRolloutUpdate    = "update"
+	rulesetRolloutUnchanged = "unchanged"
+)
+
+// RulesetRolloutTarget is the outcome of a ruleset rollout on one repository.
+type RulesetRolloutTarget struct {
+	Repository string `json:"repository"`
+	// Action is create, update or unchanged, depending on whether the repository has a ruleset
+	// of the same name and whether it differs.
+	Action string `json:"action,omitempty"`
+	// Applied reports whether the ruleset was written, which dry runs never do.
+	Applied   bool   `json:"applied"`
+	RulesetID int64  `json:"ruleset_id,omitempty"`
+	Error     string `json:"error,omitempty"`
+}
+
+// RulesetRollout reports a ruleset rollout across repositories.
+type RulesetRollout struct {
+	Source      string                 `json:"source"`
+	Ruleset     string                 `json:"ruleset"`
+	Enforcement string                 `json:"enforcement"`
+	DryRun      bool                   `json:"dry_run"`
+	Summary     map[string]int         `json:"summary"`
+	Targets     []RulesetRolloutTarget `json:"targets"`
+}
+
+// rulesetForRepository copies the settings of a ruleset that apply to a single repository. The
+// repository conditions of organization rulesets are left out, as repository rulesets only
+// target refs.
+func rulesetForRepository(source *github.RepositoryRuleset, enforcement string) github.RepositoryRuleset {
+	ruleset := github.RepositoryRuleset{
+		Name:         source.Name,
+		Target:       source.Target,
+		Enforcement:  source.Enforcement,
+		BypassActors: source.BypassActors,
+		Rules:        source.Rules,
+	}
+	if enforcement != "" {
+		ruleset.Enforcement = github.RulesetEnforcement(enforcement)
+	}
+	if source.Conditions != nil && source.Conditions.RefName != nil {
+		ruleset.Conditions = &github.RepositoryRulesetConditions{RefName: source.Conditions.RefName}
+	}
+	return ruleset
+}
+
+// rulesetsEqual reports whether two rulesets have the same settings once copied to a repository,
+// comparing their JSON so that unset and empty fields are alike.
+func rulesetsEqual(a, b github.RepositoryRuleset) bool {
+	aJSON, errA := json.Marshal(a)
+	bJSON, errB := json.Marshal(b)
+	if errA != nil || errB != nil {
+		return false
+	}
+	var aValue, bValue any
+	if json.Unmarshal(aJSON, &aValue) != nil || json.Unmarshal(bJSON, &bValue) != nil {
+		return false
+	}
+	return reflect.DeepEqual(aValue, bValue)
+}
+
+// parseRolloutTarget splits an owner/repo target, defaulting the owner to defaultOwner.
+func parseRolloutTarget(target, defaultOwner string) (string, string, error) {
+	owner, repo, found := strings.Cut(strings.TrimSpace(target), "/")
+	if !found {
+		owner, repo = defaultOwner, owner
+	}
+	if owner == "" || repo == "" || strings.Contains(repo, "/") {
+		return "", "", fmt.Errorf("invalid target repository %q: expected owner/repo", target)
+	}
+	return owner, repo, nil
+}
+
+// findRulesetByName returns the ruleset of a repository with the given name, including its rules,
+// or nil when there is none. Rulesets inherited from the organization are not considered.
+func findRulesetByName(ctx context.Context, client *github.Client, owner, repo, name string) (*github.RepositoryRuleset, error) {
+	opts := &github.RepositoryListRulesetsOptions{ListOptions: github.ListOptions{PerPage: 100}}
+	for {
+		rulesets, resp, err := client.Repositories.GetAllRulesets(ctx, owner, repo, opts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list rulesets: %w", err)
+		}
+		_ = resp.Body.Close()
+		for _, ruleset := range rulesets {
+			if !strings.EqualFold(ruleset.Name, name) {
+				continue
+			}
+			existing, resp, err := client.Repositories.GetRuleset(ctx, owner, repo, ruleset.GetID(), false)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get ruleset %d: %w", ruleset.GetID(), err)
+			}
+			_ = resp.Body.Close()
+			return existing, nil
+		}
+		if resp.NextPage == 0 {
+			return nil, nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
+
+// rolloutRuleset plans, and unless dryRun applies, the ruleset on one repository.
+func rolloutRuleset(ctx context.Context, client *github.Client, owner, repo string, ruleset github.RepositoryRuleset, dryRun bool) RulesetRolloutTarget {
+	result := RulesetRolloutTarget{Repository: owner + "/" + repo}
+	existing, err := findRulesetByName(ctx, client, owner, repo, ruleset.Name)
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+
+	switch {
+	case existing == nil:
+		result.Action = rulesetRolloutCreate
+	case rulesetsEqual(rulesetForRepository(existing, ""), ruleset):
+		result.Action = rulesetRolloutUnchanged
+		result.RulesetID = existing.GetID()
+		return result
+	default:
+		result.Action = rulesetRolloutUpdate
+		result.RulesetID = existing.GetID()
+	}
+	if dryRun {
+		return result
+	}
+
+	var written *github.RepositoryRuleset
+	var resp *github.Response
+	if existing == nil {
+		written, resp, err = client.Repositories.CreateRuleset(ctx, owner, repo, ruleset)
+	} else {
+		written, resp, err = client.Repositories.UpdateRuleset(ctx, owner, repo, existing.GetID(), ruleset)
+	}
+	if err != nil {
+		result.Error = fmt.Sprintf("failed to %s ruleset: %s", result.Action, err)
+		return result
+	}
+	_ = resp.Body.Close()
+	result.Applied = true
+	result.RulesetID = written.GetID()
+	return result
+}
+
+// RolloutRuleset creates a tool that copies a ruleset to many repositories.
+func RolloutRuleset(t translations.TranslationHelperFunc) inventory.ServerTool {
+	return NewTool(
+		ToolsetMetadataRepos,
+		mcp.Tool{
+			Name: "rollout_ruleset",
+			Description: t("TOOL_ROLLOUT_RULESET_DESCRIPTION", fmt.Sprintf(`Copy a ruleset from a repository or organization to up to %d target repositories, to standardize branch and tag policies.
+Targets that already have a ruleset with the same name get it updated; the others get it created. By default this is a dry run reporting what would be created, updated or left unchanged on each target without changing anything: review it with the user, then call again with dry_run false to apply. Targets are handled independently, so one failing does not stop the others.`, maxRulesetRolloutTargets)),
+			Annotations: &mcp.ToolAnnotations{
+				Title:           t("TOOL_ROLLOUT_RULESET_USER_TITLE", "Roll out ruleset to repositories"),
+				ReadOnlyHint:    false,
+				DestructiveHint: jsonschema.Ptr(true),
+			},
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"source_owner": {
+						Type:        "string",
+						Description: "Owner of the source repository, or the organization whose ruleset to copy when source_repo is omitted",
+					},
+					"source_repo": {
+						Type:        "string",
+						Description: "Repository the ruleset is copied from. Omit to copy an organization ruleset.",
+					},
+					"ruleset_id": {
+						Type:        "number",
+						Description: "ID of the ruleset to copy",
+					},
+					"targets": {
+						Type:        "array",
+						Description: fmt.Sprintf("Repositories to copy the ruleset to, as owner/repo, or repository names of source_owner (max %d)", maxRulesetRolloutTargets),
+						Items:       &jsonschema.Schema{Type: "string"},
+						MinItems:    jsonschema.Ptr(1),
+						MaxItems:    jsonschema.Ptr(maxRulesetRolloutTargets),
+					},
+					"enforcement": {
+						Type:        "string",
+						Description: "Enforcement of the copied ruleset, overriding the source's. Use evaluate to roll a ruleset out without blocking anyone at first.",
+						Enum:        []any{"active", "evaluate", "disabled"},
+					},
+					"dry_run": {
+						Type:        "boolean",
+						Description: "Only report what would change on each target",
+						Default:     json.RawMessage(`true`),
+					},
+				},
+				Required: []string{"source_owner", "ruleset_id", "targets"},
+			},
+		},
+		[]scopes.Scope{scopes.Repo},
+		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+			sourceOwner, err := RequiredParam[string](args, "source_owner")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			sourceRepo, err := OptionalParam[string](args, "source_repo")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			rulesetID, err := RequiredBigInt(args, "ruleset_id")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			targets, err := OptionalStringArrayParam(args, "targets")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			if len(targets) == 0 {
+				return utils.NewToolResultError("missing required parameter: targets"), nil, nil
+			}
+			if len(targets) > maxRulesetRolloutTargets {
+				return utils.NewToolResultError(fmt.Sprintf("too many targets: a ruleset can be rolled out to %d repositories per call, got %d", maxRulesetRolloutTargets, len(targets))), nil, nil
+			}
+			enforcement, err := OptionalParam[string](args, "enforcement")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			if enforcement != "" && enforcement != "active" && enforcement != "evaluate" && enforcement != "disabled" {
+				return utils.NewToolResultError("enforcement must be active, evaluate or disabled"), nil, nil
+			}
+			dryRun, err := OptionalBoolParamWithDefault(args, "dry_run", true)
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+
+			type target struct{ owner, repo string }
+			parsed := make([]target, 0, len(targets))
+			seen := map[string]bool{}
+			for _, raw := range targets {
+				owner, repo, err := parseRolloutTarget(raw, sourceOwner)
+				if err != nil {
+					return utils.NewToolResultError(err.Error()), nil, nil
+				}
+				key := strings.ToLower(owner + "/" + repo)
+				if seen[key] {
+					continue
+				}
+				seen[key] = true
+				parsed = append(parsed, target{owner, repo})
+			}
+
+			client, err := deps.GetClient(ctx)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			var source *github.RepositoryRuleset
+			var resp *github.Response
+			sourceName := sourceOwner
+			if sourceRepo != "" {
+				sourceName = sourceOwner + "/" + sourceRepo
+				source, resp, err = client.Repositories.GetRuleset(ctx, sourceOwner, sourceRepo, rulesetID, false)
+			} else {
+				source, resp, err = client.Organizations.GetRepositoryRuleset(ctx, sourceOwner, rulesetID)
+			}
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to get source ruleset", resp, err), nil, nil
+			}
+			_ = resp.Body.Close()
+
+			ruleset := rulesetForRepository(source, enforcement)
+			rollout := RulesetRollout{
+				Source:      sourceName,
+				Ruleset:     source.Name,
+				Enforcement: string(ruleset.Enforcement),
+				DryRun:      dryRun,
+				Summary:     map[string]int{rulesetRolloutCreate: 0, rulesetRolloutUpdate: 0, rulesetRolloutUnchanged: 0, "failed": 0},
+				Targets:     make([]RulesetRolloutTarget, len(parsed)),
+			}
+
+			var wg sync.WaitGroup
+			sem := make(chan struct{}, rulesetRolloutParallelism)
+			for i, target := range parsed {
+				wg.Add(1)
+				sem <- struct{}{}
+				go func() {
+					defer func() {
+						<-sem
+						wg.Done()
+					}()
+					rollout.Targets[i] = rolloutRuleset(ctx, client, target.owner, target.repo, ruleset, dryRun)
+				}()
+			}
+			wg.Wait()
+
+			for _, target := range rollout.Targets {
+				if target.Error != "" {
+					rollout.Summary["failed"]++
+				} else {
+					rollout.Summary[target.Action]++
+				}
+			}
+			return MarshalledTextResult(rollout), nil, nil
+		},
+	)
+}
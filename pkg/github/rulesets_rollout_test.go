@@ -0,0 +1,161 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v82/github"
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_RolloutRuleset(t *testing.T) {
+	serverTool := RolloutRuleset(translations.NullTranslationHelper)
+	tool := serverTool.Tool
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "rollout_ruleset", tool.Name)
+	assert.False(t, tool.Annotations.ReadOnlyHint)
+	schema := tool.InputSchema.(*jsonschema.Schema)
+	assert.ElementsMatch(t, schema.Required, []string{"source_owner", "ruleset_id", "targets"})
+
+	rules := []map[string]any{{"type": "deletion"}, {"type": "non_fast_forward"}}
+	refName := map[string]any{"include": []string{"~DEFAULT_BRANCH"}, "exclude": []string{}}
+	orgRuleset := map[string]any{
+		"id":          42,
+		"name":        "protect-main",
+		"target":      "branch",
+		"source_type": "Organization",
+		"source":      "acme",
+		"enforcement": "active",
+		"conditions": map[string]any{
+			"ref_name":        refName,
+			"repository_name": map[string]any{"include": []string{"*"}, "exclude": []string{}},
+		},
+		"rules": rules,
+	}
+	repoRuleset := func(id int, enforcement string) map[string]any {
+		return map[string]any{
+			"id":          id,
+			"name":        "protect-main",
+			"target":      "branch",
+			"source_type": "Repository",
+			"enforcement": enforcement,
+			"conditions":  map[string]any{"ref_name": refName},
+			"rules":       rules,
+		}
+	}
+	existing := map[string]map[string]any{
+		"api":  repoRuleset(7, "active"),
+		"docs": repoRuleset(8, "evaluate"),
+	}
+
+	newHandlers := func(writes *[]string) map[string]http.HandlerFunc {
+		var mu sync.Mutex
+		record := func(r *http.Request) map[string]any {
+			mu.Lock()
+			defer mu.Unlock()
+			*writes = append(*writes, r.Method+" "+r.URL.Path)
+			var body map[string]any
+			data, _ := io.ReadAll(r.Body)
+			_ = json.Unmarshal(data, &body)
+			return body
+		}
+		repoOf := func(r *http.Request) string { return strings.Split(r.URL.Path, "/")[3] }
+		return map[string]http.HandlerFunc{
+			GetOrgsRulesetsByOrgByRulesetID: mockResponse(t, http.StatusOK, orgRuleset),
+			GetReposRulesetsByOwnerByRepo: func(w http.ResponseWriter, r *http.Request) {
+				repo := repoOf(r)
+				if repo == "gone" {
+					mockResponse(t, http.StatusNotFound, map[string]string{"message": "Not Found"})(w, r)
+					return
+				}
+				list := []map[string]any{{"id": 3, "name": "other"}}
+				if ruleset, ok := existing[repo]; ok {
+					list = append(list, map[string]any{"id": ruleset["id"], "name": ruleset["name"]})
+				}
+				mockResponse(t, http.StatusOK, list)(w, r)
+			},
+			GetReposRulesetsByOwnerByRepoByRulesetID: func(w http.ResponseWriter, r *http.Request) {
+				mockResponse(t, http.StatusOK, existing[repoOf(r)])(w, r)
+			},
+			PostReposRulesetsByOwnerByRepo: func(w http.ResponseWriter, r *http.Request) {
+				body := record(r)
+				assert.Equal(t, "protect-main", body["name"])
+				assert.Equal(t, map[string]any{"ref_name": map[string]any{"include": []any{"~DEFAULT_BRANCH"}, "exclude": []any{}}}, body["conditions"],
+					"repository conditions of the organization ruleset are left out")
+				assert.Empty(t, body["source"], "the source organization is not copied")
+				mockResponse(t, http.StatusCreated, map[string]any{"id": 100, "name": "protect-main"})(w, r)
+			},
+			PutReposRulesetsByOwnerByRepoByRulesetID: func(w http.ResponseWriter, r *http.Request) {
+				body := record(r)
+				assert.Equal(t, "active", body["enforcement"])
+				mockResponse(t, http.StatusOK, map[string]any{"id": 8, "name": "protect-main"})(w, r)
+			},
+		}
+	}
+
+	run := func(t *testing.T, args map[string]any, writes *[]string) RulesetRollout {
+		deps := BaseDeps{Client: github.NewClient(MockHTTPClientWithHandlers(newHandlers(writes)))}
+		request := createMCPRequest(args)
+		result, err := serverTool.Handler(deps)(ContextWithDeps(context.Background(), deps), &request)
+		require.NoError(t, err)
+		require.False(t, result.IsError, getTextResult(t, result).Text)
+		var rollout RulesetRollout
+		require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &rollout))
+		return rollout
+	}
+	targets := []any{"acme/web", "api", "acme/docs", "acme/gone", "acme/API"}
+
+	t.Run("dry run reports the plan without writing", func(t *testing.T) {
+		var writes []string
+		rollout := run(t, map[string]any{"source_owner": "acme", "ruleset_id": float64(42), "targets": targets}, &writes)
+
+		assert.Empty(t, writes)
+		assert.True(t, rollout.DryRun)
+		assert.Equal(t, "acme", rollout.Source)
+		assert.Equal(t, "protect-main", rollout.Ruleset)
+		require.Len(t, rollout.Targets, 4, "duplicate targets are rolled out once")
+		assert.Equal(t, RulesetRolloutTarget{Repository: "acme/web", Action: "create"}, rollout.Targets[0])
+		assert.Equal(t, RulesetRolloutTarget{Repository: "acme/api", Action: "unchanged", RulesetID: 7}, rollout.Targets[1])
+		assert.Equal(t, RulesetRolloutTarget{Repository: "acme/docs", Action: "update", RulesetID: 8}, rollout.Targets[2])
+		assert.Equal(t, "acme/gone", rollout.Targets[3].Repository)
+		assert.Contains(t, rollout.Targets[3].Error, "failed to list rulesets")
+		assert.Equal(t, map[string]int{"create": 1, "update": 1, "unchanged": 1, "failed": 1}, rollout.Summary)
+	})
+
+	t.Run("applies the ruleset", func(t *testing.T) {
+		var writes []string
+		rollout := run(t, map[string]any{"source_owner": "acme", "ruleset_id": float64(42), "targets": targets, "dry_run": false}, &writes)
+
+		assert.ElementsMatch(t, []string{"POST /repos/acme/web/rulesets", "PUT /repos/acme/docs/rulesets/8"}, writes)
+		assert.Equal(t, RulesetRolloutTarget{Repository: "acme/web", Action: "create", Applied: true, RulesetID: 100}, rollout.Targets[0])
+		assert.False(t, rollout.Targets[1].Applied)
+		assert.True(t, rollout.Targets[2].Applied)
+	})
+
+	t.Run("enforcement override", func(t *testing.T) {
+		var writes []string
+		rollout := run(t, map[string]any{"source_owner": "acme", "ruleset_id": float64(42), "targets": []any{"docs"}, "enforcement": "evaluate"}, &writes)
+
+		assert.Equal(t, "evaluate", rollout.Enforcement)
+		assert.Equal(t, "unchanged", rollout.Targets[0].Action)
+	})
+
+	t.Run("invalid target", func(t *testing.T) {
+		deps := BaseDeps{Client: github.NewClient(MockHTTPClientWithHandlers(nil))}
+		request := createMCPRequest(map[string]any{"source_owner": "acme", "ruleset_id": float64(42), "targets": []any{"a/b/c"}})
+		result, err := serverTool.Handler(deps)(ContextWithDeps(context.Background(), deps), &request)
+		require.NoError(t, err)
+		require.True(t, result.IsError)
+		assert.Contains(t, getErrorResult(t, result).Text, "invalid target repository")
+	})
+}
@@ -0,0 +1,323 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/inventory"
+	"github.com/github/github-mcp-server/pkg/scopes"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/github/github-mcp-server/pkg/utils"
+	"github.com/google/go-github/v82/github"
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/shurcooL/githubv4"
+)
+
+// savedReplyPlaceholder matches {{name}} placeholders in saved reply bodies.
+var savedReplyPlaceholder = regexp.MustCompile(`\{\{\s*([A-Za-z0-9_.-]+)\s*\}\}`)
+
+// SavedReply is a saved reply of the authenticated user.
+type SavedReply struct {
+	ID           string   `json:"id"`
+	Title        string   `json:"title"`
+	Body         string   `json:"body"`
+	Placeholders []string `json:"placeholders,omitempty"`
+}
+
+type savedReplyNode struct {
+	ID    githubv4.ID
+	Title githubv4.String
+	Body  githubv4.String
+}
+
+func convertToSavedReply(node savedReplyNode) SavedReply {
+	return SavedReply{
+		ID:           fmt.Sprintf("%v", node.ID),
+		Title:        string(node.Title),
+		Body:         string(node.Body),
+		Placeholders: savedReplyPlaceholders(string(node.Body)),
+	}
+}
+
+// savedReplyPlaceholders returns the distinct placeholder names used in body, sorted.
+func savedReplyPlaceholders(body string) []string {
+	seen := map[string]bool{}
+	var names []string
+	for _, m := range savedReplyPlaceholder.FindAllStringSubmatch(body, -1) {
+		if !seen[m[1]] {
+			seen[m[1]] = true
+			names = append(names, m[1])
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// applySavedReplySubstitutions replaces {{name}} placeholders in body with values from
+// substitutions. It returns the rendered body and the sorted names of any placeholders
+// that had no substitution.
+func applySavedReplySubstitutions(body string, substitutions map[string]string) (string, []string) {
+	missing := map[string]bool{}
+	rendered := savedReplyPlaceholder.ReplaceAllStringFunc(body, func(match string) string {
+		name := savedReplyPlaceholder.FindStringSubmatch(match)[1]
+		if value, ok := substitutions[name]; ok {
+			return value
+		}
+		missing[name] = true
+		return match
+	})
+
+	names := make([]string, 0, len(missing))
+	for name := range missing {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return rendered, names
+}
+
+// savedRepliesCursorScope binds the cursors of list_saved_replies, which pages through the saved
+// replies of the authenticated user.
+const savedRepliesCursorScope = "list_saved_replies"
+
+// ListSavedReplies creates a tool to list the authenticated user's saved replies.
+func ListSavedReplies(t translations.TranslationHelperFunc) inventory.ServerTool {
+	return NewTool(
+		ToolsetMetadataIssues,
+		mcp.Tool{
+			Name:        "list_saved_replies",
+			Description: t("TOOL_LIST_SAVED_REPLIES_DESCRIPTION", "List the authenticated user's saved replies. Saved replies are reusable comment templates; any {{name}} placeholders in a reply body are reported so they can be filled in with 'add_issue_comment_from_saved_reply'."),
+			Annotations: &mcp.ToolAnnotations{
+				Title:        t("TOOL_LIST_SAVED_REPLIES_USER_TITLE", "List saved replies"),
+				ReadOnlyHint: true,
+			},
+			InputSchema: WithCursorPagination(&jsonschema.Schema{
+				Type:       "object",
+				Properties: map[string]*jsonschema.Schema{},
+			}),
+		},
+		nil,
+		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+			pagination, err := OptionalCursorPaginationParams(args)
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			paginationParams, err := pagination.ToSignedGraphQLParams(ctx, savedRepliesCursorScope)
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+
+			client, err := deps.GetGQLClient(ctx)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to get GitHub GQL client: %w", err)
+			}
+
+			var q struct {
+				Viewer struct {
+					SavedReplies struct {
+						Nodes      []savedReplyNode
+						PageInfo   PageInfoFragment
+						TotalCount int
+					} `graphql:"savedReplies(first: $first, after: $after)"`
+				}
+			}
+			vars := map[string]any{
+				"first": githubv4.Int(*paginationParams.First),
+			}
+			if paginationParams.After != nil {
+				vars["after"] = githubv4.String(*paginationParams.After)
+			} else {
+				vars["after"] = (*githubv4.String)(nil)
+			}
+			if err := client.Query(ctx, &q, vars); err != nil {
+				return ghErrors.NewGitHubGraphQLErrorResponse(ctx, "failed to list saved replies", err), nil, nil
+			}
+
+			replies := make([]SavedReply, 0, len(q.Viewer.SavedReplies.Nodes))
+			for _, node := range q.Viewer.SavedReplies.Nodes {
+				replies = append(replies, convertToSavedReply(node))
+			}
+
+			response := map[string]any{
+				"saved_replies": replies,
+				"pageInfo": map[string]any{
+					"hasNextPage":     q.Viewer.SavedReplies.PageInfo.HasNextPage,
+					"hasPreviousPage": q.Viewer.SavedReplies.PageInfo.HasPreviousPage,
+					"startCursor":     signCursor(ctx, savedRepliesCursorScope, string(q.Viewer.SavedReplies.PageInfo.StartCursor)),
+					"endCursor":       signCursor(ctx, savedRepliesCursorScope, string(q.Viewer.SavedReplies.PageInfo.EndCursor)),
+				},
+				"totalCount": q.Viewer.SavedReplies.TotalCount,
+			}
+
+			out, err := json.Marshal(response)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to marshal saved replies: %w", err)
+			}
+
+			return utils.NewToolResultText(string(out)), nil, nil
+		},
+	)
+}
+
+// AddIssueCommentFromSavedReply creates a tool to comment on an issue or pull request using one
+// of the authenticated user's saved replies, with {{name}} placeholders substituted.
+func AddIssueCommentFromSavedReply(t translations.TranslationHelperFunc) inventory.ServerTool {
+	return NewTool(
+		ToolsetMetadataIssues,
+		mcp.Tool{
+			Name:        "add_issue_comment_from_saved_reply",
+			Description: t("TOOL_ADD_ISSUE_COMMENT_FROM_SAVED_REPLY_DESCRIPTION", "Add a comment to an issue or pull request using one of the authenticated user's saved replies. Placeholders of the form {{name}} in the saved reply are replaced with values from 'substitutions'; the comment is not posted if any placeholder is left unfilled."),
+			Annotations: &mcp.ToolAnnotations{
+				Title:        t("TOOL_ADD_ISSUE_COMMENT_FROM_SAVED_REPLY_USER_TITLE", "Add comment from saved reply"),
+				ReadOnlyHint: false,
+			},
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"owner": {
+						Type:        "string",
+						Description: "Repository owner",
+					},
+					"repo": {
+						Type:        "string",
+						Description: "Repository name",
+					},
+					"issue_number": {
+						Type:        "number",
+						Description: "Issue or pull request number to comment on",
+					},
+					"saved_reply": {
+						Type:        "string",
+						Description: "Title or ID of the saved reply to use",
+					},
+					"substitutions": {
+						Type:        "object",
+						Description: "Values for {{name}} placeholders in the saved reply, keyed by placeholder name. Example: {\"username\": \"octocat\"}",
+						AdditionalProperties: &jsonschema.Schema{
+							Type: "string",
+						},
+					},
+				},
+				Required: []string{"owner", "repo", "issue_number", "saved_reply"},
+			},
+		},
+		[]scopes.Scope{scopes.Repo},
+		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+			owner, err := RequiredParam[string](args, "owner")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			repo, err := RequiredParam[string](args, "repo")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			issueNumber, err := RequiredInt(args, "issue_number")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			savedReply, err := RequiredParam[string](args, "saved_reply")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+
+			substitutions, err := substitutionsParam(args)
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+
+			gqlClient, err := deps.GetGQLClient(ctx)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to get GitHub GQL client: %w", err)
+			}
+
+			var q struct {
+				Viewer struct {
+					SavedReplies struct {
+						Nodes []savedReplyNode
+					} `graphql:"savedReplies(first: 100)"`
+				}
+			}
+			if err := gqlClient.Query(ctx, &q, nil); err != nil {
+				return ghErrors.NewGitHubGraphQLErrorResponse(ctx, "failed to list saved replies", err), nil, nil
+			}
+
+			var reply *savedReplyNode
+			for i, node := range q.Viewer.SavedReplies.Nodes {
+				if fmt.Sprintf("%v", node.ID) == savedReply || strings.EqualFold(string(node.Title), savedReply) {
+					reply = &q.Viewer.SavedReplies.Nodes[i]
+					break
+				}
+			}
+			if reply == nil {
+				return utils.NewToolResultError(fmt.Sprintf("saved reply '%s' not found", savedReply)), nil, nil
+			}
+
+			body, missing := applySavedReplySubstitutions(string(reply.Body), substitutions)
+			if len(missing) > 0 {
+				return utils.NewToolResultError(fmt.Sprintf("missing substitutions for placeholders: %s", strings.Join(missing, ", "))), nil, nil
+			}
+
+			client, err := deps.GetClient(ctx)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			result, err := createIssueCommentResult(ctx, client, owner, repo, issueNumber, body)
+			return result, nil, err
+		},
+	)
+}
+
+// substitutionsParam reads the values of {{name}} placeholders from the substitutions argument.
+func substitutionsParam(args map[string]any) (map[string]string, error) {
+	substitutions := map[string]string{}
+	raw, ok := args["substitutions"]
+	if !ok || raw == nil {
+		return substitutions, nil
+	}
+	rawMap, ok := raw.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("substitutions must be an object")
+	}
+	for k, v := range rawMap {
+		s, ok := v.(string)
+		if !ok {
+			return nil, fmt.Errorf("substitution '%s' must be a string", k)
+		}
+		substitutions[k] = s
+	}
+	return substitutions, nil
+}
+
+// createIssueCommentResult posts a comment on an issue or pull request and returns the result
+// naming the new comment.
+func createIssueCommentResult(ctx context.Context, client *github.Client, owner, repo string, issueNumber int, body string) (*mcp.CallToolResult, error) {
+	createdComment, resp, err := client.Issues.CreateComment(ctx, owner, repo, issueNumber, &github.IssueComment{
+		Body: github.Ptr(body),
+	})
+	if err != nil {
+		return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to create comment", resp, err), nil
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusCreated {
+		respBody, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read response body: %w", err)
+		}
+		return ghErrors.NewGitHubAPIStatusErrorResponse(ctx, "failed to create comment", resp, respBody), nil
+	}
+
+	minimalResponse := MinimalResponse{
+		ID:  fmt.Sprintf("%d", createdComment.GetID()),
+		URL: createdComment.GetHTMLURL(),
+	}
+
+	return MarshalledTextResult(minimalResponse), nil
+}
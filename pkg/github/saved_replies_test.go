@@ -0,0 +1,220 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/githubv4mock"
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v82/github"
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/shurcooL/githubv4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ListSavedReplies(t *testing.T) {
+	// Verify tool definition once
+	serverTool := ListSavedReplies(translations.NullTranslationHelper)
+	tool := serverTool.Tool
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "list_saved_replies", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.True(t, tool.Annotations.ReadOnlyHint)
+	schema := tool.InputSchema.(*jsonschema.Schema)
+	assert.Contains(t, schema.Properties, "perPage")
+	assert.Contains(t, schema.Properties, "after")
+
+	var query struct {
+		Viewer struct {
+			SavedReplies struct {
+				Nodes      []savedReplyNode
+				PageInfo   PageInfoFragment
+				TotalCount int
+			} `graphql:"savedReplies(first: $first, after: $after)"`
+		}
+	}
+
+	mockedClient := githubv4mock.NewMockedHTTPClient(
+		githubv4mock.NewQueryMatcher(
+			query,
+			map[string]any{
+				"first": githubv4.Int(30),
+				"after": (*githubv4.String)(nil),
+			},
+			githubv4mock.DataResponse(map[string]any{
+				"viewer": map[string]any{
+					"savedReplies": map[string]any{
+						"nodes": []map[string]any{
+							{"id": "SR_1", "title": "Duplicate", "body": "Thanks @{{user}}, this duplicates #{{issue}}."},
+							{"id": "SR_2", "title": "Thanks", "body": "Thanks for the report!"},
+						},
+						"pageInfo": map[string]any{
+							"hasNextPage":     false,
+							"hasPreviousPage": false,
+							"startCursor":     "",
+							"endCursor":       "",
+						},
+						"totalCount": 2,
+					},
+				},
+			}),
+		),
+	)
+
+	deps := BaseDeps{
+		GQLClient: githubv4.NewClient(mockedClient),
+	}
+	handler := serverTool.Handler(deps)
+	request := createMCPRequest(map[string]any{})
+
+	result, err := handler(ContextWithDeps(context.Background(), deps), &request)
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	textContent := getTextResult(t, result)
+	var response struct {
+		SavedReplies []SavedReply `json:"saved_replies"`
+		TotalCount   int          `json:"totalCount"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(textContent.Text), &response))
+	require.Len(t, response.SavedReplies, 2)
+	assert.Equal(t, 2, response.TotalCount)
+	assert.Equal(t, "Duplicate", response.SavedReplies[0].Title)
+	assert.Equal(t, []string{"issue", "user"}, response.SavedReplies[0].Placeholders)
+	assert.Empty(t, response.SavedReplies[1].Placeholders)
+}
+
+func Test_AddIssueCommentFromSavedReply(t *testing.T) {
+	// Verify tool definition once
+	serverTool := AddIssueCommentFromSavedReply(translations.NullTranslationHelper)
+	tool := serverTool.Tool
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "add_issue_comment_from_saved_reply", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.False(t, tool.Annotations.ReadOnlyHint)
+	schema := tool.InputSchema.(*jsonschema.Schema)
+	assert.Contains(t, schema.Properties, "owner")
+	assert.Contains(t, schema.Properties, "repo")
+	assert.Contains(t, schema.Properties, "issue_number")
+	assert.Contains(t, schema.Properties, "saved_reply")
+	assert.Contains(t, schema.Properties, "substitutions")
+	assert.ElementsMatch(t, schema.Required, []string{"owner", "repo", "issue_number", "saved_reply"})
+
+	var query struct {
+		Viewer struct {
+			SavedReplies struct {
+				Nodes []savedReplyNode
+			} `graphql:"savedReplies(first: 100)"`
+		}
+	}
+	savedRepliesMatcher := githubv4mock.NewQueryMatcher(
+		query,
+		nil,
+		githubv4mock.DataResponse(map[string]any{
+			"viewer": map[string]any{
+				"savedReplies": map[string]any{
+					"nodes": []map[string]any{
+						{"id": "SR_1", "title": "Duplicate", "body": "Thanks @{{user}}, this duplicates #{{ issue }}."},
+					},
+				},
+			},
+		}),
+	)
+
+	mockComment := &github.IssueComment{
+		ID:      github.Ptr(int64(123)),
+		HTMLURL: github.Ptr("https://github.com/owner/repo/issues/42#issuecomment-123"),
+	}
+
+	tests := []struct {
+		name           string
+		restHandlers   map[string]http.HandlerFunc
+		requestArgs    map[string]any
+		expectToolErr  bool
+		expectedErrMsg string
+	}{
+		{
+			name: "posts rendered saved reply",
+			restHandlers: map[string]http.HandlerFunc{
+				PostReposIssuesCommentsByOwnerByRepoByIssueNumber: expectRequestBody(t, map[string]any{
+					"body": "Thanks @octocat, this duplicates #7.",
+				}).andThen(mockResponse(t, http.StatusCreated, mockComment)),
+			},
+			requestArgs: map[string]any{
+				"owner":        "owner",
+				"repo":         "repo",
+				"issue_number": float64(42),
+				"saved_reply":  "duplicate",
+				"substitutions": map[string]any{
+					"user":  "octocat",
+					"issue": "7",
+				},
+			},
+		},
+		{
+			name:         "missing substitution",
+			restHandlers: map[string]http.HandlerFunc{},
+			requestArgs: map[string]any{
+				"owner":         "owner",
+				"repo":          "repo",
+				"issue_number":  float64(42),
+				"saved_reply":   "SR_1",
+				"substitutions": map[string]any{"user": "octocat"},
+			},
+			expectToolErr:  true,
+			expectedErrMsg: "missing substitutions for placeholders: issue",
+		},
+		{
+			name:         "saved reply not found",
+			restHandlers: map[string]http.HandlerFunc{},
+			requestArgs: map[string]any{
+				"owner":        "owner",
+				"repo":         "repo",
+				"issue_number": float64(42),
+				"saved_reply":  "Welcome",
+			},
+			expectToolErr:  true,
+			expectedErrMsg: "saved reply 'Welcome' not found",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			deps := BaseDeps{
+				Client:    github.NewClient(MockHTTPClientWithHandlers(tc.restHandlers)),
+				GQLClient: githubv4.NewClient(githubv4mock.NewMockedHTTPClient(savedRepliesMatcher)),
+			}
+			handler := serverTool.Handler(deps)
+			request := createMCPRequest(tc.requestArgs)
+
+			result, err := handler(ContextWithDeps(context.Background(), deps), &request)
+			require.NoError(t, err)
+
+			if tc.expectToolErr {
+				require.True(t, result.IsError)
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			require.False(t, result.IsError)
+			textContent := getTextResult(t, result)
+			var response MinimalResponse
+			require.NoError(t, json.Unmarshal([]byte(textContent.Text), &response))
+			assert.Equal(t, "123", response.ID)
+			assert.Equal(t, mockComment.GetHTMLURL(), response.URL)
+		})
+	}
+}
+
+func Test_ApplySavedReplySubstitutions(t *testing.T) {
+	rendered, missing := applySavedReplySubstitutions("Hi {{name}}, see {{link}} and {{ name }}.", map[string]string{"name": "Mona"})
+	assert.Equal(t, "Hi Mona, see {{link}} and Mona.", rendered)
+	assert.Equal(t, []string{"link"}, missing)
+}
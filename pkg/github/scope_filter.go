@@ -2,6 +2,8 @@ package github
 
 import (
 	"context"
+	"fmt"
+	"strings"
 
 	"github.com/github/github-mcp-server/pkg/inventory"
 	"github.com/github/github-mcp-server/pkg/scopes"
@@ -55,10 +57,48 @@ func onlyRequiresRepoScopes(acceptedScopes []string) bool {
 //	inventory := github.NewInventory(t).WithFilter(filter).Build()
 func CreateToolScopeFilter(tokenScopes []string) inventory.ToolFilter {
 	return func(_ context.Context, tool *inventory.ServerTool) (bool, error) {
+		return ExplainToolScopeFilter(tokenScopes, tool).Visible, nil
+	}
+}
+
+// ToolScopeDecision explains whether CreateToolScopeFilter shows a tool to a token.
+type ToolScopeDecision struct {
+	Tool           string   `json:"tool"`
+	Toolset        string   `json:"toolset"`
+	RequiredScopes []string `json:"required_scopes"`
+	AcceptedScopes []string `json:"accepted_scopes"`
+	Visible        bool     `json:"visible"`
+	Reason         string   `json:"reason"`
+}
+
+// ExplainToolScopeFilter reports the decision CreateToolScopeFilter makes for a tool, and why.
+func ExplainToolScopeFilter(tokenScopes []string, tool *inventory.ServerTool) ToolScopeDecision {
+	decision := ToolScopeDecision{
+		Tool:           tool.Tool.Name,
+		Toolset:        string(tool.Toolset.ID),
+		RequiredScopes: tool.RequiredScopes,
+		AcceptedScopes: tool.AcceptedScopes,
+	}
+	if decision.RequiredScopes == nil {
+		decision.RequiredScopes = []string{}
+	}
+	if decision.AcceptedScopes == nil {
+		decision.AcceptedScopes = []string{}
+	}
+
+	switch {
+	case len(tool.AcceptedScopes) == 0:
+		decision.Visible = true
+		decision.Reason = "the tool requires no scopes"
+	case tool.Tool.Annotations != nil && tool.Tool.Annotations.ReadOnlyHint && onlyRequiresRepoScopes(tool.AcceptedScopes):
 		// Read-only tools requiring only repo/public_repo work on public repos without any scope
-		if tool.Tool.Annotations != nil && tool.Tool.Annotations.ReadOnlyHint && onlyRequiresRepoScopes(tool.AcceptedScopes) {
-			return true, nil
-		}
-		return scopes.HasRequiredScopes(tokenScopes, tool.AcceptedScopes), nil
+		decision.Visible = true
+		decision.Reason = "the tool is read-only and works on public repositories without any scope"
+	case scopes.HasRequiredScopes(tokenScopes, tool.AcceptedScopes):
+		decision.Visible = true
+		decision.Reason = "the token has a scope granting one of the accepted scopes"
+	default:
+		decision.Reason = fmt.Sprintf("the token has none of the accepted scopes: %s", strings.Join(tool.AcceptedScopes, ", "))
 	}
+	return decision
 }
@@ -6,6 +6,9 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"slices"
+	"strings"
+	"time"
 
 	ghErrors "github.com/github/github-mcp-server/pkg/errors"
 	"github.com/github/github-mcp-server/pkg/inventory"
@@ -45,6 +48,8 @@ func SearchRepositories(t translations.TranslationHelperFunc) inventory.ServerTo
 		Required: []string{"query"},
 	}
 	WithPagination(schema)
+	WithSearchRanking(schema)
+	WithRepoFilter(schema)
 
 	return NewTool(
 		ToolsetMetadataRepos,
@@ -79,6 +84,15 @@ func SearchRepositories(t translations.TranslationHelperFunc) inventory.ServerTo
 			if err != nil {
 				return utils.NewToolResultError(err.Error()), nil, nil
 			}
+			rank, err := searchRankParam(args)
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			filter, err := repoFilterParams(ctx, args)
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			query = repoFilterQuery(query, filter, true)
 			opts := &github.SearchOptions{
 				Sort:  sort,
 				Order: order,
@@ -110,38 +124,16 @@ func SearchRepositories(t translations.TranslationHelperFunc) inventory.ServerTo
 				return ghErrors.NewGitHubAPIStatusErrorResponse(ctx, "failed to search repositories", resp, body), nil, nil
 			}
 
+			if rank == rankWeighted {
+				rankByWeight(result.Repositories, time.Now(), repositoryRankSignals)
+			}
+
 			// Return either minimal or full response based on parameter
 			var r []byte
 			if minimalOutput {
 				minimalRepos := make([]MinimalRepository, 0, len(result.Repositories))
 				for _, repo := range result.Repositories {
-					minimalRepo := MinimalRepository{
-						ID:            repo.GetID(),
-						Name:          repo.GetName(),
-						FullName:      repo.GetFullName(),
-						Description:   repo.GetDescription(),
-						HTMLURL:       repo.GetHTMLURL(),
-						Language:      repo.GetLanguage(),
-						Stars:         repo.GetStargazersCount(),
-						Forks:         repo.GetForksCount(),
-						OpenIssues:    repo.GetOpenIssuesCount(),
-						Private:       repo.GetPrivate(),
-						Fork:          repo.GetFork(),
-						Archived:      repo.GetArchived(),
-						DefaultBranch: repo.GetDefaultBranch(),
-					}
-
-					if repo.UpdatedAt != nil {
-						minimalRepo.UpdatedAt = repo.UpdatedAt.Format("2006-01-02T15:04:05Z")
-					}
-					if repo.CreatedAt != nil {
-						minimalRepo.CreatedAt = repo.CreatedAt.Format("2006-01-02T15:04:05Z")
-					}
-					if repo.Topics != nil {
-						minimalRepo.Topics = repo.Topics
-					}
-
-					minimalRepos = append(minimalRepos, minimalRepo)
+					minimalRepos = append(minimalRepos, convertToMinimalRepository(repo))
 				}
 
 				minimalResult := &MinimalSearchRepositoriesResult{
@@ -166,6 +158,183 @@ func SearchRepositories(t translations.TranslationHelperFunc) inventory.ServerTo
 	)
 }
 
+// SearchOrgRepositories creates a tool to find an organization's repositories by topic, language
+// and custom property values.
+func SearchOrgRepositories(t translations.TranslationHelperFunc) inventory.ServerTool {
+	schema := &jsonschema.Schema{
+		Type: "object",
+		Properties: map[string]*jsonschema.Schema{
+			"org": {
+				Type:        "string",
+				Description: "Organization login",
+			},
+			"topics": {
+				Type:        "array",
+				Description: "Only include repositories tagged with all of these topics",
+				Items: &jsonschema.Schema{
+					Type: "string",
+				},
+			},
+			"language": {
+				Type:        "string",
+				Description: "Only include repositories whose primary language is this language (e.g. 'go')",
+			},
+			"custom_properties": {
+				Type:        "object",
+				Description: "Only include repositories whose custom properties have these values, e.g. {\"tier\": \"tier-1\"}",
+				AdditionalProperties: &jsonschema.Schema{
+					Type: "string",
+				},
+			},
+			"sort": {
+				Type:        "string",
+				Description: "Sort repositories by field. 'updated' sorts by most recent activity.",
+				Enum:        []any{"updated", "stars", "forks"},
+				Default:     json.RawMessage(`"updated"`),
+			},
+			"order": {
+				Type:        "string",
+				Description: "Sort order",
+				Enum:        []any{"asc", "desc"},
+			},
+		},
+		Required: []string{"org"},
+	}
+	WithPagination(schema)
+	WithRepoFilter(schema)
+
+	return NewTool(
+		ToolsetMetadataRepos,
+		mcp.Tool{
+			Name:        "search_org_repositories",
+			Description: t("TOOL_SEARCH_ORG_REPOSITORIES_DESCRIPTION", "Find an organization's repositories by any combination of topics, primary language and custom property values, sorted by recent activity by default. Use this to build service catalogs, e.g. 'all Go services tagged tier-1'."),
+			Annotations: &mcp.ToolAnnotations{
+				Title:        t("TOOL_SEARCH_ORG_REPOSITORIES_USER_TITLE", "Search organization repositories"),
+				ReadOnlyHint: true,
+			},
+			InputSchema: schema,
+		},
+		[]scopes.Scope{scopes.Repo},
+		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+			org, err := RequiredParam[string](args, "org")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			topics, err := OptionalStringArrayParam(args, "topics")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			language, err := OptionalParam[string](args, "language")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			properties := map[string]string{}
+			if raw, ok := args["custom_properties"]; ok && raw != nil {
+				rawMap, ok := raw.(map[string]any)
+				if !ok {
+					return utils.NewToolResultError("custom_properties must be an object"), nil, nil
+				}
+				for name, value := range rawMap {
+					s, ok := value.(string)
+					if !ok {
+						return utils.NewToolResultError(fmt.Sprintf("custom property %s must be a string", name)), nil, nil
+					}
+					properties[name] = s
+				}
+			}
+			filter, err := repoFilterParams(ctx, args)
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			sort, err := OptionalParam[string](args, "sort")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			if sort == "" {
+				sort = "updated"
+			}
+			order, err := OptionalParam[string](args, "order")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			pagination, err := OptionalPaginationParams(args)
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+
+			query := buildOrgRepositoriesQuery(org, topics, language, properties, filter)
+			opts := &github.SearchOptions{
+				Sort:  sort,
+				Order: order,
+				ListOptions: github.ListOptions{
+					Page:    pagination.Page,
+					PerPage: pagination.PerPage,
+				},
+			}
+
+			client, err := deps.GetClient(ctx)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+			result, resp, err := client.Search.Repositories(ctx, query, opts)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to search repositories with query '%s'", query),
+					resp,
+					err,
+				), nil, nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			minimalRepos := make([]MinimalRepository, 0, len(result.Repositories))
+			for _, repo := range result.Repositories {
+				minimalRepos = append(minimalRepos, convertToMinimalRepository(repo))
+			}
+
+			return MarshalledTextResult(&MinimalSearchRepositoriesResult{
+				TotalCount:        result.GetTotal(),
+				IncompleteResults: result.GetIncompleteResults(),
+				Items:             minimalRepos,
+			}), nil, nil
+		},
+	)
+}
+
+// buildOrgRepositoriesQuery builds a repository search query from structured filters. Custom
+// properties are matched with the props.NAME:VALUE qualifier.
+func buildOrgRepositoriesQuery(org string, topics []string, language string, properties map[string]string, filter RepoFilter) string {
+	terms := []string{"org:" + quoteSearchValue(org)}
+	for _, topic := range topics {
+		terms = append(terms, "topic:"+quoteSearchValue(topic))
+	}
+	if language != "" {
+		terms = append(terms, "language:"+quoteSearchValue(language))
+	}
+	names := make([]string, 0, len(properties))
+	for name := range properties {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	for _, name := range names {
+		terms = append(terms, fmt.Sprintf("props.%s:%s", name, quoteSearchValue(properties[name])))
+	}
+	if filter.ExcludeArchived {
+		terms = append(terms, "archived:false")
+	}
+	if !filter.ExcludeForks {
+		terms = append(terms, "fork:true")
+	}
+	return strings.Join(terms, " ")
+}
+
+// quoteSearchValue quotes a search qualifier value if it contains whitespace.
+func quoteSearchValue(value string) string {
+	if strings.ContainsAny(value, " \t") {
+		return `"` + strings.ReplaceAll(value, `"`, "") + `"`
+	}
+	return value
+}
+
 // SearchCode creates a tool to search for code across GitHub repositories.
 func SearchCode(t translations.TranslationHelperFunc) inventory.ServerTool {
 	schema := &jsonschema.Schema{
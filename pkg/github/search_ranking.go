@@ -0,0 +1,122 @@
+package github
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"slices"
+	"time"
+
+	"github.com/google/go-github/v82/github"
+	"github.com/google/jsonschema-go/jsonschema"
+)
+
+const (
+	// rankBestMatch keeps the order of GitHub search, and rankWeighted re-ranks it by recency
+	// and activity.
+	rankBestMatch = "best_match"
+	rankWeighted  = "weighted"
+
+	// The weights of the position in GitHub search, the recency and the activity of a result in
+	// its weighted rank.
+	rankPositionWeight = 0.4
+	rankRecencyWeight  = 0.35
+	rankActivityWeight = 0.25
+	// rankRecencyHalfLife is the age at which the recency of a result counts half.
+	rankRecencyHalfLife = 30 * 24 * time.Hour
+)
+
+// WithSearchRanking adds the parameter re-ranking search results to a search tool.
+func WithSearchRanking(schema *jsonschema.Schema) *jsonschema.Schema {
+	schema.Properties["rank"] = &jsonschema.Schema{
+		Type:        "string",
+		Description: "'best_match' keeps GitHub's order. 'weighted' re-ranks the returned page, favoring recently updated results and active repositories over GitHub's relevance order alone",
+		Enum:        []any{rankBestMatch, rankWeighted},
+		Default:     json.RawMessage(`"best_match"`),
+	}
+	return schema
+}
+
+// searchRankParam reads the parameter added by WithSearchRanking.
+func searchRankParam(args map[string]any) (string, error) {
+	rank, err := OptionalParam[string](args, "rank")
+	if err != nil {
+		return "", err
+	}
+	switch rank {
+	case "":
+		return rankBestMatch, nil
+	case rankBestMatch, rankWeighted:
+		return rank, nil
+	default:
+		return "", fmt.Errorf("invalid rank %q: must be %s or %s", rank, rankBestMatch, rankWeighted)
+	}
+}
+
+// rankSignals are the signals a result is weighted by, besides its position in GitHub search.
+type rankSignals struct {
+	updated  time.Time
+	activity int
+}
+
+// rankByWeight stably re-ranks items by a weighted score of their position, how recently they
+// were updated and their activity relative to the other items.
+func rankByWeight[T any](items []T, now time.Time, signals func(T) rankSignals) {
+	if len(items) < 2 {
+		return
+	}
+	type scored struct {
+		item  T
+		score float64
+	}
+	all := make([]scored, len(items))
+	maxActivity := 0
+	for _, item := range items {
+		maxActivity = max(maxActivity, signals(item).activity)
+	}
+	for i, item := range items {
+		s := signals(item)
+		score := rankPositionWeight * (1 - float64(i)/float64(len(items)))
+		if !s.updated.IsZero() {
+			age := max(now.Sub(s.updated), 0)
+			score += rankRecencyWeight * math.Pow(0.5, float64(age)/float64(rankRecencyHalfLife))
+		}
+		if maxActivity > 0 {
+			score += rankActivityWeight * math.Log1p(float64(max(s.activity, 0))) / math.Log1p(float64(maxActivity))
+		}
+		all[i] = scored{item: item, score: score}
+	}
+	slices.SortStableFunc(all, func(a, b scored) int {
+		switch {
+		case a.score > b.score:
+			return -1
+		case a.score < b.score:
+			return 1
+		}
+		return 0
+	})
+	for i := range all {
+		items[i] = all[i].item
+	}
+}
+
+// repositoryRankSignals weights repositories by their last push and their stars and forks.
+func repositoryRankSignals(repo *github.Repository) rankSignals {
+	updated := repo.GetPushedAt().Time
+	if updated.IsZero() {
+		updated = repo.GetUpdatedAt().Time
+	}
+	return rankSignals{
+		updated:  updated,
+		activity: repo.GetStargazersCount() + repo.GetForksCount(),
+	}
+}
+
+// issueRankSignals weights issues and pull requests by their last update and their comments and
+// reactions.
+func issueRankSignals(issue *github.Issue) rankSignals {
+	return rankSignals{
+		updated:  issue.GetUpdatedAt().Time,
+		activity: issue.GetComments() + issue.GetReactions().GetTotalCount(),
+	}
+}
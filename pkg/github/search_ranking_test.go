@@ -0,0 +1,93 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v82/github"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_RankByWeight(t *testing.T) {
+	now := time.Date(2026, 10, 17, 0, 0, 0, 0, time.UTC)
+	repo := func(name string, pushedDaysAgo, stars int) *github.Repository {
+		return &github.Repository{
+			Name:            github.Ptr(name),
+			PushedAt:        &github.Timestamp{Time: now.AddDate(0, 0, -pushedDaysAgo)},
+			StargazersCount: github.Ptr(stars),
+		}
+	}
+	names := func(repos []*github.Repository) []string {
+		var names []string
+		for _, r := range repos {
+			names = append(names, r.GetName())
+		}
+		return names
+	}
+
+	t.Run("recent and active results move up", func(t *testing.T) {
+		repos := []*github.Repository{
+			repo("stale", 900, 10),
+			repo("abandoned", 2000, 0),
+			repo("active", 2, 5000),
+		}
+		rankByWeight(repos, now, repositoryRankSignals)
+		assert.Equal(t, []string{"active", "stale", "abandoned"}, names(repos))
+	})
+
+	t.Run("equal signals keep the search order", func(t *testing.T) {
+		repos := []*github.Repository{repo("first", 10, 10), repo("second", 10, 10), repo("third", 10, 10)}
+		rankByWeight(repos, now, repositoryRankSignals)
+		assert.Equal(t, []string{"first", "second", "third"}, names(repos))
+	})
+
+	t.Run("issues are weighted by comments and reactions", func(t *testing.T) {
+		issues := []*github.Issue{
+			{Number: github.Ptr(1), UpdatedAt: &github.Timestamp{Time: now.AddDate(-1, 0, 0)}},
+			{Number: github.Ptr(2), UpdatedAt: &github.Timestamp{Time: now.AddDate(0, 0, -1)}, Comments: github.Ptr(12), Reactions: &github.Reactions{TotalCount: github.Ptr(30)}},
+		}
+		rankByWeight(issues, now, issueRankSignals)
+		assert.Equal(t, 2, issues[0].GetNumber())
+	})
+}
+
+func Test_SearchRepositories_WeightedRank(t *testing.T) {
+	serverTool := SearchRepositories(translations.NullTranslationHelper)
+	recent := time.Now().Add(-time.Hour)
+	mockedClient := MockHTTPClientWithHandlers(map[string]http.HandlerFunc{
+		GetSearchRepositories: expectQueryParams(t, map[string]string{
+			"q":        "cli",
+			"page":     "1",
+			"per_page": "30",
+		}).andThen(
+			mockResponse(t, http.StatusOK, &github.RepositoriesSearchResult{
+				Total: github.Ptr(2),
+				Repositories: []*github.Repository{
+					{FullName: github.Ptr("octo-org/old-cli"), PushedAt: &github.Timestamp{Time: recent.AddDate(-5, 0, 0)}},
+					{FullName: github.Ptr("octo-org/cli"), PushedAt: &github.Timestamp{Time: recent}, StargazersCount: github.Ptr(800)},
+				},
+			}),
+		),
+	})
+	deps := BaseDeps{Client: github.NewClient(mockedClient)}
+	request := createMCPRequest(map[string]any{"query": "cli", "rank": "weighted", "include_archived": true})
+	result, err := serverTool.Handler(deps)(ContextWithDeps(context.Background(), deps), &request)
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	var returned MinimalSearchRepositoriesResult
+	require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returned))
+	require.Len(t, returned.Items, 2)
+	assert.Equal(t, "octo-org/cli", returned.Items[0].FullName)
+	assert.Equal(t, "octo-org/old-cli", returned.Items[1].FullName)
+
+	request = createMCPRequest(map[string]any{"query": "cli", "rank": "newest"})
+	result, err = serverTool.Handler(deps)(ContextWithDeps(context.Background(), deps), &request)
+	require.NoError(t, err)
+	assert.Contains(t, getErrorResult(t, result).Text, `invalid rank "newest"`)
+}
@@ -68,7 +68,7 @@ func Test_SearchRepositories(t *testing.T) {
 			name: "successful repository search",
 			mockedClient: MockHTTPClientWithHandlers(map[string]http.HandlerFunc{
 				GetSearchRepositories: expectQueryParams(t, map[string]string{
-					"q":        "golang test",
+					"q":        "golang test archived:false",
 					"sort":     "stars",
 					"order":    "desc",
 					"page":     "2",
@@ -91,7 +91,7 @@ func Test_SearchRepositories(t *testing.T) {
 			name: "repository search with default pagination",
 			mockedClient: MockHTTPClientWithHandlers(map[string]http.HandlerFunc{
 				GetSearchRepositories: expectQueryParams(t, map[string]string{
-					"q":        "golang test",
+					"q":        "golang test archived:false",
 					"page":     "1",
 					"per_page": "30",
 				}).andThen(
@@ -186,7 +186,7 @@ func Test_SearchRepositories_FullOutput(t *testing.T) {
 
 	mockedClient := MockHTTPClientWithHandlers(map[string]http.HandlerFunc{
 		GetSearchRepositories: expectQueryParams(t, map[string]string{
-			"q":        "golang test",
+			"q":        "golang test archived:false",
 			"page":     "1",
 			"per_page": "30",
 		}).andThen(
@@ -228,6 +228,120 @@ func Test_SearchRepositories_FullOutput(t *testing.T) {
 	assert.Equal(t, *mockSearchResult.Repositories[0].Name, *returnedResult.Repositories[0].Name)
 }
 
+func Test_SearchOrgRepositories(t *testing.T) {
+	// Verify tool definition once
+	serverTool := SearchOrgRepositories(translations.NullTranslationHelper)
+	tool := serverTool.Tool
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	schema, ok := tool.InputSchema.(*jsonschema.Schema)
+	require.True(t, ok, "InputSchema should be *jsonschema.Schema")
+
+	assert.Equal(t, "search_org_repositories", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, schema.Properties, "org")
+	assert.Contains(t, schema.Properties, "topics")
+	assert.Contains(t, schema.Properties, "language")
+	assert.Contains(t, schema.Properties, "custom_properties")
+	assert.Contains(t, schema.Properties, "include_archived")
+	assert.Contains(t, schema.Properties, "sort")
+	assert.ElementsMatch(t, schema.Required, []string{"org"})
+
+	mockSearchResult := &github.RepositoriesSearchResult{
+		Total:             github.Ptr(1),
+		IncompleteResults: github.Ptr(false),
+		Repositories: []*github.Repository{
+			{
+				ID:       github.Ptr(int64(1)),
+				Name:     github.Ptr("billing"),
+				FullName: github.Ptr("octo-org/billing"),
+				Language: github.Ptr("Go"),
+				Topics:   []string{"service", "payments"},
+			},
+		},
+	}
+
+	tests := []struct {
+		name           string
+		requestArgs    map[string]any
+		expectedQuery  map[string]string
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "combines topics, language and custom properties",
+			requestArgs: map[string]any{
+				"org":               "octo-org",
+				"topics":            []any{"service", "payments"},
+				"language":          "go",
+				"custom_properties": map[string]any{"tier": "tier-1", "owner team": "Core Payments"},
+			},
+			expectedQuery: map[string]string{
+				"q":        `org:octo-org topic:service topic:payments language:go props.owner team:"Core Payments" props.tier:tier-1 archived:false`,
+				"sort":     "updated",
+				"page":     "1",
+				"per_page": "30",
+			},
+		},
+		{
+			name: "include archived sorted by stars",
+			requestArgs: map[string]any{
+				"org":              "octo-org",
+				"include_archived": true,
+				"sort":             "stars",
+				"order":            "desc",
+			},
+			expectedQuery: map[string]string{
+				"q":        "org:octo-org",
+				"sort":     "stars",
+				"order":    "desc",
+				"page":     "1",
+				"per_page": "30",
+			},
+		},
+		{
+			name: "custom property values must be strings",
+			requestArgs: map[string]any{
+				"org":               "octo-org",
+				"custom_properties": map[string]any{"tier": float64(1)},
+			},
+			expectError:    true,
+			expectedErrMsg: "custom property tier must be a string",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			handlers := map[string]http.HandlerFunc{
+				GetSearchRepositories: mockResponse(t, http.StatusOK, mockSearchResult),
+			}
+			if tc.expectedQuery != nil {
+				handlers[GetSearchRepositories] = expectQueryParams(t, tc.expectedQuery).andThen(
+					mockResponse(t, http.StatusOK, mockSearchResult),
+				)
+			}
+			deps := BaseDeps{Client: github.NewClient(MockHTTPClientWithHandlers(handlers))}
+			handler := serverTool.Handler(deps)
+
+			request := createMCPRequest(tc.requestArgs)
+			result, err := handler(ContextWithDeps(context.Background(), deps), &request)
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			var returned MinimalSearchRepositoriesResult
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &returned))
+			require.Len(t, returned.Items, 1)
+			assert.Equal(t, "octo-org/billing", returned.Items[0].FullName)
+			assert.Equal(t, []string{"service", "payments"}, returned.Items[0].Topics)
+		})
+	}
+}
+
 func Test_SearchCode(t *testing.T) {
 	// Verify tool definition once
 	serverTool := SearchCode(translations.NullTranslationHelper)
@@ -7,6 +7,7 @@ import (
 	"io"
 	"net/http"
 	"regexp"
+	"time"
 
 	ghErrors "github.com/github/github-mcp-server/pkg/errors"
 	"github.com/github/github-mcp-server/pkg/utils"
@@ -67,6 +68,16 @@ func searchHandler(
 		query = fmt.Sprintf("repo:%s/%s %s", owner, repo, query)
 	}
 
+	rank, err := searchRankParam(args)
+	if err != nil {
+		return utils.NewToolResultError(err.Error()), nil
+	}
+	filter, err := repoFilterParams(ctx, args)
+	if err != nil {
+		return utils.NewToolResultError(err.Error()), nil
+	}
+	query = repoFilterQuery(query, filter, false)
+
 	sort, err := OptionalParam[string](args, "sort")
 	if err != nil {
 		return utils.NewToolResultError(err.Error()), nil
@@ -108,6 +119,10 @@ func searchHandler(
 		return ghErrors.NewGitHubAPIStatusErrorResponse(ctx, errorPrefix, resp, body), nil
 	}
 
+	if rank == rankWeighted {
+		rankByWeight(result.Issues, time.Now(), issueRankSignals)
+	}
+
 	r, err := json.Marshal(result)
 	if err != nil {
 		return utils.NewToolResultErrorFromErr(errorPrefix+": failed to marshal response", err), nil
@@ -2,7 +2,6 @@ package github
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
@@ -17,12 +16,16 @@ import (
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 )
 
+// secretScanningRedactor removes the detected secret from secret scanning alerts, so that tools
+// only return its type, location and state.
+var secretScanningRedactor = utils.NewRedactor("secret")
+
 func GetSecretScanningAlert(t translations.TranslationHelperFunc) inventory.ServerTool {
 	return NewTool(
 		ToolsetMetadataSecretProtection,
 		mcp.Tool{
 			Name:        "get_secret_scanning_alert",
-			Description: t("TOOL_GET_SECRET_SCANNING_ALERT_DESCRIPTION", "Get details of a specific secret scanning alert in a GitHub repository."),
+			Description: t("TOOL_GET_SECRET_SCANNING_ALERT_DESCRIPTION", "Get details of a specific secret scanning alert in a GitHub repository. The detected secret value is never included."),
 			Annotations: &mcp.ToolAnnotations{
 				Title:        t("TOOL_GET_SECRET_SCANNING_ALERT_USER_TITLE", "Get secret scanning alert"),
 				ReadOnlyHint: true,
@@ -84,7 +87,7 @@ func GetSecretScanningAlert(t translations.TranslationHelperFunc) inventory.Serv
 				return ghErrors.NewGitHubAPIStatusErrorResponse(ctx, "failed to get alert", resp, body), nil, nil
 			}
 
-			r, err := json.Marshal(alert)
+			r, err := secretScanningRedactor.Marshal(alert)
 			if err != nil {
 				return nil, nil, fmt.Errorf("failed to marshal alert: %w", err)
 			}
@@ -99,7 +102,7 @@ func ListSecretScanningAlerts(t translations.TranslationHelperFunc) inventory.Se
 		ToolsetMetadataSecretProtection,
 		mcp.Tool{
 			Name:        "list_secret_scanning_alerts",
-			Description: t("TOOL_LIST_SECRET_SCANNING_ALERTS_DESCRIPTION", "List secret scanning alerts in a GitHub repository."),
+			Description: t("TOOL_LIST_SECRET_SCANNING_ALERTS_DESCRIPTION", "List secret scanning alerts in a GitHub repository. The detected secret values are never included."),
 			Annotations: &mcp.ToolAnnotations{
 				Title:        t("TOOL_LIST_SECRET_SCANNING_ALERTS_USER_TITLE", "List secret scanning alerts"),
 				ReadOnlyHint: true,
@@ -178,7 +181,7 @@ func ListSecretScanningAlerts(t translations.TranslationHelperFunc) inventory.Se
 				return ghErrors.NewGitHubAPIStatusErrorResponse(ctx, "failed to list alerts", resp, body), nil, nil
 			}
 
-			r, err := json.Marshal(alerts)
+			r, err := secretScanningRedactor.Marshal(alerts)
 			if err != nil {
 				return nil, nil, fmt.Errorf("failed to marshal alerts: %w", err)
 			}
@@ -187,3 +190,116 @@ func ListSecretScanningAlerts(t translations.TranslationHelperFunc) inventory.Se
 		},
 	)
 }
+
+func UpdateSecretScanningAlert(t translations.TranslationHelperFunc) inventory.ServerTool {
+	return NewTool(
+		ToolsetMetadataSecretProtection,
+		mcp.Tool{
+			Name:        "update_secret_scanning_alert",
+			Description: t("TOOL_UPDATE_SECRET_SCANNING_ALERT_DESCRIPTION", "Resolve a secret scanning alert with a resolution, or reopen a resolved alert, in a GitHub repository. The detected secret value is never included."),
+			Annotations: &mcp.ToolAnnotations{
+				Title:        t("TOOL_UPDATE_SECRET_SCANNING_ALERT_USER_TITLE", "Update secret scanning alert"),
+				ReadOnlyHint: false,
+			},
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"owner": {
+						Type:        "string",
+						Description: "The owner of the repository.",
+					},
+					"repo": {
+						Type:        "string",
+						Description: "The name of the repository.",
+					},
+					"alertNumber": {
+						Type:        "number",
+						Description: "The number of the alert.",
+					},
+					"state": {
+						Type:        "string",
+						Description: "The new state of the alert.",
+						Enum:        []any{"open", "resolved"},
+					},
+					"resolution": {
+						Type:        "string",
+						Description: "The reason for resolving the alert. Required when state is resolved.",
+						Enum:        []any{"false_positive", "wont_fix", "revoked", "used_in_tests"},
+					},
+					"resolution_comment": {
+						Type:        "string",
+						Description: "A comment explaining the resolution.",
+					},
+				},
+				Required: []string{"owner", "repo", "alertNumber", "state"},
+			},
+		},
+		[]scopes.Scope{scopes.SecurityEvents},
+		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+			owner, err := RequiredParam[string](args, "owner")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			repo, err := RequiredParam[string](args, "repo")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			alertNumber, err := RequiredInt(args, "alertNumber")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			state, err := RequiredParam[string](args, "state")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			resolution, err := OptionalParam[string](args, "resolution")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			resolutionComment, err := OptionalParam[string](args, "resolution_comment")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+
+			opts := &github.SecretScanningAlertUpdateOptions{State: state}
+			switch state {
+			case "resolved":
+				if resolution == "" {
+					return utils.NewToolResultError("resolution is required when state is resolved"), nil, nil
+				}
+				opts.Resolution = github.Ptr(resolution)
+				if resolutionComment != "" {
+					opts.ResolutionComment = github.Ptr(resolutionComment)
+				}
+			case "open":
+				if resolution != "" || resolutionComment != "" {
+					return utils.NewToolResultError("resolution and resolution_comment can only be set when state is resolved"), nil, nil
+				}
+			default:
+				return utils.NewToolResultError(fmt.Sprintf("invalid state %q: must be open or resolved", state)), nil, nil
+			}
+
+			client, err := deps.GetClient(ctx)
+			if err != nil {
+				return utils.NewToolResultErrorFromErr("failed to get GitHub client", err), nil, nil
+			}
+
+			alert, resp, err := client.SecretScanning.UpdateAlert(ctx, owner, repo, int64(alertNumber), opts)
+			if err != nil {
+				return ghErrors.NewGitHubAPIErrorResponse(ctx,
+					fmt.Sprintf("failed to update alert with number '%d'", alertNumber),
+					resp,
+					err,
+				), nil, nil
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			r, err := secretScanningRedactor.Marshal(alert)
+			if err != nil {
+				return utils.NewToolResultErrorFromErr("failed to marshal alert", err), nil, nil
+			}
+
+			return utils.NewToolResultText(string(r)), nil, nil
+		},
+	)
+}
@@ -7,6 +7,7 @@ import (
 	"testing"
 
 	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/inventory"
 	"github.com/github/github-mcp-server/pkg/translations"
 	"github.com/google/go-github/v82/github"
 	"github.com/google/jsonschema-go/jsonschema"
@@ -249,3 +250,109 @@ func Test_ListSecretScanningAlerts(t *testing.T) {
 		})
 	}
 }
+
+func Test_SecretScanningAlertRedaction(t *testing.T) {
+	leaked := &github.SecretScanningAlert{
+		Number:            github.Ptr(42),
+		State:             github.Ptr("resolved"),
+		Resolution:        github.Ptr("revoked"),
+		SecretType:        github.Ptr("github_personal_access_token"),
+		Secret:            github.Ptr("ghp_leakedTokenValue123"),
+		ResolutionComment: github.Ptr("Rotated ghp_leakedTokenValue123"),
+		FirstLocationDetected: &github.SecretScanningAlertLocationDetails{
+			Path:      github.Ptr("config/prod.env"),
+			Startline: github.Ptr(3),
+		},
+	}
+	mockedClient := MockHTTPClientWithHandlers(map[string]http.HandlerFunc{
+		GetReposSecretScanningAlertsByOwnerByRepo:              mockResponse(t, http.StatusOK, []*github.SecretScanningAlert{leaked}),
+		GetReposSecretScanningAlertsByOwnerByRepoByAlertNumber: mockResponse(t, http.StatusOK, leaked),
+	})
+	deps := BaseDeps{Client: github.NewClient(mockedClient)}
+
+	for _, serverTool := range []inventory.ServerTool{
+		GetSecretScanningAlert(translations.NullTranslationHelper),
+		ListSecretScanningAlerts(translations.NullTranslationHelper),
+	} {
+		t.Run(serverTool.Tool.Name, func(t *testing.T) {
+			request := createMCPRequest(map[string]any{"owner": "owner", "repo": "repo", "alertNumber": float64(42)})
+			result, err := serverTool.Handler(deps)(ContextWithDeps(context.Background(), deps), &request)
+			require.NoError(t, err)
+			require.False(t, result.IsError)
+
+			text := getTextResult(t, result).Text
+			assert.NotContains(t, text, "ghp_leakedTokenValue123")
+			assert.NotContains(t, text, `"secret"`)
+			assert.Contains(t, text, "Rotated [REDACTED]")
+			assert.Contains(t, text, "config/prod.env")
+			assert.Contains(t, text, "github_personal_access_token")
+		})
+	}
+}
+
+func Test_UpdateSecretScanningAlert(t *testing.T) {
+	toolDef := UpdateSecretScanningAlert(translations.NullTranslationHelper)
+	require.NoError(t, toolsnaps.Test(toolDef.Tool.Name, toolDef.Tool))
+	assert.False(t, toolDef.Tool.Annotations.ReadOnlyHint)
+
+	tests := []struct {
+		name           string
+		requestArgs    map[string]any
+		expectedBody   map[string]any
+		expectedErrMsg string
+	}{
+		{
+			name:         "resolve alert",
+			requestArgs:  map[string]any{"owner": "owner", "repo": "repo", "alertNumber": float64(42), "state": "resolved", "resolution": "revoked", "resolution_comment": "Rotated"},
+			expectedBody: map[string]any{"state": "resolved", "resolution": "revoked", "resolution_comment": "Rotated"},
+		},
+		{
+			name:         "reopen alert",
+			requestArgs:  map[string]any{"owner": "owner", "repo": "repo", "alertNumber": float64(42), "state": "open"},
+			expectedBody: map[string]any{"state": "open"},
+		},
+		{
+			name:           "resolution required",
+			requestArgs:    map[string]any{"owner": "owner", "repo": "repo", "alertNumber": float64(42), "state": "resolved"},
+			expectedErrMsg: "resolution is required when state is resolved",
+		},
+		{
+			name:           "resolution with open state",
+			requestArgs:    map[string]any{"owner": "owner", "repo": "repo", "alertNumber": float64(42), "state": "open", "resolution": "wont_fix"},
+			expectedErrMsg: "resolution and resolution_comment can only be set when state is resolved",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockedClient := MockHTTPClientWithHandlers(map[string]http.HandlerFunc{
+				PatchReposSecretScanningAlertsByOwnerByRepoByAlertNumber: func(w http.ResponseWriter, r *http.Request) {
+					var body map[string]any
+					require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+					assert.Equal(t, tc.expectedBody, body)
+					w.WriteHeader(http.StatusOK)
+					_ = json.NewEncoder(w).Encode(&github.SecretScanningAlert{
+						Number: github.Ptr(42),
+						State:  github.Ptr(tc.expectedBody["state"].(string)),
+						Secret: github.Ptr("ghp_leakedTokenValue123"),
+					})
+				},
+			})
+			deps := BaseDeps{Client: github.NewClient(mockedClient)}
+			request := createMCPRequest(tc.requestArgs)
+			result, err := toolDef.Handler(deps)(ContextWithDeps(context.Background(), deps), &request)
+			require.NoError(t, err)
+
+			if tc.expectedErrMsg != "" {
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+			require.False(t, result.IsError)
+			text := getTextResult(t, result).Text
+			assert.NotContains(t, text, "ghp_leakedTokenValue123")
+			var alert github.SecretScanningAlert
+			require.NoError(t, json.Unmarshal([]byte(text), &alert))
+			assert.Equal(t, tc.expectedBody["state"], alert.GetState())
+		})
+	}
+}
@@ -0,0 +1,320 @@
+package github
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/inventory"
+	"github.com/github/github-mcp-server/pkg/scopes"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/github/github-mcp-server/pkg/utils"
+	"github.com/google/go-github/v82/github"
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+const (
+	// maxSecurityCoverageRepos caps the repositories a coverage report checks, as each one takes
+	// two requests besides listing it.
+	maxSecurityCoverageRepos = 500
+
+	// securityCoverageParallelism is the number of repositories checked at once.
+	securityCoverageParallelism = 8
+)
+
+// Feature states of a security coverage report. Unknown means the token cannot see the setting,
+// which usually requires admin access to the repository.
+const (
+	securityFeatureEnabled  = "enabled"
+	securityFeatureDisabled = "disabled"
+	securityFeatureUnknown  = "unknown"
+)
+
+// securityCoverageFeatures are the features of a coverage report, in column order.
+var securityCoverageFeatures = []string{
+	"advanced_security",
+	"code_scanning",
+	"secret_scanning",
+	"push_protection",
+	"dependabot_alerts",
+	"dependabot_security_updates",
+}
+
+// RepositorySecurityCoverage is the state of each Advanced Security feature in a repository.
+type RepositorySecurityCoverage struct {
+	Name       string            `json:"name"`
+	Visibility string            `json:"visibility"`
+	Features   map[string]string `json:"features"`
+}
+
+// OrgSecurityCoverage reports the Advanced Security features enabled across an organization.
+type OrgSecurityCoverage struct {
+	Org string `json:"org"`
+	// Summary counts the repositories in each state, per feature.
+	Summary map[string]map[string]int `json:"summary"`
+	// Missing lists the repositories each feature is disabled in.
+	Missing      map[string][]string          `json:"missing"`
+	Repositories []RepositorySecurityCoverage `json:"repositories"`
+	Note         string                       `json:"note,omitempty"`
+}
+
+// securityAnalysisState returns the state of a security_and_analysis setting.
+func securityAnalysisState(status string) string {
+	switch status {
+	case "enabled":
+		return securityFeatureEnabled
+	case "disabled":
+		return securityFeatureDisabled
+	default:
+		return securityFeatureUnknown
+	}
+}
+
+// newRepositorySecurityCoverage reads the settings the repository listing includes. Code
+// scanning and Dependabot alerts are unknown until checked separately.
+func newRepositorySecurityCoverage(repo *github.Repository) RepositorySecurityCoverage {
+	settings := repo.GetSecurityAndAnalysis()
+	return RepositorySecurityCoverage{
+		Name:       repo.GetName(),
+		Visibility: repo.GetVisibility(),
+		Features: map[string]string{
+			"advanced_security":           securityAnalysisState(settings.GetAdvancedSecurity().GetStatus()),
+			"code_scanning":               securityFeatureUnknown,
+			"secret_scanning":             securityAnalysisState(settings.GetSecretScanning().GetStatus()),
+			"push_protection":             securityAnalysisState(settings.GetSecretScanningPushProtection().GetStatus()),
+			"dependabot_alerts":           securityFeatureUnknown,
+			"dependabot_security_updates": securityAnalysisState(settings.GetDependabotSecurityUpdates().GetStatus()),
+		},
+	}
+}
+
+// checkCodeScanning reports whether a repository has code scanning analyses. Repositories
+// without any answer 404, and those where it cannot be enabled answer 403.
+func checkCodeScanning(ctx context.Context, client *github.Client, owner, repo string) string {
+	analyses, resp, err := client.CodeScanning.ListAnalysesForRepo(ctx, owner, repo, &github.AnalysesListOptions{ListOptions: github.ListOptions{PerPage: 1}})
+	if resp != nil {
+		_ = resp.Body.Close()
+	}
+	if err != nil {
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			return securityFeatureDisabled
+		}
+		if resp != nil && resp.StatusCode == http.StatusForbidden && strings.Contains(strings.ToLower(err.Error()), "must be enabled") {
+			return securityFeatureDisabled
+		}
+		return securityFeatureUnknown
+	}
+	if len(analyses) == 0 {
+		return securityFeatureDisabled
+	}
+	return securityFeatureEnabled
+}
+
+// checkDependabotAlerts reports whether a repository has Dependabot alerts enabled.
+func checkDependabotAlerts(ctx context.Context, client *github.Client, owner, repo string) string {
+	enabled, resp, err := client.Repositories.GetVulnerabilityAlerts(ctx, owner, repo)
+	if resp != nil {
+		_ = resp.Body.Close()
+	}
+	switch {
+	case err != nil:
+		return securityFeatureUnknown
+	case enabled:
+		return securityFeatureEnabled
+	default:
+		return securityFeatureDisabled
+	}
+}
+
+// summarizeSecurityCoverage counts the repositories in each state and lists those missing each
+// feature.
+func summarizeSecurityCoverage(report *OrgSecurityCoverage) {
+	report.Summary = map[string]map[string]int{}
+	report.Missing = map[string][]string{}
+	for _, feature := range securityCoverageFeatures {
+		report.Summary[feature] = map[string]int{securityFeatureEnabled: 0, securityFeatureDisabled: 0, securityFeatureUnknown: 0}
+		report.Missing[feature] = []string{}
+	}
+	for _, repo := range report.Repositories {
+		for _, feature := range securityCoverageFeatures {
+			state := repo.Features[feature]
+			report.Summary[feature][state]++
+			if state == securityFeatureDisabled {
+				report.Missing[feature] = append(report.Missing[feature], repo.Name)
+			}
+		}
+	}
+}
+
+// encodeSecurityCoverageCSV encodes a coverage report as CSV, one row per repository.
+func encodeSecurityCoverageCSV(report OrgSecurityCoverage) (string, error) {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	_ = w.Write(append([]string{"repository", "visibility"}, securityCoverageFeatures...))
+	for _, repo := range report.Repositories {
+		row := []string{repo.Name, repo.Visibility}
+		for _, feature := range securityCoverageFeatures {
+			row = append(row, repo.Features[feature])
+		}
+		_ = w.Write(row)
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return "", fmt.Errorf("failed to encode report: %w", err)
+	}
+	return buf.String(), nil
+}
+
+// GetOrgSecurityCoverage creates a tool reporting which repositories of an organization have
+// GitHub Advanced Security features enabled.
+func GetOrgSecurityCoverage(t translations.TranslationHelperFunc) inventory.ServerTool {
+	return NewTool(
+		ToolsetMetadataCodeSecurity,
+		mcp.Tool{
+			Name: "get_org_security_coverage",
+			Description: t("TOOL_GET_ORG_SECURITY_COVERAGE_DESCRIPTION", `Report which repositories of an organization have GitHub Advanced Security features enabled: Advanced Security, code scanning, secret scanning, push protection, Dependabot alerts and Dependabot security updates.
+Features are "enabled", "disabled", or "unknown" when the token cannot see the setting, which usually requires admin access. Use the csv format to export the report.`),
+			Annotations: &mcp.ToolAnnotations{
+				Title:        t("TOOL_GET_ORG_SECURITY_COVERAGE_USER_TITLE", "Get organization security coverage"),
+				ReadOnlyHint: true,
+			},
+			InputSchema: WithRepoFilter(&jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"org": {
+						Type:        "string",
+						Description: "Organization login",
+					},
+					"repos": {
+						Type:        "array",
+						Description: "Only report these repositories (names without the owner). Defaults to every repository in the organization.",
+						Items: &jsonschema.Schema{
+							Type: "string",
+						},
+					},
+					"format": {
+						Type:        "string",
+						Description: "Format of the report: json with a summary, or csv with one row per repository",
+						Enum:        []any{"json", "csv"},
+						Default:     json.RawMessage(`"json"`),
+					},
+				},
+				Required: []string{"org"},
+			}),
+		},
+		[]scopes.Scope{scopes.Repo},
+		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+			org, err := RequiredParam[string](args, "org")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			repos, err := OptionalStringArrayParam(args, "repos")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			format, err := OptionalParam[string](args, "format")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			if format == "" {
+				format = "json"
+			}
+			if format != "json" && format != "csv" {
+				return utils.NewToolResultError("format must be json or csv"), nil, nil
+			}
+			filter, err := repoFilterParams(ctx, args)
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+
+			client, err := deps.GetClient(ctx)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			wanted := map[string]bool{}
+			for _, r := range repos {
+				wanted[strings.ToLower(r)] = true
+			}
+
+			report := OrgSecurityCoverage{Org: org, Repositories: []RepositorySecurityCoverage{}}
+			opts := &github.RepositoryListByOrgOptions{Type: "all", ListOptions: github.ListOptions{PerPage: 100}}
+			for {
+				page, resp, err := client.Repositories.ListByOrg(ctx, org, opts)
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to list repositories", resp, err), nil, nil
+				}
+				_ = resp.Body.Close()
+
+				for _, repo := range page {
+					if len(wanted) > 0 && !wanted[strings.ToLower(repo.GetName())] {
+						continue
+					}
+					if len(wanted) == 0 && ((filter.ExcludeArchived && repo.GetArchived()) || (filter.ExcludeForks && repo.GetFork())) {
+						continue
+					}
+					report.Repositories = append(report.Repositories, newRepositorySecurityCoverage(repo))
+				}
+				if resp.NextPage == 0 || len(report.Repositories) >= maxSecurityCoverageRepos {
+					break
+				}
+				opts.Page = resp.NextPage
+			}
+			if len(report.Repositories) > maxSecurityCoverageRepos {
+				report.Repositories = report.Repositories[:maxSecurityCoverageRepos]
+			}
+			if len(report.Repositories) == maxSecurityCoverageRepos {
+				report.Note = fmt.Sprintf("only the first %d repositories were checked; pass repos to check others", maxSecurityCoverageRepos)
+			}
+
+			var wg sync.WaitGroup
+			sem := make(chan struct{}, securityCoverageParallelism)
+			for i := range report.Repositories {
+				wg.Add(1)
+				sem <- struct{}{}
+				go func(repo *RepositorySecurityCoverage) {
+					defer func() {
+						<-sem
+						wg.Done()
+					}()
+					codeScanning := checkCodeScanning(ctx, client, org, repo.Name)
+					dependabotAlerts := checkDependabotAlerts(ctx, client, org, repo.Name)
+					repo.Features["code_scanning"] = codeScanning
+					repo.Features["dependabot_alerts"] = dependabotAlerts
+				}(&report.Repositories[i])
+			}
+			wg.Wait()
+
+			slices.SortFunc(report.Repositories, func(a, b RepositorySecurityCoverage) int {
+				return strings.Compare(strings.ToLower(a.Name), strings.ToLower(b.Name))
+			})
+			summarizeSecurityCoverage(&report)
+
+			if format == "json" {
+				return MarshalledTextResult(report), nil, nil
+			}
+
+			data, err := encodeSecurityCoverageCSV(report)
+			if err != nil {
+				return nil, nil, err
+			}
+			message := fmt.Sprintf("Security coverage of %d repositories of %s as CSV.", len(report.Repositories), org)
+			if report.Note != "" {
+				message += " Note: " + report.Note + "."
+			}
+			return utils.NewToolResultResource(message, &mcp.ResourceContents{
+				URI:      fmt.Sprintf("org://%s/security-coverage.csv", org),
+				MIMEType: "text/csv",
+				Text:     data,
+			}), nil, nil
+		},
+	)
+}
@@ -0,0 +1,106 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v82/github"
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_GetOrgSecurityCoverage(t *testing.T) {
+	serverTool := GetOrgSecurityCoverage(translations.NullTranslationHelper)
+	tool := serverTool.Tool
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_org_security_coverage", tool.Name)
+	assert.True(t, tool.Annotations.ReadOnlyHint)
+	schema := tool.InputSchema.(*jsonschema.Schema)
+	assert.ElementsMatch(t, schema.Required, []string{"org"})
+
+	enabled := func(status string) map[string]any { return map[string]any{"status": status} }
+	handlers := map[string]http.HandlerFunc{
+		GetOrgsReposByOrg: mockResponse(t, http.StatusOK, []map[string]any{
+			{
+				"name":       "web",
+				"visibility": "private",
+				"security_and_analysis": map[string]any{
+					"advanced_security":               enabled("enabled"),
+					"secret_scanning":                 enabled("enabled"),
+					"secret_scanning_push_protection": enabled("disabled"),
+					"dependabot_security_updates":     enabled("enabled"),
+				},
+			},
+			// Without admin access the settings are not included
+			{"name": "api", "visibility": "internal"},
+			{"name": "old", "visibility": "private", "archived": true},
+		}),
+		GetReposCodeScanningAnalysesByOwnerByRepo: func(w http.ResponseWriter, r *http.Request) {
+			if strings.Contains(r.URL.Path, "/web/") {
+				mockResponse(t, http.StatusOK, []map[string]any{{"id": 1}})(w, r)
+				return
+			}
+			mockResponse(t, http.StatusNotFound, map[string]string{"message": "no analysis found"})(w, r)
+		},
+		GetReposVulnerabilityAlertsByOwnerByRepo: func(w http.ResponseWriter, r *http.Request) {
+			if strings.Contains(r.URL.Path, "/web/") {
+				w.WriteHeader(http.StatusNoContent)
+				return
+			}
+			w.WriteHeader(http.StatusNotFound)
+		},
+	}
+
+	t.Run("json report", func(t *testing.T) {
+		deps := BaseDeps{Client: github.NewClient(MockHTTPClientWithHandlers(handlers))}
+		request := createMCPRequest(map[string]any{"org": "acme"})
+
+		result, err := serverTool.Handler(deps)(ContextWithDeps(context.Background(), deps), &request)
+		require.NoError(t, err)
+		require.False(t, result.IsError)
+
+		var report OrgSecurityCoverage
+		require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &report))
+		require.Len(t, report.Repositories, 2, "archived repositories are left out by default")
+		assert.Equal(t, "api", report.Repositories[0].Name)
+		assert.Equal(t, map[string]string{
+			"advanced_security":           "enabled",
+			"code_scanning":               "enabled",
+			"secret_scanning":             "enabled",
+			"push_protection":             "disabled",
+			"dependabot_alerts":           "enabled",
+			"dependabot_security_updates": "enabled",
+		}, report.Repositories[1].Features)
+		assert.Equal(t, "unknown", report.Repositories[0].Features["secret_scanning"])
+		assert.Equal(t, []string{"api"}, report.Missing["code_scanning"])
+		assert.Equal(t, []string{"web"}, report.Missing["push_protection"])
+		assert.Equal(t, map[string]int{"enabled": 1, "disabled": 1, "unknown": 0}, report.Summary["dependabot_alerts"])
+	})
+
+	t.Run("csv export", func(t *testing.T) {
+		deps := BaseDeps{Client: github.NewClient(MockHTTPClientWithHandlers(handlers))}
+		request := createMCPRequest(map[string]any{"org": "acme", "format": "csv", "include_archived": true})
+
+		result, err := serverTool.Handler(deps)(ContextWithDeps(context.Background(), deps), &request)
+		require.NoError(t, err)
+		require.False(t, result.IsError)
+		require.Len(t, result.Content, 2)
+
+		resource, ok := result.Content[1].(*mcp.EmbeddedResource)
+		require.True(t, ok)
+		assert.Equal(t, "text/csv", resource.Resource.MIMEType)
+		assert.Equal(t, `repository,visibility,advanced_security,code_scanning,secret_scanning,push_protection,dependabot_alerts,dependabot_security_updates
+api,internal,unknown,disabled,unknown,unknown,disabled,unknown
+old,private,unknown,disabled,unknown,unknown,disabled,unknown
+web,private,enabled,enabled,enabled,disabled,enabled,enabled
+`, resource.Resource.Text)
+	})
+}
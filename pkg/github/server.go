@@ -8,8 +8,11 @@ import (
 	"strings"
 	"time"
 
+	"github.com/github/github-mcp-server/pkg/audit"
 	gherrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/http/transport"
 	"github.com/github/github-mcp-server/pkg/inventory"
+	"github.com/github/github-mcp-server/pkg/observability/tracing"
 	"github.com/github/github-mcp-server/pkg/octicons"
 	"github.com/github/github-mcp-server/pkg/translations"
 	"github.com/github/github-mcp-server/pkg/utils"
@@ -20,12 +23,36 @@ type MCPServerConfig struct {
 	// Version of the server
 	Version string
 
+	// Commit and BuildDate identify the build of the server
+	Commit    string
+	BuildDate string
+
 	// GitHub Host to target for API requests (e.g. github.com or github.enterprise.com)
 	Host string
 
+	// UploadHost, when set, is the URL release assets are uploaded to instead of the one derived
+	// from Host (e.g. https://uploads.github.example.com/ or https://github.example.com/api/uploads/)
+	UploadHost string
+
 	// GitHub Token to authenticate with the GitHub API
 	Token string
 
+	// TokenSource, when set, provides the token requests are authenticated with instead of
+	// Token, so that long-lived sessions pick up tokens replaced before or after they expire.
+	// Token should still hold its initial token, which identifies the type of token.
+	TokenSource transport.TokenSource
+
+	// AppID and AppPrivateKey authenticate as a GitHub App installation instead of with Token,
+	// creating installation tokens as they expire. AppInstallationID selects the installation,
+	// and may be zero when the app has a single one.
+	AppID             int64
+	AppPrivateKey     []byte
+	AppInstallationID int64
+
+	// Profiles are other hosts and tokens tool calls can use through their profile argument, such
+	// as a GitHub Enterprise Server instance next to github.com
+	Profiles []ProfileConfig
+
 	// EnabledToolsets is a list of toolsets to enable
 	// See: https://github.com/github/github-mcp-server?tab=readme-ov-file#tool-configuration
 	EnabledToolsets []string
@@ -45,12 +72,45 @@ type MCPServerConfig struct {
 	// ReadOnly indicates if we should only offer read-only tools
 	ReadOnly bool
 
+	// WithoutDestructive indicates if we should hide write tools that may delete or overwrite data
+	WithoutDestructive bool
+
+	// OnlyIdempotent indicates if we should hide write tools whose repeated calls have additional effects
+	OnlyIdempotent bool
+
+	// EstimateTokens indicates if we should attach the estimated token count of tool results to their _meta
+	EstimateTokens bool
+
+	// DedupResults indicates if we should replace tool results identical to an earlier result of the session with a marker
+	DedupResults bool
+
+	// EventWebhook configures the webhook tool events are sent to
+	EventWebhook EventWebhookConfig
+
+	// CursorSigningKey signs the pagination cursors returned by GraphQL tools. When empty, a random
+	// key is used, and cursors stop working when the server restarts
+	CursorSigningKey string
+
+	// RepoFilter sets the repositories list and search tools leave out by default. When nil,
+	// DefaultRepoFilter is used
+	RepoFilter *RepoFilter
+
+	// CommentTemplates are the comment templates create_issue_comment_from_template can post,
+	// in addition to those of each repository's configuration
+	CommentTemplates CommentTemplates
+
+	// RootsEnforcement is what we should do with tool calls targeting a repository outside the client's roots
+	RootsEnforcement RootsEnforcement
+
 	// Translator provides translated text for the server tooling
 	Translator translations.TranslationHelperFunc
 
 	// Content window size
 	ContentWindowSize int
 
+	// ContentWindowOverrides overrides the content window size for some toolsets or tools
+	ContentWindowOverrides ContentWindowOverrides
+
 	// LockdownMode indicates if we should enable lockdown mode
 	LockdownMode bool
 
@@ -61,6 +121,12 @@ type MCPServerConfig struct {
 	Logger *slog.Logger
 	// RepoAccessTTL overrides the default TTL for repository access cache entries.
 	RepoAccessTTL *time.Duration
+	// ResponseCache, when set, caches REST and raw content responses, which are revalidated with
+	// conditional requests before they are reused.
+	ResponseCache transport.ResponseCache
+	// LockdownTrustOwnContent overrides whether lockdown mode always trusts content authored by
+	// the token owner. Defaults to true.
+	LockdownTrustOwnContent *bool
 
 	// ExcludeTools is a list of tool names that should be disabled regardless of
 	// other configuration. These tools will be excluded even if their toolset is enabled
@@ -72,6 +138,44 @@ type MCPServerConfig struct {
 	// This is used for PAT scope filtering where we can't issue scope challenges.
 	TokenScopes []string
 
+	// ToolNamePrefix, when set, is put in front of every tool name clients see
+	// (e.g. "github." for "github.list_issues")
+	ToolNamePrefix string
+
+	// GHESVersion is the GitHub Enterprise Server version (e.g. 3.16) whose bundled
+	// capabilities are used to hide tools the instance does not support.
+	GHESVersion string
+
+	// SessionCallBudget is the maximum number of GitHub API requests a session may make.
+	// Zero means unlimited.
+	SessionCallBudget int
+
+	// SessionCostBudget is the maximum estimated rate limit cost of the GitHub API requests
+	// a session may make. Zero means unlimited.
+	SessionCostBudget int
+
+	// TrackAPIUsage charges the GitHub API requests of each session to a budget, even when
+	// no limit is set, so that get_api_usage can report them. Sessions must have IDs.
+	TrackAPIUsage bool
+
+	// AuditLog, when set, receives an audit record of every write tool call.
+	AuditLog audit.Sink
+
+	// DryRun runs every write tool call as a dry run, describing the GitHub API requests it
+	// would make without making them. Single calls can ask for a dry run with their dry_run argument.
+	DryRun bool
+
+	// Tracer, when set, records spans of MCP requests. The GitHub clients record the spans of
+	// API calls separately.
+	Tracer tracing.Tracer
+
+	// WriteQuota limits the write tool calls a session may make without approval.
+	WriteQuota WriteQuotaConfig
+
+	// ConfirmDestructive, when set, holds the tools whose calls the user must confirm, with
+	// their prompts.
+	ConfirmDestructive DestructiveToolPrompts
+
 	// Additional server options to apply
 	ServerOptions []MCPServerOption
 }
@@ -79,6 +183,12 @@ type MCPServerConfig struct {
 type MCPServerOption func(*mcp.ServerOptions)
 
 func NewMCPServer(ctx context.Context, cfg *MCPServerConfig, deps ToolDependencies, inv *inventory.Inventory, middleware ...mcp.Middleware) (*mcp.Server, error) {
+	if cfg.ToolNamePrefix != "" {
+		if err := ValidateToolNamePrefix(cfg.ToolNamePrefix); err != nil {
+			return nil, err
+		}
+	}
+
 	// Create the MCP server
 	serverOpts := &mcp.ServerOptions{
 		Instructions:      inv.Instructions(),
@@ -103,11 +213,79 @@ func NewMCPServer(ctx context.Context, cfg *MCPServerConfig, deps ToolDependenci
 
 	ghServer := NewServer(cfg.Version, cfg.Translator("SERVER_NAME", "github-mcp-server"), cfg.Translator("SERVER_TITLE", "GitHub MCP Server"), serverOpts)
 
-	// Add middlewares. Order matters - for example, the error context middleware should be applied last so that it runs FIRST (closest to the handler) to ensure all errors are captured,
-	// and any middleware that needs to read or modify the context should be before it.
+	// Add middlewares. Order matters: each middleware wraps those added before it, so the first
+	// added runs closest to the handler and the last added sees requests first.
+	if cfg.DedupResults {
+		ghServer.AddReceivingMiddleware(ResultDedupMiddleware)
+	}
+	isWriteTool := func(name string) bool {
+		tool, _, err := inv.FindToolByName(name)
+		return err == nil && !tool.IsReadOnly()
+	}
+	if cfg.CursorSigningKey != "" {
+		ghServer.AddReceivingMiddleware(CursorSigningMiddleware(cfg.CursorSigningKey))
+	}
+	if cfg.RepoFilter != nil {
+		ghServer.AddReceivingMiddleware(RepoFilterMiddleware(*cfg.RepoFilter))
+	}
+	if len(cfg.CommentTemplates) > 0 {
+		ghServer.AddReceivingMiddleware(CommentTemplatesMiddleware(cfg.CommentTemplates))
+	}
+	if len(cfg.ConfirmDestructive) > 0 {
+		ghServer.AddReceivingMiddleware(ConfirmDestructiveMiddleware(cfg.ConfirmDestructive))
+	}
+	if len(cfg.ContentWindowOverrides) > 0 {
+		ghServer.AddReceivingMiddleware(ContentWindowMiddleware(cfg.ContentWindowOverrides, inv))
+	}
+	if cfg.TrackAPIUsage || cfg.SessionCallBudget > 0 || cfg.SessionCostBudget > 0 {
+		ghServer.AddReceivingMiddleware(SessionBudgetMiddleware(cfg.SessionCallBudget, cfg.SessionCostBudget))
+	}
+	if cfg.WriteQuota.PerHour > 0 {
+		ghServer.AddReceivingMiddleware(WriteQuotaMiddleware(cfg.WriteQuota, isWriteTool))
+	}
+	if cfg.RootsEnforcement != "" && cfg.RootsEnforcement != RootsEnforcementOff {
+		// Inside the roots middleware, so the roots are loaded before the first call is checked
+		ghServer.AddReceivingMiddleware(RootsEnforcementMiddleware(cfg.RootsEnforcement, cfg.Logger))
+	}
+	if cfg.EventWebhook.URL != "" {
+		// Outside roots enforcement, so that its denials are sent
+		ghServer.AddReceivingMiddleware(EventWebhookMiddleware(cfg.EventWebhook, cfg.Logger, isWriteTool))
+	}
+	if cfg.AuditLog != nil {
+		// Outside roots enforcement, so that its denials are recorded
+		ghServer.AddReceivingMiddleware(AuditLogMiddleware(cfg.AuditLog, cfg.Logger, isWriteTool))
+	}
+	// Outside the audit log, event webhook, confirmation and write quota middleware, which exempt
+	// dry runs
+	ghServer.AddReceivingMiddleware(DryRunMiddleware(cfg.DryRun, isWriteTool))
+	// Outside the middleware above, so that they run with the dependencies of the call's profile
 	ghServer.AddReceivingMiddleware(middleware...)
-	ghServer.AddReceivingMiddleware(InjectDepsMiddleware(deps))
+	ghServer.AddReceivingMiddleware(RootsMiddleware(cfg.Host))
+	if isDotcomHost(cfg.Host) {
+		ghServer.AddReceivingMiddleware(githubIncidentMiddleware(githubStatus))
+	}
+	ghServer.AddReceivingMiddleware(RecordToolCallsMiddleware)
 	ghServer.AddReceivingMiddleware(addGitHubAPIErrorToContext)
+	// Outside the middleware checking arguments, so that they see the arguments the tool runs with
+	ghServer.AddReceivingMiddleware(ArgumentRepairMiddleware(inv))
+	// Outside the middleware looking tools up by name, so that they see the tools actually called
+	ghServer.AddReceivingMiddleware(DeprecatedAliasMiddleware(DeprecatedToolAliases, DeprecatedToolAliasCalls, inv))
+	ghServer.AddReceivingMiddleware(injectServerInfoMiddleware(cfg, inv))
+	ghServer.AddReceivingMiddleware(InjectDepsMiddleware(deps))
+	if cfg.EstimateTokens {
+		// Outside the middleware rewriting or replacing results, so the estimate covers the
+		// content of the result returned
+		ghServer.AddReceivingMiddleware(TokenEstimateMiddleware)
+	}
+	if cfg.ToolNamePrefix != "" {
+		// Outside the other middleware but tracing, so every other middleware sees the plain tool
+		// names
+		ghServer.AddReceivingMiddleware(ToolNamePrefixMiddleware(cfg.ToolNamePrefix))
+	}
+	if cfg.Tracer != nil {
+		// Added after the prefix middleware, so the span covers the whole chain
+		ghServer.AddReceivingMiddleware(TracingMiddleware(cfg.Tracer))
+	}
 
 	if unrecognized := inv.UnrecognizedToolsets(); len(unrecognized) > 0 {
 		cfg.Logger.Warn("Warning: unrecognized toolsets ignored", "toolsets", strings.Join(unrecognized, ", "))
@@ -211,7 +389,7 @@ func CompletionsHandler(getClient GetClientFn) func(ctx context.Context, req *mc
 			}
 			return nil, fmt.Errorf("unsupported resource URI: %s", req.Params.Ref.URI)
 		case "ref/prompt":
-			return nil, nil
+			return PromptCompletionHandler(getClient)(ctx, req)
 		default:
 			return nil, fmt.Errorf("unsupported ref type: %s", req.Params.Ref.Type)
 		}
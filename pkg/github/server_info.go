@@ -0,0 +1,128 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"runtime"
+	"runtime/debug"
+	"slices"
+
+	"github.com/github/github-mcp-server/pkg/inventory"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// ServerInfo describes the build and configuration of the running server, as returned by the
+// get_server_info tool.
+type ServerInfo struct {
+	Version            string   `json:"version"`
+	Commit             string   `json:"commit,omitempty"`
+	BuildDate          string   `json:"build_date,omitempty"`
+	GoVersion          string   `json:"go_version"`
+	Host               string   `json:"host"`
+	GHESVersion        string   `json:"ghes_version,omitempty"`
+	ReadOnly           bool     `json:"read_only"`
+	WithoutDestructive bool     `json:"without_destructive"`
+	OnlyIdempotent     bool     `json:"only_idempotent"`
+	LockdownMode       bool     `json:"lockdown_mode"`
+	InsidersMode       bool     `json:"insiders_mode"`
+	DynamicToolsets    bool     `json:"dynamic_toolsets"`
+	EnabledToolsets    []string `json:"enabled_toolsets"`
+	FeatureFlags       []string `json:"feature_flags"`
+}
+
+// serverInfoSource holds what the get_server_info tool reports on. The inventory is read at call
+// time, as toolsets may be enabled at runtime in dynamic mode.
+type serverInfoSource struct {
+	cfg *MCPServerConfig
+	inv *inventory.Inventory
+}
+
+type serverInfoContextKey struct{}
+
+// injectServerInfoMiddleware makes the server configuration available to the get_server_info tool.
+func injectServerInfoMiddleware(cfg *MCPServerConfig, inv *inventory.Inventory) mcp.Middleware {
+	source := &serverInfoSource{cfg: cfg, inv: inv}
+	return func(next mcp.MethodHandler) mcp.MethodHandler {
+		return func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+			return next(context.WithValue(ctx, serverInfoContextKey{}, source), method, req)
+		}
+	}
+}
+
+// buildServerInfo reports the configuration in effect for the current request.
+func buildServerInfo(ctx context.Context, deps ToolDependencies, source *serverInfoSource) ServerInfo {
+	flags := deps.GetFlags(ctx)
+	info := ServerInfo{
+		GoVersion:       runtime.Version(),
+		Host:            "github.com",
+		LockdownMode:    flags.LockdownMode,
+		InsidersMode:    flags.InsidersMode,
+		EnabledToolsets: []string{},
+		FeatureFlags:    []string{},
+	}
+	if source != nil {
+		cfg := source.cfg
+		info.Version = cfg.Version
+		info.Commit = cfg.Commit
+		info.BuildDate = cfg.BuildDate
+		info.GHESVersion = cfg.GHESVersion
+		info.ReadOnly = cfg.ReadOnly
+		info.WithoutDestructive = cfg.WithoutDestructive
+		info.OnlyIdempotent = cfg.OnlyIdempotent
+		info.DynamicToolsets = cfg.DynamicToolsets
+		if cfg.Host != "" {
+			info.Host = cfg.Host
+		}
+		for _, id := range source.inv.EnabledToolsetIDs() {
+			info.EnabledToolsets = append(info.EnabledToolsets, string(id))
+		}
+	}
+	if info.Commit == "" {
+		info.Commit = vcsRevision()
+	}
+	for _, flag := range AllowedFeatureFlags {
+		if deps.IsFeatureEnabled(ctx, flag) {
+			info.FeatureFlags = append(info.FeatureFlags, flag)
+		}
+	}
+	slices.Sort(info.EnabledToolsets)
+	return info
+}
+
+// vcsRevision returns the commit the binary was built from, if the Go toolchain recorded it.
+func vcsRevision() string {
+	buildInfo, ok := debug.ReadBuildInfo()
+	if !ok {
+		return ""
+	}
+	for _, setting := range buildInfo.Settings {
+		if setting.Key == "vcs.revision" {
+			return setting.Value
+		}
+	}
+	return ""
+}
+
+// GetServerInfo creates a tool that reports the version, build and configuration of the server.
+func GetServerInfo(t translations.TranslationHelperFunc) inventory.ServerTool {
+	return NewTool(
+		ToolsetMetadataContext,
+		mcp.Tool{
+			Name:        "get_server_info",
+			Description: t("TOOL_GET_SERVER_INFO_DESCRIPTION", "Get the version, commit and build date of this GitHub MCP server, and the configuration it is running with: host, enabled toolsets, active feature flags, and whether read-only, lockdown and insiders modes are on. Use this when tools behave differently than expected."),
+			Annotations: &mcp.ToolAnnotations{
+				Title:        t("TOOL_GET_SERVER_INFO_USER_TITLE", "Get server info"),
+				ReadOnlyHint: true,
+			},
+			// Use json.RawMessage to ensure "properties" is included even when empty.
+			// OpenAI strict mode requires the properties field to be present.
+			InputSchema: json.RawMessage(`{"type":"object","properties":{}}`),
+		},
+		nil,
+		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, _ map[string]any) (*mcp.CallToolResult, any, error) {
+			source, _ := ctx.Value(serverInfoContextKey{}).(*serverInfoSource)
+			return MarshalledTextResult(buildServerInfo(ctx, deps, source)), nil, nil
+		},
+	)
+}
@@ -0,0 +1,74 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_GetServerInfo(t *testing.T) {
+	// Verify tool definition once
+	serverTool := GetServerInfo(translations.NullTranslationHelper)
+	tool := serverTool.Tool
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_server_info", tool.Name)
+	assert.True(t, tool.Annotations.ReadOnlyHint)
+
+	inv, err := NewInventory(translations.NullTranslationHelper).WithToolsets([]string{"repos", "context"}).Build()
+	require.NoError(t, err)
+	cfg := &MCPServerConfig{
+		Version:     "v1.2.3",
+		Commit:      "abc123",
+		BuildDate:   "2026-01-02",
+		Host:        "https://github.example.com",
+		GHESVersion: "3.16",
+		ReadOnly:    true,
+	}
+
+	deps := BaseDeps{
+		Flags: FeatureFlags{LockdownMode: true},
+		featureChecker: func(_ context.Context, flag string) (bool, error) {
+			return flag == FeatureFlagIssuesGranular, nil
+		},
+	}
+	handler := serverTool.Handler(deps)
+
+	t.Run("reports server configuration", func(t *testing.T) {
+		ctx := context.WithValue(ContextWithDeps(context.Background(), deps), serverInfoContextKey{}, &serverInfoSource{cfg: cfg, inv: inv})
+		request := createMCPRequest(map[string]any{})
+		result, err := handler(ctx, &request)
+		require.NoError(t, err)
+
+		var info ServerInfo
+		require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &info))
+		assert.Equal(t, "v1.2.3", info.Version)
+		assert.Equal(t, "abc123", info.Commit)
+		assert.Equal(t, "2026-01-02", info.BuildDate)
+		assert.Equal(t, "https://github.example.com", info.Host)
+		assert.Equal(t, "3.16", info.GHESVersion)
+		assert.True(t, info.ReadOnly)
+		assert.True(t, info.LockdownMode)
+		assert.False(t, info.InsidersMode)
+		assert.Equal(t, []string{"context", "repos"}, info.EnabledToolsets)
+		assert.Equal(t, []string{FeatureFlagIssuesGranular}, info.FeatureFlags)
+		assert.NotEmpty(t, info.GoVersion)
+	})
+
+	t.Run("defaults without server configuration", func(t *testing.T) {
+		request := createMCPRequest(map[string]any{})
+		result, err := handler(ContextWithDeps(context.Background(), deps), &request)
+		require.NoError(t, err)
+
+		var info ServerInfo
+		require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &info))
+		assert.Equal(t, "github.com", info.Host)
+		assert.Empty(t, info.EnabledToolsets)
+		assert.True(t, info.LockdownMode)
+	})
+}
@@ -5,11 +5,15 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"log/slog"
 	"net/http"
+	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 
+	"github.com/github/github-mcp-server/pkg/http/transport"
 	"github.com/github/github-mcp-server/pkg/lockdown"
 	"github.com/github/github-mcp-server/pkg/observability"
 	"github.com/github/github-mcp-server/pkg/observability/metrics"
@@ -324,3 +328,78 @@ func TestResolveEnabledToolsets(t *testing.T) {
 		})
 	}
 }
+
+// countingTransport answers every request with an empty JSON object and counts the requests.
+type countingTransport struct {
+	requests atomic.Int32
+}
+
+func (c *countingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	c.requests.Add(1)
+	status, body := http.StatusCreated, `{"number":1,"html_url":"https://github.com/octo/ink/issues/1"}`
+	if req.URL.Path == "/user" {
+		status, body = http.StatusOK, `{"login":"octocat"}`
+	}
+	return &http.Response{
+		StatusCode: status,
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+		Body:       io.NopCloser(strings.NewReader(body)),
+		Request:    req,
+	}, nil
+}
+
+// resetSessionState discards the server-side state of the session without an ID, which the
+// sessions over in-memory transports share, before and after the test.
+func resetSessionState(t *testing.T) {
+	reset := func() {
+		sessionWrites.delete("")
+		sessionBudgets.delete("")
+		sessionRoots.delete("")
+		sessionRootProfiles.delete("")
+		sessionResults.delete("")
+		sessionContexts.delete("")
+		toolCallHistory.delete("")
+	}
+	reset()
+	t.Cleanup(reset)
+}
+
+// connectTestServer connects a client created with clientOpts to a server created by NewMCPServer
+// with the given toolsets, whose REST requests go through github.
+func connectTestServer(t *testing.T, cfg MCPServerConfig, toolsets []string, github *countingTransport, clientOpts *mcp.ClientOptions) *mcp.ClientSession {
+	t.Helper()
+	return connectTestClient(t, cfg, toolsets, testServerDeps(github), mcp.NewClient(&mcp.Implementation{Name: "test-client"}, clientOpts))
+}
+
+// testServerDeps returns the dependencies of a test server whose REST requests go through github.
+func testServerDeps(github *countingTransport) stubDeps {
+	return stubDeps{
+		clientFn: stubClientFnFromHTTP(&http.Client{Transport: &transport.DryRunTransport{Transport: github}}),
+		obsv:     stubExporters(),
+	}
+}
+
+// connectTestClient connects client to a server created by NewMCPServer with the given toolsets
+// and dependencies.
+func connectTestClient(t *testing.T, cfg MCPServerConfig, toolsets []string, deps ToolDependencies, client *mcp.Client) *mcp.ClientSession {
+	t.Helper()
+	resetSessionState(t)
+	cfg.Version = "test"
+	cfg.Translator = translations.NullTranslationHelper
+	if cfg.Logger == nil {
+		cfg.Logger = slog.New(slog.DiscardHandler)
+	}
+	inv, err := NewInventory(cfg.Translator).WithToolsets(toolsets).Build()
+	require.NoError(t, err)
+	server, err := NewMCPServer(context.Background(), &cfg, deps, inv)
+	require.NoError(t, err)
+
+	st, ct := mcp.NewInMemoryTransports()
+	ss, err := server.Connect(context.Background(), st, nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = ss.Close() })
+	cs, err := client.Connect(context.Background(), ct, nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = cs.Close() })
+	return cs
+}
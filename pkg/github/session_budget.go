@@ -0,0 +1,117 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/github/github-mcp-server/pkg/http/transport"
+	"github.com/github/github-mcp-server/pkg/inventory"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/github/github-mcp-server/pkg/utils"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// sessionBudgets holds the GitHub API budget of each session.
+var sessionBudgets = newSessionStore[*transport.APIBudget](sessionStoreMaxSessions)
+
+// SessionBudgetMiddleware charges the GitHub API requests made by tool calls to a per-session
+// budget of maxCalls requests and maxCost estimated rate limit cost. Once a session's budget is
+// exhausted its tool calls are refused, protecting a shared token from a runaway agent loop. The
+// GitHub clients must be built with transport.APIBudgetTransport for requests to be charged.
+func SessionBudgetMiddleware(maxCalls, maxCost int) mcp.Middleware {
+	return func(next mcp.MethodHandler) mcp.MethodHandler {
+		return func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+			callReq, ok := req.(*mcp.CallToolRequest)
+			if method != "tools/call" || !ok {
+				return next(ctx, method, req)
+			}
+
+			var budget *transport.APIBudget
+			sessionBudgets.update(sessionID(callReq), func(b **transport.APIBudget) {
+				if *b == nil {
+					*b = transport.NewAPIBudget(maxCalls, maxCost)
+				}
+				budget = *b
+			})
+			if budget.Exhausted() {
+				return utils.NewToolResultError(budgetExhaustedMessage(budget)), nil
+			}
+
+			return next(transport.ContextWithAPIBudget(ctx, budget), method, req)
+		}
+	}
+}
+
+func budgetExhaustedMessage(budget *transport.APIBudget) string {
+	calls, cost := budget.Usage()
+	maxCalls, maxCost := budget.Limits()
+	var limits string
+	switch {
+	case maxCalls > 0 && maxCost > 0:
+		limits = fmt.Sprintf("%d calls or an estimated cost of %d", maxCalls, maxCost)
+	case maxCalls > 0:
+		limits = fmt.Sprintf("%d calls", maxCalls)
+	default:
+		limits = fmt.Sprintf("an estimated cost of %d", maxCost)
+	}
+	return fmt.Sprintf("the GitHub API budget of this session is exhausted: %d calls with an estimated cost of %d were made, and the limit is %s. "+
+		"Further GitHub API calls are refused to protect the shared token. Stop retrying and ask the user how to proceed; a new session starts with a fresh budget.",
+		calls, cost, limits)
+}
+
+// APIUsage is the GitHub API usage of a session.
+type APIUsage struct {
+	Calls int `json:"calls"`
+	// EstimatedCost is the estimated rate limit cost of the calls, using the actual cost of
+	// GraphQL queries where GitHub reported it.
+	EstimatedCost  int `json:"estimated_cost"`
+	GraphQLQueries int `json:"graphql_queries"`
+	GraphQLCost    int `json:"graphql_cost"`
+	// MaxCalls, MaxCost and the remaining amounts are only set when the session's budget is
+	// limited.
+	MaxCalls       int `json:"max_calls,omitempty"`
+	RemainingCalls int `json:"remaining_calls,omitempty"`
+	MaxCost        int `json:"max_cost,omitempty"`
+	RemainingCost  int `json:"remaining_cost,omitempty"`
+}
+
+// GetAPIUsage creates a tool reporting the GitHub API usage of the current session.
+func GetAPIUsage(t translations.TranslationHelperFunc) inventory.ServerTool {
+	return NewTool(
+		ToolsetMetadataContext,
+		mcp.Tool{
+			Name:        "get_api_usage",
+			Description: t("TOOL_GET_API_USAGE_DESCRIPTION", "Get the GitHub API usage of this session: the number of calls made, their estimated rate limit cost, the actual cost of GraphQL queries, and how much of the session's budget is left when one is configured. Use this to pace tasks making many calls."),
+			Annotations: &mcp.ToolAnnotations{
+				Title:        t("TOOL_GET_API_USAGE_USER_TITLE", "Get session API usage"),
+				ReadOnlyHint: true,
+			},
+			// Use json.RawMessage to ensure "properties" is included even when empty.
+			// OpenAI strict mode requires the properties field to be present.
+			InputSchema: json.RawMessage(`{"type":"object","properties":{}}`),
+		},
+		nil,
+		func(ctx context.Context, _ ToolDependencies, _ *mcp.CallToolRequest, _ map[string]any) (*mcp.CallToolResult, any, error) {
+			budget, ok := transport.APIBudgetFromContext(ctx)
+			if !ok {
+				return utils.NewToolResultError("API usage is not tracked by this server"), nil, nil
+			}
+			return MarshalledTextResult(apiUsage(budget)), nil, nil
+		},
+	)
+}
+
+func apiUsage(budget *transport.APIBudget) APIUsage {
+	usage := APIUsage{}
+	usage.Calls, usage.EstimatedCost = budget.Usage()
+	usage.GraphQLQueries, usage.GraphQLCost = budget.GraphQLUsage()
+	usage.MaxCalls, usage.MaxCost = budget.Limits()
+	if usage.MaxCalls > 0 {
+		usage.RemainingCalls = max(usage.MaxCalls-usage.Calls, 0)
+	}
+	if usage.MaxCost > 0 {
+		usage.RemainingCost = max(usage.MaxCost-usage.EstimatedCost, 0)
+	}
+	return usage
+}
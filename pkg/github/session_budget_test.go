@@ -0,0 +1,89 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/http/transport"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/github/github-mcp-server/pkg/utils"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_SessionBudgetMiddleware(t *testing.T) {
+	sessionBudgets.delete("")
+	t.Cleanup(func() { sessionBudgets.delete("") })
+
+	// The handler stands in for a tool making one GitHub API read request.
+	handler := SessionBudgetMiddleware(2, 0)(func(ctx context.Context, _ string, _ mcp.Request) (mcp.Result, error) {
+		budget, ok := transport.APIBudgetFromContext(ctx)
+		require.True(t, ok)
+		if err := budget.Spend(1); err != nil {
+			return utils.NewToolResultErrorFromErr("failed to get issue", err), nil
+		}
+		return utils.NewToolResultText("ok"), nil
+	})
+	call := func() *mcp.CallToolResult {
+		result, err := handler(context.Background(), "tools/call", &mcp.CallToolRequest{
+			Params: &mcp.CallToolParamsRaw{Name: "issue_read"},
+		})
+		require.NoError(t, err)
+		return result.(*mcp.CallToolResult)
+	}
+
+	assert.False(t, call().IsError)
+	assert.False(t, call().IsError)
+
+	refused := call()
+	require.True(t, refused.IsError)
+	text := getTextResult(t, refused).Text
+	assert.Contains(t, text, "the GitHub API budget of this session is exhausted")
+	assert.Contains(t, text, "2 calls with an estimated cost of 2 were made, and the limit is 2 calls")
+}
+
+func Test_GetAPIUsage(t *testing.T) {
+	serverTool := GetAPIUsage(translations.NullTranslationHelper)
+	tool := serverTool.Tool
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_api_usage", tool.Name)
+	assert.True(t, tool.Annotations.ReadOnlyHint)
+
+	call := func(ctx context.Context) *mcp.CallToolResult {
+		deps := BaseDeps{}
+		request := createMCPRequest(map[string]any{})
+		result, err := serverTool.Handler(deps)(ContextWithDeps(ctx, deps), &request)
+		require.NoError(t, err)
+		return result
+	}
+
+	t.Run("reports usage against the budget", func(t *testing.T) {
+		budget := transport.NewAPIBudget(10, 0)
+		require.NoError(t, budget.Spend(1))
+		require.NoError(t, budget.Spend(1))
+		budget.RecordGraphQLCost(4)
+
+		result := call(transport.ContextWithAPIBudget(context.Background(), budget))
+		require.False(t, result.IsError)
+		var usage APIUsage
+		require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &usage))
+		assert.Equal(t, APIUsage{
+			Calls:          2,
+			EstimatedCost:  5,
+			GraphQLQueries: 1,
+			GraphQLCost:    4,
+			MaxCalls:       10,
+			RemainingCalls: 8,
+		}, usage)
+	})
+
+	t.Run("untracked", func(t *testing.T) {
+		result := call(context.Background())
+		require.True(t, result.IsError)
+		assert.Contains(t, getErrorResult(t, result).Text, "not tracked")
+	})
+}
@@ -0,0 +1,187 @@
+package github
+
+import (
+	"context"
+	"strings"
+
+	"github.com/github/github-mcp-server/pkg/inventory"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/github/github-mcp-server/pkg/utils"
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// setContextToolName is the name of the set_context tool, whose arguments are never defaulted.
+const setContextToolName = "set_context"
+
+// SessionContext holds the default owner, repository and ref of an MCP session. Tools that take
+// these parameters use the defaults when the caller omits them. Path is only inferred from roots
+// of a directory of the repository.
+type SessionContext struct {
+	Owner string `json:"owner,omitempty"`
+	Repo  string `json:"repo,omitempty"`
+	Ref   string `json:"ref,omitempty"`
+	Path  string `json:"path,omitempty"`
+}
+
+// pathScopedTools are the read tools whose path argument scopes their results to a directory,
+// and so defaults to the directory of the session's roots.
+var pathScopedTools = map[string]bool{
+	"get_file_contents": true,
+	"list_commits":      true,
+}
+
+var sessionContexts = newSessionStore[SessionContext](sessionStoreMaxSessions)
+
+// setSessionContext replaces the context of a session. An empty context clears it.
+func setSessionContext(sessionID string, sc SessionContext) {
+	if sc == (SessionContext{}) {
+		sessionContexts.delete(sessionID)
+		return
+	}
+	sessionContexts.update(sessionID, func(v *SessionContext) { *v = sc })
+}
+
+// activeSessionContext returns the defaults in effect for a session, and where they come from:
+// "set_context", "roots", or "" when the session has none.
+func activeSessionContext(sessionID string) (SessionContext, string) {
+	if sc, ok := sessionContexts.load(sessionID); ok {
+		return sc, setContextToolName
+	}
+	if rc, ok := sessionRoots.load(sessionID); ok && rc.sessionContext() != (SessionContext{}) {
+		return rc.sessionContext(), "roots"
+	}
+	return SessionContext{}, ""
+}
+
+// applySessionContext fills in owner, repo, ref and branch arguments the caller omitted from the
+// session context, for tools whose input schema declares them. Sessions without a context set by
+// set_context default to the repository inferred from the client's roots. The repo default only
+// applies to the session's owner, and the ref default only to the session's repository. The
+// branch argument of create_branch names the branch to create, so it is never defaulted.
+//
+// Roots of a directory, as in monorepos, also scope the path argument of pathScopedTools and the
+// search_code queries restricted to the session's repository to that directory, unless the
+// caller gave a path.
+func applySessionContext(req *mcp.CallToolRequest, tool *mcp.Tool, args map[string]any) {
+	if tool.Name == setContextToolName {
+		return
+	}
+	schema, ok := tool.InputSchema.(*jsonschema.Schema)
+	if !ok {
+		return
+	}
+	sc, _ := activeSessionContext(sessionID(req))
+	if sc == (SessionContext{}) {
+		return
+	}
+
+	fill := func(name, value string) {
+		if _, declared := schema.Properties[name]; !declared || value == "" {
+			return
+		}
+		if v, ok := args[name].(string); !ok || v == "" {
+			args[name] = value
+		}
+	}
+	fill("owner", sc.Owner)
+	if args["owner"] == sc.Owner {
+		fill("repo", sc.Repo)
+		if args["repo"] == sc.Repo {
+			fill("ref", sc.Ref)
+			if tool.Name != "create_branch" {
+				fill("branch", sc.Ref)
+			}
+			if pathScopedTools[tool.Name] {
+				fill("path", sc.Path)
+			}
+		}
+	}
+	if tool.Name == "search_code" {
+		if query, ok := args["query"].(string); ok {
+			args["query"] = scopeCodeSearchQuery(query, sc)
+		}
+	}
+}
+
+// scopeCodeSearchQuery adds a path qualifier for the session's directory to code search queries
+// restricted to the session's repository that have none.
+func scopeCodeSearchQuery(query string, sc SessionContext) string {
+	if sc.Path == "" || sc.Owner == "" || sc.Repo == "" {
+		return query
+	}
+	inRepo := false
+	for _, term := range strings.Fields(query) {
+		switch {
+		case strings.HasPrefix(term, "path:"):
+			return query
+		case strings.EqualFold(term, "repo:"+sc.Owner+"/"+sc.Repo):
+			inRepo = true
+		}
+	}
+	if !inRepo {
+		return query
+	}
+	return query + " path:" + sc.Path
+}
+
+// SetContext creates a tool to set the default owner, repository and ref for the session.
+func SetContext(t translations.TranslationHelperFunc) inventory.ServerTool {
+	return NewTool(
+		ToolsetMetadataContext,
+		mcp.Tool{
+			Name: setContextToolName,
+			Description: t("TOOL_SET_CONTEXT_DESCRIPTION", `Set the default owner, repository and ref for this session. Tools called afterwards use them when 'owner', 'repo', 'ref' or 'branch' are omitted.
+Call this once you know which repository the user is working in. Call it without arguments to clear the defaults.`),
+			Annotations: &mcp.ToolAnnotations{
+				Title: t("TOOL_SET_CONTEXT_USER_TITLE", "Set session context"),
+				// Only the server-side session defaults change, nothing on GitHub.
+				ReadOnlyHint:   true,
+				IdempotentHint: true,
+			},
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"owner": {
+						Type:        "string",
+						Description: "Default repository owner",
+					},
+					"repo": {
+						Type:        "string",
+						Description: "Default repository name. Requires owner.",
+					},
+					"ref": {
+						Type:        "string",
+						Description: "Default branch, tag or commit SHA. Requires owner and repo.",
+					},
+				},
+			},
+		},
+		nil,
+		func(_ context.Context, _ ToolDependencies, req *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+			owner, err := OptionalParam[string](args, "owner")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			repo, err := OptionalParam[string](args, "repo")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			ref, err := OptionalParam[string](args, "ref")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			if repo != "" && owner == "" {
+				return utils.NewToolResultError("repo requires owner"), nil, nil
+			}
+			if ref != "" && repo == "" {
+				return utils.NewToolResultError("ref requires owner and repo"), nil, nil
+			}
+
+			sc := SessionContext{Owner: owner, Repo: repo, Ref: ref}
+			setSessionContext(sessionID(req), sc)
+
+			return MarshalledTextResult(sc), nil, nil
+		},
+	)
+}
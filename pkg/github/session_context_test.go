@@ -0,0 +1,202 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v82/github"
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_SetContext(t *testing.T) {
+	// Verify tool definition once
+	serverTool := SetContext(translations.NullTranslationHelper)
+	tool := serverTool.Tool
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "set_context", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.True(t, tool.Annotations.ReadOnlyHint)
+	schema := tool.InputSchema.(*jsonschema.Schema)
+	assert.Contains(t, schema.Properties, "owner")
+	assert.Contains(t, schema.Properties, "repo")
+	assert.Contains(t, schema.Properties, "ref")
+	assert.Empty(t, schema.Required)
+
+	tests := []struct {
+		name           string
+		requestArgs    map[string]any
+		expectError    bool
+		expectedErrMsg string
+		expected       SessionContext
+	}{
+		{
+			name:        "sets owner, repo and ref",
+			requestArgs: map[string]any{"owner": "octo", "repo": "hello", "ref": "main"},
+			expected:    SessionContext{Owner: "octo", Repo: "hello", Ref: "main"},
+		},
+		{
+			name:        "clears the context",
+			requestArgs: map[string]any{},
+			expected:    SessionContext{},
+		},
+		{
+			name:           "repo without owner",
+			requestArgs:    map[string]any{"repo": "hello"},
+			expectError:    true,
+			expectedErrMsg: "repo requires owner",
+		},
+		{
+			name:           "ref without repo",
+			requestArgs:    map[string]any{"owner": "octo", "ref": "main"},
+			expectError:    true,
+			expectedErrMsg: "ref requires owner and repo",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Cleanup(func() { sessionContexts.delete("") })
+			deps := BaseDeps{}
+			handler := serverTool.Handler(deps)
+
+			request := createMCPRequest(tc.requestArgs)
+			result, err := handler(ContextWithDeps(context.Background(), deps), &request)
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			var sc SessionContext
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &sc))
+			assert.Equal(t, tc.expected, sc)
+			stored, _ := sessionContexts.load("")
+			assert.Equal(t, tc.expected, stored)
+		})
+	}
+}
+
+func Test_SessionContextDefaultsToolArguments(t *testing.T) {
+	t.Cleanup(func() { sessionContexts.delete("") })
+	setSessionContext("", SessionContext{Owner: "octo", Repo: "hello", Ref: "main"})
+
+	serverTool := ListBranches(translations.NullTranslationHelper)
+	mockBranches := []*github.Branch{{Name: github.Ptr("main")}}
+
+	tests := []struct {
+		name        string
+		requestArgs map[string]any
+		handler     string
+	}{
+		{
+			name:        "owner and repo omitted",
+			requestArgs: nil,
+			handler:     "GET /repos/octo/hello/branches",
+		},
+		{
+			name:        "explicit owner is not mixed with the session repo",
+			requestArgs: map[string]any{"owner": "other", "repo": "world"},
+			handler:     "GET /repos/other/world/branches",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			deps := BaseDeps{Client: github.NewClient(MockHTTPClientWithHandlers(map[string]http.HandlerFunc{
+				tc.handler: mockResponse(t, http.StatusOK, mockBranches),
+			}))}
+			handler := serverTool.Handler(deps)
+
+			request := createMCPRequest(tc.requestArgs)
+			result, err := handler(ContextWithDeps(context.Background(), deps), &request)
+			require.NoError(t, err)
+			require.False(t, result.IsError, getTextResult(t, result).Text)
+		})
+	}
+}
+
+func Test_ApplySessionContext(t *testing.T) {
+	t.Cleanup(func() { sessionContexts.delete("") })
+	setSessionContext("", SessionContext{Owner: "octo", Repo: "hello", Ref: "main"})
+
+	tool := GetPermalink(translations.NullTranslationHelper).Tool
+
+	args := map[string]any{"path": "README.md"}
+	applySessionContext(nil, &tool, args)
+	assert.Equal(t, map[string]any{"owner": "octo", "repo": "hello", "ref": "main", "path": "README.md"}, args)
+
+	// The session ref belongs to the session repository only.
+	args = map[string]any{"repo": "other", "path": "README.md"}
+	applySessionContext(nil, &tool, args)
+	assert.Equal(t, map[string]any{"owner": "octo", "repo": "other", "path": "README.md"}, args)
+
+	// Branch arguments default to the session ref, except for the branch create_branch creates.
+	tool = CreateOrUpdateFile(translations.NullTranslationHelper).Tool
+	args = map[string]any{"path": "README.md"}
+	applySessionContext(nil, &tool, args)
+	assert.Equal(t, map[string]any{"owner": "octo", "repo": "hello", "branch": "main", "path": "README.md"}, args)
+
+	tool = CreateBranch(translations.NullTranslationHelper).Tool
+	args = map[string]any{"branch": "feature"}
+	applySessionContext(nil, &tool, args)
+	assert.Equal(t, map[string]any{"owner": "octo", "repo": "hello", "branch": "feature"}, args)
+
+	// Tools without these parameters are left alone.
+	tool = GetMe(translations.NullTranslationHelper).Tool
+	args = map[string]any{}
+	applySessionContext(nil, &tool, args)
+	assert.Empty(t, args)
+}
+
+func Test_ApplySessionContextFromDirectoryRoots(t *testing.T) {
+	sessionRoots.update("", func(rc **RootContext) {
+		*rc = &RootContext{Supported: true, Owner: "octo", Repo: "monorepo", Ref: "main", Path: "services/payments"}
+	})
+	t.Cleanup(func() { sessionRoots.delete("") })
+
+	// Read tools scoped by their path default to the directory of the roots.
+	for _, tool := range []mcp.Tool{GetFileContents(translations.NullTranslationHelper).Tool, ListCommits(translations.NullTranslationHelper).Tool} {
+		args := map[string]any{}
+		applySessionContext(nil, &tool, args)
+		assert.Equal(t, "services/payments", args["path"], tool.Name)
+
+		args = map[string]any{"path": "go.mod"}
+		applySessionContext(nil, &tool, args)
+		assert.Equal(t, "go.mod", args["path"], tool.Name)
+
+		args = map[string]any{"owner": "octo", "repo": "other"}
+		applySessionContext(nil, &tool, args)
+		assert.NotContains(t, args, "path", tool.Name)
+	}
+
+	// Paths of write tools name the file to write, so they are never defaulted.
+	tool := CreateOrUpdateFile(translations.NullTranslationHelper).Tool
+	args := map[string]any{}
+	applySessionContext(nil, &tool, args)
+	assert.NotContains(t, args, "path")
+
+	// Code searches in the repository of the roots are scoped to their directory.
+	tool = SearchCode(translations.NullTranslationHelper).Tool
+	for query, expected := range map[string]string{
+		"Charge repo:octo/monorepo":             "Charge repo:octo/monorepo path:services/payments",
+		"Charge repo:Octo/Monorepo":             "Charge repo:Octo/Monorepo path:services/payments",
+		"Charge repo:octo/monorepo path:libs":   "Charge repo:octo/monorepo path:libs",
+		"Charge repo:octo/other":                "Charge repo:octo/other",
+		"Charge org:octo":                       "Charge org:octo",
+		"Charge repo:octo/monorepo language:go": "Charge repo:octo/monorepo language:go path:services/payments",
+	} {
+		args := map[string]any{"query": query}
+		applySessionContext(nil, &tool, args)
+		assert.Equal(t, expected, args["query"], query)
+	}
+}
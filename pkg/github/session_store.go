@@ -0,0 +1,107 @@
+package github
+
+import (
+	"container/list"
+	"sync"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+const (
+	// sessionStateTTL is how long the state of an idle session is kept before it is discarded.
+	sessionStateTTL = 24 * time.Hour
+	// sessionStoreMaxSessions bounds the number of sessions whose state is kept. In stateless HTTP
+	// mode session IDs come from the client, so every request may start a new session.
+	sessionStoreMaxSessions = 10000
+)
+
+type sessionEntry[T any] struct {
+	id       string
+	value    T
+	lastUsed time.Time
+}
+
+// sessionStore keeps server-side state for each session served by this process, keyed by session
+// ID. Sessions without an ID, such as the stdio session, share the empty key. The state of sessions
+// idle for longer than sessionStateTTL is discarded, as is that of the least recently used
+// sessions beyond maxSessions.
+type sessionStore[T any] struct {
+	mu          sync.Mutex
+	maxSessions int
+	entries     map[string]*list.Element
+	// lru orders the entries from the most to the least recently used
+	lru *list.List
+}
+
+func newSessionStore[T any](maxSessions int) *sessionStore[T] {
+	return &sessionStore[T]{maxSessions: maxSessions, entries: map[string]*list.Element{}, lru: list.New()}
+}
+
+// load returns the state of a session, and whether it has any.
+func (s *sessionStore[T]) load(sessionID string) (T, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.prune(time.Now())
+	elem, ok := s.entries[sessionID]
+	if !ok {
+		var zero T
+		return zero, false
+	}
+	entry := elem.Value.(*sessionEntry[T])
+	entry.lastUsed = time.Now()
+	s.lru.MoveToFront(elem)
+	return entry.value, true
+}
+
+// update calls fn with the state of a session, starting from the zero value if it has none. fn runs
+// with the store locked, so it must not call back into the store.
+func (s *sessionStore[T]) update(sessionID string, fn func(*T)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := time.Now()
+	s.prune(now)
+	elem, ok := s.entries[sessionID]
+	if ok {
+		s.lru.MoveToFront(elem)
+	} else {
+		elem = s.lru.PushFront(&sessionEntry[T]{id: sessionID})
+		s.entries[sessionID] = elem
+		for s.lru.Len() > s.maxSessions {
+			s.remove(s.lru.Back())
+		}
+	}
+	entry := elem.Value.(*sessionEntry[T])
+	fn(&entry.value)
+	entry.lastUsed = now
+}
+
+// delete discards the state of a session.
+func (s *sessionStore[T]) delete(sessionID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if elem, ok := s.entries[sessionID]; ok {
+		s.remove(elem)
+	}
+}
+
+// prune discards the state of the sessions idle for longer than sessionStateTTL, which are at the
+// back of the list.
+func (s *sessionStore[T]) prune(now time.Time) {
+	for elem := s.lru.Back(); elem != nil && now.Sub(elem.Value.(*sessionEntry[T]).lastUsed) > sessionStateTTL; elem = s.lru.Back() {
+		s.remove(elem)
+	}
+}
+
+func (s *sessionStore[T]) remove(elem *list.Element) {
+	s.lru.Remove(elem)
+	delete(s.entries, elem.Value.(*sessionEntry[T]).id)
+}
+
+// sessionID returns the ID of the session a request belongs to.
+func sessionID(req *mcp.CallToolRequest) string {
+	if req == nil || req.Session == nil {
+		return ""
+	}
+	return req.Session.ID()
+}
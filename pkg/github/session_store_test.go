@@ -0,0 +1,35 @@
+package github
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_SessionStoreEvictsLeastRecentlyUsed(t *testing.T) {
+	store := newSessionStore[int](2)
+	store.update("a", func(v *int) { *v = 1 })
+	store.update("b", func(v *int) { *v = 2 })
+	_, _ = store.load("a")
+	store.update("c", func(v *int) { *v = 3 })
+
+	_, ok := store.load("b")
+	assert.False(t, ok)
+	for id, want := range map[string]int{"a": 1, "c": 3} {
+		got, ok := store.load(id)
+		assert.True(t, ok, id)
+		assert.Equal(t, want, got, id)
+	}
+}
+
+func Test_SessionStoreDiscardsIdleSessions(t *testing.T) {
+	store := newSessionStore[int](sessionStoreMaxSessions)
+	store.update("idle", func(v *int) { *v = 1 })
+	store.entries["idle"].Value.(*sessionEntry[int]).lastUsed = time.Now().Add(-sessionStateTTL - time.Minute)
+	store.update("active", func(v *int) { *v = 2 })
+
+	_, ok := store.load("idle")
+	assert.False(t, ok)
+	assert.Equal(t, 1, store.lru.Len())
+}
@@ -0,0 +1,100 @@
+package github
+
+import (
+	"context"
+	"fmt"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/inventory"
+	"github.com/github/github-mcp-server/pkg/scopes"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/github/github-mcp-server/pkg/utils"
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/shurcooL/githubv4"
+)
+
+// SocialPreview is the image shown when a link to a repository is shared.
+type SocialPreview struct {
+	Repository string `json:"repository"`
+	// Custom reports whether the repository has its own image, rather than the one GitHub
+	// generates from its name, description and owner avatar
+	Custom   bool   `json:"custom"`
+	ImageURL string `json:"image_url"`
+	// SettingsURL is the page where the image is uploaded, which the API cannot do
+	SettingsURL string `json:"settings_url"`
+}
+
+// socialPreviewQuery reads the social preview image of a repository.
+type socialPreviewQuery struct {
+	Repository struct {
+		NameWithOwner            githubv4.String
+		URL                      githubv4.URI
+		OpenGraphImageURL        githubv4.URI `graphql:"openGraphImageUrl"`
+		UsesCustomOpenGraphImage githubv4.Boolean
+	} `graphql:"repository(owner: $owner, name: $repo)"`
+}
+
+// GetRepositorySocialPreview creates a tool to read whether a repository has a social preview
+// image configured.
+func GetRepositorySocialPreview(t translations.TranslationHelperFunc) inventory.ServerTool {
+	return NewTool(
+		ToolsetMetadataRepos,
+		mcp.Tool{
+			Name: "get_repository_social_preview",
+			Description: t("TOOL_GET_REPOSITORY_SOCIAL_PREVIEW_DESCRIPTION", `Get the social preview image of a repository, shown when links to it are shared, and whether it is a custom image or the one GitHub generates.
+The image can only be uploaded in the repository settings, at the returned settings_url.`),
+			Annotations: &mcp.ToolAnnotations{
+				Title:        t("TOOL_GET_REPOSITORY_SOCIAL_PREVIEW_USER_TITLE", "Get repository social preview"),
+				ReadOnlyHint: true,
+			},
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"owner": {
+						Type:        "string",
+						Description: "Repository owner",
+					},
+					"repo": {
+						Type:        "string",
+						Description: "Repository name",
+					},
+				},
+				Required: []string{"owner", "repo"},
+			},
+		},
+		[]scopes.Scope{scopes.Repo},
+		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+			owner, err := RequiredParam[string](args, "owner")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			repo, err := RequiredParam[string](args, "repo")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+
+			gqlClient, err := deps.GetGQLClient(ctx)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to get GitHub GQL client: %w", err)
+			}
+
+			var query socialPreviewQuery
+			vars := map[string]any{
+				"owner": githubv4.String(owner),
+				"repo":  githubv4.String(repo),
+			}
+			if err := gqlClient.Query(ctx, &query, vars); err != nil {
+				return ghErrors.NewGitHubGraphQLErrorResponse(ctx, "failed to get repository social preview", err), nil, nil
+			}
+
+			repository := query.Repository
+			return MarshalledTextResult(SocialPreview{
+				Repository:  string(repository.NameWithOwner),
+				Custom:      bool(repository.UsesCustomOpenGraphImage),
+				ImageURL:    repository.OpenGraphImageURL.String(),
+				SettingsURL: repository.URL.String() + "/settings",
+			}), nil, nil
+		},
+	)
+}
@@ -0,0 +1,93 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/githubv4mock"
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/shurcooL/githubv4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_GetRepositorySocialPreview(t *testing.T) {
+	serverTool := GetRepositorySocialPreview(translations.NullTranslationHelper)
+	tool := serverTool.Tool
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_repository_social_preview", tool.Name)
+	assert.True(t, tool.Annotations.ReadOnlyHint)
+	schema := tool.InputSchema.(*jsonschema.Schema)
+	assert.ElementsMatch(t, schema.Required, []string{"owner", "repo"})
+
+	vars := map[string]any{"owner": githubv4.String("octo"), "repo": githubv4.String("app")}
+	tests := []struct {
+		name           string
+		response       githubv4mock.GQLResponse
+		expected       SocialPreview
+		expectedErrMsg string
+	}{
+		{
+			name: "custom image",
+			response: githubv4mock.DataResponse(map[string]any{
+				"repository": map[string]any{
+					"nameWithOwner":            "octo/app",
+					"url":                      "https://github.com/octo/app",
+					"openGraphImageUrl":        "https://repository-images.githubusercontent.com/1/abc",
+					"usesCustomOpenGraphImage": true,
+				},
+			}),
+			expected: SocialPreview{
+				Repository:  "octo/app",
+				Custom:      true,
+				ImageURL:    "https://repository-images.githubusercontent.com/1/abc",
+				SettingsURL: "https://github.com/octo/app/settings",
+			},
+		},
+		{
+			name: "generated image",
+			response: githubv4mock.DataResponse(map[string]any{
+				"repository": map[string]any{
+					"nameWithOwner":            "octo/app",
+					"url":                      "https://github.com/octo/app",
+					"openGraphImageUrl":        "https://opengraph.githubassets.com/1/octo/app",
+					"usesCustomOpenGraphImage": false,
+				},
+			}),
+			expected: SocialPreview{
+				Repository:  "octo/app",
+				ImageURL:    "https://opengraph.githubassets.com/1/octo/app",
+				SettingsURL: "https://github.com/octo/app/settings",
+			},
+		},
+		{
+			name:           "repository not found",
+			response:       githubv4mock.ErrorResponse("Could not resolve to a Repository with the name 'octo/app'."),
+			expectedErrMsg: "failed to get repository social preview",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			gqlClient := githubv4.NewClient(githubv4mock.NewMockedHTTPClient(
+				githubv4mock.NewQueryMatcher(socialPreviewQuery{}, vars, tc.response),
+			))
+			deps := BaseDeps{GQLClient: gqlClient}
+			request := createMCPRequest(map[string]any{"owner": "octo", "repo": "app"})
+			result, err := serverTool.Handler(deps)(ContextWithDeps(context.Background(), deps), &request)
+			require.NoError(t, err)
+			if tc.expectedErrMsg != "" {
+				assert.Contains(t, getErrorResult(t, result).Text, tc.expectedErrMsg)
+				return
+			}
+			require.False(t, result.IsError, getTextResult(t, result).Text)
+			var preview SocialPreview
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &preview))
+			assert.Equal(t, tc.expected, preview)
+		})
+	}
+}
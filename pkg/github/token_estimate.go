@@ -0,0 +1,77 @@
+package github
+
+import (
+	"context"
+	"unicode/utf8"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// TokenEstimateMetaKey is the _meta key under which the estimated token count of a tool result is
+// attached, when token estimation is enabled.
+const TokenEstimateMetaKey = "github.com/token-estimate"
+
+// TokenEstimate is the estimated number of tokens the content of a tool result takes up in a
+// model's context.
+type TokenEstimate struct {
+	Tokens int `json:"tokens"`
+	// Method names how the estimate was made, so consumers can tell it from an exact count
+	Method string `json:"method"`
+}
+
+// tokenEstimateMethod describes the heuristic of estimateTokens.
+const tokenEstimateMethod = "heuristic"
+
+// estimateTokens estimates the number of tokens of text without a tokenizer. BPE tokenizers such
+// as cl100k and o200k average about four characters per token for English text, code and JSON,
+// while non-ASCII characters usually take a token or more each.
+func estimateTokens(text string) int {
+	ascii, other := 0, 0
+	for i := 0; i < len(text); {
+		if text[i] < utf8.RuneSelf {
+			ascii++
+			i++
+			continue
+		}
+		_, size := utf8.DecodeRuneInString(text[i:])
+		other++
+		i += size
+	}
+	return (ascii+3)/4 + other
+}
+
+// estimateResultTokens estimates the tokens of the text content of a tool result.
+func estimateResultTokens(result *mcp.CallToolResult) int {
+	tokens := 0
+	for _, content := range result.Content {
+		switch c := content.(type) {
+		case *mcp.TextContent:
+			tokens += estimateTokens(c.Text)
+		case *mcp.EmbeddedResource:
+			if c.Resource != nil {
+				tokens += estimateTokens(c.Resource.Text)
+			}
+		}
+	}
+	return tokens
+}
+
+// TokenEstimateMiddleware attaches a TokenEstimate to tool results, helping hosts and agents
+// budget their context before adding results to prompts.
+func TokenEstimateMiddleware(next mcp.MethodHandler) mcp.MethodHandler {
+	return func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+		result, err := next(ctx, method, req)
+		toolResult, ok := result.(*mcp.CallToolResult)
+		if method != "tools/call" || !ok || toolResult == nil {
+			return result, err
+		}
+		if toolResult.Meta == nil {
+			toolResult.Meta = mcp.Meta{}
+		}
+		toolResult.Meta[TokenEstimateMetaKey] = TokenEstimate{
+			Tokens: estimateResultTokens(toolResult),
+			Method: tokenEstimateMethod,
+		}
+		return toolResult, err
+	}
+}
@@ -0,0 +1,64 @@
+package github
+
+import (
+	"context"
+	"testing"
+
+	"github.com/github/github-mcp-server/pkg/utils"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_estimateTokens(t *testing.T) {
+	assert.Equal(t, 0, estimateTokens(""))
+	assert.Equal(t, 1, estimateTokens("abc"))
+	assert.Equal(t, 5, estimateTokens(`{"number":42,"x":1}`))
+	// Non-ASCII characters count a token each
+	assert.Equal(t, 1+2, estimateTokens("ok 漢字"))
+}
+
+func Test_TokenEstimateMiddleware(t *testing.T) {
+	handler := TokenEstimateMiddleware(func(_ context.Context, method string, _ mcp.Request) (mcp.Result, error) {
+		if method == "tools/list" {
+			return &mcp.ListToolsResult{}, nil
+		}
+		return &mcp.CallToolResult{Content: []mcp.Content{
+			&mcp.TextContent{Text: "12345678"},
+			&mcp.EmbeddedResource{Resource: &mcp.ResourceContents{URI: "repo://octo/hello/contents/README.md", Text: "1234"}},
+		}}, nil
+	})
+
+	result, err := handler(context.Background(), "tools/call", &mcp.CallToolRequest{Params: &mcp.CallToolParamsRaw{Name: "get_file_contents"}})
+	require.NoError(t, err)
+	assert.Equal(t, TokenEstimate{Tokens: 3, Method: "heuristic"}, result.(*mcp.CallToolResult).Meta[TokenEstimateMetaKey])
+
+	_, err = handler(context.Background(), "tools/list", &mcp.ListToolsRequest{})
+	require.NoError(t, err)
+
+	// Error results are estimated too, as they are added to the context all the same
+	errorHandler := TokenEstimateMiddleware(func(context.Context, string, mcp.Request) (mcp.Result, error) {
+		return utils.NewToolResultError("not found"), nil
+	})
+	result, err = errorHandler(context.Background(), "tools/call", &mcp.CallToolRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, 3, result.(*mcp.CallToolResult).Meta[TokenEstimateMetaKey].(TokenEstimate).Tokens)
+}
+
+func Test_NewMCPServerEstimatesReturnedResults(t *testing.T) {
+	github := &countingTransport{}
+	cs := connectTestServer(t, MCPServerConfig{EstimateTokens: true, WriteQuota: WriteQuotaConfig{PerHour: 1}}, []string{"issues"}, github, nil)
+
+	for range 2 {
+		result, err := cs.CallTool(context.Background(), &mcp.CallToolParams{
+			Name:      "issue_write",
+			Arguments: map[string]any{"method": "create", "owner": "octo", "repo": "ink", "title": "Flaky test"},
+		})
+		require.NoError(t, err)
+		// The second call is refused by the write quota, whose refusal is estimated too
+		require.Contains(t, result.Meta, TokenEstimateMetaKey)
+		estimate := result.Meta[TokenEstimateMetaKey].(map[string]any)
+		assert.Equal(t, float64(estimateResultTokens(result)), estimate["tokens"])
+	}
+	assert.Equal(t, int32(1), github.requests.Load())
+}
@@ -0,0 +1,33 @@
+package github
+
+import (
+	"context"
+
+	"github.com/github/github-mcp-server/pkg/inventory"
+	"github.com/github/github-mcp-server/pkg/utils"
+)
+
+// appInstallationTools are the tools whose endpoints only accept GitHub App installation tokens.
+var appInstallationTools = map[string]bool{
+	"create_check_run": true,
+	"update_check_run": true,
+	// Only the app providing a custom deployment protection rule can review it
+	"review_deployment_protection_rule": true,
+}
+
+// CreateTokenTypeFilter creates an inventory.ToolFilter that hides tools the type of the token
+// cannot use, such as the tools creating check runs, which only GitHub App installations can
+// call. Nothing is hidden when the token type is unknown.
+//
+// Example usage:
+//
+//	filter := github.CreateTokenTypeFilter(utils.TokenTypeOf(token))
+//	inventory := github.NewInventory(t).WithFilter(filter).Build()
+func CreateTokenTypeFilter(tokenType utils.TokenType) inventory.ToolFilter {
+	return func(_ context.Context, tool *inventory.ServerTool) (bool, error) {
+		if tokenType == utils.TokenTypeUnknown || tokenType == utils.TokenTypeServerToServerGitHubAppToken {
+			return true, nil
+		}
+		return !appInstallationTools[tool.Tool.Name], nil
+	}
+}
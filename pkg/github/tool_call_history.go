@@ -0,0 +1,215 @@
+package github
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"slices"
+	"strings"
+	"time"
+
+	ghcontext "github.com/github/github-mcp-server/pkg/context"
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/inventory"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/github/github-mcp-server/pkg/utils"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+const (
+	// getLastToolErrorToolName is the name of the get_last_tool_error tool, whose own calls are not recorded.
+	getLastToolErrorToolName = "get_last_tool_error"
+	// toolCallHistorySize is the number of recent tool calls kept per session.
+	toolCallHistorySize = 20
+	// toolCallRecordMaxBytes caps the arguments and result text kept per call.
+	toolCallRecordMaxBytes = 4096
+	// toolCallHistorySessions bounds the number of sessions whose tool calls are kept.
+	toolCallHistorySessions = 1000
+)
+
+// ToolCallError describes a GitHub API error that occurred during a tool call.
+type ToolCallError struct {
+	Kind       string `json:"kind"`
+	Message    string `json:"message"`
+	Detail     string `json:"detail,omitempty"`
+	StatusCode int    `json:"status_code,omitempty"`
+	RequestID  string `json:"request_id,omitempty"`
+	URL        string `json:"url,omitempty"`
+}
+
+// ToolCallRecord is an entry of the tool call history of a session.
+type ToolCallRecord struct {
+	Tool         string          `json:"tool"`
+	Arguments    string          `json:"arguments,omitempty"`
+	StartedAt    time.Time       `json:"started_at"`
+	DurationMs   int64           `json:"duration_ms"`
+	IsError      bool            `json:"is_error"`
+	Result       string          `json:"result,omitempty"`
+	GitHubErrors []ToolCallError `json:"github_errors,omitempty"`
+}
+
+// toolCallHistory keeps a ring buffer of the recent tool calls of each session, keyed by
+// toolCallHistoryKey.
+var toolCallHistory = newSessionStore[[]ToolCallRecord](toolCallHistorySessions)
+
+// toolCallHistoryKey returns the key of the tool call history of the session a request belongs to.
+// Over HTTP the session ID is sent by the client, so the history is also keyed by the token of the
+// request: presenting the session ID of another token does not reveal its tool calls.
+func toolCallHistoryKey(ctx context.Context, req *mcp.CallToolRequest) string {
+	id := sessionID(req)
+	if tokenInfo, ok := ghcontext.GetTokenInfo(ctx); ok && tokenInfo != nil && tokenInfo.Token != "" {
+		sum := sha256.Sum256([]byte(tokenInfo.Token))
+		id += "/" + hex.EncodeToString(sum[:])
+	}
+	return id
+}
+
+func recordToolCall(key string, record ToolCallRecord) {
+	toolCallHistory.update(key, func(records *[]ToolCallRecord) {
+		*records = append(*records, record)
+		if len(*records) > toolCallHistorySize {
+			*records = slices.Clone((*records)[len(*records)-toolCallHistorySize:])
+		}
+	})
+}
+
+// lastToolError returns the most recent failed tool call of a session.
+func lastToolError(key string) (ToolCallRecord, bool) {
+	var last ToolCallRecord
+	var found bool
+	toolCallHistory.update(key, func(records *[]ToolCallRecord) {
+		for i := len(*records) - 1; i >= 0; i-- {
+			if (*records)[i].IsError {
+				last, found = (*records)[i], true
+				return
+			}
+		}
+	})
+	return last, found
+}
+
+// RecordToolCallsMiddleware records every tool call and its outcome in the session's tool call
+// history, including the GitHub API errors the handler reported. It must run outside
+// addGitHubAPIErrorToContext so that the errors collected by the handler can be read back.
+func RecordToolCallsMiddleware(next mcp.MethodHandler) mcp.MethodHandler {
+	return func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+		callReq, ok := req.(*mcp.CallToolRequest)
+		if method != "tools/call" || !ok || callReq.Params == nil || callReq.Params.Name == getLastToolErrorToolName {
+			return next(ctx, method, req)
+		}
+
+		// Inner middleware reuses the error collection when the context already has one.
+		ctx = ghErrors.ContextWithGitHubErrors(ctx)
+		started := time.Now()
+		result, err := next(ctx, method, req)
+
+		record := ToolCallRecord{
+			Tool:       callReq.Params.Name,
+			Arguments:  truncateRecordText(string(callReq.Params.Arguments)),
+			StartedAt:  started,
+			DurationMs: time.Since(started).Milliseconds(),
+		}
+		if err != nil {
+			record.IsError = true
+			record.Result = truncateRecordText(err.Error())
+		} else if toolResult, ok := result.(*mcp.CallToolResult); ok && toolResult != nil {
+			record.IsError = toolResult.IsError
+			record.Result = truncateRecordText(toolResultText(toolResult))
+		}
+		record.GitHubErrors = collectToolCallErrors(ctx)
+		recordToolCall(toolCallHistoryKey(ctx, callReq), record)
+
+		return result, err
+	}
+}
+
+// collectToolCallErrors returns the GitHub errors the handler recorded in the context.
+func collectToolCallErrors(ctx context.Context) []ToolCallError {
+	var result []ToolCallError
+	if apiErrors, err := ghErrors.GetGitHubAPIErrors(ctx); err == nil {
+		for _, e := range apiErrors {
+			callErr := ToolCallError{Kind: "rest", Message: e.Message}
+			if e.Err != nil {
+				callErr.Detail = e.Err.Error()
+			}
+			if e.Response != nil && e.Response.Response != nil {
+				callErr.StatusCode = e.Response.StatusCode
+				callErr.RequestID = e.Response.Header.Get("X-GitHub-Request-Id")
+				if e.Response.Request != nil {
+					callErr.URL = e.Response.Request.Method + " " + e.Response.Request.URL.String()
+				}
+			}
+			result = append(result, callErr)
+		}
+	}
+	if gqlErrors, err := ghErrors.GetGitHubGraphQLErrors(ctx); err == nil {
+		for _, e := range gqlErrors {
+			callErr := ToolCallError{Kind: "graphql", Message: e.Message}
+			if e.Err != nil {
+				callErr.Detail = e.Err.Error()
+			}
+			result = append(result, callErr)
+		}
+	}
+	if rawErrors, err := ghErrors.GetGitHubRawAPIErrors(ctx); err == nil {
+		for _, e := range rawErrors {
+			callErr := ToolCallError{Kind: "raw", Message: e.Message}
+			if e.Err != nil {
+				callErr.Detail = e.Err.Error()
+			}
+			if e.Response != nil {
+				callErr.StatusCode = e.Response.StatusCode
+				if e.Response.Request != nil {
+					callErr.URL = e.Response.Request.Method + " " + e.Response.Request.URL.String()
+				}
+			}
+			result = append(result, callErr)
+		}
+	}
+	return result
+}
+
+// toolResultText joins the text content of a tool result.
+func toolResultText(result *mcp.CallToolResult) string {
+	var parts []string
+	for _, c := range result.Content {
+		if text, ok := c.(*mcp.TextContent); ok {
+			parts = append(parts, text.Text)
+		}
+	}
+	return strings.Join(parts, "\n")
+}
+
+func truncateRecordText(s string) string {
+	if len(s) <= toolCallRecordMaxBytes {
+		return s
+	}
+	return strings.ToValidUTF8(s[:toolCallRecordMaxBytes], "") + "…"
+}
+
+// GetLastToolError creates a tool that returns the most recent failed tool call of the session.
+func GetLastToolError(t translations.TranslationHelperFunc) inventory.ServerTool {
+	return NewTool(
+		ToolsetMetadataContext,
+		mcp.Tool{
+			Name:        getLastToolErrorToolName,
+			Description: t("TOOL_GET_LAST_TOOL_ERROR_DESCRIPTION", "Get the most recent failed tool call in this session, with its arguments and the full GitHub API error detail (status code, request ID and URL). Use this to understand why a tool call failed before retrying."),
+			Annotations: &mcp.ToolAnnotations{
+				Title:        t("TOOL_GET_LAST_TOOL_ERROR_USER_TITLE", "Get last tool error"),
+				ReadOnlyHint: true,
+			},
+			// Use json.RawMessage to ensure "properties" is included even when empty.
+			// OpenAI strict mode requires the properties field to be present.
+			InputSchema: json.RawMessage(`{"type":"object","properties":{}}`),
+		},
+		nil,
+		func(ctx context.Context, _ ToolDependencies, req *mcp.CallToolRequest, _ map[string]any) (*mcp.CallToolResult, any, error) {
+			record, ok := lastToolError(toolCallHistoryKey(ctx, req))
+			if !ok {
+				return utils.NewToolResultText("No tool call has failed in this session."), nil, nil
+			}
+			return MarshalledTextResult(record), nil, nil
+		},
+	)
+}
@@ -0,0 +1,121 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	ghcontext "github.com/github/github-mcp-server/pkg/context"
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/github/github-mcp-server/pkg/utils"
+	"github.com/google/go-github/v82/github"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func resetToolCallHistory(t *testing.T) {
+	t.Helper()
+	toolCallHistory.delete("")
+	t.Cleanup(func() { toolCallHistory.delete("") })
+}
+
+// callThroughMiddleware calls a tool handler through the recording and error collection middleware,
+// in the order NewMCPServer installs them.
+func callThroughMiddleware(t *testing.T, name string, args string, handler mcp.MethodHandler) {
+	t.Helper()
+	chain := RecordToolCallsMiddleware(addGitHubAPIErrorToContext(handler))
+	_, _ = chain(context.Background(), "tools/call", &mcp.CallToolRequest{
+		Params: &mcp.CallToolParamsRaw{Name: name, Arguments: json.RawMessage(args)},
+	})
+}
+
+func Test_GetLastToolError(t *testing.T) {
+	// Verify tool definition once
+	serverTool := GetLastToolError(translations.NullTranslationHelper)
+	tool := serverTool.Tool
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_last_tool_error", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.True(t, tool.Annotations.ReadOnlyHint)
+
+	resp := &github.Response{Response: &http.Response{
+		StatusCode: http.StatusNotFound,
+		Header:     http.Header{"X-Github-Request-Id": []string{"ABCD:1234"}},
+		Request:    httptest.NewRequest(http.MethodGet, "https://api.github.com/repos/octo/missing", nil),
+	}}
+	failing := func(ctx context.Context, _ string, _ mcp.Request) (mcp.Result, error) {
+		return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to get repository", resp, errors.New("404 Not Found")), nil
+	}
+	succeeding := func(_ context.Context, _ string, _ mcp.Request) (mcp.Result, error) {
+		return utils.NewToolResultText("ok"), nil
+	}
+
+	callLastToolError := func(t *testing.T) *mcp.CallToolResult {
+		deps := BaseDeps{}
+		handler := serverTool.Handler(deps)
+		request := createMCPRequest(map[string]any{})
+		result, err := handler(ContextWithDeps(context.Background(), deps), &request)
+		require.NoError(t, err)
+		return result
+	}
+
+	t.Run("no failures", func(t *testing.T) {
+		resetToolCallHistory(t)
+		callThroughMiddleware(t, "get_me", `{}`, succeeding)
+
+		result := callLastToolError(t)
+		assert.Equal(t, "No tool call has failed in this session.", getTextResult(t, result).Text)
+	})
+
+	t.Run("returns the last failure with GitHub error detail", func(t *testing.T) {
+		resetToolCallHistory(t)
+		callThroughMiddleware(t, "get_repository", `{"owner":"octo","repo":"missing"}`, failing)
+		callThroughMiddleware(t, "get_me", `{}`, succeeding)
+
+		var record ToolCallRecord
+		require.NoError(t, json.Unmarshal([]byte(getTextResult(t, callLastToolError(t)).Text), &record))
+		assert.Equal(t, "get_repository", record.Tool)
+		assert.JSONEq(t, `{"owner":"octo","repo":"missing"}`, record.Arguments)
+		assert.True(t, record.IsError)
+		assert.Contains(t, record.Result, "failed to get repository")
+		assert.Equal(t, []ToolCallError{{
+			Kind:       "rest",
+			Message:    "failed to get repository",
+			Detail:     "404 Not Found",
+			StatusCode: http.StatusNotFound,
+			RequestID:  "ABCD:1234",
+			URL:        "GET https://api.github.com/repos/octo/missing",
+		}}, record.GitHubErrors)
+	})
+}
+
+func Test_ToolCallHistoryIsBounded(t *testing.T) {
+	resetToolCallHistory(t)
+	for i := 0; i < toolCallHistorySize+5; i++ {
+		recordToolCall("", ToolCallRecord{Tool: "get_me"})
+	}
+	records, _ := toolCallHistory.load("")
+	assert.Len(t, records, toolCallHistorySize)
+}
+
+func Test_ToolCallHistoryIsKeyedByToken(t *testing.T) {
+	req := &mcp.CallToolRequest{Params: &mcp.CallToolParamsRaw{Name: "get_me"}}
+	alice := ghcontext.WithTokenInfo(context.Background(), &ghcontext.TokenInfo{Token: "alice-token"})
+	mallory := ghcontext.WithTokenInfo(context.Background(), &ghcontext.TokenInfo{Token: "mallory-token"})
+	key := toolCallHistoryKey(alice, req)
+	t.Cleanup(func() { toolCallHistory.delete(key) })
+
+	recordToolCall(key, ToolCallRecord{Tool: "get_me", IsError: true})
+	_, found := lastToolError(toolCallHistoryKey(alice, req))
+	assert.True(t, found)
+	_, found = lastToolError(toolCallHistoryKey(mallory, req))
+	assert.False(t, found)
+	assert.NotContains(t, key, "alice-token")
+}
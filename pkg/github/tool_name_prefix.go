@@ -0,0 +1,76 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// validToolNamePrefix matches prefixes that keep tool names within the characters allowed by
+// the MCP specification.
+var validToolNamePrefix = regexp.MustCompile(`^[A-Za-z0-9_.-]{1,64}$`)
+
+// ValidateToolNamePrefix checks that prefix can be put in front of tool names.
+func ValidateToolNamePrefix(prefix string) error {
+	if !validToolNamePrefix.MatchString(prefix) {
+		return fmt.Errorf("invalid tool name prefix %q: use up to 64 letters, digits, '_', '-' or '.'", prefix)
+	}
+	return nil
+}
+
+// StripToolNamePrefix removes prefix from tool names that carry it, so configured tool lists
+// may use either the namespaced or the plain names. Deprecated aliases are then resolved as
+// usual, so a namespaced alias such as "github.get_workflow" still maps to its replacement.
+func StripToolNamePrefix(prefix string, names []string) []string {
+	if prefix == "" || names == nil {
+		return names
+	}
+	stripped := make([]string, len(names))
+	for i, name := range names {
+		stripped[i] = strings.TrimPrefix(strings.TrimSpace(name), prefix)
+	}
+	return stripped
+}
+
+// ToolNamePrefixMiddleware namespaces the tool names clients see, e.g. "github.list_issues",
+// for servers running behind MCP aggregators where plain names collide with other servers'
+// tools. Tools are registered and handled under their plain names: tools/list results are
+// prefixed on the way out and tools/call names are stripped on the way in, so the middleware
+// must be the outermost one.
+func ToolNamePrefixMiddleware(prefix string) mcp.Middleware {
+	return func(next mcp.MethodHandler) mcp.MethodHandler {
+		return func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+			switch method {
+			case "tools/call":
+				if callReq, ok := req.(*mcp.CallToolRequest); ok && callReq.Params != nil {
+					params := *callReq.Params
+					params.Name = strings.TrimPrefix(params.Name, prefix)
+					stripped := *callReq
+					stripped.Params = &params
+					req = &stripped
+				}
+				return next(ctx, method, req)
+			case "tools/list":
+				result, err := next(ctx, method, req)
+				listResult, ok := result.(*mcp.ListToolsResult)
+				if err != nil || !ok || listResult == nil {
+					return result, err
+				}
+				prefixed := *listResult
+				prefixed.Tools = make([]*mcp.Tool, len(listResult.Tools))
+				for i, tool := range listResult.Tools {
+					// Copy the tool, as the result shares the server's registered tools
+					toolCopy := *tool
+					toolCopy.Name = prefix + tool.Name
+					prefixed.Tools[i] = &toolCopy
+				}
+				return &prefixed, nil
+			default:
+				return next(ctx, method, req)
+			}
+		}
+	}
+}
@@ -0,0 +1,110 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ValidateToolNamePrefix(t *testing.T) {
+	for _, prefix := range []string{"github.", "gh_", "github-", "GHE"} {
+		assert.NoError(t, ValidateToolNamePrefix(prefix), prefix)
+	}
+	for _, prefix := range []string{"", "git hub.", "github/", "github:"} {
+		assert.Error(t, ValidateToolNamePrefix(prefix), prefix)
+	}
+}
+
+func Test_StripToolNamePrefix(t *testing.T) {
+	assert.Nil(t, StripToolNamePrefix("github.", nil))
+	assert.Equal(t, []string{"list_issues"}, StripToolNamePrefix("", []string{"list_issues"}))
+	assert.Equal(t,
+		[]string{"list_issues", "get_me", "get_workflow"},
+		StripToolNamePrefix("github.", []string{"github.list_issues", " get_me", "github.get_workflow"}),
+	)
+}
+
+func Test_ToolNamePrefixMiddleware(t *testing.T) {
+	registered := []*mcp.Tool{{Name: "list_issues"}, {Name: "get_me"}}
+	var calledName string
+	handler := ToolNamePrefixMiddleware("github.")(func(_ context.Context, method string, req mcp.Request) (mcp.Result, error) {
+		switch method {
+		case "tools/list":
+			return &mcp.ListToolsResult{Tools: registered}, nil
+		case "tools/call":
+			calledName = req.(*mcp.CallToolRequest).Params.Name
+			return &mcp.CallToolResult{}, nil
+		default:
+			return nil, nil
+		}
+	})
+
+	t.Run("tools/list prefixes names without changing registered tools", func(t *testing.T) {
+		result, err := handler(context.Background(), "tools/list", &mcp.ListToolsRequest{})
+		require.NoError(t, err)
+		tools := result.(*mcp.ListToolsResult).Tools
+		require.Len(t, tools, 2)
+		assert.Equal(t, "github.list_issues", tools[0].Name)
+		assert.Equal(t, "github.get_me", tools[1].Name)
+		assert.Equal(t, "list_issues", registered[0].Name)
+	})
+
+	t.Run("tools/call strips the prefix", func(t *testing.T) {
+		req := &mcp.CallToolRequest{Params: &mcp.CallToolParamsRaw{Name: "github.list_issues"}}
+		_, err := handler(context.Background(), "tools/call", req)
+		require.NoError(t, err)
+		assert.Equal(t, "list_issues", calledName)
+		assert.Equal(t, "github.list_issues", req.Params.Name)
+	})
+}
+
+// connectPrefixedServer connects a client to a server created by NewMCPServer with the "github."
+// tool name prefix and the issues toolset, whose REST requests go through github.
+func connectPrefixedServer(t *testing.T, cfg MCPServerConfig, github *countingTransport) *mcp.ClientSession {
+	t.Helper()
+	cfg.ToolNamePrefix = "github."
+	return connectTestServer(t, cfg, []string{"issues"}, github, nil)
+}
+
+func callCreateIssue(t *testing.T, cs *mcp.ClientSession) *mcp.CallToolResult {
+	t.Helper()
+	result, err := cs.CallTool(context.Background(), &mcp.CallToolParams{
+		Name:      "github.issue_write",
+		Arguments: map[string]any{"method": "create", "owner": "octo", "repo": "ink", "title": "Flaky test"},
+	})
+	require.NoError(t, err)
+	return result
+}
+
+func Test_NewMCPServerWithToolNamePrefix(t *testing.T) {
+	t.Run("dry run", func(t *testing.T) {
+		github := &countingTransport{}
+		cs := connectPrefixedServer(t, MCPServerConfig{DryRun: true}, github)
+
+		tools, err := cs.ListTools(context.Background(), nil)
+		require.NoError(t, err)
+		idx := slices.IndexFunc(tools.Tools, func(tool *mcp.Tool) bool { return tool.Name == "github.issue_write" })
+		require.GreaterOrEqual(t, idx, 0)
+		schema, err := json.Marshal(tools.Tools[idx].InputSchema)
+		require.NoError(t, err)
+		assert.Contains(t, string(schema), `"dry_run"`)
+
+		result := callCreateIssue(t, cs)
+		assert.Contains(t, getTextResult(t, result).Text, `"dry_run":true`)
+		assert.Zero(t, github.requests.Load())
+	})
+
+	t.Run("write quota", func(t *testing.T) {
+		github := &countingTransport{}
+		cs := connectPrefixedServer(t, MCPServerConfig{WriteQuota: WriteQuotaConfig{PerHour: 1}}, github)
+
+		assert.False(t, callCreateIssue(t, cs).IsError)
+		assert.Contains(t, getErrorResult(t, callCreateIssue(t, cs)).Text, "write quota of 1 per hour reached")
+		assert.Equal(t, int32(1), github.requests.Load())
+	})
+}
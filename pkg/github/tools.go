@@ -76,6 +76,11 @@ var (
 		Description: "GitHub Actions workflows and CI/CD operations",
 		Icon:        "workflow",
 	}
+	ToolsetMetadataChecks = inventory.ToolsetMetadata{
+		ID:          "checks",
+		Description: "GitHub Checks: check runs, check suites and their annotations",
+		Icon:        "check-circle",
+	}
 	ToolsetMetadataCodeSecurity = inventory.ToolsetMetadata{
 		ID:          "code_security",
 		Description: "Code security related tools, such as GitHub Code Scanning",
@@ -88,7 +93,7 @@ var (
 	}
 	ToolsetMetadataDependabot = inventory.ToolsetMetadata{
 		ID:          "dependabot",
-		Description: "Dependabot tools",
+		Description: "Dependabot alerts and dependency graph tools",
 		Icon:        "dependabot",
 	}
 	ToolsetMetadataNotifications = inventory.ToolsetMetadata{
@@ -123,6 +128,16 @@ var (
 		Description: "GitHub Stargazers related tools",
 		Icon:        "star",
 	}
+	ToolsetMetadataMigrations = inventory.ToolsetMetadata{
+		ID:          "migrations",
+		Description: "Organization migration and repository source import tools",
+		Icon:        "repo-forked",
+	}
+	ToolsetMetadataReleases = inventory.ToolsetMetadata{
+		ID:          "releases",
+		Description: "GitHub Releases and release asset tools",
+		Icon:        "tag",
+	}
 	ToolsetMetadataDynamic = inventory.ToolsetMetadata{
 		ID:          "dynamic",
 		Description: "Discover GitHub MCP tools that can help achieve tasks by enabling additional sets of tools, you can control the enablement of any toolset to access its tools when this toolset is enabled.",
@@ -175,12 +190,22 @@ func AllTools(t translations.TranslationHelperFunc) []inventory.ServerTool {
 	return []inventory.ServerTool{
 		// Context tools
 		GetMe(t),
+		SetContext(t),
+		GetRootContext(t),
+		GetLastToolError(t),
+		GetServerInfo(t),
+		GetRateLimit(t),
+		GetAPIUsage(t),
+		ListFeatures(t),
+		WatchResource(t),
 		GetTeams(t),
 		GetTeamMembers(t),
 
 		// Repository tools
 		SearchRepositories(t),
+		SearchOrgRepositories(t),
 		GetFileContents(t),
+		GetFilesContents(t),
 		ListCommits(t),
 		SearchCode(t),
 		GetCommit(t),
@@ -190,15 +215,23 @@ func AllTools(t translations.TranslationHelperFunc) []inventory.ServerTool {
 		ListReleases(t),
 		GetLatestRelease(t),
 		GetReleaseByTag(t),
+		ResolveGitHubURL(t),
+		GetPermalink(t),
 		CreateOrUpdateFile(t),
 		CreateRepository(t),
 		ForkRepository(t),
 		CreateBranch(t),
 		PushFiles(t),
+		PreviewPushRules(t),
+		RolloutRuleset(t),
 		DeleteFile(t),
 		ListStarredRepositories(t),
 		StarRepository(t),
 		UnstarRepository(t),
+		GetTrendingRepositories(t),
+		GetRepositorySocialPreview(t),
+		GetRepositoriesDigest(t),
+		GetHandoffReport(t),
 
 		// Git tools
 		GetRepositoryTree(t),
@@ -207,28 +240,76 @@ func AllTools(t translations.TranslationHelperFunc) []inventory.ServerTool {
 		IssueRead(t),
 		SearchIssues(t),
 		ListIssues(t),
+		ExportIssues(t),
+		CheckIssueSLAs(t),
+		FindGoodFirstIssues(t),
+		CurateGoodFirstIssues(t),
 		ListIssueTypes(t),
+		ListMilestones(t),
+		MilestoneWrite(t),
 		IssueWrite(t),
 		AddIssueComment(t),
+		GetIssueHierarchy(t),
 		SubIssueWrite(t),
+		IssueDependencyWrite(t),
+		IssuePinWrite(t),
+		IssueLockWrite(t),
+		ListReactions(t),
+		ReactionWrite(t),
+		ConvertIssueToDiscussion(t),
+		ListSavedReplies(t),
+		AddIssueCommentFromSavedReply(t),
+		ListCommentTemplates(t),
+		CreateIssueCommentFromTemplate(t),
 
 		// User tools
 		SearchUsers(t),
+		ListSSHKeys(t),
+		ListGPGKeys(t),
 
 		// Organization tools
 		SearchOrgs(t),
+		ListOrgMemberSSHKeys(t),
+		GetOrgProfile(t),
+		UpdateOrgProfile(t),
+		UpdateOrgProfileREADME(t),
+
+		// Migration tools
+		ListOrgMigrations(t),
+		GetMigrationStatus(t),
+		StartOrgMigration(t),
+		StartSourceImport(t),
+
+		// Release tools
+		CreateRelease(t),
+		UpdateRelease(t),
+		GenerateReleaseNotes(t),
+		GetVersionChangelog(t),
+		ListReleaseAssets(t),
+		UploadReleaseAsset(t),
+		DownloadReleaseAsset(t),
 
 		// Pull request tools
 		PullRequestRead(t),
+		GetPullRequestContext(t),
+		CheckMergeReadiness(t),
+		GetMergeQueue(t),
+		GetContributorOnboardingStatus(t),
+		LinkedIssueWrite(t),
 		ListPullRequests(t),
 		SearchPullRequests(t),
 		MergePullRequest(t),
+		MergeQueueWrite(t),
+		EnablePullRequestAutoMerge(t),
+		DisablePullRequestAutoMerge(t),
 		UpdatePullRequestBranch(t),
 		CreatePullRequest(t),
 		UpdatePullRequest(t),
 		PullRequestReviewWrite(t),
 		AddCommentToPendingReview(t),
 		AddReplyToPullRequestComment(t),
+		ApplyReviewSuggestion(t),
+		SplitPullRequest(t),
 
 		// Copilot tools
 		AssignCopilotToIssue(t),
@@ -237,14 +318,21 @@ func AllTools(t translations.TranslationHelperFunc) []inventory.ServerTool {
 		// Code security tools
 		GetCodeScanningAlert(t),
 		ListCodeScanningAlerts(t),
+		UpdateCodeScanningAlert(t),
+		GetOrgSecurityCoverage(t),
 
 		// Secret protection tools
 		GetSecretScanningAlert(t),
 		ListSecretScanningAlerts(t),
+		UpdateSecretScanningAlert(t),
 
 		// Dependabot tools
 		GetDependabotAlert(t),
 		ListDependabotAlerts(t),
+		UpdateDependabotAlert(t),
+		GenerateDependabotConfig(t),
+		UpdateDependabotConfig(t),
+		GetRepositorySBOM(t),
 
 		// Notification tools
 		ListNotifications(t),
@@ -259,12 +347,33 @@ func AllTools(t translations.TranslationHelperFunc) []inventory.ServerTool {
 		GetDiscussion(t),
 		GetDiscussionComments(t),
 		ListDiscussionCategories(t),
+		CreateDiscussion(t),
+		AddDiscussionComment(t),
+		MarkDiscussionAnswer(t),
+		ConvertDiscussionToIssue(t),
 
 		// Actions tools
 		ActionsList(t),
 		ActionsGet(t),
+		GetWorkflowDependencies(t),
+		PinActions(t),
 		ActionsRunTrigger(t),
 		ActionsGetJobLogs(t),
+		WatchWorkflowRun(t),
+		ListActionsCaches(t),
+		DeleteActionsCache(t),
+		GetEnvironmentProtection(t),
+		UpdateEnvironmentProtection(t),
+		ListDeploymentProtectionRules(t),
+		ReviewDeploymentProtectionRule(t),
+		GetOrgDefaultBranchStatus(t),
+
+		// Checks tools
+		ListCheckRuns(t),
+		GetCheckRunAnnotations(t),
+		RerequestCheckSuite(t),
+		CreateCheckRun(t),
+		UpdateCheckRun(t),
 
 		// Security advisories tools
 		ListGlobalSecurityAdvisories(t),
@@ -288,6 +397,7 @@ func AllTools(t translations.TranslationHelperFunc) []inventory.ServerTool {
 		GetLabelForLabelsToolset(t),
 		ListLabels(t),
 		LabelWrite(t),
+		ApplyLabels(t),
 
 		// Granular issue tools (feature-flagged, replace consolidated issue_write/sub_issue_write)
 		GranularCreateIssue(t),
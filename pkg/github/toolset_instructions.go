@@ -18,7 +18,9 @@ Check 'list_issue_types' first for organizations to use proper issue types. Use
 func generatePullRequestsToolsetInstructions(inv *inventory.Inventory) string {
 	instructions := `## Pull Requests
 
-PR review workflow: Always use 'pull_request_review_write' with method 'create' to create a pending review, then 'add_comment_to_pending_review' to add comments, and finally 'pull_request_review_write' with method 'submit_pending' to submit the review for complex reviews with line-specific comments.`
+PR review workflow: Always use 'pull_request_review_write' with method 'create' to create a pending review, then 'add_comment_to_pending_review' to add comments, and finally 'pull_request_review_write' with method 'submit_pending' to submit the review for complex reviews with line-specific comments.
+
+Before merging, or to find out what is holding a pull request back, use 'check_merge_readiness' rather than combining its status, checks and reviews yourself.`
 
 	if inv.HasToolset("repos") {
 		instructions += `
@@ -31,7 +33,9 @@ Before creating a pull request, search for pull request templates in the reposit
 func generateDiscussionsToolsetInstructions(_ *inventory.Inventory) string {
 	return `## Discussions
 
-Use 'list_discussion_categories' to understand available categories before creating discussions. Filter by category for better organization.`
+Use 'list_discussion_categories' to understand available categories before creating discussions. Filter by category for better organization.
+
+To answer a question, read it with 'get_discussion' and 'get_discussion_comments', reply with 'add_discussion_comment', and use 'mark_discussion_answer' with the node ID of the comment that answers it.`
 }
 
 func generateProjectsToolsetInstructions(_ *inventory.Inventory) string {
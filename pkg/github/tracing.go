@@ -0,0 +1,58 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/github/github-mcp-server/pkg/observability/tracing"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// TracingMiddleware records a server span for each MCP request, covering the middleware chain and
+// the handler, so that the spans of the GitHub API calls a tool makes are its children. Tool call
+// spans carry the tool name and the repository targeted, and are marked failed when the tool
+// returns an error result. It must be the outermost middleware, so tool call spans are named
+// after the tool the client called, with its prefix.
+func TracingMiddleware(tracer tracing.Tracer) mcp.Middleware {
+	return func(next mcp.MethodHandler) mcp.MethodHandler {
+		return func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+			name := method
+			attrs := map[string]any{"mcp.method.name": method}
+			if callReq, ok := req.(*mcp.CallToolRequest); ok && callReq.Params != nil {
+				name += " " + callReq.Params.Name
+				attrs["gen_ai.tool.name"] = callReq.Params.Name
+				var args struct {
+					Owner string `json:"owner"`
+					Repo  string `json:"repo"`
+				}
+				if len(callReq.Params.Arguments) > 0 && json.Unmarshal(callReq.Params.Arguments, &args) == nil && args.Owner != "" {
+					attrs["github.owner"] = args.Owner
+					if args.Repo != "" {
+						attrs["github.repository"] = args.Owner + "/" + args.Repo
+					}
+				}
+				if id := sessionID(callReq); id != "" {
+					attrs["mcp.session.id"] = id
+				}
+			}
+
+			ctx, span := tracer.Start(ctx, name, tracing.SpanKindServer, attrs)
+			defer span.End()
+
+			result, err := next(ctx, method, req)
+			switch {
+			case err != nil:
+				span.SetError(err.Error())
+			case isToolError(result):
+				span.SetAttributes(map[string]any{"error.type": "tool_error"})
+				span.SetError(truncateRecordText(toolResultText(result.(*mcp.CallToolResult))))
+			}
+			return result, err
+		}
+	}
+}
+
+func isToolError(result mcp.Result) bool {
+	toolResult, ok := result.(*mcp.CallToolResult)
+	return ok && toolResult != nil && toolResult.IsError
+}
@@ -0,0 +1,119 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/github/github-mcp-server/pkg/observability/tracing"
+	"github.com/github/github-mcp-server/pkg/utils"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingTracer records the spans it starts.
+type recordingTracer struct {
+	spans []*recordedSpan
+}
+
+type recordedSpan struct {
+	name  string
+	kind  tracing.SpanKind
+	attrs map[string]any
+	err   string
+	ended bool
+}
+
+func (t *recordingTracer) Start(ctx context.Context, name string, kind tracing.SpanKind, attrs map[string]any) (context.Context, tracing.Span) {
+	span := &recordedSpan{name: name, kind: kind, attrs: map[string]any{}}
+	span.SetAttributes(attrs)
+	t.spans = append(t.spans, span)
+	return tracing.ContextWithSpan(ctx, span), span
+}
+
+func (s *recordedSpan) SetAttributes(attrs map[string]any) {
+	for key, value := range attrs {
+		s.attrs[key] = value
+	}
+}
+
+func (s *recordedSpan) SetError(message string) { s.err = message }
+func (s *recordedSpan) End()                    { s.ended = true }
+
+func Test_TracingMiddleware(t *testing.T) {
+	tracer := &recordingTracer{}
+	handler := TracingMiddleware(tracer)(func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+		// The handler's API calls are children of the request's span.
+		span := tracing.SpanFromContext(ctx)
+		require.Same(t, tracer.spans[len(tracer.spans)-1], span)
+		if method == "resources/read" {
+			return nil, errors.New("resource not found")
+		}
+		if req.(*mcp.CallToolRequest).Params.Name == "get_me" {
+			return utils.NewToolResultText("ok"), nil
+		}
+		return utils.NewToolResultError("issue not found"), nil
+	})
+
+	args, err := json.Marshal(map[string]any{"owner": "octo", "repo": "app", "issue_number": 42})
+	require.NoError(t, err)
+	_, err = handler(context.Background(), "tools/call", &mcp.CallToolRequest{
+		Params: &mcp.CallToolParamsRaw{Name: "issue_read", Arguments: args},
+	})
+	require.NoError(t, err)
+	_, err = handler(context.Background(), "tools/call", &mcp.CallToolRequest{
+		Params: &mcp.CallToolParamsRaw{Name: "get_me"},
+	})
+	require.NoError(t, err)
+	_, err = handler(context.Background(), "resources/read", &mcp.ReadResourceRequest{})
+	require.Error(t, err)
+
+	require.Len(t, tracer.spans, 3)
+	failed, succeeded, resource := tracer.spans[0], tracer.spans[1], tracer.spans[2]
+
+	assert.Equal(t, "tools/call issue_read", failed.name)
+	assert.Equal(t, tracing.SpanKindServer, failed.kind)
+	assert.Equal(t, map[string]any{
+		"mcp.method.name":   "tools/call",
+		"gen_ai.tool.name":  "issue_read",
+		"github.owner":      "octo",
+		"github.repository": "octo/app",
+		"error.type":        "tool_error",
+	}, failed.attrs)
+	assert.Equal(t, "issue not found", failed.err)
+	assert.True(t, failed.ended)
+
+	assert.Equal(t, "tools/call get_me", succeeded.name)
+	assert.NotContains(t, succeeded.attrs, "github.owner")
+	assert.Empty(t, succeeded.err)
+	assert.True(t, succeeded.ended)
+
+	assert.Equal(t, "resources/read", resource.name)
+	assert.Equal(t, "resource not found", resource.err)
+	assert.True(t, resource.ended)
+}
+
+func Test_NewMCPServerTracesWholeChain(t *testing.T) {
+	tracer := &recordingTracer{}
+	github := &countingTransport{}
+	cs := connectPrefixedServer(t, MCPServerConfig{Tracer: tracer, WriteQuota: WriteQuotaConfig{PerHour: 1}}, github)
+
+	callCreateIssue(t, cs)
+	// Refused by the write quota, outside the tool
+	result := callCreateIssue(t, cs)
+	require.True(t, result.IsError)
+
+	var spans []*recordedSpan
+	for _, span := range tracer.spans {
+		if span.name == "tools/call github.issue_write" {
+			spans = append(spans, span)
+		}
+	}
+	require.Len(t, spans, 2)
+	assert.Equal(t, "octo/ink", spans[0].attrs["github.repository"])
+	assert.Empty(t, spans[0].err)
+	assert.Equal(t, getErrorResult(t, result).Text, spans[1].err)
+	assert.True(t, spans[1].ended)
+}
@@ -0,0 +1,300 @@
+package github
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	ghErrors "github.com/github/github-mcp-server/pkg/errors"
+	"github.com/github/github-mcp-server/pkg/inventory"
+	"github.com/github/github-mcp-server/pkg/scopes"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/github/github-mcp-server/pkg/utils"
+	"github.com/google/go-github/v82/github"
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+const (
+	// trendingDefaultLimit is the default number of trending repositories returned.
+	trendingDefaultLimit = 10
+	// trendingMaxLimit caps the number of candidates whose stargazers are inspected.
+	trendingMaxLimit = 25
+	// trendingMinStars excludes repositories too small to be trending.
+	trendingMinStars = 10
+	// trendingMaxStarPages caps how many pages of 100 stargazers are read per repository.
+	trendingMaxStarPages = 3
+	// trendingCandidatesPerLimit is the number of candidates each search returns per repository
+	// asked for, as the most starred repositories are not the ones gaining the most stars.
+	trendingCandidatesPerLimit = 2
+	// trendingNewRepositoryAge is how recently a repository must have been created to be searched
+	// for among the new repositories, which the most starred active repositories crowd out.
+	trendingNewRepositoryAge = 90 * 24 * time.Hour
+)
+
+// trendingWindows maps the windows of the GitHub trending page to their length.
+var trendingWindows = map[string]time.Duration{
+	"daily":   24 * time.Hour,
+	"weekly":  7 * 24 * time.Hour,
+	"monthly": 30 * 24 * time.Hour,
+}
+
+// TrendingRepository is a repository with the number of stars it gained during the window.
+type TrendingRepository struct {
+	MinimalRepository
+	StarsGained int `json:"stars_gained"`
+	// StarsGainedIsLowerBound is set when more stars were gained than could be counted.
+	StarsGainedIsLowerBound bool `json:"stars_gained_is_lower_bound,omitempty"`
+}
+
+// TrendingRepositories is the result of the get_trending_repositories tool.
+type TrendingRepositories struct {
+	Window       string               `json:"window"`
+	Since        time.Time            `json:"since"`
+	Topic        string               `json:"topic,omitempty"`
+	Language     string               `json:"language,omitempty"`
+	Queries      []string             `json:"queries"`
+	Repositories []TrendingRepository `json:"repositories"`
+}
+
+// GetTrendingRepositories creates a tool that approximates the GitHub trending page for a topic or language.
+func GetTrendingRepositories(t translations.TranslationHelperFunc) inventory.ServerTool {
+	return NewTool(
+		ToolsetMetadataStargazers,
+		mcp.Tool{
+			Name: "get_trending_repositories",
+			Description: t("TOOL_GET_TRENDING_REPOSITORIES_DESCRIPTION", `Approximate the GitHub trending page for a topic and/or language: find active, popular repositories and recently created ones, and rank them by the number of stars gained during the window.
+Set 'from_starred_topics' to use the topic most common among the repositories you have starred.`),
+			Annotations: &mcp.ToolAnnotations{
+				Title:        t("TOOL_GET_TRENDING_REPOSITORIES_USER_TITLE", "Get trending repositories"),
+				ReadOnlyHint: true,
+			},
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"topic": {
+						Type:        "string",
+						Description: "Only include repositories tagged with this topic",
+					},
+					"language": {
+						Type:        "string",
+						Description: "Only include repositories whose primary language is this language",
+					},
+					"from_starred_topics": {
+						Type:        "boolean",
+						Description: "When no topic is given, use the topic most common among the authenticated user's starred repositories",
+						Default:     json.RawMessage(`false`),
+					},
+					"window": {
+						Type:        "string",
+						Description: "Period over which stars are counted",
+						Enum:        []any{"daily", "weekly", "monthly"},
+						Default:     json.RawMessage(`"weekly"`),
+					},
+					"limit": {
+						Type:        "number",
+						Description: fmt.Sprintf("Number of repositories to return (max %d)", trendingMaxLimit),
+						Minimum:     jsonschema.Ptr(1.0),
+						Maximum:     jsonschema.Ptr(float64(trendingMaxLimit)),
+						Default:     json.RawMessage(fmt.Sprintf("%d", trendingDefaultLimit)),
+					},
+				},
+			},
+		},
+		[]scopes.Scope{scopes.Repo},
+		func(ctx context.Context, deps ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+			topic, err := OptionalParam[string](args, "topic")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			language, err := OptionalParam[string](args, "language")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			fromStarredTopics, err := OptionalBoolParamWithDefault(args, "from_starred_topics", false)
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			window, err := OptionalParam[string](args, "window")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			if window == "" {
+				window = "weekly"
+			}
+			windowLength, ok := trendingWindows[window]
+			if !ok {
+				return utils.NewToolResultError(fmt.Sprintf("invalid window: %s", window)), nil, nil
+			}
+			limit, err := OptionalIntParamWithDefault(args, "limit", trendingDefaultLimit)
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			if limit < 1 || limit > trendingMaxLimit {
+				return utils.NewToolResultError(fmt.Sprintf("limit must be between 1 and %d", trendingMaxLimit)), nil, nil
+			}
+
+			client, err := deps.GetClient(ctx)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to get GitHub client: %w", err)
+			}
+
+			if topic == "" && fromStarredTopics {
+				starred, resp, err := client.Activity.ListStarred(ctx, "", &github.ActivityListStarredOptions{
+					Sort:        "created",
+					ListOptions: github.ListOptions{PerPage: 100},
+				})
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx, "failed to list starred repositories", resp, err), nil, nil
+				}
+				_ = resp.Body.Close()
+				topic = mostCommonTopic(starred)
+				if topic == "" {
+					return utils.NewToolResultError("none of your starred repositories have topics"), nil, nil
+				}
+			}
+
+			// The candidates are the most starred active repositories and the most starred recently
+			// created ones, several times as many as asked for, so that smaller repositories gaining
+			// stars fast are not crowded out by those with the most stars overall
+			since := time.Now().Add(-windowLength).UTC().Truncate(time.Hour)
+			queries := []string{
+				buildTrendingQuery(topic, language, since, time.Time{}),
+				buildTrendingQuery(topic, language, since, since.Add(-trendingNewRepositoryAge)),
+			}
+			var candidates []*github.Repository
+			seen := map[string]bool{}
+			for _, query := range queries {
+				searchResult, resp, err := client.Search.Repositories(ctx, query, &github.SearchOptions{
+					Sort:        "stars",
+					Order:       "desc",
+					ListOptions: github.ListOptions{PerPage: limit * trendingCandidatesPerLimit},
+				})
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx, fmt.Sprintf("failed to search repositories with query '%s'", query), resp, err), nil, nil
+				}
+				_ = resp.Body.Close()
+				for _, repo := range searchResult.Repositories {
+					if !seen[repo.GetFullName()] {
+						seen[repo.GetFullName()] = true
+						candidates = append(candidates, repo)
+					}
+				}
+			}
+
+			result := TrendingRepositories{
+				Window:       window,
+				Since:        since,
+				Topic:        topic,
+				Language:     language,
+				Queries:      queries,
+				Repositories: make([]TrendingRepository, 0, len(candidates)),
+			}
+			for _, repo := range candidates {
+				gained, lowerBound, resp, err := countStarsSince(ctx, client, repo.GetOwner().GetLogin(), repo.GetName(), since)
+				if err != nil {
+					return ghErrors.NewGitHubAPIErrorResponse(ctx, fmt.Sprintf("failed to list stargazers of %s", repo.GetFullName()), resp, err), nil, nil
+				}
+				result.Repositories = append(result.Repositories, TrendingRepository{
+					MinimalRepository:       convertToMinimalRepository(repo),
+					StarsGained:             gained,
+					StarsGainedIsLowerBound: lowerBound,
+				})
+			}
+			slices.SortStableFunc(result.Repositories, func(a, b TrendingRepository) int {
+				return cmp.Compare(b.StarsGained, a.StarsGained)
+			})
+			if len(result.Repositories) > limit {
+				result.Repositories = result.Repositories[:limit]
+			}
+
+			return MarshalledTextResult(result), nil, nil
+		},
+	)
+}
+
+// buildTrendingQuery builds a search query for popular repositories that were active since the
+// given time and, unless createdSince is zero, created since createdSince.
+func buildTrendingQuery(topic, language string, since, createdSince time.Time) string {
+	terms := []string{
+		fmt.Sprintf("stars:>=%d", trendingMinStars),
+		"pushed:>=" + since.Format("2006-01-02"),
+	}
+	if !createdSince.IsZero() {
+		terms = append(terms, "created:>="+createdSince.Format("2006-01-02"))
+	}
+	terms = append(terms, "archived:false")
+	if topic != "" {
+		terms = append(terms, "topic:"+quoteSearchValue(topic))
+	}
+	if language != "" {
+		terms = append(terms, "language:"+quoteSearchValue(language))
+	}
+	return strings.Join(terms, " ")
+}
+
+// mostCommonTopic returns the topic shared by the most starred repositories, breaking ties alphabetically.
+func mostCommonTopic(starred []*github.StarredRepository) string {
+	counts := map[string]int{}
+	for _, s := range starred {
+		for _, topic := range s.GetRepository().Topics {
+			counts[topic]++
+		}
+	}
+	best := ""
+	for topic, count := range counts {
+		if best == "" || count > counts[best] || (count == counts[best] && topic < best) {
+			best = topic
+		}
+	}
+	return best
+}
+
+// countStarsSince counts the stars a repository gained since the given time. Stargazers are listed
+// oldest first, so pages are read backwards from the last one until an older star is found. When
+// trendingMaxStarPages pages are exhausted first, the count is a lower bound.
+func countStarsSince(ctx context.Context, client *github.Client, owner, repo string, since time.Time) (int, bool, *github.Response, error) {
+	opts := &github.ListOptions{Page: 1, PerPage: 100}
+	stargazers, resp, err := client.Activity.ListStargazers(ctx, owner, repo, opts)
+	if err != nil {
+		return 0, false, resp, err
+	}
+	_ = resp.Body.Close()
+
+	page := 1
+	if resp.LastPage > 1 {
+		page = resp.LastPage
+		opts.Page = page
+		stargazers, resp, err = client.Activity.ListStargazers(ctx, owner, repo, opts)
+		if err != nil {
+			return 0, false, resp, err
+		}
+		_ = resp.Body.Close()
+	}
+
+	count := 0
+	for pagesRead := 1; ; pagesRead++ {
+		for _, s := range stargazers {
+			if s.GetStarredAt().After(since) {
+				count++
+			}
+		}
+		if len(stargazers) == 0 || !stargazers[0].GetStarredAt().After(since) || page == 1 {
+			return count, false, resp, nil
+		}
+		if pagesRead == trendingMaxStarPages {
+			return count, true, resp, nil
+		}
+		page--
+		opts.Page = page
+		stargazers, resp, err = client.Activity.ListStargazers(ctx, owner, repo, opts)
+		if err != nil {
+			return 0, false, resp, err
+		}
+		_ = resp.Body.Close()
+	}
+}
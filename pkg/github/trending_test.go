@@ -0,0 +1,250 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/google/go-github/v82/github"
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mockStargazerPages serves stargazers per repository and page, setting the Link header
+// so that the client learns the last page.
+func mockStargazerPages(t *testing.T, pages map[string][][]*github.Stargazer) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		segments := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+		require.Len(t, segments, 4)
+		repoPages, ok := pages[segments[2]]
+		require.True(t, ok, "unexpected repository %s", segments[2])
+		page := 1
+		if p := r.URL.Query().Get("page"); p != "" {
+			_, err := fmt.Sscanf(p, "%d", &page)
+			require.NoError(t, err)
+		}
+		require.LessOrEqual(t, page, len(repoPages))
+		if len(repoPages) > 1 {
+			w.Header().Set("Link", fmt.Sprintf(`<https://api.github.com%s?page=%d>; rel="last"`, r.URL.Path, len(repoPages)))
+		}
+		w.WriteHeader(http.StatusOK)
+		b, err := json.Marshal(repoPages[page-1])
+		require.NoError(t, err)
+		_, _ = w.Write(b)
+	}
+}
+
+func stargazersAt(times ...time.Time) []*github.Stargazer {
+	result := make([]*github.Stargazer, 0, len(times))
+	for _, at := range times {
+		result = append(result, &github.Stargazer{StarredAt: &github.Timestamp{Time: at}, User: &github.User{Login: github.Ptr("user")}})
+	}
+	return result
+}
+
+func Test_GetTrendingRepositories(t *testing.T) {
+	// Verify tool definition once
+	serverTool := GetTrendingRepositories(translations.NullTranslationHelper)
+	tool := serverTool.Tool
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+
+	assert.Equal(t, "get_trending_repositories", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	assert.True(t, tool.Annotations.ReadOnlyHint)
+	schema := tool.InputSchema.(*jsonschema.Schema)
+	assert.Contains(t, schema.Properties, "topic")
+	assert.Contains(t, schema.Properties, "language")
+	assert.Contains(t, schema.Properties, "from_starred_topics")
+	assert.Contains(t, schema.Properties, "window")
+	assert.Contains(t, schema.Properties, "limit")
+	assert.Empty(t, schema.Required)
+
+	now := time.Now()
+	old := now.Add(-60 * 24 * time.Hour)
+	recent := now.Add(-time.Hour)
+
+	mockSearch := &github.RepositoriesSearchResult{
+		Total: github.Ptr(2),
+		Repositories: []*github.Repository{
+			{
+				Name:            github.Ptr("steady"),
+				FullName:        github.Ptr("owner/steady"),
+				Owner:           &github.User{Login: github.Ptr("owner")},
+				StargazersCount: github.Ptr(5000),
+			},
+			{
+				Name:            github.Ptr("rising"),
+				FullName:        github.Ptr("owner/rising"),
+				Owner:           &github.User{Login: github.Ptr("owner")},
+				StargazersCount: github.Ptr(30),
+			},
+		},
+	}
+	stargazers := map[string][][]*github.Stargazer{
+		"steady": {stargazersAt(old, old, recent)},
+		"rising": {
+			stargazersAt(old, old),
+			stargazersAt(old, recent, recent),
+			stargazersAt(recent, recent, recent),
+		},
+		"newcomer": {stargazersAt(old, recent, recent, recent, recent, recent, recent)},
+	}
+	mockNewSearch := &github.RepositoriesSearchResult{
+		Total: github.Ptr(1),
+		Repositories: []*github.Repository{
+			{
+				Name:            github.Ptr("newcomer"),
+				FullName:        github.Ptr("owner/newcomer"),
+				Owner:           &github.User{Login: github.Ptr("owner")},
+				StargazersCount: github.Ptr(12),
+			},
+		},
+	}
+
+	tests := []struct {
+		name           string
+		mockedClient   *http.Client
+		requestArgs    map[string]any
+		expectError    bool
+		expectedErrMsg string
+		expectedQuery  string
+		expectedTopic  string
+		expectedGained map[string]int
+	}{
+		{
+			name: "ranks repositories by stars gained",
+			mockedClient: MockHTTPClientWithHandlers(map[string]http.HandlerFunc{
+				GetSearchRepositories: func(w http.ResponseWriter, r *http.Request) {
+					assert.Equal(t, "stars", r.URL.Query().Get("sort"))
+					assert.Contains(t, r.URL.Query().Get("q"), "topic:mcp language:go")
+					mockResponse(t, http.StatusOK, mockSearch)(w, r)
+				},
+				GetReposStargazersByOwnerByRepo: mockStargazerPages(t, stargazers),
+			}),
+			requestArgs: map[string]any{
+				"topic":    "mcp",
+				"language": "go",
+			},
+			expectedQuery:  "topic:mcp language:go",
+			expectedTopic:  "mcp",
+			expectedGained: map[string]int{"rising": 5, "steady": 1},
+		},
+		{
+			name: "finds recently created repositories crowded out by the most starred",
+			mockedClient: MockHTTPClientWithHandlers(map[string]http.HandlerFunc{
+				GetSearchRepositories: func(w http.ResponseWriter, r *http.Request) {
+					assert.Equal(t, "2", r.URL.Query().Get("per_page"))
+					if strings.Contains(r.URL.Query().Get("q"), "created:>=") {
+						mockResponse(t, http.StatusOK, mockNewSearch)(w, r)
+						return
+					}
+					mockResponse(t, http.StatusOK, mockSearch)(w, r)
+				},
+				GetReposStargazersByOwnerByRepo: mockStargazerPages(t, stargazers),
+			}),
+			requestArgs: map[string]any{
+				"language": "go",
+				"limit":    float64(1),
+			},
+			expectedQuery:  "language:go",
+			expectedGained: map[string]int{"newcomer": 6},
+		},
+		{
+			name: "uses most common starred topic",
+			mockedClient: MockHTTPClientWithHandlers(map[string]http.HandlerFunc{
+				GetUserStarred: mockResponse(t, http.StatusOK, []*github.StarredRepository{
+					{Repository: &github.Repository{Topics: []string{"go", "mcp"}}},
+					{Repository: &github.Repository{Topics: []string{"mcp"}}},
+				}),
+				GetSearchRepositories:           mockResponse(t, http.StatusOK, &github.RepositoriesSearchResult{}),
+				GetReposStargazersByOwnerByRepo: mockStargazerPages(t, stargazers),
+			}),
+			requestArgs: map[string]any{
+				"from_starred_topics": true,
+				"window":              "daily",
+			},
+			expectedQuery:  "topic:mcp",
+			expectedTopic:  "mcp",
+			expectedGained: map[string]int{},
+		},
+		{
+			name:           "invalid window",
+			mockedClient:   MockHTTPClientWithHandlers(map[string]http.HandlerFunc{}),
+			requestArgs:    map[string]any{"window": "yearly"},
+			expectError:    true,
+			expectedErrMsg: "invalid window: yearly",
+		},
+		{
+			name:           "limit too large",
+			mockedClient:   MockHTTPClientWithHandlers(map[string]http.HandlerFunc{}),
+			requestArgs:    map[string]any{"limit": float64(100)},
+			expectError:    true,
+			expectedErrMsg: "limit must be between 1 and 25",
+		},
+		{
+			name: "search fails",
+			mockedClient: MockHTTPClientWithHandlers(map[string]http.HandlerFunc{
+				GetSearchRepositories: mockResponse(t, http.StatusUnprocessableEntity, `{"message": "Validation Failed"}`),
+			}),
+			requestArgs:    map[string]any{"language": "go"},
+			expectError:    true,
+			expectedErrMsg: "failed to search repositories",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			deps := BaseDeps{Client: github.NewClient(tc.mockedClient)}
+			handler := serverTool.Handler(deps)
+
+			request := createMCPRequest(tc.requestArgs)
+			result, err := handler(ContextWithDeps(context.Background(), deps), &request)
+			require.NoError(t, err)
+
+			if tc.expectError {
+				errorContent := getErrorResult(t, result)
+				assert.Contains(t, errorContent.Text, tc.expectedErrMsg)
+				return
+			}
+
+			var trending TrendingRepositories
+			require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &trending))
+			require.Len(t, trending.Queries, 2)
+			assert.True(t, strings.HasSuffix(trending.Queries[0], tc.expectedQuery), trending.Queries[0])
+			assert.True(t, strings.HasSuffix(trending.Queries[1], tc.expectedQuery), trending.Queries[1])
+			assert.Contains(t, trending.Queries[1], "created:>=")
+			assert.Equal(t, tc.expectedTopic, trending.Topic)
+			require.Len(t, trending.Repositories, len(tc.expectedGained))
+			for i, repo := range trending.Repositories {
+				assert.Equal(t, tc.expectedGained[repo.Name], repo.StarsGained, repo.Name)
+				assert.False(t, repo.StarsGainedIsLowerBound)
+				if i > 0 {
+					assert.GreaterOrEqual(t, trending.Repositories[i-1].StarsGained, repo.StarsGained)
+				}
+			}
+		})
+	}
+}
+
+func Test_CountStarsSince_LowerBound(t *testing.T) {
+	now := time.Now()
+	pages := make([][]*github.Stargazer, trendingMaxStarPages+1)
+	for i := range pages {
+		pages[i] = stargazersAt(now, now)
+	}
+	client := github.NewClient(MockHTTPClientWithHandlers(map[string]http.HandlerFunc{
+		GetReposStargazersByOwnerByRepo: mockStargazerPages(t, map[string][][]*github.Stargazer{"repo": pages}),
+	}))
+
+	count, lowerBound, _, err := countStarsSince(context.Background(), client, "owner", "repo", now.Add(-time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, 2*trendingMaxStarPages, count)
+	assert.True(t, lowerBound)
+}
@@ -0,0 +1,310 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/github/github-mcp-server/pkg/inventory"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/github/github-mcp-server/pkg/utils"
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+const (
+	// defaultWatchTimeout and maxWatchTimeout bound how long watch_resource polls, in seconds.
+	defaultWatchTimeout = 120
+	maxWatchTimeout     = 900
+	// defaultWatchInterval is the initial delay between polls, in seconds. The delay grows by
+	// half after each poll, up to maxWatchInterval.
+	defaultWatchInterval = 5
+	maxWatchInterval     = 60
+)
+
+// watchClausePattern matches a single comparison of a watch condition, such as "state == closed"
+// or "labels.0.name contains bug".
+var watchClausePattern = regexp.MustCompile(`^\s*([\w.-]+)\s*(==|!=|>=|<=|>|<|\scontains\s)\s*(.*?)\s*$`)
+
+// watchClause compares the value at a dotted path of a JSON result with a literal.
+type watchClause struct {
+	path     []string
+	operator string
+	value    string
+}
+
+// parseWatchCondition parses comparisons joined by "&&". Paths are dotted, with array indexes as
+// numbers, and values may be quoted.
+func parseWatchCondition(condition string) ([]watchClause, error) {
+	var clauses []watchClause
+	for _, part := range strings.Split(condition, "&&") {
+		m := watchClausePattern.FindStringSubmatch(part)
+		if m == nil || m[3] == "" {
+			return nil, fmt.Errorf("invalid condition %q: expected comparisons such as \"state == closed\" joined by &&", strings.TrimSpace(part))
+		}
+		value := m[3]
+		if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') && value[len(value)-1] == value[0] {
+			value = value[1 : len(value)-1]
+		}
+		clauses = append(clauses, watchClause{
+			path:     strings.Split(m[1], "."),
+			operator: strings.TrimSpace(m[2]),
+			value:    value,
+		})
+	}
+	return clauses, nil
+}
+
+// holds reports whether the clause holds for a JSON value. Strings compare case-insensitively,
+// as the REST and GraphQL APIs spell states differently, e.g. "closed" and "CLOSED".
+func (c watchClause) holds(data any) bool {
+	actual := lookupJSONPath(data, c.path)
+	switch c.operator {
+	case "==":
+		return strings.EqualFold(formatJSONValue(actual), c.value)
+	case "!=":
+		return !strings.EqualFold(formatJSONValue(actual), c.value)
+	case "contains":
+		switch v := actual.(type) {
+		case []any:
+			for _, item := range v {
+				if strings.EqualFold(formatJSONValue(item), c.value) {
+					return true
+				}
+			}
+			return false
+		case string:
+			return strings.Contains(strings.ToLower(v), strings.ToLower(c.value))
+		default:
+			return false
+		}
+	default:
+		number, ok := actual.(float64)
+		expected, err := strconv.ParseFloat(c.value, 64)
+		if !ok || err != nil {
+			return false
+		}
+		switch c.operator {
+		case ">":
+			return number > expected
+		case ">=":
+			return number >= expected
+		case "<":
+			return number < expected
+		default:
+			return number <= expected
+		}
+	}
+}
+
+// lookupJSONPath returns the value at path in decoded JSON, or nil if there is none.
+func lookupJSONPath(data any, path []string) any {
+	for _, key := range path {
+		switch v := data.(type) {
+		case map[string]any:
+			data = v[key]
+		case []any:
+			i, err := strconv.Atoi(key)
+			if err != nil || i < 0 || i >= len(v) {
+				return nil
+			}
+			data = v[i]
+		default:
+			return nil
+		}
+	}
+	return data
+}
+
+func formatJSONValue(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return "null"
+	case string:
+		return v
+	case bool:
+		return strconv.FormatBool(v)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	default:
+		b, _ := json.Marshal(v)
+		return string(b)
+	}
+}
+
+// WatchResult is the outcome of a watch_resource call.
+type WatchResult struct {
+	ConditionMet   bool            `json:"condition_met"`
+	Polls          int             `json:"polls"`
+	ElapsedSeconds int             `json:"elapsed_seconds"`
+	Message        string          `json:"message,omitempty"`
+	Result         json.RawMessage `json:"result"`
+}
+
+// findWatchableTool returns the read-only tool watch_resource may poll. Only the tools available
+// to the session are considered, and deprecated names resolve to their replacement.
+func findWatchableTool(ctx context.Context, t translations.TranslationHelperFunc, name string) (inventory.ServerTool, error) {
+	if replacement, ok := DeprecatedToolAliases[name]; ok {
+		name = replacement
+	}
+	var tools []inventory.ServerTool
+	if source, ok := ctx.Value(serverInfoContextKey{}).(*serverInfoSource); ok && source.inv != nil {
+		tools = source.inv.AvailableTools(ctx)
+	} else {
+		tools = AllTools(t)
+	}
+	for _, tool := range tools {
+		if tool.Tool.Name != name {
+			continue
+		}
+		if name == "watch_resource" || !tool.IsReadOnly() {
+			return inventory.ServerTool{}, fmt.Errorf("tool %q cannot be watched: only read-only tools can", name)
+		}
+		return tool, nil
+	}
+	return inventory.ServerTool{}, fmt.Errorf("tool %q is not available", name)
+}
+
+// WatchResource creates a tool that polls another read-only tool until a condition on its result
+// holds, sparing agents from spending turns on polling loops.
+func WatchResource(t translations.TranslationHelperFunc) inventory.ServerTool {
+	return NewTool(
+		ToolsetMetadataContext,
+		mcp.Tool{
+			Name: "watch_resource",
+			Description: t("TOOL_WATCH_RESOURCE_DESCRIPTION", `Wait for a GitHub resource to reach a state by calling a read-only tool repeatedly, with a growing delay, until a condition on its JSON result holds or the timeout passes. Use this instead of calling a tool in a loop, e.g. to wait for a workflow run to complete or a pull request to be merged.
+Conditions compare dotted paths of the result with values, joined by &&: "status == completed && conclusion != failure", "labels.0.name contains bug", "comments >= 3". Operators are ==, !=, >, >=, <, <= and contains. Strings compare case-insensitively.`),
+			Annotations: &mcp.ToolAnnotations{
+				Title:        t("TOOL_WATCH_RESOURCE_USER_TITLE", "Watch resource"),
+				ReadOnlyHint: true,
+			},
+			InputSchema: &jsonschema.Schema{
+				Type: "object",
+				Properties: map[string]*jsonschema.Schema{
+					"tool": {
+						Type:        "string",
+						Description: "Name of the read-only tool to poll, e.g. actions_get",
+					},
+					"arguments": {
+						Type:        "object",
+						Description: "Arguments to call the tool with",
+					},
+					"condition": {
+						Type:        "string",
+						Description: "Condition on the tool's JSON result to wait for, e.g. \"status == completed\"",
+					},
+					"timeout_seconds": {
+						Type:        "number",
+						Description: fmt.Sprintf("How long to wait for the condition, in seconds (default %d, max %d)", defaultWatchTimeout, maxWatchTimeout),
+						Minimum:     jsonschema.Ptr(1.0),
+						Maximum:     jsonschema.Ptr(float64(maxWatchTimeout)),
+					},
+					"interval_seconds": {
+						Type:        "number",
+						Description: fmt.Sprintf("Delay before the second poll, in seconds (default %d). Later delays grow by half, up to %d seconds", defaultWatchInterval, maxWatchInterval),
+						Minimum:     jsonschema.Ptr(1.0),
+						Maximum:     jsonschema.Ptr(float64(maxWatchInterval)),
+					},
+				},
+				Required: []string{"tool", "condition"},
+			},
+		},
+		nil,
+		func(ctx context.Context, deps ToolDependencies, request *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+			toolName, err := RequiredParam[string](args, "tool")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			condition, err := RequiredParam[string](args, "condition")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			toolArgs, err := OptionalParam[map[string]any](args, "arguments")
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			timeoutSeconds, err := OptionalIntParamWithDefault(args, "timeout_seconds", defaultWatchTimeout)
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			intervalSeconds, err := OptionalIntParamWithDefault(args, "interval_seconds", defaultWatchInterval)
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			clauses, err := parseWatchCondition(condition)
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			tool, err := findWatchableTool(ctx, t, toolName)
+			if err != nil {
+				return utils.NewToolResultError(err.Error()), nil, nil
+			}
+			if toolArgs == nil {
+				toolArgs = map[string]any{}
+			}
+			if tool.Tool.Name != toolName {
+				applyDeprecatedAliasCall(DeprecatedToolAliasCalls[toolName], &tool.Tool, toolArgs)
+			}
+
+			unit := watchTimeUnit(ctx)
+			start := time.Now()
+			var watch WatchResult
+			var final *mcp.CallToolResult
+			met, err := pollUntil(ctx, request,
+				time.Duration(min(max(timeoutSeconds, 1), maxWatchTimeout))*unit,
+				time.Duration(min(max(intervalSeconds, 1), maxWatchInterval))*unit,
+				time.Duration(maxWatchInterval)*unit,
+				func(polls int) (bool, string, error) {
+					result, _, err := dispatchTool(ctx, deps, tool, toolArgs)
+					if err != nil {
+						return false, "", err
+					}
+					if result == nil || result.IsError {
+						final = result
+						return true, "", nil
+					}
+					text := ""
+					if len(result.Content) > 0 {
+						if tc, ok := result.Content[0].(*mcp.TextContent); ok {
+							text = tc.Text
+						}
+					}
+					var data any
+					if err := json.Unmarshal([]byte(text), &data); err != nil {
+						final = utils.NewToolResultError(fmt.Sprintf("tool %q did not return a JSON result to evaluate the condition on", tool.Tool.Name))
+						return true, "", nil
+					}
+
+					met := true
+					for _, clause := range clauses {
+						if !clause.holds(data) {
+							met = false
+							break
+						}
+					}
+					watch = WatchResult{
+						ConditionMet:   met,
+						Polls:          polls,
+						ElapsedSeconds: int(math.Round(float64(time.Since(start)) / float64(unit))),
+						Result:         json.RawMessage(text),
+					}
+					return met, fmt.Sprintf("Condition not met after %d polls", polls), nil
+				})
+			if err != nil {
+				return nil, nil, err
+			}
+			if final != nil {
+				return final, nil, nil
+			}
+			if !met {
+				watch.Message = fmt.Sprintf("condition %q did not hold within %d seconds; the result is from the last poll", condition, timeoutSeconds)
+			}
+			return MarshalledTextResult(watch), nil, nil
+		},
+	)
+}
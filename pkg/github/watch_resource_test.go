@@ -0,0 +1,152 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/github/github-mcp-server/internal/toolsnaps"
+	"github.com/github/github-mcp-server/pkg/inventory"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/github/github-mcp-server/pkg/utils"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_parseWatchCondition(t *testing.T) {
+	clauses, err := parseWatchCondition(`status == completed && labels contains "needs review" && comments >= 3`)
+	require.NoError(t, err)
+	require.Len(t, clauses, 3)
+	assert.Equal(t, watchClause{path: []string{"status"}, operator: "==", value: "completed"}, clauses[0])
+	assert.Equal(t, watchClause{path: []string{"labels"}, operator: "contains", value: "needs review"}, clauses[1])
+	assert.Equal(t, watchClause{path: []string{"comments"}, operator: ">=", value: "3"}, clauses[2])
+
+	for _, invalid := range []string{"", "state", "state ==", "state = closed", "a == b &&"} {
+		_, err := parseWatchCondition(invalid)
+		assert.Error(t, err, invalid)
+	}
+}
+
+func Test_watchClause_holds(t *testing.T) {
+	var data any
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"state": "CLOSED",
+		"merged": true,
+		"comments": 4,
+		"title": "Fix the flaky test",
+		"labels": [{"name": "bug"}, {"name": "ci"}],
+		"tags": ["bug", "ci"],
+		"merged_by": null
+	}`), &data))
+
+	for condition, expected := range map[string]bool{
+		"state == closed":         true,
+		"state != open":           true,
+		"merged == true":          true,
+		"merged_by == null":       true,
+		"missing == null":         true,
+		"comments > 3":            true,
+		"comments < 4":            false,
+		"comments <= 4":           true,
+		"state > 3":               false,
+		"title contains flaky":    true,
+		"tags contains CI":        true,
+		"labels.1.name == ci":     true,
+		"labels.2.name == ci":     false,
+		"labels contains bug":     false,
+		"state == 'CLOSED'":       true,
+		"comments == 4":           true,
+		"title contains unstable": false,
+	} {
+		clauses, err := parseWatchCondition(condition)
+		require.NoError(t, err, condition)
+		assert.Equal(t, expected, clauses[0].holds(data), condition)
+	}
+}
+
+func Test_WatchResource(t *testing.T) {
+	serverTool := WatchResource(translations.NullTranslationHelper)
+	tool := serverTool.Tool
+	require.NoError(t, toolsnaps.Test(tool.Name, tool))
+	assert.True(t, tool.Annotations.ReadOnlyHint)
+
+	var polls int
+	statusTool := NewTool(ToolsetMetadataActions,
+		mcp.Tool{Name: "get_status", Annotations: &mcp.ToolAnnotations{ReadOnlyHint: true}}, nil,
+		func(_ context.Context, _ ToolDependencies, _ *mcp.CallToolRequest, args map[string]any) (*mcp.CallToolResult, any, error) {
+			polls++
+			status := "in_progress"
+			if polls >= 3 {
+				status = "completed"
+			}
+			return MarshalledTextResult(map[string]any{"id": args["id"], "status": status}), nil, nil
+		})
+	writeTool := NewTool(ToolsetMetadataActions,
+		mcp.Tool{Name: "rerun", Annotations: &mcp.ToolAnnotations{}}, nil,
+		func(context.Context, ToolDependencies, *mcp.CallToolRequest, map[string]any) (*mcp.CallToolResult, any, error) {
+			return utils.NewToolResultText("{}"), nil, nil
+		})
+	inv, err := inventory.NewBuilder().
+		SetTools([]inventory.ServerTool{statusTool, writeTool, serverTool}).
+		WithToolsets([]string{"all"}).
+		Build()
+	require.NoError(t, err)
+
+	deps := BaseDeps{}
+	ctx := context.WithValue(ContextWithDeps(context.Background(), deps), serverInfoContextKey{}, &serverInfoSource{cfg: &MCPServerConfig{}, inv: inv})
+	ctx = context.WithValue(ctx, watchTimeUnitKey{}, time.Millisecond)
+	handler := serverTool.Handler(deps)
+	watch := func(args map[string]any) *mcp.CallToolResult {
+		polls = 0
+		request := createMCPRequest(args)
+		result, err := handler(ctx, &request)
+		require.NoError(t, err)
+		return result
+	}
+
+	t.Run("polls until the condition holds", func(t *testing.T) {
+		result := watch(map[string]any{
+			"tool":      "get_status",
+			"arguments": map[string]any{"id": 7},
+			"condition": "status == completed",
+		})
+		var watched WatchResult
+		require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &watched))
+		assert.True(t, watched.ConditionMet)
+		assert.Equal(t, 3, watched.Polls)
+		assert.JSONEq(t, `{"id": 7, "status": "completed"}`, string(watched.Result))
+	})
+
+	t.Run("returns the last result on timeout", func(t *testing.T) {
+		result := watch(map[string]any{
+			"tool":             "get_status",
+			"condition":        "status == cancelled",
+			"timeout_seconds":  20,
+			"interval_seconds": 1,
+		})
+		var watched WatchResult
+		require.NoError(t, json.Unmarshal([]byte(getTextResult(t, result).Text), &watched))
+		assert.False(t, watched.ConditionMet)
+		assert.Greater(t, watched.Polls, 3)
+		assert.Contains(t, watched.Message, "did not hold within 20 seconds")
+	})
+
+	t.Run("rejects tools that cannot be watched", func(t *testing.T) {
+		for name, message := range map[string]string{
+			"rerun":          `tool "rerun" cannot be watched`,
+			"watch_resource": `tool "watch_resource" cannot be watched`,
+			"list_issues":    `tool "list_issues" is not available`,
+		} {
+			result := watch(map[string]any{"tool": name, "condition": "state == closed"})
+			assert.Contains(t, getErrorResult(t, result).Text, message)
+			assert.Zero(t, polls)
+		}
+	})
+
+	t.Run("rejects invalid conditions", func(t *testing.T) {
+		result := watch(map[string]any{"tool": "get_status", "condition": "status is completed"})
+		assert.Contains(t, getErrorResult(t, result).Text, "invalid condition")
+	})
+}
@@ -0,0 +1,160 @@
+package github
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/github/github-mcp-server/pkg/http/transport"
+	"github.com/github/github-mcp-server/pkg/utils"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+const (
+	// writeQuotaWindow is the sliding window over which write tool calls are counted.
+	writeQuotaWindow = time.Hour
+	// writeApprovalTimeout bounds how long an approval webhook may take to answer.
+	writeApprovalTimeout = 30 * time.Second
+)
+
+// WriteQuotaConfig limits the write tool calls a session may make without approval.
+type WriteQuotaConfig struct {
+	// PerHour is the number of write tool calls a session may make in any hour. Zero disables the quota.
+	PerHour int
+
+	// ApprovalWebhookURL, when set, is asked to approve each write tool call beyond the quota.
+	// Otherwise the user is asked through elicitation, if the client supports it.
+	ApprovalWebhookURL string
+
+	// HTTPClient is used to call the approval webhook. Defaults to http.DefaultClient.
+	HTTPClient *http.Client
+}
+
+// WriteApprovalRequest is the JSON body POSTed to the approval webhook. The webhook approves the
+// call by answering with a 2xx status and a WriteApprovalResponse whose approved field is true.
+type WriteApprovalRequest struct {
+	SessionID        string          `json:"session_id"`
+	Tool             string          `json:"tool"`
+	Arguments        json.RawMessage `json:"arguments,omitempty"`
+	WritesInLastHour int             `json:"writes_in_last_hour"`
+	QuotaPerHour     int             `json:"quota_per_hour"`
+}
+
+// WriteApprovalResponse is the JSON body the approval webhook answers with.
+type WriteApprovalResponse struct {
+	Approved bool   `json:"approved"`
+	Reason   string `json:"reason,omitempty"`
+}
+
+// sessionWrites holds the times of the recent write tool calls of each session.
+var sessionWrites = newSessionStore[[]time.Time](sessionStoreMaxSessions)
+
+// WriteQuotaMiddleware enforces cfg on the tool calls for which isWriteTool returns true. Calls
+// beyond the quota go ahead only once approved by the webhook or the user.
+func WriteQuotaMiddleware(cfg WriteQuotaConfig, isWriteTool func(name string) bool) mcp.Middleware {
+	return func(next mcp.MethodHandler) mcp.MethodHandler {
+		return func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+			callReq, ok := req.(*mcp.CallToolRequest)
+			// Dry runs change nothing, so they do not count toward the quota
+			if method != "tools/call" || !ok || callReq.Params == nil || !isWriteTool(callReq.Params.Name) || transport.DryRunFromContext(ctx) != nil {
+				return next(ctx, method, req)
+			}
+
+			id := sessionID(callReq)
+			writes := recentWrites(id)
+			if writes >= cfg.PerHour {
+				approved, reason, err := approveWrite(ctx, cfg, callReq, writes)
+				if err != nil {
+					return utils.NewToolResultErrorFromErr(fmt.Sprintf("write quota of %d per hour reached and approval failed", cfg.PerHour), err), nil
+				}
+				if !approved {
+					msg := fmt.Sprintf("write quota of %d per hour reached and %s was not approved", cfg.PerHour, callReq.Params.Name)
+					if reason != "" {
+						msg += ": " + reason
+					}
+					return utils.NewToolResultError(msg), nil
+				}
+			}
+
+			sessionWrites.update(id, func(times *[]time.Time) {
+				*times = append(*times, time.Now())
+			})
+			return next(ctx, method, req)
+		}
+	}
+}
+
+// recentWrites returns the number of write tool calls a session made within writeQuotaWindow.
+func recentWrites(sessionID string) int {
+	var count int
+	sessionWrites.update(sessionID, func(times *[]time.Time) {
+		cutoff := time.Now().Add(-writeQuotaWindow)
+		kept := (*times)[:0]
+		for _, t := range *times {
+			if t.After(cutoff) {
+				kept = append(kept, t)
+			}
+		}
+		*times = kept
+		count = len(kept)
+	})
+	return count
+}
+
+// approveWrite asks the approval webhook, or else the user, whether a write tool call beyond the
+// quota may go ahead. Without either, the call is not approved.
+func approveWrite(ctx context.Context, cfg WriteQuotaConfig, req *mcp.CallToolRequest, writes int) (bool, string, error) {
+	if cfg.ApprovalWebhookURL != "" {
+		return callApprovalWebhook(ctx, cfg, WriteApprovalRequest{
+			SessionID:        sessionID(req),
+			Tool:             req.Params.Name,
+			Arguments:        req.Params.Arguments,
+			WritesInLastHour: writes,
+			QuotaPerHour:     cfg.PerHour,
+		})
+	}
+	if clientSupportsElicitation(req) {
+		approved, err := confirmAction(ctx, req, fmt.Sprintf(
+			"The agent has made %d write operations in the last hour, reaching the quota of %d. Allow it to call %s?",
+			writes, cfg.PerHour, req.Params.Name))
+		return approved, "", err
+	}
+	return false, "no approval webhook is configured and the client does not support elicitation", nil
+}
+
+func callApprovalWebhook(ctx context.Context, cfg WriteQuotaConfig, approval WriteApprovalRequest) (bool, string, error) {
+	body, err := json.Marshal(approval)
+	if err != nil {
+		return false, "", fmt.Errorf("failed to marshal approval request: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, writeApprovalTimeout)
+	defer cancel()
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, cfg.ApprovalWebhookURL, bytes.NewReader(body))
+	if err != nil {
+		return false, "", fmt.Errorf("failed to create approval request: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	client := cfg.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(httpReq)
+	if err != nil {
+		return false, "", fmt.Errorf("failed to call approval webhook: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return false, fmt.Sprintf("approval webhook answered with status %d", resp.StatusCode), nil
+	}
+	var answer WriteApprovalResponse
+	if err := json.NewDecoder(resp.Body).Decode(&answer); err != nil {
+		return false, "", fmt.Errorf("failed to decode approval webhook response: %w", err)
+	}
+	return answer.Approved, answer.Reason, nil
+}
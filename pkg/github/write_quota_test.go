@@ -0,0 +1,99 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/github/github-mcp-server/pkg/utils"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_WriteQuotaMiddleware(t *testing.T) {
+	isWriteTool := func(name string) bool { return name == "create_issue" }
+	next := func(_ context.Context, _ string, _ mcp.Request) (mcp.Result, error) {
+		return utils.NewToolResultText("ok"), nil
+	}
+
+	tests := []struct {
+		name           string
+		webhook        func(t *testing.T) http.HandlerFunc
+		calls          []string
+		expectedErrors []string
+	}{
+		{
+			name:           "reads are not counted",
+			calls:          []string{"create_issue", "issue_read", "issue_read", "create_issue"},
+			expectedErrors: []string{"", "", "", "write quota of 1 per hour reached and create_issue was not approved: no approval webhook is configured"},
+		},
+		{
+			name: "webhook approves",
+			webhook: func(t *testing.T) http.HandlerFunc {
+				return func(w http.ResponseWriter, r *http.Request) {
+					var approval WriteApprovalRequest
+					require.NoError(t, json.NewDecoder(r.Body).Decode(&approval))
+					assert.Equal(t, "create_issue", approval.Tool)
+					assert.Equal(t, 1, approval.WritesInLastHour)
+					assert.Equal(t, 1, approval.QuotaPerHour)
+					assert.JSONEq(t, `{"title":"t"}`, string(approval.Arguments))
+					_ = json.NewEncoder(w).Encode(WriteApprovalResponse{Approved: true})
+				}
+			},
+			calls:          []string{"create_issue", "create_issue"},
+			expectedErrors: []string{"", ""},
+		},
+		{
+			name: "webhook denies with reason",
+			webhook: func(_ *testing.T) http.HandlerFunc {
+				return func(w http.ResponseWriter, _ *http.Request) {
+					_ = json.NewEncoder(w).Encode(WriteApprovalResponse{Approved: false, Reason: "outside change window"})
+				}
+			},
+			calls:          []string{"create_issue", "create_issue"},
+			expectedErrors: []string{"", "create_issue was not approved: outside change window"},
+		},
+		{
+			name: "webhook error status",
+			webhook: func(_ *testing.T) http.HandlerFunc {
+				return func(w http.ResponseWriter, _ *http.Request) {
+					w.WriteHeader(http.StatusForbidden)
+				}
+			},
+			calls:          []string{"create_issue", "create_issue"},
+			expectedErrors: []string{"", "approval webhook answered with status 403"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			sessionWrites.delete("")
+			t.Cleanup(func() { sessionWrites.delete("") })
+
+			cfg := WriteQuotaConfig{PerHour: 1}
+			if tc.webhook != nil {
+				server := httptest.NewServer(tc.webhook(t))
+				t.Cleanup(server.Close)
+				cfg.ApprovalWebhookURL = server.URL
+			}
+			handler := WriteQuotaMiddleware(cfg, isWriteTool)(next)
+
+			for i, name := range tc.calls {
+				result, err := handler(context.Background(), "tools/call", &mcp.CallToolRequest{
+					Params: &mcp.CallToolParamsRaw{Name: name, Arguments: json.RawMessage(`{"title":"t"}`)},
+				})
+				require.NoError(t, err)
+				toolResult := result.(*mcp.CallToolResult)
+				if tc.expectedErrors[i] == "" {
+					assert.False(t, toolResult.IsError, "call %d", i)
+					continue
+				}
+				require.True(t, toolResult.IsError, "call %d", i)
+				assert.Contains(t, getTextResult(t, toolResult).Text, tc.expectedErrors[i])
+			}
+		})
+	}
+}
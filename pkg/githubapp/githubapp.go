@@ -0,0 +1,187 @@
+// Package githubapp authenticates with the GitHub API as a GitHub App installation, for
+// organizations that do not allow personal access tokens.
+package githubapp
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+
+	headers "github.com/github/github-mcp-server/pkg/http/headers"
+	gogithub "github.com/google/go-github/v82/github"
+)
+
+const (
+	// jwtLifetime is how long the JSON Web Tokens authenticating as the app are valid. GitHub
+	// accepts at most 10 minutes.
+	jwtLifetime = 9 * time.Minute
+
+	// jwtClockSkew backdates the JSON Web Tokens to allow for clock drift.
+	jwtClockSkew = time.Minute
+
+	// refreshBefore is how long before its expiry an installation token is replaced, so that
+	// requests in flight do not fail.
+	refreshBefore = 5 * time.Minute
+)
+
+// ParsePrivateKey parses the PEM encoded private key of a GitHub App, in PKCS #1 or PKCS #8 form.
+func ParsePrivateKey(pemBytes []byte) (*rsa.PrivateKey, error) {
+	block, _ := pem.Decode(pemBytes)
+	if block == nil {
+		return nil, errors.New("the GitHub App private key is not PEM encoded")
+	}
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the GitHub App private key: %w", err)
+	}
+	key, ok := parsed.(*rsa.PrivateKey)
+	if !ok {
+		return nil, errors.New("the GitHub App private key is not an RSA key")
+	}
+	return key, nil
+}
+
+// InstallationTokenSource provides installation access tokens of a GitHub App, creating a new
+// one shortly before the current one expires. It is a transport.TokenSource, and is safe for
+// concurrent use.
+type InstallationTokenSource struct {
+	appID          int64
+	key            *rsa.PrivateKey
+	installationID int64
+	client         *gogithub.Client
+	now            func() time.Time
+
+	mu        sync.Mutex
+	token     string
+	expiresAt time.Time
+}
+
+// NewInstallationTokenSource creates an InstallationTokenSource for the installation of an app.
+// When installationID is zero, the app must have exactly one installation, which is used.
+// Tokens are created through the REST API at baseURL with httpClient, or
+// http.DefaultClient when it is nil.
+func NewInstallationTokenSource(appID int64, privateKey []byte, installationID int64, baseURL *url.URL, httpClient *http.Client) (*InstallationTokenSource, error) {
+	if appID == 0 {
+		return nil, errors.New("a GitHub App ID is required")
+	}
+	key, err := ParsePrivateKey(privateKey)
+	if err != nil {
+		return nil, err
+	}
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
+	s := &InstallationTokenSource{
+		appID:          appID,
+		key:            key,
+		installationID: installationID,
+		now:            time.Now,
+	}
+	transport := httpClient.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	s.client = gogithub.NewClient(&http.Client{Transport: &appTransport{source: s, transport: transport}})
+	if baseURL != nil {
+		s.client.BaseURL = baseURL
+	}
+	return s, nil
+}
+
+// Token returns a valid installation access token.
+func (s *InstallationTokenSource) Token(ctx context.Context) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.token != "" && s.now().Before(s.expiresAt.Add(-refreshBefore)) {
+		return s.token, nil
+	}
+
+	if s.installationID == 0 {
+		installations, _, err := s.client.Apps.ListInstallations(ctx, &gogithub.ListOptions{PerPage: 2})
+		if err != nil {
+			return "", fmt.Errorf("failed to list the installations of GitHub App %d: %w", s.appID, err)
+		}
+		if len(installations) != 1 {
+			return "", fmt.Errorf("GitHub App %d has %d installations; set the installation ID to choose one", s.appID, len(installations))
+		}
+		s.installationID = installations[0].GetID()
+	}
+
+	token, _, err := s.client.Apps.CreateInstallationToken(ctx, s.installationID, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to create an installation token for GitHub App %d: %w", s.appID, err)
+	}
+	s.token = token.GetToken()
+	s.expiresAt = token.GetExpiresAt().Time
+	return s.token, nil
+}
+
+// Invalidate discards token if it is the current installation token, so that the next call to
+// Token creates a new one.
+func (s *InstallationTokenSource) Invalidate(token string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.token == token {
+		s.token = ""
+	}
+}
+
+// jwt returns a JSON Web Token authenticating as the app itself, which can only call the
+// endpoints managing its installations.
+func (s *InstallationTokenSource) jwt() (string, error) {
+	now := s.now()
+	header, err := json.Marshal(map[string]string{"alg": "RS256", "typ": "JWT"})
+	if err != nil {
+		return "", err
+	}
+	claims, err := json.Marshal(map[string]any{
+		"iat": now.Add(-jwtClockSkew).Unix(),
+		"exp": now.Add(jwtLifetime).Unix(),
+		"iss": strconv.FormatInt(s.appID, 10),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	unsigned := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(claims)
+	digest := sha256.Sum256([]byte(unsigned))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, s.key, crypto.SHA256, digest[:])
+	if err != nil {
+		return "", fmt.Errorf("failed to sign the GitHub App JSON Web Token: %w", err)
+	}
+	return unsigned + "." + base64.RawURLEncoding.EncodeToString(signature), nil
+}
+
+// appTransport authenticates the requests of the source's own client as the app.
+type appTransport struct {
+	source    *InstallationTokenSource
+	transport http.RoundTripper
+}
+
+func (t *appTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	jwt, err := t.source.jwt()
+	if err != nil {
+		return nil, err
+	}
+	req = req.Clone(req.Context())
+	req.Header.Set(headers.AuthorizationHeader, "Bearer "+jwt)
+	return t.transport.RoundTrip(req)
+}
@@ -0,0 +1,130 @@
+package githubapp
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func generateKey(t *testing.T) (*rsa.PrivateKey, []byte) {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	return key, pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+}
+
+// verifyJWT checks the signature of a JSON Web Token and returns its claims.
+func verifyJWT(t *testing.T, key *rsa.PrivateKey, token string) map[string]any {
+	t.Helper()
+	parts := strings.Split(token, ".")
+	require.Len(t, parts, 3)
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	require.NoError(t, err)
+	digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+	require.NoError(t, rsa.VerifyPKCS1v15(&key.PublicKey, crypto.SHA256, digest[:], signature))
+
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	require.NoError(t, err)
+	var claims map[string]any
+	require.NoError(t, json.Unmarshal(payload, &claims))
+	return claims
+}
+
+func TestParsePrivateKey(t *testing.T) {
+	key, pkcs1 := generateKey(t)
+
+	parsed, err := ParsePrivateKey(pkcs1)
+	require.NoError(t, err)
+	assert.True(t, key.Equal(parsed))
+
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+	parsed, err = ParsePrivateKey(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}))
+	require.NoError(t, err)
+	assert.True(t, key.Equal(parsed))
+
+	_, err = ParsePrivateKey([]byte("not a key"))
+	assert.ErrorContains(t, err, "not PEM encoded")
+}
+
+func TestInstallationTokenSource(t *testing.T) {
+	key, pemKey := generateKey(t)
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	tokensCreated := 0
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /app/installations", func(w http.ResponseWriter, r *http.Request) {
+		claims := verifyJWT(t, key, strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer "))
+		assert.Equal(t, "42", claims["iss"])
+		_, _ = w.Write([]byte(`[{"id": 7}]`))
+	})
+	mux.HandleFunc("POST /app/installations/7/access_tokens", func(w http.ResponseWriter, r *http.Request) {
+		verifyJWT(t, key, strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer "))
+		tokensCreated++
+		w.WriteHeader(http.StatusCreated)
+		_, _ = fmt.Fprintf(w, `{"token": "ghs_%d", "expires_at": %q}`, tokensCreated, now.Add(time.Hour).Format(time.RFC3339))
+	})
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	baseURL, err := url.Parse(server.URL + "/")
+	require.NoError(t, err)
+
+	source, err := NewInstallationTokenSource(42, pemKey, 0, baseURL, nil)
+	require.NoError(t, err)
+	source.now = func() time.Time { return now }
+
+	token, err := source.Token(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "ghs_1", token)
+
+	// The token is reused until shortly before it expires
+	now = now.Add(50 * time.Minute)
+	token, err = source.Token(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "ghs_1", token)
+
+	now = now.Add(6 * time.Minute)
+	token, err = source.Token(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "ghs_2", token)
+
+	// Rejected tokens are replaced
+	source.Invalidate("ghs_1")
+	token, err = source.Token(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "ghs_2", token)
+	source.Invalidate("ghs_2")
+	token, err = source.Token(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "ghs_3", token)
+}
+
+func TestInstallationTokenSourceRequiresInstallation(t *testing.T) {
+	_, pemKey := generateKey(t)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`[{"id": 7}, {"id": 8}]`))
+	}))
+	t.Cleanup(server.Close)
+	baseURL, err := url.Parse(server.URL + "/")
+	require.NoError(t, err)
+
+	source, err := NewInstallationTokenSource(42, pemKey, 0, baseURL, nil)
+	require.NoError(t, err)
+	_, err = source.Token(context.Background())
+	assert.ErrorContains(t, err, "has 2 installations")
+}
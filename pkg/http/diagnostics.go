@@ -0,0 +1,116 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"slices"
+	"strings"
+
+	ghcontext "github.com/github/github-mcp-server/pkg/context"
+	"github.com/github/github-mcp-server/pkg/github"
+	"github.com/github/github-mcp-server/pkg/http/headers"
+	"github.com/github/github-mcp-server/pkg/utils"
+)
+
+// ScopeDiagnosticsPath serves the scope diagnostics of the token making the request.
+const ScopeDiagnosticsPath = "/diagnostics/scopes"
+
+// ScopeDiagnostics reports which tools scope filtering hides from a token, and why.
+type ScopeDiagnostics struct {
+	TokenType string `json:"token_type"`
+	// TokenScopes are the OAuth scopes GitHub reports for the token, if it reports any.
+	TokenScopes []string `json:"token_scopes"`
+	// ScopeFiltering is true when tools the token lacks scopes for are hidden from it.
+	ScopeFiltering bool `json:"scope_filtering"`
+	// ScopeChallenge is true when calls to tools the token lacks scopes for are answered with
+	// an OAuth scope challenge instead.
+	ScopeChallenge bool   `json:"scope_challenge"`
+	Note           string `json:"note,omitempty"`
+	// FilteredTools counts the tools the scope filter rejects. They are only hidden when
+	// ScopeFiltering is true, so for other tokens the report is a dry run.
+	FilteredTools int                        `json:"filtered_tools"`
+	Tools         []github.ToolScopeDecision `json:"tools"`
+}
+
+// scopeDiagnostics reports, for the token in the Authorization header, the decision
+// CreateToolScopeFilter makes for each tool, whether or not the filter applies to the token.
+// Requests without a token are rejected by the ExtractUserToken middleware. The tools query
+// parameter limits the report to a comma-separated list of tools.
+func (h *Handler) scopeDiagnostics(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	tokenInfo, ok := ghcontext.GetTokenInfo(ctx)
+	if !ok || tokenInfo == nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	diagnostics := ScopeDiagnostics{
+		TokenType:      tokenTypeName(tokenInfo.TokenType),
+		TokenScopes:    []string{},
+		ScopeFiltering: tokenInfo.TokenType == utils.TokenTypePersonalAccessToken,
+		ScopeChallenge: h.config.ScopeChallenge && tokenInfo.TokenType == utils.TokenTypeOAuthAccessToken,
+		Tools:          []github.ToolScopeDecision{},
+	}
+
+	if tokenInfo.TokenType == utils.TokenTypePersonalAccessToken || tokenInfo.TokenType == utils.TokenTypeOAuthAccessToken {
+		tokenScopes, ok := ghcontext.GetTokenScopes(ctx)
+		if !ok {
+			var err error
+			tokenScopes, err = h.scopeFetcher.FetchTokenScopes(ctx, tokenInfo.Token)
+			if err != nil {
+				h.logger.Warn("failed to fetch token scopes for diagnostics", "error", err)
+				http.Error(w, "failed to fetch token scopes", http.StatusBadGateway)
+				return
+			}
+		}
+		diagnostics.TokenScopes = append(diagnostics.TokenScopes, tokenScopes...)
+	}
+
+	switch {
+	case diagnostics.ScopeFiltering:
+		diagnostics.Note = "Tools the token has no accepted scope for are hidden from tools/list."
+	case diagnostics.ScopeChallenge:
+		diagnostics.Note = "No tools are hidden. Calls to the filtered tools are answered with an OAuth scope challenge."
+	case tokenInfo.TokenType == utils.TokenTypeOAuthAccessToken:
+		diagnostics.Note = "No tools are hidden, and scope challenges are disabled. Calls to the filtered tools fail with a GitHub API error."
+	default:
+		diagnostics.Note = "No tools are hidden. GitHub does not report OAuth scopes for this token type, so its permissions are only checked by the API when a tool is called."
+	}
+
+	requested := headers.ParseCommaSeparated(r.URL.Query().Get("tools"))
+	for _, tool := range github.AllTools(h.t) {
+		if len(requested) > 0 && !slices.Contains(requested, tool.Tool.Name) {
+			continue
+		}
+		decision := github.ExplainToolScopeFilter(diagnostics.TokenScopes, &tool)
+		if !decision.Visible {
+			diagnostics.FilteredTools++
+		}
+		diagnostics.Tools = append(diagnostics.Tools, decision)
+	}
+	slices.SortFunc(diagnostics.Tools, func(a, b github.ToolScopeDecision) int {
+		return strings.Compare(a.Tool, b.Tool)
+	})
+
+	w.Header().Set(headers.ContentTypeHeader, headers.ContentTypeJSON)
+	if err := json.NewEncoder(w).Encode(diagnostics); err != nil {
+		h.logger.Error("failed to write response", "error", err)
+	}
+}
+
+func tokenTypeName(tokenType utils.TokenType) string {
+	switch tokenType {
+	case utils.TokenTypePersonalAccessToken:
+		return "personal_access_token"
+	case utils.TokenTypeFineGrainedPersonalAccessToken:
+		return "fine_grained_personal_access_token"
+	case utils.TokenTypeOAuthAccessToken:
+		return "oauth_access_token"
+	case utils.TokenTypeUserToServerGitHubAppToken:
+		return "github_app_user_token"
+	case utils.TokenTypeServerToServerGitHubAppToken:
+		return "github_app_installation_token"
+	default:
+		return "unknown"
+	}
+}
@@ -0,0 +1,89 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/github/github-mcp-server/pkg/http/headers"
+	"github.com/github/github-mcp-server/pkg/scopes"
+	"github.com/github/github-mcp-server/pkg/translations"
+	"github.com/github/github-mcp-server/pkg/utils"
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fixedScopesFetcher []string
+
+func (f fixedScopesFetcher) FetchTokenScopes(_ context.Context, _ string) ([]string, error) {
+	return f, nil
+}
+
+func TestScopeDiagnostics(t *testing.T) {
+	apiHost, err := utils.NewAPIHost("https://api.github.com")
+	require.NoError(t, err)
+
+	handler := NewHTTPMcpHandler(
+		context.Background(),
+		&ServerConfig{Version: "test"},
+		nil,
+		translations.NullTranslationHelper,
+		slog.Default(),
+		apiHost,
+		WithScopeFetcher(fixedScopesFetcher{string(scopes.ReadOrg)}),
+	)
+	r := chi.NewRouter()
+	handler.RegisterMiddleware(r)
+	handler.RegisterRoutes(r)
+
+	t.Run("requires a token", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, ScopeDiagnosticsPath, nil))
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	})
+
+	t.Run("explains the decision for each tool", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, ScopeDiagnosticsPath+"?tools=create_repository,get_file_contents,get_me", nil)
+		req.Header.Set(headers.AuthorizationHeader, "Bearer ghp_testtoken")
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+		require.Equal(t, http.StatusOK, rr.Code)
+
+		var diagnostics ScopeDiagnostics
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &diagnostics))
+		assert.Equal(t, "personal_access_token", diagnostics.TokenType)
+		assert.Equal(t, []string{"read:org"}, diagnostics.TokenScopes)
+		assert.True(t, diagnostics.ScopeFiltering)
+		assert.Equal(t, 1, diagnostics.FilteredTools)
+
+		require.Len(t, diagnostics.Tools, 3)
+		assert.Equal(t, "create_repository", diagnostics.Tools[0].Tool)
+		assert.False(t, diagnostics.Tools[0].Visible)
+		assert.Contains(t, diagnostics.Tools[0].Reason, "the token has none of the accepted scopes: repo")
+		assert.Equal(t, "get_file_contents", diagnostics.Tools[1].Tool)
+		assert.True(t, diagnostics.Tools[1].Visible)
+		assert.Contains(t, diagnostics.Tools[1].Reason, "public repositories")
+		assert.Equal(t, "get_me", diagnostics.Tools[2].Tool)
+		assert.True(t, diagnostics.Tools[2].Visible)
+		assert.Equal(t, "the tool requires no scopes", diagnostics.Tools[2].Reason)
+	})
+
+	t.Run("reports a dry run for tokens without scope filtering", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, ScopeDiagnosticsPath+"?tools=create_repository", nil)
+		req.Header.Set(headers.AuthorizationHeader, "Bearer github_pat_testtoken")
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+		require.Equal(t, http.StatusOK, rr.Code)
+
+		var diagnostics ScopeDiagnostics
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &diagnostics))
+		assert.Equal(t, "fine_grained_personal_access_token", diagnostics.TokenType)
+		assert.False(t, diagnostics.ScopeFiltering)
+		assert.Empty(t, diagnostics.TokenScopes)
+		assert.Contains(t, diagnostics.Note, "No tools are hidden")
+	})
+}
@@ -6,11 +6,13 @@ import (
 	"log/slog"
 	"net/http"
 
+	"github.com/github/github-mcp-server/pkg/audit"
 	ghcontext "github.com/github/github-mcp-server/pkg/context"
 	"github.com/github/github-mcp-server/pkg/github"
 	"github.com/github/github-mcp-server/pkg/http/middleware"
 	"github.com/github/github-mcp-server/pkg/http/oauth"
 	"github.com/github/github-mcp-server/pkg/inventory"
+	"github.com/github/github-mcp-server/pkg/observability/tracing"
 	"github.com/github/github-mcp-server/pkg/scopes"
 	"github.com/github/github-mcp-server/pkg/translations"
 	"github.com/github/github-mcp-server/pkg/utils"
@@ -36,6 +38,10 @@ type Handler struct {
 	oauthCfg               *oauth.Config
 	scopeFetcher           scopes.FetcherInterface
 	schemaCache            *mcp.SchemaCache
+	contentWindowOverrides github.ContentWindowOverrides
+	commentTemplates       github.CommentTemplates
+	tracer                 tracing.Tracer
+	auditLog               audit.Sink
 }
 
 type HandlerOptions struct {
@@ -44,6 +50,10 @@ type HandlerOptions struct {
 	OAuthConfig            *oauth.Config
 	ScopeFetcher           scopes.FetcherInterface
 	FeatureChecker         inventory.FeatureFlagChecker
+	ContentWindowOverrides github.ContentWindowOverrides
+	CommentTemplates       github.CommentTemplates
+	Tracer                 tracing.Tracer
+	AuditLog               audit.Sink
 }
 
 type HandlerOption func(*HandlerOptions)
@@ -78,6 +88,33 @@ func WithFeatureChecker(checker inventory.FeatureFlagChecker) HandlerOption {
 	}
 }
 
+func WithContentWindowOverrides(overrides github.ContentWindowOverrides) HandlerOption {
+	return func(o *HandlerOptions) {
+		o.ContentWindowOverrides = overrides
+	}
+}
+
+// WithCommentTemplates makes templates available to create_issue_comment_from_template.
+func WithCommentTemplates(templates github.CommentTemplates) HandlerOption {
+	return func(o *HandlerOptions) {
+		o.CommentTemplates = templates
+	}
+}
+
+// WithTracer records spans of the MCP requests the handler serves.
+func WithTracer(tracer tracing.Tracer) HandlerOption {
+	return func(o *HandlerOptions) {
+		o.Tracer = tracer
+	}
+}
+
+// WithAuditLog records the write tool calls the handler serves in auditLog.
+func WithAuditLog(auditLog audit.Sink) HandlerOption {
+	return func(o *HandlerOptions) {
+		o.AuditLog = auditLog
+	}
+}
+
 func NewHTTPMcpHandler(
 	ctx context.Context,
 	cfg *ServerConfig,
@@ -122,6 +159,10 @@ func NewHTTPMcpHandler(
 		oauthCfg:               opts.OAuthConfig,
 		scopeFetcher:           scopeFetcher,
 		schemaCache:            schemaCache,
+		contentWindowOverrides: opts.ContentWindowOverrides,
+		commentTemplates:       opts.CommentTemplates,
+		tracer:                 opts.Tracer,
+		auditLog:               opts.AuditLog,
 	}
 }
 
@@ -129,7 +170,9 @@ func (h *Handler) RegisterMiddleware(r chi.Router) {
 	r.Use(
 		middleware.ExtractUserToken(h.oauthCfg),
 		middleware.WithRequestConfig,
+		middleware.WithQueryFeatures(h.config.FeaturesQuerySigningKey),
 		middleware.WithMCPParse(),
+		middleware.WithToolNamePrefix(h.config.ToolNamePrefix),
 		middleware.WithPATScopes(h.logger, h.scopeFetcher),
 	)
 
@@ -141,6 +184,8 @@ func (h *Handler) RegisterMiddleware(r chi.Router) {
 // RegisterRoutes registers the routes for the MCP server
 // URL-based values take precedence over header-based values
 func (h *Handler) RegisterRoutes(r chi.Router) {
+	r.Get(ScopeDiagnosticsPath, h.scopeDiagnostics)
+
 	// Base routes
 	r.Mount("/", h)
 	r.With(withReadonly).Mount("/readonly", h)
@@ -200,11 +245,33 @@ func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	}
 
 	ghServer, err := h.githubMcpServerFactory(r, h.deps, invToUse, &github.MCPServerConfig{
-		Version:           h.config.Version,
-		Translator:        h.t,
-		ContentWindowSize: h.config.ContentWindowSize,
-		Logger:            h.logger,
-		RepoAccessTTL:     h.config.RepoAccessCacheTTL,
+		Version:                h.config.Version,
+		Commit:                 h.config.Commit,
+		BuildDate:              h.config.BuildDate,
+		Host:                   h.config.Host,
+		ReadOnly:               h.config.ReadOnly || ghcontext.IsReadonly(r.Context()),
+		Translator:             h.t,
+		ContentWindowSize:      h.config.ContentWindowSize,
+		ContentWindowOverrides: h.contentWindowOverrides,
+		Logger:                 h.logger,
+		RepoAccessTTL:          h.config.RepoAccessCacheTTL,
+		ToolNamePrefix:         h.config.ToolNamePrefix,
+		EstimateTokens:         h.config.EstimateTokens,
+		RootsEnforcement:       github.RootsEnforcement(h.config.RootsEnforcement),
+		EventWebhook: github.EventWebhookConfig{
+			URL:    h.config.EventWebhookURL,
+			Secret: h.config.EventWebhookSecret,
+			Events: h.config.EventWebhookEvents,
+		},
+		CursorSigningKey: h.config.CursorSigningKey,
+		Tracer:           h.tracer,
+		AuditLog:         h.auditLog,
+		DryRun:           h.config.DryRun,
+		RepoFilter: &github.RepoFilter{
+			ExcludeArchived: !h.config.IncludeArchivedRepos,
+			ExcludeForks:    !h.config.IncludeForks,
+		},
+		CommentTemplates: h.commentTemplates,
 		// Explicitly set empty capabilities. inv.ForMCPRequest currently returns nothing for Initialize.
 		ServerOptions: []github.MCPServerOption{
 			func(so *mcp.ServerOptions) {
@@ -274,6 +341,7 @@ func DefaultInventoryFactory(cfg *ServerConfig, t translations.TranslationHelper
 		if cfg.ReadOnly {
 			b = b.WithReadOnly(true)
 		}
+		b = b.WithoutDestructive(cfg.WithoutDestructive).OnlyIdempotent(cfg.OnlyIdempotent)
 
 		// Filter request tool names to only those in the static universe,
 		// so requests for statically-excluded tools degrade gracefully.
@@ -283,6 +351,9 @@ func DefaultInventoryFactory(cfg *ServerConfig, t translations.TranslationHelper
 
 		b = InventoryFiltersForRequest(r, b)
 		b = PATScopeFilter(b, r, scopeFetcher)
+		if tokenInfo, ok := ghcontext.GetTokenInfo(r.Context()); ok && tokenInfo != nil {
+			b = b.WithFilter(github.CreateTokenTypeFilter(tokenInfo.TokenType))
+		}
 
 		b.WithServerInstructions()
 
@@ -53,4 +53,12 @@ const (
 	GraphQLFeaturesHeader = "GraphQL-Features"
 	// GitHubAPIVersionHeader is the header used to specify the GitHub API version.
 	GitHubAPIVersionHeader = "X-GitHub-Api-Version"
+	// RateLimitRemainingHeader is the number of requests left in the current rate limit window.
+	RateLimitRemainingHeader = "X-RateLimit-Remaining"
+	// RateLimitResetHeader is the time the current rate limit window resets, in UTC epoch seconds.
+	RateLimitResetHeader = "X-RateLimit-Reset"
+	// RateLimitResourceHeader is the rate limit resource a request counted against.
+	RateLimitResourceHeader = "X-RateLimit-Resource"
+	// RetryAfterHeader is the number of seconds to wait before retrying a rate limited request.
+	RetryAfterHeader = "Retry-After"
 )
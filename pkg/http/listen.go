@@ -0,0 +1,118 @@
+package http
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// unixListenPrefix marks listen addresses that are Unix domain socket paths.
+const unixListenPrefix = "unix:"
+
+// listenAddress is an address the HTTP server listens on.
+type listenAddress struct {
+	network string
+	address string
+}
+
+func (a listenAddress) String() string {
+	if a.network == "unix" {
+		return unixListenPrefix + a.address
+	}
+	return a.address
+}
+
+// parseListenAddresses parses the --listen-addr entries: "host:port", "[ipv6]:port", a host or IP
+// address alone, which listens on port, or "unix:/path/to/socket". Without entries, the server
+// listens on port on every interface.
+func parseListenAddresses(entries []string, port int) ([]listenAddress, error) {
+	if len(entries) == 0 {
+		return []listenAddress{{network: "tcp", address: net.JoinHostPort("", strconv.Itoa(port))}}, nil
+	}
+
+	addresses := make([]listenAddress, 0, len(entries))
+	for _, entry := range entries {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		if path, ok := strings.CutPrefix(entry, unixListenPrefix); ok {
+			if path == "" {
+				return nil, fmt.Errorf("invalid listen address %q: missing socket path", entry)
+			}
+			addresses = append(addresses, listenAddress{network: "unix", address: path})
+			continue
+		}
+
+		host, portStr, err := net.SplitHostPort(entry)
+		if err != nil {
+			// An address without a port, such as 127.0.0.1, ::1 or [::1], listens on port
+			host = strings.TrimSuffix(strings.TrimPrefix(entry, "["), "]")
+			if strings.Contains(host, ":") && net.ParseIP(host) == nil {
+				return nil, fmt.Errorf("invalid listen address %q: %w", entry, err)
+			}
+			portStr = strconv.Itoa(port)
+		}
+		if p, err := strconv.Atoi(portStr); err != nil || p < 0 || p > 65535 {
+			return nil, fmt.Errorf("invalid listen address %q: invalid port %q", entry, portStr)
+		}
+		addresses = append(addresses, listenAddress{network: "tcp", address: net.JoinHostPort(host, portStr)})
+	}
+	if len(addresses) == 0 {
+		return nil, fmt.Errorf("no listen address given")
+	}
+	return addresses, nil
+}
+
+// listen opens a listener on every address. If any fails, those already opened are closed.
+func listen(addresses []listenAddress) ([]net.Listener, error) {
+	listeners := make([]net.Listener, 0, len(addresses))
+	for _, addr := range addresses {
+		if addr.network == "unix" {
+			if err := removeStaleSocket(addr.address); err != nil {
+				closeListeners(listeners)
+				return nil, err
+			}
+		}
+		l, err := net.Listen(addr.network, addr.address)
+		if err != nil {
+			closeListeners(listeners)
+			return nil, fmt.Errorf("failed to listen on %s: %w", addr, err)
+		}
+		listeners = append(listeners, l)
+	}
+	return listeners, nil
+}
+
+// removeStaleSocket removes the socket a previous run left at path, so that the server can listen
+// on it again. Other files are left alone, and listening on them fails.
+func removeStaleSocket(path string) error {
+	info, err := os.Lstat(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to check socket path %s: %w", path, err)
+	}
+	if info.Mode()&fs.ModeSocket == 0 {
+		return nil
+	}
+	if conn, err := net.Dial("unix", path); err == nil {
+		_ = conn.Close()
+		return fmt.Errorf("failed to listen on %s%s: another server is listening on it", unixListenPrefix, path)
+	}
+	if err := os.Remove(path); err != nil {
+		return fmt.Errorf("failed to remove stale socket %s: %w", path, err)
+	}
+	return nil
+}
+
+func closeListeners(listeners []net.Listener) {
+	for _, l := range listeners {
+		_ = l.Close()
+	}
+}
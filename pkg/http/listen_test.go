@@ -0,0 +1,101 @@
+package http
+
+import (
+	"net"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseListenAddresses(t *testing.T) {
+	tests := []struct {
+		name        string
+		entries     []string
+		expected    []listenAddress
+		expectedErr string
+	}{
+		{
+			name:     "default",
+			expected: []listenAddress{{network: "tcp", address: ":8082"}},
+		},
+		{
+			name:     "host and port",
+			entries:  []string{"127.0.0.1:9000"},
+			expected: []listenAddress{{network: "tcp", address: "127.0.0.1:9000"}},
+		},
+		{
+			name:    "hosts without a port",
+			entries: []string{"127.0.0.1", "::1", "[::1]", "localhost"},
+			expected: []listenAddress{
+				{network: "tcp", address: "127.0.0.1:8082"},
+				{network: "tcp", address: "[::1]:8082"},
+				{network: "tcp", address: "[::1]:8082"},
+				{network: "tcp", address: "localhost:8082"},
+			},
+		},
+		{
+			name:    "loopback and unix socket",
+			entries: []string{"[::1]:9000", "unix:/run/github-mcp-server.sock"},
+			expected: []listenAddress{
+				{network: "tcp", address: "[::1]:9000"},
+				{network: "unix", address: "/run/github-mcp-server.sock"},
+			},
+		},
+		{
+			name:        "invalid port",
+			entries:     []string{"127.0.0.1:http"},
+			expectedErr: `invalid port "http"`,
+		},
+		{
+			name:        "unix socket without a path",
+			entries:     []string{"unix:"},
+			expectedErr: "missing socket path",
+		},
+		{
+			name:        "only empty entries",
+			entries:     []string{" "},
+			expectedErr: "no listen address given",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			addresses, err := parseListenAddresses(tc.entries, 8082)
+			if tc.expectedErr != "" {
+				assert.ErrorContains(t, err, tc.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, addresses)
+		})
+	}
+}
+
+func TestListen(t *testing.T) {
+	socket := filepath.Join(t.TempDir(), "mcp.sock")
+	addresses, err := parseListenAddresses([]string{"127.0.0.1:0", "unix:" + socket}, 0)
+	require.NoError(t, err)
+
+	listeners, err := listen(addresses)
+	require.NoError(t, err)
+	require.Len(t, listeners, 2)
+	assert.Equal(t, "tcp", listeners[0].Addr().Network())
+	assert.Equal(t, socket, listeners[1].Addr().String())
+
+	// A socket another server listens on is not taken over
+	_, err = listen([]listenAddress{{network: "unix", address: socket}})
+	assert.ErrorContains(t, err, "another server is listening on it")
+	closeListeners(listeners)
+
+	// A socket left behind by a server that stopped is replaced
+	stale, err := net.Listen("unix", socket)
+	require.NoError(t, err)
+	stale.(*net.UnixListener).SetUnlinkOnClose(false)
+	require.NoError(t, stale.Close())
+
+	listeners, err = listen([]listenAddress{{network: "unix", address: socket}})
+	require.NoError(t, err)
+	closeListeners(listeners)
+}
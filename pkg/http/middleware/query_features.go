@@ -0,0 +1,61 @@
+package middleware
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"slices"
+
+	ghcontext "github.com/github/github-mcp-server/pkg/context"
+	"github.com/github/github-mcp-server/pkg/http/headers"
+)
+
+const (
+	// FeaturesQueryParam carries a comma-separated list of feature flags in the URL of a request,
+	// for clients that cannot set the X-MCP-Features header, such as browser-based test clients.
+	FeaturesQueryParam = "features"
+	// FeaturesSignatureQueryParam carries the signature of the features query parameter.
+	FeaturesSignatureQueryParam = "features_sig"
+)
+
+// SignFeatures returns the signature of a features query parameter value: the hex-encoded
+// HMAC-SHA256 of the value with key.
+func SignFeatures(key, features string) string {
+	return hex.EncodeToString(featuresMAC(key, features))
+}
+
+func featuresMAC(key, features string) []byte {
+	mac := hmac.New(sha256.New, []byte(key))
+	mac.Write([]byte(features))
+	return mac.Sum(nil)
+}
+
+// WithQueryFeatures is a middleware that adds the feature flags of the features query parameter
+// to those of the X-MCP-Features header. Since URLs are easily shared, the parameter must be
+// signed with signingKey in the features_sig parameter, and requests with a missing or invalid
+// signature are rejected. The parameter is ignored when signingKey is empty.
+func WithQueryFeatures(signingKey string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			query := r.URL.Query()
+			if signingKey == "" || !query.Has(FeaturesQueryParam) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			value := query.Get(FeaturesQueryParam)
+			signature, err := hex.DecodeString(query.Get(FeaturesSignatureQueryParam))
+			if err != nil || !hmac.Equal(signature, featuresMAC(signingKey, value)) {
+				http.Error(w, "invalid or missing features_sig for the features query parameter", http.StatusForbidden)
+				return
+			}
+
+			ctx := r.Context()
+			if features := headers.ParseCommaSeparated(value); len(features) > 0 {
+				ctx = ghcontext.WithHeaderFeatures(ctx, slices.Concat(ghcontext.GetHeaderFeatures(ctx), features))
+			}
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
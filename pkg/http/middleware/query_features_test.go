@@ -0,0 +1,92 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	ghcontext "github.com/github/github-mcp-server/pkg/context"
+	"github.com/github/github-mcp-server/pkg/http/headers"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithQueryFeatures(t *testing.T) {
+	const key = "staging-secret"
+
+	tests := []struct {
+		name               string
+		signingKey         string
+		query              url.Values
+		headerFeatures     string
+		expectedStatusCode int
+		expectedFeatures   []string
+	}{
+		{
+			name:               "signed features are added",
+			signingKey:         key,
+			query:              url.Values{"features": {"issues_granular,normalize_bodies"}, "features_sig": {SignFeatures(key, "issues_granular,normalize_bodies")}},
+			expectedStatusCode: http.StatusOK,
+			expectedFeatures:   []string{"issues_granular", "normalize_bodies"},
+		},
+		{
+			name:               "signed features follow the header features",
+			signingKey:         key,
+			query:              url.Values{"features": {"-normalize_bodies"}, "features_sig": {SignFeatures(key, "-normalize_bodies")}},
+			headerFeatures:     "normalize_bodies",
+			expectedStatusCode: http.StatusOK,
+			expectedFeatures:   []string{"normalize_bodies", "-normalize_bodies"},
+		},
+		{
+			name:               "wrong signature is rejected",
+			signingKey:         key,
+			query:              url.Values{"features": {"issues_granular"}, "features_sig": {SignFeatures("other", "issues_granular")}},
+			expectedStatusCode: http.StatusForbidden,
+		},
+		{
+			name:               "missing signature is rejected",
+			signingKey:         key,
+			query:              url.Values{"features": {"issues_granular"}},
+			expectedStatusCode: http.StatusForbidden,
+		},
+		{
+			name:               "signature of other features is rejected",
+			signingKey:         key,
+			query:              url.Values{"features": {"issues_granular,pull_requests_granular"}, "features_sig": {SignFeatures(key, "issues_granular")}},
+			expectedStatusCode: http.StatusForbidden,
+		},
+		{
+			name:               "ignored without a signing key",
+			query:              url.Values{"features": {"issues_granular"}},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:               "requests without features pass through",
+			signingKey:         key,
+			headerFeatures:     "issues_granular",
+			expectedStatusCode: http.StatusOK,
+			expectedFeatures:   []string{"issues_granular"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var features []string
+			next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				features = ghcontext.GetHeaderFeatures(r.Context())
+				w.WriteHeader(http.StatusOK)
+			})
+			handler := WithRequestConfig(WithQueryFeatures(tt.signingKey)(next))
+
+			req := httptest.NewRequest(http.MethodPost, "/mcp?"+tt.query.Encode(), nil)
+			if tt.headerFeatures != "" {
+				req.Header.Set(headers.MCPFeaturesHeader, tt.headerFeatures)
+			}
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.expectedStatusCode, rr.Code)
+			assert.Equal(t, tt.expectedFeatures, features)
+		})
+	}
+}
@@ -0,0 +1,45 @@
+package middleware
+
+import (
+	"net/http"
+	"strings"
+
+	ghcontext "github.com/github/github-mcp-server/pkg/context"
+)
+
+// WithToolNamePrefix strips the configured tool name prefix from the tool names a request
+// refers to, so inventory filtering and scope challenges work with the plain names tools are
+// registered under. It must run after WithRequestConfig and WithMCPParse.
+func WithToolNamePrefix(prefix string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if prefix == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+			ctx := r.Context()
+
+			if tools := ghcontext.GetTools(ctx); len(tools) > 0 {
+				ctx = ghcontext.WithTools(ctx, stripPrefix(prefix, tools))
+			}
+			if excludeTools := ghcontext.GetExcludeTools(ctx); len(excludeTools) > 0 {
+				ctx = ghcontext.WithExcludeTools(ctx, stripPrefix(prefix, excludeTools))
+			}
+			if methodInfo, ok := ghcontext.MCPMethod(ctx); ok && methodInfo != nil && methodInfo.Method == "tools/call" {
+				stripped := *methodInfo
+				stripped.ItemName = strings.TrimPrefix(methodInfo.ItemName, prefix)
+				ctx = ghcontext.WithMCPMethodInfo(ctx, &stripped)
+			}
+
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+func stripPrefix(prefix string, names []string) []string {
+	stripped := make([]string, len(names))
+	for i, name := range names {
+		stripped[i] = strings.TrimPrefix(name, prefix)
+	}
+	return stripped
+}
@@ -8,16 +8,20 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
+	"slices"
 	"syscall"
 	"time"
 
+	"github.com/github/github-mcp-server/pkg/audit"
 	ghcontext "github.com/github/github-mcp-server/pkg/context"
 	"github.com/github/github-mcp-server/pkg/github"
 	"github.com/github/github-mcp-server/pkg/http/oauth"
+	"github.com/github/github-mcp-server/pkg/http/transport"
 	"github.com/github/github-mcp-server/pkg/inventory"
 	"github.com/github/github-mcp-server/pkg/lockdown"
 	"github.com/github/github-mcp-server/pkg/observability"
 	"github.com/github/github-mcp-server/pkg/observability/metrics"
+	"github.com/github/github-mcp-server/pkg/observability/tracing"
 	"github.com/github/github-mcp-server/pkg/scopes"
 	"github.com/github/github-mcp-server/pkg/translations"
 	"github.com/github/github-mcp-server/pkg/utils"
@@ -28,12 +32,24 @@ type ServerConfig struct {
 	// Version of the server
 	Version string
 
+	// Commit and BuildDate identify the build of the server
+	Commit    string
+	BuildDate string
+
 	// GitHub Host to target for API requests (e.g. github.com or github.enterprise.com)
 	Host string
 
+	// UploadHost overrides the URL release assets are uploaded to, which is derived from Host
+	// by default.
+	UploadHost string
+
 	// Port to listen on (default: 8082)
 	Port int
 
+	// ListenAddrs are the addresses to listen on instead of Port on every interface:
+	// "host:port", "[ipv6]:port", a host alone, which listens on Port, or "unix:/path/to/socket"
+	ListenAddrs []string
+
 	// BaseURL is the publicly accessible URL of this server for OAuth resource metadata.
 	// If not set, the server will derive the URL from incoming request headers.
 	BaseURL string
@@ -55,12 +71,20 @@ type ServerConfig struct {
 	// Content window size
 	ContentWindowSize int
 
+	// ContentWindowOverrides are "name=size" entries overriding the content window size for a
+	// toolset or tool
+	ContentWindowOverrides []string
+
 	// LockdownMode indicates if we should enable lockdown mode
 	LockdownMode bool
 
 	// RepoAccessCacheTTL overrides the default TTL for repository access cache entries.
 	RepoAccessCacheTTL *time.Duration
 
+	// LockdownTrustOwnContent overrides whether lockdown mode always trusts content authored by
+	// the token owner. Defaults to true.
+	LockdownTrustOwnContent *bool
+
 	// ScopeChallenge indicates if we should return OAuth scope challenges, and if we should perform
 	// tool filtering based on token scopes.
 	ScopeChallenge bool
@@ -70,6 +94,41 @@ type ServerConfig struct {
 	// cannot re-enable write tools.
 	ReadOnly bool
 
+	// ToolNamePrefix, when set, is put in front of every tool name clients see.
+	ToolNamePrefix string
+
+	// WithoutDestructive hides write tools that may delete or overwrite data.
+	WithoutDestructive bool
+
+	// OnlyIdempotent hides write tools whose repeated calls have additional effects.
+	OnlyIdempotent bool
+
+	// EstimateTokens attaches the estimated token count of tool results to their _meta.
+	EstimateTokens bool
+
+	// EventWebhookURL receives tool events, signed with EventWebhookSecret when it is set.
+	// EventWebhookEvents selects the events to send, all of them when empty.
+	EventWebhookURL    string
+	EventWebhookSecret string
+	EventWebhookEvents []string
+
+	// CursorSigningKey signs the pagination cursors returned by GraphQL tools. Set the same key on
+	// every instance behind a load balancer, so that each accepts the cursors of the others.
+	CursorSigningKey string
+
+	// IncludeArchivedRepos and IncludeForks keep archived repositories and forks in the results
+	// of list and search tools by default.
+	IncludeArchivedRepos bool
+	IncludeForks         bool
+
+	// CommentTemplatesFile is a YAML file of comment templates that
+	// create_issue_comment_from_template can post.
+	CommentTemplatesFile string
+
+	// RootsEnforcement is off, warn or block: what to do with tool calls targeting a
+	// repository outside the client's roots.
+	RootsEnforcement string
+
 	// EnabledToolsets is a list of toolsets to enable.
 	// When set via CLI flag, per-request headers can only narrow within these toolsets.
 	EnabledToolsets []string
@@ -86,6 +145,37 @@ type ServerConfig struct {
 
 	// InsidersMode indicates if we should enable experimental features.
 	InsidersMode bool
+
+	// Features are feature flags applied to every request, where a '-' prefix turns a flag off.
+	// The flags of a request apply after them, so a request can turn one off, or turn on one
+	// they turn off.
+	Features []string
+
+	// FeaturesQuerySigningKey, when set, lets requests enable feature flags with the features
+	// query parameter, signed with this key in features_sig, for clients that cannot set the
+	// X-MCP-Features header.
+	FeaturesQuerySigningKey string
+
+	// CacheTTL is how long REST responses cached in memory are kept without being revalidated,
+	// and CacheMaxBytes caps the size of the cache. Zero means unlimited for both.
+	CacheTTL      time.Duration
+	CacheMaxBytes int64
+
+	// DisableCache turns off the response cache.
+	DisableCache bool
+
+	// OTLPEndpoint, when set, is the OTLP/HTTP endpoint of an OpenTelemetry collector that spans
+	// of MCP requests and GitHub API calls are exported to, with the "key=value" OTLPHeaders.
+	OTLPEndpoint string
+	OTLPHeaders  []string
+
+	// AuditLogFile and AuditLogURL, when set, receive an audit record of every write tool call,
+	// appended to the file as JSON lines and POSTed to the URL.
+	AuditLogFile string
+	AuditLogURL  string
+
+	// DryRun describes the GitHub API requests of write tool calls instead of making them.
+	DryRun bool
 }
 
 func RunHTTPServer(cfg ServerConfig) error {
@@ -111,10 +201,47 @@ func RunHTTPServer(cfg ServerConfig) error {
 	logger := slog.New(slogHandler)
 	logger.Info("starting server", "version", cfg.Version, "host", cfg.Host, "lockdownEnabled", cfg.LockdownMode, "readOnly", cfg.ReadOnly, "insidersMode", cfg.InsidersMode)
 
+	listenAddresses, err := parseListenAddresses(cfg.ListenAddrs, cfg.Port)
+	if err != nil {
+		return err
+	}
+
 	apiHost, err := utils.NewAPIHost(cfg.Host)
 	if err != nil {
 		return fmt.Errorf("failed to parse API host: %w", err)
 	}
+	apiHost, err = utils.WithUploadURL(apiHost, cfg.UploadHost)
+	if err != nil {
+		return err
+	}
+
+	if cfg.ToolNamePrefix != "" {
+		if err := github.ValidateToolNamePrefix(cfg.ToolNamePrefix); err != nil {
+			return err
+		}
+		cfg.EnabledTools = github.StripToolNamePrefix(cfg.ToolNamePrefix, cfg.EnabledTools)
+		cfg.ExcludeTools = github.StripToolNamePrefix(cfg.ToolNamePrefix, cfg.ExcludeTools)
+	}
+
+	contentWindowOverrides, err := github.ParseContentWindowOverrides(github.StripToolNamePrefix(cfg.ToolNamePrefix, cfg.ContentWindowOverrides))
+	if err != nil {
+		return err
+	}
+
+	rootsEnforcement, err := github.ParseRootsEnforcement(cfg.RootsEnforcement)
+	if err != nil {
+		return err
+	}
+	cfg.RootsEnforcement = string(rootsEnforcement)
+
+	if cfg.EventWebhookEvents, err = github.ParseToolEvents(cfg.EventWebhookEvents); err != nil {
+		return err
+	}
+
+	commentTemplates, err := github.LoadCommentTemplates(cfg.CommentTemplatesFile)
+	if err != nil {
+		return err
+	}
 
 	repoAccessOpts := []lockdown.RepoAccessOption{
 		lockdown.WithLogger(logger.With("component", "lockdown")),
@@ -122,8 +249,11 @@ func RunHTTPServer(cfg ServerConfig) error {
 	if cfg.RepoAccessCacheTTL != nil {
 		repoAccessOpts = append(repoAccessOpts, lockdown.WithTTL(*cfg.RepoAccessCacheTTL))
 	}
+	if cfg.LockdownTrustOwnContent != nil {
+		repoAccessOpts = append(repoAccessOpts, lockdown.WithTrustOwnContent(*cfg.LockdownTrustOwnContent))
+	}
 
-	featureChecker := createHTTPFeatureChecker()
+	featureChecker := createHTTPFeatureChecker(cfg.Features)
 
 	obs, err := observability.NewExporters(logger, metrics.NewNoopMetrics())
 	if err != nil {
@@ -140,6 +270,46 @@ func RunHTTPServer(cfg ServerConfig) error {
 		featureChecker,
 		obs,
 	)
+	if !cfg.DisableCache {
+		// Responses are cached per token, so that users never see each other's content
+		deps.ResponseCache = transport.NewMemoryCache(cfg.CacheTTL, cfg.CacheMaxBytes)
+	}
+
+	var tracer tracing.Tracer
+	if cfg.OTLPEndpoint != "" {
+		otlpHeaders, err := tracing.ParseOTLPHeaders(cfg.OTLPHeaders)
+		if err != nil {
+			return err
+		}
+		otlpTracer, err := tracing.NewOTLPTracer(tracing.OTLPConfig{
+			Endpoint:       cfg.OTLPEndpoint,
+			Headers:        otlpHeaders,
+			ServiceVersion: cfg.Version,
+			Logger:         logger.With("component", "tracing"),
+		})
+		if err != nil {
+			return err
+		}
+		defer func() {
+			shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			if err := otlpTracer.Shutdown(shutdownCtx); err != nil {
+				logger.Warn("failed to export remaining spans", "error", err)
+			}
+		}()
+		logger.Info("exporting traces", "endpoint", cfg.OTLPEndpoint)
+		tracer = otlpTracer
+		deps.Tracer = tracer
+	}
+
+	auditLog, err := audit.Open(audit.Config{FilePath: cfg.AuditLogFile, URL: cfg.AuditLogURL})
+	if err != nil {
+		return err
+	}
+	if auditLog != nil {
+		defer func() { _ = auditLog.Close() }()
+		logger.Info("recording write tool calls in the audit log", "file", cfg.AuditLogFile, "url", cfg.AuditLogURL)
+	}
 
 	// Initialize the global tool scope map
 	err = initGlobalToolScopeMap(t)
@@ -160,7 +330,7 @@ func RunHTTPServer(cfg ServerConfig) error {
 	}
 
 	r := chi.NewRouter()
-	handler := NewHTTPMcpHandler(ctx, &cfg, deps, t, logger, apiHost, append(serverOptions, WithFeatureChecker(featureChecker), WithOAuthConfig(oauthCfg))...)
+	handler := NewHTTPMcpHandler(ctx, &cfg, deps, t, logger, apiHost, append(serverOptions, WithFeatureChecker(featureChecker), WithOAuthConfig(oauthCfg), WithContentWindowOverrides(contentWindowOverrides), WithCommentTemplates(commentTemplates), WithTracer(tracer), WithAuditLog(auditLog))...)
 	oauthHandler, err := oauth.NewAuthHandler(oauthCfg, apiHost)
 	if err != nil {
 		return fmt.Errorf("failed to create OAuth handler: %w", err)
@@ -181,9 +351,12 @@ func RunHTTPServer(cfg ServerConfig) error {
 	})
 	logger.Info("OAuth protected resource endpoints registered", "baseURL", cfg.BaseURL)
 
-	addr := fmt.Sprintf(":%d", cfg.Port)
+	listeners, err := listen(listenAddresses)
+	if err != nil {
+		return err
+	}
+
 	httpSvr := http.Server{
-		Addr:              addr,
 		Handler:           r,
 		ReadHeaderTimeout: 60 * time.Second,
 	}
@@ -203,9 +376,19 @@ func RunHTTPServer(cfg ServerConfig) error {
 		dumpTranslations()
 	}
 
-	logger.Info("HTTP server listening", "addr", addr)
-	if err := httpSvr.ListenAndServe(); err != nil && err != http.ErrServerClosed {
-		return fmt.Errorf("HTTP server error: %w", err)
+	errC := make(chan error, len(listeners))
+	for _, l := range listeners {
+		logger.Info("HTTP server listening", "addr", l.Addr().String(), "network", l.Addr().Network())
+		go func() {
+			errC <- httpSvr.Serve(l)
+		}()
+	}
+	// Every listener stops serving on shutdown; the first to fail otherwise stops the server
+	for range listeners {
+		if err := <-errC; err != nil && err != http.ErrServerClosed {
+			_ = httpSvr.Close()
+			return fmt.Errorf("HTTP server error: %w", err)
+		}
 	}
 
 	logger.Info("server stopped gracefully")
@@ -231,9 +414,10 @@ func initGlobalToolScopeMap(t translations.TranslationHelperFunc) error {
 // createHTTPFeatureChecker creates a feature checker that resolves features
 // per-request by reading header features and insiders mode from context,
 // then validating against the centralized AllowedFeatureFlags allowlist.
-func createHTTPFeatureChecker() inventory.FeatureFlagChecker {
+// The default features apply to every request, before those of the request.
+func createHTTPFeatureChecker(defaultFeatures []string) inventory.FeatureFlagChecker {
 	return func(ctx context.Context, flag string) (bool, error) {
-		headerFeatures := ghcontext.GetHeaderFeatures(ctx)
+		headerFeatures := slices.Concat(defaultFeatures, ghcontext.GetHeaderFeatures(ctx))
 		insidersMode := ghcontext.IsInsidersMode(ctx)
 		effective := github.ResolveFeatureFlags(headerFeatures, insidersMode)
 		return effective[flag], nil
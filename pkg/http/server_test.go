@@ -11,7 +11,7 @@ import (
 )
 
 func TestCreateHTTPFeatureChecker(t *testing.T) {
-	checker := createHTTPFeatureChecker()
+	checker := createHTTPFeatureChecker(nil)
 
 	tests := []struct {
 		name           string
@@ -99,6 +99,31 @@ func TestCreateHTTPFeatureChecker(t *testing.T) {
 	}
 }
 
+func TestCreateHTTPFeatureCheckerDefaults(t *testing.T) {
+	checker := createHTTPFeatureChecker([]string{github.FeatureFlagIssuesGranular})
+
+	enabled, err := checker(context.Background(), github.FeatureFlagIssuesGranular)
+	require.NoError(t, err)
+	assert.True(t, enabled, "default features apply to requests without features")
+
+	ctx := ghcontext.WithHeaderFeatures(context.Background(), []string{"-" + github.FeatureFlagIssuesGranular})
+	enabled, err = checker(ctx, github.FeatureFlagIssuesGranular)
+	require.NoError(t, err)
+	assert.False(t, enabled, "requests can turn off default features")
+
+	checker = createHTTPFeatureChecker([]string{"-" + github.NormalizeBodiesFeatureFlag})
+	ctx = ghcontext.WithHeaderFeatures(context.Background(), []string{github.NormalizeBodiesFeatureFlag})
+	enabled, err = checker(ctx, github.NormalizeBodiesFeatureFlag)
+	require.NoError(t, err)
+	assert.True(t, enabled, "requests can turn on features the defaults turn off")
+
+	ctx = ghcontext.WithInsidersMode(context.Background(), true)
+	checker = createHTTPFeatureChecker([]string{"-" + github.MCPAppsFeatureFlag})
+	enabled, err = checker(ctx, github.MCPAppsFeatureFlag)
+	require.NoError(t, err)
+	assert.False(t, enabled, "defaults can turn off insiders features")
+}
+
 func TestHeaderAllowedFeatureFlagsMatchesAllowed(t *testing.T) {
 	// Ensure HeaderAllowedFeatureFlags delegates to AllowedFeatureFlags
 	allowed := github.HeaderAllowedFeatureFlags()
@@ -0,0 +1,135 @@
+package transport
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// ErrAPIBudgetExhausted is returned for GitHub API requests made after the budget ran out.
+var ErrAPIBudgetExhausted = errors.New("GitHub API budget exhausted")
+
+const (
+	// readRequestCost is the estimated rate limit cost of a read request.
+	readRequestCost = 1
+	// writeRequestCost is the estimated rate limit cost of a request that creates or changes
+	// content, which GitHub's secondary rate limits weigh more heavily than reads.
+	writeRequestCost = 5
+)
+
+// APIBudget limits the number of GitHub API requests, and their estimated rate limit cost, that may
+// be made against it. A non-positive limit is not enforced. It is safe for concurrent use.
+type APIBudget struct {
+	maxCalls int
+	maxCost  int
+
+	mu    sync.Mutex
+	calls int
+	cost  int
+	// graphQLQueries and graphQLCost count the GraphQL queries whose actual cost GitHub reported.
+	graphQLQueries int
+	graphQLCost    int
+}
+
+// NewAPIBudget creates a budget allowing maxCalls requests with a total estimated cost of maxCost.
+func NewAPIBudget(maxCalls, maxCost int) *APIBudget {
+	return &APIBudget{maxCalls: maxCalls, maxCost: maxCost}
+}
+
+// Spend records a request of the given cost, or returns ErrAPIBudgetExhausted if it would exceed
+// the budget.
+func (b *APIBudget) Spend(cost int) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.maxCalls > 0 && b.calls+1 > b.maxCalls {
+		return ErrAPIBudgetExhausted
+	}
+	if b.maxCost > 0 && b.cost+cost > b.maxCost {
+		return ErrAPIBudgetExhausted
+	}
+	b.calls++
+	b.cost += cost
+	return nil
+}
+
+// Exhausted reports whether no further read request fits in the budget.
+func (b *APIBudget) Exhausted() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return (b.maxCalls > 0 && b.calls >= b.maxCalls) || (b.maxCost > 0 && b.cost+readRequestCost > b.maxCost)
+}
+
+// Usage returns the number of requests made and their total estimated cost.
+func (b *APIBudget) Usage() (calls, cost int) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.calls, b.cost
+}
+
+// RecordGraphQLCost records the cost GitHub reported for a GraphQL query, which was charged as a
+// read when it was sent. Queries are never refused afterwards, but the difference counts towards
+// the budget of later requests.
+func (b *APIBudget) RecordGraphQLCost(cost int) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.graphQLQueries++
+	b.graphQLCost += cost
+	b.cost += max(cost-readRequestCost, 0)
+}
+
+// GraphQLUsage returns the number of GraphQL queries whose cost was reported, and their total
+// cost.
+func (b *APIBudget) GraphQLUsage() (queries, cost int) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.graphQLQueries, b.graphQLCost
+}
+
+// Limits returns the maximum number of requests and total estimated cost.
+func (b *APIBudget) Limits() (maxCalls, maxCost int) {
+	return b.maxCalls, b.maxCost
+}
+
+type apiBudgetKey struct{}
+
+// ContextWithAPIBudget returns a context whose GitHub API requests are charged to the budget.
+func ContextWithAPIBudget(ctx context.Context, budget *APIBudget) context.Context {
+	return context.WithValue(ctx, apiBudgetKey{}, budget)
+}
+
+// APIBudgetFromContext returns the budget requests made with ctx are charged to, if any.
+func APIBudgetFromContext(ctx context.Context) (*APIBudget, bool) {
+	budget, ok := ctx.Value(apiBudgetKey{}).(*APIBudget)
+	return budget, ok && budget != nil
+}
+
+// EstimateRequestCost estimates the rate limit cost of a GitHub API request. GraphQL requests are
+// all POSTs, so they are counted as reads; their actual cost depends on the query, and is recorded
+// once known by GraphQLCostTransport.
+func EstimateRequestCost(req *http.Request) int {
+	switch {
+	case req.Method == http.MethodGet || req.Method == http.MethodHead:
+		return readRequestCost
+	case strings.HasSuffix(req.URL.Path, "/graphql"):
+		return readRequestCost
+	default:
+		return writeRequestCost
+	}
+}
+
+// APIBudgetTransport charges each request to the APIBudget in its context, and refuses requests
+// once the budget is exhausted. Requests without a budget in their context are not limited.
+type APIBudgetTransport struct {
+	Transport http.RoundTripper
+}
+
+func (t *APIBudgetTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if budget, ok := APIBudgetFromContext(req.Context()); ok {
+		if err := budget.Spend(EstimateRequestCost(req)); err != nil {
+			return nil, err
+		}
+	}
+	return t.Transport.RoundTrip(req)
+}
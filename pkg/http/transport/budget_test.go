@@ -0,0 +1,106 @@
+package transport
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPIBudget(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		maxCalls      int
+		maxCost       int
+		costs         []int
+		expectedErrAt int
+		exhausted     bool
+	}{
+		{
+			name:          "call limit",
+			maxCalls:      2,
+			costs:         []int{1, 5, 1},
+			expectedErrAt: 2,
+			exhausted:     true,
+		},
+		{
+			name:          "cost limit refuses a write while reads still fit",
+			maxCost:       6,
+			costs:         []int{5, 5},
+			expectedErrAt: 1,
+			exhausted:     false,
+		},
+		{
+			name:          "unlimited",
+			costs:         []int{5, 5, 5},
+			expectedErrAt: -1,
+			exhausted:     false,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			budget := NewAPIBudget(tc.maxCalls, tc.maxCost)
+			for i, cost := range tc.costs {
+				err := budget.Spend(cost)
+				if i == tc.expectedErrAt {
+					require.ErrorIs(t, err, ErrAPIBudgetExhausted)
+					break
+				}
+				require.NoError(t, err)
+			}
+			assert.Equal(t, tc.exhausted, budget.Exhausted())
+		})
+	}
+}
+
+func TestEstimateRequestCost(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, 1, EstimateRequestCost(httptest.NewRequest(http.MethodGet, "https://api.github.com/repos/o/r", nil)))
+	assert.Equal(t, 1, EstimateRequestCost(httptest.NewRequest(http.MethodPost, "https://api.github.com/graphql", nil)))
+	assert.Equal(t, 5, EstimateRequestCost(httptest.NewRequest(http.MethodPost, "https://api.github.com/repos/o/r/issues", nil)))
+	assert.Equal(t, 5, EstimateRequestCost(httptest.NewRequest(http.MethodDelete, "https://api.github.com/repos/o/r", nil)))
+}
+
+func TestAPIBudgetTransport(t *testing.T) {
+	t.Parallel()
+
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: &APIBudgetTransport{Transport: http.DefaultTransport}}
+	budget := NewAPIBudget(1, 0)
+	ctx := ContextWithAPIBudget(context.Background(), budget)
+
+	do := func(ctx context.Context) error {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+		require.NoError(t, err)
+		resp, err := client.Do(req)
+		if err == nil {
+			_ = resp.Body.Close()
+		}
+		return err
+	}
+
+	require.NoError(t, do(ctx))
+	assert.ErrorIs(t, do(ctx), ErrAPIBudgetExhausted)
+	// Requests without a budget are not limited.
+	require.NoError(t, do(context.Background()))
+
+	assert.Equal(t, 2, requests)
+	calls, cost := budget.Usage()
+	assert.Equal(t, 1, calls)
+	assert.Equal(t, 1, cost)
+}
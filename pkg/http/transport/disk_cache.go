@@ -0,0 +1,261 @@
+package transport
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// CachedResponse is a response stored by a ResponseCache, revalidated with its ETag or
+// Last-Modified header before it is reused.
+type CachedResponse struct {
+	StatusCode int         `json:"status_code"`
+	Header     http.Header `json:"header"`
+	Body       []byte      `json:"body"`
+	// StoredAt is when the response was last fetched or revalidated.
+	StoredAt time.Time `json:"stored_at"`
+}
+
+// ResponseCache stores responses for ConditionalTransport. Implementations must be safe for
+// concurrent use.
+type ResponseCache interface {
+	Get(key string) (*CachedResponse, bool)
+	Set(key string, resp *CachedResponse)
+}
+
+// ConditionalTransport revalidates cached responses to GET requests with If-None-Match and
+// If-Modified-Since, and serves them again when GitHub answers 304 Not Modified. Conditional
+// requests answered with 304 do not count against the primary rate limit.
+type ConditionalTransport struct {
+	Transport http.RoundTripper
+	Cache     ResponseCache
+}
+
+func (t *ConditionalTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Method != http.MethodGet || req.Header.Get("Range") != "" || req.Header.Get("If-None-Match") != "" {
+		return t.Transport.RoundTrip(req)
+	}
+
+	key := responseCacheKey(req)
+	cached, ok := t.Cache.Get(key)
+	if ok {
+		req = req.Clone(req.Context())
+		if etag := cached.Header.Get("ETag"); etag != "" {
+			req.Header.Set("If-None-Match", etag)
+		}
+		if lastModified := cached.Header.Get("Last-Modified"); lastModified != "" {
+			req.Header.Set("If-Modified-Since", lastModified)
+		}
+	}
+
+	resp, err := t.Transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if ok && resp.StatusCode == http.StatusNotModified {
+		_ = resp.Body.Close()
+		cached.StoredAt = time.Now()
+		t.Cache.Set(key, cached)
+		header := cached.Header.Clone()
+		// Rate limit headers describe the current request, not the cached one
+		for name, values := range resp.Header {
+			if strings.HasPrefix(name, "X-Ratelimit-") {
+				header[name] = values
+			}
+		}
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", cached.StatusCode, http.StatusText(cached.StatusCode)),
+			StatusCode:    cached.StatusCode,
+			Proto:         resp.Proto,
+			ProtoMajor:    resp.ProtoMajor,
+			ProtoMinor:    resp.ProtoMinor,
+			Header:        header,
+			Body:          io.NopCloser(bytes.NewReader(cached.Body)),
+			ContentLength: int64(len(cached.Body)),
+			Request:       resp.Request,
+		}, nil
+	}
+
+	if resp.StatusCode != http.StatusOK || (resp.Header.Get("ETag") == "" && resp.Header.Get("Last-Modified") == "") ||
+		strings.Contains(resp.Header.Get("Cache-Control"), "no-store") {
+		return resp, nil
+	}
+	body, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	t.Cache.Set(key, &CachedResponse{
+		StatusCode: resp.StatusCode,
+		Header:     resp.Header.Clone(),
+		Body:       body,
+		StoredAt:   time.Now(),
+	})
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	return resp, nil
+}
+
+// responseCacheKey identifies a request by its URL, the media type it accepts and a hash of its
+// credentials, so that responses are never shared across tokens.
+func responseCacheKey(req *http.Request) string {
+	h := sha256.New()
+	for _, part := range []string{req.Method, req.URL.String(), req.Header.Get("Accept"), req.Header.Get("Authorization")} {
+		_, _ = io.WriteString(h, part)
+		_, _ = h.Write([]byte{0})
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// DiskCache is a ResponseCache persisting responses as files in a directory, so that they
+// survive restarts of the server. Entries not revalidated within the TTL expire, and the least
+// recently used entries are evicted once the cache exceeds its size cap.
+type DiskCache struct {
+	dir      string
+	ttl      time.Duration
+	maxBytes int64
+
+	mu      sync.Mutex
+	entries map[string]diskCacheEntry
+	size    int64
+}
+
+type diskCacheEntry struct {
+	size   int64
+	usedAt time.Time
+}
+
+// NewDiskCache opens the cache in dir, creating the directory if needed. A non-positive ttl or
+// maxBytes is not enforced.
+func NewDiskCache(dir string, ttl time.Duration, maxBytes int64) (*DiskCache, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("failed to create cache directory: %w", err)
+	}
+	files, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read cache directory: %w", err)
+	}
+
+	c := &DiskCache{dir: dir, ttl: ttl, maxBytes: maxBytes, entries: make(map[string]diskCacheEntry)}
+	for _, file := range files {
+		key, ok := strings.CutSuffix(file.Name(), ".json")
+		if !ok || file.IsDir() {
+			continue
+		}
+		info, err := file.Info()
+		if err != nil {
+			continue
+		}
+		c.entries[key] = diskCacheEntry{size: info.Size(), usedAt: info.ModTime()}
+		c.size += info.Size()
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.evictLocked()
+	return c, nil
+}
+
+func (c *DiskCache) Get(key string) (*CachedResponse, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, ok := c.entries[key]; !ok {
+		return nil, false
+	}
+
+	data, err := os.ReadFile(c.path(key))
+	var resp CachedResponse
+	if err != nil || json.Unmarshal(data, &resp) != nil || c.expired(resp.StoredAt) {
+		c.removeLocked(key)
+		return nil, false
+	}
+	now := time.Now()
+	c.entries[key] = diskCacheEntry{size: c.entries[key].size, usedAt: now}
+	_ = os.Chtimes(c.path(key), now, now)
+	return &resp, true
+}
+
+func (c *DiskCache) Set(key string, resp *CachedResponse) {
+	data, err := json.Marshal(resp)
+	if err != nil || (c.maxBytes > 0 && int64(len(data)) > c.maxBytes) {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	// Write to a temporary file first, so that servers sharing the directory never read a
+	// partial entry
+	tmp, err := os.CreateTemp(c.dir, key+".*.tmp")
+	if err != nil {
+		return
+	}
+	_, err = tmp.Write(data)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil || os.Rename(tmp.Name(), c.path(key)) != nil {
+		_ = os.Remove(tmp.Name())
+		return
+	}
+
+	c.size += int64(len(data)) - c.entries[key].size
+	c.entries[key] = diskCacheEntry{size: int64(len(data)), usedAt: time.Now()}
+	c.evictLocked()
+}
+
+// Size returns the total size in bytes of the cached entries.
+func (c *DiskCache) Size() int64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.size
+}
+
+func (c *DiskCache) path(key string) string {
+	return filepath.Join(c.dir, key+".json")
+}
+
+func (c *DiskCache) expired(storedAt time.Time) bool {
+	return c.ttl > 0 && time.Since(storedAt) > c.ttl
+}
+
+func (c *DiskCache) removeLocked(key string) {
+	_ = os.Remove(c.path(key))
+	c.size -= c.entries[key].size
+	delete(c.entries, key)
+}
+
+// evictLocked removes expired entries, then the least recently used ones until the cache fits in
+// its size cap. Entries are checked for expiry by the time they were last used, which is never
+// earlier than when they were stored.
+func (c *DiskCache) evictLocked() {
+	keys := make([]string, 0, len(c.entries))
+	for key, entry := range c.entries {
+		if c.expired(entry.usedAt) {
+			c.removeLocked(key)
+			continue
+		}
+		keys = append(keys, key)
+	}
+	if c.maxBytes <= 0 || c.size <= c.maxBytes {
+		return
+	}
+	slices.SortFunc(keys, func(a, b string) int {
+		return c.entries[a].usedAt.Compare(c.entries[b].usedAt)
+	})
+	for _, key := range keys {
+		if c.size <= c.maxBytes {
+			return
+		}
+		c.removeLocked(key)
+	}
+}
@@ -0,0 +1,135 @@
+package transport
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConditionalTransport(t *testing.T) {
+	t.Parallel()
+
+	var requests, notModified int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("X-RateLimit-Remaining", "4999")
+		switch r.URL.Path {
+		case "/repos/octo/app":
+			if r.Header.Get("If-None-Match") == `"v1"` {
+				notModified++
+				w.WriteHeader(http.StatusNotModified)
+				return
+			}
+			w.Header().Set("ETag", `"v1"`)
+			_, _ = io.WriteString(w, `{"name":"app"}`)
+		case "/no-store":
+			w.Header().Set("ETag", `"v1"`)
+			w.Header().Set("Cache-Control", "private, no-store")
+			_, _ = io.WriteString(w, "secret")
+		default:
+			_, _ = io.WriteString(w, "no validator")
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	dir := t.TempDir()
+	newClient := func() *http.Client {
+		cache, err := NewDiskCache(dir, time.Hour, 1<<20)
+		require.NoError(t, err)
+		return &http.Client{Transport: &ConditionalTransport{Transport: http.DefaultTransport, Cache: cache}}
+	}
+	get := func(client *http.Client, path, token string) string {
+		req, err := http.NewRequest(http.MethodGet, srv.URL+path, nil)
+		require.NoError(t, err)
+		req.Header.Set("Authorization", "Bearer "+token)
+		resp, err := client.Do(req)
+		require.NoError(t, err)
+		defer func() { _ = resp.Body.Close() }()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "4999", resp.Header.Get("X-RateLimit-Remaining"))
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		return string(body)
+	}
+
+	client := newClient()
+	assert.Equal(t, `{"name":"app"}`, get(client, "/repos/octo/app", "a"))
+	assert.Equal(t, `{"name":"app"}`, get(client, "/repos/octo/app", "a"))
+	assert.Equal(t, 1, notModified)
+
+	// A restarted server revalidates the responses cached on disk
+	assert.Equal(t, `{"name":"app"}`, get(newClient(), "/repos/octo/app", "a"))
+	assert.Equal(t, 2, notModified)
+
+	// Responses are not shared across tokens
+	assert.Equal(t, `{"name":"app"}`, get(client, "/repos/octo/app", "b"))
+	assert.Equal(t, 2, notModified)
+
+	// Responses without a validator, or that must not be stored, are not cached
+	for _, path := range []string{"/no-validator", "/no-store"} {
+		get(client, path, "a")
+		get(client, path, "a")
+	}
+	assert.Equal(t, 8, requests)
+	files, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Len(t, files, 2)
+}
+
+func TestDiskCache(t *testing.T) {
+	t.Parallel()
+
+	entry := func(body string, storedAt time.Time) *CachedResponse {
+		return &CachedResponse{StatusCode: http.StatusOK, Header: http.Header{"Etag": {`"x"`}}, Body: []byte(body), StoredAt: storedAt}
+	}
+
+	t.Run("entries expire after the TTL", func(t *testing.T) {
+		t.Parallel()
+
+		cache, err := NewDiskCache(t.TempDir(), time.Minute, 0)
+		require.NoError(t, err)
+		cache.Set("fresh", entry("a", time.Now()))
+		cache.Set("stale", entry("b", time.Now().Add(-2*time.Minute)))
+
+		resp, ok := cache.Get("fresh")
+		require.True(t, ok)
+		assert.Equal(t, "a", string(resp.Body))
+		_, ok = cache.Get("stale")
+		assert.False(t, ok)
+	})
+
+	t.Run("least recently used entries are evicted beyond the size cap", func(t *testing.T) {
+		t.Parallel()
+
+		dir := t.TempDir()
+		body := strings.Repeat("x", 400)
+		cache, err := NewDiskCache(dir, 0, 1500)
+		require.NoError(t, err)
+		cache.Set("first", entry(body, time.Now()))
+		cache.Set("second", entry(body, time.Now()))
+		_, ok := cache.Get("first")
+		require.True(t, ok)
+		cache.Set("third", entry(body, time.Now()))
+
+		_, ok = cache.Get("second")
+		assert.False(t, ok)
+		_, ok = cache.Get("first")
+		assert.True(t, ok)
+		assert.LessOrEqual(t, cache.Size(), int64(1500))
+
+		// The size of the entries on disk is known again after a restart
+		reopened, err := NewDiskCache(dir, 0, 1500)
+		require.NoError(t, err)
+		assert.Equal(t, cache.Size(), reopened.Size())
+		_, err = os.Stat(filepath.Join(dir, "second.json"))
+		assert.True(t, os.IsNotExist(err))
+	})
+}
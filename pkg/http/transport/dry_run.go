@@ -0,0 +1,143 @@
+package transport
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// DryRunRequest describes a GitHub API request that was intercepted rather than sent.
+type DryRunRequest struct {
+	Method   string `json:"method"`
+	Endpoint string `json:"endpoint"`
+	Payload  any    `json:"payload,omitempty"`
+}
+
+// DryRunRecorder collects the requests intercepted by DryRunTransport during a tool call.
+type DryRunRecorder struct {
+	mu       sync.Mutex
+	requests []DryRunRequest
+}
+
+func (r *DryRunRecorder) record(req DryRunRequest) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.requests = append(r.requests, req)
+}
+
+// Requests returns the intercepted requests, in the order they were made.
+func (r *DryRunRecorder) Requests() []DryRunRequest {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]DryRunRequest(nil), r.requests...)
+}
+
+type dryRunKey struct{}
+
+// ContextWithDryRun returns a context in which DryRunTransport intercepts mutating requests,
+// recording them in recorder.
+func ContextWithDryRun(ctx context.Context, recorder *DryRunRecorder) context.Context {
+	return context.WithValue(ctx, dryRunKey{}, recorder)
+}
+
+// DryRunFromContext returns the recorder of a dry run, or nil when ctx is not a dry run.
+func DryRunFromContext(ctx context.Context) *DryRunRecorder {
+	recorder, _ := ctx.Value(dryRunKey{}).(*DryRunRecorder)
+	return recorder
+}
+
+// DryRunTransport intercepts the mutating requests of a dry run: REST requests other than GET,
+// HEAD and OPTIONS, and GraphQL mutations. They are recorded in the context's DryRunRecorder and
+// answered with an empty success response without reaching GitHub. Reads are sent as usual, so
+// tools can still look up what they need before writing. Outside of dry runs it does nothing.
+type DryRunTransport struct {
+	Transport http.RoundTripper
+}
+
+func (t *DryRunTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	transport := t.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	recorder := DryRunFromContext(req.Context())
+	if recorder == nil {
+		return transport.RoundTrip(req)
+	}
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return transport.RoundTrip(req)
+	}
+
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = io.ReadAll(req.Body)
+		_ = req.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read request body: %w", err)
+		}
+	}
+
+	graphQL := strings.HasSuffix(req.URL.Path, "/graphql")
+	if graphQL && !isGraphQLMutation(body) {
+		req = req.Clone(req.Context())
+		req.Body = io.NopCloser(bytes.NewReader(body))
+		return transport.RoundTrip(req)
+	}
+
+	recorder.record(DryRunRequest{
+		Method:   req.Method,
+		Endpoint: req.URL.String(),
+		Payload:  dryRunPayload(body),
+	})
+
+	status, respBody := http.StatusOK, "{}"
+	switch {
+	case graphQL:
+		respBody = `{"data":{}}`
+	case req.Method == http.MethodPost:
+		status = http.StatusCreated
+	case req.Method == http.MethodDelete:
+		status, respBody = http.StatusNoContent, ""
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", status, http.StatusText(status)),
+		StatusCode:    status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        http.Header{"Content-Type": []string{"application/json"}},
+		Body:          io.NopCloser(strings.NewReader(respBody)),
+		ContentLength: int64(len(respBody)),
+		Request:       req,
+	}, nil
+}
+
+// isGraphQLMutation reports whether body is a GraphQL request for a mutation.
+func isGraphQLMutation(body []byte) bool {
+	var gql struct {
+		Query string `json:"query"`
+	}
+	if json.Unmarshal(body, &gql) != nil {
+		return false
+	}
+	return strings.HasPrefix(strings.TrimSpace(gql.Query), "mutation")
+}
+
+// dryRunPayload returns body decoded as JSON, or a note of its size when it is not JSON, such as
+// the contents of an uploaded file.
+func dryRunPayload(body []byte) any {
+	if len(body) == 0 {
+		return nil
+	}
+	var payload any
+	if json.Unmarshal(body, &payload) != nil {
+		return fmt.Sprintf("<%d bytes>", len(body))
+	}
+	return payload
+}
@@ -0,0 +1,67 @@
+package transport
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDryRunTransport(t *testing.T) {
+	t.Parallel()
+
+	var sent atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		sent.Add(1)
+		_, _ = w.Write([]byte(`{"data":{"viewer":{"login":"octocat"}}}`))
+	}))
+	t.Cleanup(server.Close)
+
+	client := &http.Client{Transport: &DryRunTransport{Transport: http.DefaultTransport}}
+	recorder := &DryRunRecorder{}
+	do := func(ctx context.Context, method, path, body string) *http.Response {
+		req, err := http.NewRequestWithContext(ctx, method, server.URL+path, strings.NewReader(body))
+		require.NoError(t, err)
+		resp, err := client.Do(req)
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = resp.Body.Close() })
+		return resp
+	}
+	dryRun := ContextWithDryRun(context.Background(), recorder)
+
+	// Reads are sent
+	do(dryRun, http.MethodGet, "/repos/octo/app", "")
+	do(dryRun, http.MethodPost, "/graphql", `{"query":"query{viewer{login}}"}`)
+	assert.Equal(t, int32(2), sent.Load())
+
+	// Writes are recorded and answered without being sent
+	resp := do(dryRun, http.MethodPost, "/repos/octo/app/issues", `{"title":"Flaky test"}`)
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+	resp = do(dryRun, http.MethodDelete, "/repos/octo/app/labels/bug", "")
+	assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+	resp = do(dryRun, http.MethodPost, "/graphql", `{"query":"mutation($input:CloseDiscussionInput!){closeDiscussion(input:$input){discussion{id}}}","variables":{"input":{"discussionId":"D_1"}}}`)
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"data":{}}`, string(body))
+	do(dryRun, http.MethodPut, "/repos/octo/app/contents/logo.png", "\x89PNG")
+	assert.Equal(t, int32(2), sent.Load())
+
+	requests := recorder.Requests()
+	require.Len(t, requests, 4)
+	assert.Equal(t, DryRunRequest{Method: http.MethodPost, Endpoint: server.URL + "/repos/octo/app/issues", Payload: map[string]any{"title": "Flaky test"}}, requests[0])
+	assert.Equal(t, DryRunRequest{Method: http.MethodDelete, Endpoint: server.URL + "/repos/octo/app/labels/bug"}, requests[1])
+	assert.Equal(t, server.URL+"/graphql", requests[2].Endpoint)
+	assert.Equal(t, map[string]any{"input": map[string]any{"discussionId": "D_1"}}, requests[2].Payload.(map[string]any)["variables"])
+	assert.Equal(t, "<4 bytes>", requests[3].Payload)
+
+	// Outside of dry runs, writes are sent
+	do(context.Background(), http.MethodPost, "/repos/octo/app/issues", `{"title":"Flaky test"}`)
+	assert.Equal(t, int32(3), sent.Load())
+	assert.Len(t, recorder.Requests(), 4)
+}
@@ -0,0 +1,159 @@
+package transport
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/github/github-mcp-server/pkg/observability/tracing"
+)
+
+// graphQLCostAlias is the alias the rate limit cost is selected under, chosen so that it never
+// clashes with a field of the query.
+const graphQLCostAlias = "mcpRateLimit"
+
+// GraphQLCostTransport measures the actual rate limit cost of GraphQL queries. It adds the
+// rateLimit { cost } field to each query, and removes it from the response before the caller
+// decodes it. The cost is recorded in the APIBudget of the request's context, if any, logged, and
+// added to the request's span.
+// Mutations are not measured, as their cost is not reported.
+type GraphQLCostTransport struct {
+	Transport http.RoundTripper
+	// Logger, when set, logs the cost of each query.
+	Logger *slog.Logger
+}
+
+func (t *GraphQLCostTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Method != http.MethodPost || req.Body == nil || req.Body == http.NoBody {
+		return t.Transport.RoundTrip(req)
+	}
+
+	body, err := io.ReadAll(req.Body)
+	_ = req.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	req = req.Clone(req.Context())
+	setRequestBody(req, body)
+
+	var payload map[string]json.RawMessage
+	var query string
+	if json.Unmarshal(body, &payload) != nil || json.Unmarshal(payload["query"], &query) != nil {
+		return t.Transport.RoundTrip(req)
+	}
+	span := tracing.SpanFromContext(req.Context())
+	span.SetAttributes(map[string]any{"graphql.root_field": graphQLRootField(query)})
+	measured, ok := injectGraphQLCost(query)
+	if !ok {
+		return t.Transport.RoundTrip(req)
+	}
+	payload["query"], _ = json.Marshal(measured)
+	if measuredBody, err := json.Marshal(payload); err == nil {
+		setRequestBody(req, measuredBody)
+	}
+
+	resp, err := t.Transport.RoundTrip(req)
+	if err != nil || resp.StatusCode != http.StatusOK {
+		return resp, err
+	}
+	respBody, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	cost, stripped, ok := extractGraphQLCost(respBody)
+	if !ok {
+		stripped = respBody
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(stripped))
+	resp.ContentLength = int64(len(stripped))
+	resp.Header.Del("Content-Length")
+	if !ok {
+		return resp, nil
+	}
+
+	span.SetAttributes(map[string]any{"github.graphql.cost": cost})
+	attrs := []any{"query", graphQLRootField(query), "cost", cost}
+	if budget, found := APIBudgetFromContext(req.Context()); found {
+		budget.RecordGraphQLCost(cost)
+		queries, total := budget.GraphQLUsage()
+		attrs = append(attrs, "sessionQueries", queries, "sessionCost", total)
+	}
+	if t.Logger != nil {
+		t.Logger.Info("graphql query cost", attrs...)
+	}
+	return resp, nil
+}
+
+func setRequestBody(req *http.Request, body []byte) {
+	req.Body = io.NopCloser(bytes.NewReader(body))
+	req.GetBody = func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(body)), nil
+	}
+	req.ContentLength = int64(len(body))
+}
+
+// injectGraphQLCost adds the rate limit cost to the selection of a query. Mutations, and documents
+// with fragments whose last selection may not be the query's, are left alone.
+func injectGraphQLCost(query string) (string, bool) {
+	query = strings.TrimSpace(query)
+	if !strings.HasPrefix(query, "{") && !strings.HasPrefix(query, "query") {
+		return "", false
+	}
+	if strings.Contains(query, "fragment ") || strings.Contains(query, graphQLCostAlias) {
+		return "", false
+	}
+	end := strings.LastIndex(query, "}")
+	if end < 0 {
+		return "", false
+	}
+	return query[:end] + " " + graphQLCostAlias + ":rateLimit{cost}" + query[end:], true
+}
+
+// extractGraphQLCost returns the cost selected by injectGraphQLCost, and the response without it.
+func extractGraphQLCost(body []byte) (int, []byte, bool) {
+	var payload map[string]json.RawMessage
+	if json.Unmarshal(body, &payload) != nil {
+		return 0, nil, false
+	}
+	var data map[string]json.RawMessage
+	if json.Unmarshal(payload["data"], &data) != nil || data == nil {
+		return 0, nil, false
+	}
+	var rateLimit struct {
+		Cost int `json:"cost"`
+	}
+	if json.Unmarshal(data[graphQLCostAlias], &rateLimit) != nil {
+		return 0, nil, false
+	}
+	delete(data, graphQLCostAlias)
+
+	var err error
+	if payload["data"], err = json.Marshal(data); err != nil {
+		return 0, nil, false
+	}
+	stripped, err := json.Marshal(payload)
+	if err != nil {
+		return 0, nil, false
+	}
+	return rateLimit.Cost, stripped, true
+}
+
+// graphQLRootField returns the first field a query selects, to tell queries apart in logs.
+func graphQLRootField(query string) string {
+	start := strings.Index(query, "{")
+	if start < 0 {
+		return ""
+	}
+	field := strings.TrimSpace(query[start+1:])
+	end := strings.IndexFunc(field, func(r rune) bool {
+		return !(r == '_' || r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9')
+	})
+	if end >= 0 {
+		field = field[:end]
+	}
+	return field
+}
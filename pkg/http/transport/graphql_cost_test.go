@@ -0,0 +1,99 @@
+package transport
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInjectGraphQLCost(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		query    string
+		expected string
+		ok       bool
+	}{
+		{
+			name:     "anonymous query",
+			query:    "{viewer{login}}",
+			expected: "{viewer{login} mcpRateLimit:rateLimit{cost}}",
+			ok:       true,
+		},
+		{
+			name:     "named query with variables",
+			query:    "query($owner:String!){repository(owner:$owner){id}}",
+			expected: "query($owner:String!){repository(owner:$owner){id} mcpRateLimit:rateLimit{cost}}",
+			ok:       true,
+		},
+		{name: "mutation", query: "mutation{addStar(input:{}){clientMutationId}}"},
+		{name: "fragment", query: "query{viewer{...F}} fragment F on User{login}"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			query, ok := injectGraphQLCost(tc.query)
+			assert.Equal(t, tc.ok, ok)
+			assert.Equal(t, tc.expected, query)
+		})
+	}
+}
+
+func TestGraphQLCostTransport(t *testing.T) {
+	t.Parallel()
+
+	var sent string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload struct {
+			Query string `json:"query"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+		sent = payload.Query
+		if strings.HasPrefix(payload.Query, "mutation") {
+			_, _ = io.WriteString(w, `{"data":{"addStar":{"clientMutationId":null}}}`)
+			return
+		}
+		_, _ = io.WriteString(w, `{"data":{"search":{"issueCount":3},"mcpRateLimit":{"cost":7}}}`)
+	}))
+	defer server.Close()
+
+	budget := NewAPIBudget(0, 0)
+	client := &http.Client{Transport: &GraphQLCostTransport{Transport: &APIBudgetTransport{Transport: http.DefaultTransport}}}
+	post := func(query string) string {
+		body, err := json.Marshal(map[string]any{"query": query, "variables": map[string]any{}})
+		require.NoError(t, err)
+		req, err := http.NewRequestWithContext(ContextWithAPIBudget(context.Background(), budget), http.MethodPost, server.URL+"/graphql", strings.NewReader(string(body)))
+		require.NoError(t, err)
+		resp, err := client.Do(req)
+		require.NoError(t, err)
+		defer func() { _ = resp.Body.Close() }()
+		data, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		return string(data)
+	}
+
+	body := post(`query{search(query:"is:open",type:ISSUE){issueCount}}`)
+	assert.Contains(t, sent, "mcpRateLimit:rateLimit{cost}")
+	assert.JSONEq(t, `{"data":{"search":{"issueCount":3}}}`, body, "the cost is removed from the response")
+
+	queries, cost := budget.GraphQLUsage()
+	assert.Equal(t, 1, queries)
+	assert.Equal(t, 7, cost)
+	calls, estimated := budget.Usage()
+	assert.Equal(t, 1, calls)
+	assert.Equal(t, 7, estimated, "the actual cost replaces the estimated read")
+
+	body = post("mutation{addStar(input:{}){clientMutationId}}")
+	assert.NotContains(t, sent, "mcpRateLimit")
+	assert.JSONEq(t, `{"data":{"addStar":{"clientMutationId":null}}}`, body)
+	queries, _ = budget.GraphQLUsage()
+	assert.Equal(t, 1, queries)
+}
@@ -0,0 +1,95 @@
+package transport
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+// MemoryCache is a ResponseCache holding responses in memory for the lifetime of the server.
+// Entries not revalidated within the TTL expire, and the least recently used entries are evicted
+// once the cache exceeds its size cap.
+type MemoryCache struct {
+	ttl      time.Duration
+	maxBytes int64
+
+	mu      sync.Mutex
+	entries map[string]*list.Element
+	// lru orders the entries from most to least recently used
+	lru  *list.List
+	size int64
+}
+
+type memoryCacheEntry struct {
+	key  string
+	resp CachedResponse
+	size int64
+}
+
+// NewMemoryCache creates an empty cache. A non-positive ttl or maxBytes is not enforced.
+func NewMemoryCache(ttl time.Duration, maxBytes int64) *MemoryCache {
+	return &MemoryCache{ttl: ttl, maxBytes: maxBytes, entries: make(map[string]*list.Element), lru: list.New()}
+}
+
+func (c *MemoryCache) Get(key string) (*CachedResponse, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	elem, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	entry := elem.Value.(*memoryCacheEntry)
+	if c.ttl > 0 && time.Since(entry.resp.StoredAt) > c.ttl {
+		c.removeLocked(elem)
+		return nil, false
+	}
+	c.lru.MoveToFront(elem)
+	// Callers update the response they get, so hand out a copy
+	resp := entry.resp
+	resp.Header = resp.Header.Clone()
+	return &resp, true
+}
+
+func (c *MemoryCache) Set(key string, resp *CachedResponse) {
+	entry := &memoryCacheEntry{key: key, resp: *resp, size: cachedResponseSize(resp)}
+	entry.resp.Header = resp.Header.Clone()
+	if c.maxBytes > 0 && entry.size > c.maxBytes {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if elem, ok := c.entries[key]; ok {
+		c.removeLocked(elem)
+	}
+	c.entries[key] = c.lru.PushFront(entry)
+	c.size += entry.size
+	for c.maxBytes > 0 && c.size > c.maxBytes {
+		c.removeLocked(c.lru.Back())
+	}
+}
+
+// Size returns the total size in bytes of the cached entries.
+func (c *MemoryCache) Size() int64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.size
+}
+
+func (c *MemoryCache) removeLocked(elem *list.Element) {
+	entry := c.lru.Remove(elem).(*memoryCacheEntry)
+	delete(c.entries, entry.key)
+	c.size -= entry.size
+}
+
+// cachedResponseSize estimates the memory a cached response takes up.
+func cachedResponseSize(resp *CachedResponse) int64 {
+	size := int64(len(resp.Body))
+	for name, values := range resp.Header {
+		size += int64(len(name))
+		for _, value := range values {
+			size += int64(len(value))
+		}
+	}
+	return size
+}
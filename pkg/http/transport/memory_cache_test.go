@@ -0,0 +1,72 @@
+package transport
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryCache(t *testing.T) {
+	t.Parallel()
+
+	entry := func(body string, storedAt time.Time) *CachedResponse {
+		return &CachedResponse{StatusCode: http.StatusOK, Header: http.Header{"Etag": {`"x"`}}, Body: []byte(body), StoredAt: storedAt}
+	}
+
+	t.Run("entries expire after the TTL", func(t *testing.T) {
+		t.Parallel()
+
+		cache := NewMemoryCache(time.Minute, 0)
+		cache.Set("fresh", entry("a", time.Now()))
+		cache.Set("stale", entry("b", time.Now().Add(-2*time.Minute)))
+
+		resp, ok := cache.Get("fresh")
+		require.True(t, ok)
+		assert.Equal(t, "a", string(resp.Body))
+		_, ok = cache.Get("stale")
+		assert.False(t, ok)
+	})
+
+	t.Run("least recently used entries are evicted beyond the size cap", func(t *testing.T) {
+		t.Parallel()
+
+		body := strings.Repeat("x", 400)
+		cache := NewMemoryCache(0, 1000)
+		cache.Set("first", entry(body, time.Now()))
+		cache.Set("second", entry(body, time.Now()))
+		_, ok := cache.Get("first")
+		require.True(t, ok)
+		cache.Set("third", entry(body, time.Now()))
+
+		_, ok = cache.Get("second")
+		assert.False(t, ok)
+		_, ok = cache.Get("first")
+		assert.True(t, ok)
+		assert.LessOrEqual(t, cache.Size(), int64(1000))
+
+		// Entries larger than the cap are never stored
+		cache.Set("huge", entry(strings.Repeat("x", 2000), time.Now()))
+		_, ok = cache.Get("huge")
+		assert.False(t, ok)
+	})
+
+	t.Run("callers cannot change the cached entries", func(t *testing.T) {
+		t.Parallel()
+
+		cache := NewMemoryCache(time.Hour, 1<<20)
+		resp := entry("a", time.Now())
+		cache.Set("key", resp)
+		resp.Header.Set("Etag", `"changed"`)
+
+		cached, ok := cache.Get("key")
+		require.True(t, ok)
+		assert.Equal(t, `"x"`, cached.Header.Get("Etag"), "the cache keeps its own copy")
+		cached.Header.Set("X-Other", "1")
+		cached, _ = cache.Get("key")
+		assert.Empty(t, cached.Header.Get("X-Other"))
+	})
+}
@@ -0,0 +1,222 @@
+package transport
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	headers "github.com/github/github-mcp-server/pkg/http/headers"
+)
+
+const (
+	// DefaultRateLimitRetries is the number of times a rate limited request is retried.
+	DefaultRateLimitRetries = 2
+	// DefaultRateLimitMaxWait is the longest a request waits for a rate limit to lift. Requests
+	// limited for longer fail straight away, so that agents can tell the user rather than hang.
+	DefaultRateLimitMaxWait = 90 * time.Second
+
+	// secondaryRateLimitBackoff is the wait before retrying a request hitting a secondary rate
+	// limit without a Retry-After header, which GitHub asks to be at least a minute. It doubles
+	// with each retry.
+	secondaryRateLimitBackoff = time.Minute
+
+	// maxRateLimitBodySize is the size of the error body read to recognize secondary rate limits.
+	maxRateLimitBodySize = 64 << 10
+)
+
+// RateLimitTransport waits out GitHub's rate limits instead of failing requests. Requests for a
+// resource whose primary rate limit is exhausted are queued until it resets, and requests refused
+// by a primary or secondary rate limit are retried after the Retry-After delay, the reset time,
+// or a jittered backoff. Requests that would wait longer than MaxWait are sent, or returned, as
+// they are, so that the rate limit error reaches the caller.
+type RateLimitTransport struct {
+	Transport http.RoundTripper
+	// MaxRetries is the number of times a rate limited request is retried. Zero uses
+	// DefaultRateLimitRetries.
+	MaxRetries int
+	// MaxWait is the longest a request waits for a rate limit. Zero uses DefaultRateLimitMaxWait.
+	MaxWait time.Duration
+
+	now   func() time.Time
+	sleep func(ctx context.Context, d time.Duration) error
+
+	mu sync.Mutex
+	// exhausted holds the reset time of each resource whose primary rate limit ran out.
+	exhausted map[string]time.Time
+}
+
+func (t *RateLimitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resource := rateLimitResource(req)
+	if wait := t.exhaustedFor(resource); wait > 0 && wait <= t.maxWait() {
+		if err := t.wait(req.Context(), wait); err != nil {
+			return nil, err
+		}
+	}
+
+	maxRetries := t.MaxRetries
+	if maxRetries == 0 {
+		maxRetries = DefaultRateLimitRetries
+	}
+	for attempt := 0; ; attempt++ {
+		resp, err := t.Transport.RoundTrip(req)
+		if err != nil {
+			return nil, err
+		}
+		t.observe(resource, resp)
+
+		wait, limited := t.retryDelay(resp, attempt)
+		if !limited || attempt >= maxRetries || wait > t.maxWait() {
+			return resp, nil
+		}
+		// Requests whose body was consumed cannot be retried
+		if req.Body != nil && req.Body != http.NoBody {
+			if req.GetBody == nil {
+				return resp, nil
+			}
+			body, err := req.GetBody()
+			if err != nil {
+				return resp, nil
+			}
+			req = req.Clone(req.Context())
+			req.Body = body
+		}
+		_ = resp.Body.Close()
+		if err := t.wait(req.Context(), wait); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// retryDelay reports whether resp was refused by a rate limit, and how long to wait before
+// retrying it.
+func (t *RateLimitTransport) retryDelay(resp *http.Response, attempt int) (time.Duration, bool) {
+	if resp.StatusCode != http.StatusForbidden && resp.StatusCode != http.StatusTooManyRequests {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(resp.Header.Get(headers.RetryAfterHeader)); err == nil {
+		return time.Duration(seconds)*time.Second + jitter(time.Second), true
+	}
+	if resp.Header.Get(headers.RateLimitRemainingHeader) == "0" {
+		if reset, ok := rateLimitReset(resp); ok {
+			// Wait a second past the reset to absorb clock skew
+			return max(reset.Sub(t.clock()), 0) + time.Second, true
+		}
+	}
+	if resp.StatusCode == http.StatusTooManyRequests || isSecondaryRateLimit(resp) {
+		backoff := secondaryRateLimitBackoff << attempt
+		return backoff + jitter(backoff/4), true
+	}
+	return 0, false
+}
+
+// observe records the reset time of resource when resp reports its rate limit exhausted.
+func (t *RateLimitTransport) observe(resource string, resp *http.Response) {
+	if r := resp.Header.Get(headers.RateLimitResourceHeader); r != "" {
+		resource = r
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if resp.Header.Get(headers.RateLimitRemainingHeader) != "0" {
+		delete(t.exhausted, resource)
+		return
+	}
+	if reset, ok := rateLimitReset(resp); ok {
+		if t.exhausted == nil {
+			t.exhausted = map[string]time.Time{}
+		}
+		t.exhausted[resource] = reset
+	}
+}
+
+// exhaustedFor returns how long until the primary rate limit of resource resets, or zero when it
+// is not known to be exhausted.
+func (t *RateLimitTransport) exhaustedFor(resource string) time.Duration {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	reset, ok := t.exhausted[resource]
+	if !ok {
+		return 0
+	}
+	wait := reset.Sub(t.clock())
+	if wait <= 0 {
+		delete(t.exhausted, resource)
+		return 0
+	}
+	return wait + time.Second
+}
+
+func (t *RateLimitTransport) maxWait() time.Duration {
+	if t.MaxWait == 0 {
+		return DefaultRateLimitMaxWait
+	}
+	return t.MaxWait
+}
+
+func (t *RateLimitTransport) clock() time.Time {
+	if t.now != nil {
+		return t.now()
+	}
+	return time.Now()
+}
+
+func (t *RateLimitTransport) wait(ctx context.Context, d time.Duration) error {
+	if t.sleep != nil {
+		return t.sleep(ctx, d)
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// rateLimitResource guesses the rate limit resource a request counts against, before GitHub
+// names it in the response.
+func rateLimitResource(req *http.Request) string {
+	switch {
+	case strings.HasSuffix(req.URL.Path, "/graphql"):
+		return "graphql"
+	case strings.Contains(req.URL.Path, "/search/"):
+		return "search"
+	default:
+		return "core"
+	}
+}
+
+// rateLimitReset returns the reset time of the rate limit window resp counted against.
+func rateLimitReset(resp *http.Response) (time.Time, bool) {
+	seconds, err := strconv.ParseInt(resp.Header.Get(headers.RateLimitResetHeader), 10, 64)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return time.Unix(seconds, 0), true
+}
+
+// isSecondaryRateLimit reports whether a 403 response was refused by a secondary rate limit,
+// which GitHub only tells apart by its message. The body is restored for the caller.
+func isSecondaryRateLimit(resp *http.Response) bool {
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxRateLimitBodySize))
+	_ = resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	if err != nil {
+		return false
+	}
+	return bytes.Contains(bytes.ToLower(body), []byte("secondary rate limit"))
+}
+
+// jitter returns a random duration up to d, spreading out retries of concurrent requests.
+func jitter(d time.Duration) time.Duration {
+	if d <= 0 {
+		return 0
+	}
+	return rand.N(d)
+}
@@ -0,0 +1,207 @@
+package transport
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestRateLimitTransport creates a RateLimitTransport whose clock only moves when it sleeps,
+// recording each wait.
+func newTestRateLimitTransport(now time.Time, waits *[]time.Duration) *RateLimitTransport {
+	t := &RateLimitTransport{Transport: http.DefaultTransport}
+	t.now = func() time.Time { return now }
+	t.sleep = func(_ context.Context, d time.Duration) error {
+		*waits = append(*waits, d)
+		now = now.Add(d)
+		return nil
+	}
+	return t
+}
+
+func TestRateLimitTransport(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	reset := strconv.FormatInt(now.Add(30*time.Second).Unix(), 10)
+
+	tests := []struct {
+		name string
+		// respond answers the nth request, counting from zero
+		respond       func(w http.ResponseWriter, n int32)
+		maxWait       time.Duration
+		expectedCalls int32
+		expectedCode  int
+		// checkWaits checks the waits before each retry
+		checkWaits func(t *testing.T, waits []time.Duration)
+	}{
+		{
+			name: "retry after header",
+			respond: func(w http.ResponseWriter, n int32) {
+				if n == 0 {
+					w.Header().Set("Retry-After", "5")
+					w.WriteHeader(http.StatusForbidden)
+					return
+				}
+				w.WriteHeader(http.StatusOK)
+			},
+			expectedCalls: 2,
+			expectedCode:  http.StatusOK,
+			checkWaits: func(t *testing.T, waits []time.Duration) {
+				require.Len(t, waits, 1)
+				assert.GreaterOrEqual(t, waits[0], 5*time.Second)
+				assert.Less(t, waits[0], 6*time.Second)
+			},
+		},
+		{
+			name: "primary rate limit waits for the reset",
+			respond: func(w http.ResponseWriter, n int32) {
+				if n == 0 {
+					w.Header().Set("X-RateLimit-Remaining", "0")
+					w.Header().Set("X-RateLimit-Reset", reset)
+					w.WriteHeader(http.StatusForbidden)
+					return
+				}
+				w.Header().Set("X-RateLimit-Remaining", "4999")
+				w.WriteHeader(http.StatusOK)
+			},
+			expectedCalls: 2,
+			expectedCode:  http.StatusOK,
+			checkWaits: func(t *testing.T, waits []time.Duration) {
+				assert.Equal(t, []time.Duration{31 * time.Second}, waits)
+			},
+		},
+		{
+			name: "secondary rate limit backs off",
+			respond: func(w http.ResponseWriter, n int32) {
+				if n == 0 {
+					w.WriteHeader(http.StatusForbidden)
+					_, _ = io.WriteString(w, `{"message":"You have exceeded a secondary rate limit."}`)
+					return
+				}
+				w.WriteHeader(http.StatusOK)
+			},
+			expectedCalls: 2,
+			expectedCode:  http.StatusOK,
+			checkWaits: func(t *testing.T, waits []time.Duration) {
+				require.Len(t, waits, 1)
+				assert.GreaterOrEqual(t, waits[0], time.Minute)
+			},
+		},
+		{
+			name: "gives up after the retries",
+			respond: func(w http.ResponseWriter, _ int32) {
+				w.Header().Set("Retry-After", "1")
+				w.WriteHeader(http.StatusTooManyRequests)
+			},
+			expectedCalls: 3,
+			expectedCode:  http.StatusTooManyRequests,
+			checkWaits: func(t *testing.T, waits []time.Duration) {
+				assert.Len(t, waits, 2)
+			},
+		},
+		{
+			name: "does not wait longer than the maximum",
+			respond: func(w http.ResponseWriter, _ int32) {
+				w.Header().Set("Retry-After", "600")
+				w.WriteHeader(http.StatusForbidden)
+			},
+			expectedCalls: 1,
+			expectedCode:  http.StatusForbidden,
+			checkWaits: func(t *testing.T, waits []time.Duration) {
+				assert.Empty(t, waits)
+			},
+		},
+		{
+			name: "other forbidden responses are not retried",
+			respond: func(w http.ResponseWriter, _ int32) {
+				w.WriteHeader(http.StatusForbidden)
+				_, _ = io.WriteString(w, `{"message":"Resource not accessible by integration"}`)
+			},
+			expectedCalls: 1,
+			expectedCode:  http.StatusForbidden,
+			checkWaits: func(t *testing.T, waits []time.Duration) {
+				assert.Empty(t, waits)
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var calls atomic.Int32
+			var bodies []string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, _ := io.ReadAll(r.Body)
+				bodies = append(bodies, string(body))
+				tc.respond(w, calls.Add(1)-1)
+			}))
+			defer server.Close()
+
+			var waits []time.Duration
+			rt := newTestRateLimitTransport(now, &waits)
+			rt.MaxWait = tc.maxWait
+
+			req, err := http.NewRequest(http.MethodPost, server.URL+"/repos/owner/repo/issues", strings.NewReader(`{"title":"t"}`))
+			require.NoError(t, err)
+			resp, err := rt.RoundTrip(req)
+			require.NoError(t, err)
+			body, err := io.ReadAll(resp.Body)
+			require.NoError(t, err)
+			_ = resp.Body.Close()
+
+			assert.Equal(t, tc.expectedCode, resp.StatusCode)
+			assert.Equal(t, tc.expectedCalls, calls.Load())
+			for _, b := range bodies {
+				assert.Equal(t, `{"title":"t"}`, b, "retries resend the body")
+			}
+			if tc.expectedCode == http.StatusForbidden && len(body) > 0 {
+				assert.Contains(t, string(body), "message", "the error body reaches the caller")
+			}
+			tc.checkWaits(t, waits)
+		})
+	}
+}
+
+func TestRateLimitTransportQueuesExhaustedResources(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/search/issues" {
+			w.Header().Set("X-RateLimit-Resource", "search")
+			w.Header().Set("X-RateLimit-Remaining", "0")
+			w.Header().Set("X-RateLimit-Reset", strconv.FormatInt(now.Add(10*time.Second).Unix(), 10))
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	var waits []time.Duration
+	rt := newTestRateLimitTransport(now, &waits)
+	get := func(path string) {
+		req, err := http.NewRequest(http.MethodGet, server.URL+path, nil)
+		require.NoError(t, err)
+		resp, err := rt.RoundTrip(req)
+		require.NoError(t, err)
+		_ = resp.Body.Close()
+	}
+
+	// The last search request used up the quota, which only delays further searches
+	get("/search/issues")
+	assert.Empty(t, waits)
+	get("/repos/owner/repo")
+	assert.Empty(t, waits)
+	get("/search/issues")
+	assert.Equal(t, []time.Duration{11 * time.Second}, waits)
+}
@@ -0,0 +1,271 @@
+package transport
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"os/exec"
+	"regexp"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+
+	headers "github.com/github/github-mcp-server/pkg/http/headers"
+)
+
+// TokenSource provides the token GitHub API requests are authenticated with. Implementations
+// must be safe for concurrent use.
+type TokenSource interface {
+	// Token returns the current token.
+	Token(ctx context.Context) (string, error)
+	// Invalidate reports that the API rejected token, so that the next call to Token provides
+	// a new one if it can.
+	Invalidate(token string)
+}
+
+// StaticTokenSource is a TokenSource always providing the same token.
+type StaticTokenSource string
+
+func (s StaticTokenSource) Token(context.Context) (string, error) {
+	return string(s), nil
+}
+
+func (StaticTokenSource) Invalidate(string) {}
+
+// DefaultTokenCommandTTL is how long the output of a token command is used before the command
+// runs again, unless the API rejects the token first.
+const DefaultTokenCommandTTL = 5 * time.Minute
+
+// CommandTokenSource provides the token printed by a shell command, such as "gh auth token",
+// running it again once its output is older than a TTL or has been rejected.
+type CommandTokenSource struct {
+	command string
+	ttl     time.Duration
+	now     func() time.Time
+
+	mu        sync.Mutex
+	token     string
+	fetchedAt time.Time
+}
+
+// NewCommandTokenSource creates a CommandTokenSource running command, whose output is reused for
+// ttl, or DefaultTokenCommandTTL when ttl is not positive.
+func NewCommandTokenSource(command string, ttl time.Duration) *CommandTokenSource {
+	if ttl <= 0 {
+		ttl = DefaultTokenCommandTTL
+	}
+	return &CommandTokenSource{command: command, ttl: ttl, now: time.Now}
+}
+
+func (s *CommandTokenSource) Token(ctx context.Context) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.token != "" && s.now().Sub(s.fetchedAt) < s.ttl {
+		return s.token, nil
+	}
+
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = exec.CommandContext(ctx, "cmd", "/C", s.command)
+	} else {
+		cmd = exec.CommandContext(ctx, "sh", "-c", s.command)
+	}
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("token command failed: %w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+	token := strings.TrimSpace(string(out))
+	if token == "" {
+		return "", errors.New("token command printed no token")
+	}
+	s.token, s.fetchedAt = token, s.now()
+	return token, nil
+}
+
+func (s *CommandTokenSource) Invalidate(token string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.token == token {
+		s.token = ""
+	}
+}
+
+// FileTokenSource provides the token stored in a file, reading it again whenever the file
+// changes, so that an external process can rotate it.
+type FileTokenSource struct {
+	path string
+
+	mu      sync.Mutex
+	token   string
+	modTime time.Time
+}
+
+// NewFileTokenSource creates a FileTokenSource reading the file at path.
+func NewFileTokenSource(path string) *FileTokenSource {
+	return &FileTokenSource{path: path}
+}
+
+func (s *FileTokenSource) Token(context.Context) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	info, err := os.Stat(s.path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read token file: %w", err)
+	}
+	if s.token != "" && info.ModTime().Equal(s.modTime) {
+		return s.token, nil
+	}
+
+	content, err := os.ReadFile(s.path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read token file: %w", err)
+	}
+	token := strings.TrimSpace(string(content))
+	if token == "" {
+		return "", fmt.Errorf("token file %s is empty", s.path)
+	}
+	s.token, s.modTime = token, info.ModTime()
+	return token, nil
+}
+
+func (s *FileTokenSource) Invalidate(token string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.token == token {
+		s.token = ""
+	}
+}
+
+// SessionTokenMetaKey is the key of the initialize request's _meta holding the token of a
+// SessionTokenSource.
+const SessionTokenMetaKey = "github_token"
+
+// sessionTokenPlaceholder matches the placeholders of an environment variable template.
+var sessionTokenPlaceholder = regexp.MustCompile(`\{([A-Za-z0-9_]+)\}`)
+
+// sessionTokenValue matches the _meta values allowed in environment variable names.
+var sessionTokenValue = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+// SessionTokenSource provides the token of the client that initialized the session, so that one
+// configured server can serve several users. Its token is unknown until Resolve is called with
+// the _meta of the initialize request.
+type SessionTokenSource struct {
+	envTemplate string
+	getenv      func(string) string
+
+	mu    sync.RWMutex
+	token string
+}
+
+// NewSessionTokenSource creates a SessionTokenSource. The token is read from the
+// SessionTokenMetaKey entry of the initialize request's _meta or, when the client does not
+// provide it and envTemplate is set, from the environment variable envTemplate names, with
+// "{key}" replaced by the value of key in _meta, such as GITHUB_TOKEN_{user}.
+func NewSessionTokenSource(envTemplate string) *SessionTokenSource {
+	return &SessionTokenSource{envTemplate: envTemplate, getenv: os.Getenv}
+}
+
+// Resolve sets the token from the _meta of the initialize request.
+func (s *SessionTokenSource) Resolve(meta map[string]any) error {
+	token, err := s.resolve(meta)
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.token = token
+	return nil
+}
+
+func (s *SessionTokenSource) resolve(meta map[string]any) (string, error) {
+	if token, _ := meta[SessionTokenMetaKey].(string); strings.TrimSpace(token) != "" {
+		return strings.TrimSpace(token), nil
+	}
+	if s.envTemplate == "" {
+		return "", fmt.Errorf("the client did not provide a GitHub token in the %s entry of the initialize request's _meta", SessionTokenMetaKey)
+	}
+
+	var missing []string
+	name := sessionTokenPlaceholder.ReplaceAllStringFunc(s.envTemplate, func(placeholder string) string {
+		key := placeholder[1 : len(placeholder)-1]
+		value, _ := meta[key].(string)
+		if !sessionTokenValue.MatchString(value) {
+			missing = append(missing, key)
+			return placeholder
+		}
+		return value
+	})
+	if len(missing) > 0 {
+		return "", fmt.Errorf("the client did not provide a GitHub token, nor valid %s entries of the initialize request's _meta to find one", strings.Join(missing, ", "))
+	}
+	token := strings.TrimSpace(s.getenv(name))
+	if token == "" {
+		return "", fmt.Errorf("environment variable %s holds no GitHub token", name)
+	}
+	return token, nil
+}
+
+func (s *SessionTokenSource) Token(context.Context) (string, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.token == "" {
+		return "", errors.New("no GitHub token: the session has not been initialized")
+	}
+	return s.token, nil
+}
+
+// Invalidate does nothing, as only the client can provide another token.
+func (*SessionTokenSource) Invalidate(string) {}
+
+// TokenSourceTransport authenticates requests with the token of a TokenSource. When the API
+// rejects the token with a 401, the token is invalidated and the request retried once with a
+// new token, so that long-lived sessions survive token expiry.
+type TokenSourceTransport struct {
+	Transport http.RoundTripper
+	Source    TokenSource
+}
+
+func (t *TokenSourceTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	token, err := t.Source.Token(req.Context())
+	if err != nil {
+		return nil, err
+	}
+	resp, err := t.roundTrip(req, token)
+	if err != nil || resp.StatusCode != http.StatusUnauthorized {
+		return resp, err
+	}
+
+	// Requests whose body was consumed cannot be retried
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		return resp, nil
+	}
+	t.Source.Invalidate(token)
+	newToken, err := t.Source.Token(req.Context())
+	if err != nil || newToken == token {
+		return resp, nil
+	}
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return resp, nil
+		}
+		req = req.Clone(req.Context())
+		req.Body = body
+	}
+	_ = resp.Body.Close()
+	return t.roundTrip(req, newToken)
+}
+
+func (t *TokenSourceTransport) roundTrip(req *http.Request, token string) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.Header.Set(headers.AuthorizationHeader, "Bearer "+token)
+	return t.Transport.RoundTrip(req)
+}
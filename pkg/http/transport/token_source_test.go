@@ -0,0 +1,151 @@
+package transport
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCommandTokenSource(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses a POSIX shell command")
+	}
+	t.Parallel()
+
+	// Each run of the command appends a line to a file and prints the number of lines
+	counter := filepath.Join(t.TempDir(), "runs")
+	source := NewCommandTokenSource("echo x >> "+counter+" && printf 'token_%s\\n' $(wc -l < "+counter+" | tr -d ' ')", time.Minute)
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	source.now = func() time.Time { return now }
+
+	token, err := source.Token(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "token_1", token)
+
+	token, err = source.Token(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "token_1", token, "the output is reused within the TTL")
+
+	now = now.Add(2 * time.Minute)
+	token, err = source.Token(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "token_2", token)
+
+	source.Invalidate("token_2")
+	token, err = source.Token(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "token_3", token)
+
+	_, err = NewCommandTokenSource("echo oops >&2; exit 1", 0).Token(context.Background())
+	assert.ErrorContains(t, err, "oops")
+}
+
+func TestFileTokenSource(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "token")
+	require.NoError(t, os.WriteFile(path, []byte("token_1\n"), 0600))
+	source := NewFileTokenSource(path)
+
+	token, err := source.Token(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "token_1", token)
+
+	require.NoError(t, os.WriteFile(path, []byte("token_2"), 0600))
+	require.NoError(t, os.Chtimes(path, time.Now(), time.Now().Add(time.Minute)))
+	token, err = source.Token(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "token_2", token)
+
+	require.NoError(t, os.Remove(path))
+	_, err = source.Token(context.Background())
+	assert.ErrorContains(t, err, "failed to read token file")
+}
+
+// rotatingTokenSource provides "old" until it is invalidated, then "new".
+type rotatingTokenSource struct {
+	invalidated bool
+}
+
+func (s *rotatingTokenSource) Token(context.Context) (string, error) {
+	if s.invalidated {
+		return "new", nil
+	}
+	return "old", nil
+}
+
+func (s *rotatingTokenSource) Invalidate(token string) {
+	s.invalidated = s.invalidated || token == "old"
+}
+
+func TestTokenSourceTransport(t *testing.T) {
+	t.Parallel()
+
+	var bodies []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(body))
+		if r.Header.Get("Authorization") != "Bearer new" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(server.Close)
+
+	client := &http.Client{Transport: &TokenSourceTransport{Transport: http.DefaultTransport, Source: &rotatingTokenSource{}}}
+	resp, err := client.Post(server.URL, "text/plain", strings.NewReader("payload"))
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, []string{"payload", "payload"}, bodies, "the request is retried with its body")
+
+	// A token that cannot be replaced is not retried
+	bodies = nil
+	client = &http.Client{Transport: &TokenSourceTransport{Transport: http.DefaultTransport, Source: StaticTokenSource("old")}}
+	resp, err = client.Get(server.URL)
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	assert.Len(t, bodies, 1)
+}
+
+func TestSessionTokenSource(t *testing.T) {
+	source := NewSessionTokenSource("")
+	_, err := source.Token(context.Background())
+	assert.ErrorContains(t, err, "the session has not been initialized")
+
+	assert.ErrorContains(t, source.Resolve(nil), "did not provide a GitHub token")
+	require.NoError(t, source.Resolve(map[string]any{"github_token": " ghp_session\n"}))
+	token, err := source.Token(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "ghp_session", token)
+
+	source = NewSessionTokenSource("GITHUB_TOKEN_{user}")
+	source.getenv = func(name string) string {
+		return map[string]string{"GITHUB_TOKEN_octocat": "ghp_octocat"}[name]
+	}
+	assert.ErrorContains(t, source.Resolve(map[string]any{}), "nor valid user entries")
+	assert.ErrorContains(t, source.Resolve(map[string]any{"user": "../octocat"}), "nor valid user entries")
+	assert.EqualError(t, source.Resolve(map[string]any{"user": "hubot"}), "environment variable GITHUB_TOKEN_hubot holds no GitHub token")
+	require.NoError(t, source.Resolve(map[string]any{"user": "octocat"}))
+	token, err = source.Token(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "ghp_octocat", token)
+
+	// A token passed by the client takes precedence over the environment
+	require.NoError(t, source.Resolve(map[string]any{"user": "octocat", "github_token": "ghp_own"}))
+	token, err = source.Token(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "ghp_own", token)
+}
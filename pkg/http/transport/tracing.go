@@ -0,0 +1,45 @@
+package transport
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/github/github-mcp-server/pkg/http/headers"
+	"github.com/github/github-mcp-server/pkg/observability/tracing"
+)
+
+// TracingTransport records a client span for each GitHub API request, a child of the span of the
+// tool call making it, with the method, path, response status and remaining rate limit. Transports
+// it wraps can annotate the span with tracing.SpanFromContext.
+type TracingTransport struct {
+	Transport http.RoundTripper
+	Tracer    tracing.Tracer
+}
+
+func (t *TracingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	name := "GitHub REST " + req.Method
+	if strings.HasSuffix(req.URL.Path, "/graphql") {
+		name = "GitHub GraphQL"
+	}
+	ctx, span := t.Tracer.Start(req.Context(), name, tracing.SpanKindClient, map[string]any{
+		"http.request.method": req.Method,
+		"server.address":      req.URL.Host,
+		"url.path":            req.URL.Path,
+	})
+	defer span.End()
+
+	resp, err := t.Transport.RoundTrip(req.WithContext(ctx))
+	if err != nil {
+		span.SetError(err.Error())
+		return nil, err
+	}
+	attrs := map[string]any{"http.response.status_code": resp.StatusCode}
+	if remaining := resp.Header.Get(headers.RateLimitRemainingHeader); remaining != "" {
+		attrs["github.rate_limit.remaining"] = remaining
+	}
+	span.SetAttributes(attrs)
+	if resp.StatusCode >= http.StatusBadRequest {
+		span.SetError(resp.Status)
+	}
+	return resp, nil
+}
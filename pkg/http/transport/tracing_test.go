@@ -0,0 +1,90 @@
+package transport
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/github/github-mcp-server/pkg/observability/tracing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingTracer records the spans it starts.
+type recordingTracer struct {
+	mu    sync.Mutex
+	spans []*recordedSpan
+}
+
+type recordedSpan struct {
+	name  string
+	kind  tracing.SpanKind
+	attrs map[string]any
+	err   string
+	ended bool
+}
+
+func (t *recordingTracer) Start(ctx context.Context, name string, kind tracing.SpanKind, attrs map[string]any) (context.Context, tracing.Span) {
+	span := &recordedSpan{name: name, kind: kind, attrs: map[string]any{}}
+	span.SetAttributes(attrs)
+	t.mu.Lock()
+	t.spans = append(t.spans, span)
+	t.mu.Unlock()
+	return tracing.ContextWithSpan(ctx, span), span
+}
+
+func (s *recordedSpan) SetAttributes(attrs map[string]any) {
+	for key, value := range attrs {
+		s.attrs[key] = value
+	}
+}
+
+func (s *recordedSpan) SetError(message string) { s.err = message }
+func (s *recordedSpan) End()                    { s.ended = true }
+
+func TestTracingTransport(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-RateLimit-Remaining", "4999")
+		if strings.HasSuffix(r.URL.Path, "/missing") {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(`{"data":{"viewer":{"login":"octocat"},"mcpRateLimit":{"cost":3}}}`))
+	}))
+	t.Cleanup(server.Close)
+
+	tracer := &recordingTracer{}
+	client := &http.Client{Transport: &TracingTransport{
+		Transport: &GraphQLCostTransport{Transport: http.DefaultTransport},
+		Tracer:    tracer,
+	}}
+
+	resp, err := client.Get(server.URL + "/repos/octo/app/missing")
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+	resp, err = client.Post(server.URL+"/graphql", "application/json", strings.NewReader(`{"query":"{viewer{login}}"}`))
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+
+	require.Len(t, tracer.spans, 2)
+	rest, graphql := tracer.spans[0], tracer.spans[1]
+
+	assert.Equal(t, "GitHub REST GET", rest.name)
+	assert.Equal(t, tracing.SpanKindClient, rest.kind)
+	assert.Equal(t, "/repos/octo/app/missing", rest.attrs["url.path"])
+	assert.Equal(t, http.StatusNotFound, rest.attrs["http.response.status_code"])
+	assert.Equal(t, "4999", rest.attrs["github.rate_limit.remaining"])
+	assert.Equal(t, "404 Not Found", rest.err)
+	assert.True(t, rest.ended)
+
+	assert.Equal(t, "GitHub GraphQL", graphql.name)
+	assert.Equal(t, "viewer", graphql.attrs["graphql.root_field"])
+	assert.Equal(t, 3, graphql.attrs["github.graphql.cost"])
+	assert.Empty(t, graphql.err)
+	assert.True(t, graphql.ended)
+}
@@ -47,6 +47,8 @@ type Builder struct {
 
 	// Configuration options (processed at Build time)
 	readOnly             bool
+	withoutDestructive   bool
+	onlyIdempotent       bool
 	toolsetIDs           []string // raw input, processed at Build()
 	toolsetIDsIsNil      bool     // tracks if nil was passed (nil = defaults)
 	additionalTools      []string // raw input, processed at Build()
@@ -95,6 +97,22 @@ func (b *Builder) WithReadOnly(readOnly bool) *Builder {
 	return b
 }
 
+// WithoutDestructive sets whether destructive tools should be hidden.
+// When true, write tools that may delete or overwrite data are filtered out, while additive
+// write tools remain available. Returns self for chaining.
+func (b *Builder) WithoutDestructive(withoutDestructive bool) *Builder {
+	b.withoutDestructive = withoutDestructive
+	return b
+}
+
+// OnlyIdempotent sets whether only idempotent tools should be available.
+// When true, write tools whose repeated calls have additional effects are filtered out.
+// Returns self for chaining.
+func (b *Builder) OnlyIdempotent(onlyIdempotent bool) *Builder {
+	b.onlyIdempotent = onlyIdempotent
+	return b
+}
+
 func (b *Builder) WithServerInstructions() *Builder {
 	b.generateInstructions = true
 	return b
@@ -222,13 +240,15 @@ func (b *Builder) Build() (*Inventory, error) {
 	}
 
 	r := &Inventory{
-		tools:             tools,
-		resourceTemplates: b.resourceTemplates,
-		prompts:           b.prompts,
-		deprecatedAliases: b.deprecatedAliases,
-		readOnly:          b.readOnly,
-		featureChecker:    b.featureChecker,
-		filters:           b.filters,
+		tools:              tools,
+		resourceTemplates:  b.resourceTemplates,
+		prompts:            b.prompts,
+		deprecatedAliases:  b.deprecatedAliases,
+		readOnly:           b.readOnly,
+		withoutDestructive: b.withoutDestructive,
+		onlyIdempotent:     b.onlyIdempotent,
+		featureChecker:     b.featureChecker,
+		filters:            b.filters,
 	}
 
 	// Process toolsets and pre-compute metadata in a single pass
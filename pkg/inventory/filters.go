@@ -51,11 +51,26 @@ func (r *Inventory) isFeatureFlagAllowed(ctx context.Context, enableFlag, disabl
 	return true
 }
 
+// annotationsAllowed checks a tool's annotations against the read-only, destructive and
+// idempotent filters.
+func (r *Inventory) annotationsAllowed(tool *ServerTool) bool {
+	if r.readOnly && !tool.IsReadOnly() {
+		return false
+	}
+	if r.withoutDestructive && tool.IsDestructive() {
+		return false
+	}
+	if r.onlyIdempotent && !tool.IsIdempotent() {
+		return false
+	}
+	return true
+}
+
 // isToolEnabled checks if a specific tool is enabled based on current filters.
 // Filter evaluation order:
 //  1. Tool.Enabled (tool self-filtering)
 //  2. FeatureFlagEnable/FeatureFlagDisable
-//  3. Read-only filter
+//  3. Read-only, destructive and idempotent filters
 //  4. Builder filters (via WithFilter)
 //  5. Toolset/additional tools
 func (r *Inventory) isToolEnabled(ctx context.Context, tool *ServerTool) bool {
@@ -74,8 +89,8 @@ func (r *Inventory) isToolEnabled(ctx context.Context, tool *ServerTool) bool {
 	if !r.isFeatureFlagAllowed(ctx, tool.FeatureFlagEnable, tool.FeatureFlagDisable) {
 		return false
 	}
-	// 3. Check read-only filter (applies to all tools)
-	if r.readOnly && !tool.IsReadOnly() {
+	// 3. Check read-only, destructive and idempotent filters (apply to all tools)
+	if !r.annotationsAllowed(tool) {
 		return false
 	}
 	// 4. Apply builder filters
@@ -217,14 +232,14 @@ func (r *Inventory) filterPromptsByName(name string) []ServerPrompt {
 
 // ToolsForToolset returns all tools belonging to a specific toolset.
 // This method bypasses the toolset enabled filter (for dynamic toolset registration),
-// but still respects the read-only filter.
+// but still respects the read-only, destructive and idempotent filters.
 func (r *Inventory) ToolsForToolset(toolsetID ToolsetID) []ServerTool {
 	var result []ServerTool
 	for i := range r.tools {
 		tool := &r.tools[i]
-		// Only check read-only filter, not toolset enabled filter
+		// Only check annotation filters, not toolset enabled filter
 		if tool.Toolset.ID == toolsetID {
-			if r.readOnly && !tool.IsReadOnly() {
+			if !r.annotationsAllowed(tool) {
 				continue
 			}
 			result = append(result, *tool)
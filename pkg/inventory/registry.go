@@ -43,6 +43,10 @@ type Inventory struct {
 	// Filters - these control what's returned by Available* methods
 	// readOnly when true filters out write tools
 	readOnly bool
+	// withoutDestructive when true filters out destructive write tools
+	withoutDestructive bool
+	// onlyIdempotent when true filters out non-idempotent write tools
+	onlyIdempotent bool
 	// enabledToolsets when non-nil, only include tools/resources/prompts from these toolsets
 	// when nil, all toolsets are enabled
 	enabledToolsets map[ToolsetID]bool
@@ -109,6 +113,8 @@ func (r *Inventory) ForMCPRequest(method string, itemName string) *Inventory {
 		prompts:              r.prompts,
 		deprecatedAliases:    r.deprecatedAliases,
 		readOnly:             r.readOnly,
+		withoutDestructive:   r.withoutDestructive,
+		onlyIdempotent:       r.onlyIdempotent,
 		enabledToolsets:      r.enabledToolsets, // shared, not modified
 		additionalTools:      r.additionalTools, // shared, not modified
 		featureChecker:       r.featureChecker,
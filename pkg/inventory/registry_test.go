@@ -2239,3 +2239,66 @@ func TestCreateExcludeToolsFilter(t *testing.T) {
 	require.NoError(t, err)
 	require.True(t, allowed, "allowed_tool should be included")
 }
+
+// mockToolWithAnnotations creates a ServerTool with the given annotations for testing
+func mockToolWithAnnotations(name string, toolsetID string, annotations *mcp.ToolAnnotations) ServerTool {
+	tool := mockTool(name, toolsetID, false)
+	tool.Tool.Annotations = annotations
+	return tool
+}
+
+func TestWithoutDestructiveAndOnlyIdempotent(t *testing.T) {
+	destructive, nonDestructive := true, false
+	tools := []ServerTool{
+		mockTool("read", "toolset1", true),
+		mockToolWithAnnotations("delete", "toolset1", &mcp.ToolAnnotations{DestructiveHint: &destructive}),
+		mockToolWithAnnotations("create", "toolset1", &mcp.ToolAnnotations{DestructiveHint: &nonDestructive}),
+		mockToolWithAnnotations("set", "toolset1", &mcp.ToolAnnotations{DestructiveHint: &nonDestructive, IdempotentHint: true}),
+		mockToolWithAnnotations("unannotated", "toolset1", nil),
+	}
+
+	tests := []struct {
+		name               string
+		withoutDestructive bool
+		onlyIdempotent     bool
+		expected           []string
+	}{
+		{
+			name:     "no filters",
+			expected: []string{"create", "delete", "read", "set", "unannotated"},
+		},
+		{
+			name:               "without destructive treats unannotated write tools as destructive",
+			withoutDestructive: true,
+			expected:           []string{"create", "read", "set"},
+		},
+		{
+			name:           "only idempotent keeps read-only tools",
+			onlyIdempotent: true,
+			expected:       []string{"read", "set"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			reg := mustBuild(t, NewBuilder().
+				SetTools(tools).
+				WithToolsets([]string{"all"}).
+				WithoutDestructive(tc.withoutDestructive).
+				OnlyIdempotent(tc.onlyIdempotent))
+
+			var names []string
+			for _, tool := range reg.AvailableTools(context.Background()) {
+				names = append(names, tool.Tool.Name)
+			}
+			require.Equal(t, tc.expected, names)
+
+			// Dynamic toolset registration applies the same filters
+			names = nil
+			for _, tool := range reg.ToolsForToolset("toolset1") {
+				names = append(names, tool.Tool.Name)
+			}
+			require.Equal(t, tc.expected, names)
+		})
+	}
+}
@@ -89,6 +89,22 @@ func (st *ServerTool) IsReadOnly() bool {
 	return st.Tool.Annotations != nil && st.Tool.Annotations.ReadOnlyHint
 }
 
+// IsDestructive returns true if this tool may perform destructive updates. Following the MCP
+// specification, write tools without a DestructiveHint annotation are assumed to be destructive.
+func (st *ServerTool) IsDestructive() bool {
+	if st.IsReadOnly() {
+		return false
+	}
+	return st.Tool.Annotations == nil || st.Tool.Annotations.DestructiveHint == nil || *st.Tool.Annotations.DestructiveHint
+}
+
+// IsIdempotent returns true if calling this tool repeatedly with the same arguments has no
+// additional effect. Read-only tools are always idempotent; write tools only when annotated
+// with IdempotentHint.
+func (st *ServerTool) IsIdempotent() bool {
+	return st.IsReadOnly() || (st.Tool.Annotations != nil && st.Tool.Annotations.IdempotentHint)
+}
+
 // HasHandler returns true if this tool has a handler function.
 func (st *ServerTool) HasHandler() bool {
 	return st.HandlerFunc != nil
@@ -21,6 +21,7 @@ type RepoAccessCache struct {
 	ttl              time.Duration
 	logger           *slog.Logger
 	trustedBotLogins map[string]struct{}
+	trustOwnContent  bool
 }
 
 type repoAccessCacheEntry struct {
@@ -74,6 +75,14 @@ func WithCacheName(name string) RepoAccessOption {
 	}
 }
 
+// WithTrustOwnContent controls whether content authored by the token owner is always treated as
+// safe, regardless of their collaborator status on the repository. It is enabled by default.
+func WithTrustOwnContent(trusted bool) RepoAccessOption {
+	return func(c *RepoAccessCache) {
+		c.trustOwnContent = trusted
+	}
+}
+
 // GetInstance returns the singleton instance of RepoAccessCache.
 // It initializes the instance on first call with the provided client and options.
 // Subsequent calls ignore the client and options parameters and return the existing instance.
@@ -82,23 +91,32 @@ func GetInstance(client *githubv4.Client, opts ...RepoAccessOption) *RepoAccessC
 	instanceMu.Lock()
 	defer instanceMu.Unlock()
 	if instance == nil {
-		instance = &RepoAccessCache{
-			client: client,
-			cache:  cache2go.Cache(defaultRepoAccessCacheKey),
-			ttl:    defaultRepoAccessTTL,
-			trustedBotLogins: map[string]struct{}{
-				"copilot": {},
-			},
-		}
-		for _, opt := range opts {
-			if opt != nil {
-				opt(instance)
-			}
-		}
+		instance = NewRepoAccessCache(client, opts...)
 	}
 	return instance
 }
 
+// NewRepoAccessCache creates a RepoAccessCache separate from the singleton instance, for clients
+// of another GitHub host or token. Give it its own cache name with WithCacheName, so that its
+// entries are not shared with the singleton.
+func NewRepoAccessCache(client *githubv4.Client, opts ...RepoAccessOption) *RepoAccessCache {
+	c := &RepoAccessCache{
+		client: client,
+		cache:  cache2go.Cache(defaultRepoAccessCacheKey),
+		ttl:    defaultRepoAccessTTL,
+		trustedBotLogins: map[string]struct{}{
+			"copilot": {},
+		},
+		trustOwnContent: true,
+	}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(c)
+		}
+	}
+	return c
+}
+
 // SetLogger updates the logger used for cache diagnostics.
 func (c *RepoAccessCache) SetLogger(logger *slog.Logger) {
 	c.mu.Lock()
@@ -118,7 +136,7 @@ type CacheStats struct {
 // - the content was created by a trusted bot;
 // - the author currently has push access to the repository;
 // - the repository is private;
-// - the content was created by the viewer.
+// - the content was created by the viewer, unless disabled with WithTrustOwnContent.
 func (c *RepoAccessCache) IsSafeContent(ctx context.Context, username, owner, repo string) (bool, error) {
 	repoInfo, err := c.getRepoAccessInfo(ctx, username, owner, repo)
 	if err != nil {
@@ -128,7 +146,7 @@ func (c *RepoAccessCache) IsSafeContent(ctx context.Context, username, owner, re
 	c.logDebug(ctx, fmt.Sprintf("evaluated repo access for user %s to %s/%s for content filtering, result: hasPushAccess=%t, isPrivate=%t",
 		username, owner, repo, repoInfo.HasPushAccess, repoInfo.IsPrivate))
 
-	if c.isTrustedBot(username) || repoInfo.IsPrivate || repoInfo.ViewerLogin == strings.ToLower(username) {
+	if c.isTrustedBot(username) || repoInfo.IsPrivate || c.isOwnContent(repoInfo.ViewerLogin, username) {
 		return true, nil
 	}
 	return repoInfo.HasPushAccess, nil
@@ -269,6 +287,12 @@ func (c *RepoAccessCache) isTrustedBot(username string) bool {
 	return ok
 }
 
+// isOwnContent reports whether content by username was authored by the token owner. Logins are
+// compared case-insensitively since the API returns the viewer's login in its canonical casing.
+func (c *RepoAccessCache) isOwnContent(viewerLogin, username string) bool {
+	return c.trustOwnContent && viewerLogin != "" && strings.EqualFold(viewerLogin, username)
+}
+
 func cacheKey(owner, repo string) string {
 	return fmt.Sprintf("%s/%s", strings.ToLower(owner), strings.ToLower(repo))
 }
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/github/github-mcp-server/internal/githubv4mock"
+	"github.com/muesli/cache2go"
 	"github.com/shurcooL/githubv4"
 	"github.com/stretchr/testify/require"
 )
@@ -110,3 +111,80 @@ func TestRepoAccessCacheEvictsAfterTTL(t *testing.T) {
 	require.True(t, info.HasPushAccess)
 	require.EqualValues(t, 2, transport.CallCount())
 }
+
+func newOwnContentRepoAccessCache(t *testing.T, viewerLogin string, opts ...RepoAccessOption) *RepoAccessCache {
+	t.Helper()
+
+	var query repoAccessQuery
+
+	variables := map[string]any{
+		"owner":    githubv4.String(testOwner),
+		"name":     githubv4.String(testRepo),
+		"username": githubv4.String(testUser),
+	}
+
+	// The viewer is not a collaborator on the public repository.
+	response := githubv4mock.DataResponse(map[string]any{
+		"viewer": map[string]any{
+			"login": viewerLogin,
+		},
+		"repository": map[string]any{
+			"isPrivate": false,
+			"collaborators": map[string]any{
+				"edges": []any{},
+			},
+		},
+	})
+
+	httpClient := githubv4mock.NewMockedHTTPClient(githubv4mock.NewQueryMatcher(query, variables, response))
+	cache := &RepoAccessCache{
+		client:          githubv4.NewClient(httpClient),
+		cache:           cache2go.Cache(t.Name()),
+		ttl:             time.Minute,
+		trustOwnContent: true,
+	}
+	for _, opt := range opts {
+		opt(cache)
+	}
+	return cache
+}
+
+func TestIsSafeContentTrustsOwnContent(t *testing.T) {
+	tests := []struct {
+		name        string
+		viewerLogin string
+		opts        []RepoAccessOption
+		expected    bool
+	}{
+		{
+			name:        "viewer's own content is trusted without push access",
+			viewerLogin: testUser,
+			expected:    true,
+		},
+		{
+			name:        "viewer login differs only in case",
+			viewerLogin: "OctoCat",
+			expected:    true,
+		},
+		{
+			name:        "trust in own content disabled",
+			viewerLogin: testUser,
+			opts:        []RepoAccessOption{WithTrustOwnContent(false)},
+			expected:    false,
+		},
+		{
+			name:        "content by another user without push access",
+			viewerLogin: "monalisa",
+			expected:    false,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			cache := newOwnContentRepoAccessCache(t, tc.viewerLogin, tc.opts...)
+			safe, err := cache.IsSafeContent(t.Context(), testUser, testOwner, testRepo)
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, safe)
+		})
+	}
+}
@@ -0,0 +1,23 @@
+package tracing
+
+import "context"
+
+// NoopTracer is a no-op implementation of the Tracer interface.
+type NoopTracer struct{}
+
+var _ Tracer = (*NoopTracer)(nil)
+
+// NewNoopTracer returns a new NoopTracer.
+func NewNoopTracer() *NoopTracer {
+	return &NoopTracer{}
+}
+
+func (n *NoopTracer) Start(ctx context.Context, _ string, _ SpanKind, _ map[string]any) (context.Context, Span) {
+	return ctx, noopSpan{}
+}
+
+type noopSpan struct{}
+
+func (noopSpan) SetAttributes(_ map[string]any) {}
+func (noopSpan) SetError(_ string)              {}
+func (noopSpan) End()                           {}
@@ -0,0 +1,367 @@
+package tracing
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// otlpBatchSize is the number of ended spans that triggers an export, and otlpBatchInterval
+	// how long spans wait at most before being exported.
+	otlpBatchSize     = 512
+	otlpBatchInterval = 5 * time.Second
+	// otlpMaxQueue bounds the spans waiting to be exported; spans beyond it are dropped rather
+	// than growing memory while the collector is unreachable.
+	otlpMaxQueue = 4096
+)
+
+// OTLPConfig configures an OTLPTracer.
+type OTLPConfig struct {
+	// Endpoint is the URL of an OTLP/HTTP receiver, such as http://localhost:4318. Spans are
+	// posted to its /v1/traces path unless the URL already names it.
+	Endpoint string
+	// Headers are sent with every export, for example to authenticate with the collector.
+	Headers map[string]string
+
+	ServiceName    string
+	ServiceVersion string
+
+	// Logger, when set, logs failed exports.
+	Logger *slog.Logger
+	// Client is used to post spans. Defaults to a client with a 10 second timeout.
+	Client *http.Client
+}
+
+// OTLPTracer exports spans to an OpenTelemetry collector with the OTLP/HTTP JSON encoding. Spans
+// are exported in batches in the background; call Shutdown to export the remaining ones.
+type OTLPTracer struct {
+	endpoint string
+	headers  map[string]string
+	resource otlpResource
+	logger   *slog.Logger
+	client   *http.Client
+
+	mu      sync.Mutex
+	queue   []otlpSpan
+	dropped int
+
+	flush chan struct{}
+	done  chan struct{}
+	once  sync.Once
+	wg    sync.WaitGroup
+}
+
+var _ Tracer = (*OTLPTracer)(nil)
+
+// NewOTLPTracer creates a tracer exporting to the collector of cfg, and starts its exporter.
+func NewOTLPTracer(cfg OTLPConfig) (*OTLPTracer, error) {
+	endpoint, err := url.Parse(cfg.Endpoint)
+	if err != nil || (endpoint.Scheme != "http" && endpoint.Scheme != "https") || endpoint.Host == "" {
+		return nil, fmt.Errorf("invalid OTLP endpoint %q: must be an http or https URL", cfg.Endpoint)
+	}
+	if !strings.HasSuffix(endpoint.Path, "/v1/traces") {
+		endpoint.Path = strings.TrimSuffix(endpoint.Path, "/") + "/v1/traces"
+	}
+
+	client := cfg.Client
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+	serviceName := cfg.ServiceName
+	if serviceName == "" {
+		serviceName = "github-mcp-server"
+	}
+	resource := otlpResource{Attributes: otlpAttributes(map[string]any{"service.name": serviceName})}
+	if cfg.ServiceVersion != "" {
+		resource.Attributes = append(resource.Attributes, otlpAttributes(map[string]any{"service.version": cfg.ServiceVersion})...)
+	}
+
+	t := &OTLPTracer{
+		endpoint: endpoint.String(),
+		headers:  cfg.Headers,
+		resource: resource,
+		logger:   cfg.Logger,
+		client:   client,
+		flush:    make(chan struct{}, 1),
+		done:     make(chan struct{}),
+	}
+	t.wg.Add(1)
+	go t.run()
+	return t, nil
+}
+
+// ParseOTLPHeaders parses "key=value" entries, the format of the OTEL_EXPORTER_OTLP_HEADERS
+// environment variable, into headers.
+func ParseOTLPHeaders(entries []string) (map[string]string, error) {
+	headers := map[string]string{}
+	for _, entry := range entries {
+		key, value, ok := strings.Cut(entry, "=")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" {
+			return nil, fmt.Errorf("invalid OTLP header %q: expected key=value", entry)
+		}
+		if decoded, err := url.QueryUnescape(strings.TrimSpace(value)); err == nil {
+			value = decoded
+		}
+		headers[key] = value
+	}
+	return headers, nil
+}
+
+func (t *OTLPTracer) Start(ctx context.Context, name string, kind SpanKind, attrs map[string]any) (context.Context, Span) {
+	span := &otlpActiveSpan{
+		tracer: t,
+		span: otlpSpan{
+			Name:              name,
+			Kind:              int(kind),
+			StartTimeUnixNano: strconv.FormatInt(time.Now().UnixNano(), 10),
+			SpanID:            randomHex(8),
+			Attributes:        otlpAttributes(attrs),
+		},
+	}
+	if parent, ok := ctx.Value(spanKey{}).(*otlpActiveSpan); ok {
+		span.span.TraceID = parent.span.TraceID
+		span.span.ParentSpanID = parent.span.SpanID
+	} else {
+		span.span.TraceID = randomHex(16)
+	}
+	return ContextWithSpan(ctx, span), span
+}
+
+// Shutdown stops the exporter once the spans ended so far are exported, or ctx is done.
+func (t *OTLPTracer) Shutdown(ctx context.Context) error {
+	t.once.Do(func() { close(t.done) })
+	exported := make(chan struct{})
+	go func() {
+		t.wg.Wait()
+		close(exported)
+	}()
+	select {
+	case <-exported:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (t *OTLPTracer) enqueue(span otlpSpan) {
+	t.mu.Lock()
+	if len(t.queue) >= otlpMaxQueue {
+		t.dropped++
+		t.mu.Unlock()
+		return
+	}
+	t.queue = append(t.queue, span)
+	full := len(t.queue) >= otlpBatchSize
+	t.mu.Unlock()
+	if full {
+		select {
+		case t.flush <- struct{}{}:
+		default:
+		}
+	}
+}
+
+func (t *OTLPTracer) run() {
+	defer t.wg.Done()
+	ticker := time.NewTicker(otlpBatchInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+		case <-t.flush:
+		case <-t.done:
+			t.export()
+			return
+		}
+		t.export()
+	}
+}
+
+// export posts the queued spans in batches. Failed batches are dropped: tracing must never hold
+// up the server.
+func (t *OTLPTracer) export() {
+	t.mu.Lock()
+	queue, dropped := t.queue, t.dropped
+	t.queue, t.dropped = nil, 0
+	t.mu.Unlock()
+	if dropped > 0 && t.logger != nil {
+		t.logger.Warn("dropped spans while the OTLP exporter was behind", "spans", dropped)
+	}
+
+	for start := 0; start < len(queue); start += otlpBatchSize {
+		batch := queue[start:min(start+otlpBatchSize, len(queue))]
+		if err := t.post(batch); err != nil && t.logger != nil {
+			t.logger.Warn("failed to export spans", "endpoint", t.endpoint, "spans", len(batch), "error", err)
+		}
+	}
+}
+
+func (t *OTLPTracer) post(spans []otlpSpan) error {
+	body, err := json.Marshal(otlpExportRequest{ResourceSpans: []otlpResourceSpans{{
+		Resource: t.resource,
+		ScopeSpans: []otlpScopeSpans{{
+			Scope: otlpScope{Name: "github.com/github/github-mcp-server"},
+			Spans: spans,
+		}},
+	}}})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, t.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for key, value := range t.headers {
+		req.Header.Set(key, value)
+	}
+	resp, err := t.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	_, _ = io.Copy(io.Discard, resp.Body)
+	if resp.StatusCode >= 300 {
+		return errors.New(resp.Status)
+	}
+	return nil
+}
+
+// otlpActiveSpan is a span being recorded by an OTLPTracer.
+type otlpActiveSpan struct {
+	tracer *OTLPTracer
+
+	mu    sync.Mutex
+	span  otlpSpan
+	ended bool
+}
+
+func (s *otlpActiveSpan) SetAttributes(attrs map[string]any) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.span.Attributes = append(s.span.Attributes, otlpAttributes(attrs)...)
+}
+
+func (s *otlpActiveSpan) SetError(message string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.span.Status = &otlpStatus{Code: otlpStatusError, Message: message}
+}
+
+func (s *otlpActiveSpan) End() {
+	s.mu.Lock()
+	if s.ended {
+		s.mu.Unlock()
+		return
+	}
+	s.ended = true
+	s.span.EndTimeUnixNano = strconv.FormatInt(time.Now().UnixNano(), 10)
+	span := s.span
+	s.mu.Unlock()
+	s.tracer.enqueue(span)
+}
+
+func randomHex(n int) string {
+	b := make([]byte, n)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// The OTLP/HTTP JSON encoding of trace export requests. IDs are hex encoded, and 64-bit integers
+// are strings, as the protobuf JSON mapping requires.
+
+const otlpStatusError = 2
+
+type otlpExportRequest struct {
+	ResourceSpans []otlpResourceSpans `json:"resourceSpans"`
+}
+
+type otlpResourceSpans struct {
+	Resource   otlpResource     `json:"resource"`
+	ScopeSpans []otlpScopeSpans `json:"scopeSpans"`
+}
+
+type otlpResource struct {
+	Attributes []otlpAttribute `json:"attributes"`
+}
+
+type otlpScopeSpans struct {
+	Scope otlpScope  `json:"scope"`
+	Spans []otlpSpan `json:"spans"`
+}
+
+type otlpScope struct {
+	Name string `json:"name"`
+}
+
+type otlpSpan struct {
+	TraceID           string          `json:"traceId"`
+	SpanID            string          `json:"spanId"`
+	ParentSpanID      string          `json:"parentSpanId,omitempty"`
+	Name              string          `json:"name"`
+	Kind              int             `json:"kind"`
+	StartTimeUnixNano string          `json:"startTimeUnixNano"`
+	EndTimeUnixNano   string          `json:"endTimeUnixNano"`
+	Attributes        []otlpAttribute `json:"attributes,omitempty"`
+	Status            *otlpStatus     `json:"status,omitempty"`
+}
+
+type otlpStatus struct {
+	Code    int    `json:"code"`
+	Message string `json:"message,omitempty"`
+}
+
+type otlpAttribute struct {
+	Key   string         `json:"key"`
+	Value map[string]any `json:"value"`
+}
+
+// otlpAttributes encodes attributes sorted by key, representing values of other types as
+// strings.
+func otlpAttributes(attrs map[string]any) []otlpAttribute {
+	if len(attrs) == 0 {
+		return nil
+	}
+	keys := make([]string, 0, len(attrs))
+	for key := range attrs {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+
+	encoded := make([]otlpAttribute, 0, len(attrs))
+	for _, key := range keys {
+		var value map[string]any
+		switch v := attrs[key].(type) {
+		case string:
+			value = map[string]any{"stringValue": v}
+		case bool:
+			value = map[string]any{"boolValue": v}
+		case int:
+			value = map[string]any{"intValue": strconv.Itoa(v)}
+		case int64:
+			value = map[string]any{"intValue": strconv.FormatInt(v, 10)}
+		case float64:
+			value = map[string]any{"doubleValue": v}
+		default:
+			value = map[string]any{"stringValue": fmt.Sprint(v)}
+		}
+		encoded = append(encoded, otlpAttribute{Key: key, Value: value})
+	}
+	return encoded
+}
@@ -0,0 +1,126 @@
+package tracing
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// collector is an OTLP/HTTP receiver recording the spans exported to it.
+type collector struct {
+	mu       sync.Mutex
+	paths    []string
+	headers  []http.Header
+	requests []otlpExportRequest
+}
+
+func (c *collector) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var req otlpExportRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.paths = append(c.paths, r.URL.Path)
+	c.headers = append(c.headers, r.Header.Clone())
+	c.requests = append(c.requests, req)
+}
+
+func (c *collector) spans() map[string]otlpSpan {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	spans := map[string]otlpSpan{}
+	for _, req := range c.requests {
+		for _, resourceSpans := range req.ResourceSpans {
+			for _, scopeSpans := range resourceSpans.ScopeSpans {
+				for _, span := range scopeSpans.Spans {
+					spans[span.Name] = span
+				}
+			}
+		}
+	}
+	return spans
+}
+
+func TestOTLPTracer(t *testing.T) {
+	t.Parallel()
+
+	c := &collector{}
+	server := httptest.NewServer(c)
+	t.Cleanup(server.Close)
+
+	tracer, err := NewOTLPTracer(OTLPConfig{
+		Endpoint:       server.URL,
+		Headers:        map[string]string{"Authorization": "Bearer collector-token"},
+		ServiceVersion: "1.2.3",
+	})
+	require.NoError(t, err)
+
+	ctx, parent := tracer.Start(context.Background(), "tools/call issue_read", SpanKindServer, map[string]any{"gen_ai.tool.name": "issue_read"})
+	_, child := tracer.Start(ctx, "GitHub REST GET", SpanKindClient, map[string]any{"http.response.status_code": 404})
+	assert.Same(t, parent, SpanFromContext(ctx))
+	child.SetError("404 Not Found")
+	child.End()
+	child.End()
+	parent.End()
+
+	require.NoError(t, tracer.Shutdown(context.Background()))
+
+	require.Len(t, c.paths, 1)
+	assert.Equal(t, "/v1/traces", c.paths[0])
+	assert.Equal(t, "Bearer collector-token", c.headers[0].Get("Authorization"))
+	assert.Equal(t, []otlpAttribute{
+		{Key: "service.name", Value: map[string]any{"stringValue": "github-mcp-server"}},
+		{Key: "service.version", Value: map[string]any{"stringValue": "1.2.3"}},
+	}, c.requests[0].ResourceSpans[0].Resource.Attributes)
+
+	spans := c.spans()
+	require.Len(t, spans, 2, "a span ended twice is exported once")
+	toolSpan, apiSpan := spans["tools/call issue_read"], spans["GitHub REST GET"]
+	assert.Len(t, toolSpan.TraceID, 32)
+	assert.Empty(t, toolSpan.ParentSpanID)
+	assert.Equal(t, int(SpanKindServer), toolSpan.Kind)
+	assert.Nil(t, toolSpan.Status)
+
+	assert.Equal(t, toolSpan.TraceID, apiSpan.TraceID)
+	assert.Equal(t, toolSpan.SpanID, apiSpan.ParentSpanID)
+	assert.Equal(t, int(SpanKindClient), apiSpan.Kind)
+	assert.Equal(t, &otlpStatus{Code: otlpStatusError, Message: "404 Not Found"}, apiSpan.Status)
+	assert.Equal(t, []otlpAttribute{{Key: "http.response.status_code", Value: map[string]any{"intValue": "404"}}}, apiSpan.Attributes)
+}
+
+func TestNewOTLPTracerInvalidEndpoint(t *testing.T) {
+	t.Parallel()
+
+	for _, endpoint := range []string{"", "localhost:4318", "grpc://localhost:4317"} {
+		_, err := NewOTLPTracer(OTLPConfig{Endpoint: endpoint})
+		assert.ErrorContains(t, err, "invalid OTLP endpoint", endpoint)
+	}
+}
+
+func TestParseOTLPHeaders(t *testing.T) {
+	t.Parallel()
+
+	headers, err := ParseOTLPHeaders([]string{"Authorization=Basic%20abc", " x-tenant = octo "})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"Authorization": "Basic abc", "x-tenant": "octo"}, headers)
+
+	_, err = ParseOTLPHeaders([]string{"no-value"})
+	assert.ErrorContains(t, err, `invalid OTLP header "no-value"`)
+}
+
+func TestSpanFromContextWithoutSpan(t *testing.T) {
+	t.Parallel()
+
+	span := SpanFromContext(context.Background())
+	span.SetAttributes(map[string]any{"key": "value"})
+	span.SetError("ignored")
+	span.End()
+}
@@ -0,0 +1,47 @@
+package tracing
+
+import "context"
+
+// SpanKind is the role of a span in a trace, with the values of OpenTelemetry's span kinds.
+type SpanKind int
+
+const (
+	// SpanKindInternal is an operation within the server.
+	SpanKindInternal SpanKind = 1
+	// SpanKindServer is a request the server handles, such as an MCP tool call.
+	SpanKindServer SpanKind = 2
+	// SpanKindClient is a request the server makes, such as a GitHub API call.
+	SpanKindClient SpanKind = 3
+)
+
+// Tracer is a backend-agnostic interface for recording spans.
+// Implementations can export to an OpenTelemetry collector or discard (noop).
+type Tracer interface {
+	// Start starts a span, a child of the span of ctx if there is one, and returns a context
+	// carrying it. Attribute values are strings, integers, floats or booleans.
+	Start(ctx context.Context, name string, kind SpanKind, attrs map[string]any) (context.Context, Span)
+}
+
+// Span is an operation being traced. It must be ended exactly once.
+type Span interface {
+	SetAttributes(attrs map[string]any)
+	// SetError marks the operation as failed.
+	SetError(message string)
+	End()
+}
+
+type spanKey struct{}
+
+// ContextWithSpan returns a context carrying span, the parent of spans started from it.
+func ContextWithSpan(ctx context.Context, span Span) context.Context {
+	return context.WithValue(ctx, spanKey{}, span)
+}
+
+// SpanFromContext returns the span of ctx, or a noop span when there is none, so that callers can
+// annotate the current span without checking whether tracing is enabled.
+func SpanFromContext(ctx context.Context) Span {
+	if span, ok := ctx.Value(spanKey{}).(Span); ok {
+		return span
+	}
+	return noopSpan{}
+}
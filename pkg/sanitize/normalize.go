@@ -0,0 +1,150 @@
+package sanitize
+
+import (
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+var (
+	htmlCommentPattern  = regexp.MustCompile(`(?s)<!--.*?-->`)
+	summaryPattern      = regexp.MustCompile(`(?is)<summary[^>]*>(.*?)</summary>`)
+	markdownLinkPattern = regexp.MustCompile(`(\]\()([^)\s]+)`)
+	htmlURLAttrPattern  = regexp.MustCompile(`(?i)(\b(?:href|src)=["'])([^"']+)`)
+)
+
+// NormalizeMarkdown rewrites a GitHub issue, pull request or discussion body into plain Markdown
+// that is cheaper to tokenize and carries fewer hidden prompt-injection vectors:
+// - HTML comments are removed;
+// - <details> blocks are collapsed into their summary followed by their content;
+// - relative links and image sources are resolved against baseURL, if it is set.
+//
+// Fenced code blocks are left untouched.
+func NormalizeMarkdown(input string, baseURL string) string {
+	if input == "" {
+		return input
+	}
+
+	var base *url.URL
+	if baseURL != "" {
+		if u, err := url.Parse(baseURL); err == nil && u.IsAbs() {
+			base = u
+		}
+	}
+
+	segments := splitCodeFences(input)
+	for i, segment := range segments {
+		if segment.fenced {
+			continue
+		}
+		text := htmlCommentPattern.ReplaceAllString(segment.text, "")
+		text = collapseDetails(text)
+		if base != nil {
+			text = absolutizeLinks(text, base)
+		}
+		segments[i].text = text
+	}
+
+	var b strings.Builder
+	for _, segment := range segments {
+		b.WriteString(segment.text)
+	}
+	return b.String()
+}
+
+type markdownSegment struct {
+	text   string
+	fenced bool
+}
+
+// splitCodeFences splits input into alternating prose and fenced code block segments.
+func splitCodeFences(input string) []markdownSegment {
+	var segments []markdownSegment
+	var current strings.Builder
+	fence := ""
+
+	flush := func(fenced bool) {
+		if current.Len() > 0 {
+			segments = append(segments, markdownSegment{text: current.String(), fenced: fenced})
+			current.Reset()
+		}
+	}
+
+	for _, line := range strings.SplitAfter(input, "\n") {
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case fence == "" && (strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~")):
+			flush(false)
+			fence = trimmed[:3]
+			current.WriteString(line)
+		case fence != "" && strings.HasPrefix(trimmed, fence):
+			current.WriteString(line)
+			flush(true)
+			fence = ""
+		default:
+			current.WriteString(line)
+		}
+	}
+	flush(fence != "")
+	return segments
+}
+
+// collapseDetails replaces <details> blocks, innermost first, with their summary in bold
+// followed by the block's content.
+func collapseDetails(input string) string {
+	for {
+		lower := strings.ToLower(input)
+		start := strings.LastIndex(lower, "<details")
+		if start == -1 {
+			return input
+		}
+		openEnd := strings.Index(lower[start:], ">")
+		closeStart := strings.Index(lower[start:], "</details>")
+		if openEnd == -1 || closeStart == -1 || closeStart < openEnd {
+			return input
+		}
+		openEnd += start + 1
+		closeStart += start
+
+		content := input[openEnd:closeStart]
+		summary := ""
+		if m := summaryPattern.FindStringSubmatchIndex(content); m != nil {
+			summary = strings.TrimSpace(content[m[2]:m[3]])
+			content = content[:m[0]] + content[m[1]:]
+		}
+		content = strings.TrimSpace(content)
+
+		var replacement string
+		switch {
+		case summary != "" && content != "":
+			replacement = "**" + summary + "**\n\n" + content
+		case summary != "":
+			replacement = "**" + summary + "**"
+		default:
+			replacement = content
+		}
+		input = input[:start] + replacement + input[closeStart+len("</details>"):]
+	}
+}
+
+// absolutizeLinks resolves relative Markdown link targets and HTML href/src attributes against base.
+func absolutizeLinks(input string, base *url.URL) string {
+	resolve := func(pattern *regexp.Regexp, text string) string {
+		return pattern.ReplaceAllStringFunc(text, func(match string) string {
+			m := pattern.FindStringSubmatch(match)
+			return m[1] + resolveRelativeURL(m[2], base)
+		})
+	}
+	return resolve(htmlURLAttrPattern, resolve(markdownLinkPattern, input))
+}
+
+func resolveRelativeURL(target string, base *url.URL) string {
+	if strings.HasPrefix(target, "#") {
+		return target
+	}
+	u, err := url.Parse(target)
+	if err != nil || u.IsAbs() || u.Host != "" {
+		return target
+	}
+	return base.ResolveReference(u).String()
+}
@@ -32,6 +32,9 @@ const (
 	// Gist grants write access to gists
 	Gist Scope = "gist"
 
+	// Workflow grants permission to add and update GitHub Actions workflow files
+	Workflow Scope = "workflow"
+
 	// Notifications grants access to notifications
 	Notifications Scope = "notifications"
 
@@ -58,18 +61,40 @@ const (
 
 	// WritePackages grants write access to packages
 	WritePackages Scope = "write:packages"
+
+	// ReadPublicKey grants read access to the user's SSH keys
+	ReadPublicKey Scope = "read:public_key"
+
+	// WritePublicKey grants read and write access to the user's SSH keys
+	WritePublicKey Scope = "write:public_key"
+
+	// AdminPublicKey grants full control of the user's SSH keys
+	AdminPublicKey Scope = "admin:public_key"
+
+	// ReadGPGKey grants read access to the user's GPG keys
+	ReadGPGKey Scope = "read:gpg_key"
+
+	// WriteGPGKey grants read and write access to the user's GPG keys
+	WriteGPGKey Scope = "write:gpg_key"
+
+	// AdminGPGKey grants full control of the user's GPG keys
+	AdminGPGKey Scope = "admin:gpg_key"
 )
 
 // ScopeHierarchy defines parent-child relationships between scopes.
 // A parent scope implicitly grants access to all child scopes.
 // For example, "repo" grants access to "public_repo" and "security_events".
 var ScopeHierarchy = map[Scope][]Scope{
-	Repo:          {PublicRepo, SecurityEvents},
-	AdminOrg:      {WriteOrg, ReadOrg},
-	WriteOrg:      {ReadOrg},
-	Project:       {ReadProject},
-	WritePackages: {ReadPackages},
-	User:          {ReadUser, UserEmail},
+	Repo:           {PublicRepo, SecurityEvents},
+	AdminOrg:       {WriteOrg, ReadOrg},
+	WriteOrg:       {ReadOrg},
+	Project:        {ReadProject},
+	WritePackages:  {ReadPackages},
+	User:           {ReadUser, UserEmail},
+	AdminPublicKey: {WritePublicKey, ReadPublicKey},
+	WritePublicKey: {ReadPublicKey},
+	AdminGPGKey:    {WriteGPGKey, ReadGPGKey},
+	WriteGPGKey:    {ReadGPGKey},
 }
 
 // ScopeSet represents a set of OAuth scopes.
@@ -149,6 +149,10 @@ func TestScopeHierarchy(t *testing.T) {
 	assert.Contains(t, ScopeHierarchy[WritePackages], ReadPackages)
 	assert.Contains(t, ScopeHierarchy[User], ReadUser)
 	assert.Contains(t, ScopeHierarchy[User], UserEmail)
+	assert.Contains(t, ScopeHierarchy[AdminPublicKey], ReadPublicKey)
+	assert.Contains(t, ScopeHierarchy[WritePublicKey], ReadPublicKey)
+	assert.Contains(t, ScopeHierarchy[AdminGPGKey], ReadGPGKey)
+	assert.Contains(t, ScopeHierarchy[WriteGPGKey], ReadGPGKey)
 }
 
 func TestExpandScopeSet(t *testing.T) {
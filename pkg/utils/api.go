@@ -58,6 +58,37 @@ func (a APIHost) AuthorizationServerURL(_ context.Context) (*url.URL, error) {
 	return a.authorizationServerURL, nil
 }
 
+// uploadURLOverride resolves the URLs of a host, except for uploads, which go to uploadURL.
+type uploadURLOverride struct {
+	APIHostResolver
+	uploadURL *url.URL
+}
+
+func (o uploadURLOverride) UploadURL(_ context.Context) (*url.URL, error) {
+	return o.uploadURL, nil
+}
+
+// WithUploadURL overrides the upload URL of host, for GitHub Enterprise Server instances
+// serving uploads from a domain other than the one derived from the host. It returns host
+// unchanged when uploadURL is empty.
+func WithUploadURL(host APIHostResolver, uploadURL string) (APIHostResolver, error) {
+	if uploadURL == "" {
+		return host, nil
+	}
+	u, err := url.Parse(uploadURL)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse upload host as URL: %s", uploadURL)
+	}
+	if u.Scheme == "" || u.Host == "" {
+		return nil, fmt.Errorf("upload host must have a scheme (http or https) and a hostname: %s", uploadURL)
+	}
+	// go-github resolves upload paths against the URL, which must end in a slash.
+	if !strings.HasSuffix(u.Path, "/") {
+		u.Path += "/"
+	}
+	return uploadURLOverride{APIHostResolver: host, uploadURL: u}, nil
+}
+
 func newDotcomHost() (APIHost, error) {
 	baseRestURL, err := url.Parse("https://api.github.com/")
 	if err != nil {
@@ -73,3 +73,24 @@ func TestParseAPIHost(t *testing.T) {
 		})
 	}
 }
+
+func TestWithUploadURL(t *testing.T) {
+	host, err := NewAPIHost("https://github.example.com")
+	require.NoError(t, err)
+
+	unchanged, err := WithUploadURL(host, "")
+	require.NoError(t, err)
+	assert.Equal(t, host, unchanged)
+
+	overridden, err := WithUploadURL(host, "https://uploads.example-cdn.com/github")
+	require.NoError(t, err)
+	uploadURL, err := overridden.UploadURL(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, "https://uploads.example-cdn.com/github/", uploadURL.String())
+	restURL, err := overridden.BaseRESTURL(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, "https://github.example.com/api/v3/", restURL.String())
+
+	_, err = WithUploadURL(host, "uploads.example-cdn.com")
+	assert.ErrorContains(t, err, "upload host must have a scheme")
+}
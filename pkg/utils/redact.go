@@ -0,0 +1,85 @@
+package utils //nolint:revive //TODO: figure out a better name for this package
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+)
+
+// RedactedValue replaces the occurrences of redacted values in sanitized responses.
+const RedactedValue = "[REDACTED]"
+
+// Redactor sanitizes API responses before they are returned by tools, so that sensitive values,
+// such as the secrets detected by secret scanning, never reach the model.
+type Redactor struct {
+	fields map[string]struct{}
+}
+
+// NewRedactor returns a Redactor removing the JSON fields with the given names, at any depth.
+func NewRedactor(fields ...string) *Redactor {
+	r := &Redactor{fields: make(map[string]struct{}, len(fields))}
+	for _, field := range fields {
+		r.fields[field] = struct{}{}
+	}
+	return r
+}
+
+// Marshal marshals v to JSON without the redacted fields. The string values of the removed fields
+// are also replaced by RedactedValue wherever else they occur, such as in a comment quoting them.
+func (r *Redactor) Marshal(v any) ([]byte, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var tree any
+	if err := decoder.Decode(&tree); err != nil {
+		return nil, err
+	}
+
+	var values []string
+	tree = r.remove(tree, &values)
+	if len(values) > 0 {
+		tree = scrub(tree, strings.NewReplacer(values...))
+	}
+	return json.Marshal(tree)
+}
+
+// remove deletes the redacted fields from tree, collecting the pairs of string values to replace.
+func (r *Redactor) remove(tree any, values *[]string) any {
+	switch node := tree.(type) {
+	case map[string]any:
+		for key, value := range node {
+			if _, ok := r.fields[key]; ok {
+				if s, ok := value.(string); ok && s != "" {
+					*values = append(*values, s, RedactedValue)
+				}
+				delete(node, key)
+				continue
+			}
+			node[key] = r.remove(value, values)
+		}
+	case []any:
+		for i, value := range node {
+			node[i] = r.remove(value, values)
+		}
+	}
+	return tree
+}
+
+func scrub(tree any, replacer *strings.Replacer) any {
+	switch node := tree.(type) {
+	case map[string]any:
+		for key, value := range node {
+			node[key] = scrub(value, replacer)
+		}
+	case []any:
+		for i, value := range node {
+			node[i] = scrub(value, replacer)
+		}
+	case string:
+		return replacer.Replace(node)
+	}
+	return tree
+}
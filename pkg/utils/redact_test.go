@@ -0,0 +1,60 @@
+package utils //nolint:revive //TODO: figure out a better name for this package
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRedactorMarshal(t *testing.T) {
+	type alert struct {
+		Number            int64  `json:"number"`
+		Secret            string `json:"secret,omitempty"`
+		ResolutionComment string `json:"resolution_comment,omitempty"`
+	}
+
+	tests := []struct {
+		name  string
+		input any
+		want  string
+	}{
+		{
+			name:  "removes the field and other occurrences of its value",
+			input: alert{Number: 9007199254740993, Secret: "ghp_abc123", ResolutionComment: "revoked ghp_abc123 today"},
+			want:  `{"number":9007199254740993,"resolution_comment":"revoked [REDACTED] today"}`,
+		},
+		{
+			name: "redacts every element of a list",
+			input: []alert{
+				{Number: 1, Secret: "AKIA1"},
+				{Number: 2, Secret: "AKIA2", ResolutionComment: "same as AKIA1"},
+			},
+			want: `[{"number":1},{"number":2,"resolution_comment":"same as [REDACTED]"}]`,
+		},
+		{
+			name:  "removes nested fields",
+			input: map[string]any{"alert": map[string]any{"secret": "xoxb-1", "state": "open"}},
+			want:  `{"alert":{"state":"open"}}`,
+		},
+		{
+			name:  "leaves responses without the field unchanged",
+			input: alert{Number: 3, ResolutionComment: "false positive"},
+			want:  `{"number":3,"resolution_comment":"false positive"}`,
+		},
+	}
+
+	redactor := NewRedactor("secret")
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := redactor.Marshal(tc.input)
+			require.NoError(t, err)
+			assert.JSONEq(t, tc.want, string(got))
+		})
+	}
+
+	_, err := redactor.Marshal(func() {})
+	var unsupported *json.UnsupportedTypeError
+	assert.ErrorAs(t, err, &unsupported)
+}
@@ -60,16 +60,26 @@ func ParseAuthorizationHeader(req *http.Request) (tokenType TokenType, token str
 		}
 	}
 
+	tokenType = TokenTypeOf(token)
+	if tokenType == TokenTypeUnknown {
+		return 0, "", ErrBadAuthorizationHeader
+	}
+	return tokenType, token, nil
+}
+
+// TokenTypeOf returns the type of a GitHub token from its prefix, or TokenTypeUnknown if the
+// token is not in a recognized format.
+func TokenTypeOf(token string) TokenType {
 	for prefix, tokenType := range supportedGitHubPrefixes {
 		if strings.HasPrefix(token, prefix) {
-			return tokenType, token, nil
+			return tokenType
 		}
 	}
 
 	matchesOldTokenPattern := oldPatternRegexp.MatchString(token)
 	if matchesOldTokenPattern {
-		return TokenTypePersonalAccessToken, token, nil
+		return TokenTypePersonalAccessToken
 	}
 
-	return 0, "", ErrBadAuthorizationHeader
+	return TokenTypeUnknown
 }